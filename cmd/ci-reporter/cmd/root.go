@@ -28,12 +28,16 @@ import (
 	"github.com/shurcooL/githubv4"
 	"github.com/spf13/cobra"
 	"github.com/tj/go-spin"
+
+	"k8s.io/release/pkg/logging"
+	"sigs.k8s.io/release-utils/log"
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "reporter",
-	Short: "Github and Testgrid report generator",
-	Long:  "CI-Signal reporter that generates github and testgrid reports.",
+	Use:               "reporter",
+	Short:             "Github and Testgrid report generator",
+	Long:              "CI-Signal reporter that generates github and testgrid reports.",
+	PersistentPreRunE: initLogging,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		setGithubConfig(cmd, args)
 		// all available reporters are used by default that are used to generate the report
@@ -60,11 +64,27 @@ type Config struct {
 
 var cfg = &Config{}
 
+var (
+	// logLevel is the global log level.
+	logLevel string
+
+	// loggingOpts are the options of the global logger.
+	loggingOpts = logging.DefaultOptions()
+)
+
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&cfg.ReleaseVersion, "release-version", "v", "", "Specify a Kubernetes release versions like '1.22' which will populate the report additionally")
 	rootCmd.PersistentFlags().BoolVarP(&cfg.ShortReport, "short", "s", false, "A short report for mails and slack")
 	rootCmd.PersistentFlags().BoolVar(&cfg.JSONOutput, "json", false, "Report output in json format")
 	rootCmd.PersistentFlags().StringVarP(&cfg.Filepath, "file", "f", "", "Specify a filepath to write the report to a file")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", fmt.Sprintf("the logging verbosity, either %s", log.LevelNames()))
+
+	loggingOpts.AddFlags(rootCmd.PersistentFlags())
+}
+
+func initLogging(*cobra.Command, []string) error {
+	loggingOpts.Level = logLevel
+	return logging.Setup(loggingOpts)
 }
 
 // RunReport used to execute
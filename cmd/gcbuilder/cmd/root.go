@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/release/pkg/gcp/build"
+	"k8s.io/release/pkg/logging"
 	"sigs.k8s.io/release-utils/log"
 )
 
@@ -56,6 +57,9 @@ func Execute() {
 	}
 }
 
+// loggingOpts are the options of the global logger.
+var loggingOpts = logging.DefaultOptions()
+
 func init() {
 	rootCmd.PersistentFlags().StringVar(&buildOpts.ConfigDir, "config-dir", ".", "Configuration directory")
 	rootCmd.PersistentFlags().StringVar(&buildOpts.BuildDir, "build-dir", "", "If provided, this directory will be uploaded as the source for the Google Cloud Build run.")
@@ -69,6 +73,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&buildOpts.EnvPassthrough, "env-passthrough", "", "Comma-separated list of specified environment variables to be passed to GCB as substitutions with an _ prefix. If the variable doesn't exist, the substitution will exist but be empty.")
 	rootCmd.PersistentFlags().StringVar(&rootOpts.logLevel, "log-level", "info", fmt.Sprintf("the logging verbosity, either %s", log.LevelNames()))
 
+	loggingOpts.AddFlags(rootCmd.PersistentFlags())
+
 	buildOpts.ConfigDir = strings.TrimSuffix(buildOpts.ConfigDir, "/")
 }
 
@@ -88,5 +94,6 @@ func run() error {
 }
 
 func initLogging(*cobra.Command, []string) error {
-	return log.SetupGlobalLogger(rootOpts.logLevel)
+	loggingOpts.Level = rootOpts.logLevel
+	return logging.Setup(loggingOpts)
 }
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/adoption"
+)
+
+type adoptionReportOptions struct {
+	*adoption.Options
+	reportFile string
+	output     string
+	json       bool
+}
+
+var adoptionReportOpts = &adoptionReportOptions{Options: adoption.DefaultOptions()}
+
+// adoptionReportCmd represents the subcommand for `krel adoption-report`
+var adoptionReportCmd = &cobra.Command{
+	Use:   "adoption-report",
+	Short: "Poll the asset downloads and image pulls of a release and generate an adoption report",
+	Long: `krel adoption-report
+
+Samples the download counts of the GitHub release assets and, if a registry
+metrics URL is provided, the image pulls of a release. The samples are
+persisted in the state file, so that running the command periodically (or
+once using --interval) collects them over the first days after the release.
+Samples are only taken within these days, while the report of the collected
+samples gets generated on every run for the release retrospective.
+
+The report is written as markdown, or as JSON or YAML by using --output, to
+stdout or the --report file.`,
+	Example:       "krel adoption-report --tag v1.30.0 --state adoption-v1.30.0.json --interval 6h",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdoptionReport(adoptionReportOpts)
+	},
+}
+
+func init() {
+	adoptionReportCmd.PersistentFlags().StringVar(
+		&adoptionReportOpts.Owner,
+		"org",
+		adoptionReportOpts.Owner,
+		"GitHub organization of the release",
+	)
+
+	adoptionReportCmd.PersistentFlags().StringVar(
+		&adoptionReportOpts.Repo,
+		"repo",
+		adoptionReportOpts.Repo,
+		"GitHub repository of the release",
+	)
+
+	adoptionReportCmd.PersistentFlags().StringVar(
+		&adoptionReportOpts.Tag,
+		"tag",
+		adoptionReportOpts.Tag,
+		"tag of the release",
+	)
+
+	adoptionReportCmd.PersistentFlags().IntVar(
+		&adoptionReportOpts.Days,
+		"days",
+		adoptionReportOpts.Days,
+		"number of days after the release which get sampled",
+	)
+
+	adoptionReportCmd.PersistentFlags().StringVar(
+		&adoptionReportOpts.State,
+		"state",
+		adoptionReportOpts.State,
+		"file persisting the samples between runs",
+	)
+
+	adoptionReportCmd.PersistentFlags().StringVar(
+		&adoptionReportOpts.RegistryMetricsURL,
+		"registry-metrics-url",
+		adoptionReportOpts.RegistryMetricsURL,
+		"URL serving the image pulls as JSON object of image references to pull counts",
+	)
+
+	adoptionReportCmd.PersistentFlags().DurationVar(
+		&adoptionReportOpts.Interval,
+		"interval",
+		adoptionReportOpts.Interval,
+		"keep sampling in this interval until the end of the days, a single sample is taken if zero",
+	)
+
+	adoptionReportCmd.PersistentFlags().StringVar(
+		&adoptionReportOpts.reportFile,
+		"report",
+		"",
+		"path where the report gets written to, printed to stdout if empty",
+	)
+
+	addOutputFlag(adoptionReportCmd.PersistentFlags(), &adoptionReportOpts.output)
+
+	adoptionReportCmd.PersistentFlags().BoolVar(
+		&adoptionReportOpts.json,
+		"json",
+		false,
+		"write the report as JSON instead of markdown",
+	)
+
+	if err := adoptionReportCmd.PersistentFlags().MarkDeprecated("json", "use --output json instead"); err != nil {
+		logrus.Fatal(err)
+	}
+
+	rootCmd.AddCommand(adoptionReportCmd)
+}
+
+func runAdoptionReport(opts *adoptionReportOptions) error {
+	report, err := adoption.New(opts.Options).Run()
+	if err != nil {
+		return fmt.Errorf("generate adoption report: %w", err)
+	}
+
+	output := opts.output
+	if opts.json {
+		output = outputJSON
+	}
+
+	var w io.Writer = os.Stdout
+	if opts.reportFile != "" {
+		f, err := os.OpenFile(opts.reportFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("open adoption report: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := writeOutput(w, output, report, func() error {
+		_, err := io.WriteString(w, report.Markdown())
+		return err
+	}); err != nil {
+		return fmt.Errorf("write adoption report: %w", err)
+	}
+	return nil
+}
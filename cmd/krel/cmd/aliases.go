@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/approver"
+	"k8s.io/release/pkg/urlalias"
+)
+
+var (
+	aliasesOpts   = urlalias.DefaultOptions()
+	aliasesOutput string
+)
+
+// aliasesCmd represents the subcommand for `krel aliases`
+var aliasesCmd = &cobra.Command{
+	Use:   "aliases",
+	Short: "Manage the short alias URLs of the release artifacts",
+	Long: fmt.Sprintf(`krel aliases
+
+krel aliases manages short and stable alias URLs of the release artifacts, for
+example dl.k8s.io/v1.31.0/kubernetes.tar.gz, which redirect to the artifacts
+below the release directory. The aliases are kept in the %s manifest next to
+the version markers, where they get added by krel release automatically. The
+redirector does not read the manifest, use krel aliases render for the nginx
+rules to be added to its configuration.`,
+		urlalias.ManifestFile,
+	),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+// aliasesPublishCmd represents the subcommand for `krel aliases publish`
+var aliasesPublishCmd = &cobra.Command{
+	Use:           "publish --version v1.31.0",
+	Short:         "Add the aliases of a release to the manifest",
+	Long:          "Adds the aliases of the --files of a release to the manifest, which is only written if --nomock is set.",
+	Example:       "krel aliases publish --version v1.31.0 --nomock",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAliasesPublish(aliasesOpts)
+	},
+}
+
+// aliasesVerifyCmd represents the subcommand for `krel aliases verify`
+var aliasesVerifyCmd = &cobra.Command{
+	Use:           "verify --version v1.31.0",
+	Short:         "Verify that the aliases of a release redirect to existing artifacts",
+	Example:       "krel aliases verify --version v1.31.0 -o json",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAliasesVerify(aliasesOpts, aliasesOutput)
+	},
+}
+
+// aliasesRenderCmd represents the subcommand for `krel aliases render`
+var aliasesRenderCmd = &cobra.Command{
+	Use:           "render",
+	Short:         "Print the alias manifest as nginx redirect rules",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manifest, err := urlalias.New(aliasesOpts).Manifest()
+		if err != nil {
+			return err
+		}
+		fmt.Print(manifest.Nginx())
+		return nil
+	},
+}
+
+func init() {
+	aliasesCmd.PersistentFlags().StringVar(&aliasesOpts.Version, "version", "", "release version of the aliases, for example v1.31.0")
+	aliasesCmd.PersistentFlags().StringVar(&aliasesOpts.Bucket, "bucket", aliasesOpts.Bucket, "release bucket containing the artifacts and the alias manifest")
+	aliasesCmd.PersistentFlags().StringVar(&aliasesOpts.GCSRoot, "gcs-root", aliasesOpts.GCSRoot, "top-level GCS directory of the releases")
+	aliasesCmd.PersistentFlags().StringSliceVar(&aliasesOpts.Files, "files", aliasesOpts.Files, "release artifacts relative to the release directory which get an alias")
+	addOutputFlag(aliasesVerifyCmd.PersistentFlags(), &aliasesOutput)
+
+	aliasesCmd.AddCommand(aliasesPublishCmd, aliasesVerifyCmd, aliasesRenderCmd)
+	rootCmd.AddCommand(aliasesCmd)
+}
+
+func runAliasesPublish(opts *urlalias.Options) error {
+	opts.NoMock = rootOpts.nomock
+	if opts.NoMock {
+		version, err := util.TagStringToSemver(opts.Version)
+		if err != nil {
+			return fmt.Errorf("invalid version %s: %w", opts.Version, err)
+		}
+		branch := fmt.Sprintf("release-%d.%d", version.Major, version.Minor)
+		if err := approver.Check("aliases publish", branch); err != nil {
+			return err
+		}
+	}
+	_, err := urlalias.New(opts).Publish()
+	return err
+}
+
+func runAliasesVerify(opts *urlalias.Options, output string) error {
+	results, verifyErr := urlalias.New(opts).Verify()
+	if results == nil {
+		return verifyErr
+	}
+
+	if err := writeOutput(os.Stdout, output, results, func() error {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Alias", "Target", "Error"})
+		table.SetAutoWrapText(false)
+		for _, res := range results {
+			table.Append([]string{res.URL(), res.TargetURL(), res.Error})
+		}
+		table.Render()
+		return nil
+	}); err != nil {
+		return err
+	}
+	return verifyErr
+}
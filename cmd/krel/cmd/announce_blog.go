@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/blog"
+)
+
+// blogAnnounceCmd represents the subcommand for `krel announce blog`
+var blogAnnounceCmd = &cobra.Command{
+	Use:   "blog",
+	Short: "Propose the release blog post to the Kubernetes website",
+	Long: fmt.Sprintf(`krel announce blog
+
+krel announce blog renders the release blog post skeleton from the release
+notes JSON of the tag (--%s,-t). The skeleton contains the highlights, the
+urgent upgrade notes and a downloads table.
+
+By default the post will be only printed. To open a pull request against
+kubernetes/%s, use the --nomock flag together with a fork of the website
+repository (--fork or $%s).
+
+If --%s,-p is given, then the post will be only printed as well.`,
+		tagFlag,
+		blog.WebsiteRepo,
+		blog.ForkEnvKey,
+		printOnlyFlag,
+	),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBlogAnnounce(blogAnnounceOpts, announceOpts, rootOpts)
+	},
+}
+
+type blogAnnounceOptions struct {
+	*blog.Options
+	date string
+}
+
+var blogAnnounceOpts = &blogAnnounceOptions{Options: blog.DefaultOptions()}
+
+func init() {
+	blogAnnounceCmd.PersistentFlags().StringVar(
+		&blogAnnounceOpts.ReleaseNotesFile,
+		"release-notes-file",
+		"",
+		"release notes JSON file of the tag, as written by `release-notes --format json`",
+	)
+
+	blogAnnounceCmd.PersistentFlags().StringVar(
+		&blogAnnounceOpts.date,
+		"date",
+		"",
+		"publishing date of the blog post in the format YYYY-MM-DD, defaults to today",
+	)
+
+	blogAnnounceCmd.PersistentFlags().IntVar(
+		&blogAnnounceOpts.MaxHighlights,
+		"max-highlights",
+		blogAnnounceOpts.MaxHighlights,
+		"maximum number of features listed as highlights",
+	)
+
+	blogAnnounceCmd.PersistentFlags().StringVar(
+		&blogAnnounceOpts.Fork,
+		"fork",
+		blogAnnounceOpts.Fork,
+		fmt.Sprintf("GitHub organization of the kubernetes/%s fork", blog.WebsiteRepo),
+	)
+
+	blogAnnounceCmd.PersistentFlags().BoolVar(
+		&blogAnnounceOpts.UseSSH,
+		"use-ssh",
+		true,
+		"use SSH to push to the fork",
+	)
+
+	blogAnnounceCmd.PersistentFlags().StringVar(
+		&blogAnnounceOpts.Template,
+		"template",
+		blogAnnounceOpts.Template,
+		"name of an official template or path to a custom template of the post, see `krel templates list`",
+	)
+
+	if err := blogAnnounceCmd.MarkPersistentFlagRequired("release-notes-file"); err != nil {
+		logrus.Fatal(err)
+	}
+
+	announceCmd.AddCommand(blogAnnounceCmd)
+}
+
+func runBlogAnnounce(opts *blogAnnounceOptions, announceRootOpts *announceOptions, rootOpts *rootOptions) error {
+	if opts.date != "" {
+		date, err := time.Parse(time.DateOnly, opts.date)
+		if err != nil {
+			return fmt.Errorf("parse date: %w", err)
+		}
+		opts.Date = date
+	}
+	opts.Tag = announceRootOpts.tag
+	opts.NoMock = rootOpts.nomock && !announceRootOpts.printOnly
+
+	_, pr, err := blog.New(opts.Options).Run()
+	if err != nil {
+		return fmt.Errorf("create release blog post: %w", err)
+	}
+	if pr != 0 {
+		logrus.Infof(
+			"Release blog post proposed in https://github.com/kubernetes/%s/pull/%d",
+			blog.WebsiteRepo, pr,
+		)
+	}
+	return nil
+}
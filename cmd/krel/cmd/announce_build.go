@@ -29,32 +29,23 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/release-utils/command"
+
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/imagerewrite"
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/templates"
 )
 
 const (
 	branchFlag            = "branch"
 	changeLogFilePathFlag = "changelog-file-path"
 	changeLogHTMLFlag     = "changelog-html-file"
+	componentsFlag        = "components"
 	workDirFlag           = "workdir"
 )
 
 const semVerRegex string = `^?(\d+)(\.\d+)?(\.\d+)`
 
-const branchCreationMsg = `Kubernetes Community,
-<p>Kubernetes' {{ .Branch }} branch has been created.</p>
-<p>The release owner will be sending updates on how to interact with this branch shortly.  The <a href=https://git.k8s.io/community/contributors/devel/sig-release/cherry-picks.md target="_blank">Cherrypick Guide</a> has some general guidance on how things will proceed.</p>
-<p>Announced by your <a href=https://git.k8s.io/website/content/en/releases/release-managers.md target="_blank">Kubernetes Release Managers</a>.</p>
-`
-
-const releaseAnnouncementMsg = `Kubernetes Community,
-<p>Kubernetes <b>{{ .Tag }}</b> has been built and pushed using Golang version <b>{{ .GoVersion }}</b> .</p>
-<p>The release notes have been updated in <a href=https://git.k8s.io/kubernetes/{{ .ChangelogFilePath }}/#{{ .StrippedTag }} target="_blank">{{ .ChangelogFileName }}</a>, with a pointer to them on <a href=https://github.com/kubernetes/kubernetes/releases/tag/{{ .Tag }} target="_blank">github</a>:</p>
-<p><hr>{{ .ChangelogHTML }}<hr></p>
-
-<p><br>Contributors, the <a href=https://git.k8s.io/kubernetes/{{ .ChangelogFilePath }}/#{{ .StrippedTag }} target="_blank">{{ .ChangelogFileName }}</a> has been bootstrapped with {{ .Tag }} release notes and you may edit now as needed.</p>
-<p><br><br>Published by your <a href=hhttps://git.k8s.io/website/content/en/releases/release-managers.md href target="_blank">Kubernetes Release Managers</a>.</p>
-`
-
 // buildAnnounceCmd represents the subcommand for `krel announce build`
 var buildAnnounceCmd = &cobra.Command{
 	Use:           "build",
@@ -94,12 +85,15 @@ type buildAnnounceOptions struct {
 }
 
 type buildBranchAnnounceOptions struct {
-	branch string
+	branch   string
+	template string
 }
 
 type buildReleaseAnnounceOptions struct {
 	changelogFilePath string
 	changelogHTML     string
+	components        []string
+	template          string
 }
 
 var (
@@ -108,6 +102,8 @@ var (
 	buildReleaseAnnounceOpts = &buildReleaseAnnounceOptions{}
 )
 
+const templateFlagUsage = "name of an official template or path to a custom template of the announcement, see `krel templates list`"
+
 func init() {
 	buildBranchAnnounceCmd.PersistentFlags().StringVarP(
 		&buildBranchAnnounceOpts.branch,
@@ -133,6 +129,27 @@ func init() {
 		"contents of the changelog",
 	)
 
+	buildReleaseAnnounceCmd.PersistentFlags().StringSliceVar(
+		&buildReleaseAnnounceOpts.components,
+		componentsFlag,
+		nil,
+		"comma separated list of the released components for a partial release (default all)",
+	)
+
+	buildBranchAnnounceCmd.PersistentFlags().StringVar(
+		&buildBranchAnnounceOpts.template,
+		"template",
+		templates.BranchAnnouncement,
+		templateFlagUsage,
+	)
+
+	buildReleaseAnnounceCmd.PersistentFlags().StringVar(
+		&buildReleaseAnnounceOpts.template,
+		"template",
+		templates.ReleaseAnnouncement,
+		templateFlagUsage,
+	)
+
 	buildAnnounceCmd.PersistentFlags().StringVarP(
 		&buildAnnounceOpts.workDir,
 		workDirFlag,
@@ -168,18 +185,24 @@ func init() {
 func runBuildBranchAnnounce(opts *buildBranchAnnounceOptions, buildOpts *buildAnnounceOptions) error {
 	logrus.Info("Building release announcement for branch creation")
 
+	branchCreationMsg, err := templates.Read(opts.template)
+	if err != nil {
+		return err
+	}
 	t, err := template.New("announcement-branch").Parse(branchCreationMsg)
 	if err != nil {
 		return err
 	}
+	product := branding.Default().ProductName
 	announcement := bytes.Buffer{}
 	if err := t.Execute(&announcement, struct {
-		Branch string
-	}{opts.branch}); err != nil {
+		Product string
+		Branch  string
+	}{product, opts.branch}); err != nil {
 		return fmt.Errorf("generating the announcement html file: %w", err)
 	}
 
-	announcementSubject := fmt.Sprintf("Kubernetes %s branch has been created", opts.branch)
+	announcementSubject := fmt.Sprintf("%s %s branch has been created", product, opts.branch)
 	return buildOpts.saveAnnouncement(announcementSubject, announcement)
 }
 
@@ -189,8 +212,17 @@ func runBuildReleaseAnnounce(opts *buildReleaseAnnounceOptions, buildOpts *build
 		return fmt.Errorf("validating announcement send options: %w", err)
 	}
 
+	components, err := release.ParseComponents(opts.components)
+	if err != nil {
+		return fmt.Errorf("parse components: %w", err)
+	}
+
 	logrus.Info("Building release announcement for new release")
 
+	releaseAnnouncementMsg, err := templates.Read(opts.template)
+	if err != nil {
+		return err
+	}
 	t, err := template.New("announcement-release").Parse(releaseAnnouncementMsg)
 	if err != nil {
 		return err
@@ -206,36 +238,55 @@ func runBuildReleaseAnnounce(opts *buildReleaseAnnounceOptions, buildOpts *build
 		return err
 	}
 
+	product := branding.Default().ProductName
 	announcement := bytes.Buffer{}
 	if err := t.Execute(&announcement, struct {
+		Product           string
 		Tag               string
 		StrippedTag       string
 		GoVersion         string
 		ChangelogFilePath string
 		ChangelogFileName string
 		ChangelogHTML     string
+		Components        string
 	}{
+		product,
 		announceOpts.tag,
 		strings.ReplaceAll(announceOpts.tag, ".", ""),
 		goVersion,
 		opts.changelogFilePath,
 		filepath.Base(opts.changelogFilePath),
 		string(changelogHTML),
+		partialComponents(components),
 	}); err != nil {
 		return fmt.Errorf("generating the announcement html file: %w", err)
 	}
 
-	announcementSubject := fmt.Sprintf("Kubernetes %s is live!", announceOpts.tag)
+	announcementSubject := fmt.Sprintf("%s %s is live!", product, announceOpts.tag)
+	if components.Partial() {
+		announcementSubject = fmt.Sprintf(
+			"%s %s (%s) is live!", product, announceOpts.tag, components,
+		)
+	}
 
 	return buildOpts.saveAnnouncement(announcementSubject, announcement)
 }
 
+// partialComponents returns the component list of a partial release or an
+// empty string for a full release.
+func partialComponents(components release.Components) string {
+	if !components.Partial() {
+		return ""
+	}
+	return components.String()
+}
+
 func (opts *buildAnnounceOptions) saveAnnouncement(announcementSubject string, announcement bytes.Buffer) error {
 	logrus.Info("Creating announcement files")
 
 	absOutputPath := filepath.Join(opts.workDir, "announcement.html")
 	logrus.Infof("Writing HTML file to %s", absOutputPath)
-	err := os.WriteFile(absOutputPath, announcement.Bytes(), os.FileMode(0o644))
+	err := os.WriteFile(absOutputPath, []byte(imagerewrite.Apply(announcement.String())), os.FileMode(0o644))
 	if err != nil {
 		return fmt.Errorf("saving announcement.html: %w", err)
 	}
@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/env"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/announce"
+)
+
+const publishAtFlag = "publish-at"
+
+type publishAnnounceOptions struct {
+	publishAt          string
+	skipMail           bool
+	socialWebhookURL   string
+	notifyWebhookURLs  []string
+	name               string
+	email              string
+	socialTemplate     string
+	socialMaxLength    int
+	githubOrganization string
+	githubRepository   string
+}
+
+var publishAnnounceOpts = &publishAnnounceOptions{}
+
+// publishAnnounceCmd represents the subcommand for `krel announce publish`
+var publishAnnounceCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Publish the announcement of an embargoed release on all channels at a scheduled time",
+	Long: fmt.Sprintf(`krel announce publish
+
+krel announce publish holds back the announcement of a release until
+--%s and then publishes it on all channels at the same time:
+
+- the draft GitHub release page, created by krel release --%s,
+- the stable and latest version markers, held back by the release as well,
+- the announcement mail (unless --skip-mail),
+- the short social announcement if --social-webhook-url or $%s is set,
+- the chat notifications of every --notify-webhook-url.
+
+Everything is verified before waiting, so that a missing draft release page,
+announcement or broken social template fails right away. A channel which
+fails at the publish time does not stop the others.
+
+The schedule is not persisted, the command has to keep running in the
+foreground until the publish time and needs to be restarted if interrupted.
+
+By default only the mail gets sent to the test Google Group after waiting,
+ie: the announcement run will only be a mock run. To publish the embargoed
+release, use the --nomock flag.`,
+		publishAtFlag, publishAtFlag, socialWebhookURLEnvKey,
+	),
+	Example:       "krel announce publish --tag v1.30.0 --publish-at 2024-04-17T16:00:00Z --nomock",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAnnouncePublish(publishAnnounceOpts, announceOpts, rootOpts)
+	},
+}
+
+func init() {
+	publishAnnounceCmd.PersistentFlags().StringVar(
+		&publishAnnounceOpts.publishAt,
+		publishAtFlag,
+		"",
+		"RFC3339 time the announcement gets published at, for example 2024-04-17T16:00:00Z",
+	)
+
+	publishAnnounceCmd.PersistentFlags().BoolVar(
+		&publishAnnounceOpts.skipMail,
+		"skip-mail",
+		false,
+		"do not mail the announcement",
+	)
+
+	publishAnnounceCmd.PersistentFlags().StringVarP(
+		&publishAnnounceOpts.name,
+		nameFlag,
+		"n",
+		"",
+		"mail sender name",
+	)
+
+	publishAnnounceCmd.PersistentFlags().StringVarP(
+		&publishAnnounceOpts.email,
+		emailFlag,
+		"e",
+		"",
+		"email address",
+	)
+
+	publishAnnounceCmd.PersistentFlags().StringVar(
+		&publishAnnounceOpts.socialWebhookURL,
+		"social-webhook-url",
+		env.Default(socialWebhookURLEnvKey, ""),
+		"URL of the social webhook the short announcement gets posted to, skipped if empty",
+	)
+
+	publishAnnounceCmd.PersistentFlags().StringVar(
+		&publishAnnounceOpts.socialTemplate,
+		"social-template",
+		"",
+		"name of an official template or path to a custom one, defaults to the official social announcement",
+	)
+
+	publishAnnounceCmd.PersistentFlags().IntVar(
+		&publishAnnounceOpts.socialMaxLength,
+		"social-max-length",
+		announce.DefaultSocialMaxLength,
+		"maximum number of characters of the rendered social announcement, unlimited if zero",
+	)
+
+	publishAnnounceCmd.PersistentFlags().StringSliceVar(
+		&publishAnnounceOpts.notifyWebhookURLs,
+		"notify-webhook-url",
+		[]string{},
+		"chat webhook URL to be notified about the release, can be specified multiple times",
+	)
+
+	publishAnnounceCmd.PersistentFlags().StringVar(
+		&publishAnnounceOpts.githubOrganization,
+		"github-org",
+		git.DefaultGithubOrg,
+		"GitHub organization of the draft release page",
+	)
+
+	publishAnnounceCmd.PersistentFlags().StringVar(
+		&publishAnnounceOpts.githubRepository,
+		"github-repo",
+		git.DefaultGithubRepo,
+		"GitHub repository of the draft release page",
+	)
+
+	if err := publishAnnounceCmd.MarkPersistentFlagRequired(publishAtFlag); err != nil {
+		logrus.Fatal(err)
+	}
+
+	announceCmd.AddCommand(publishAnnounceCmd)
+}
+
+func runAnnouncePublish(opts *publishAnnounceOptions, announceRootOpts *announceOptions, rootOpts *rootOptions) error {
+	if err := announceRootOpts.Validate(); err != nil {
+		return fmt.Errorf("validating announcement publish options: %w", err)
+	}
+
+	publishAt, err := time.Parse(time.RFC3339, opts.publishAt)
+	if err != nil {
+		return fmt.Errorf("parsing --%s: %w", publishAtFlag, err)
+	}
+
+	embargoOpts := &announce.EmbargoOptions{
+		Tag:         announceRootOpts.tag,
+		PublishAt:   publishAt,
+		Owner:       opts.githubOrganization,
+		Repo:        opts.githubRepository,
+		NoMock:      rootOpts.nomock,
+		WebhookURLs: opts.notifyWebhookURLs,
+	}
+	if !opts.skipMail {
+		embargoOpts.Send = &announce.SendOptions{
+			Tag:            announceRootOpts.tag,
+			SendgridAPIKey: sendAnnounceOpts.sendgridAPIKey,
+			Name:           opts.name,
+			Email:          opts.email,
+			NoMock:         rootOpts.nomock,
+			Archive:        announce.DefaultArchiveOptions(rootOpts.nomock),
+		}
+	}
+	if opts.socialWebhookURL != "" {
+		embargoOpts.Social = &announce.SocialOptions{
+			Tag:        announceRootOpts.tag,
+			WebhookURL: opts.socialWebhookURL,
+			Template:   opts.socialTemplate,
+			MaxLength:  opts.socialMaxLength,
+		}
+	}
+
+	if announceRootOpts.printOnly {
+		logrus.Infof(
+			"Would publish the announcement of %s at %s",
+			announceRootOpts.tag, publishAt.Format(time.RFC3339),
+		)
+		return nil
+	}
+
+	// There is nobody to confirm at the publish time, so we ask before
+	// waiting for it.
+	if rootOpts.nomock {
+		_, yes, err := util.Ask(
+			fmt.Sprintf(
+				"Publish announcement of %s at %s? (y/N)",
+				announceRootOpts.tag, publishAt.Format(time.RFC3339),
+			),
+			"y:Y:yes|n:N:no|N", 10,
+		)
+		if err != nil {
+			return err
+		}
+		if !yes {
+			logrus.Info("Not publishing announcement")
+			return nil
+		}
+	}
+
+	return announce.NewEmbargo(embargoOpts).Run()
+}
@@ -23,10 +23,9 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"k8s.io/release/pkg/announce"
 	"k8s.io/release/pkg/mail"
-	"k8s.io/release/pkg/release"
 	"sigs.k8s.io/release-utils/env"
-	"sigs.k8s.io/release-utils/http"
 	"sigs.k8s.io/release-utils/util"
 )
 
@@ -34,6 +33,9 @@ const (
 	sendgridAPIKeyEnvKey = "SENDGRID_API_KEY" //nolint:gosec // it's just the key
 	nameFlag             = "name"
 	emailFlag            = "email"
+	dkimDomainFlag       = "dkim-domain"
+	dkimSelectorFlag     = "dkim-selector"
+	dkimKeyFlag          = "dkim-key"
 )
 
 // announceCmd represents the subcommand for `krel announce`
@@ -49,6 +51,17 @@ By default the mail will be sent only to a test Google Group %q,
 ie: the announcement run will only be a mock run.  To do an
 official announcement, use the --nomock flag.
 
+After sending, all links, download URLs and images of the announcement get
+verified, which can be disabled by using --skip-verify.
+
+The mail contains the List-Id and List-Unsubscribe headers of the
+announcement group. It can be signed with DKIM by setting --%s, --%s and
+--%s, in which case it gets delivered via the Sendgrid SMTP relay.
+
+The sent announcement gets published as HTML page to the announcement archive
+of the release bucket, which contains an index page of all announcements. Use
+--skip-archive to not publish it.
+
 It is necessary to export the $%s environment variable. An API key can be created by
 registering a sendgrid.com account and adding the key here:
 
@@ -68,6 +81,9 @@ without doing anything else.`,
 		sendgridAPIKeyEnvKey,
 		nameFlag,
 		emailFlag,
+		dkimDomainFlag,
+		dkimSelectorFlag,
+		dkimKeyFlag,
 		tagFlag,
 		printOnlyFlag,
 	),
@@ -82,6 +98,10 @@ type sendAnnounceOptions struct {
 	sendgridAPIKey string
 	name           string
 	email          string
+	skipVerify     bool
+	skipArchive    bool
+	nonInteractive bool
+	dkim           mail.DKIMOptions
 }
 
 var sendAnnounceOpts = &sendAnnounceOptions{}
@@ -105,6 +125,48 @@ func init() {
 		"email address",
 	)
 
+	sendAnnounceCmd.PersistentFlags().BoolVar(
+		&sendAnnounceOpts.skipVerify,
+		"skip-verify",
+		false,
+		"do not verify the URLs and images of the announcement after sending it",
+	)
+
+	sendAnnounceCmd.PersistentFlags().BoolVar(
+		&sendAnnounceOpts.nonInteractive,
+		nonInteractiveFlag,
+		false,
+		"do not ask for confirmation before sending the --nomock announcement",
+	)
+
+	sendAnnounceCmd.PersistentFlags().BoolVar(
+		&sendAnnounceOpts.skipArchive,
+		"skip-archive",
+		false,
+		"do not publish the announcement to the announcement archive after sending it",
+	)
+
+	sendAnnounceCmd.PersistentFlags().StringVar(
+		&sendAnnounceOpts.dkim.Domain,
+		dkimDomainFlag,
+		"",
+		"domain of the DKIM signature, has to match the sender address",
+	)
+
+	sendAnnounceCmd.PersistentFlags().StringVar(
+		&sendAnnounceOpts.dkim.Selector,
+		dkimSelectorFlag,
+		"",
+		"DNS selector of the DKIM public key",
+	)
+
+	sendAnnounceCmd.PersistentFlags().StringVar(
+		&sendAnnounceOpts.dkim.PrivateKey,
+		dkimKeyFlag,
+		"",
+		"path to the PEM encoded RSA private key for signing the mail with DKIM",
+	)
+
 	announceCmd.AddCommand(sendAnnounceCmd)
 }
 
@@ -114,18 +176,9 @@ func runAnnounce(opts *sendAnnounceOptions, announceRootOpts *announceOptions, r
 	}
 	logrus.Info("Retrieving release announcement from Google Cloud Bucket")
 
-	tag := util.AddTagPrefix(announceRootOpts.tag)
-	u := fmt.Sprintf(
-		"%s/archive/anago-%s/announcement.html",
-		release.URLPrefixForBucket(release.ProductionBucket), tag,
-	)
-	logrus.Infof("Using announcement remote URL: %s", u)
-
-	content, err := http.GetURLResponse(u, false)
+	content, err := announce.Fetch(announceRootOpts.tag)
 	if err != nil {
-		return fmt.Errorf(
-			"unable to retrieve release announcement form url: %s: %w", u, err,
-		)
+		return fmt.Errorf("fetch announcement: %w", err)
 	}
 
 	if announceRootOpts.printOnly {
@@ -140,51 +193,42 @@ func runAnnounce(opts *sendAnnounceOptions, announceRootOpts *announceOptions, r
 		)
 	}
 
-	logrus.Info("Preparing mail sender")
-	m := mail.NewSender(opts.sendgridAPIKey)
-
-	if opts.name != "" && opts.email != "" {
-		if err := m.SetSender(opts.name, opts.email); err != nil {
-			return fmt.Errorf("unable to set mail sender: %w", err)
-		}
-	} else {
-		logrus.Info("Retrieving default sender from sendgrid API")
-		if err := m.SetDefaultSender(); err != nil {
-			return fmt.Errorf("setting default sender: %w", err)
-		}
+	sendOpts := &announce.SendOptions{
+		Tag:            announceRootOpts.tag,
+		SendgridAPIKey: opts.sendgridAPIKey,
+		Name:           opts.name,
+		Email:          opts.email,
+		NoMock:         rootOpts.nomock,
 	}
-
-	groups := []mail.GoogleGroup{mail.KubernetesAnnounceTestGoogleGroup}
-	if rootOpts.nomock {
-		groups = []mail.GoogleGroup{
-			mail.KubernetesAnnounceGoogleGroup,
-			mail.KubernetesDevGoogleGroup,
+	if opts.dkim != (mail.DKIMOptions{}) {
+		sendOpts.DKIM = &opts.dkim
+	}
+	if !opts.skipArchive {
+		sendOpts.Archive = announce.DefaultArchiveOptions(rootOpts.nomock)
+	}
+	if rootOpts.nomock && !opts.nonInteractive {
+		sendOpts.Confirm = func() (bool, error) {
+			_, yes, err := util.Ask("Send email? (y/N)", "y:Y:yes|n:N:no|N", 10)
+			return yes, err
 		}
 	}
-	logrus.Infof("Using Google Groups as announcement target: %v", groups)
 
-	if err := m.SetGoogleGroupRecipients(groups...); err != nil {
-		return fmt.Errorf("unable to set mail recipients: %w", err)
+	if err := announce.Send(sendOpts, content); err != nil {
+		return err
 	}
 
-	logrus.Info("Sending mail")
-	subject := fmt.Sprintf("Kubernetes %s is live!", tag)
-
-	yes := true
-
-	if rootOpts.nomock {
-		_, yes, err = util.Ask("Send email? (y/N)", "y:Y:yes|n:N:no|N", 10)
-		if err != nil {
-			return err
-		}
+	if opts.skipVerify {
+		return nil
 	}
 
-	if yes {
-		if err := m.Send(content, subject); err != nil {
-			return fmt.Errorf("unable to send mail: %w", err)
-		}
+	// The mail is already sent, so broken references are only reported to
+	// be fixed as soon as possible.
+	logrus.Info("Verifying the references of the announcement")
+	if _, err := announce.NewVerifier(&announce.VerifyOptions{
+		Tag: announceRootOpts.tag,
+	}).Verify(content); err != nil {
+		logrus.Warnf("Unable to verify announcement: %v", err)
 	}
-
 	return nil
 }
 
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/release-utils/env"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/templates"
+)
+
+// socialWebhookURLEnvKey is the environment variable containing the default
+// social webhook URL.
+const socialWebhookURLEnvKey = "KREL_SOCIAL_WEBHOOK_URL"
+
+var socialAnnounceOpts = announce.DefaultSocialOptions()
+
+// socialAnnounceCmd represents the subcommand for `krel announce social`
+var socialAnnounceCmd = &cobra.Command{
+	Use:   "social",
+	Short: "Post the short announcement of a release to a social webhook",
+	Long: fmt.Sprintf(`krel announce social
+
+krel announce social renders the short announcement of a release and POSTs it
+as JSON object with the "text", "tag" and "url" fields to a generic webhook,
+like the ones of Zapier, IFTTT or Buffer. These services forward the post to
+the social networks, which decouples krel from their APIs.
+
+The %q template is used by default, which can be replaced by an official
+template name or the path to a custom one using --template. The rendered post
+must not exceed --max-length characters.
+
+By default the post only gets printed, ie: the announcement run will only be a
+mock run. To post it to the webhook (--webhook-url or $%s), use the --nomock
+flag.`,
+		templates.SocialAnnouncement, socialWebhookURLEnvKey,
+	),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAnnounceSocial(socialAnnounceOpts, announceOpts, rootOpts)
+	},
+}
+
+func init() {
+	socialAnnounceCmd.PersistentFlags().StringVar(
+		&socialAnnounceOpts.WebhookURL,
+		"webhook-url",
+		env.Default(socialWebhookURLEnvKey, ""),
+		"URL of the social webhook the announcement gets posted to",
+	)
+
+	socialAnnounceCmd.PersistentFlags().StringVar(
+		&socialAnnounceOpts.Template,
+		"template",
+		"",
+		"name of an official template or path to a custom one, defaults to the official social announcement",
+	)
+
+	socialAnnounceCmd.PersistentFlags().IntVar(
+		&socialAnnounceOpts.MaxLength,
+		"max-length",
+		socialAnnounceOpts.MaxLength,
+		"maximum number of characters of the rendered post, unlimited if zero",
+	)
+
+	announceCmd.AddCommand(socialAnnounceCmd)
+}
+
+func runAnnounceSocial(opts *announce.SocialOptions, announceRootOpts *announceOptions, rootOpts *rootOptions) error {
+	if err := announceRootOpts.Validate(); err != nil {
+		return fmt.Errorf("validating social announcement options: %w", err)
+	}
+	opts.Tag = announceRootOpts.tag
+	poster := announce.NewSocialPoster(opts)
+
+	if announceRootOpts.printOnly || !rootOpts.nomock {
+		post, err := poster.Render()
+		if err != nil {
+			return fmt.Errorf("render social announcement: %w", err)
+		}
+		logrus.Infof("The social announcement is:")
+		fmt.Println(post.Text)
+		return nil
+	}
+
+	opts.Confirm = func() (bool, error) {
+		_, yes, err := util.Ask("Post social announcement? (y/N)", "y:Y:yes|n:N:no|N", 10)
+		return yes, err
+	}
+	if _, err := poster.Post(); err != nil {
+		return err
+	}
+	return nil
+}
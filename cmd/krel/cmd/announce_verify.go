@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/announce"
+)
+
+// verifyAnnounceCmd represents the subcommand for `krel announce verify`
+var verifyAnnounceCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the published URLs and images of a release announcement",
+	Long: fmt.Sprintf(`krel announce verify
+
+krel announce verify retrieves the announcement of an already built
+Kubernetes release (--%s,-t) and probes all its links, the canonical download
+URLs, the SHA512 checksums of the listed artifacts and the referenced
+registry.k8s.io images.
+
+The broken references are printed as table, or as JSON or YAML by using
+--output. They fail the command, unless --strict=false is set.`,
+		tagFlag,
+	),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVerifyAnnounce(verifyAnnounceOpts, announceOpts, verifyAnnounceOutput)
+	},
+}
+
+var (
+	verifyAnnounceOpts   = &announce.VerifyOptions{}
+	verifyAnnounceOutput string
+)
+
+func init() {
+	verifyAnnounceCmd.PersistentFlags().BoolVar(
+		&verifyAnnounceOpts.Strict,
+		"strict",
+		true,
+		"fail if any reference of the announcement is broken, otherwise only warn",
+	)
+	addOutputFlag(verifyAnnounceCmd.PersistentFlags(), &verifyAnnounceOutput)
+
+	announceCmd.AddCommand(verifyAnnounceCmd)
+}
+
+func runVerifyAnnounce(opts *announce.VerifyOptions, announceRootOpts *announceOptions, output string) error {
+	if err := announceRootOpts.Validate(); err != nil {
+		return fmt.Errorf("validating announcement verify options: %w", err)
+	}
+	opts.Tag = announceRootOpts.tag
+
+	content, err := announce.Fetch(announceRootOpts.tag)
+	if err != nil {
+		return fmt.Errorf("fetch announcement: %w", err)
+	}
+
+	problems, verifyErr := announce.NewVerifier(opts).Verify(content)
+	if err := writeOutput(os.Stdout, output, problems, func() error {
+		if len(problems) == 0 {
+			return nil
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Reference", "Problem"})
+		table.SetAutoWrapText(false)
+		for _, p := range problems {
+			table.Append([]string{p.Reference, p.Message})
+		}
+		table.Render()
+		return nil
+	}); err != nil {
+		return err
+	}
+	if verifyErr != nil {
+		return fmt.Errorf("verify announcement: %w", verifyErr)
+	}
+	logrus.Infof("Announcement of %s verified", announceRootOpts.tag)
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/audit"
+)
+
+// auditCmd represents the subcommand for `krel audit`
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the audit log of mutating release operations",
+	Long: fmt.Sprintf(`audit can be used to inspect the audit log of non-mock stage and release
+runs.
+
+Every mutating operation, like pushing git tags, writing to buckets, pushing
+container images or changing GitHub releases, gets recorded with the actor
+($%s or the current user) into an append-only log. Each entry contains the
+hash of its predecessor, which makes modifications and removals detectable.
+
+The log gets signed using sigstore and is stored next to the staged artifacts
+in gs://<bucket>/stage/<build-version>/audit and within the release archive
+in gs://<bucket>/archive/anago-<version>/audit. The signature can be verified
+by using:
+
+  cosign verify-blob %s --signature %s.sig --certificate %s.cert \
+    --certificate-identity <identity> --certificate-oidc-issuer <issuer>
+`, audit.ActorEnvKey, audit.FileName, audit.FileName, audit.FileName),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// auditVerifyCmd represents the subcommand for `krel audit verify`
+var auditVerifyCmd = &cobra.Command{
+	Use:           "verify <file>",
+	Short:         "Verify the integrity of an audit log and print its entries",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runAuditVerify(args[0], auditVerifyOutput)
+	},
+}
+
+var auditVerifyOutput string
+
+func init() {
+	addOutputFlag(auditVerifyCmd.PersistentFlags(), &auditVerifyOutput)
+	auditCmd.AddCommand(auditVerifyCmd)
+	rootCmd.AddCommand(auditCmd)
+}
+
+func runAuditVerify(path, output string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read audit log: %w", err)
+	}
+	entries, err := audit.Verify(content)
+	if err != nil {
+		return fmt.Errorf("audit log %s is not intact: %w", path, err)
+	}
+
+	return writeOutput(os.Stdout, output, entries, func() error {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Time", "Actor", "Action", "Target", "Details"})
+		table.SetAutoWrapText(false)
+		for _, e := range entries {
+			details := make([]string, 0, len(e.Details))
+			for k, v := range e.Details {
+				details = append(details, k+"="+v)
+			}
+			sort.Strings(details)
+			table.Append([]string{
+				e.Time.Format(time.RFC3339), e.Actor, string(e.Action), e.Target, strings.Join(details, ", "),
+			})
+		}
+		table.Render()
+
+		fmt.Printf("Audit log %s is intact (%d entries)\n", path, len(entries))
+		return nil
+	})
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/approver"
+	"k8s.io/release/pkg/imagebackfill"
+)
+
+var backfillImagesOpts = imagebackfill.DefaultOptions()
+
+// backfillImagesCmd represents the subcommand for `krel backfill-images`
+var backfillImagesCmd = &cobra.Command{
+	Use:   "backfill-images --version <version>",
+	Short: "Promote architecture images missing after a partial promotion from staging",
+	Long: `krel backfill-images
+
+Detects the architecture images and manifest lists of a release version which
+are missing on the production registry, for example because the promotion
+failed half way, and opens a pull request against kubernetes/k8s.io which
+adds just their staging digests to the image promoter manifest instead of
+re-running the whole release.
+
+Manifest lists are promoted as well if they do not exist or miss any
+architecture. Without --nomock the missing images are only reported.`,
+	Example:       "krel backfill-images --version v1.30.1 --fork user --nomock",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBackfillImages(backfillImagesOpts)
+	},
+}
+
+func init() {
+	backfillImagesCmd.PersistentFlags().StringVar(
+		&backfillImagesOpts.Version,
+		"version",
+		"",
+		"release version to be repaired, for example v1.30.1",
+	)
+
+	backfillImagesCmd.PersistentFlags().StringVar(
+		&backfillImagesOpts.StagingRegistry,
+		"staging-registry",
+		backfillImagesOpts.StagingRegistry,
+		"registry to promote the missing images from",
+	)
+
+	backfillImagesCmd.PersistentFlags().StringVar(
+		&backfillImagesOpts.ProductionRegistry,
+		"production-registry",
+		backfillImagesOpts.ProductionRegistry,
+		"registry the missing images are checked on",
+	)
+
+	backfillImagesCmd.PersistentFlags().StringSliceVar(
+		&backfillImagesOpts.Images,
+		"images",
+		backfillImagesOpts.Images,
+		"release images to be checked",
+	)
+
+	backfillImagesCmd.PersistentFlags().StringSliceVar(
+		&backfillImagesOpts.Architectures,
+		"architectures",
+		backfillImagesOpts.Architectures,
+		"architectures every image has to exist for, defaults to the image architectures of the build platforms",
+	)
+
+	backfillImagesCmd.PersistentFlags().StringVar(
+		&backfillImagesOpts.Fork,
+		"fork",
+		"",
+		"the GitHub organization of the kubernetes/k8s.io fork used for opening the image promotion pull request",
+	)
+
+	backfillImagesCmd.PersistentFlags().BoolVar(
+		&backfillImagesOpts.UseSSH,
+		"use-ssh",
+		false,
+		"push to the fork via SSH instead of HTTPS",
+	)
+
+	rootCmd.AddCommand(backfillImagesCmd)
+}
+
+func runBackfillImages(opts *imagebackfill.Options) error {
+	opts.Confirm = rootOpts.nomock
+	if opts.Confirm {
+		version, err := util.TagStringToSemver(opts.Version)
+		if err != nil {
+			return fmt.Errorf("invalid version %s: %w", opts.Version, err)
+		}
+		branch := fmt.Sprintf("release-%d.%d", version.Major, version.Minor)
+		if err := approver.Check("backfill images", branch); err != nil {
+			return err
+		}
+	}
+	return imagebackfill.New(opts).Run()
+}
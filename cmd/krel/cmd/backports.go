@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/backport"
+	"k8s.io/release/pkg/notes/options"
+)
+
+var (
+	backportsOpts   = backport.DefaultOptions()
+	backportsSince  string
+	backportsOutput string
+)
+
+// backportsCmd represents the subcommand for `krel backports`
+var backportsCmd = &cobra.Command{
+	Use:   "backports [--since 2025-06-01] [--branches release-1.30] [-o json|yaml]",
+	Short: "Suggest merged pull requests as cherry pick candidates for the active release branches",
+	Long: `backports assists the patch release managers with triaging cherry pick
+candidates. It gathers the release notes of the pull requests merged into the
+default branch since --since and suggests the ones of the --kinds for every
+active release branch. The active branches are the supported ones of the
+published supported versions document, unless --branches is set.
+
+A pull request is not suggested for a branch if it already has a cherry pick
+pull request for it, or if all of its non-test changes touch files which do
+not exist on the branch, because the changed code got introduced later. The
+file check can be disabled by using --check-files=false, which saves GitHub
+API requests. Instead of gathering the release notes, a JSON file written by
+release-notes --format json can be used with --notes-file.
+
+The suggestions are printed as markdown, or as JSON or YAML when using
+--output.
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBackports(backportsOpts, backportsSince, backportsOutput)
+	},
+}
+
+func init() {
+	backportsCmd.PersistentFlags().StringVar(&backportsOpts.GitHubOrg, "github-org", backportsOpts.GitHubOrg, "GitHub organization of the repository")
+	backportsCmd.PersistentFlags().StringVar(&backportsOpts.GitHubRepo, "github-repo", backportsOpts.GitHubRepo, "GitHub repository of the pull requests")
+	backportsCmd.PersistentFlags().StringSliceVar(&backportsOpts.Branches, "branches", nil, "release branches to suggest backports for (default the supported branches)")
+	backportsCmd.PersistentFlags().StringVar(&backportsOpts.SupportedVersionsURL, "supported-versions-url", backportsOpts.SupportedVersionsURL, "URL of the published supported versions document")
+	backportsCmd.PersistentFlags().StringVar(&backportsSince, "since", "", "date (YYYY-MM-DD or RFC3339) from which on merged pull requests are considered (default 30 days ago)")
+	backportsCmd.PersistentFlags().StringVar(&backportsOpts.RepoPath, "repo-path", "", "path to a local clone of the repository for gathering the release notes")
+	backportsCmd.PersistentFlags().StringVar(&backportsOpts.NotesFile, "notes-file", "", "JSON release notes file to use instead of gathering the release notes")
+	backportsCmd.PersistentFlags().StringSliceVar(&backportsOpts.Kinds, "kinds", backportsOpts.Kinds, "kinds of pull requests to suggest")
+	backportsCmd.PersistentFlags().BoolVar(&backportsOpts.CheckFiles, "check-files", backportsOpts.CheckFiles, "only suggest pull requests changing non-test files which exist on the branch")
+	addOutputFlag(backportsCmd.PersistentFlags(), &backportsOutput)
+
+	rootCmd.AddCommand(backportsCmd)
+}
+
+func runBackports(opts *backport.Options, since, output string) error {
+	opts.Since = time.Now().AddDate(0, 0, -30)
+	if since != "" {
+		date, err := options.ParseDate(since)
+		if err != nil {
+			return fmt.Errorf("parse --since: %w", err)
+		}
+		opts.Since = date
+	}
+
+	report, err := backport.New(opts).Run()
+	if err != nil {
+		return fmt.Errorf("suggesting backports: %w", err)
+	}
+
+	return writeOutput(os.Stdout, output, report, func() error {
+		_, err := fmt.Fprint(os.Stdout, report.Markdown())
+		return err
+	})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/baseimage"
+)
+
+type checkBaseImagesOptions struct {
+	branch   string
+	platform string
+}
+
+var checkBaseImagesOpts = &checkBaseImagesOptions{
+	branch:   "master",
+	platform: "linux/amd64",
+}
+
+// checkBaseImagesCmd represents the subcommand for `krel check-base-images`
+var checkBaseImagesCmd = &cobra.Command{
+	Use:   "check-base-images --base-image-policy <file> <image>...",
+	Short: "Verify that container images are built on an allowed base image",
+	Long: `check-base-images verifies that the provided container images are built on
+one of the base images allowed for a release branch. The allowlist is a YAML
+file provided via --base-image-policy, which contains base images pinned by
+digest:
+
+  default:
+  - registry.k8s.io/build-image/go-runner@sha256:...
+  branches:
+    release-1.30:
+    - gcr.io/distroless/static@sha256:...
+
+An image complies if its layers start with the layers of an allowed base image
+for --platform. The same check runs during krel release on every image before
+publishing the release, and fails it on violations.
+`,
+	Example:       "krel check-base-images --base-image-policy base-images.yaml --branch release-1.30 registry.k8s.io/kube-proxy:v1.30.0",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Args:          cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCheckBaseImages(checkBaseImagesOpts, args)
+	},
+}
+
+func init() {
+	checkBaseImagesCmd.PersistentFlags().StringVar(&checkBaseImagesOpts.branch, "branch", checkBaseImagesOpts.branch, "release branch of the images, which selects the allowed base images")
+	checkBaseImagesCmd.PersistentFlags().StringVar(&checkBaseImagesOpts.platform, "platform", checkBaseImagesOpts.platform, "os/arch platform of the images to be checked")
+
+	rootCmd.AddCommand(checkBaseImagesCmd)
+}
+
+func runCheckBaseImages(opts *checkBaseImagesOptions, refs []string) error {
+	checker := baseimage.Default()
+	if !checker.Enabled() {
+		return errors.New("no base image policy provided, use --base-image-policy")
+	}
+
+	images := make([]baseimage.Image, 0, len(refs))
+	for _, ref := range refs {
+		images = append(images, baseimage.Image{Ref: ref, Platform: opts.platform})
+	}
+	if err := checker.Check(opts.branch, images); err != nil {
+		return fmt.Errorf("check base images: %w", err)
+	}
+	return nil
+}
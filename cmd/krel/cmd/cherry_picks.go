@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/cherrypick"
+	"k8s.io/release/pkg/release"
+)
+
+var cherryPicksOpts = cherrypick.DefaultOptions()
+
+// cherryPicksCmd represents the subcommand for `krel cherry-picks`
+var cherryPicksCmd = &cobra.Command{
+	Use:   "cherry-picks --branch <release-branch> [--milestone <milestone>] [--label <label>] [--merge] [--nomock]",
+	Short: "Validate and merge approved cherry picks for a release branch",
+	Long: `cherry-picks collects all open cherry pick pull requests targeting the
+provided release branch, which match the given --milestone and/or --label.
+
+Every cherry pick is validated to have all --required-labels (lgtm and approved
+per default), no blocking labels like do-not-merge/* or needs-rebase and only
+successful status contexts. The result is printed as markdown table ordered by
+the pull request number.
+
+If --merge is set to true, then krel will merge the ready cherry picks in order
+and stops at the first one which is not ready. The merge will only happen if the
+'--nomock' flag is specified.
+`,
+	Example:       "krel cherry-picks --branch release-1.29 --label cherry-pick-approved --merge",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cherryPicksOpts.NoMock = rootOpts.nomock
+		return cherrypick.New(cherryPicksOpts).Run()
+	},
+}
+
+func init() {
+	cherryPicksCmd.PersistentFlags().StringVar(&cherryPicksOpts.GitHubOrg, "github-org", release.GetK8sOrg(), "the GitHub organization of the repository")
+	cherryPicksCmd.PersistentFlags().StringVar(&cherryPicksOpts.GitHubRepo, "github-repo", release.GetK8sRepo(), "the GitHub repository containing the cherry picks")
+	cherryPicksCmd.PersistentFlags().StringVar(&cherryPicksOpts.Branch, "branch", "", "release branch targeted by the cherry picks")
+	cherryPicksCmd.PersistentFlags().StringVar(&cherryPicksOpts.Milestone, "milestone", "", "milestone title to filter the cherry picks")
+	cherryPicksCmd.PersistentFlags().StringVar(&cherryPicksOpts.Label, "label", cherrypick.DefaultLabel, "label to filter the cherry picks")
+	cherryPicksCmd.PersistentFlags().StringSliceVar(&cherryPicksOpts.RequiredLabels, "required-labels", cherrypick.DefaultRequiredLabels, "labels which have to be set on every cherry pick")
+	cherryPicksCmd.PersistentFlags().StringSliceVar(&cherryPicksOpts.IgnoredContexts, "ignored-contexts", cherrypick.DefaultIgnoredContexts, "status contexts which are not required to pass")
+	cherryPicksCmd.PersistentFlags().BoolVar(&cherryPicksOpts.Merge, "merge", false, "merge the ready cherry picks in order")
+
+	rootCmd.AddCommand(cherryPicksCmd)
+}
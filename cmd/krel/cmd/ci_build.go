@@ -17,6 +17,7 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -36,7 +37,8 @@ krel ci-build [--noupdatelatest] [--bucket=<GCS bucket>] [--gcs-root=<GCS root>]
 Scenarios:
 
 krel ci-build --allow-dup --fast --registry=gcr.io/foo     - Run a fast build and push images to gcr.io/foo
-krel ci-build --bucket cool-bucket --gcs-root new-gcs-root - Push to gs://cool-bucket/new-gcs-root`
+krel ci-build --bucket cool-bucket --gcs-root new-gcs-root - Push to gs://cool-bucket/new-gcs-root
+krel ci-build --nightly --nightly-retain 5                  - Push a nightly build and keep the last 5 builds`
 
 var ciBuildOpts = &build.Options{}
 
@@ -89,6 +91,23 @@ func init() {
 		"Do not update the latest file",
 	)
 
+	ciBuildCmd.PersistentFlags().BoolVar(
+		&ciBuildOpts.Nightly,
+		"nightly",
+		false,
+		fmt.Sprintf(
+			"Publish a nightly build, which updates the %s marker and deletes old builds of the same minor release",
+			release.VersionMarkerLatestGreen+release.VersionMarkerSuffix,
+		),
+	)
+
+	ciBuildCmd.PersistentFlags().IntVar(
+		&ciBuildOpts.NightlyRetain,
+		"nightly-retain",
+		build.DefaultNightlyRetain,
+		"Number of nightly builds per minor release to keep, including the new one (--nightly only)",
+	)
+
 	// TODO: Configure a default const here
 	ciBuildCmd.PersistentFlags().StringVar(
 		&ciBuildOpts.Bucket,
@@ -149,5 +168,14 @@ func init() {
 func runCIBuild(opts *build.Options) error {
 	opts.CI = true
 
+	if opts.Nightly {
+		if opts.NoUpdateLatest {
+			return errors.New("--nightly cannot be combined with --noupdatelatest")
+		}
+		if opts.NightlyRetain < 1 {
+			return fmt.Errorf("--nightly-retain must be at least 1, got %d", opts.NightlyRetain)
+		}
+	}
+
 	return build.NewInstance(opts).Build()
 }
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/releasecompare"
+)
+
+var (
+	compareOpts   = releasecompare.DefaultOptions()
+	compareOutput string
+)
+
+// compareCmd represents the subcommand for `krel compare`
+var compareCmd = &cobra.Command{
+	Use:   "compare <from-version> <to-version> [-o json|yaml]",
+	Short: "Compare the published artifacts and images of two releases",
+	Long: `compare reports the differences between two published releases, which is
+useful for upgrade planning and security reviews:
+
+- the artifacts which got added or removed, based on the SHA256SUMS files in
+  the release bucket,
+- the image digests of both versions in the release registry, including
+  whether an image is built on a different base image for --platform,
+- the size deltas of all artifacts recorded by the artifact size report.
+
+The versions do not have to be on the same release branch. The report is
+printed as markdown, or as JSON or YAML when using --output.
+`,
+	Example:       "krel compare v1.30.2 v1.31.0",
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCompare(compareOpts, args[0], args[1], compareOutput)
+	},
+}
+
+func init() {
+	compareCmd.PersistentFlags().StringVar(&compareOpts.Bucket, "bucket", compareOpts.Bucket, "bucket containing the released artifacts")
+	compareCmd.PersistentFlags().StringVar(&compareOpts.Registry, "registry", "", "registry the images got released to (default the registry of the branding)")
+	compareCmd.PersistentFlags().StringSliceVar(&compareOpts.Images, "images", compareOpts.Images, "names of the images to compare")
+	compareCmd.PersistentFlags().StringVar(&compareOpts.Platform, "platform", compareOpts.Platform, "platform in the format <os>/<arch> for comparing the base images")
+	addOutputFlag(compareCmd.PersistentFlags(), &compareOutput)
+
+	rootCmd.AddCommand(compareCmd)
+}
+
+func runCompare(opts *releasecompare.Options, from, to, output string) error {
+	report, err := releasecompare.New(opts).Compare(from, to)
+	if err != nil {
+		return fmt.Errorf("comparing releases: %w", err)
+	}
+
+	return writeOutput(os.Stdout, output, report, func() error {
+		_, err := fmt.Fprint(os.Stdout, report.Markdown())
+		return err
+	})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/artifactdiff"
+)
+
+var (
+	compareArtifactsOpts   = artifactdiff.DefaultOptions()
+	compareArtifactsOutput string
+)
+
+// compareArtifactsCmd represents the subcommand for `krel compare-artifacts`
+var compareArtifactsCmd = &cobra.Command{
+	Use:   "compare-artifacts --build-version <build-version> --version <version>",
+	Short: "Compare the staged artifacts of a version with the released ones",
+	Long: `compare-artifacts compares the staged bucket contents of a version with
+the final release bucket location and the staged images with the ones in the
+release registry. It reports artifacts which are missing, extra or have a
+different digest, which is a fast way to confirm that a release completed
+fully.
+
+A markdown table of all differences is printed to stdout, or the full report
+as JSON or YAML by using --output, and the full report can be additionally
+written as JSON by using --report. The command fails if
+any of the staged artifacts is missing or has a different digest. Extra
+artifacts, like signatures published after the release, are only reported.
+
+Setting --previous-version compares the set of staged artifacts with the
+released artifacts of the previous release instead, which flags added or
+removed artifacts, like a missing architecture tarball, before releasing.
+The command fails for all changes which are not allowlisted by the path
+patterns of --allow-added and --allow-removed. Patterns without a slash are
+matched against the file name.
+`,
+	Example: `krel compare-artifacts --build-version v1.30.0-rc.2.10+e38139724f8f00 --version v1.30.0
+krel compare-artifacts --build-version v1.30.2-rc.0.8+a7f8b5f6bd0e9c --version v1.30.2 --previous-version v1.30.1 --allow-added 'bin/linux/riscv64/*'`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCompareArtifacts(compareArtifactsOpts, compareArtifactsOutput)
+	},
+}
+
+func init() {
+	compareArtifactsCmd.PersistentFlags().StringVar(&compareArtifactsOpts.Bucket, "bucket", compareArtifactsOpts.Bucket, "bucket containing the staged and released artifacts")
+	compareArtifactsCmd.PersistentFlags().StringVar(&compareArtifactsOpts.BuildVersion, buildVersionFlag, "", "build version of the stage run")
+	compareArtifactsCmd.PersistentFlags().StringVar(&compareArtifactsOpts.Version, "version", "", "released version, for example v1.30.0")
+	compareArtifactsCmd.PersistentFlags().StringVar(&compareArtifactsOpts.StagingRegistry, "staging-registry", compareArtifactsOpts.StagingRegistry, "registry the images got staged to")
+	compareArtifactsCmd.PersistentFlags().StringVar(&compareArtifactsOpts.ReleaseRegistry, "release-registry", "", "registry the images got promoted to (default the registry of the branding)")
+	compareArtifactsCmd.PersistentFlags().StringVar(&compareArtifactsOpts.ReportFile, "report", "", "optional path for writing the comparison report as JSON")
+	compareArtifactsCmd.PersistentFlags().StringVar(&compareArtifactsOpts.PreviousVersion, "previous-version", "", "compare the set of staged artifacts with the ones of this release instead")
+	compareArtifactsCmd.PersistentFlags().StringSliceVar(&compareArtifactsOpts.AllowAdded, "allow-added", nil, "path patterns of artifacts which are expected to be added compared to the previous version")
+	compareArtifactsCmd.PersistentFlags().StringSliceVar(&compareArtifactsOpts.AllowRemoved, "allow-removed", compareArtifactsOpts.AllowRemoved, "path patterns of artifacts which are expected to be removed compared to the previous version")
+	addOutputFlag(compareArtifactsCmd.PersistentFlags(), &compareArtifactsOutput)
+
+	for _, flag := range []string{buildVersionFlag, "version"} {
+		if err := compareArtifactsCmd.MarkPersistentFlagRequired(flag); err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	rootCmd.AddCommand(compareArtifactsCmd)
+}
+
+func runCompareArtifacts(opts *artifactdiff.Options, output string) error {
+	comparer := artifactdiff.New(opts)
+	if opts.PreviousVersion != "" {
+		report, err := comparer.CompareWithPrevious()
+		if err != nil {
+			return err
+		}
+		if err := comparer.WriteReport(report); err != nil {
+			return err
+		}
+		if err := writeOutput(os.Stdout, output, report, func() error {
+			if len(report.Anomalies) > 0 {
+				fmt.Print(report.Markdown())
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return comparer.CheckAnomalyReport(report)
+	}
+
+	report, err := comparer.Compare()
+	if err != nil {
+		return err
+	}
+	if err := comparer.WriteReport(report); err != nil {
+		return err
+	}
+	if err := writeOutput(os.Stdout, output, report, func() error {
+		if len(report.Differences()) > 0 {
+			fmt.Print(report.Markdown())
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return comparer.Check(report)
+}
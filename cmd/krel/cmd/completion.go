@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/config"
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-sdk/regex"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// releaseTypes are all supported release types in their usual order.
+var releaseTypes = []string{
+	release.ReleaseTypeAlpha,
+	release.ReleaseTypeBeta,
+	release.ReleaseTypeRC,
+	release.ReleaseTypeOfficial,
+}
+
+// flagCompletions are the completion functions for flags shared between
+// multiple commands, indexed by the flag name.
+var flagCompletions = map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+	"type":         completeReleaseTypes,
+	"release-type": completeReleaseTypes,
+	"branch":       completeBranches,
+	"bucket":       completeBuckets,
+	"profile":      completeProfiles,
+}
+
+// registerCompletions adds the flag completions to all commands defining
+// one of the flags in flagCompletions.
+func registerCompletions(cmd *cobra.Command) {
+	for name, fn := range flagCompletions {
+		if cmd.LocalNonPersistentFlags().Lookup(name) == nil && cmd.PersistentFlags().Lookup(name) == nil {
+			continue
+		}
+		if err := cmd.RegisterFlagCompletionFunc(name, fn); err != nil {
+			logrus.Debugf("Unable to register completion for flag %q of %s: %v", name, cmd.CommandPath(), err)
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		registerCompletions(sub)
+	}
+}
+
+func completeReleaseTypes(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return releaseTypes, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeBranches completes the branches set in the config file, the
+// default branch and the release branches of kubernetes/kubernetes.
+func completeBranches(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	branches := append([]string{git.DefaultBranch}, completionConfig().FlagValues("branch")...)
+
+	remoteBranches, err := github.New().ListBranches(git.DefaultGithubOrg, git.DefaultGithubRepo)
+	if err != nil {
+		logrus.Debugf("Unable to list remote branches for completion: %v", err)
+	}
+	for _, branch := range remoteBranches {
+		if name := branch.GetName(); strings.HasPrefix(name, "release-") && regex.BranchRegex.MatchString(name) {
+			branches = append(branches, name)
+		}
+	}
+
+	return uniqueSorted(branches), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeBuckets completes the default release buckets as well as the ones
+// set in the config file.
+func completeBuckets(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	buckets := append([]string{
+		release.ProductionBucket,
+		release.TestBucket,
+		release.CIBucketK8sInfra,
+	}, completionConfig().FlagValues("bucket")...)
+	return uniqueSorted(buckets), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeProfiles(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return completionConfig().ProfileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionConfig loads the config file on a best effort basis, because
+// completions run without the root command initialization.
+func completionConfig() *config.Config {
+	path := rootOpts.configFile
+	if path == "" {
+		path = config.DefaultPath()
+	}
+	if !util.Exists(path) {
+		return &config.Config{}
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		logrus.Debugf("Unable to load config for completion: %v", err)
+		return &config.Config{}
+	}
+	return cfg
+}
+
+func uniqueSorted(values []string) []string {
+	seen := map[string]bool{}
+	res := []string{}
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		res = append(res, v)
+	}
+	sort.Strings(res)
+	return res
+}
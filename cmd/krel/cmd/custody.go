@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/custody"
+)
+
+type custodyOptions struct {
+	layout    *custody.Options
+	stepKeys  []string
+	output    string
+	step      string
+	key       string
+	baseDir   string
+	linkDir   string
+	materials []string
+	products  []string
+}
+
+var custodyOpts = &custodyOptions{layout: custody.DefaultOptions()}
+
+// custodyCmd represents the subcommand for `krel custody`
+var custodyCmd = &cobra.Command{
+	Use:   "custody",
+	Short: "Generate and verify the in-toto chain of custody of a release",
+	Long: fmt.Sprintf(`custody generates in-toto metadata covering the release pipeline steps
+%s.
+
+A layout signed by the release managers defines the functionaries allowed to
+perform every step and requires each step to consume exactly the artifacts
+produced by its predecessor. Every step records the hashes of its materials
+and products in a link signed by the functionary, which lets auditors verify
+the complete chain of custody for a release.
+
+The stage and release commands record their links automatically if a
+functionary key is provided via --custody-key.
+`, strings.Join(custody.Steps, " → ")),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+var custodyLayoutCmd = &cobra.Command{
+	Use:           "layout --layout-key <key> --functionary-key <key.pub>",
+	Short:         "Generate the signed in-toto layout of the release pipeline",
+	Example:       "krel custody layout --layout-key release-managers.pem --functionary-key gcb.pub --step-key sign=signer.pub",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCustodyLayout(custodyOpts)
+	},
+}
+
+var custodyRecordCmd = &cobra.Command{
+	Use:           "record --step <step> --key <key> --products <path>",
+	Short:         "Record the signed in-toto link of a release pipeline step",
+	Example:       "krel custody record --step push --key gcb.pem --base-dir _output/gcs-stage --materials _output/gcs-stage --products _output/gcs-stage",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := custody.RecordLink(
+			custodyOpts.step, custodyOpts.key, custodyOpts.baseDir,
+			custodyOpts.linkDir, custodyOpts.materials, custodyOpts.products,
+		)
+		return err
+	},
+}
+
+var custodyVerifyCmd = &cobra.Command{
+	Use:           "verify --layout <root.layout> --layout-key <key.pub>",
+	Short:         "Verify the recorded links of a release against the layout",
+	Example:       "krel custody verify --layout root.layout --layout-key release-managers.pub --link-dir links",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return custody.Verify(custodyOpts.output, []string{custodyOpts.layout.LayoutKey}, custodyOpts.linkDir)
+	},
+}
+
+func init() {
+	custodyLayoutCmd.PersistentFlags().StringVar(&custodyOpts.layout.LayoutKey, "layout-key", "", "path to the private key for signing the layout")
+	custodyLayoutCmd.PersistentFlags().StringSliceVar(&custodyOpts.layout.FunctionaryKeys, "functionary-key", nil, "paths to public keys of functionaries allowed to perform all steps")
+	custodyLayoutCmd.PersistentFlags().StringArrayVar(&custodyOpts.stepKeys, "step-key", nil, fmt.Sprintf("public key of a functionary allowed to perform a single step in the format <step>=<path>, where step is one of %s", strings.Join(custody.Steps, ", ")))
+	custodyLayoutCmd.PersistentFlags().DurationVar(&custodyOpts.layout.Expires, "expires", custodyOpts.layout.Expires, "duration until the layout expires")
+	custodyLayoutCmd.PersistentFlags().StringVar(&custodyOpts.layout.Readme, "readme", "", "description embedded into the layout, for example the release version")
+	custodyLayoutCmd.PersistentFlags().StringVar(&custodyOpts.output, "output", custody.LayoutFile, "path for writing the signed layout")
+
+	custodyRecordCmd.PersistentFlags().StringVar(&custodyOpts.step, "step", "", fmt.Sprintf("name of the recorded step, one of %s", strings.Join(custody.Steps, ", ")))
+	custodyRecordCmd.PersistentFlags().StringVar(&custodyOpts.key, "key", "", "path to the private key of the functionary for signing the link")
+	custodyRecordCmd.PersistentFlags().StringVar(&custodyOpts.baseDir, "base-dir", "", "directory the artifact paths are recorded relative to")
+	custodyRecordCmd.PersistentFlags().StringSliceVar(&custodyOpts.materials, "materials", nil, "files or directories consumed by the step")
+	custodyRecordCmd.PersistentFlags().StringSliceVar(&custodyOpts.products, "products", nil, "files or directories produced by the step")
+	custodyRecordCmd.PersistentFlags().StringVar(&custodyOpts.linkDir, "link-dir", ".", "directory for writing the link")
+
+	custodyVerifyCmd.PersistentFlags().StringVar(&custodyOpts.output, "layout", custody.LayoutFile, "path to the signed layout")
+	custodyVerifyCmd.PersistentFlags().StringVar(&custodyOpts.layout.LayoutKey, "layout-key", "", "path to the public key of the layout signer")
+	custodyVerifyCmd.PersistentFlags().StringVar(&custodyOpts.linkDir, "link-dir", ".", "directory containing the links of all steps")
+
+	for cmd, flags := range map[*cobra.Command][]string{
+		custodyLayoutCmd: {"layout-key"},
+		custodyRecordCmd: {"step", "key"},
+		custodyVerifyCmd: {"layout-key"},
+	} {
+		for _, flag := range flags {
+			if err := cmd.MarkPersistentFlagRequired(flag); err != nil {
+				logrus.Fatal(err)
+			}
+		}
+	}
+
+	custodyCmd.AddCommand(custodyLayoutCmd, custodyRecordCmd, custodyVerifyCmd)
+	rootCmd.AddCommand(custodyCmd)
+}
+
+func runCustodyLayout(opts *custodyOptions) error {
+	for _, stepKey := range opts.stepKeys {
+		step, path, ok := strings.Cut(stepKey, "=")
+		if !ok || step == "" || path == "" {
+			return fmt.Errorf("invalid step key %q, expected <step>=<path>", stepKey)
+		}
+		opts.layout.StepKeys[step] = append(opts.layout.StepKeys[step], path)
+	}
+
+	layout, err := custody.NewLayout(opts.layout)
+	if err != nil {
+		return fmt.Errorf("generate layout: %w", err)
+	}
+	if err := layout.Dump(opts.output); err != nil {
+		return fmt.Errorf("write layout: %w", err)
+	}
+	logrus.Infof("Wrote layout to %s", filepath.Clean(opts.output))
+	return nil
+}
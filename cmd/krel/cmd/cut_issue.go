@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/cutissue"
+)
+
+var cutIssueOpts = cutissue.DefaultOptions()
+
+// cutIssueCmd represents the subcommand for `krel cut-issue`
+var cutIssueCmd = &cobra.Command{
+	Use:   "cut-issue",
+	Short: "Create and update the release cut tracking issue",
+	Long: `cut-issue manages the GitHub tracking issue ("Cut vx.y.z release") for a release
+cut in kubernetes/sig-release.
+
+The issue contains the schedule, links to the CI dashboards and jobs as well
+as checklists for each release phase. The stage and release items will be
+checked off automatically by krel stage and krel release, while all other
+items can be checked off by using the check subcommand.
+
+Only open issues authored by the user of $GITHUB_TOKEN are considered to be
+the release cut issue of the version.
+
+Issues are only created or modified if the '--nomock' flag is specified.
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var cutIssueCreateCmd = &cobra.Command{
+	Use:           "create --version <version>",
+	Short:         "Create or update the release cut issue from the template",
+	Example:       "krel cut-issue create --version v1.29.1 --date 2024-01-17 --release-managers user1,user2",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cutIssueOpts.NoMock = rootOpts.nomock
+		_, err := cutissue.New(cutIssueOpts).Create()
+		return err
+	},
+}
+
+var cutIssueCheckCmd = &cobra.Command{
+	Use:   "check --version <version> <item>...",
+	Short: "Check off items in the release cut issue",
+	Long: "check checks off the provided items in the release cut issue. Available items are:\n\n" +
+		strings.Join([]string{
+			cutissue.ItemCISignal, cutissue.ItemCherryPicks,
+			cutissue.ItemStageMock, cutissue.ItemStage,
+			cutissue.ItemReleaseMock, cutissue.ItemRelease,
+			cutissue.ItemAnnounce, cutissue.ItemPackages,
+		}, ", "),
+	Example:       "krel cut-issue check --version v1.29.1 " + cutissue.ItemCISignal,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("at least one item has to be provided")
+		}
+		cutIssueOpts.NoMock = rootOpts.nomock
+		return cutissue.New(cutIssueOpts).Check(args...)
+	},
+}
+
+func init() {
+	cutIssueCmd.PersistentFlags().StringVar(&cutIssueOpts.GitHubOrg, "github-org", cutIssueOpts.GitHubOrg, "the GitHub organization of the tracking repository")
+	cutIssueCmd.PersistentFlags().StringVar(&cutIssueOpts.GitHubRepo, "github-repo", cutIssueOpts.GitHubRepo, "the GitHub repository containing the tracking issues")
+	cutIssueCmd.PersistentFlags().StringVar(&cutIssueOpts.Version, "version", "", "the version to be released, for example v1.29.1")
+
+	cutIssueCreateCmd.PersistentFlags().StringVar(&cutIssueOpts.Branch, "branch", "", "the release branch, will be inferred from the version if not set")
+	cutIssueCreateCmd.PersistentFlags().StringVar(&cutIssueOpts.Date, "date", "", "the target date of the release cut")
+	cutIssueCreateCmd.PersistentFlags().StringSliceVar(&cutIssueOpts.ReleaseManagers, "release-managers", nil, "GitHub handles of the release managers, which will be assigned to the issue")
+	cutIssueCreateCmd.PersistentFlags().StringVar(&cutIssueOpts.Template, "template", "", "path to a custom issue body template")
+
+	cutIssueCmd.AddCommand(cutIssueCreateCmd, cutIssueCheckCmd)
+	rootCmd.AddCommand(cutIssueCmd)
+}
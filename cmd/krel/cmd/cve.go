@@ -21,12 +21,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/olekukonko/tablewriter"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"k8s.io/release/pkg/announce"
 	"k8s.io/release/pkg/cve"
+	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-utils/editor"
 )
 
@@ -77,9 +81,49 @@ delete all content from the file.
 	Args: argFunc,
 }
 
+var cveShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show a published CVE map",
+	Long: `The show command prints the data of a CVE map which has already been
+published to the release bucket, as table or as JSON or YAML by using
+--output.
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showCVE(cveOpts)
+	},
+	Args: argFunc,
+}
+
+var cveAdviseCmd = &cobra.Command{
+	Use:   "advise",
+	Short: "Attach a CVE advisory to the affected GitHub releases",
+	Long: `The advise command prepends a security notice section linking the
+advisory of a published CVE to the GitHub release pages of all affected
+releases. The affected releases are selected by a semver range, for example:
+
+  krel cve advise CVE-2024-1234 --affected ">=1.29.0 <1.29.3 || >=1.30.0 <1.30.1"
+
+The title and advisory link default to the title and tracking issue of the
+CVE map in the release bucket. Releases which already contain the notice are
+left untouched. Without --nomock, the updated pages are only printed.
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return adviseCVE(cveOpts)
+	},
+	Args: argFunc,
+}
+
 type cveOptions struct {
 	CVE      string   // CVE identifier to work on
 	mapFiles []string // List of mapfiles
+	affected string   // Semver range of the affected releases
+	advisory string   // Link to the security advisory
+	title    string   // Title of the vulnerability
+	output   string   // Output format of the show subcommand
 }
 
 var argFunc = func(cmd *cobra.Command, args []string) error {
@@ -104,7 +148,34 @@ func init() {
 		"update vulnerability data from a local map file",
 	)
 
-	cveCmd.AddCommand(cveEditCmd, cveDeleteCmd)
+	cveAdviseCmd.PersistentFlags().StringVar(
+		&cveOpts.affected,
+		"affected",
+		"",
+		"semver range of the affected releases, eg \">=1.29.0 <1.29.3\"",
+	)
+
+	cveAdviseCmd.PersistentFlags().StringVar(
+		&cveOpts.advisory,
+		"advisory",
+		"",
+		"link to the security advisory, defaults to the tracking issue of the CVE",
+	)
+
+	cveAdviseCmd.PersistentFlags().StringVar(
+		&cveOpts.title,
+		"title",
+		"",
+		"title of the vulnerability, defaults to the title of the CVE",
+	)
+
+	if err := cveAdviseCmd.MarkPersistentFlagRequired("affected"); err != nil {
+		logrus.Fatal(err)
+	}
+
+	addOutputFlag(cveShowCmd.PersistentFlags(), &cveOpts.output)
+
+	cveCmd.AddCommand(cveEditCmd, cveDeleteCmd, cveShowCmd, cveAdviseCmd)
 	rootCmd.AddCommand(cveCmd)
 }
 
@@ -212,3 +283,66 @@ func editExistingCVE(opts *cveOptions) (err error) {
 	// If the file was changed, re-write it:
 	return client.Write(opts.CVE, tempFilePath)
 }
+
+// showCVE prints the data of a published CVE map
+func showCVE(opts *cveOptions) error {
+	data, err := cve.NewClient().Get(opts.CVE)
+	if err != nil {
+		return fmt.Errorf("reading CVE entry: %w", err)
+	}
+	return writeOutput(os.Stdout, opts.output, data, func() error {
+		prs := make([]string, 0, len(data.LinkedPRs))
+		for _, pr := range data.LinkedPRs {
+			prs = append(prs, "#"+strconv.Itoa(pr))
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetAutoWrapText(false)
+		table.AppendBulk([][]string{
+			{"ID", data.ID},
+			{"Title", data.Title},
+			{"Issue", data.TrackingIssue},
+			{"Score", fmt.Sprintf("%.1f (%s)", data.CVSSScore, data.CVSSRating)},
+			{"Vector", data.CVSSVector},
+			{"Pull Requests", strings.Join(prs, ", ")},
+		})
+		table.Render()
+		return nil
+	})
+}
+
+// adviseCVE adds the security notice of a CVE to the affected releases
+func adviseCVE(opts *cveOptions) error {
+	noticeOpts := &announce.SecurityNoticeOptions{
+		CVE:      opts.CVE,
+		Title:    opts.title,
+		Advisory: opts.advisory,
+		Affected: opts.affected,
+		Owner:    git.DefaultGithubOrg,
+		Repo:     git.DefaultGithubRepo,
+		NoMock:   rootOpts.nomock,
+	}
+
+	if noticeOpts.Title == "" || noticeOpts.Advisory == "" {
+		data, err := cve.NewClient().Get(opts.CVE)
+		if err != nil {
+			return fmt.Errorf("reading CVE entry: %w", err)
+		}
+		if noticeOpts.Title == "" {
+			noticeOpts.Title = data.Title
+		}
+		if noticeOpts.Advisory == "" {
+			noticeOpts.Advisory = data.TrackingIssue
+		}
+	}
+
+	tags, err := announce.AddSecurityNotices(noticeOpts)
+	if err != nil {
+		return fmt.Errorf("adding security notices: %w", err)
+	}
+	if len(tags) == 0 {
+		logrus.Infof("No release pages to update for %s", opts.CVE)
+		return nil
+	}
+	logrus.Infof("Added the security notice of %s to %s", opts.CVE, strings.Join(tags, ", "))
+	return nil
+}
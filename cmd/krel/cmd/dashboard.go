@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/dashboard"
+)
+
+var dashboardOpts = dashboard.DefaultOptions()
+
+// dashboardCmd represents the subcommand for `krel dashboard`
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Generate a static HTML dashboard of the releases, the schedule and the CI signal",
+	Long: fmt.Sprintf(`dashboard renders a static HTML page for the release team, which contains
+
+- the CI signal of the release blocking dashboards of --branches,
+- the active and upcoming freezes of the --schedule,
+- the latest releases of the announcement archive along with their build
+  environment manifests.
+
+The page and its data are written to %s and %s in --output-dir and get
+published to the %s directory of --publish-bucket if set.
+`, dashboard.IndexFile, dashboard.DataFile, dashboard.Dir),
+	Example:       "krel dashboard --branches master,release-1.30 --schedule schedule.yaml --publish-bucket gs://my-bucket",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := dashboard.New(dashboardOpts).Run()
+		return err
+	},
+}
+
+func init() {
+	dashboardCmd.PersistentFlags().StringVar(&dashboardOpts.Bucket, "bucket", dashboardOpts.Bucket, "release bucket containing the announcement archive and the released artifacts")
+	dashboardCmd.PersistentFlags().StringSliceVar(&dashboardOpts.Branches, "branches", dashboardOpts.Branches, "branches to show the CI signal for")
+	dashboardCmd.PersistentFlags().IntVar(&dashboardOpts.Releases, "releases", dashboardOpts.Releases, "number of shown releases")
+	dashboardCmd.PersistentFlags().StringVar(&dashboardOpts.ScheduleFile, "schedule", "", "optional release schedule YAML of kubernetes/sig-release")
+	dashboardCmd.PersistentFlags().IntVar(&dashboardOpts.AllowedFlakyJobs, "allowed-flaky-jobs", 0, "number of flaky release blocking CI jobs per branch which are not shown as blocking")
+	dashboardCmd.PersistentFlags().StringVar(&dashboardOpts.OutputDir, "output-dir", dashboardOpts.OutputDir, "local directory to write the dashboard to")
+	dashboardCmd.PersistentFlags().StringVar(&dashboardOpts.PublishBucket, "publish-bucket", "", "optional bucket to publish the dashboard to")
+
+	rootCmd.AddCommand(dashboardCmd)
+}
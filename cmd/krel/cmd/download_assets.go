@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/releaseassets"
+)
+
+var downloadAssetsOpts = releaseassets.DefaultOptions()
+
+// downloadAssetsCmd represents the subcommand for `krel download-assets`
+var downloadAssetsCmd = &cobra.Command{
+	Use:   "download-assets --tag <tag>",
+	Short: "Download and verify the assets of a GitHub release",
+	Long: `download-assets downloads the assets of a GitHub release into
+<output-dir>/<org>/<repo>/<tag>, for example to mirror a release or to build
+an air-gapped bundle.
+
+Every downloaded asset gets verified against the published SHA256SUMS,
+SHA512SUMS and checksums.txt manifests.
+
+The command fails if any asset does not pass the verification. The
+downloaded files are kept for further inspection.
+`,
+	Example: `krel download-assets --tag v1.30.0 --output-dir ./mirror
+krel download-assets --tag v1.30.0 --asset '*.tar.gz'`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		assets, err := releaseassets.New(downloadAssetsOpts).Download()
+		if len(assets) > 0 {
+			releaseassets.PrintAssets(os.Stdout, assets)
+		}
+		return err
+	},
+}
+
+func init() {
+	downloadAssetsCmd.PersistentFlags().StringVar(&downloadAssetsOpts.GitHubOrg, "org", downloadAssetsOpts.GitHubOrg, "the GitHub organization of the release")
+	downloadAssetsCmd.PersistentFlags().StringVar(&downloadAssetsOpts.GitHubRepo, "repo", downloadAssetsOpts.GitHubRepo, "the GitHub repository of the release")
+	downloadAssetsCmd.PersistentFlags().StringVarP(&downloadAssetsOpts.Tag, "tag", "t", "", "the tag of the release, for example v1.30.0")
+	downloadAssetsCmd.PersistentFlags().StringVar(&downloadAssetsOpts.OutputDir, "output-dir", downloadAssetsOpts.OutputDir, "the directory to lay out the assets in")
+	downloadAssetsCmd.PersistentFlags().StringSliceVar(&downloadAssetsOpts.Patterns, "asset", nil, "only download assets matching the shell pattern, can be set multiple times")
+	downloadAssetsCmd.PersistentFlags().BoolVar(&downloadAssetsOpts.VerifyChecksums, "verify-checksums", downloadAssetsOpts.VerifyChecksums, "verify the assets against the published checksum manifests")
+
+	rootCmd.AddCommand(downloadAssetsCmd)
+}
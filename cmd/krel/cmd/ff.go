@@ -26,10 +26,13 @@ import (
 	"k8s.io/release/pkg/fastforward"
 	"k8s.io/release/pkg/release"
 	kgit "sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/env"
 )
 
 var ffOpts = &fastforward.Options{}
 
+const notifyWebhookURLEnvKey = "FF_NOTIFY_WEBHOOK_URL"
+
 // ffCmd represents the base command when called without any subcommands
 var ffCmd = &cobra.Command{
 	Use:     "fast-forward --branch <release-branch> [--ref <main-ref>] [--nomock] [--cleanup]",
@@ -64,13 +67,33 @@ interaction.  This mode is mainly made for CI purposes.
 
 If --submit is set to true, then krel fast-forward will run by submitting a new
 Google Cloud Build job.
+
+If --diff is set to true, then krel fast-forward will only print the list of
+commits (author, subject and pull request link) which would be merged into the
+release branch. Nothing will be merged or pushed in that mode.
+
+If --diff-branches is set, then krel fast-forward does the same for multiple
+release branches at once. Every branch gets checked out into its own git
+worktree sharing the objects of the repository, which allows to verify the
+merge base tags and list the commits of all branches concurrently.
+
+If --notify-webhook-url is set, then krel will post a message about the
+success or failure of the fast-forward to that (Slack compatible) webhook. The
+message contains the old and new HEAD of the release branch as well as a link
+to the CI run, which can be set via --ci-run-url or will be inferred from the
+Prow environment. If --submit is set, then the webhook URL gets forwarded to the
+Google Cloud Build job, which sends the message instead.
+
+If --ssh-key is set, then krel will push over SSH using only that private key,
+for example a deploy key of the repository, instead of using HTTPS and the
+GitHub token. This cannot be combined with --submit.
 `, kgit.Remotify(kgit.DefaultBranch)),
 	Example:       "krel fast-forward --branch release-1.17 --ref origin/master --cleanup",
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ffOpts.NoMock = rootOpts.nomock
-		return fastforward.New(ffOpts).Run()
+		return fastforward.New(ffOpts).RunContext(runContext())
 	},
 }
 
@@ -84,6 +107,11 @@ func init() {
 	ffCmd.PersistentFlags().BoolVar(&ffOpts.Cleanup, "cleanup", false, "cleanup the repository after the run")
 	ffCmd.PersistentFlags().BoolVar(&ffOpts.NonInteractive, "non-interactive", false, "do not require any user interaction")
 	ffCmd.PersistentFlags().BoolVar(&ffOpts.Submit, "submit", false, "run inside of Google Cloud Build by submitting a new job")
+	ffCmd.PersistentFlags().BoolVar(&ffOpts.Diff, "diff", false, "only print the commits which would be fast forwarded without merging or pushing")
+	ffCmd.PersistentFlags().StringVar(&ffOpts.NotifyWebhookURL, "notify-webhook-url", env.Default(notifyWebhookURLEnvKey, ""), fmt.Sprintf("webhook URL to be notified about the result of the fast forward, can be set via %s as well", notifyWebhookURLEnvKey))
+	ffCmd.PersistentFlags().StringVar(&ffOpts.CIRunURL, "ci-run-url", "", "link to the CI run to be included in notifications, will be inferred from the Prow environment if not set")
+	ffCmd.PersistentFlags().StringSliceVar(&ffOpts.DiffBranches, "diff-branches", nil, "verify the release branches concurrently and print their commits which would be fast forwarded, using one git worktree per branch")
+	ffCmd.PersistentFlags().StringVar(&ffOpts.SSHKey, "ssh-key", release.GitSSHKey(), fmt.Sprintf("private SSH key, like a deploy key, to push over SSH instead of HTTPS, can be set via %s as well", release.GitSSHKeyEnvKey))
 
 	rootCmd.AddCommand(ffCmd)
 }
@@ -17,9 +17,13 @@ limitations under the License.
 package cmd
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/release"
 )
 
 var historyOpts = gcb.NewHistoryOptions()
@@ -27,7 +31,7 @@ var historyOpts = gcb.NewHistoryOptions()
 // historyCmd is a krel subcommand which generates information about the
 // command that the operator ran for a specific release cut.
 var historyCmd = &cobra.Command{
-	Use:           "history --branch release-1.19 --date-from 2020-06-18 [--date-to 2020-06-19]",
+	Use:           "history --branch release-1.19 --date-from 2020-06-18 [--date-to 2020-06-19] [--output json]",
 	Short:         "Run history to build a list of commands that ran when cutting a specific Kubernetes release",
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -41,7 +45,7 @@ func init() {
 		&historyOpts.Branch,
 		"branch",
 		historyOpts.Branch,
-		"The release branch for which the release should be build",
+		"The release branch for which the release should be build, an empty string considers all branches",
 	)
 
 	historyCmd.PersistentFlags().StringVar(
@@ -58,5 +62,19 @@ func init() {
 		"Get the jobs ending from a specific date.",
 	)
 
+	historyCmd.PersistentFlags().StringVar(
+		&historyOpts.ReleaseType,
+		"release-type",
+		historyOpts.ReleaseType,
+		fmt.Sprintf("Filter the jobs by release type (%s), considers all types if empty",
+			strings.Join([]string{
+				release.ReleaseTypeAlpha, release.ReleaseTypeBeta,
+				release.ReleaseTypeRC, release.ReleaseTypeOfficial,
+			}, ", "),
+		),
+	)
+
+	addOutputFlag(historyCmd.PersistentFlags(), &historyOpts.Output, gcb.HistoryOutputs...)
+
 	rootCmd.AddCommand(historyCmd)
 }
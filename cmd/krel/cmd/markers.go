@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/workdir"
+)
+
+type markersOptions struct {
+	bucket        string
+	gcsRoot       string
+	fast          bool
+	privateBucket bool
+}
+
+var markersOpts = &markersOptions{}
+
+// markersCmd represents the subcommand for `krel markers`
+var markersCmd = &cobra.Command{
+	Use:   "markers",
+	Short: "Check and roll back the version markers on dl.k8s.io",
+	Long: `krel markers
+
+krel markers manages the version marker files (like stable.txt, latest-1.txt or
+stable-1.31.txt) which point to the latest published releases. Version markers
+are only allowed to point to releases which are published and verified, which
+means that the release path contains the SHA256SUMS checksum file.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+// markersCheckCmd represents the subcommand for `krel markers check`
+var markersCheckCmd = &cobra.Command{
+	Use:   "check [marker...]",
+	Short: "Verify that the version markers point to published and verified releases",
+	Long: fmt.Sprintf(`krel markers check
+
+Checks that all provided version markers (without the %s suffix) exist, contain
+a version valid for their name and point to published and verified releases.
+The %q and %q markers are checked if no marker is provided.`,
+		release.VersionMarkerSuffix, release.VersionMarkerStable, release.VersionMarkerLatest,
+	),
+	Example:       "krel markers check stable stable-1.31 latest-1.32",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMarkersCheck(markersOpts, args)
+	},
+}
+
+// markersRollbackCmd represents the subcommand for `krel markers rollback`
+var markersRollbackCmd = &cobra.Command{
+	Use:   "rollback <marker> <version>",
+	Short: "Roll back a version marker to a previous release",
+	Long: `krel markers rollback
+
+Sets the version marker (without the .txt suffix) to the provided version, even
+if the currently published version is newer. The version has to be published
+and verified. The version marker will only be changed if --nomock is set.`,
+	Example:       "krel markers rollback stable-1.31 v1.31.1 --nomock",
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMarkersRollback(markersOpts, rootOpts, args[0], args[1])
+	},
+}
+
+func init() {
+	markersCmd.PersistentFlags().StringVar(
+		&markersOpts.bucket,
+		"bucket",
+		release.ProductionBucket,
+		"GCS bucket containing the version markers",
+	)
+
+	markersCmd.PersistentFlags().StringVar(
+		&markersOpts.gcsRoot,
+		"gcs-root",
+		"release",
+		"top-level GCS directory of the releases",
+	)
+
+	markersCmd.PersistentFlags().BoolVar(
+		&markersOpts.fast,
+		"fast",
+		false,
+		"use the version markers of fast builds",
+	)
+
+	markersCmd.PersistentFlags().BoolVar(
+		&markersOpts.privateBucket,
+		"private-bucket",
+		false,
+		"do not validate the version markers via their public URL",
+	)
+
+	markersCmd.AddCommand(markersCheckCmd, markersRollbackCmd)
+	rootCmd.AddCommand(markersCmd)
+}
+
+func runMarkersCheck(opts *markersOptions, markers []string) error {
+	if len(markers) == 0 {
+		markers = []string{release.VersionMarkerStable, release.VersionMarkerLatest}
+	}
+
+	if err := release.NewPublisher().CheckVersionMarkers(
+		opts.bucket, opts.gcsRoot, markers, opts.fast,
+	); err != nil {
+		return fmt.Errorf("inconsistent version markers: %w", err)
+	}
+	logrus.Infof("All version markers are consistent: %v", markers)
+	return nil
+}
+
+func runMarkersRollback(opts *markersOptions, rootOpts *rootOptions, marker, version string) error {
+	publisher := release.NewPublisher()
+
+	if !rootOpts.nomock {
+		if err := release.ValidateVersionMarkerVersion(marker, version); err != nil {
+			return fmt.Errorf("validate version marker: %w", err)
+		}
+		if err := publisher.VerifyPublishedRelease(
+			opts.bucket, opts.gcsRoot, version, opts.fast,
+		); err != nil {
+			return fmt.Errorf("verify release %s: %w", version, err)
+		}
+		logrus.Infof(
+			"Not rolling back version marker %s to %s in mock mode", marker, version,
+		)
+		return nil
+	}
+
+	buildDir, err := workdir.MkdirTemp("krel-markers-")
+	if err != nil {
+		return fmt.Errorf("create build dir: %w", err)
+	}
+	defer os.RemoveAll(buildDir)
+
+	return publisher.RollbackVersionMarker(
+		marker, version, buildDir, opts.bucket, opts.gcsRoot,
+		opts.privateBucket, opts.fast,
+	)
+}
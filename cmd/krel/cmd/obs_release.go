@@ -159,7 +159,7 @@ func runOBSRelease(options *obs.ReleaseOptions) error {
 		if err := options.Validate(&obs.State{}, true); err != nil {
 			return fmt.Errorf("prechecking release options: %w", err)
 		}
-		return obsRelease.Submit(stream)
+		return obsRelease.SubmitContext(runContext(), stream)
 	}
 	return obsRelease.Run()
 }
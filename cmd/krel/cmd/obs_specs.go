@@ -102,6 +102,13 @@ func init() {
 		"only create specs without downloading binaries and creating archives",
 	)
 
+	obsSpecsCmd.PersistentFlags().StringVar(
+		&specsOpts.ReleaseNotes,
+		"release-notes",
+		specsOpts.ReleaseNotes,
+		"path or https:// URL of the JSON release notes used for the package changelog",
+	)
+
 	obsCmd.AddCommand(obsSpecsCmd)
 }
 
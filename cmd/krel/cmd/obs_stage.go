@@ -223,7 +223,7 @@ func runOBSStage(options *obs.StageOptions) error {
 		if err := options.Validate(&obs.State{}, true); err != nil {
 			return fmt.Errorf("prechecking stage options: %w", err)
 		}
-		return stage.Submit(stream)
+		return stage.SubmitContext(runContext(), stream)
 	}
 	return stage.Run()
 }
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/obs"
+)
+
+// obsWatchCmd represents the subcommand for `krel obs watch`
+var obsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Detect new upstream versions of cri-tools and kubernetes-cni to package",
+	Long: `krel obs watch
+
+This subcommand checks the upstream releases of the companion packages
+cri-tools (kubernetes-sigs/cri-tools) and kubernetes-cni
+(containernetworking/plugins) and compares them against the versions published
+on pkgs.k8s.io for every provided Kubernetes minor version. cri-tools releases
+are matched to the Kubernetes minor version, while the latest kubernetes-cni
+release is used for all of them.
+
+Outdated packages are listed, and using --submit stages them by submitting a
+"krel obs stage" Google Cloud Build (GCB) job per package and minor version.
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runOBSWatch(obsWatchOptions)
+	},
+}
+
+var (
+	obsWatchOptions = obs.DefaultWatchOptions()
+	obsWatchOutput  string
+	obsWatchJSON    bool
+)
+
+func init() {
+	obsWatchCmd.PersistentFlags().
+		StringSliceVar(
+			&obsWatchOptions.KubernetesVersions,
+			"kubernetes-versions",
+			obsWatchOptions.KubernetesVersions,
+			"Kubernetes minor versions whose package repositories should be checked, like v1.30",
+		)
+
+	obsWatchCmd.PersistentFlags().
+		StringSliceVar(
+			&obsWatchOptions.Packages,
+			obsPackagesFlag,
+			obsWatchOptions.Packages,
+			"List of companion packages to check",
+		)
+
+	obsWatchCmd.PersistentFlags().
+		StringSliceVar(
+			&obsWatchOptions.Architectures,
+			obsArchitecturesFlag,
+			obsWatchOptions.Architectures,
+			"List of architectures to build",
+		)
+
+	obsWatchCmd.PersistentFlags().
+		StringVar(
+			&obsWatchOptions.SpecTemplatePath,
+			obsSpecTemplatePathFlag,
+			obsWatchOptions.SpecTemplatePath,
+			"Path to a directory containing templates for specs",
+		)
+
+	obsWatchCmd.PersistentFlags().
+		StringVar(
+			&obsWatchOptions.RepositoryURL,
+			"repository-url",
+			obsWatchOptions.RepositoryURL,
+			"Base URL of the published package repositories",
+		)
+
+	obsWatchCmd.PersistentFlags().
+		BoolVar(
+			&obsWatchOptions.Submit,
+			submitJobFlag,
+			false,
+			"Submit a stage job for every outdated package",
+		)
+
+	obsWatchCmd.PersistentFlags().
+		BoolVar(
+			&obsWatchOptions.Stream,
+			streamFlag,
+			false,
+			"Run the Google Cloud Build jobs synchronously",
+		)
+
+	addOutputFlag(obsWatchCmd.PersistentFlags(), &obsWatchOutput)
+
+	obsWatchCmd.PersistentFlags().
+		BoolVar(
+			&obsWatchJSON,
+			"json",
+			false,
+			"Print the outdated packages as JSON",
+		)
+
+	if err := obsWatchCmd.PersistentFlags().MarkDeprecated("json", "use --output json instead"); err != nil {
+		logrus.Fatal(err)
+	}
+
+	obsCmd.AddCommand(obsWatchCmd)
+}
+
+func runOBSWatch(options *obs.WatchOptions) error {
+	options.NoMock = rootOpts.nomock
+
+	updates, err := obs.NewWatcher(options).Run()
+	if err != nil {
+		return fmt.Errorf("watching companion packages: %w", err)
+	}
+
+	output := obsWatchOutput
+	if obsWatchJSON {
+		output = outputJSON
+	}
+
+	return writeOutput(os.Stdout, output, updates, func() error {
+		if len(updates) == 0 {
+			fmt.Println("All companion packages are up to date")
+			return nil
+		}
+
+		lines := []string{}
+		for _, u := range updates {
+			published := u.Published
+			if published == "" {
+				published = "unpublished"
+			}
+			lines = append(lines, fmt.Sprintf(
+				"%s (Kubernetes %s): %s -> %s", u.Package, u.KubernetesVersion, published, u.Upstream,
+			))
+		}
+		fmt.Println(strings.Join(lines, "\n"))
+		return nil
+	})
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// outputTable prints the human readable output of a subcommand.
+	outputTable = "table"
+
+	// outputJSON prints the output of a subcommand as JSON.
+	outputJSON = "json"
+
+	// outputYAML prints the output of a subcommand as YAML.
+	outputYAML = "yaml"
+)
+
+// outputFormats are the output formats supported by all read-only
+// subcommands.
+var outputFormats = []string{outputTable, outputJSON, outputYAML}
+
+// addOutputFlag adds the --output (-o) flag to a read-only subcommand. The
+// first format is the default one, all outputFormats are used if none is
+// provided.
+func addOutputFlag(flags *pflag.FlagSet, output *string, formats ...string) {
+	if len(formats) == 0 {
+		formats = outputFormats
+	}
+	flags.StringVarP(
+		output,
+		"output",
+		"o",
+		formats[0],
+		fmt.Sprintf("The output format, one of: %s", strings.Join(formats, ", ")),
+	)
+}
+
+// writeOutput writes v to w in the structured output format, or calls table
+// for rendering the human readable output.
+func writeOutput(w io.Writer, format string, v any, table func() error) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			return fmt.Errorf("encode JSON output: %w", err)
+		}
+		return nil
+
+	case outputYAML:
+		content, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("encode YAML output: %w", err)
+		}
+		_, err = w.Write(content)
+		return err
+
+	case outputTable, "":
+		return table()
+
+	default:
+		return fmt.Errorf(
+			"unsupported output format %q, must be one of: %s",
+			format, strings.Join(outputFormats, ", "),
+		)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteOutput(t *testing.T) {
+	value := []struct {
+		Name string `json:"name"`
+	}{{Name: "foo"}}
+
+	for _, tc := range []struct {
+		format      string
+		expected    string
+		table       bool
+		expectedErr bool
+	}{
+		{format: outputJSON, expected: "[\n  {\n    \"name\": \"foo\"\n  }\n]\n"},
+		{format: outputYAML, expected: "- name: foo\n"},
+		{format: outputTable, table: true},
+		{format: "", table: true},
+		{format: "xml", expectedErr: true},
+	} {
+		buf := &bytes.Buffer{}
+		tableCalled := false
+		err := writeOutput(buf, tc.format, value, func() error {
+			tableCalled = true
+			return nil
+		})
+
+		if tc.expectedErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.table, tableCalled)
+		require.Equal(t, tc.expected, buf.String())
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/pendingnotes"
+)
+
+var pendingNotesOpts = pendingnotes.DefaultOptions()
+
+// pendingNotesCmd represents the subcommand for `krel pending-notes`
+var pendingNotesCmd = &cobra.Command{
+	Use:   "pending-notes --branch release-1.31 [--issue 1234] [--interval 6h]",
+	Short: "Summarize the release notes merged since the latest patch release of a branch on GitHub",
+	Long: `pending-notes gathers the release notes merged into --branch since its
+latest patch release and keeps a summary of them up to date on GitHub. This
+gives the maintainers visibility into what the next patch release will contain
+without running the release notes tooling.
+
+The summary is maintained in a dedicated issue of the tracking repository, or
+as comment on an existing --issue, which get identified by a hidden marker of
+the branch and have to be authored by the user of $GITHUB_TOKEN. They are only
+edited if the summary changed. Running the command
+with --interval keeps updating the summary periodically, while failing updates
+get retried in the next interval.
+
+Without --nomock the summary is only printed.
+`,
+	Example:       "krel pending-notes --branch release-1.31 --interval 6h --nomock",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPendingNotes(pendingNotesOpts)
+	},
+}
+
+func init() {
+	pendingNotesCmd.PersistentFlags().StringVar(&pendingNotesOpts.GitHubOrg, "github-org", pendingNotesOpts.GitHubOrg, "GitHub organization of the tracking repository")
+	pendingNotesCmd.PersistentFlags().StringVar(&pendingNotesOpts.GitHubRepo, "github-repo", pendingNotesOpts.GitHubRepo, "GitHub repository containing the summary issue")
+	pendingNotesCmd.PersistentFlags().StringVar(&pendingNotesOpts.Branch, "branch", "", "release branch to summarize, for example release-1.31")
+	pendingNotesCmd.PersistentFlags().IntVar(&pendingNotesOpts.Issue, "issue", 0, "existing issue of the tracking repository to maintain the summary as comment on, instead of a dedicated issue")
+	pendingNotesCmd.PersistentFlags().StringVar(&pendingNotesOpts.RepoPath, "repo-path", "", "path to a local clone of kubernetes/kubernetes for gathering the release notes")
+	pendingNotesCmd.PersistentFlags().DurationVar(&pendingNotesOpts.Interval, "interval", 0, "keep updating the summary in this interval, it gets updated once if zero")
+
+	rootCmd.AddCommand(pendingNotesCmd)
+}
+
+func runPendingNotes(opts *pendingnotes.Options) error {
+	opts.NoMock = rootOpts.nomock
+	if err := pendingnotes.New(opts).Run(); err != nil {
+		return fmt.Errorf("updating pending release notes: %w", err)
+	}
+	return nil
+}
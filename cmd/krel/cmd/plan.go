@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/plan"
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/vulnscan"
+)
+
+var (
+	planOptions = anago.DefaultStageOptions()
+	planOutput  = plan.OutputText
+)
+
+// planCmd represents the subcommand for `krel plan`
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Print the ordered actions of a release before running it",
+	Long: `krel plan
+
+Resolves the options of a release, like the release type, branch and build
+version together with the global flags and the selected profile, into the
+ordered actions of the stage and release jobs as well as the announcement.
+Nothing gets executed, similar to 'terraform plan'.
+
+The plan contains the resolved versions, buckets, registries, built platforms
+and announcement recipients. Resolving the versions of a --build-version
+requires checking whether the release branch already exists on GitHub.
+Invalid options get reported as problems, which make the command fail.
+`,
+	Example:       "krel plan --type rc --branch release-1.30 --build-version v1.30.0-rc.0.34+a1b2c3d4e5f6a7",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPlan(planOptions, planOutput)
+	},
+}
+
+func init() {
+	planCmd.PersistentFlags().StringVar(
+		&planOptions.ReleaseType,
+		"type",
+		planOptions.ReleaseType,
+		fmt.Sprintf("The release type, must be one of: '%s'",
+			strings.Join([]string{
+				release.ReleaseTypeAlpha,
+				release.ReleaseTypeBeta,
+				release.ReleaseTypeRC,
+				release.ReleaseTypeOfficial,
+			}, "', '"),
+		),
+	)
+	planCmd.PersistentFlags().StringVar(&planOptions.ReleaseBranch, "branch", planOptions.ReleaseBranch, "The release branch for which the release should be build")
+	planCmd.PersistentFlags().StringVar(&planOptions.BuildVersion, buildVersionFlag, "", "The build version to be released, discovered from the latest CI build on submit if empty")
+	planCmd.PersistentFlags().StringVar(&planOptions.Commit, commitFlag, "", "Full SHA of the commit to be tagged instead of the head of the release branch")
+	planCmd.PersistentFlags().BoolVar(&planOptions.SkipCISignalCheck, "skip-ci-signal-check", false, "Do not verify the release blocking CI jobs before submitting the job")
+	planCmd.PersistentFlags().IntVar(&planOptions.AllowedFlakyJobs, "allowed-flaky-jobs", 0, "Number of flaky release blocking CI jobs which are tolerated")
+	planCmd.PersistentFlags().StringVar(
+		&planOptions.VulnerabilityScan,
+		"vulnerability-scan",
+		planOptions.VulnerabilityScan,
+		fmt.Sprintf("How vulnerabilities found in the staged images are handled, must be one of: '%s'",
+			strings.Join([]string{vulnscan.ModeFail, vulnscan.ModeWarn, vulnscan.ModeOff}, "', '"),
+		),
+	)
+	planCmd.PersistentFlags().Float64Var(&planOptions.SizeThreshold, "size-threshold", planOptions.SizeThreshold, "Growth in percent of an artifact since the previous release, from which on it gets reported")
+	addOutputFlag(planCmd.PersistentFlags(), &planOutput, plan.OutputText, plan.OutputJSON, plan.OutputYAML)
+
+	rootCmd.AddCommand(planCmd)
+}
+
+func runPlan(options *anago.StageOptions, output string) error {
+	options.NoMock = rootOpts.nomock
+
+	p, err := plan.New(options).Plan()
+	if err != nil {
+		return fmt.Errorf("resolve release plan: %w", err)
+	}
+	if err := p.Write(os.Stdout, output); err != nil {
+		return err
+	}
+	if !p.Valid() {
+		return fmt.Errorf("release plan has %d problems", len(p.Problems))
+	}
+	return nil
+}
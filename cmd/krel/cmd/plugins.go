@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/plugin"
+)
+
+var pluginManager = plugin.New()
+
+// pluginsCmd represents the subcommand for `krel plugins`
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "List the discovered krel plugins",
+	Long: `plugins lists all plugins discovered in the plugin directory, which
+defaults to ~/.krel/plugins and can be set via $KREL_PLUGIN_DIR.
+
+Every plugin is a sub directory containing a plugin.yaml manifest like:
+
+  name: my-distro
+  description: Publish the release to the my-distro mirror
+  command: ./run.sh
+  # Add the plugin as "krel my-distro" subcommand
+  subcommand: true
+  # Run the plugin before or after phases of the stage and release process
+  hooks:
+  - phase: push-artifacts
+    when: after
+
+Hooks get executed with the arguments "hook <before|after> <phase>" and the
+environment variables KREL_PLUGIN_PHASE and KREL_PLUGIN_WHEN. The phases are
+named like the steps of "krel stage" and "krel release", for example
+"prepare-workspace", "build" or "create-announcement". A failing hook fails
+the phase.
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(*cobra.Command, []string) error {
+		plugins := pluginManager.Plugins()
+		return writeOutput(os.Stdout, pluginsOutput, plugins, func() error {
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Name", "Subcommand", "Hooks", "Description"})
+			table.SetAutoWrapText(false)
+			for _, p := range plugins {
+				hooks := []string{}
+				for _, hook := range p.Hooks {
+					hooks = append(hooks, string(hook.When)+" "+hook.Phase)
+				}
+				subcommand := ""
+				if p.Subcommand {
+					subcommand = "krel " + p.Name
+				}
+				table.Append([]string{p.Name, subcommand, strings.Join(hooks, ", "), p.Description})
+			}
+			table.Render()
+			return nil
+		})
+	},
+}
+
+var pluginsOutput string
+
+func init() {
+	addOutputFlag(pluginsCmd.PersistentFlags(), &pluginsOutput)
+	rootCmd.AddCommand(pluginsCmd)
+}
+
+// loadPlugins discovers the plugins and registers their subcommands. It has
+// to run after all built-in subcommands got registered.
+func loadPlugins() {
+	dir := plugin.DefaultDir()
+	if dir == "" {
+		return
+	}
+	if err := pluginManager.Load(dir); err != nil {
+		logrus.Warnf("Unable to load plugins: %v", err)
+		return
+	}
+	plugin.SetDefault(pluginManager)
+
+	for _, p := range pluginManager.Plugins() {
+		if !p.Subcommand {
+			continue
+		}
+		if existing, _, err := rootCmd.Find([]string{p.Name}); err == nil && existing != rootCmd {
+			logrus.Warnf("Skipping subcommand of plugin %s because it conflicts with an existing command", p.Name)
+			continue
+		}
+
+		p := p
+		rootCmd.AddCommand(&cobra.Command{
+			Use:                p.Name,
+			Short:              p.Description,
+			DisableFlagParsing: true,
+			SilenceUsage:       true,
+			SilenceErrors:      true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return pluginManager.RunCommand(cmd.Context(), p, args)
+			},
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"k8s.io/release/pkg/build"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/sdk"
 )
 
 const pushCmdDescription = `
@@ -31,15 +32,23 @@ Used for pushing developer builds and Jenkins' continuous builds.
 Developer pushes simply run as they do pushing to devel/ on GCS.`
 
 const pushCmdExample = `
-krel push [--noupdatelatest] [--ci] [--bucket=<GCS bucket>] [--private-bucket]
+krel push [--noupdatelatest] [--ci] [--bucket=<GCS bucket>] [--private-bucket] [--components=<components>]
 
 Scenarios:
 
 krel push                                   - Do a developer push
 krel push --ci                              - Do a CI push
-krel push --bucket=kubernetes-release-$USER - Do a developer push to kubernetes-release-$USER`
+krel push --bucket=kubernetes-release-$USER - Do a developer push to kubernetes-release-$USER
+krel push --components=kubectl              - Push only the kubectl binaries and image
+krel push --version=v1.30.0 --source=gs://<bucket>/stage/<build-version>/v1.30.0/gcs-stage/v1.30.0
+                                            - Copy the artifacts server side from another bucket`
 
-var pushBuildOpts = &build.Options{}
+var (
+	pushBuildOpts = &build.Options{}
+
+	// pushComponents are the names of the components for a partial push.
+	pushComponents []string
+)
 
 var pushBuildCmd = &cobra.Command{
 	Use:           "push",
@@ -146,9 +155,39 @@ func init() {
 		"Validate that the remote image digests exists",
 	)
 
+	pushBuildCmd.PersistentFlags().StringVar(
+		&pushBuildOpts.Source,
+		"source",
+		"",
+		"Remote bucket path to copy the artifacts from instead of the local build directory, requires --version and does not push container images",
+	)
+
+	pushBuildCmd.PersistentFlags().StringVar(
+		&pushBuildOpts.Version,
+		"version",
+		"",
+		"Version to push, which is discovered from the local build if not set",
+	)
+
+	pushBuildCmd.PersistentFlags().StringSliceVar(
+		&pushComponents,
+		"components",
+		nil,
+		fmt.Sprintf(
+			"Comma separated list of components for a partial release, one or more of: %s (default all)",
+			release.Components(release.AllComponents()),
+		),
+	)
+
 	rootCmd.AddCommand(pushBuildCmd)
 }
 
 func runPushBuild(opts *build.Options) error {
-	return build.NewInstance(opts).Push()
+	components, err := release.ParseComponents(pushComponents)
+	if err != nil {
+		return fmt.Errorf("parse components: %w", err)
+	}
+	opts.Components = components
+
+	return sdk.New().Push(runContext(), opts)
 }
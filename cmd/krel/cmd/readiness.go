@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/readiness"
+)
+
+var (
+	readinessOpts   = readiness.DefaultOptions()
+	readinessOutput string
+)
+
+// readinessCmd represents the subcommand for `krel readiness`
+var readinessCmd = &cobra.Command{
+	Use:   "readiness --branch release-1.30 [--milestone v1.30] [-o json|yaml]",
+	Short: "Aggregate the release readiness signals into a scorecard for the go/no-go meeting",
+	Long: `readiness collects the following signals of a release into a single scorecard:
+
+- the green percentage of the release blocking CI dashboard of --branch,
+- the open release blocking issues and pull requests of the --milestone,
+- the open test flake issues of the --milestone,
+- the open documentation pull requests against the dev branch of the website,
+- the enhancements of the --milestone which got a freeze exception.
+
+Every check results in go, at-risk, no-go or unknown if the signal could not
+be retrieved. The scorecard is printed as markdown, or as JSON or YAML when
+using --output.
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReadiness(readinessOpts, readinessOutput)
+	},
+}
+
+func init() {
+	readinessCmd.PersistentFlags().StringVar(&readinessOpts.Branch, "branch", "", "release branch to generate the scorecard for, for example release-1.30")
+	readinessCmd.PersistentFlags().StringVar(&readinessOpts.Milestone, "milestone", "", "GitHub milestone of the release, defaults to v1.30 for release-1.30")
+	readinessCmd.PersistentFlags().StringVar(&readinessOpts.Org, "org", readinessOpts.Org, "GitHub organization of the repositories")
+	readinessCmd.PersistentFlags().StringVar(&readinessOpts.Repo, "repo", readinessOpts.Repo, "GitHub repository of the release blockers and test flakes")
+	readinessCmd.PersistentFlags().StringVar(&readinessOpts.DocsRepo, "docs-repo", readinessOpts.DocsRepo, "GitHub repository of the documentation pull requests")
+	readinessCmd.PersistentFlags().StringVar(&readinessOpts.EnhancementsRepo, "enhancements-repo", readinessOpts.EnhancementsRepo, "GitHub repository of the enhancements")
+	readinessCmd.PersistentFlags().StringVar(&readinessOpts.BlockerLabel, "blocker-label", readinessOpts.BlockerLabel, "label of the issues and pull requests blocking the release")
+	readinessCmd.PersistentFlags().StringVar(&readinessOpts.FlakeLabel, "flake-label", readinessOpts.FlakeLabel, "label of the issues tracking test flakes")
+	readinessCmd.PersistentFlags().StringVar(&readinessOpts.ExceptionLabel, "exception-label", readinessOpts.ExceptionLabel, "label of the enhancements which got a freeze exception")
+	readinessCmd.PersistentFlags().IntVar(&readinessOpts.AllowedFlakyJobs, "allowed-flaky-jobs", 0, "number of flaky release blocking CI jobs which are not counted as blocking")
+	addOutputFlag(readinessCmd.PersistentFlags(), &readinessOutput)
+
+	rootCmd.AddCommand(readinessCmd)
+}
+
+func runReadiness(opts *readiness.Options, output string) error {
+	card, err := readiness.New(opts).Run()
+	if err != nil {
+		return fmt.Errorf("generating readiness scorecard: %w", err)
+	}
+
+	return writeOutput(os.Stdout, output, card, func() error {
+		_, err := fmt.Fprint(os.Stdout, card.Markdown())
+		return err
+	})
+}
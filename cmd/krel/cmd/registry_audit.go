@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/registryaudit"
+)
+
+var (
+	registryAuditOpts   = registryaudit.DefaultOptions()
+	registryAuditOutput string
+)
+
+// registryAuditCmd represents the subcommand for `krel registry-audit`
+var registryAuditCmd = &cobra.Command{
+	Use:   "registry-audit --config <file>",
+	Short: "Verify that legacy registry paths resolve to registry.k8s.io",
+	Long: `registry-audit probes a list of legacy registry paths (k8s.gcr.io) and
+verifies that they redirect to (or alias) registry.k8s.io and resolve to the
+same image digests.
+
+The paths can be provided by using --paths or a YAML config file like:
+
+  paths:
+  - k8s.gcr.io/pause:3.9
+  - k8s.gcr.io/kube-apiserver:v1.26.0
+
+A markdown report of all probed paths is printed to stdout, or as JSON or
+YAML by using --output, and can be additionally written as JSON by using
+--report. The command fails if any of the
+paths does not resolve to the same digest as in the target registry.
+`,
+	Example:       "krel registry-audit --config legacy-paths.yaml --report audit.json",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRegistryAudit(registryAuditOpts, registryAuditOutput)
+	},
+}
+
+func init() {
+	registryAuditCmd.PersistentFlags().StringVar(&registryAuditOpts.ConfigFile, "config", "", "YAML file containing the list of legacy image paths to be probed")
+	registryAuditCmd.PersistentFlags().StringSliceVar(&registryAuditOpts.Paths, "paths", nil, "legacy image paths to be probed, for example k8s.gcr.io/pause:3.9")
+	registryAuditCmd.PersistentFlags().StringVar(&registryAuditOpts.LegacyRegistry, "legacy-registry", registryAuditOpts.LegacyRegistry, "the deprecated registry of the legacy paths")
+	registryAuditCmd.PersistentFlags().StringVar(&registryAuditOpts.TargetRegistry, "target-registry", registryAuditOpts.TargetRegistry, "the registry the legacy paths should resolve to")
+	registryAuditCmd.PersistentFlags().StringVar(&registryAuditOpts.ReportFile, "report", "", "optional path for writing the audit report as JSON")
+	addOutputFlag(registryAuditCmd.PersistentFlags(), &registryAuditOutput)
+
+	rootCmd.AddCommand(registryAuditCmd)
+}
+
+func runRegistryAudit(opts *registryaudit.Options, output string) error {
+	auditor := registryaudit.New(opts)
+	report, err := auditor.Audit()
+	if err != nil {
+		return err
+	}
+	if err := auditor.WriteReport(report); err != nil {
+		return err
+	}
+	if err := writeOutput(os.Stdout, output, report, func() error {
+		fmt.Print(report.Markdown())
+		return nil
+	}); err != nil {
+		return err
+	}
+	return auditor.Check(report)
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/approver"
+	"k8s.io/release/pkg/gcp/build"
 	"k8s.io/release/pkg/release"
 	"sigs.k8s.io/release-sdk/github"
 )
@@ -104,6 +106,38 @@ func init() {
 			"The build version to be released.",
 		)
 
+	releaseCmd.PersistentFlags().
+		StringVar(
+			&releaseOptions.Commit,
+			commitFlag,
+			"",
+			"Full SHA of the pinned commit of the staged build, which gets recorded on the GitHub release page",
+		)
+
+	releaseCmd.PersistentFlags().
+		StringVar(
+			&releaseOptions.PublishAt,
+			publishAtFlag,
+			"",
+			"RFC3339 time of an embargoed release, creates the GitHub release page as draft and holds back the version markers to be published by 'krel announce publish'",
+		)
+
+	releaseCmd.PersistentFlags().
+		StringSliceVar(
+			&releaseOptions.AllowAddedArtifacts,
+			"allow-added-artifacts",
+			releaseOptions.AllowAddedArtifacts,
+			"Path patterns of artifacts which are expected to be added compared to the previous release, other added artifacts fail the release",
+		)
+
+	releaseCmd.PersistentFlags().
+		StringSliceVar(
+			&releaseOptions.AllowRemovedArtifacts,
+			"allow-removed-artifacts",
+			releaseOptions.AllowRemovedArtifacts,
+			"Path patterns of artifacts which are expected to be removed compared to the previous release, other removed artifacts fail the release",
+		)
+
 	releaseCmd.PersistentFlags().
 		BoolVar(
 			&submitJob,
@@ -120,6 +154,38 @@ func init() {
 			"Run the Google Cloud Build job synchronously",
 		)
 
+	releaseCmd.PersistentFlags().
+		BoolVar(
+			&releaseOptions.NonInteractive,
+			nonInteractiveFlag,
+			false,
+			"Do not ask for confirmation before submitting a --nomock Google Cloud Build job",
+		)
+
+	releaseCmd.PersistentFlags().
+		BoolVar(
+			&releaseOptions.Local,
+			"local",
+			false,
+			"Run the steps of the Google Cloud Build job locally in containers, for debugging job changes in mock mode",
+		)
+
+	releaseCmd.PersistentFlags().
+		StringVar(
+			&releaseOptions.ContainerRuntime,
+			"container-runtime",
+			build.DefaultContainerRuntime,
+			"The container runtime used for running --local jobs, for example docker or podman",
+		)
+
+	releaseCmd.PersistentFlags().
+		StringVar(
+			&releaseOptions.CustodyKey,
+			"custody-key",
+			"",
+			"Path to the private in-toto key of the functionary, which records the chain of custody links of the push, sign and promote steps next to the staged build",
+		)
+
 	if err := releaseCmd.PersistentFlags().MarkHidden(submitJobFlag); err != nil {
 		logrus.Fatal(err)
 	}
@@ -133,6 +199,8 @@ func init() {
 
 func runRelease(options *anago.ReleaseOptions) error {
 	options.NoMock = rootOpts.nomock
+	options.AllowAddedArtifacts = splitSubstitution(options.AllowAddedArtifacts)
+	options.AllowRemovedArtifacts = splitSubstitution(options.AllowRemovedArtifacts)
 	rel := anago.NewRelease(options)
 
 	if submitJob {
@@ -141,7 +209,12 @@ func runRelease(options *anago.ReleaseOptions) error {
 		if err := options.Validate(&anago.State{}); err != nil {
 			return fmt.Errorf("prechecking release options: %w", err)
 		}
-		return rel.Submit(stream)
+		if options.NoMock {
+			if err := approver.Check("release", options.ReleaseBranch); err != nil {
+				return err
+			}
+		}
+		return rel.SubmitContext(runContext(), stream)
 	}
-	return rel.Run()
+	return rel.RunContext(runContext())
 }
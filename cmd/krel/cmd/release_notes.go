@@ -889,7 +889,7 @@ func releaseNotesJSON(repoPath, tag string) (jsonString string, err error) {
 	}
 
 	// Fetch the notes
-	releaseNotes, err := notes.GatherReleaseNotes(notesOptions)
+	releaseNotes, err := notes.GatherReleaseNotesContext(runContext(), notesOptions)
 	if err != nil {
 		return "", fmt.Errorf("gathering release notes: %w", err)
 	}
@@ -934,7 +934,7 @@ func gatherNotesFrom(repoPath, startTag string) (*notes.ReleaseNotes, error) {
 	logrus.Infof("Using end tag %v", releaseNotesOpts.tag)
 
 	// Fetch the notes
-	releaseNotes, err := notes.GatherReleaseNotes(notesOptions)
+	releaseNotes, err := notes.GatherReleaseNotesContext(runContext(), notesOptions)
 	if err != nil {
 		return nil, fmt.Errorf("gathering release notes: %w", err)
 	}
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/feed"
+	"k8s.io/release/pkg/notes/options"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/github"
+)
+
+// releaseNotesFeedCmd represents the subcommand for `krel release-notes feed`
+var releaseNotesFeedCmd = &cobra.Command{
+	Use:   "feed",
+	Short: "Write an Atom or RSS feed of the unreleased notes of a release branch",
+	Long: `krel release-notes feed
+
+Gathers the release notes of all pull requests merged into a release branch
+since its latest patch release and writes them as Atom or RSS feed, one entry
+per pull request. Publishing the feed allows downstream users to monitor the
+incoming changes for the next patch release.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReleaseNotesFeed(releaseNotesFeedOpts)
+	},
+}
+
+type releaseNotesFeedOptions struct {
+	branch string
+	output string
+	format string
+}
+
+var releaseNotesFeedOpts = &releaseNotesFeedOptions{}
+
+func init() {
+	releaseNotesFeedCmd.PersistentFlags().StringVarP(
+		&releaseNotesFeedOpts.branch,
+		"branch",
+		"b",
+		"",
+		"release branch of the feed, for example release-1.30",
+	)
+
+	releaseNotesFeedCmd.PersistentFlags().StringVarP(
+		&releaseNotesFeedOpts.output,
+		"output",
+		"o",
+		"",
+		"file to write the feed to, defaults to stdout",
+	)
+
+	releaseNotesFeedCmd.PersistentFlags().StringVar(
+		&releaseNotesFeedOpts.format,
+		"format",
+		string(feed.FormatAtom),
+		fmt.Sprintf("format of the feed (%s or %s)", feed.FormatAtom, feed.FormatRSS),
+	)
+
+	releaseNotesCmd.AddCommand(releaseNotesFeedCmd)
+}
+
+// Validate checks if passed cmdline options are sane
+func (o *releaseNotesFeedOptions) Validate() error {
+	if token, isset := os.LookupEnv(github.TokenEnvKey); !isset || token == "" {
+		return fmt.Errorf("cannot generate release notes if %s env variable is not set", github.TokenEnvKey)
+	}
+	if o.branch == "" {
+		return errors.New("no release branch specified via --branch")
+	}
+	if !git.IsReleaseBranch(o.branch) {
+		return fmt.Errorf("%s is not a release branch", o.branch)
+	}
+	switch feed.Format(o.format) {
+	case feed.FormatAtom, feed.FormatRSS:
+	default:
+		return fmt.Errorf("invalid feed format %q", o.format)
+	}
+	return nil
+}
+
+func runReleaseNotesFeed(opts *releaseNotesFeedOptions) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("validating command line options: %w", err)
+	}
+
+	notesOptions := options.New()
+	notesOptions.Branch = opts.branch
+	notesOptions.RepoPath = releaseNotesOpts.repoPath
+	notesOptions.DiscoverMode = options.RevisionDiscoveryModePatchToLatest
+	notesOptions.Debug = logrus.StandardLogger().Level >= logrus.DebugLevel
+	notesOptions.MapProviderStrings = releaseNotesOpts.mapProviders
+	notesOptions.ListReleaseNotesV2 = releaseNotesOpts.listReleaseNotesV2
+	notesOptions.AddMarkdownLinks = true
+
+	if err := notesOptions.ValidateAndFinish(); err != nil {
+		return err
+	}
+	logrus.Infof(
+		"Gathering release notes of %s from %s", opts.branch, notesOptions.StartRev,
+	)
+
+	releaseNotes, err := notes.GatherReleaseNotesContext(runContext(), notesOptions)
+	if err != nil {
+		return fmt.Errorf("gathering release notes: %w", err)
+	}
+
+	out, err := renderReleaseNotesFeed(
+		opts, notesOptions.StartRev, releaseNotes, time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "" {
+		_, err := os.Stdout.Write(out)
+		return err
+	}
+	if err := os.WriteFile(opts.output, out, 0o644); err != nil {
+		return fmt.Errorf("writing feed: %w", err)
+	}
+	logrus.Infof(
+		"Wrote feed with %d release notes to %s",
+		len(releaseNotes.History()), opts.output,
+	)
+	return nil
+}
+
+// renderReleaseNotesFeed renders the release notes merged into the branch
+// after the startRev.
+func renderReleaseNotesFeed(
+	opts *releaseNotesFeedOptions, startRev string,
+	releaseNotes *notes.ReleaseNotes, updated time.Time,
+) ([]byte, error) {
+	product := branding.Default().ProductName
+	f := feed.New(
+		fmt.Sprintf("%s %s release notes", product, opts.branch),
+		fmt.Sprintf(
+			"https://github.com/%s/%s/tree/%s",
+			git.DefaultGithubOrg, git.DefaultGithubRepo, opts.branch,
+		),
+		fmt.Sprintf(
+			"Release notes of %s merged into %s since %s",
+			product, opts.branch, startRev,
+		),
+		releaseNotes,
+		updated,
+	)
+
+	out, err := f.Render(feed.Format(opts.format))
+	if err != nil {
+		return nil, fmt.Errorf("rendering feed: %w", err)
+	}
+	return out, nil
+}
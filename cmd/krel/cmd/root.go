@@ -17,13 +17,42 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"k8s.io/release/pkg/approver"
+	"k8s.io/release/pkg/baseimage"
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/budget"
+	"k8s.io/release/pkg/config"
+	"k8s.io/release/pkg/freeze"
+	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/ghauth"
+	"k8s.io/release/pkg/ghusage"
+	"k8s.io/release/pkg/gitclone"
+	"k8s.io/release/pkg/imagerewrite"
+	"k8s.io/release/pkg/knownissues"
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/logging"
+	"k8s.io/release/pkg/metrics"
+	"k8s.io/release/pkg/network"
+	"k8s.io/release/pkg/platforms"
+	"k8s.io/release/pkg/progress"
+	"k8s.io/release/pkg/signkey"
+	"k8s.io/release/pkg/tagscheme"
+	"k8s.io/release/pkg/tracing"
+	"k8s.io/release/pkg/workdir"
 	"sigs.k8s.io/release-utils/log"
-	"sigs.k8s.io/release-utils/version"
+	"sigs.k8s.io/release-utils/util"
 )
 
 const (
@@ -44,12 +73,14 @@ pushing the Kubernetes release artifacts to the Google Cloud Storage.
 
 Each subcommand should contain its own self describing help output which
 clarifies its purpose.`,
-	PersistentPreRunE: initLogging,
+	PersistentPreRunE: initRoot,
 }
 
 type rootOptions struct {
-	nomock   bool
-	logLevel string
+	nomock     bool
+	logLevel   string
+	configFile string
+	profile    string
 }
 
 var rootOpts = &rootOptions{}
@@ -57,11 +88,170 @@ var rootOpts = &rootOptions{}
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	loadPlugins()
+	registerCompletions(rootCmd)
+
+	ctx, cancel := signalContext()
+	defer cancel()
+	cancelRun = cancel
+
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+
+	// Flushing has to work even if the run context got cancelled.
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer flushCancel()
+
+	if metricsOpts.Enabled() {
+		metrics.ObserveRun(cmd.CommandPath(), time.Since(start), err)
+		if pushErr := metrics.New(metricsOpts).Push(flushCtx); pushErr != nil {
+			logrus.Warnf("Unable to push metrics: %v", pushErr)
+		}
+	}
+	if shutdownErr := shutdownTracing(flushCtx); shutdownErr != nil {
+		logrus.Warnf("Unable to export remaining traces: %v", shutdownErr)
+	}
+	if cleanupErr := workdir.Cleanup(); cleanupErr != nil {
+		logrus.Warnf("Unable to remove temporary files: %v", cleanupErr)
+	}
+	if tracker := ghusage.Default(); tracker != nil && tracker.Total() > 0 {
+		if reportErr := tracker.Report(os.Stderr); reportErr != nil {
+			logrus.Warnf("Unable to report GitHub API usage: %v", reportErr)
+		}
+	}
+	if err != nil {
+		flushCancel()
 		logrus.Fatal(err)
 	}
 }
 
+// flushTimeout is the maximum time for flushing metrics and traces on exit.
+const flushTimeout = 30 * time.Second
+
+// signalContext returns a context which gets cancelled on the first SIGINT
+// or SIGTERM. A second signal exits the process immediately.
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	stopped := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-signals:
+			logrus.Warnf(
+				"Received %s, stopping in-flight operations. Send it again to exit immediately",
+				sig,
+			)
+			cancel()
+		case <-stopped:
+			return
+		}
+
+		select {
+		case sig := <-signals:
+			logrus.Warnf("Received %s again, exiting", sig)
+			os.Exit(signalExitCode(sig))
+		case <-stopped:
+		}
+	}()
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(stopped)
+			cancel()
+		})
+	}
+}
+
+// signalExitCode returns the conventional exit code of a process terminated
+// by the provided signal.
+func signalExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
+
+// runContext returns the context of the current run, which gets cancelled
+// on termination.
+func runContext() context.Context {
+	if ctx := rootCmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+var (
+	// loggingOpts are the options of the global logger.
+	loggingOpts = logging.DefaultOptions()
+
+	// tracingOpts are the options of the global tracer.
+	tracingOpts = tracing.DefaultOptions()
+
+	// metricsOpts are the options for pushing the run metrics.
+	metricsOpts = metrics.DefaultOptions()
+
+	// networkOpts are the options of the shared HTTP transport.
+	networkOpts = network.DefaultOptions()
+
+	// layoutOpts are the options of the artifact layout policy.
+	layoutOpts = layout.DefaultOptions()
+
+	// brandingOpts are the options of the downstream rebranding.
+	brandingOpts = branding.DefaultOptions()
+
+	// tagSchemeOpts are the options of the release tag format.
+	tagSchemeOpts = tagscheme.DefaultOptions()
+
+	// signKeyOpts are the options of the signing key custody.
+	signKeyOpts = signkey.DefaultOptions()
+
+	// imageRewriteOpts are the options of the announced image registries.
+	imageRewriteOpts = imagerewrite.DefaultOptions()
+
+	// progressOpts are the options of the progress rendering.
+	progressOpts = progress.DefaultOptions()
+
+	// budgetOpts are the options of the phase timeout budget.
+	budgetOpts = budget.DefaultOptions()
+
+	// workdirOpts are the options of the temporary directories.
+	workdirOpts = workdir.DefaultOptions()
+
+	// knownIssuesOpts are the options of the changelog known issues.
+	knownIssuesOpts = knownissues.DefaultOptions()
+
+	// ghusageOpts are the options of the GitHub API usage tracking.
+	ghusageOpts = ghusage.DefaultOptions()
+
+	// platformsOpts are the options of the built platform matrix.
+	platformsOpts = platforms.DefaultOptions()
+
+	// baseImageOpts are the options of the base image policy.
+	baseImageOpts = baseimage.DefaultOptions()
+
+	// ghauthOpts are the GitHub App authentication options.
+	ghauthOpts = ghauth.DefaultOptions()
+
+	// gitcloneOpts are the shallow and partial clone options.
+	gitcloneOpts = gitclone.DefaultOptions()
+
+	// freezeOpts are the release freeze enforcement options.
+	freezeOpts = freeze.DefaultOptions()
+
+	// approverOpts are the release manager approval options.
+	approverOpts = approver.DefaultOptions()
+
+	// shutdownTracing flushes the remaining spans on exit.
+	shutdownTracing = func(context.Context) error { return nil }
+
+	// cancelRun cancels the context of the run.
+	cancelRun = func() {}
+)
+
 func init() {
 	rootCmd.PersistentFlags().BoolVar(
 		&rootOpts.nomock,
@@ -77,9 +267,180 @@ func init() {
 		fmt.Sprintf("the logging verbosity, either %s", log.LevelNames()),
 	)
 
-	rootCmd.AddCommand(version.WithFont("slant"))
+	rootCmd.PersistentFlags().StringVar(
+		&rootOpts.configFile,
+		"config",
+		"",
+		fmt.Sprintf("the configuration file containing flag defaults and profiles (default $%s or ~/%s)", config.PathEnvKey, config.DefaultFileName),
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&rootOpts.profile,
+		"profile",
+		"",
+		fmt.Sprintf("the profile of the configuration file to be used (default $%s or the profile set in the file)", config.ProfileEnvKey),
+	)
+
+	loggingOpts.AddFlags(rootCmd.PersistentFlags())
+	tracingOpts.AddFlags(rootCmd.PersistentFlags())
+	metricsOpts.AddFlags(rootCmd.PersistentFlags())
+	networkOpts.AddFlags(rootCmd.PersistentFlags())
+	layoutOpts.AddFlags(rootCmd.PersistentFlags())
+	brandingOpts.AddFlags(rootCmd.PersistentFlags())
+	tagSchemeOpts.AddFlags(rootCmd.PersistentFlags())
+	signKeyOpts.AddFlags(rootCmd.PersistentFlags())
+	imageRewriteOpts.AddFlags(rootCmd.PersistentFlags())
+	progressOpts.AddFlags(rootCmd.PersistentFlags())
+	budgetOpts.AddFlags(rootCmd.PersistentFlags())
+	workdirOpts.AddFlags(rootCmd.PersistentFlags())
+	knownIssuesOpts.AddFlags(rootCmd.PersistentFlags())
+	ghusageOpts.AddFlags(rootCmd.PersistentFlags())
+	platformsOpts.AddFlags(rootCmd.PersistentFlags())
+	baseImageOpts.AddFlags(rootCmd.PersistentFlags())
+	ghauthOpts.AddFlags(rootCmd.PersistentFlags())
+	gitcloneOpts.AddFlags(rootCmd.PersistentFlags())
+	freezeOpts.AddFlags(rootCmd.PersistentFlags())
+	approverOpts.AddFlags(rootCmd.PersistentFlags())
+
+	rootCmd.AddCommand(newVersionCmd())
+}
+
+func initRoot(cmd *cobra.Command, args []string) error {
+	if err := initConfig(cmd, args); err != nil {
+		return err
+	}
+	if err := initLogging(cmd, args); err != nil {
+		return err
+	}
+	if err := network.Setup(networkOpts); err != nil {
+		return fmt.Errorf("setup network: %w", err)
+	}
+	if err := layout.Setup(layoutOpts); err != nil {
+		return fmt.Errorf("setup layout policy: %w", err)
+	}
+	if err := branding.Setup(brandingOpts); err != nil {
+		return fmt.Errorf("setup branding: %w", err)
+	}
+	if err := tagscheme.Setup(tagSchemeOpts); err != nil {
+		return fmt.Errorf("setup tag scheme: %w", err)
+	}
+	if err := signkey.Setup(signKeyOpts); err != nil {
+		return fmt.Errorf("setup signing key: %w", err)
+	}
+	if err := imagerewrite.Setup(imageRewriteOpts); err != nil {
+		return fmt.Errorf("setup image rewrites: %w", err)
+	}
+	if err := progress.Setup(progressOpts); err != nil {
+		return fmt.Errorf("setup progress: %w", err)
+	}
+	budgetOpts.Timeouts = splitSubstitution(budgetOpts.Timeouts)
+	if err := budget.Setup(budgetOpts); err != nil {
+		return fmt.Errorf("setup phase timeouts: %w", err)
+	}
+	if err := workdir.Setup(workdirOpts); err != nil {
+		return fmt.Errorf("setup temporary directories: %w", err)
+	}
+	knownIssuesOpts.Labels = splitSubstitution(knownIssuesOpts.Labels)
+	knownissues.Setup(knownIssuesOpts)
+	if err := ghauth.Setup(ghauthOpts); err != nil {
+		return fmt.Errorf("setup GitHub App authentication: %w", err)
+	}
+	if err := ghusage.Setup(ghusageOpts); err != nil {
+		return fmt.Errorf("setup GitHub API usage tracking: %w", err)
+	}
+	ghusage.Default().OnExhausted(cancelRun)
+	platformsOpts.Platforms = splitSubstitution(platformsOpts.Platforms)
+	platformsOpts.ExtraPlatforms = splitSubstitution(platformsOpts.ExtraPlatforms)
+	if err := platforms.Setup(platformsOpts); err != nil {
+		return fmt.Errorf("setup platform matrix: %w", err)
+	}
+	if err := baseimage.Setup(baseImageOpts); err != nil {
+		return fmt.Errorf("setup base image policy: %w", err)
+	}
+	if err := gitclone.Setup(gitcloneOpts); err != nil {
+		return fmt.Errorf("setup git clone options: %w", err)
+	}
+	if err := freeze.Setup(freezeOpts); err != nil {
+		return fmt.Errorf("setup release freeze: %w", err)
+	}
+	approverOpts.Rules = splitSubstitution(approverOpts.Rules)
+	approverOpts.TrustedIdentities = splitSubstitution(approverOpts.TrustedIdentities)
+	if err := approver.Setup(approverOpts); err != nil {
+		return fmt.Errorf("setup release manager approvals: %w", err)
+	}
+	if err := initTracing(cmd, args); err != nil {
+		return err
+	}
+	metrics.Setup(metricsOpts)
+	return nil
+}
+
+func initConfig(cmd *cobra.Command, _ []string) error {
+	explicitConfig := rootOpts.configFile != "" || os.Getenv(config.PathEnvKey) != ""
+	if rootOpts.configFile == "" {
+		rootOpts.configFile = config.DefaultPath()
+	}
+	if rootOpts.profile == "" {
+		rootOpts.profile = os.Getenv(config.ProfileEnvKey)
+	}
+	if !util.Exists(rootOpts.configFile) {
+		if explicitConfig {
+			return fmt.Errorf("config file %s does not exist", rootOpts.configFile)
+		}
+		if rootOpts.profile != "" {
+			return fmt.Errorf("profile %q selected but no config file found", rootOpts.profile)
+		}
+		return nil
+	}
+
+	cfg, err := config.Load(rootOpts.configFile)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	resolved, err := cfg.Resolve(rootOpts.profile, command)
+	if err != nil {
+		return fmt.Errorf("resolve config: %w", err)
+	}
+	if resolved.Profile != "" {
+		logrus.Infof("Using profile %q from %s", resolved.Profile, rootOpts.configFile)
+	}
+	if resolved.Credentials != nil {
+		logrus.Infof("Isolating the credentials of profile %q", resolved.Profile)
+	}
+
+	if err := resolved.ApplyFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("apply config: %w", err)
+	}
+	if err := resolved.ApplyTokens(); err != nil {
+		return fmt.Errorf("apply config tokens: %w", err)
+	}
+	return nil
+}
+
+// splitSubstitution splits a single value separated by the string slice
+// separator, which is used for passing lists as GCB substitutions.
+func splitSubstitution(values []string) []string {
+	if len(values) != 1 {
+		return values
+	}
+	if values[0] == "" {
+		return nil
+	}
+	return strings.Split(values[0], gcb.StringSliceSeparator)
 }
 
 func initLogging(*cobra.Command, []string) error {
-	return log.SetupGlobalLogger(rootOpts.logLevel)
+	loggingOpts.Level = rootOpts.logLevel
+	return logging.Setup(loggingOpts)
+}
+
+func initTracing(*cobra.Command, []string) error {
+	shutdown, err := tracing.Setup(tracingOpts)
+	if err != nil {
+		return fmt.Errorf("setup tracing: %w", err)
+	}
+	shutdownTracing = shutdown
+	return nil
 }
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/chatops"
+	"k8s.io/release/pkg/server"
+)
+
+var (
+	serveOpts   = server.DefaultOptions()
+	chatopsOpts = chatops.DefaultOptions()
+)
+
+// serveOperations are the krel operations exposed by `krel serve`.
+var serveOperations = []*server.Operation{
+	{
+		Name:        "stage",
+		Description: "Submit a stage job to Google Cloud Build",
+		Args:        []string{"stage", "--" + submitJobFlag, "--" + nonInteractiveFlag},
+		Parameters:  []string{"type", "branch", buildVersionFlag, commitFlag, "skip-ci-signal-check", "nomock"},
+	},
+	{
+		Name:        "release",
+		Description: "Submit a release job to Google Cloud Build",
+		Args:        []string{"release", "--" + submitJobFlag, "--" + nonInteractiveFlag},
+		Parameters:  []string{"type", "branch", buildVersionFlag, commitFlag, "nomock"},
+	},
+	{
+		Name:        "announce",
+		Description: "Send the release announcement",
+		Args:        []string{"announce", "send", "--" + nonInteractiveFlag},
+		Parameters:  []string{tagFlag, nameFlag, emailFlag, printOnlyFlag, "nomock"},
+	},
+	{
+		Name:        "notes",
+		Description: "Update the release notes draft",
+		Args:        []string{"release-notes", "--create-draft-pr", "--interactiveMode=false"},
+		Parameters:  []string{"tag", "fork"},
+	},
+}
+
+// serveCmd represents the subcommand for `krel serve`
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve release operations via an authenticated REST API",
+	Long: `serve exposes the stage, release, announce and notes operations via a REST
+API, which allows web UIs or ChatOps bots to drive releases.
+
+All API requests require the bearer token set via $KREL_SERVE_TOKEN. The API
+only listens on the loopback interface by default, --tls-cert-file and
+--tls-key-file serve it via HTTPS when listening on other addresses. Every
+operation runs as asynchronous, non-interactive krel job, which can be
+tracked via the API:
+
+  GET  /healthz                  health check without authentication
+  GET  /api/v1/operations        list the available operations and parameters
+  POST /api/v1/jobs              start a job, for example:
+                                 {"operation": "stage", "parameters": {"type": "rc", "branch": "release-1.30"}}
+  GET  /api/v1/jobs              list all jobs
+  GET  /api/v1/jobs/<id>         get the status of a job
+  GET  /api/v1/jobs/<id>/logs    get the output of a job
+
+Running jobs get interrupted when the server shuts down.
+
+If --slack-config is set, the Slack slash command endpoint gets served at
+/slack/commands. It requires the signing secret and bot token of the Slack app
+via $SLACK_SIGNING_SECRET and $SLACK_BOT_TOKEN. The config file maps
+operations, including "status", to the Slack user IDs allowed to run them:
+
+  permissions:
+    stage: [U012AB3CD]
+    status: [U012AB3CD]
+    "*": [U045EF6GH]   # allowed to run all operations
+
+Operations started from Slack require a confirmation by the same user within
+five minutes and post their progress into a thread of the channel:
+
+  /release stage release-1.31 rc [--nomock]
+  /release release release-1.31 rc v1.31.0-rc.1 [--nomock]
+  /release announce v1.31.0-rc.1 [--nomock]
+  /release notes v1.31.0-rc.1
+  /release status <job-id>
+  /release confirm <code>
+`,
+	Example:       "KREL_SERVE_TOKEN=$(cat token) krel serve --address :8443 --tls-cert-file tls.crt --tls-key-file tls.key",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runServe(cmd)
+	},
+}
+
+func runServe(cmd *cobra.Command) error {
+	srv := server.New(serveOpts, serveOperations...)
+
+	if chatopsOpts.Enabled() {
+		bot := chatops.New(cmd.Context(), chatopsOpts, srv)
+		if err := bot.Init(); err != nil {
+			return fmt.Errorf("init Slack integration: %w", err)
+		}
+		srv.Handle(chatops.Path, bot)
+	}
+
+	return srv.Run(cmd.Context())
+}
+
+func init() {
+	serveCmd.PersistentFlags().StringVar(&serveOpts.Address, "address", serveOpts.Address, "the listen address of the API server")
+	serveCmd.PersistentFlags().StringVar(&serveOpts.TLSCertFile, "tls-cert-file", "", "path to the TLS certificate for serving the API via HTTPS")
+	serveCmd.PersistentFlags().StringVar(&serveOpts.TLSKeyFile, "tls-key-file", "", "path to the TLS private key for serving the API via HTTPS")
+	serveCmd.PersistentFlags().StringVar(&chatopsOpts.ConfigFile, "slack-config", "", "path to the Slack permissions config, enables the slash command endpoint")
+	serveCmd.PersistentFlags().DurationVar(&chatopsOpts.PollInterval, "slack-poll-interval", chatopsOpts.PollInterval, "interval for posting job progress updates to Slack")
+
+	rootCmd.AddCommand(serveCmd)
+}
@@ -17,10 +17,16 @@ limitations under the License.
 package cmd
 
 import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/nozzle/throttler"
@@ -30,6 +36,12 @@ import (
 	"sigs.k8s.io/release-sdk/gcli"
 	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-sdk/sign"
+	"sigs.k8s.io/release-utils/env"
+
+	"k8s.io/release/pkg/progress"
+	"k8s.io/release/pkg/signkey"
+	"k8s.io/release/pkg/tsa"
+	"k8s.io/release/pkg/workdir"
 )
 
 const (
@@ -40,6 +52,8 @@ const (
 	certIdentityRegexpFlag   = "certificate-identity-regexp"
 	certOidcIssuerFlag       = "certificate-oidc-issuer"
 	certOidcIssuerRegexpFlag = "certificate-oidc-issuer-regexp"
+	tsaURLFlag               = "tsa-url"
+	manifestFlag             = "manifest"
 	sigExt                   = ".sig"
 	certExt                  = ".cert"
 )
@@ -54,6 +68,10 @@ type signBlobOptions struct {
 	certOidcIssuerRegexp string
 	certIdentity         string
 	certIdentityRegexp   string
+
+	tsaURL string
+
+	manifestPath string
 }
 
 type signingBundle struct {
@@ -62,12 +80,41 @@ type signingBundle struct {
 	fileLocalLocation     string
 }
 
+// signingManifestEntry is a single signed file of the signing manifest.
+type signingManifestEntry struct {
+	// File is the location of the signed file.
+	File string `json:"file"`
+
+	// Digest is the SHA256 digest of the signed file.
+	Digest string `json:"digest"`
+
+	// Signature is the location of the signature.
+	Signature string `json:"signature"`
+
+	// Certificate is the location of the signing certificate, which is empty
+	// when signing with a private key.
+	Certificate string `json:"certificate,omitempty"`
+
+	// Identity is the subject of the signing certificate, like an email
+	// address or a workflow URI.
+	Identity string `json:"identity,omitempty"`
+}
+
 var signBlobOpts = &signBlobOptions{}
 
 // signBlobCmd represents the subcommand for `krel sign blobs`
 var signBlobCmd = &cobra.Command{
-	Use:           "blobs",
-	Short:         "Sign blobs",
+	Use:   "blobs <gs://bucket/path | file | directory | glob>...",
+	Short: "Sign blobs",
+	Long: `blobs signs the provided files or the contents of a GCS bucket path.
+
+Local directories get signed recursively and glob patterns are expanded,
+while existing signatures, certificates and timestamps are skipped. All files
+are signed concurrently, and --manifest writes a JSON signing manifest listing
+the digest, signature, certificate and certificate identity of every signed
+file.`,
+	Example: `krel sign blobs --manifest signing-manifest.json ./artifacts 'extras/*.tar.gz'
+krel sign blobs gs://kubernetes-release/release/v1.30.0`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -89,7 +136,7 @@ func init() {
 		privateKeyPathFlag,
 		"",
 		"",
-		"path for the cosign private key",
+		"path for the cosign private key, overrides the global --signing-key",
 	)
 
 	signBlobCmd.PersistentFlags().StringVarP(
@@ -132,6 +179,20 @@ func init() {
 		"A regular expression alternative to --certificate-oidc-issuer. Accepts the Go regular expression syntax described at https://golang.org/s/re2syntax. Either --certificate-oidc-issuer or --certificate-oidc-issuer-regexp must be set for keyless flows.",
 	)
 
+	signBlobCmd.PersistentFlags().StringVar(
+		&signBlobOpts.tsaURL,
+		tsaURLFlag,
+		env.Default(tsa.URLEnvKey, ""),
+		fmt.Sprintf("URL of a RFC 3161 time stamping authority to timestamp the signatures and checksum manifests, can be set via %s as well", tsa.URLEnvKey),
+	)
+
+	signBlobCmd.PersistentFlags().StringVar(
+		&signBlobOpts.manifestPath,
+		manifestFlag,
+		"",
+		"write a JSON manifest of all signed files to the set path",
+	)
+
 	signCmd.AddCommand(signBlobCmd)
 }
 
@@ -140,6 +201,13 @@ func runSignBlobs(signOpts *signOptions, signBlobOpts *signBlobOptions, args []s
 		return fmt.Errorf("blobs to be signed does not exist: %w", err)
 	}
 
+	if !strings.HasPrefix(args[0], object.GcsPrefix) {
+		args, err = expandBlobArgs(args)
+		if err != nil {
+			return fmt.Errorf("expanding blobs to be signed: %w", err)
+		}
+	}
+
 	var tempDir string
 	defer func() {
 		if tempDir != "" {
@@ -147,13 +215,16 @@ func runSignBlobs(signOpts *signOptions, signBlobOpts *signBlobOptions, args []s
 		}
 	}()
 
-	var bundle []signingBundle
+	var (
+		bundle    []signingBundle
+		manifests []signingBundle
+	)
 	isGCSBucket := false
 	if strings.HasPrefix(args[0], object.GcsPrefix) {
 		// GCS Bucket remote location
 		isGCSBucket = true
 
-		tempDir, err = os.MkdirTemp("", "release-sign-blobs-")
+		tempDir, err = workdir.MkdirTemp("release-sign-blobs-")
 		if err != nil {
 			return fmt.Errorf("creating a temporary directory to save the files to be signed: %w", err)
 		}
@@ -166,6 +237,21 @@ func runSignBlobs(signOpts *signOptions, signBlobOpts *signBlobOptions, args []s
 
 		gcsClient := object.NewGCS()
 		for _, file := range strings.Fields(output) {
+			destinationPath := strings.TrimPrefix(file, object.GcsPrefix)
+			localPath := filepath.Join(tempDir, filepath.Dir(destinationPath), filepath.Base(destinationPath))
+
+			if base := filepath.Base(file); signBlobOpts.tsaURL != "" && (base == "SHA256SUMS" || base == "SHA512SUMS") {
+				if err := gcsClient.CopyToLocal(file, localPath); err != nil {
+					return fmt.Errorf("copying checksum manifest to timestamp: %w", err)
+				}
+				manifests = append(manifests, signingBundle{
+					destinationPathToCopy: filepath.Dir(destinationPath),
+					fileToSign:            base,
+					fileLocalLocation:     localPath,
+				})
+				continue
+			}
+
 			if strings.HasSuffix(file, ".sha256") || strings.HasSuffix(file, ".sha512") ||
 				strings.HasSuffix(file, ":") || strings.HasSuffix(file, ".docker_tag") ||
 				strings.Contains(file, "SHA256SUMS") || strings.Contains(file, "SHA512SUMS") ||
@@ -174,8 +260,6 @@ func runSignBlobs(signOpts *signOptions, signBlobOpts *signBlobOptions, args []s
 				continue
 			}
 
-			destinationPath := strings.TrimPrefix(file, object.GcsPrefix)
-			localPath := filepath.Join(tempDir, filepath.Dir(destinationPath), filepath.Base(destinationPath))
 			if err := gcsClient.CopyToLocal(file, localPath); err != nil {
 				return fmt.Errorf("copying file to sign: %w", err)
 			}
@@ -196,15 +280,19 @@ func runSignBlobs(signOpts *signOptions, signBlobOpts *signBlobOptions, args []s
 		}
 	}
 
+	signed := make([]*sign.SignedFile, len(bundle))
+	bar := progress.NewBar("Signing blobs", len(bundle))
 	t := throttler.New(int(signOpts.maxWorkers), len(bundle))
-	for _, fileBundle := range bundle {
-		go func(fileBundle signingBundle) {
+	for i, fileBundle := range bundle {
+		go func(i int, fileBundle signingBundle) {
 			logrus.Infof("Signing %s...", fileBundle.fileToSign)
-			signerOpts := sign.Default()
+			signerOpts := signkey.Default().SignOptions()
 			signerOpts.Verbose = signOpts.verbose
 			signerOpts.Timeout = signOpts.timeout
-			signerOpts.PrivateKeyPath = signBlobOpts.privateKeyPath
-			signerOpts.PublicKeyPath = signBlobOpts.publicKeyPath
+			if signBlobOpts.privateKeyPath != "" {
+				signerOpts.PrivateKeyPath = signBlobOpts.privateKeyPath
+				signerOpts.PublicKeyPath = signBlobOpts.publicKeyPath
+			}
 
 			signerOpts.OutputCertificatePath = fmt.Sprintf("%s/%s%s", signBlobOpts.outputPath, fileBundle.fileToSign, certExt)
 			signerOpts.OutputSignaturePath = fmt.Sprintf("%s/%s%s", signBlobOpts.outputPath, fileBundle.fileToSign, sigExt)
@@ -219,21 +307,31 @@ func runSignBlobs(signOpts *signOptions, signBlobOpts *signBlobOptions, args []s
 			signerOpts.CertOidcIssuerRegexp = signBlobOpts.certOidcIssuerRegexp
 
 			signer := sign.New(signerOpts)
-			if _, err := signer.SignFile(fileBundle.fileLocalLocation); err != nil {
+			res, err := signer.SignFile(fileBundle.fileLocalLocation)
+			if err != nil {
 				t.Done(fmt.Errorf("signing the file %s: %w", fileBundle.fileLocalLocation, err))
 				return
 			}
+			signed[i] = res.File()
+			bar.Increment()
 			t.Done(nil)
-		}(fileBundle)
+		}(i, fileBundle)
 
 		if t.Throttle() > 0 {
 			break
 		}
 	}
+	bar.Finish()
 	if err := t.Err(); err != nil {
 		return fmt.Errorf("signing the blobs: %w", err)
 	}
 
+	if signBlobOpts.tsaURL != "" {
+		if err := timestampBlobs(signBlobOpts, bundle, manifests); err != nil {
+			return fmt.Errorf("timestamping the signatures: %w", err)
+		}
+	}
+
 	if isGCSBucket {
 		logrus.Info("Copying Certificates and Signatures back to the bucket...")
 		for _, fileBundle := range bundle {
@@ -244,19 +342,188 @@ func runSignBlobs(signOpts *signOptions, signBlobOpts *signBlobOptions, args []s
 				signFiles = fmt.Sprintf("%s%s", fileBundle.fileLocalLocation, sigExt)
 			}
 
-			logrus.Infof("Copying %s and %s...", certFiles, signFiles)
+			files := []string{certFiles, signFiles}
+			if signBlobOpts.tsaURL != "" {
+				files = append(files, signFiles+tsa.Extension)
+			}
+
+			logrus.Infof("Copying %s...", strings.Join(files, ", "))
+			if _, err := gcli.GSUtilOutput(append(
+				append([]string{"cp"}, files...),
+				fmt.Sprintf("%s%s", object.GcsPrefix, fileBundle.destinationPathToCopy),
+			)...); err != nil {
+				return fmt.Errorf("copying certificates and signatures to the bucket: %w", err)
+			}
+		}
+
+		for _, manifest := range manifests {
+			timestampFile := manifest.fileLocalLocation + tsa.Extension
+			logrus.Infof("Copying %s...", timestampFile)
 			if _, err := gcli.GSUtilOutput(
-				"cp", certFiles, signFiles, fmt.Sprintf("%s%s", object.GcsPrefix, fileBundle.destinationPathToCopy),
+				"cp", timestampFile, fmt.Sprintf("%s%s", object.GcsPrefix, manifest.destinationPathToCopy),
 			); err != nil {
-				return fmt.Errorf("copying certificates and signatures to the bucket: %w", err)
+				return fmt.Errorf("copying timestamps to the bucket: %w", err)
 			}
 		}
 	}
 
+	if signBlobOpts.manifestPath != "" {
+		if err := writeSigningManifest(signBlobOpts.manifestPath, bundle, signed, isGCSBucket); err != nil {
+			return fmt.Errorf("writing the signing manifest: %w", err)
+		}
+	}
+
 	logrus.Info("Done")
 	return nil
 }
 
+// expandBlobArgs expands the glob patterns of the local blobs to be signed
+// and walks directories recursively. Existing signatures, certificates and
+// timestamps are skipped.
+func expandBlobArgs(args []string) ([]string, error) {
+	files := []string{}
+	seen := map[string]bool{}
+	add := func(path string) {
+		if seen[path] || strings.HasSuffix(path, sigExt) ||
+			strings.HasSuffix(path, certExt) || strings.HasSuffix(path, tsa.Extension) {
+			return
+		}
+		seen[path] = true
+		files = append(files, path)
+	}
+
+	for _, arg := range args {
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", arg, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("blob %s does not exist", arg)
+		}
+
+		for _, match := range matches {
+			if err := filepath.WalkDir(match, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.Type().IsRegular() {
+					add(path)
+				}
+				return nil
+			}); err != nil {
+				return nil, fmt.Errorf("walking %s: %w", match, err)
+			}
+		}
+	}
+	return files, nil
+}
+
+// writeSigningManifest writes the JSON manifest of the signed files. The
+// locations point to the bucket if the files got signed from there.
+func writeSigningManifest(path string, bundle []signingBundle, signed []*sign.SignedFile, isGCSBucket bool) error {
+	entries := []signingManifestEntry{}
+	for i, fileBundle := range bundle {
+		if signed[i] == nil {
+			continue
+		}
+
+		entry := signingManifestEntry{
+			File:      fileBundle.fileLocalLocation,
+			Digest:    "sha256:" + signed[i].SHA256(),
+			Signature: signed[i].SignaturePath(),
+		}
+
+		if certPath := signed[i].CertificatePath(); certPath != "" {
+			if _, err := os.Stat(certPath); err == nil {
+				identity, err := certificateIdentity(certPath)
+				if err != nil {
+					return fmt.Errorf("reading identity of %s: %w", certPath, err)
+				}
+				entry.Certificate = certPath
+				entry.Identity = identity
+			}
+		}
+
+		if isGCSBucket {
+			location := fmt.Sprintf("%s%s/%s", object.GcsPrefix, fileBundle.destinationPathToCopy, fileBundle.fileToSign)
+			entry.File = location
+			entry.Signature = location + sigExt
+			if entry.Certificate != "" {
+				entry.Certificate = location + certExt
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].File < entries[j].File })
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	logrus.Infof("Wrote signing manifest of %d files to %s", len(entries), path)
+	return nil
+}
+
+// certificateIdentity returns the subject alternative name of the signing
+// certificate, which gets written base64 encoded by cosign.
+func certificateIdentity(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read certificate: %w", err)
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data))); err == nil {
+		data = decoded
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return "", errors.New("no PEM encoded certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("parse certificate: %w", err)
+	}
+
+	switch {
+	case len(cert.EmailAddresses) > 0:
+		return cert.EmailAddresses[0], nil
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String(), nil
+	default:
+		return cert.Subject.String(), nil
+	}
+}
+
+// timestampBlobs requests RFC 3161 timestamps for the signatures of the bundle
+// and the checksum manifests, which get written next to them.
+func timestampBlobs(signBlobOpts *signBlobOptions, bundle, manifests []signingBundle) error {
+	client := tsa.New(signBlobOpts.tsaURL)
+
+	files := []string{}
+	for _, fileBundle := range bundle {
+		signFile := fmt.Sprintf("%s/%s%s", signBlobOpts.outputPath, fileBundle.fileToSign, sigExt)
+		if signBlobOpts.outputPath == "" {
+			signFile = fmt.Sprintf("%s%s", fileBundle.fileLocalLocation, sigExt)
+		}
+		files = append(files, signFile)
+	}
+	for _, manifest := range manifests {
+		files = append(files, manifest.fileLocalLocation)
+	}
+
+	logrus.Infof("Timestamping %d files using %s", len(files), signBlobOpts.tsaURL)
+	for _, file := range files {
+		if _, err := client.TimestampFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validateSignBlobsArgs(args []string) error {
 	if len(args) < 1 {
 		return errors.New("missing set files or gcs bucket")
@@ -273,11 +540,5 @@ func validateSignBlobsArgs(args []string) error {
 		return nil
 	}
 
-	for _, file := range args {
-		if _, err := os.Stat(file); os.IsNotExist(err) {
-			return fmt.Errorf("blob %s does not exist", file)
-		}
-	}
-
 	return nil
 }
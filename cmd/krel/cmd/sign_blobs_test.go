@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandBlobArgs(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{
+		"artifacts/kubectl",
+		"artifacts/kubectl.sig",
+		"artifacts/kubectl.cert",
+		"artifacts/bin/kubeadm",
+		"artifacts/bin/kubeadm.sig.tsr",
+		"extras/a.tar.gz",
+		"extras/b.tar.gz",
+		"extras/README",
+	} {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(file), 0o600))
+	}
+
+	files, err := expandBlobArgs([]string{
+		filepath.Join(dir, "artifacts"),
+		filepath.Join(dir, "extras", "*.tar.gz"),
+		filepath.Join(dir, "artifacts", "kubectl"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "artifacts", "bin", "kubeadm"),
+		filepath.Join(dir, "artifacts", "kubectl"),
+		filepath.Join(dir, "extras", "a.tar.gz"),
+		filepath.Join(dir, "extras", "b.tar.gz"),
+	}, files)
+
+	_, err = expandBlobArgs([]string{filepath.Join(dir, "missing", "*")})
+	require.ErrorContains(t, err, "does not exist")
+}
+
+func TestCertificateIdentity(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	workflow, err := url.Parse("https://github.com/kubernetes/release/.github/workflows/sign.yml@refs/heads/master")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		template *x509.Certificate
+		encode   func([]byte) []byte
+		expected string
+	}{
+		{
+			name:     "email",
+			template: &x509.Certificate{SerialNumber: big.NewInt(1), EmailAddresses: []string{"krel-trust@k8s-releng-prod.iam.gserviceaccount.com"}},
+			encode:   func(b []byte) []byte { return []byte(base64.StdEncoding.EncodeToString(b)) },
+			expected: "krel-trust@k8s-releng-prod.iam.gserviceaccount.com",
+		},
+		{
+			name:     "URI",
+			template: &x509.Certificate{SerialNumber: big.NewInt(2), URIs: []*url.URL{workflow}},
+			encode:   func(b []byte) []byte { return b },
+			expected: workflow.String(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			der, err := x509.CreateCertificate(rand.Reader, tc.template, tc.template, &key.PublicKey, key)
+			require.NoError(t, err)
+
+			path := filepath.Join(t.TempDir(), "kubectl.cert")
+			require.NoError(t, os.WriteFile(path, tc.encode(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), 0o600))
+
+			identity, err := certificateIdentity(path)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, identity)
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "invalid.cert")
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0o600))
+	_, err = certificateIdentity(path)
+	require.Error(t, err)
+}
@@ -24,7 +24,12 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/approver"
+	"k8s.io/release/pkg/attribution"
+	"k8s.io/release/pkg/gcp/build"
+	"k8s.io/release/pkg/gcp/gcb"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/vulnscan"
 	"sigs.k8s.io/release-sdk/github"
 )
 
@@ -56,8 +61,30 @@ Build (GCB) job which does:
 5. Generate release notes: Generate the CHANGELOG-x.y.md file and commit it
    into the local working repository.
 
-6. Stage: Copies the build artifacts to a Google Cloud Bucket.
-`, github.TokenEnvKey, release.BuildDir),
+6. Generate license attribution: Assemble the LICENSES notices of all
+   vendored dependencies into the %s archive. Unknown
+   licenses will fail the job.
+
+7. Stage: Copies the build artifacts to a Google Cloud Bucket.
+
+Before submitting the job, krel verifies that the jobs on the release blocking
+TestGrid dashboard of the branch are green. Up to --allowed-flaky-jobs flaky
+jobs are tolerated, while failing or stale jobs always block the release cut. A
+summary of the dashboard will be printed if the signal is not green. In mock
+mode the result is only logged, and the check can be disabled completely by
+using --skip-ci-signal-check.
+
+Hotfix builds can pin an exact revision by using --commit together with a
+--build-version referencing that commit. The commit gets tagged as is, without
+an empty release commit, and is recorded in the provenance attestation, the
+build environment manifest and the GitHub release page.
+
+The built container images are scanned for vulnerabilities using trivy. The
+report will be staged next to the images and, depending on
+--vulnerability-scan, new vulnerabilities fail the job or only produce a
+warning. Already known vulnerabilities can be excluded by using
+--ignored-vulnerabilities.
+`, github.TokenEnvKey, release.BuildDir, attribution.ArchiveName),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -72,9 +99,11 @@ var (
 )
 
 const (
-	buildVersionFlag = "build-version"
-	submitJobFlag    = "submit"
-	streamFlag       = "stream"
+	buildVersionFlag   = "build-version"
+	commitFlag         = "commit"
+	submitJobFlag      = "submit"
+	streamFlag         = "stream"
+	nonInteractiveFlag = "non-interactive"
 )
 
 func init() {
@@ -108,6 +137,14 @@ func init() {
 			"The build version to be released.",
 		)
 
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.Commit,
+			commitFlag,
+			"",
+			"Full SHA of the commit to be tagged instead of the head of the release branch, requires a --build-version referencing it",
+		)
+
 	stageCmd.PersistentFlags().
 		BoolVar(
 			&submitJob,
@@ -124,10 +161,93 @@ func init() {
 			"Run the Google Cloud Build job synchronously",
 		)
 
-	for _, flag := range []string{buildVersionFlag, submitJobFlag} {
-		if err := stageCmd.PersistentFlags().MarkHidden(flag); err != nil {
-			logrus.Fatal(err)
-		}
+	stageCmd.PersistentFlags().
+		BoolVar(
+			&stageOptions.NonInteractive,
+			nonInteractiveFlag,
+			false,
+			"Do not ask for confirmation before submitting a --nomock Google Cloud Build job",
+		)
+
+	stageCmd.PersistentFlags().
+		BoolVar(
+			&stageOptions.Local,
+			"local",
+			false,
+			"Run the steps of the Google Cloud Build job locally in containers, for debugging job changes in mock mode",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.ContainerRuntime,
+			"container-runtime",
+			build.DefaultContainerRuntime,
+			"The container runtime used for running --local jobs, for example docker or podman",
+		)
+
+	stageCmd.PersistentFlags().
+		BoolVar(
+			&stageOptions.SkipCISignalCheck,
+			"skip-ci-signal-check",
+			false,
+			"Do not verify the release blocking CI jobs before submitting the job",
+		)
+
+	stageCmd.PersistentFlags().
+		IntVar(
+			&stageOptions.AllowedFlakyJobs,
+			"allowed-flaky-jobs",
+			0,
+			"Number of flaky release blocking CI jobs which are tolerated",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.VulnerabilityScan,
+			"vulnerability-scan",
+			stageOptions.VulnerabilityScan,
+			fmt.Sprintf("How vulnerabilities found in the staged images are handled, must be one of: '%s'",
+				strings.Join([]string{
+					vulnscan.ModeFail,
+					vulnscan.ModeWarn,
+					vulnscan.ModeOff,
+				}, "', '"),
+			))
+
+	stageCmd.PersistentFlags().
+		StringSliceVar(
+			&stageOptions.IgnoredVulnerabilities,
+			"ignored-vulnerabilities",
+			nil,
+			"Already known vulnerability IDs (like CVE-2023-12345) which are not reported by the image scan",
+		)
+
+	stageCmd.PersistentFlags().
+		Float64Var(
+			&stageOptions.SizeThreshold,
+			"size-threshold",
+			stageOptions.SizeThreshold,
+			"Growth in percent of an artifact since the previous release, from which on it gets reported in the release cut issue",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.EncryptionKey,
+			"encryption-key",
+			stageOptions.EncryptionKey,
+			"Cloud KMS key for encrypting the staged artifacts of an embargoed security release, for example projects/k8s-releng-prod/locations/global/keyRings/release/cryptoKeys/embargo",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.CustodyKey,
+			"custody-key",
+			"",
+			"Path to the private in-toto key of the functionary, which records the chain of custody links of the stage and sign steps next to the staged build",
+		)
+
+	if err := stageCmd.PersistentFlags().MarkHidden(submitJobFlag); err != nil {
+		logrus.Fatal(err)
 	}
 
 	rootCmd.AddCommand(stageCmd)
@@ -135,6 +255,15 @@ func init() {
 
 func runStage(options *anago.StageOptions) error {
 	options.NoMock = rootOpts.nomock
+
+	// Allow submitting ignored vulnerabilities separated by the string slice
+	// separator, which is used for passing the GCB substitution.
+	if len(options.IgnoredVulnerabilities) == 1 {
+		options.IgnoredVulnerabilities = strings.Split(
+			options.IgnoredVulnerabilities[0], gcb.StringSliceSeparator,
+		)
+	}
+
 	stage := anago.NewStage(options)
 	if submitJob {
 		// Perform a local check of the specified options before launching a
@@ -142,7 +271,12 @@ func runStage(options *anago.StageOptions) error {
 		if err := options.Validate(&anago.State{}); err != nil {
 			return fmt.Errorf("prechecking stage options: %w", err)
 		}
-		return stage.Submit(stream)
+		if options.NoMock {
+			if err := approver.Check("stage", options.ReleaseBranch); err != nil {
+				return err
+			}
+		}
+		return stage.SubmitContext(runContext(), stream)
 	}
-	return stage.Run()
+	return stage.RunContext(runContext())
 }
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/stagingchangelog"
+)
+
+var stagingChangelogsOpts = stagingchangelog.DefaultOptions()
+
+// stagingChangelogsCmd represents the subcommand for `krel staging-changelogs`
+var stagingChangelogsCmd = &cobra.Command{
+	Use:   "staging-changelogs --tag <tag>",
+	Short: "Generate the changelogs of the staging repositories and publish them as GitHub releases",
+	Long: fmt.Sprintf(`staging-changelogs generates a changelog for every published staging
+repository, like client-go, from the release notes of kubernetes/kubernetes
+pull requests which change files below %s/<repo>.
+
+The changelogs start at the previous patch release by default, which can be
+changed by using --previous-tag. They are always printed and optionally
+written to --output-dir.
+
+With --nomock, the changelogs get published as GitHub releases of the
+corresponding tags on the repositories mirrored by the publishing-bot, for
+example v0.30.1 of kubernetes/client-go for v1.30.1. The tags have to be
+published before, existing releases get updated.
+`, stagingchangelog.StagingDir),
+	Example:       "krel staging-changelogs --tag v1.30.1 --repos client-go,api --nomock",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stagingChangelogsOpts.NoMock = rootOpts.nomock
+		results, err := stagingchangelog.New(stagingChangelogsOpts).Run()
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			fmt.Printf("# %s %s\n\n%s\n", result.Repo, result.Tag, result.Changelog)
+		}
+		return nil
+	},
+}
+
+func init() {
+	stagingChangelogsCmd.PersistentFlags().StringVarP(&stagingChangelogsOpts.Tag, tagFlag, "t", "", "kubernetes/kubernetes tag to generate the changelogs for, for example v1.30.1")
+	stagingChangelogsCmd.PersistentFlags().StringVar(&stagingChangelogsOpts.PreviousTag, "previous-tag", "", "tag to start the changelogs at (default the previous patch release)")
+	stagingChangelogsCmd.PersistentFlags().StringSliceVar(&stagingChangelogsOpts.Repos, "repos", stagingChangelogsOpts.Repos, "staging repositories to generate the changelogs for")
+	stagingChangelogsCmd.PersistentFlags().StringVar(&stagingChangelogsOpts.Org, "org", stagingChangelogsOpts.Org, "GitHub organization of the published staging repositories")
+	stagingChangelogsCmd.PersistentFlags().StringVar(&stagingChangelogsOpts.RepoPath, "repo-path", "", "path to a local kubernetes/kubernetes clone")
+	stagingChangelogsCmd.PersistentFlags().StringVar(&stagingChangelogsOpts.OutputDir, "output-dir", "", "optional directory for writing the changelogs")
+
+	if err := stagingChangelogsCmd.MarkPersistentFlagRequired(tagFlag); err != nil {
+		logrus.Fatal(err)
+	}
+
+	rootCmd.AddCommand(stagingChangelogsCmd)
+}
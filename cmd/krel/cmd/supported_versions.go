@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/supportedversions"
+)
+
+var supportedVersionsOpts = supportedversions.DefaultOptions()
+
+// supportedVersionsCmd represents the subcommand for `krel supported-versions`
+var supportedVersionsCmd = &cobra.Command{
+	Use:   "supported-versions",
+	Short: "Generate and publish the supported minor versions as JSON document",
+	Long: fmt.Sprintf(`krel supported-versions
+
+Generates a JSON document of the minor versions, whether they are still
+supported, their latest patch releases and their end of life dates. The dates
+are taken from the patch release schedule and the end of life branches of
+kubernetes/website (data/releases/schedule.yaml and eol.yaml), while the latest
+patches are taken from the stable version markers of the release bucket.
+
+Using --publish uploads the document as %s to the release bucket, which is the
+production bucket if --nomock is set, so that it is available at a stable URL
+like %s/release/%s.`,
+		supportedversions.FileName, release.ProductionBucketURL, supportedversions.FileName,
+	),
+	Example:       "krel supported-versions --schedule schedule.yaml --eol eol.yaml --publish --nomock",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSupportedVersions(supportedVersionsOpts, cmd.Flags().Changed("bucket"))
+	},
+}
+
+func init() {
+	supportedVersionsCmd.PersistentFlags().StringVar(
+		&supportedVersionsOpts.Schedule,
+		"schedule",
+		supportedVersionsOpts.Schedule,
+		"path to the patch release schedule YAML",
+	)
+
+	supportedVersionsCmd.PersistentFlags().StringVar(
+		&supportedVersionsOpts.EOL,
+		"eol",
+		supportedVersionsOpts.EOL,
+		"path to the end of life branches YAML",
+	)
+
+	supportedVersionsCmd.PersistentFlags().StringVar(
+		&supportedVersionsOpts.Output,
+		"output",
+		supportedVersionsOpts.Output,
+		"path where the document gets written to, printed to stdout if empty",
+	)
+
+	supportedVersionsCmd.PersistentFlags().BoolVar(
+		&supportedVersionsOpts.Publish,
+		"publish",
+		supportedVersionsOpts.Publish,
+		"upload the document to the release bucket",
+	)
+
+	supportedVersionsCmd.PersistentFlags().StringVar(
+		&supportedVersionsOpts.Bucket,
+		"bucket",
+		supportedVersionsOpts.Bucket,
+		fmt.Sprintf("GCS bucket to publish the document to (default %q with --nomock)", release.ProductionBucket),
+	)
+
+	rootCmd.AddCommand(supportedVersionsCmd)
+}
+
+func runSupportedVersions(opts *supportedversions.Options, bucketSet bool) error {
+	if rootOpts.nomock && !bucketSet {
+		opts.Bucket = release.ProductionBucket
+	}
+
+	doc, err := supportedversions.New(opts).Run()
+	if err != nil {
+		return fmt.Errorf("generate supported versions: %w", err)
+	}
+
+	if opts.Output == "" && !opts.Publish {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/templates"
+)
+
+// templatesCmd represents the subcommand for `krel templates`
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List and show the official announcement templates",
+	Long: `templates lists and shows the official Kubernetes announcement templates,
+which are embedded into krel.
+
+Commands accepting a --template flag, like "krel announce build" and
+"krel announce blog", select an official template by its name, otherwise
+the value is used as path to a custom template. Dumping an official
+template with "krel templates show" is a good starting point for
+customizing it.
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List the official templates",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(*cobra.Command, []string) error {
+		list := templates.List()
+		return writeOutput(os.Stdout, templatesListOutput, list, func() error {
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Name", "Description"})
+			table.SetAutoWrapText(false)
+			for _, t := range list {
+				table.Append([]string{t.Name, t.Description})
+			}
+			table.Render()
+			return nil
+		})
+	},
+}
+
+var templatesListOutput string
+
+var templatesShowCmd = &cobra.Command{
+	Use:           "show NAME",
+	Short:         "Print an official template",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("command takes only one argument: the template name")
+		}
+		return nil
+	},
+	ValidArgsFunction: func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		names := []string{}
+		for _, t := range templates.List() {
+			names = append(names, t.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		content, err := templates.Get(args[0])
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(cmd.OutOrStdout(), content)
+		return err
+	},
+}
+
+func init() {
+	addOutputFlag(templatesListCmd.PersistentFlags(), &templatesListOutput)
+	templatesCmd.AddCommand(templatesListCmd, templatesShowCmd)
+	rootCmd.AddCommand(templatesCmd)
+}
@@ -18,20 +18,17 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"strings"
-	"time"
 
-	"github.com/mitchellh/mapstructure"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"k8s.io/release/pkg/testgrid"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/github"
-	"sigs.k8s.io/release-utils/http"
 )
 
 const (
@@ -49,18 +46,6 @@ type TestGridOptions struct {
 
 var testGridOpts = &TestGridOptions{}
 
-type TestgridJobInfo struct {
-	OverallStatus string `mapstructure:"overall_status"`
-	DashboardName string `mapstructure:"dashboard_name"`
-}
-
-type TestGridJob struct {
-	DashboardName string
-	JobName       string
-	Status        string
-	GCSLocation   string
-}
-
 const (
 	statePassing = "PASSING"
 	stateFlaky   = "FLAKY"
@@ -72,8 +57,20 @@ const (
 
 // testGridCmd represents the base command when called without any subcommands
 var testGridCmd = &cobra.Command{
-	Use:           "testgridshot --branch <release-branch>",
-	Short:         "Take a screenshot of the testgrid dashboards",
+	Use:   "testgridshot --branch <release-branch>",
+	Short: "Generate a health report of the testgrid dashboards",
+	Long: `testgridshot retrieves the job summaries of the sig-release blocking and
+informing dashboards for the provided branch via the TestGrid JSON API.
+
+The result is rendered as markdown health report, which contains the pass rate
+of every dashboard as well as a table of all jobs matching the provided
+--states, including their recent success rate, last green run and the failing
+or flaky tests.
+
+If --github-issue is set, then the report will be posted as comment to the
+release cut issue in kubernetes/sig-release. Otherwise it will be printed to
+stdout.
+`,
 	Example:       "krel testgridshot --branch 1.17",
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -89,8 +86,8 @@ func init() {
 	testGridCmd.PersistentFlags().StringSliceVar(&testGridOpts.boards, "boards", []string{boardBlocking, boardInforming},
 		"Which Boards to retrieve the dashboards, defaults to blocking and informing")
 
-	testGridCmd.PersistentFlags().StringSliceVar(&testGridOpts.states, "states", []string{stateFailing},
-		"Which States to watch for each dashboard, default to failing")
+	testGridCmd.PersistentFlags().StringSliceVar(&testGridOpts.states, "states", []string{stateFailing, stateFlaky},
+		"Which States to list the jobs for each dashboard, default to failing and flaky")
 
 	testGridCmd.PersistentFlags().StringVar(&testGridOpts.testgridURL,
 		"testgrid-url", "https://testgrid.k8s.io", "The TestGrid URL")
@@ -99,7 +96,13 @@ func init() {
 		"github-issue", -1, "The GitHub Issue for the release cut")
 
 	testGridCmd.PersistentFlags().StringVar(&testGridOpts.bucket, "bucket", "k8s-staging-releng",
-		"The name of the bucket to upload the images to. The files will be put into '/testgridshot/<release>/<datetime>_<rand>/...'. Defaults to k8s-staging-releng")
+		"The name of the bucket to upload the images to")
+
+	if err := testGridCmd.PersistentFlags().MarkDeprecated(
+		"bucket", "screenshots are not taken any more",
+	); err != nil {
+		logrus.Fatal(err)
+	}
 
 	rootCmd.AddCommand(testGridCmd)
 }
@@ -111,86 +114,42 @@ func runTestGridShot(opts *TestGridOptions) error {
 		return fmt.Errorf("validating testgridshot options: %w", err)
 	}
 
-	testgridJobs := []TestGridJob{}
+	dashboards := []testgrid.DashboardName{}
 	for _, board := range opts.boards {
-		testGridDashboard := fmt.Sprintf("%s/sig-release-%s-%s/summary", opts.testgridURL, opts.branch, board)
-		content, err := http.NewAgent().WithTimeout(30 * time.Second).Get(testGridDashboard)
-		if err != nil {
-			return fmt.Errorf("unable to retrieve release announcement form url: %s: %w", testGridDashboard, err)
-		}
-
-		var result map[string]interface{}
-		err = json.Unmarshal(content, &result)
-		if err != nil {
-			return fmt.Errorf("unable unmarshal the testgrid response: %w", err)
-		}
+		dashboards = append(dashboards, testgrid.DashboardName(
+			fmt.Sprintf("sig-release-%s-%s", opts.branch, board),
+		))
+	}
 
-		testgridJobsTemp := []TestGridJob{}
-		for jobName, jobData := range result {
-			result := TestgridJobInfo{}
-			err = mapstructure.Decode(jobData, &result)
-			if err != nil {
-				return fmt.Errorf("decode testgrid data: %w", err)
-			}
-
-			for _, state := range opts.states {
-				if state == result.OverallStatus {
-					testgridJobsTemp = append(testgridJobsTemp, TestGridJob{
-						JobName:       jobName,
-						DashboardName: result.DashboardName,
-						Status:        result.OverallStatus,
-					})
-				}
-			}
-		}
-		testgridJobs = append(testgridJobs, testgridJobsTemp...)
+	states := []testgrid.OverallStatus{}
+	for _, state := range opts.states {
+		states = append(states, testgrid.OverallStatus(state))
 	}
 
-	err := generateIssueComment(testgridJobs, opts)
+	tg := testgrid.New()
+	tg.SetURL(opts.testgridURL)
+
+	logrus.Infof("Retrieving summaries for dashboards: %v", dashboards)
+	data, err := tg.Summaries(dashboards)
 	if err != nil {
+		return fmt.Errorf("retrieving the testgrid summaries: %w", err)
+	}
+
+	if err := generateIssueComment(tg.Report(data, dashboards, states), opts); err != nil {
 		return fmt.Errorf("generating the GitHub issue comment: %w", err)
 	}
 
 	return nil
 }
 
-func generateIssueComment(testgridJobs []TestGridJob, opts *TestGridOptions) error {
+func generateIssueComment(report string, opts *TestGridOptions) error {
 	// Generate comment to GH
-	output := []string{}
-	output = append(output, fmt.Sprintf("<!-- ----[ issue comment ]---- -->\n### Testgrid dashboards for %s\n", opts.branch))
-
-	timeNow := time.Now().UTC()
-	for _, state := range opts.states {
-		output = append(output, fmt.Sprintf("Boards checked for %s:", state))
-		for _, board := range opts.boards {
-			output = append(output, fmt.Sprintf("- [sig-release-%[1]s-%[2]s](%[3]s/sig-release-%[1]s-%[2]s)", opts.branch, board, opts.testgridURL))
-		}
-		output = append(output, "\n")
-
-		haveState := false
-		for _, job := range testgridJobs {
-			if state == job.Status {
-				output = append(output,
-					fmt.Sprintf(
-						"<details><summary><tt>%[1]s</tt> %[2]s <a href=\"%[5]s/%[3]s#%[4]s&width=30\">%[3]s#%[4]s - TestGrid</a></summary><p>\n"+
-							"\n%[3]s#%[4]s\n\n"+
-							"</p></details>",
-						timeNow, state, job.DashboardName, job.JobName, opts.testgridURL),
-				)
-
-				haveState = true
-			}
-		}
-
-		if !haveState {
-			output = append(output, fmt.Sprintf("**No %s Jobs**", state))
-		}
-
-		output = append(output, "\n\n")
+	output := []string{
+		fmt.Sprintf("<!-- ----[ issue comment ]---- -->\n### Testgrid dashboards for %s\n", opts.branch),
+		report,
+		"\n**comment generated by [krel](https://github.com/kubernetes/release/tree/master/docs/krel)**\n\n<!-- ----[ issue comment ]---- -->",
 	}
 
-	output = append(output, "\n**comment generated by [krel](https://github.com/kubernetes/release/tree/master/docs/krel)**\n\n<!-- ----[ issue comment ]---- -->")
-
 	if opts.gitHubIssue != -1 {
 		gh := github.New()
 
@@ -238,7 +197,7 @@ func (o *TestGridOptions) Validate() error {
 	if o.gitHubIssue != -1 {
 		token, isSet := os.LookupEnv(github.TokenEnvKey)
 		if !isSet || token == "" {
-			return fmt.Errorf("cannot send the screenshots if %s environment variable is not set", github.TokenEnvKey)
+			return fmt.Errorf("cannot send the report if %s environment variable is not set", github.TokenEnvKey)
 		}
 
 		gh := github.New()
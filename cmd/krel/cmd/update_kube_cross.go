@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/kubecross"
+)
+
+var updateKubeCrossOpts = kubecross.DefaultUpdateOptions()
+
+// updateKubeCrossCmd represents the subcommand for `krel update-kube-cross`
+var updateKubeCrossCmd = &cobra.Command{
+	Use:   "update-kube-cross --fork <org>",
+	Short: "Bump kube-cross and related builder images to the latest Go patch releases",
+	Long: `update-kube-cross detects new Go patch releases and bumps the variants of the
+kube-cross, go-runner and releng-ci images in a local kubernetes/release
+checkout, including their Makefiles and the dependencies.yaml.
+
+If --nomock is set, the updated image variants are built and pushed by
+submitting Google Cloud Build jobs. Afterwards pull requests updating the
+kube-cross version, .go-version and build/dependencies.yaml are opened against
+every kubernetes/kubernetes branch which uses a bumped kube-cross image. The
+pull requests are pushed to the kubernetes/kubernetes fork of the --fork
+organization and held until the new images got promoted.
+`,
+	Example:       "krel update-kube-cross --fork my-user --nomock",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		updateKubeCrossOpts.NoMock = rootOpts.nomock
+		_, err := kubecross.NewUpdater(updateKubeCrossOpts).Run()
+		return err
+	},
+}
+
+func init() {
+	updateKubeCrossCmd.PersistentFlags().StringVar(&updateKubeCrossOpts.RepoPath, "repo", updateKubeCrossOpts.RepoPath, "the path to the local kubernetes/release repository")
+	updateKubeCrossCmd.PersistentFlags().StringVar(&updateKubeCrossOpts.Fork, "fork", "", "the GitHub organization of the kubernetes/kubernetes fork used for opening the pull requests")
+	updateKubeCrossCmd.PersistentFlags().BoolVar(&updateKubeCrossOpts.UseSSH, "use-ssh", false, "push to the fork via SSH instead of HTTPS")
+
+	rootCmd.AddCommand(updateKubeCrossCmd)
+}
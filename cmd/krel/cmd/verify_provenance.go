@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/provenancecheck"
+)
+
+var (
+	verifyProvenanceOpts   = provenancecheck.DefaultOptions()
+	verifyProvenanceOutput string
+)
+
+// verifyProvenanceCmd represents the subcommand for `krel verify-provenance`
+var verifyProvenanceCmd = &cobra.Command{
+	Use:   "verify-provenance --version <version> --artifact <path>",
+	Short: "Verify the signature and SLSA provenance of a release artifact",
+	Long: `verify-provenance downloads a published release artifact together with its
+signature, certificate and the SLSA provenance attestation of the release.
+
+The following checks are performed:
+
+- signature: the artifact is signed by the expected keyless identity
+- predicate type: the attestation is a SLSA v0.2 provenance statement
+- subject: the attestation lists the artifact with its SHA256 digest
+- builder: the attestation was issued by the expected builder
+- source: the built sources match the commit of the release tag
+
+The command prints a verdict of all checks, or the checks as JSON or YAML
+by using --output, and fails if any of them did not pass.
+`,
+	Example:       "krel verify-provenance --version v1.29.1 --artifact bin/linux/amd64/kubectl",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVerifyProvenance(verifyProvenanceOpts, verifyProvenanceOutput)
+	},
+}
+
+func init() {
+	verifyProvenanceCmd.PersistentFlags().StringVar(&verifyProvenanceOpts.Version, "version", "", "the release tag of the artifact, for example v1.29.1")
+	verifyProvenanceCmd.PersistentFlags().StringVar(&verifyProvenanceOpts.Artifact, "artifact", "", "the path of the artifact below the release directory, for example bin/linux/amd64/kubectl")
+	verifyProvenanceCmd.PersistentFlags().StringVar(&verifyProvenanceOpts.BaseURL, "base-url", verifyProvenanceOpts.BaseURL, "the location of the published release artifacts")
+	verifyProvenanceCmd.PersistentFlags().StringVar(&verifyProvenanceOpts.BuilderID, "builder-id", verifyProvenanceOpts.BuilderID, "the expected builder identity of the provenance attestation")
+	verifyProvenanceCmd.PersistentFlags().StringVar(&verifyProvenanceOpts.SourceRepo, "source-repo", verifyProvenanceOpts.SourceRepo, "the expected material URI of the built sources")
+	verifyProvenanceCmd.PersistentFlags().StringVar(&verifyProvenanceOpts.CertIdentityRegexp, "certificate-identity-regexp", verifyProvenanceOpts.CertIdentityRegexp, "the expected identity of the signing certificate")
+	verifyProvenanceCmd.PersistentFlags().StringVar(&verifyProvenanceOpts.CertOidcIssuer, "certificate-oidc-issuer", verifyProvenanceOpts.CertOidcIssuer, "the expected OIDC issuer of the signing certificate")
+	verifyProvenanceCmd.PersistentFlags().StringVar(&verifyProvenanceOpts.WorkDir, "work-dir", "", "the directory for the downloaded files, a temporary one will be used if not set")
+	addOutputFlag(verifyProvenanceCmd.PersistentFlags(), &verifyProvenanceOutput)
+
+	rootCmd.AddCommand(verifyProvenanceCmd)
+}
+
+func runVerifyProvenance(opts *provenancecheck.Options, output string) error {
+	verifier := provenancecheck.New(opts)
+	verdict, err := verifier.Verify()
+	if err != nil {
+		return err
+	}
+	if err := writeOutput(os.Stdout, output, verdict, func() error {
+		verdict.Print(os.Stdout)
+		return nil
+	}); err != nil {
+		return err
+	}
+	return verifier.Check(verdict)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/reproducible"
+)
+
+var verifyReproducibleOpts = reproducible.DefaultOptions()
+
+// verifyReproducibleCmd represents the subcommand for `krel verify-reproducible`
+var verifyReproducibleCmd = &cobra.Command{
+	Use:   "verify-reproducible --version <version>",
+	Short: "Verify that release artifacts can be rebuilt bit-for-bit",
+	Long: `verify-reproducible rebuilds the selected binaries from the release tag in a
+clean checkout of the Kubernetes repository and compares their SHA256 digests
+against the published artifacts on dl.k8s.io.
+
+The build runs in the containerized Kubernetes build environment, which means
+that a working docker installation is required. The command reports which
+artifacts are reproducible and fails if any of them diverge.
+`,
+	Example:       "krel verify-reproducible --version v1.29.1 --binaries kubectl,kubeadm --platforms linux/amd64,linux/arm64",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reproducible.New(verifyReproducibleOpts).Run()
+	},
+}
+
+func init() {
+	verifyReproducibleCmd.PersistentFlags().StringVar(&verifyReproducibleOpts.Version, "version", "", "the release tag to be rebuilt, for example v1.29.1")
+	verifyReproducibleCmd.PersistentFlags().StringSliceVar(&verifyReproducibleOpts.Binaries, "binaries", verifyReproducibleOpts.Binaries, "the binaries to be rebuilt and verified")
+	verifyReproducibleCmd.PersistentFlags().StringSliceVar(&verifyReproducibleOpts.Platforms, "platforms", verifyReproducibleOpts.Platforms, "the os/arch platforms to be rebuilt and verified")
+	verifyReproducibleCmd.PersistentFlags().StringVar(&verifyReproducibleOpts.BaseURL, "base-url", verifyReproducibleOpts.BaseURL, "the location of the published release artifacts")
+	verifyReproducibleCmd.PersistentFlags().StringVar(&verifyReproducibleOpts.WorkDir, "work-dir", "", "the directory used for building, a temporary one will be used if not set")
+
+	rootCmd.AddCommand(verifyReproducibleCmd)
+}
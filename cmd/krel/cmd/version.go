@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/release-utils/version"
+)
+
+// newVersionCmd returns the version subcommand, which supports the --output
+// flag like all other read-only subcommands.
+func newVersionCmd() *cobra.Command {
+	cmd := version.WithFont("slant")
+
+	var output string
+	addOutputFlag(cmd.Flags(), &output)
+
+	printText := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		info := version.GetVersionInfo()
+		return writeOutput(cmd.OutOrStdout(), output, &info, func() error {
+			return printText(cmd, args)
+		})
+	}
+	return cmd
+}
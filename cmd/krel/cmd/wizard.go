@@ -0,0 +1,231 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/regex"
+)
+
+// wizardCmd represents the subcommand for `krel wizard`
+var wizardCmd = &cobra.Command{
+	Use:   "wizard",
+	Short: "Interactively walk through staging or releasing Kubernetes",
+	Long: `krel wizard
+
+The wizard guides release team members through submitting a stage or release
+job by asking for the required settings one by one. Every answer gets
+validated before continuing, and the equivalent krel command line is shown
+before anything gets submitted.
+
+Running a release requires the build version of a previous stage, which is
+part of the stage job output.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWizard(cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(wizardCmd)
+}
+
+// wizardAttempts is the number of attempts for answering a single question.
+const wizardAttempts = 3
+
+type wizardAnswers struct {
+	operation    string
+	branch       string
+	releaseType  string
+	buildVersion string
+	nomock       bool
+}
+
+// args returns the krel arguments for running the answered operation.
+func (a *wizardAnswers) args() []string {
+	args := []string{a.operation, "--branch=" + a.branch, "--type=" + a.releaseType}
+	if a.buildVersion != "" {
+		args = append(args, "--"+buildVersionFlag+"="+a.buildVersion)
+	}
+	if a.nomock {
+		args = append(args, "--nomock")
+	}
+	return args
+}
+
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask prompts for an answer until it passes the validation. The default
+// value gets used for empty answers.
+func (p *prompter) ask(question, def string, validate func(string) error) (string, error) {
+	if def != "" {
+		question = fmt.Sprintf("%s [%s]", question, def)
+	}
+	for i := 0; i < wizardAttempts; i++ {
+		fmt.Fprintf(p.out, "%s: ", question)
+		line, err := p.in.ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			return "", fmt.Errorf("read answer: %w", err)
+		}
+
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = def
+		}
+		if err := validate(answer); err != nil {
+			fmt.Fprintf(p.out, "Invalid answer: %v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+	return "", fmt.Errorf("no valid answer after %d attempts", wizardAttempts)
+}
+
+func (p *prompter) confirm(question string) (bool, error) {
+	answer, err := p.ask(question+" (y/N)", "n", oneOf("y", "yes", "n", "no"))
+	if err != nil {
+		return false, err
+	}
+	return answer == "y" || answer == "yes", nil
+}
+
+func oneOf(values ...string) func(string) error {
+	return func(s string) error {
+		for _, v := range values {
+			if s == v {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of: %s", strings.Join(values, ", "))
+	}
+}
+
+func validateBranch(branch string) error {
+	if regex.BranchRegex.FindString(branch) != branch {
+		return fmt.Errorf("%q is not a valid release branch like %s or release-1.31", branch, git.DefaultBranch)
+	}
+	return nil
+}
+
+func validateBuildVersion(required bool) func(string) error {
+	return func(version string) error {
+		if version == "" {
+			if required {
+				return errors.New("a build version is required")
+			}
+			return nil
+		}
+		if !strings.HasPrefix(version, "v") {
+			return fmt.Errorf("%q has to start with a v", version)
+		}
+		valid, err := release.IsValidReleaseBuild(version)
+		if err != nil {
+			return fmt.Errorf("validate build version: %w", err)
+		}
+		if !valid {
+			return fmt.Errorf("%q is not a valid build version", version)
+		}
+		return nil
+	}
+}
+
+func askWizard(p *prompter) (answers *wizardAnswers, err error) {
+	answers = &wizardAnswers{}
+
+	if answers.operation, err = p.ask(
+		"Operation (stage, release)", "stage", oneOf("stage", "release"),
+	); err != nil {
+		return nil, err
+	}
+
+	if answers.branch, err = p.ask(
+		"Release branch", git.DefaultBranch, validateBranch,
+	); err != nil {
+		return nil, err
+	}
+
+	if answers.releaseType, err = p.ask(
+		fmt.Sprintf("Release type (%s)", strings.Join(releaseTypes, ", ")),
+		release.ReleaseTypeAlpha, oneOf(releaseTypes...),
+	); err != nil {
+		return nil, err
+	}
+
+	question := "Build version, empty to use the latest green build"
+	if answers.operation == "release" {
+		question = "Build version of the staged release"
+	}
+	if answers.buildVersion, err = p.ask(
+		question, "", validateBuildVersion(answers.operation == "release"),
+	); err != nil {
+		return nil, err
+	}
+
+	if answers.nomock, err = p.confirm("Target the production environment (--nomock)?"); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+func runWizard(in io.Reader, out io.Writer) error {
+	p := &prompter{in: bufio.NewReader(in), out: out}
+
+	answers, err := askWizard(p)
+	if err != nil {
+		return fmt.Errorf("wizard: %w", err)
+	}
+
+	fmt.Fprintf(out, "\nThe equivalent command is:\n\n  krel %s\n\n", strings.Join(answers.args(), " "))
+	if answers.nomock {
+		fmt.Fprintln(out, "This is NOT a mock run and will target the production environment.")
+	}
+
+	yes, err := p.confirm(fmt.Sprintf("Submit the %s job?", answers.operation))
+	if err != nil {
+		return fmt.Errorf("wizard: %w", err)
+	}
+	if !yes {
+		fmt.Fprintln(out, "Aborted")
+		return nil
+	}
+
+	rootOpts.nomock = answers.nomock
+	if answers.operation == "release" {
+		releaseOptions.ReleaseBranch = answers.branch
+		releaseOptions.ReleaseType = answers.releaseType
+		releaseOptions.BuildVersion = answers.buildVersion
+		return runRelease(releaseOptions)
+	}
+
+	stageOptions.ReleaseBranch = answers.branch
+	stageOptions.ReleaseType = answers.releaseType
+	stageOptions.BuildVersion = answers.buildVersion
+	return runStage(stageOptions)
+}
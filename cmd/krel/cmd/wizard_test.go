@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAskWizard(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		input        string
+		expectedArgs []string
+		shouldError  bool
+	}{
+		{
+			name:         "defaults",
+			input:        "\n\n\n\n\n",
+			expectedArgs: []string{"stage", "--branch=master", "--type=alpha"},
+		},
+		{
+			name:  "release",
+			input: "release\nrelease-1.31\nrc\nv1.31.0-rc.1.5+0123456789abcd\ny\n",
+			expectedArgs: []string{
+				"release", "--branch=release-1.31", "--type=rc",
+				"--build-version=v1.31.0-rc.1.5+0123456789abcd", "--nomock",
+			},
+		},
+		{
+			name:         "retry after invalid answers",
+			input:        "cut\nstage\nfoo\nrelease-1.31\nofficial\n1.31.0\n\nno\n",
+			expectedArgs: []string{"stage", "--branch=release-1.31", "--type=official"},
+		},
+		{
+			name:        "release requires build version",
+			input:       "release\nrelease-1.31\nrc\n\n\n\n",
+			shouldError: true,
+		},
+		{
+			name:        "no input",
+			input:       "",
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			p := &prompter{in: bufio.NewReader(strings.NewReader(tc.input)), out: out}
+
+			answers, err := askWizard(p)
+			if tc.shouldError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedArgs, answers.args())
+		})
+	}
+}
+
+func TestCompletions(t *testing.T) {
+	registerCompletions(rootCmd)
+
+	for _, cmd := range []string{"stage", "release"} {
+		sub, _, err := rootCmd.Find([]string{cmd})
+		require.NoError(t, err)
+		fn, ok := sub.GetFlagCompletionFunc("type")
+		require.True(t, ok, cmd)
+		values, _ := fn(sub, nil, "")
+		require.Equal(t, releaseTypes, values)
+	}
+
+	values, _ := completeBuckets(rootCmd, nil, "")
+	require.Contains(t, values, "kubernetes-release")
+}
@@ -38,3 +38,4 @@ install -p -m 755 ${KUBE_ARCH}/crictl %{buildroot}%{_bindir}/crictl
 %doc README.md
 
 %changelog
+{{ .RPMChangelog }}
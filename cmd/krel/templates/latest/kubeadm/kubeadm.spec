@@ -57,3 +57,4 @@ install -p -m 644 10-kubeadm.conf %{buildroot}%{_unitdir}/kubelet.service.d/10-k
 %doc README.md
 
 %changelog
+{{ .RPMChangelog }}
@@ -37,3 +37,4 @@ install -p -m 755 ${KUBE_ARCH}/kubectl %{buildroot}%{_bindir}/kubectl
 %doc README.md
 
 %changelog
+{{ .RPMChangelog }}
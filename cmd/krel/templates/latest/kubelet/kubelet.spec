@@ -93,3 +93,4 @@ install -p -m 644 -T kubelet.env %{buildroot}%{_sysconfdir}/sysconfig/kubelet
 %systemd_postun kubelet.service
 
 %changelog
+{{ .RPMChangelog }}
@@ -49,3 +49,4 @@ touch %{buildroot}%{_sysconfdir}/cni/net.d/.kubernetes-cni-keep
 %doc README.md
 
 %changelog
+{{ .RPMChangelog }}
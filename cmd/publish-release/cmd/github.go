@@ -32,6 +32,7 @@ import (
 	"github.com/spf13/cobra"
 	"google.golang.org/api/option"
 	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/workdir"
 )
 
 // releaseNotesCmd represents the subcommand for `krel release-notes`
@@ -75,6 +76,15 @@ Simply point the asset flag to an object in a bucket instead of a file path:
 
   --asset="gs://kubernetes-release/release/v1.25.1/bin/linux/amd64/kubectl"
 
+UPDATING PAGES
+==============
+Existing release pages get overwritten unless --noupdate is set. Use
+--archive to save the previous body and asset list of the page as JSON file
+to a local directory or a gs:// path before updating it. The updated page
+notes its revision and the archive location in a trailing HTML comment:
+
+  --archive=gs://kubernetes-release/archive/github-pages
+
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Run the PR creation function
@@ -84,6 +94,7 @@ Simply point the asset flag to an object in a bucket instead of a file path:
 
 type githubPageCmdLineOptions struct {
 	noupdate         bool
+	archive          string
 	draft            bool
 	sbom             bool
 	sbomFormat       string
@@ -110,7 +121,7 @@ func init() {
 		&ghPageOpts.template,
 		"template",
 		"",
-		"path to a custom page template",
+		"name of an official template or path to a custom page template",
 	)
 	githubPageCmd.PersistentFlags().StringVarP(
 		&ghPageOpts.name,
@@ -139,6 +150,12 @@ func init() {
 		false,
 		"Fail if the release already exists",
 	)
+	githubPageCmd.PersistentFlags().StringVar(
+		&ghPageOpts.archive,
+		"archive",
+		"",
+		"Local directory or gs:// path to archive the previous page to before updating an existing release",
+	)
 	githubPageCmd.PersistentFlags().BoolVar(
 		&ghPageOpts.draft,
 		"draft",
@@ -241,7 +258,7 @@ func processRemoteAsset(urlString string) (path string, err error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*50)
 	defer cancel()
 
-	tmpDir, err := os.MkdirTemp("", "publish-release-asset-")
+	tmpDir, err := workdir.MkdirTemp("publish-release-asset-")
 	if err != nil {
 		return path, fmt.Errorf("creating temp directory: %w", err)
 	}
@@ -309,6 +326,7 @@ func runGithubPage(opts *githubPageCmdLineOptions) (err error) {
 		Tag:                   commandLineOpts.tag,
 		NoMock:                commandLineOpts.nomock,
 		UpdateIfReleaseExists: !opts.noupdate,
+		ArchiveLocation:       opts.archive,
 		Name:                  opts.name,
 		Draft:                 opts.draft,
 		ReleaseNotesFile:      opts.ReleaseNotesFile,
@@ -21,6 +21,8 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"k8s.io/release/pkg/logging"
+	"k8s.io/release/pkg/network"
 	"sigs.k8s.io/release-utils/log"
 )
 
@@ -34,7 +36,7 @@ This tool lets software developers announce new software releases.
 	Use:               "publish-release",
 	SilenceUsage:      true,
 	SilenceErrors:     true,
-	PersistentPreRunE: initLogging,
+	PersistentPreRunE: initRoot,
 }
 
 type commandLineOptions struct {
@@ -45,6 +47,14 @@ type commandLineOptions struct {
 
 var commandLineOpts = &commandLineOptions{}
 
+var (
+	// loggingOpts are the options of the global logger.
+	loggingOpts = logging.DefaultOptions()
+
+	// networkOpts are the options of the shared HTTP transport.
+	networkOpts = network.DefaultOptions()
+)
+
 func init() {
 	rootCmd.PersistentFlags().StringVarP(
 		&commandLineOpts.tag,
@@ -65,6 +75,9 @@ func init() {
 		"info",
 		fmt.Sprintf("the logging verbosity, either %s", log.LevelNames()),
 	)
+
+	loggingOpts.AddFlags(rootCmd.PersistentFlags())
+	networkOpts.AddFlags(rootCmd.PersistentFlags())
 }
 
 // Execute builds the command
@@ -74,6 +87,13 @@ func Execute() {
 	}
 }
 
-func initLogging(*cobra.Command, []string) error {
-	return log.SetupGlobalLogger(commandLineOpts.logLevel)
+func initRoot(*cobra.Command, []string) error {
+	loggingOpts.Level = commandLineOpts.logLevel
+	if err := logging.Setup(loggingOpts); err != nil {
+		return err
+	}
+	if err := network.Setup(networkOpts); err != nil {
+		return fmt.Errorf("setup network: %w", err)
+	}
+	return nil
 }
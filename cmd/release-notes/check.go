@@ -20,11 +20,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/compliance"
 	"k8s.io/release/pkg/notes/options"
+	"k8s.io/release/pkg/redact"
 	"sigs.k8s.io/release-utils/env"
 )
 
@@ -38,14 +43,32 @@ const (
 		"For more information see:\n" +
 		"https://github.com/kubernetes/release/tree/master/cmd/release-notes\n\n\n"
 	bkTicks = "```"
+
+	webhookReadHeaderTimeout = 10 * time.Second
 )
 
 type checkPROptions struct {
 	options.Options
 	PullRequests []int
+
+	// Compliance checks the release note block of the pull requests against
+	// their labels, optionally commenting on them.
+	Compliance bool
+	Comment    bool
+
+	// WebhookAddress serves the compliance check as GitHub webhook if set.
+	WebhookAddress string
+	WebhookSecret  string
 }
 
 func (o *checkPROptions) ValidateAndFinish() error {
+	if o.WebhookAddress != "" {
+		if o.WebhookSecret == "" {
+			return errors.New("webhook secret is required for serving webhooks")
+		}
+		return nil
+	}
+
 	var lenErr, prNrErr, orgErr, repoErr error
 	if len(o.PullRequests) == 0 {
 		lenErr = fmt.Errorf("no pull requests numbers specified")
@@ -112,6 +135,34 @@ func addCheckPRFlags(subcommand *cobra.Command) {
 		[]int{},
 		"pull request number(s) to check",
 	)
+
+	subcommand.PersistentFlags().BoolVar(
+		&checkPROpts.Compliance,
+		"compliance",
+		env.IsSet("COMPLIANCE"),
+		"Check that the release note block is parseable and matches the release note and kind labels",
+	)
+
+	subcommand.PersistentFlags().BoolVar(
+		&checkPROpts.Comment,
+		"comment",
+		env.IsSet("COMMENT"),
+		"Post the compliance problems as comment on the pull requests, implies --compliance",
+	)
+
+	subcommand.PersistentFlags().StringVar(
+		&checkPROpts.WebhookAddress,
+		"webhook-address",
+		env.Default("WEBHOOK_ADDRESS", ""),
+		"Serve the compliance check with comments as GitHub pull request webhook on the address, for example :8080",
+	)
+
+	subcommand.PersistentFlags().StringVar(
+		&checkPROpts.WebhookSecret,
+		"webhook-secret",
+		env.Default("WEBHOOK_SECRET", ""),
+		"Secret of the GitHub webhook for verifying the payload signatures",
+	)
 }
 
 func addCheckPR(parent *cobra.Command) {
@@ -146,6 +197,17 @@ NONE
 Either of these will instruct the release note checked to allow a PR without a
 valid note.
 
+--compliance additionally verifies that the release note block matches the
+labels of the PR: NONE notes must not be labeled release-note, notes must
+not be labeled release-note-none, "action required" has to match the
+release-note-action-required label and a kind/ label has to be set. Using
+--comment posts the problems as actionable comment on the PR, which gets
+updated by subsequent checks and deleted once the PR is compliant.
+
+Setting --webhook-address serves the compliance check with comments as GitHub
+webhook for pull request events, which have to be signed using
+--webhook-secret.
+
 To generate release notes from these blocks, use release-notes generate.
 
 
@@ -154,6 +216,13 @@ To generate release notes from these blocks, use release-notes generate.
 		SilenceUsage:  false,
 		SilenceErrors: false,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if checkPROpts.WebhookAddress != "" {
+				return serveComplianceWebhook(checkPROpts)
+			}
+			if checkPROpts.Compliance || checkPROpts.Comment {
+				return checkCompliance(checkPROpts)
+			}
+
 			g, err := notes.NewGatherer(context.Background(), &options.Options{
 				GithubBaseURL: checkPROpts.GithubBaseURL,
 				GithubOrg:     checkPROpts.GithubOrg,
@@ -187,3 +256,48 @@ To generate release notes from these blocks, use release-notes generate.
 	addCheckPRFlags(checkprCmd)
 	parent.AddCommand(checkprCmd)
 }
+
+// checkCompliance checks the compliance of all pull requests.
+func checkCompliance(opts *checkPROptions) error {
+	checker := compliance.New(&compliance.Options{Comment: opts.Comment})
+
+	errs := []error{}
+	for _, prNr := range opts.PullRequests {
+		res, err := checker.Check(opts.GithubOrg, opts.GithubRepo, prNr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("checking compliance of PR #%d: %w", prNr, err))
+			continue
+		}
+		for _, problem := range res.Problems {
+			errs = append(errs, fmt.Errorf("PR #%d: %s", prNr, problem))
+		}
+	}
+
+	if len(errs) > 0 {
+		fmt.Fprintf(os.Stderr, "\nError Checking Release Notes:\n\n"+pullRequestGuidance)
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// serveComplianceWebhook serves the compliance check as GitHub webhook.
+func serveComplianceWebhook(opts *checkPROptions) error {
+	srv := newComplianceWebhook(opts)
+	logrus.Infof("Serving release notes compliance webhook on %s", opts.WebhookAddress)
+	return srv.ListenAndServe()
+}
+
+// newComplianceWebhook returns the server of the compliance webhook. The
+// secret can be set by flag as well, which is why it gets registered for
+// being redacted from the log output in addition to the WEBHOOK_SECRET.
+func newComplianceWebhook(opts *checkPROptions) *http.Server {
+	redact.Register(opts.WebhookSecret)
+	return &http.Server{
+		Addr: opts.WebhookAddress,
+		Handler: compliance.New(&compliance.Options{
+			Comment:       true,
+			WebhookSecret: opts.WebhookSecret,
+		}),
+		ReadHeaderTimeout: webhookReadHeaderTimeout,
+	}
+}
@@ -17,10 +17,24 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
+	"k8s.io/release/pkg/logging"
+	"k8s.io/release/pkg/notes/compliance"
+	"k8s.io/release/pkg/notes/compliance/compliancefakes"
 	"k8s.io/release/pkg/notes/options"
+	"k8s.io/release/pkg/redact"
 )
 
 func TestPROptsValidateAndFinish(t *testing.T) {
@@ -84,6 +98,21 @@ func TestPROptsValidateAndFinish(t *testing.T) {
 			},
 			mustErr: true,
 		},
+		{
+			name: "webhook",
+			sut: checkPROptions{
+				WebhookAddress: ":8080",
+				WebhookSecret:  "secret",
+			},
+			mustErr: false,
+		},
+		{
+			name: "webhook without secret",
+			sut: checkPROptions{
+				WebhookAddress: ":8080",
+			},
+			mustErr: true,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.mustErr {
@@ -94,3 +123,56 @@ func TestPROptsValidateAndFinish(t *testing.T) {
 		})
 	}
 }
+
+func TestComplianceWebhookRedactsSecret(t *testing.T) {
+	const secret = "webhook-secret-value"
+
+	logger := logrus.StandardLogger()
+	oldOut, oldFormatter, oldLevel := logger.Out, logger.Formatter, logger.Level
+	defer func() {
+		logger.SetOutput(oldOut)
+		logger.SetFormatter(oldFormatter)
+		logger.SetLevel(oldLevel)
+		redact.Reset()
+	}()
+	require.NoError(t, logging.Setup(logging.DefaultOptions()))
+
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+
+	srv := newComplianceWebhook(&checkPROptions{
+		WebhookAddress: ":8080",
+		WebhookSecret:  secret,
+	})
+	checker, ok := srv.Handler.(*compliance.Checker)
+	require.True(t, ok)
+	mock := &compliancefakes.FakeImpl{}
+	mock.ListCommentsReturns(nil, fmt.Errorf("request signed with %s failed", secret))
+	checker.SetImpl(mock)
+
+	body := "no block"
+	payload, err := json.Marshal(&gogithub.PullRequestEvent{
+		Action:      gogithub.String("edited"),
+		PullRequest: &gogithub.PullRequest{Number: gogithub.Int(42), Body: &body},
+		Repo: &gogithub.Repository{
+			Name:  gogithub.String("release"),
+			Owner: &gogithub.User{Login: gogithub.String("kubernetes")},
+		},
+	})
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadGateway, rec.Code)
+
+	require.Contains(t, buf.String(), "Unable to check pull request")
+	require.Contains(t, buf.String(), redact.Placeholder)
+	require.NotContains(t, buf.String(), secret)
+}
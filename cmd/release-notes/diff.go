@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/notes/diff"
+	"k8s.io/release/pkg/notes/options"
+)
+
+type diffOptions struct {
+	format        string
+	outputFile    string
+	failOnChanges bool
+}
+
+var diffOpts = &diffOptions{}
+
+// addDiff adds the diff subcommand to the main release notes cobra cmd.
+func addDiff(parent *cobra.Command) {
+	diffCmd := &cobra.Command{
+		Short: "Compare two release notes JSON documents",
+		Long: `release-notes diff <old.json> <new.json>
+
+Compares two release notes documents generated with --format=json, for example
+of the last release candidate and the final release, and reports all added,
+removed and edited notes.
+
+Notes are edited if their markdown, kinds, SIGs, areas or one of the feature,
+action required and do not publish flags changed.`,
+		Use:           "diff",
+		Example:       "release-notes diff release-notes-rc.1.json release-notes.json --fail-on-changes",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(diffOpts, args[0], args[1])
+		},
+	}
+
+	diffCmd.PersistentFlags().StringVar(
+		&diffOpts.format,
+		"format",
+		options.FormatMarkdown,
+		fmt.Sprintf("The format of the diff report (options: %s, %s)", options.FormatJSON, options.FormatMarkdown),
+	)
+
+	diffCmd.PersistentFlags().StringVar(
+		&diffOpts.outputFile,
+		"output",
+		"",
+		"The path where the diff report will be written, defaults to stdout",
+	)
+
+	diffCmd.PersistentFlags().BoolVar(
+		&diffOpts.failOnChanges,
+		"fail-on-changes",
+		false,
+		"Exit with an error if the documents differ",
+	)
+
+	parent.AddCommand(diffCmd)
+}
+
+func runDiff(opts *diffOptions, oldFile, newFile string) error {
+	if opts.format != options.FormatJSON && opts.format != options.FormatMarkdown {
+		return fmt.Errorf("invalid format: %s", opts.format)
+	}
+
+	res, err := diff.CompareFiles(oldFile, newFile)
+	if err != nil {
+		return fmt.Errorf("comparing release notes: %w", err)
+	}
+
+	var report string
+	if opts.format == options.FormatJSON {
+		content, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal diff: %w", err)
+		}
+		report = string(content) + "\n"
+	} else {
+		report = res.Markdown()
+	}
+
+	if opts.outputFile == "" {
+		fmt.Print(report)
+	} else {
+		if err := os.WriteFile(opts.outputFile, []byte(report), os.FileMode(0o644)); err != nil {
+			return fmt.Errorf("writing output file: %w", err)
+		}
+		logrus.Infof("Release notes diff written to file: %s", opts.outputFile)
+	}
+
+	if opts.failOnChanges && !res.Empty() {
+		return errors.New("the release notes documents differ")
+	}
+	return nil
+}
@@ -113,6 +113,40 @@ func addGenerateFlags(subcommand *cobra.Command) {
 		"The git revision to end at. Can be used as alternative to end-sha.",
 	)
 
+	// startDate can be used to resolve the start revision from the branch
+	// history. Can be used as alternative to start-sha and start-rev.
+	subcommand.PersistentFlags().StringVar(
+		&releaseNotesOpts.startDate,
+		"start-date",
+		env.Default("START_DATE", ""),
+		"The date (YYYY-MM-DD or RFC3339) to start at, which uses the last commit of the branch before it. Can be used as alternative to start-sha and start-rev.",
+	)
+
+	// endDate can be used to resolve the end revision from the branch
+	// history. Can be used as alternative to end-sha and end-rev.
+	subcommand.PersistentFlags().StringVar(
+		&releaseNotesOpts.endDate,
+		"end-date",
+		env.Default("END_DATE", ""),
+		"The date (YYYY-MM-DD or RFC3339) to end at, which uses the last commit of the branch before it. Defaults to the head of the branch if only start-date is set.",
+	)
+
+	// catalogExport is the path of the message catalog for translation teams.
+	subcommand.PersistentFlags().StringVar(
+		&releaseNotesOpts.catalogExport,
+		"catalog-export",
+		env.Default("CATALOG_EXPORT", ""),
+		"Export the release notes as YAML message catalog to the path. Translations of an existing catalog are kept.",
+	)
+
+	// catalogDir contains the translated message catalogs.
+	subcommand.PersistentFlags().StringVar(
+		&releaseNotesOpts.catalogDir,
+		"catalog-dir",
+		env.Default("CATALOG_DIR", ""),
+		"Directory of translated YAML message catalogs, used to render the markdown release notes additionally in every language next to the output file.",
+	)
+
 	// repoPath contains the path to a local Kubernetes repository to avoid the
 	// delay during git clone
 	subcommand.PersistentFlags().StringVar(
@@ -160,6 +194,20 @@ func addGenerateFlags(subcommand *cobra.Command) {
 		"Only commits from this GitHub user are considered. Set to empty string to include all users",
 	)
 
+	subcommand.PersistentFlags().StringSliceVar(
+		&opts.Paths,
+		"path",
+		[]string{},
+		"Only consider pull requests which change files below one of the repository paths, for example staging/src/k8s.io/client-go. Can be specified multiple times.",
+	)
+
+	subcommand.PersistentFlags().StringSliceVar(
+		&opts.AuthorFilters,
+		"author-filter",
+		[]string{},
+		"Exclude or group the notes of bot and automation accounts, using the format <author>[:<kind>]=<exclude|group|include>, for example dependabot[bot]=exclude. Can be specified multiple times.",
+	)
+
 	subcommand.PersistentFlags().BoolVar(
 		&opts.Debug,
 		"debug",
@@ -255,6 +303,9 @@ func addGenerate(parent *cobra.Command) {
 			return WriteReleaseNotes(releaseNotes)
 		},
 		PreRunE: func(*cobra.Command, []string) error {
+			if err := releaseNotesOpts.parseDates(opts); err != nil {
+				return err
+			}
 			return opts.ValidateAndFinish()
 		},
 	}
@@ -262,3 +313,18 @@ func addGenerate(parent *cobra.Command) {
 	addGenerateFlags(generateCmd)
 	parent.AddCommand(generateCmd)
 }
+
+// parseDates sets the date range of the release notes options if provided.
+func (o *releaseNotesOptions) parseDates(opts *options.Options) (err error) {
+	if o.startDate != "" {
+		if opts.StartDate, err = options.ParseDate(o.startDate); err != nil {
+			return fmt.Errorf("parsing start date: %w", err)
+		}
+	}
+	if o.endDate != "" {
+		if opts.EndDate, err = options.ParseDate(o.endDate); err != nil {
+			return fmt.Errorf("parsing end date: %w", err)
+		}
+	}
+	return nil
+}
@@ -26,9 +26,14 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"k8s.io/release/pkg/ghauth"
+	"k8s.io/release/pkg/logging"
+	"k8s.io/release/pkg/network"
 	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/catalog"
 	"k8s.io/release/pkg/notes/document"
 	"k8s.io/release/pkg/notes/options"
+	"k8s.io/release/pkg/workdir"
 	"sigs.k8s.io/mdtoc/pkg/mdtoc"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-utils/log"
@@ -39,6 +44,10 @@ type releaseNotesOptions struct {
 	outputFile      string
 	tableOfContents bool
 	dependencies    bool
+	startDate       string
+	endDate         string
+	catalogExport   string
+	catalogDir      string
 }
 
 var (
@@ -64,7 +73,7 @@ func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
 			return fmt.Errorf("opening the supplied output file: %w", err)
 		}
 	} else {
-		output, err = os.CreateTemp("", "release-notes-")
+		output, err = workdir.CreateTemp("release-notes-")
 		if err != nil {
 			return fmt.Errorf("creating a temporary file to write the release notes to: %w", err)
 		}
@@ -105,46 +114,30 @@ func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
 			return fmt.Errorf("encoding JSON output: %w", err)
 		}
 	} else {
-		doc, err := document.New(releaseNotes, opts.StartRev, opts.EndRev)
+		deps, err := dependencyReport()
 		if err != nil {
-			return fmt.Errorf("creating release note document: %w", err)
+			return err
 		}
 
-		markdown, err := doc.RenderMarkdownTemplate(opts.ReleaseBucket, opts.ReleaseTars, "", opts.GoTemplate)
+		markdown, err := renderMarkdown(releaseNotes, deps)
 		if err != nil {
-			return fmt.Errorf("rendering release note document with template: %w", err)
-		}
-
-		const nl = "\n"
-		if releaseNotesOpts.dependencies {
-			if opts.StartSHA == opts.EndSHA {
-				logrus.Info("Skipping dependency report because start and end SHA are the same")
-			} else {
-				url := git.GetRepoURL(opts.GithubOrg, opts.GithubRepo, false)
-				deps, err := notes.NewDependencies().ChangesForURL(
-					url, opts.StartSHA, opts.EndSHA,
-				)
-				if err != nil {
-					return fmt.Errorf("generating dependency report: %w", err)
-				}
-				markdown += strings.Repeat(nl, 2) + deps
-			}
+			return err
+		}
+
+		if _, err := output.WriteString(markdown); err != nil {
+			return fmt.Errorf("writing output file: %w", err)
 		}
 
-		if releaseNotesOpts.tableOfContents {
-			toc, err := mdtoc.GenerateTOC([]byte(markdown), mdtoc.Options{
-				Dryrun:     false,
-				SkipPrefix: false,
-				MaxDepth:   mdtoc.MaxHeaderDepth,
-			})
-			if err != nil {
-				return fmt.Errorf("generating table of contents: %w", err)
+		if releaseNotesOpts.catalogDir != "" {
+			if err := writeLocalizedReleaseNotes(releaseNotes, deps, output.Name()); err != nil {
+				return fmt.Errorf("writing localized release notes: %w", err)
 			}
-			markdown = toc + nl + markdown
 		}
+	}
 
-		if _, err := output.WriteString(markdown); err != nil {
-			return fmt.Errorf("writing output file: %w", err)
+	if releaseNotesOpts.catalogExport != "" {
+		if err := exportCatalog(releaseNotes, releaseNotesOpts.catalogExport); err != nil {
+			return fmt.Errorf("exporting message catalog: %w", err)
 		}
 	}
 
@@ -152,6 +145,106 @@ func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
 	return nil
 }
 
+// dependencyReport returns the dependency changes between the start and end
+// SHA, or an empty string if the report is disabled.
+func dependencyReport() (string, error) {
+	if !releaseNotesOpts.dependencies {
+		return "", nil
+	}
+	if opts.StartSHA == opts.EndSHA {
+		logrus.Info("Skipping dependency report because start and end SHA are the same")
+		return "", nil
+	}
+
+	url := git.GetRepoURL(opts.GithubOrg, opts.GithubRepo, false)
+	deps, err := notes.NewDependencies().ChangesForURL(
+		url, opts.StartSHA, opts.EndSHA,
+	)
+	if err != nil {
+		return "", fmt.Errorf("generating dependency report: %w", err)
+	}
+	return deps, nil
+}
+
+// renderMarkdown renders the release notes document including the
+// dependency report and table of contents if enabled.
+func renderMarkdown(releaseNotes *notes.ReleaseNotes, deps string) (string, error) {
+	doc, err := document.New(releaseNotes, opts.StartRev, opts.EndRev)
+	if err != nil {
+		return "", fmt.Errorf("creating release note document: %w", err)
+	}
+
+	markdown, err := doc.RenderMarkdownTemplate(opts.ReleaseBucket, opts.ReleaseTars, "", opts.GoTemplate)
+	if err != nil {
+		return "", fmt.Errorf("rendering release note document with template: %w", err)
+	}
+
+	const nl = "\n"
+	if deps != "" {
+		markdown += strings.Repeat(nl, 2) + deps
+	}
+
+	if releaseNotesOpts.tableOfContents {
+		toc, err := mdtoc.GenerateTOC([]byte(markdown), mdtoc.Options{
+			Dryrun:     false,
+			SkipPrefix: false,
+			MaxDepth:   mdtoc.MaxHeaderDepth,
+		})
+		if err != nil {
+			return "", fmt.Errorf("generating table of contents: %w", err)
+		}
+		markdown = toc + nl + markdown
+	}
+	return markdown, nil
+}
+
+// writeLocalizedReleaseNotes renders the release notes for every catalog of
+// the catalog dir next to the output file.
+func writeLocalizedReleaseNotes(releaseNotes *notes.ReleaseNotes, deps, outputFile string) error {
+	catalogs, err := catalog.LoadDir(releaseNotesOpts.catalogDir)
+	if err != nil {
+		return fmt.Errorf("loading message catalogs: %w", err)
+	}
+
+	for _, c := range catalogs {
+		markdown, err := renderMarkdown(c.Localize(releaseNotes), deps)
+		if err != nil {
+			return fmt.Errorf("rendering %s release notes: %w", c.Language, err)
+		}
+
+		path := catalog.LocalizedPath(outputFile, c.Language)
+		if err := os.WriteFile(path, []byte(markdown), os.FileMode(0o644)); err != nil {
+			return fmt.Errorf("writing %s release notes: %w", c.Language, err)
+		}
+		logrus.Infof("Release notes in %s written to file: %s", c.Language, path)
+	}
+	return nil
+}
+
+// exportCatalog writes the message catalog of the release notes. The
+// translations of an already existing catalog are kept.
+func exportCatalog(releaseNotes *notes.ReleaseNotes, path string) error {
+	language := catalog.SourceLanguage
+	var existing *catalog.Catalog
+	if _, err := os.Stat(path); err == nil {
+		existing, err = catalog.Load(path)
+		if err != nil {
+			return err
+		}
+		language = existing.Language
+	}
+
+	c := catalog.Export(releaseNotes, language)
+	if existing != nil {
+		c.Merge(existing)
+	}
+	if err := c.Save(path); err != nil {
+		return err
+	}
+	logrus.Infof("Message catalog with %d notes written to file: %s", len(c.Messages), path)
+	return nil
+}
+
 // hackDefaultSubcommand is a utility function that hacks the "generate"
 // subcommand as default to avoid breaking compatibility with previoud
 // versions of release-notes.
@@ -175,7 +268,6 @@ func hackDefaultSubcommand(cmd *cobra.Command) {
 
 func main() {
 	logrus.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
-	logrus.AddHook(log.NewFilenameHook())
 
 	cmd := &cobra.Command{
 		Short:         "release-notes - The Kubernetes Release Notes Generator",
@@ -184,8 +276,33 @@ func main() {
 		SilenceErrors: true,
 	}
 
+	loggingOpts := logging.DefaultOptions()
+	cmd.PersistentFlags().StringVar(
+		&loggingOpts.Level,
+		"log-level",
+		loggingOpts.Level,
+		fmt.Sprintf("the logging verbosity, either %s", log.LevelNames()),
+	)
+	loggingOpts.AddFlags(cmd.PersistentFlags())
+
+	networkOpts := network.DefaultOptions()
+	networkOpts.AddFlags(cmd.PersistentFlags())
+
+	ghauthOpts := ghauth.DefaultOptions()
+	ghauthOpts.AddFlags(cmd.PersistentFlags())
+	cmd.PersistentPreRunE = func(*cobra.Command, []string) error {
+		if err := logging.Setup(loggingOpts); err != nil {
+			return err
+		}
+		if err := network.Setup(networkOpts); err != nil {
+			return fmt.Errorf("setup network: %w", err)
+		}
+		return ghauth.Setup(ghauthOpts)
+	}
+
 	addGenerate(cmd)
 	addCheckPR(cmd)
+	addDiff(cmd)
 
 	cmd.AddCommand(version.WithFont("slant"))
 
@@ -24,6 +24,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"k8s.io/release/pkg/logging"
 	"sigs.k8s.io/release-utils/log"
 	"sigs.k8s.io/release-utils/version"
 	"sigs.k8s.io/yaml"
@@ -73,6 +74,9 @@ func Execute() {
 	}
 }
 
+// loggingOpts are the options of the global logger.
+var loggingOpts = logging.DefaultOptions()
+
 func init() {
 	rootCmd.PersistentFlags().StringVarP(
 		&opts.configPath,
@@ -106,6 +110,8 @@ func init() {
 		fmt.Sprintf("the logging verbosity, either %s", log.LevelNames()),
 	)
 
+	loggingOpts.AddFlags(rootCmd.PersistentFlags())
+
 	rootCmd.PersistentFlags().StringVarP(
 		&opts.typeFile,
 		typeFlag,
@@ -132,7 +138,8 @@ func init() {
 }
 
 func initLogging(*cobra.Command, []string) error {
-	return log.SetupGlobalLogger(opts.logLevel)
+	loggingOpts.Level = opts.logLevel
+	return logging.Setup(loggingOpts)
 }
 
 func run(opts *options) error {
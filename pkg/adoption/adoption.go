@@ -0,0 +1,390 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package adoption polls the download counts of the GitHub release assets
+// and the container image pulls of a release over its first days and
+// generates an adoption report for the release retrospective.
+package adoption
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultDays is the default number of days after the release which get
+	// polled.
+	DefaultDays = 14
+
+	day = 24 * time.Hour
+)
+
+// Options are the main options for the adoption report.
+type Options struct {
+	// Owner is the GitHub organization of the release.
+	Owner string
+
+	// Repo is the GitHub repository of the release.
+	Repo string
+
+	// Tag is the tag of the release.
+	Tag string
+
+	// Days is the number of days after the release which get polled.
+	Days int
+
+	// State is the path of the file persisting the samples between runs.
+	State string
+
+	// RegistryMetricsURL is an optional URL serving the image pulls as JSON
+	// object, which maps image references to pull counts.
+	RegistryMetricsURL string
+
+	// Interval polls continuously until the end of the window if set,
+	// otherwise a single sample is taken.
+	Interval time.Duration
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Owner: "kubernetes",
+		Repo:  "kubernetes",
+		Days:  DefaultDays,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Owner == "" || o.Repo == "" {
+		return errors.New("owner and repository must not be empty")
+	}
+	if o.Tag == "" {
+		return errors.New("tag must not be empty")
+	}
+	if o.Days <= 0 {
+		return fmt.Errorf("days have to be positive, got %d", o.Days)
+	}
+	if o.State == "" {
+		return errors.New("state file must not be empty")
+	}
+	if o.Interval < 0 {
+		return fmt.Errorf("interval must not be negative, got %s", o.Interval)
+	}
+	return nil
+}
+
+// Sample are the download and pull counts at a point in time.
+type Sample struct {
+	Time   time.Time        `json:"time"`
+	Assets map[string]int64 `json:"assets"`
+	Images map[string]int64 `json:"images,omitempty"`
+}
+
+// Total returns the sum of all asset downloads.
+func (s *Sample) Total() int64 {
+	var total int64
+	for _, count := range s.Assets {
+		total += count
+	}
+	return total
+}
+
+// History contains all samples of a release.
+type History struct {
+	Tag         string    `json:"tag"`
+	PublishedAt time.Time `json:"publishedAt"`
+	Samples     []*Sample `json:"samples"`
+}
+
+// Day are the downloads until the end of a day after the release.
+type Day struct {
+	Day       int    `json:"day"`
+	Date      string `json:"date"`
+	Downloads int64  `json:"downloads"`
+	New       int64  `json:"new"`
+}
+
+// Count is the download or pull count of a single asset or image.
+type Count struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// Report is the adoption of a release.
+type Report struct {
+	Tag         string    `json:"tag"`
+	PublishedAt time.Time `json:"publishedAt"`
+	Samples     int       `json:"samples"`
+	Downloads   int64     `json:"downloads"`
+	Days        []*Day    `json:"days"`
+	Assets      []*Count  `json:"assets"`
+	Images      []*Count  `json:"images,omitempty"`
+}
+
+// NewReport summarizes the history over the provided number of days.
+func NewReport(history *History, days int) *Report {
+	report := &Report{
+		Tag:         history.Tag,
+		PublishedAt: history.PublishedAt,
+		Samples:     len(history.Samples),
+		Days:        []*Day{},
+		Assets:      []*Count{},
+	}
+	if len(history.Samples) == 0 {
+		return report
+	}
+
+	samples := append([]*Sample{}, history.Samples...)
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Time.Before(samples[j].Time)
+	})
+
+	var previous int64
+	for d := 1; d <= days; d++ {
+		end := history.PublishedAt.Add(time.Duration(d) * day)
+		var last *Sample
+		for _, s := range samples {
+			if s.Time.Before(end) && !s.Time.Before(end.Add(-day)) {
+				last = s
+			}
+		}
+		if last == nil {
+			continue
+		}
+		total := last.Total()
+		report.Days = append(report.Days, &Day{
+			Day:       d,
+			Date:      last.Time.Format(time.DateOnly),
+			Downloads: total,
+			New:       total - previous,
+		})
+		previous = total
+	}
+
+	latest := samples[len(samples)-1]
+	report.Downloads = latest.Total()
+	report.Assets = counts(latest.Assets)
+	if len(latest.Images) > 0 {
+		report.Images = counts(latest.Images)
+	}
+	return report
+}
+
+// counts returns the counts sorted by the highest first.
+func counts(m map[string]int64) []*Count {
+	res := make([]*Count, 0, len(m))
+	for name, count := range m {
+		res = append(res, &Count{Name: name, Count: count})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count == res[j].Count {
+			return res[i].Name < res[j].Name
+		}
+		return res[i].Count > res[j].Count
+	})
+	return res
+}
+
+// Markdown returns the report as markdown, suitable for the release retro.
+func (r *Report) Markdown() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "### Adoption report for %s\n\n", r.Tag)
+	fmt.Fprintf(
+		buf, "Published on %s, %d downloads of the release assets in %d samples.\n",
+		r.PublishedAt.Format(time.DateOnly), r.Downloads, r.Samples,
+	)
+
+	if len(r.Days) > 0 {
+		buf.WriteString("\n#### Downloads per day\n\n")
+		rows := [][]string{}
+		for _, d := range r.Days {
+			rows = append(rows, []string{
+				strconv.Itoa(d.Day), d.Date, strconv.FormatInt(d.Downloads, 10), fmt.Sprintf("+%d", d.New),
+			})
+		}
+		renderTable(buf, []string{"Day", "Date", "Downloads", "New"}, rows)
+	}
+
+	for _, section := range []struct {
+		title  string
+		header string
+		counts []*Count
+	}{
+		{"Asset downloads", "Asset", r.Assets},
+		{"Image pulls", "Image", r.Images},
+	} {
+		if len(section.counts) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "\n#### %s\n\n", section.title)
+		rows := [][]string{}
+		for _, c := range section.counts {
+			rows = append(rows, []string{c.Name, strconv.FormatInt(c.Count, 10)})
+		}
+		renderTable(buf, []string{section.header, "Count"}, rows)
+	}
+	return buf.String()
+}
+
+func renderTable(buf *bytes.Buffer, header []string, rows [][]string) {
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeader(header)
+	table.AppendBulk(rows)
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+}
+
+// Adoption is the main structure for polling the adoption of a release.
+type Adoption struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Adoption instance.
+func New(opts *Options) *Adoption {
+	return &Adoption{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (a *Adoption) SetImpl(impl impl) {
+	a.impl = impl
+}
+
+// Run samples the download counts while the release is within its first
+// days, persists the samples in the state file and returns the report.
+func (a *Adoption) Run() (*Report, error) {
+	if err := a.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	history, err := a.loadHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := a.impl.GetRelease(a.options.Owner, a.options.Repo, a.options.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("get release %s: %w", a.options.Tag, err)
+	}
+	history.Tag = a.options.Tag
+	history.PublishedAt = release.GetPublishedAt().Time.UTC()
+	end := history.PublishedAt.Add(time.Duration(a.options.Days) * day)
+
+	for {
+		now := a.impl.Now()
+		if !now.Before(end) {
+			logrus.Infof(
+				"Not sampling %s, the first %d days after the release ended on %s",
+				a.options.Tag, a.options.Days, end.Format(time.DateOnly),
+			)
+			break
+		}
+
+		sample, err := a.sample(release.GetID(), now)
+		if err != nil {
+			return nil, err
+		}
+		history.Samples = append(history.Samples, sample)
+		if err := a.saveHistory(history); err != nil {
+			return nil, err
+		}
+		logrus.Infof("Sampled %d downloads of %s", sample.Total(), a.options.Tag)
+
+		if a.options.Interval == 0 {
+			break
+		}
+		wait := a.options.Interval
+		if remaining := end.Sub(now); remaining < wait {
+			wait = remaining
+		}
+		a.impl.Sleep(wait)
+	}
+
+	return NewReport(history, a.options.Days), nil
+}
+
+// sample takes the current download and pull counts.
+func (a *Adoption) sample(releaseID int64, now time.Time) (*Sample, error) {
+	assets, err := a.impl.ListAssets(a.options.Owner, a.options.Repo, releaseID)
+	if err != nil {
+		return nil, fmt.Errorf("list assets of %s: %w", a.options.Tag, err)
+	}
+	sample := &Sample{Time: now, Assets: map[string]int64{}}
+	for _, asset := range assets {
+		sample.Assets[asset.GetName()] = int64(asset.GetDownloadCount())
+	}
+
+	if a.options.RegistryMetricsURL != "" {
+		pulls, err := a.impl.ImagePulls(a.options.RegistryMetricsURL)
+		if err != nil {
+			logrus.Warnf("Unable to get the image pulls: %v", err)
+		} else {
+			sample.Images = pulls
+		}
+	}
+	return sample, nil
+}
+
+func (a *Adoption) loadHistory() (*History, error) {
+	content, err := a.impl.ReadFile(a.options.State)
+	if errors.Is(err, os.ErrNotExist) {
+		logrus.Infof("Starting new adoption history in %s", a.options.State)
+		return &History{Samples: []*Sample{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read adoption history: %w", err)
+	}
+	history := &History{}
+	if err := json.Unmarshal(content, history); err != nil {
+		return nil, fmt.Errorf("unmarshal adoption history: %w", err)
+	}
+	if history.Tag != "" && history.Tag != a.options.Tag {
+		return nil, fmt.Errorf(
+			"adoption history %s belongs to %s, not %s",
+			a.options.State, history.Tag, a.options.Tag,
+		)
+	}
+	return history, nil
+}
+
+func (a *Adoption) saveHistory(history *History) error {
+	content, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal adoption history: %w", err)
+	}
+	if err := a.impl.WriteFile(a.options.State, content, 0o600); err != nil {
+		return fmt.Errorf("write adoption history: %w", err)
+	}
+	return nil
+}
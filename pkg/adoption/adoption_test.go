@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adoption_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/adoption"
+	"k8s.io/release/pkg/adoption/adoptionfakes"
+)
+
+var published = time.Date(2024, 4, 17, 16, 0, 0, 0, time.UTC)
+
+func testRelease() *gogithub.RepositoryRelease {
+	return &gogithub.RepositoryRelease{
+		ID:          gogithub.Int64(1),
+		TagName:     gogithub.String("v1.30.0"),
+		PublishedAt: &gogithub.Timestamp{Time: published},
+	}
+}
+
+func testAssets(counts ...int) []*gogithub.ReleaseAsset {
+	names := []string{"kubernetes.tar.gz", "kubernetes-src.tar.gz"}
+	assets := []*gogithub.ReleaseAsset{}
+	for i, c := range counts {
+		assets = append(assets, &gogithub.ReleaseAsset{
+			Name: gogithub.String(names[i]), DownloadCount: gogithub.Int(c),
+		})
+	}
+	return assets
+}
+
+func TestNewReport(t *testing.T) {
+	history := &adoption.History{
+		Tag:         "v1.30.0",
+		PublishedAt: published,
+		Samples: []*adoption.Sample{
+			{Time: published.Add(30 * time.Hour), Assets: map[string]int64{"a": 20, "b": 5}},
+			{Time: published.Add(2 * time.Hour), Assets: map[string]int64{"a": 5}},
+			{Time: published.Add(20 * time.Hour), Assets: map[string]int64{"a": 10, "b": 2}},
+			{Time: published.Add(80 * time.Hour), Assets: map[string]int64{"a": 40, "b": 10}, Images: map[string]int64{"registry.k8s.io/kube-apiserver:v1.30.0": 100}},
+		},
+	}
+
+	report := adoption.NewReport(history, 3)
+	require.Equal(t, 4, report.Samples)
+	require.EqualValues(t, 50, report.Downloads)
+	require.Equal(t, []*adoption.Day{
+		{Day: 1, Date: "2024-04-18", Downloads: 12, New: 12},
+		{Day: 2, Date: "2024-04-18", Downloads: 25, New: 13},
+	}, report.Days)
+	require.Equal(t, []*adoption.Count{{Name: "a", Count: 40}, {Name: "b", Count: 10}}, report.Assets)
+	require.Equal(t, []*adoption.Count{{Name: "registry.k8s.io/kube-apiserver:v1.30.0", Count: 100}}, report.Images)
+
+	md := report.Markdown()
+	require.Contains(t, md, "### Adoption report for v1.30.0")
+	require.Contains(t, md, "50 downloads of the release assets in 4 samples")
+	require.Contains(t, md, "#### Image pulls")
+
+	empty := adoption.NewReport(&adoption.History{Tag: "v1.30.0"}, 3)
+	require.Empty(t, empty.Days)
+	require.NotContains(t, empty.Markdown(), "#### Asset downloads")
+}
+
+func TestRun(t *testing.T) {
+	existing, err := json.Marshal(&adoption.History{
+		Tag:         "v1.30.0",
+		PublishedAt: published,
+		Samples:     []*adoption.Sample{{Time: published.Add(time.Hour), Assets: map[string]int64{"kubernetes.tar.gz": 1}}},
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		prepare  func(*adoption.Options, *adoptionfakes.FakeImpl)
+		samples  int
+		sleeps   int
+		writes   int
+		imageErr bool
+		err      bool
+	}{
+		{
+			name: "first sample",
+			prepare: func(_ *adoption.Options, mock *adoptionfakes.FakeImpl) {
+				mock.ReadFileReturns(nil, os.ErrNotExist)
+			},
+			samples: 1,
+			writes:  1,
+		},
+		{
+			name: "append to existing history",
+			prepare: func(_ *adoption.Options, mock *adoptionfakes.FakeImpl) {
+				mock.ReadFileReturns(existing, nil)
+			},
+			samples: 2,
+			writes:  1,
+		},
+		{
+			name: "window ended",
+			prepare: func(_ *adoption.Options, mock *adoptionfakes.FakeImpl) {
+				mock.ReadFileReturns(existing, nil)
+				mock.NowReturns(published.Add(15 * 24 * time.Hour))
+			},
+			samples: 1,
+		},
+		{
+			name: "poll until the window ends",
+			prepare: func(opts *adoption.Options, mock *adoptionfakes.FakeImpl) {
+				opts.Days = 2
+				opts.Interval = 24 * time.Hour
+				mock.ReadFileReturns(nil, os.ErrNotExist)
+				mock.NowReturnsOnCall(0, published.Add(time.Hour))
+				mock.NowReturnsOnCall(1, published.Add(25*time.Hour))
+				mock.NowReturnsOnCall(2, published.Add(48*time.Hour))
+			},
+			samples: 2,
+			sleeps:  2,
+			writes:  2,
+		},
+		{
+			name: "image pulls are optional",
+			prepare: func(opts *adoption.Options, mock *adoptionfakes.FakeImpl) {
+				opts.RegistryMetricsURL = "https://metrics.example.com/pulls.json"
+				mock.ReadFileReturns(nil, os.ErrNotExist)
+				mock.ImagePullsReturns(nil, errors.New(""))
+			},
+			samples: 1,
+			writes:  1,
+		},
+		{
+			name: "history of other release",
+			prepare: func(opts *adoption.Options, mock *adoptionfakes.FakeImpl) {
+				opts.Tag = "v1.30.1"
+				mock.ReadFileReturns(existing, nil)
+			},
+			err: true,
+		},
+		{
+			name: "failure on getting release",
+			prepare: func(_ *adoption.Options, mock *adoptionfakes.FakeImpl) {
+				mock.ReadFileReturns(nil, os.ErrNotExist)
+				mock.GetReleaseReturns(nil, errors.New(""))
+			},
+			err: true,
+		},
+		{
+			name: "failure on listing assets",
+			prepare: func(_ *adoption.Options, mock *adoptionfakes.FakeImpl) {
+				mock.ReadFileReturns(nil, os.ErrNotExist)
+				mock.ListAssetsReturns(nil, errors.New(""))
+			},
+			err: true,
+		},
+		{
+			name: "failure on writing history",
+			prepare: func(_ *adoption.Options, mock *adoptionfakes.FakeImpl) {
+				mock.ReadFileReturns(nil, os.ErrNotExist)
+				mock.WriteFileReturns(errors.New(""))
+			},
+			writes: 1,
+			err:    true,
+		},
+		{
+			name: "invalid options",
+			prepare: func(opts *adoption.Options, _ *adoptionfakes.FakeImpl) {
+				opts.Days = 0
+			},
+			err: true,
+		},
+	} {
+		opts := adoption.DefaultOptions()
+		opts.Tag = "v1.30.0"
+		opts.State = "adoption.json"
+
+		mock := &adoptionfakes.FakeImpl{}
+		mock.GetReleaseReturns(testRelease(), nil)
+		mock.ListAssetsReturns(testAssets(10, 2), nil)
+		mock.NowReturns(published.Add(2 * time.Hour))
+		tc.prepare(opts, mock)
+
+		sut := adoption.New(opts)
+		sut.SetImpl(mock)
+
+		report, err := sut.Run()
+		require.Equal(t, tc.writes, mock.WriteFileCallCount(), tc.name)
+		require.Equal(t, tc.sleeps, mock.SleepCallCount(), tc.name)
+		if tc.err {
+			require.Error(t, err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.samples, report.Samples, tc.name)
+		require.Equal(t, published, report.PublishedAt, tc.name)
+	}
+}
@@ -0,0 +1,564 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package adoptionfakes
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v58/github"
+)
+
+type FakeImpl struct {
+	GetReleaseStub        func(string, string, string) (*github.RepositoryRelease, error)
+	getReleaseMutex       sync.RWMutex
+	getReleaseArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getReleaseReturns struct {
+		result1 *github.RepositoryRelease
+		result2 error
+	}
+	getReleaseReturnsOnCall map[int]struct {
+		result1 *github.RepositoryRelease
+		result2 error
+	}
+	ImagePullsStub        func(string) (map[string]int64, error)
+	imagePullsMutex       sync.RWMutex
+	imagePullsArgsForCall []struct {
+		arg1 string
+	}
+	imagePullsReturns struct {
+		result1 map[string]int64
+		result2 error
+	}
+	imagePullsReturnsOnCall map[int]struct {
+		result1 map[string]int64
+		result2 error
+	}
+	ListAssetsStub        func(string, string, int64) ([]*github.ReleaseAsset, error)
+	listAssetsMutex       sync.RWMutex
+	listAssetsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}
+	listAssetsReturns struct {
+		result1 []*github.ReleaseAsset
+		result2 error
+	}
+	listAssetsReturnsOnCall map[int]struct {
+		result1 []*github.ReleaseAsset
+		result2 error
+	}
+	NowStub        func() time.Time
+	nowMutex       sync.RWMutex
+	nowArgsForCall []struct {
+	}
+	nowReturns struct {
+		result1 time.Time
+	}
+	nowReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	ReadFileStub        func(string) ([]byte, error)
+	readFileMutex       sync.RWMutex
+	readFileArgsForCall []struct {
+		arg1 string
+	}
+	readFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	SleepStub        func(time.Duration)
+	sleepMutex       sync.RWMutex
+	sleepArgsForCall []struct {
+		arg1 time.Duration
+	}
+	WriteFileStub        func(string, []byte, os.FileMode) error
+	writeFileMutex       sync.RWMutex
+	writeFileArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+		arg3 os.FileMode
+	}
+	writeFileReturns struct {
+		result1 error
+	}
+	writeFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) GetRelease(arg1 string, arg2 string, arg3 string) (*github.RepositoryRelease, error) {
+	fake.getReleaseMutex.Lock()
+	ret, specificReturn := fake.getReleaseReturnsOnCall[len(fake.getReleaseArgsForCall)]
+	fake.getReleaseArgsForCall = append(fake.getReleaseArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetReleaseStub
+	fakeReturns := fake.getReleaseReturns
+	fake.recordInvocation("GetRelease", []interface{}{arg1, arg2, arg3})
+	fake.getReleaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GetReleaseCallCount() int {
+	fake.getReleaseMutex.RLock()
+	defer fake.getReleaseMutex.RUnlock()
+	return len(fake.getReleaseArgsForCall)
+}
+
+func (fake *FakeImpl) GetReleaseCalls(stub func(string, string, string) (*github.RepositoryRelease, error)) {
+	fake.getReleaseMutex.Lock()
+	defer fake.getReleaseMutex.Unlock()
+	fake.GetReleaseStub = stub
+}
+
+func (fake *FakeImpl) GetReleaseArgsForCall(i int) (string, string, string) {
+	fake.getReleaseMutex.RLock()
+	defer fake.getReleaseMutex.RUnlock()
+	argsForCall := fake.getReleaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) GetReleaseReturns(result1 *github.RepositoryRelease, result2 error) {
+	fake.getReleaseMutex.Lock()
+	defer fake.getReleaseMutex.Unlock()
+	fake.GetReleaseStub = nil
+	fake.getReleaseReturns = struct {
+		result1 *github.RepositoryRelease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GetReleaseReturnsOnCall(i int, result1 *github.RepositoryRelease, result2 error) {
+	fake.getReleaseMutex.Lock()
+	defer fake.getReleaseMutex.Unlock()
+	fake.GetReleaseStub = nil
+	if fake.getReleaseReturnsOnCall == nil {
+		fake.getReleaseReturnsOnCall = make(map[int]struct {
+			result1 *github.RepositoryRelease
+			result2 error
+		})
+	}
+	fake.getReleaseReturnsOnCall[i] = struct {
+		result1 *github.RepositoryRelease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ImagePulls(arg1 string) (map[string]int64, error) {
+	fake.imagePullsMutex.Lock()
+	ret, specificReturn := fake.imagePullsReturnsOnCall[len(fake.imagePullsArgsForCall)]
+	fake.imagePullsArgsForCall = append(fake.imagePullsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ImagePullsStub
+	fakeReturns := fake.imagePullsReturns
+	fake.recordInvocation("ImagePulls", []interface{}{arg1})
+	fake.imagePullsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ImagePullsCallCount() int {
+	fake.imagePullsMutex.RLock()
+	defer fake.imagePullsMutex.RUnlock()
+	return len(fake.imagePullsArgsForCall)
+}
+
+func (fake *FakeImpl) ImagePullsCalls(stub func(string) (map[string]int64, error)) {
+	fake.imagePullsMutex.Lock()
+	defer fake.imagePullsMutex.Unlock()
+	fake.ImagePullsStub = stub
+}
+
+func (fake *FakeImpl) ImagePullsArgsForCall(i int) string {
+	fake.imagePullsMutex.RLock()
+	defer fake.imagePullsMutex.RUnlock()
+	argsForCall := fake.imagePullsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ImagePullsReturns(result1 map[string]int64, result2 error) {
+	fake.imagePullsMutex.Lock()
+	defer fake.imagePullsMutex.Unlock()
+	fake.ImagePullsStub = nil
+	fake.imagePullsReturns = struct {
+		result1 map[string]int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ImagePullsReturnsOnCall(i int, result1 map[string]int64, result2 error) {
+	fake.imagePullsMutex.Lock()
+	defer fake.imagePullsMutex.Unlock()
+	fake.ImagePullsStub = nil
+	if fake.imagePullsReturnsOnCall == nil {
+		fake.imagePullsReturnsOnCall = make(map[int]struct {
+			result1 map[string]int64
+			result2 error
+		})
+	}
+	fake.imagePullsReturnsOnCall[i] = struct {
+		result1 map[string]int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListAssets(arg1 string, arg2 string, arg3 int64) ([]*github.ReleaseAsset, error) {
+	fake.listAssetsMutex.Lock()
+	ret, specificReturn := fake.listAssetsReturnsOnCall[len(fake.listAssetsArgsForCall)]
+	fake.listAssetsArgsForCall = append(fake.listAssetsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	stub := fake.ListAssetsStub
+	fakeReturns := fake.listAssetsReturns
+	fake.recordInvocation("ListAssets", []interface{}{arg1, arg2, arg3})
+	fake.listAssetsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ListAssetsCallCount() int {
+	fake.listAssetsMutex.RLock()
+	defer fake.listAssetsMutex.RUnlock()
+	return len(fake.listAssetsArgsForCall)
+}
+
+func (fake *FakeImpl) ListAssetsCalls(stub func(string, string, int64) ([]*github.ReleaseAsset, error)) {
+	fake.listAssetsMutex.Lock()
+	defer fake.listAssetsMutex.Unlock()
+	fake.ListAssetsStub = stub
+}
+
+func (fake *FakeImpl) ListAssetsArgsForCall(i int) (string, string, int64) {
+	fake.listAssetsMutex.RLock()
+	defer fake.listAssetsMutex.RUnlock()
+	argsForCall := fake.listAssetsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) ListAssetsReturns(result1 []*github.ReleaseAsset, result2 error) {
+	fake.listAssetsMutex.Lock()
+	defer fake.listAssetsMutex.Unlock()
+	fake.ListAssetsStub = nil
+	fake.listAssetsReturns = struct {
+		result1 []*github.ReleaseAsset
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListAssetsReturnsOnCall(i int, result1 []*github.ReleaseAsset, result2 error) {
+	fake.listAssetsMutex.Lock()
+	defer fake.listAssetsMutex.Unlock()
+	fake.ListAssetsStub = nil
+	if fake.listAssetsReturnsOnCall == nil {
+		fake.listAssetsReturnsOnCall = make(map[int]struct {
+			result1 []*github.ReleaseAsset
+			result2 error
+		})
+	}
+	fake.listAssetsReturnsOnCall[i] = struct {
+		result1 []*github.ReleaseAsset
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Now() time.Time {
+	fake.nowMutex.Lock()
+	ret, specificReturn := fake.nowReturnsOnCall[len(fake.nowArgsForCall)]
+	fake.nowArgsForCall = append(fake.nowArgsForCall, struct {
+	}{})
+	stub := fake.NowStub
+	fakeReturns := fake.nowReturns
+	fake.recordInvocation("Now", []interface{}{})
+	fake.nowMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) NowCallCount() int {
+	fake.nowMutex.RLock()
+	defer fake.nowMutex.RUnlock()
+	return len(fake.nowArgsForCall)
+}
+
+func (fake *FakeImpl) NowCalls(stub func() time.Time) {
+	fake.nowMutex.Lock()
+	defer fake.nowMutex.Unlock()
+	fake.NowStub = stub
+}
+
+func (fake *FakeImpl) NowReturns(result1 time.Time) {
+	fake.nowMutex.Lock()
+	defer fake.nowMutex.Unlock()
+	fake.NowStub = nil
+	fake.nowReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeImpl) NowReturnsOnCall(i int, result1 time.Time) {
+	fake.nowMutex.Lock()
+	defer fake.nowMutex.Unlock()
+	fake.NowStub = nil
+	if fake.nowReturnsOnCall == nil {
+		fake.nowReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.nowReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeImpl) ReadFile(arg1 string) ([]byte, error) {
+	fake.readFileMutex.Lock()
+	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
+	fake.readFileArgsForCall = append(fake.readFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReadFileStub
+	fakeReturns := fake.readFileReturns
+	fake.recordInvocation("ReadFile", []interface{}{arg1})
+	fake.readFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ReadFileCallCount() int {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return len(fake.readFileArgsForCall)
+}
+
+func (fake *FakeImpl) ReadFileCalls(stub func(string) ([]byte, error)) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = stub
+}
+
+func (fake *FakeImpl) ReadFileArgsForCall(i int) string {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	argsForCall := fake.readFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ReadFileReturns(result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	fake.readFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	if fake.readFileReturnsOnCall == nil {
+		fake.readFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Sleep(arg1 time.Duration) {
+	fake.sleepMutex.Lock()
+	fake.sleepArgsForCall = append(fake.sleepArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.SleepStub
+	fake.recordInvocation("Sleep", []interface{}{arg1})
+	fake.sleepMutex.Unlock()
+	if stub != nil {
+		fake.SleepStub(arg1)
+	}
+}
+
+func (fake *FakeImpl) SleepCallCount() int {
+	fake.sleepMutex.RLock()
+	defer fake.sleepMutex.RUnlock()
+	return len(fake.sleepArgsForCall)
+}
+
+func (fake *FakeImpl) SleepCalls(stub func(time.Duration)) {
+	fake.sleepMutex.Lock()
+	defer fake.sleepMutex.Unlock()
+	fake.SleepStub = stub
+}
+
+func (fake *FakeImpl) SleepArgsForCall(i int) time.Duration {
+	fake.sleepMutex.RLock()
+	defer fake.sleepMutex.RUnlock()
+	argsForCall := fake.sleepArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte, arg3 os.FileMode) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeFileMutex.Lock()
+	ret, specificReturn := fake.writeFileReturnsOnCall[len(fake.writeFileArgsForCall)]
+	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+		arg3 os.FileMode
+	}{arg1, arg2Copy, arg3})
+	stub := fake.WriteFileStub
+	fakeReturns := fake.writeFileReturns
+	fake.recordInvocation("WriteFile", []interface{}{arg1, arg2Copy, arg3})
+	fake.writeFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) WriteFileCallCount() int {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return len(fake.writeFileArgsForCall)
+}
+
+func (fake *FakeImpl) WriteFileCalls(stub func(string, []byte, os.FileMode) error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = stub
+}
+
+func (fake *FakeImpl) WriteFileArgsForCall(i int) (string, []byte, os.FileMode) {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	argsForCall := fake.writeFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) WriteFileReturns(result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	fake.writeFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteFileReturnsOnCall(i int, result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	if fake.writeFileReturnsOnCall == nil {
+		fake.writeFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getReleaseMutex.RLock()
+	defer fake.getReleaseMutex.RUnlock()
+	fake.imagePullsMutex.RLock()
+	defer fake.imagePullsMutex.RUnlock()
+	fake.listAssetsMutex.RLock()
+	defer fake.listAssetsMutex.RUnlock()
+	fake.nowMutex.RLock()
+	defer fake.nowMutex.RUnlock()
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	fake.sleepMutex.RLock()
+	defer fake.sleepMutex.RUnlock()
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adoption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/http"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt adoptionfakes/fake_impl.go > adoptionfakes/_fake_impl.go && mv adoptionfakes/_fake_impl.go adoptionfakes/fake_impl.go"
+type impl interface {
+	GetRelease(owner, repo, tag string) (*gogithub.RepositoryRelease, error)
+	ListAssets(owner, repo string, releaseID int64) ([]*gogithub.ReleaseAsset, error)
+	ImagePulls(url string) (map[string]int64, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) GetRelease(owner, repo, tag string) (*gogithub.RepositoryRelease, error) {
+	release, _, err := github.New().Client().GetReleaseByTag(context.Background(), owner, repo, tag)
+	return release, err
+}
+
+func (*defaultImpl) ListAssets(owner, repo string, releaseID int64) ([]*gogithub.ReleaseAsset, error) {
+	return github.New().ListReleaseAssets(owner, repo, releaseID)
+}
+
+func (*defaultImpl) ImagePulls(url string) (map[string]int64, error) {
+	content, err := http.NewAgent().Get(url)
+	if err != nil {
+		return nil, err
+	}
+	pulls := map[string]int64{}
+	if err := json.Unmarshal(content, &pulls); err != nil {
+		return nil, fmt.Errorf("unmarshal image pulls: %w", err)
+	}
+	return pulls, nil
+}
+
+func (*defaultImpl) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (*defaultImpl) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (*defaultImpl) Now() time.Time {
+	return time.Now().UTC()
+}
+
+func (*defaultImpl) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
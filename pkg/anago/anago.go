@@ -17,15 +17,37 @@ limitations under the License.
 package anago
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/release/pkg/artifactdiff"
+	"k8s.io/release/pkg/baseimage"
+	"k8s.io/release/pkg/budget"
+	"k8s.io/release/pkg/buildenv"
+	"k8s.io/release/pkg/custody"
+	"k8s.io/release/pkg/cutissue"
+	"k8s.io/release/pkg/encryption"
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/metrics"
+	"k8s.io/release/pkg/plugin"
+	"k8s.io/release/pkg/progress"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/sizereport"
+	"k8s.io/release/pkg/tracing"
+	"k8s.io/release/pkg/vulnscan"
+	"k8s.io/release/pkg/workdir"
 	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-utils/log"
 	"sigs.k8s.io/release-utils/util"
 	"sigs.k8s.io/release-utils/version"
@@ -50,10 +72,20 @@ const (
 	// releaseNotesJSONFile is the file containing the release notes in json format
 	releaseNotesJSONFile = workspaceDir + "/src/release-notes.json"
 
+	// auditPath is the bucket subdirectory containing the audit log.
+	auditPath = "audit"
+
+	// custodyPath is the bucket subdirectory containing the in-toto links of
+	// the chain of custody.
+	custodyPath = "custody"
+
 	// The default license for all artifacts
 	LicenseIdentifier = "Apache-2.0"
 )
 
+// commitRegex matches a full git commit SHA.
+var commitRegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
 // Options are settings which will be used by `StageOptions` as well as
 // `ReleaseOptions`.
 type Options struct {
@@ -73,6 +105,29 @@ type Options struct {
 	// The build version to be released. Has to be specified in the format:
 	// `vX.Y.Z-[alpha|beta|rc].N.C+SHA`
 	BuildVersion string
+
+	// Commit is the full SHA of an explicit commit to be tagged instead of
+	// the head of the release branch, for example for hotfix builds. The
+	// build version has to reference the same commit.
+	Commit string
+
+	// Local runs the submitted job steps by using a local container runtime
+	// instead of Google Cloud Build, for debugging job changes.
+	Local bool
+
+	// ContainerRuntime is the container runtime executable used for local
+	// jobs, for example docker or podman. Defaults to docker if empty.
+	ContainerRuntime string
+
+	// NonInteractive does not ask for confirmation before submitting a
+	// --nomock job, for example when running as child process of
+	// `krel serve`.
+	NonInteractive bool
+
+	// CustodyKey is the optional path to the private in-toto key of the
+	// functionary, which records the links of the chain of custody steps
+	// performed by the run.
+	CustodyKey string
 }
 
 // DefaultOptions returns a new Options instance.
@@ -86,8 +141,8 @@ func DefaultOptions() *Options {
 // String returns a string representation for the `ReleaseOptions` type.
 func (o *Options) String() string {
 	return fmt.Sprintf(
-		"NoMock: %v, ReleaseType: %q, BuildVersion: %q, ReleaseBranch: %q",
-		o.NoMock, o.ReleaseType, o.BuildVersion, o.ReleaseBranch,
+		"NoMock: %v, ReleaseType: %q, BuildVersion: %q, ReleaseBranch: %q, Commit: %q",
+		o.NoMock, o.ReleaseType, o.BuildVersion, o.ReleaseBranch, o.Commit,
 	)
 }
 
@@ -106,6 +161,14 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("invalid release branch: %s", o.ReleaseBranch)
 	}
 
+	if o.Commit != "" && !commitRegex.MatchString(o.Commit) {
+		return fmt.Errorf("invalid commit %q, must be a full SHA", o.Commit)
+	}
+
+	if o.CustodyKey != "" && !util.Exists(o.CustodyKey) {
+		return fmt.Errorf("custody key %s does not exist", o.CustodyKey)
+	}
+
 	return nil
 }
 
@@ -123,6 +186,12 @@ func (o *Options) ValidateBuildVersion(state *State) error {
 	if err != nil {
 		return fmt.Errorf("invalid build version: %s: %w", o.BuildVersion, err)
 	}
+	if o.Commit != "" && (len(semverBuildVersion.Build) == 0 ||
+		!strings.HasPrefix(o.Commit, semverBuildVersion.Build[0])) {
+		return fmt.Errorf(
+			"build version %s does not reference commit %s", o.BuildVersion, o.Commit,
+		)
+	}
 	state.semverBuildVersion = semverBuildVersion
 	return nil
 }
@@ -183,6 +252,9 @@ func (s *State) SetVersions(versions *release.Versions) {
 // StageState holds the release process state
 type StageState struct {
 	*State
+
+	// buildEnvironment is the toolchain recorded after the build.
+	buildEnvironment *buildenv.Manifest
 }
 
 // DefaultStageState create a new default `StageState`.
@@ -195,15 +267,65 @@ func DefaultStageState() *StageState {
 // StageOptions contains the options for running `Stage`.
 type StageOptions struct {
 	*Options
+
+	// SkipCISignalCheck does not verify the release blocking CI jobs before
+	// submitting the stage job if set to true.
+	SkipCISignalCheck bool
+
+	// AllowedFlakyJobs is the number of flaky release blocking jobs which
+	// are tolerated by the CI signal check.
+	AllowedFlakyJobs int
+
+	// VulnerabilityScan defines how vulnerabilities found in the staged
+	// images are handled. Can be one of "fail", "warn" (default) or "off".
+	VulnerabilityScan string
+
+	// IgnoredVulnerabilities are already known vulnerability IDs which are
+	// not reported by the vulnerability scan.
+	IgnoredVulnerabilities []string
+
+	// SizeThreshold is the growth in percent of an artifact since the
+	// previous release, from which on it gets reported in the release cut
+	// issue.
+	SizeThreshold float64
+
+	// EncryptionKey is the optional Cloud KMS key for encrypting the
+	// artifacts staged to GCS ahead of an embargoed security release. They
+	// get decrypted by `krel release`, which requires no further
+	// configuration.
+	EncryptionKey string
 }
 
 // DefaultStageOptions create a new default `StageOptions`.
 func DefaultStageOptions() *StageOptions {
 	return &StageOptions{
-		Options: DefaultOptions(),
+		Options:           DefaultOptions(),
+		VulnerabilityScan: vulnscan.ModeWarn,
+		SizeThreshold:     sizereport.DefaultThreshold,
 	}
 }
 
+// VulnerabilityScanOptions returns the options for scanning the staged
+// images.
+func (s *StageOptions) VulnerabilityScanOptions() *vulnscan.Options {
+	opts := vulnscan.DefaultOptions()
+	if s.VulnerabilityScan != "" {
+		opts.Mode = s.VulnerabilityScan
+	}
+	opts.IgnoredVulnerabilities = s.IgnoredVulnerabilities
+	return opts
+}
+
+// SizeReportOptions returns the options for reporting the artifact sizes.
+func (s *StageOptions) SizeReportOptions() *sizereport.Options {
+	opts := sizereport.DefaultOptions()
+	opts.Bucket = s.Bucket()
+	if s.SizeThreshold > 0 {
+		opts.Threshold = s.SizeThreshold
+	}
+	return opts
+}
+
 // String returns a string representation for the `StageOptions` type.
 func (s *StageOptions) String() string {
 	return s.Options.String()
@@ -215,11 +337,21 @@ func (s *StageOptions) Validate(state *State) error {
 		return fmt.Errorf("validating generic options: %w", err)
 	}
 
+	if err := s.VulnerabilityScanOptions().Validate(); err != nil {
+		return fmt.Errorf("validating vulnerability scan options: %w", err)
+	}
+
+	if s.EncryptionKey != "" {
+		if err := encryption.ValidateKeyName(s.EncryptionKey); err != nil {
+			return fmt.Errorf("validating encryption key: %w", err)
+		}
+	}
+
 	// build version is optional for staging, but if provided we should
 	// validate it.
 	if s.Options.BuildVersion != "" {
 		if err := s.Options.ValidateBuildVersion(state); err != nil {
-			return fmt.Errorf("validating build version")
+			return fmt.Errorf("validating build version: %w", err)
 		}
 	}
 
@@ -243,8 +375,19 @@ func (s *Stage) SetClient(client stageClient) {
 
 // Submit can be used to submit a staging Google Cloud Build (GCB) job.
 func (s *Stage) Submit(stream bool) error {
+	return s.SubmitContext(context.Background(), stream)
+}
+
+// SubmitContext is like Submit but stops watching the job if the provided
+// context gets cancelled.
+func (s *Stage) SubmitContext(ctx context.Context, stream bool) error {
+	logrus.Info("Checking CI signal")
+	if err := s.client.CheckCISignal(); err != nil {
+		return fmt.Errorf("check CI signal: %w", err)
+	}
+
 	logrus.Info("Submitting stage GCB job")
-	if err := s.client.Submit(stream); err != nil {
+	if err := s.client.Submit(ctx, stream); err != nil {
 		return fmt.Errorf("submit stage job: %w", err)
 	}
 	return nil
@@ -253,73 +396,58 @@ func (s *Stage) Submit(stream bool) error {
 // Run for the `Stage` struct prepares a release and puts the results on a
 // staging bucket.
 func (s *Stage) Run() error {
-	s.client.InitState()
-
-	if err := s.client.InitLogFile(); err != nil {
-		return fmt.Errorf("init log file: %w", err)
-	}
-
-	logger := log.NewStepLogger(12)
-	v := version.GetVersionInfo()
-	logger.Infof("Using krel version: %s", v.GitVersion)
-
-	logger.WithStep().Info("Validating options")
-	if err := s.client.ValidateOptions(); err != nil {
-		return fmt.Errorf("validate options: %w", err)
-	}
-
-	logger.WithStep().Info("Checking prerequisites")
-	if err := s.client.CheckPrerequisites(); err != nil {
-		return fmt.Errorf("check prerequisites: %w", err)
-	}
-
-	logger.WithStep().Info("Checking release branch state")
-	if err := s.client.CheckReleaseBranchState(); err != nil {
-		return fmt.Errorf("check release branch state: %w", err)
-	}
+	return s.RunContext(context.Background())
+}
 
-	logger.WithStep().Info("Generating release version")
-	if err := s.client.GenerateReleaseVersion(); err != nil {
-		return fmt.Errorf("generate release version: %w", err)
-	}
+// RunContext is like Run but stops before the next step if the provided
+// context gets cancelled.
+func (s *Stage) RunContext(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "stage")
+	defer func() { tracing.End(span, err) }()
 
-	logger.WithStep().Info("Preparing workspace")
-	if err := s.client.PrepareWorkspace(); err != nil {
-		return fmt.Errorf("prepare workspace: %w", err)
-	}
+	ctx, summary := progress.NewSummary(ctx, "Stage")
+	defer func() { summary.Print(err) }()
 
-	logger.WithStep().Info("Tagging repository")
-	if err := s.client.TagRepository(); err != nil {
-		return fmt.Errorf("tag repository: %w", err)
-	}
-
-	logger.WithStep().Info("Building release")
-	if err := s.client.Build(); err != nil {
-		return fmt.Errorf("build release: %w", err)
-	}
+	s.client.InitState()
 
-	logger.WithStep().Info("Generating changelog")
-	if err := s.client.GenerateChangelog(); err != nil {
-		return fmt.Errorf("generate changelog: %w", err)
+	if err := s.client.InitLogFile(); err != nil {
+		return fmt.Errorf("init log file: %w", err)
 	}
 
-	logger.WithStep().Info("Verifying artifacts")
-	if err := s.client.VerifyArtifacts(); err != nil {
-		return fmt.Errorf("verifying artifacts: %w", err)
+	if err := runSteps(ctx, s.steps()); err != nil {
+		return err
 	}
+	logrus.Info("Stage done")
+	return nil
+}
 
-	logger.WithStep().Info("Generating bill of materials")
-	if err := s.client.GenerateBillOfMaterials(); err != nil {
-		return fmt.Errorf("generating sbom: %w", err)
-	}
+// Steps returns the ordered steps of the stage process.
+func (s *Stage) Steps() []Step {
+	return stepsOf(s.steps())
+}
 
-	logger.WithStep().Info("Staging artifacts")
-	if err := s.client.StageArtifacts(); err != nil {
-		return fmt.Errorf("stage release artifacts: %w", err)
+func (s *Stage) steps() []step {
+	return []step{
+		{name: "validate options", info: "Validating options", run: s.client.ValidateOptions, fail: "validate options"},
+		{name: "check prerequisites", info: "Checking prerequisites", run: s.client.CheckPrerequisites, fail: "check prerequisites"},
+		{name: "check release branch state", info: "Checking release branch state", run: s.client.CheckReleaseBranchState, fail: "check release branch state"},
+		{name: "generate release version", info: "Generating release version", run: s.client.GenerateReleaseVersion, fail: "generate release version"},
+		{name: "prepare workspace", info: "Preparing workspace", run: s.client.PrepareWorkspace, fail: "prepare workspace"},
+		{name: "tag repository", info: "Tagging repository", run: s.client.TagRepository, fail: "tag repository"},
+		{name: "build", info: "Building release", run: s.client.Build, fail: "build release"},
+		{name: "generate changelog", info: "Generating changelog", run: s.client.GenerateChangelog, fail: "generate changelog"},
+		{name: "generate attribution", info: "Generating license attribution", run: s.client.GenerateAttribution, fail: "generate attribution"},
+		{name: "verify artifacts", info: "Verifying artifacts", run: s.client.VerifyArtifacts, fail: "verifying artifacts"},
+		{name: "scan images", info: "Scanning images for vulnerabilities", run: s.client.ScanImages, fail: "scanning images"},
+		{name: "generate bill of materials", info: "Generating bill of materials", run: s.client.GenerateBillOfMaterials, fail: "generating sbom"},
+		{name: "stage artifacts", info: "Staging artifacts", run: s.client.StageArtifacts, fail: "stage release artifacts"},
+		// The size report is only informational, which means that failures
+		// are not fatal.
+		{name: "report artifact sizes", info: "Reporting artifact sizes", run: s.client.ReportArtifactSizes, warn: "Unable to report artifact sizes"},
+		// The release cut issue is only used for tracking, which means that
+		// failures are not fatal.
+		{name: "update release cut issue", info: "Updating release cut issue", run: s.client.UpdateReleaseCutIssue, warn: "Unable to update release cut issue"},
 	}
-
-	logger.Info("Stage done")
-	return nil
 }
 
 // ReleaseState holds the release process state
@@ -337,12 +465,29 @@ func DefaultReleaseState() *ReleaseState {
 // ReleaseOptions contains the options for running `Release`.
 type ReleaseOptions struct {
 	*Options
+
+	// PublishAt is the optional RFC3339 time of an embargoed release. The
+	// GitHub release page is only created as draft and the version markers
+	// are not updated if set, both get published together with the
+	// announcement by `krel announce publish`.
+	PublishAt string
+
+	// AllowAddedArtifacts are path patterns of artifacts which are expected
+	// to be added compared to the previous release. All other added
+	// artifacts fail the release.
+	AllowAddedArtifacts []string
+
+	// AllowRemovedArtifacts are path patterns of artifacts which are
+	// expected to be removed compared to the previous release. All other
+	// removed artifacts fail the release.
+	AllowRemovedArtifacts []string
 }
 
 // DefaultReleaseOptions create a new default `ReleaseOptions`.
 func DefaultReleaseOptions() *ReleaseOptions {
 	return &ReleaseOptions{
-		Options: DefaultOptions(),
+		Options:               DefaultOptions(),
+		AllowRemovedArtifacts: slices.Clone(artifactdiff.DefaultAllowRemoved),
 	}
 }
 
@@ -359,6 +504,11 @@ func (r *ReleaseOptions) Validate(state *State) error {
 	if err := r.Options.ValidateBuildVersion(state); err != nil {
 		return fmt.Errorf("validating build version: %w", err)
 	}
+	if r.PublishAt != "" {
+		if _, err := time.Parse(time.RFC3339, r.PublishAt); err != nil {
+			return fmt.Errorf("invalid publish time %q: %w", r.PublishAt, err)
+		}
+	}
 	return nil
 }
 
@@ -379,8 +529,14 @@ func (r *Release) SetClient(client releaseClient) {
 
 // Submit can be used to submit a releasing Google Cloud Build (GCB) job.
 func (r *Release) Submit(stream bool) error {
+	return r.SubmitContext(context.Background(), stream)
+}
+
+// SubmitContext is like Submit but stops watching the job if the provided
+// context gets cancelled.
+func (r *Release) SubmitContext(ctx context.Context, stream bool) error {
 	logrus.Info("Submitting release GCB job")
-	if err := r.client.Submit(stream); err != nil {
+	if err := r.client.Submit(ctx, stream); err != nil {
 		return fmt.Errorf("submit release job: %w", err)
 	}
 	return nil
@@ -388,73 +544,259 @@ func (r *Release) Submit(stream bool) error {
 
 // Run for `Release` struct finishes a previously staged release.
 func (r *Release) Run() error {
+	return r.RunContext(context.Background())
+}
+
+// RunContext is like Run but stops before the next step if the provided
+// context gets cancelled.
+func (r *Release) RunContext(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "release")
+	defer func() { tracing.End(span, err) }()
+
+	ctx, summary := progress.NewSummary(ctx, "Release")
+	defer func() { summary.Print(err) }()
+
 	r.client.InitState()
 
 	if err := r.client.InitLogFile(); err != nil {
 		return fmt.Errorf("init log file: %w", err)
 	}
 
-	logger := log.NewStepLogger(12)
-	v := version.GetVersionInfo()
-	logger.Infof("Using krel version: %s", v.GitVersion)
-
-	logger.WithStep().Info("Validating options")
-	if err := r.client.ValidateOptions(); err != nil {
-		return fmt.Errorf("validate options: %w", err)
+	if err := runSteps(ctx, r.steps()); err != nil {
+		return err
 	}
+	logrus.Info("Release done")
+	return nil
+}
 
-	logger.WithStep().Info("Checking prerequisites")
-	if err := r.client.CheckPrerequisites(); err != nil {
-		return fmt.Errorf("check prerequisites: %w", err)
+// Steps returns the ordered steps of the release process.
+func (r *Release) Steps() []Step {
+	return stepsOf(r.steps())
+}
+
+func (r *Release) steps() []step {
+	return []step{
+		{name: "validate options", info: "Validating options", run: r.client.ValidateOptions, fail: "validate options"},
+		{name: "check prerequisites", info: "Checking prerequisites", run: r.client.CheckPrerequisites, fail: "check prerequisites"},
+		{name: "check release branch state", info: "Checking release branch state", run: r.client.CheckReleaseBranchState, fail: "check release branch state"},
+		{name: "generate release version", info: "Generating release version", run: r.client.GenerateReleaseVersion, fail: "generate release version"},
+		{name: "prepare workspace", info: "Preparing workspace", run: r.client.PrepareWorkspace, fail: "prepare workspace"},
+		// For now, we only notify provenance errors as not to treat them as
+		// fatal while we finish testing SLSA compliance.
+		{name: "check provenance", info: "Checking artifacts provenance", run: r.client.CheckProvenance, warn: "Unable to check provenance attestation"},
+		{name: "check artifact anomalies", info: "Checking artifact anomalies", run: r.client.CheckArtifactAnomalies, fail: "check artifact anomalies"},
+		{name: "push artifacts", info: "Pushing artifacts", run: r.client.PushArtifacts, fail: "push artifacts"},
+		{name: "push git objects", info: "Pushing git objects", run: r.client.PushGitObjects, fail: "push git objects"},
+		{name: "create announcement", info: "Creating announcement", run: r.client.CreateAnnouncement, fail: "create announcement"},
+		{name: "update GitHub page", info: "Updating GitHub release page", run: r.client.UpdateGitHubPage, fail: "updating github page"},
+		{name: "archive", info: "Archiving release", run: r.client.Archive, fail: "archive release"},
+		// The release cut issue is only used for tracking, which means that
+		// failures are not fatal.
+		{name: "update release cut issue", info: "Updating release cut issue", run: r.client.UpdateReleaseCutIssue, warn: "Unable to update release cut issue"},
+	}
+}
+
+// checkBaseImages verifies the base images of all single-platform images of
+// the version against the configured base image policy.
+func checkBaseImages(registry, version, buildPath, branch string) error {
+	checker := baseimage.Default()
+	if !checker.Enabled() {
+		logrus.Info("No base image policy configured, skipping the check")
+		return nil
+	}
+
+	images := []baseimage.Image{}
+	if _, err := release.NewImages().GetManifestImages(
+		registry, strings.ReplaceAll(version, "+", "_"), buildPath,
+		func(path, _, image string) error {
+			arch := filepath.Base(filepath.Dir(path))
+			images = append(images, baseimage.Image{Ref: image, Platform: "linux/" + arch})
+			return nil
+		},
+	); err != nil {
+		return fmt.Errorf("get manifest images: %w", err)
+	}
+	return checker.Check(branch, images)
+}
+
+// checkReleaseCutIssue checks off the provided item in the release cut issue
+// for the version, if the issue exists. Mock runs only log the update.
+func checkReleaseCutIssue(version, item string, noMock bool) error {
+	opts := cutissue.DefaultOptions()
+	opts.Version = version
+	opts.NoMock = noMock
+
+	err := cutissue.New(opts).Check(item)
+	if errors.Is(err, cutissue.ErrIssueNotFound) {
+		logrus.Infof("Skipping update: %v", err)
+		return nil
+	}
+	return err
+}
+
+// commentReleaseCutIssue adds the provided markdown as comment to the release
+// cut issue for the version, if the issue exists. Mock runs only log the
+// comment.
+func commentReleaseCutIssue(version, body string, noMock bool) error {
+	opts := cutissue.DefaultOptions()
+	opts.Version = version
+	opts.NoMock = noMock
+
+	err := cutissue.New(opts).Comment(body)
+	if errors.Is(err, cutissue.ErrIssueNotFound) {
+		logrus.Infof("Skipping comment: %v", err)
+		return nil
+	}
+	return err
+}
+
+// custodyArtifacts returns the local release artifacts of the versions which
+// are covered by the chain of custody. They are available in the stage run
+// as well as after copying the staged build in the release run.
+func custodyArtifacts(versions []string) []string {
+	res := []string{}
+	for _, version := range versions {
+		buildDir := filepath.Join(gitRoot, fmt.Sprintf("%s-%s", release.BuildDir, version))
+		res = append(res,
+			filepath.Join(buildDir, release.GCSStagePath, version, release.KubernetesTar),
+			filepath.Join(buildDir, release.ImagesPath),
+		)
+	}
+	return res
+}
+
+// recordCustodyLinks records the links of the chain of custody steps for the
+// release artifacts of the versions and returns the directory containing
+// them. Only the stage step has no materials, because it creates the
+// artifacts.
+func recordCustodyLinks(keyPath string, steps, versions []string) (string, error) {
+	dir, err := workdir.MkdirTemp("custody-")
+	if err != nil {
+		return "", fmt.Errorf("create link directory: %w", err)
 	}
 
-	logger.WithStep().Info("Checking release branch state")
-	if err := r.client.CheckReleaseBranchState(); err != nil {
-		return fmt.Errorf("check release branch state: %w", err)
+	artifacts := custodyArtifacts(versions)
+	for _, step := range steps {
+		var materials []string
+		if step != custody.StepStage {
+			materials = artifacts
+		}
+		if _, err := custody.RecordLink(step, keyPath, gitRoot, dir, materials, artifacts); err != nil {
+			return "", fmt.Errorf("record %s link: %w", step, err)
+		}
 	}
+	return dir, nil
+}
 
-	logger.WithStep().Info("Generating release version")
-	if err := r.client.GenerateReleaseVersion(); err != nil {
-		return fmt.Errorf("generate release version: %w", err)
+// pushCustodyLinks uploads the links of the directory next to the staged
+// build, where the links of the stage and release runs are collected.
+func pushCustodyLinks(dir, bucket, buildVersion string) error {
+	links, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read link directory: %w", err)
 	}
 
-	logger.WithStep().Info("Preparing workspace")
-	if err := r.client.PrepareWorkspace(); err != nil {
-		return fmt.Errorf("prepare workspace: %w", err)
+	gcs := object.NewGCS()
+	gcs.SetOptions(gcs.WithNoClobber(false))
+	dst := object.GcsPrefix + layout.Default().StagePath(bucket, buildVersion, custodyPath)
+	for _, link := range links {
+		if err := gcs.CopyToRemote(
+			filepath.Join(dir, link.Name()), dst+"/"+link.Name(),
+		); err != nil {
+			return fmt.Errorf("push link %s: %w", link.Name(), err)
+		}
 	}
+	return nil
+}
 
-	logger.WithStep().Info("Checking artifacts provenance")
-	if err := r.client.CheckProvenance(); err != nil {
-		// For now, we only notify provenance errors as not to treat
-		// them as fatal while we finish testing SLSA compliance.
-		logrus.Warnf("Unable to check provenance attestation: %v", err)
-	}
+// Step is a single step of the stage or release process.
+type Step struct {
+	// Name of the step, which is also used for the tracing spans, metrics,
+	// plugin phases, budgets and disk space requirements.
+	Name string
 
-	logger.WithStep().Info("Pushing artifacts")
-	if err := r.client.PushArtifacts(); err != nil {
-		return fmt.Errorf("push artifacts: %w", err)
-	}
+	// NonFatal is true if a failure of the step only produces a warning.
+	NonFatal bool
+}
 
-	logger.WithStep().Info("Pushing git objects")
-	if err := r.client.PushGitObjects(); err != nil {
-		return fmt.Errorf("push git objects: %w", err)
-	}
+// step is a Step together with its implementation.
+type step struct {
+	// name of the step.
+	name string
 
-	logger.WithStep().Info("Creating announcement")
-	if err := r.client.CreateAnnouncement(); err != nil {
-		return fmt.Errorf("create announcement: %w", err)
-	}
+	// info is logged before running the step.
+	info string
 
-	logger.WithStep().Info("Updating GitHub release page")
-	if err := r.client.UpdateGitHubPage(); err != nil {
-		return fmt.Errorf("updating github page: %w", err)
-	}
+	// run executes the step.
+	run func() error
 
-	logger.WithStep().Info("Archiving release")
-	if err := r.client.Archive(); err != nil {
-		return fmt.Errorf("archive release: %w", err)
+	// fail wraps the error of a fatal step.
+	fail string
+
+	// warn is logged together with the error of a non-fatal step.
+	warn string
+}
+
+// stepsOf returns the descriptions of the steps.
+func stepsOf(steps []step) []Step {
+	res := make([]Step, 0, len(steps))
+	for _, s := range steps {
+		res = append(res, Step{Name: s.name, NonFatal: s.warn != ""})
 	}
+	return res
+}
 
-	logger.Info("Release done")
+// runSteps runs the steps in order and stops at the first fatal failure.
+func runSteps(ctx context.Context, steps []step) error {
+	logger := log.NewStepLogger(uint(len(steps)))
+	v := version.GetVersionInfo()
+	logger.Infof("Using krel version: %s", v.GitVersion)
+
+	for _, s := range steps {
+		logger.WithStep().Info(s.info)
+		if err := runStep(ctx, s.name, s.run); err != nil {
+			if s.warn != "" {
+				logrus.Warnf("%s: %v", s.warn, err)
+				continue
+			}
+			return fmt.Errorf("%s: %w", s.fail, err)
+		}
+	}
 	return nil
 }
+
+// runStep executes a single step of the stage or release process by tracing
+// it, recording its metrics, verifying the free disk space and running the
+// plugin hooks of its phase within the timeout budget of the step. The step
+// does not get executed if ctx is already cancelled.
+func runStep(ctx context.Context, name string, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		logrus.Warnf("Run cancelled before step %q, state of previous steps is kept", name)
+		return fmt.Errorf("skipping %s: %w", name, err)
+	}
+
+	phase := plugin.Phase(name)
+	start := time.Now()
+	spinner := progress.NewSpinner(name)
+	err := tracing.Run(ctx, name, func() error {
+		return budget.Run(ctx, name, func(ctx context.Context) error {
+			if err := workdir.CheckDiskSpace(name, workspaceDir); err != nil {
+				return err
+			}
+			if err := plugin.RunHooks(ctx, phase, plugin.Before); err != nil {
+				return err
+			}
+			if err := fn(); err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("skipping after hooks of %s: %w", name, err)
+			}
+			return plugin.RunHooks(ctx, phase, plugin.After)
+		})
+	})
+	spinner.Stop(err)
+	metrics.ObservePhase(name, time.Since(start), err)
+	progress.SummaryFromContext(ctx).Record(name, time.Since(start), err)
+	return err
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package anago_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -107,6 +108,20 @@ func TestRunStage(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // GenerateAttribution fails
+			prepare: func(mock *anagofakes.FakeStageClient) {
+				mockGenerateReleaseVersionStage(mock)
+				mock.GenerateAttributionReturns(err)
+			},
+			shouldError: true,
+		},
+		{ // ScanImages fails
+			prepare: func(mock *anagofakes.FakeStageClient) {
+				mockGenerateReleaseVersionStage(mock)
+				mock.ScanImagesReturns(err)
+			},
+			shouldError: true,
+		},
 		{ // StageArtifacts fails
 			prepare: func(mock *anagofakes.FakeStageClient) {
 				mockGenerateReleaseVersionStage(mock)
@@ -114,6 +129,13 @@ func TestRunStage(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // UpdateReleaseCutIssue fails
+			prepare: func(mock *anagofakes.FakeStageClient) {
+				mockGenerateReleaseVersionStage(mock)
+				mock.UpdateReleaseCutIssueReturns(err)
+			},
+			shouldError: false,
+		},
 	} {
 		opts := anago.DefaultStageOptions()
 		sut := anago.NewStage(opts)
@@ -206,6 +228,13 @@ func TestRunRelease(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // UpdateReleaseCutIssue fails
+			prepare: func(mock *anagofakes.FakeReleaseClient) {
+				mockGenerateReleaseVersionRelease(mock)
+				mock.UpdateReleaseCutIssueReturns(err)
+			},
+			shouldError: false,
+		},
 	} {
 		opts := anago.DefaultReleaseOptions()
 		sut := anago.NewRelease(opts)
@@ -222,6 +251,25 @@ func TestRunRelease(t *testing.T) {
 	}
 }
 
+func TestRunContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	stageMock := &anagofakes.FakeStageClient{}
+	stage := anago.NewStage(anago.DefaultStageOptions())
+	stage.SetClient(stageMock)
+	require.ErrorIs(t, stage.RunContext(ctx), context.Canceled)
+	require.Equal(t, 1, stageMock.InitLogFileCallCount())
+	require.Zero(t, stageMock.ValidateOptionsCallCount())
+
+	releaseMock := &anagofakes.FakeReleaseClient{}
+	rel := anago.NewRelease(anago.DefaultReleaseOptions())
+	rel.SetClient(releaseMock)
+	require.ErrorIs(t, rel.RunContext(ctx), context.Canceled)
+	require.Equal(t, 1, releaseMock.InitLogFileCallCount())
+	require.Zero(t, releaseMock.ValidateOptionsCallCount())
+}
+
 func TestValidateOptions(t *testing.T) {
 	for _, tc := range []struct {
 		provided    *anago.Options
@@ -247,6 +295,14 @@ func TestValidateOptions(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // abbreviated commit
+			provided: &anago.Options{
+				ReleaseType:   release.ReleaseTypeAlpha,
+				ReleaseBranch: git.DefaultBranch,
+				Commit:        "8f6ffb24df9896",
+			},
+			shouldError: true,
+		},
 	} {
 		err := tc.provided.Validate()
 		if tc.shouldError {
@@ -278,6 +334,24 @@ func TestValidateBuildVersion(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // build version referencing the pinned commit
+			provided: &anago.Options{
+				ReleaseType:   release.ReleaseTypeAlpha,
+				ReleaseBranch: git.DefaultBranch,
+				BuildVersion:  "v1.20.0-beta.1.203+8f6ffb24df9896",
+				Commit:        "8f6ffb24df98960ed5ad9ef1e2a3b95a1acaa3c1",
+			},
+			shouldError: false,
+		},
+		{ // build version not referencing the pinned commit
+			provided: &anago.Options{
+				ReleaseType:   release.ReleaseTypeAlpha,
+				ReleaseBranch: git.DefaultBranch,
+				BuildVersion:  "v1.20.0-beta.1.203+8f6ffb24df9896",
+				Commit:        "4628c605aadb9b8a6ec9f3bdc7be0ba0cd6b4e5f",
+			},
+			shouldError: true,
+		},
 	} {
 		state := anago.DefaultState()
 		err := tc.provided.ValidateBuildVersion(state)
@@ -296,7 +370,7 @@ func TestStagingOptionsValidate(t *testing.T) {
 	}{
 		{ // valid build version should validate
 			provided: &anago.StageOptions{
-				&anago.Options{
+				Options: &anago.Options{
 					ReleaseType:   release.ReleaseTypeAlpha,
 					ReleaseBranch: git.DefaultBranch,
 					BuildVersion:  "v1.20.0-beta.1.203+8f6ffb24df9896",
@@ -306,7 +380,7 @@ func TestStagingOptionsValidate(t *testing.T) {
 		},
 		{ // empty build version should validate
 			provided: &anago.StageOptions{
-				&anago.Options{
+				Options: &anago.Options{
 					ReleaseType:   release.ReleaseTypeAlpha,
 					ReleaseBranch: git.DefaultBranch,
 				},
@@ -315,7 +389,7 @@ func TestStagingOptionsValidate(t *testing.T) {
 		},
 		{ // invalid build version should not validate
 			provided: &anago.StageOptions{
-				&anago.Options{
+				Options: &anago.Options{
 					ReleaseType:   release.ReleaseTypeAlpha,
 					ReleaseBranch: git.DefaultBranch,
 					BuildVersion:  "decaf-bad",
@@ -323,6 +397,36 @@ func TestStagingOptionsValidate(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // invalid vulnerability scan mode should not validate
+			provided: &anago.StageOptions{
+				Options: &anago.Options{
+					ReleaseType:   release.ReleaseTypeAlpha,
+					ReleaseBranch: git.DefaultBranch,
+				},
+				VulnerabilityScan: "wrong",
+			},
+			shouldError: true,
+		},
+		{ // valid encryption key should validate
+			provided: &anago.StageOptions{
+				Options: &anago.Options{
+					ReleaseType:   release.ReleaseTypeAlpha,
+					ReleaseBranch: git.DefaultBranch,
+				},
+				EncryptionKey: "projects/k8s-releng-prod/locations/global/keyRings/release/cryptoKeys/embargo",
+			},
+			shouldError: false,
+		},
+		{ // invalid encryption key should not validate
+			provided: &anago.StageOptions{
+				Options: &anago.Options{
+					ReleaseType:   release.ReleaseTypeAlpha,
+					ReleaseBranch: git.DefaultBranch,
+				},
+				EncryptionKey: "embargo",
+			},
+			shouldError: true,
+		},
 	} {
 		state := anago.DefaultState()
 		err := tc.provided.Validate(state)
@@ -351,6 +455,12 @@ func TestSubmitStage(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // CheckCISignal fails
+			prepare: func(mock *anagofakes.FakeStageClient) {
+				mock.CheckCISignalReturns(err)
+			},
+			shouldError: true,
+		},
 	} {
 		opts := anago.DefaultStageOptions()
 		sut := anago.NewStage(opts)
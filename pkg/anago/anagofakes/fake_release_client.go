@@ -18,6 +18,7 @@ limitations under the License.
 package anagofakes
 
 import (
+	"context"
 	"sync"
 )
 
@@ -32,6 +33,16 @@ type FakeReleaseClient struct {
 	archiveReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CheckArtifactAnomaliesStub        func() error
+	checkArtifactAnomaliesMutex       sync.RWMutex
+	checkArtifactAnomaliesArgsForCall []struct {
+	}
+	checkArtifactAnomaliesReturns struct {
+		result1 error
+	}
+	checkArtifactAnomaliesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CheckPrerequisitesStub        func() error
 	checkPrerequisitesMutex       sync.RWMutex
 	checkPrerequisitesArgsForCall []struct {
@@ -126,10 +137,11 @@ type FakeReleaseClient struct {
 	pushGitObjectsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SubmitStub        func(bool) error
+	SubmitStub        func(context.Context, bool) error
 	submitMutex       sync.RWMutex
 	submitArgsForCall []struct {
-		arg1 bool
+		arg1 context.Context
+		arg2 bool
 	}
 	submitReturns struct {
 		result1 error
@@ -147,6 +159,16 @@ type FakeReleaseClient struct {
 	updateGitHubPageReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateReleaseCutIssueStub        func() error
+	updateReleaseCutIssueMutex       sync.RWMutex
+	updateReleaseCutIssueArgsForCall []struct {
+	}
+	updateReleaseCutIssueReturns struct {
+		result1 error
+	}
+	updateReleaseCutIssueReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ValidateOptionsStub        func() error
 	validateOptionsMutex       sync.RWMutex
 	validateOptionsArgsForCall []struct {
@@ -214,6 +236,59 @@ func (fake *FakeReleaseClient) ArchiveReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeReleaseClient) CheckArtifactAnomalies() error {
+	fake.checkArtifactAnomaliesMutex.Lock()
+	ret, specificReturn := fake.checkArtifactAnomaliesReturnsOnCall[len(fake.checkArtifactAnomaliesArgsForCall)]
+	fake.checkArtifactAnomaliesArgsForCall = append(fake.checkArtifactAnomaliesArgsForCall, struct {
+	}{})
+	stub := fake.CheckArtifactAnomaliesStub
+	fakeReturns := fake.checkArtifactAnomaliesReturns
+	fake.recordInvocation("CheckArtifactAnomalies", []interface{}{})
+	fake.checkArtifactAnomaliesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseClient) CheckArtifactAnomaliesCallCount() int {
+	fake.checkArtifactAnomaliesMutex.RLock()
+	defer fake.checkArtifactAnomaliesMutex.RUnlock()
+	return len(fake.checkArtifactAnomaliesArgsForCall)
+}
+
+func (fake *FakeReleaseClient) CheckArtifactAnomaliesCalls(stub func() error) {
+	fake.checkArtifactAnomaliesMutex.Lock()
+	defer fake.checkArtifactAnomaliesMutex.Unlock()
+	fake.CheckArtifactAnomaliesStub = stub
+}
+
+func (fake *FakeReleaseClient) CheckArtifactAnomaliesReturns(result1 error) {
+	fake.checkArtifactAnomaliesMutex.Lock()
+	defer fake.checkArtifactAnomaliesMutex.Unlock()
+	fake.CheckArtifactAnomaliesStub = nil
+	fake.checkArtifactAnomaliesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseClient) CheckArtifactAnomaliesReturnsOnCall(i int, result1 error) {
+	fake.checkArtifactAnomaliesMutex.Lock()
+	defer fake.checkArtifactAnomaliesMutex.Unlock()
+	fake.CheckArtifactAnomaliesStub = nil
+	if fake.checkArtifactAnomaliesReturnsOnCall == nil {
+		fake.checkArtifactAnomaliesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkArtifactAnomaliesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseClient) CheckPrerequisites() error {
 	fake.checkPrerequisitesMutex.Lock()
 	ret, specificReturn := fake.checkPrerequisitesReturnsOnCall[len(fake.checkPrerequisitesArgsForCall)]
@@ -715,18 +790,19 @@ func (fake *FakeReleaseClient) PushGitObjectsReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeReleaseClient) Submit(arg1 bool) error {
+func (fake *FakeReleaseClient) Submit(arg1 context.Context, arg2 bool) error {
 	fake.submitMutex.Lock()
 	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
 	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
-		arg1 bool
-	}{arg1})
+		arg1 context.Context
+		arg2 bool
+	}{arg1, arg2})
 	stub := fake.SubmitStub
 	fakeReturns := fake.submitReturns
-	fake.recordInvocation("Submit", []interface{}{arg1})
+	fake.recordInvocation("Submit", []interface{}{arg1, arg2})
 	fake.submitMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -740,17 +816,17 @@ func (fake *FakeReleaseClient) SubmitCallCount() int {
 	return len(fake.submitArgsForCall)
 }
 
-func (fake *FakeReleaseClient) SubmitCalls(stub func(bool) error) {
+func (fake *FakeReleaseClient) SubmitCalls(stub func(context.Context, bool) error) {
 	fake.submitMutex.Lock()
 	defer fake.submitMutex.Unlock()
 	fake.SubmitStub = stub
 }
 
-func (fake *FakeReleaseClient) SubmitArgsForCall(i int) bool {
+func (fake *FakeReleaseClient) SubmitArgsForCall(i int) (context.Context, bool) {
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	argsForCall := fake.submitArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeReleaseClient) SubmitReturns(result1 error) {
@@ -829,6 +905,59 @@ func (fake *FakeReleaseClient) UpdateGitHubPageReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeReleaseClient) UpdateReleaseCutIssue() error {
+	fake.updateReleaseCutIssueMutex.Lock()
+	ret, specificReturn := fake.updateReleaseCutIssueReturnsOnCall[len(fake.updateReleaseCutIssueArgsForCall)]
+	fake.updateReleaseCutIssueArgsForCall = append(fake.updateReleaseCutIssueArgsForCall, struct {
+	}{})
+	stub := fake.UpdateReleaseCutIssueStub
+	fakeReturns := fake.updateReleaseCutIssueReturns
+	fake.recordInvocation("UpdateReleaseCutIssue", []interface{}{})
+	fake.updateReleaseCutIssueMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseClient) UpdateReleaseCutIssueCallCount() int {
+	fake.updateReleaseCutIssueMutex.RLock()
+	defer fake.updateReleaseCutIssueMutex.RUnlock()
+	return len(fake.updateReleaseCutIssueArgsForCall)
+}
+
+func (fake *FakeReleaseClient) UpdateReleaseCutIssueCalls(stub func() error) {
+	fake.updateReleaseCutIssueMutex.Lock()
+	defer fake.updateReleaseCutIssueMutex.Unlock()
+	fake.UpdateReleaseCutIssueStub = stub
+}
+
+func (fake *FakeReleaseClient) UpdateReleaseCutIssueReturns(result1 error) {
+	fake.updateReleaseCutIssueMutex.Lock()
+	defer fake.updateReleaseCutIssueMutex.Unlock()
+	fake.UpdateReleaseCutIssueStub = nil
+	fake.updateReleaseCutIssueReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseClient) UpdateReleaseCutIssueReturnsOnCall(i int, result1 error) {
+	fake.updateReleaseCutIssueMutex.Lock()
+	defer fake.updateReleaseCutIssueMutex.Unlock()
+	fake.UpdateReleaseCutIssueStub = nil
+	if fake.updateReleaseCutIssueReturnsOnCall == nil {
+		fake.updateReleaseCutIssueReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateReleaseCutIssueReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseClient) ValidateOptions() error {
 	fake.validateOptionsMutex.Lock()
 	ret, specificReturn := fake.validateOptionsReturnsOnCall[len(fake.validateOptionsArgsForCall)]
@@ -887,6 +1016,8 @@ func (fake *FakeReleaseClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.archiveMutex.RLock()
 	defer fake.archiveMutex.RUnlock()
+	fake.checkArtifactAnomaliesMutex.RLock()
+	defer fake.checkArtifactAnomaliesMutex.RUnlock()
 	fake.checkPrerequisitesMutex.RLock()
 	defer fake.checkPrerequisitesMutex.RUnlock()
 	fake.checkProvenanceMutex.RLock()
@@ -911,6 +1042,8 @@ func (fake *FakeReleaseClient) Invocations() map[string][][]interface{} {
 	defer fake.submitMutex.RUnlock()
 	fake.updateGitHubPageMutex.RLock()
 	defer fake.updateGitHubPageMutex.RUnlock()
+	fake.updateReleaseCutIssueMutex.RLock()
+	defer fake.updateReleaseCutIssueMutex.RUnlock()
 	fake.validateOptionsMutex.RLock()
 	defer fake.validateOptionsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -18,13 +18,16 @@ limitations under the License.
 package anagofakes
 
 import (
+	"context"
 	"sync"
 
 	semver "github.com/blang/semver/v4"
 	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/blog"
 	"k8s.io/release/pkg/build"
 	"k8s.io/release/pkg/gcp/gcb"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/urlalias"
 	"sigs.k8s.io/release-sdk/object"
 )
 
@@ -55,6 +58,36 @@ type FakeReleaseImpl struct {
 		result1 bool
 		result2 error
 	}
+	CheckArtifactAnomaliesStub        func(string, string, string, string, []string, []string) error
+	checkArtifactAnomaliesMutex       sync.RWMutex
+	checkArtifactAnomaliesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 []string
+		arg6 []string
+	}
+	checkArtifactAnomaliesReturns struct {
+		result1 error
+	}
+	checkArtifactAnomaliesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CheckBaseImagesStub        func(string, string, string, string) error
+	checkBaseImagesMutex       sync.RWMutex
+	checkBaseImagesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	checkBaseImagesReturns struct {
+		result1 error
+	}
+	checkBaseImagesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CheckPrerequisitesStub        func() error
 	checkPrerequisitesMutex       sync.RWMutex
 	checkPrerequisitesArgsForCall []struct {
@@ -76,6 +109,19 @@ type FakeReleaseImpl struct {
 	checkReleaseBucketReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CheckReleaseCutIssueStub        func(string, string, bool) error
+	checkReleaseCutIssueMutex       sync.RWMutex
+	checkReleaseCutIssueArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	checkReleaseCutIssueReturns struct {
+		result1 error
+	}
+	checkReleaseCutIssueReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CheckStageProvenanceStub        func(string, string, *release.Versions) error
 	checkStageProvenanceMutex       sync.RWMutex
 	checkStageProvenanceArgsForCall []struct {
@@ -126,6 +172,17 @@ type FakeReleaseImpl struct {
 	createAnnouncementReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CreateBlogPostStub        func(*blog.Options) error
+	createBlogPostMutex       sync.RWMutex
+	createBlogPostArgsForCall []struct {
+		arg1 *blog.Options
+	}
+	createBlogPostReturns struct {
+		result1 error
+	}
+	createBlogPostReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreatePubBotBranchIssueStub        func(string) error
 	createPubBotBranchIssueMutex       sync.RWMutex
 	createPubBotBranchIssueArgsForCall []struct {
@@ -192,6 +249,17 @@ type FakeReleaseImpl struct {
 	prepareWorkspaceReleaseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PublishAliasesStub        func(*urlalias.Options) error
+	publishAliasesMutex       sync.RWMutex
+	publishAliasesArgsForCall []struct {
+		arg1 *urlalias.Options
+	}
+	publishAliasesReturns struct {
+		result1 error
+	}
+	publishAliasesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PublishReleaseNotesIndexStub        func(string, string, string) error
 	publishReleaseNotesIndexMutex       sync.RWMutex
 	publishReleaseNotesIndexArgsForCall []struct {
@@ -235,6 +303,30 @@ type FakeReleaseImpl struct {
 	pushBranchesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PushContainerImagesStub        func(*build.Options) error
+	pushContainerImagesMutex       sync.RWMutex
+	pushContainerImagesArgsForCall []struct {
+		arg1 *build.Options
+	}
+	pushContainerImagesReturns struct {
+		result1 error
+	}
+	pushContainerImagesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PushCustodyLinksStub        func(string, string, string) error
+	pushCustodyLinksMutex       sync.RWMutex
+	pushCustodyLinksArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	pushCustodyLinksReturns struct {
+		result1 error
+	}
+	pushCustodyLinksReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PushMainBranchStub        func(*release.GitObjectPusher) error
 	pushMainBranchMutex       sync.RWMutex
 	pushMainBranchArgsForCall []struct {
@@ -258,10 +350,54 @@ type FakeReleaseImpl struct {
 	pushTagsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SubmitStub        func(*gcb.Options) error
+	RecordCustodyLinksStub        func(string, []string, []string) (string, error)
+	recordCustodyLinksMutex       sync.RWMutex
+	recordCustodyLinksArgsForCall []struct {
+		arg1 string
+		arg2 []string
+		arg3 []string
+	}
+	recordCustodyLinksReturns struct {
+		result1 string
+		result2 error
+	}
+	recordCustodyLinksReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	StageEncryptedStub        func(*build.Options, string) (bool, error)
+	stageEncryptedMutex       sync.RWMutex
+	stageEncryptedArgsForCall []struct {
+		arg1 *build.Options
+		arg2 string
+	}
+	stageEncryptedReturns struct {
+		result1 bool
+		result2 error
+	}
+	stageEncryptedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	StagedReleaseVersionsStub        func(string, string) (*release.Versions, error)
+	stagedReleaseVersionsMutex       sync.RWMutex
+	stagedReleaseVersionsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	stagedReleaseVersionsReturns struct {
+		result1 *release.Versions
+		result2 error
+	}
+	stagedReleaseVersionsReturnsOnCall map[int]struct {
+		result1 *release.Versions
+		result2 error
+	}
+	SubmitStub        func(context.Context, *gcb.Options) error
 	submitMutex       sync.RWMutex
 	submitArgsForCall []struct {
-		arg1 *gcb.Options
+		arg1 context.Context
+		arg2 *gcb.Options
 	}
 	submitReturns struct {
 		result1 error
@@ -435,6 +571,146 @@ func (fake *FakeReleaseImpl) BranchNeedsCreationReturnsOnCall(i int, result1 boo
 	}{result1, result2}
 }
 
+func (fake *FakeReleaseImpl) CheckArtifactAnomalies(arg1 string, arg2 string, arg3 string, arg4 string, arg5 []string, arg6 []string) error {
+	var arg5Copy []string
+	if arg5 != nil {
+		arg5Copy = make([]string, len(arg5))
+		copy(arg5Copy, arg5)
+	}
+	var arg6Copy []string
+	if arg6 != nil {
+		arg6Copy = make([]string, len(arg6))
+		copy(arg6Copy, arg6)
+	}
+	fake.checkArtifactAnomaliesMutex.Lock()
+	ret, specificReturn := fake.checkArtifactAnomaliesReturnsOnCall[len(fake.checkArtifactAnomaliesArgsForCall)]
+	fake.checkArtifactAnomaliesArgsForCall = append(fake.checkArtifactAnomaliesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 []string
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5Copy, arg6Copy})
+	stub := fake.CheckArtifactAnomaliesStub
+	fakeReturns := fake.checkArtifactAnomaliesReturns
+	fake.recordInvocation("CheckArtifactAnomalies", []interface{}{arg1, arg2, arg3, arg4, arg5Copy, arg6Copy})
+	fake.checkArtifactAnomaliesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) CheckArtifactAnomaliesCallCount() int {
+	fake.checkArtifactAnomaliesMutex.RLock()
+	defer fake.checkArtifactAnomaliesMutex.RUnlock()
+	return len(fake.checkArtifactAnomaliesArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) CheckArtifactAnomaliesCalls(stub func(string, string, string, string, []string, []string) error) {
+	fake.checkArtifactAnomaliesMutex.Lock()
+	defer fake.checkArtifactAnomaliesMutex.Unlock()
+	fake.CheckArtifactAnomaliesStub = stub
+}
+
+func (fake *FakeReleaseImpl) CheckArtifactAnomaliesArgsForCall(i int) (string, string, string, string, []string, []string) {
+	fake.checkArtifactAnomaliesMutex.RLock()
+	defer fake.checkArtifactAnomaliesMutex.RUnlock()
+	argsForCall := fake.checkArtifactAnomaliesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeReleaseImpl) CheckArtifactAnomaliesReturns(result1 error) {
+	fake.checkArtifactAnomaliesMutex.Lock()
+	defer fake.checkArtifactAnomaliesMutex.Unlock()
+	fake.CheckArtifactAnomaliesStub = nil
+	fake.checkArtifactAnomaliesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) CheckArtifactAnomaliesReturnsOnCall(i int, result1 error) {
+	fake.checkArtifactAnomaliesMutex.Lock()
+	defer fake.checkArtifactAnomaliesMutex.Unlock()
+	fake.CheckArtifactAnomaliesStub = nil
+	if fake.checkArtifactAnomaliesReturnsOnCall == nil {
+		fake.checkArtifactAnomaliesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkArtifactAnomaliesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) CheckBaseImages(arg1 string, arg2 string, arg3 string, arg4 string) error {
+	fake.checkBaseImagesMutex.Lock()
+	ret, specificReturn := fake.checkBaseImagesReturnsOnCall[len(fake.checkBaseImagesArgsForCall)]
+	fake.checkBaseImagesArgsForCall = append(fake.checkBaseImagesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CheckBaseImagesStub
+	fakeReturns := fake.checkBaseImagesReturns
+	fake.recordInvocation("CheckBaseImages", []interface{}{arg1, arg2, arg3, arg4})
+	fake.checkBaseImagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) CheckBaseImagesCallCount() int {
+	fake.checkBaseImagesMutex.RLock()
+	defer fake.checkBaseImagesMutex.RUnlock()
+	return len(fake.checkBaseImagesArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) CheckBaseImagesCalls(stub func(string, string, string, string) error) {
+	fake.checkBaseImagesMutex.Lock()
+	defer fake.checkBaseImagesMutex.Unlock()
+	fake.CheckBaseImagesStub = stub
+}
+
+func (fake *FakeReleaseImpl) CheckBaseImagesArgsForCall(i int) (string, string, string, string) {
+	fake.checkBaseImagesMutex.RLock()
+	defer fake.checkBaseImagesMutex.RUnlock()
+	argsForCall := fake.checkBaseImagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeReleaseImpl) CheckBaseImagesReturns(result1 error) {
+	fake.checkBaseImagesMutex.Lock()
+	defer fake.checkBaseImagesMutex.Unlock()
+	fake.CheckBaseImagesStub = nil
+	fake.checkBaseImagesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) CheckBaseImagesReturnsOnCall(i int, result1 error) {
+	fake.checkBaseImagesMutex.Lock()
+	defer fake.checkBaseImagesMutex.Unlock()
+	fake.CheckBaseImagesStub = nil
+	if fake.checkBaseImagesReturnsOnCall == nil {
+		fake.checkBaseImagesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkBaseImagesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseImpl) CheckPrerequisites() error {
 	fake.checkPrerequisitesMutex.Lock()
 	ret, specificReturn := fake.checkPrerequisitesReturnsOnCall[len(fake.checkPrerequisitesArgsForCall)]
@@ -549,6 +825,69 @@ func (fake *FakeReleaseImpl) CheckReleaseBucketReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeReleaseImpl) CheckReleaseCutIssue(arg1 string, arg2 string, arg3 bool) error {
+	fake.checkReleaseCutIssueMutex.Lock()
+	ret, specificReturn := fake.checkReleaseCutIssueReturnsOnCall[len(fake.checkReleaseCutIssueArgsForCall)]
+	fake.checkReleaseCutIssueArgsForCall = append(fake.checkReleaseCutIssueArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.CheckReleaseCutIssueStub
+	fakeReturns := fake.checkReleaseCutIssueReturns
+	fake.recordInvocation("CheckReleaseCutIssue", []interface{}{arg1, arg2, arg3})
+	fake.checkReleaseCutIssueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) CheckReleaseCutIssueCallCount() int {
+	fake.checkReleaseCutIssueMutex.RLock()
+	defer fake.checkReleaseCutIssueMutex.RUnlock()
+	return len(fake.checkReleaseCutIssueArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) CheckReleaseCutIssueCalls(stub func(string, string, bool) error) {
+	fake.checkReleaseCutIssueMutex.Lock()
+	defer fake.checkReleaseCutIssueMutex.Unlock()
+	fake.CheckReleaseCutIssueStub = stub
+}
+
+func (fake *FakeReleaseImpl) CheckReleaseCutIssueArgsForCall(i int) (string, string, bool) {
+	fake.checkReleaseCutIssueMutex.RLock()
+	defer fake.checkReleaseCutIssueMutex.RUnlock()
+	argsForCall := fake.checkReleaseCutIssueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeReleaseImpl) CheckReleaseCutIssueReturns(result1 error) {
+	fake.checkReleaseCutIssueMutex.Lock()
+	defer fake.checkReleaseCutIssueMutex.Unlock()
+	fake.CheckReleaseCutIssueStub = nil
+	fake.checkReleaseCutIssueReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) CheckReleaseCutIssueReturnsOnCall(i int, result1 error) {
+	fake.checkReleaseCutIssueMutex.Lock()
+	defer fake.checkReleaseCutIssueMutex.Unlock()
+	fake.CheckReleaseCutIssueStub = nil
+	if fake.checkReleaseCutIssueReturnsOnCall == nil {
+		fake.checkReleaseCutIssueReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkReleaseCutIssueReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseImpl) CheckStageProvenance(arg1 string, arg2 string, arg3 *release.Versions) error {
 	fake.checkStageProvenanceMutex.Lock()
 	ret, specificReturn := fake.checkStageProvenanceReturnsOnCall[len(fake.checkStageProvenanceArgsForCall)]
@@ -799,6 +1138,67 @@ func (fake *FakeReleaseImpl) CreateAnnouncementReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeReleaseImpl) CreateBlogPost(arg1 *blog.Options) error {
+	fake.createBlogPostMutex.Lock()
+	ret, specificReturn := fake.createBlogPostReturnsOnCall[len(fake.createBlogPostArgsForCall)]
+	fake.createBlogPostArgsForCall = append(fake.createBlogPostArgsForCall, struct {
+		arg1 *blog.Options
+	}{arg1})
+	stub := fake.CreateBlogPostStub
+	fakeReturns := fake.createBlogPostReturns
+	fake.recordInvocation("CreateBlogPost", []interface{}{arg1})
+	fake.createBlogPostMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) CreateBlogPostCallCount() int {
+	fake.createBlogPostMutex.RLock()
+	defer fake.createBlogPostMutex.RUnlock()
+	return len(fake.createBlogPostArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) CreateBlogPostCalls(stub func(*blog.Options) error) {
+	fake.createBlogPostMutex.Lock()
+	defer fake.createBlogPostMutex.Unlock()
+	fake.CreateBlogPostStub = stub
+}
+
+func (fake *FakeReleaseImpl) CreateBlogPostArgsForCall(i int) *blog.Options {
+	fake.createBlogPostMutex.RLock()
+	defer fake.createBlogPostMutex.RUnlock()
+	argsForCall := fake.createBlogPostArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeReleaseImpl) CreateBlogPostReturns(result1 error) {
+	fake.createBlogPostMutex.Lock()
+	defer fake.createBlogPostMutex.Unlock()
+	fake.CreateBlogPostStub = nil
+	fake.createBlogPostReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) CreateBlogPostReturnsOnCall(i int, result1 error) {
+	fake.createBlogPostMutex.Lock()
+	defer fake.createBlogPostMutex.Unlock()
+	fake.CreateBlogPostStub = nil
+	if fake.createBlogPostReturnsOnCall == nil {
+		fake.createBlogPostReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createBlogPostReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseImpl) CreatePubBotBranchIssue(arg1 string) error {
 	fake.createPubBotBranchIssueMutex.Lock()
 	ret, specificReturn := fake.createPubBotBranchIssueReturnsOnCall[len(fake.createPubBotBranchIssueArgsForCall)]
@@ -1118,6 +1518,67 @@ func (fake *FakeReleaseImpl) PrepareWorkspaceReleaseReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeReleaseImpl) PublishAliases(arg1 *urlalias.Options) error {
+	fake.publishAliasesMutex.Lock()
+	ret, specificReturn := fake.publishAliasesReturnsOnCall[len(fake.publishAliasesArgsForCall)]
+	fake.publishAliasesArgsForCall = append(fake.publishAliasesArgsForCall, struct {
+		arg1 *urlalias.Options
+	}{arg1})
+	stub := fake.PublishAliasesStub
+	fakeReturns := fake.publishAliasesReturns
+	fake.recordInvocation("PublishAliases", []interface{}{arg1})
+	fake.publishAliasesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) PublishAliasesCallCount() int {
+	fake.publishAliasesMutex.RLock()
+	defer fake.publishAliasesMutex.RUnlock()
+	return len(fake.publishAliasesArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) PublishAliasesCalls(stub func(*urlalias.Options) error) {
+	fake.publishAliasesMutex.Lock()
+	defer fake.publishAliasesMutex.Unlock()
+	fake.PublishAliasesStub = stub
+}
+
+func (fake *FakeReleaseImpl) PublishAliasesArgsForCall(i int) *urlalias.Options {
+	fake.publishAliasesMutex.RLock()
+	defer fake.publishAliasesMutex.RUnlock()
+	argsForCall := fake.publishAliasesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeReleaseImpl) PublishAliasesReturns(result1 error) {
+	fake.publishAliasesMutex.Lock()
+	defer fake.publishAliasesMutex.Unlock()
+	fake.PublishAliasesStub = nil
+	fake.publishAliasesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) PublishAliasesReturnsOnCall(i int, result1 error) {
+	fake.publishAliasesMutex.Lock()
+	defer fake.publishAliasesMutex.Unlock()
+	fake.PublishAliasesStub = nil
+	if fake.publishAliasesReturnsOnCall == nil {
+		fake.publishAliasesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.publishAliasesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseImpl) PublishReleaseNotesIndex(arg1 string, arg2 string, arg3 string) error {
 	fake.publishReleaseNotesIndexMutex.Lock()
 	ret, specificReturn := fake.publishReleaseNotesIndexReturnsOnCall[len(fake.publishReleaseNotesIndexArgsForCall)]
@@ -1321,6 +1782,130 @@ func (fake *FakeReleaseImpl) PushBranchesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeReleaseImpl) PushContainerImages(arg1 *build.Options) error {
+	fake.pushContainerImagesMutex.Lock()
+	ret, specificReturn := fake.pushContainerImagesReturnsOnCall[len(fake.pushContainerImagesArgsForCall)]
+	fake.pushContainerImagesArgsForCall = append(fake.pushContainerImagesArgsForCall, struct {
+		arg1 *build.Options
+	}{arg1})
+	stub := fake.PushContainerImagesStub
+	fakeReturns := fake.pushContainerImagesReturns
+	fake.recordInvocation("PushContainerImages", []interface{}{arg1})
+	fake.pushContainerImagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) PushContainerImagesCallCount() int {
+	fake.pushContainerImagesMutex.RLock()
+	defer fake.pushContainerImagesMutex.RUnlock()
+	return len(fake.pushContainerImagesArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) PushContainerImagesCalls(stub func(*build.Options) error) {
+	fake.pushContainerImagesMutex.Lock()
+	defer fake.pushContainerImagesMutex.Unlock()
+	fake.PushContainerImagesStub = stub
+}
+
+func (fake *FakeReleaseImpl) PushContainerImagesArgsForCall(i int) *build.Options {
+	fake.pushContainerImagesMutex.RLock()
+	defer fake.pushContainerImagesMutex.RUnlock()
+	argsForCall := fake.pushContainerImagesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeReleaseImpl) PushContainerImagesReturns(result1 error) {
+	fake.pushContainerImagesMutex.Lock()
+	defer fake.pushContainerImagesMutex.Unlock()
+	fake.PushContainerImagesStub = nil
+	fake.pushContainerImagesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) PushContainerImagesReturnsOnCall(i int, result1 error) {
+	fake.pushContainerImagesMutex.Lock()
+	defer fake.pushContainerImagesMutex.Unlock()
+	fake.PushContainerImagesStub = nil
+	if fake.pushContainerImagesReturnsOnCall == nil {
+		fake.pushContainerImagesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushContainerImagesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) PushCustodyLinks(arg1 string, arg2 string, arg3 string) error {
+	fake.pushCustodyLinksMutex.Lock()
+	ret, specificReturn := fake.pushCustodyLinksReturnsOnCall[len(fake.pushCustodyLinksArgsForCall)]
+	fake.pushCustodyLinksArgsForCall = append(fake.pushCustodyLinksArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.PushCustodyLinksStub
+	fakeReturns := fake.pushCustodyLinksReturns
+	fake.recordInvocation("PushCustodyLinks", []interface{}{arg1, arg2, arg3})
+	fake.pushCustodyLinksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) PushCustodyLinksCallCount() int {
+	fake.pushCustodyLinksMutex.RLock()
+	defer fake.pushCustodyLinksMutex.RUnlock()
+	return len(fake.pushCustodyLinksArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) PushCustodyLinksCalls(stub func(string, string, string) error) {
+	fake.pushCustodyLinksMutex.Lock()
+	defer fake.pushCustodyLinksMutex.Unlock()
+	fake.PushCustodyLinksStub = stub
+}
+
+func (fake *FakeReleaseImpl) PushCustodyLinksArgsForCall(i int) (string, string, string) {
+	fake.pushCustodyLinksMutex.RLock()
+	defer fake.pushCustodyLinksMutex.RUnlock()
+	argsForCall := fake.pushCustodyLinksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeReleaseImpl) PushCustodyLinksReturns(result1 error) {
+	fake.pushCustodyLinksMutex.Lock()
+	defer fake.pushCustodyLinksMutex.Unlock()
+	fake.PushCustodyLinksStub = nil
+	fake.pushCustodyLinksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) PushCustodyLinksReturnsOnCall(i int, result1 error) {
+	fake.pushCustodyLinksMutex.Lock()
+	defer fake.pushCustodyLinksMutex.Unlock()
+	fake.PushCustodyLinksStub = nil
+	if fake.pushCustodyLinksReturnsOnCall == nil {
+		fake.pushCustodyLinksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushCustodyLinksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseImpl) PushMainBranch(arg1 *release.GitObjectPusher) error {
 	fake.pushMainBranchMutex.Lock()
 	ret, specificReturn := fake.pushMainBranchReturnsOnCall[len(fake.pushMainBranchArgsForCall)]
@@ -1449,18 +2034,225 @@ func (fake *FakeReleaseImpl) PushTagsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeReleaseImpl) Submit(arg1 *gcb.Options) error {
+func (fake *FakeReleaseImpl) RecordCustodyLinks(arg1 string, arg2 []string, arg3 []string) (string, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.recordCustodyLinksMutex.Lock()
+	ret, specificReturn := fake.recordCustodyLinksReturnsOnCall[len(fake.recordCustodyLinksArgsForCall)]
+	fake.recordCustodyLinksArgsForCall = append(fake.recordCustodyLinksArgsForCall, struct {
+		arg1 string
+		arg2 []string
+		arg3 []string
+	}{arg1, arg2Copy, arg3Copy})
+	stub := fake.RecordCustodyLinksStub
+	fakeReturns := fake.recordCustodyLinksReturns
+	fake.recordInvocation("RecordCustodyLinks", []interface{}{arg1, arg2Copy, arg3Copy})
+	fake.recordCustodyLinksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeReleaseImpl) RecordCustodyLinksCallCount() int {
+	fake.recordCustodyLinksMutex.RLock()
+	defer fake.recordCustodyLinksMutex.RUnlock()
+	return len(fake.recordCustodyLinksArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) RecordCustodyLinksCalls(stub func(string, []string, []string) (string, error)) {
+	fake.recordCustodyLinksMutex.Lock()
+	defer fake.recordCustodyLinksMutex.Unlock()
+	fake.RecordCustodyLinksStub = stub
+}
+
+func (fake *FakeReleaseImpl) RecordCustodyLinksArgsForCall(i int) (string, []string, []string) {
+	fake.recordCustodyLinksMutex.RLock()
+	defer fake.recordCustodyLinksMutex.RUnlock()
+	argsForCall := fake.recordCustodyLinksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeReleaseImpl) RecordCustodyLinksReturns(result1 string, result2 error) {
+	fake.recordCustodyLinksMutex.Lock()
+	defer fake.recordCustodyLinksMutex.Unlock()
+	fake.RecordCustodyLinksStub = nil
+	fake.recordCustodyLinksReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReleaseImpl) RecordCustodyLinksReturnsOnCall(i int, result1 string, result2 error) {
+	fake.recordCustodyLinksMutex.Lock()
+	defer fake.recordCustodyLinksMutex.Unlock()
+	fake.RecordCustodyLinksStub = nil
+	if fake.recordCustodyLinksReturnsOnCall == nil {
+		fake.recordCustodyLinksReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.recordCustodyLinksReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReleaseImpl) StageEncrypted(arg1 *build.Options, arg2 string) (bool, error) {
+	fake.stageEncryptedMutex.Lock()
+	ret, specificReturn := fake.stageEncryptedReturnsOnCall[len(fake.stageEncryptedArgsForCall)]
+	fake.stageEncryptedArgsForCall = append(fake.stageEncryptedArgsForCall, struct {
+		arg1 *build.Options
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.StageEncryptedStub
+	fakeReturns := fake.stageEncryptedReturns
+	fake.recordInvocation("StageEncrypted", []interface{}{arg1, arg2})
+	fake.stageEncryptedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeReleaseImpl) StageEncryptedCallCount() int {
+	fake.stageEncryptedMutex.RLock()
+	defer fake.stageEncryptedMutex.RUnlock()
+	return len(fake.stageEncryptedArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) StageEncryptedCalls(stub func(*build.Options, string) (bool, error)) {
+	fake.stageEncryptedMutex.Lock()
+	defer fake.stageEncryptedMutex.Unlock()
+	fake.StageEncryptedStub = stub
+}
+
+func (fake *FakeReleaseImpl) StageEncryptedArgsForCall(i int) (*build.Options, string) {
+	fake.stageEncryptedMutex.RLock()
+	defer fake.stageEncryptedMutex.RUnlock()
+	argsForCall := fake.stageEncryptedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeReleaseImpl) StageEncryptedReturns(result1 bool, result2 error) {
+	fake.stageEncryptedMutex.Lock()
+	defer fake.stageEncryptedMutex.Unlock()
+	fake.StageEncryptedStub = nil
+	fake.stageEncryptedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReleaseImpl) StageEncryptedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.stageEncryptedMutex.Lock()
+	defer fake.stageEncryptedMutex.Unlock()
+	fake.StageEncryptedStub = nil
+	if fake.stageEncryptedReturnsOnCall == nil {
+		fake.stageEncryptedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.stageEncryptedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReleaseImpl) StagedReleaseVersions(arg1 string, arg2 string) (*release.Versions, error) {
+	fake.stagedReleaseVersionsMutex.Lock()
+	ret, specificReturn := fake.stagedReleaseVersionsReturnsOnCall[len(fake.stagedReleaseVersionsArgsForCall)]
+	fake.stagedReleaseVersionsArgsForCall = append(fake.stagedReleaseVersionsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.StagedReleaseVersionsStub
+	fakeReturns := fake.stagedReleaseVersionsReturns
+	fake.recordInvocation("StagedReleaseVersions", []interface{}{arg1, arg2})
+	fake.stagedReleaseVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeReleaseImpl) StagedReleaseVersionsCallCount() int {
+	fake.stagedReleaseVersionsMutex.RLock()
+	defer fake.stagedReleaseVersionsMutex.RUnlock()
+	return len(fake.stagedReleaseVersionsArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) StagedReleaseVersionsCalls(stub func(string, string) (*release.Versions, error)) {
+	fake.stagedReleaseVersionsMutex.Lock()
+	defer fake.stagedReleaseVersionsMutex.Unlock()
+	fake.StagedReleaseVersionsStub = stub
+}
+
+func (fake *FakeReleaseImpl) StagedReleaseVersionsArgsForCall(i int) (string, string) {
+	fake.stagedReleaseVersionsMutex.RLock()
+	defer fake.stagedReleaseVersionsMutex.RUnlock()
+	argsForCall := fake.stagedReleaseVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeReleaseImpl) StagedReleaseVersionsReturns(result1 *release.Versions, result2 error) {
+	fake.stagedReleaseVersionsMutex.Lock()
+	defer fake.stagedReleaseVersionsMutex.Unlock()
+	fake.StagedReleaseVersionsStub = nil
+	fake.stagedReleaseVersionsReturns = struct {
+		result1 *release.Versions
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReleaseImpl) StagedReleaseVersionsReturnsOnCall(i int, result1 *release.Versions, result2 error) {
+	fake.stagedReleaseVersionsMutex.Lock()
+	defer fake.stagedReleaseVersionsMutex.Unlock()
+	fake.StagedReleaseVersionsStub = nil
+	if fake.stagedReleaseVersionsReturnsOnCall == nil {
+		fake.stagedReleaseVersionsReturnsOnCall = make(map[int]struct {
+			result1 *release.Versions
+			result2 error
+		})
+	}
+	fake.stagedReleaseVersionsReturnsOnCall[i] = struct {
+		result1 *release.Versions
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReleaseImpl) Submit(arg1 context.Context, arg2 *gcb.Options) error {
 	fake.submitMutex.Lock()
 	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
 	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
-		arg1 *gcb.Options
-	}{arg1})
+		arg1 context.Context
+		arg2 *gcb.Options
+	}{arg1, arg2})
 	stub := fake.SubmitStub
 	fakeReturns := fake.submitReturns
-	fake.recordInvocation("Submit", []interface{}{arg1})
+	fake.recordInvocation("Submit", []interface{}{arg1, arg2})
 	fake.submitMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1474,17 +2266,17 @@ func (fake *FakeReleaseImpl) SubmitCallCount() int {
 	return len(fake.submitArgsForCall)
 }
 
-func (fake *FakeReleaseImpl) SubmitCalls(stub func(*gcb.Options) error) {
+func (fake *FakeReleaseImpl) SubmitCalls(stub func(context.Context, *gcb.Options) error) {
 	fake.submitMutex.Lock()
 	defer fake.submitMutex.Unlock()
 	fake.SubmitStub = stub
 }
 
-func (fake *FakeReleaseImpl) SubmitArgsForCall(i int) *gcb.Options {
+func (fake *FakeReleaseImpl) SubmitArgsForCall(i int) (context.Context, *gcb.Options) {
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	argsForCall := fake.submitArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeReleaseImpl) SubmitReturns(result1 error) {
@@ -1702,10 +2494,16 @@ func (fake *FakeReleaseImpl) Invocations() map[string][][]interface{} {
 	defer fake.archiveReleaseMutex.RUnlock()
 	fake.branchNeedsCreationMutex.RLock()
 	defer fake.branchNeedsCreationMutex.RUnlock()
+	fake.checkArtifactAnomaliesMutex.RLock()
+	defer fake.checkArtifactAnomaliesMutex.RUnlock()
+	fake.checkBaseImagesMutex.RLock()
+	defer fake.checkBaseImagesMutex.RUnlock()
 	fake.checkPrerequisitesMutex.RLock()
 	defer fake.checkPrerequisitesMutex.RUnlock()
 	fake.checkReleaseBucketMutex.RLock()
 	defer fake.checkReleaseBucketMutex.RUnlock()
+	fake.checkReleaseCutIssueMutex.RLock()
+	defer fake.checkReleaseCutIssueMutex.RUnlock()
 	fake.checkStageProvenanceMutex.RLock()
 	defer fake.checkStageProvenanceMutex.RUnlock()
 	fake.copyStagedFromGCSMutex.RLock()
@@ -1714,6 +2512,8 @@ func (fake *FakeReleaseImpl) Invocations() map[string][][]interface{} {
 	defer fake.copyToRemoteMutex.RUnlock()
 	fake.createAnnouncementMutex.RLock()
 	defer fake.createAnnouncementMutex.RUnlock()
+	fake.createBlogPostMutex.RLock()
+	defer fake.createBlogPostMutex.RUnlock()
 	fake.createPubBotBranchIssueMutex.RLock()
 	defer fake.createPubBotBranchIssueMutex.RUnlock()
 	fake.generateReleaseVersionMutex.RLock()
@@ -1724,16 +2524,28 @@ func (fake *FakeReleaseImpl) Invocations() map[string][][]interface{} {
 	defer fake.normalizePathMutex.RUnlock()
 	fake.prepareWorkspaceReleaseMutex.RLock()
 	defer fake.prepareWorkspaceReleaseMutex.RUnlock()
+	fake.publishAliasesMutex.RLock()
+	defer fake.publishAliasesMutex.RUnlock()
 	fake.publishReleaseNotesIndexMutex.RLock()
 	defer fake.publishReleaseNotesIndexMutex.RUnlock()
 	fake.publishVersionMutex.RLock()
 	defer fake.publishVersionMutex.RUnlock()
 	fake.pushBranchesMutex.RLock()
 	defer fake.pushBranchesMutex.RUnlock()
+	fake.pushContainerImagesMutex.RLock()
+	defer fake.pushContainerImagesMutex.RUnlock()
+	fake.pushCustodyLinksMutex.RLock()
+	defer fake.pushCustodyLinksMutex.RUnlock()
 	fake.pushMainBranchMutex.RLock()
 	defer fake.pushMainBranchMutex.RUnlock()
 	fake.pushTagsMutex.RLock()
 	defer fake.pushTagsMutex.RUnlock()
+	fake.recordCustodyLinksMutex.RLock()
+	defer fake.recordCustodyLinksMutex.RUnlock()
+	fake.stageEncryptedMutex.RLock()
+	defer fake.stageEncryptedMutex.RUnlock()
+	fake.stagedReleaseVersionsMutex.RLock()
+	defer fake.stagedReleaseVersionsMutex.RUnlock()
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	fake.toFileMutex.RLock()
@@ -18,6 +18,7 @@ limitations under the License.
 package anagofakes
 
 import (
+	"context"
 	"sync"
 )
 
@@ -32,6 +33,16 @@ type FakeStageClient struct {
 	buildReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CheckCISignalStub        func() error
+	checkCISignalMutex       sync.RWMutex
+	checkCISignalArgsForCall []struct {
+	}
+	checkCISignalReturns struct {
+		result1 error
+	}
+	checkCISignalReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CheckPrerequisitesStub        func() error
 	checkPrerequisitesMutex       sync.RWMutex
 	checkPrerequisitesArgsForCall []struct {
@@ -52,6 +63,16 @@ type FakeStageClient struct {
 	checkReleaseBranchStateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	GenerateAttributionStub        func() error
+	generateAttributionMutex       sync.RWMutex
+	generateAttributionArgsForCall []struct {
+	}
+	generateAttributionReturns struct {
+		result1 error
+	}
+	generateAttributionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GenerateBillOfMaterialsStub        func() error
 	generateBillOfMaterialsMutex       sync.RWMutex
 	generateBillOfMaterialsArgsForCall []struct {
@@ -106,6 +127,26 @@ type FakeStageClient struct {
 	prepareWorkspaceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ReportArtifactSizesStub        func() error
+	reportArtifactSizesMutex       sync.RWMutex
+	reportArtifactSizesArgsForCall []struct {
+	}
+	reportArtifactSizesReturns struct {
+		result1 error
+	}
+	reportArtifactSizesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ScanImagesStub        func() error
+	scanImagesMutex       sync.RWMutex
+	scanImagesArgsForCall []struct {
+	}
+	scanImagesReturns struct {
+		result1 error
+	}
+	scanImagesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StageArtifactsStub        func() error
 	stageArtifactsMutex       sync.RWMutex
 	stageArtifactsArgsForCall []struct {
@@ -116,10 +157,11 @@ type FakeStageClient struct {
 	stageArtifactsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SubmitStub        func(bool) error
+	SubmitStub        func(context.Context, bool) error
 	submitMutex       sync.RWMutex
 	submitArgsForCall []struct {
-		arg1 bool
+		arg1 context.Context
+		arg2 bool
 	}
 	submitReturns struct {
 		result1 error
@@ -137,6 +179,16 @@ type FakeStageClient struct {
 	tagRepositoryReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateReleaseCutIssueStub        func() error
+	updateReleaseCutIssueMutex       sync.RWMutex
+	updateReleaseCutIssueArgsForCall []struct {
+	}
+	updateReleaseCutIssueReturns struct {
+		result1 error
+	}
+	updateReleaseCutIssueReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ValidateOptionsStub        func() error
 	validateOptionsMutex       sync.RWMutex
 	validateOptionsArgsForCall []struct {
@@ -214,6 +266,59 @@ func (fake *FakeStageClient) BuildReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStageClient) CheckCISignal() error {
+	fake.checkCISignalMutex.Lock()
+	ret, specificReturn := fake.checkCISignalReturnsOnCall[len(fake.checkCISignalArgsForCall)]
+	fake.checkCISignalArgsForCall = append(fake.checkCISignalArgsForCall, struct {
+	}{})
+	stub := fake.CheckCISignalStub
+	fakeReturns := fake.checkCISignalReturns
+	fake.recordInvocation("CheckCISignal", []interface{}{})
+	fake.checkCISignalMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageClient) CheckCISignalCallCount() int {
+	fake.checkCISignalMutex.RLock()
+	defer fake.checkCISignalMutex.RUnlock()
+	return len(fake.checkCISignalArgsForCall)
+}
+
+func (fake *FakeStageClient) CheckCISignalCalls(stub func() error) {
+	fake.checkCISignalMutex.Lock()
+	defer fake.checkCISignalMutex.Unlock()
+	fake.CheckCISignalStub = stub
+}
+
+func (fake *FakeStageClient) CheckCISignalReturns(result1 error) {
+	fake.checkCISignalMutex.Lock()
+	defer fake.checkCISignalMutex.Unlock()
+	fake.CheckCISignalStub = nil
+	fake.checkCISignalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) CheckCISignalReturnsOnCall(i int, result1 error) {
+	fake.checkCISignalMutex.Lock()
+	defer fake.checkCISignalMutex.Unlock()
+	fake.CheckCISignalStub = nil
+	if fake.checkCISignalReturnsOnCall == nil {
+		fake.checkCISignalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkCISignalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageClient) CheckPrerequisites() error {
 	fake.checkPrerequisitesMutex.Lock()
 	ret, specificReturn := fake.checkPrerequisitesReturnsOnCall[len(fake.checkPrerequisitesArgsForCall)]
@@ -320,6 +425,59 @@ func (fake *FakeStageClient) CheckReleaseBranchStateReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeStageClient) GenerateAttribution() error {
+	fake.generateAttributionMutex.Lock()
+	ret, specificReturn := fake.generateAttributionReturnsOnCall[len(fake.generateAttributionArgsForCall)]
+	fake.generateAttributionArgsForCall = append(fake.generateAttributionArgsForCall, struct {
+	}{})
+	stub := fake.GenerateAttributionStub
+	fakeReturns := fake.generateAttributionReturns
+	fake.recordInvocation("GenerateAttribution", []interface{}{})
+	fake.generateAttributionMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageClient) GenerateAttributionCallCount() int {
+	fake.generateAttributionMutex.RLock()
+	defer fake.generateAttributionMutex.RUnlock()
+	return len(fake.generateAttributionArgsForCall)
+}
+
+func (fake *FakeStageClient) GenerateAttributionCalls(stub func() error) {
+	fake.generateAttributionMutex.Lock()
+	defer fake.generateAttributionMutex.Unlock()
+	fake.GenerateAttributionStub = stub
+}
+
+func (fake *FakeStageClient) GenerateAttributionReturns(result1 error) {
+	fake.generateAttributionMutex.Lock()
+	defer fake.generateAttributionMutex.Unlock()
+	fake.GenerateAttributionStub = nil
+	fake.generateAttributionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) GenerateAttributionReturnsOnCall(i int, result1 error) {
+	fake.generateAttributionMutex.Lock()
+	defer fake.generateAttributionMutex.Unlock()
+	fake.GenerateAttributionStub = nil
+	if fake.generateAttributionReturnsOnCall == nil {
+		fake.generateAttributionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.generateAttributionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageClient) GenerateBillOfMaterials() error {
 	fake.generateBillOfMaterialsMutex.Lock()
 	ret, specificReturn := fake.generateBillOfMaterialsReturnsOnCall[len(fake.generateBillOfMaterialsArgsForCall)]
@@ -609,6 +767,112 @@ func (fake *FakeStageClient) PrepareWorkspaceReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeStageClient) ReportArtifactSizes() error {
+	fake.reportArtifactSizesMutex.Lock()
+	ret, specificReturn := fake.reportArtifactSizesReturnsOnCall[len(fake.reportArtifactSizesArgsForCall)]
+	fake.reportArtifactSizesArgsForCall = append(fake.reportArtifactSizesArgsForCall, struct {
+	}{})
+	stub := fake.ReportArtifactSizesStub
+	fakeReturns := fake.reportArtifactSizesReturns
+	fake.recordInvocation("ReportArtifactSizes", []interface{}{})
+	fake.reportArtifactSizesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageClient) ReportArtifactSizesCallCount() int {
+	fake.reportArtifactSizesMutex.RLock()
+	defer fake.reportArtifactSizesMutex.RUnlock()
+	return len(fake.reportArtifactSizesArgsForCall)
+}
+
+func (fake *FakeStageClient) ReportArtifactSizesCalls(stub func() error) {
+	fake.reportArtifactSizesMutex.Lock()
+	defer fake.reportArtifactSizesMutex.Unlock()
+	fake.ReportArtifactSizesStub = stub
+}
+
+func (fake *FakeStageClient) ReportArtifactSizesReturns(result1 error) {
+	fake.reportArtifactSizesMutex.Lock()
+	defer fake.reportArtifactSizesMutex.Unlock()
+	fake.ReportArtifactSizesStub = nil
+	fake.reportArtifactSizesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) ReportArtifactSizesReturnsOnCall(i int, result1 error) {
+	fake.reportArtifactSizesMutex.Lock()
+	defer fake.reportArtifactSizesMutex.Unlock()
+	fake.ReportArtifactSizesStub = nil
+	if fake.reportArtifactSizesReturnsOnCall == nil {
+		fake.reportArtifactSizesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reportArtifactSizesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) ScanImages() error {
+	fake.scanImagesMutex.Lock()
+	ret, specificReturn := fake.scanImagesReturnsOnCall[len(fake.scanImagesArgsForCall)]
+	fake.scanImagesArgsForCall = append(fake.scanImagesArgsForCall, struct {
+	}{})
+	stub := fake.ScanImagesStub
+	fakeReturns := fake.scanImagesReturns
+	fake.recordInvocation("ScanImages", []interface{}{})
+	fake.scanImagesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageClient) ScanImagesCallCount() int {
+	fake.scanImagesMutex.RLock()
+	defer fake.scanImagesMutex.RUnlock()
+	return len(fake.scanImagesArgsForCall)
+}
+
+func (fake *FakeStageClient) ScanImagesCalls(stub func() error) {
+	fake.scanImagesMutex.Lock()
+	defer fake.scanImagesMutex.Unlock()
+	fake.ScanImagesStub = stub
+}
+
+func (fake *FakeStageClient) ScanImagesReturns(result1 error) {
+	fake.scanImagesMutex.Lock()
+	defer fake.scanImagesMutex.Unlock()
+	fake.ScanImagesStub = nil
+	fake.scanImagesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) ScanImagesReturnsOnCall(i int, result1 error) {
+	fake.scanImagesMutex.Lock()
+	defer fake.scanImagesMutex.Unlock()
+	fake.ScanImagesStub = nil
+	if fake.scanImagesReturnsOnCall == nil {
+		fake.scanImagesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.scanImagesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageClient) StageArtifacts() error {
 	fake.stageArtifactsMutex.Lock()
 	ret, specificReturn := fake.stageArtifactsReturnsOnCall[len(fake.stageArtifactsArgsForCall)]
@@ -662,18 +926,19 @@ func (fake *FakeStageClient) StageArtifactsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeStageClient) Submit(arg1 bool) error {
+func (fake *FakeStageClient) Submit(arg1 context.Context, arg2 bool) error {
 	fake.submitMutex.Lock()
 	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
 	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
-		arg1 bool
-	}{arg1})
+		arg1 context.Context
+		arg2 bool
+	}{arg1, arg2})
 	stub := fake.SubmitStub
 	fakeReturns := fake.submitReturns
-	fake.recordInvocation("Submit", []interface{}{arg1})
+	fake.recordInvocation("Submit", []interface{}{arg1, arg2})
 	fake.submitMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -687,17 +952,17 @@ func (fake *FakeStageClient) SubmitCallCount() int {
 	return len(fake.submitArgsForCall)
 }
 
-func (fake *FakeStageClient) SubmitCalls(stub func(bool) error) {
+func (fake *FakeStageClient) SubmitCalls(stub func(context.Context, bool) error) {
 	fake.submitMutex.Lock()
 	defer fake.submitMutex.Unlock()
 	fake.SubmitStub = stub
 }
 
-func (fake *FakeStageClient) SubmitArgsForCall(i int) bool {
+func (fake *FakeStageClient) SubmitArgsForCall(i int) (context.Context, bool) {
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	argsForCall := fake.submitArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeStageClient) SubmitReturns(result1 error) {
@@ -776,6 +1041,59 @@ func (fake *FakeStageClient) TagRepositoryReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStageClient) UpdateReleaseCutIssue() error {
+	fake.updateReleaseCutIssueMutex.Lock()
+	ret, specificReturn := fake.updateReleaseCutIssueReturnsOnCall[len(fake.updateReleaseCutIssueArgsForCall)]
+	fake.updateReleaseCutIssueArgsForCall = append(fake.updateReleaseCutIssueArgsForCall, struct {
+	}{})
+	stub := fake.UpdateReleaseCutIssueStub
+	fakeReturns := fake.updateReleaseCutIssueReturns
+	fake.recordInvocation("UpdateReleaseCutIssue", []interface{}{})
+	fake.updateReleaseCutIssueMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageClient) UpdateReleaseCutIssueCallCount() int {
+	fake.updateReleaseCutIssueMutex.RLock()
+	defer fake.updateReleaseCutIssueMutex.RUnlock()
+	return len(fake.updateReleaseCutIssueArgsForCall)
+}
+
+func (fake *FakeStageClient) UpdateReleaseCutIssueCalls(stub func() error) {
+	fake.updateReleaseCutIssueMutex.Lock()
+	defer fake.updateReleaseCutIssueMutex.Unlock()
+	fake.UpdateReleaseCutIssueStub = stub
+}
+
+func (fake *FakeStageClient) UpdateReleaseCutIssueReturns(result1 error) {
+	fake.updateReleaseCutIssueMutex.Lock()
+	defer fake.updateReleaseCutIssueMutex.Unlock()
+	fake.UpdateReleaseCutIssueStub = nil
+	fake.updateReleaseCutIssueReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) UpdateReleaseCutIssueReturnsOnCall(i int, result1 error) {
+	fake.updateReleaseCutIssueMutex.Lock()
+	defer fake.updateReleaseCutIssueMutex.Unlock()
+	fake.UpdateReleaseCutIssueStub = nil
+	if fake.updateReleaseCutIssueReturnsOnCall == nil {
+		fake.updateReleaseCutIssueReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateReleaseCutIssueReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageClient) ValidateOptions() error {
 	fake.validateOptionsMutex.Lock()
 	ret, specificReturn := fake.validateOptionsReturnsOnCall[len(fake.validateOptionsArgsForCall)]
@@ -887,10 +1205,14 @@ func (fake *FakeStageClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.checkCISignalMutex.RLock()
+	defer fake.checkCISignalMutex.RUnlock()
 	fake.checkPrerequisitesMutex.RLock()
 	defer fake.checkPrerequisitesMutex.RUnlock()
 	fake.checkReleaseBranchStateMutex.RLock()
 	defer fake.checkReleaseBranchStateMutex.RUnlock()
+	fake.generateAttributionMutex.RLock()
+	defer fake.generateAttributionMutex.RUnlock()
 	fake.generateBillOfMaterialsMutex.RLock()
 	defer fake.generateBillOfMaterialsMutex.RUnlock()
 	fake.generateChangelogMutex.RLock()
@@ -903,12 +1225,18 @@ func (fake *FakeStageClient) Invocations() map[string][][]interface{} {
 	defer fake.initStateMutex.RUnlock()
 	fake.prepareWorkspaceMutex.RLock()
 	defer fake.prepareWorkspaceMutex.RUnlock()
+	fake.reportArtifactSizesMutex.RLock()
+	defer fake.reportArtifactSizesMutex.RUnlock()
+	fake.scanImagesMutex.RLock()
+	defer fake.scanImagesMutex.RUnlock()
 	fake.stageArtifactsMutex.RLock()
 	defer fake.stageArtifactsMutex.RUnlock()
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	fake.tagRepositoryMutex.RLock()
 	defer fake.tagRepositoryMutex.RUnlock()
+	fake.updateReleaseCutIssueMutex.RLock()
+	defer fake.updateReleaseCutIssueMutex.RUnlock()
 	fake.validateOptionsMutex.RLock()
 	defer fake.validateOptionsMutex.RUnlock()
 	fake.verifyArtifactsMutex.RLock()
@@ -18,15 +18,21 @@ limitations under the License.
 package anagofakes
 
 import (
+	"context"
 	"sync"
 
 	semver "github.com/blang/semver/v4"
 	"github.com/in-toto/in-toto-golang/in_toto"
 	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/attribution"
 	"k8s.io/release/pkg/build"
+	"k8s.io/release/pkg/buildenv"
 	"k8s.io/release/pkg/changelog"
 	"k8s.io/release/pkg/gcp/gcb"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/sizereport"
+	"k8s.io/release/pkg/testgrid"
+	"k8s.io/release/pkg/vulnscan"
 	"sigs.k8s.io/bom/pkg/provenance"
 	"sigs.k8s.io/bom/pkg/spdx"
 	"sigs.k8s.io/release-sdk/git"
@@ -85,6 +91,33 @@ type FakeStageImpl struct {
 		result1 *spdx.Document
 		result2 error
 	}
+	CISignalStub        func(string) (*testgrid.Signal, error)
+	cISignalMutex       sync.RWMutex
+	cISignalArgsForCall []struct {
+		arg1 string
+	}
+	cISignalReturns struct {
+		result1 *testgrid.Signal
+		result2 error
+	}
+	cISignalReturnsOnCall map[int]struct {
+		result1 *testgrid.Signal
+		result2 error
+	}
+	CheckBaseImagesStub        func(string, string, string, string) error
+	checkBaseImagesMutex       sync.RWMutex
+	checkBaseImagesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	checkBaseImagesReturns struct {
+		result1 error
+	}
+	checkBaseImagesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CheckPrerequisitesStub        func() error
 	checkPrerequisitesMutex       sync.RWMutex
 	checkPrerequisitesArgsForCall []struct {
@@ -106,6 +139,19 @@ type FakeStageImpl struct {
 	checkReleaseBucketReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CheckReleaseCutIssueStub        func(string, string, bool) error
+	checkReleaseCutIssueMutex       sync.RWMutex
+	checkReleaseCutIssueArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	checkReleaseCutIssueReturns struct {
+		result1 error
+	}
+	checkReleaseCutIssueReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CheckoutStub        func(*git.Repo, string, ...string) error
 	checkoutMutex       sync.RWMutex
 	checkoutArgsForCall []struct {
@@ -119,6 +165,34 @@ type FakeStageImpl struct {
 	checkoutReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CollectBuildEnvironmentStub        func(string, string, string) (*buildenv.Manifest, error)
+	collectBuildEnvironmentMutex       sync.RWMutex
+	collectBuildEnvironmentArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	collectBuildEnvironmentReturns struct {
+		result1 *buildenv.Manifest
+		result2 error
+	}
+	collectBuildEnvironmentReturnsOnCall map[int]struct {
+		result1 *buildenv.Manifest
+		result2 error
+	}
+	CommentReleaseCutIssueStub        func(string, string, bool) error
+	commentReleaseCutIssueMutex       sync.RWMutex
+	commentReleaseCutIssueArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	commentReleaseCutIssueReturns struct {
+		result1 error
+	}
+	commentReleaseCutIssueReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CommitEmptyStub        func(*git.Repo, string) error
 	commitEmptyMutex       sync.RWMutex
 	commitEmptyArgsForCall []struct {
@@ -180,6 +254,17 @@ type FakeStageImpl struct {
 		result1 *provenance.Statement
 		result2 error
 	}
+	GenerateAttributionStub        func(*attribution.Options) error
+	generateAttributionMutex       sync.RWMutex
+	generateAttributionArgsForCall []struct {
+		arg1 *attribution.Options
+	}
+	generateAttributionReturns struct {
+		result1 error
+	}
+	generateAttributionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GenerateChangelogStub        func(*changelog.Options) error
 	generateChangelogMutex       sync.RWMutex
 	generateChangelogArgsForCall []struct {
@@ -381,6 +466,19 @@ type FakeStageImpl struct {
 	pushContainerImagesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PushCustodyLinksStub        func(string, string, string) error
+	pushCustodyLinksMutex       sync.RWMutex
+	pushCustodyLinksArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	pushCustodyLinksReturns struct {
+		result1 error
+	}
+	pushCustodyLinksReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PushReleaseArtifactsStub        func(*build.Options, string, string) error
 	pushReleaseArtifactsMutex       sync.RWMutex
 	pushReleaseArtifactsArgsForCall []struct {
@@ -394,6 +492,50 @@ type FakeStageImpl struct {
 	pushReleaseArtifactsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PushReleaseVersionsStub        func(string, string, *release.Versions) error
+	pushReleaseVersionsMutex       sync.RWMutex
+	pushReleaseVersionsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *release.Versions
+	}
+	pushReleaseVersionsReturns struct {
+		result1 error
+	}
+	pushReleaseVersionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RecordCustodyLinksStub        func(string, []string, []string) (string, error)
+	recordCustodyLinksMutex       sync.RWMutex
+	recordCustodyLinksArgsForCall []struct {
+		arg1 string
+		arg2 []string
+		arg3 []string
+	}
+	recordCustodyLinksReturns struct {
+		result1 string
+		result2 error
+	}
+	recordCustodyLinksReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ReportArtifactSizesStub        func(*sizereport.Options, string, string, string) (*sizereport.Report, error)
+	reportArtifactSizesMutex       sync.RWMutex
+	reportArtifactSizesArgsForCall []struct {
+		arg1 *sizereport.Options
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	reportArtifactSizesReturns struct {
+		result1 *sizereport.Report
+		result2 error
+	}
+	reportArtifactSizesReturnsOnCall map[int]struct {
+		result1 *sizereport.Report
+		result2 error
+	}
 	RevParseStub        func(*git.Repo, string) (string, error)
 	revParseMutex       sync.RWMutex
 	revParseArgsForCall []struct {
@@ -422,6 +564,19 @@ type FakeStageImpl struct {
 		result1 string
 		result2 error
 	}
+	ScanImagesStub        func(*vulnscan.Options, []string, string) error
+	scanImagesMutex       sync.RWMutex
+	scanImagesArgsForCall []struct {
+		arg1 *vulnscan.Options
+		arg2 []string
+		arg3 string
+	}
+	scanImagesReturns struct {
+		result1 error
+	}
+	scanImagesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StageLocalArtifactsStub        func(*build.Options) error
 	stageLocalArtifactsMutex       sync.RWMutex
 	stageLocalArtifactsArgsForCall []struct {
@@ -446,10 +601,11 @@ type FakeStageImpl struct {
 	stageLocalSourceTreeReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SubmitStub        func(*gcb.Options) error
+	SubmitStub        func(context.Context, *gcb.Options) error
 	submitMutex       sync.RWMutex
 	submitArgsForCall []struct {
-		arg1 *gcb.Options
+		arg1 context.Context
+		arg2 *gcb.Options
 	}
 	submitReturns struct {
 		result1 error
@@ -492,6 +648,18 @@ type FakeStageImpl struct {
 	verifyArtifactsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WriteBuildEnvironmentStub        func(*buildenv.Manifest, string) error
+	writeBuildEnvironmentMutex       sync.RWMutex
+	writeBuildEnvironmentArgsForCall []struct {
+		arg1 *buildenv.Manifest
+		arg2 string
+	}
+	writeBuildEnvironmentReturns struct {
+		result1 error
+	}
+	writeBuildEnvironmentReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WriteSourceBOMStub        func(*spdx.Document, string) error
 	writeSourceBOMMutex       sync.RWMutex
 	writeSourceBOMArgsForCall []struct {
@@ -762,6 +930,134 @@ func (fake *FakeStageImpl) BuildBaseArtifactsSBOMReturnsOnCall(i int, result1 *s
 	}{result1, result2}
 }
 
+func (fake *FakeStageImpl) CISignal(arg1 string) (*testgrid.Signal, error) {
+	fake.cISignalMutex.Lock()
+	ret, specificReturn := fake.cISignalReturnsOnCall[len(fake.cISignalArgsForCall)]
+	fake.cISignalArgsForCall = append(fake.cISignalArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CISignalStub
+	fakeReturns := fake.cISignalReturns
+	fake.recordInvocation("CISignal", []interface{}{arg1})
+	fake.cISignalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStageImpl) CISignalCallCount() int {
+	fake.cISignalMutex.RLock()
+	defer fake.cISignalMutex.RUnlock()
+	return len(fake.cISignalArgsForCall)
+}
+
+func (fake *FakeStageImpl) CISignalCalls(stub func(string) (*testgrid.Signal, error)) {
+	fake.cISignalMutex.Lock()
+	defer fake.cISignalMutex.Unlock()
+	fake.CISignalStub = stub
+}
+
+func (fake *FakeStageImpl) CISignalArgsForCall(i int) string {
+	fake.cISignalMutex.RLock()
+	defer fake.cISignalMutex.RUnlock()
+	argsForCall := fake.cISignalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStageImpl) CISignalReturns(result1 *testgrid.Signal, result2 error) {
+	fake.cISignalMutex.Lock()
+	defer fake.cISignalMutex.Unlock()
+	fake.CISignalStub = nil
+	fake.cISignalReturns = struct {
+		result1 *testgrid.Signal
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStageImpl) CISignalReturnsOnCall(i int, result1 *testgrid.Signal, result2 error) {
+	fake.cISignalMutex.Lock()
+	defer fake.cISignalMutex.Unlock()
+	fake.CISignalStub = nil
+	if fake.cISignalReturnsOnCall == nil {
+		fake.cISignalReturnsOnCall = make(map[int]struct {
+			result1 *testgrid.Signal
+			result2 error
+		})
+	}
+	fake.cISignalReturnsOnCall[i] = struct {
+		result1 *testgrid.Signal
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStageImpl) CheckBaseImages(arg1 string, arg2 string, arg3 string, arg4 string) error {
+	fake.checkBaseImagesMutex.Lock()
+	ret, specificReturn := fake.checkBaseImagesReturnsOnCall[len(fake.checkBaseImagesArgsForCall)]
+	fake.checkBaseImagesArgsForCall = append(fake.checkBaseImagesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CheckBaseImagesStub
+	fakeReturns := fake.checkBaseImagesReturns
+	fake.recordInvocation("CheckBaseImages", []interface{}{arg1, arg2, arg3, arg4})
+	fake.checkBaseImagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) CheckBaseImagesCallCount() int {
+	fake.checkBaseImagesMutex.RLock()
+	defer fake.checkBaseImagesMutex.RUnlock()
+	return len(fake.checkBaseImagesArgsForCall)
+}
+
+func (fake *FakeStageImpl) CheckBaseImagesCalls(stub func(string, string, string, string) error) {
+	fake.checkBaseImagesMutex.Lock()
+	defer fake.checkBaseImagesMutex.Unlock()
+	fake.CheckBaseImagesStub = stub
+}
+
+func (fake *FakeStageImpl) CheckBaseImagesArgsForCall(i int) (string, string, string, string) {
+	fake.checkBaseImagesMutex.RLock()
+	defer fake.checkBaseImagesMutex.RUnlock()
+	argsForCall := fake.checkBaseImagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeStageImpl) CheckBaseImagesReturns(result1 error) {
+	fake.checkBaseImagesMutex.Lock()
+	defer fake.checkBaseImagesMutex.Unlock()
+	fake.CheckBaseImagesStub = nil
+	fake.checkBaseImagesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) CheckBaseImagesReturnsOnCall(i int, result1 error) {
+	fake.checkBaseImagesMutex.Lock()
+	defer fake.checkBaseImagesMutex.Unlock()
+	fake.CheckBaseImagesStub = nil
+	if fake.checkBaseImagesReturnsOnCall == nil {
+		fake.checkBaseImagesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkBaseImagesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageImpl) CheckPrerequisites() error {
 	fake.checkPrerequisitesMutex.Lock()
 	ret, specificReturn := fake.checkPrerequisitesReturnsOnCall[len(fake.checkPrerequisitesArgsForCall)]
@@ -876,6 +1172,69 @@ func (fake *FakeStageImpl) CheckReleaseBucketReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeStageImpl) CheckReleaseCutIssue(arg1 string, arg2 string, arg3 bool) error {
+	fake.checkReleaseCutIssueMutex.Lock()
+	ret, specificReturn := fake.checkReleaseCutIssueReturnsOnCall[len(fake.checkReleaseCutIssueArgsForCall)]
+	fake.checkReleaseCutIssueArgsForCall = append(fake.checkReleaseCutIssueArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.CheckReleaseCutIssueStub
+	fakeReturns := fake.checkReleaseCutIssueReturns
+	fake.recordInvocation("CheckReleaseCutIssue", []interface{}{arg1, arg2, arg3})
+	fake.checkReleaseCutIssueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) CheckReleaseCutIssueCallCount() int {
+	fake.checkReleaseCutIssueMutex.RLock()
+	defer fake.checkReleaseCutIssueMutex.RUnlock()
+	return len(fake.checkReleaseCutIssueArgsForCall)
+}
+
+func (fake *FakeStageImpl) CheckReleaseCutIssueCalls(stub func(string, string, bool) error) {
+	fake.checkReleaseCutIssueMutex.Lock()
+	defer fake.checkReleaseCutIssueMutex.Unlock()
+	fake.CheckReleaseCutIssueStub = stub
+}
+
+func (fake *FakeStageImpl) CheckReleaseCutIssueArgsForCall(i int) (string, string, bool) {
+	fake.checkReleaseCutIssueMutex.RLock()
+	defer fake.checkReleaseCutIssueMutex.RUnlock()
+	argsForCall := fake.checkReleaseCutIssueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStageImpl) CheckReleaseCutIssueReturns(result1 error) {
+	fake.checkReleaseCutIssueMutex.Lock()
+	defer fake.checkReleaseCutIssueMutex.Unlock()
+	fake.CheckReleaseCutIssueStub = nil
+	fake.checkReleaseCutIssueReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) CheckReleaseCutIssueReturnsOnCall(i int, result1 error) {
+	fake.checkReleaseCutIssueMutex.Lock()
+	defer fake.checkReleaseCutIssueMutex.Unlock()
+	fake.CheckReleaseCutIssueStub = nil
+	if fake.checkReleaseCutIssueReturnsOnCall == nil {
+		fake.checkReleaseCutIssueReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkReleaseCutIssueReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageImpl) Checkout(arg1 *git.Repo, arg2 string, arg3 ...string) error {
 	fake.checkoutMutex.Lock()
 	ret, specificReturn := fake.checkoutReturnsOnCall[len(fake.checkoutArgsForCall)]
@@ -939,6 +1298,135 @@ func (fake *FakeStageImpl) CheckoutReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStageImpl) CollectBuildEnvironment(arg1 string, arg2 string, arg3 string) (*buildenv.Manifest, error) {
+	fake.collectBuildEnvironmentMutex.Lock()
+	ret, specificReturn := fake.collectBuildEnvironmentReturnsOnCall[len(fake.collectBuildEnvironmentArgsForCall)]
+	fake.collectBuildEnvironmentArgsForCall = append(fake.collectBuildEnvironmentArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CollectBuildEnvironmentStub
+	fakeReturns := fake.collectBuildEnvironmentReturns
+	fake.recordInvocation("CollectBuildEnvironment", []interface{}{arg1, arg2, arg3})
+	fake.collectBuildEnvironmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStageImpl) CollectBuildEnvironmentCallCount() int {
+	fake.collectBuildEnvironmentMutex.RLock()
+	defer fake.collectBuildEnvironmentMutex.RUnlock()
+	return len(fake.collectBuildEnvironmentArgsForCall)
+}
+
+func (fake *FakeStageImpl) CollectBuildEnvironmentCalls(stub func(string, string, string) (*buildenv.Manifest, error)) {
+	fake.collectBuildEnvironmentMutex.Lock()
+	defer fake.collectBuildEnvironmentMutex.Unlock()
+	fake.CollectBuildEnvironmentStub = stub
+}
+
+func (fake *FakeStageImpl) CollectBuildEnvironmentArgsForCall(i int) (string, string, string) {
+	fake.collectBuildEnvironmentMutex.RLock()
+	defer fake.collectBuildEnvironmentMutex.RUnlock()
+	argsForCall := fake.collectBuildEnvironmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStageImpl) CollectBuildEnvironmentReturns(result1 *buildenv.Manifest, result2 error) {
+	fake.collectBuildEnvironmentMutex.Lock()
+	defer fake.collectBuildEnvironmentMutex.Unlock()
+	fake.CollectBuildEnvironmentStub = nil
+	fake.collectBuildEnvironmentReturns = struct {
+		result1 *buildenv.Manifest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStageImpl) CollectBuildEnvironmentReturnsOnCall(i int, result1 *buildenv.Manifest, result2 error) {
+	fake.collectBuildEnvironmentMutex.Lock()
+	defer fake.collectBuildEnvironmentMutex.Unlock()
+	fake.CollectBuildEnvironmentStub = nil
+	if fake.collectBuildEnvironmentReturnsOnCall == nil {
+		fake.collectBuildEnvironmentReturnsOnCall = make(map[int]struct {
+			result1 *buildenv.Manifest
+			result2 error
+		})
+	}
+	fake.collectBuildEnvironmentReturnsOnCall[i] = struct {
+		result1 *buildenv.Manifest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStageImpl) CommentReleaseCutIssue(arg1 string, arg2 string, arg3 bool) error {
+	fake.commentReleaseCutIssueMutex.Lock()
+	ret, specificReturn := fake.commentReleaseCutIssueReturnsOnCall[len(fake.commentReleaseCutIssueArgsForCall)]
+	fake.commentReleaseCutIssueArgsForCall = append(fake.commentReleaseCutIssueArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.CommentReleaseCutIssueStub
+	fakeReturns := fake.commentReleaseCutIssueReturns
+	fake.recordInvocation("CommentReleaseCutIssue", []interface{}{arg1, arg2, arg3})
+	fake.commentReleaseCutIssueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) CommentReleaseCutIssueCallCount() int {
+	fake.commentReleaseCutIssueMutex.RLock()
+	defer fake.commentReleaseCutIssueMutex.RUnlock()
+	return len(fake.commentReleaseCutIssueArgsForCall)
+}
+
+func (fake *FakeStageImpl) CommentReleaseCutIssueCalls(stub func(string, string, bool) error) {
+	fake.commentReleaseCutIssueMutex.Lock()
+	defer fake.commentReleaseCutIssueMutex.Unlock()
+	fake.CommentReleaseCutIssueStub = stub
+}
+
+func (fake *FakeStageImpl) CommentReleaseCutIssueArgsForCall(i int) (string, string, bool) {
+	fake.commentReleaseCutIssueMutex.RLock()
+	defer fake.commentReleaseCutIssueMutex.RUnlock()
+	argsForCall := fake.commentReleaseCutIssueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStageImpl) CommentReleaseCutIssueReturns(result1 error) {
+	fake.commentReleaseCutIssueMutex.Lock()
+	defer fake.commentReleaseCutIssueMutex.Unlock()
+	fake.CommentReleaseCutIssueStub = nil
+	fake.commentReleaseCutIssueReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) CommentReleaseCutIssueReturnsOnCall(i int, result1 error) {
+	fake.commentReleaseCutIssueMutex.Lock()
+	defer fake.commentReleaseCutIssueMutex.Unlock()
+	fake.CommentReleaseCutIssueStub = nil
+	if fake.commentReleaseCutIssueReturnsOnCall == nil {
+		fake.commentReleaseCutIssueReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.commentReleaseCutIssueReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageImpl) CommitEmpty(arg1 *git.Repo, arg2 string) error {
 	fake.commitEmptyMutex.Lock()
 	ret, specificReturn := fake.commitEmptyReturnsOnCall[len(fake.commitEmptyArgsForCall)]
@@ -1245,6 +1733,67 @@ func (fake *FakeStageImpl) GenerateAttestationReturnsOnCall(i int, result1 *prov
 	}{result1, result2}
 }
 
+func (fake *FakeStageImpl) GenerateAttribution(arg1 *attribution.Options) error {
+	fake.generateAttributionMutex.Lock()
+	ret, specificReturn := fake.generateAttributionReturnsOnCall[len(fake.generateAttributionArgsForCall)]
+	fake.generateAttributionArgsForCall = append(fake.generateAttributionArgsForCall, struct {
+		arg1 *attribution.Options
+	}{arg1})
+	stub := fake.GenerateAttributionStub
+	fakeReturns := fake.generateAttributionReturns
+	fake.recordInvocation("GenerateAttribution", []interface{}{arg1})
+	fake.generateAttributionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) GenerateAttributionCallCount() int {
+	fake.generateAttributionMutex.RLock()
+	defer fake.generateAttributionMutex.RUnlock()
+	return len(fake.generateAttributionArgsForCall)
+}
+
+func (fake *FakeStageImpl) GenerateAttributionCalls(stub func(*attribution.Options) error) {
+	fake.generateAttributionMutex.Lock()
+	defer fake.generateAttributionMutex.Unlock()
+	fake.GenerateAttributionStub = stub
+}
+
+func (fake *FakeStageImpl) GenerateAttributionArgsForCall(i int) *attribution.Options {
+	fake.generateAttributionMutex.RLock()
+	defer fake.generateAttributionMutex.RUnlock()
+	argsForCall := fake.generateAttributionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStageImpl) GenerateAttributionReturns(result1 error) {
+	fake.generateAttributionMutex.Lock()
+	defer fake.generateAttributionMutex.Unlock()
+	fake.GenerateAttributionStub = nil
+	fake.generateAttributionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) GenerateAttributionReturnsOnCall(i int, result1 error) {
+	fake.generateAttributionMutex.Lock()
+	defer fake.generateAttributionMutex.Unlock()
+	fake.GenerateAttributionStub = nil
+	if fake.generateAttributionReturnsOnCall == nil {
+		fake.generateAttributionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.generateAttributionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageImpl) GenerateChangelog(arg1 *changelog.Options) error {
 	fake.generateChangelogMutex.Lock()
 	ret, specificReturn := fake.generateChangelogReturnsOnCall[len(fake.generateChangelogArgsForCall)]
@@ -2220,6 +2769,69 @@ func (fake *FakeStageImpl) PushContainerImagesReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
+func (fake *FakeStageImpl) PushCustodyLinks(arg1 string, arg2 string, arg3 string) error {
+	fake.pushCustodyLinksMutex.Lock()
+	ret, specificReturn := fake.pushCustodyLinksReturnsOnCall[len(fake.pushCustodyLinksArgsForCall)]
+	fake.pushCustodyLinksArgsForCall = append(fake.pushCustodyLinksArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.PushCustodyLinksStub
+	fakeReturns := fake.pushCustodyLinksReturns
+	fake.recordInvocation("PushCustodyLinks", []interface{}{arg1, arg2, arg3})
+	fake.pushCustodyLinksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) PushCustodyLinksCallCount() int {
+	fake.pushCustodyLinksMutex.RLock()
+	defer fake.pushCustodyLinksMutex.RUnlock()
+	return len(fake.pushCustodyLinksArgsForCall)
+}
+
+func (fake *FakeStageImpl) PushCustodyLinksCalls(stub func(string, string, string) error) {
+	fake.pushCustodyLinksMutex.Lock()
+	defer fake.pushCustodyLinksMutex.Unlock()
+	fake.PushCustodyLinksStub = stub
+}
+
+func (fake *FakeStageImpl) PushCustodyLinksArgsForCall(i int) (string, string, string) {
+	fake.pushCustodyLinksMutex.RLock()
+	defer fake.pushCustodyLinksMutex.RUnlock()
+	argsForCall := fake.pushCustodyLinksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStageImpl) PushCustodyLinksReturns(result1 error) {
+	fake.pushCustodyLinksMutex.Lock()
+	defer fake.pushCustodyLinksMutex.Unlock()
+	fake.PushCustodyLinksStub = nil
+	fake.pushCustodyLinksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) PushCustodyLinksReturnsOnCall(i int, result1 error) {
+	fake.pushCustodyLinksMutex.Lock()
+	defer fake.pushCustodyLinksMutex.Unlock()
+	fake.PushCustodyLinksStub = nil
+	if fake.pushCustodyLinksReturnsOnCall == nil {
+		fake.pushCustodyLinksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushCustodyLinksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageImpl) PushReleaseArtifacts(arg1 *build.Options, arg2 string, arg3 string) error {
 	fake.pushReleaseArtifactsMutex.Lock()
 	ret, specificReturn := fake.pushReleaseArtifactsReturnsOnCall[len(fake.pushReleaseArtifactsArgsForCall)]
@@ -2283,6 +2895,212 @@ func (fake *FakeStageImpl) PushReleaseArtifactsReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeStageImpl) PushReleaseVersions(arg1 string, arg2 string, arg3 *release.Versions) error {
+	fake.pushReleaseVersionsMutex.Lock()
+	ret, specificReturn := fake.pushReleaseVersionsReturnsOnCall[len(fake.pushReleaseVersionsArgsForCall)]
+	fake.pushReleaseVersionsArgsForCall = append(fake.pushReleaseVersionsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *release.Versions
+	}{arg1, arg2, arg3})
+	stub := fake.PushReleaseVersionsStub
+	fakeReturns := fake.pushReleaseVersionsReturns
+	fake.recordInvocation("PushReleaseVersions", []interface{}{arg1, arg2, arg3})
+	fake.pushReleaseVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) PushReleaseVersionsCallCount() int {
+	fake.pushReleaseVersionsMutex.RLock()
+	defer fake.pushReleaseVersionsMutex.RUnlock()
+	return len(fake.pushReleaseVersionsArgsForCall)
+}
+
+func (fake *FakeStageImpl) PushReleaseVersionsCalls(stub func(string, string, *release.Versions) error) {
+	fake.pushReleaseVersionsMutex.Lock()
+	defer fake.pushReleaseVersionsMutex.Unlock()
+	fake.PushReleaseVersionsStub = stub
+}
+
+func (fake *FakeStageImpl) PushReleaseVersionsArgsForCall(i int) (string, string, *release.Versions) {
+	fake.pushReleaseVersionsMutex.RLock()
+	defer fake.pushReleaseVersionsMutex.RUnlock()
+	argsForCall := fake.pushReleaseVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStageImpl) PushReleaseVersionsReturns(result1 error) {
+	fake.pushReleaseVersionsMutex.Lock()
+	defer fake.pushReleaseVersionsMutex.Unlock()
+	fake.PushReleaseVersionsStub = nil
+	fake.pushReleaseVersionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) PushReleaseVersionsReturnsOnCall(i int, result1 error) {
+	fake.pushReleaseVersionsMutex.Lock()
+	defer fake.pushReleaseVersionsMutex.Unlock()
+	fake.PushReleaseVersionsStub = nil
+	if fake.pushReleaseVersionsReturnsOnCall == nil {
+		fake.pushReleaseVersionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushReleaseVersionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) RecordCustodyLinks(arg1 string, arg2 []string, arg3 []string) (string, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.recordCustodyLinksMutex.Lock()
+	ret, specificReturn := fake.recordCustodyLinksReturnsOnCall[len(fake.recordCustodyLinksArgsForCall)]
+	fake.recordCustodyLinksArgsForCall = append(fake.recordCustodyLinksArgsForCall, struct {
+		arg1 string
+		arg2 []string
+		arg3 []string
+	}{arg1, arg2Copy, arg3Copy})
+	stub := fake.RecordCustodyLinksStub
+	fakeReturns := fake.recordCustodyLinksReturns
+	fake.recordInvocation("RecordCustodyLinks", []interface{}{arg1, arg2Copy, arg3Copy})
+	fake.recordCustodyLinksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStageImpl) RecordCustodyLinksCallCount() int {
+	fake.recordCustodyLinksMutex.RLock()
+	defer fake.recordCustodyLinksMutex.RUnlock()
+	return len(fake.recordCustodyLinksArgsForCall)
+}
+
+func (fake *FakeStageImpl) RecordCustodyLinksCalls(stub func(string, []string, []string) (string, error)) {
+	fake.recordCustodyLinksMutex.Lock()
+	defer fake.recordCustodyLinksMutex.Unlock()
+	fake.RecordCustodyLinksStub = stub
+}
+
+func (fake *FakeStageImpl) RecordCustodyLinksArgsForCall(i int) (string, []string, []string) {
+	fake.recordCustodyLinksMutex.RLock()
+	defer fake.recordCustodyLinksMutex.RUnlock()
+	argsForCall := fake.recordCustodyLinksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStageImpl) RecordCustodyLinksReturns(result1 string, result2 error) {
+	fake.recordCustodyLinksMutex.Lock()
+	defer fake.recordCustodyLinksMutex.Unlock()
+	fake.RecordCustodyLinksStub = nil
+	fake.recordCustodyLinksReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStageImpl) RecordCustodyLinksReturnsOnCall(i int, result1 string, result2 error) {
+	fake.recordCustodyLinksMutex.Lock()
+	defer fake.recordCustodyLinksMutex.Unlock()
+	fake.RecordCustodyLinksStub = nil
+	if fake.recordCustodyLinksReturnsOnCall == nil {
+		fake.recordCustodyLinksReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.recordCustodyLinksReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStageImpl) ReportArtifactSizes(arg1 *sizereport.Options, arg2 string, arg3 string, arg4 string) (*sizereport.Report, error) {
+	fake.reportArtifactSizesMutex.Lock()
+	ret, specificReturn := fake.reportArtifactSizesReturnsOnCall[len(fake.reportArtifactSizesArgsForCall)]
+	fake.reportArtifactSizesArgsForCall = append(fake.reportArtifactSizesArgsForCall, struct {
+		arg1 *sizereport.Options
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ReportArtifactSizesStub
+	fakeReturns := fake.reportArtifactSizesReturns
+	fake.recordInvocation("ReportArtifactSizes", []interface{}{arg1, arg2, arg3, arg4})
+	fake.reportArtifactSizesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStageImpl) ReportArtifactSizesCallCount() int {
+	fake.reportArtifactSizesMutex.RLock()
+	defer fake.reportArtifactSizesMutex.RUnlock()
+	return len(fake.reportArtifactSizesArgsForCall)
+}
+
+func (fake *FakeStageImpl) ReportArtifactSizesCalls(stub func(*sizereport.Options, string, string, string) (*sizereport.Report, error)) {
+	fake.reportArtifactSizesMutex.Lock()
+	defer fake.reportArtifactSizesMutex.Unlock()
+	fake.ReportArtifactSizesStub = stub
+}
+
+func (fake *FakeStageImpl) ReportArtifactSizesArgsForCall(i int) (*sizereport.Options, string, string, string) {
+	fake.reportArtifactSizesMutex.RLock()
+	defer fake.reportArtifactSizesMutex.RUnlock()
+	argsForCall := fake.reportArtifactSizesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeStageImpl) ReportArtifactSizesReturns(result1 *sizereport.Report, result2 error) {
+	fake.reportArtifactSizesMutex.Lock()
+	defer fake.reportArtifactSizesMutex.Unlock()
+	fake.ReportArtifactSizesStub = nil
+	fake.reportArtifactSizesReturns = struct {
+		result1 *sizereport.Report
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStageImpl) ReportArtifactSizesReturnsOnCall(i int, result1 *sizereport.Report, result2 error) {
+	fake.reportArtifactSizesMutex.Lock()
+	defer fake.reportArtifactSizesMutex.Unlock()
+	fake.ReportArtifactSizesStub = nil
+	if fake.reportArtifactSizesReturnsOnCall == nil {
+		fake.reportArtifactSizesReturnsOnCall = make(map[int]struct {
+			result1 *sizereport.Report
+			result2 error
+		})
+	}
+	fake.reportArtifactSizesReturnsOnCall[i] = struct {
+		result1 *sizereport.Report
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStageImpl) RevParse(arg1 *git.Repo, arg2 string) (string, error) {
 	fake.revParseMutex.Lock()
 	ret, specificReturn := fake.revParseReturnsOnCall[len(fake.revParseArgsForCall)]
@@ -2413,6 +3231,74 @@ func (fake *FakeStageImpl) RevParseTagReturnsOnCall(i int, result1 string, resul
 	}{result1, result2}
 }
 
+func (fake *FakeStageImpl) ScanImages(arg1 *vulnscan.Options, arg2 []string, arg3 string) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.scanImagesMutex.Lock()
+	ret, specificReturn := fake.scanImagesReturnsOnCall[len(fake.scanImagesArgsForCall)]
+	fake.scanImagesArgsForCall = append(fake.scanImagesArgsForCall, struct {
+		arg1 *vulnscan.Options
+		arg2 []string
+		arg3 string
+	}{arg1, arg2Copy, arg3})
+	stub := fake.ScanImagesStub
+	fakeReturns := fake.scanImagesReturns
+	fake.recordInvocation("ScanImages", []interface{}{arg1, arg2Copy, arg3})
+	fake.scanImagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) ScanImagesCallCount() int {
+	fake.scanImagesMutex.RLock()
+	defer fake.scanImagesMutex.RUnlock()
+	return len(fake.scanImagesArgsForCall)
+}
+
+func (fake *FakeStageImpl) ScanImagesCalls(stub func(*vulnscan.Options, []string, string) error) {
+	fake.scanImagesMutex.Lock()
+	defer fake.scanImagesMutex.Unlock()
+	fake.ScanImagesStub = stub
+}
+
+func (fake *FakeStageImpl) ScanImagesArgsForCall(i int) (*vulnscan.Options, []string, string) {
+	fake.scanImagesMutex.RLock()
+	defer fake.scanImagesMutex.RUnlock()
+	argsForCall := fake.scanImagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStageImpl) ScanImagesReturns(result1 error) {
+	fake.scanImagesMutex.Lock()
+	defer fake.scanImagesMutex.Unlock()
+	fake.ScanImagesStub = nil
+	fake.scanImagesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) ScanImagesReturnsOnCall(i int, result1 error) {
+	fake.scanImagesMutex.Lock()
+	defer fake.scanImagesMutex.Unlock()
+	fake.ScanImagesStub = nil
+	if fake.scanImagesReturnsOnCall == nil {
+		fake.scanImagesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.scanImagesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageImpl) StageLocalArtifacts(arg1 *build.Options) error {
 	fake.stageLocalArtifactsMutex.Lock()
 	ret, specificReturn := fake.stageLocalArtifactsReturnsOnCall[len(fake.stageLocalArtifactsArgsForCall)]
@@ -2537,18 +3423,19 @@ func (fake *FakeStageImpl) StageLocalSourceTreeReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
-func (fake *FakeStageImpl) Submit(arg1 *gcb.Options) error {
+func (fake *FakeStageImpl) Submit(arg1 context.Context, arg2 *gcb.Options) error {
 	fake.submitMutex.Lock()
 	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
 	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
-		arg1 *gcb.Options
-	}{arg1})
+		arg1 context.Context
+		arg2 *gcb.Options
+	}{arg1, arg2})
 	stub := fake.SubmitStub
 	fakeReturns := fake.submitReturns
-	fake.recordInvocation("Submit", []interface{}{arg1})
+	fake.recordInvocation("Submit", []interface{}{arg1, arg2})
 	fake.submitMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -2562,17 +3449,17 @@ func (fake *FakeStageImpl) SubmitCallCount() int {
 	return len(fake.submitArgsForCall)
 }
 
-func (fake *FakeStageImpl) SubmitCalls(stub func(*gcb.Options) error) {
+func (fake *FakeStageImpl) SubmitCalls(stub func(context.Context, *gcb.Options) error) {
 	fake.submitMutex.Lock()
 	defer fake.submitMutex.Unlock()
 	fake.SubmitStub = stub
 }
 
-func (fake *FakeStageImpl) SubmitArgsForCall(i int) *gcb.Options {
+func (fake *FakeStageImpl) SubmitArgsForCall(i int) (context.Context, *gcb.Options) {
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	argsForCall := fake.submitArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeStageImpl) SubmitReturns(result1 error) {
@@ -2788,6 +3675,68 @@ func (fake *FakeStageImpl) VerifyArtifactsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStageImpl) WriteBuildEnvironment(arg1 *buildenv.Manifest, arg2 string) error {
+	fake.writeBuildEnvironmentMutex.Lock()
+	ret, specificReturn := fake.writeBuildEnvironmentReturnsOnCall[len(fake.writeBuildEnvironmentArgsForCall)]
+	fake.writeBuildEnvironmentArgsForCall = append(fake.writeBuildEnvironmentArgsForCall, struct {
+		arg1 *buildenv.Manifest
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.WriteBuildEnvironmentStub
+	fakeReturns := fake.writeBuildEnvironmentReturns
+	fake.recordInvocation("WriteBuildEnvironment", []interface{}{arg1, arg2})
+	fake.writeBuildEnvironmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) WriteBuildEnvironmentCallCount() int {
+	fake.writeBuildEnvironmentMutex.RLock()
+	defer fake.writeBuildEnvironmentMutex.RUnlock()
+	return len(fake.writeBuildEnvironmentArgsForCall)
+}
+
+func (fake *FakeStageImpl) WriteBuildEnvironmentCalls(stub func(*buildenv.Manifest, string) error) {
+	fake.writeBuildEnvironmentMutex.Lock()
+	defer fake.writeBuildEnvironmentMutex.Unlock()
+	fake.WriteBuildEnvironmentStub = stub
+}
+
+func (fake *FakeStageImpl) WriteBuildEnvironmentArgsForCall(i int) (*buildenv.Manifest, string) {
+	fake.writeBuildEnvironmentMutex.RLock()
+	defer fake.writeBuildEnvironmentMutex.RUnlock()
+	argsForCall := fake.writeBuildEnvironmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStageImpl) WriteBuildEnvironmentReturns(result1 error) {
+	fake.writeBuildEnvironmentMutex.Lock()
+	defer fake.writeBuildEnvironmentMutex.Unlock()
+	fake.WriteBuildEnvironmentStub = nil
+	fake.writeBuildEnvironmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) WriteBuildEnvironmentReturnsOnCall(i int, result1 error) {
+	fake.writeBuildEnvironmentMutex.Lock()
+	defer fake.writeBuildEnvironmentMutex.Unlock()
+	fake.WriteBuildEnvironmentStub = nil
+	if fake.writeBuildEnvironmentReturnsOnCall == nil {
+		fake.writeBuildEnvironmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeBuildEnvironmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageImpl) WriteSourceBOM(arg1 *spdx.Document, arg2 string) error {
 	fake.writeSourceBOMMutex.Lock()
 	ret, specificReturn := fake.writeSourceBOMReturnsOnCall[len(fake.writeSourceBOMArgsForCall)]
@@ -2861,12 +3810,22 @@ func (fake *FakeStageImpl) Invocations() map[string][][]interface{} {
 	defer fake.branchNeedsCreationMutex.RUnlock()
 	fake.buildBaseArtifactsSBOMMutex.RLock()
 	defer fake.buildBaseArtifactsSBOMMutex.RUnlock()
+	fake.cISignalMutex.RLock()
+	defer fake.cISignalMutex.RUnlock()
+	fake.checkBaseImagesMutex.RLock()
+	defer fake.checkBaseImagesMutex.RUnlock()
 	fake.checkPrerequisitesMutex.RLock()
 	defer fake.checkPrerequisitesMutex.RUnlock()
 	fake.checkReleaseBucketMutex.RLock()
 	defer fake.checkReleaseBucketMutex.RUnlock()
+	fake.checkReleaseCutIssueMutex.RLock()
+	defer fake.checkReleaseCutIssueMutex.RUnlock()
 	fake.checkoutMutex.RLock()
 	defer fake.checkoutMutex.RUnlock()
+	fake.collectBuildEnvironmentMutex.RLock()
+	defer fake.collectBuildEnvironmentMutex.RUnlock()
+	fake.commentReleaseCutIssueMutex.RLock()
+	defer fake.commentReleaseCutIssueMutex.RUnlock()
 	fake.commitEmptyMutex.RLock()
 	defer fake.commitEmptyMutex.RUnlock()
 	fake.currentBranchMutex.RLock()
@@ -2877,6 +3836,8 @@ func (fake *FakeStageImpl) Invocations() map[string][][]interface{} {
 	defer fake.dockerHubLoginMutex.RUnlock()
 	fake.generateAttestationMutex.RLock()
 	defer fake.generateAttestationMutex.RUnlock()
+	fake.generateAttributionMutex.RLock()
+	defer fake.generateAttributionMutex.RUnlock()
 	fake.generateChangelogMutex.RLock()
 	defer fake.generateChangelogMutex.RUnlock()
 	fake.generateReleaseVersionMutex.RLock()
@@ -2907,12 +3868,22 @@ func (fake *FakeStageImpl) Invocations() map[string][][]interface{} {
 	defer fake.pushAttestationMutex.RUnlock()
 	fake.pushContainerImagesMutex.RLock()
 	defer fake.pushContainerImagesMutex.RUnlock()
+	fake.pushCustodyLinksMutex.RLock()
+	defer fake.pushCustodyLinksMutex.RUnlock()
 	fake.pushReleaseArtifactsMutex.RLock()
 	defer fake.pushReleaseArtifactsMutex.RUnlock()
+	fake.pushReleaseVersionsMutex.RLock()
+	defer fake.pushReleaseVersionsMutex.RUnlock()
+	fake.recordCustodyLinksMutex.RLock()
+	defer fake.recordCustodyLinksMutex.RUnlock()
+	fake.reportArtifactSizesMutex.RLock()
+	defer fake.reportArtifactSizesMutex.RUnlock()
 	fake.revParseMutex.RLock()
 	defer fake.revParseMutex.RUnlock()
 	fake.revParseTagMutex.RLock()
 	defer fake.revParseTagMutex.RUnlock()
+	fake.scanImagesMutex.RLock()
+	defer fake.scanImagesMutex.RUnlock()
 	fake.stageLocalArtifactsMutex.RLock()
 	defer fake.stageLocalArtifactsMutex.RUnlock()
 	fake.stageLocalSourceTreeMutex.RLock()
@@ -2925,6 +3896,8 @@ func (fake *FakeStageImpl) Invocations() map[string][][]interface{} {
 	defer fake.toFileMutex.RUnlock()
 	fake.verifyArtifactsMutex.RLock()
 	defer fake.verifyArtifactsMutex.RUnlock()
+	fake.writeBuildEnvironmentMutex.RLock()
+	defer fake.writeBuildEnvironmentMutex.RUnlock()
 	fake.writeSourceBOMMutex.RLock()
 	defer fake.writeSourceBOMMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -17,6 +17,8 @@ limitations under the License.
 package anago
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -25,9 +27,21 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/approver"
+	"k8s.io/release/pkg/artifactdiff"
+	"k8s.io/release/pkg/attribution"
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/blog"
+	"k8s.io/release/pkg/branding"
 	"k8s.io/release/pkg/build"
+	"k8s.io/release/pkg/custody"
+	"k8s.io/release/pkg/cutissue"
 	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/layout"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/retry"
+	"k8s.io/release/pkg/urlalias"
+	"k8s.io/release/pkg/workdir"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-utils/log"
@@ -38,8 +52,9 @@ import (
 //
 //counterfeiter:generate . releaseClient
 type releaseClient interface {
-	// Submit can be used to submit a Google Cloud Build (GCB) job.
-	Submit(stream bool) error
+	// Submit can be used to submit a Google Cloud Build (GCB) job, which
+	// stops being watched once ctx gets cancelled.
+	Submit(ctx context.Context, stream bool) error
 
 	// InitState initializes the default internal state.
 	InitState()
@@ -73,6 +88,11 @@ type releaseClient interface {
 	// and verifies them against the provenance metadata.
 	CheckProvenance() error
 
+	// CheckArtifactAnomalies compares the set of staged artifacts of every
+	// version with the one of its previous release and fails for added or
+	// removed artifacts which are not allowlisted.
+	CheckArtifactAnomalies() error
+
 	// PushArtifacts pushes the generated artifacts to the release bucket and
 	// Google Container Registry for the specified release `versions`.
 	PushArtifacts() error
@@ -92,6 +112,10 @@ type releaseClient interface {
 	// Archive copies the release process logs to a bucket and sets private
 	// permissions on it.
 	Archive() error
+
+	// UpdateReleaseCutIssue checks off the release item in the release cut
+	// issue.
+	UpdateReleaseCutIssue() error
 }
 
 // DefaultRelease is the default staging implementation used in production.
@@ -124,7 +148,7 @@ type defaultReleaseImpl struct{}
 //
 //counterfeiter:generate . releaseImpl
 type releaseImpl interface {
-	Submit(options *gcb.Options) error
+	Submit(ctx context.Context, options *gcb.Options) error
 	ToFile(fileName string) error
 	CheckPrerequisites() error
 	BranchNeedsCreation(
@@ -134,11 +158,17 @@ type releaseImpl interface {
 	GenerateReleaseVersion(
 		releaseType, version, branch string, branchFromMaster bool,
 	) (*release.Versions, error)
+	StagedReleaseVersions(bucket, buildVersion string) (*release.Versions, error)
+	RecordCustodyLinks(keyPath string, steps, versions []string) (string, error)
+	PushCustodyLinks(dir, bucket, buildVersion string) error
 	CheckReleaseBucket(options *build.Options) error
 	CopyStagedFromGCS(
 		options *build.Options, stagedBucket, buildVersion string,
 	) error
+	StageEncrypted(options *build.Options, buildVersion string) (bool, error)
+	PushContainerImages(options *build.Options) error
 	ValidateImages(registry, version, buildPath string) error
+	CheckBaseImages(registry, version, buildPath, branch string) error
 	PublishVersion(
 		buildType, version, buildDir, bucket, gcsRoot string,
 		versionMarkers []string,
@@ -159,11 +189,17 @@ type releaseImpl interface {
 		gcsIndexRootPath, gcsReleaseNotesPath, version string,
 	) error
 	CreatePubBotBranchIssue(string) error
+	CreateBlogPost(options *blog.Options) error
 	CheckStageProvenance(string, string, *release.Versions) error
+	CheckArtifactAnomalies(bucket, buildVersion, version, previousVersion string, allowAdded, allowRemoved []string) error
+	CheckReleaseCutIssue(version, item string, noMock bool) error
+	PublishAliases(options *urlalias.Options) error
 }
 
-func (d *defaultReleaseImpl) Submit(options *gcb.Options) error {
-	return gcb.New(options).Submit()
+func (d *defaultReleaseImpl) Submit(ctx context.Context, options *gcb.Options) error {
+	g := gcb.New(options)
+	g.SetContext(ctx)
+	return g.Submit()
 }
 
 func (d *defaultReleaseImpl) ToFile(fileName string) error {
@@ -201,6 +237,22 @@ func (d *defaultReleaseImpl) GenerateReleaseVersion(
 	)
 }
 
+func (d *defaultReleaseImpl) StagedReleaseVersions(
+	bucket, buildVersion string,
+) (*release.Versions, error) {
+	return release.ReadStagedVersions(bucket, buildVersion)
+}
+
+func (d *defaultReleaseImpl) RecordCustodyLinks(
+	keyPath string, steps, versions []string,
+) (string, error) {
+	return recordCustodyLinks(keyPath, steps, versions)
+}
+
+func (d *defaultReleaseImpl) PushCustodyLinks(dir, bucket, buildVersion string) error {
+	return pushCustodyLinks(dir, bucket, buildVersion)
+}
+
 func (d *defaultReleaseImpl) CheckReleaseBucket(
 	options *build.Options,
 ) error {
@@ -214,12 +266,32 @@ func (d *defaultReleaseImpl) CopyStagedFromGCS(
 		CopyStagedFromGCS(stagedBucket, buildVersion)
 }
 
+func (d *defaultReleaseImpl) StageEncrypted(
+	options *build.Options, buildVersion string,
+) (bool, error) {
+	return build.NewInstance(options).StageEncrypted(buildVersion)
+}
+
+func (d *defaultReleaseImpl) PushContainerImages(
+	options *build.Options,
+) error {
+	return build.NewInstance(options).PushContainerImages()
+}
+
 func (d *defaultReleaseImpl) ValidateImages(
 	registry, version, buildPath string,
 ) error {
 	return release.NewImages().Validate(registry, version, buildPath)
 }
 
+// CheckBaseImages verifies the base images of all single-platform images of
+// the version against the configured base image policy.
+func (d *defaultReleaseImpl) CheckBaseImages(
+	registry, version, buildPath, branch string,
+) error {
+	return checkBaseImages(registry, version, buildPath, branch)
+}
+
 func (d *defaultReleaseImpl) PublishVersion(
 	buildType, version, buildDir, bucket, gcsRoot string, //nolint: gocritic
 	versionMarkers []string, //nolint: gocritic
@@ -230,15 +302,40 @@ func (d *defaultReleaseImpl) PublishVersion(
 		PublishVersion("release", version, buildDir, bucket, gcsRoot, nil, false, false)
 }
 
-func (d *DefaultRelease) Submit(stream bool) error {
+func (d *DefaultRelease) Submit(ctx context.Context, stream bool) error {
 	options := gcb.NewDefaultOptions()
 	options.Stream = stream
 	options.Release = true
 	options.NoMock = d.options.NoMock
+	options.NonInteractive = d.options.NonInteractive
 	options.Branch = d.options.ReleaseBranch
 	options.ReleaseType = d.options.ReleaseType
 	options.BuildVersion = d.options.BuildVersion
-	return d.impl.Submit(options)
+	options.Commit = d.options.Commit
+	options.PublishAt = d.options.PublishAt
+	options.AllowAddedArtifacts = d.options.AllowAddedArtifacts
+	options.AllowRemovedArtifacts = d.options.AllowRemovedArtifacts
+	options.Local = d.options.Local
+	options.ContainerRuntime = d.options.ContainerRuntime
+	options.CustodyKey = d.options.CustodyKey
+	return d.impl.Submit(ctx, options)
+}
+
+// recordCustodyLinks records the links of the chain of custody steps and
+// pushes them next to the links of the staged build.
+func (d *DefaultRelease) recordCustodyLinks(steps []string) error {
+	dir, err := d.impl.RecordCustodyLinks(
+		d.options.CustodyKey, steps, d.state.versions.Ordered(),
+	)
+	if err != nil {
+		return fmt.Errorf("record links: %w", err)
+	}
+	if err := d.impl.PushCustodyLinks(
+		dir, d.options.Bucket(), d.options.BuildVersion,
+	); err != nil {
+		return fmt.Errorf("push links: %w", err)
+	}
+	return nil
 }
 
 func (d *DefaultRelease) InitState() {
@@ -249,12 +346,19 @@ func (d *DefaultRelease) InitLogFile() error {
 	logrus.SetFormatter(
 		&logrus.TextFormatter{FullTimestamp: true, ForceColors: true},
 	)
-	logFile := filepath.Join(os.TempDir(), "release.log")
+	logFile := filepath.Join(workdir.TempDir(), "release.log")
 	if err := d.impl.ToFile(logFile); err != nil {
 		return fmt.Errorf("setup log file: %w", err)
 	}
 	d.state.logFile = logFile
 	logrus.Infof("Additionally logging to file %s", d.state.logFile)
+
+	// Mutating operations are only audited for non-mock runs.
+	if d.options.NoMock {
+		if err := audit.Setup(audit.DefaultOptions()); err != nil {
+			return fmt.Errorf("setup audit log: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -263,6 +367,10 @@ func (d *defaultReleaseImpl) CreateAnnouncement(options *announce.Options) error
 	return announce.CreateForRelease(options)
 }
 
+func (d *defaultReleaseImpl) CheckReleaseCutIssue(version, item string, noMock bool) error {
+	return checkReleaseCutIssue(version, item, noMock)
+}
+
 func (d *defaultReleaseImpl) ArchiveRelease(options *release.ArchiverOptions) error {
 	// Create a new release archiver
 	return release.NewArchiver(options).ArchiveRelease()
@@ -300,7 +408,13 @@ func (d *defaultReleaseImpl) NormalizePath(
 func (d *defaultReleaseImpl) CopyToRemote(
 	store object.Store, src, gcsPath string,
 ) error {
-	return store.CopyToRemote(src, gcsPath)
+	if err := retry.Do(context.Background(), retry.ServiceGCS, func() error {
+		return store.CopyToRemote(src, gcsPath)
+	}); err != nil {
+		return err
+	}
+	audit.Record(audit.ActionBucketWrite, gcsPath, map[string]string{"source": src})
+	return nil
 }
 
 func (d *defaultReleaseImpl) PublishReleaseNotesIndex(
@@ -311,10 +425,20 @@ func (d *defaultReleaseImpl) PublishReleaseNotesIndex(
 	)
 }
 
+func (d *defaultReleaseImpl) PublishAliases(options *urlalias.Options) error {
+	_, err := urlalias.New(options).Publish()
+	return err
+}
+
 func (d *defaultReleaseImpl) CreatePubBotBranchIssue(branchName string) error {
 	return release.CreatePubBotBranchIssue(branchName)
 }
 
+func (d *defaultReleaseImpl) CreateBlogPost(options *blog.Options) error {
+	_, _, err := blog.New(options).Run()
+	return err
+}
+
 // NewGitPusher returns a new instance of the git pusher to reuse
 func (d *defaultReleaseImpl) NewGitPusher(
 	opts *release.GitObjectPusherOptions,
@@ -330,6 +454,11 @@ func (d *DefaultRelease) ValidateOptions() error {
 	if err := d.options.Validate(d.state.State); err != nil {
 		return fmt.Errorf("validating options: %w", err)
 	}
+	if d.options.NoMock {
+		if err := approver.Check("release", d.options.ReleaseBranch); err != nil {
+			return fmt.Errorf("checking release manager approval: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -351,6 +480,19 @@ func (d *DefaultRelease) CheckReleaseBranchState() error {
 }
 
 func (d *DefaultRelease) GenerateReleaseVersion() error {
+	// Prefer the versions rendered by the stage over generating them again
+	staged, err := d.impl.StagedReleaseVersions(
+		d.options.Bucket(), d.options.BuildVersion,
+	)
+	if err != nil {
+		return fmt.Errorf("get staged release versions: %w", err)
+	}
+	if staged != nil {
+		logrus.Infof("Using staged release versions: %s", staged.String())
+		d.state.versions = staged
+		return nil
+	}
+
 	versions, err := d.impl.GenerateReleaseVersion(
 		d.options.ReleaseType,
 		d.options.BuildVersion,
@@ -377,6 +519,8 @@ func (d *DefaultRelease) PrepareWorkspace() error {
 func (d *DefaultRelease) PushArtifacts() error {
 	const gcsRoot = "release"
 
+	// The images of encrypted stages get signed when pushing them now
+	signed := false
 	for _, version := range d.state.versions.Ordered() {
 		logrus.Infof("Pushing artifacts for version %s", version)
 		buildDir := filepath.Join(
@@ -407,37 +551,93 @@ func (d *DefaultRelease) PushArtifacts() error {
 		// image manifest validation against production instead of staging.
 		targetRegistry := containerRegistry
 		if targetRegistry == release.GCRIOPathStaging {
-			targetRegistry = release.GCRIOPathProd
+			targetRegistry = branding.Default().Registry
+		}
+
+		// The container images of an encrypted stage are not pushed to
+		// keep the embargo, which means that they can be only promoted
+		// after pushing the decrypted image archives now.
+		encrypted, err := d.impl.StageEncrypted(pushBuildOptions, d.options.BuildVersion)
+		if err != nil {
+			return fmt.Errorf("check if the stage is encrypted: %w", err)
+		}
+		if encrypted {
+			if err := d.impl.PushContainerImages(pushBuildOptions); err != nil {
+				return fmt.Errorf("pushing container images: %w", err)
+			}
+			signed = true
+
+			// Fail before the images of the encrypted stage can be
+			// promoted if any got built on an unexpected base
+			if err := d.impl.CheckBaseImages(
+				containerRegistry, version, buildDir, d.options.ReleaseBranch,
+			); err != nil {
+				return fmt.Errorf("check base images: %w", err)
+			}
+			logrus.Warnf(
+				"Pushed container images of encrypted stage to %s, "+
+					"they have to be promoted after the release", containerRegistry,
+			)
+			targetRegistry = containerRegistry
 		}
 
-		// Image promotion has been done on nomock stage, verify that the
-		// images are available.
+		// Image promotion has been done on nomock stage (if not encrypted),
+		// verify that the images are available.
 		if err := d.impl.ValidateImages(
 			targetRegistry, version, buildDir,
 		); err != nil {
 			return fmt.Errorf("validate container images: %w", err)
 		}
 
-		if err := d.impl.PublishVersion(
+		// Embargoed releases get their version markers updated by
+		// `krel announce publish` at the publish time
+		if d.options.PublishAt != "" {
+			logrus.Infof("Deferring the version markers of %s until %s", version, d.options.PublishAt)
+		} else if err := d.impl.PublishVersion(
 			"release", version, buildDir, bucket, gcsRoot, nil, false, false,
 		); err != nil {
 			return fmt.Errorf("publish release: %w", err)
 		}
+
+		aliasOpts := urlalias.DefaultOptions()
+		aliasOpts.Version = version
+		aliasOpts.Bucket = bucket
+		aliasOpts.GCSRoot = gcsRoot
+		aliasOpts.NoMock = d.options.NoMock
+		if err := d.impl.PublishAliases(aliasOpts); err != nil {
+			return fmt.Errorf("publish URL aliases: %w", err)
+		}
+	}
+
+	// Record the chain of custody of the pushed artifacts, whose images
+	// have been validated to be promoted
+	if d.options.CustodyKey != "" {
+		steps := []string{custody.StepPush}
+		if signed {
+			steps = append(steps, custody.StepSign)
+		}
+		steps = append(steps, custody.StepPromote)
+		if err := d.recordCustodyLinks(steps); err != nil {
+			return fmt.Errorf("record chain of custody: %w", err)
+		}
 	}
 
 	logrus.Info("Publishing release notes JSON")
 	objStore := object.NewGCS()
 	objStore.SetOptions(objStore.WithNoClobber(false))
-	gcsReleaseRootPath, err := d.impl.NormalizePath(
-		objStore, d.options.Bucket(), gcsRoot,
-	)
+	markerPath, err := layout.Default().MarkerPath(d.options.Bucket(), gcsRoot, false)
+	if err != nil {
+		return fmt.Errorf("get release root path: %w", err)
+	}
+	gcsReleaseRootPath, err := d.impl.NormalizePath(objStore, markerPath)
 	if err != nil {
 		return fmt.Errorf("get GCS release root path: %w", err)
 	}
 
-	gcsReleaseNotesPath := gcsReleaseRootPath + fmt.Sprintf(
-		"/%s/release-notes.json", d.state.versions.Prime(),
-	)
+	gcsReleaseNotesPath, err := d.gcsReleasePath(objStore, gcsRoot, d.state.versions.Prime(), "release-notes.json")
+	if err != nil {
+		return fmt.Errorf("get GCS release notes path: %w", err)
+	}
 
 	if err := d.impl.CopyToRemote(
 		objStore,
@@ -448,12 +648,14 @@ func (d *DefaultRelease) PushArtifacts() error {
 	}
 
 	for _, version := range d.state.versions.Ordered() {
+		gcsProvenancePath, err := d.gcsReleasePath(objStore, gcsRoot, version, "provenance.json")
+		if err != nil {
+			return fmt.Errorf("get GCS provenance path: %w", err)
+		}
 		if err := d.impl.CopyToRemote(
 			objStore,
-			filepath.Join(os.TempDir(), fmt.Sprintf("provenance-%s.json", version)),
-			gcsReleaseRootPath+fmt.Sprintf(
-				"/%s/provenance.json", version,
-			),
+			filepath.Join(workdir.TempDir(), fmt.Sprintf("provenance-%s.json", version)),
+			gcsProvenancePath,
 		); err != nil {
 			return fmt.Errorf("copying provenance data to release bucket: %w", err)
 		}
@@ -469,6 +671,18 @@ func (d *DefaultRelease) PushArtifacts() error {
 	return nil
 }
 
+// gcsReleasePath returns the GCS path of a file next to the released
+// artifacts of the provided version.
+func (d *DefaultRelease) gcsReleasePath(
+	objStore object.Store, gcsRoot, version, file string,
+) (string, error) {
+	releasePath, err := layout.Default().ReleasePath(d.options.Bucket(), gcsRoot, version, false)
+	if err != nil {
+		return "", err
+	}
+	return d.impl.NormalizePath(objStore, releasePath, file)
+}
+
 // PushGitObjects uploads to the remote repository the release's tags and branches.
 // Internally, this function calls the release implementation's PushTags,
 // PushBranches and PushMainBranch methods
@@ -562,6 +776,20 @@ func (d *DefaultRelease) CreateAnnouncement() error {
 			logrus.Info("Not creating publishing bot issue in mock release")
 		}
 	}
+
+	// Propose the release blog post skeleton for new minor versions
+	if primeSemver.Patch == 0 && d.options.ReleaseType == release.ReleaseTypeOfficial {
+		blogOpts := blog.DefaultOptions()
+		blogOpts.Tag = d.state.versions.Prime()
+		blogOpts.ReleaseNotesFile = releaseNotesJSONFile
+		blogOpts.NoMock = d.options.NoMock
+		if err := d.impl.CreateBlogPost(blogOpts); err != nil {
+			// The blog post can be drafted by hand as well, so do not
+			// break the release process
+			logrus.Warn("Failed to create the release blog post")
+			logrus.Error(err)
+		}
+	}
 	return nil
 }
 
@@ -580,8 +808,8 @@ func (d *DefaultRelease) UpdateGitHubPage() error {
 		Tag:                   d.state.versions.Prime(),
 		NoMock:                d.options.NoMock,
 		UpdateIfReleaseExists: true,
-		Name:                  "Kubernetes " + d.state.versions.Prime(),
-		Draft:                 false,
+		Name:                  branding.Default().ProductName + " " + d.state.versions.Prime(),
+		Draft:                 d.options.PublishAt != "",
 		Owner:                 git.DefaultGithubOrg,
 		Repo:                  git.DefaultGithubRepo,
 		// PageTemplate: ,     // If we use a custom template, define it here
@@ -591,6 +819,54 @@ func (d *DefaultRelease) UpdateGitHubPage() error {
 			"changelog": changelogURL,
 		},
 	}
+	if d.options.Commit != "" {
+		ghPageOpts.Substitutions["commit"] = d.options.Commit
+	}
+
+	// Archive the previous revision of the page if it gets updated
+	archiverOptions := &release.ArchiverOptions{
+		PrimeVersion: d.state.versions.Prime(),
+		Bucket:       d.options.Bucket(),
+	}
+	if archivePath := archiverOptions.ArchiveBucketPath(); archivePath != "" {
+		ghPageOpts.ArchiveLocation = archivePath + "/github-page"
+	}
+
+	stageDir := filepath.Join(
+		gitRoot,
+		fmt.Sprintf("%s-%s", release.BuildDir, d.state.versions.Prime()),
+		release.GCSStagePath,
+		d.state.versions.Prime(),
+	)
+
+	// Attach the consolidated checksums for third-party installers
+	checksumsFile := filepath.Join(stageDir, release.ChecksumsFile)
+	if util.Exists(checksumsFile) {
+		ghPageOpts.AssetFiles = append(
+			ghPageOpts.AssetFiles,
+			checksumsFile+":Checksums",
+		)
+	} else {
+		logrus.Warnf(
+			"Checksums file %s not found, not attaching it to the release page",
+			checksumsFile,
+		)
+	}
+
+	// Attach the license attribution archive if it got staged
+	attributionArchive := filepath.Join(stageDir, attribution.ArchiveName)
+	if util.Exists(attributionArchive) {
+		ghPageOpts.AssetFiles = append(
+			ghPageOpts.AssetFiles,
+			attributionArchive+":License attribution",
+		)
+	} else {
+		logrus.Warnf(
+			"License attribution archive %s not found, not attaching it to the release page",
+			attributionArchive,
+		)
+	}
+
 	// Update the release page (or simply output it during mock)
 	if err := d.impl.UpdateGitHubPage(ghPageOpts); err != nil {
 		return fmt.Errorf("updating GitHub release page: %w", err)
@@ -614,6 +890,13 @@ func (d *DefaultRelease) Archive() error {
 		return fmt.Errorf("running the release archival process: %w", err)
 	}
 
+	// Store the signed audit log of a non-mock run within the archive
+	if err := audit.Publish(func(dir string) error {
+		return d.publishAuditLog(dir, archiverOptions.ArchiveBucketPath())
+	}); err != nil {
+		return fmt.Errorf("publish audit log: %w", err)
+	}
+
 	args := ""
 	if d.options.NoMock {
 		args += " --nomock"
@@ -627,6 +910,41 @@ func (d *DefaultRelease) Archive() error {
 	return nil
 }
 
+// publishAuditLog copies all files of the audit log directory into the
+// release archive.
+func (d *DefaultRelease) publishAuditLog(dir, archivePath string) error {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read audit log directory: %w", err)
+	}
+	objStore := object.NewGCS()
+	objStore.SetOptions(objStore.WithNoClobber(false))
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		if err := d.impl.CopyToRemote(
+			objStore,
+			filepath.Join(dir, f.Name()),
+			archivePath+"/"+auditPath+"/"+f.Name(),
+		); err != nil {
+			return fmt.Errorf("copy %s: %w", f.Name(), err)
+		}
+	}
+	return nil
+}
+
+func (d *DefaultRelease) UpdateReleaseCutIssue() error {
+	item := cutissue.ItemReleaseMock
+	if d.options.NoMock {
+		item = cutissue.ItemRelease
+	}
+	if err := d.impl.CheckReleaseCutIssue(d.state.versions.Prime(), item, d.options.NoMock); err != nil {
+		return fmt.Errorf("check %s item: %w", item, err)
+	}
+	return nil
+}
+
 // CheckProvenance verifies the artifacts staged in the release bucket
 // by verifying the provenance metadata generated during the stage run.
 func (d *DefaultRelease) CheckProvenance() error {
@@ -650,3 +968,37 @@ func (d *defaultReleaseImpl) CheckStageProvenance(bucket, buildVersion string, v
 
 	return nil
 }
+
+// CheckArtifactAnomalies compares the staged artifacts of all versions, which
+// have a previous release, with the released artifacts of it.
+func (d *DefaultRelease) CheckArtifactAnomalies() error {
+	errs := []error{}
+	for _, version := range d.state.versions.Ordered() {
+		previous, err := artifactdiff.PreviousRelease(version)
+		if err != nil {
+			logrus.Infof("Skipping artifact anomaly check: %v", err)
+			continue
+		}
+		if err := d.impl.CheckArtifactAnomalies(
+			d.options.Bucket(), d.options.BuildVersion, version, previous,
+			d.options.AllowAddedArtifacts, d.options.AllowRemovedArtifacts,
+		); err != nil {
+			errs = append(errs, fmt.Errorf("check artifacts of %s: %w", version, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *defaultReleaseImpl) CheckArtifactAnomalies(
+	bucket, buildVersion, version, previousVersion string,
+	allowAdded, allowRemoved []string,
+) error {
+	opts := artifactdiff.DefaultOptions()
+	opts.Bucket = bucket
+	opts.BuildVersion = buildVersion
+	opts.Version = version
+	opts.PreviousVersion = previousVersion
+	opts.AllowAdded = allowAdded
+	opts.AllowRemoved = allowRemoved
+	return artifactdiff.New(opts).CheckAnomalies()
+}
@@ -18,11 +18,14 @@ limitations under the License.
 package anago_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"k8s.io/release/pkg/anago"
 	"k8s.io/release/pkg/anago/anagofakes"
+	"k8s.io/release/pkg/artifactdiff"
+	"k8s.io/release/pkg/custody"
 	"k8s.io/release/pkg/release"
 )
 
@@ -164,6 +167,21 @@ func TestGenerateReleaseVersionRelease(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // staged versions are used
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.StagedReleaseVersionsReturns(
+					release.NewReleaseVersions("v1.30.1+acme.2", "v1.30.1+acme.2", "", "", ""), nil,
+				)
+				mock.GenerateReleaseVersionReturns(nil, err)
+			},
+			shouldError: false,
+		},
+		{ // StagedReleaseVersions fails
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.StagedReleaseVersionsReturns(nil, err)
+			},
+			shouldError: true,
+		},
 	} {
 		tc := tc
 		opts := anago.DefaultReleaseOptions()
@@ -191,6 +209,7 @@ func TestGenerateReleaseVersionRelease(t *testing.T) {
 func TestPushArtifacts(t *testing.T) {
 	for _, tc := range []struct {
 		prepare     func(*anagofakes.FakeReleaseImpl)
+		publishAt   string
 		shouldError bool
 	}{
 		{ // success
@@ -209,6 +228,25 @@ func TestPushArtifacts(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // StageEncrypted fails
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.StageEncryptedReturns(false, err)
+			},
+			shouldError: true,
+		},
+		{ // PushContainerImages fails for encrypted stage
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.StageEncryptedReturns(true, nil)
+				mock.PushContainerImagesReturns(err)
+			},
+			shouldError: true,
+		},
+		{ // success for encrypted stage
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.StageEncryptedReturns(true, nil)
+			},
+			shouldError: false,
+		},
 		// TODO: bypassing this for now due to the fail in the promotion process
 		// that sign the images. We will release the Feb/2023 patch releases without full
 		// signatures but we will sign those in a near future in a deatached process
@@ -219,12 +257,38 @@ func TestPushArtifacts(t *testing.T) {
 		// 	},
 		// 	shouldError: true,
 		// },
+		{ // CheckBaseImages fails for encrypted stage
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.StageEncryptedReturns(true, nil)
+				mock.CheckBaseImagesReturns(err)
+			},
+			shouldError: true,
+		},
+		{ // base images are checked by the stage if not encrypted
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.CheckBaseImagesReturns(err)
+			},
+			shouldError: false,
+		},
 		{ // PusblishVersion fails
 			prepare: func(mock *anagofakes.FakeReleaseImpl) {
 				mock.PublishVersionReturns(err)
 			},
 			shouldError: true,
 		},
+		{ // version markers are deferred for embargoed releases
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.PublishVersionReturns(err)
+			},
+			publishAt:   "2024-04-17T16:00:00Z",
+			shouldError: false,
+		},
+		{ // PublishAliases fails
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.PublishAliasesReturns(err)
+			},
+			shouldError: true,
+		},
 		{ // NormalizePath fails
 			prepare: func(mock *anagofakes.FakeReleaseImpl) {
 				mock.NormalizePathReturns("", err)
@@ -245,6 +309,7 @@ func TestPushArtifacts(t *testing.T) {
 		},
 	} {
 		opts := anago.DefaultReleaseOptions()
+		opts.PublishAt = tc.publishAt
 
 		sut := anago.NewDefaultRelease(opts)
 
@@ -265,6 +330,60 @@ func TestPushArtifacts(t *testing.T) {
 	}
 }
 
+func TestPushArtifactsCustody(t *testing.T) {
+	for _, tc := range []struct {
+		prepare       func(*anagofakes.FakeReleaseImpl)
+		expectedSteps []string
+		shouldError   bool
+	}{
+		{ // push and promote
+			prepare:       func(*anagofakes.FakeReleaseImpl) {},
+			expectedSteps: []string{custody.StepPush, custody.StepPromote},
+		},
+		{ // images of encrypted stages get signed by the release
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.StageEncryptedReturns(true, nil)
+			},
+			expectedSteps: []string{custody.StepPush, custody.StepSign, custody.StepPromote},
+		},
+		{ // RecordCustodyLinks fails
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.RecordCustodyLinksReturns("", err)
+			},
+			expectedSteps: []string{custody.StepPush, custody.StepPromote},
+			shouldError:   true,
+		},
+		{ // PushCustodyLinks fails
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.PushCustodyLinksReturns(err)
+			},
+			expectedSteps: []string{custody.StepPush, custody.StepPromote},
+			shouldError:   true,
+		},
+	} {
+		opts := anago.DefaultReleaseOptions()
+		opts.CustodyKey = "functionary.pem"
+		sut := anago.NewDefaultRelease(opts)
+		sut.SetState(
+			generateTestingReleaseState(&testStateParameters{versionsTag: &testVersionTag}),
+		)
+		mock := &anagofakes.FakeReleaseImpl{}
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+
+		err := sut.PushArtifacts()
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+			require.Equal(t, 1, mock.PushCustodyLinksCallCount())
+		}
+		key, steps, _ := mock.RecordCustodyLinksArgsForCall(0)
+		require.Equal(t, opts.CustodyKey, key)
+		require.Equal(t, tc.expectedSteps, steps)
+	}
+}
+
 func TestPrepareWorkspaceRelease(t *testing.T) {
 	for _, tc := range []struct {
 		prepare     func(*anagofakes.FakeReleaseImpl)
@@ -316,7 +435,7 @@ func TestSubmitReleaseImpl(t *testing.T) {
 		mock := &anagofakes.FakeReleaseImpl{}
 		tc.prepare(mock)
 		sut.SetImpl(mock)
-		err := sut.Submit(false)
+		err := sut.Submit(context.Background(), false)
 		if tc.shouldError {
 			require.NotNil(t, err)
 		} else {
@@ -327,8 +446,10 @@ func TestSubmitReleaseImpl(t *testing.T) {
 
 func TestCreateAnnouncement(t *testing.T) {
 	for _, tc := range []struct {
-		prepare     func(*anagofakes.FakeReleaseImpl)
-		shouldError bool
+		releaseType   string
+		prepare       func(*anagofakes.FakeReleaseImpl)
+		blogPostCalls int
+		shouldError   bool
 	}{
 		{ // success
 			prepare:     func(*anagofakes.FakeReleaseImpl) {},
@@ -340,8 +461,25 @@ func TestCreateAnnouncement(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // success official release with blog post
+			releaseType:   release.ReleaseTypeOfficial,
+			prepare:       func(*anagofakes.FakeReleaseImpl) {},
+			blogPostCalls: 1,
+			shouldError:   false,
+		},
+		{ // blog post fails, which is not fatal
+			releaseType: release.ReleaseTypeOfficial,
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.CreateBlogPostReturns(err)
+			},
+			blogPostCalls: 1,
+			shouldError:   false,
+		},
 	} {
 		opts := anago.DefaultReleaseOptions()
+		if tc.releaseType != "" {
+			opts.ReleaseType = tc.releaseType
+		}
 		sut := anago.NewDefaultRelease(opts)
 		sut.SetState(
 			generateTestingReleaseState(&testStateParameters{versionsTag: &testVersionTag}),
@@ -354,6 +492,7 @@ func TestCreateAnnouncement(t *testing.T) {
 			require.NotNil(t, err)
 		} else {
 			require.Nil(t, err)
+			require.Equal(t, tc.blogPostCalls, mock.CreateBlogPostCallCount())
 		}
 	}
 }
@@ -412,12 +551,17 @@ func TestPushGitObjects(t *testing.T) {
 func TestUpdateGitHubPage(t *testing.T) {
 	for _, tc := range []struct {
 		prepare     func(*anagofakes.FakeReleaseImpl)
+		publishAt   string
 		shouldError bool
 	}{
 		{ // success
 			prepare:     func(*anagofakes.FakeReleaseImpl) {},
 			shouldError: false,
 		},
+		{ // embargoed release creates a draft
+			prepare:   func(*anagofakes.FakeReleaseImpl) {},
+			publishAt: "2024-04-17T16:00:00Z",
+		},
 		{ // Pushing list of branches fails
 			prepare: func(mock *anagofakes.FakeReleaseImpl) {
 				mock.UpdateGitHubPageReturns(err)
@@ -426,6 +570,7 @@ func TestUpdateGitHubPage(t *testing.T) {
 		},
 	} {
 		opts := anago.DefaultReleaseOptions()
+		opts.PublishAt = tc.publishAt
 		sut := anago.NewDefaultRelease(opts)
 		sut.SetState(
 			generateTestingReleaseState(&testStateParameters{versionsTag: &testVersionTag}),
@@ -438,6 +583,11 @@ func TestUpdateGitHubPage(t *testing.T) {
 			require.NotNil(t, err)
 		} else {
 			require.Nil(t, err)
+			require.Equal(t,
+				"gs://"+opts.Bucket()+"/archive/anago-"+testVersionTag+"/github-page",
+				mock.UpdateGitHubPageArgsForCall(0).ArchiveLocation,
+			)
+			require.Equal(t, tc.publishAt != "", mock.UpdateGitHubPageArgsForCall(0).Draft)
 		}
 	}
 }
@@ -474,3 +624,101 @@ func TestCheckProvenance(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckArtifactAnomalies(t *testing.T) {
+	for _, tc := range []struct {
+		versionsTag string
+		prepare     func(*anagofakes.FakeReleaseImpl)
+		calls       int
+		shouldError bool
+	}{
+		{ // success
+			versionsTag: testVersionTag,
+			prepare:     func(*anagofakes.FakeReleaseImpl) {},
+			calls:       1,
+		},
+		{ // pre-releases are skipped
+			versionsTag: "v1.20.0-rc.1",
+			prepare:     func(*anagofakes.FakeReleaseImpl) {},
+		},
+		{ // anomalies found
+			versionsTag: testVersionTag,
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.CheckArtifactAnomaliesReturns(err)
+			},
+			calls:       1,
+			shouldError: true,
+		},
+	} {
+		opts := anago.DefaultReleaseOptions()
+		sut := anago.NewDefaultRelease(opts)
+		sut.SetState(
+			generateTestingReleaseState(&testStateParameters{versionsTag: &tc.versionsTag}),
+		)
+		mock := &anagofakes.FakeReleaseImpl{}
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+		err := sut.CheckArtifactAnomalies()
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+		require.Equal(t, tc.calls, mock.CheckArtifactAnomaliesCallCount())
+		if tc.calls > 0 {
+			_, _, version, previous, allowAdded, allowRemoved := mock.CheckArtifactAnomaliesArgsForCall(0)
+			require.Equal(t, testVersionTag, version)
+			require.Equal(t, "v1.19.0", previous)
+			require.Empty(t, allowAdded)
+			require.Equal(t, artifactdiff.DefaultAllowRemoved, allowRemoved)
+		}
+	}
+}
+
+func TestUpdateReleaseCutIssueRelease(t *testing.T) {
+	for _, tc := range []struct {
+		noMock       bool
+		prepare      func(*anagofakes.FakeReleaseImpl)
+		expectedItem string
+		shouldError  bool
+	}{
+		{ // success
+			noMock:       true,
+			prepare:      func(*anagofakes.FakeReleaseImpl) {},
+			expectedItem: "release",
+			shouldError:  false,
+		},
+		{ // success mock
+			prepare:      func(*anagofakes.FakeReleaseImpl) {},
+			expectedItem: "release-mock",
+			shouldError:  false,
+		},
+		{ // CheckReleaseCutIssue fails
+			prepare: func(mock *anagofakes.FakeReleaseImpl) {
+				mock.CheckReleaseCutIssueReturns(err)
+			},
+			expectedItem: "release-mock",
+			shouldError:  true,
+		},
+	} {
+		opts := anago.DefaultReleaseOptions()
+		opts.NoMock = tc.noMock
+		sut := anago.NewDefaultRelease(opts)
+		sut.SetState(
+			generateTestingReleaseState(&testStateParameters{versionsTag: &testVersionTag}),
+		)
+		mock := &anagofakes.FakeReleaseImpl{}
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+		err := sut.UpdateReleaseCutIssue()
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+		version, item, noMock := mock.CheckReleaseCutIssueArgsForCall(0)
+		require.Equal(t, testVersionTag, version)
+		require.Equal(t, tc.expectedItem, item)
+		require.Equal(t, tc.noMock, noMock)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package anago
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -29,10 +30,24 @@ import (
 	slsa "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/release/pkg/approver"
+	"k8s.io/release/pkg/attribution"
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/branding"
 	"k8s.io/release/pkg/build"
+	"k8s.io/release/pkg/buildenv"
 	"k8s.io/release/pkg/changelog"
+	"k8s.io/release/pkg/custody"
+	"k8s.io/release/pkg/cutissue"
 	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/metrics"
+	"k8s.io/release/pkg/platforms"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/sizereport"
+	"k8s.io/release/pkg/testgrid"
+	"k8s.io/release/pkg/vulnscan"
+	"k8s.io/release/pkg/workdir"
 	"sigs.k8s.io/bom/pkg/provenance"
 	"sigs.k8s.io/bom/pkg/spdx"
 	"sigs.k8s.io/release-sdk/git"
@@ -43,8 +58,9 @@ import (
 //
 //counterfeiter:generate . stageClient
 type stageClient interface {
-	// Submit can be used to submit a Google Cloud Build (GCB) job.
-	Submit(stream bool) error
+	// Submit can be used to submit a Google Cloud Build (GCB) job, which
+	// stops being watched once ctx gets cancelled.
+	Submit(ctx context.Context, stream bool) error
 
 	// InitState initializes the default internal state.
 	InitState()
@@ -66,6 +82,10 @@ type stageClient interface {
 	// be created.
 	CheckReleaseBranchState() error
 
+	// CheckCISignal verifies that the release blocking jobs of the release
+	// branch are green (or within the allowed flake tolerance).
+	CheckCISignal() error
+
 	// GenerateReleaseVersion discovers the next versions to be released.
 	GenerateReleaseVersion() error
 
@@ -87,15 +107,32 @@ type stageClient interface {
 	// into the local repository.
 	GenerateChangelog() error
 
+	// GenerateAttribution assembles the license notices of all vendored
+	// dependencies into a distributable archive.
+	GenerateAttribution() error
+
 	// VerifyArtifacts performs verification of the generated artifacts
 	VerifyArtifacts() error
 
+	// ScanImages scans the built container images for vulnerabilities and
+	// writes the report next to the images.
+	ScanImages() error
+
 	// GenerateBillOfMaterials generates the SBOM documents for the Kubernetes
 	// source code and the release artifacts.
 	GenerateBillOfMaterials() error
 
 	// StageArtifacts copies the build artifacts to a Google Cloud Bucket.
 	StageArtifacts() error
+
+	// ReportArtifactSizes persists the sizes of the staged artifacts and
+	// comments on the release cut issue if they grew beyond the threshold
+	// since the previous release.
+	ReportArtifactSizes() error
+
+	// UpdateReleaseCutIssue checks off the stage item in the release cut
+	// issue.
+	UpdateReleaseCutIssue() error
 }
 
 // DefaultStage is the default staging implementation used in production.
@@ -133,13 +170,14 @@ type defaultStageImpl struct{}
 //
 //counterfeiter:generate . stageImpl
 type stageImpl interface {
-	Submit(options *gcb.Options) error
+	Submit(ctx context.Context, options *gcb.Options) error
 	ToFile(fileName string) error
 	CheckPrerequisites() error
 	BranchNeedsCreation(
 		branch, releaseType string, buildVersion semver.Version,
 	) (bool, error)
 	PrepareWorkspaceStage(noMock bool) error
+	CISignal(branch string) (*testgrid.Signal, error)
 	GenerateReleaseVersion(
 		releaseType, version, branch string, branchFromMaster bool,
 	) (*release.Versions, error)
@@ -155,6 +193,7 @@ type stageImpl interface {
 	DockerHubLogin() error
 	MakeCross(version string) error
 	GenerateChangelog(options *changelog.Options) error
+	GenerateAttribution(options *attribution.Options) error
 	StageLocalSourceTree(
 		options *build.Options, workDir, buildVersion string,
 	) error
@@ -164,6 +203,7 @@ type stageImpl interface {
 		options *build.Options, srcPath, gcsPath string,
 	) error
 	PushContainerImages(options *build.Options) error
+	CheckBaseImages(registry, version, buildPath, branch string) error
 	GenerateVersionArtifactsBOM(string) error
 	GenerateSourceTreeBOM(options *spdx.DocGenerateOptions) (*spdx.Document, error)
 	WriteSourceBOM(spdxDoc *spdx.Document, version string) error
@@ -174,14 +214,27 @@ type stageImpl interface {
 	AddBinariesToSBOM(*spdx.Document, string) error
 	AddTarfilesToSBOM(*spdx.Document, string) error
 	VerifyArtifacts([]string) error
+	ScanImages(options *vulnscan.Options, images []string, reportPath string) error
 	GenerateAttestation(*StageState, *StageOptions) (*provenance.Statement, error)
 	PushAttestation(*provenance.Statement, *StageOptions) error
+	PushReleaseVersions(bucket, buildVersion string, versions *release.Versions) error
+	RecordCustodyLinks(keyPath string, steps, versions []string) (string, error)
+	PushCustodyLinks(dir, bucket, buildVersion string) error
 	GetProvenanceSubjects(*StageOptions, string) ([]intoto.Subject, error)
 	GetOutputDirSubjects(*StageOptions, string, string) ([]intoto.Subject, error)
+	CheckReleaseCutIssue(version, item string, noMock bool) error
+	ReportArtifactSizes(
+		options *sizereport.Options, version, stageDir, imagesDir string,
+	) (*sizereport.Report, error)
+	CommentReleaseCutIssue(version, body string, noMock bool) error
+	CollectBuildEnvironment(repoRoot, buildVersion, commit string) (*buildenv.Manifest, error)
+	WriteBuildEnvironment(manifest *buildenv.Manifest, path string) error
 }
 
-func (d *defaultStageImpl) Submit(options *gcb.Options) error {
-	return gcb.New(options).Submit()
+func (d *defaultStageImpl) Submit(ctx context.Context, options *gcb.Options) error {
+	g := gcb.New(options)
+	g.SetContext(ctx)
+	return g.Submit()
 }
 
 func (d *defaultStageImpl) ToFile(fileName string) error {
@@ -207,6 +260,10 @@ func (d *defaultStageImpl) PrepareWorkspaceStage(noMock bool) error {
 	return os.Chdir(gitRoot)
 }
 
+func (d *defaultStageImpl) CISignal(branch string) (*testgrid.Signal, error) {
+	return testgrid.New().Signal(branch)
+}
+
 func (d *defaultStageImpl) GenerateReleaseVersion(
 	releaseType, version, branch string, branchFromMaster bool,
 ) (*release.Versions, error) {
@@ -215,6 +272,22 @@ func (d *defaultStageImpl) GenerateReleaseVersion(
 	)
 }
 
+func (d *defaultStageImpl) PushReleaseVersions(
+	bucket, buildVersion string, versions *release.Versions,
+) error {
+	return release.WriteStagedVersions(bucket, buildVersion, versions)
+}
+
+func (d *defaultStageImpl) RecordCustodyLinks(
+	keyPath string, steps, versions []string,
+) (string, error) {
+	return recordCustodyLinks(keyPath, steps, versions)
+}
+
+func (d *defaultStageImpl) PushCustodyLinks(dir, bucket, buildVersion string) error {
+	return pushCustodyLinks(dir, bucket, buildVersion)
+}
+
 func (d *defaultStageImpl) OpenRepo(repoPath string) (*git.Repo, error) {
 	return git.OpenRepo(repoPath)
 }
@@ -293,14 +366,32 @@ func (d *defaultStageImpl) PushContainerImages(
 	return build.NewInstance(options).PushContainerImages()
 }
 
-func (d *DefaultStage) Submit(stream bool) error {
+// CheckBaseImages verifies the base images of all single-platform images of
+// the version against the configured base image policy.
+func (d *defaultStageImpl) CheckBaseImages(
+	registry, version, buildPath, branch string,
+) error {
+	return checkBaseImages(registry, version, buildPath, branch)
+}
+
+func (d *DefaultStage) Submit(ctx context.Context, stream bool) error {
 	options := gcb.NewDefaultOptions()
 	options.Stream = stream
 	options.Stage = true
 	options.NoMock = d.options.NoMock
+	options.NonInteractive = d.options.NonInteractive
 	options.Branch = d.options.ReleaseBranch
 	options.ReleaseType = d.options.ReleaseType
-	return d.impl.Submit(options)
+	options.VulnerabilityScan = d.options.VulnerabilityScan
+	options.IgnoredVulnerabilities = d.options.IgnoredVulnerabilities
+	options.SizeThreshold = d.options.SizeThreshold
+	options.BuildVersion = d.options.BuildVersion
+	options.Commit = d.options.Commit
+	options.Local = d.options.Local
+	options.ContainerRuntime = d.options.ContainerRuntime
+	options.EncryptionKey = d.options.EncryptionKey
+	options.CustodyKey = d.options.CustodyKey
+	return d.impl.Submit(ctx, options)
 }
 
 // ListBinaries returns a list of all the binaries obtained
@@ -320,22 +411,133 @@ func (d *defaultStageImpl) ListTarballs(version string) ([]string, error) {
 	return release.ListBuildTarballs(gitRoot, version)
 }
 
+func (d *defaultStageImpl) GenerateAttribution(options *attribution.Options) error {
+	if _, err := attribution.New(options).Generate(); err != nil {
+		return fmt.Errorf("generate attribution: %w", err)
+	}
+	return nil
+}
+
 // VerifyArtifacts check the artifacts produced are correct
 func (d *defaultStageImpl) VerifyArtifacts(versions []string) error {
 	// Create a new artifact checker to verify the consistency of
 	// the produced artifacts.
+	goVersion, err := release.PinnedGoVersion(gitRoot)
+	if err != nil {
+		return fmt.Errorf("get pinned go version: %w", err)
+	}
+
 	checker := release.NewArtifactCheckerWithOptions(
 		&release.ArtifactCheckerOptions{
-			GitRoot:  gitRoot,
-			Versions: versions,
+			GitRoot:   gitRoot,
+			Versions:  versions,
+			GoVersion: goVersion,
 		},
 	)
+	if matrix := platforms.Default(); matrix.Custom() {
+		checker.Options().Platforms = matrix.Strings()
+	}
+
+	// Ensure binaries got built for the whole platform matrix
+	if err := checker.CheckBuildPlatforms(); err != nil {
+		return fmt.Errorf("checking build platforms: %w", err)
+	}
 
 	// Ensure binaries are of the correct architecture
 	if err := checker.CheckBinaryArchitectures(); err != nil {
 		return fmt.Errorf("checking binary architectures: %w", err)
 	}
 
+	// Ensure binaries embed the correct version and build metadata
+	if err := checker.CheckBinaryBuildInfo(); err != nil {
+		return fmt.Errorf("checking binary build info: %w", err)
+	}
+
+	// Ensure binaries comply with the static and dynamic linkage policy
+	if err := checker.CheckBinaryLinkage(); err != nil {
+		return fmt.Errorf("checking binary linkage: %w", err)
+	}
+
+	// Ensure binaries and images use the Go version pinned for the branch
+	if err := checker.CheckGoVersions(); err != nil {
+		return fmt.Errorf("checking go versions: %w", err)
+	}
+
+	return nil
+}
+
+func (d *defaultStageImpl) ScanImages(
+	options *vulnscan.Options, images []string, reportPath string,
+) error {
+	return vulnscan.New(options).Run(images, reportPath)
+}
+
+func (d *defaultStageImpl) CheckReleaseCutIssue(version, item string, noMock bool) error {
+	return checkReleaseCutIssue(version, item, noMock)
+}
+
+func (d *defaultStageImpl) ReportArtifactSizes(
+	options *sizereport.Options, version, stageDir, imagesDir string,
+) (*sizereport.Report, error) {
+	return sizereport.New(options).Run(version, stageDir, imagesDir)
+}
+
+func (d *defaultStageImpl) CommentReleaseCutIssue(version, body string, noMock bool) error {
+	return commentReleaseCutIssue(version, body, noMock)
+}
+
+func (d *defaultStageImpl) CollectBuildEnvironment(repoRoot, buildVersion, commit string) (*buildenv.Manifest, error) {
+	return buildenv.New().Collect(repoRoot, buildVersion, commit)
+}
+
+func (d *defaultStageImpl) WriteBuildEnvironment(manifest *buildenv.Manifest, path string) error {
+	return buildenv.New().Write(manifest, path)
+}
+
+// ReportArtifactSizes compares the sizes of the locally staged artifacts and
+// images of all versions with their previous release. Artifacts which grew
+// beyond the threshold get reported in the release cut issue.
+func (d *DefaultStage) ReportArtifactSizes() error {
+	for _, version := range d.state.versions.Ordered() {
+		buildDir := filepath.Join(
+			gitRoot, fmt.Sprintf("%s-%s", release.BuildDir, version),
+		)
+		report, err := d.impl.ReportArtifactSizes(
+			d.options.SizeReportOptions(),
+			version,
+			filepath.Join(buildDir, release.GCSStagePath, version),
+			filepath.Join(buildDir, release.ImagesPath),
+		)
+		if err != nil {
+			return fmt.Errorf("report artifact sizes of %s: %w", version, err)
+		}
+
+		if len(report.Regressions) == 0 {
+			logrus.Infof("No artifact size regressions found for %s", version)
+			continue
+		}
+
+		logrus.Warnf(
+			"Found %d artifacts of %s which grew by more than %.0f%%",
+			len(report.Regressions), version, report.Threshold,
+		)
+		if err := d.impl.CommentReleaseCutIssue(
+			d.state.versions.Prime(), report.Markdown(), d.options.NoMock,
+		); err != nil {
+			return fmt.Errorf("comment size report of %s: %w", version, err)
+		}
+	}
+	return nil
+}
+
+func (d *DefaultStage) UpdateReleaseCutIssue() error {
+	item := cutissue.ItemStageMock
+	if d.options.NoMock {
+		item = cutissue.ItemStage
+	}
+	if err := d.impl.CheckReleaseCutIssue(d.state.versions.Prime(), item, d.options.NoMock); err != nil {
+		return fmt.Errorf("check %s item: %w", item, err)
+	}
 	return nil
 }
 
@@ -343,23 +545,35 @@ func (d *DefaultStage) InitLogFile() error {
 	logrus.SetFormatter(
 		&logrus.TextFormatter{FullTimestamp: true, ForceColors: true},
 	)
-	logFile := filepath.Join(os.TempDir(), "stage.log")
+	logFile := filepath.Join(workdir.TempDir(), "stage.log")
 	if err := d.impl.ToFile(logFile); err != nil {
 		return fmt.Errorf("setup log file: %w", err)
 	}
 	d.state.logFile = logFile
 	logrus.Infof("Additionally logging to file %s", d.state.logFile)
+
+	// Mutating operations are only audited for non-mock runs.
+	if d.options.NoMock {
+		if err := audit.Setup(audit.DefaultOptions()); err != nil {
+			return fmt.Errorf("setup audit log: %w", err)
+		}
+	}
 	return nil
 }
 
 func (d *DefaultStage) InitState() {
-	d.state = &StageState{DefaultState()}
+	d.state = &StageState{State: DefaultState()}
 }
 
 func (d *DefaultStage) ValidateOptions() error {
 	if err := d.options.Validate(d.state.State); err != nil {
 		return fmt.Errorf("validating options: %w", err)
 	}
+	if d.options.NoMock {
+		if err := approver.Check("stage", d.options.ReleaseBranch); err != nil {
+			return fmt.Errorf("checking release manager approval: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -380,6 +594,29 @@ func (d *DefaultStage) CheckReleaseBranchState() error {
 	return nil
 }
 
+func (d *DefaultStage) CheckCISignal() error {
+	if d.options.SkipCISignalCheck {
+		logrus.Warn("Skipping CI signal check")
+		return nil
+	}
+
+	signal, err := d.impl.CISignal(d.options.ReleaseBranch)
+	if err != nil {
+		return fmt.Errorf("get CI signal for branch %s: %w", d.options.ReleaseBranch, err)
+	}
+
+	if err := signal.Check(d.options.AllowedFlakyJobs); err != nil {
+		if !d.options.NoMock {
+			logrus.Warnf("Ignoring CI signal in mock mode: %v", err)
+			return nil
+		}
+		return fmt.Errorf("release cut blocked: %w", err)
+	}
+
+	logrus.Infof("CI signal on dashboard %s is green", signal.Dashboard)
+	return nil
+}
+
 func (d *DefaultStage) GenerateReleaseVersion() error {
 	versions, err := d.impl.GenerateReleaseVersion(
 		d.options.ReleaseType,
@@ -416,13 +653,8 @@ func (d *DefaultStage) TagRepository() error {
 			return fmt.Errorf("tag %s already exists: %w", version, err)
 		}
 
-		// Usually the build version contains a commit we can reference. If
-		// not, because the build version is exactly a tag, then we fallback to
-		// that tag.
-		commit := d.options.BuildVersion
-		if len(d.state.semverBuildVersion.Build) > 0 {
-			commit = d.state.semverBuildVersion.Build[0]
-		}
+		commit := d.buildCommit()
+		pinned := d.options.Commit != ""
 
 		if d.state.createReleaseBranch {
 			logrus.Infof("Creating release branch %s", d.options.ReleaseBranch)
@@ -447,6 +679,13 @@ func (d *DefaultStage) TagRepository() error {
 					return fmt.Errorf("checkout %s branch: %w", git.DefaultBranch, err)
 				}
 			}
+		} else if pinned {
+			// Tagging the pinned commit directly leaves the head detached,
+			// which skips the empty release commit below.
+			logrus.Infof("Checking out pinned commit %s", commit)
+			if err := d.impl.Checkout(repo, commit); err != nil {
+				return fmt.Errorf("checking out pinned commit %s: %w", commit, err)
+			}
 		} else {
 			logrus.Infof("Checking out branch %s", d.options.ReleaseBranch)
 			if err := d.impl.Checkout(repo, d.options.ReleaseBranch); err != nil {
@@ -495,7 +734,7 @@ func (d *DefaultStage) TagRepository() error {
 			logrus.Infof("Creating empty release commit for tag %s", version)
 			if err := d.impl.CommitEmpty(
 				repo,
-				fmt.Sprintf("Release commit for Kubernetes %s", version),
+				fmt.Sprintf("Release commit for %s %s", branding.Default().ProductName, version),
 			); err != nil {
 				return fmt.Errorf("create empty release commit: %w", err)
 			}
@@ -515,9 +754,11 @@ func (d *DefaultStage) TagRepository() error {
 		}
 
 		// If a custom ref is provided, try to merge it into the release
-		// branch.
+		// branch. Pinned commits are tagged unchanged.
 		ref := release.GetK8sRef()
-		if ref != release.DefaultK8sRef {
+		if ref != release.DefaultK8sRef && pinned {
+			logrus.Warnf("Not merging custom ref %s into pinned commit %s", ref, commit)
+		} else if ref != release.DefaultK8sRef {
 			logrus.Infof("Merging custom ref: %s", ref)
 			if err := d.impl.Merge(repo, git.Remotify(ref)); err != nil {
 				return fmt.Errorf("merge k8s ref: %w", err)
@@ -530,7 +771,7 @@ func (d *DefaultStage) TagRepository() error {
 			repo,
 			version,
 			fmt.Sprintf(
-				"Kubernetes %s release %s", d.options.ReleaseType, version,
+				"%s %s release %s", branding.Default().ProductName, d.options.ReleaseType, version,
 			),
 		); err != nil {
 			return fmt.Errorf("tag version: %w", err)
@@ -540,7 +781,7 @@ func (d *DefaultStage) TagRepository() error {
 		// detached HEAD state. So we checkout the branch again.
 		// The next stage (build) will checkout the branch it needs, but
 		// let's not end this step with a detached HEAD
-		if detachHead {
+		if detachHead || (pinned && !d.state.createReleaseBranch) {
 			logrus.Infof("Checking out %s to reattach HEAD", d.options.ReleaseBranch)
 			if err := d.impl.Checkout(repo, d.options.ReleaseBranch); err != nil {
 				return fmt.Errorf("checking out branch %s: %w", d.options.ReleaseBranch, err)
@@ -550,6 +791,20 @@ func (d *DefaultStage) TagRepository() error {
 	return nil
 }
 
+// buildCommit returns the commit to be tagged. This is the pinned commit if
+// provided. Usually the build version contains a commit we can reference. If
+// not, because the build version is exactly a tag, then we fallback to that
+// tag.
+func (d *DefaultStage) buildCommit() string {
+	if d.options.Commit != "" {
+		return d.options.Commit
+	}
+	if len(d.state.semverBuildVersion.Build) > 0 {
+		return d.state.semverBuildVersion.Build[0]
+	}
+	return d.options.BuildVersion
+}
+
 func (d *DefaultStage) Build() error {
 	// Log in to Docker Hub to avoid getting rate limited
 	if err := d.impl.DockerHubLogin(); err != nil {
@@ -562,6 +817,15 @@ func (d *DefaultStage) Build() error {
 			return fmt.Errorf("build artifacts: %w", err)
 		}
 	}
+
+	// Record the toolchain, which gets published next to the artifacts
+	buildEnvironment, err := d.impl.CollectBuildEnvironment(
+		gitRoot, d.options.BuildVersion, d.buildCommit(),
+	)
+	if err != nil {
+		return fmt.Errorf("record build environment: %w", err)
+	}
+	d.state.buildEnvironment = buildEnvironment
 	return nil
 }
 
@@ -570,6 +834,37 @@ func (d *DefaultStage) VerifyArtifacts() error {
 	return d.impl.VerifyArtifacts(d.state.versions.Ordered())
 }
 
+// ScanImages scans the image tarballs of all versions for vulnerabilities.
+// The report will be staged together with the images.
+func (d *DefaultStage) ScanImages() error {
+	for _, version := range d.state.versions.Ordered() {
+		archives, err := d.impl.ListImageArchives(version)
+		if err != nil {
+			return fmt.Errorf("list image archives for %s: %w", version, err)
+		}
+
+		images := []string{}
+		for _, archive := range archives {
+			if strings.HasSuffix(archive, ".tar") {
+				images = append(images, archive)
+			}
+		}
+
+		reportPath := filepath.Join(
+			gitRoot,
+			fmt.Sprintf("%s-%s", release.BuildDir, version),
+			release.ImagesPath,
+			vulnscan.ReportFile,
+		)
+		if err := d.impl.ScanImages(
+			d.options.VulnerabilityScanOptions(), images, reportPath,
+		); err != nil {
+			return fmt.Errorf("scan images of %s: %w", version, err)
+		}
+	}
+	return nil
+}
+
 func (d *DefaultStage) GenerateChangelog() error {
 	repoPath := gitRoot
 
@@ -619,6 +914,26 @@ func (d *DefaultStage) GenerateChangelog() error {
 	})
 }
 
+// GenerateAttribution creates the license attribution archive for every
+// version. The archive is placed next to the release tarballs to get staged
+// together with them.
+func (d *DefaultStage) GenerateAttribution() error {
+	for _, version := range d.state.versions.Ordered() {
+		if err := d.impl.GenerateAttribution(&attribution.Options{
+			RepoRoot: gitRoot,
+			OutputPath: filepath.Join(
+				gitRoot,
+				fmt.Sprintf("%s-%s", release.BuildDir, version),
+				release.ReleaseTarsPath,
+				attribution.ArchiveName,
+			),
+		}); err != nil {
+			return fmt.Errorf("generate attribution for %s: %w", version, err)
+		}
+	}
+	return nil
+}
+
 // AddBinariesToSBOM reads the produced "naked" binaries and adds them to the sbom
 func (d *defaultStageImpl) AddBinariesToSBOM(sbom *spdx.Document, version string) error {
 	binaries, err := d.ListBinaries(version)
@@ -720,7 +1035,7 @@ func (d *defaultStageImpl) GenerateVersionArtifactsBOM(version string) error {
 		URI: fmt.Sprintf("https://sbom.k8s.io/%s/source", version),
 	}
 	if err := extRef.ReadSourceFile(
-		filepath.Join(os.TempDir(), fmt.Sprintf("source-bom-%s.spdx", version)),
+		filepath.Join(workdir.TempDir(), fmt.Sprintf("source-bom-%s.spdx", version)),
 	); err != nil {
 		return fmt.Errorf("reading the source file as external reference: %w", err)
 	}
@@ -738,7 +1053,7 @@ func (d *defaultStageImpl) GenerateVersionArtifactsBOM(version string) error {
 	}
 
 	// Write the Release Artifacts SBOM to disk
-	if err := doc.Write(filepath.Join(os.TempDir(), fmt.Sprintf("release-bom-%s.spdx", version))); err != nil {
+	if err := doc.Write(filepath.Join(workdir.TempDir(), fmt.Sprintf("release-bom-%s.spdx", version))); err != nil {
 		return fmt.Errorf("writing artifacts SBOM for %s: %w", version, err)
 	}
 	return nil
@@ -762,7 +1077,7 @@ func (d *defaultStageImpl) WriteSourceBOM(
 ) error {
 	spdxDoc.Namespace = fmt.Sprintf("https://sbom.k8s.io/%s/source", version)
 	spdxDoc.Name = fmt.Sprintf("kubernetes-%s", version)
-	if err := spdxDoc.Write(filepath.Join(os.TempDir(), fmt.Sprintf("source-bom-%s.spdx", version))); err != nil {
+	if err := spdxDoc.Write(filepath.Join(workdir.TempDir(), fmt.Sprintf("source-bom-%s.spdx", version))); err != nil {
 		return fmt.Errorf("writing the source code SBOM: %w", err)
 	}
 	return nil
@@ -776,7 +1091,7 @@ func (d *DefaultStage) GenerateBillOfMaterials() error {
 	spdxDOC, err := d.impl.GenerateSourceTreeBOM(&spdx.DocGenerateOptions{
 		ProcessGoModules: true,
 		License:          LicenseIdentifier,
-		OutputFile:       filepath.Join(os.TempDir(), "kubernetes-source.spdx"),
+		OutputFile:       filepath.Join(workdir.TempDir(), "kubernetes-source.spdx"),
 		Namespace:        "https://sbom.k8s.io/REPLACE/source", // This one gets replaced when writing to disk
 		ScanLicenses:     true,
 		Directories:      []string{gitRoot},
@@ -814,6 +1129,10 @@ func (d *DefaultStage) StageArtifacts() error {
 		Registry:                   d.options.ContainerRegistry(),
 		AllowDup:                   true,
 		ValidateRemoteImageDigests: true,
+		EncryptionKey:              d.options.EncryptionKey,
+	}
+	if d.options.EncryptionKey != "" {
+		logrus.Infof("Encrypting staged artifacts using %s", d.options.EncryptionKey)
 	}
 	if err := d.impl.CheckReleaseBucket(pushBuildOptions); err != nil {
 		return fmt.Errorf("check release bucket access: %w", err)
@@ -850,8 +1169,16 @@ func (d *DefaultStage) StageArtifacts() error {
 		if err := d.impl.StageLocalArtifacts(pushBuildOptions); err != nil {
 			return fmt.Errorf("staging local artifacts: %w", err)
 		}
-		gcsPath := filepath.Join(
-			d.options.Bucket(), release.StagePath, d.options.BuildVersion, version,
+
+		// Add the build environment manifest to the staged artifacts
+		if err := d.impl.WriteBuildEnvironment(
+			d.state.buildEnvironment,
+			filepath.Join(buildDir, release.GCSStagePath, version, buildenv.ManifestFilename),
+		); err != nil {
+			return fmt.Errorf("writing build environment manifest: %w", err)
+		}
+		gcsPath := layout.Default().StagePath(
+			d.options.Bucket(), d.options.BuildVersion, version,
 		)
 
 		// Push gcs-stage to GCS
@@ -872,9 +1199,23 @@ func (d *DefaultStage) StageArtifacts() error {
 			return fmt.Errorf("pushing release artifacts: %w", err)
 		}
 
-		// Push container images into registry
-		if err := d.impl.PushContainerImages(pushBuildOptions); err != nil {
-			return fmt.Errorf("pushing container images: %w", err)
+		// Push container images into registry. The images would expose the
+		// embargoed binaries, which is why they get pushed by the release
+		// run from the decrypted image archives for encrypted stages.
+		if d.options.EncryptionKey != "" {
+			logrus.Info("Not pushing container images of an encrypted stage")
+		} else {
+			if err := d.impl.PushContainerImages(pushBuildOptions); err != nil {
+				return fmt.Errorf("pushing container images: %w", err)
+			}
+
+			// Fail before the images can be promoted if any got built on an
+			// unexpected base
+			if err := d.impl.CheckBaseImages(
+				d.options.ContainerRegistry(), version, buildDir, d.options.ReleaseBranch,
+			); err != nil {
+				return fmt.Errorf("check base images: %w", err)
+			}
 		}
 
 		// Add artifacts to the attestation, this should get both release-images
@@ -886,6 +1227,29 @@ func (d *DefaultStage) StageArtifacts() error {
 			return fmt.Errorf("adding provenance of release-images for version %s: %w", version, err)
 		}
 		statement.Subject = append(statement.Subject, subjects...)
+
+		// Recording the artifact metrics is best effort only
+		for kind, dir := range map[string]string{
+			release.GCSStagePath: filepath.Join(buildDir, release.GCSStagePath, version),
+			release.ImagesPath:   filepath.Join(buildDir, release.ImagesPath),
+		} {
+			if err := metrics.ObserveArtifacts(kind, dir); err != nil {
+				logrus.Warnf("Unable to record artifact metrics: %v", err)
+			}
+		}
+	}
+
+	// Record the chain of custody of the staged artifacts. The images have
+	// been signed when pushing them, which is not the case for encrypted
+	// stages.
+	if d.options.CustodyKey != "" {
+		steps := []string{custody.StepStage}
+		if d.options.EncryptionKey == "" {
+			steps = append(steps, custody.StepSign)
+		}
+		if err := d.recordCustodyLinks(steps); err != nil {
+			return fmt.Errorf("record chain of custody: %w", err)
+		}
 	}
 
 	// Push the attestation metadata file to the bucket
@@ -893,11 +1257,29 @@ func (d *DefaultStage) StageArtifacts() error {
 		return fmt.Errorf("writing provenance metadata to disk: %w", err)
 	}
 
+	// Store the rendered versions for the release, because tag schemes can
+	// render different tags when generating them again
+	if err := d.impl.PushReleaseVersions(
+		d.options.Bucket(), d.options.BuildVersion, d.state.versions,
+	); err != nil {
+		return fmt.Errorf("push release versions: %w", err)
+	}
+
 	// Delete the local source tarball
 	if err := d.impl.DeleteLocalSourceTarball(pushBuildOptions, workspaceDir); err != nil {
 		return fmt.Errorf("delete source tarball: %w", err)
 	}
 
+	// Store the signed audit log of a non-mock run next to the artifacts
+	if err := audit.Publish(func(dir string) error {
+		return d.impl.PushReleaseArtifacts(
+			pushBuildOptions, dir,
+			layout.Default().StagePath(d.options.Bucket(), d.options.BuildVersion, auditPath),
+		)
+	}); err != nil {
+		return fmt.Errorf("publish audit log: %w", err)
+	}
+
 	args := ""
 	if d.options.NoMock {
 		args += " --nomock"
@@ -909,6 +1291,9 @@ func (d *DefaultStage) StageArtifacts() error {
 		args += " --branch=" + d.options.ReleaseBranch
 	}
 	args += " --build-version=" + d.options.BuildVersion
+	if d.options.Commit != "" {
+		args += " --commit=" + d.options.Commit
+	}
 
 	logrus.Infof(
 		"To release this staged build, run:\n\n$ krel release%s", args,
@@ -916,6 +1301,23 @@ func (d *DefaultStage) StageArtifacts() error {
 	return nil
 }
 
+// recordCustodyLinks records the links of the chain of custody steps and
+// pushes them next to the staged build.
+func (d *DefaultStage) recordCustodyLinks(steps []string) error {
+	dir, err := d.impl.RecordCustodyLinks(
+		d.options.CustodyKey, steps, d.state.versions.Ordered(),
+	)
+	if err != nil {
+		return fmt.Errorf("record links: %w", err)
+	}
+	if err := d.impl.PushCustodyLinks(
+		dir, d.options.Bucket(), d.options.BuildVersion,
+	); err != nil {
+		return fmt.Errorf("push links: %w", err)
+	}
+	return nil
+}
+
 // GenerateAttestation creates a provenance attestation with its predicate
 // preloaded with the current krel run information
 func (d *defaultStageImpl) GenerateAttestation(state *StageState, options *StageOptions) (attestation *provenance.Statement, err error) {
@@ -928,17 +1330,24 @@ func (d *defaultStageImpl) GenerateAttestation(state *StageState, options *Stage
 	if options.NoMock {
 		arguments["nomock"] = "true"
 	}
-
-	// Fetch the last commit:
-	repo, err := git.OpenRepo(gitRoot)
-	if err != nil {
-		return nil, fmt.Errorf("opening repository to check commit hash: %w", err)
+	if options.Commit != "" {
+		arguments["commit"] = options.Commit
 	}
 
-	// Get the k/k commit we are building
-	commitSHA, err := repo.LastCommitSha()
-	if err != nil {
-		return nil, fmt.Errorf("getting k/k build point: %w", err)
+	// Get the k/k commit we are building, which is exactly the pinned one if
+	// provided
+	commitSHA := options.Commit
+	if commitSHA == "" {
+		// Fetch the last commit:
+		repo, err := git.OpenRepo(gitRoot)
+		if err != nil {
+			return nil, fmt.Errorf("opening repository to check commit hash: %w", err)
+		}
+
+		commitSHA, err = repo.LastCommitSha()
+		if err != nil {
+			return nil, fmt.Errorf("getting k/k build point: %w", err)
+		}
 	}
 
 	// Create the predicate to populate it with the current
@@ -974,10 +1383,10 @@ func (d *defaultStageImpl) GenerateAttestation(state *StageState, options *Stage
 // PushAttestation writes the provenance metadata to the staging location in
 // the Google Cloud Bucket.
 func (d *defaultStageImpl) PushAttestation(attestation *provenance.Statement, options *StageOptions) (err error) {
-	gcsPath := filepath.Join(options.Bucket(), release.StagePath, options.BuildVersion)
+	gcsPath := layout.Default().StagePath(options.Bucket(), options.BuildVersion)
 
 	// Create a temporary file:
-	f, err := os.CreateTemp("", "provenance-")
+	f, err := workdir.CreateTemp("provenance-")
 	if err != nil {
 		return fmt.Errorf("creating temp file for provenance metadata: %w", err)
 	}
@@ -990,8 +1399,9 @@ func (d *defaultStageImpl) PushAttestation(attestation *provenance.Statement, op
 
 	// Upload the metadata file to the staging bucket
 	pushBuildOptions := &build.Options{
-		Bucket:   options.Bucket(),
-		AllowDup: true,
+		Bucket:        options.Bucket(),
+		AllowDup:      true,
+		EncryptionKey: options.EncryptionKey,
 	}
 
 	if err := d.CheckReleaseBucket(pushBuildOptions); err != nil {
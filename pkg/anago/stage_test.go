@@ -18,6 +18,8 @@ limitations under the License.
 package anago_test
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
 
 	intoto "github.com/in-toto/in-toto-golang/in_toto"
@@ -25,7 +27,14 @@ import (
 
 	"k8s.io/release/pkg/anago"
 	"k8s.io/release/pkg/anago/anagofakes"
+	"k8s.io/release/pkg/attribution"
+	"k8s.io/release/pkg/buildenv"
+	"k8s.io/release/pkg/custody"
+	"k8s.io/release/pkg/gcp/gcb"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/sizereport"
+	"k8s.io/release/pkg/testgrid"
+	"k8s.io/release/pkg/vulnscan"
 	"sigs.k8s.io/bom/pkg/provenance"
 	"sigs.k8s.io/bom/pkg/spdx"
 	"sigs.k8s.io/release-sdk/git"
@@ -151,6 +160,84 @@ func TestCheckReleaseBranchStateStage(t *testing.T) {
 	}
 }
 
+func TestCheckCISignalStage(t *testing.T) {
+	failing := &testgrid.Signal{Jobs: testgrid.JobData{
+		"job": testgrid.JobSummary{OverallStatus: testgrid.Failing},
+	}}
+	flaky := &testgrid.Signal{Jobs: testgrid.JobData{
+		"job": testgrid.JobSummary{OverallStatus: testgrid.Flaky},
+	}}
+
+	for _, tc := range []struct {
+		prepare     func(*anagofakes.FakeStageImpl, *anago.StageOptions)
+		shouldError bool
+	}{
+		{ // success
+			prepare: func(mock *anagofakes.FakeStageImpl, opts *anago.StageOptions) {
+				opts.NoMock = true
+				mock.CISignalReturns(&testgrid.Signal{}, nil)
+			},
+			shouldError: false,
+		},
+		{ // success flaky within tolerance
+			prepare: func(mock *anagofakes.FakeStageImpl, opts *anago.StageOptions) {
+				opts.NoMock = true
+				opts.AllowedFlakyJobs = 1
+				mock.CISignalReturns(flaky, nil)
+			},
+			shouldError: false,
+		},
+		{ // success failing signal in mock mode
+			prepare: func(mock *anagofakes.FakeStageImpl, opts *anago.StageOptions) {
+				mock.CISignalReturns(failing, nil)
+			},
+			shouldError: false,
+		},
+		{ // success skipped
+			prepare: func(mock *anagofakes.FakeStageImpl, opts *anago.StageOptions) {
+				opts.NoMock = true
+				opts.SkipCISignalCheck = true
+				mock.CISignalReturns(nil, err)
+			},
+			shouldError: false,
+		},
+		{ // failing signal
+			prepare: func(mock *anagofakes.FakeStageImpl, opts *anago.StageOptions) {
+				opts.NoMock = true
+				mock.CISignalReturns(failing, nil)
+			},
+			shouldError: true,
+		},
+		{ // flaky signal exceeding tolerance
+			prepare: func(mock *anagofakes.FakeStageImpl, opts *anago.StageOptions) {
+				opts.NoMock = true
+				mock.CISignalReturns(flaky, nil)
+			},
+			shouldError: true,
+		},
+		{ // CISignal fails
+			prepare: func(mock *anagofakes.FakeStageImpl, opts *anago.StageOptions) {
+				mock.CISignalReturns(nil, err)
+			},
+			shouldError: true,
+		},
+	} {
+		opts := anago.DefaultStageOptions()
+		sut := anago.NewDefaultStage(opts)
+
+		mock := &anagofakes.FakeStageImpl{}
+		tc.prepare(mock, opts)
+		sut.SetImpl(mock)
+
+		err := sut.CheckCISignal()
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
 func TestGenerateReleaseVersionStage(t *testing.T) {
 	for _, tc := range []struct {
 		prepare             func(*anagofakes.FakeStageImpl)
@@ -377,7 +464,7 @@ func TestTagRepository(t *testing.T) {
 
 		state.SetVersions(tc.versions)
 		state.SetCreateReleaseBranch(tc.createReleaseBranch)
-		sut.SetState(&anago.StageState{state})
+		sut.SetState(&anago.StageState{State: state})
 
 		mock := &anagofakes.FakeStageImpl{}
 		tc.prepare(mock)
@@ -392,9 +479,42 @@ func TestTagRepository(t *testing.T) {
 	}
 }
 
+func TestTagRepositoryPinnedCommit(t *testing.T) {
+	const commit = "4628c605aadb9b8a6ec9f3bdc7be0ba0cd6b4e5f"
+
+	opts := anago.DefaultStageOptions()
+	opts.BuildVersion = "v1.20.0-rc.0.358+4628c605aadb9b"
+	opts.ReleaseBranch = "release-1.20"
+	opts.Commit = commit
+	state := anago.DefaultState()
+	require.NoError(t, opts.Validate(state))
+
+	sut := anago.NewDefaultStage(opts)
+	state.SetVersions(release.NewReleaseVersions("", "", "v1.20.0-rc.1", "", ""))
+	sut.SetState(&anago.StageState{State: state})
+
+	mock := &anagofakes.FakeStageImpl{}
+	mock.RevParseTagReturns("", err)
+	sut.SetImpl(mock)
+
+	require.NoError(t, sut.TagRepository())
+
+	// The pinned commit gets tagged as is
+	require.Equal(t, 2, mock.CheckoutCallCount())
+	_, rev, _ := mock.CheckoutArgsForCall(0)
+	require.Equal(t, commit, rev)
+	require.Zero(t, mock.CommitEmptyCallCount())
+	require.Equal(t, 1, mock.TagCallCount())
+
+	// The head gets reattached afterwards
+	_, rev, _ = mock.CheckoutArgsForCall(1)
+	require.Equal(t, "release-1.20", rev)
+}
+
 func TestBuild(t *testing.T) {
 	for _, tc := range []struct {
 		prepare     func(*anagofakes.FakeStageImpl)
+		commit      string
 		shouldError bool
 	}{
 		{ // success
@@ -413,8 +533,27 @@ func TestBuild(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // pinned commit gets recorded
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.CollectBuildEnvironmentCalls(func(_, _, commit string) (*buildenv.Manifest, error) {
+					if commit != "4628c605aadb9b8a6ec9f3bdc7be0ba0cd6b4e5f" {
+						return nil, err
+					}
+					return &buildenv.Manifest{Commit: commit}, nil
+				})
+			},
+			commit:      "4628c605aadb9b8a6ec9f3bdc7be0ba0cd6b4e5f",
+			shouldError: false,
+		},
+		{ // CollectBuildEnvironment fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.CollectBuildEnvironmentReturns(nil, err)
+			},
+			shouldError: true,
+		},
 	} {
 		opts := anago.DefaultStageOptions()
+		opts.Commit = tc.commit
 		sut := anago.NewDefaultStage(opts)
 
 		sut.SetState(
@@ -498,6 +637,12 @@ func TestStageArtifacts(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // WriteBuildEnvironment fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.WriteBuildEnvironmentReturns(err)
+			},
+			shouldError: true,
+		},
 		{ // PushReleaseArtifacts fails on first
 			prepare: func(mock *anagofakes.FakeStageImpl) {
 				mock.PushReleaseArtifactsReturns(err)
@@ -516,6 +661,12 @@ func TestStageArtifacts(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // CheckBaseImages fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.CheckBaseImagesReturns(err)
+			},
+			shouldError: true,
+		},
 		{ // DeleteLocalSourceTarball fails
 			prepare: func(mock *anagofakes.FakeStageImpl) {
 				mock.DeleteLocalSourceTarballReturns(err)
@@ -534,6 +685,12 @@ func TestStageArtifacts(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // PushReleaseVersions fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.PushReleaseVersionsReturns(err)
+			},
+			shouldError: true,
+		},
 		{ // GetProvenanceSubjects fails
 			prepare: func(mock *anagofakes.FakeStageImpl) {
 				mock.GetProvenanceSubjectsReturns(nil, err)
@@ -571,10 +728,90 @@ func TestStageArtifacts(t *testing.T) {
 	}
 }
 
+func TestStageArtifactsEncrypted(t *testing.T) {
+	opts := anago.DefaultStageOptions()
+	opts.EncryptionKey = "projects/p/locations/global/keyRings/r/cryptoKeys/k"
+	sut := anago.NewDefaultStage(opts)
+	mock := &anagofakes.FakeStageImpl{}
+	mock.GenerateAttestationReturns(provenance.NewSLSAStatement(), nil)
+	sut.SetImpl(mock)
+	sut.SetState(
+		generateTestingStageState(
+			&testStateParameters{versionsTag: &testVersionTag},
+		),
+	)
+
+	require.Nil(t, sut.StageArtifacts())
+	require.Zero(t, mock.PushContainerImagesCallCount())
+	require.Positive(t, mock.PushReleaseArtifactsCallCount())
+	pushOpts, _, _ := mock.PushReleaseArtifactsArgsForCall(0)
+	require.Equal(t, opts.EncryptionKey, pushOpts.EncryptionKey)
+}
+
+func TestStageArtifactsCustody(t *testing.T) {
+	for _, tc := range []struct {
+		encryptionKey string
+		prepare       func(*anagofakes.FakeStageImpl)
+		expectedSteps []string
+		shouldError   bool
+	}{
+		{ // stage and sign
+			prepare:       func(*anagofakes.FakeStageImpl) {},
+			expectedSteps: []string{custody.StepStage, custody.StepSign},
+		},
+		{ // images of encrypted stages are not signed
+			encryptionKey: "projects/p/locations/global/keyRings/r/cryptoKeys/k",
+			prepare:       func(*anagofakes.FakeStageImpl) {},
+			expectedSteps: []string{custody.StepStage},
+		},
+		{ // RecordCustodyLinks fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.RecordCustodyLinksReturns("", err)
+			},
+			expectedSteps: []string{custody.StepStage, custody.StepSign},
+			shouldError:   true,
+		},
+		{ // PushCustodyLinks fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.PushCustodyLinksReturns(err)
+			},
+			expectedSteps: []string{custody.StepStage, custody.StepSign},
+			shouldError:   true,
+		},
+	} {
+		opts := anago.DefaultStageOptions()
+		opts.CustodyKey = "functionary.pem"
+		opts.EncryptionKey = tc.encryptionKey
+		sut := anago.NewDefaultStage(opts)
+		mock := &anagofakes.FakeStageImpl{}
+		mock.GenerateAttestationReturns(provenance.NewSLSAStatement(), nil)
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+		sut.SetState(
+			generateTestingStageState(
+				&testStateParameters{versionsTag: &testVersionTag},
+			),
+		)
+
+		err := sut.StageArtifacts()
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+			require.Equal(t, 1, mock.PushCustodyLinksCallCount())
+		}
+		key, steps, versions := mock.RecordCustodyLinksArgsForCall(0)
+		require.Equal(t, opts.CustodyKey, key)
+		require.Equal(t, tc.expectedSteps, steps)
+		require.Equal(t, []string{testVersionTag}, versions)
+	}
+}
+
 func TestSubmitStageImpl(t *testing.T) {
 	for _, tc := range []struct {
-		prepare     func(*anagofakes.FakeStageImpl)
-		shouldError bool
+		prepare        func(*anagofakes.FakeStageImpl)
+		nonInteractive bool
+		shouldError    bool
 	}{
 		{ // success
 			prepare:     func(*anagofakes.FakeStageImpl) {},
@@ -586,13 +823,26 @@ func TestSubmitStageImpl(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // non interactive submission gets forwarded
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.SubmitCalls(func(_ context.Context, options *gcb.Options) error {
+					if !options.NonInteractive {
+						return err
+					}
+					return nil
+				})
+			},
+			nonInteractive: true,
+			shouldError:    false,
+		},
 	} {
 		opts := anago.DefaultStageOptions()
+		opts.NonInteractive = tc.nonInteractive
 		sut := anago.NewDefaultStage(opts)
 		mock := &anagofakes.FakeStageImpl{}
 		tc.prepare(mock)
 		sut.SetImpl(mock)
-		err := sut.Submit(false)
+		err := sut.Submit(context.Background(), false)
 		if tc.shouldError {
 			require.NotNil(t, err)
 		} else {
@@ -731,3 +981,219 @@ func TestVerifyArtifactsImpl(t *testing.T) {
 		}
 	}
 }
+
+func TestScanImages(t *testing.T) {
+	for _, tc := range []struct {
+		prepare func(*anagofakes.FakeStageImpl)
+		assert  func(*anagofakes.FakeStageImpl, error)
+	}{
+		{ // success
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.ListImageArchivesReturns([]string{
+					"amd64/kube-apiserver.tar",
+					"amd64/kube-apiserver.docker_tag",
+				}, nil)
+			},
+			assert: func(mock *anagofakes.FakeStageImpl, err error) {
+				require.Nil(t, err)
+				require.Equal(t, 1, mock.ScanImagesCallCount())
+				opts, images, reportPath := mock.ScanImagesArgsForCall(0)
+				require.Equal(t, vulnscan.ModeFail, opts.Mode)
+				require.Equal(t, []string{"CVE-2024-1"}, opts.IgnoredVulnerabilities)
+				require.Equal(t, []string{"amd64/kube-apiserver.tar"}, images)
+				require.Equal(t, vulnscan.ReportFile, filepath.Base(reportPath))
+			},
+		},
+		{ // ListImageArchives fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.ListImageArchivesReturns(nil, err)
+			},
+			assert: func(mock *anagofakes.FakeStageImpl, err error) {
+				require.NotNil(t, err)
+				require.Zero(t, mock.ScanImagesCallCount())
+			},
+		},
+		{ // ScanImages fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.ScanImagesReturns(err)
+			},
+			assert: func(mock *anagofakes.FakeStageImpl, err error) {
+				require.NotNil(t, err)
+			},
+		},
+	} {
+		opts := anago.DefaultStageOptions()
+		opts.VulnerabilityScan = vulnscan.ModeFail
+		opts.IgnoredVulnerabilities = []string{"CVE-2024-1"}
+		sut := anago.NewDefaultStage(opts)
+		mock := &anagofakes.FakeStageImpl{}
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+		sut.SetState(
+			generateTestingStageState(
+				&testStateParameters{versionsTag: &testVersionTag},
+			),
+		)
+		tc.assert(mock, sut.ScanImages())
+	}
+}
+
+func TestReportArtifactSizes(t *testing.T) {
+	regressions := &sizereport.Report{
+		Version:         "v1.18.0",
+		PreviousVersion: "v1.17.0",
+		Threshold:       5,
+		Regressions: []*sizereport.Regression{
+			{Name: "bin/linux/amd64/kubectl", Kind: sizereport.KindArtifact, PreviousSize: 10, Size: 20, Growth: 100},
+		},
+	}
+	for _, tc := range []struct {
+		prepare func(*anagofakes.FakeStageImpl)
+		assert  func(*anagofakes.FakeStageImpl, error)
+	}{
+		{ // success without regressions
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.ReportArtifactSizesReturns(&sizereport.Report{}, nil)
+			},
+			assert: func(mock *anagofakes.FakeStageImpl, err error) {
+				require.Nil(t, err)
+				require.Equal(t, 1, mock.ReportArtifactSizesCallCount())
+				opts, version, stageDir, imagesDir := mock.ReportArtifactSizesArgsForCall(0)
+				require.Equal(t, 5.0, opts.Threshold)
+				require.Equal(t, release.TestBucket, opts.Bucket)
+				require.Equal(t, testVersionTag, version)
+				require.Equal(t, testVersionTag, filepath.Base(stageDir))
+				require.Equal(t, filepath.Base(release.ImagesPath), filepath.Base(imagesDir))
+				require.Zero(t, mock.CommentReleaseCutIssueCallCount())
+			},
+		},
+		{ // success with regressions
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.ReportArtifactSizesReturns(regressions, nil)
+			},
+			assert: func(mock *anagofakes.FakeStageImpl, err error) {
+				require.Nil(t, err)
+				require.Equal(t, 1, mock.CommentReleaseCutIssueCallCount())
+				_, body, noMock := mock.CommentReleaseCutIssueArgsForCall(0)
+				require.Contains(t, body, "bin/linux/amd64/kubectl")
+				require.False(t, noMock)
+			},
+		},
+		{ // ReportArtifactSizes fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.ReportArtifactSizesReturns(nil, err)
+			},
+			assert: func(mock *anagofakes.FakeStageImpl, err error) {
+				require.NotNil(t, err)
+				require.Zero(t, mock.CommentReleaseCutIssueCallCount())
+			},
+		},
+		{ // CommentReleaseCutIssue fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.ReportArtifactSizesReturns(regressions, nil)
+				mock.CommentReleaseCutIssueReturns(err)
+			},
+			assert: func(mock *anagofakes.FakeStageImpl, err error) {
+				require.NotNil(t, err)
+			},
+		},
+	} {
+		opts := anago.DefaultStageOptions()
+		opts.SizeThreshold = 5
+		sut := anago.NewDefaultStage(opts)
+		mock := &anagofakes.FakeStageImpl{}
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+		sut.SetState(
+			generateTestingStageState(
+				&testStateParameters{versionsTag: &testVersionTag},
+			),
+		)
+		tc.assert(mock, sut.ReportArtifactSizes())
+	}
+}
+
+func TestGenerateAttribution(t *testing.T) {
+	for _, tc := range []struct {
+		prepare func(*anagofakes.FakeStageImpl)
+		assert  func(*anagofakes.FakeStageImpl, error)
+	}{
+		{ // success
+			prepare: func(*anagofakes.FakeStageImpl) {},
+			assert: func(mock *anagofakes.FakeStageImpl, err error) {
+				require.Nil(t, err)
+				require.Equal(t, 1, mock.GenerateAttributionCallCount())
+				opts := mock.GenerateAttributionArgsForCall(0)
+				require.NotEmpty(t, opts.RepoRoot)
+				require.Equal(t, attribution.ArchiveName, filepath.Base(opts.OutputPath))
+				require.Equal(t, release.ReleaseTarsPath, filepath.Base(filepath.Dir(opts.OutputPath)))
+			},
+		},
+		{ // GenerateAttribution fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.GenerateAttributionReturns(err)
+			},
+			assert: func(mock *anagofakes.FakeStageImpl, err error) {
+				require.NotNil(t, err)
+			},
+		},
+	} {
+		sut := anago.NewDefaultStage(anago.DefaultStageOptions())
+		mock := &anagofakes.FakeStageImpl{}
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+		sut.SetState(
+			generateTestingStageState(
+				&testStateParameters{versionsTag: &testVersionTag},
+			),
+		)
+		tc.assert(mock, sut.GenerateAttribution())
+	}
+}
+
+func TestUpdateReleaseCutIssueStage(t *testing.T) {
+	for _, tc := range []struct {
+		noMock       bool
+		prepare      func(*anagofakes.FakeStageImpl)
+		expectedItem string
+		shouldError  bool
+	}{
+		{ // success
+			noMock:       true,
+			prepare:      func(*anagofakes.FakeStageImpl) {},
+			expectedItem: "stage",
+			shouldError:  false,
+		},
+		{ // success mock
+			prepare:      func(*anagofakes.FakeStageImpl) {},
+			expectedItem: "stage-mock",
+			shouldError:  false,
+		},
+		{ // CheckReleaseCutIssue fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.CheckReleaseCutIssueReturns(err)
+			},
+			expectedItem: "stage-mock",
+			shouldError:  true,
+		},
+	} {
+		opts := anago.DefaultStageOptions()
+		opts.NoMock = tc.noMock
+		sut := anago.NewDefaultStage(opts)
+		sut.SetState(
+			generateTestingStageState(&testStateParameters{versionsTag: &testVersionTag}),
+		)
+		mock := &anagofakes.FakeStageImpl{}
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+		err := sut.UpdateReleaseCutIssue()
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+		_, item, noMock := mock.CheckReleaseCutIssueArgsForCall(0)
+		require.Equal(t, tc.expectedItem, item)
+		require.Equal(t, tc.noMock, noMock)
+	}
+}
@@ -27,6 +27,8 @@ import (
 	"sigs.k8s.io/release-utils/command"
 	"sigs.k8s.io/release-utils/util"
 
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/imagerewrite"
 	"k8s.io/release/pkg/kubecross"
 )
 
@@ -37,7 +39,7 @@ const (
 
 const branchAnnouncement = `Kubernetes Community,
 <p>
-Kubernetes' %s branch has been created.
+%s' %s branch has been created.
 <p>
 The release owner will be sending updates on how to interact with this branch
 shortly. The <a href=https://git.k8s.io/community/contributors/devel/sig-release/cherry-picks.md>Cherrypick
@@ -50,7 +52,7 @@ Managers</A>.
 
 const releaseAnnouncement = `Kubernetes Community,
 <p>
-Kubernetes <b>%s</b> has been built and pushed using Golang version <b>%s</b>.
+%s <b>%s</b> has been built and pushed using Golang version <b>%s</b>.%s
 <p>
 The release notes have been updated in
 <a href=https://git.k8s.io/kubernetes/%s>%s</a>, with a pointer to them on
@@ -75,10 +77,11 @@ func CreateForBranch(opts *Options) error {
 		opts.branch, opts.workDir,
 	)
 
+	product := branding.Default().ProductName
 	if err := create(
 		opts.workDir,
-		fmt.Sprintf("Kubernetes %s branch has been created", opts.branch),
-		fmt.Sprintf(branchAnnouncement, opts.branch),
+		fmt.Sprintf("%s %s branch has been created", product, opts.branch),
+		fmt.Sprintf(branchAnnouncement, product, opts.branch),
 	); err != nil {
 		return fmt.Errorf("creating branch announcement: %w", err)
 	}
@@ -116,11 +119,22 @@ func CreateForRelease(opts *Options) error {
 	}
 	logrus.Infof("Found the following Go version: %s", goVersion)
 
+	product := branding.Default().ProductName
+	subject := fmt.Sprintf("%s %s is live!", product, opts.tag)
+	partialNote := ""
+	if opts.components.Partial() {
+		subject = fmt.Sprintf("%s %s (%s) is live!", product, opts.tag, opts.components)
+		partialNote = fmt.Sprintf(
+			"\n<p>\nThis is a partial release, which only contains: <b>%s</b>.",
+			opts.components,
+		)
+	}
+
 	if err := create(
 		opts.workDir,
-		fmt.Sprintf("Kubernetes %s is live!", opts.tag),
+		subject,
 		fmt.Sprintf(releaseAnnouncement,
-			opts.tag, goVersion, opts.changelogPath,
+			product, opts.tag, goVersion, partialNote, opts.changelogPath,
 			filepath.Base(opts.changelogPath), opts.tag, changelog,
 			opts.changelogPath, filepath.Base(opts.changelogPath), opts.tag,
 		),
@@ -151,7 +165,7 @@ func create(workDir, subject, message string) error {
 	//nolint:gosec // TODO(gosec): G306: Expect WriteFile permissions to be
 	// 0600 or less
 	if err := os.WriteFile(
-		announcementFile, []byte(message), 0o755,
+		announcementFile, []byte(imagerewrite.Apply(message)), 0o755,
 	); err != nil {
 		return fmt.Errorf(
 			"writing announcement to file %s: %w",
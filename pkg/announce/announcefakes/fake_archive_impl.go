@@ -0,0 +1,209 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package announcefakes
+
+import (
+	"sync"
+)
+
+type FakeArchiveImpl struct {
+	ReadObjectStub        func(string) ([]byte, error)
+	readObjectMutex       sync.RWMutex
+	readObjectArgsForCall []struct {
+		arg1 string
+	}
+	readObjectReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readObjectReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	WriteObjectStub        func(string, []byte) error
+	writeObjectMutex       sync.RWMutex
+	writeObjectArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	writeObjectReturns struct {
+		result1 error
+	}
+	writeObjectReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeArchiveImpl) ReadObject(arg1 string) ([]byte, error) {
+	fake.readObjectMutex.Lock()
+	ret, specificReturn := fake.readObjectReturnsOnCall[len(fake.readObjectArgsForCall)]
+	fake.readObjectArgsForCall = append(fake.readObjectArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReadObjectStub
+	fakeReturns := fake.readObjectReturns
+	fake.recordInvocation("ReadObject", []interface{}{arg1})
+	fake.readObjectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArchiveImpl) ReadObjectCallCount() int {
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
+	return len(fake.readObjectArgsForCall)
+}
+
+func (fake *FakeArchiveImpl) ReadObjectCalls(stub func(string) ([]byte, error)) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = stub
+}
+
+func (fake *FakeArchiveImpl) ReadObjectArgsForCall(i int) string {
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
+	argsForCall := fake.readObjectArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeArchiveImpl) ReadObjectReturns(result1 []byte, result2 error) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = nil
+	fake.readObjectReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArchiveImpl) ReadObjectReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = nil
+	if fake.readObjectReturnsOnCall == nil {
+		fake.readObjectReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readObjectReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArchiveImpl) WriteObject(arg1 string, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeObjectMutex.Lock()
+	ret, specificReturn := fake.writeObjectReturnsOnCall[len(fake.writeObjectArgsForCall)]
+	fake.writeObjectArgsForCall = append(fake.writeObjectArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.WriteObjectStub
+	fakeReturns := fake.writeObjectReturns
+	fake.recordInvocation("WriteObject", []interface{}{arg1, arg2Copy})
+	fake.writeObjectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeArchiveImpl) WriteObjectCallCount() int {
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
+	return len(fake.writeObjectArgsForCall)
+}
+
+func (fake *FakeArchiveImpl) WriteObjectCalls(stub func(string, []byte) error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = stub
+}
+
+func (fake *FakeArchiveImpl) WriteObjectArgsForCall(i int) (string, []byte) {
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
+	argsForCall := fake.writeObjectArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeArchiveImpl) WriteObjectReturns(result1 error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = nil
+	fake.writeObjectReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeArchiveImpl) WriteObjectReturnsOnCall(i int, result1 error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = nil
+	if fake.writeObjectReturnsOnCall == nil {
+		fake.writeObjectReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeObjectReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeArchiveImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeArchiveImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,857 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package announcefakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v58/github"
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/ghperms"
+)
+
+type FakeEmbargoImpl struct {
+	CheckPermissionsStub        func(string, string, string, ...ghperms.Permission) error
+	checkPermissionsMutex       sync.RWMutex
+	checkPermissionsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 []ghperms.Permission
+	}
+	checkPermissionsReturns struct {
+		result1 error
+	}
+	checkPermissionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FetchAnnouncementStub        func(string) (string, error)
+	fetchAnnouncementMutex       sync.RWMutex
+	fetchAnnouncementArgsForCall []struct {
+		arg1 string
+	}
+	fetchAnnouncementReturns struct {
+		result1 string
+		result2 error
+	}
+	fetchAnnouncementReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetReleaseStub        func(string, string, string) (*github.RepositoryRelease, error)
+	getReleaseMutex       sync.RWMutex
+	getReleaseArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getReleaseReturns struct {
+		result1 *github.RepositoryRelease
+		result2 error
+	}
+	getReleaseReturnsOnCall map[int]struct {
+		result1 *github.RepositoryRelease
+		result2 error
+	}
+	NotifyStub        func(string, string) error
+	notifyMutex       sync.RWMutex
+	notifyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	notifyReturns struct {
+		result1 error
+	}
+	notifyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	NowStub        func() time.Time
+	nowMutex       sync.RWMutex
+	nowArgsForCall []struct {
+	}
+	nowReturns struct {
+		result1 time.Time
+	}
+	nowReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	PostSocialStub        func(*announce.SocialOptions) error
+	postSocialMutex       sync.RWMutex
+	postSocialArgsForCall []struct {
+		arg1 *announce.SocialOptions
+	}
+	postSocialReturns struct {
+		result1 error
+	}
+	postSocialReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PublishReleaseStub        func(string, string, *github.RepositoryRelease) error
+	publishReleaseMutex       sync.RWMutex
+	publishReleaseArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *github.RepositoryRelease
+	}
+	publishReleaseReturns struct {
+		result1 error
+	}
+	publishReleaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PublishVersionMarkersStub        func(string) error
+	publishVersionMarkersMutex       sync.RWMutex
+	publishVersionMarkersArgsForCall []struct {
+		arg1 string
+	}
+	publishVersionMarkersReturns struct {
+		result1 error
+	}
+	publishVersionMarkersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RenderSocialStub        func(*announce.SocialOptions) (*announce.SocialPost, error)
+	renderSocialMutex       sync.RWMutex
+	renderSocialArgsForCall []struct {
+		arg1 *announce.SocialOptions
+	}
+	renderSocialReturns struct {
+		result1 *announce.SocialPost
+		result2 error
+	}
+	renderSocialReturnsOnCall map[int]struct {
+		result1 *announce.SocialPost
+		result2 error
+	}
+	SendAnnouncementStub        func(*announce.SendOptions, string) error
+	sendAnnouncementMutex       sync.RWMutex
+	sendAnnouncementArgsForCall []struct {
+		arg1 *announce.SendOptions
+		arg2 string
+	}
+	sendAnnouncementReturns struct {
+		result1 error
+	}
+	sendAnnouncementReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SleepStub        func(time.Duration)
+	sleepMutex       sync.RWMutex
+	sleepArgsForCall []struct {
+		arg1 time.Duration
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeEmbargoImpl) CheckPermissions(arg1 string, arg2 string, arg3 string, arg4 ...ghperms.Permission) error {
+	fake.checkPermissionsMutex.Lock()
+	ret, specificReturn := fake.checkPermissionsReturnsOnCall[len(fake.checkPermissionsArgsForCall)]
+	fake.checkPermissionsArgsForCall = append(fake.checkPermissionsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 []ghperms.Permission
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CheckPermissionsStub
+	fakeReturns := fake.checkPermissionsReturns
+	fake.recordInvocation("CheckPermissions", []interface{}{arg1, arg2, arg3, arg4})
+	fake.checkPermissionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeEmbargoImpl) CheckPermissionsCallCount() int {
+	fake.checkPermissionsMutex.RLock()
+	defer fake.checkPermissionsMutex.RUnlock()
+	return len(fake.checkPermissionsArgsForCall)
+}
+
+func (fake *FakeEmbargoImpl) CheckPermissionsCalls(stub func(string, string, string, ...ghperms.Permission) error) {
+	fake.checkPermissionsMutex.Lock()
+	defer fake.checkPermissionsMutex.Unlock()
+	fake.CheckPermissionsStub = stub
+}
+
+func (fake *FakeEmbargoImpl) CheckPermissionsArgsForCall(i int) (string, string, string, []ghperms.Permission) {
+	fake.checkPermissionsMutex.RLock()
+	defer fake.checkPermissionsMutex.RUnlock()
+	argsForCall := fake.checkPermissionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeEmbargoImpl) CheckPermissionsReturns(result1 error) {
+	fake.checkPermissionsMutex.Lock()
+	defer fake.checkPermissionsMutex.Unlock()
+	fake.CheckPermissionsStub = nil
+	fake.checkPermissionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) CheckPermissionsReturnsOnCall(i int, result1 error) {
+	fake.checkPermissionsMutex.Lock()
+	defer fake.checkPermissionsMutex.Unlock()
+	fake.CheckPermissionsStub = nil
+	if fake.checkPermissionsReturnsOnCall == nil {
+		fake.checkPermissionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkPermissionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) FetchAnnouncement(arg1 string) (string, error) {
+	fake.fetchAnnouncementMutex.Lock()
+	ret, specificReturn := fake.fetchAnnouncementReturnsOnCall[len(fake.fetchAnnouncementArgsForCall)]
+	fake.fetchAnnouncementArgsForCall = append(fake.fetchAnnouncementArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FetchAnnouncementStub
+	fakeReturns := fake.fetchAnnouncementReturns
+	fake.recordInvocation("FetchAnnouncement", []interface{}{arg1})
+	fake.fetchAnnouncementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEmbargoImpl) FetchAnnouncementCallCount() int {
+	fake.fetchAnnouncementMutex.RLock()
+	defer fake.fetchAnnouncementMutex.RUnlock()
+	return len(fake.fetchAnnouncementArgsForCall)
+}
+
+func (fake *FakeEmbargoImpl) FetchAnnouncementCalls(stub func(string) (string, error)) {
+	fake.fetchAnnouncementMutex.Lock()
+	defer fake.fetchAnnouncementMutex.Unlock()
+	fake.FetchAnnouncementStub = stub
+}
+
+func (fake *FakeEmbargoImpl) FetchAnnouncementArgsForCall(i int) string {
+	fake.fetchAnnouncementMutex.RLock()
+	defer fake.fetchAnnouncementMutex.RUnlock()
+	argsForCall := fake.fetchAnnouncementArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeEmbargoImpl) FetchAnnouncementReturns(result1 string, result2 error) {
+	fake.fetchAnnouncementMutex.Lock()
+	defer fake.fetchAnnouncementMutex.Unlock()
+	fake.FetchAnnouncementStub = nil
+	fake.fetchAnnouncementReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEmbargoImpl) FetchAnnouncementReturnsOnCall(i int, result1 string, result2 error) {
+	fake.fetchAnnouncementMutex.Lock()
+	defer fake.fetchAnnouncementMutex.Unlock()
+	fake.FetchAnnouncementStub = nil
+	if fake.fetchAnnouncementReturnsOnCall == nil {
+		fake.fetchAnnouncementReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.fetchAnnouncementReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEmbargoImpl) GetRelease(arg1 string, arg2 string, arg3 string) (*github.RepositoryRelease, error) {
+	fake.getReleaseMutex.Lock()
+	ret, specificReturn := fake.getReleaseReturnsOnCall[len(fake.getReleaseArgsForCall)]
+	fake.getReleaseArgsForCall = append(fake.getReleaseArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetReleaseStub
+	fakeReturns := fake.getReleaseReturns
+	fake.recordInvocation("GetRelease", []interface{}{arg1, arg2, arg3})
+	fake.getReleaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEmbargoImpl) GetReleaseCallCount() int {
+	fake.getReleaseMutex.RLock()
+	defer fake.getReleaseMutex.RUnlock()
+	return len(fake.getReleaseArgsForCall)
+}
+
+func (fake *FakeEmbargoImpl) GetReleaseCalls(stub func(string, string, string) (*github.RepositoryRelease, error)) {
+	fake.getReleaseMutex.Lock()
+	defer fake.getReleaseMutex.Unlock()
+	fake.GetReleaseStub = stub
+}
+
+func (fake *FakeEmbargoImpl) GetReleaseArgsForCall(i int) (string, string, string) {
+	fake.getReleaseMutex.RLock()
+	defer fake.getReleaseMutex.RUnlock()
+	argsForCall := fake.getReleaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeEmbargoImpl) GetReleaseReturns(result1 *github.RepositoryRelease, result2 error) {
+	fake.getReleaseMutex.Lock()
+	defer fake.getReleaseMutex.Unlock()
+	fake.GetReleaseStub = nil
+	fake.getReleaseReturns = struct {
+		result1 *github.RepositoryRelease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEmbargoImpl) GetReleaseReturnsOnCall(i int, result1 *github.RepositoryRelease, result2 error) {
+	fake.getReleaseMutex.Lock()
+	defer fake.getReleaseMutex.Unlock()
+	fake.GetReleaseStub = nil
+	if fake.getReleaseReturnsOnCall == nil {
+		fake.getReleaseReturnsOnCall = make(map[int]struct {
+			result1 *github.RepositoryRelease
+			result2 error
+		})
+	}
+	fake.getReleaseReturnsOnCall[i] = struct {
+		result1 *github.RepositoryRelease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEmbargoImpl) Notify(arg1 string, arg2 string) error {
+	fake.notifyMutex.Lock()
+	ret, specificReturn := fake.notifyReturnsOnCall[len(fake.notifyArgsForCall)]
+	fake.notifyArgsForCall = append(fake.notifyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NotifyStub
+	fakeReturns := fake.notifyReturns
+	fake.recordInvocation("Notify", []interface{}{arg1, arg2})
+	fake.notifyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeEmbargoImpl) NotifyCallCount() int {
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	return len(fake.notifyArgsForCall)
+}
+
+func (fake *FakeEmbargoImpl) NotifyCalls(stub func(string, string) error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = stub
+}
+
+func (fake *FakeEmbargoImpl) NotifyArgsForCall(i int) (string, string) {
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	argsForCall := fake.notifyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeEmbargoImpl) NotifyReturns(result1 error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = nil
+	fake.notifyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) NotifyReturnsOnCall(i int, result1 error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = nil
+	if fake.notifyReturnsOnCall == nil {
+		fake.notifyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.notifyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) Now() time.Time {
+	fake.nowMutex.Lock()
+	ret, specificReturn := fake.nowReturnsOnCall[len(fake.nowArgsForCall)]
+	fake.nowArgsForCall = append(fake.nowArgsForCall, struct {
+	}{})
+	stub := fake.NowStub
+	fakeReturns := fake.nowReturns
+	fake.recordInvocation("Now", []interface{}{})
+	fake.nowMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeEmbargoImpl) NowCallCount() int {
+	fake.nowMutex.RLock()
+	defer fake.nowMutex.RUnlock()
+	return len(fake.nowArgsForCall)
+}
+
+func (fake *FakeEmbargoImpl) NowCalls(stub func() time.Time) {
+	fake.nowMutex.Lock()
+	defer fake.nowMutex.Unlock()
+	fake.NowStub = stub
+}
+
+func (fake *FakeEmbargoImpl) NowReturns(result1 time.Time) {
+	fake.nowMutex.Lock()
+	defer fake.nowMutex.Unlock()
+	fake.NowStub = nil
+	fake.nowReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) NowReturnsOnCall(i int, result1 time.Time) {
+	fake.nowMutex.Lock()
+	defer fake.nowMutex.Unlock()
+	fake.NowStub = nil
+	if fake.nowReturnsOnCall == nil {
+		fake.nowReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.nowReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) PostSocial(arg1 *announce.SocialOptions) error {
+	fake.postSocialMutex.Lock()
+	ret, specificReturn := fake.postSocialReturnsOnCall[len(fake.postSocialArgsForCall)]
+	fake.postSocialArgsForCall = append(fake.postSocialArgsForCall, struct {
+		arg1 *announce.SocialOptions
+	}{arg1})
+	stub := fake.PostSocialStub
+	fakeReturns := fake.postSocialReturns
+	fake.recordInvocation("PostSocial", []interface{}{arg1})
+	fake.postSocialMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeEmbargoImpl) PostSocialCallCount() int {
+	fake.postSocialMutex.RLock()
+	defer fake.postSocialMutex.RUnlock()
+	return len(fake.postSocialArgsForCall)
+}
+
+func (fake *FakeEmbargoImpl) PostSocialCalls(stub func(*announce.SocialOptions) error) {
+	fake.postSocialMutex.Lock()
+	defer fake.postSocialMutex.Unlock()
+	fake.PostSocialStub = stub
+}
+
+func (fake *FakeEmbargoImpl) PostSocialArgsForCall(i int) *announce.SocialOptions {
+	fake.postSocialMutex.RLock()
+	defer fake.postSocialMutex.RUnlock()
+	argsForCall := fake.postSocialArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeEmbargoImpl) PostSocialReturns(result1 error) {
+	fake.postSocialMutex.Lock()
+	defer fake.postSocialMutex.Unlock()
+	fake.PostSocialStub = nil
+	fake.postSocialReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) PostSocialReturnsOnCall(i int, result1 error) {
+	fake.postSocialMutex.Lock()
+	defer fake.postSocialMutex.Unlock()
+	fake.PostSocialStub = nil
+	if fake.postSocialReturnsOnCall == nil {
+		fake.postSocialReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.postSocialReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) PublishRelease(arg1 string, arg2 string, arg3 *github.RepositoryRelease) error {
+	fake.publishReleaseMutex.Lock()
+	ret, specificReturn := fake.publishReleaseReturnsOnCall[len(fake.publishReleaseArgsForCall)]
+	fake.publishReleaseArgsForCall = append(fake.publishReleaseArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *github.RepositoryRelease
+	}{arg1, arg2, arg3})
+	stub := fake.PublishReleaseStub
+	fakeReturns := fake.publishReleaseReturns
+	fake.recordInvocation("PublishRelease", []interface{}{arg1, arg2, arg3})
+	fake.publishReleaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeEmbargoImpl) PublishReleaseCallCount() int {
+	fake.publishReleaseMutex.RLock()
+	defer fake.publishReleaseMutex.RUnlock()
+	return len(fake.publishReleaseArgsForCall)
+}
+
+func (fake *FakeEmbargoImpl) PublishReleaseCalls(stub func(string, string, *github.RepositoryRelease) error) {
+	fake.publishReleaseMutex.Lock()
+	defer fake.publishReleaseMutex.Unlock()
+	fake.PublishReleaseStub = stub
+}
+
+func (fake *FakeEmbargoImpl) PublishReleaseArgsForCall(i int) (string, string, *github.RepositoryRelease) {
+	fake.publishReleaseMutex.RLock()
+	defer fake.publishReleaseMutex.RUnlock()
+	argsForCall := fake.publishReleaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeEmbargoImpl) PublishReleaseReturns(result1 error) {
+	fake.publishReleaseMutex.Lock()
+	defer fake.publishReleaseMutex.Unlock()
+	fake.PublishReleaseStub = nil
+	fake.publishReleaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) PublishReleaseReturnsOnCall(i int, result1 error) {
+	fake.publishReleaseMutex.Lock()
+	defer fake.publishReleaseMutex.Unlock()
+	fake.PublishReleaseStub = nil
+	if fake.publishReleaseReturnsOnCall == nil {
+		fake.publishReleaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.publishReleaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) PublishVersionMarkers(arg1 string) error {
+	fake.publishVersionMarkersMutex.Lock()
+	ret, specificReturn := fake.publishVersionMarkersReturnsOnCall[len(fake.publishVersionMarkersArgsForCall)]
+	fake.publishVersionMarkersArgsForCall = append(fake.publishVersionMarkersArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.PublishVersionMarkersStub
+	fakeReturns := fake.publishVersionMarkersReturns
+	fake.recordInvocation("PublishVersionMarkers", []interface{}{arg1})
+	fake.publishVersionMarkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeEmbargoImpl) PublishVersionMarkersCallCount() int {
+	fake.publishVersionMarkersMutex.RLock()
+	defer fake.publishVersionMarkersMutex.RUnlock()
+	return len(fake.publishVersionMarkersArgsForCall)
+}
+
+func (fake *FakeEmbargoImpl) PublishVersionMarkersCalls(stub func(string) error) {
+	fake.publishVersionMarkersMutex.Lock()
+	defer fake.publishVersionMarkersMutex.Unlock()
+	fake.PublishVersionMarkersStub = stub
+}
+
+func (fake *FakeEmbargoImpl) PublishVersionMarkersArgsForCall(i int) string {
+	fake.publishVersionMarkersMutex.RLock()
+	defer fake.publishVersionMarkersMutex.RUnlock()
+	argsForCall := fake.publishVersionMarkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeEmbargoImpl) PublishVersionMarkersReturns(result1 error) {
+	fake.publishVersionMarkersMutex.Lock()
+	defer fake.publishVersionMarkersMutex.Unlock()
+	fake.PublishVersionMarkersStub = nil
+	fake.publishVersionMarkersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) PublishVersionMarkersReturnsOnCall(i int, result1 error) {
+	fake.publishVersionMarkersMutex.Lock()
+	defer fake.publishVersionMarkersMutex.Unlock()
+	fake.PublishVersionMarkersStub = nil
+	if fake.publishVersionMarkersReturnsOnCall == nil {
+		fake.publishVersionMarkersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.publishVersionMarkersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) RenderSocial(arg1 *announce.SocialOptions) (*announce.SocialPost, error) {
+	fake.renderSocialMutex.Lock()
+	ret, specificReturn := fake.renderSocialReturnsOnCall[len(fake.renderSocialArgsForCall)]
+	fake.renderSocialArgsForCall = append(fake.renderSocialArgsForCall, struct {
+		arg1 *announce.SocialOptions
+	}{arg1})
+	stub := fake.RenderSocialStub
+	fakeReturns := fake.renderSocialReturns
+	fake.recordInvocation("RenderSocial", []interface{}{arg1})
+	fake.renderSocialMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEmbargoImpl) RenderSocialCallCount() int {
+	fake.renderSocialMutex.RLock()
+	defer fake.renderSocialMutex.RUnlock()
+	return len(fake.renderSocialArgsForCall)
+}
+
+func (fake *FakeEmbargoImpl) RenderSocialCalls(stub func(*announce.SocialOptions) (*announce.SocialPost, error)) {
+	fake.renderSocialMutex.Lock()
+	defer fake.renderSocialMutex.Unlock()
+	fake.RenderSocialStub = stub
+}
+
+func (fake *FakeEmbargoImpl) RenderSocialArgsForCall(i int) *announce.SocialOptions {
+	fake.renderSocialMutex.RLock()
+	defer fake.renderSocialMutex.RUnlock()
+	argsForCall := fake.renderSocialArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeEmbargoImpl) RenderSocialReturns(result1 *announce.SocialPost, result2 error) {
+	fake.renderSocialMutex.Lock()
+	defer fake.renderSocialMutex.Unlock()
+	fake.RenderSocialStub = nil
+	fake.renderSocialReturns = struct {
+		result1 *announce.SocialPost
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEmbargoImpl) RenderSocialReturnsOnCall(i int, result1 *announce.SocialPost, result2 error) {
+	fake.renderSocialMutex.Lock()
+	defer fake.renderSocialMutex.Unlock()
+	fake.RenderSocialStub = nil
+	if fake.renderSocialReturnsOnCall == nil {
+		fake.renderSocialReturnsOnCall = make(map[int]struct {
+			result1 *announce.SocialPost
+			result2 error
+		})
+	}
+	fake.renderSocialReturnsOnCall[i] = struct {
+		result1 *announce.SocialPost
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEmbargoImpl) SendAnnouncement(arg1 *announce.SendOptions, arg2 string) error {
+	fake.sendAnnouncementMutex.Lock()
+	ret, specificReturn := fake.sendAnnouncementReturnsOnCall[len(fake.sendAnnouncementArgsForCall)]
+	fake.sendAnnouncementArgsForCall = append(fake.sendAnnouncementArgsForCall, struct {
+		arg1 *announce.SendOptions
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SendAnnouncementStub
+	fakeReturns := fake.sendAnnouncementReturns
+	fake.recordInvocation("SendAnnouncement", []interface{}{arg1, arg2})
+	fake.sendAnnouncementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeEmbargoImpl) SendAnnouncementCallCount() int {
+	fake.sendAnnouncementMutex.RLock()
+	defer fake.sendAnnouncementMutex.RUnlock()
+	return len(fake.sendAnnouncementArgsForCall)
+}
+
+func (fake *FakeEmbargoImpl) SendAnnouncementCalls(stub func(*announce.SendOptions, string) error) {
+	fake.sendAnnouncementMutex.Lock()
+	defer fake.sendAnnouncementMutex.Unlock()
+	fake.SendAnnouncementStub = stub
+}
+
+func (fake *FakeEmbargoImpl) SendAnnouncementArgsForCall(i int) (*announce.SendOptions, string) {
+	fake.sendAnnouncementMutex.RLock()
+	defer fake.sendAnnouncementMutex.RUnlock()
+	argsForCall := fake.sendAnnouncementArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeEmbargoImpl) SendAnnouncementReturns(result1 error) {
+	fake.sendAnnouncementMutex.Lock()
+	defer fake.sendAnnouncementMutex.Unlock()
+	fake.SendAnnouncementStub = nil
+	fake.sendAnnouncementReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) SendAnnouncementReturnsOnCall(i int, result1 error) {
+	fake.sendAnnouncementMutex.Lock()
+	defer fake.sendAnnouncementMutex.Unlock()
+	fake.SendAnnouncementStub = nil
+	if fake.sendAnnouncementReturnsOnCall == nil {
+		fake.sendAnnouncementReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.sendAnnouncementReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEmbargoImpl) Sleep(arg1 time.Duration) {
+	fake.sleepMutex.Lock()
+	fake.sleepArgsForCall = append(fake.sleepArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.SleepStub
+	fake.recordInvocation("Sleep", []interface{}{arg1})
+	fake.sleepMutex.Unlock()
+	if stub != nil {
+		fake.SleepStub(arg1)
+	}
+}
+
+func (fake *FakeEmbargoImpl) SleepCallCount() int {
+	fake.sleepMutex.RLock()
+	defer fake.sleepMutex.RUnlock()
+	return len(fake.sleepArgsForCall)
+}
+
+func (fake *FakeEmbargoImpl) SleepCalls(stub func(time.Duration)) {
+	fake.sleepMutex.Lock()
+	defer fake.sleepMutex.Unlock()
+	fake.SleepStub = stub
+}
+
+func (fake *FakeEmbargoImpl) SleepArgsForCall(i int) time.Duration {
+	fake.sleepMutex.RLock()
+	defer fake.sleepMutex.RUnlock()
+	argsForCall := fake.sleepArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeEmbargoImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.checkPermissionsMutex.RLock()
+	defer fake.checkPermissionsMutex.RUnlock()
+	fake.fetchAnnouncementMutex.RLock()
+	defer fake.fetchAnnouncementMutex.RUnlock()
+	fake.getReleaseMutex.RLock()
+	defer fake.getReleaseMutex.RUnlock()
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	fake.nowMutex.RLock()
+	defer fake.nowMutex.RUnlock()
+	fake.postSocialMutex.RLock()
+	defer fake.postSocialMutex.RUnlock()
+	fake.publishReleaseMutex.RLock()
+	defer fake.publishReleaseMutex.RUnlock()
+	fake.publishVersionMarkersMutex.RLock()
+	defer fake.publishVersionMarkersMutex.RUnlock()
+	fake.renderSocialMutex.RLock()
+	defer fake.renderSocialMutex.RUnlock()
+	fake.sendAnnouncementMutex.RLock()
+	defer fake.sendAnnouncementMutex.RUnlock()
+	fake.sleepMutex.RLock()
+	defer fake.sleepMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeEmbargoImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,286 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package announcefakes
+
+import (
+	"sync"
+)
+
+type FakeVerifyImpl struct {
+	GetStub        func(string) (string, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 string
+	}
+	getReturns struct {
+		result1 string
+		result2 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ImageDigestStub        func(string) (string, error)
+	imageDigestMutex       sync.RWMutex
+	imageDigestArgsForCall []struct {
+		arg1 string
+	}
+	imageDigestReturns struct {
+		result1 string
+		result2 error
+	}
+	imageDigestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	StatusCodeStub        func(string) (int, error)
+	statusCodeMutex       sync.RWMutex
+	statusCodeArgsForCall []struct {
+		arg1 string
+	}
+	statusCodeReturns struct {
+		result1 int
+		result2 error
+	}
+	statusCodeReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeVerifyImpl) Get(arg1 string) (string, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetStub
+	fakeReturns := fake.getReturns
+	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.getMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVerifyImpl) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeVerifyImpl) GetCalls(stub func(string) (string, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *FakeVerifyImpl) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVerifyImpl) GetReturns(result1 string, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVerifyImpl) GetReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVerifyImpl) ImageDigest(arg1 string) (string, error) {
+	fake.imageDigestMutex.Lock()
+	ret, specificReturn := fake.imageDigestReturnsOnCall[len(fake.imageDigestArgsForCall)]
+	fake.imageDigestArgsForCall = append(fake.imageDigestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ImageDigestStub
+	fakeReturns := fake.imageDigestReturns
+	fake.recordInvocation("ImageDigest", []interface{}{arg1})
+	fake.imageDigestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVerifyImpl) ImageDigestCallCount() int {
+	fake.imageDigestMutex.RLock()
+	defer fake.imageDigestMutex.RUnlock()
+	return len(fake.imageDigestArgsForCall)
+}
+
+func (fake *FakeVerifyImpl) ImageDigestCalls(stub func(string) (string, error)) {
+	fake.imageDigestMutex.Lock()
+	defer fake.imageDigestMutex.Unlock()
+	fake.ImageDigestStub = stub
+}
+
+func (fake *FakeVerifyImpl) ImageDigestArgsForCall(i int) string {
+	fake.imageDigestMutex.RLock()
+	defer fake.imageDigestMutex.RUnlock()
+	argsForCall := fake.imageDigestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVerifyImpl) ImageDigestReturns(result1 string, result2 error) {
+	fake.imageDigestMutex.Lock()
+	defer fake.imageDigestMutex.Unlock()
+	fake.ImageDigestStub = nil
+	fake.imageDigestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVerifyImpl) ImageDigestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.imageDigestMutex.Lock()
+	defer fake.imageDigestMutex.Unlock()
+	fake.ImageDigestStub = nil
+	if fake.imageDigestReturnsOnCall == nil {
+		fake.imageDigestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.imageDigestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVerifyImpl) StatusCode(arg1 string) (int, error) {
+	fake.statusCodeMutex.Lock()
+	ret, specificReturn := fake.statusCodeReturnsOnCall[len(fake.statusCodeArgsForCall)]
+	fake.statusCodeArgsForCall = append(fake.statusCodeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.StatusCodeStub
+	fakeReturns := fake.statusCodeReturns
+	fake.recordInvocation("StatusCode", []interface{}{arg1})
+	fake.statusCodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVerifyImpl) StatusCodeCallCount() int {
+	fake.statusCodeMutex.RLock()
+	defer fake.statusCodeMutex.RUnlock()
+	return len(fake.statusCodeArgsForCall)
+}
+
+func (fake *FakeVerifyImpl) StatusCodeCalls(stub func(string) (int, error)) {
+	fake.statusCodeMutex.Lock()
+	defer fake.statusCodeMutex.Unlock()
+	fake.StatusCodeStub = stub
+}
+
+func (fake *FakeVerifyImpl) StatusCodeArgsForCall(i int) string {
+	fake.statusCodeMutex.RLock()
+	defer fake.statusCodeMutex.RUnlock()
+	argsForCall := fake.statusCodeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVerifyImpl) StatusCodeReturns(result1 int, result2 error) {
+	fake.statusCodeMutex.Lock()
+	defer fake.statusCodeMutex.Unlock()
+	fake.StatusCodeStub = nil
+	fake.statusCodeReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVerifyImpl) StatusCodeReturnsOnCall(i int, result1 int, result2 error) {
+	fake.statusCodeMutex.Lock()
+	defer fake.statusCodeMutex.Unlock()
+	fake.StatusCodeStub = nil
+	if fake.statusCodeReturnsOnCall == nil {
+		fake.statusCodeReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.statusCodeReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVerifyImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	fake.imageDigestMutex.RLock()
+	defer fake.imageDigestMutex.RUnlock()
+	fake.statusCodeMutex.RLock()
+	defer fake.statusCodeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeVerifyImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-sdk/object"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/gcp/gcs"
+	"k8s.io/release/pkg/release"
+)
+
+const (
+	// ArchiveDir is the bucket directory of the announcement archive.
+	ArchiveDir = "announcements"
+
+	// archiveIndexJSON contains the entries of the archive.
+	archiveIndexJSON = "index.json"
+
+	// archiveIndexHTML is the rendered index page of the archive.
+	archiveIndexHTML = "index.html"
+)
+
+const archivePageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Subject }}</title>
+</head>
+<body>
+<h1>{{ .Subject }}</h1>
+<p>Sent on {{ .Date.Format "2006-01-02" }}</p>
+<hr>
+{{ .Content }}
+<hr>
+<p><a href="` + archiveIndexHTML + `">All announcements</a></p>
+</body>
+</html>
+`
+
+const archiveIndexTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Release announcements</title>
+</head>
+<body>
+<h1>Release announcements</h1>
+<ul>
+{{- range . }}
+<li><a href="{{ .File }}">{{ .Subject }}</a> ({{ .Date.Format "2006-01-02" }})</li>
+{{- end }}
+</ul>
+</body>
+</html>
+`
+
+// ArchiveOptions are the settings for archiving sent announcements.
+type ArchiveOptions struct {
+	// Bucket is the bucket containing the archive below ArchiveDir.
+	Bucket string
+}
+
+// DefaultArchiveOptions returns the archive options of the production
+// bucket, or of the test bucket for mock runs.
+func DefaultArchiveOptions(noMock bool) *ArchiveOptions {
+	if noMock {
+		return &ArchiveOptions{Bucket: release.ProductionBucket}
+	}
+	return &ArchiveOptions{Bucket: release.TestBucket}
+}
+
+// Validate checks if the options are correctly set.
+func (o *ArchiveOptions) Validate() error {
+	if o.Bucket == "" {
+		return fmt.Errorf("cannot archive announcement: %w", ErrMissingArchiveBucket)
+	}
+	return nil
+}
+
+// ArchiveEntry is a single announcement in the archive.
+type ArchiveEntry struct {
+	// Tag is the announced release tag.
+	Tag string `json:"tag"`
+
+	// Subject is the subject of the announcement mail.
+	Subject string `json:"subject"`
+
+	// Date is the time the announcement got sent.
+	Date time.Time `json:"date"`
+
+	// File is the name of the rendered announcement in the archive.
+	File string `json:"file"`
+}
+
+// Archiver publishes sent announcements as HTML pages along with an index
+// page, which keeps them linkable for people not on the mailing lists.
+type Archiver struct {
+	impl    archiveImpl
+	options *ArchiveOptions
+}
+
+// NewArchiver returns a new Archiver instance.
+func NewArchiver(options *ArchiveOptions) *Archiver {
+	return &Archiver{&defaultArchiveImpl{}, options}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (a *Archiver) SetImpl(impl archiveImpl) {
+	a.impl = impl
+}
+
+// Archive renders the announcement content, pushes it to the archive and
+// updates the index page. It returns the public URL of the archived
+// announcement. Archiving a tag again replaces its previous entry.
+func (a *Archiver) Archive(tag, subject, content string) (string, error) {
+	if err := a.options.Validate(); err != nil {
+		return "", fmt.Errorf("validating archive options: %w", err)
+	}
+	tag = util.AddTagPrefix(tag)
+	entry := ArchiveEntry{
+		Tag:     tag,
+		Subject: subject,
+		Date:    time.Now().UTC(),
+		File:    tag + ".html",
+	}
+
+	page := &bytes.Buffer{}
+	if err := template.Must(template.New("page").Parse(archivePageTemplate)).Execute(page, struct {
+		ArchiveEntry
+		Content template.HTML
+	}{entry, template.HTML(content)}); err != nil { //nolint:gosec // the announcement is HTML built by us
+		return "", fmt.Errorf("render announcement: %w", err)
+	}
+
+	entries, err := a.entries()
+	if err != nil {
+		return "", fmt.Errorf("read archive index: %w", err)
+	}
+	entries = append(entries, entry)
+	for i := 0; i < len(entries)-1; i++ {
+		if entries[i].Tag == tag {
+			entries = append(entries[:i], entries[i+1:]...)
+			break
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Date.After(entries[j].Date)
+	})
+
+	index := &bytes.Buffer{}
+	if err := template.Must(template.New("index").Parse(archiveIndexTemplate)).Execute(index, entries); err != nil {
+		return "", fmt.Errorf("render archive index: %w", err)
+	}
+	indexJSON, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal archive index: %w", err)
+	}
+
+	for file, content := range map[string][]byte{
+		entry.File:       page.Bytes(),
+		archiveIndexJSON: indexJSON,
+		archiveIndexHTML: index.Bytes(),
+	} {
+		if err := a.impl.WriteObject(a.path(file), content); err != nil {
+			return "", fmt.Errorf("write %s to archive: %w", file, err)
+		}
+	}
+
+	u := fmt.Sprintf("%s/%s/%s", release.URLPrefixForBucket(a.options.Bucket), ArchiveDir, entry.File)
+	logrus.Infof("Archived announcement as %s", u)
+	return u, nil
+}
+
+// entries returns the current entries of the archive.
+func (a *Archiver) entries() ([]ArchiveEntry, error) {
+	content, err := a.impl.ReadObject(a.path(archiveIndexJSON))
+	if err != nil {
+		return nil, err
+	}
+	entries := []ArchiveEntry{}
+	if content == nil {
+		return entries, nil
+	}
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("unmarshal archive index: %w", err)
+	}
+	return entries, nil
+}
+
+func (a *Archiver) path(file string) string {
+	return object.GcsPrefix + filepath.Join(
+		strings.TrimPrefix(a.options.Bucket, object.GcsPrefix), ArchiveDir, file,
+	)
+}
+
+//counterfeiter:generate . archiveImpl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt announcefakes/fake_archive_impl.go > announcefakes/_fake_archive_impl.go && mv announcefakes/_fake_archive_impl.go announcefakes/fake_archive_impl.go"
+type archiveImpl interface {
+	// ReadObject returns nil if the object does not exist.
+	ReadObject(gcsPath string) ([]byte, error)
+	WriteObject(gcsPath string, content []byte) error
+}
+
+type defaultArchiveImpl struct{}
+
+func (*defaultArchiveImpl) ReadObject(gcsPath string) ([]byte, error) {
+	return gcs.ReadObject(gcsPath)
+}
+
+func (*defaultArchiveImpl) WriteObject(gcsPath string, content []byte) error {
+	return gcs.WriteObject(gcsPath, content)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/announce/announcefakes"
+)
+
+func TestArchive(t *testing.T) {
+	const bucket = "kubernetes-release-test"
+
+	existing, err := json.Marshal([]announce.ArchiveEntry{
+		{
+			Tag:     "v1.30.0",
+			Subject: "Kubernetes v1.30.0 is live!",
+			Date:    time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC),
+			File:    "v1.30.0.html",
+		},
+		{
+			Tag:     "v1.29.4",
+			Subject: "Kubernetes v1.29.4 is live!",
+			Date:    time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC),
+			File:    "v1.29.4.html",
+		},
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		tag         string
+		prepare     func(*announcefakes.FakeArchiveImpl)
+		entries     []string
+		shouldError bool
+	}{
+		{ // first announcement
+			tag:     "1.30.0",
+			prepare: func(*announcefakes.FakeArchiveImpl) {},
+			entries: []string{"v1.30.0"},
+		},
+		{ // new announcement gets prepended
+			tag: "v1.30.1",
+			prepare: func(mock *announcefakes.FakeArchiveImpl) {
+				mock.ReadObjectReturns(existing, nil)
+			},
+			entries: []string{"v1.30.1", "v1.30.0", "v1.29.4"},
+		},
+		{ // existing announcement gets replaced
+			tag: "v1.29.4",
+			prepare: func(mock *announcefakes.FakeArchiveImpl) {
+				mock.ReadObjectReturns(existing, nil)
+			},
+			entries: []string{"v1.29.4", "v1.30.0"},
+		},
+		{ // failure on read
+			tag: "v1.30.1",
+			prepare: func(mock *announcefakes.FakeArchiveImpl) {
+				mock.ReadObjectReturns(nil, errors.New(""))
+			},
+			shouldError: true,
+		},
+		{ // failure on write
+			tag: "v1.30.1",
+			prepare: func(mock *announcefakes.FakeArchiveImpl) {
+				mock.WriteObjectReturns(errors.New(""))
+			},
+			shouldError: true,
+		},
+	} {
+		mock := &announcefakes.FakeArchiveImpl{}
+		tc.prepare(mock)
+
+		sut := announce.NewArchiver(&announce.ArchiveOptions{Bucket: bucket})
+		sut.SetImpl(mock)
+
+		u, err := sut.Archive(tc.tag, "Subject <"+tc.tag+">", "<p>Announcement</p>")
+		if tc.shouldError {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, mock.ReadObjectArgsForCall(0), "gs://"+bucket+"/announcements/index.json")
+
+		objects := map[string]string{}
+		for i := 0; i < mock.WriteObjectCallCount(); i++ {
+			path, content := mock.WriteObjectArgsForCall(i)
+			objects[strings.TrimPrefix(path, "gs://"+bucket+"/announcements/")] = string(content)
+		}
+		require.Len(t, objects, 3)
+
+		tag := "v" + strings.TrimPrefix(tc.tag, "v")
+		require.True(t, strings.HasSuffix(u, "/announcements/"+tag+".html"))
+
+		page := objects[tag+".html"]
+		require.Contains(t, page, "<title>Subject &lt;"+tc.tag+"&gt;</title>")
+		require.Contains(t, page, "<p>Announcement</p>")
+		require.Contains(t, page, `<a href="index.html">`)
+
+		entries := []announce.ArchiveEntry{}
+		require.NoError(t, json.Unmarshal([]byte(objects["index.json"]), &entries))
+		tags := []string{}
+		for _, entry := range entries {
+			tags = append(tags, entry.Tag)
+			require.Contains(t, objects["index.html"], `<a href="`+entry.File+`">`)
+		}
+		require.Equal(t, tc.entries, tags)
+	}
+}
+
+func TestArchiveOptionsValidate(t *testing.T) {
+	require.ErrorIs(t, (&announce.ArchiveOptions{}).Validate(), announce.ErrMissingArchiveBucket)
+	require.NoError(t, announce.DefaultArchiveOptions(false).Validate())
+	require.NotEqual(t, announce.DefaultArchiveOptions(false), announce.DefaultArchiveOptions(true))
+}
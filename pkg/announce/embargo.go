@@ -0,0 +1,333 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/ghperms"
+	"k8s.io/release/pkg/notify"
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/workdir"
+)
+
+// embargoLogInterval is the maximum time between two progress messages
+// while waiting for the publish time.
+const embargoLogInterval = 10 * time.Minute
+
+// EmbargoOptions are the settings for announcing a release on all channels
+// simultaneously at a scheduled time. The GitHub release page has to exist as
+// draft, which gets created by `krel release --publish-at`. The version
+// markers are held back by the release as well and get updated at the
+// publish time, while the release tag is already pushed by the release job.
+type EmbargoOptions struct {
+	// Tag is the release tag to announce.
+	Tag string
+
+	// PublishAt is the time the announcement gets published.
+	PublishAt time.Time
+
+	// Owner and Repo are the GitHub repository of the draft release page.
+	Owner string
+	Repo  string
+
+	// NoMock publishes the release page, the version markers, the social
+	// post and the notifications. Only the mail gets sent to the test group
+	// otherwise.
+	NoMock bool
+
+	// Send mails the announcement if set.
+	Send *SendOptions
+
+	// Social posts the short announcement if set.
+	Social *SocialOptions
+
+	// WebhookURLs are the chat webhooks notified about the release.
+	WebhookURLs []string
+}
+
+// Validate checks if the options are correctly set, where the publish time
+// has to be after now.
+func (o *EmbargoOptions) Validate(now time.Time) error {
+	if o.Tag == "" {
+		return fmt.Errorf("cannot schedule announcement: %w", ErrMissingTag)
+	}
+	if o.Owner == "" {
+		return fmt.Errorf("cannot schedule announcement: %w", ErrMissingOwner)
+	}
+	if o.Repo == "" {
+		return fmt.Errorf("cannot schedule announcement: %w", ErrMissingRepository)
+	}
+	if o.PublishAt.IsZero() {
+		return fmt.Errorf("cannot schedule announcement: %w", ErrMissingPublishTime)
+	}
+	if !o.PublishAt.After(now) {
+		return fmt.Errorf(
+			"cannot schedule announcement for %s: %w",
+			o.PublishAt.Format(time.RFC3339), ErrPublishTimePassed,
+		)
+	}
+	if o.Send != nil {
+		if err := o.Send.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.Social != nil {
+		if err := o.Social.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Embargo holds back the announcement of a release until its publish time.
+type Embargo struct {
+	impl    embargoImpl
+	options *EmbargoOptions
+}
+
+// NewEmbargo returns a new Embargo instance.
+func NewEmbargo(options *EmbargoOptions) *Embargo {
+	return &Embargo{&defaultEmbargoImpl{}, options}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (e *Embargo) SetImpl(impl embargoImpl) {
+	e.impl = impl
+}
+
+// Run verifies that all channels are ready, waits until the publish time and
+// publishes the release page, the version markers, the mail, the social post
+// and the notifications at the same time. The schedule is not persisted,
+// which means that Run has to keep running until the publish time. It returns the errors of all channels
+// which failed to publish.
+func (e *Embargo) Run() error {
+	if err := e.options.Validate(e.impl.Now()); err != nil {
+		return fmt.Errorf("validating embargo options: %w", err)
+	}
+	tag := util.AddTagPrefix(e.options.Tag)
+
+	// Fail before the publish time if anything is not ready
+	release, err := e.impl.GetRelease(e.options.Owner, e.options.Repo, tag)
+	if err != nil {
+		return fmt.Errorf("get GitHub release %s: %w", tag, err)
+	}
+	if release == nil || !release.GetDraft() {
+		return fmt.Errorf("GitHub release %s: %w", tag, ErrReleaseNotDraft)
+	}
+	if e.options.NoMock {
+		if err := e.impl.CheckPermissions(
+			"publishing the embargoed release", e.options.Owner, e.options.Repo, ghperms.Releases,
+		); err != nil {
+			return err
+		}
+	}
+
+	content := ""
+	if e.options.Send != nil {
+		content, err = e.impl.FetchAnnouncement(tag)
+		if err != nil {
+			return fmt.Errorf("fetch announcement: %w", err)
+		}
+	}
+
+	if e.options.Social != nil {
+		if _, err := e.impl.RenderSocial(e.options.Social); err != nil {
+			return fmt.Errorf("render social announcement: %w", err)
+		}
+	}
+
+	e.wait(tag)
+
+	logrus.Infof("Publishing announcement of %s", tag)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	publish := func(channel string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("publish %s: %w", channel, err))
+				mu.Unlock()
+				return
+			}
+			logrus.Infof("Published %s", channel)
+		}()
+	}
+
+	if e.options.NoMock {
+		publish("GitHub release", func() error {
+			return e.impl.PublishRelease(e.options.Owner, e.options.Repo, release)
+		})
+		publish("version markers", func() error {
+			return e.impl.PublishVersionMarkers(tag)
+		})
+	} else {
+		logrus.Infof("Not publishing GitHub release %s and its version markers in mock mode", tag)
+	}
+
+	if e.options.Send != nil {
+		publish("mail", func() error {
+			return e.impl.SendAnnouncement(e.options.Send, content)
+		})
+	}
+
+	if e.options.Social != nil && e.options.NoMock {
+		publish("social announcement", func() error {
+			return e.impl.PostSocial(e.options.Social)
+		})
+	}
+
+	text := fmt.Sprintf(
+		"%s %s is live: %s", branding.Default().ProductName, tag, release.GetHTMLURL(),
+	)
+	if len(e.options.WebhookURLs) > 0 && !e.options.NoMock {
+		logrus.Infof("Not sending notifications in mock mode: %s", text)
+	} else {
+		for _, url := range e.options.WebhookURLs {
+			url := url
+			publish("notification", func() error {
+				return e.impl.Notify(url, text)
+			})
+		}
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// wait blocks until the publish time, logging the remaining time regularly.
+func (e *Embargo) wait(tag string) {
+	for {
+		remaining := e.options.PublishAt.Sub(e.impl.Now())
+		if remaining <= 0 {
+			return
+		}
+		logrus.Infof(
+			"Announcement of %s is embargoed until %s, waiting %s",
+			tag, e.options.PublishAt.Format(time.RFC3339), remaining.Round(time.Second),
+		)
+		e.impl.Sleep(min(remaining, embargoLogInterval))
+	}
+}
+
+//counterfeiter:generate . embargoImpl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt announcefakes/fake_embargo_impl.go > announcefakes/_fake_embargo_impl.go && mv announcefakes/_fake_embargo_impl.go announcefakes/fake_embargo_impl.go"
+type embargoImpl interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	// GetRelease returns nil if the release does not exist.
+	GetRelease(owner, repo, tag string) (*gogithub.RepositoryRelease, error)
+	PublishRelease(owner, repo string, release *gogithub.RepositoryRelease) error
+	PublishVersionMarkers(tag string) error
+	FetchAnnouncement(tag string) (string, error)
+	SendAnnouncement(opts *SendOptions, content string) error
+	RenderSocial(opts *SocialOptions) (*SocialPost, error)
+	PostSocial(opts *SocialOptions) error
+	Notify(url, text string) error
+	CheckPermissions(operation, owner, repo string, perms ...ghperms.Permission) error
+}
+
+type defaultEmbargoImpl struct{}
+
+func (*defaultEmbargoImpl) Now() time.Time {
+	return time.Now()
+}
+
+func (*defaultEmbargoImpl) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (*defaultEmbargoImpl) CheckPermissions(operation, owner, repo string, perms ...ghperms.Permission) error {
+	return ghperms.Check(operation, owner, repo, perms...)
+}
+
+func (*defaultEmbargoImpl) GetRelease(owner, repo, tag string) (*gogithub.RepositoryRelease, error) {
+	releases, err := github.New().Releases(owner, repo, true)
+	if err != nil {
+		return nil, fmt.Errorf("listing the repositories releases: %w", err)
+	}
+	for _, release := range releases {
+		if release.GetTagName() == tag {
+			return release, nil
+		}
+	}
+	return nil, nil
+}
+
+func (*defaultEmbargoImpl) PublishRelease(owner, repo string, release *gogithub.RepositoryRelease) error {
+	draft := false
+	if _, err := github.New().UpdateReleasePageWithOptions(
+		owner, repo, release.GetID(), release.GetTagName(), release.GetTargetCommitish(),
+		&github.UpdateReleasePageOptions{Draft: &draft},
+	); err != nil {
+		return err
+	}
+	audit.Record(audit.ActionGitHubAPI, fmt.Sprintf("%s/%s@%s", owner, repo, release.GetTagName()), map[string]string{
+		"operation":  "publish draft release",
+		"release-id": strconv.FormatInt(release.GetID(), 10),
+	})
+	return nil
+}
+
+func (*defaultEmbargoImpl) PublishVersionMarkers(tag string) error {
+	dir, err := workdir.MkdirTemp("version-markers-")
+	if err != nil {
+		return fmt.Errorf("create version marker directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	return release.NewPublisher().PublishVersion(
+		"release", tag, dir, release.ProductionBucket, "release", nil, false, false,
+	)
+}
+
+func (*defaultEmbargoImpl) FetchAnnouncement(tag string) (string, error) {
+	return Fetch(tag)
+}
+
+func (*defaultEmbargoImpl) SendAnnouncement(opts *SendOptions, content string) error {
+	return Send(opts, content)
+}
+
+func (*defaultEmbargoImpl) RenderSocial(opts *SocialOptions) (*SocialPost, error) {
+	return NewSocialPoster(opts).Render()
+}
+
+func (*defaultEmbargoImpl) PostSocial(opts *SocialOptions) error {
+	_, err := NewSocialPoster(opts).Post()
+	return err
+}
+
+func (*defaultEmbargoImpl) Notify(url, text string) error {
+	return notify.New().Send(url, &notify.Message{Text: text})
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/announce/announcefakes"
+	"k8s.io/release/pkg/ghperms"
+)
+
+func TestEmbargoRun(t *testing.T) {
+	start := time.Date(2024, 4, 17, 15, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name          string
+		prepare       func(*announce.EmbargoOptions, *announcefakes.FakeEmbargoImpl)
+		err           error
+		publishErr    bool
+		publishes     int
+		mails         int
+		socials       int
+		notifications int
+	}{
+		{
+			name:          "all channels",
+			publishes:     1,
+			mails:         1,
+			socials:       1,
+			notifications: 2,
+		},
+		{
+			name: "mock mode only sends the mail",
+			prepare: func(opts *announce.EmbargoOptions, _ *announcefakes.FakeEmbargoImpl) {
+				opts.NoMock = false
+			},
+			mails: 1,
+		},
+		{
+			name: "skipped channels",
+			prepare: func(opts *announce.EmbargoOptions, _ *announcefakes.FakeEmbargoImpl) {
+				opts.Send = nil
+				opts.Social = nil
+				opts.WebhookURLs = nil
+			},
+			publishes: 1,
+		},
+		{
+			name: "publish time passed",
+			prepare: func(opts *announce.EmbargoOptions, _ *announcefakes.FakeEmbargoImpl) {
+				opts.PublishAt = start.Add(-time.Minute)
+			},
+			err: announce.ErrPublishTimePassed,
+		},
+		{
+			name: "missing publish time",
+			prepare: func(opts *announce.EmbargoOptions, _ *announcefakes.FakeEmbargoImpl) {
+				opts.PublishAt = time.Time{}
+			},
+			err: announce.ErrMissingPublishTime,
+		},
+		{
+			name: "release already published",
+			prepare: func(_ *announce.EmbargoOptions, mock *announcefakes.FakeEmbargoImpl) {
+				mock.GetReleaseReturns(&gogithub.RepositoryRelease{Draft: gogithub.Bool(false)}, nil)
+			},
+			err: announce.ErrReleaseNotDraft,
+		},
+		{
+			name: "release not found",
+			prepare: func(_ *announce.EmbargoOptions, mock *announcefakes.FakeEmbargoImpl) {
+				mock.GetReleaseReturns(nil, nil)
+			},
+			err: announce.ErrReleaseNotDraft,
+		},
+		{
+			name: "missing GitHub permissions",
+			prepare: func(_ *announce.EmbargoOptions, mock *announcefakes.FakeEmbargoImpl) {
+				mock.CheckPermissionsReturns(ghperms.ErrMissingPermissions)
+			},
+			err: ghperms.ErrMissingPermissions,
+		},
+		{
+			name: "failing channel does not stop the others",
+			prepare: func(_ *announce.EmbargoOptions, mock *announcefakes.FakeEmbargoImpl) {
+				mock.SendAnnouncementReturns(errors.New("sendgrid unavailable"))
+			},
+			publishErr:    true,
+			publishes:     1,
+			mails:         1,
+			socials:       1,
+			notifications: 2,
+		},
+	} {
+		now := start
+		mock := &announcefakes.FakeEmbargoImpl{}
+		mock.NowCalls(func() time.Time { return now })
+		mock.SleepCalls(func(d time.Duration) { now = now.Add(d) })
+		mock.GetReleaseReturns(&gogithub.RepositoryRelease{
+			Draft:   gogithub.Bool(true),
+			HTMLURL: gogithub.String("https://github.com/kubernetes/kubernetes/releases/tag/v1.30.0"),
+		}, nil)
+		mock.FetchAnnouncementReturns("<p>announcement</p>", nil)
+
+		opts := &announce.EmbargoOptions{
+			Tag:       "v1.30.0",
+			PublishAt: start.Add(25 * time.Minute),
+			Owner:     "kubernetes",
+			Repo:      "kubernetes",
+			NoMock:    true,
+			Send: &announce.SendOptions{
+				Tag: "v1.30.0", SendgridAPIKey: "key",
+			},
+			Social: &announce.SocialOptions{
+				Tag: "v1.30.0", WebhookURL: "https://hooks.example.com/social",
+			},
+			WebhookURLs: []string{"https://hooks.example.com/a", "https://hooks.example.com/b"},
+		}
+		if tc.prepare != nil {
+			tc.prepare(opts, mock)
+		}
+
+		sut := announce.NewEmbargo(opts)
+		sut.SetImpl(mock)
+
+		err := sut.Run()
+		if tc.err != nil {
+			require.ErrorIs(t, err, tc.err, tc.name)
+			require.Zero(t, mock.SleepCallCount(), tc.name)
+			continue
+		}
+		if tc.publishErr {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+
+		require.False(t, now.Before(opts.PublishAt), tc.name)
+		require.Equal(t, 3, mock.SleepCallCount(), tc.name)
+		require.Equal(t, tc.publishes, mock.PublishReleaseCallCount(), tc.name)
+		require.Equal(t, tc.publishes, mock.PublishVersionMarkersCallCount(), tc.name)
+		require.Equal(t, tc.mails, mock.SendAnnouncementCallCount(), tc.name)
+		require.Equal(t, tc.socials, mock.PostSocialCallCount(), tc.name)
+		require.Equal(t, tc.notifications, mock.NotifyCallCount(), tc.name)
+
+		urls := map[string]bool{}
+		for i := 0; i < mock.NotifyCallCount(); i++ {
+			url, text := mock.NotifyArgsForCall(i)
+			require.Equal(t, "Kubernetes v1.30.0 is live: https://github.com/kubernetes/kubernetes/releases/tag/v1.30.0", text)
+			urls[url] = true
+		}
+		require.Len(t, urls, tc.notifications, tc.name)
+	}
+}
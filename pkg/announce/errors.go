@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce
+
+import "errors"
+
+// The errors returned by the announce package, which can be matched by
+// using errors.Is.
+var (
+	// ErrMissingTag is returned if no release tag is specified.
+	ErrMissingTag = errors.New("missing tag")
+
+	// ErrMissingOwner is returned if no GitHub organization is specified.
+	ErrMissingOwner = errors.New("missing GitHub organization")
+
+	// ErrMissingRepository is returned if no GitHub repository is
+	// specified.
+	ErrMissingRepository = errors.New("missing repository")
+
+	// ErrInvalidRepoSlug is returned if a repository slug is not in the
+	// form org/repo.
+	ErrInvalidRepoSlug = errors.New("invalid repository slug")
+
+	// ErrMissingGitHubToken is returned if a GitHub operation requires a
+	// token, but none is set.
+	ErrMissingGitHubToken = errors.New("missing GitHub token")
+
+	// ErrMissingSendgridAPIKey is returned if an announcement should be
+	// sent without a Sendgrid API key.
+	ErrMissingSendgridAPIKey = errors.New("missing Sendgrid API key")
+
+	// ErrMissingArchiveBucket is returned if no announcement archive
+	// bucket is specified.
+	ErrMissingArchiveBucket = errors.New("missing archive bucket")
+
+	// ErrMissingWebhookURL is returned if a social announcement should be
+	// posted without a webhook URL.
+	ErrMissingWebhookURL = errors.New("missing webhook URL")
+
+	// ErrPostTooLong is returned if the rendered social announcement exceeds
+	// the maximum length.
+	ErrPostTooLong = errors.New("post too long")
+
+	// ErrMissingCVE is returned if a security notice has no CVE
+	// identifier.
+	ErrMissingCVE = errors.New("missing CVE identifier")
+
+	// ErrMissingAdvisory is returned if a security notice has no advisory
+	// link.
+	ErrMissingAdvisory = errors.New("missing advisory link")
+
+	// ErrInvalidSubstitution is returned if a page template substitution
+	// is not in the form key:value.
+	ErrInvalidSubstitution = errors.New("substitution value not well formed")
+
+	// ErrTagNotFound is returned if the release tag does not exist in the
+	// repository.
+	ErrTagNotFound = errors.New("tag not found")
+
+	// ErrReleaseExists is returned if the GitHub release already exists and
+	// should not be updated.
+	ErrReleaseExists = errors.New("release already exists")
+
+	// ErrAssetNotFound is returned if a release asset file does not exist.
+	ErrAssetNotFound = errors.New("asset file does not exist")
+
+	// ErrMissingPublishTime is returned if an embargoed announcement has no
+	// publish time.
+	ErrMissingPublishTime = errors.New("missing publish time")
+
+	// ErrPublishTimePassed is returned if the publish time of an embargoed
+	// announcement is not in the future.
+	ErrPublishTimePassed = errors.New("publish time already passed")
+
+	// ErrReleaseNotDraft is returned if the GitHub release of an embargoed
+	// announcement does not exist as draft.
+	ErrReleaseNotDraft = errors.New("release is not a draft")
+
+	// ErrBrokenReferences is returned if an announcement contains broken
+	// references.
+	ErrBrokenReferences = errors.New("broken references")
+)
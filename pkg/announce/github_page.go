@@ -18,45 +18,56 @@ package announce
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	gogithub "github.com/google/go-github/v58/github"
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/bom/pkg/serialize"
 	"sigs.k8s.io/bom/pkg/spdx"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-utils/hash"
 	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/ghperms"
+	"k8s.io/release/pkg/imagerewrite"
+	"k8s.io/release/pkg/retry"
+	"k8s.io/release/pkg/templates"
+	"k8s.io/release/pkg/workdir"
 )
 
 const (
 	sbomFileName      = "sbom.spdx"
 	assetDownloadPath = "/releases/download/"
+
+	// pageRevisionMarker is the HTML comment which tracks the revision of
+	// a release page across updates.
+	pageRevisionMarker = "<!-- krel-page-revision: %d%s -->"
 )
 
-// ghPageBody is a generic template to build the GitHub
-// rekease page.
-const ghPageBody = `{{ if .Substitutions.logo }}
-![Logo]({{ .Substitutions.logo }} "Logo")
-{{ end }}
-{{ .Substitutions.intro }}
-{{ if .Substitutions.changelog }}
-See [the CHANGELOG]({{ .Substitutions.changelog }}) for more details.
-{{ end }}
-{{ if .Substitutions.ReleaseNotes }}
-### Release Notes
+// pageRevisionRegex matches the revision marker in a release page body.
+var pageRevisionRegex = regexp.MustCompile(`<!-- krel-page-revision: (\d+)`)
 
-{{ .Substitutions.ReleaseNotes }}
-{{ end }}
+// errReleaseNotFound is returned if a created release does not yet show up
+// in the GitHub API.
+var errReleaseNotFound = errors.New("release not found, even when call to github was successful")
 
-`
+// ghPageBody is a generic template to build the GitHub
+// rekease page.
+var ghPageBody = templates.MustGet(templates.GitHubPage)
 
 // GitHubPageOptions data for building the release page
 type GitHubPageOptions struct {
@@ -93,6 +104,11 @@ type GitHubPageOptions struct {
 	// unless specified so.
 	UpdateIfReleaseExists bool
 
+	// ArchiveLocation is a local directory or gs:// path where the body
+	// and asset list of an existing release page get saved before it is
+	// updated. Archiving is skipped if empty.
+	ArchiveLocation string
+
 	// We can use a custom page template by spcifiying the path. The
 	// file is a go template file that renders markdown.
 	PageTemplate string
@@ -130,7 +146,7 @@ type Asset struct {
 // GenerateReleaseSBOM creates an SBOM describing the release
 func GenerateReleaseSBOM(opts *SBOMOptions) (string, error) {
 	// Create a temporary file to write the sbom
-	dir, err := os.MkdirTemp("", "project-sbom-")
+	dir, err := workdir.MkdirTemp("project-sbom-")
 	if err != nil {
 		return "", fmt.Errorf("creating temporary directory to write sbom: %w", err)
 	}
@@ -204,7 +220,7 @@ func GenerateReleaseSBOM(opts *SBOMOptions) (string, error) {
 func UpdateGitHubPage(opts *GitHubPageOptions) (err error) {
 	token := os.Getenv(github.TokenEnvKey)
 	if token == "" {
-		return errors.New("cannot update release page without a GitHub token")
+		return fmt.Errorf("cannot update release page: %w", ErrMissingGitHubToken)
 	}
 
 	gh := github.New()
@@ -264,6 +280,7 @@ func UpdateGitHubPage(opts *GitHubPageOptions) (err error) {
 	if err != nil {
 		return fmt.Errorf("executing page template: %w", err)
 	}
+	output = bytes.NewBufferString(imagerewrite.Apply(output.String()))
 
 	// If we are in mock, we write it to stdout and exit. All checks
 	// performed to the repo are skipped as the tag may not exist yet.
@@ -276,6 +293,12 @@ func UpdateGitHubPage(opts *GitHubPageOptions) (err error) {
 		return nil
 	}
 
+	if err := ghperms.Check(
+		"updating the GitHub release page", opts.Owner, opts.Repo, ghperms.Releases,
+	); err != nil {
+		return err
+	}
+
 	// Check to see that a tag exists.
 	// non-draft release posts to github create a tag.  We don't want to
 	// create any tags on the repo this way. The tag should already exist
@@ -288,7 +311,7 @@ func UpdateGitHubPage(opts *GitHubPageOptions) (err error) {
 		logrus.Warnf("The %s tag doesn't exist yet on GitHub.", opts.Tag)
 		logrus.Warnf("That can't be good.")
 		logrus.Warnf("We certainly cannot publish a release without a tag.")
-		return errors.New("tag not found while trying to publish release page")
+		return fmt.Errorf("%w while trying to publish release page", ErrTagNotFound)
 	}
 
 	// Get the release we are looking for
@@ -299,21 +322,34 @@ func UpdateGitHubPage(opts *GitHubPageOptions) (err error) {
 
 	// Does the release exist yet?
 	var releaseID int64
+	var existing *gogithub.RepositoryRelease
 	commitish := ""
 	for _, release := range releases {
 		if release.GetTagName() == opts.Tag {
 			releaseID = release.GetID()
 			commitish = release.GetTargetCommitish()
+			existing = release
 		}
 	}
 
 	if releaseID != 0 {
 		logrus.Warnf("The %s is already published on github.", opts.Tag)
 		if !opts.UpdateIfReleaseExists {
-			return errors.New("release " + opts.Tag + " already exists. Left intact")
+			return fmt.Errorf("%w: %s, left intact", ErrReleaseExists, opts.Tag)
 		}
 		logrus.Infof("Using release id %d to update existing release.", releaseID)
 		releaseVerb = "Updating"
+
+		// Keep the previous revision of the page recoverable
+		revision := pageRevision(existing.GetBody())
+		archivePath := ""
+		if opts.ArchiveLocation != "" {
+			archivePath, err = archiveReleasePage(gh, opts, existing, revision)
+			if err != nil {
+				return fmt.Errorf("archiving the existing release page: %w", err)
+			}
+		}
+		output.WriteString(pageRevisionFooter(revision+1, archivePath))
 	}
 
 	// Post release data
@@ -328,32 +364,38 @@ func UpdateGitHubPage(opts *GitHubPageOptions) (err error) {
 	if err != nil {
 		return fmt.Errorf("updating the release on GitHub: %w", err)
 	}
+	audit.Record(audit.ActionGitHubAPI, fmt.Sprintf("%s/%s@%s", opts.Owner, opts.Repo, opts.Tag), map[string]string{
+		"operation":  strings.ToLower(releaseVerb) + " release",
+		"release-id": strconv.FormatInt(release.GetID(), 10),
+	})
 
 	// Releases often take a bit of time to show up in the API
 	// after creating the page. If the release does not appear
 	// in the API right away , sleep 3 secs and retry 3 times.
-	for checkAttempts := 3; checkAttempts >= 0; checkAttempts-- {
-		releaseFound := false
-		releases, err = gh.Releases(opts.Owner, opts.Repo, true)
+	policy := retry.DefaultPolicy()
+	policy.Attempts = 4
+	policy.InitialDelay = 3 * time.Second
+	policy.Factor = 1
+	policy.Classify = func(err error) (bool, time.Duration) {
+		if errors.Is(err, errReleaseNotFound) {
+			return true, 0
+		}
+		return retry.Classify(err)
+	}
+	if err := policy.Do(context.Background(), retry.ServiceGitHub, func() error {
+		releases, err := gh.Releases(opts.Owner, opts.Repo, true)
 		if err != nil {
 			return fmt.Errorf("listing releases in repository: %w", err)
 		}
 		// Check if the page shows up in the API
 		for _, testRelease := range releases {
 			if testRelease.GetID() == release.GetID() {
-				releaseFound = true
-				break
+				return nil
 			}
 		}
-		if releaseFound {
-			break
-		}
-
-		if checkAttempts == 0 {
-			return errors.New("release not found, even when call to github was successful")
-		}
-		logrus.Info("Release page not yet returned by the GitHub API, sleeping and retrying")
-		time.Sleep(3 * time.Second)
+		return errReleaseNotFound
+	}); err != nil {
+		return err
 	}
 
 	// Delete any assets reviously uploaded
@@ -369,11 +411,112 @@ func UpdateGitHubPage(opts *GitHubPageOptions) (err error) {
 			return fmt.Errorf("uploading %s to the release: %w", assetData["realpath"], err)
 		}
 		logrus.Info("Successfully uploaded asset #", asset.GetID())
+		audit.Record(audit.ActionGitHubAPI, fmt.Sprintf("%s/%s@%s", opts.Owner, opts.Repo, opts.Tag), map[string]string{
+			"operation": "upload release asset",
+			"asset":     assetData["realpath"],
+		})
 	}
 	logrus.Infof("Release %s published on GitHub", opts.Tag)
 	return nil
 }
 
+// archivedReleasePage is the archived revision of a GitHub release page.
+type archivedReleasePage struct {
+	Tag        string                 `json:"tag"`
+	Name       string                 `json:"name"`
+	Revision   int                    `json:"revision"`
+	ArchivedAt time.Time              `json:"archivedAt"`
+	Draft      bool                   `json:"draft"`
+	Prerelease bool                   `json:"prerelease"`
+	Body       string                 `json:"body"`
+	Assets     []archivedReleaseAsset `json:"assets"`
+}
+
+// archivedReleaseAsset is an asset of an archived GitHub release page.
+type archivedReleaseAsset struct {
+	Name        string `json:"name"`
+	Label       string `json:"label,omitempty"`
+	Size        int    `json:"size"`
+	DownloadURL string `json:"downloadURL"`
+}
+
+// pageRevision returns the revision of a release page body, which is 1 for
+// pages without revision marker.
+func pageRevision(body string) int {
+	revision := 1
+	for _, match := range pageRevisionRegex.FindAllStringSubmatch(body, -1) {
+		if r, err := strconv.Atoi(match[1]); err == nil && r > revision {
+			revision = r
+		}
+	}
+	return revision
+}
+
+// pageRevisionFooter returns the revision marker appended to an updated
+// release page body.
+func pageRevisionFooter(revision int, archivePath string) string {
+	archived := ""
+	if archivePath != "" {
+		archived = ", previous revision archived at " + archivePath
+	}
+	return "\n\n" + fmt.Sprintf(pageRevisionMarker, revision, archived) + "\n"
+}
+
+// archiveReleasePage saves the body and asset list of an existing release
+// page to the archive location and returns the path of the archive.
+func archiveReleasePage(
+	gh *github.GitHub, opts *GitHubPageOptions,
+	release *gogithub.RepositoryRelease, revision int,
+) (string, error) {
+	assets, err := gh.ListReleaseAssets(opts.Owner, opts.Repo, release.GetID())
+	if err != nil {
+		return "", fmt.Errorf("listing the release assets: %w", err)
+	}
+
+	page := &archivedReleasePage{
+		Tag:        release.GetTagName(),
+		Name:       release.GetName(),
+		Revision:   revision,
+		ArchivedAt: time.Now().UTC(),
+		Draft:      release.GetDraft(),
+		Prerelease: release.GetPrerelease(),
+		Body:       release.GetBody(),
+		Assets:     []archivedReleaseAsset{},
+	}
+	for _, asset := range assets {
+		page.Assets = append(page.Assets, archivedReleaseAsset{
+			Name:        asset.GetName(),
+			Label:       asset.GetLabel(),
+			Size:        asset.GetSize(),
+			DownloadURL: asset.GetBrowserDownloadURL(),
+		})
+	}
+	content, err := json.MarshalIndent(page, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal release page: %w", err)
+	}
+
+	fileName := fmt.Sprintf("github-page-%s-r%d.json", opts.Tag, revision)
+	if strings.HasPrefix(opts.ArchiveLocation, object.GcsPrefix) {
+		archivePath := strings.TrimSuffix(opts.ArchiveLocation, "/") + "/" + fileName
+		if err := (&defaultArchiveImpl{}).WriteObject(archivePath, content); err != nil {
+			return "", fmt.Errorf("write %s: %w", archivePath, err)
+		}
+		logrus.Infof("Archived revision %d of the release page to %s", revision, archivePath)
+		return archivePath, nil
+	}
+
+	if err := os.MkdirAll(opts.ArchiveLocation, os.FileMode(0o755)); err != nil {
+		return "", fmt.Errorf("create archive directory: %w", err)
+	}
+	archivePath := filepath.Join(opts.ArchiveLocation, fileName)
+	if err := os.WriteFile(archivePath, content, 0o600); err != nil {
+		return "", fmt.Errorf("write %s: %w", archivePath, err)
+	}
+	logrus.Infof("Archived revision %d of the release page to %s", revision, archivePath)
+	return archivePath, nil
+}
+
 // processAssetFiles reads the command line strings and returns
 // a map holding the needed info from the asset files
 func processAssetFiles(assetFiles []string) (releaseAssets []map[string]string, err error) {
@@ -396,7 +539,7 @@ func processAssetFiles(assetFiles []string) (releaseAssets []map[string]string,
 
 		// Verify path exists
 		if !util.Exists(path) {
-			return nil, errors.New("unable to render release page, asset file does not exist")
+			return nil, fmt.Errorf("unable to render release page: %w: %s", ErrAssetNotFound, path)
 		}
 
 		assetData["realpath"] = path
@@ -456,13 +599,13 @@ func getFileHashes(path string) (hashes map[string]string, err error) {
 func (o *GitHubPageOptions) Validate() error {
 	// TODO: Check that the tag is well formed
 	if o.Tag == "" {
-		return errors.New("cannot update github page without a tag")
+		return fmt.Errorf("cannot update github page: %w", ErrMissingTag)
 	}
 	if o.Repo == "" {
-		return errors.New("cannot update github page, repository not defined")
+		return fmt.Errorf("cannot update github page: %w", ErrMissingRepository)
 	}
 	if o.Owner == "" {
-		return errors.New("cannot update github page, github organization not defined")
+		return fmt.Errorf("cannot update github page: %w", ErrMissingOwner)
 	}
 
 	return nil
@@ -475,7 +618,7 @@ func (o *GitHubPageOptions) ParseSubstitutions(subs []string) error {
 	for _, sString := range subs {
 		p := strings.SplitN(sString, ":", 2)
 		if len(p) != 2 || p[0] == "" {
-			return errors.New("substitution value not well formed: " + sString)
+			return fmt.Errorf("%w: %s", ErrInvalidSubstitution, sString)
 		}
 		o.Substitutions[p[0]] = p[1]
 	}
@@ -487,15 +630,15 @@ func (o *GitHubPageOptions) ParseSubstitutions(subs []string) error {
 func (o *GitHubPageOptions) SetRepository(repoSlug string) error {
 	org, repo, err := git.ParseRepoSlug(repoSlug)
 	if err != nil {
-		return fmt.Errorf("parsing repository slug: %w", err)
+		return fmt.Errorf("%w %q: %w", ErrInvalidRepoSlug, repoSlug, err)
 	}
 	o.Owner = org
 	o.Repo = repo
 	return nil
 }
 
-// ReadTemplate reads a custom template from a file and sets
-// the PageTemplate option with its content
+// ReadTemplate reads a custom template from a file or selects an official
+// template by its name and sets the PageTemplate option with its content
 func (o *GitHubPageOptions) ReadTemplate(templatePath string) error {
 	// If path is empty, no custom template will be used
 	if templatePath == "" {
@@ -503,12 +646,11 @@ func (o *GitHubPageOptions) ReadTemplate(templatePath string) error {
 		return nil
 	}
 
-	// Otherwise, read a custom template from a file
-	templateData, err := os.ReadFile(templatePath)
+	// Otherwise, read the official or a custom template
+	templateData, err := templates.Read(templatePath)
 	if err != nil {
 		return fmt.Errorf("reading page template text: %w", err)
 	}
-	logrus.Infof("Using custom template from %s", templatePath)
-	o.PageTemplate = string(templateData)
+	o.PageTemplate = templateData
 	return nil
 }
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/release-sdk/github"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/httpreplay"
+)
+
+func TestUpdateGitHubPage(t *testing.T) {
+	asset := filepath.Join(t.TempDir(), "kubernetes.tar.gz")
+	require.NoError(t, os.WriteFile(asset, []byte("binary"), 0o600))
+
+	for _, tc := range []struct {
+		name        string
+		cassette    string
+		update      bool
+		archive     bool
+		assets      []string
+		expectedErr string
+	}{
+		{
+			name:     "create release",
+			cassette: "update-github-page-create.yaml",
+		},
+		{
+			name:        "release exists",
+			cassette:    "update-github-page-exists.yaml",
+			expectedErr: "release already exists: v1.30.0",
+		},
+		{
+			name:     "update release and replace assets",
+			cassette: "update-github-page-update.yaml",
+			update:   true,
+			assets:   []string{asset},
+		},
+		{
+			name:     "update release and archive the previous page",
+			cassette: "update-github-page-archive.yaml",
+			update:   true,
+			archive:  true,
+			assets:   []string{asset},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			httpreplay.Use(t, filepath.Join("testdata", "cassettes", tc.cassette))
+			t.Setenv(github.TokenEnvKey, "token")
+
+			archiveDir := ""
+			if tc.archive {
+				archiveDir = t.TempDir()
+			}
+
+			err := announce.UpdateGitHubPage(&announce.GitHubPageOptions{
+				Tag:                   "v1.30.0",
+				Name:                  "Kubernetes v1.30.0",
+				Owner:                 "kubernetes",
+				Repo:                  "kubernetes",
+				NoMock:                true,
+				UpdateIfReleaseExists: tc.update,
+				AssetFiles:            tc.assets,
+				ArchiveLocation:       archiveDir,
+				Substitutions:         map[string]string{},
+			})
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.archive {
+				content, err := os.ReadFile(filepath.Join(archiveDir, "github-page-v1.30.0-r2.json"))
+				require.NoError(t, err)
+				page := struct {
+					Revision int    `json:"revision"`
+					Body     string `json:"body"`
+					Assets   []struct {
+						Name string `json:"name"`
+					} `json:"assets"`
+				}{}
+				require.NoError(t, json.Unmarshal(content, &page))
+				require.Equal(t, 2, page.Revision)
+				require.Equal(t, "notes\n\n<!-- krel-page-revision: 2 -->", page.Body)
+				require.Len(t, page.Assets, 1)
+				require.Equal(t, "kubernetes.tar.gz", page.Assets[0].Name)
+			}
+		})
+	}
+}
+
+func TestPrependSecurityNotice(t *testing.T) {
+	opts := &announce.SecurityNoticeOptions{
+		CVE:      "CVE-2024-1234",
+		Title:    "Node privilege escalation",
+		Advisory: "https://github.com/kubernetes/kubernetes/issues/1",
+	}
+
+	body, updated := announce.PrependSecurityNotice("notes", opts)
+	require.True(t, updated)
+	require.Equal(t, "<!-- security-notice: CVE-2024-1234 -->\n## Security Notice\n\n"+
+		"This release is affected by [CVE-2024-1234](https://github.com/kubernetes/kubernetes/issues/1): "+
+		"Node privilege escalation. Please see the advisory for the fixed versions and mitigations.\n\nnotes", body)
+
+	again, updated := announce.PrependSecurityNotice(body, opts)
+	require.False(t, updated)
+	require.Equal(t, body, again)
+}
+
+func TestAddSecurityNotices(t *testing.T) {
+	httpreplay.Use(t, filepath.Join("testdata", "cassettes", "security-notice.yaml"))
+	t.Setenv(github.TokenEnvKey, "token")
+
+	tags, err := announce.AddSecurityNotices(&announce.SecurityNoticeOptions{
+		CVE:      "CVE-2024-1234",
+		Advisory: "https://github.com/kubernetes/kubernetes/issues/1",
+		Affected: ">=1.29.0 <1.29.3",
+		Owner:    "kubernetes",
+		Repo:     "kubernetes",
+		NoMock:   true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.29.0"}, tags)
+
+	_, err = announce.AddSecurityNotices(&announce.SecurityNoticeOptions{
+		CVE:      "CVE-2024-1234",
+		Advisory: "https://github.com/kubernetes/kubernetes/issues/1",
+		Affected: "1.29",
+		Owner:    "kubernetes",
+		Repo:     "kubernetes",
+	})
+	require.ErrorContains(t, err, "parsing affected versions range")
+}
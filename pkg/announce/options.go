@@ -16,6 +16,8 @@ limitations under the License.
 
 package announce
 
+import "k8s.io/release/pkg/release"
+
 type Options struct {
 	// workDir is the directory where announcement.html and
 	// announcement-subject.txt will be written
@@ -33,6 +35,8 @@ type Options struct {
 	// changelogFile is the path to an HTML file containing the changelog
 	// which will be embedded in the announcement template
 	changelogFile string
+	// components are the released components of a partial release
+	components release.Components
 }
 
 // NewOptions can be used to create a new Options instance
@@ -69,3 +73,8 @@ func (o *Options) WithChangelogFile(changelogFile string) *Options {
 	o.changelogFile = changelogFile
 	return o
 }
+
+func (o *Options) WithComponents(components release.Components) *Options {
+	o.components = components
+	return o
+}
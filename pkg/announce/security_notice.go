@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/ghperms"
+)
+
+// SecurityNoticeOptions are the options for attaching a security advisory
+// to already published GitHub releases.
+type SecurityNoticeOptions struct {
+	// CVE is the identifier of the vulnerability, eg CVE-2024-1234
+	CVE string
+
+	// Title of the vulnerability, optional
+	Title string
+
+	// Advisory is the link to the published security advisory
+	Advisory string
+
+	// Affected is the semver range of the affected releases,
+	// eg ">=1.29.0 <1.29.3 || >=1.30.0 <1.30.1"
+	Affected string
+
+	// Owner GitHub organization which owns the repository
+	Owner string
+
+	// Repo is the name of the repository of the releases
+	Repo string
+
+	// Run in non-mocked mode, which edits the release pages. Otherwise the
+	// updated pages are only printed.
+	NoMock bool
+}
+
+// Validate checks the security notice options.
+func (o *SecurityNoticeOptions) Validate() error {
+	if o.CVE == "" {
+		return fmt.Errorf("invalid security notice: %w", ErrMissingCVE)
+	}
+	if o.Advisory == "" {
+		return fmt.Errorf("invalid security notice: %w", ErrMissingAdvisory)
+	}
+	if o.Owner == "" || o.Repo == "" {
+		return fmt.Errorf("invalid security notice: %w", ErrMissingRepository)
+	}
+	if _, err := semver.ParseRange(o.Affected); err != nil {
+		return fmt.Errorf("parsing affected versions range: %w", err)
+	}
+	return nil
+}
+
+// securityNoticeMarker identifies the notice of a CVE in a release page, so
+// that running the update again does not add it twice.
+func securityNoticeMarker(cve string) string {
+	return fmt.Sprintf("<!-- security-notice: %s -->", cve)
+}
+
+// PrependSecurityNotice adds the security notice section of the CVE to the
+// beginning of the release page body. It returns false if the body already
+// contains the notice.
+func PrependSecurityNotice(body string, opts *SecurityNoticeOptions) (string, bool) {
+	marker := securityNoticeMarker(opts.CVE)
+	if strings.Contains(body, marker) {
+		return body, false
+	}
+
+	link := fmt.Sprintf("[%s](%s)", opts.CVE, opts.Advisory)
+	if opts.Title != "" {
+		link += ": " + opts.Title
+	}
+
+	notice := fmt.Sprintf(
+		"%s\n## Security Notice\n\nThis release is affected by %s. "+
+			"Please see the advisory for the fixed versions and mitigations.\n",
+		marker, link,
+	)
+	if body == "" {
+		return notice, true
+	}
+	return notice + "\n" + body, true
+}
+
+// AddSecurityNotices prepends the security notice of a CVE to the GitHub
+// pages of all releases in the affected versions range. It returns the tags
+// of the updated releases.
+func AddSecurityNotices(opts *SecurityNoticeOptions) (tags []string, err error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating security notice options: %w", err)
+	}
+	affected, err := semver.ParseRange(opts.Affected)
+	if err != nil {
+		return nil, fmt.Errorf("parsing affected versions range: %w", err)
+	}
+
+	if opts.NoMock && os.Getenv(github.TokenEnvKey) == "" {
+		return nil, fmt.Errorf("cannot update release pages: %w", ErrMissingGitHubToken)
+	}
+	if opts.NoMock {
+		if err := ghperms.Check(
+			"adding security notices", opts.Owner, opts.Repo, ghperms.Releases,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	gh := github.New()
+	releases, err := listAllReleases(gh, opts.Owner, opts.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("listing the repositories releases: %w", err)
+	}
+
+	for _, release := range releases {
+		tag := release.GetTagName()
+		version, err := util.TagStringToSemver(tag)
+		if err != nil {
+			logrus.Debugf("Skipping release %s: %v", tag, err)
+			continue
+		}
+		if !affected(version) {
+			continue
+		}
+
+		body, updated := PrependSecurityNotice(release.GetBody(), opts)
+		if !updated {
+			logrus.Infof("Release %s already contains the notice of %s", tag, opts.CVE)
+			continue
+		}
+
+		if !opts.NoMock {
+			logrus.Infof("Mock mode, outputting the release page of %s", tag)
+			if _, err := os.Stdout.WriteString(body); err != nil {
+				return nil, fmt.Errorf("writing github page to stdout: %w", err)
+			}
+			tags = append(tags, tag)
+			continue
+		}
+
+		logrus.Infof("Adding the security notice of %s to release %s", opts.CVE, tag)
+		if _, err := gh.UpdateReleasePage(
+			opts.Owner, opts.Repo, release.GetID(),
+			tag, release.GetTargetCommitish(), release.GetName(), body,
+			release.GetDraft(), release.GetPrerelease(),
+		); err != nil {
+			return nil, fmt.Errorf("updating the release %s on GitHub: %w", tag, err)
+		}
+		audit.Record(audit.ActionGitHubAPI, fmt.Sprintf("%s/%s@%s", opts.Owner, opts.Repo, tag), map[string]string{
+			"operation":  "add security notice",
+			"cve":        opts.CVE,
+			"release-id": strconv.FormatInt(release.GetID(), 10),
+		})
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// listAllReleases returns the releases of all pages, because older patch
+// releases are affected by a CVE as well.
+func listAllReleases(gh *github.GitHub, owner, repo string) ([]*gogithub.RepositoryRelease, error) {
+	client := gh.Client()
+	opts := &gogithub.ListOptions{PerPage: 100}
+	releases := []*gogithub.RepositoryRelease{}
+	for {
+		more, resp, err := client.ListReleases(context.Background(), owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, more...)
+		if resp.NextPage == 0 {
+			return releases, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-utils/http"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/mail"
+	"k8s.io/release/pkg/release"
+)
+
+// SendOptions are the settings for mailing a release announcement.
+type SendOptions struct {
+	// Tag is the release tag to announce.
+	Tag string
+
+	// SendgridAPIKey is the API key used for sending the mail.
+	SendgridAPIKey string
+
+	// Name and Email are the sender of the mail. The default sender of the
+	// Sendgrid account will be used if one of them is empty.
+	Name  string
+	Email string
+
+	// NoMock sends the mail to the production Google Groups instead of the
+	// test group.
+	NoMock bool
+
+	// Confirm gets called right before sending the mail if set. The mail
+	// will not be sent if it returns false.
+	Confirm func() (bool, error)
+
+	// DKIM signs the mail with the configured key if set.
+	DKIM *mail.DKIMOptions
+
+	// Archive publishes the announcement to the archive after sending it if
+	// set.
+	Archive *ArchiveOptions
+}
+
+// Validate checks if the options are correctly set.
+func (o *SendOptions) Validate() error {
+	if o.Tag == "" {
+		return fmt.Errorf("cannot send announcement: %w", ErrMissingTag)
+	}
+	if o.SendgridAPIKey == "" {
+		return fmt.Errorf("cannot send announcement: %w", ErrMissingSendgridAPIKey)
+	}
+	if o.DKIM != nil {
+		if err := o.DKIM.Validate(); err != nil {
+			return fmt.Errorf("validating DKIM options: %w", err)
+		}
+	}
+	return nil
+}
+
+// Recipients returns the Google Groups the announcement will be sent to.
+func (o *SendOptions) Recipients() []mail.GoogleGroup {
+	if o.NoMock {
+		return []mail.GoogleGroup{
+			mail.KubernetesAnnounceGoogleGroup,
+			mail.KubernetesDevGoogleGroup,
+		}
+	}
+	return []mail.GoogleGroup{mail.KubernetesAnnounceTestGoogleGroup}
+}
+
+// Subject returns the subject of the announcement mail.
+func (o *SendOptions) Subject() string {
+	return fmt.Sprintf("%s %s is live!", branding.Default().ProductName, util.AddTagPrefix(o.Tag))
+}
+
+// Fetch retrieves the announcement of an already staged release from the
+// production bucket.
+func Fetch(tag string) (string, error) {
+	u := fmt.Sprintf(
+		"%s/archive/anago-%s/announcement.html",
+		release.URLPrefixForBucket(release.ProductionBucket), util.AddTagPrefix(tag),
+	)
+	logrus.Infof("Using announcement remote URL: %s", u)
+
+	content, err := http.GetURLResponse(u, false)
+	if err != nil {
+		return "", fmt.Errorf(
+			"unable to retrieve release announcement form url: %s: %w", u, err,
+		)
+	}
+	return content, nil
+}
+
+// Send mails the announcement content to the Kubernetes Google Groups.
+func Send(opts *SendOptions, content string) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("validating announcement send options: %w", err)
+	}
+
+	logrus.Info("Preparing mail sender")
+	m := mail.NewSender(opts.SendgridAPIKey)
+
+	if opts.Name != "" && opts.Email != "" {
+		if err := m.SetSender(opts.Name, opts.Email); err != nil {
+			return fmt.Errorf("unable to set mail sender: %w", err)
+		}
+	} else {
+		logrus.Info("Retrieving default sender from sendgrid API")
+		if err := m.SetDefaultSender(); err != nil {
+			return fmt.Errorf("setting default sender: %w", err)
+		}
+	}
+
+	groups := opts.Recipients()
+	logrus.Infof("Using Google Groups as announcement target: %v", groups)
+
+	if err := m.SetGoogleGroupRecipients(groups...); err != nil {
+		return fmt.Errorf("unable to set mail recipients: %w", err)
+	}
+
+	// Strict receivers junk bulk mails without list headers, which is why
+	// we use the ones of the announcement group.
+	m.SetGoogleGroupListHeaders(groups[0])
+
+	if opts.DKIM != nil {
+		logrus.Infof("Signing mail with DKIM key of domain %s", opts.DKIM.Domain)
+		if err := m.SetDKIM(opts.DKIM); err != nil {
+			return fmt.Errorf("unable to enable DKIM signing: %w", err)
+		}
+	}
+
+	if opts.Confirm != nil {
+		yes, err := opts.Confirm()
+		if err != nil {
+			return fmt.Errorf("confirm sending mail: %w", err)
+		}
+		if !yes {
+			logrus.Info("Not sending mail")
+			return nil
+		}
+	}
+
+	logrus.Info("Sending mail")
+	subject := opts.Subject()
+	if err := m.Send(content, subject); err != nil {
+		return fmt.Errorf("unable to send mail: %w", err)
+	}
+
+	if opts.Archive == nil {
+		return nil
+	}
+
+	logrus.Info("Archiving announcement")
+	if _, err := NewArchiver(opts.Archive).Archive(opts.Tag, subject, content); err != nil {
+		return fmt.Errorf("mail sent, but archiving failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/httpreplay"
+	"k8s.io/release/pkg/mail"
+)
+
+func TestFetch(t *testing.T) {
+	httpreplay.Use(t, "testdata/cassettes/fetch.yaml")
+
+	content, err := announce.Fetch("1.30.0")
+	require.NoError(t, err)
+	require.Contains(t, content, "Kubernetes Community is proud to announce")
+}
+
+func TestSendOptionsValidate(t *testing.T) {
+	for _, tc := range []struct {
+		opts        *announce.SendOptions
+		shouldError bool
+		err         error
+	}{
+		{ // success
+			opts: &announce.SendOptions{Tag: "v1.30.0", SendgridAPIKey: "key"},
+		},
+		{ // success with DKIM
+			opts: &announce.SendOptions{
+				Tag: "v1.30.0", SendgridAPIKey: "key",
+				DKIM: &mail.DKIMOptions{Domain: "kubernetes.io", Selector: "krel", PrivateKey: "key.pem"},
+			},
+		},
+		{ // incomplete DKIM
+			opts: &announce.SendOptions{
+				Tag: "v1.30.0", SendgridAPIKey: "key",
+				DKIM: &mail.DKIMOptions{Domain: "kubernetes.io"},
+			},
+			shouldError: true,
+		},
+		{ // no API key
+			opts:        &announce.SendOptions{Tag: "v1.30.0"},
+			shouldError: true,
+			err:         announce.ErrMissingSendgridAPIKey,
+		},
+		{ // no tag
+			opts:        &announce.SendOptions{SendgridAPIKey: "key"},
+			shouldError: true,
+			err:         announce.ErrMissingTag,
+		},
+	} {
+		err := tc.opts.Validate()
+		if tc.shouldError {
+			require.Error(t, err)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			}
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
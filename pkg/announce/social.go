@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/templates"
+)
+
+// DefaultSocialMaxLength is the default maximum number of characters of a
+// social announcement.
+const DefaultSocialMaxLength = 280
+
+// SocialOptions are the settings for posting the short announcement of a
+// release to a generic social webhook, like the ones of Zapier, IFTTT or
+// Buffer.
+type SocialOptions struct {
+	// Tag is the release tag to announce.
+	Tag string
+
+	// WebhookURL is the endpoint the announcement gets posted to.
+	WebhookURL string
+
+	// Template is the name of an official template or the path to a custom
+	// one. The official social announcement template is used if empty.
+	Template string
+
+	// MaxLength is the maximum number of characters of the rendered
+	// announcement, which is not limited if zero.
+	MaxLength int
+
+	// Confirm gets called right before posting if set. The announcement will
+	// not be posted if it returns false.
+	Confirm func() (bool, error)
+}
+
+// DefaultSocialOptions returns a new default SocialOptions instance.
+func DefaultSocialOptions() *SocialOptions {
+	return &SocialOptions{MaxLength: DefaultSocialMaxLength}
+}
+
+// Validate checks if the options are correctly set.
+func (o *SocialOptions) Validate() error {
+	if o.Tag == "" {
+		return fmt.Errorf("cannot post social announcement: %w", ErrMissingTag)
+	}
+	if o.WebhookURL == "" {
+		return fmt.Errorf("cannot post social announcement: %w", ErrMissingWebhookURL)
+	}
+	return nil
+}
+
+// SocialPost is the JSON payload posted to the webhook. Automation services
+// can map its fields to the post of any social network.
+type SocialPost struct {
+	// Text is the rendered short announcement.
+	Text string `json:"text"`
+
+	// Tag is the announced release tag.
+	Tag string `json:"tag"`
+
+	// URL is the GitHub release page of the tag.
+	URL string `json:"url"`
+}
+
+// socialData is the data available in the social announcement template.
+type socialData struct {
+	Product    string
+	Tag        string
+	ReleaseURL string
+}
+
+// SocialPoster renders and posts the short announcement of a release.
+type SocialPoster struct {
+	impl    socialImpl
+	options *SocialOptions
+}
+
+// NewSocialPoster returns a new SocialPoster instance.
+func NewSocialPoster(options *SocialOptions) *SocialPoster {
+	return &SocialPoster{&defaultSocialImpl{}, options}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (s *SocialPoster) SetImpl(impl socialImpl) {
+	s.impl = impl
+}
+
+// Render returns the post of the short announcement.
+func (s *SocialPoster) Render() (*SocialPost, error) {
+	if s.options.Tag == "" {
+		return nil, fmt.Errorf("cannot render social announcement: %w", ErrMissingTag)
+	}
+	tag := util.AddTagPrefix(s.options.Tag)
+	data := &socialData{
+		Product:    branding.Default().ProductName,
+		Tag:        tag,
+		ReleaseURL: "https://github.com/kubernetes/kubernetes/releases/tag/" + tag,
+	}
+
+	name := s.options.Template
+	if name == "" {
+		name = templates.SocialAnnouncement
+	}
+	content, err := templates.Read(name)
+	if err != nil {
+		return nil, fmt.Errorf("read template: %w", err)
+	}
+	t, err := template.New("social").Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("execute template: %w", err)
+	}
+
+	text := strings.TrimSpace(buf.String())
+	if length := utf8.RuneCountInString(text); s.options.MaxLength > 0 && length > s.options.MaxLength {
+		return nil, fmt.Errorf(
+			"social announcement has %d of %d allowed characters: %w",
+			length, s.options.MaxLength, ErrPostTooLong,
+		)
+	}
+	return &SocialPost{Text: text, Tag: tag, URL: data.ReleaseURL}, nil
+}
+
+// Post renders the short announcement and posts it to the webhook. It
+// returns the post, even if it did not get confirmed.
+func (s *SocialPoster) Post() (*SocialPost, error) {
+	if err := s.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating social announcement options: %w", err)
+	}
+
+	post, err := s.Render()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.options.Confirm != nil {
+		yes, err := s.options.Confirm()
+		if err != nil {
+			return post, fmt.Errorf("confirm posting social announcement: %w", err)
+		}
+		if !yes {
+			logrus.Info("Not posting social announcement")
+			return post, nil
+		}
+	}
+
+	body, err := json.Marshal(post)
+	if err != nil {
+		return post, fmt.Errorf("marshal social announcement: %w", err)
+	}
+
+	logrus.Info("Posting social announcement")
+	if err := s.impl.PostJSON(s.options.WebhookURL, body); err != nil {
+		return post, fmt.Errorf("post social announcement: %w", err)
+	}
+	return post, nil
+}
+
+//counterfeiter:generate . socialImpl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt announcefakes/fake_social_impl.go > announcefakes/_fake_social_impl.go && mv announcefakes/_fake_social_impl.go announcefakes/fake_social_impl.go"
+type socialImpl interface {
+	PostJSON(url string, body []byte) error
+}
+
+type defaultSocialImpl struct{}
+
+func (*defaultSocialImpl) PostJSON(url string, body []byte) error {
+	client := &http.Client{Timeout: 30 * time.Second}
+	res, err := client.Post(url, "application/json", bytes.NewReader(body)) //nolint:noctx // the webhook is configured by the user
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		resBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			return fmt.Errorf("read response body: %w", err)
+		}
+		return fmt.Errorf("webhook returned HTTP status %d: %s", res.StatusCode, resBody)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/announce/announcefakes"
+)
+
+func TestSocialPost(t *testing.T) {
+	customTemplate := filepath.Join(t.TempDir(), "social.tmpl")
+	require.NoError(t, os.WriteFile(customTemplate, []byte("{{ .Tag }} is out, {{ .Product }} fans!\n"), 0o600))
+	longTemplate := filepath.Join(t.TempDir(), "long.tmpl")
+	require.NoError(t, os.WriteFile(longTemplate, []byte("{{ .ReleaseURL }} {{ .ReleaseURL }}"), 0o600))
+
+	for _, tc := range []struct {
+		name     string
+		prepare  func(*announce.SocialOptions, *announcefakes.FakeSocialImpl)
+		expected string
+		posts    int
+		postErr  bool
+		err      error
+	}{
+		{
+			name:     "official template",
+			expected: "Kubernetes v1.30.0 is live! Check out the release notes: https://github.com/kubernetes/kubernetes/releases/tag/v1.30.0",
+			posts:    1,
+		},
+		{
+			name: "custom template",
+			prepare: func(opts *announce.SocialOptions, _ *announcefakes.FakeSocialImpl) {
+				opts.Template = customTemplate
+			},
+			expected: "v1.30.0 is out, Kubernetes fans!",
+			posts:    1,
+		},
+		{
+			name: "not confirmed",
+			prepare: func(opts *announce.SocialOptions, _ *announcefakes.FakeSocialImpl) {
+				opts.Confirm = func() (bool, error) { return false, nil }
+			},
+			expected: "Kubernetes v1.30.0 is live! Check out the release notes: https://github.com/kubernetes/kubernetes/releases/tag/v1.30.0",
+		},
+		{
+			name: "too long",
+			prepare: func(opts *announce.SocialOptions, _ *announcefakes.FakeSocialImpl) {
+				opts.Template = longTemplate
+				opts.MaxLength = 100
+			},
+			err: announce.ErrPostTooLong,
+		},
+		{
+			name: "missing webhook URL",
+			prepare: func(opts *announce.SocialOptions, _ *announcefakes.FakeSocialImpl) {
+				opts.WebhookURL = ""
+			},
+			err: announce.ErrMissingWebhookURL,
+		},
+		{
+			name: "missing tag",
+			prepare: func(opts *announce.SocialOptions, _ *announcefakes.FakeSocialImpl) {
+				opts.Tag = ""
+			},
+			err: announce.ErrMissingTag,
+		},
+		{
+			name: "failure on posting",
+			prepare: func(_ *announce.SocialOptions, mock *announcefakes.FakeSocialImpl) {
+				mock.PostJSONReturns(errors.New("status 500"))
+			},
+			posts:   1,
+			postErr: true,
+		},
+	} {
+		opts := announce.DefaultSocialOptions()
+		opts.Tag = "1.30.0"
+		opts.WebhookURL = "https://hooks.example.com/social"
+		mock := &announcefakes.FakeSocialImpl{}
+		if tc.prepare != nil {
+			tc.prepare(opts, mock)
+		}
+
+		sut := announce.NewSocialPoster(opts)
+		sut.SetImpl(mock)
+
+		post, err := sut.Post()
+		require.Equal(t, tc.posts, mock.PostJSONCallCount(), tc.name)
+		if tc.err != nil {
+			require.ErrorIs(t, err, tc.err, tc.name)
+			continue
+		}
+		if tc.postErr {
+			require.Error(t, err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expected, post.Text, tc.name)
+
+		if tc.posts > 0 {
+			url, body := mock.PostJSONArgsForCall(0)
+			require.Equal(t, opts.WebhookURL, url)
+			payload := &announce.SocialPost{}
+			require.NoError(t, json.Unmarshal(body, payload))
+			require.Equal(t, post, payload)
+			require.Equal(t, "v1.30.0", payload.Tag)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/retry"
+)
+
+var (
+	urlRe    = regexp.MustCompile(`https?://[^\s"'<>]+`)
+	imageRe  = regexp.MustCompile(`registry\.k8s\.io/[a-z0-9][a-z0-9./_-]*:[A-Za-z0-9][A-Za-z0-9._-]*(@sha256:[a-f0-9]{64})?`)
+	sha512Re = regexp.MustCompile(`\b[a-f0-9]{128}\b`)
+)
+
+// VerifyOptions are the settings for verifying the references of a release
+// announcement.
+type VerifyOptions struct {
+	// Tag is the announced release tag, used to probe the canonical
+	// download URLs.
+	Tag string
+
+	// Strict fails the verification if any reference is broken. Otherwise
+	// the problems are only reported as warnings.
+	Strict bool
+}
+
+// Problem is a broken reference in the announcement.
+type Problem struct {
+	// Reference is the URL or image reference.
+	Reference string `json:"reference"`
+
+	// Message describes why the reference is broken.
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Reference, p.Message)
+}
+
+// Verifier probes the URLs, checksums and image references of a release
+// announcement.
+type Verifier struct {
+	impl    verifyImpl
+	options *VerifyOptions
+}
+
+// NewVerifier returns a new Verifier instance.
+func NewVerifier(options *VerifyOptions) *Verifier {
+	return &Verifier{&defaultVerifyImpl{}, options}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (v *Verifier) SetImpl(impl verifyImpl) {
+	v.impl = impl
+}
+
+// Verify probes all references of the announcement content and returns the
+// found problems. An error is returned in strict mode if there are any.
+func (v *Verifier) Verify(content string) ([]Problem, error) {
+	problems := []Problem{}
+
+	urls := announcementURLs(content)
+	if v.options.Tag != "" {
+		urls = appendUnique(urls, CanonicalURLs(v.options.Tag)...)
+	}
+	logrus.Infof("Probing %d URLs of the announcement", len(urls))
+	for _, u := range urls {
+		status, err := v.impl.StatusCode(u)
+		if err != nil {
+			problems = append(problems, Problem{u, fmt.Sprintf("request failed: %v", err)})
+			continue
+		}
+		if status >= http.StatusBadRequest {
+			problems = append(problems, Problem{u, fmt.Sprintf("returned status %d", status)})
+		}
+	}
+
+	checksums := announcementChecksums(content)
+	logrus.Infof("Verifying %d artifact checksums of the announcement", len(checksums))
+	for _, u := range sortedKeys(checksums) {
+		remote, err := v.impl.Get(u + ".sha512")
+		if err != nil {
+			problems = append(problems, Problem{u, fmt.Sprintf("get published checksum: %v", err)})
+			continue
+		}
+		if fields := strings.Fields(remote); len(fields) == 0 || fields[0] != checksums[u] {
+			problems = append(problems, Problem{u, "announced SHA512 does not match the published one"})
+		}
+	}
+
+	images := announcementImages(content)
+	logrus.Infof("Verifying %d image references of the announcement", len(images))
+	for _, image := range images {
+		ref, announcedDigest, _ := strings.Cut(image, "@")
+		digest, err := v.impl.ImageDigest(ref)
+		if err != nil {
+			problems = append(problems, Problem{image, fmt.Sprintf("resolve image: %v", err)})
+			continue
+		}
+		if announcedDigest != "" && announcedDigest != digest {
+			problems = append(problems, Problem{image, "announced digest does not match " + digest})
+		}
+	}
+
+	if len(problems) == 0 {
+		logrus.Info("All references of the announcement are valid")
+		return problems, nil
+	}
+
+	for _, p := range problems {
+		logrus.Warnf("Broken announcement reference %s", p)
+	}
+	if v.options.Strict {
+		return problems, fmt.Errorf("found %d %w in the announcement", len(problems), ErrBrokenReferences)
+	}
+	return problems, nil
+}
+
+// CanonicalURLs returns the download URLs and links which every release
+// announcement has to provide.
+func CanonicalURLs(tag string) []string {
+	tag = util.AddTagPrefix(tag)
+	b := branding.Default()
+	return []string{
+		b.DownloadURL(tag, "kubernetes-src.tar.gz"),
+		b.DownloadURL("release", tag, "bin/linux/amd64/kubectl"),
+		fmt.Sprintf("https://github.com/kubernetes/kubernetes/releases/tag/%s", tag),
+	}
+}
+
+// announcementURLs returns all unique URLs of the content.
+func announcementURLs(content string) []string {
+	res := []string{}
+	for _, u := range urlRe.FindAllString(content, -1) {
+		res = appendUnique(res, strings.TrimRight(u, ".,;:)"))
+	}
+	return res
+}
+
+// announcementChecksums returns the SHA512 checksums of the downloads from
+// the download host, which are listed in the same table row as their link.
+func announcementChecksums(content string) map[string]string {
+	res := map[string]string{}
+	prefix := branding.Default().DownloadURL() + "/"
+	for _, row := range strings.Split(content, "<tr") {
+		checksum := sha512Re.FindString(row)
+		if checksum == "" {
+			continue
+		}
+		for _, u := range urlRe.FindAllString(row, -1) {
+			if strings.HasPrefix(u, prefix) {
+				res[u] = checksum
+				break
+			}
+		}
+	}
+	return res
+}
+
+// announcementImages returns all unique registry.k8s.io image references of
+// the content.
+func announcementImages(content string) []string {
+	res := []string{}
+	for _, image := range imageRe.FindAllString(content, -1) {
+		res = appendUnique(res, strings.TrimRight(image, ".-_"))
+	}
+	return res
+}
+
+func appendUnique(list []string, elems ...string) []string {
+	for _, elem := range elems {
+		found := false
+		for _, existing := range list {
+			if existing == elem {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, elem)
+		}
+	}
+	return list
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . verifyImpl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt announcefakes/fake_verify_impl.go > announcefakes/_fake_verify_impl.go && mv announcefakes/_fake_verify_impl.go announcefakes/fake_verify_impl.go"
+type verifyImpl interface {
+	StatusCode(url string) (int, error)
+	Get(url string) (string, error)
+	ImageDigest(ref string) (string, error)
+}
+
+type defaultVerifyImpl struct{}
+
+func (*defaultVerifyImpl) StatusCode(url string) (int, error) {
+	req, err := http.NewRequest(http.MethodHead, url, http.NoBody)
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func (*defaultVerifyImpl) Get(url string) (string, error) {
+	resp, err := http.Get(url) //nolint:gosec,noctx // the URLs are part of the announcement
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("returned status %d", resp.StatusCode)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read response body: %w", err)
+	}
+	return string(content), nil
+}
+
+func (*defaultVerifyImpl) ImageDigest(ref string) (string, error) {
+	var digest string
+	err := retry.Do(context.Background(), retry.ServiceRegistry, func() (err error) {
+		digest, err = crane.Digest(ref)
+		return err
+	})
+	return digest, err
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announce_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/announce/announcefakes"
+)
+
+var (
+	testSHA512 = strings.Repeat("ab", 64)
+	testDigest = "sha256:" + strings.Repeat("cd", 32)
+)
+
+const testAnnouncement = `Kubernetes Community,
+<p>
+The release notes have been updated in
+<a href=https://git.k8s.io/kubernetes/CHANGELOG/CHANGELOG-1.30.md>CHANGELOG-1.30.md</a>, with a pointer to them on
+<a href=https://github.com/kubernetes/kubernetes/releases/tag/v1.30.0>GitHub</a>:
+<table>
+<tr><td><a href="https://dl.k8s.io/v1.30.0/kubernetes.tar.gz">kubernetes.tar.gz</a></td><td>%s</td></tr>
+</table>
+<p>
+Images: registry.k8s.io/kube-apiserver:v1.30.0@%s and registry.k8s.io/kube-proxy:v1.30.0.
+`
+
+func TestVerify(t *testing.T) {
+	content := strings.Replace(testAnnouncement, "%s", testSHA512, 1)
+	content = strings.Replace(content, "%s", testDigest, 1)
+
+	for _, tc := range []struct {
+		strict      bool
+		prepare     func(*announcefakes.FakeVerifyImpl)
+		problems    int
+		shouldError bool
+	}{
+		{ // success
+			prepare: func(mock *announcefakes.FakeVerifyImpl) {
+				mock.StatusCodeReturns(http.StatusOK, nil)
+				mock.GetReturns(testSHA512+"  kubernetes.tar.gz", nil)
+				mock.ImageDigestReturns(testDigest, nil)
+			},
+			problems:    0,
+			shouldError: false,
+		},
+		{ // broken URL in non strict mode
+			prepare: func(mock *announcefakes.FakeVerifyImpl) {
+				mock.StatusCodeReturns(http.StatusOK, nil)
+				mock.StatusCodeReturnsOnCall(1, http.StatusNotFound, nil)
+				mock.GetReturns(testSHA512, nil)
+				mock.ImageDigestReturns(testDigest, nil)
+			},
+			problems:    1,
+			shouldError: false,
+		},
+		{ // broken URL in strict mode
+			strict: true,
+			prepare: func(mock *announcefakes.FakeVerifyImpl) {
+				mock.StatusCodeReturns(0, errors.New("test"))
+				mock.GetReturns(testSHA512, nil)
+				mock.ImageDigestReturns(testDigest, nil)
+			},
+			problems:    5,
+			shouldError: true,
+		},
+		{ // checksum mismatch
+			strict: true,
+			prepare: func(mock *announcefakes.FakeVerifyImpl) {
+				mock.StatusCodeReturns(http.StatusOK, nil)
+				mock.GetReturns("wrong", nil)
+				mock.ImageDigestReturns(testDigest, nil)
+			},
+			problems:    1,
+			shouldError: true,
+		},
+		{ // image digest mismatch
+			strict: true,
+			prepare: func(mock *announcefakes.FakeVerifyImpl) {
+				mock.StatusCodeReturns(http.StatusOK, nil)
+				mock.GetReturns(testSHA512, nil)
+				mock.ImageDigestReturns("sha256:wrong", nil)
+			},
+			problems:    1,
+			shouldError: true,
+		},
+		{ // image does not exist
+			strict: true,
+			prepare: func(mock *announcefakes.FakeVerifyImpl) {
+				mock.StatusCodeReturns(http.StatusOK, nil)
+				mock.GetReturns(testSHA512, nil)
+				mock.ImageDigestReturnsOnCall(0, testDigest, nil)
+				mock.ImageDigestReturnsOnCall(1, "", errors.New("test"))
+			},
+			problems:    1,
+			shouldError: true,
+		},
+	} {
+		sut := announce.NewVerifier(&announce.VerifyOptions{Tag: "1.30.0", Strict: tc.strict})
+		mock := &announcefakes.FakeVerifyImpl{}
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+
+		problems, err := sut.Verify(content)
+		require.Len(t, problems, tc.problems)
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+
+		// 3 links of the announcement and 2 additional canonical URLs
+		require.Equal(t, 5, mock.StatusCodeCallCount())
+		require.Equal(t, "https://dl.k8s.io/v1.30.0/kubernetes.tar.gz.sha512", mock.GetArgsForCall(0))
+		require.Equal(t, "registry.k8s.io/kube-apiserver:v1.30.0", mock.ImageDigestArgsForCall(0))
+		require.Equal(t, "registry.k8s.io/kube-proxy:v1.30.0", mock.ImageDigestArgsForCall(1))
+	}
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package approver verifies that the invoking user is an authorized release
+// or patch release manager of the target branch, based on the SIG Release
+// teams data, before running mutating operations in no-mock mode.
+package approver
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/release-utils/env"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// TeamsEnvKey is the environment variable containing the default path or
+	// URL of the teams data.
+	TeamsEnvKey = "KREL_APPROVER_TEAMS"
+
+	// DefaultTeam is the team authorized for all branches by default.
+	DefaultTeam = "release-managers"
+)
+
+// Teams maps the team names to the GitHub logins of their members, which
+// include the members of nested teams.
+type Teams map[string][]string
+
+// team is a team of the teams data as maintained in kubernetes/org.
+type team struct {
+	Members     []string        `json:"members,omitempty"`
+	Maintainers []string        `json:"maintainers,omitempty"`
+	Teams       map[string]team `json:"teams,omitempty"`
+}
+
+// Parse returns the teams of the provided teams data, like the
+// `config/kubernetes/sig-release/teams.yaml` file of kubernetes/org.
+func Parse(content []byte) (Teams, error) {
+	data := struct {
+		Teams map[string]team `json:"teams"`
+	}{}
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("parse teams data: %w", err)
+	}
+	teams := Teams{}
+	for name := range data.Teams {
+		collect(teams, name, data.Teams[name])
+	}
+	return teams, nil
+}
+
+// collect adds the team and its nested teams, and returns the members of the
+// team including the nested ones.
+func collect(teams Teams, name string, t team) []string {
+	logins := map[string]bool{}
+	for _, login := range append(t.Members, t.Maintainers...) {
+		logins[strings.ToLower(login)] = true
+	}
+	for child := range t.Teams {
+		for _, login := range collect(teams, child, t.Teams[child]) {
+			logins[login] = true
+		}
+	}
+	members := make([]string, 0, len(logins))
+	for login := range logins {
+		members = append(members, login)
+	}
+	sort.Strings(members)
+	teams[name] = members
+	return members
+}
+
+// Options are the approver settings.
+type Options struct {
+	// Teams is the path or URL of the teams data. Approvals are not enforced
+	// if it is empty.
+	Teams string
+
+	// Rules are the teams authorized for mutating operations, in the format
+	// `team` for all branches or `team=branch-pattern` for the branches
+	// matching the pattern, like `release-1.30-managers=release-1.30`.
+	Rules []string
+
+	// TrustedIdentities are GitHub logins which are authorized without being
+	// member of a team, like the one of the token used by Google Cloud Build.
+	TrustedIdentities []string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Teams: env.Default(TeamsEnvKey, ""),
+		Rules: []string{DefaultTeam},
+	}
+}
+
+// AddFlags adds the approver flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Teams,
+		"approver-teams",
+		o.Teams,
+		fmt.Sprintf("path or URL of the SIG Release teams data used to verify that mutating operations are run by release managers (default $%s)", TeamsEnvKey),
+	)
+	flags.StringSliceVar(
+		&o.Rules,
+		"approver-rules",
+		o.Rules,
+		"teams authorized for mutating operations, as 'team' for all branches or 'team=branch-pattern'",
+	)
+	flags.StringSliceVar(
+		&o.TrustedIdentities,
+		"approver-trusted-identities",
+		o.TrustedIdentities,
+		"GitHub logins authorized without team membership, like the one of the token used by Google Cloud Build",
+	)
+}
+
+// rule authorizes the members of a team for the branches matching a pattern.
+type rule struct {
+	team, pattern string
+}
+
+func (r rule) matches(branch string) bool {
+	if r.pattern == "" {
+		return true
+	}
+	ok, err := path.Match(r.pattern, branch)
+	return err == nil && ok
+}
+
+// Guard refuses operations by users who are not authorized for a branch.
+type Guard struct {
+	impl    impl
+	teams   Teams
+	rules   []rule
+	trusted map[string]bool
+}
+
+// NewGuard creates a new Guard for the provided teams and options.
+func NewGuard(teams Teams, opts *Options) (*Guard, error) {
+	g := &Guard{
+		impl:    &defaultImpl{},
+		teams:   teams,
+		trusted: map[string]bool{},
+	}
+	for _, r := range opts.Rules {
+		name, pattern, _ := strings.Cut(strings.TrimSpace(r), "=")
+		if name == "" {
+			return nil, fmt.Errorf("invalid approver rule %q", r)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid branch pattern of approver rule %q: %w", r, err)
+		}
+		if _, ok := teams[name]; !ok {
+			return nil, fmt.Errorf("team %s of approver rule %q not found in teams data", name, r)
+		}
+		g.rules = append(g.rules, rule{team: name, pattern: pattern})
+	}
+	if len(g.rules) == 0 {
+		return nil, errors.New("at least one approver rule is required")
+	}
+	for _, identity := range opts.TrustedIdentities {
+		g.trusted[strings.ToLower(identity)] = true
+	}
+	return g, nil
+}
+
+// SetImpl can be used to set the internal implementation.
+func (g *Guard) SetImpl(impl impl) {
+	g.impl = impl
+}
+
+// Check returns an error if the invoking user is not a member of a team
+// authorized for the operation on the provided branch. The user is always
+// the one of $GITHUB_TOKEN, because any asserted identity could be forged.
+func (g *Guard) Check(operation, branch string) error {
+	login, err := g.impl.AuthenticatedLogin()
+	if err != nil {
+		return fmt.Errorf("determine the identity for %s of %s: %w", operation, branch, err)
+	}
+	identity := strings.ToLower(login)
+
+	if g.trusted[identity] {
+		logrus.Infof("Trusting %s for %s of %s", identity, operation, branch)
+		return nil
+	}
+
+	authorized := []string{}
+	for _, r := range g.rules {
+		if !r.matches(branch) {
+			continue
+		}
+		authorized = append(authorized, r.team)
+		for _, member := range g.teams[r.team] {
+			if member == identity {
+				logrus.Infof(
+					"Authorized %s for %s of %s as member of team %s",
+					identity, operation, branch, r.team,
+				)
+				return nil
+			}
+		}
+	}
+
+	if len(authorized) == 0 {
+		return fmt.Errorf("refusing %s of %s: no team is authorized for the branch", operation, branch)
+	}
+	return fmt.Errorf(
+		"refusing %s of %s: %s is not a member of the authorized teams %s",
+		operation, branch, identity, strings.Join(authorized, ", "),
+	)
+}
+
+var (
+	mu            sync.RWMutex
+	active        *Guard
+	activeOptions *Options
+)
+
+// Setup enables the package level guard if teams data is configured.
+func Setup(opts *Options) error {
+	var guard *Guard
+	if opts.Teams != "" {
+		content, err := (&defaultImpl{}).ReadTeams(opts.Teams)
+		if err != nil {
+			return fmt.Errorf("read teams data: %w", err)
+		}
+		teams, err := Parse(content)
+		if err != nil {
+			return err
+		}
+		guard, err = NewGuard(teams, opts)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("Enforcing release manager approvals using teams data %s", opts.Teams)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	active = guard
+	activeOptions = nil
+	if guard != nil {
+		activeOptions = opts
+	}
+	return nil
+}
+
+// ActiveOptions returns the options of the package level guard, or nil if
+// no teams data is configured.
+func ActiveOptions() *Options {
+	mu.RLock()
+	defer mu.RUnlock()
+	return activeOptions
+}
+
+// Check verifies the operation using the package level guard. It does
+// nothing if no teams data is configured.
+func Check(operation, branch string) error {
+	mu.RLock()
+	guard := active
+	mu.RUnlock()
+
+	if guard == nil {
+		return nil
+	}
+	return guard.Check(operation, branch)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/approver"
+	"k8s.io/release/pkg/approver/approverfakes"
+)
+
+const testTeams = `teams:
+  release-managers:
+    description: Release Managers
+    members:
+    - Alice
+    maintainers:
+    - bob
+    teams:
+      release-engineering:
+        members:
+        - carol
+  release-1.30-managers:
+    members:
+    - dave
+`
+
+func TestParse(t *testing.T) {
+	teams, err := approver.Parse([]byte(testTeams))
+	require.NoError(t, err)
+	require.Equal(t, approver.Teams{
+		"release-managers":      {"alice", "bob", "carol"},
+		"release-engineering":   {"carol"},
+		"release-1.30-managers": {"dave"},
+	}, teams)
+
+	_, err = approver.Parse([]byte("teams: ["))
+	require.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	teams, err := approver.Parse([]byte(testTeams))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		opts     *approver.Options
+		login    string
+		loginErr error
+		branch   string
+		err      bool
+	}{
+		{
+			name:   "member of default team",
+			opts:   &approver.Options{Rules: []string{"release-managers"}},
+			login:  "ALICE",
+			branch: "release-1.30",
+		},
+		{
+			name:   "member of nested team",
+			opts:   &approver.Options{Rules: []string{"release-managers"}},
+			login:  "carol",
+			branch: "master",
+		},
+		{
+			name:   "not a member",
+			opts:   &approver.Options{Rules: []string{"release-managers"}},
+			login:  "dave",
+			branch: "release-1.30",
+			err:    true,
+		},
+		{
+			name: "member of branch team",
+			opts: &approver.Options{
+				Rules: []string{"release-managers", "release-1.30-managers=release-1.30"},
+			},
+			login:  "dave",
+			branch: "release-1.30",
+		},
+		{
+			name: "branch team for other branch",
+			opts: &approver.Options{
+				Rules: []string{"release-managers", "release-1.30-managers=release-1.30"},
+			},
+			login:  "dave",
+			branch: "release-1.29",
+			err:    true,
+		},
+		{
+			name:   "no team for branch",
+			opts:   &approver.Options{Rules: []string{"release-1.30-managers=release-1.30"}},
+			login:  "alice",
+			branch: "master",
+			err:    true,
+		},
+		{
+			name: "trusted identity",
+			opts: &approver.Options{
+				Rules:             []string{"release-managers"},
+				TrustedIdentities: []string{"k8s-release-robot"},
+			},
+			login:  "K8s-Release-Robot",
+			branch: "release-1.30",
+		},
+		{
+			name:   "identity of the token",
+			opts:   &approver.Options{Rules: []string{"release-managers"}},
+			login:  "bob",
+			branch: "release-1.30",
+		},
+		{
+			name: "trusted identity of another token",
+			opts: &approver.Options{
+				Rules:             []string{"release-managers"},
+				TrustedIdentities: []string{"k8s-release-robot"},
+			},
+			login:  "mallory",
+			branch: "release-1.30",
+			err:    true,
+		},
+		{
+			name:     "failure on getting the identity",
+			opts:     &approver.Options{Rules: []string{"release-managers"}},
+			loginErr: errors.New(""),
+			branch:   "release-1.30",
+			err:      true,
+		},
+	} {
+		guard, err := approver.NewGuard(teams, tc.opts)
+		require.NoError(t, err, tc.name)
+		mock := &approverfakes.FakeImpl{}
+		mock.AuthenticatedLoginReturns(tc.login, tc.loginErr)
+		guard.SetImpl(mock)
+
+		err = guard.Check("stage", tc.branch)
+		if tc.err {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
+
+func TestNewGuardInvalidRules(t *testing.T) {
+	teams, err := approver.Parse([]byte(testTeams))
+	require.NoError(t, err)
+
+	for _, rules := range [][]string{
+		{},
+		{"=release-1.30"},
+		{"unknown-team"},
+		{"release-managers=release-["},
+	} {
+		_, err := approver.NewGuard(teams, &approver.Options{Rules: rules})
+		require.Error(t, err, rules)
+	}
+}
+
+func TestSetup(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, approver.Setup(&approver.Options{})) })
+
+	require.NoError(t, approver.Setup(&approver.Options{}))
+	require.NoError(t, approver.Check("stage", "master"))
+	require.Nil(t, approver.ActiveOptions())
+
+	teamsFile := filepath.Join(t.TempDir(), "teams.yaml")
+	require.NoError(t, os.WriteFile(teamsFile, []byte(testTeams), 0o600))
+	opts := &approver.Options{
+		Teams: teamsFile,
+		Rules: []string{"release-managers"},
+	}
+	require.NoError(t, approver.Setup(opts))
+	require.Equal(t, opts, approver.ActiveOptions())
+
+	require.Error(t, approver.Setup(&approver.Options{Teams: filepath.Join(t.TempDir(), "missing.yaml")}))
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"sigs.k8s.io/release-sdk/github"
+	khttp "sigs.k8s.io/release-utils/http"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt approverfakes/fake_impl.go > approverfakes/_fake_impl.go && mv approverfakes/_fake_impl.go approverfakes/fake_impl.go"
+type impl interface {
+	ReadTeams(location string) ([]byte, error)
+	AuthenticatedLogin() (string, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) ReadTeams(location string) ([]byte, error) {
+	if strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://") {
+		content, err := khttp.NewAgent().Get(location)
+		if err != nil {
+			return nil, fmt.Errorf("download teams data: %w", err)
+		}
+		return content, nil
+	}
+	return os.ReadFile(location)
+}
+
+func (*defaultImpl) AuthenticatedLogin() (string, error) {
+	token := os.Getenv(github.TokenEnvKey)
+	if token == "" {
+		return "", fmt.Errorf("$%s is not set", github.TokenEnvKey)
+	}
+	user, _, err := gogithub.NewClient(nil).WithAuthToken(token).Users.Get(
+		context.Background(), "",
+	)
+	if err != nil {
+		return "", fmt.Errorf("get authenticated user: %w", err)
+	}
+	if user.GetLogin() == "" {
+		return "", errors.New("authenticated user has no login")
+	}
+	return user.GetLogin(), nil
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactdiff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/release"
+)
+
+// The statuses of an artifact anomaly between two releases.
+const (
+	// StatusAdded means that the artifact did not exist in the previous
+	// release.
+	StatusAdded = "added"
+
+	// StatusRemoved means that the artifact of the previous release does
+	// not exist any more.
+	StatusRemoved = "removed"
+)
+
+// versionPlaceholder replaces the version in artifact paths, which makes the
+// paths of different releases comparable.
+const versionPlaceholder = "<version>"
+
+// DefaultAllowRemoved are the artifacts which get published after staging,
+// and therefore never exist in the staged artifacts.
+var DefaultAllowRemoved = []string{"*.sig", "*.cert"}
+
+// Anomaly is an artifact which got added or removed compared to the previous
+// release.
+type Anomaly struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+
+	// Allowed is true if the change is allowlisted.
+	Allowed bool `json:"allowed"`
+}
+
+// AnomalyReport is the result of comparing the artifact set of a version with
+// the one of the previous release.
+type AnomalyReport struct {
+	Version         string     `json:"version"`
+	PreviousVersion string     `json:"previousVersion"`
+	Anomalies       []*Anomaly `json:"anomalies"`
+}
+
+// Unexpected returns all anomalies which are not allowlisted.
+func (r *AnomalyReport) Unexpected() []*Anomaly {
+	res := []*Anomaly{}
+	for _, a := range r.Anomalies {
+		if !a.Allowed {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+// Markdown returns a markdown table of all anomalies in the report.
+func (r *AnomalyReport) Markdown() string {
+	buf := &bytes.Buffer{}
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeader([]string{"Path", "Status", "Allowed"})
+	for _, a := range r.Anomalies {
+		table.Append([]string{a.Path, a.Status, fmt.Sprint(a.Allowed)})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+	return buf.String()
+}
+
+// PreviousRelease returns the release to compare the artifact set of the
+// version with, which is the previous patch release for patch releases and
+// the previous minor release for minor releases. Pre-releases have no
+// previous release which is expected to have the same artifacts.
+func PreviousRelease(version string) (string, error) {
+	sv, err := util.TagStringToSemver(version)
+	if err != nil {
+		return "", fmt.Errorf("invalid version %s: %w", version, err)
+	}
+	switch {
+	case len(sv.Pre) > 0:
+		return "", fmt.Errorf("no previous release can be derived for pre-release %s", version)
+	case sv.Patch > 0:
+		return fmt.Sprintf("v%d.%d.%d", sv.Major, sv.Minor, sv.Patch-1), nil
+	case sv.Minor > 0:
+		return fmt.Sprintf("v%d.%d.0", sv.Major, sv.Minor-1), nil
+	}
+	return "", fmt.Errorf("no previous release can be derived for %s", version)
+}
+
+// CompareWithPrevious compares the set of staged artifacts of the version
+// with the released artifacts of PreviousVersion, which flags missing or new
+// artifacts, like a missing architecture, before the version gets released.
+// Only the artifact paths are compared, not their digests.
+func (c *Comparer) CompareWithPrevious() (*AnomalyReport, error) {
+	if err := c.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+	if c.options.PreviousVersion == "" {
+		return nil, errors.New("no previous version specified")
+	}
+
+	version := util.AddTagPrefix(c.options.Version)
+	previous := util.AddTagPrefix(c.options.PreviousVersion)
+	stagePath := layout.Default().StagePath(
+		c.options.Bucket, c.options.BuildVersion, version, release.GCSStagePath, version,
+	)
+	previousPath, err := layout.Default().ReleasePath(c.options.Bucket, "release", previous, false)
+	if err != nil {
+		return nil, fmt.Errorf("get release path of %s: %w", previous, err)
+	}
+
+	logrus.Infof("Listing staged artifacts in %s", stagePath)
+	staged, err := c.impl.ListObjects(stagePath)
+	if err != nil {
+		return nil, fmt.Errorf("list staged artifacts: %w", err)
+	}
+	if len(staged) == 0 {
+		return nil, fmt.Errorf("no staged artifacts found in %s", stagePath)
+	}
+	logrus.Infof("Listing artifacts of %s in %s", previous, previousPath)
+	released, err := c.impl.ListObjects(previousPath)
+	if err != nil {
+		return nil, fmt.Errorf("list artifacts of %s: %w", previous, err)
+	}
+	if len(released) == 0 {
+		return nil, fmt.Errorf("no artifacts of %s found in %s", previous, previousPath)
+	}
+
+	current := normalizedPaths(staged, version)
+	before := normalizedPaths(released, previous)
+	report := &AnomalyReport{
+		Version:         version,
+		PreviousVersion: previous,
+		Anomalies:       []*Anomaly{},
+	}
+	all := map[string]struct{}{}
+	for p := range current {
+		all[p] = struct{}{}
+	}
+	for p := range before {
+		all[p] = struct{}{}
+	}
+	for _, p := range sortedKeys(all) {
+		_, isCurrent := current[p]
+		_, isBefore := before[p]
+		switch {
+		case isCurrent && !isBefore:
+			report.Anomalies = append(report.Anomalies, &Anomaly{
+				Path: p, Status: StatusAdded, Allowed: matchesAny(p, c.options.AllowAdded),
+			})
+		case !isCurrent && isBefore:
+			report.Anomalies = append(report.Anomalies, &Anomaly{
+				Path: p, Status: StatusRemoved, Allowed: matchesAny(p, c.options.AllowRemoved),
+			})
+		}
+	}
+	return report, nil
+}
+
+// CheckAnomalies compares the artifact sets and fails if there are
+// anomalies which are not allowlisted.
+func (c *Comparer) CheckAnomalies() error {
+	report, err := c.CompareWithPrevious()
+	if err != nil {
+		return err
+	}
+	if len(report.Anomalies) > 0 {
+		fmt.Print(report.Markdown())
+	}
+	return c.CheckAnomalyReport(report)
+}
+
+// CheckAnomalyReport fails if the report contains anomalies which are not
+// allowlisted.
+func (c *Comparer) CheckAnomalyReport(report *AnomalyReport) error {
+	if unexpected := report.Unexpected(); len(unexpected) > 0 {
+		return fmt.Errorf(
+			"%d unexpected artifact changes of %s compared to %s",
+			len(unexpected), report.Version, report.PreviousVersion,
+		)
+	}
+	logrus.Infof(
+		"The artifacts of %s match the ones of %s, %d allowlisted changes",
+		report.Version, report.PreviousVersion, len(report.Anomalies),
+	)
+	return nil
+}
+
+// normalizedPaths returns the artifact paths with the version replaced by a
+// placeholder.
+func normalizedPaths(artifacts map[string]string, version string) map[string]struct{} {
+	res := map[string]struct{}{}
+	for p := range artifacts {
+		res[strings.ReplaceAll(p, version, versionPlaceholder)] = struct{}{}
+	}
+	return res
+}
+
+// matchesAny returns true if the artifact path matches any of the patterns.
+// Patterns without a slash are matched against the file name, others against
+// the full path.
+func matchesAny(artifact string, patterns []string) bool {
+	for _, pattern := range patterns {
+		name := artifact
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(artifact)
+		}
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
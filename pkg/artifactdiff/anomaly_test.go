@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactdiff_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/artifactdiff"
+	"k8s.io/release/pkg/artifactdiff/artifactdifffakes"
+)
+
+func TestPreviousRelease(t *testing.T) {
+	for _, tc := range []struct {
+		version, expected string
+		shouldErr         bool
+	}{
+		{version: "v1.30.2", expected: "v1.30.1"},
+		{version: "1.30.1", expected: "v1.30.0"},
+		{version: "v1.31.0", expected: "v1.30.0"},
+		{version: "v1.31.0-rc.1", shouldErr: true},
+		{version: "v1.0.0", shouldErr: true},
+		{version: "wrong", shouldErr: true},
+	} {
+		res, err := artifactdiff.PreviousRelease(tc.version)
+		if tc.shouldErr {
+			require.Error(t, err, tc.version)
+		} else {
+			require.NoError(t, err, tc.version)
+			require.Equal(t, tc.expected, res)
+		}
+	}
+}
+
+func TestCompareWithPrevious(t *testing.T) {
+	staged := map[string]string{
+		"kubernetes.tar.gz":                      "crc32c:00000001",
+		"kubernetes-src-v1.30.1.tar.gz":          "crc32c:00000002",
+		"bin/linux/amd64/kubectl":                "crc32c:00000003",
+		"bin/linux/ppc64le/kubectl":              "crc32c:00000004",
+		"bin/linux/riscv64/kubectl":              "crc32c:00000005",
+		"kubernetes-client-linux-amd64.tar.gz":   "crc32c:00000006",
+		"kubernetes-client-linux-ppc64le.tar.gz": "crc32c:00000007",
+	}
+	previous := map[string]string{
+		"kubernetes.tar.gz":                      "crc32c:00000011",
+		"kubernetes-src-v1.30.0.tar.gz":          "crc32c:00000012",
+		"bin/linux/amd64/kubectl":                "crc32c:00000013",
+		"bin/linux/amd64/kubectl.sig":            "crc32c:00000014",
+		"bin/linux/arm64/kubectl":                "crc32c:00000015",
+		"bin/linux/ppc64le/kubectl":              "crc32c:00000016",
+		"kubernetes-client-linux-amd64.tar.gz":   "crc32c:00000017",
+		"kubernetes-client-linux-ppc64le.tar.gz": "crc32c:00000018",
+	}
+
+	for _, tc := range []struct {
+		name       string
+		allowAdded []string
+		previous   map[string]string
+		assert     func(*testing.T, *artifactdiff.AnomalyReport, error)
+	}{
+		{
+			name:     "anomalies",
+			previous: previous,
+			assert: func(t *testing.T, report *artifactdiff.AnomalyReport, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "v1.30.0", report.PreviousVersion)
+				require.Equal(t, []*artifactdiff.Anomaly{
+					{Path: "bin/linux/amd64/kubectl.sig", Status: artifactdiff.StatusRemoved, Allowed: true},
+					{Path: "bin/linux/arm64/kubectl", Status: artifactdiff.StatusRemoved},
+					{Path: "bin/linux/riscv64/kubectl", Status: artifactdiff.StatusAdded},
+				}, report.Anomalies)
+				require.Len(t, report.Unexpected(), 2)
+				require.Contains(t, report.Markdown(), "bin/linux/arm64/kubectl")
+			},
+		},
+		{
+			name:       "allowlisted",
+			allowAdded: []string{"bin/linux/riscv64/*"},
+			previous:   previous,
+			assert: func(t *testing.T, report *artifactdiff.AnomalyReport, err error) {
+				require.NoError(t, err)
+				require.Len(t, report.Anomalies, 3)
+				require.Len(t, report.Unexpected(), 1)
+				require.Equal(t, "bin/linux/arm64/kubectl", report.Unexpected()[0].Path)
+			},
+		},
+		{
+			name:     "no previous artifacts",
+			previous: map[string]string{},
+			assert: func(t *testing.T, _ *artifactdiff.AnomalyReport, err error) {
+				require.Error(t, err)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &artifactdifffakes.FakeImpl{}
+			mock.ListObjectsCalls(func(gcsPath string) (map[string]string, error) {
+				switch gcsPath {
+				case "bucket/stage/v1.30.1-rc.0.10+abc/v1.30.1/gcs-stage/v1.30.1":
+					return staged, nil
+				case "bucket/release/v1.30.0":
+					return tc.previous, nil
+				}
+				return nil, errTest
+			})
+
+			opts := newOptions()
+			opts.BuildVersion = "v1.30.1-rc.0.10+abc"
+			opts.Version = "v1.30.1"
+			opts.PreviousVersion = "v1.30.0"
+			opts.AllowAdded = tc.allowAdded
+			sut := artifactdiff.New(opts)
+			sut.SetImpl(mock)
+
+			report, err := sut.CompareWithPrevious()
+			tc.assert(t, report, err)
+			if err == nil {
+				require.Equal(t, len(report.Unexpected()) > 0, sut.CheckAnomalies() != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,430 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package artifactdiff compares the staged artifacts of a version with the
+// released ones, which confirms that a release completed fully.
+package artifactdiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/encryption"
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/release"
+)
+
+// The kinds of the compared artifacts.
+const (
+	// KindFile is an artifact in the release bucket.
+	KindFile = "file"
+
+	// KindImage is a container image in the release registry.
+	KindImage = "image"
+)
+
+// The statuses of a compared artifact.
+const (
+	// StatusOK means that the released artifact matches the staged one.
+	StatusOK = "ok"
+
+	// StatusMissing means that the staged artifact has not been released.
+	StatusMissing = "missing"
+
+	// StatusExtra means that the released artifact has not been staged, for
+	// example because it got published after the release.
+	StatusExtra = "extra"
+
+	// StatusMismatch means that the digests of the released and staged
+	// artifact differ.
+	StatusMismatch = "digest mismatch"
+)
+
+// Options are the main options for comparing staged and released artifacts.
+type Options struct {
+	// Bucket is the bucket containing the staged and released artifacts.
+	Bucket string
+
+	// BuildVersion is the build version of the stage run.
+	BuildVersion string
+
+	// Version is the released version, for example v1.30.0.
+	Version string
+
+	// StagingRegistry is the registry the images got staged to.
+	StagingRegistry string
+
+	// ReleaseRegistry is the registry the images got promoted to. Empty
+	// means the registry of the branding.
+	ReleaseRegistry string
+
+	// ReportFile is an optional path for writing the report as JSON.
+	ReportFile string
+
+	// PreviousVersion is the release to compare the set of staged artifacts
+	// with, see CompareWithPrevious.
+	PreviousVersion string
+
+	// AllowAdded are path patterns of artifacts which are expected to be
+	// added compared to the previous release.
+	AllowAdded []string
+
+	// AllowRemoved are path patterns of artifacts which are expected to be
+	// removed compared to the previous release.
+	AllowRemoved []string
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Bucket:          release.ProductionBucket,
+		StagingRegistry: release.GCRIOPathStaging,
+		AllowRemoved:    slices.Clone(DefaultAllowRemoved),
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Bucket == "" {
+		return errors.New("no bucket specified")
+	}
+	if o.BuildVersion == "" {
+		return errors.New("no build version specified")
+	}
+	if o.Version == "" {
+		return errors.New("no version specified")
+	}
+	if _, err := util.TagStringToSemver(o.Version); err != nil {
+		return fmt.Errorf("invalid version %s: %w", o.Version, err)
+	}
+	if o.StagingRegistry == "" {
+		return errors.New("no staging registry specified")
+	}
+	return nil
+}
+
+// Result is the comparison result of a single artifact.
+type Result struct {
+	Kind           string `json:"kind"`
+	Path           string `json:"path"`
+	Status         string `json:"status"`
+	StagedDigest   string `json:"stagedDigest,omitempty"`
+	ReleasedDigest string `json:"releasedDigest,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// Failed returns true if the artifact has not been released correctly.
+func (r *Result) Failed() bool {
+	return r.Error != "" || r.Status == StatusMissing || r.Status == StatusMismatch
+}
+
+// Report is the result of comparing all artifacts of a version.
+type Report struct {
+	Version         string    `json:"version"`
+	StagePath       string    `json:"stagePath"`
+	ReleasePath     string    `json:"releasePath"`
+	StagingRegistry string    `json:"stagingRegistry"`
+	ReleaseRegistry string    `json:"releaseRegistry"`
+	Results         []*Result `json:"results"`
+
+	// Encrypted is true if the artifacts got staged encrypted, which means
+	// that only their paths are comparable.
+	Encrypted bool `json:"encrypted,omitempty"`
+}
+
+// Failed returns all results which have not been released correctly.
+func (r *Report) Failed() []*Result {
+	failed := []*Result{}
+	for _, res := range r.Results {
+		if res.Failed() {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Differences returns all results which are not OK.
+func (r *Report) Differences() []*Result {
+	diff := []*Result{}
+	for _, res := range r.Results {
+		if res.Error != "" || res.Status != StatusOK {
+			diff = append(diff, res)
+		}
+	}
+	return diff
+}
+
+// Markdown returns a markdown table of all differences in the report.
+func (r *Report) Markdown() string {
+	buf := &bytes.Buffer{}
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeader([]string{"Kind", "Path", "Staged", "Released", "Status"})
+	for _, res := range r.Differences() {
+		status := res.Status
+		if res.Error != "" {
+			status = "error: " + res.Error
+		}
+		table.Append([]string{
+			res.Kind, res.Path, orDash(res.StagedDigest), orDash(res.ReleasedDigest), status,
+		})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+	return buf.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// Comparer is the main structure for comparing staged and released
+// artifacts.
+type Comparer struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Comparer instance.
+func New(opts *Options) *Comparer {
+	return &Comparer{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (c *Comparer) SetImpl(impl impl) {
+	c.impl = impl
+}
+
+// Compare compares the staged bucket contents and images of the version with
+// the released ones and returns the report.
+func (c *Comparer) Compare() (*Report, error) {
+	if err := c.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	version := util.AddTagPrefix(c.options.Version)
+	releasePath, err := layout.Default().ReleasePath(c.options.Bucket, "release", version, false)
+	if err != nil {
+		return nil, fmt.Errorf("get release path: %w", err)
+	}
+	releaseRegistry := c.options.ReleaseRegistry
+	if releaseRegistry == "" {
+		releaseRegistry = branding.Default().Registry
+	}
+	report := &Report{
+		Version: version,
+		StagePath: layout.Default().StagePath(
+			c.options.Bucket, c.options.BuildVersion, version, release.GCSStagePath, version,
+		),
+		ReleasePath:     releasePath,
+		StagingRegistry: c.options.StagingRegistry,
+		ReleaseRegistry: releaseRegistry,
+		Results:         []*Result{},
+	}
+
+	logrus.Infof("Listing staged artifacts in %s", report.StagePath)
+	staged, err := c.impl.ListObjects(report.StagePath)
+	if err != nil {
+		return nil, fmt.Errorf("list staged artifacts: %w", err)
+	}
+	if len(staged) == 0 {
+		return nil, fmt.Errorf("no staged artifacts found in %s", report.StagePath)
+	}
+	logrus.Infof("Listing released artifacts in %s", report.ReleasePath)
+	released, err := c.impl.ListObjects(report.ReleasePath)
+	if err != nil {
+		return nil, fmt.Errorf("list released artifacts: %w", err)
+	}
+	report.Encrypted, err = c.impl.PathExists(layout.Default().StagePath(
+		c.options.Bucket, c.options.BuildVersion, encryption.MarkerFile,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("check if the staged artifacts are encrypted: %w", err)
+	}
+	if report.Encrypted {
+		logrus.Info("The staged artifacts are encrypted, only comparing their paths")
+	}
+	report.Results = append(report.Results, compareFiles(staged, released, !report.Encrypted)...)
+
+	imagesPath := layout.Default().StagePath(
+		c.options.Bucket, c.options.BuildVersion, version, release.ImagesPath,
+	)
+	logrus.Infof("Listing staged images in %s", imagesPath)
+	imageArchives, err := c.impl.ListObjects(imagesPath)
+	if err != nil {
+		return nil, fmt.Errorf("list staged images: %w", err)
+	}
+	for _, image := range imageNames(imageArchives) {
+		report.Results = append(report.Results, c.compareImage(report, image))
+	}
+	return report, nil
+}
+
+// Run compares the artifacts, writes the report and fails if any of the
+// staged artifacts has not been released correctly.
+func (c *Comparer) Run() error {
+	report, err := c.Compare()
+	if err != nil {
+		return err
+	}
+	if err := c.WriteReport(report); err != nil {
+		return err
+	}
+	if len(report.Differences()) > 0 {
+		fmt.Print(report.Markdown())
+	}
+	return c.Check(report)
+}
+
+// Check fails if any of the staged artifacts of the report has not been
+// released correctly.
+func (c *Comparer) Check(report *Report) error {
+	if failed := report.Failed(); len(failed) > 0 {
+		return fmt.Errorf(
+			"%d of %d staged artifacts of %s have not been released correctly",
+			len(failed), len(report.Results), report.Version,
+		)
+	}
+	logrus.Infof(
+		"All %d staged artifacts of %s have been released", len(report.Results), report.Version,
+	)
+	return nil
+}
+
+// WriteReport writes the report as JSON to the report file, if configured.
+func (c *Comparer) WriteReport(report any) error {
+	if c.options.ReportFile == "" {
+		return nil
+	}
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+	if err := c.impl.WriteFile(c.options.ReportFile, content); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	logrus.Infof("Wrote artifact comparison report to %s", c.options.ReportFile)
+	return nil
+}
+
+// compareFiles compares the checksums of the staged and released objects.
+// Only their paths are compared if compareDigests is false.
+func compareFiles(staged, released map[string]string, compareDigests bool) []*Result {
+	paths := map[string]struct{}{}
+	for p := range staged {
+		paths[p] = struct{}{}
+	}
+	for p := range released {
+		paths[p] = struct{}{}
+	}
+
+	res := []*Result{}
+	for _, p := range sortedKeys(paths) {
+		stagedDigest, isStaged := staged[p]
+		releasedDigest, isReleased := released[p]
+		result := &Result{
+			Kind:           KindFile,
+			Path:           p,
+			StagedDigest:   stagedDigest,
+			ReleasedDigest: releasedDigest,
+		}
+		switch {
+		case !isReleased:
+			result.Status = StatusMissing
+		case !isStaged:
+			result.Status = StatusExtra
+		case compareDigests && stagedDigest != releasedDigest:
+			result.Status = StatusMismatch
+		default:
+			result.Status = StatusOK
+		}
+		res = append(res, result)
+	}
+	return res
+}
+
+// compareImage compares the digest of the image in the staging and release
+// registry.
+func (c *Comparer) compareImage(report *Report, image string) *Result {
+	tag := strings.ReplaceAll(report.Version, "+", "_")
+	res := &Result{Kind: KindImage, Path: fmt.Sprintf("%s:%s", image, tag)}
+
+	stagedRef := fmt.Sprintf("%s/%s", strings.TrimSuffix(report.StagingRegistry, "/"), res.Path)
+	stagedDigest, err := c.impl.Digest(stagedRef)
+	if err != nil {
+		res.Error = fmt.Sprintf("resolve staged digest: %v", err)
+		return res
+	}
+	res.StagedDigest = stagedDigest
+
+	releasedRef := fmt.Sprintf("%s/%s", strings.TrimSuffix(report.ReleaseRegistry, "/"), res.Path)
+	releasedDigest, err := c.impl.Digest(releasedRef)
+	if err != nil {
+		logrus.Warnf("Unable to resolve released image %s: %v", releasedRef, err)
+		res.Status = StatusMissing
+		return res
+	}
+	res.ReleasedDigest = releasedDigest
+
+	res.Status = StatusOK
+	if stagedDigest != releasedDigest {
+		res.Status = StatusMismatch
+	}
+	return res
+}
+
+// imageNames returns the sorted unique image names of the staged image
+// archives, which are stored as <arch>/<image>[-<arch>].tar.
+func imageNames(archives map[string]string) []string {
+	names := map[string]struct{}{}
+	for archive := range archives {
+		if name, ok := strings.CutSuffix(path.Base(archive), ".tar"); ok {
+			arch := path.Base(path.Dir(archive))
+			names[strings.TrimSuffix(name, "-"+arch)] = struct{}{}
+		}
+	}
+	return sortedKeys(names)
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
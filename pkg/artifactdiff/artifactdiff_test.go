@@ -0,0 +1,231 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactdiff_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/artifactdiff"
+	"k8s.io/release/pkg/artifactdiff/artifactdifffakes"
+)
+
+var errTest = errors.New("test")
+
+func newOptions() *artifactdiff.Options {
+	opts := artifactdiff.DefaultOptions()
+	opts.Bucket = "bucket"
+	opts.BuildVersion = "v1.30.0-rc.2.10+abc"
+	opts.Version = "v1.30.0"
+	opts.StagingRegistry = "staging.example"
+	opts.ReleaseRegistry = "registry.example"
+	return opts
+}
+
+func newMock(released map[string]string, releasedImages map[string]string) *artifactdifffakes.FakeImpl {
+	mock := &artifactdifffakes.FakeImpl{}
+	mock.ListObjectsCalls(func(gcsPath string) (map[string]string, error) {
+		switch gcsPath {
+		case "bucket/stage/v1.30.0-rc.2.10+abc/v1.30.0/gcs-stage/v1.30.0":
+			return map[string]string{
+				"kubernetes.tar.gz":              "crc32c:00000001",
+				"bin/linux/amd64/kubectl":        "crc32c:00000002",
+				"bin/linux/amd64/kubectl.sha256": "crc32c:00000003",
+			}, nil
+		case "bucket/release/v1.30.0":
+			return released, nil
+		case "bucket/stage/v1.30.0-rc.2.10+abc/v1.30.0/release-images":
+			return map[string]string{
+				"amd64/kube-apiserver.tar":    "crc32c:00000004",
+				"arm64/kube-apiserver.tar":    "crc32c:00000005",
+				"amd64/conformance-amd64.tar": "crc32c:00000006",
+			}, nil
+		}
+		return nil, errTest
+	})
+	mock.DigestCalls(func(ref string) (string, error) {
+		registry, image, _ := strings.Cut(ref, "/")
+		if registry == "staging.example" {
+			return "sha256:" + image, nil
+		}
+		if digest, ok := releasedImages[image]; ok {
+			return digest, nil
+		}
+		return "", errTest
+	})
+	return mock
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	complete := map[string]string{
+		"kubernetes.tar.gz":              "crc32c:00000001",
+		"bin/linux/amd64/kubectl":        "crc32c:00000002",
+		"bin/linux/amd64/kubectl.sha256": "crc32c:00000003",
+	}
+	completeImages := map[string]string{
+		"kube-apiserver:v1.30.0": "sha256:kube-apiserver:v1.30.0",
+		"conformance:v1.30.0":    "sha256:conformance:v1.30.0",
+	}
+
+	for _, tc := range []struct {
+		name           string
+		released       map[string]string
+		releasedImages map[string]string
+		encrypted      bool
+		differences    map[string]string
+		failed         int
+	}{
+		{
+			name:           "complete release",
+			released:       complete,
+			releasedImages: completeImages,
+			differences:    map[string]string{},
+		},
+		{
+			name: "missing, extra and mismatched files",
+			released: map[string]string{
+				"kubernetes.tar.gz":       "crc32c:00000001",
+				"bin/linux/amd64/kubectl": "crc32c:ffffffff",
+				"kubernetes.tar.gz.sig":   "crc32c:00000007",
+			},
+			releasedImages: completeImages,
+			differences: map[string]string{
+				"bin/linux/amd64/kubectl":        artifactdiff.StatusMismatch,
+				"bin/linux/amd64/kubectl.sha256": artifactdiff.StatusMissing,
+				"kubernetes.tar.gz.sig":          artifactdiff.StatusExtra,
+			},
+			failed: 2,
+		},
+		{
+			name: "encrypted stage only compares paths",
+			released: map[string]string{
+				"kubernetes.tar.gz":       "crc32c:ffffffff",
+				"bin/linux/amd64/kubectl": "crc32c:ffffffff",
+			},
+			releasedImages: completeImages,
+			encrypted:      true,
+			differences: map[string]string{
+				"bin/linux/amd64/kubectl.sha256": artifactdiff.StatusMissing,
+			},
+			failed: 1,
+		},
+		{
+			name:     "missing and mismatched images",
+			released: complete,
+			releasedImages: map[string]string{
+				"kube-apiserver:v1.30.0": "sha256:other",
+			},
+			differences: map[string]string{
+				"kube-apiserver:v1.30.0": artifactdiff.StatusMismatch,
+				"conformance:v1.30.0":    artifactdiff.StatusMissing,
+			},
+			failed: 2,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := newMock(tc.released, tc.releasedImages)
+			mock.PathExistsReturns(tc.encrypted, nil)
+			sut := artifactdiff.New(newOptions())
+			sut.SetImpl(mock)
+
+			report, err := sut.Compare()
+			require.NoError(t, err)
+			require.Equal(t, "bucket/release/v1.30.0", report.ReleasePath)
+			require.Equal(t, tc.encrypted, report.Encrypted)
+			require.Equal(t,
+				"bucket/stage/v1.30.0-rc.2.10+abc/ENCRYPTED",
+				mock.PathExistsArgsForCall(0),
+			)
+
+			differences := map[string]string{}
+			for _, res := range report.Differences() {
+				differences[res.Path] = res.Status
+			}
+			require.Equal(t, tc.differences, differences)
+			require.Len(t, report.Failed(), tc.failed)
+		})
+	}
+}
+
+func TestCompareFailure(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		prepare func(*artifactdiff.Options, *artifactdifffakes.FakeImpl)
+	}{
+		{
+			name: "invalid options",
+			prepare: func(opts *artifactdiff.Options, _ *artifactdifffakes.FakeImpl) {
+				opts.Version = "invalid"
+			},
+		},
+		{
+			name: "listing fails",
+			prepare: func(_ *artifactdiff.Options, mock *artifactdifffakes.FakeImpl) {
+				mock.ListObjectsReturns(nil, errTest)
+			},
+		},
+		{
+			name: "nothing staged",
+			prepare: func(_ *artifactdiff.Options, mock *artifactdifffakes.FakeImpl) {
+				mock.ListObjectsReturns(map[string]string{}, nil)
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newOptions()
+			mock := &artifactdifffakes.FakeImpl{}
+			tc.prepare(opts, mock)
+			sut := artifactdiff.New(opts)
+			sut.SetImpl(mock)
+
+			_, err := sut.Compare()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	opts := newOptions()
+	opts.ReportFile = "/tmp/report.json"
+	mock := newMock(map[string]string{}, map[string]string{})
+	sut := artifactdiff.New(opts)
+	sut.SetImpl(mock)
+
+	require.ErrorContains(t, sut.Run(), "5 of 5 staged artifacts of v1.30.0 have not been released correctly")
+	require.Equal(t, 1, mock.WriteFileCallCount())
+
+	_, content := mock.WriteFileArgsForCall(0)
+	report := &artifactdiff.Report{}
+	require.NoError(t, json.Unmarshal(content, report))
+	require.Len(t, report.Results, 5)
+}
@@ -0,0 +1,367 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package artifactdifffakes
+
+import (
+	"sync"
+)
+
+type FakeImpl struct {
+	DigestStub        func(string) (string, error)
+	digestMutex       sync.RWMutex
+	digestArgsForCall []struct {
+		arg1 string
+	}
+	digestReturns struct {
+		result1 string
+		result2 error
+	}
+	digestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ListObjectsStub        func(string) (map[string]string, error)
+	listObjectsMutex       sync.RWMutex
+	listObjectsArgsForCall []struct {
+		arg1 string
+	}
+	listObjectsReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	listObjectsReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	PathExistsStub        func(string) (bool, error)
+	pathExistsMutex       sync.RWMutex
+	pathExistsArgsForCall []struct {
+		arg1 string
+	}
+	pathExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	pathExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	WriteFileStub        func(string, []byte) error
+	writeFileMutex       sync.RWMutex
+	writeFileArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	writeFileReturns struct {
+		result1 error
+	}
+	writeFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) Digest(arg1 string) (string, error) {
+	fake.digestMutex.Lock()
+	ret, specificReturn := fake.digestReturnsOnCall[len(fake.digestArgsForCall)]
+	fake.digestArgsForCall = append(fake.digestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DigestStub
+	fakeReturns := fake.digestReturns
+	fake.recordInvocation("Digest", []interface{}{arg1})
+	fake.digestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) DigestCallCount() int {
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	return len(fake.digestArgsForCall)
+}
+
+func (fake *FakeImpl) DigestCalls(stub func(string) (string, error)) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = stub
+}
+
+func (fake *FakeImpl) DigestArgsForCall(i int) string {
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	argsForCall := fake.digestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) DigestReturns(result1 string, result2 error) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = nil
+	fake.digestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) DigestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = nil
+	if fake.digestReturnsOnCall == nil {
+		fake.digestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.digestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListObjects(arg1 string) (map[string]string, error) {
+	fake.listObjectsMutex.Lock()
+	ret, specificReturn := fake.listObjectsReturnsOnCall[len(fake.listObjectsArgsForCall)]
+	fake.listObjectsArgsForCall = append(fake.listObjectsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListObjectsStub
+	fakeReturns := fake.listObjectsReturns
+	fake.recordInvocation("ListObjects", []interface{}{arg1})
+	fake.listObjectsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ListObjectsCallCount() int {
+	fake.listObjectsMutex.RLock()
+	defer fake.listObjectsMutex.RUnlock()
+	return len(fake.listObjectsArgsForCall)
+}
+
+func (fake *FakeImpl) ListObjectsCalls(stub func(string) (map[string]string, error)) {
+	fake.listObjectsMutex.Lock()
+	defer fake.listObjectsMutex.Unlock()
+	fake.ListObjectsStub = stub
+}
+
+func (fake *FakeImpl) ListObjectsArgsForCall(i int) string {
+	fake.listObjectsMutex.RLock()
+	defer fake.listObjectsMutex.RUnlock()
+	argsForCall := fake.listObjectsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ListObjectsReturns(result1 map[string]string, result2 error) {
+	fake.listObjectsMutex.Lock()
+	defer fake.listObjectsMutex.Unlock()
+	fake.ListObjectsStub = nil
+	fake.listObjectsReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListObjectsReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.listObjectsMutex.Lock()
+	defer fake.listObjectsMutex.Unlock()
+	fake.ListObjectsStub = nil
+	if fake.listObjectsReturnsOnCall == nil {
+		fake.listObjectsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.listObjectsReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) PathExists(arg1 string) (bool, error) {
+	fake.pathExistsMutex.Lock()
+	ret, specificReturn := fake.pathExistsReturnsOnCall[len(fake.pathExistsArgsForCall)]
+	fake.pathExistsArgsForCall = append(fake.pathExistsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.PathExistsStub
+	fakeReturns := fake.pathExistsReturns
+	fake.recordInvocation("PathExists", []interface{}{arg1})
+	fake.pathExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) PathExistsCallCount() int {
+	fake.pathExistsMutex.RLock()
+	defer fake.pathExistsMutex.RUnlock()
+	return len(fake.pathExistsArgsForCall)
+}
+
+func (fake *FakeImpl) PathExistsCalls(stub func(string) (bool, error)) {
+	fake.pathExistsMutex.Lock()
+	defer fake.pathExistsMutex.Unlock()
+	fake.PathExistsStub = stub
+}
+
+func (fake *FakeImpl) PathExistsArgsForCall(i int) string {
+	fake.pathExistsMutex.RLock()
+	defer fake.pathExistsMutex.RUnlock()
+	argsForCall := fake.pathExistsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) PathExistsReturns(result1 bool, result2 error) {
+	fake.pathExistsMutex.Lock()
+	defer fake.pathExistsMutex.Unlock()
+	fake.PathExistsStub = nil
+	fake.pathExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) PathExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.pathExistsMutex.Lock()
+	defer fake.pathExistsMutex.Unlock()
+	fake.PathExistsStub = nil
+	if fake.pathExistsReturnsOnCall == nil {
+		fake.pathExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.pathExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeFileMutex.Lock()
+	ret, specificReturn := fake.writeFileReturnsOnCall[len(fake.writeFileArgsForCall)]
+	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.WriteFileStub
+	fakeReturns := fake.writeFileReturns
+	fake.recordInvocation("WriteFile", []interface{}{arg1, arg2Copy})
+	fake.writeFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) WriteFileCallCount() int {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return len(fake.writeFileArgsForCall)
+}
+
+func (fake *FakeImpl) WriteFileCalls(stub func(string, []byte) error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = stub
+}
+
+func (fake *FakeImpl) WriteFileArgsForCall(i int) (string, []byte) {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	argsForCall := fake.writeFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) WriteFileReturns(result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	fake.writeFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteFileReturnsOnCall(i int, result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	if fake.writeFileReturnsOnCall == nil {
+		fake.writeFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	fake.listObjectsMutex.RLock()
+	defer fake.listObjectsMutex.RUnlock()
+	fake.pathExistsMutex.RLock()
+	defer fake.pathExistsMutex.RUnlock()
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
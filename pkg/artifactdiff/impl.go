@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactdiff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"google.golang.org/api/iterator"
+	"sigs.k8s.io/release-sdk/object"
+
+	"k8s.io/release/pkg/retry"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt artifactdifffakes/fake_impl.go > artifactdifffakes/_fake_impl.go && mv artifactdifffakes/_fake_impl.go artifactdifffakes/fake_impl.go"
+type impl interface {
+	ListObjects(gcsPath string) (map[string]string, error)
+	PathExists(gcsPath string) (bool, error)
+	Digest(ref string) (string, error)
+	WriteFile(name string, data []byte) error
+}
+
+type defaultImpl struct{}
+
+// ListObjects returns the CRC32C checksums of all objects below the GCS path,
+// indexed by their path relative to it. The checksum is available for all
+// objects, including composite ones.
+func (*defaultImpl) ListObjects(gcsPath string) (map[string]string, error) {
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(gcsPath, object.GcsPrefix), "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("no bucket in GCS path %s", gcsPath)
+	}
+	prefix = strings.TrimSuffix(prefix, "/") + "/"
+
+	ctx := context.Background()
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create storage client: %w", err)
+	}
+	defer client.Close()
+
+	res := map[string]string{}
+	it := client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list objects of %s: %w", gcsPath, err)
+		}
+		res[strings.TrimPrefix(attrs.Name, prefix)] = fmt.Sprintf("crc32c:%08x", attrs.CRC32C)
+	}
+	return res, nil
+}
+
+func (*defaultImpl) PathExists(gcsPath string) (bool, error) {
+	gcs := object.NewGCS()
+	normalized, err := gcs.NormalizePath(gcsPath)
+	if err != nil {
+		return false, fmt.Errorf("normalize %s: %w", gcsPath, err)
+	}
+	return gcs.PathExists(normalized)
+}
+
+func (*defaultImpl) Digest(ref string) (string, error) {
+	var digest string
+	err := retry.Do(context.Background(), retry.ServiceRegistry, func() (err error) {
+		digest, err = crane.Digest(ref)
+		return err
+	})
+	return digest, err
+}
+
+func (*defaultImpl) WriteFile(name string, data []byte) error {
+	return os.WriteFile(name, data, 0o644)
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attribution
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ArchiveName is the file name of the attribution archive.
+	ArchiveName = "kubernetes-licenses.tar.gz"
+
+	// LicensesDir is the directory in the Kubernetes repository containing
+	// the license notices of all vendored dependencies.
+	LicensesDir = "LICENSES"
+
+	// attributionFile is the summary of all dependencies and licenses within
+	// the archive.
+	attributionFile = "ATTRIBUTION.md"
+
+	// archiveRoot is the top level directory of the archive.
+	archiveRoot = "kubernetes-licenses"
+
+	// kubernetesModule is the name used for the top level license.
+	kubernetesModule = "k8s.io/kubernetes"
+)
+
+// Options are the main options for generating the attribution archive.
+type Options struct {
+	// RepoRoot is the path to the Kubernetes repository.
+	RepoRoot string
+
+	// OutputPath is the path of the resulting archive.
+	OutputPath string
+}
+
+// Dependency is a single license notice within the attribution archive.
+type Dependency struct {
+	// Name is the name of the dependency, usually the Go module path.
+	Name string
+
+	// Path is the path to the license file relative to the repository root.
+	Path string
+
+	// License is the SPDX identifier of the license.
+	License string
+}
+
+// Attribution is the main structure for generating attribution archives.
+type Attribution struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Attribution instance.
+func New(opts *Options) *Attribution {
+	return &Attribution{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (a *Attribution) SetImpl(impl impl) {
+	a.impl = impl
+}
+
+// Generate collects and classifies all license notices of the repository and
+// writes them into the attribution archive. It fails if any of the licenses
+// is unknown.
+func (a *Attribution) Generate() ([]*Dependency, error) {
+	if a.options.RepoRoot == "" || a.options.OutputPath == "" {
+		return nil, errors.New("repository root and output path have to be set")
+	}
+
+	dependencies, err := a.Dependencies()
+	if err != nil {
+		return nil, fmt.Errorf("collect dependencies: %w", err)
+	}
+
+	unknown := []string{}
+	for _, dep := range dependencies {
+		id, err := a.impl.ClassifyLicense(filepath.Join(a.options.RepoRoot, dep.Path))
+		if err != nil {
+			return nil, fmt.Errorf("classify license of %s: %w", dep.Name, err)
+		}
+		if id == "" {
+			unknown = append(unknown, dep.Name)
+			continue
+		}
+		dep.License = id
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf(
+			"unknown licenses for %d dependencies: %s",
+			len(unknown), strings.Join(unknown, ", "),
+		)
+	}
+
+	logrus.Infof(
+		"Writing attribution archive for %d dependencies to %s",
+		len(dependencies), a.options.OutputPath,
+	)
+	if err := a.writeArchive(dependencies); err != nil {
+		return nil, fmt.Errorf("write attribution archive: %w", err)
+	}
+	return dependencies, nil
+}
+
+// Dependencies returns the sorted list of license notices of the repository.
+// This includes the top level LICENSE file as well as all files in the
+// LICENSES directory.
+func (a *Attribution) Dependencies() ([]*Dependency, error) {
+	dependencies := []*Dependency{{Name: kubernetesModule, Path: "LICENSE"}}
+
+	root := filepath.Join(a.options.RepoRoot, LicensesDir)
+	if err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(a.options.RepoRoot, path)
+		if err != nil {
+			return fmt.Errorf("get relative path: %w", err)
+		}
+
+		// For example LICENSES/vendor/github.com/foo/bar/LICENSE results in
+		// github.com/foo/bar, while third party notices keep their prefix.
+		name := filepath.ToSlash(filepath.Dir(
+			strings.TrimPrefix(rel, LicensesDir+string(filepath.Separator)),
+		))
+		name = strings.TrimPrefix(name, "vendor/")
+
+		dependencies = append(dependencies, &Dependency{Name: name, Path: rel})
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("walk %s: %w", root, err)
+	}
+
+	sort.SliceStable(dependencies[1:], func(i, j int) bool {
+		return dependencies[i+1].Path < dependencies[j+1].Path
+	})
+	return dependencies, nil
+}
+
+// Summary returns a markdown document listing all dependencies and their
+// licenses.
+func Summary(dependencies []*Dependency) string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("# Kubernetes license attribution\n\n")
+	buf.WriteString("This archive contains the license notices of Kubernetes and all its vendored dependencies.\n\n")
+
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeader([]string{"Dependency", "License", "Notice"})
+	for _, dep := range dependencies {
+		table.Append([]string{dep.Name, dep.License, archivePath(dep.Path)})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+	return buf.String()
+}
+
+// archivePath returns the path of a license notice within the archive.
+func archivePath(path string) string {
+	return filepath.ToSlash(strings.TrimPrefix(path, LicensesDir+string(filepath.Separator)))
+}
+
+// writeArchive writes the attribution archive. File modification times and
+// ordering are fixed to produce reproducible archives.
+func (a *Attribution) writeArchive(dependencies []*Dependency) error {
+	if err := os.MkdirAll(filepath.Dir(a.options.OutputPath), os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+
+	f, err := os.Create(a.options.OutputPath)
+	if err != nil {
+		return fmt.Errorf("create archive: %w", err)
+	}
+	defer f.Close()
+
+	gzipWriter := gzip.NewWriter(f)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	writeFile := func(name string, content []byte) error {
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:    archiveRoot + "/" + name,
+			Mode:    0o644,
+			Size:    int64(len(content)),
+			ModTime: time.Unix(0, 0),
+			Format:  tar.FormatPAX,
+		}); err != nil {
+			return fmt.Errorf("write header for %s: %w", name, err)
+		}
+		if _, err := io.Copy(tarWriter, bytes.NewReader(content)); err != nil {
+			return fmt.Errorf("write content of %s: %w", name, err)
+		}
+		return nil
+	}
+
+	if err := writeFile(attributionFile, []byte(Summary(dependencies))); err != nil {
+		return err
+	}
+
+	for _, dep := range dependencies {
+		content, err := os.ReadFile(filepath.Join(a.options.RepoRoot, dep.Path))
+		if err != nil {
+			return fmt.Errorf("read license notice: %w", err)
+		}
+		if err := writeFile(archivePath(dep.Path), content); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("close tar writer: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("close gzip writer: %w", err)
+	}
+	return nil
+}
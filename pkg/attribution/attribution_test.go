@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attribution
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/attribution/attributionfakes"
+)
+
+var errTest = errors.New("test")
+
+func writeTestRepo(t *testing.T) string {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"LICENSE": "kubernetes license",
+		"LICENSES/vendor/github.com/foo/bar/LICENSE": "bar license",
+		"LICENSES/vendor/github.com/foo/baz/LICENSE": "baz license",
+		"LICENSES/third_party/forked/golang/LICENSE": "golang license",
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func readTestArchive(t *testing.T, path string) map[string]string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	gzipReader, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+
+	res := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		res[header.Name] = string(content)
+	}
+	return res
+}
+
+func TestDependencies(t *testing.T) {
+	t.Parallel()
+
+	sut := New(&Options{RepoRoot: writeTestRepo(t)})
+	deps, err := sut.Dependencies()
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, dep := range deps {
+		names = append(names, dep.Name)
+	}
+	require.Equal(t, []string{
+		"k8s.io/kubernetes",
+		"third_party/forked/golang",
+		"github.com/foo/bar",
+		"github.com/foo/baz",
+	}, names)
+	require.Equal(t, filepath.Join("LICENSES", "vendor", "github.com", "foo", "bar", "LICENSE"), deps[2].Path)
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		prepare func(*attributionfakes.FakeImpl)
+		assert  func([]*Dependency, string, error)
+	}{
+		{ // success
+			prepare: func(mock *attributionfakes.FakeImpl) {
+				mock.ClassifyLicenseReturns("Apache-2.0", nil)
+				mock.ClassifyLicenseReturnsOnCall(3, "MIT", nil)
+			},
+			assert: func(deps []*Dependency, output string, err error) {
+				require.NoError(t, err)
+				require.Len(t, deps, 4)
+				require.Equal(t, "MIT", deps[3].License)
+
+				files := readTestArchive(t, output)
+				require.Len(t, files, 5)
+				require.Equal(t, "kubernetes license", files["kubernetes-licenses/LICENSE"])
+				require.Equal(t, "bar license", files["kubernetes-licenses/vendor/github.com/foo/bar/LICENSE"])
+				require.Equal(t, "golang license", files["kubernetes-licenses/third_party/forked/golang/LICENSE"])
+				require.Contains(t,
+					files["kubernetes-licenses/ATTRIBUTION.md"],
+					"| github.com/foo/baz        | MIT        | vendor/github.com/foo/baz/LICENSE |",
+				)
+			},
+		},
+		{ // unknown license
+			prepare: func(mock *attributionfakes.FakeImpl) {
+				mock.ClassifyLicenseReturns("Apache-2.0", nil)
+				mock.ClassifyLicenseReturnsOnCall(2, "", nil)
+			},
+			assert: func(_ []*Dependency, output string, err error) {
+				require.ErrorContains(t, err, "github.com/foo/bar")
+				require.NoFileExists(t, output)
+			},
+		},
+		{ // ClassifyLicense fails
+			prepare: func(mock *attributionfakes.FakeImpl) {
+				mock.ClassifyLicenseReturns("", errTest)
+			},
+			assert: func(_ []*Dependency, output string, err error) {
+				require.Error(t, err)
+				require.NoFileExists(t, output)
+			},
+		},
+	} {
+		mock := &attributionfakes.FakeImpl{}
+		tc.prepare(mock)
+
+		output := filepath.Join(t.TempDir(), "out", ArchiveName)
+		sut := New(&Options{RepoRoot: writeTestRepo(t), OutputPath: output})
+		sut.SetImpl(mock)
+		deps, err := sut.Generate()
+		tc.assert(deps, output, err)
+	}
+}
+
+func TestGenerateReproducible(t *testing.T) {
+	t.Parallel()
+
+	repo := writeTestRepo(t)
+	contents := [][]byte{}
+	for i := 0; i < 2; i++ {
+		mock := &attributionfakes.FakeImpl{}
+		mock.ClassifyLicenseReturns("Apache-2.0", nil)
+
+		output := filepath.Join(t.TempDir(), ArchiveName)
+		sut := New(&Options{RepoRoot: repo, OutputPath: output})
+		sut.SetImpl(mock)
+		_, err := sut.Generate()
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(output)
+		require.NoError(t, err)
+		contents = append(contents, content)
+	}
+	require.Equal(t, contents[0], contents[1])
+}
+
+func TestGenerateInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(&Options{}).Generate()
+	require.Error(t, err)
+}
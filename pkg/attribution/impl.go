@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attribution
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/bom/pkg/license"
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt attributionfakes/fake_impl.go > attributionfakes/_fake_impl.go && mv attributionfakes/_fake_impl.go attributionfakes/fake_impl.go"
+type impl interface {
+	ClassifyLicense(path string) (string, error)
+}
+
+type defaultImpl struct {
+	reader *license.Reader
+}
+
+// ClassifyLicense returns the SPDX license identifier of the provided file or
+// an empty string if the license is unknown.
+func (d *defaultImpl) ClassifyLicense(path string) (string, error) {
+	if d.reader == nil {
+		// Reuse the SPDX license cache, which is already populated on stage
+		reader, err := license.NewReaderWithOptions(&license.ReaderOptions{
+			ConfidenceThreshold: license.DefaultReaderOptions.ConfidenceThreshold,
+			CacheDir:            spdx.NewSPDX().Options().LicenseCacheDir,
+		})
+		if err != nil {
+			return "", fmt.Errorf("create license reader: %w", err)
+		}
+		d.reader = reader
+	}
+
+	l, err := d.reader.LicenseFromFile(path)
+	if err != nil {
+		return "", err
+	}
+	if l == nil {
+		return "", nil
+	}
+	return l.LicenseID, nil
+}
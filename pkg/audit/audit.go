@@ -0,0 +1,298 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/workdir"
+)
+
+const (
+	// FileName is the name of the audit log within its directory.
+	FileName = "audit.jsonl"
+
+	// ActorEnvKey is the environment variable containing the actor to be
+	// recorded, for example the user who submitted the Google Cloud Build
+	// job.
+	ActorEnvKey = "KREL_AUDIT_ACTOR"
+)
+
+// Action is the type of a mutating operation.
+type Action string
+
+const (
+	// ActionGitPush is a push of a tag or branch to the remote repository.
+	ActionGitPush Action = "git-push"
+
+	// ActionBucketWrite is a write to a Google Cloud Storage bucket.
+	ActionBucketWrite Action = "bucket-write"
+
+	// ActionRegistryPush is a push of container images to a registry.
+	ActionRegistryPush Action = "registry-push"
+
+	// ActionGitHubAPI is a mutating call to the GitHub API.
+	ActionGitHubAPI Action = "github-api"
+
+	// ActionFreezeOverride is an operation confirmed to run during a freeze
+	// of the release schedule.
+	ActionFreezeOverride Action = "freeze-override"
+
+	// ActionPhaseTimeout is a release phase which exceeded its timeout budget
+	// and failed the run.
+	ActionPhaseTimeout Action = "phase-timeout"
+)
+
+// Entry is a single record of the audit log. Every entry contains the hash
+// of its predecessor, which makes the log tamper evident.
+type Entry struct {
+	// Time is the UTC time when the action completed.
+	Time time.Time `json:"time"`
+
+	// Actor is the user or service account running the operation.
+	Actor string `json:"actor"`
+
+	// Action is the type of the operation.
+	Action Action `json:"action"`
+
+	// Target is the mutated object, for example a bucket path or tag.
+	Target string `json:"target"`
+
+	// Details contain additional information about the action.
+	Details map[string]string `json:"details,omitempty"`
+
+	// Previous is the hash of the previous entry, empty for the first one.
+	Previous string `json:"previous,omitempty"`
+
+	// Hash is the SHA-256 of the entry without the hash itself.
+	Hash string `json:"hash"`
+}
+
+func (e *Entry) digest() (string, error) {
+	unhashed := *e
+	unhashed.Hash = ""
+	content, err := json.Marshal(&unhashed)
+	if err != nil {
+		return "", fmt.Errorf("marshal entry: %w", err)
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Options are the settings of the audit log.
+type Options struct {
+	// Dir is the directory containing the audit log and its signature.
+	Dir string
+
+	// Actor is recorded for every entry.
+	Actor string
+}
+
+// DefaultOptions returns a new default Options instance. The actor defaults
+// to $KREL_AUDIT_ACTOR or the current user.
+func DefaultOptions() *Options {
+	actor := os.Getenv(ActorEnvKey)
+	if actor == "" {
+		if u, err := user.Current(); err == nil {
+			actor = u.Username
+		}
+	}
+	return &Options{
+		Dir:   filepath.Join(workdir.TempDir(), "audit"),
+		Actor: actor,
+	}
+}
+
+// Log is an append-only audit log of mutating operations.
+type Log struct {
+	impl     impl
+	options  *Options
+	mu       sync.Mutex
+	previous string
+}
+
+// New creates a new audit log.
+func New(opts *Options) *Log {
+	return &Log{impl: &defaultImpl{}, options: opts}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (l *Log) SetImpl(impl impl) {
+	l.impl = impl
+}
+
+// Path returns the file path of the audit log.
+func (l *Log) Path() string {
+	return filepath.Join(l.options.Dir, FileName)
+}
+
+// Open verifies an already existing audit log and continues its hash chain.
+func (l *Log) Open() error {
+	content, err := l.impl.ReadFile(l.Path())
+	if err != nil {
+		return fmt.Errorf("read audit log: %w", err)
+	}
+	entries, err := Verify(content)
+	if err != nil {
+		return fmt.Errorf("verify existing audit log: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.previous = ""
+	if len(entries) > 0 {
+		l.previous = entries[len(entries)-1].Hash
+	}
+	return nil
+}
+
+// Record appends a new entry to the audit log.
+func (l *Log) Record(action Action, target string, details map[string]string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := &Entry{
+		Time:     l.impl.Now(),
+		Actor:    l.options.Actor,
+		Action:   action,
+		Target:   target,
+		Details:  details,
+		Previous: l.previous,
+	}
+	hash, err := entry.digest()
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal entry: %w", err)
+	}
+	if err := l.impl.AppendFile(l.Path(), append(line, '\n')); err != nil {
+		return fmt.Errorf("append entry: %w", err)
+	}
+	l.previous = hash
+	return nil
+}
+
+// Sign creates a sigstore signature and certificate next to the audit log.
+func (l *Log) Sign() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.impl.SignFile(l.Path()); err != nil {
+		return fmt.Errorf("sign audit log: %w", err)
+	}
+	return nil
+}
+
+// Verify parses the audit log content and checks the hash chain. It returns
+// the entries if the log is intact.
+func Verify(content []byte) ([]*Entry, error) {
+	entries := []*Entry{}
+	previous := ""
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		entry := &Entry{}
+		if err := json.Unmarshal(line, entry); err != nil {
+			return nil, fmt.Errorf("line %d: unmarshal entry: %w", i+1, err)
+		}
+		if entry.Previous != previous {
+			return nil, fmt.Errorf("line %d: entry does not follow its predecessor", i+1)
+		}
+		hash, err := entry.digest()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if hash != entry.Hash {
+			return nil, fmt.Errorf("line %d: hash mismatch, entry has been modified", i+1)
+		}
+		previous = entry.Hash
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+var (
+	defaultLog   *Log
+	defaultLogMu sync.RWMutex
+)
+
+// Setup enables the package level audit log, which is used for recording by
+// the release operations. It should only be enabled for non-mock runs.
+func Setup(opts *Options) error {
+	l := New(opts)
+	if err := l.Open(); err != nil {
+		return err
+	}
+	logrus.Infof("Recording mutating operations in audit log %s", l.Path())
+
+	defaultLogMu.Lock()
+	defer defaultLogMu.Unlock()
+	defaultLog = l
+	return nil
+}
+
+// Default returns the package level audit log or nil if not enabled.
+func Default() *Log {
+	defaultLogMu.RLock()
+	defer defaultLogMu.RUnlock()
+	return defaultLog
+}
+
+// Record appends an entry to the package level audit log if enabled. The
+// action already took place at this point, which is why failures to write
+// the log are only reported.
+func Record(action Action, target string, details map[string]string) {
+	l := Default()
+	if l == nil {
+		return
+	}
+	if err := l.Record(action, target, details); err != nil {
+		logrus.Errorf("Unable to record %s of %s in audit log: %v", action, target, err)
+	}
+}
+
+// Publish signs the package level audit log and hands its directory to the
+// upload function. It does nothing if the audit log is not enabled.
+func Publish(upload func(dir string) error) error {
+	l := Default()
+	if l == nil {
+		return nil
+	}
+	if err := l.Sign(); err != nil {
+		logrus.Warnf("Publishing unsigned audit log: %v", err)
+	}
+	if err := upload(l.options.Dir); err != nil {
+		return fmt.Errorf("upload audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/audit/auditfakes"
+)
+
+func TestRecord(t *testing.T) {
+	log := &bytes.Buffer{}
+	mock := &auditfakes.FakeImpl{}
+	mock.NowReturns(time.Date(2024, 8, 13, 12, 0, 0, 0, time.UTC))
+	mock.AppendFileCalls(func(_ string, data []byte) error {
+		log.Write(data)
+		return nil
+	})
+
+	sut := audit.New(&audit.Options{Dir: "dir", Actor: "user"})
+	sut.SetImpl(mock)
+	require.NoError(t, sut.Open())
+
+	require.NoError(t, sut.Record(audit.ActionGitPush, "v1.31.0", nil))
+	require.NoError(t, sut.Record(audit.ActionBucketWrite, "gs://bucket/release", map[string]string{"source": "dir"}))
+
+	path, _ := mock.AppendFileArgsForCall(0)
+	require.Equal(t, filepath.Join("dir", audit.FileName), path)
+
+	entries, err := audit.Verify(log.Bytes())
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "user", entries[0].Actor)
+	require.Equal(t, audit.ActionGitPush, entries[0].Action)
+	require.Empty(t, entries[0].Previous)
+	require.Equal(t, entries[0].Hash, entries[1].Previous)
+
+	// Continue the chain of an existing log
+	mock.ReadFileReturns(log.Bytes(), nil)
+	resumed := audit.New(&audit.Options{Dir: "dir", Actor: "other"})
+	resumed.SetImpl(mock)
+	require.NoError(t, resumed.Open())
+	require.NoError(t, resumed.Record(audit.ActionRegistryPush, "registry.k8s.io", nil))
+
+	entries, err = audit.Verify(log.Bytes())
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	mock.AppendFileReturns(errors.New("test"))
+	require.Error(t, sut.Record(audit.ActionGitPush, "v1.31.1", nil))
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	sut := audit.New(&audit.Options{Dir: dir, Actor: "user"})
+	require.NoError(t, sut.Open())
+	require.NoError(t, sut.Record(audit.ActionGitPush, "v1.31.0", nil))
+	require.NoError(t, sut.Record(audit.ActionGitHubAPI, "kubernetes/kubernetes@v1.31.0", nil))
+	require.NoError(t, sut.Record(audit.ActionBucketWrite, "gs://bucket", nil))
+
+	content, err := os.ReadFile(sut.Path())
+	require.NoError(t, err)
+	lines := bytes.SplitAfter(content, []byte("\n"))
+
+	for _, tc := range []struct {
+		name        string
+		content     []byte
+		shouldError bool
+	}{
+		{
+			name:    "intact",
+			content: content,
+		},
+		{
+			name:    "empty",
+			content: nil,
+		},
+		{
+			name:        "modified entry",
+			content:     bytes.Replace(content, []byte("v1.31.0"), []byte("v1.31.1"), 1),
+			shouldError: true,
+		},
+		{
+			name:        "removed entry",
+			content:     bytes.Join([][]byte{lines[0], lines[2]}, nil),
+			shouldError: true,
+		},
+		{
+			name:        "invalid JSON",
+			content:     append(content, []byte("{\n")...),
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := audit.Verify(tc.content)
+			if tc.shouldError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPublishDisabled(t *testing.T) {
+	called := false
+	require.NoError(t, audit.Publish(func(string) error {
+		called = true
+		return nil
+	}))
+	require.False(t, called)
+}
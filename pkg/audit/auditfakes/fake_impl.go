@@ -0,0 +1,349 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package auditfakes
+
+import (
+	"sync"
+	"time"
+)
+
+type FakeImpl struct {
+	AppendFileStub        func(string, []byte) error
+	appendFileMutex       sync.RWMutex
+	appendFileArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	appendFileReturns struct {
+		result1 error
+	}
+	appendFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	NowStub        func() time.Time
+	nowMutex       sync.RWMutex
+	nowArgsForCall []struct {
+	}
+	nowReturns struct {
+		result1 time.Time
+	}
+	nowReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	ReadFileStub        func(string) ([]byte, error)
+	readFileMutex       sync.RWMutex
+	readFileArgsForCall []struct {
+		arg1 string
+	}
+	readFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	SignFileStub        func(string) error
+	signFileMutex       sync.RWMutex
+	signFileArgsForCall []struct {
+		arg1 string
+	}
+	signFileReturns struct {
+		result1 error
+	}
+	signFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) AppendFile(arg1 string, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.appendFileMutex.Lock()
+	ret, specificReturn := fake.appendFileReturnsOnCall[len(fake.appendFileArgsForCall)]
+	fake.appendFileArgsForCall = append(fake.appendFileArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.AppendFileStub
+	fakeReturns := fake.appendFileReturns
+	fake.recordInvocation("AppendFile", []interface{}{arg1, arg2Copy})
+	fake.appendFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) AppendFileCallCount() int {
+	fake.appendFileMutex.RLock()
+	defer fake.appendFileMutex.RUnlock()
+	return len(fake.appendFileArgsForCall)
+}
+
+func (fake *FakeImpl) AppendFileCalls(stub func(string, []byte) error) {
+	fake.appendFileMutex.Lock()
+	defer fake.appendFileMutex.Unlock()
+	fake.AppendFileStub = stub
+}
+
+func (fake *FakeImpl) AppendFileArgsForCall(i int) (string, []byte) {
+	fake.appendFileMutex.RLock()
+	defer fake.appendFileMutex.RUnlock()
+	argsForCall := fake.appendFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) AppendFileReturns(result1 error) {
+	fake.appendFileMutex.Lock()
+	defer fake.appendFileMutex.Unlock()
+	fake.AppendFileStub = nil
+	fake.appendFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) AppendFileReturnsOnCall(i int, result1 error) {
+	fake.appendFileMutex.Lock()
+	defer fake.appendFileMutex.Unlock()
+	fake.AppendFileStub = nil
+	if fake.appendFileReturnsOnCall == nil {
+		fake.appendFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appendFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Now() time.Time {
+	fake.nowMutex.Lock()
+	ret, specificReturn := fake.nowReturnsOnCall[len(fake.nowArgsForCall)]
+	fake.nowArgsForCall = append(fake.nowArgsForCall, struct {
+	}{})
+	stub := fake.NowStub
+	fakeReturns := fake.nowReturns
+	fake.recordInvocation("Now", []interface{}{})
+	fake.nowMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) NowCallCount() int {
+	fake.nowMutex.RLock()
+	defer fake.nowMutex.RUnlock()
+	return len(fake.nowArgsForCall)
+}
+
+func (fake *FakeImpl) NowCalls(stub func() time.Time) {
+	fake.nowMutex.Lock()
+	defer fake.nowMutex.Unlock()
+	fake.NowStub = stub
+}
+
+func (fake *FakeImpl) NowReturns(result1 time.Time) {
+	fake.nowMutex.Lock()
+	defer fake.nowMutex.Unlock()
+	fake.NowStub = nil
+	fake.nowReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeImpl) NowReturnsOnCall(i int, result1 time.Time) {
+	fake.nowMutex.Lock()
+	defer fake.nowMutex.Unlock()
+	fake.NowStub = nil
+	if fake.nowReturnsOnCall == nil {
+		fake.nowReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.nowReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeImpl) ReadFile(arg1 string) ([]byte, error) {
+	fake.readFileMutex.Lock()
+	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
+	fake.readFileArgsForCall = append(fake.readFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReadFileStub
+	fakeReturns := fake.readFileReturns
+	fake.recordInvocation("ReadFile", []interface{}{arg1})
+	fake.readFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ReadFileCallCount() int {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return len(fake.readFileArgsForCall)
+}
+
+func (fake *FakeImpl) ReadFileCalls(stub func(string) ([]byte, error)) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = stub
+}
+
+func (fake *FakeImpl) ReadFileArgsForCall(i int) string {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	argsForCall := fake.readFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ReadFileReturns(result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	fake.readFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	if fake.readFileReturnsOnCall == nil {
+		fake.readFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SignFile(arg1 string) error {
+	fake.signFileMutex.Lock()
+	ret, specificReturn := fake.signFileReturnsOnCall[len(fake.signFileArgsForCall)]
+	fake.signFileArgsForCall = append(fake.signFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SignFileStub
+	fakeReturns := fake.signFileReturns
+	fake.recordInvocation("SignFile", []interface{}{arg1})
+	fake.signFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) SignFileCallCount() int {
+	fake.signFileMutex.RLock()
+	defer fake.signFileMutex.RUnlock()
+	return len(fake.signFileArgsForCall)
+}
+
+func (fake *FakeImpl) SignFileCalls(stub func(string) error) {
+	fake.signFileMutex.Lock()
+	defer fake.signFileMutex.Unlock()
+	fake.SignFileStub = stub
+}
+
+func (fake *FakeImpl) SignFileArgsForCall(i int) string {
+	fake.signFileMutex.RLock()
+	defer fake.signFileMutex.RUnlock()
+	argsForCall := fake.signFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) SignFileReturns(result1 error) {
+	fake.signFileMutex.Lock()
+	defer fake.signFileMutex.Unlock()
+	fake.SignFileStub = nil
+	fake.signFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) SignFileReturnsOnCall(i int, result1 error) {
+	fake.signFileMutex.Lock()
+	defer fake.signFileMutex.Unlock()
+	fake.SignFileStub = nil
+	if fake.signFileReturnsOnCall == nil {
+		fake.signFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.signFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.appendFileMutex.RLock()
+	defer fake.appendFileMutex.RUnlock()
+	fake.nowMutex.RLock()
+	defer fake.nowMutex.RUnlock()
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	fake.signFileMutex.RLock()
+	defer fake.signFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
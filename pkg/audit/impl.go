@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sigs.k8s.io/release-sdk/sign"
+
+	"k8s.io/release/pkg/signkey"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt auditfakes/fake_impl.go > auditfakes/_fake_impl.go && mv auditfakes/_fake_impl.go auditfakes/fake_impl.go"
+type impl interface {
+	Now() time.Time
+	ReadFile(path string) ([]byte, error)
+	AppendFile(path string, data []byte) error
+	SignFile(path string) error
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) Now() time.Time {
+	return time.Now().UTC()
+}
+
+func (*defaultImpl) ReadFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return content, err
+}
+
+func (*defaultImpl) AppendFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("sync audit log: %w", err)
+	}
+	return f.Close()
+}
+
+func (*defaultImpl) SignFile(path string) error {
+	_, err := sign.New(signkey.Default().SignOptions()).SignFile(path)
+	return err
+}
@@ -0,0 +1,460 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backport suggests merged pull requests of the default branch as
+// cherry pick candidates for the active release branches, based on their
+// release notes.
+package backport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-sdk/git"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/supportedversions"
+)
+
+// fileStatusAdded is the GitHub status of files added by a pull request.
+const fileStatusAdded = "added"
+
+// DefaultKinds are the kinds of pull requests which are backport candidates.
+var DefaultKinds = []string{
+	string(notes.KindBug),
+	string(notes.KindRegression),
+	string(notes.KindFailingTest),
+}
+
+// cherryPickRe matches the pull request numbers in the title of a cherry
+// pick, for example "Automated cherry pick of #123: Fix foo".
+var cherryPickRe = regexp.MustCompile(`#(\d+)`)
+
+// Options are the main options for suggesting backports.
+type Options struct {
+	// GitHubOrg is the GitHub organization of the repository.
+	GitHubOrg string
+
+	// GitHubRepo is the GitHub repository of the pull requests.
+	GitHubRepo string
+
+	// Branches are the release branches to suggest backports for. Empty
+	// means all supported branches of the supported versions document.
+	Branches []string
+
+	// SupportedVersionsURL is the URL of the published supported versions
+	// document.
+	SupportedVersionsURL string
+
+	// Since is the date from which on merged pull requests are considered.
+	Since time.Time
+
+	// RepoPath is the optional path to a local clone of the repository,
+	// which is used for gathering the release notes.
+	RepoPath string
+
+	// NotesFile is a JSON release notes file as written by
+	// `release-notes --format json`, which is used instead of gathering the
+	// release notes.
+	NotesFile string
+
+	// Kinds are the kinds of pull requests to suggest.
+	Kinds []string
+
+	// CheckFiles verifies that a pull request changes non-test files which
+	// exist on the release branch.
+	CheckFiles bool
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		GitHubOrg:  git.DefaultGithubOrg,
+		GitHubRepo: git.DefaultGithubRepo,
+		SupportedVersionsURL: fmt.Sprintf(
+			"%s/release/%s", release.ProductionBucketURL, supportedversions.FileName,
+		),
+		Kinds:      slices.Clone(DefaultKinds),
+		CheckFiles: true,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.GitHubOrg == "" || o.GitHubRepo == "" {
+		return errors.New("GitHub organization and repository must not be empty")
+	}
+	for _, branch := range o.Branches {
+		if !strings.HasPrefix(branch, "release-") || !git.IsReleaseBranch(branch) {
+			return fmt.Errorf("invalid release branch: %q", branch)
+		}
+	}
+	if len(o.Branches) == 0 && o.SupportedVersionsURL == "" {
+		return errors.New("either branches or the supported versions URL has to be specified")
+	}
+	if o.Since.IsZero() {
+		return errors.New("no start date specified")
+	}
+	if len(o.Kinds) == 0 {
+		return errors.New("no kinds specified")
+	}
+	return nil
+}
+
+// Suggestion is a pull request which is a backport candidate.
+type Suggestion struct {
+	Number int      `json:"number"`
+	URL    string   `json:"url"`
+	Author string   `json:"author"`
+	Note   string   `json:"note"`
+	Kinds  []string `json:"kinds"`
+	SIGs   []string `json:"sigs,omitempty"`
+}
+
+// Branch are the backport suggestions of a single release branch.
+type Branch struct {
+	Name string `json:"name"`
+
+	// MaintenanceMode is true if the branch only receives critical fixes.
+	MaintenanceMode bool `json:"maintenanceMode,omitempty"`
+
+	Suggestions []*Suggestion `json:"suggestions"`
+
+	// Picked are the candidates which already have a cherry pick pull
+	// request for the branch.
+	Picked []int `json:"picked"`
+}
+
+// Report are the backport suggestions of all branches.
+type Report struct {
+	Since    time.Time `json:"since"`
+	Kinds    []string  `json:"kinds"`
+	Branches []*Branch `json:"branches"`
+}
+
+// Markdown returns the report as markdown.
+func (r *Report) Markdown() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(
+		buf, "## Backport suggestions of %s since %s\n",
+		strings.Join(r.Kinds, ", "), r.Since.Format(time.DateOnly),
+	)
+	for _, branch := range r.Branches {
+		fmt.Fprintf(buf, "\n### %s\n\n", branch.Name)
+		if branch.MaintenanceMode {
+			buf.WriteString("The branch is in maintenance mode and only receives critical fixes.\n\n")
+		}
+		if len(branch.Suggestions) == 0 {
+			buf.WriteString("No backport candidates found.\n")
+		} else {
+			table := tablewriter.NewWriter(buf)
+			table.SetAutoWrapText(false)
+			table.SetAlignment(tablewriter.ALIGN_LEFT)
+			table.SetHeader([]string{"PR", "Kinds", "SIGs", "Author", "Note"})
+			for _, s := range branch.Suggestions {
+				table.Append([]string{
+					fmt.Sprintf("[#%d](%s)", s.Number, s.URL),
+					strings.Join(s.Kinds, ", "), strings.Join(s.SIGs, ", "),
+					"@" + s.Author, firstLine(s.Note),
+				})
+			}
+			table.SetBorders(tablewriter.Border{
+				Left: true, Top: false, Right: true, Bottom: false,
+			})
+			table.SetCenterSeparator("|")
+			table.Render()
+		}
+		if len(branch.Picked) > 0 {
+			picked := make([]string, 0, len(branch.Picked))
+			for _, number := range branch.Picked {
+				picked = append(picked, fmt.Sprintf("#%d", number))
+			}
+			fmt.Fprintf(buf, "\nAlready cherry picked: %s\n", strings.Join(picked, ", "))
+		}
+	}
+	return buf.String()
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
+
+// Suggester is the main structure for suggesting backports.
+type Suggester struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Suggester instance.
+func New(opts *Options) *Suggester {
+	return &Suggester{
+		impl:    newDefaultImpl(),
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (s *Suggester) SetImpl(impl impl) {
+	s.impl = impl
+}
+
+// Run suggests the backports for the current date.
+func (s *Suggester) Run() (*Report, error) {
+	return s.Suggest(time.Now())
+}
+
+// Suggest returns the merged pull requests of the configured kinds, which
+// have not been cherry picked to the active release branches yet. Pull
+// requests which only change tests or files not existing on a branch are not
+// suggested if CheckFiles is set.
+func (s *Suggester) Suggest(now time.Time) (*Report, error) {
+	if err := s.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	report := &Report{
+		Since:    s.options.Since,
+		Kinds:    s.options.Kinds,
+		Branches: []*Branch{},
+	}
+	branches, err := s.branches(now)
+	if err != nil {
+		return nil, fmt.Errorf("get release branches: %w", err)
+	}
+
+	candidates, err := s.candidates()
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Found %d backport candidates since %s", len(candidates), s.options.Since.Format(time.DateOnly))
+
+	files := map[int][]string{}
+	if s.options.CheckFiles {
+		for _, candidate := range candidates {
+			if files[candidate.Number], err = s.changedFiles(candidate.Number); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, branch := range branches {
+		picked, err := s.cherryPicked(branch.Name)
+		if err != nil {
+			return nil, fmt.Errorf("get cherry picks of %s: %w", branch.Name, err)
+		}
+		for _, candidate := range candidates {
+			if picked[candidate.Number] {
+				branch.Picked = append(branch.Picked, candidate.Number)
+				continue
+			}
+			if s.options.CheckFiles {
+				exists, err := s.anyFileExists(branch.Name, files[candidate.Number])
+				if err != nil {
+					return nil, err
+				}
+				if !exists {
+					logrus.Debugf("Skipping PR #%d which does not touch any code of %s", candidate.Number, branch.Name)
+					continue
+				}
+			}
+			branch.Suggestions = append(branch.Suggestions, candidate)
+		}
+		report.Branches = append(report.Branches, branch)
+	}
+	return report, nil
+}
+
+// branches returns the configured release branches or the supported ones of
+// the supported versions document.
+func (s *Suggester) branches(now time.Time) ([]*Branch, error) {
+	res := []*Branch{}
+	if len(s.options.Branches) > 0 {
+		for _, name := range s.options.Branches {
+			res = append(res, newBranch(name, false))
+		}
+		return res, nil
+	}
+
+	logrus.Infof("Reading supported versions from %s", s.options.SupportedVersionsURL)
+	content, err := s.impl.GetURL(s.options.SupportedVersionsURL)
+	if err != nil {
+		return nil, fmt.Errorf("get supported versions: %w", err)
+	}
+	doc := &supportedversions.Document{}
+	if err := json.Unmarshal(content, doc); err != nil {
+		return nil, fmt.Errorf("unmarshal supported versions: %w", err)
+	}
+
+	today := now.Format(time.DateOnly)
+	for _, version := range doc.Versions {
+		if !version.Supported {
+			continue
+		}
+		maintenance := version.MaintenanceModeStartDate != "" && version.MaintenanceModeStartDate <= today
+		res = append(res, newBranch("release-"+version.Version, maintenance))
+	}
+	if len(res) == 0 {
+		return nil, errors.New("no supported versions found")
+	}
+	return res, nil
+}
+
+func newBranch(name string, maintenance bool) *Branch {
+	return &Branch{
+		Name:            name,
+		MaintenanceMode: maintenance,
+		Suggestions:     []*Suggestion{},
+		Picked:          []int{},
+	}
+}
+
+// candidates returns the release notes of the configured kinds, sorted by
+// pull request number. Notes of automated changes are skipped.
+func (s *Suggester) candidates() ([]*Suggestion, error) {
+	byPR, err := s.releaseNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*Suggestion{}
+	for _, note := range byPR {
+		if note.Automated || !slices.ContainsFunc(note.Kinds, func(kind string) bool {
+			return slices.Contains(s.options.Kinds, kind)
+		}) {
+			continue
+		}
+		res = append(res, &Suggestion{
+			Number: note.PrNumber,
+			URL:    note.PrURL,
+			Author: note.Author,
+			Note:   note.Text,
+			Kinds:  note.Kinds,
+			SIGs:   note.SIGs,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Number < res[j].Number })
+	return res, nil
+}
+
+func (s *Suggester) releaseNotes() (notes.ReleaseNotesByPR, error) {
+	if s.options.NotesFile != "" {
+		content, err := s.impl.ReadFile(s.options.NotesFile)
+		if err != nil {
+			return nil, fmt.Errorf("read release notes: %w", err)
+		}
+		byPR := notes.ReleaseNotesByPR{}
+		if err := json.Unmarshal(content, &byPR); err != nil {
+			return nil, fmt.Errorf("unmarshal release notes: %w", err)
+		}
+		return byPR, nil
+	}
+
+	notesOptions := options.New()
+	notesOptions.Branch = git.DefaultBranch
+	notesOptions.GithubOrg = s.options.GitHubOrg
+	notesOptions.GithubRepo = s.options.GitHubRepo
+	notesOptions.RepoPath = s.options.RepoPath
+	notesOptions.StartDate = s.options.Since
+	if err := notesOptions.ValidateAndFinish(); err != nil {
+		return nil, fmt.Errorf("validating notes options: %w", err)
+	}
+
+	releaseNotes, err := s.impl.GatherReleaseNotes(notesOptions)
+	if err != nil {
+		return nil, fmt.Errorf("gathering release notes: %w", err)
+	}
+	return releaseNotes.ByPR(), nil
+}
+
+// changedFiles returns the files of the pull request which existed before,
+// excluding tests.
+func (s *Suggester) changedFiles(number int) ([]string, error) {
+	files, err := s.impl.ListPullRequestFiles(s.options.GitHubOrg, s.options.GitHubRepo, number)
+	if err != nil {
+		return nil, fmt.Errorf("list files of PR #%d: %w", number, err)
+	}
+
+	res := []string{}
+	for _, file := range files {
+		name := file.GetFilename()
+		if file.GetStatus() == fileStatusAdded || isTest(name) {
+			continue
+		}
+		if previous := file.GetPreviousFilename(); previous != "" {
+			name = previous
+		}
+		res = append(res, name)
+	}
+	return res, nil
+}
+
+func isTest(file string) bool {
+	return strings.HasSuffix(file, "_test.go") ||
+		strings.HasPrefix(file, "test/") ||
+		slices.Contains(strings.Split(path.Dir(file), "/"), "testdata")
+}
+
+func (s *Suggester) anyFileExists(branch string, files []string) (bool, error) {
+	for _, file := range files {
+		exists, err := s.impl.FileExists(s.options.GitHubOrg, s.options.GitHubRepo, branch, file)
+		if err != nil {
+			return false, fmt.Errorf("check if %s exists on %s: %w", file, branch, err)
+		}
+		if exists {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cherryPicked returns the numbers of all pull requests which got cherry
+// picked to the branch since the start date.
+func (s *Suggester) cherryPicked(branch string) (map[int]bool, error) {
+	issues, err := s.impl.SearchIssues(strings.Join([]string{
+		fmt.Sprintf("repo:%s/%s", s.options.GitHubOrg, s.options.GitHubRepo),
+		"is:pr", "base:" + branch, `"cherry pick of"`,
+		"created:>=" + s.options.Since.Format(time.DateOnly),
+	}, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[int]bool{}
+	for _, issue := range issues {
+		// The original title follows the colon and may reference other PRs
+		prefix, _, _ := strings.Cut(issue.GetTitle(), ":")
+		for _, match := range cherryPickRe.FindAllStringSubmatch(prefix, -1) {
+			if number, err := strconv.Atoi(match[1]); err == nil {
+				res[number] = true
+			}
+		}
+	}
+	return res, nil
+}
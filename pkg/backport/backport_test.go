@@ -0,0 +1,244 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backport_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/backport"
+	"k8s.io/release/pkg/backport/backportfakes"
+)
+
+var errTest = errors.New("test")
+
+const testNotes = `{
+	"100": {"pr_number": 100, "pr_url": "https://github.com/kubernetes/kubernetes/pull/100", "author": "alice", "text": "Fixed a crash of the kubelet.\nMore details.", "kinds": ["bug"], "sigs": ["node"]},
+	"101": {"pr_number": 101, "pr_url": "https://github.com/kubernetes/kubernetes/pull/101", "author": "bob", "text": "Added a feature.", "kinds": ["feature"]},
+	"102": {"pr_number": 102, "pr_url": "https://github.com/kubernetes/kubernetes/pull/102", "author": "carol", "text": "Fixed a regression of the scheduler.", "kinds": ["regression"], "sigs": ["scheduling"]},
+	"103": {"pr_number": 103, "pr_url": "https://github.com/kubernetes/kubernetes/pull/103", "author": "bot", "text": "Bumped a dependency.", "kinds": ["bug"], "automated": true},
+	"104": {"pr_number": 104, "pr_url": "https://github.com/kubernetes/kubernetes/pull/104", "author": "dave", "text": "Fixed a flaky test.", "kinds": ["failing-test"]}
+}`
+
+const testSupportedVersions = `{"versions": [
+	{"version": "1.31", "supported": true},
+	{"version": "1.30", "supported": true, "maintenanceModeStartDate": "2025-04-28"},
+	{"version": "1.29", "supported": false}
+]}`
+
+func testImpl() *backportfakes.FakeImpl {
+	mock := &backportfakes.FakeImpl{}
+	mock.ReadFileReturns([]byte(testNotes), nil)
+	mock.GetURLReturns([]byte(testSupportedVersions), nil)
+	mock.SearchIssuesCalls(func(query string) ([]*gogithub.Issue, error) {
+		if strings.Contains(query, "base:release-1.31") {
+			return []*gogithub.Issue{
+				{Title: gogithub.String("Automated cherry pick of #100: Fix kubelet crash (#99)")},
+			}, nil
+		}
+		return nil, nil
+	})
+	mock.ListPullRequestFilesCalls(func(_, _ string, number int) ([]*gogithub.CommitFile, error) {
+		switch number {
+		case 102:
+			return []*gogithub.CommitFile{
+				{Filename: gogithub.String("pkg/scheduler/framework/new.go"), Status: gogithub.String("renamed"), PreviousFilename: gogithub.String("pkg/scheduler/framework/old.go")},
+			}, nil
+		case 104:
+			return []*gogithub.CommitFile{
+				{Filename: gogithub.String("test/e2e/node/pods.go"), Status: gogithub.String("modified")},
+				{Filename: gogithub.String("pkg/kubelet/new.go"), Status: gogithub.String("added")},
+			}, nil
+		}
+		return []*gogithub.CommitFile{
+			{Filename: gogithub.String("pkg/kubelet/kubelet.go"), Status: gogithub.String("modified")},
+			{Filename: gogithub.String("pkg/kubelet/kubelet_test.go"), Status: gogithub.String("modified")},
+		}, nil
+	})
+	mock.FileExistsCalls(func(_, _, ref, path string) (bool, error) {
+		// The scheduler code got introduced in 1.31
+		return ref == "release-1.31" || !strings.HasPrefix(path, "pkg/scheduler/"), nil
+	})
+	return mock
+}
+
+func testOptions() *backport.Options {
+	opts := backport.DefaultOptions()
+	opts.NotesFile = "notes.json"
+	opts.Since = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	return opts
+}
+
+func TestSuggest(t *testing.T) {
+	mock := testImpl()
+	sut := backport.New(testOptions())
+	sut.SetImpl(mock)
+
+	report, err := sut.Suggest(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, report.Branches, 2)
+
+	require.Equal(t, "release-1.31", report.Branches[0].Name)
+	require.False(t, report.Branches[0].MaintenanceMode)
+	require.Len(t, report.Branches[0].Suggestions, 1)
+	require.Equal(t, 102, report.Branches[0].Suggestions[0].Number)
+	require.Equal(t, []int{100}, report.Branches[0].Picked)
+
+	require.Equal(t, "release-1.30", report.Branches[1].Name)
+	require.True(t, report.Branches[1].MaintenanceMode)
+	require.Len(t, report.Branches[1].Suggestions, 1)
+	require.Equal(t, 100, report.Branches[1].Suggestions[0].Number)
+	require.Empty(t, report.Branches[1].Picked)
+
+	require.Contains(t, mock.SearchIssuesArgsForCall(0), "created:>=2025-06-01")
+	_, _, _, path := mock.FileExistsArgsForCall(0)
+	require.Equal(t, "pkg/scheduler/framework/old.go", path)
+
+	markdown := report.Markdown()
+	require.Contains(t, markdown, "### release-1.30")
+	require.Contains(t, markdown, "[#100](https://github.com/kubernetes/kubernetes/pull/100)")
+	require.Contains(t, markdown, "Fixed a crash of the kubelet.")
+	require.NotContains(t, markdown, "More details.")
+	require.Contains(t, markdown, "only receives critical fixes")
+	require.Contains(t, markdown, "Already cherry picked: #100")
+}
+
+func TestSuggestBranchesWithoutFileCheck(t *testing.T) {
+	mock := testImpl()
+	opts := testOptions()
+	opts.Branches = []string{"release-1.30"}
+	opts.Kinds = []string{"failing-test"}
+	opts.CheckFiles = false
+	sut := backport.New(opts)
+	sut.SetImpl(mock)
+
+	report, err := sut.Suggest(time.Now())
+	require.NoError(t, err)
+	require.Len(t, report.Branches, 1)
+	require.Len(t, report.Branches[0].Suggestions, 1)
+	require.Equal(t, 104, report.Branches[0].Suggestions[0].Number)
+	require.Zero(t, mock.GetURLCallCount())
+	require.Zero(t, mock.ListPullRequestFilesCallCount())
+	require.Zero(t, mock.FileExistsCallCount())
+}
+
+func TestSuggestFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		prepare func(*backportfakes.FakeImpl, *backport.Options)
+	}{
+		{
+			name: "invalid options",
+			prepare: func(_ *backportfakes.FakeImpl, o *backport.Options) {
+				o.Branches = []string{"master"}
+			},
+		},
+		{
+			name: "get supported versions fails",
+			prepare: func(mock *backportfakes.FakeImpl, _ *backport.Options) {
+				mock.GetURLReturns(nil, errTest)
+			},
+		},
+		{
+			name: "no supported versions",
+			prepare: func(mock *backportfakes.FakeImpl, _ *backport.Options) {
+				mock.GetURLReturns([]byte(`{"versions": []}`), nil)
+			},
+		},
+		{
+			name: "read notes fails",
+			prepare: func(mock *backportfakes.FakeImpl, _ *backport.Options) {
+				mock.ReadFileReturns(nil, errTest)
+			},
+		},
+		{
+			name: "list files fails",
+			prepare: func(mock *backportfakes.FakeImpl, _ *backport.Options) {
+				mock.ListPullRequestFilesReturns(nil, errTest)
+			},
+		},
+		{
+			name: "search fails",
+			prepare: func(mock *backportfakes.FakeImpl, _ *backport.Options) {
+				mock.SearchIssuesReturns(nil, errTest)
+			},
+		},
+		{
+			name: "file exists fails",
+			prepare: func(mock *backportfakes.FakeImpl, _ *backport.Options) {
+				mock.FileExistsReturns(false, errTest)
+			},
+		},
+	} {
+		mock := testImpl()
+		opts := testOptions()
+		tc.prepare(mock, opts)
+		sut := backport.New(opts)
+		sut.SetImpl(mock)
+
+		_, err := sut.Suggest(time.Now())
+		require.Error(t, err, tc.name)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		modify  func(*backport.Options)
+		success bool
+	}{
+		{
+			name:    "default",
+			modify:  func(*backport.Options) {},
+			success: true,
+		},
+		{
+			name:   "no start date",
+			modify: func(o *backport.Options) { o.Since = time.Time{} },
+		},
+		{
+			name:   "invalid branch",
+			modify: func(o *backport.Options) { o.Branches = []string{"release-1"} },
+		},
+		{
+			name:   "no branches",
+			modify: func(o *backport.Options) { o.SupportedVersionsURL = "" },
+		},
+		{
+			name:   "no kinds",
+			modify: func(o *backport.Options) { o.Kinds = nil },
+		},
+		{
+			name:   "no repository",
+			modify: func(o *backport.Options) { o.GitHubRepo = "" },
+		},
+	} {
+		opts := testOptions()
+		tc.modify(opts)
+		err := opts.Validate()
+		if tc.success {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.Error(t, err, tc.name)
+		}
+	}
+}
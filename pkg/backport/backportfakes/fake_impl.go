@@ -0,0 +1,537 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package backportfakes
+
+import (
+	"sync"
+
+	"github.com/google/go-github/v58/github"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+)
+
+type FakeImpl struct {
+	FileExistsStub        func(string, string, string, string) (bool, error)
+	fileExistsMutex       sync.RWMutex
+	fileExistsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	fileExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	fileExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	GatherReleaseNotesStub        func(*options.Options) (*notes.ReleaseNotes, error)
+	gatherReleaseNotesMutex       sync.RWMutex
+	gatherReleaseNotesArgsForCall []struct {
+		arg1 *options.Options
+	}
+	gatherReleaseNotesReturns struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}
+	gatherReleaseNotesReturnsOnCall map[int]struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}
+	GetURLStub        func(string) ([]byte, error)
+	getURLMutex       sync.RWMutex
+	getURLArgsForCall []struct {
+		arg1 string
+	}
+	getURLReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getURLReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	ListPullRequestFilesStub        func(string, string, int) ([]*github.CommitFile, error)
+	listPullRequestFilesMutex       sync.RWMutex
+	listPullRequestFilesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}
+	listPullRequestFilesReturns struct {
+		result1 []*github.CommitFile
+		result2 error
+	}
+	listPullRequestFilesReturnsOnCall map[int]struct {
+		result1 []*github.CommitFile
+		result2 error
+	}
+	ReadFileStub        func(string) ([]byte, error)
+	readFileMutex       sync.RWMutex
+	readFileArgsForCall []struct {
+		arg1 string
+	}
+	readFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	SearchIssuesStub        func(string) ([]*github.Issue, error)
+	searchIssuesMutex       sync.RWMutex
+	searchIssuesArgsForCall []struct {
+		arg1 string
+	}
+	searchIssuesReturns struct {
+		result1 []*github.Issue
+		result2 error
+	}
+	searchIssuesReturnsOnCall map[int]struct {
+		result1 []*github.Issue
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) FileExists(arg1 string, arg2 string, arg3 string, arg4 string) (bool, error) {
+	fake.fileExistsMutex.Lock()
+	ret, specificReturn := fake.fileExistsReturnsOnCall[len(fake.fileExistsArgsForCall)]
+	fake.fileExistsArgsForCall = append(fake.fileExistsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.FileExistsStub
+	fakeReturns := fake.fileExistsReturns
+	fake.recordInvocation("FileExists", []interface{}{arg1, arg2, arg3, arg4})
+	fake.fileExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) FileExistsCallCount() int {
+	fake.fileExistsMutex.RLock()
+	defer fake.fileExistsMutex.RUnlock()
+	return len(fake.fileExistsArgsForCall)
+}
+
+func (fake *FakeImpl) FileExistsCalls(stub func(string, string, string, string) (bool, error)) {
+	fake.fileExistsMutex.Lock()
+	defer fake.fileExistsMutex.Unlock()
+	fake.FileExistsStub = stub
+}
+
+func (fake *FakeImpl) FileExistsArgsForCall(i int) (string, string, string, string) {
+	fake.fileExistsMutex.RLock()
+	defer fake.fileExistsMutex.RUnlock()
+	argsForCall := fake.fileExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) FileExistsReturns(result1 bool, result2 error) {
+	fake.fileExistsMutex.Lock()
+	defer fake.fileExistsMutex.Unlock()
+	fake.FileExistsStub = nil
+	fake.fileExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) FileExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.fileExistsMutex.Lock()
+	defer fake.fileExistsMutex.Unlock()
+	fake.FileExistsStub = nil
+	if fake.fileExistsReturnsOnCall == nil {
+		fake.fileExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.fileExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GatherReleaseNotes(arg1 *options.Options) (*notes.ReleaseNotes, error) {
+	fake.gatherReleaseNotesMutex.Lock()
+	ret, specificReturn := fake.gatherReleaseNotesReturnsOnCall[len(fake.gatherReleaseNotesArgsForCall)]
+	fake.gatherReleaseNotesArgsForCall = append(fake.gatherReleaseNotesArgsForCall, struct {
+		arg1 *options.Options
+	}{arg1})
+	stub := fake.GatherReleaseNotesStub
+	fakeReturns := fake.gatherReleaseNotesReturns
+	fake.recordInvocation("GatherReleaseNotes", []interface{}{arg1})
+	fake.gatherReleaseNotesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GatherReleaseNotesCallCount() int {
+	fake.gatherReleaseNotesMutex.RLock()
+	defer fake.gatherReleaseNotesMutex.RUnlock()
+	return len(fake.gatherReleaseNotesArgsForCall)
+}
+
+func (fake *FakeImpl) GatherReleaseNotesCalls(stub func(*options.Options) (*notes.ReleaseNotes, error)) {
+	fake.gatherReleaseNotesMutex.Lock()
+	defer fake.gatherReleaseNotesMutex.Unlock()
+	fake.GatherReleaseNotesStub = stub
+}
+
+func (fake *FakeImpl) GatherReleaseNotesArgsForCall(i int) *options.Options {
+	fake.gatherReleaseNotesMutex.RLock()
+	defer fake.gatherReleaseNotesMutex.RUnlock()
+	argsForCall := fake.gatherReleaseNotesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) GatherReleaseNotesReturns(result1 *notes.ReleaseNotes, result2 error) {
+	fake.gatherReleaseNotesMutex.Lock()
+	defer fake.gatherReleaseNotesMutex.Unlock()
+	fake.GatherReleaseNotesStub = nil
+	fake.gatherReleaseNotesReturns = struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GatherReleaseNotesReturnsOnCall(i int, result1 *notes.ReleaseNotes, result2 error) {
+	fake.gatherReleaseNotesMutex.Lock()
+	defer fake.gatherReleaseNotesMutex.Unlock()
+	fake.GatherReleaseNotesStub = nil
+	if fake.gatherReleaseNotesReturnsOnCall == nil {
+		fake.gatherReleaseNotesReturnsOnCall = make(map[int]struct {
+			result1 *notes.ReleaseNotes
+			result2 error
+		})
+	}
+	fake.gatherReleaseNotesReturnsOnCall[i] = struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GetURL(arg1 string) ([]byte, error) {
+	fake.getURLMutex.Lock()
+	ret, specificReturn := fake.getURLReturnsOnCall[len(fake.getURLArgsForCall)]
+	fake.getURLArgsForCall = append(fake.getURLArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetURLStub
+	fakeReturns := fake.getURLReturns
+	fake.recordInvocation("GetURL", []interface{}{arg1})
+	fake.getURLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GetURLCallCount() int {
+	fake.getURLMutex.RLock()
+	defer fake.getURLMutex.RUnlock()
+	return len(fake.getURLArgsForCall)
+}
+
+func (fake *FakeImpl) GetURLCalls(stub func(string) ([]byte, error)) {
+	fake.getURLMutex.Lock()
+	defer fake.getURLMutex.Unlock()
+	fake.GetURLStub = stub
+}
+
+func (fake *FakeImpl) GetURLArgsForCall(i int) string {
+	fake.getURLMutex.RLock()
+	defer fake.getURLMutex.RUnlock()
+	argsForCall := fake.getURLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) GetURLReturns(result1 []byte, result2 error) {
+	fake.getURLMutex.Lock()
+	defer fake.getURLMutex.Unlock()
+	fake.GetURLStub = nil
+	fake.getURLReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GetURLReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getURLMutex.Lock()
+	defer fake.getURLMutex.Unlock()
+	fake.GetURLStub = nil
+	if fake.getURLReturnsOnCall == nil {
+		fake.getURLReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getURLReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListPullRequestFiles(arg1 string, arg2 string, arg3 int) ([]*github.CommitFile, error) {
+	fake.listPullRequestFilesMutex.Lock()
+	ret, specificReturn := fake.listPullRequestFilesReturnsOnCall[len(fake.listPullRequestFilesArgsForCall)]
+	fake.listPullRequestFilesArgsForCall = append(fake.listPullRequestFilesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.ListPullRequestFilesStub
+	fakeReturns := fake.listPullRequestFilesReturns
+	fake.recordInvocation("ListPullRequestFiles", []interface{}{arg1, arg2, arg3})
+	fake.listPullRequestFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ListPullRequestFilesCallCount() int {
+	fake.listPullRequestFilesMutex.RLock()
+	defer fake.listPullRequestFilesMutex.RUnlock()
+	return len(fake.listPullRequestFilesArgsForCall)
+}
+
+func (fake *FakeImpl) ListPullRequestFilesCalls(stub func(string, string, int) ([]*github.CommitFile, error)) {
+	fake.listPullRequestFilesMutex.Lock()
+	defer fake.listPullRequestFilesMutex.Unlock()
+	fake.ListPullRequestFilesStub = stub
+}
+
+func (fake *FakeImpl) ListPullRequestFilesArgsForCall(i int) (string, string, int) {
+	fake.listPullRequestFilesMutex.RLock()
+	defer fake.listPullRequestFilesMutex.RUnlock()
+	argsForCall := fake.listPullRequestFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) ListPullRequestFilesReturns(result1 []*github.CommitFile, result2 error) {
+	fake.listPullRequestFilesMutex.Lock()
+	defer fake.listPullRequestFilesMutex.Unlock()
+	fake.ListPullRequestFilesStub = nil
+	fake.listPullRequestFilesReturns = struct {
+		result1 []*github.CommitFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListPullRequestFilesReturnsOnCall(i int, result1 []*github.CommitFile, result2 error) {
+	fake.listPullRequestFilesMutex.Lock()
+	defer fake.listPullRequestFilesMutex.Unlock()
+	fake.ListPullRequestFilesStub = nil
+	if fake.listPullRequestFilesReturnsOnCall == nil {
+		fake.listPullRequestFilesReturnsOnCall = make(map[int]struct {
+			result1 []*github.CommitFile
+			result2 error
+		})
+	}
+	fake.listPullRequestFilesReturnsOnCall[i] = struct {
+		result1 []*github.CommitFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadFile(arg1 string) ([]byte, error) {
+	fake.readFileMutex.Lock()
+	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
+	fake.readFileArgsForCall = append(fake.readFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReadFileStub
+	fakeReturns := fake.readFileReturns
+	fake.recordInvocation("ReadFile", []interface{}{arg1})
+	fake.readFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ReadFileCallCount() int {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return len(fake.readFileArgsForCall)
+}
+
+func (fake *FakeImpl) ReadFileCalls(stub func(string) ([]byte, error)) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = stub
+}
+
+func (fake *FakeImpl) ReadFileArgsForCall(i int) string {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	argsForCall := fake.readFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ReadFileReturns(result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	fake.readFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	if fake.readFileReturnsOnCall == nil {
+		fake.readFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SearchIssues(arg1 string) ([]*github.Issue, error) {
+	fake.searchIssuesMutex.Lock()
+	ret, specificReturn := fake.searchIssuesReturnsOnCall[len(fake.searchIssuesArgsForCall)]
+	fake.searchIssuesArgsForCall = append(fake.searchIssuesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SearchIssuesStub
+	fakeReturns := fake.searchIssuesReturns
+	fake.recordInvocation("SearchIssues", []interface{}{arg1})
+	fake.searchIssuesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) SearchIssuesCallCount() int {
+	fake.searchIssuesMutex.RLock()
+	defer fake.searchIssuesMutex.RUnlock()
+	return len(fake.searchIssuesArgsForCall)
+}
+
+func (fake *FakeImpl) SearchIssuesCalls(stub func(string) ([]*github.Issue, error)) {
+	fake.searchIssuesMutex.Lock()
+	defer fake.searchIssuesMutex.Unlock()
+	fake.SearchIssuesStub = stub
+}
+
+func (fake *FakeImpl) SearchIssuesArgsForCall(i int) string {
+	fake.searchIssuesMutex.RLock()
+	defer fake.searchIssuesMutex.RUnlock()
+	argsForCall := fake.searchIssuesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) SearchIssuesReturns(result1 []*github.Issue, result2 error) {
+	fake.searchIssuesMutex.Lock()
+	defer fake.searchIssuesMutex.Unlock()
+	fake.SearchIssuesStub = nil
+	fake.searchIssuesReturns = struct {
+		result1 []*github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SearchIssuesReturnsOnCall(i int, result1 []*github.Issue, result2 error) {
+	fake.searchIssuesMutex.Lock()
+	defer fake.searchIssuesMutex.Unlock()
+	fake.SearchIssuesStub = nil
+	if fake.searchIssuesReturnsOnCall == nil {
+		fake.searchIssuesReturnsOnCall = make(map[int]struct {
+			result1 []*github.Issue
+			result2 error
+		})
+	}
+	fake.searchIssuesReturnsOnCall[i] = struct {
+		result1 []*github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.fileExistsMutex.RLock()
+	defer fake.fileExistsMutex.RUnlock()
+	fake.gatherReleaseNotesMutex.RLock()
+	defer fake.gatherReleaseNotesMutex.RUnlock()
+	fake.getURLMutex.RLock()
+	defer fake.getURLMutex.RUnlock()
+	fake.listPullRequestFilesMutex.RLock()
+	defer fake.listPullRequestFilesMutex.RUnlock()
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	fake.searchIssuesMutex.RLock()
+	defer fake.searchIssuesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/env"
+	khttp "sigs.k8s.io/release-utils/http"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt backportfakes/fake_impl.go > backportfakes/_fake_impl.go && mv backportfakes/_fake_impl.go backportfakes/fake_impl.go"
+type impl interface {
+	GatherReleaseNotes(opts *options.Options) (*notes.ReleaseNotes, error)
+	ReadFile(name string) ([]byte, error)
+	GetURL(url string) ([]byte, error)
+	SearchIssues(query string) ([]*gogithub.Issue, error)
+	ListPullRequestFiles(owner, repo string, number int) ([]*gogithub.CommitFile, error)
+	FileExists(owner, repo, ref, path string) (bool, error)
+}
+
+type defaultImpl struct {
+	client *gogithub.Client
+}
+
+func newDefaultImpl() *defaultImpl {
+	httpClient := http.DefaultClient
+	if token := env.Default(github.TokenEnvKey, ""); token != "" {
+		httpClient = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		))
+	}
+	return &defaultImpl{client: gogithub.NewClient(httpClient)}
+}
+
+func (*defaultImpl) GatherReleaseNotes(opts *options.Options) (*notes.ReleaseNotes, error) {
+	return notes.GatherReleaseNotes(opts)
+}
+
+func (*defaultImpl) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (*defaultImpl) GetURL(url string) ([]byte, error) {
+	return khttp.NewAgent().Get(url)
+}
+
+func (d *defaultImpl) SearchIssues(query string) ([]*gogithub.Issue, error) {
+	res := []*gogithub.Issue{}
+	opts := &gogithub.SearchOptions{ListOptions: gogithub.ListOptions{PerPage: 100}}
+	for {
+		result, resp, err := d.client.Search.Issues(context.Background(), query, opts)
+		if err != nil {
+			return nil, fmt.Errorf("search issues: %w", err)
+		}
+		res = append(res, result.Issues...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+	return res, nil
+}
+
+func (d *defaultImpl) ListPullRequestFiles(owner, repo string, number int) ([]*gogithub.CommitFile, error) {
+	res := []*gogithub.CommitFile{}
+	opts := &gogithub.ListOptions{PerPage: 100}
+	for {
+		files, resp, err := d.client.PullRequests.ListFiles(context.Background(), owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("list files: %w", err)
+		}
+		res = append(res, files...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return res, nil
+}
+
+// FileExists returns true if the path exists at the ref of the repository.
+func (d *defaultImpl) FileExists(owner, repo, ref, path string) (bool, error) {
+	_, _, resp, err := d.client.Repositories.GetContents(
+		context.Background(), owner, repo, path,
+		&gogithub.RepositoryContentGetOptions{Ref: ref},
+	)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get contents of %s: %w", path, err)
+	}
+	return true, nil
+}
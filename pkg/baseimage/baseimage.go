@@ -0,0 +1,292 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package baseimage verifies that released container images are built on an
+// allowed base image. The allowlist contains the pinned base image digests
+// per release branch, and an image complies if its layers start with the
+// layers of one of them. Images built on an unexpected or outdated base, for
+// example because of a misconfigured builder, fail the check.
+package baseimage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-utils/env"
+	"sigs.k8s.io/yaml"
+)
+
+// PolicyEnvKey is the environment variable containing the default path of
+// the base image policy.
+const PolicyEnvKey = "KREL_BASE_IMAGE_POLICY"
+
+// Policy is the allowlist of base images.
+type Policy struct {
+	// Default are the allowed base images of branches without own entry.
+	Default []string `json:"default,omitempty"`
+
+	// Branches are the allowed base images per release branch.
+	Branches map[string][]string `json:"branches,omitempty"`
+}
+
+// Validate verifies that all base images are pinned by digest.
+func (p *Policy) Validate() error {
+	refs := append([]string{}, p.Default...)
+	for _, branchRefs := range p.Branches {
+		refs = append(refs, branchRefs...)
+	}
+	for _, ref := range refs {
+		if !strings.Contains(ref, "@sha256:") {
+			return fmt.Errorf("base image %q is not pinned by digest", ref)
+		}
+	}
+	return nil
+}
+
+// Allowed returns the allowed base images of the branch.
+func (p *Policy) Allowed(branch string) []string {
+	if refs, ok := p.Branches[branch]; ok {
+		return refs
+	}
+	return p.Default
+}
+
+// Options are the options for checking the base images.
+type Options struct {
+	// PolicyFile is the path to the YAML base image policy. Disables the check
+	// if empty.
+	PolicyFile string
+
+	// PolicyData is the YAML or JSON base image policy, which takes
+	// precedence over the file. It is used for forwarding the policy to the
+	// GCB jobs, which cannot access the local file.
+	PolicyData string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		PolicyFile: env.Default(PolicyEnvKey, ""),
+	}
+}
+
+// AddFlags adds the base image policy flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.PolicyFile,
+		"base-image-policy",
+		o.PolicyFile,
+		fmt.Sprintf("path to the YAML allowlist of base image digests per release branch, which released images have to be built on (default $%s)", PolicyEnvKey),
+	)
+	flags.StringVar(
+		&o.PolicyData,
+		"base-image-policy-data",
+		o.PolicyData,
+		"YAML or JSON base image policy, which takes precedence over --base-image-policy",
+	)
+}
+
+// Image is a single-platform container image to be checked.
+type Image struct {
+	// Ref is the reference of the image.
+	Ref string
+
+	// Platform is the platform of the image in the format <os>/<arch>.
+	Platform string
+}
+
+// Checker checks images against the base image policy.
+type Checker struct {
+	impl   impl
+	policy *Policy
+}
+
+// New creates a new Checker for the provided policy, which can be nil to
+// disable the check.
+func New(policy *Policy) *Checker {
+	return &Checker{impl: &defaultImpl{}, policy: policy}
+}
+
+// NewFromFile creates a new Checker for the policy file.
+func NewFromFile(path string) (*Checker, error) {
+	c := New(nil)
+	if err := c.LoadPolicy(path); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SetImpl can be used to set the internal implementation.
+func (c *Checker) SetImpl(impl impl) {
+	c.impl = impl
+}
+
+// LoadPolicy reads and validates the policy file.
+func (c *Checker) LoadPolicy(path string) error {
+	data, err := c.impl.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read base image policy: %w", err)
+	}
+	policy, err := parsePolicy(data)
+	if err != nil {
+		return fmt.Errorf("base image policy %s: %w", path, err)
+	}
+	c.policy = policy
+	return nil
+}
+
+// parsePolicy parses and validates the YAML or JSON policy.
+func parsePolicy(data []byte) (*Policy, error) {
+	policy := &Policy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("validate: %w", err)
+	}
+	return policy, nil
+}
+
+// PolicyData returns the policy as single line JSON, which can be passed
+// via --base-image-policy-data. It is empty if no policy is configured.
+func (c *Checker) PolicyData() string {
+	if c.policy == nil {
+		return ""
+	}
+	data, err := json.Marshal(c.policy)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// Enabled returns true if a policy is configured.
+func (c *Checker) Enabled() bool {
+	return c.policy != nil
+}
+
+// Check verifies that all images are built on one of the allowed base images
+// of the branch. It returns an error listing all non-compliant images.
+func (c *Checker) Check(branch string, images []Image) error {
+	if !c.Enabled() {
+		logrus.Info("No base image policy configured, skipping the check")
+		return nil
+	}
+
+	allowed := c.policy.Allowed(branch)
+	if len(allowed) == 0 {
+		return fmt.Errorf("no base images allowed for branch %s", branch)
+	}
+
+	baseLayers := map[string][]string{}
+	errs := []error{}
+	for _, image := range images {
+		layers, err := c.impl.Layers(image.Ref, image.Platform)
+		if err != nil {
+			return fmt.Errorf("get layers of %s: %w", image.Ref, err)
+		}
+
+		base := ""
+		for _, ref := range allowed {
+			key := ref + " " + image.Platform
+			if _, ok := baseLayers[key]; !ok {
+				refLayers, err := c.impl.Layers(ref, image.Platform)
+				if err != nil {
+					return fmt.Errorf("get layers of base image %s: %w", ref, err)
+				}
+				baseLayers[key] = refLayers
+			}
+			if hasPrefix(layers, baseLayers[key]) {
+				base = ref
+				break
+			}
+		}
+
+		if base == "" {
+			errs = append(errs, fmt.Errorf(
+				"%s (%s) is not built on an allowed base image of %s",
+				image.Ref, image.Platform, branch,
+			))
+			continue
+		}
+		logrus.Infof("Image %s (%s) is built on %s", image.Ref, image.Platform, base)
+	}
+	return errors.Join(errs...)
+}
+
+// hasPrefix returns true if the base layers are not empty and match the
+// first layers of the image.
+func hasPrefix(layers, base []string) bool {
+	if len(base) == 0 || len(base) > len(layers) {
+		return false
+	}
+	for i := range base {
+		if layers[i] != base[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	mu      sync.RWMutex
+	current = New(nil)
+)
+
+// Setup loads the base image policy of the provided options.
+func Setup(opts *Options) error {
+	if opts.PolicyData != "" {
+		policy, err := parsePolicy([]byte(opts.PolicyData))
+		if err != nil {
+			return fmt.Errorf("base image policy data: %w", err)
+		}
+		logrus.Info("Checking released images against the provided base image policy data")
+		SetDefault(New(policy))
+		return nil
+	}
+	if opts.PolicyFile == "" {
+		SetDefault(New(nil))
+		return nil
+	}
+	c, err := NewFromFile(opts.PolicyFile)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Checking released images against the base image policy %s", opts.PolicyFile)
+	SetDefault(c)
+	return nil
+}
+
+// SetDefault sets the global base image checker.
+func SetDefault(c *Checker) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = c
+}
+
+// Default returns the global base image checker, which is disabled if not
+// set up.
+func Default() *Checker {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
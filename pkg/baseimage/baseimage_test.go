@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baseimage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/baseimage"
+	"k8s.io/release/pkg/baseimage/baseimagefakes"
+)
+
+const (
+	distroless    = "gcr.io/distroless/static@sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	distrolessOld = "gcr.io/distroless/static@sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	goRunner      = "registry.k8s.io/build-image/go-runner@sha256:3333333333333333333333333333333333333333333333333333333333333333"
+)
+
+var errTest = errors.New("test")
+
+func layers(m map[string][]string) func(string, string) ([]string, error) {
+	return func(ref, _ string) ([]string, error) {
+		l, ok := m[ref]
+		if !ok {
+			return nil, errTest
+		}
+		return l, nil
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		data        string
+		shouldError bool
+	}{
+		{
+			name: "valid",
+			data: "default:\n- " + goRunner + "\nbranches:\n  release-1.30:\n  - " + distroless + "\n",
+		},
+		{
+			name:        "not pinned",
+			data:        "default:\n- gcr.io/distroless/static:latest\n",
+			shouldError: true,
+		},
+		{
+			name:        "unknown field",
+			data:        "allowed:\n- " + goRunner + "\n",
+			shouldError: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &baseimagefakes.FakeImpl{}
+			mock.ReadFileReturns([]byte(tc.data), nil)
+			sut := baseimage.New(nil)
+			sut.SetImpl(mock)
+			require.False(t, sut.Enabled())
+
+			err := sut.LoadPolicy("policy.yaml")
+			if tc.shouldError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, sut.Enabled())
+		})
+	}
+}
+
+func TestSetupPolicyData(t *testing.T) {
+	defer baseimage.SetDefault(baseimage.New(nil))
+
+	policy := &baseimage.Policy{
+		Default:  []string{goRunner},
+		Branches: map[string][]string{"release-1.30": {distroless}},
+	}
+	data := baseimage.New(policy).PolicyData()
+	require.NotContains(t, data, "\n")
+
+	require.NoError(t, baseimage.Setup(&baseimage.Options{
+		PolicyFile: "missing.yaml",
+		PolicyData: data,
+	}))
+	require.True(t, baseimage.Default().Enabled())
+	require.Equal(t, data, baseimage.Default().PolicyData())
+
+	require.Error(t, baseimage.Setup(&baseimage.Options{
+		PolicyData: "default:\n- gcr.io/distroless/static:latest\n",
+	}))
+
+	require.NoError(t, baseimage.Setup(baseimage.DefaultOptions()))
+	require.Empty(t, baseimage.Default().PolicyData())
+}
+
+func TestAllowed(t *testing.T) {
+	t.Parallel()
+
+	policy := &baseimage.Policy{
+		Default:  []string{goRunner},
+		Branches: map[string][]string{"release-1.30": {distroless}},
+	}
+	require.Equal(t, []string{distroless}, policy.Allowed("release-1.30"))
+	require.Equal(t, []string{goRunner}, policy.Allowed("master"))
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	registry := map[string][]string{
+		distroless:    {"sha256:a", "sha256:b"},
+		distrolessOld: {"sha256:x", "sha256:b"},
+		goRunner:      {"sha256:a", "sha256:b", "sha256:c"},
+
+		"registry.k8s.io/kube-proxy-amd64:v1.30.0":     {"sha256:a", "sha256:b", "sha256:d"},
+		"registry.k8s.io/kube-apiserver-amd64:v1.30.0": {"sha256:a", "sha256:b", "sha256:c", "sha256:e"},
+		"registry.k8s.io/kube-outdated-amd64:v1.30.0":  {"sha256:x", "sha256:b", "sha256:f"},
+	}
+	policy := &baseimage.Policy{
+		Default:  []string{goRunner},
+		Branches: map[string][]string{"release-1.30": {goRunner, distroless}},
+	}
+
+	for _, tc := range []struct {
+		name        string
+		branch      string
+		images      []string
+		shouldError bool
+	}{
+		{
+			name:   "allowed bases",
+			branch: "release-1.30",
+			images: []string{
+				"registry.k8s.io/kube-proxy-amd64:v1.30.0",
+				"registry.k8s.io/kube-apiserver-amd64:v1.30.0",
+			},
+		},
+		{
+			name:        "base not allowed for branch",
+			branch:      "master",
+			images:      []string{"registry.k8s.io/kube-proxy-amd64:v1.30.0"},
+			shouldError: true,
+		},
+		{
+			name:        "outdated base",
+			branch:      "release-1.30",
+			images:      []string{"registry.k8s.io/kube-outdated-amd64:v1.30.0"},
+			shouldError: true,
+		},
+		{
+			name:        "image not found",
+			branch:      "release-1.30",
+			images:      []string{"registry.k8s.io/missing-amd64:v1.30.0"},
+			shouldError: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &baseimagefakes.FakeImpl{}
+			mock.LayersCalls(layers(registry))
+			sut := baseimage.New(policy)
+			sut.SetImpl(mock)
+
+			images := []baseimage.Image{}
+			for _, ref := range tc.images {
+				images = append(images, baseimage.Image{Ref: ref, Platform: "linux/amd64"})
+			}
+			err := sut.Check(tc.branch, images)
+			if tc.shouldError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckDisabled(t *testing.T) {
+	t.Parallel()
+
+	mock := &baseimagefakes.FakeImpl{}
+	sut := baseimage.New(nil)
+	sut.SetImpl(mock)
+
+	require.NoError(t, sut.Check("master", []baseimage.Image{{Ref: "registry.k8s.io/pause:3.9"}}))
+	require.Zero(t, mock.LayersCallCount())
+}
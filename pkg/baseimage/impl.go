@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baseimage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"k8s.io/release/pkg/retry"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt baseimagefakes/fake_impl.go > baseimagefakes/_fake_impl.go && mv baseimagefakes/_fake_impl.go baseimagefakes/fake_impl.go"
+type impl interface {
+	Layers(ref, platform string) ([]string, error)
+	ReadFile(name string) ([]byte, error)
+}
+
+type defaultImpl struct{}
+
+// Layers returns the layer digests of the image ref for the platform in the
+// format <os>/<arch>.
+func (*defaultImpl) Layers(ref, platform string) ([]string, error) {
+	goos, arch, _ := strings.Cut(platform, "/")
+	opts := crane.WithPlatform(&v1.Platform{OS: goos, Architecture: arch})
+
+	var manifest *v1.Manifest
+	if err := retry.Do(context.Background(), retry.ServiceRegistry, func() error {
+		img, err := crane.Pull(ref, opts)
+		if err != nil {
+			return err
+		}
+		manifest, err = img.Manifest()
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("get manifest of %s: %w", ref, err)
+	}
+
+	layers := make([]string, 0, len(manifest.Layers))
+	for _, layer := range manifest.Layers {
+		layers = append(layers, layer.Digest.String())
+	}
+	return layers, nil
+}
+
+func (*defaultImpl) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
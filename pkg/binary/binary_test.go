@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -173,3 +174,42 @@ func TestGetPEHeader(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildInfo(t *testing.T) {
+	// The test binary itself contains the build info
+	sut, err := binary.New(os.Args[0])
+	require.Nil(t, err)
+
+	info, err := sut.BuildInfo()
+	require.Nil(t, err)
+	require.Equal(t, runtime.Version(), info.GoVersion)
+	require.NotEmpty(t, info.BuildMode)
+
+	// Fragments do not contain any build info
+	for _, testBin := range GetTestHeaders() {
+		testBin := testBin
+		f := writeTestBinary(t, &testBin.Data)
+		bin, err := binary.New(f.Name())
+		if err == nil {
+			_, err = bin.BuildInfo()
+			require.NotNil(t, err)
+		}
+		os.Remove(f.Name())
+	}
+}
+
+func TestLinkage(t *testing.T) {
+	sut, err := binary.New(os.Args[0])
+	require.Nil(t, err)
+
+	mode, err := sut.LinkMode()
+	require.Nil(t, err)
+
+	linkage, err := sut.Linkage()
+	require.Nil(t, err)
+	require.Equal(t, mode, linkage.Mode)
+	if mode == binary.LinkModeStatic {
+		require.Empty(t, linkage.Libraries)
+		require.Empty(t, linkage.Symbols)
+	}
+}
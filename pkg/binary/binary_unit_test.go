@@ -51,6 +51,75 @@ func TestContainsString(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestParseLinkerVariables(t *testing.T) {
+	for _, tc := range []struct {
+		ldflags  string
+		expected map[string]string
+	}{
+		{ // empty
+			ldflags:  "",
+			expected: map[string]string{},
+		},
+		{ // no variables
+			ldflags:  "-s -w",
+			expected: map[string]string{},
+		},
+		{ // kubernetes style quoted variables
+			ldflags: "-s -w -X 'k8s.io/component-base/version.gitVersion=v1.29.1' " +
+				"-X 'k8s.io/component-base/version.buildDate=2024-01-17T13:38:41Z'",
+			expected: map[string]string{
+				"k8s.io/component-base/version.gitVersion": "v1.29.1",
+				"k8s.io/component-base/version.buildDate":  "2024-01-17T13:38:41Z",
+			},
+		},
+		{ // equals and double quoted syntax
+			ldflags: `-X=main.version=v1.0.0 -X "main.message=hello world" -X`,
+			expected: map[string]string{
+				"main.version": "v1.0.0",
+				"main.message": "hello world",
+			},
+		},
+	} {
+		require.Equal(t, tc.expected, parseLinkerVariables(tc.ldflags))
+	}
+}
+
+func TestGitVersion(t *testing.T) {
+	info := &BuildInfo{Variables: map[string]string{
+		"k8s.io/component-base/version.gitCommit":  "abc",
+		"k8s.io/component-base/version.gitVersion": "v1.29.1",
+	}}
+	require.Equal(t, "v1.29.1", info.GitVersion())
+	require.Empty(t, (&BuildInfo{}).GitVersion())
+}
+
+func TestCompareGLIBCVersions(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"2.17", "2.17", 0},
+		{"2.2.5", "2.17", -1},
+		{"2.34", "2.17", 1},
+		{"2.17", "2.17.0", 0},
+		{"2.3.2", "2.3", 1},
+	} {
+		require.Equal(t, tc.expected, CompareGLIBCVersions(tc.a, tc.b), "%s vs %s", tc.a, tc.b)
+	}
+}
+
+func TestMaxGLIBCVersion(t *testing.T) {
+	linkage := &Linkage{Symbols: map[string]string{
+		"malloc":         "GLIBC_2.2.5",
+		"pthread_create": "GLIBC_2.17",
+		"fcntl64":        "GLIBC_2.3.2",
+	}}
+	require.Equal(t, "2.17", linkage.MaxGLIBCVersion())
+	require.Equal(t, []string{"fcntl64@GLIBC_2.3.2", "pthread_create@GLIBC_2.17"}, linkage.SymbolsNewerThanGLIBC("2.3"))
+	require.Empty(t, linkage.SymbolsNewerThanGLIBC("2.17"))
+	require.Empty(t, (&Linkage{}).MaxGLIBCVersion())
+}
+
 var kubectlFragment = `nxsirlx0QAAAAAAA0HZAFANwVyHQekA7vuLSGA57QHEaitUNKXtAY+ef53SofUDqSbATP1Z+QGgo
 7CEZK4RA97PI/X55hUACFbBWgMiFQO85+v5CLoZABGeTp8C4i0D///////+PQBhRnRjrAphA5jvf
 zhnyo0BqJIxot/+oQB7FLgvj9rJAaUuYyn5qtECfyHUuMhK1QAAAAAAAiMNAER3/Jb8Vx0Dhka4+
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"strings"
+)
+
+// Build settings recorded by the Go toolchain.
+const (
+	settingBuildMode  = "-buildmode"
+	settingLDFlags    = "-ldflags"
+	settingCGOEnabled = "CGO_ENABLED"
+
+	// gitVersionVariable is the suffix of the linker variables used by
+	// Kubernetes to embed the version, for example
+	// k8s.io/component-base/version.gitVersion.
+	gitVersionVariable = "/version.gitVersion"
+)
+
+// BuildInfo contains the build metadata embedded into a Go binary.
+type BuildInfo struct {
+	// GoVersion is the version of the Go toolchain which built the binary.
+	GoVersion string
+
+	// Path is the package path of the main package.
+	Path string
+
+	// BuildMode is the build mode used, for example `exe` or `pie`.
+	BuildMode string
+
+	// CGOEnabled indicates if the binary has been built with CGO.
+	CGOEnabled bool
+
+	// LDFlags are the raw flags passed to the linker.
+	LDFlags string
+
+	// Variables contains all string variables set via `-X` linker flags.
+	Variables map[string]string
+}
+
+// BuildInfo extracts the build metadata from the binary. This works for all
+// supported executable formats (ELF, Mach-O and PE).
+func (b *Binary) BuildInfo() (*BuildInfo, error) {
+	info, err := buildinfo.ReadFile(b.options.Path)
+	if err != nil {
+		return nil, fmt.Errorf("reading build info from %s: %w", b.options.Path, err)
+	}
+
+	res := &BuildInfo{
+		GoVersion: info.GoVersion,
+		Path:      info.Path,
+		Variables: map[string]string{},
+	}
+
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case settingBuildMode:
+			res.BuildMode = setting.Value
+		case settingCGOEnabled:
+			res.CGOEnabled = setting.Value == "1"
+		case settingLDFlags:
+			res.LDFlags = setting.Value
+			res.Variables = parseLinkerVariables(setting.Value)
+		}
+	}
+
+	return res, nil
+}
+
+// GitVersion returns the Kubernetes version embedded via the linker flags or
+// an empty string if not set. The linker flags are not recorded for binaries
+// built with -trimpath, callers have to fall back to scanning the binary.
+func (i *BuildInfo) GitVersion() string {
+	for name, value := range i.Variables {
+		if strings.HasSuffix(name, gitVersionVariable) {
+			return value
+		}
+	}
+	return ""
+}
+
+// parseLinkerVariables returns all variables set via `-X name=value` in the
+// provided linker flags.
+func parseLinkerVariables(ldflags string) map[string]string {
+	res := map[string]string{}
+
+	fields := splitQuoted(ldflags)
+	for i := 0; i < len(fields); i++ {
+		def := ""
+		switch {
+		case fields[i] == "-X" && i+1 < len(fields):
+			i++
+			def = fields[i]
+		case strings.HasPrefix(fields[i], "-X="):
+			def = strings.TrimPrefix(fields[i], "-X=")
+		default:
+			continue
+		}
+
+		name, value, found := strings.Cut(def, "=")
+		if found {
+			res[name] = value
+		}
+	}
+
+	return res
+}
+
+// splitQuoted splits the string at spaces, while respecting single and
+// double quoted parts.
+func splitQuoted(s string) []string {
+	res := []string{}
+	var (
+		current strings.Builder
+		quote   rune
+		inField bool
+	)
+
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == 0 && (r == '\'' || r == '"'):
+			quote = r
+			inField = true
+		case quote == 0 && r == ' ':
+			if inField {
+				res = append(res, current.String())
+				current.Reset()
+				inField = false
+			}
+		default:
+			current.WriteRune(r)
+			inField = true
+		}
+	}
+
+	if inField {
+		res = append(res, current.String())
+	}
+	return res
+}
@@ -111,7 +111,13 @@ func (eh *ELFHeader) MachineType() string {
 		return consts.ArchitectureARM64
 	// 0xF3	RISC-V
 	case 0xF3:
+		if eh.WordLength() == 64 {
+			return consts.ArchitectureRISCV64
+		}
 		return consts.ArchitectureRISCV
+	// 0x102	LoongArch
+	case 0x102:
+		return consts.ArchitectureLOONG64
 	}
 	logrus.Warn("Unknown machine type in elf binary")
 	return "arch unknown"
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary
+
+import (
+	debugelf "debug/elf"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// glibcSymbolPrefix is the version prefix of symbols provided by glibc.
+const glibcSymbolPrefix = "GLIBC_"
+
+// Linkage contains the dynamic linking information of a binary.
+type Linkage struct {
+	// Mode is the linking mode of the binary.
+	Mode LinkMode
+
+	// Libraries are the shared libraries required by the binary (DT_NEEDED).
+	Libraries []string
+
+	// Symbols maps the versioned symbols imported from glibc to their
+	// version, for example `GLIBC_2.2.5`.
+	Symbols map[string]string
+}
+
+// Linkage returns the dynamic linking information of the binary. Libraries
+// and symbols are only available for ELF binaries, all other formats will
+// only report the link mode.
+func (b *Binary) Linkage() (*Linkage, error) {
+	mode, err := b.LinkMode()
+	if err != nil {
+		return nil, fmt.Errorf("getting link mode: %w", err)
+	}
+
+	res := &Linkage{
+		Mode:      mode,
+		Libraries: []string{},
+		Symbols:   map[string]string{},
+	}
+	if mode != LinkModeDynamic || b.OS() != LINUX {
+		return res, nil
+	}
+
+	elfFile, err := debugelf.Open(b.options.Path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse elf: %w", err)
+	}
+	defer elfFile.Close()
+
+	libraries, err := elfFile.ImportedLibraries()
+	if err != nil {
+		return nil, fmt.Errorf("reading imported libraries: %w", err)
+	}
+	sort.Strings(libraries)
+	res.Libraries = libraries
+
+	symbols, err := elfFile.ImportedSymbols()
+	if err != nil {
+		return nil, fmt.Errorf("reading imported symbols: %w", err)
+	}
+	for _, symbol := range symbols {
+		if strings.HasPrefix(symbol.Version, glibcSymbolPrefix) {
+			res.Symbols[symbol.Name] = symbol.Version
+		}
+	}
+
+	return res, nil
+}
+
+// MaxGLIBCVersion returns the highest glibc symbol version required by the
+// binary, for example `2.17`, or an empty string if it does not use glibc.
+func (l *Linkage) MaxGLIBCVersion() string {
+	highest := ""
+	for _, version := range l.Symbols {
+		version = strings.TrimPrefix(version, glibcSymbolPrefix)
+		if highest == "" || CompareGLIBCVersions(version, highest) > 0 {
+			highest = version
+		}
+	}
+	return highest
+}
+
+// SymbolsNewerThanGLIBC returns the sorted glibc symbols which require a
+// version higher than the provided one, in the format `name@GLIBC_x.y`.
+func (l *Linkage) SymbolsNewerThanGLIBC(version string) []string {
+	res := []string{}
+	for name, symbolVersion := range l.Symbols {
+		if CompareGLIBCVersions(
+			strings.TrimPrefix(symbolVersion, glibcSymbolPrefix), version,
+		) > 0 {
+			res = append(res, name+"@"+symbolVersion)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// CompareGLIBCVersions compares two dotted glibc versions like `2.2.5` and
+// `2.17`. The result will be 0 if a == b, -1 if a < b, and +1 if a > b.
+// Non numeric parts are considered as zero.
+func CompareGLIBCVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		aNum, bNum := 0, 0
+		if i < len(aParts) {
+			aNum, _ = strconv.Atoi(aParts[i]) //nolint:errcheck // zero is fine
+		}
+		if i < len(bParts) {
+			bNum, _ = strconv.Atoi(bParts[i]) //nolint:errcheck // zero is fine
+		}
+		if aNum < bNum {
+			return -1
+		}
+		if aNum > bNum {
+			return 1
+		}
+	}
+	return 0
+}
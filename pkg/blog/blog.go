@@ -0,0 +1,374 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package blog generates the release blog post skeleton and proposes it to
+// the Kubernetes website repository.
+package blog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/env"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/templates"
+)
+
+const (
+	// WebsiteRepo is the repository of the Kubernetes website below the
+	// kubernetes organization.
+	WebsiteRepo = "website"
+
+	// WebsiteBranch is the default branch of the website repository.
+	WebsiteBranch = "main"
+
+	// PostsPath is the directory of the blog posts in the website
+	// repository.
+	PostsPath = "content/en/blog/_posts"
+
+	// ForkEnvKey is the environment variable containing the default GitHub
+	// organization of the website fork.
+	ForkEnvKey = "KREL_BLOG_FORK"
+
+	// DefaultMaxHighlights is the default maximum number of highlights in
+	// the blog post.
+	DefaultMaxHighlights = 10
+)
+
+// Options are the main options for generating the release blog post.
+type Options struct {
+	// Tag is the released version, for example v1.30.0.
+	Tag string
+
+	// ReleaseNotesFile is the JSON file containing the release notes of the
+	// version, as written by `release-notes --format json`.
+	ReleaseNotesFile string
+
+	// Date is the publishing date of the post. Defaults to today.
+	Date time.Time
+
+	// MaxHighlights is the maximum number of features listed as
+	// highlights.
+	MaxHighlights int
+
+	// Fork is the GitHub organization of the kubernetes/website fork used
+	// for opening the pull request.
+	Fork string
+
+	// UseSSH specifies if the fork should be pushed via SSH.
+	UseSSH bool
+
+	// NoMock actually opens the pull request if set to true. Otherwise the
+	// post only gets rendered.
+	NoMock bool
+
+	// Template is the name of an official template or the path to a custom
+	// template of the post. Defaults to the official blog post template.
+	Template string
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		MaxHighlights: DefaultMaxHighlights,
+		Fork:          env.Default(ForkEnvKey, ""),
+		Template:      templates.BlogPost,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if _, err := util.TagStringToSemver(o.Tag); err != nil {
+		return fmt.Errorf("invalid tag %q: %w", o.Tag, err)
+	}
+	if o.ReleaseNotesFile == "" {
+		return errors.New("release notes file must not be empty")
+	}
+	if o.NoMock && o.Fork == "" {
+		return errors.New("a fork is required to open pull requests in no mock mode")
+	}
+	return nil
+}
+
+// Blog is the main structure for generating release blog posts.
+type Blog struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Blog instance.
+func New(options *Options) *Blog {
+	return &Blog{&defaultImpl{}, options}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (b *Blog) SetImpl(impl impl) {
+	b.impl = impl
+}
+
+// Post is the rendered release blog post.
+type Post struct {
+	// Path is the path of the post relative to the website repository root.
+	Path string
+
+	// Title is the title of the post.
+	Title string
+
+	// Content is the markdown of the post including its front matter.
+	Content string
+}
+
+// Run renders the blog post and opens a pull request against the website
+// repository. The number of the pull request is zero in mock mode.
+func (b *Blog) Run() (*Post, int, error) {
+	if err := b.options.Validate(); err != nil {
+		return nil, 0, fmt.Errorf("validating options: %w", err)
+	}
+
+	post, err := b.Render()
+	if err != nil {
+		return nil, 0, fmt.Errorf("render blog post: %w", err)
+	}
+
+	if !b.options.NoMock {
+		logrus.Infof("Not opening website pull request in mock mode, post %s:\n%s", post.Path, post.Content)
+		return post, 0, nil
+	}
+
+	pr, err := b.CreatePullRequest(post)
+	if err != nil {
+		return nil, 0, fmt.Errorf("create pull request: %w", err)
+	}
+	return post, pr, nil
+}
+
+// Render generates the blog post skeleton from the release notes.
+func (b *Blog) Render() (*Post, error) {
+	content, err := b.impl.ReadFile(b.options.ReleaseNotesFile)
+	if err != nil {
+		return nil, fmt.Errorf("read release notes: %w", err)
+	}
+	releaseNotes := notes.ReleaseNotesByPR{}
+	if err := json.Unmarshal(content, &releaseNotes); err != nil {
+		return nil, fmt.Errorf("unmarshal release notes: %w", err)
+	}
+
+	version, err := util.TagStringToSemver(b.options.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("parse tag: %w", err)
+	}
+	tag := util.AddTagPrefix(b.options.Tag)
+
+	date := b.options.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	highlights, urgent := selectNotes(releaseNotes, b.options.MaxHighlights)
+	product := branding.Default().ProductName
+	data := &postData{
+		Title:         fmt.Sprintf("%s %s released", product, tag),
+		Product:       product,
+		Date:          date.Format(time.DateOnly),
+		Slug:          "kubernetes-" + strings.ReplaceAll(tag, ".", "-") + "-release",
+		Tag:           tag,
+		ChangelogURL:  changelogURL(tag, version.Major, version.Minor),
+		Highlights:    highlights,
+		UrgentNotes:   urgent,
+		Downloads:     downloads(tag),
+		ReleaseNotesN: len(releaseNotes),
+	}
+
+	templateName := b.options.Template
+	if templateName == "" {
+		templateName = templates.BlogPost
+	}
+	postTemplate, err := templates.Read(templateName)
+	if err != nil {
+		return nil, fmt.Errorf("read template: %w", err)
+	}
+	t, err := template.New("post").Parse(postTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("execute template: %w", err)
+	}
+
+	return &Post{
+		Path:    filepath.Join(PostsPath, fmt.Sprintf("%s-%s.md", data.Date, data.Slug)),
+		Title:   data.Title,
+		Content: buf.String(),
+	}, nil
+}
+
+// CreatePullRequest commits the post to a branch of the fork and opens the
+// pull request against the website repository.
+func (b *Blog) CreatePullRequest(post *Post) (int, error) {
+	branch := "release-blog-" + util.AddTagPrefix(b.options.Tag)
+	repo, err := b.impl.PrepareFork(branch, WebsiteBranch, b.options.Fork, b.options.UseSSH)
+	if err != nil {
+		return 0, fmt.Errorf("prepare fork: %w", err)
+	}
+	defer func() {
+		if err := b.impl.Cleanup(repo); err != nil {
+			logrus.Warnf("Unable to cleanup website repository: %v", err)
+		}
+	}()
+
+	if err := b.impl.WriteFile(
+		filepath.Join(b.impl.RepoDir(repo), post.Path), []byte(post.Content),
+	); err != nil {
+		return 0, fmt.Errorf("write post: %w", err)
+	}
+	if err := b.impl.Add(repo, post.Path); err != nil {
+		return 0, fmt.Errorf("add post: %w", err)
+	}
+	if err := b.impl.Commit(repo, "Add blog post for "+post.Title); err != nil {
+		return 0, fmt.Errorf("commit post: %w", err)
+	}
+	if err := b.impl.PushToRemote(repo, branch); err != nil {
+		return 0, fmt.Errorf("push branch %s: %w", branch, err)
+	}
+
+	pr, err := b.impl.CreatePullRequest(
+		WebsiteBranch, b.options.Fork+":"+branch, "Blog: "+post.Title, pullRequestBody,
+	)
+	if err != nil {
+		return 0, err
+	}
+	logrus.Infof("Opened website pull request #%d for %s", pr, post.Path)
+	return pr, nil
+}
+
+// selectNotes returns the publishable feature notes, limited to max, and all
+// urgent upgrade notes, both sorted by their pull request number.
+func selectNotes(releaseNotes notes.ReleaseNotesByPR, maxHighlights int) (highlights, urgent []string) {
+	prs := make([]int, 0, len(releaseNotes))
+	for pr := range releaseNotes {
+		prs = append(prs, pr)
+	}
+	sort.Ints(prs)
+
+	for _, pr := range prs {
+		note := releaseNotes[pr]
+		if note == nil || note.DoNotPublish || note.Duplicate {
+			continue
+		}
+		text := strings.TrimSpace(note.Markdown)
+		if text == "" {
+			text = strings.TrimSpace(note.Text)
+		}
+		if text == "" {
+			continue
+		}
+		if note.ActionRequired {
+			urgent = append(urgent, text)
+		}
+		if isFeature(note) && len(highlights) < maxHighlights {
+			highlights = append(highlights, text)
+		}
+	}
+	return highlights, urgent
+}
+
+func isFeature(note *notes.ReleaseNote) bool {
+	if note.Feature {
+		return true
+	}
+	for _, kind := range note.Kinds {
+		if kind == "feature" || kind == "api-change" {
+			return true
+		}
+	}
+	return false
+}
+
+func changelogURL(tag string, major, minor uint64) string {
+	return fmt.Sprintf(
+		"https://github.com/kubernetes/kubernetes/blob/master/CHANGELOG/CHANGELOG-%d.%d.md#%s",
+		major, minor, strings.ReplaceAll(tag, ".", ""),
+	)
+}
+
+type download struct {
+	Name, Platform, URL string
+}
+
+// downloads returns the most common release artifacts.
+func downloads(tag string) []download {
+	b := branding.Default()
+	res := []download{{
+		Name:     "kubernetes-src.tar.gz",
+		Platform: "Source",
+		URL:      b.DownloadURL(tag, "kubernetes-src.tar.gz"),
+	}}
+	for _, platform := range []string{"linux/amd64", "linux/arm64", "darwin/amd64", "darwin/arm64", "windows/amd64"} {
+		name := "kubectl"
+		if strings.HasPrefix(platform, "windows") {
+			name += ".exe"
+		}
+		res = append(res, download{
+			Name:     name,
+			Platform: platform,
+			URL:      b.DownloadURL("release", tag, "bin", platform, name),
+		})
+	}
+	for _, platform := range []string{"linux/amd64", "linux/arm64"} {
+		name := fmt.Sprintf("kubernetes-server-%s.tar.gz", strings.ReplaceAll(platform, "/", "-"))
+		res = append(res, download{
+			Name:     name,
+			Platform: platform,
+			URL:      b.DownloadURL(tag, name),
+		})
+	}
+	return res
+}
+
+type postData struct {
+	Title         string
+	Product       string
+	Date          string
+	Slug          string
+	Tag           string
+	ChangelogURL  string
+	Highlights    []string
+	UrgentNotes   []string
+	Downloads     []download
+	ReleaseNotesN int
+}
+
+const pullRequestBody = `This adds the generated blog post skeleton of the release.
+
+The release team still needs to write the introduction and review the
+highlights before publishing.
+
+/hold
+/area blog
+`
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blog_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/release-sdk/git"
+
+	"k8s.io/release/pkg/blog"
+	"k8s.io/release/pkg/blog/blogfakes"
+	"k8s.io/release/pkg/notes"
+)
+
+var errTest = errors.New("test")
+
+func testReleaseNotes(t *testing.T) []byte {
+	res, err := json.Marshal(notes.ReleaseNotesByPR{
+		3: {Markdown: "Added the foo feature", Kinds: []string{"feature"}},
+		1: {Markdown: "Added the bar API", Kinds: []string{"api-change"}},
+		2: {Markdown: "Removed the deprecated flag", ActionRequired: true},
+		4: {Markdown: "Fixed a bug", Kinds: []string{"bug"}},
+		5: {Markdown: "Hidden feature", Feature: true, DoNotPublish: true},
+	})
+	require.Nil(t, err)
+	return res
+}
+
+func testOptions() *blog.Options {
+	opts := blog.DefaultOptions()
+	opts.Tag = "v1.30.0"
+	opts.ReleaseNotesFile = "release-notes.json"
+	opts.Date = time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC)
+	opts.Fork = "fork"
+	return opts
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		modify      func(*blog.Options)
+		shouldError bool
+	}{
+		{ // success
+			modify:      func(*blog.Options) {},
+			shouldError: false,
+		},
+		{ // invalid tag
+			modify:      func(o *blog.Options) { o.Tag = "wrong" },
+			shouldError: true,
+		},
+		{ // no release notes
+			modify:      func(o *blog.Options) { o.ReleaseNotesFile = "" },
+			shouldError: true,
+		},
+		{ // no fork in no mock mode
+			modify: func(o *blog.Options) {
+				o.NoMock = true
+				o.Fork = ""
+			},
+			shouldError: true,
+		},
+	} {
+		opts := testOptions()
+		tc.modify(opts)
+		err := opts.Validate()
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	opts := testOptions()
+	opts.MaxHighlights = 1
+	sut := blog.New(opts)
+	mock := &blogfakes.FakeImpl{}
+	mock.ReadFileReturns(testReleaseNotes(t), nil)
+	sut.SetImpl(mock)
+
+	post, err := sut.Render()
+	require.Nil(t, err)
+	require.Equal(t, "content/en/blog/_posts/2024-04-17-kubernetes-v1-30-0-release.md", post.Path)
+	require.Equal(t, "Kubernetes v1.30.0 released", post.Title)
+	require.Contains(t, post.Content, "date: 2024-04-17")
+	require.Contains(t, post.Content, "- Added the bar API")
+	require.NotContains(t, post.Content, "Added the foo feature")
+	require.NotContains(t, post.Content, "Hidden feature")
+	require.Contains(t, post.Content, "- Removed the deprecated flag")
+	require.Contains(t, post.Content, "https://dl.k8s.io/release/v1.30.0/bin/windows/amd64/kubectl.exe")
+	require.Contains(t, post.Content, "https://dl.k8s.io/v1.30.0/kubernetes-server-linux-arm64.tar.gz")
+	require.Contains(t, post.Content, "CHANGELOG-1.30.md#v1300")
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		noMock      bool
+		prepare     func(*blogfakes.FakeImpl)
+		assert      func(*blogfakes.FakeImpl, int)
+		shouldError bool
+	}{
+		{ // success mock
+			prepare: func(*blogfakes.FakeImpl) {},
+			assert: func(mock *blogfakes.FakeImpl, pr int) {
+				require.Zero(t, pr)
+				require.Zero(t, mock.PrepareForkCallCount())
+			},
+		},
+		{ // success no mock
+			noMock: true,
+			prepare: func(mock *blogfakes.FakeImpl) {
+				mock.PrepareForkReturns(&git.Repo{}, nil)
+				mock.RepoDirReturns("/repo")
+				mock.CreatePullRequestReturns(42, nil)
+			},
+			assert: func(mock *blogfakes.FakeImpl, pr int) {
+				require.Equal(t, 42, pr)
+				branch, base, fork, _ := mock.PrepareForkArgsForCall(0)
+				require.Equal(t, "release-blog-v1.30.0", branch)
+				require.Equal(t, blog.WebsiteBranch, base)
+				require.Equal(t, "fork", fork)
+				path, _ := mock.WriteFileArgsForCall(0)
+				require.Equal(t, "/repo/content/en/blog/_posts/2024-04-17-kubernetes-v1-30-0-release.md", path)
+				_, head, _, _ := mock.CreatePullRequestArgsForCall(0)
+				require.Equal(t, "fork:release-blog-v1.30.0", head)
+				require.Equal(t, 1, mock.CleanupCallCount())
+			},
+		},
+		{ // read release notes fails
+			prepare: func(mock *blogfakes.FakeImpl) {
+				mock.ReadFileReturns(nil, errTest)
+			},
+			shouldError: true,
+		},
+		{ // prepare fork fails
+			noMock: true,
+			prepare: func(mock *blogfakes.FakeImpl) {
+				mock.PrepareForkReturns(nil, errTest)
+			},
+			shouldError: true,
+		},
+		{ // push fails
+			noMock: true,
+			prepare: func(mock *blogfakes.FakeImpl) {
+				mock.PrepareForkReturns(&git.Repo{}, nil)
+				mock.PushToRemoteReturns(errTest)
+			},
+			shouldError: true,
+		},
+		{ // create pull request fails
+			noMock: true,
+			prepare: func(mock *blogfakes.FakeImpl) {
+				mock.PrepareForkReturns(&git.Repo{}, nil)
+				mock.CreatePullRequestReturns(0, errTest)
+			},
+			shouldError: true,
+		},
+	} {
+		opts := testOptions()
+		opts.NoMock = tc.noMock
+		sut := blog.New(opts)
+		mock := &blogfakes.FakeImpl{}
+		mock.ReadFileReturns(testReleaseNotes(t), nil)
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+
+		post, pr, err := sut.Run()
+		if tc.shouldError {
+			require.NotNil(t, err)
+			continue
+		}
+		require.Nil(t, err)
+		require.NotNil(t, post)
+		tc.assert(mock, pr)
+	}
+}
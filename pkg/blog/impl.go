@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blog
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/github"
+
+	"k8s.io/release/pkg/gitclone"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt blogfakes/fake_impl.go > blogfakes/_fake_impl.go && mv blogfakes/_fake_impl.go blogfakes/fake_impl.go"
+type impl interface {
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path string, content []byte) error
+	PrepareFork(branch, baseBranch, forkOrg string, useSSH bool) (*git.Repo, error)
+	RepoDir(repo *git.Repo) string
+	Add(repo *git.Repo, path string) error
+	Commit(repo *git.Repo, msg string) error
+	PushToRemote(repo *git.Repo, branch string) error
+	Cleanup(repo *git.Repo) error
+	CreatePullRequest(baseBranch, head, title, body string) (int, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (*defaultImpl) WriteFile(path string, content []byte) error {
+	//nolint:gosec // the files are part of a repository checkout
+	return os.WriteFile(path, content, 0o644)
+}
+
+func (*defaultImpl) PrepareFork(
+	branch, baseBranch, forkOrg string, useSSH bool,
+) (*git.Repo, error) {
+	repo, err := github.PrepareFork(
+		branch,
+		git.DefaultGithubOrg, WebsiteRepo,
+		forkOrg, WebsiteRepo,
+		useSSH, false, gitclone.Default().GoGit(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.Checkout("-B", branch, git.Remotify(baseBranch)); err != nil {
+		return nil, fmt.Errorf("checkout %s based on %s: %w", branch, baseBranch, err)
+	}
+	return repo, nil
+}
+
+func (*defaultImpl) RepoDir(repo *git.Repo) string {
+	return repo.Dir()
+}
+
+func (*defaultImpl) Add(repo *git.Repo, path string) error {
+	return repo.Add(path)
+}
+
+func (*defaultImpl) Commit(repo *git.Repo, msg string) error {
+	return repo.UserCommit(msg)
+}
+
+func (*defaultImpl) PushToRemote(repo *git.Repo, branch string) error {
+	return repo.PushToRemote(github.UserForkName, branch)
+}
+
+func (*defaultImpl) Cleanup(repo *git.Repo) error {
+	return repo.Cleanup()
+}
+
+func (*defaultImpl) CreatePullRequest(
+	baseBranch, head, title, body string,
+) (int, error) {
+	pr, err := github.New().CreatePullRequest(
+		git.DefaultGithubOrg, WebsiteRepo, baseBranch, head, title, body,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return pr.GetNumber(), nil
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package branding contains the product names and locations which appear in
+// the built artifacts and announcements. Downstream forks can provide their
+// own branding to produce rebranded artifacts and release pages without
+// patching the upstream code.
+package branding
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-utils/env"
+	"sigs.k8s.io/yaml"
+)
+
+// FileEnvKey is the environment variable containing the default path to the
+// branding file.
+const FileEnvKey = "KREL_BRANDING"
+
+// The values of the default branding.
+const (
+	// DefaultProductName is the upstream product name.
+	DefaultProductName = "Kubernetes"
+
+	// DefaultRegistry is the upstream production container registry.
+	DefaultRegistry = "registry.k8s.io"
+
+	// DefaultDownloadHost is the upstream host serving the release
+	// artifacts.
+	DefaultDownloadHost = "dl.k8s.io"
+)
+
+// Branding contains the names and locations of a product. Empty fields use
+// their default.
+type Branding struct {
+	// ProductName is used in announcements, release pages and commit
+	// messages, for example "Kubernetes".
+	ProductName string `json:"productName,omitempty"`
+
+	// Registry is the production container registry, for example
+	// registry.k8s.io.
+	Registry string `json:"registry,omitempty"`
+
+	// DownloadHost is the host serving the release artifacts, for example
+	// dl.k8s.io.
+	DownloadHost string `json:"downloadHost,omitempty"`
+}
+
+// DefaultBranding returns the upstream Kubernetes branding.
+func DefaultBranding() *Branding {
+	b := &Branding{}
+	if err := b.Validate(); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Load reads a branding from the provided YAML file.
+func Load(file string) (*Branding, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read branding: %w", err)
+	}
+	b := &Branding{}
+	if err := yaml.UnmarshalStrict(content, b); err != nil {
+		return nil, fmt.Errorf("unmarshal branding: %w", err)
+	}
+	if err := b.Validate(); err != nil {
+		return nil, fmt.Errorf("validate branding %s: %w", file, err)
+	}
+	return b, nil
+}
+
+// Validate sets the defaults of empty fields and ensures that the locations
+// are plain hosts.
+func (b *Branding) Validate() error {
+	if b.ProductName == "" {
+		b.ProductName = DefaultProductName
+	}
+	if b.Registry == "" {
+		b.Registry = DefaultRegistry
+	}
+	if b.DownloadHost == "" {
+		b.DownloadHost = DefaultDownloadHost
+	}
+	if strings.Contains(b.DownloadHost, "://") || strings.Contains(b.DownloadHost, "/") {
+		return fmt.Errorf("download host %q must not contain a scheme or path", b.DownloadHost)
+	}
+	if strings.Contains(b.Registry, "://") || strings.HasSuffix(b.Registry, "/") {
+		return fmt.Errorf("registry %q must not contain a scheme or trailing slash", b.Registry)
+	}
+	return nil
+}
+
+// DownloadURL returns the URL of the download host, optionally followed by
+// the provided path elements, for example https://dl.k8s.io/release/v1.30.0.
+func (b *Branding) DownloadURL(elem ...string) string {
+	res := "https://" + b.DownloadHost
+	for _, e := range elem {
+		if e = strings.Trim(e, "/"); e != "" {
+			res += "/" + e
+		}
+	}
+	return res
+}
+
+// Options are the options for selecting the branding.
+type Options struct {
+	// File is the YAML file containing the branding. Empty means the
+	// default branding.
+	File string
+
+	// ProductName, Registry and DownloadHost take precedence over the ones
+	// of the File. They are used for forwarding the branding to the GCB
+	// jobs.
+	ProductName  string
+	Registry     string
+	DownloadHost string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		File: env.Default(FileEnvKey, ""),
+	}
+}
+
+// AddFlags adds the branding flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.File,
+		"branding",
+		o.File,
+		fmt.Sprintf("YAML file of the product name, registry and download host (default $%s)", FileEnvKey),
+	)
+	flags.StringVar(
+		&o.ProductName,
+		"branding-product-name",
+		o.ProductName,
+		"product name used in announcements and release pages, takes precedence over --branding",
+	)
+	flags.StringVar(
+		&o.Registry,
+		"branding-registry",
+		o.Registry,
+		"production container registry of the release images, takes precedence over --branding",
+	)
+	flags.StringVar(
+		&o.DownloadHost,
+		"branding-download-host",
+		o.DownloadHost,
+		"host serving the release artifacts, takes precedence over --branding",
+	)
+}
+
+var (
+	mu      sync.RWMutex
+	current = DefaultBranding()
+)
+
+// Setup loads the branding of the provided options and uses it for building
+// and announcing releases.
+func Setup(opts *Options) error {
+	overrides := opts.ProductName != "" || opts.Registry != "" || opts.DownloadHost != ""
+	if opts.File == "" && !overrides {
+		SetDefault(nil)
+		return nil
+	}
+
+	b := &Branding{}
+	if opts.File != "" {
+		var err error
+		if b, err = Load(opts.File); err != nil {
+			return err
+		}
+		logrus.Infof("Using branding %s of %s", opts.File, b.ProductName)
+	}
+	if overrides {
+		if opts.ProductName != "" {
+			b.ProductName = opts.ProductName
+		}
+		if opts.Registry != "" {
+			b.Registry = opts.Registry
+		}
+		if opts.DownloadHost != "" {
+			b.DownloadHost = opts.DownloadHost
+		}
+		if err := b.Validate(); err != nil {
+			return fmt.Errorf("validate branding: %w", err)
+		}
+	}
+	SetDefault(b)
+	return nil
+}
+
+// SetDefault sets the branding used for building and announcing releases. A
+// nil branding restores the default one.
+func SetDefault(b *Branding) {
+	mu.Lock()
+	defer mu.Unlock()
+	if b == nil {
+		b = DefaultBranding()
+	}
+	current = b
+}
+
+// Default returns the branding used for building and announcing releases.
+func Default() *Branding {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
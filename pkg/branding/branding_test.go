@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package branding_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/branding"
+)
+
+func TestDefaultBranding(t *testing.T) {
+	sut := branding.DefaultBranding()
+
+	require.Equal(t, "Kubernetes", sut.ProductName)
+	require.Equal(t, "registry.k8s.io", sut.Registry)
+	require.Equal(t, "https://dl.k8s.io", sut.DownloadURL())
+	require.Equal(t,
+		"https://dl.k8s.io/release/v1.30.0/bin/linux/amd64/kubectl",
+		sut.DownloadURL("release", "/v1.30.0/", "bin/linux/amd64", "kubectl"),
+	)
+}
+
+func TestLoad(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		content   string
+		expected  *branding.Branding
+		shouldErr bool
+	}{
+		{
+			name: "full branding",
+			content: `productName: Acme Kubernetes
+registry: registry.acme.example/k8s
+downloadHost: downloads.acme.example`,
+			expected: &branding.Branding{
+				ProductName:  "Acme Kubernetes",
+				Registry:     "registry.acme.example/k8s",
+				DownloadHost: "downloads.acme.example",
+			},
+		},
+		{
+			name:    "defaults of empty fields",
+			content: `productName: Acme Kubernetes`,
+			expected: &branding.Branding{
+				ProductName:  "Acme Kubernetes",
+				Registry:     branding.DefaultRegistry,
+				DownloadHost: branding.DefaultDownloadHost,
+			},
+		},
+		{
+			name:      "unknown field",
+			content:   `product: Acme`,
+			shouldErr: true,
+		},
+		{
+			name:      "download host with scheme",
+			content:   `downloadHost: https://downloads.acme.example`,
+			shouldErr: true,
+		},
+		{
+			name:      "registry with scheme",
+			content:   `registry: https://registry.acme.example`,
+			shouldErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "branding.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(tc.content), 0o600))
+
+			res, err := branding.Load(file)
+			if tc.shouldErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, res)
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "branding.yaml")
+	require.NoError(t, os.WriteFile(
+		file, []byte("productName: Acme Kubernetes\nregistry: registry.acme.example"), 0o600,
+	))
+
+	require.NoError(t, branding.Setup(&branding.Options{File: file}))
+	defer branding.SetDefault(nil)
+	require.Equal(t, "Acme Kubernetes", branding.Default().ProductName)
+	require.Equal(t, "registry.acme.example", branding.Default().Registry)
+
+	require.NoError(t, branding.Setup(&branding.Options{File: file, Registry: "registry.gcb.example"}))
+	require.Equal(t, "Acme Kubernetes", branding.Default().ProductName)
+	require.Equal(t, "registry.gcb.example", branding.Default().Registry)
+
+	require.Error(t, branding.Setup(&branding.Options{DownloadHost: "https://downloads.acme.example"}))
+
+	require.Error(t, branding.Setup(&branding.Options{File: filepath.Join(t.TempDir(), "missing")}))
+
+	require.NoError(t, branding.Setup(&branding.Options{}))
+	require.Equal(t, "Kubernetes", branding.Default().ProductName)
+}
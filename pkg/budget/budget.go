@@ -0,0 +1,285 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package budget limits the duration of the release phases. A phase which
+// exceeds its timeout budget gets its context cancelled, is recorded in the
+// audit log and optionally notifies a webhook right away, and fails the run
+// instead of silently eating the whole release window.
+package budget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-utils/env"
+
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/notify"
+	"k8s.io/release/pkg/plugin"
+)
+
+// WebhookEnvKey is the environment variable containing the default webhook
+// URL notified about exceeded budgets.
+const WebhookEnvKey = "KREL_PHASE_TIMEOUT_WEBHOOK"
+
+// ExceededError is returned if a phase exceeded its timeout budget.
+type ExceededError struct {
+	// Phase is the name of the phase.
+	Phase string
+
+	// Budget is the maximum duration of the phase.
+	Budget time.Duration
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("phase %q exceeded its timeout budget of %s", e.Phase, e.Budget)
+}
+
+// Options are the options for limiting the phase durations.
+type Options struct {
+	// Timeouts are the budgets of single phases in the format
+	// <phase>=<duration>, for example "build=2h" or "stage-artifacts=1h".
+	Timeouts []string
+
+	// DefaultTimeout is the budget of all phases without an explicit one.
+	// Zero means unlimited.
+	DefaultTimeout time.Duration
+
+	// WebhookURL is an optional webhook notified about exceeded budgets.
+	WebhookURL string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		WebhookURL: env.Default(WebhookEnvKey, ""),
+	}
+}
+
+// AddFlags adds the budget flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringArrayVar(
+		&o.Timeouts,
+		"phase-timeout",
+		o.Timeouts,
+		`maximum duration of a release phase in the format <phase>=<duration>, for example "build=2h", can be set multiple times`,
+	)
+	flags.DurationVar(
+		&o.DefaultTimeout,
+		"phase-timeout-default",
+		o.DefaultTimeout,
+		"maximum duration of every release phase without an explicit --phase-timeout (default unlimited)",
+	)
+	flags.StringVar(
+		&o.WebhookURL,
+		"phase-timeout-webhook",
+		o.WebhookURL,
+		fmt.Sprintf("webhook URL notified if a phase exceeds its timeout (default $%s)", WebhookEnvKey),
+	)
+}
+
+// Budget contains the timeouts of the release phases.
+type Budget struct {
+	impl       impl
+	timeouts   map[string]time.Duration
+	fallback   time.Duration
+	webhookURL string
+}
+
+// New parses the budget of the provided options.
+func New(opts *Options) (*Budget, error) {
+	if opts.DefaultTimeout < 0 {
+		return nil, fmt.Errorf("invalid default phase timeout %s", opts.DefaultTimeout)
+	}
+
+	b := &Budget{
+		impl:       &defaultImpl{},
+		timeouts:   map[string]time.Duration{},
+		fallback:   opts.DefaultTimeout,
+		webhookURL: opts.WebhookURL,
+	}
+	for _, timeout := range opts.Timeouts {
+		phase, value, ok := strings.Cut(timeout, "=")
+		phase = strings.TrimSpace(phase)
+		if !ok || phase == "" {
+			return nil, fmt.Errorf("invalid phase timeout %q, expected <phase>=<duration>", timeout)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid duration of phase timeout %q", timeout)
+		}
+		b.timeouts[plugin.Phase(phase)] = d
+	}
+	return b, nil
+}
+
+// SetImpl can be used to set the internal implementation.
+func (b *Budget) SetImpl(impl impl) {
+	b.impl = impl
+}
+
+// For returns the timeout of the phase, which is zero if unlimited.
+func (b *Budget) For(phase string) time.Duration {
+	if b == nil {
+		return 0
+	}
+	if d, ok := b.timeouts[plugin.Phase(phase)]; ok {
+		return d
+	}
+	return b.fallback
+}
+
+// String returns a printable representation of the timeouts.
+func (b *Budget) String() string {
+	timeouts := []string{}
+	for phase, d := range b.timeouts {
+		timeouts = append(timeouts, fmt.Sprintf("%s=%s", phase, d))
+	}
+	sort.Strings(timeouts)
+	if b.fallback > 0 {
+		timeouts = append(timeouts, fmt.Sprintf("default=%s", b.fallback))
+	}
+	return strings.Join(timeouts, ", ")
+}
+
+// Run runs the function of the phase within its timeout. The function gets a
+// context which is cancelled once the timeout is exceeded, which gets
+// escalated immediately. The function is expected to return early on the
+// cancelled context and is never left running after Run returned, so it
+// cannot keep mutating the release after the phase was reported as failed.
+// An ExceededError is returned if the timeout was exceeded.
+func (b *Budget) Run(ctx context.Context, phase string, fn func(context.Context) error) error {
+	timeout := b.For(phase)
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	exceeded := &ExceededError{Phase: phase, Budget: timeout}
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, exceeded)
+	defer cancel()
+
+	escalated := make(chan struct{})
+	escalation := time.AfterFunc(timeout, func() {
+		defer close(escalated)
+		b.escalate(exceeded)
+	})
+	err := fn(ctx)
+	if escalation.Stop() {
+		return err
+	}
+	<-escalated
+	if err == nil || IsExceeded(err) || errors.Is(err, context.DeadlineExceeded) {
+		return exceeded
+	}
+	return fmt.Errorf("%w: %w", exceeded, err)
+}
+
+// escalate records the exceeded budget in the audit log and notifies the
+// webhook. Failures of the notification are only reported, because the run
+// fails anyway.
+func (b *Budget) escalate(exceeded *ExceededError) {
+	logrus.Errorf("Failing the run: %v", exceeded)
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	b.impl.Record(audit.ActionPhaseTimeout, exceeded.Phase, map[string]string{
+		"budget": exceeded.Budget.String(),
+		"host":   hostname,
+	})
+
+	if b.webhookURL == "" {
+		return
+	}
+	if err := b.impl.Notify(b.webhookURL, &notify.Message{
+		Text: fmt.Sprintf(":rotating_light: Release run on %s failed: %v", hostname, exceeded),
+	}); err != nil {
+		logrus.Warnf("Unable to notify about exceeded phase timeout: %v", err)
+	}
+}
+
+// IsExceeded returns true if the error is caused by an exceeded budget.
+func IsExceeded(err error) bool {
+	var exceeded *ExceededError
+	return errors.As(err, &exceeded)
+}
+
+var (
+	mu            sync.RWMutex
+	current       *Budget
+	activeOptions *Options
+)
+
+// Setup parses the budget of the provided options and uses it for limiting
+// the release phases.
+func Setup(opts *Options) error {
+	b, err := New(opts)
+	if err != nil {
+		return err
+	}
+	if len(b.timeouts) > 0 || b.fallback > 0 {
+		logrus.Infof("Using phase timeouts %s", b)
+	}
+	SetDefault(b)
+	mu.Lock()
+	activeOptions = opts
+	mu.Unlock()
+	return nil
+}
+
+// ActiveOptions returns the options of the last Setup, or nil if Setup was
+// not called.
+func ActiveOptions() *Options {
+	mu.RLock()
+	defer mu.RUnlock()
+	return activeOptions
+}
+
+// SetDefault sets the budget used for limiting the release phases. A nil
+// budget disables the limits.
+func SetDefault(b *Budget) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = b
+}
+
+// Default returns the budget used for limiting the release phases, which is
+// nil if disabled.
+func Default() *Budget {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Run runs the function of the phase within the timeout of the default
+// budget.
+func Run(ctx context.Context, phase string, fn func(context.Context) error) error {
+	b := Default()
+	if b == nil {
+		return fn(ctx)
+	}
+	return b.Run(ctx, phase, fn)
+}
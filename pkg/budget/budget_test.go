@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budget_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/budget"
+	"k8s.io/release/pkg/budget/budgetfakes"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		opts       *budget.Options
+		phase      string
+		expected   time.Duration
+		shouldFail bool
+	}{
+		{
+			name:     "no budget",
+			opts:     &budget.Options{},
+			phase:    "build",
+			expected: 0,
+		},
+		{
+			name:     "phase budget",
+			opts:     &budget.Options{Timeouts: []string{"build=2h"}},
+			phase:    "build",
+			expected: 2 * time.Hour,
+		},
+		{
+			name:     "normalized phase name",
+			opts:     &budget.Options{Timeouts: []string{"stage-artifacts=30m"}},
+			phase:    "stage artifacts",
+			expected: 30 * time.Minute,
+		},
+		{
+			name: "default budget",
+			opts: &budget.Options{
+				Timeouts:       []string{"build=2h"},
+				DefaultTimeout: time.Hour,
+			},
+			phase:    "archive",
+			expected: time.Hour,
+		},
+		{
+			name:       "missing duration",
+			opts:       &budget.Options{Timeouts: []string{"build"}},
+			shouldFail: true,
+		},
+		{
+			name:       "missing phase",
+			opts:       &budget.Options{Timeouts: []string{"=2h"}},
+			shouldFail: true,
+		},
+		{
+			name:       "invalid duration",
+			opts:       &budget.Options{Timeouts: []string{"build=forever"}},
+			shouldFail: true,
+		},
+		{
+			name:       "negative duration",
+			opts:       &budget.Options{Timeouts: []string{"build=-1h"}},
+			shouldFail: true,
+		},
+		{
+			name:       "negative default",
+			opts:       &budget.Options{DefaultTimeout: -time.Hour},
+			shouldFail: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := budget.New(tc.opts)
+			if tc.shouldFail {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, b.For(tc.phase))
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test")
+
+	for _, tc := range []struct {
+		name           string
+		webhookURL     string
+		fn             func(context.Context) error
+		notifyErr      error
+		expectedErr    error
+		exceeded       bool
+		expectedNotify int
+	}{
+		{
+			name:        "success within budget",
+			fn:          func(context.Context) error { return nil },
+			expectedErr: nil,
+		},
+		{
+			name:        "failure within budget",
+			fn:          func(context.Context) error { return errTest },
+			expectedErr: errTest,
+		},
+		{
+			name:     "budget exceeded",
+			fn:       func(context.Context) error { time.Sleep(200 * time.Millisecond); return nil },
+			exceeded: true,
+		},
+		{
+			name: "budget exceeded with cancelled context",
+			fn: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			exceeded: true,
+		},
+		{
+			name:           "budget exceeded with notification",
+			webhookURL:     "https://example.com/hook",
+			fn:             func(context.Context) error { time.Sleep(200 * time.Millisecond); return nil },
+			exceeded:       true,
+			expectedNotify: 1,
+		},
+		{
+			name:           "budget exceeded with failing notification",
+			webhookURL:     "https://example.com/hook",
+			fn:             func(context.Context) error { time.Sleep(200 * time.Millisecond); return nil },
+			notifyErr:      errTest,
+			exceeded:       true,
+			expectedNotify: 1,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := budget.New(&budget.Options{
+				Timeouts:   []string{"build=50ms"},
+				WebhookURL: tc.webhookURL,
+			})
+			require.NoError(t, err)
+
+			mock := &budgetfakes.FakeImpl{}
+			mock.NotifyReturns(tc.notifyErr)
+			b.SetImpl(mock)
+
+			returned := false
+			err = b.Run(context.Background(), "build", func(ctx context.Context) error {
+				defer func() { returned = true }()
+				return tc.fn(ctx)
+			})
+			require.True(t, returned)
+			require.Equal(t, tc.exceeded, budget.IsExceeded(err))
+			require.Equal(t, tc.expectedNotify, mock.NotifyCallCount())
+			if !tc.exceeded {
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.Zero(t, mock.RecordCallCount())
+				return
+			}
+
+			require.EqualError(t, err, `phase "build" exceeded its timeout budget of 50ms`)
+			require.Equal(t, 1, mock.RecordCallCount())
+			action, target, details := mock.RecordArgsForCall(0)
+			require.Equal(t, audit.ActionPhaseTimeout, action)
+			require.Equal(t, "build", target)
+			require.Equal(t, "50ms", details["budget"])
+			if tc.expectedNotify > 0 {
+				url, msg := mock.NotifyArgsForCall(0)
+				require.Equal(t, tc.webhookURL, url)
+				require.Contains(t, msg.Text, `phase "build" exceeded`)
+			}
+		})
+	}
+}
+
+func TestRunUnlimited(t *testing.T) {
+	t.Parallel()
+
+	b, err := budget.New(&budget.Options{Timeouts: []string{"build=1ms"}})
+	require.NoError(t, err)
+	mock := &budgetfakes.FakeImpl{}
+	b.SetImpl(mock)
+
+	require.NoError(t, b.Run(context.Background(), "archive", func(context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}))
+	require.Zero(t, mock.RecordCallCount())
+}
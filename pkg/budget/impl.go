@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budget
+
+import (
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/notify"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt budgetfakes/fake_impl.go > budgetfakes/_fake_impl.go && mv budgetfakes/_fake_impl.go budgetfakes/fake_impl.go"
+type impl interface {
+	Record(action audit.Action, target string, details map[string]string)
+	Notify(url string, msg *notify.Message) error
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) Record(action audit.Action, target string, details map[string]string) {
+	audit.Record(action, target, details)
+}
+
+func (*defaultImpl) Notify(url string, msg *notify.Message) error {
+	return notify.New().Send(url, msg)
+}
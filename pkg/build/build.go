@@ -17,18 +17,25 @@ limitations under the License.
 package build
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/release/pkg/layout"
 	"k8s.io/release/pkg/release"
 	"sigs.k8s.io/release-sdk/object"
 )
 
 var DefaultExtraVersionMarkers = []string{}
 
+// DefaultNightlyRetain is the default number of nightly builds which are kept
+// per minor release.
+const DefaultNightlyRetain = 10
+
 // Instance is the main structure for creating and pushing builds.
 type Instance struct {
+	ctx      context.Context
 	opts     *Options
 	objStore object.GCS
 }
@@ -37,6 +44,7 @@ type Instance struct {
 // TODO: Prefer functional options here instead
 func NewInstance(opts *Options) *Instance {
 	instance := &Instance{
+		ctx:      context.Background(),
 		opts:     opts,
 		objStore: *object.NewGCS(),
 	}
@@ -48,6 +56,12 @@ func NewInstance(opts *Options) *Instance {
 	return instance
 }
 
+// SetContext sets the context of the instance, which cancels in-flight
+// remote operations and stops pushing before the next phase if cancelled.
+func (bi *Instance) SetContext(ctx context.Context) {
+	bi.ctx = ctx
+}
+
 // Options are the main options to pass to `Instance`.
 type Options struct {
 	// Specify an alternate bucket for pushes (normally 'devel' or 'ci').
@@ -95,6 +109,12 @@ type Options struct {
 	// used to overwrite this behavior.
 	Version string
 
+	// Source is an optional remote bucket path containing the artifacts of
+	// Version, for example its gcs-stage directory of a stage run. The
+	// artifacts get copied server side instead of being pushed from the
+	// local BuildDir, and container images are not pushed.
+	Source string
+
 	// Append suffix to version name if set.
 	VersionSuffix string
 
@@ -114,9 +134,24 @@ type Options struct {
 	// Do not update the latest file.
 	NoUpdateLatest bool
 
+	// Nightly publishes a CI build of the current workspace as nightly build,
+	// which additionally updates the latest-green version marker and deletes
+	// the old builds of the same minor release according to NightlyRetain.
+	Nightly bool
+
+	// The number of nightly builds per minor release which are kept,
+	// including the new one.
+	NightlyRetain int
+
 	// Do not mark published bits on GCS as publicly readable.
 	PrivateBucket bool
 
+	// EncryptionKey is the optional Cloud KMS key for encrypting the staged
+	// artifacts of an embargoed security release before pushing them to
+	// GCS. The artifacts get decrypted transparently when copying them from
+	// the stage to the release path.
+	EncryptionKey string
+
 	// Validate that the remote image digests exists.
 	ValidateRemoteImageDigests bool
 
@@ -124,8 +159,15 @@ type Options struct {
 	// `ExtraWindowsStageFiles`, otherwise they will be skipped.
 	StageExtraFiles bool
 
-	// This sets the KUBE_BUILD_PLATFORMS value for make release/quick-release commands
+	// This sets the KUBE_BUILD_PLATFORMS value for make release/quick-release
+	// commands. Defaults to the configured platform matrix if empty.
 	KubeBuildPlatforms string
+
+	// Components restricts the pushed binaries and images to the selected
+	// ones, for example for an emergency kubectl rebuild. Release tarballs
+	// and version markers are skipped for partial selections. An empty
+	// selection pushes all components.
+	Components release.Components
 }
 
 // TODO: Refactor so that version is not required as a parameter
@@ -134,7 +176,7 @@ func (bi *Instance) getGCSBuildPath(version string) (string, error) {
 		bi.setBucket()
 	}
 
-	buildPath, err := bi.objStore.GetReleasePath(
+	releasePath, err := layout.Default().ReleasePath(
 		bi.opts.Bucket,
 		bi.opts.GCSRoot,
 		version,
@@ -144,6 +186,11 @@ func (bi *Instance) getGCSBuildPath(version string) (string, error) {
 		return "", fmt.Errorf("get GCS release path: %w", err)
 	}
 
+	buildPath, err := bi.objStore.NormalizePath(releasePath)
+	if err != nil {
+		return "", fmt.Errorf("normalize GCS release path: %w", err)
+	}
+
 	return buildPath, nil
 }
 
@@ -24,6 +24,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/release/pkg/gcp/auth"
+	"k8s.io/release/pkg/platforms"
 	"k8s.io/release/pkg/release"
 	"sigs.k8s.io/release-utils/command"
 )
@@ -91,8 +92,12 @@ func (bi *Instance) Build() error {
 	}
 
 	cmd := command.New("make", releaseType)
-	if bi.opts.KubeBuildPlatforms != "" {
-		cmd.Env(fmt.Sprintf("KUBE_BUILD_PLATFORMS=%s", bi.opts.KubeBuildPlatforms))
+	kubeBuildPlatforms := bi.opts.KubeBuildPlatforms
+	if kubeBuildPlatforms == "" && platforms.Default().Custom() {
+		kubeBuildPlatforms = platforms.Default().KubeBuildPlatforms()
+	}
+	if kubeBuildPlatforms != "" {
+		cmd.Env(fmt.Sprintf("KUBE_BUILD_PLATFORMS=%s", kubeBuildPlatforms))
 	}
 	if buildErr := cmd.RunSuccess(); buildErr != nil {
 		return fmt.Errorf("running make %s: %w", releaseType, buildErr)
@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import "errors"
+
+// The errors returned by the build package, which can be matched by using
+// errors.Is.
+var (
+	// ErrMissingVersion is returned if no version to be pushed could be
+	// determined.
+	ErrMissingVersion = errors.New("a version is required")
+
+	// ErrInvalidBuildVersion is returned if the build version cannot be
+	// released.
+	ErrInvalidBuildVersion = errors.New("not valid for release")
+
+	// ErrDirtyBuild is returned if a dirty build should be pushed in CI.
+	ErrDirtyBuild = errors.New("refusing to push dirty build")
+
+	// ErrRemoteSourceUnsupported is returned if an option is not supported
+	// when pushing from a remote source.
+	ErrRemoteSourceUnsupported = errors.New("cannot be pushed from a remote source")
+
+	// ErrBucketPermissions is returned if the release bucket cannot be
+	// written to.
+	ErrBucketPermissions = errors.New("missing bucket permissions")
+)
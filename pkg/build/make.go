@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/release/pkg/platforms"
 	"k8s.io/release/pkg/release"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-utils/command"
@@ -89,11 +90,15 @@ func (m *Make) MakeCross(version string) error {
 	logrus.Infof("Setting %s to force serial build", buildMemoryKey)
 	os.Setenv(buildMemoryKey, "32")
 
+	makeVars := []string{fmt.Sprintf("KUBE_DOCKER_IMAGE_TAG=%s", version)}
+	if matrix := platforms.Default(); matrix.Custom() {
+		logrus.Infof("Building platforms %s", matrix.KubeBuildPlatforms())
+		makeVars = append(makeVars, fmt.Sprintf("KUBE_BUILD_PLATFORMS=%s", matrix.KubeBuildPlatforms()))
+	}
+
 	logrus.Info("Building binaries")
 	if err := m.impl.Command(
-		"make",
-		"cross-in-a-container",
-		fmt.Sprintf("KUBE_DOCKER_IMAGE_TAG=%s", version),
+		"make", append([]string{"cross-in-a-container"}, makeVars...)...,
 	); err != nil {
 		return fmt.Errorf("build version %s: %w", version, err)
 	}
@@ -106,10 +111,10 @@ func (m *Make) MakeCross(version string) error {
 
 	logrus.Info("Building package tarballs")
 	if err := m.impl.Command(
-		"make",
-		"package-tarballs",
-		fmt.Sprintf("KUBE_DOCKER_IMAGE_TAG=%s", version),
-		fmt.Sprintf("OUT_DIR=%s", newBuildDir),
+		"make", append(
+			[]string{"package-tarballs"},
+			append(makeVars, fmt.Sprintf("OUT_DIR=%s", newBuildDir))...,
+		)...,
 	); err != nil {
 		return fmt.Errorf("build package tarballs: %w", err)
 	}
@@ -24,6 +24,7 @@ import (
 
 	"k8s.io/release/pkg/build"
 	"k8s.io/release/pkg/build/buildfakes"
+	"k8s.io/release/pkg/platforms"
 )
 
 var err = errors.New("error")
@@ -80,3 +81,24 @@ func TestMakeCross(t *testing.T) {
 		}
 	}
 }
+
+func TestMakeCrossPlatforms(t *testing.T) {
+	matrix, err := platforms.New(&platforms.Options{
+		Platforms: []string{"linux/amd64", "linux/riscv64"},
+	})
+	require.NoError(t, err)
+	previous := platforms.Default()
+	platforms.SetDefault(matrix)
+	defer platforms.SetDefault(previous)
+
+	sut := build.NewMake()
+	mock := &buildfakes.FakeImpl{}
+	sut.SetImpl(mock)
+
+	require.NoError(t, sut.MakeCross("v1.20.0"))
+	_, args := mock.CommandArgsForCall(0)
+	require.Equal(t, []string{
+		"cross-in-a-container", "KUBE_DOCKER_IMAGE_TAG=v1.20.0",
+		"KUBE_BUILD_PLATFORMS=linux/amd64 linux/riscv64",
+	}, args)
+}
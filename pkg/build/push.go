@@ -17,18 +17,23 @@ limitations under the License.
 package build
 
 import (
-	"context"
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"cloud.google.com/go/storage"
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/release/pkg/attribution"
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/encryption"
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/progress"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/workdir"
 	"sigs.k8s.io/release-utils/tar"
 	"sigs.k8s.io/release-utils/util"
 )
@@ -103,29 +108,52 @@ var ExtraWindowsStageFiles = []stageFile{
 
 // Push pushes the build by taking the internal options into account.
 func (bi *Instance) Push() error {
+	if bi.opts.Source != "" {
+		return bi.pushFromSource()
+	}
+
 	version, err := bi.findLatestVersion()
 	if err != nil {
 		return fmt.Errorf("find latest version: %w", err)
 	}
 
 	if version == "" {
-		return errors.New("cannot push an empty version")
+		return fmt.Errorf("cannot push: %w", ErrMissingVersion)
 	}
 
 	logrus.Infof("Latest version is %s", version)
 
+	if err := bi.opts.Components.Validate(); err != nil {
+		return fmt.Errorf("validate components: %w", err)
+	}
+	if bi.opts.Components.Partial() {
+		logrus.Infof("Pushing a partial release of: %s", bi.opts.Components)
+	}
+
 	if err := bi.CheckReleaseBucket(); err != nil {
 		return fmt.Errorf("check release bucket access: %w", err)
 	}
 
+	if err := bi.checkCancelled("stage local artifacts"); err != nil {
+		return err
+	}
+
 	if err := bi.StageLocalArtifacts(); err != nil {
 		return fmt.Errorf("staging local artifacts: %w", err)
 	}
 
+	if err := bi.checkCancelled("push container images"); err != nil {
+		return err
+	}
+
 	if err := bi.PushContainerImages(); err != nil {
 		return fmt.Errorf("push container images: %w", err)
 	}
 
+	if err := bi.checkCancelled("push release artifacts"); err != nil {
+		return err
+	}
+
 	gcsDest, gcsDestErr := bi.getGCSBuildPath(version)
 	if gcsDestErr != nil {
 		return fmt.Errorf("get GCS destination: %w", gcsDestErr)
@@ -138,6 +166,57 @@ func (bi *Instance) Push() error {
 		return fmt.Errorf("push release artifacts: %w", err)
 	}
 
+	return bi.publish(version)
+}
+
+// pushFromSource copies the artifacts from the remote Source server side to
+// the bucket, which avoids downloading them to the local machine first.
+func (bi *Instance) pushFromSource() error {
+	version := bi.opts.Version
+	if version == "" {
+		return fmt.Errorf("%w for pushing from a remote source", ErrMissingVersion)
+	}
+	if bi.opts.Registry != "" {
+		return fmt.Errorf("container images %w", ErrRemoteSourceUnsupported)
+	}
+	if bi.opts.Components.Partial() {
+		return fmt.Errorf("partial releases %w", ErrRemoteSourceUnsupported)
+	}
+
+	valid, err := release.IsValidReleaseBuild(version)
+	if err != nil {
+		return fmt.Errorf("determine if release build version is valid: %w", err)
+	}
+	if !valid {
+		return fmt.Errorf("build version %s is %w", version, ErrInvalidBuildVersion)
+	}
+	if bi.opts.CI && release.IsDirtyBuild(version) {
+		return fmt.Errorf("%w %s with --ci flag given", ErrDirtyBuild, version)
+	}
+
+	if err := bi.CheckReleaseBucket(); err != nil {
+		return fmt.Errorf("check release bucket access: %w", err)
+	}
+
+	if err := bi.checkCancelled("copy release artifacts"); err != nil {
+		return err
+	}
+
+	gcsDest, err := bi.getGCSBuildPath(version)
+	if err != nil {
+		return fmt.Errorf("get GCS destination: %w", err)
+	}
+
+	if err := bi.CopyReleaseArtifacts(bi.opts.Source, gcsDest); err != nil {
+		return fmt.Errorf("copy release artifacts: %w", err)
+	}
+
+	return bi.publish(version)
+}
+
+// publish updates the version markers of CI builds after the artifacts got
+// pushed.
+func (bi *Instance) publish(version string) error {
 	if !bi.opts.CI {
 		logrus.Info("No CI flag set, we're done")
 		return nil
@@ -148,8 +227,22 @@ func (bi *Instance) Push() error {
 		return nil
 	}
 
+	if bi.opts.Components.Partial() {
+		logrus.Info("Not updating version markers for a partial release")
+		return nil
+	}
+
+	if err := bi.checkCancelled("publish release"); err != nil {
+		return err
+	}
+
 	// Publish release to GCS
 	extraVersionMarkers := bi.opts.ExtraVersionMarkers
+	if bi.opts.Nightly {
+		extraVersionMarkers = append(
+			slices.Clone(extraVersionMarkers), release.VersionMarkerLatestGreen,
+		)
+	}
 	if err := release.NewPublisher().PublishVersion(
 		bi.opts.BuildType,
 		version,
@@ -163,6 +256,28 @@ func (bi *Instance) Push() error {
 		return fmt.Errorf("publish release: %w", err)
 	}
 
+	if bi.opts.Nightly {
+		if _, err := release.NewPublisher().PruneBuilds(
+			bi.opts.Bucket,
+			bi.opts.GCSRoot,
+			version,
+			bi.opts.Fast,
+			bi.opts.NightlyRetain,
+		); err != nil {
+			return fmt.Errorf("prune nightly builds: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkCancelled returns an error if the context of the instance got
+// cancelled. Already pushed artifacts are kept, which means that a new run
+// only has to sync the remaining ones.
+func (bi *Instance) checkCancelled(next string) error {
+	if err := bi.ctx.Err(); err != nil {
+		return fmt.Errorf("push cancelled before %s: %w", next, err)
+	}
 	return nil
 }
 
@@ -210,14 +325,14 @@ func (bi *Instance) findLatestVersion() (latestVersion string, err error) {
 	}
 	if !valid {
 		return "", fmt.Errorf(
-			"build version %s is not valid for release", latestVersion,
+			"build version %s is %w", latestVersion, ErrInvalidBuildVersion,
 		)
 	}
 
 	if bi.opts.CI && release.IsDirtyBuild(latestVersion) {
 		return "", fmt.Errorf(
-			"refusing to push dirty build %s with --ci flag given",
-			latestVersion,
+			"%w %s with --ci flag given",
+			ErrDirtyBuild, latestVersion,
 		)
 	}
 
@@ -260,7 +375,7 @@ func setupBuildDir(bi *Instance, isBazel bool) {
 func (bi *Instance) CheckReleaseBucket() error {
 	logrus.Infof("Checking bucket %s for write permissions", bi.opts.Bucket)
 
-	client, err := storage.NewClient(context.Background())
+	client, err := storage.NewClient(bi.ctx)
 	if err != nil {
 		return fmt.Errorf(
 			"fetching gcloud credentials, try running "+
@@ -280,15 +395,15 @@ func (bi *Instance) CheckReleaseBucket() error {
 	// Check if bucket exists and user has permissions
 	requiredGCSPerms := []string{"storage.objects.create"}
 	perms, err := bucket.IAM().TestPermissions(
-		context.Background(), requiredGCSPerms,
+		bi.ctx, requiredGCSPerms,
 	)
 	if err != nil {
 		return fmt.Errorf("find release artifact bucket, try running `gcloud auth application-default login`: %w", err)
 	}
 	if len(perms) != 1 {
 		return fmt.Errorf(
-			"GCP user must have at least %s permissions on bucket %s",
-			requiredGCSPerms, bi.opts.Bucket,
+			"%w: GCP user must have at least %s permissions on bucket %s",
+			ErrBucketPermissions, requiredGCSPerms, bi.opts.Bucket,
 		)
 	}
 
@@ -305,25 +420,29 @@ func (bi *Instance) StageLocalArtifacts() error {
 		return fmt.Errorf("remove and replace GCS staging directory: %w", err)
 	}
 
-	// Copy release tarballs to local GCS staging directory for push
-	logrus.Info("Copying release tarballs")
-	if err := util.CopyDirContentsLocal(
-		filepath.Join(bi.opts.BuildDir, release.ReleaseTarsPath), stageDir,
-	); err != nil {
-		return fmt.Errorf("copy source directory into destination: %w", err)
-	}
-
-	if bi.opts.StageExtraFiles {
-		// Copy helpful GCP scripts to local GCS staging directory for push
-		logrus.Info("Copying extra GCP stage files")
-		if err := bi.copyStageFiles(stageDir, ExtraGcpStageFiles); err != nil {
-			return fmt.Errorf("copy GCP stage files: %w", err)
+	if bi.opts.Components.Partial() {
+		logrus.Info("Skipping release tarballs and extra files for a partial release")
+	} else {
+		// Copy release tarballs to local GCS staging directory for push
+		logrus.Info("Copying release tarballs")
+		if err := util.CopyDirContentsLocal(
+			filepath.Join(bi.opts.BuildDir, release.ReleaseTarsPath), stageDir,
+		); err != nil {
+			return fmt.Errorf("copy source directory into destination: %w", err)
 		}
 
-		// Copy helpful Windows scripts to local GCS staging directory for push
-		logrus.Info("Copying extra Windows stage files")
-		if err := bi.copyStageFiles(stageDir, ExtraWindowsStageFiles); err != nil {
-			return fmt.Errorf("copy Windows stage files: %w", err)
+		if bi.opts.StageExtraFiles {
+			// Copy helpful GCP scripts to local GCS staging directory for push
+			logrus.Info("Copying extra GCP stage files")
+			if err := bi.copyStageFiles(stageDir, ExtraGcpStageFiles); err != nil {
+				return fmt.Errorf("copy GCP stage files: %w", err)
+			}
+
+			// Copy helpful Windows scripts to local GCS staging directory for push
+			logrus.Info("Copying extra Windows stage files")
+			if err := bi.copyStageFiles(stageDir, ExtraWindowsStageFiles); err != nil {
+				return fmt.Errorf("copy Windows stage files: %w", err)
+			}
 		}
 	}
 
@@ -332,12 +451,17 @@ func (bi *Instance) StageLocalArtifacts() error {
 	plainBinariesPath := filepath.Join(bi.opts.BuildDir, release.ReleaseStagePath)
 	if util.Exists(plainBinariesPath) {
 		logrus.Info("Copying plain binaries")
-		if err := release.CopyBinaries(
+		if err := release.CopyComponentBinaries(
 			filepath.Join(bi.opts.BuildDir, release.ReleaseStagePath),
 			stageDir,
+			bi.opts.Components,
 		); err != nil {
 			return fmt.Errorf("stage binaries: %w", err)
 		}
+	} else if bi.opts.Components.Partial() {
+		return fmt.Errorf(
+			"plain binaries dir %s is required for a partial release", plainBinariesPath,
+		)
 	} else {
 		logrus.Infof(
 			"Skipping not existing plain binaries dir %s", plainBinariesPath,
@@ -346,8 +470,8 @@ func (bi *Instance) StageLocalArtifacts() error {
 
 	// Write the bill of materials manifests
 	for filename, sbom := range map[string]string{
-		"kubernetes-source.spdx":  filepath.Join(os.TempDir(), fmt.Sprintf("source-bom-%s.spdx", bi.opts.Version)),
-		"kubernetes-release.spdx": filepath.Join(os.TempDir(), fmt.Sprintf("release-bom-%s.spdx", bi.opts.Version)),
+		"kubernetes-source.spdx":  filepath.Join(workdir.TempDir(), fmt.Sprintf("source-bom-%s.spdx", bi.opts.Version)),
+		"kubernetes-release.spdx": filepath.Join(workdir.TempDir(), fmt.Sprintf("release-bom-%s.spdx", bi.opts.Version)),
 	} {
 		if err := util.CopyFileLocal(
 			sbom, filepath.Join(stageDir, filename), false,
@@ -368,6 +492,8 @@ func (bi *Instance) StageLocalArtifacts() error {
 // it. It also ensures that the base dir exists before copying the file (if the
 // file is `required`).
 func (bi *Instance) copyStageFiles(stageDir string, files []stageFile) error {
+	bar := progress.NewBar("Staging files", len(files))
+	defer bar.Finish()
 	for _, file := range files {
 		dstPath := filepath.Join(stageDir, file.dstPath)
 
@@ -389,6 +515,7 @@ func (bi *Instance) copyStageFiles(stageDir string, files []stageFile) error {
 		); err != nil {
 			return fmt.Errorf("copy stage file: %w", err)
 		}
+		bar.Increment()
 	}
 
 	return nil
@@ -409,17 +536,48 @@ func (bi *Instance) PushReleaseArtifacts(srcPath, gcsPath string) error {
 		return fmt.Errorf("checking if source path is a directory: %w", err)
 	}
 
+	if bi.opts.EncryptionKey != "" {
+		encryptedPath, cleanup, err := bi.encrypt(srcPath, finfo.IsDir())
+		if err != nil {
+			return fmt.Errorf("encrypting release artifacts: %w", err)
+		}
+		defer cleanup()
+		srcPath = encryptedPath
+	}
+
 	// If we are handling a single file copy instead of rsync
 	if !finfo.IsDir() {
 		if err := bi.objStore.CopyToRemote(srcPath, dstPath); err != nil {
 			return fmt.Errorf("copying file to GCS: %w", err)
 		}
+		audit.Record(audit.ActionBucketWrite, dstPath, map[string]string{"source": srcPath})
 		return nil
 	}
 
 	if err := bi.objStore.RsyncRecursive(srcPath, dstPath); err != nil {
 		return fmt.Errorf("rsync artifacts to GCS: %w", err)
 	}
+	audit.Record(audit.ActionBucketWrite, dstPath, map[string]string{"source": srcPath})
+	return nil
+}
+
+// CopyReleaseArtifacts copies the artifacts of the remote `srcPath` to the
+// remote `gcsPath`. The copy happens server side if both are in GCS.
+func (bi *Instance) CopyReleaseArtifacts(srcPath, gcsPath string) error {
+	src, err := bi.objStore.NormalizePath(srcPath)
+	if err != nil {
+		return fmt.Errorf("normalize GCS source: %w", err)
+	}
+	dst, err := bi.objStore.NormalizePath(gcsPath)
+	if err != nil {
+		return fmt.Errorf("normalize GCS destination: %w", err)
+	}
+
+	logrus.Infof("Bucket to bucket rsync of release artifacts from %s to %s", src, dst)
+	if err := bi.objStore.RsyncRecursive(src, dst); err != nil {
+		return fmt.Errorf("rsync artifacts between buckets: %w", err)
+	}
+	audit.Record(audit.ActionBucketWrite, dst, map[string]string{"source": src})
 	return nil
 }
 
@@ -433,6 +591,7 @@ func (bi *Instance) PushContainerImages() error {
 	}
 
 	images := release.NewImages()
+	images.SetComponents(bi.opts.Components)
 	logrus.Infof("Publishing container images for %s", bi.opts.Version)
 
 	if err := images.Publish(
@@ -440,6 +599,7 @@ func (bi *Instance) PushContainerImages() error {
 	); err != nil {
 		return fmt.Errorf("publish container images: %w", err)
 	}
+	audit.Record(audit.ActionRegistryPush, bi.opts.Registry, map[string]string{"version": bi.opts.Version})
 
 	if !bi.opts.ValidateRemoteImageDigests {
 		logrus.Info("Will not validate remote image digests")
@@ -455,6 +615,42 @@ func (bi *Instance) PushContainerImages() error {
 	return nil
 }
 
+// encrypt encrypts the file or directory into a temporary location, which
+// gets removed by the returned cleanup function.
+func (bi *Instance) encrypt(srcPath string, isDir bool) (dst string, cleanup func(), err error) {
+	dir, err := workdir.MkdirTemp("encrypted-")
+	if err != nil {
+		return "", nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	cleanup = func() { os.RemoveAll(dir) }
+
+	envelope := encryption.New(&encryption.Options{KeyName: bi.opts.EncryptionKey})
+	if isDir {
+		err = envelope.EncryptDir(srcPath, dir)
+		dst = dir
+	} else {
+		dst = filepath.Join(dir, filepath.Base(srcPath))
+		err = envelope.EncryptFile(srcPath, dst)
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return dst, cleanup, nil
+}
+
+// StageEncrypted returns true if the artifacts of the stage run got
+// encrypted.
+func (bi *Instance) StageEncrypted(buildVersion string) (bool, error) {
+	marker, err := bi.objStore.NormalizePath(
+		layout.Default().StagePath(bi.opts.Bucket, buildVersion, encryption.MarkerFile),
+	)
+	if err != nil {
+		return false, fmt.Errorf("normalize encryption marker path: %w", err)
+	}
+	return bi.objStore.PathExists(marker)
+}
+
 // CopyStagedFromGCS copies artifacts from GCS and between buckets as needed.
 // TODO: Investigate if it's worthwhile to use any of the bi.objStore.Get*Path()
 //
@@ -467,7 +663,7 @@ func (bi *Instance) CopyStagedFromGCS(stagedBucket, buildVersion string) error {
 		bi.objStore.WithAllowMissing(false),
 	)
 
-	gcsStageRoot := filepath.Join(bi.opts.Bucket, release.StagePath, buildVersion, bi.opts.Version)
+	gcsStageRoot := layout.Default().StagePath(bi.opts.Bucket, buildVersion, bi.opts.Version)
 	src := filepath.Join(gcsStageRoot, release.GCSStagePath, bi.opts.Version)
 
 	gcsSrc, gcsSrcErr := bi.objStore.NormalizePath(src)
@@ -475,15 +671,29 @@ func (bi *Instance) CopyStagedFromGCS(stagedBucket, buildVersion string) error {
 		return fmt.Errorf("normalize GCS source: %w", gcsSrcErr)
 	}
 
-	dst, dstErr := bi.objStore.NormalizePath(bi.opts.Bucket, "release", bi.opts.Version)
+	releasePath, releasePathErr := layout.Default().ReleasePath(bi.opts.Bucket, "release", bi.opts.Version, false)
+	if releasePathErr != nil {
+		return fmt.Errorf("get release path: %w", releasePathErr)
+	}
+
+	dst, dstErr := bi.objStore.NormalizePath(releasePath)
 	if dstErr != nil {
 		return fmt.Errorf("normalize GCS destination: %w", dstErr)
 	}
 
+	encrypted, err := bi.StageEncrypted(buildVersion)
+	if err != nil {
+		return fmt.Errorf("check if the stage is encrypted: %w", err)
+	}
+	if encrypted {
+		return bi.copyEncryptedStagedFromGCS(gcsStageRoot, gcsSrc, dst)
+	}
+
 	logrus.Infof("Bucket to bucket rsync from %s to %s", gcsSrc, dst)
 	if err := bi.objStore.RsyncRecursive(gcsSrc, dst); err != nil {
 		return fmt.Errorf("copy stage to release bucket: %w", err)
 	}
+	audit.Record(audit.ActionBucketWrite, dst, map[string]string{"source": gcsSrc})
 
 	src = filepath.Join(src, release.KubernetesTar)
 	dst = filepath.Join(bi.opts.BuildDir, release.GCSStagePath, bi.opts.Version, release.KubernetesTar)
@@ -492,6 +702,17 @@ func (bi *Instance) CopyStagedFromGCS(stagedBucket, buildVersion string) error {
 		return fmt.Errorf("copy to local: %w", err)
 	}
 
+	// The license attribution archive is not available for releases staged
+	// before it got introduced, which is why we allow it to be missing.
+	src = filepath.Join(gcsStageRoot, release.GCSStagePath, bi.opts.Version, attribution.ArchiveName)
+	dst = filepath.Join(bi.opts.BuildDir, release.GCSStagePath, bi.opts.Version, attribution.ArchiveName)
+	logrus.Infof("Copy license attribution archive %s to %s", src, dst)
+	bi.objStore.SetOptions(bi.objStore.WithAllowMissing(true))
+	if err := bi.objStore.CopyToLocal(src, dst); err != nil {
+		return fmt.Errorf("copy attribution archive to local: %w", err)
+	}
+	bi.objStore.SetOptions(bi.objStore.WithAllowMissing(false))
+
 	src = filepath.Join(gcsStageRoot, release.ImagesPath)
 	if err := os.MkdirAll(bi.opts.BuildDir, os.FileMode(0o755)); err != nil {
 		return fmt.Errorf("create dst dir: %w", err)
@@ -504,6 +725,44 @@ func (bi *Instance) CopyStagedFromGCS(stagedBucket, buildVersion string) error {
 	return nil
 }
 
+// copyEncryptedStagedFromGCS is the variant of CopyStagedFromGCS for
+// encrypted stage runs. The bucket to bucket copy is not possible in that
+// case, which is why the artifacts get downloaded and decrypted before
+// pushing them to the release path.
+func (bi *Instance) copyEncryptedStagedFromGCS(gcsStageRoot, gcsSrc, dst string) error {
+	stageDir := filepath.Join(bi.opts.BuildDir, release.GCSStagePath)
+	if err := os.MkdirAll(stageDir, os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("create dst dir: %w", err)
+	}
+	logrus.Infof("Copy encrypted staged artifacts %s to %s", gcsSrc, stageDir)
+	if err := bi.objStore.CopyToLocal(gcsSrc, stageDir); err != nil {
+		return fmt.Errorf("copy to local: %w", err)
+	}
+
+	src := filepath.Join(gcsStageRoot, release.ImagesPath)
+	logrus.Infof("Copy encrypted container images %s to %s", src, bi.opts.BuildDir)
+	if err := bi.objStore.CopyToLocal(src, bi.opts.BuildDir); err != nil {
+		return fmt.Errorf("copy to local: %w", err)
+	}
+
+	envelope := encryption.New(encryption.DefaultOptions())
+	localSrc := filepath.Join(stageDir, bi.opts.Version)
+	for _, dir := range []string{
+		localSrc, filepath.Join(bi.opts.BuildDir, release.ImagesPath),
+	} {
+		if _, err := envelope.DecryptDir(dir); err != nil {
+			return fmt.Errorf("decrypt staged artifacts: %w", err)
+		}
+	}
+
+	logrus.Infof("Pushing decrypted artifacts from %s to %s", localSrc, dst)
+	if err := bi.objStore.RsyncRecursive(localSrc, dst); err != nil {
+		return fmt.Errorf("copy stage to release bucket: %w", err)
+	}
+	audit.Record(audit.ActionBucketWrite, dst, map[string]string{"source": gcsSrc})
+	return nil
+}
+
 // StageLocalSourceTree creates a src.tar.gz from the Kubernetes sources and
 // uploads it to GCS.
 func (bi *Instance) StageLocalSourceTree(workDir, buildVersion string) error {
@@ -526,9 +785,33 @@ func (bi *Instance) StageLocalSourceTree(workDir, buildVersion string) error {
 		bi.objStore.WithAllowMissing(false),
 		bi.objStore.WithNoClobber(false),
 	)
+
+	// The source tree is the first staged artifact, which is why the
+	// encryption marker of the stage run gets written together with it.
+	uploadPath := tarballPath
+	if bi.opts.EncryptionKey != "" {
+		encryptedPath, cleanup, err := bi.encrypt(tarballPath, false)
+		if err != nil {
+			return fmt.Errorf("encrypt tarball: %w", err)
+		}
+		defer cleanup()
+		uploadPath = encryptedPath
+
+		marker := filepath.Join(filepath.Dir(encryptedPath), encryption.MarkerFile)
+		if err := os.WriteFile(marker, []byte(bi.opts.EncryptionKey+"\n"), 0o644); err != nil {
+			return fmt.Errorf("write encryption marker: %w", err)
+		}
+		if err := bi.objStore.CopyToRemote(
+			marker,
+			layout.Default().StagePath(bi.opts.Bucket, buildVersion, encryption.MarkerFile),
+		); err != nil {
+			return fmt.Errorf("copy encryption marker to GCS: %w", err)
+		}
+	}
+
 	if err := bi.objStore.CopyToRemote(
-		tarballPath,
-		filepath.Join(bi.opts.Bucket, release.StagePath, buildVersion, release.SourcesTar),
+		uploadPath,
+		layout.Default().StagePath(bi.opts.Bucket, buildVersion, release.SourcesTar),
 	); err != nil {
 		return fmt.Errorf("copy tarball to GCS: %w", err)
 	}
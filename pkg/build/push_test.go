@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+)
+
+func TestPushFromSourceValidation(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts *Options
+		err  string
+		is   error
+	}{
+		{
+			name: "no version",
+			opts: &Options{},
+			err:  "a version is required",
+			is:   ErrMissingVersion,
+		},
+		{
+			name: "registry",
+			opts: &Options{Version: "v1.30.0", Registry: "gcr.io/foo"},
+			err:  "container images cannot be pushed",
+			is:   ErrRemoteSourceUnsupported,
+		},
+		{
+			name: "partial",
+			opts: &Options{Version: "v1.30.0", Components: release.Components{"kubectl"}},
+			err:  "partial releases cannot be pushed",
+			is:   ErrRemoteSourceUnsupported,
+		},
+		{
+			name: "invalid version",
+			opts: &Options{Version: "wrong"},
+			err:  "is not valid for release",
+			is:   ErrInvalidBuildVersion,
+		},
+		{
+			name: "dirty CI build",
+			opts: &Options{Version: "v1.30.0-alpha.1.10+3ff09514d162b0-dirty", CI: true},
+			err:  "refusing to push dirty build",
+			is:   ErrDirtyBuild,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.opts.Source = "gs://bucket/stage/v1.30.0/gcs-stage/v1.30.0"
+			bi := &Instance{ctx: context.Background(), opts: tc.opts}
+			err := bi.Push()
+			require.ErrorContains(t, err, tc.err)
+			require.ErrorIs(t, err, tc.is)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package buildenv records the toolchain used for building a release.
+package buildenv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+const (
+	// ManifestFilename is the name of the build environment manifest, which
+	// gets published next to the release artifacts.
+	ManifestFilename = "build-environment.json"
+
+	// KubeCrossImage is the builder image of the Kubernetes build.
+	KubeCrossImage = "registry.k8s.io/build-image/kube-cross"
+
+	// notAvailable is recorded for tools which are not installed.
+	notAvailable = "not available"
+)
+
+// Manifest describes the environment of a stage run.
+type Manifest struct {
+	// BuildVersion is the staged build version.
+	BuildVersion string `json:"buildVersion"`
+
+	// Commit is the Kubernetes commit SHA the build got tagged from.
+	Commit string `json:"commit,omitempty"`
+
+	// Created is the time the manifest got recorded.
+	Created time.Time `json:"created"`
+
+	// Krel is the version of krel running the stage.
+	Krel string `json:"krel"`
+
+	// GoVersion is the Go version required by the Kubernetes sources.
+	GoVersion string `json:"goVersion"`
+
+	// Images are the builder images used for the build.
+	Images []Image `json:"images"`
+
+	// Tools are the versions of the tools available on the build host.
+	Tools map[string]string `json:"tools"`
+
+	// OS describes the build host.
+	OS OS `json:"os"`
+}
+
+// Image is a container image used for the build.
+type Image struct {
+	// Reference is the tag based image reference.
+	Reference string `json:"reference"`
+
+	// Digest is the repository digest of the image, if available.
+	Digest string `json:"digest,omitempty"`
+}
+
+// OS describes the operating system of the build host.
+type OS struct {
+	// Name is the pretty name of the distribution.
+	Name string `json:"name"`
+
+	// Kernel is the kernel release.
+	Kernel string `json:"kernel"`
+
+	// Platform is the os/arch pair of the host.
+	Platform string `json:"platform"`
+}
+
+// Collector records the build environment.
+type Collector struct {
+	impl impl
+}
+
+// New returns a new Collector instance.
+func New() *Collector {
+	return &Collector{impl: &defaultImpl{}}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (c *Collector) SetImpl(impl impl) {
+	c.impl = impl
+}
+
+// Collect records the environment of the build of commit in the Kubernetes
+// repository at repoRoot. Tools which are not available get recorded as such.
+func (c *Collector) Collect(repoRoot, buildVersion, commit string) (*Manifest, error) {
+	goVersion, err := c.impl.ReadFile(filepath.Join(repoRoot, ".go-version"))
+	if err != nil {
+		return nil, fmt.Errorf("read go version: %w", err)
+	}
+	kubeCross, err := c.impl.ReadFile(filepath.Join(repoRoot, "build", "build-image", "cross", "VERSION"))
+	if err != nil {
+		return nil, fmt.Errorf("read kube-cross version: %w", err)
+	}
+
+	image := Image{Reference: fmt.Sprintf("%s:%s", KubeCrossImage, strings.TrimSpace(string(kubeCross)))}
+	digests, err := c.impl.Command("docker", "image", "inspect", "--format", "{{ join .RepoDigests \"\\n\" }}", image.Reference)
+	if err != nil {
+		logrus.Warnf("Unable to get the digest of %s: %v", image.Reference, err)
+	} else if digest := firstLine(digests); digest != "" {
+		image.Digest = digest
+	}
+
+	manifest := &Manifest{
+		BuildVersion: buildVersion,
+		Commit:       commit,
+		Created:      time.Now().UTC(),
+		Krel:         version.GetVersionInfo().GitVersion,
+		GoVersion:    strings.TrimSpace(string(goVersion)),
+		Images:       []Image{image},
+		Tools:        map[string]string{},
+		OS: OS{
+			Name:     notAvailable,
+			Kernel:   notAvailable,
+			Platform: runtime.GOOS + "/" + runtime.GOARCH,
+		},
+	}
+
+	for tool, args := range map[string][]string{
+		"go":     {"go", "version"},
+		"make":   {"make", "--version"},
+		"bazel":  {"bazel", "--version"},
+		"docker": {"docker", "version", "--format", "{{ .Server.Version }}"},
+	} {
+		manifest.Tools[tool] = notAvailable
+		output, err := c.impl.Command(args[0], args[1:]...)
+		if err != nil {
+			logrus.Debugf("Tool %s is not available: %v", tool, err)
+			continue
+		}
+		manifest.Tools[tool] = firstLine(output)
+	}
+
+	if kernel, err := c.impl.Command("uname", "-r"); err == nil {
+		manifest.OS.Kernel = firstLine(kernel)
+	}
+	if osRelease, err := c.impl.ReadFile("/etc/os-release"); err == nil {
+		for _, line := range strings.Split(string(osRelease), "\n") {
+			if name, ok := strings.CutPrefix(line, "PRETTY_NAME="); ok {
+				manifest.OS.Name = strings.Trim(name, `"`)
+			}
+		}
+	}
+
+	return manifest, nil
+}
+
+// Write stores the manifest as JSON file.
+func (c *Collector) Write(manifest *Manifest, path string) error {
+	if manifest == nil {
+		return errors.New("no build environment recorded")
+	}
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal build environment: %w", err)
+	}
+	return c.impl.WriteFile(path, append(content, '\n'))
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildenv_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/buildenv"
+	"k8s.io/release/pkg/buildenv/buildenvfakes"
+)
+
+var errTest = errors.New("test")
+
+func newMock() *buildenvfakes.FakeImpl {
+	mock := &buildenvfakes.FakeImpl{}
+	mock.ReadFileCalls(func(path string) ([]byte, error) {
+		switch path {
+		case "/repo/.go-version":
+			return []byte("1.22.1\n"), nil
+		case "/repo/build/build-image/cross/VERSION":
+			return []byte("v1.30.0-go1.22.1-bullseye.0\n"), nil
+		case "/etc/os-release":
+			return []byte("ID=debian\nPRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\n"), nil
+		}
+		return nil, os.ErrNotExist
+	})
+	mock.CommandCalls(func(name string, args ...string) (string, error) {
+		switch name {
+		case "docker":
+			if args[0] == "image" {
+				return "registry.k8s.io/build-image/kube-cross@sha256:abc\nother@sha256:def", nil
+			}
+			return "24.0.7", nil
+		case "go":
+			return "go version go1.22.1 linux/amd64", nil
+		case "make":
+			return "GNU Make 4.3\nBuilt for x86_64-pc-linux-gnu", nil
+		case "uname":
+			return "6.1.0-18-cloud-amd64", nil
+		}
+		return "", errTest
+	})
+	return mock
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	sut := buildenv.New()
+	sut.SetImpl(newMock())
+
+	manifest, err := sut.Collect("/repo", "v1.30.0-rc.0.10+abc", "abc")
+	require.NoError(t, err)
+	require.Equal(t, "v1.30.0-rc.0.10+abc", manifest.BuildVersion)
+	require.Equal(t, "abc", manifest.Commit)
+	require.Equal(t, "1.22.1", manifest.GoVersion)
+	require.Equal(t, []buildenv.Image{{
+		Reference: "registry.k8s.io/build-image/kube-cross:v1.30.0-go1.22.1-bullseye.0",
+		Digest:    "registry.k8s.io/build-image/kube-cross@sha256:abc",
+	}}, manifest.Images)
+	require.Equal(t, map[string]string{
+		"go":     "go version go1.22.1 linux/amd64",
+		"make":   "GNU Make 4.3",
+		"bazel":  "not available",
+		"docker": "24.0.7",
+	}, manifest.Tools)
+	require.Equal(t, "Debian GNU/Linux 12 (bookworm)", manifest.OS.Name)
+	require.Equal(t, "6.1.0-18-cloud-amd64", manifest.OS.Kernel)
+}
+
+func TestCollectMissingSources(t *testing.T) {
+	t.Parallel()
+
+	mock := newMock()
+	mock.ReadFileReturns(nil, errTest)
+	sut := buildenv.New()
+	sut.SetImpl(mock)
+
+	_, err := sut.Collect("/repo", "v1.30.0", "")
+	require.ErrorContains(t, err, "read go version")
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	mock := newMock()
+	sut := buildenv.New()
+	sut.SetImpl(mock)
+
+	require.Error(t, sut.Write(nil, "/out/"+buildenv.ManifestFilename))
+
+	manifest, err := sut.Collect("/repo", "v1.30.0", "")
+	require.NoError(t, err)
+	require.NoError(t, sut.Write(manifest, "/out/"+buildenv.ManifestFilename))
+	require.Equal(t, 1, mock.WriteFileCallCount())
+
+	path, content := mock.WriteFileArgsForCall(0)
+	require.Equal(t, "/out/build-environment.json", path)
+	written := &buildenv.Manifest{}
+	require.NoError(t, json.Unmarshal(content, written))
+	require.Equal(t, "1.22.1", written.GoVersion)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildenv
+
+import (
+	"os"
+
+	"sigs.k8s.io/release-utils/command"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt buildenvfakes/fake_impl.go > buildenvfakes/_fake_impl.go && mv buildenvfakes/_fake_impl.go buildenvfakes/fake_impl.go"
+type impl interface {
+	Command(name string, args ...string) (string, error)
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path string, content []byte) error
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) Command(name string, args ...string) (string, error) {
+	res, err := command.New(name, args...).RunSilentSuccessOutput()
+	if err != nil {
+		return "", err
+	}
+	return res.OutputTrimNL(), nil
+}
+
+func (*defaultImpl) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (*defaultImpl) WriteFile(path string, content []byte) error {
+	return os.WriteFile(path, content, 0o644) //nolint:gosec // the manifest gets published
+}
@@ -188,6 +188,14 @@ func (c *Changelog) Run() error {
 		return fmt.Errorf("generate release notes: %w", err)
 	}
 
+	knownIssues, err := c.impl.KnownIssues(tag)
+	if err != nil {
+		return fmt.Errorf("generate known issues: %w", err)
+	}
+	if knownIssues != "" {
+		markdown += strings.Repeat(nl, 2) + knownIssues
+	}
+
 	if c.options.Dependencies {
 		logrus.Info("Generating dependency changes")
 		deps, err := c.impl.DependencyChanges(startRev, endRev)
@@ -120,6 +120,12 @@ func TestRun(t *testing.T) {
 			},
 			shouldErr: true,
 		},
+		{ // KnownIssues failed
+			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
+				mock.KnownIssuesReturns("", err)
+			},
+			shouldErr: true,
+		},
 		{ // DependencyChanges failed
 			prepare: func(mock *changelogfakes.FakeImpl, opts *changelog.Options) {
 				opts.Dependencies = true
@@ -177,6 +177,19 @@ type FakeImpl struct {
 		result1 string
 		result2 error
 	}
+	KnownIssuesStub        func(semver.Version) (string, error)
+	knownIssuesMutex       sync.RWMutex
+	knownIssuesArgsForCall []struct {
+		arg1 semver.Version
+	}
+	knownIssuesReturns struct {
+		result1 string
+		result2 error
+	}
+	knownIssuesReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	LatestGitHubTagsPerBranchStub        func() (github.TagsPerBranch, error)
 	latestGitHubTagsPerBranchMutex       sync.RWMutex
 	latestGitHubTagsPerBranchArgsForCall []struct {
@@ -1086,6 +1099,70 @@ func (fake *FakeImpl) GetURLResponseReturnsOnCall(i int, result1 string, result2
 	}{result1, result2}
 }
 
+func (fake *FakeImpl) KnownIssues(arg1 semver.Version) (string, error) {
+	fake.knownIssuesMutex.Lock()
+	ret, specificReturn := fake.knownIssuesReturnsOnCall[len(fake.knownIssuesArgsForCall)]
+	fake.knownIssuesArgsForCall = append(fake.knownIssuesArgsForCall, struct {
+		arg1 semver.Version
+	}{arg1})
+	stub := fake.KnownIssuesStub
+	fakeReturns := fake.knownIssuesReturns
+	fake.recordInvocation("KnownIssues", []interface{}{arg1})
+	fake.knownIssuesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) KnownIssuesCallCount() int {
+	fake.knownIssuesMutex.RLock()
+	defer fake.knownIssuesMutex.RUnlock()
+	return len(fake.knownIssuesArgsForCall)
+}
+
+func (fake *FakeImpl) KnownIssuesCalls(stub func(semver.Version) (string, error)) {
+	fake.knownIssuesMutex.Lock()
+	defer fake.knownIssuesMutex.Unlock()
+	fake.KnownIssuesStub = stub
+}
+
+func (fake *FakeImpl) KnownIssuesArgsForCall(i int) semver.Version {
+	fake.knownIssuesMutex.RLock()
+	defer fake.knownIssuesMutex.RUnlock()
+	argsForCall := fake.knownIssuesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) KnownIssuesReturns(result1 string, result2 error) {
+	fake.knownIssuesMutex.Lock()
+	defer fake.knownIssuesMutex.Unlock()
+	fake.KnownIssuesStub = nil
+	fake.knownIssuesReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) KnownIssuesReturnsOnCall(i int, result1 string, result2 error) {
+	fake.knownIssuesMutex.Lock()
+	defer fake.knownIssuesMutex.Unlock()
+	fake.KnownIssuesStub = nil
+	if fake.knownIssuesReturnsOnCall == nil {
+		fake.knownIssuesReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.knownIssuesReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeImpl) LatestGitHubTagsPerBranch() (github.TagsPerBranch, error) {
 	fake.latestGitHubTagsPerBranchMutex.Lock()
 	ret, specificReturn := fake.latestGitHubTagsPerBranchReturnsOnCall[len(fake.latestGitHubTagsPerBranchArgsForCall)]
@@ -1105,6 +1182,8 @@ func (fake *FakeImpl) LatestGitHubTagsPerBranch() (github.TagsPerBranch, error)
 }
 
 func (fake *FakeImpl) LatestGitHubTagsPerBranchCallCount() int {
+	fake.knownIssuesMutex.RLock()
+	defer fake.knownIssuesMutex.RUnlock()
 	fake.latestGitHubTagsPerBranchMutex.RLock()
 	defer fake.latestGitHubTagsPerBranchMutex.RUnlock()
 	return len(fake.latestGitHubTagsPerBranchArgsForCall)
@@ -31,9 +31,11 @@ import (
 	"sigs.k8s.io/mdtoc/pkg/mdtoc"
 
 	"k8s.io/release/pkg/cve"
+	"k8s.io/release/pkg/knownissues"
 	"k8s.io/release/pkg/notes"
 	"k8s.io/release/pkg/notes/document"
 	"k8s.io/release/pkg/notes/options"
+	"k8s.io/release/pkg/workdir"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/github"
 	"sigs.k8s.io/release-sdk/object"
@@ -58,6 +60,7 @@ type impl interface {
 	LatestGitHubTagsPerBranch() (github.TagsPerBranch, error)
 	GenerateTOC(markdown string) (string, error)
 	DependencyChanges(from, to string) (string, error)
+	KnownIssues(tag semver.Version) (string, error)
 	Checkout(repo *git.Repo, rev string, args ...string) error
 
 	// Used in `generateReleaseNotes()`
@@ -136,6 +139,10 @@ func (*defaultImpl) GenerateTOC(markdown string) (string, error) {
 	})
 }
 
+func (*defaultImpl) KnownIssues(tag semver.Version) (string, error) {
+	return knownissues.Default().Markdown(tag)
+}
+
 func (*defaultImpl) DependencyChanges(from, to string) (string, error) {
 	return notes.NewDependencies().Changes(from, to)
 }
@@ -228,7 +235,7 @@ func (*defaultImpl) Rm(repo *git.Repo, force bool, files ...string) error {
 
 // CloneCVEData copies the CVE data maps from the release bucket
 func (*defaultImpl) CloneCVEData() (cveDir string, err error) {
-	tmpdir, err := os.MkdirTemp(os.TempDir(), "cve-maps-")
+	tmpdir, err := workdir.MkdirTemp("cve-maps-")
 	if err != nil {
 		return "", fmt.Errorf("creating temporary dir for CVE data: %w", err)
 	}
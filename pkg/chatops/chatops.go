@@ -0,0 +1,497 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chatops
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/release/pkg/server"
+)
+
+const (
+	// SigningSecretEnvKey is the environment variable containing the Slack
+	// signing secret used to verify the requests.
+	SigningSecretEnvKey = "SLACK_SIGNING_SECRET"
+
+	// BotTokenEnvKey is the environment variable containing the Slack bot
+	// token used to post progress updates.
+	BotTokenEnvKey = "SLACK_BOT_TOKEN"
+
+	// Path is the HTTP path of the slash command endpoint.
+	Path = "/slack/commands"
+
+	// DefaultPollInterval is the default interval for checking the job
+	// progress.
+	DefaultPollInterval = 30 * time.Second
+
+	// DefaultConfirmationTimeout is the default time for confirming a
+	// requested operation.
+	DefaultConfirmationTimeout = 5 * time.Minute
+
+	// OperationStatus is the operation name for querying the job status,
+	// which has to be allowed like every other operation.
+	OperationStatus = "status"
+
+	maxRequestAge = 5 * time.Minute
+	maxBodySize   = 1 << 20
+	logTailLines  = 20
+)
+
+// Options are the settings of the Slack integration.
+type Options struct {
+	// ConfigFile is the path to the RBAC configuration.
+	ConfigFile string
+
+	// SigningSecret is used to verify that requests originate from Slack.
+	SigningSecret string
+
+	// BotToken is used to post the progress updates.
+	BotToken string
+
+	// PollInterval is the interval for checking the job progress.
+	PollInterval time.Duration
+
+	// ConfirmationTimeout is the time for confirming an operation.
+	ConfirmationTimeout time.Duration
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		SigningSecret:       os.Getenv(SigningSecretEnvKey),
+		BotToken:            os.Getenv(BotTokenEnvKey),
+		PollInterval:        DefaultPollInterval,
+		ConfirmationTimeout: DefaultConfirmationTimeout,
+	}
+}
+
+// Enabled returns true if the Slack integration is configured.
+func (o *Options) Enabled() bool {
+	return o.ConfigFile != ""
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.SigningSecret == "" {
+		return fmt.Errorf("no Slack signing secret specified, set it via $%s", SigningSecretEnvKey)
+	}
+	if o.BotToken == "" {
+		return fmt.Errorf("no Slack bot token specified, set it via $%s", BotTokenEnvKey)
+	}
+	if o.PollInterval <= 0 {
+		return errors.New("poll interval has to be positive")
+	}
+	if o.ConfirmationTimeout <= 0 {
+		return errors.New("confirmation timeout has to be positive")
+	}
+	return nil
+}
+
+// Config is the RBAC configuration of the Slack integration.
+type Config struct {
+	// Permissions maps operation names to the Slack user IDs which are
+	// allowed to run them. The special operation "*" allows all operations.
+	Permissions map[string][]string `json:"permissions"`
+}
+
+// Allowed returns true if the user is allowed to run the operation.
+func (c *Config) Allowed(user, operation string) bool {
+	for _, op := range []string{operation, "*"} {
+		for _, u := range c.Permissions[op] {
+			if u == user {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Jobs is the job API used by the bot, usually a *server.Server.
+//
+//counterfeiter:generate . Jobs
+type Jobs interface {
+	Submit(operation string, params map[string]string) (*server.Job, error)
+	Job(id string) (*server.Job, bool)
+}
+
+// command is a parsed slash command.
+type command struct {
+	operation string
+	params    map[string]string
+}
+
+func (c *command) String() string {
+	keys := make([]string, 0, len(c.params))
+	for k := range c.params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, 0, len(keys))
+	for _, k := range keys {
+		params = append(params, fmt.Sprintf("%s=%s", k, c.params[k]))
+	}
+	if len(params) == 0 {
+		return c.operation
+	}
+	return fmt.Sprintf("%s (%s)", c.operation, strings.Join(params, ", "))
+}
+
+type pendingCommand struct {
+	*command
+	user    string
+	channel string
+	expires time.Time
+}
+
+// Bot handles Slack slash commands like "/release stage release-1.31 rc" by
+// running the corresponding operations as server jobs.
+type Bot struct {
+	ctx     context.Context
+	impl    impl
+	options *Options
+	config  *Config
+	jobs    Jobs
+
+	mu      sync.Mutex
+	pending map[string]*pendingCommand
+}
+
+// New creates a new Bot. Progress updates stop when ctx gets cancelled.
+func New(ctx context.Context, opts *Options, jobs Jobs) *Bot {
+	return &Bot{
+		ctx:     ctx,
+		impl:    &defaultImpl{},
+		options: opts,
+		config:  &Config{},
+		jobs:    jobs,
+		pending: map[string]*pendingCommand{},
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (b *Bot) SetImpl(impl impl) {
+	b.impl = impl
+}
+
+// Init validates the options and loads the RBAC configuration.
+func (b *Bot) Init() error {
+	if err := b.options.Validate(); err != nil {
+		return fmt.Errorf("validate options: %w", err)
+	}
+	content, err := b.impl.ReadFile(b.options.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return fmt.Errorf("unmarshal config: %w", err)
+	}
+	b.config = config
+	return nil
+}
+
+// ServeHTTP handles a single slash command request.
+func (b *Bot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "unable to read body", http.StatusBadRequest)
+		return
+	}
+	if err := b.verify(r.Header, body, time.Now()); err != nil {
+		logrus.Warnf("Rejecting Slack request: %v", err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	text := b.handle(form.Get("user_id"), form.Get("channel_id"), form.Get("command"), form.Get("text"))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]string{
+		"response_type": "ephemeral",
+		"text":          text,
+	}); err != nil {
+		logrus.Warnf("Unable to write Slack response: %v", err)
+	}
+}
+
+// verify checks the Slack request signature, see
+// https://api.slack.com/authentication/verifying-requests-from-slack
+func (b *Bot) verify(header http.Header, body []byte, now time.Time) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse request timestamp: %w", err)
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return fmt.Errorf("request timestamp is too old: %v", age)
+	}
+
+	mac := hmac.New(sha256.New, []byte(b.options.SigningSecret))
+	fmt.Fprintf(mac, "v0:%s:%s", timestamp, body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature"))) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+func (b *Bot) handle(user, channel, slashCommand, text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || fields[0] == "help" {
+		return usage(slashCommand)
+	}
+
+	switch fields[0] {
+	case OperationStatus:
+		if len(fields) != 2 {
+			return usage(slashCommand)
+		}
+		if !b.config.Allowed(user, OperationStatus) {
+			logrus.Warnf("User %s is not allowed to run %s", user, OperationStatus)
+			return fmt.Sprintf("You are not allowed to run %s", OperationStatus)
+		}
+		job, ok := b.jobs.Job(fields[1])
+		if !ok {
+			return fmt.Sprintf("Job %s not found", fields[1])
+		}
+		return jobStatus(job)
+
+	case "confirm":
+		if len(fields) != 2 {
+			return usage(slashCommand)
+		}
+		return b.confirm(user, fields[1])
+	}
+
+	cmd, err := parseCommand(fields)
+	if err != nil {
+		return fmt.Sprintf("%v\n\n%s", err, usage(slashCommand))
+	}
+	if !b.config.Allowed(user, cmd.operation) {
+		logrus.Warnf("User %s is not allowed to run %s", user, cmd.operation)
+		return fmt.Sprintf("You are not allowed to run %s", cmd.operation)
+	}
+
+	code := uuid.NewString()[:8]
+	now := time.Now()
+	b.mu.Lock()
+	b.prune(now)
+	b.pending[code] = &pendingCommand{
+		command: cmd,
+		user:    user,
+		channel: channel,
+		expires: now.Add(b.options.ConfirmationTimeout),
+	}
+	b.mu.Unlock()
+
+	return fmt.Sprintf(
+		"About to run %s. Confirm within %v by using: %s confirm %s",
+		cmd, b.options.ConfirmationTimeout, slashCommand, code,
+	)
+}
+
+// prune removes the expired pending commands, b.mu has to be held.
+func (b *Bot) prune(now time.Time) {
+	for code, cmd := range b.pending {
+		if now.After(cmd.expires) {
+			delete(b.pending, code)
+		}
+	}
+}
+
+func (b *Bot) confirm(user, code string) string {
+	b.mu.Lock()
+	cmd, ok := b.pending[code]
+	if ok && cmd.user == user {
+		delete(b.pending, code)
+	}
+	b.mu.Unlock()
+
+	if !ok || cmd.user != user {
+		return fmt.Sprintf("No pending operation found for code %s", code)
+	}
+	if time.Now().After(cmd.expires) {
+		return fmt.Sprintf("Confirmation of %s expired, please run it again", cmd)
+	}
+	if !b.config.Allowed(user, cmd.operation) {
+		logrus.Warnf("User %s is not allowed to run %s", user, cmd.operation)
+		return fmt.Sprintf("You are not allowed to run %s", cmd.operation)
+	}
+
+	job, err := b.jobs.Submit(cmd.operation, cmd.params)
+	if err != nil {
+		return fmt.Sprintf("Unable to start %s: %v", cmd, err)
+	}
+
+	ts, err := b.impl.PostMessage(
+		b.ctx, b.options.BotToken, cmd.channel, "",
+		fmt.Sprintf("<@%s> started %s as job %s", user, cmd, job.ID),
+	)
+	if err != nil {
+		logrus.Warnf("Unable to post job update: %v", err)
+		return fmt.Sprintf("Started job %s, but progress updates are not available: %v", job.ID, err)
+	}
+
+	go b.watch(job.ID, cmd.channel, ts)
+	return fmt.Sprintf("Started job %s", job.ID)
+}
+
+// watch posts the progress of the job into the thread of the provided
+// message until the job finished.
+func (b *Bot) watch(id, channel, threadTS string) {
+	ticker := time.NewTicker(b.options.PollInterval)
+	defer ticker.Stop()
+
+	var lastStatus server.Status
+	for {
+		job, ok := b.jobs.Job(id)
+		if !ok {
+			return
+		}
+		if job.Status != lastStatus {
+			lastStatus = job.Status
+			if _, err := b.impl.PostMessage(
+				b.ctx, b.options.BotToken, channel, threadTS, jobStatus(job),
+			); err != nil {
+				logrus.Warnf("Unable to post job update: %v", err)
+			}
+		}
+		if job.Finished != nil {
+			return
+		}
+
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func jobStatus(job *server.Job) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Job %s (%s) is %s", job.ID, job.Operation, job.Status)
+	if job.Started != nil && job.Finished != nil {
+		fmt.Fprintf(&sb, " after %v", job.Finished.Sub(*job.Started).Round(time.Second))
+	}
+	if job.Error != "" {
+		fmt.Fprintf(&sb, ": %s", job.Error)
+	}
+	if job.Finished != nil {
+		if logs := tail(string(job.Logs()), logTailLines); logs != "" {
+			fmt.Fprintf(&sb, "\n```\n%s\n```", logs)
+		}
+	}
+	return sb.String()
+}
+
+func tail(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseCommand converts the slash command arguments into an operation, for
+// example "stage release-1.31 rc" or "release release-1.31 rc v1.31.0-rc.1".
+// The argument "--nomock" targets the production environment.
+func parseCommand(fields []string) (*command, error) {
+	cmd := &command{operation: fields[0], params: map[string]string{}}
+	args := []string{}
+	for _, f := range fields[1:] {
+		if f == "--nomock" {
+			cmd.params["nomock"] = "true"
+			continue
+		}
+		args = append(args, f)
+	}
+
+	var names []string
+	switch cmd.operation {
+	case "stage":
+		names = []string{"branch", "type", "build-version"}
+		if len(args) < 2 {
+			return nil, errors.New("stage requires a branch and a release type")
+		}
+	case "release":
+		names = []string{"branch", "type", "build-version"}
+		if len(args) != 3 {
+			return nil, errors.New("release requires a branch, release type and build version")
+		}
+	case "announce", "notes":
+		names = []string{"tag"}
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%s requires a tag", cmd.operation)
+		}
+	default:
+		return nil, fmt.Errorf("unknown operation %q", cmd.operation)
+	}
+
+	if len(args) > len(names) {
+		return nil, fmt.Errorf("too many arguments for %s", cmd.operation)
+	}
+	for i, arg := range args {
+		cmd.params[names[i]] = arg
+	}
+	return cmd, nil
+}
+
+func usage(slashCommand string) string {
+	if slashCommand == "" {
+		slashCommand = "/release"
+	}
+	return strings.Join([]string{
+		"Usage:",
+		slashCommand + " stage <branch> <type> [<build-version>] [--nomock]",
+		slashCommand + " release <branch> <type> <build-version> [--nomock]",
+		slashCommand + " announce <tag> [--nomock]",
+		slashCommand + " notes <tag>",
+		slashCommand + " status <job-id>",
+		slashCommand + " confirm <code>",
+	}, "\n")
+}
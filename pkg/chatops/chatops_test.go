@@ -0,0 +1,275 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chatops_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/chatops"
+	"k8s.io/release/pkg/chatops/chatopsfakes"
+	"k8s.io/release/pkg/server"
+)
+
+const (
+	testSecret = "secret"
+	testConfig = `
+permissions:
+  stage: [U1]
+  "*": [U2]
+`
+)
+
+func newTestBot(t *testing.T, mock *chatopsfakes.FakeImpl, jobs *chatopsfakes.FakeJobs) *chatops.Bot {
+	mock.ReadFileReturns([]byte(testConfig), nil)
+	sut := chatops.New(context.Background(), &chatops.Options{
+		ConfigFile:          "config.yaml",
+		SigningSecret:       testSecret,
+		BotToken:            "token",
+		PollInterval:        time.Millisecond,
+		ConfirmationTimeout: time.Minute,
+	}, jobs)
+	sut.SetImpl(mock)
+	require.NoError(t, sut.Init())
+	return sut
+}
+
+func slashCommand(t *testing.T, bot http.Handler, user, text, secret string, ts time.Time) (int, string) {
+	body := url.Values{
+		"user_id":    {user},
+		"channel_id": {"C1"},
+		"command":    {"/release"},
+		"text":       {text},
+	}.Encode()
+	timestamp := strconv.FormatInt(ts.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:%s", timestamp, body)
+
+	req := httptest.NewRequest(http.MethodPost, chatops.Path, strings.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	rec := httptest.NewRecorder()
+	bot.ServeHTTP(rec, req)
+
+	res := map[string]string{}
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	}
+	return rec.Code, res["text"]
+}
+
+func TestSignature(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		secret       string
+		ts           time.Time
+		expectedCode int
+	}{
+		{
+			name:         "valid",
+			secret:       testSecret,
+			ts:           time.Now(),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "wrong secret",
+			secret:       "wrong",
+			ts:           time.Now(),
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "replayed request",
+			secret:       testSecret,
+			ts:           time.Now().Add(-time.Hour),
+			expectedCode: http.StatusUnauthorized,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sut := newTestBot(t, &chatopsfakes.FakeImpl{}, &chatopsfakes.FakeJobs{})
+			code, _ := slashCommand(t, sut, "U1", "help", tc.secret, tc.ts)
+			require.Equal(t, tc.expectedCode, code)
+		})
+	}
+}
+
+func TestCommands(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		user     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "help",
+			user:     "U1",
+			text:     "",
+			expected: "Usage:",
+		},
+		{
+			name:     "stage requires arguments",
+			user:     "U1",
+			text:     "stage release-1.31",
+			expected: "stage requires a branch and a release type",
+		},
+		{
+			name:     "unknown operation",
+			user:     "U2",
+			text:     "deploy",
+			expected: `unknown operation "deploy"`,
+		},
+		{
+			name:     "not allowed",
+			user:     "U1",
+			text:     "release release-1.31 rc v1.31.0-rc.1",
+			expected: "You are not allowed to run release",
+		},
+		{
+			name:     "unknown user",
+			user:     "U3",
+			text:     "stage release-1.31 rc",
+			expected: "You are not allowed to run stage",
+		},
+		{
+			name:     "stage needs confirmation",
+			user:     "U1",
+			text:     "stage release-1.31 rc --nomock",
+			expected: "About to run stage (branch=release-1.31, nomock=true, type=rc)",
+		},
+		{
+			name:     "wildcard permission",
+			user:     "U2",
+			text:     "announce v1.31.0",
+			expected: "About to run announce (tag=v1.31.0)",
+		},
+		{
+			name:     "confirm unknown code",
+			user:     "U1",
+			text:     "confirm abc",
+			expected: "No pending operation found for code abc",
+		},
+		{
+			name:     "status not allowed",
+			user:     "U1",
+			text:     "status abc",
+			expected: "You are not allowed to run status",
+		},
+		{
+			name:     "status unknown job",
+			user:     "U2",
+			text:     "status abc",
+			expected: "Job abc not found",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sut := newTestBot(t, &chatopsfakes.FakeImpl{}, &chatopsfakes.FakeJobs{})
+			code, text := slashCommand(t, sut, tc.user, tc.text, testSecret, time.Now())
+			require.Equal(t, http.StatusOK, code)
+			require.Contains(t, text, tc.expected)
+		})
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	mock := &chatopsfakes.FakeImpl{}
+	mock.PostMessageReturns("1234.5678", nil)
+
+	now := time.Now()
+	jobs := &chatopsfakes.FakeJobs{}
+	jobs.SubmitReturns(&server.Job{ID: "job-1", Operation: "stage", Status: server.StatusPending}, nil)
+	jobs.JobReturns(&server.Job{
+		ID: "job-1", Operation: "stage", Status: server.StatusSucceeded,
+		Started: &now, Finished: &now,
+	}, true)
+
+	sut := newTestBot(t, mock, jobs)
+	_, text := slashCommand(t, sut, "U1", "stage release-1.31 rc", testSecret, time.Now())
+	code := regexp.MustCompile(`confirm (\S+)$`).FindStringSubmatch(text)
+	require.Len(t, code, 2)
+
+	// Only the requesting user can confirm
+	_, text = slashCommand(t, sut, "U2", "confirm "+code[1], testSecret, time.Now())
+	require.Contains(t, text, "No pending operation found")
+
+	_, text = slashCommand(t, sut, "U1", "confirm "+code[1], testSecret, time.Now())
+	require.Equal(t, "Started job job-1", text)
+
+	require.Equal(t, 1, jobs.SubmitCallCount())
+	op, params := jobs.SubmitArgsForCall(0)
+	require.Equal(t, "stage", op)
+	require.Equal(t, map[string]string{"branch": "release-1.31", "type": "rc"}, params)
+
+	// The thread gets updated with the final job status
+	require.Eventually(t, func() bool { return mock.PostMessageCallCount() == 2 }, time.Second, time.Millisecond)
+	_, _, channel, threadTS, msg := mock.PostMessageArgsForCall(1)
+	require.Equal(t, "C1", channel)
+	require.Equal(t, "1234.5678", threadTS)
+	require.Contains(t, msg, "Job job-1 (stage) is succeeded")
+
+	// The code can be used only once
+	_, text = slashCommand(t, sut, "U1", "confirm "+code[1], testSecret, time.Now())
+	require.Contains(t, text, "No pending operation found")
+}
+
+func TestConfirmExpired(t *testing.T) {
+	mock := &chatopsfakes.FakeImpl{}
+	mock.ReadFileReturns([]byte(testConfig), nil)
+	jobs := &chatopsfakes.FakeJobs{}
+	sut := chatops.New(context.Background(), &chatops.Options{
+		ConfigFile:          "config.yaml",
+		SigningSecret:       testSecret,
+		BotToken:            "token",
+		PollInterval:        time.Millisecond,
+		ConfirmationTimeout: time.Millisecond,
+	}, jobs)
+	sut.SetImpl(mock)
+	require.NoError(t, sut.Init())
+
+	confirmCode := func() string {
+		_, text := slashCommand(t, sut, "U1", "stage release-1.31 rc", testSecret, time.Now())
+		code := regexp.MustCompile(`confirm (\S+)$`).FindStringSubmatch(text)
+		require.Len(t, code, 2)
+		return code[1]
+	}
+
+	first := confirmCode()
+	time.Sleep(5 * time.Millisecond)
+	_, text := slashCommand(t, sut, "U1", "confirm "+first, testSecret, time.Now())
+	require.Contains(t, text, "expired")
+
+	// Expired confirmations get removed by later requests
+	second := confirmCode()
+	time.Sleep(5 * time.Millisecond)
+	confirmCode()
+	_, text = slashCommand(t, sut, "U1", "confirm "+second, testSecret, time.Now())
+	require.Contains(t, text, "No pending operation found")
+	require.Zero(t, jobs.SubmitCallCount())
+}
@@ -0,0 +1,198 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package chatopsfakes
+
+import (
+	"sync"
+
+	"k8s.io/release/pkg/chatops"
+	"k8s.io/release/pkg/server"
+)
+
+type FakeJobs struct {
+	JobStub        func(string) (*server.Job, bool)
+	jobMutex       sync.RWMutex
+	jobArgsForCall []struct {
+		arg1 string
+	}
+	jobReturns struct {
+		result1 *server.Job
+		result2 bool
+	}
+	jobReturnsOnCall map[int]struct {
+		result1 *server.Job
+		result2 bool
+	}
+	SubmitStub        func(string, map[string]string) (*server.Job, error)
+	submitMutex       sync.RWMutex
+	submitArgsForCall []struct {
+		arg1 string
+		arg2 map[string]string
+	}
+	submitReturns struct {
+		result1 *server.Job
+		result2 error
+	}
+	submitReturnsOnCall map[int]struct {
+		result1 *server.Job
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeJobs) Job(arg1 string) (*server.Job, bool) {
+	fake.jobMutex.Lock()
+	ret, specificReturn := fake.jobReturnsOnCall[len(fake.jobArgsForCall)]
+	fake.jobArgsForCall = append(fake.jobArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.JobStub
+	fakeReturns := fake.jobReturns
+	fake.recordInvocation("Job", []interface{}{arg1})
+	fake.jobMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJobs) JobCallCount() int {
+	fake.jobMutex.RLock()
+	defer fake.jobMutex.RUnlock()
+	return len(fake.jobArgsForCall)
+}
+
+func (fake *FakeJobs) JobCalls(stub func(string) (*server.Job, bool)) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = stub
+}
+
+func (fake *FakeJobs) JobArgsForCall(i int) string {
+	fake.jobMutex.RLock()
+	defer fake.jobMutex.RUnlock()
+	argsForCall := fake.jobArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJobs) JobReturns(result1 *server.Job, result2 bool) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = nil
+	fake.jobReturns = struct {
+		result1 *server.Job
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeJobs) JobReturnsOnCall(i int, result1 *server.Job, result2 bool) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = nil
+	if fake.jobReturnsOnCall == nil {
+		fake.jobReturnsOnCall = make(map[int]struct {
+			result1 *server.Job
+			result2 bool
+		})
+	}
+	fake.jobReturnsOnCall[i] = struct {
+		result1 *server.Job
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeJobs) Submit(arg1 string, arg2 map[string]string) (*server.Job, error) {
+	fake.submitMutex.Lock()
+	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
+	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
+		arg1 string
+		arg2 map[string]string
+	}{arg1, arg2})
+	stub := fake.SubmitStub
+	fakeReturns := fake.submitReturns
+	fake.recordInvocation("Submit", []interface{}{arg1, arg2})
+	fake.submitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJobs) SubmitCallCount() int {
+	fake.submitMutex.RLock()
+	defer fake.submitMutex.RUnlock()
+	return len(fake.submitArgsForCall)
+}
+
+func (fake *FakeJobs) SubmitCalls(stub func(string, map[string]string) (*server.Job, error)) {
+	fake.submitMutex.Lock()
+	defer fake.submitMutex.Unlock()
+	fake.SubmitStub = stub
+}
+
+func (fake *FakeJobs) SubmitArgsForCall(i int) (string, map[string]string) {
+	fake.submitMutex.RLock()
+	defer fake.submitMutex.RUnlock()
+	argsForCall := fake.submitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJobs) SubmitReturns(result1 *server.Job, result2 error) {
+	fake.submitMutex.Lock()
+	defer fake.submitMutex.Unlock()
+	fake.SubmitStub = nil
+	fake.submitReturns = struct {
+		result1 *server.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobs) SubmitReturnsOnCall(i int, result1 *server.Job, result2 error) {
+	fake.submitMutex.Lock()
+	defer fake.submitMutex.Unlock()
+	fake.SubmitStub = nil
+	if fake.submitReturnsOnCall == nil {
+		fake.submitReturnsOnCall = make(map[int]struct {
+			result1 *server.Job
+			result2 error
+		})
+	}
+	fake.submitReturnsOnCall[i] = struct {
+		result1 *server.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobs) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.jobMutex.RLock()
+	defer fake.jobMutex.RUnlock()
+	fake.submitMutex.RLock()
+	defer fake.submitMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeJobs) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ chatops.Jobs = new(FakeJobs)
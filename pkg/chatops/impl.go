@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chatops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+const postMessageURL = "https://slack.com/api/chat.postMessage"
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt chatopsfakes/fake_impl.go > chatopsfakes/_fake_impl.go && mv chatopsfakes/_fake_impl.go chatopsfakes/fake_impl.go"
+type impl interface {
+	PostMessage(ctx context.Context, token, channel, threadTS, text string) (ts string, err error)
+	ReadFile(path string) ([]byte, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) PostMessage(
+	ctx context.Context, token, channel, threadTS, text string,
+) (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"channel":   channel,
+		"thread_ts": threadTS,
+		"text":      text,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postMessageURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	res := struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+		TS    string `json:"ts"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return "", fmt.Errorf("decode response: %w", err)
+	}
+	if !res.OK {
+		return "", fmt.Errorf("post message: %s", res.Error)
+	}
+	return res.TS, nil
+}
+
+func (*defaultImpl) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
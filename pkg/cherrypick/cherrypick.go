@@ -0,0 +1,354 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cherrypick
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-sdk/git"
+
+	"k8s.io/release/pkg/approver"
+	"k8s.io/release/pkg/freeze"
+)
+
+const (
+	// DefaultLabel is the label which marks cherry picks as approved by the
+	// release managers.
+	DefaultLabel = "cherry-pick-approved"
+
+	statusSuccess = "success"
+)
+
+// DefaultRequiredLabels are the labels every cherry pick needs to have
+// before it can be merged.
+var DefaultRequiredLabels = []string{"lgtm", "approved"}
+
+// DefaultBlockingLabelPrefixes are label prefixes which prevent a cherry pick
+// from being merged.
+var DefaultBlockingLabelPrefixes = []string{"do-not-merge/", "needs-rebase"}
+
+// DefaultIgnoredContexts are status contexts which do not have to be
+// successful, for example because they're reporting the merge pool state.
+var DefaultIgnoredContexts = []string{"tide"}
+
+// Options is the main structure for configuring a cherry pick batch.
+type Options struct {
+	// GitHubOrg is the GitHub organization of the repository.
+	GitHubOrg string
+
+	// GitHubRepo is the GitHub repository containing the cherry picks.
+	GitHubRepo string
+
+	// Branch is the release branch targeted by the cherry picks.
+	Branch string
+
+	// Milestone is the optional milestone title to filter the cherry picks.
+	Milestone string
+
+	// Label is the optional label to filter the cherry picks.
+	Label string
+
+	// RequiredLabels have to be set on every cherry pick to be mergeable.
+	RequiredLabels []string
+
+	// BlockingLabelPrefixes prevent a cherry pick from being merged if any
+	// of its labels starts with one of them.
+	BlockingLabelPrefixes []string
+
+	// IgnoredContexts are status contexts which are not required to pass.
+	IgnoredContexts []string
+
+	// Merge the ready cherry picks in order if set to true.
+	Merge bool
+
+	// NoMock actually merges the pull requests if set to true.
+	NoMock bool
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		GitHubOrg:             git.DefaultGithubOrg,
+		GitHubRepo:            git.DefaultGithubRepo,
+		Label:                 DefaultLabel,
+		RequiredLabels:        DefaultRequiredLabels,
+		BlockingLabelPrefixes: DefaultBlockingLabelPrefixes,
+		IgnoredContexts:       DefaultIgnoredContexts,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if !strings.HasPrefix(o.Branch, "release-") || !git.IsReleaseBranch(o.Branch) {
+		return fmt.Errorf("invalid release branch: %q", o.Branch)
+	}
+	if o.Milestone == "" && o.Label == "" {
+		return errors.New("either a milestone or a label has to be specified")
+	}
+	if o.GitHubOrg == "" || o.GitHubRepo == "" {
+		return errors.New("GitHub organization and repository must not be empty")
+	}
+	return nil
+}
+
+// PullRequest is a cherry pick pull request including its validation result.
+type PullRequest struct {
+	// Number is the pull request number.
+	Number int
+
+	// Title is the pull request title.
+	Title string
+
+	// Author is the GitHub login of the pull request author.
+	Author string
+
+	// URL is the link to the pull request.
+	URL string
+
+	// HeadSHA is the commit the status checks have been validated for.
+	HeadSHA string
+
+	// Problems contains all reasons why the cherry pick is not ready.
+	Problems []string
+}
+
+// Ready returns true if the cherry pick can be merged.
+func (p *PullRequest) Ready() bool {
+	return len(p.Problems) == 0
+}
+
+// CherryPick is the main structure of this package.
+type CherryPick struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new CherryPick instance.
+func New(opts *Options) *CherryPick {
+	return &CherryPick{
+		impl:    newDefaultImpl(),
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (c *CherryPick) SetImpl(impl impl) {
+	c.impl = impl
+}
+
+// Run collects and validates the cherry picks, prints a report and merges
+// them in order if requested.
+func (c *CherryPick) Run() error {
+	if err := c.options.Validate(); err != nil {
+		return fmt.Errorf("validating options: %w", err)
+	}
+
+	prs, err := c.Collect()
+	if err != nil {
+		return fmt.Errorf("collecting cherry picks: %w", err)
+	}
+
+	if len(prs) == 0 {
+		logrus.Infof("No cherry picks found for branch %s", c.options.Branch)
+		return nil
+	}
+
+	printReport(os.Stdout, prs)
+
+	if !c.options.Merge {
+		return nil
+	}
+	return c.merge(prs)
+}
+
+// Collect retrieves all open cherry picks for the configured branch and
+// validates their state, sorted by pull request number.
+func (c *CherryPick) Collect() ([]*PullRequest, error) {
+	listOpts := &gogithub.IssueListByRepoOptions{State: "open"}
+
+	if c.options.Milestone != "" {
+		milestone, exists, err := c.impl.GetMilestone(
+			c.options.GitHubOrg, c.options.GitHubRepo, c.options.Milestone,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("get milestone %s: %w", c.options.Milestone, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("milestone %s does not exist", c.options.Milestone)
+		}
+		listOpts.Milestone = fmt.Sprint(milestone.GetNumber())
+	}
+
+	if c.options.Label != "" {
+		listOpts.Labels = []string{c.options.Label}
+	}
+
+	logrus.Infof(
+		"Listing cherry picks for %s/%s (milestone: %q, label: %q)",
+		c.options.GitHubOrg, c.options.GitHubRepo,
+		c.options.Milestone, c.options.Label,
+	)
+	issues, err := c.impl.ListIssues(c.options.GitHubOrg, c.options.GitHubRepo, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("list issues: %w", err)
+	}
+
+	res := []*PullRequest{}
+	for _, issue := range issues {
+		if !issue.IsPullRequest() {
+			continue
+		}
+
+		pr, err := c.impl.GetPullRequest(
+			c.options.GitHubOrg, c.options.GitHubRepo, issue.GetNumber(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("get pull request #%d: %w", issue.GetNumber(), err)
+		}
+
+		if pr.GetBase().GetRef() != c.options.Branch {
+			logrus.Debugf(
+				"Skipping PR #%d targeting branch %s",
+				pr.GetNumber(), pr.GetBase().GetRef(),
+			)
+			continue
+		}
+
+		validated, err := c.validate(pr)
+		if err != nil {
+			return nil, fmt.Errorf("validate pull request #%d: %w", pr.GetNumber(), err)
+		}
+		res = append(res, validated)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Number < res[j].Number })
+	return res, nil
+}
+
+func (c *CherryPick) validate(pr *gogithub.PullRequest) (*PullRequest, error) {
+	res := &PullRequest{
+		Number:  pr.GetNumber(),
+		Title:   pr.GetTitle(),
+		Author:  pr.GetUser().GetLogin(),
+		URL:     pr.GetHTMLURL(),
+		HeadSHA: pr.GetHead().GetSHA(),
+	}
+
+	labels := map[string]bool{}
+	for _, label := range pr.Labels {
+		labels[label.GetName()] = true
+		for _, prefix := range c.options.BlockingLabelPrefixes {
+			if strings.HasPrefix(label.GetName(), prefix) {
+				res.Problems = append(res.Problems, "blocked by label "+label.GetName())
+			}
+		}
+	}
+
+	for _, label := range c.options.RequiredLabels {
+		if !labels[label] {
+			res.Problems = append(res.Problems, "missing label "+label)
+		}
+	}
+
+	if pr.GetDraft() {
+		res.Problems = append(res.Problems, "is a draft")
+	}
+
+	status, err := c.impl.GetCombinedStatus(
+		c.options.GitHubOrg, c.options.GitHubRepo, res.HeadSHA,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get combined status: %w", err)
+	}
+
+	ignored := map[string]bool{}
+	for _, context := range c.options.IgnoredContexts {
+		ignored[context] = true
+	}
+	for _, s := range status.Statuses {
+		if ignored[s.GetContext()] || s.GetState() == statusSuccess {
+			continue
+		}
+		res.Problems = append(res.Problems, fmt.Sprintf(
+			"context %s is %s", s.GetContext(), s.GetState(),
+		))
+	}
+
+	return res, nil
+}
+
+func (c *CherryPick) merge(prs []*PullRequest) error {
+	if c.options.NoMock {
+		if err := freeze.Check("merging cherry picks", c.options.Branch); err != nil {
+			return err
+		}
+		if err := approver.Check("merging cherry picks", c.options.Branch); err != nil {
+			return err
+		}
+	}
+
+	for _, pr := range prs {
+		if !pr.Ready() {
+			// Later cherry picks may depend on this one, so we cannot
+			// continue merging in order.
+			logrus.Warnf("Stopping merge at PR #%d because it is not ready", pr.Number)
+			return nil
+		}
+
+		if !c.options.NoMock {
+			logrus.Infof("Would merge PR #%d: %s (mock)", pr.Number, pr.Title)
+			continue
+		}
+
+		logrus.Infof("Merging PR #%d: %s", pr.Number, pr.Title)
+		if err := c.impl.MergePullRequest(
+			c.options.GitHubOrg, c.options.GitHubRepo, pr.Number, pr.HeadSHA,
+		); err != nil {
+			return fmt.Errorf("merge pull request #%d: %w", pr.Number, err)
+		}
+	}
+	return nil
+}
+
+func printReport(w io.Writer, prs []*PullRequest) {
+	table := tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"PR", "Title", "Author", "Ready?", "Problems"})
+	for _, pr := range prs {
+		ready := "Yes"
+		if !pr.Ready() {
+			ready = "No"
+		}
+		table.Append([]string{
+			pr.URL, pr.Title, pr.Author, ready, strings.Join(pr.Problems, ", "),
+		})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+}
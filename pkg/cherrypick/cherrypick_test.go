@@ -0,0 +1,328 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cherrypick
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/cherrypick/cherrypickfakes"
+	"k8s.io/release/pkg/freeze"
+)
+
+var errTest = errors.New("test")
+
+const branch = "release-1.29"
+
+func testPR(number int, labels ...string) *gogithub.PullRequest {
+	pr := &gogithub.PullRequest{
+		Number:  gogithub.Int(number),
+		Title:   gogithub.String("Automated cherry pick"),
+		HTMLURL: gogithub.String("https://github.com/kubernetes/kubernetes/pull/1"),
+		User:    &gogithub.User{Login: gogithub.String("user")},
+		Base:    &gogithub.PullRequestBranch{Ref: gogithub.String(branch)},
+		Head:    &gogithub.PullRequestBranch{SHA: gogithub.String("sha")},
+	}
+	for _, label := range labels {
+		pr.Labels = append(pr.Labels, &gogithub.Label{Name: gogithub.String(label)})
+	}
+	return pr
+}
+
+func testStatus(states ...string) *gogithub.CombinedStatus {
+	res := &gogithub.CombinedStatus{}
+	for i := 0; i < len(states); i += 2 {
+		res.Statuses = append(res.Statuses, &gogithub.RepoStatus{
+			Context: gogithub.String(states[i]),
+			State:   gogithub.String(states[i+1]),
+		})
+	}
+	return res
+}
+
+func testOptions() *Options {
+	opts := DefaultOptions()
+	opts.Branch = branch
+	return opts
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		modify      func(*Options)
+		shouldError bool
+	}{
+		{ // success
+			modify:      func(*Options) {},
+			shouldError: false,
+		},
+		{ // success milestone only
+			modify: func(o *Options) {
+				o.Label = ""
+				o.Milestone = "v1.29"
+			},
+			shouldError: false,
+		},
+		{ // invalid branch
+			modify:      func(o *Options) { o.Branch = "master" },
+			shouldError: true,
+		},
+		{ // no milestone or label
+			modify:      func(o *Options) { o.Label = "" },
+			shouldError: true,
+		},
+		{ // no repo
+			modify:      func(o *Options) { o.GitHubRepo = "" },
+			shouldError: true,
+		},
+	} {
+		opts := testOptions()
+		tc.modify(opts)
+		err := opts.Validate()
+		if tc.shouldError {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		prepare func(*cherrypickfakes.FakeImpl) *Options
+		assert  func([]*PullRequest, error)
+	}{
+		{ // success
+			prepare: func(mock *cherrypickfakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{
+					{Number: gogithub.Int(3), PullRequestLinks: &gogithub.PullRequestLinks{}},
+					{Number: gogithub.Int(2)},
+					{Number: gogithub.Int(1), PullRequestLinks: &gogithub.PullRequestLinks{}},
+				}, nil)
+				mock.GetPullRequestReturnsOnCall(0, testPR(3, "lgtm", "approved"), nil)
+				mock.GetPullRequestReturnsOnCall(1, testPR(1, "lgtm"), nil)
+				mock.GetCombinedStatusReturnsOnCall(0, testStatus("tide", "pending", "test", "success"), nil)
+				mock.GetCombinedStatusReturnsOnCall(1, testStatus("test", "failure"), nil)
+				return testOptions()
+			},
+			assert: func(res []*PullRequest, err error) {
+				require.NoError(t, err)
+				require.Len(t, res, 2)
+				require.Equal(t, 1, res[0].Number)
+				require.False(t, res[0].Ready())
+				require.Equal(t, []string{
+					"missing label approved", "context test is failure",
+				}, res[0].Problems)
+				require.Equal(t, 3, res[1].Number)
+				require.True(t, res[1].Ready())
+			},
+		},
+		{ // success blocking label and other branch
+			prepare: func(mock *cherrypickfakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{
+					{Number: gogithub.Int(1), PullRequestLinks: &gogithub.PullRequestLinks{}},
+					{Number: gogithub.Int(2), PullRequestLinks: &gogithub.PullRequestLinks{}},
+				}, nil)
+				pr := testPR(1, "lgtm", "approved", "do-not-merge/hold")
+				other := testPR(2, "lgtm", "approved")
+				other.Base.Ref = gogithub.String("release-1.28")
+				mock.GetPullRequestReturnsOnCall(0, pr, nil)
+				mock.GetPullRequestReturnsOnCall(1, other, nil)
+				mock.GetCombinedStatusReturns(testStatus(), nil)
+				return testOptions()
+			},
+			assert: func(res []*PullRequest, err error) {
+				require.NoError(t, err)
+				require.Len(t, res, 1)
+				require.Equal(t, []string{"blocked by label do-not-merge/hold"}, res[0].Problems)
+			},
+		},
+		{ // success milestone filter
+			prepare: func(mock *cherrypickfakes.FakeImpl) *Options {
+				mock.GetMilestoneReturns(&gogithub.Milestone{Number: gogithub.Int(42)}, true, nil)
+				opts := testOptions()
+				opts.Milestone = "v1.29"
+				return opts
+			},
+			assert: func(res []*PullRequest, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res)
+			},
+		},
+		{ // failure milestone does not exist
+			prepare: func(mock *cherrypickfakes.FakeImpl) *Options {
+				mock.GetMilestoneReturns(nil, false, nil)
+				opts := testOptions()
+				opts.Milestone = "v1.29"
+				return opts
+			},
+			assert: func(res []*PullRequest, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure ListIssues
+			prepare: func(mock *cherrypickfakes.FakeImpl) *Options {
+				mock.ListIssuesReturns(nil, errTest)
+				return testOptions()
+			},
+			assert: func(res []*PullRequest, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure GetPullRequest
+			prepare: func(mock *cherrypickfakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{
+					{Number: gogithub.Int(1), PullRequestLinks: &gogithub.PullRequestLinks{}},
+				}, nil)
+				mock.GetPullRequestReturns(nil, errTest)
+				return testOptions()
+			},
+			assert: func(res []*PullRequest, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure GetCombinedStatus
+			prepare: func(mock *cherrypickfakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{
+					{Number: gogithub.Int(1), PullRequestLinks: &gogithub.PullRequestLinks{}},
+				}, nil)
+				mock.GetPullRequestReturns(testPR(1), nil)
+				mock.GetCombinedStatusReturns(nil, errTest)
+				return testOptions()
+			},
+			assert: func(res []*PullRequest, err error) {
+				require.Error(t, err)
+			},
+		},
+	} {
+		mock := &cherrypickfakes.FakeImpl{}
+		sut := New(tc.prepare(mock))
+		sut.SetImpl(mock)
+		tc.assert(sut.Collect())
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		prs     []*PullRequest
+		noMock  bool
+		err     error
+		merged  int
+		wantErr bool
+	}{
+		{ // success all ready
+			prs:    []*PullRequest{{Number: 1}, {Number: 2}},
+			noMock: true,
+			merged: 2,
+		},
+		{ // success stop at first not ready
+			prs:    []*PullRequest{{Number: 1}, {Number: 2, Problems: []string{"a"}}, {Number: 3}},
+			noMock: true,
+			merged: 1,
+		},
+		{ // success mock
+			prs:    []*PullRequest{{Number: 1}, {Number: 2}},
+			merged: 0,
+		},
+		{ // failure merge
+			prs:     []*PullRequest{{Number: 1}, {Number: 2}},
+			noMock:  true,
+			err:     errTest,
+			merged:  1,
+			wantErr: true,
+		},
+	} {
+		mock := &cherrypickfakes.FakeImpl{}
+		mock.MergePullRequestReturns(tc.err)
+		opts := testOptions()
+		opts.NoMock = tc.noMock
+		sut := New(opts)
+		sut.SetImpl(mock)
+
+		err := sut.merge(tc.prs)
+		if tc.wantErr {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+		require.Equal(t, tc.merged, mock.MergePullRequestCallCount())
+	}
+}
+
+func TestMergeFreeze(t *testing.T) {
+	schedule := filepath.Join(t.TempDir(), "schedule.yaml")
+	require.NoError(t, os.WriteFile(schedule, []byte(`releases:
+- version: 1.29
+  timeline:
+    - what: Test Freeze
+      when: Tue March 5, 2024
+`), 0o600))
+	t.Cleanup(func() { require.NoError(t, freeze.Setup(&freeze.Options{})) })
+
+	prs := []*PullRequest{{Number: 1}}
+	for _, override := range []string{"", "wrong"} {
+		require.NoError(t, freeze.Setup(&freeze.Options{Schedule: schedule, Override: override}))
+
+		mock := &cherrypickfakes.FakeImpl{}
+		opts := testOptions()
+		opts.NoMock = true
+		sut := New(opts)
+		sut.SetImpl(mock)
+
+		err := sut.merge(prs)
+		require.ErrorContains(t, err, "refusing merging cherry picks of release-1.29 during the test freeze of 1.29")
+		require.Zero(t, mock.MergePullRequestCallCount())
+	}
+
+	windows, err := freeze.Load(schedule)
+	require.NoError(t, err)
+	token := freeze.NewGuard(windows, "").TokenAt("merging cherry picks", branch, time.Now())
+	require.NoError(t, freeze.Setup(&freeze.Options{Schedule: schedule, Override: token}))
+
+	mock := &cherrypickfakes.FakeImpl{}
+	opts := testOptions()
+	opts.NoMock = true
+	sut := New(opts)
+	sut.SetImpl(mock)
+
+	require.NoError(t, sut.merge(prs))
+	require.Equal(t, 1, mock.MergePullRequestCallCount())
+}
+
+func TestPrintReport(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	printReport(buf, []*PullRequest{
+		{Number: 1, URL: "url1", Title: "title", Author: "user"},
+		{Number: 2, URL: "url2", Title: "title", Author: "user", Problems: []string{"a", "b"}},
+	})
+	require.Contains(t, buf.String(), "| url1 | title | user   | Yes    |          |")
+	require.Contains(t, buf.String(), "| url2 | title | user   | No     | a, b     |")
+}
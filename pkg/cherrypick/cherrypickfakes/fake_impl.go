@@ -0,0 +1,468 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package cherrypickfakes
+
+import (
+	"sync"
+
+	"github.com/google/go-github/v58/github"
+)
+
+type FakeImpl struct {
+	GetCombinedStatusStub        func(string, string, string) (*github.CombinedStatus, error)
+	getCombinedStatusMutex       sync.RWMutex
+	getCombinedStatusArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getCombinedStatusReturns struct {
+		result1 *github.CombinedStatus
+		result2 error
+	}
+	getCombinedStatusReturnsOnCall map[int]struct {
+		result1 *github.CombinedStatus
+		result2 error
+	}
+	GetMilestoneStub        func(string, string, string) (*github.Milestone, bool, error)
+	getMilestoneMutex       sync.RWMutex
+	getMilestoneArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getMilestoneReturns struct {
+		result1 *github.Milestone
+		result2 bool
+		result3 error
+	}
+	getMilestoneReturnsOnCall map[int]struct {
+		result1 *github.Milestone
+		result2 bool
+		result3 error
+	}
+	GetPullRequestStub        func(string, string, int) (*github.PullRequest, error)
+	getPullRequestMutex       sync.RWMutex
+	getPullRequestArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}
+	getPullRequestReturns struct {
+		result1 *github.PullRequest
+		result2 error
+	}
+	getPullRequestReturnsOnCall map[int]struct {
+		result1 *github.PullRequest
+		result2 error
+	}
+	ListIssuesStub        func(string, string, *github.IssueListByRepoOptions) ([]*github.Issue, error)
+	listIssuesMutex       sync.RWMutex
+	listIssuesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *github.IssueListByRepoOptions
+	}
+	listIssuesReturns struct {
+		result1 []*github.Issue
+		result2 error
+	}
+	listIssuesReturnsOnCall map[int]struct {
+		result1 []*github.Issue
+		result2 error
+	}
+	MergePullRequestStub        func(string, string, int, string) error
+	mergePullRequestMutex       sync.RWMutex
+	mergePullRequestArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}
+	mergePullRequestReturns struct {
+		result1 error
+	}
+	mergePullRequestReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) GetCombinedStatus(arg1 string, arg2 string, arg3 string) (*github.CombinedStatus, error) {
+	fake.getCombinedStatusMutex.Lock()
+	ret, specificReturn := fake.getCombinedStatusReturnsOnCall[len(fake.getCombinedStatusArgsForCall)]
+	fake.getCombinedStatusArgsForCall = append(fake.getCombinedStatusArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetCombinedStatusStub
+	fakeReturns := fake.getCombinedStatusReturns
+	fake.recordInvocation("GetCombinedStatus", []interface{}{arg1, arg2, arg3})
+	fake.getCombinedStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GetCombinedStatusCallCount() int {
+	fake.getCombinedStatusMutex.RLock()
+	defer fake.getCombinedStatusMutex.RUnlock()
+	return len(fake.getCombinedStatusArgsForCall)
+}
+
+func (fake *FakeImpl) GetCombinedStatusCalls(stub func(string, string, string) (*github.CombinedStatus, error)) {
+	fake.getCombinedStatusMutex.Lock()
+	defer fake.getCombinedStatusMutex.Unlock()
+	fake.GetCombinedStatusStub = stub
+}
+
+func (fake *FakeImpl) GetCombinedStatusArgsForCall(i int) (string, string, string) {
+	fake.getCombinedStatusMutex.RLock()
+	defer fake.getCombinedStatusMutex.RUnlock()
+	argsForCall := fake.getCombinedStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) GetCombinedStatusReturns(result1 *github.CombinedStatus, result2 error) {
+	fake.getCombinedStatusMutex.Lock()
+	defer fake.getCombinedStatusMutex.Unlock()
+	fake.GetCombinedStatusStub = nil
+	fake.getCombinedStatusReturns = struct {
+		result1 *github.CombinedStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GetCombinedStatusReturnsOnCall(i int, result1 *github.CombinedStatus, result2 error) {
+	fake.getCombinedStatusMutex.Lock()
+	defer fake.getCombinedStatusMutex.Unlock()
+	fake.GetCombinedStatusStub = nil
+	if fake.getCombinedStatusReturnsOnCall == nil {
+		fake.getCombinedStatusReturnsOnCall = make(map[int]struct {
+			result1 *github.CombinedStatus
+			result2 error
+		})
+	}
+	fake.getCombinedStatusReturnsOnCall[i] = struct {
+		result1 *github.CombinedStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GetMilestone(arg1 string, arg2 string, arg3 string) (*github.Milestone, bool, error) {
+	fake.getMilestoneMutex.Lock()
+	ret, specificReturn := fake.getMilestoneReturnsOnCall[len(fake.getMilestoneArgsForCall)]
+	fake.getMilestoneArgsForCall = append(fake.getMilestoneArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetMilestoneStub
+	fakeReturns := fake.getMilestoneReturns
+	fake.recordInvocation("GetMilestone", []interface{}{arg1, arg2, arg3})
+	fake.getMilestoneMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeImpl) GetMilestoneCallCount() int {
+	fake.getMilestoneMutex.RLock()
+	defer fake.getMilestoneMutex.RUnlock()
+	return len(fake.getMilestoneArgsForCall)
+}
+
+func (fake *FakeImpl) GetMilestoneCalls(stub func(string, string, string) (*github.Milestone, bool, error)) {
+	fake.getMilestoneMutex.Lock()
+	defer fake.getMilestoneMutex.Unlock()
+	fake.GetMilestoneStub = stub
+}
+
+func (fake *FakeImpl) GetMilestoneArgsForCall(i int) (string, string, string) {
+	fake.getMilestoneMutex.RLock()
+	defer fake.getMilestoneMutex.RUnlock()
+	argsForCall := fake.getMilestoneArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) GetMilestoneReturns(result1 *github.Milestone, result2 bool, result3 error) {
+	fake.getMilestoneMutex.Lock()
+	defer fake.getMilestoneMutex.Unlock()
+	fake.GetMilestoneStub = nil
+	fake.getMilestoneReturns = struct {
+		result1 *github.Milestone
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImpl) GetMilestoneReturnsOnCall(i int, result1 *github.Milestone, result2 bool, result3 error) {
+	fake.getMilestoneMutex.Lock()
+	defer fake.getMilestoneMutex.Unlock()
+	fake.GetMilestoneStub = nil
+	if fake.getMilestoneReturnsOnCall == nil {
+		fake.getMilestoneReturnsOnCall = make(map[int]struct {
+			result1 *github.Milestone
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getMilestoneReturnsOnCall[i] = struct {
+		result1 *github.Milestone
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImpl) GetPullRequest(arg1 string, arg2 string, arg3 int) (*github.PullRequest, error) {
+	fake.getPullRequestMutex.Lock()
+	ret, specificReturn := fake.getPullRequestReturnsOnCall[len(fake.getPullRequestArgsForCall)]
+	fake.getPullRequestArgsForCall = append(fake.getPullRequestArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetPullRequestStub
+	fakeReturns := fake.getPullRequestReturns
+	fake.recordInvocation("GetPullRequest", []interface{}{arg1, arg2, arg3})
+	fake.getPullRequestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GetPullRequestCallCount() int {
+	fake.getPullRequestMutex.RLock()
+	defer fake.getPullRequestMutex.RUnlock()
+	return len(fake.getPullRequestArgsForCall)
+}
+
+func (fake *FakeImpl) GetPullRequestCalls(stub func(string, string, int) (*github.PullRequest, error)) {
+	fake.getPullRequestMutex.Lock()
+	defer fake.getPullRequestMutex.Unlock()
+	fake.GetPullRequestStub = stub
+}
+
+func (fake *FakeImpl) GetPullRequestArgsForCall(i int) (string, string, int) {
+	fake.getPullRequestMutex.RLock()
+	defer fake.getPullRequestMutex.RUnlock()
+	argsForCall := fake.getPullRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) GetPullRequestReturns(result1 *github.PullRequest, result2 error) {
+	fake.getPullRequestMutex.Lock()
+	defer fake.getPullRequestMutex.Unlock()
+	fake.GetPullRequestStub = nil
+	fake.getPullRequestReturns = struct {
+		result1 *github.PullRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GetPullRequestReturnsOnCall(i int, result1 *github.PullRequest, result2 error) {
+	fake.getPullRequestMutex.Lock()
+	defer fake.getPullRequestMutex.Unlock()
+	fake.GetPullRequestStub = nil
+	if fake.getPullRequestReturnsOnCall == nil {
+		fake.getPullRequestReturnsOnCall = make(map[int]struct {
+			result1 *github.PullRequest
+			result2 error
+		})
+	}
+	fake.getPullRequestReturnsOnCall[i] = struct {
+		result1 *github.PullRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListIssues(arg1 string, arg2 string, arg3 *github.IssueListByRepoOptions) ([]*github.Issue, error) {
+	fake.listIssuesMutex.Lock()
+	ret, specificReturn := fake.listIssuesReturnsOnCall[len(fake.listIssuesArgsForCall)]
+	fake.listIssuesArgsForCall = append(fake.listIssuesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *github.IssueListByRepoOptions
+	}{arg1, arg2, arg3})
+	stub := fake.ListIssuesStub
+	fakeReturns := fake.listIssuesReturns
+	fake.recordInvocation("ListIssues", []interface{}{arg1, arg2, arg3})
+	fake.listIssuesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ListIssuesCallCount() int {
+	fake.listIssuesMutex.RLock()
+	defer fake.listIssuesMutex.RUnlock()
+	return len(fake.listIssuesArgsForCall)
+}
+
+func (fake *FakeImpl) ListIssuesCalls(stub func(string, string, *github.IssueListByRepoOptions) ([]*github.Issue, error)) {
+	fake.listIssuesMutex.Lock()
+	defer fake.listIssuesMutex.Unlock()
+	fake.ListIssuesStub = stub
+}
+
+func (fake *FakeImpl) ListIssuesArgsForCall(i int) (string, string, *github.IssueListByRepoOptions) {
+	fake.listIssuesMutex.RLock()
+	defer fake.listIssuesMutex.RUnlock()
+	argsForCall := fake.listIssuesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) ListIssuesReturns(result1 []*github.Issue, result2 error) {
+	fake.listIssuesMutex.Lock()
+	defer fake.listIssuesMutex.Unlock()
+	fake.ListIssuesStub = nil
+	fake.listIssuesReturns = struct {
+		result1 []*github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListIssuesReturnsOnCall(i int, result1 []*github.Issue, result2 error) {
+	fake.listIssuesMutex.Lock()
+	defer fake.listIssuesMutex.Unlock()
+	fake.ListIssuesStub = nil
+	if fake.listIssuesReturnsOnCall == nil {
+		fake.listIssuesReturnsOnCall = make(map[int]struct {
+			result1 []*github.Issue
+			result2 error
+		})
+	}
+	fake.listIssuesReturnsOnCall[i] = struct {
+		result1 []*github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) MergePullRequest(arg1 string, arg2 string, arg3 int, arg4 string) error {
+	fake.mergePullRequestMutex.Lock()
+	ret, specificReturn := fake.mergePullRequestReturnsOnCall[len(fake.mergePullRequestArgsForCall)]
+	fake.mergePullRequestArgsForCall = append(fake.mergePullRequestArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.MergePullRequestStub
+	fakeReturns := fake.mergePullRequestReturns
+	fake.recordInvocation("MergePullRequest", []interface{}{arg1, arg2, arg3, arg4})
+	fake.mergePullRequestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) MergePullRequestCallCount() int {
+	fake.mergePullRequestMutex.RLock()
+	defer fake.mergePullRequestMutex.RUnlock()
+	return len(fake.mergePullRequestArgsForCall)
+}
+
+func (fake *FakeImpl) MergePullRequestCalls(stub func(string, string, int, string) error) {
+	fake.mergePullRequestMutex.Lock()
+	defer fake.mergePullRequestMutex.Unlock()
+	fake.MergePullRequestStub = stub
+}
+
+func (fake *FakeImpl) MergePullRequestArgsForCall(i int) (string, string, int, string) {
+	fake.mergePullRequestMutex.RLock()
+	defer fake.mergePullRequestMutex.RUnlock()
+	argsForCall := fake.mergePullRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) MergePullRequestReturns(result1 error) {
+	fake.mergePullRequestMutex.Lock()
+	defer fake.mergePullRequestMutex.Unlock()
+	fake.MergePullRequestStub = nil
+	fake.mergePullRequestReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) MergePullRequestReturnsOnCall(i int, result1 error) {
+	fake.mergePullRequestMutex.Lock()
+	defer fake.mergePullRequestMutex.Unlock()
+	fake.MergePullRequestStub = nil
+	if fake.mergePullRequestReturnsOnCall == nil {
+		fake.mergePullRequestReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.mergePullRequestReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getCombinedStatusMutex.RLock()
+	defer fake.getCombinedStatusMutex.RUnlock()
+	fake.getMilestoneMutex.RLock()
+	defer fake.getMilestoneMutex.RUnlock()
+	fake.getPullRequestMutex.RLock()
+	defer fake.getPullRequestMutex.RUnlock()
+	fake.listIssuesMutex.RLock()
+	defer fake.listIssuesMutex.RUnlock()
+	fake.mergePullRequestMutex.RLock()
+	defer fake.mergePullRequestMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cherrypick
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"golang.org/x/oauth2"
+
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/env"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt cherrypickfakes/fake_impl.go > cherrypickfakes/_fake_impl.go && mv cherrypickfakes/_fake_impl.go cherrypickfakes/fake_impl.go"
+type impl interface {
+	GetMilestone(owner, repo, title string) (*gogithub.Milestone, bool, error)
+	ListIssues(owner, repo string, opts *gogithub.IssueListByRepoOptions) ([]*gogithub.Issue, error)
+	GetPullRequest(owner, repo string, number int) (*gogithub.PullRequest, error)
+	GetCombinedStatus(owner, repo, ref string) (*gogithub.CombinedStatus, error)
+	MergePullRequest(owner, repo string, number int, sha string) error
+}
+
+type defaultImpl struct {
+	client *gogithub.Client
+}
+
+func newDefaultImpl() *defaultImpl {
+	httpClient := http.DefaultClient
+	if token := env.Default(github.TokenEnvKey, ""); token != "" {
+		httpClient = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		))
+	}
+	return &defaultImpl{client: gogithub.NewClient(httpClient)}
+}
+
+func (*defaultImpl) GetMilestone(owner, repo, title string) (*gogithub.Milestone, bool, error) {
+	return github.New().GetMilestone(owner, repo, title)
+}
+
+func (d *defaultImpl) ListIssues(
+	owner, repo string, opts *gogithub.IssueListByRepoOptions,
+) ([]*gogithub.Issue, error) {
+	res := []*gogithub.Issue{}
+	opts.ListOptions.PerPage = 100
+	for {
+		issues, resp, err := d.client.Issues.ListByRepo(
+			context.Background(), owner, repo, opts,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("list issues: %w", err)
+		}
+		res = append(res, issues...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+	return res, nil
+}
+
+func (d *defaultImpl) GetPullRequest(owner, repo string, number int) (*gogithub.PullRequest, error) {
+	pr, _, err := d.client.PullRequests.Get(context.Background(), owner, repo, number)
+	return pr, err
+}
+
+func (d *defaultImpl) GetCombinedStatus(owner, repo, ref string) (*gogithub.CombinedStatus, error) {
+	status, _, err := d.client.Repositories.GetCombinedStatus(
+		context.Background(), owner, repo, ref, &gogithub.ListOptions{PerPage: 100},
+	)
+	return status, err
+}
+
+func (d *defaultImpl) MergePullRequest(owner, repo string, number int, sha string) error {
+	res, _, err := d.client.PullRequests.Merge(
+		context.Background(), owner, repo, number, "",
+		&gogithub.PullRequestOptions{SHA: sha, MergeMethod: "merge"},
+	)
+	if err != nil {
+		return err
+	}
+	if !res.GetMerged() {
+		return fmt.Errorf("pull request not merged: %s", res.GetMessage())
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultFileName is the name of the configuration file in the home
+	// directory of the user.
+	DefaultFileName = ".krel.yaml"
+
+	// PathEnvKey is the environment variable to override the default
+	// configuration file path.
+	PathEnvKey = "KREL_CONFIG"
+
+	// ProfileEnvKey is the environment variable to select a profile.
+	ProfileEnvKey = "KREL_PROFILE"
+)
+
+// Config is the central krel configuration. Its settings get applied in
+// the following order, where later layers override earlier ones:
+//
+//  1. Flags of the top level settings
+//  2. Commands of the top level settings
+//  3. Flags of the selected profile
+//  4. Commands of the selected profile
+//
+// Flags provided on the command line always take precedence.
+type Config struct {
+	Settings `json:",inline"`
+
+	// Profile is the default profile to be used if none is selected.
+	Profile string `json:"profile,omitempty"`
+
+	// Profiles are the named sets of settings, for example "k8s-official"
+	// or "my-fork".
+	Profiles map[string]*Settings `json:"profiles,omitempty"`
+}
+
+// Settings are the flag defaults of a single layer.
+type Settings struct {
+	// Flags are the defaults for all commands, indexed by the flag name.
+	// Commands not supporting a flag ignore it.
+	Flags map[string]any `json:"flags,omitempty"`
+
+	// Commands are the per command defaults, indexed by the command path
+	// without the root command, for example "stage" or "obs stage".
+	Commands map[string]map[string]any `json:"commands,omitempty"`
+
+	// TokenFiles maps environment variables to files containing their
+	// value, for example GITHUB_TOKEN to ~/.config/krel/github-token. The
+	// variables are only set if they are not already part of the
+	// environment.
+	TokenFiles map[string]string `json:"tokenFiles,omitempty"`
+
+	// Credentials isolate the credentials of this layer. Token files of
+	// earlier layers are not inherited if set.
+	Credentials *Credentials `json:"credentials,omitempty"`
+}
+
+// Resolved are the merged settings for a single command.
+type Resolved struct {
+	// Profile is the name of the used profile, empty if none.
+	Profile string
+
+	// Flags are the flag values indexed by the flag name.
+	Flags map[string]string
+
+	// TokenFiles maps environment variables to their token files.
+	TokenFiles map[string]string
+
+	// Credentials are the isolated credentials, nil if not isolated.
+	Credentials *Credentials
+}
+
+// DefaultPath returns the path to the configuration file, which is either
+// set via $KREL_CONFIG or defaults to ~/.krel.yaml.
+func DefaultPath() string {
+	if path := os.Getenv(PathEnvKey); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return DefaultFileName
+	}
+	return filepath.Join(home, DefaultFileName)
+}
+
+// Load reads the configuration from the provided path.
+func Load(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+	return Parse(content)
+}
+
+// Parse parses the provided YAML configuration.
+func Parse(content []byte) (*Config, error) {
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("unmarshal config: %w", err)
+	}
+	if config.Profile != "" {
+		if _, ok := config.Profiles[config.Profile]; !ok {
+			return nil, fmt.Errorf("default profile %q does not exist", config.Profile)
+		}
+	}
+	return config, nil
+}
+
+// Resolve merges all layers of the configuration for the provided profile
+// and command. The default profile is used if profile is empty.
+func (c *Config) Resolve(profile, command string) (*Resolved, error) {
+	if profile == "" {
+		profile = c.Profile
+	}
+
+	layers := []*Settings{&c.Settings}
+	if profile != "" {
+		settings, ok := c.Profiles[profile]
+		if !ok {
+			return nil, fmt.Errorf(
+				"profile %q does not exist, available profiles: %s",
+				profile, strings.Join(c.ProfileNames(), ", "),
+			)
+		}
+		if settings != nil {
+			layers = append(layers, settings)
+		}
+	}
+
+	res := &Resolved{
+		Profile:    profile,
+		Flags:      map[string]string{},
+		TokenFiles: map[string]string{},
+	}
+	for _, layer := range layers {
+		for _, values := range []map[string]any{layer.Flags, layer.Commands[command]} {
+			for name, value := range values {
+				v, err := valueString(value)
+				if err != nil {
+					return nil, fmt.Errorf("flag %q: %w", name, err)
+				}
+				res.Flags[name] = v
+			}
+		}
+		if layer.Credentials != nil {
+			res.Credentials = layer.Credentials
+			res.TokenFiles = map[string]string{}
+		}
+		for env, file := range layer.TokenFiles {
+			res.TokenFiles[env] = file
+		}
+	}
+	return res, nil
+}
+
+// ProfileNames returns the sorted names of all profiles.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FlagValues returns the sorted, distinct values of the flag within all
+// layers, profiles and commands. It can be used for shell completions.
+func (c *Config) FlagValues(name string) []string {
+	layers := []*Settings{&c.Settings}
+	for _, profile := range c.ProfileNames() {
+		if settings := c.Profiles[profile]; settings != nil {
+			layers = append(layers, settings)
+		}
+	}
+
+	seen := map[string]bool{}
+	res := []string{}
+	add := func(values map[string]any) {
+		value, ok := values[name]
+		if !ok {
+			return
+		}
+		v, err := valueString(value)
+		if err != nil || seen[v] {
+			return
+		}
+		seen[v] = true
+		res = append(res, v)
+	}
+	for _, layer := range layers {
+		add(layer.Flags)
+		for _, values := range layer.Commands {
+			add(values)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// ApplyFlags sets the resolved values for all flags which have not been
+// provided on the command line. Unknown flags are skipped, because the
+// top level flags apply to every command.
+func (r *Resolved) ApplyFlags(flags *pflag.FlagSet) error {
+	names := make([]string, 0, len(r.Flags))
+	for name := range r.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := flags.Lookup(name)
+		if flag == nil {
+			logrus.Debugf("Skipping unknown flag %q from config", name)
+			continue
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := flags.Set(name, r.Flags[name]); err != nil {
+			return fmt.Errorf("set flag %q from config: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ApplyTokens sets the environment variables from the resolved token files
+// if they are not already set. Isolated credentials unset all other
+// credential variables and always set the token files.
+func (r *Resolved) ApplyTokens() error {
+	if r.Credentials != nil {
+		if err := r.applyCredentials(); err != nil {
+			return fmt.Errorf("isolate credentials: %w", err)
+		}
+	}
+	for env, file := range r.TokenFiles {
+		if _, ok := os.LookupEnv(env); ok && r.Credentials == nil {
+			continue
+		}
+		content, err := os.ReadFile(expandHome(file))
+		if err != nil {
+			return fmt.Errorf("read token file for %s: %w", env, err)
+		}
+		if err := os.Setenv(env, strings.TrimSpace(string(content))); err != nil {
+			return fmt.Errorf("set %s: %w", env, err)
+		}
+	}
+	return nil
+}
+
+func valueString(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", errors.New("value must not be empty")
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			s, err := valueString(e)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, s)
+		}
+		return strings.Join(values, ","), nil
+	case map[string]any:
+		return "", errors.New("maps are not supported as values")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/config"
+)
+
+const testConfig = `
+profile: k8s-official
+flags:
+  log-level: debug
+commands:
+  stage:
+    branch: master
+tokenFiles:
+  SLACK_BOT_TOKEN: /tmp/slack
+profiles:
+  k8s-official:
+    flags:
+      nomock: true
+  my-fork:
+    flags:
+      nomock: false
+      repo-slugs: [foo/bar, foo/baz]
+    commands:
+      stage:
+        branch: release-1.30
+        retries: 1000000
+    tokenFiles:
+      GITHUB_TOKEN: /tmp/token
+  sig-foo:
+    flags:
+      registry: gcr.io/sig-foo
+    tokenFiles:
+      GITHUB_TOKEN: /tmp/sig-foo-token
+    credentials:
+      dockerConfig: /tmp/sig-foo-docker
+`
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		content     string
+		shouldError bool
+	}{
+		{name: "valid", content: testConfig},
+		{name: "empty", content: ""},
+		{name: "unknown field", content: "foo: bar", shouldError: true},
+		{name: "missing default profile", content: "profile: foo", shouldError: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := config.Parse([]byte(tc.content))
+			if tc.shouldError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	cfg, err := config.Parse([]byte(testConfig))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name, profile, command string
+		expected               *config.Resolved
+		shouldError            bool
+	}{
+		{
+			name:    "default profile",
+			command: "stage",
+			expected: &config.Resolved{
+				Profile: "k8s-official",
+				Flags: map[string]string{
+					"log-level": "debug",
+					"branch":    "master",
+					"nomock":    "true",
+				},
+				TokenFiles: map[string]string{"SLACK_BOT_TOKEN": "/tmp/slack"},
+			},
+		},
+		{
+			name:    "selected profile",
+			profile: "my-fork",
+			command: "stage",
+			expected: &config.Resolved{
+				Profile: "my-fork",
+				Flags: map[string]string{
+					"log-level":  "debug",
+					"branch":     "release-1.30",
+					"nomock":     "false",
+					"repo-slugs": "foo/bar,foo/baz",
+					"retries":    "1000000",
+				},
+				TokenFiles: map[string]string{
+					"GITHUB_TOKEN":    "/tmp/token",
+					"SLACK_BOT_TOKEN": "/tmp/slack",
+				},
+			},
+		},
+		{
+			name:    "other command",
+			profile: "my-fork",
+			command: "release",
+			expected: &config.Resolved{
+				Profile: "my-fork",
+				Flags: map[string]string{
+					"log-level":  "debug",
+					"nomock":     "false",
+					"repo-slugs": "foo/bar,foo/baz",
+				},
+				TokenFiles: map[string]string{
+					"GITHUB_TOKEN":    "/tmp/token",
+					"SLACK_BOT_TOKEN": "/tmp/slack",
+				},
+			},
+		},
+		{
+			name:    "isolated credentials",
+			profile: "sig-foo",
+			command: "stage",
+			expected: &config.Resolved{
+				Profile: "sig-foo",
+				Flags: map[string]string{
+					"log-level": "debug",
+					"branch":    "master",
+					"registry":  "gcr.io/sig-foo",
+				},
+				TokenFiles:  map[string]string{"GITHUB_TOKEN": "/tmp/sig-foo-token"},
+				Credentials: &config.Credentials{DockerConfig: "/tmp/sig-foo-docker"},
+			},
+		},
+		{
+			name:        "unknown profile",
+			profile:     "foo",
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := cfg.Resolve(tc.profile, tc.command)
+			if tc.shouldError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, res)
+		})
+	}
+}
+
+func TestFlagValues(t *testing.T) {
+	cfg, err := config.Parse([]byte(testConfig))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"master", "release-1.30"}, cfg.FlagValues("branch"))
+	require.Equal(t, []string{"false", "true"}, cfg.FlagValues("nomock"))
+	require.Equal(t, []string{"foo/bar,foo/baz"}, cfg.FlagValues("repo-slugs"))
+	require.Empty(t, cfg.FlagValues("bucket"))
+}
+
+func TestApplyFlags(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	branch := flags.String("branch", "", "")
+	nomock := flags.Bool("nomock", false, "")
+	logLevel := flags.String("log-level", "info", "")
+	require.NoError(t, flags.Parse([]string{"--log-level=warn"}))
+
+	res := &config.Resolved{Flags: map[string]string{
+		"branch":    "master",
+		"nomock":    "true",
+		"log-level": "debug",
+		"unknown":   "value",
+	}}
+	require.NoError(t, res.ApplyFlags(flags))
+	require.Equal(t, "master", *branch)
+	require.True(t, *nomock)
+	require.Equal(t, "warn", *logLevel)
+
+	res = &config.Resolved{Flags: map[string]string{"nomock": "invalid"}}
+	flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("nomock", false, "")
+	require.Error(t, res.ApplyFlags(flags))
+}
+
+func TestApplyTokens(t *testing.T) {
+	const env = "KREL_CONFIG_TEST_TOKEN"
+	file := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(file, []byte("secret\n"), 0o600))
+
+	res := &config.Resolved{TokenFiles: map[string]string{env: file}}
+	t.Setenv(env, "")
+	require.NoError(t, os.Unsetenv(env))
+	require.NoError(t, res.ApplyTokens())
+	require.Equal(t, "secret", os.Getenv(env))
+
+	t.Setenv(env, "existing")
+	require.NoError(t, res.ApplyTokens())
+	require.Equal(t, "existing", os.Getenv(env))
+
+	res.TokenFiles[env+"_MISSING"] = filepath.Join(t.TempDir(), "missing")
+	require.Error(t, res.ApplyTokens())
+}
+
+func TestApplyTokensIsolated(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(file, []byte("profile\n"), 0o600))
+
+	t.Setenv("GITHUB_TOKEN", "host")
+	t.Setenv("SLACK_BOT_TOKEN", "host")
+	t.Setenv("DOCKER_CONFIG", "/host/docker")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "/host/key.json")
+	t.Setenv("CLOUDSDK_CONFIG", "/host/gcloud")
+	t.Setenv("VAULT_TOKEN", "host")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "host")
+	t.Setenv("KREL_SOCIAL_WEBHOOK_URL", "host")
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+
+	res := &config.Resolved{
+		Profile:     "sig-foo",
+		TokenFiles:  map[string]string{"GITHUB_TOKEN": file},
+		Credentials: &config.Credentials{DockerConfig: "/sig-foo/docker"},
+	}
+	require.NoError(t, res.ApplyTokens())
+	require.Equal(t, "profile", os.Getenv("GITHUB_TOKEN"))
+	require.Equal(t, "/sig-foo/docker", os.Getenv("DOCKER_CONFIG"))
+	gcloud := filepath.Join(cache, "krel", "profiles", "sig-foo", "gcloud")
+	require.Equal(t, gcloud, os.Getenv("CLOUDSDK_CONFIG"))
+	require.DirExists(t, gcloud)
+	for _, env := range []string{
+		"SLACK_BOT_TOKEN",
+		"GOOGLE_APPLICATION_CREDENTIALS",
+		"VAULT_TOKEN",
+		"AWS_SECRET_ACCESS_KEY",
+		"KREL_SOCIAL_WEBHOOK_URL",
+	} {
+		_, ok := os.LookupEnv(env)
+		require.False(t, ok, env)
+	}
+}
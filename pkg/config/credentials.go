@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+// credentialEnvKeys are the environment variables pointing to or containing
+// credentials, which are unset for isolated credentials if the profile does
+// not provide them.
+var credentialEnvKeys = []string{
+	// Google Cloud
+	"GOOGLE_APPLICATION_CREDENTIALS",
+	"GOOGLE_APPLICATION_CREDENTIALS_JSON",
+	"CLOUDSDK_CONFIG",
+	"CLOUDSDK_AUTH_ACCESS_TOKEN_FILE",
+
+	// Container registries
+	"DOCKER_CONFIG",
+	"DOCKERHUB_TOKEN",
+	"REGISTRY_AUTH_FILE",
+
+	// GitHub and git
+	"GITHUB_TOKEN",
+	"GITHUB_APP_ID",
+	"GITHUB_APP_INSTALLATION_ID",
+	"GITHUB_APP_PRIVATE_KEY_PATH",
+	"KREL_GIT_SSH_KEY",
+
+	// Signing and the KMS providers
+	"KREL_SIGNING_KEY",
+	"VAULT_ADDR",
+	"VAULT_TOKEN",
+	"AWS_ACCESS_KEY_ID",
+	"AWS_SECRET_ACCESS_KEY",
+	"AWS_SESSION_TOKEN",
+	"AWS_PROFILE",
+	"AWS_SHARED_CREDENTIALS_FILE",
+	"AZURE_CLIENT_ID",
+	"AZURE_CLIENT_SECRET",
+	"AZURE_TENANT_ID",
+
+	// Notifications and services
+	"SENDGRID_API_KEY",
+	"SLACK_BOT_TOKEN",
+	"SLACK_SIGNING_SECRET",
+	"KREL_SERVE_TOKEN",
+	"KREL_SOCIAL_WEBHOOK_URL",
+	"KREL_PHASE_TIMEOUT_WEBHOOK",
+	"KREL_FREEZE_OVERRIDE",
+	"FF_NOTIFY_WEBHOOK_URL",
+	"WEBHOOK_SECRET",
+	"OBS_PASSWORD",
+}
+
+// isolatedDirEnvKeys are the configuration directories which fall back to
+// the shared directories in the home of the user if unset. They point to
+// empty per-profile directories instead if the profile does not set them.
+var isolatedDirEnvKeys = map[string]string{
+	"CLOUDSDK_CONFIG": "gcloud",
+	"DOCKER_CONFIG":   "docker",
+}
+
+// Credentials isolate the credentials of a profile from the environment
+// and the other profiles, for example on a host shared by several projects.
+// Credential environment variables not provided by the profile get unset,
+// and the token files of the profile override the environment.
+type Credentials struct {
+	// GoogleCredentials is the path to the service account key file
+	// used as $GOOGLE_APPLICATION_CREDENTIALS.
+	GoogleCredentials string `json:"googleCredentials,omitempty"`
+
+	// GCloudConfig is the gcloud and gsutil configuration directory used
+	// as $CLOUDSDK_CONFIG.
+	GCloudConfig string `json:"gcloudConfig,omitempty"`
+
+	// DockerConfig is the directory of the container registry credentials
+	// used as $DOCKER_CONFIG.
+	DockerConfig string `json:"dockerConfig,omitempty"`
+}
+
+// env returns the environment variables set by the credentials.
+func (c *Credentials) env() map[string]string {
+	env := map[string]string{}
+	for key, value := range map[string]string{
+		"GOOGLE_APPLICATION_CREDENTIALS": c.GoogleCredentials,
+		"CLOUDSDK_CONFIG":                c.GCloudConfig,
+		"DOCKER_CONFIG":                  c.DockerConfig,
+	} {
+		if value != "" {
+			env[key] = expandHome(value)
+		}
+	}
+	return env
+}
+
+// profileDir returns the directory for the isolated configuration of the
+// provided profile, which is created if it does not exist.
+func profileDir(profile, name string) (string, error) {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("get user cache dir: %w", err)
+	}
+	if profile == "" {
+		profile = "default"
+	}
+	dir := filepath.Join(cache, "krel", "profiles", filepath.Base(profile), name)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("create %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// applyCredentials isolates the environment for the resolved credentials.
+func (r *Resolved) applyCredentials() error {
+	env := r.Credentials.env()
+	for key, name := range isolatedDirEnvKeys {
+		if _, ok := env[key]; ok {
+			continue
+		}
+		dir, err := profileDir(r.Profile, name)
+		if err != nil {
+			return fmt.Errorf("isolate %s: %w", key, err)
+		}
+		env[key] = dir
+	}
+	for _, key := range credentialEnvKeys {
+		if _, ok := env[key]; ok {
+			continue
+		}
+		if _, ok := r.TokenFiles[key]; ok {
+			continue
+		}
+		if _, ok := os.LookupEnv(key); !ok {
+			continue
+		}
+		logrus.Debugf("Unsetting %s for the credentials of profile %q", key, r.Profile)
+		if err := os.Unsetenv(key); err != nil {
+			return fmt.Errorf("unset %s: %w", key, err)
+		}
+	}
+	for key, value := range env {
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("set %s: %w", key, err)
+		}
+	}
+	return nil
+}
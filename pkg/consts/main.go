@@ -42,6 +42,9 @@ const (
 	ArchitecturePPC64 string = "ppc64le"
 	ArchitectureS390X string = "s390x"
 	ArchitectureRISCV string = "riscv"
+
+	ArchitectureRISCV64 string = "riscv64"
+	ArchitectureLOONG64 string = "loong64"
 )
 
 var (
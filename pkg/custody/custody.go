@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package custody generates and verifies in-toto metadata for the chain of
+// custody of a release. A signed layout describes the stage, push, sign and
+// promote steps together with the functionaries allowed to perform them,
+// while every step records a signed link of the artifacts it consumed and
+// produced. Auditors can verify the complete pipeline by using the layout and
+// the links, instead of only the provenance of single artifacts.
+package custody
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// StepStage is the step building and staging the release artifacts.
+	StepStage = "stage"
+
+	// StepPush is the step pushing the staged artifacts to the release
+	// bucket.
+	StepPush = "push"
+
+	// StepSign is the step signing the pushed artifacts.
+	StepSign = "sign"
+
+	// StepPromote is the step promoting the signed artifacts to production.
+	StepPromote = "promote"
+
+	// LayoutFile is the default file name of the signed layout.
+	LayoutFile = "root.layout"
+
+	// DefaultExpiration is the default validity of a generated layout.
+	DefaultExpiration = 365 * 24 * time.Hour
+)
+
+// Steps are the supply chain steps of a release, in their order.
+var Steps = []string{StepStage, StepPush, StepSign, StepPromote}
+
+// hashAlgorithms are used for recording the artifacts of a link.
+var hashAlgorithms = []string{"sha256", "sha512"}
+
+// Options are the options for generating a layout.
+type Options struct {
+	// LayoutKey is the path to the private key used to sign the layout.
+	LayoutKey string
+
+	// FunctionaryKeys are the paths to the public keys of the functionaries
+	// allowed to perform every step.
+	FunctionaryKeys []string
+
+	// StepKeys are the paths to public keys of functionaries which are only
+	// allowed to perform a particular step, indexed by its name.
+	StepKeys map[string][]string
+
+	// Expires is the duration until the layout expires.
+	Expires time.Duration
+
+	// Readme is a human readable description which gets embedded into the
+	// layout, for example the release version.
+	Readme string
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		StepKeys: map[string][]string{},
+		Expires:  DefaultExpiration,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.LayoutKey == "" {
+		return errors.New("no layout key specified")
+	}
+	if o.Expires <= 0 {
+		return fmt.Errorf("invalid layout expiration %s", o.Expires)
+	}
+	for step := range o.StepKeys {
+		if !slices.Contains(Steps, step) {
+			return fmt.Errorf("unknown step %q, expected one of %s", step, strings.Join(Steps, ", "))
+		}
+	}
+	for _, step := range Steps {
+		if len(o.FunctionaryKeys) == 0 && len(o.StepKeys[step]) == 0 {
+			return fmt.Errorf("no functionary key specified for step %q", step)
+		}
+	}
+	return nil
+}
+
+// NewLayout creates the layout of the release pipeline and signs it with the
+// layout key.
+func NewLayout(opts *Options) (*intoto.Metablock, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	layout := intoto.Layout{
+		Type:    "layout",
+		Keys:    map[string]intoto.Key{},
+		Inspect: []intoto.Inspection{},
+		Expires: time.Now().UTC().Add(opts.Expires).Format(intoto.ISO8601DateSchema),
+		Readme:  opts.Readme,
+	}
+
+	common, err := loadPublicKeys(layout.Keys, opts.FunctionaryKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range Steps {
+		keyIDs, err := loadPublicKeys(layout.Keys, opts.StepKeys[name])
+		if err != nil {
+			return nil, err
+		}
+		materials, products := rules(name)
+		layout.Steps = append(layout.Steps, intoto.Step{
+			Type:            "step",
+			PubKeys:         append(slices.Clone(common), keyIDs...),
+			ExpectedCommand: []string{},
+			Threshold:       1,
+			SupplyChainItem: intoto.SupplyChainItem{
+				Name:              name,
+				ExpectedMaterials: materials,
+				ExpectedProducts:  products,
+			},
+		})
+	}
+
+	key := intoto.Key{}
+	if err := key.LoadKeyDefaults(opts.LayoutKey); err != nil {
+		return nil, fmt.Errorf("load layout key %s: %w", opts.LayoutKey, err)
+	}
+
+	metablock := &intoto.Metablock{Signed: layout, Signatures: []intoto.Signature{}}
+	if err := metablock.Sign(key); err != nil {
+		return nil, fmt.Errorf("sign layout: %w", err)
+	}
+	return metablock, nil
+}
+
+// rules returns the expected materials and products of a step. Every step
+// has to consume exactly the products of its predecessor, while only the
+// stage step may create artifacts and the sign step may add signatures,
+// certificates and timestamps.
+func rules(step string) (materials, products [][]string) {
+	if step == StepStage {
+		return [][]string{{"ALLOW", "*"}},
+			[][]string{{"CREATE", "*"}, {"DISALLOW", "*"}}
+	}
+
+	previous := Steps[slices.Index(Steps, step)-1]
+	match := []string{"MATCH", "*", "WITH", "PRODUCTS", "FROM", previous}
+	materials = [][]string{match, {"DISALLOW", "*"}}
+	products = [][]string{match}
+	if step == StepSign {
+		products = append(products,
+			[]string{"CREATE", "*.sig"},
+			[]string{"CREATE", "*.cert"},
+			[]string{"CREATE", "*.tsr"},
+		)
+	}
+	return materials, append(products, []string{"DISALLOW", "*"})
+}
+
+// loadPublicKeys loads the public keys at the paths into the keys of the
+// layout and returns their IDs.
+func loadPublicKeys(keys map[string]intoto.Key, paths []string) ([]string, error) {
+	keyIDs := []string{}
+	for _, path := range paths {
+		key := intoto.Key{}
+		if err := key.LoadKeyDefaults(path); err != nil {
+			return nil, fmt.Errorf("load functionary key %s: %w", path, err)
+		}
+		// Never embed private key material into the layout
+		key.KeyVal.Private = ""
+		keys[key.KeyID] = key
+		keyIDs = append(keyIDs, key.KeyID)
+	}
+	return keyIDs, nil
+}
+
+// RecordLink records the materials and products of a step in a link signed
+// with the private key of the functionary and writes it to the output
+// directory. Artifact paths are recorded relative to the base directory, so
+// that they match between the steps. It returns the path of the link.
+func RecordLink(step, keyPath, baseDir, outputDir string, materials, products []string) (string, error) {
+	if !slices.Contains(Steps, step) {
+		return "", fmt.Errorf("unknown step %q, expected one of %s", step, strings.Join(Steps, ", "))
+	}
+
+	key := intoto.Key{}
+	if err := key.LoadKeyDefaults(keyPath); err != nil {
+		return "", fmt.Errorf("load functionary key %s: %w", keyPath, err)
+	}
+
+	lStripPaths := []string{}
+	if baseDir != "" {
+		lStripPaths = append(lStripPaths, filepath.Clean(baseDir)+string(filepath.Separator))
+	}
+
+	link, err := intoto.InTotoRun(
+		step, "", materials, products, nil, key, hashAlgorithms,
+		nil, lStripPaths, false, false, false,
+	)
+	if err != nil {
+		return "", fmt.Errorf("record link for step %s: %w", step, err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return "", fmt.Errorf("create output directory: %w", err)
+	}
+	dst := filepath.Join(outputDir, fmt.Sprintf(intoto.LinkNameFormat, step, key.KeyID))
+	if err := link.Dump(dst); err != nil {
+		return "", fmt.Errorf("write link %s: %w", dst, err)
+	}
+	logrus.Infof("Recorded link of step %s in %s", step, dst)
+	return dst, nil
+}
+
+// Verify checks the layout against the public keys of its signers and
+// verifies the links in the link directory against it.
+func Verify(layoutPath string, layoutKeyPaths []string, linkDir string) error {
+	if len(layoutKeyPaths) == 0 {
+		return errors.New("no layout key specified")
+	}
+
+	layoutKeys := map[string]intoto.Key{}
+	if _, err := loadPublicKeys(layoutKeys, layoutKeyPaths); err != nil {
+		return err
+	}
+
+	layout, err := intoto.LoadMetadata(layoutPath)
+	if err != nil {
+		return fmt.Errorf("load layout %s: %w", layoutPath, err)
+	}
+
+	if _, err := intoto.InTotoVerify(
+		layout, layoutKeys, linkDir, "release", map[string]string{}, nil, false,
+	); err != nil {
+		return fmt.Errorf("verify release supply chain: %w", err)
+	}
+	logrus.Infof("Verified supply chain of %s", layoutPath)
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package custody_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/custody"
+)
+
+// writeKeyPair writes a new ed25519 key pair to the directory and returns
+// the paths of the private and public key.
+func writeKeyPair(t *testing.T, dir, name string) (private, public string) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+
+	private = filepath.Join(dir, name)
+	public = private + ".pub"
+	require.NoError(t, os.WriteFile(private, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0o600))
+	require.NoError(t, os.WriteFile(public, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o600))
+	return private, public
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		modify  func(*custody.Options)
+		wantErr string
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name:    "no layout key",
+			modify:  func(o *custody.Options) { o.LayoutKey = "" },
+			wantErr: "no layout key",
+		},
+		{
+			name:    "invalid expiration",
+			modify:  func(o *custody.Options) { o.Expires = 0 },
+			wantErr: "invalid layout expiration",
+		},
+		{
+			name:    "unknown step",
+			modify:  func(o *custody.Options) { o.StepKeys["build"] = []string{"key.pub"} },
+			wantErr: `unknown step "build"`,
+		},
+		{
+			name: "step without functionary",
+			modify: func(o *custody.Options) {
+				o.FunctionaryKeys = nil
+				o.StepKeys[custody.StepStage] = []string{"key.pub"}
+			},
+			wantErr: `no functionary key specified for step "push"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := custody.DefaultOptions()
+			opts.LayoutKey = "layout"
+			opts.FunctionaryKeys = []string{"key.pub"}
+			if tc.modify != nil {
+				tc.modify(opts)
+			}
+			err := opts.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewLayout(t *testing.T) {
+	dir := t.TempDir()
+	layoutKey, _ := writeKeyPair(t, dir, "layout")
+	_, functionary := writeKeyPair(t, dir, "functionary")
+	_, signer := writeKeyPair(t, dir, "signer")
+
+	opts := custody.DefaultOptions()
+	opts.LayoutKey = layoutKey
+	opts.FunctionaryKeys = []string{functionary}
+	opts.StepKeys[custody.StepSign] = []string{signer}
+	opts.Readme = "v1.30.0"
+
+	metablock, err := custody.NewLayout(opts)
+	require.NoError(t, err)
+	require.Len(t, metablock.Signatures, 1)
+
+	layout, ok := metablock.Signed.(intoto.Layout)
+	require.True(t, ok)
+	require.Equal(t, "v1.30.0", layout.Readme)
+	require.Len(t, layout.Keys, 2)
+	for _, key := range layout.Keys {
+		require.Empty(t, key.KeyVal.Private)
+	}
+
+	expires, err := time.Parse(intoto.ISO8601DateSchema, layout.Expires)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(custody.DefaultExpiration), expires, time.Minute)
+
+	require.Len(t, layout.Steps, len(custody.Steps))
+	for i, step := range layout.Steps {
+		require.Equal(t, custody.Steps[i], step.Name)
+		if step.Name == custody.StepSign {
+			require.Len(t, step.PubKeys, 2)
+		} else {
+			require.Len(t, step.PubKeys, 1)
+		}
+	}
+	require.Equal(t,
+		[]string{"MATCH", "*", "WITH", "PRODUCTS", "FROM", custody.StepPush},
+		layout.Steps[2].ExpectedMaterials[0],
+	)
+
+	opts.LayoutKey = filepath.Join(dir, "missing")
+	_, err = custody.NewLayout(opts)
+	require.ErrorContains(t, err, "load layout key")
+}
+
+func TestRecordAndVerify(t *testing.T) {
+	dir := t.TempDir()
+	layoutKey, layoutPub := writeKeyPair(t, dir, "layout")
+	functionary, functionaryPub := writeKeyPair(t, dir, "functionary")
+
+	opts := custody.DefaultOptions()
+	opts.LayoutKey = layoutKey
+	opts.FunctionaryKeys = []string{functionaryPub}
+	metablock, err := custody.NewLayout(opts)
+	require.NoError(t, err)
+	layoutPath := filepath.Join(dir, custody.LayoutFile)
+	require.NoError(t, metablock.Dump(layoutPath))
+
+	artifacts := filepath.Join(dir, "artifacts")
+	require.NoError(t, os.MkdirAll(filepath.Join(artifacts, "bin"), 0o755))
+	kubectl := filepath.Join(artifacts, "bin", "kubectl")
+	require.NoError(t, os.WriteFile(kubectl, []byte("kubectl"), 0o600))
+	tarball := filepath.Join(artifacts, "kubernetes.tar.gz")
+	require.NoError(t, os.WriteFile(tarball, []byte("tarball"), 0o600))
+
+	links := filepath.Join(dir, "links")
+	record := func(step string, materials, products []string) {
+		_, err := custody.RecordLink(step, functionary, artifacts, links, materials, products)
+		require.NoError(t, err)
+	}
+
+	record(custody.StepStage, nil, []string{artifacts})
+	record(custody.StepPush, []string{artifacts}, []string{artifacts})
+	require.NoError(t, os.WriteFile(kubectl+".sig", []byte("signature"), 0o600))
+	record(custody.StepSign, []string{kubectl, tarball}, []string{artifacts})
+
+	// The promotion is still missing
+	require.Error(t, custody.Verify(layoutPath, []string{layoutPub}, links))
+
+	record(custody.StepPromote, []string{artifacts}, []string{artifacts})
+	require.NoError(t, custody.Verify(layoutPath, []string{layoutPub}, links))
+
+	// Tampering after signing breaks the chain of custody
+	require.NoError(t, os.WriteFile(tarball, []byte("modified"), 0o600))
+	record(custody.StepPromote, []string{artifacts}, []string{artifacts})
+	require.Error(t, custody.Verify(layoutPath, []string{layoutPub}, links))
+
+	// The layout has to be signed by the expected key
+	_, otherPub := writeKeyPair(t, dir, "other")
+	require.Error(t, custody.Verify(layoutPath, []string{otherPub}, links))
+
+	_, err = custody.RecordLink("build", functionary, artifacts, links, nil, nil)
+	require.ErrorContains(t, err, "unknown step")
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cutissue
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/blang/semver/v4"
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/testgrid"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// ErrIssueNotFound is returned if no open release cut issue exists for the
+// version.
+var ErrIssueNotFound = errors.New("release cut issue not found")
+
+var checkedItemRE = regexp.MustCompile(`(?m)^- \[[xX]\] <!-- item:([\w-]+) -->`)
+
+// Options are the main options for managing release cut issues.
+type Options struct {
+	// GitHubOrg is the GitHub organization of the tracking repository.
+	GitHubOrg string
+
+	// GitHubRepo is the GitHub repository containing the tracking issues.
+	GitHubRepo string
+
+	// Version is the release version to be cut, for example v1.29.1.
+	Version string
+
+	// Branch is the release branch to be used. It will be inferred from the
+	// version if not set.
+	Branch string
+
+	// Date is the optional target date of the release cut.
+	Date string
+
+	// ReleaseManagers are the optional GitHub handles of the release
+	// managers cutting the release.
+	ReleaseManagers []string
+
+	// Template is an optional path to a custom issue body template.
+	Template string
+
+	// NoMock actually creates or updates the issue if set to true.
+	NoMock bool
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		GitHubOrg:  git.DefaultGithubOrg,
+		GitHubRepo: git.DefaultGithubReleaseRepo,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.GitHubOrg == "" || o.GitHubRepo == "" {
+		return errors.New("GitHub organization and repository must not be empty")
+	}
+	if _, err := util.TagStringToSemver(o.Version); err != nil {
+		return fmt.Errorf("invalid version %q: %w", o.Version, err)
+	}
+	return nil
+}
+
+// CutIssue is the main structure for managing release cut issues.
+type CutIssue struct {
+	impl    impl
+	options *Options
+
+	// login is the cached login of the token, which authors the issue.
+	login string
+}
+
+// New returns a new CutIssue instance.
+func New(opts *Options) *CutIssue {
+	return &CutIssue{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (c *CutIssue) SetImpl(impl impl) {
+	c.impl = impl
+}
+
+// Title returns the title of the release cut issue.
+func (c *CutIssue) Title() string {
+	return fmt.Sprintf("Cut %s release", util.AddTagPrefix(c.options.Version))
+}
+
+// Create files a new release cut issue or updates the existing one by
+// re-rendering the template, while keeping all checked items.
+func (c *CutIssue) Create() (*gogithub.Issue, error) {
+	if err := c.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	existing, err := c.find()
+	if err != nil && !errors.Is(err, ErrIssueNotFound) {
+		return nil, err
+	}
+
+	checked := map[string]bool{}
+	if existing != nil {
+		for _, match := range checkedItemRE.FindAllStringSubmatch(existing.GetBody(), -1) {
+			checked[match[1]] = true
+		}
+	}
+
+	body, err := c.render(checked)
+	if err != nil {
+		return nil, fmt.Errorf("render issue body: %w", err)
+	}
+
+	if !c.options.NoMock {
+		logrus.Infof("Not creating or updating issue %q in mock mode, body:\n%s", c.Title(), body)
+		return existing, nil
+	}
+
+	if existing != nil {
+		logrus.Infof("Updating release cut issue #%d", existing.GetNumber())
+		issue, err := c.impl.UpdateIssueBody(
+			c.options.GitHubOrg, c.options.GitHubRepo, existing.GetNumber(), body,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("update issue #%d: %w", existing.GetNumber(), err)
+		}
+		return issue, nil
+	}
+
+	logrus.Infof("Creating release cut issue %q", c.Title())
+	issue, err := c.impl.CreateIssue(
+		c.options.GitHubOrg, c.options.GitHubRepo, c.Title(), body,
+		&github.NewIssueOptions{Assignees: c.options.ReleaseManagers},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create issue: %w", err)
+	}
+	logrus.Infof("Release cut issue created: %s", issue.GetHTMLURL())
+	return issue, nil
+}
+
+// Check checks off the provided items in the release cut issue.
+func (c *CutIssue) Check(items ...string) error {
+	if err := c.options.Validate(); err != nil {
+		return fmt.Errorf("validating options: %w", err)
+	}
+
+	issue, err := c.find()
+	if err != nil {
+		return err
+	}
+
+	body := issue.GetBody()
+	for _, item := range items {
+		body = strings.ReplaceAll(
+			body,
+			fmt.Sprintf("- [ ] <!-- item:%s -->", item),
+			fmt.Sprintf("- [x] <!-- item:%s -->", item),
+		)
+	}
+
+	if body == issue.GetBody() {
+		logrus.Infof("Items %v are already checked or not part of issue #%d", items, issue.GetNumber())
+		return nil
+	}
+
+	if !c.options.NoMock {
+		logrus.Infof("Not updating issue #%d in mock mode, body:\n%s", issue.GetNumber(), body)
+		return nil
+	}
+
+	logrus.Infof("Checking off items %v in release cut issue #%d", items, issue.GetNumber())
+	if _, err := c.impl.UpdateIssueBody(
+		c.options.GitHubOrg, c.options.GitHubRepo, issue.GetNumber(), body,
+	); err != nil {
+		return fmt.Errorf("update issue #%d: %w", issue.GetNumber(), err)
+	}
+	return nil
+}
+
+// Comment adds the provided markdown as comment to the release cut issue.
+func (c *CutIssue) Comment(body string) error {
+	if err := c.options.Validate(); err != nil {
+		return fmt.Errorf("validating options: %w", err)
+	}
+
+	issue, err := c.find()
+	if err != nil {
+		return err
+	}
+
+	if !c.options.NoMock {
+		logrus.Infof("Not commenting on issue #%d in mock mode, comment:\n%s", issue.GetNumber(), body)
+		return nil
+	}
+
+	logrus.Infof("Commenting on release cut issue #%d", issue.GetNumber())
+	if err := c.impl.CreateComment(
+		c.options.GitHubOrg, c.options.GitHubRepo, issue.GetNumber(), body,
+	); err != nil {
+		return fmt.Errorf("comment on issue #%d: %w", issue.GetNumber(), err)
+	}
+	return nil
+}
+
+// currentLogin returns the login of the token. Only issues authored by it are
+// considered to be the release cut issue, because anyone can open an issue
+// with the same title and pre-check its items.
+func (c *CutIssue) currentLogin() (string, error) {
+	if c.login != "" {
+		return c.login, nil
+	}
+	login, err := c.impl.Login()
+	if err != nil {
+		return "", fmt.Errorf("get login of the GitHub token: %w", err)
+	}
+	c.login = login
+	return login, nil
+}
+
+func (c *CutIssue) find() (*gogithub.Issue, error) {
+	login, err := c.currentLogin()
+	if err != nil {
+		return nil, err
+	}
+	issues, err := c.impl.ListIssues(c.options.GitHubOrg, c.options.GitHubRepo)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"list issues for %s/%s: %w",
+			c.options.GitHubOrg, c.options.GitHubRepo, err,
+		)
+	}
+
+	title := c.Title()
+	for _, issue := range issues {
+		if !issue.IsPullRequest() &&
+			issue.GetUser().GetLogin() == login &&
+			issue.GetTitle() == title {
+			return issue, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrIssueNotFound, title)
+}
+
+type templateData struct {
+	Version         string
+	Branch          string
+	Date            string
+	Milestone       string
+	ReleaseManagers []string
+	Links           []link
+	Phases          []Phase
+}
+
+type link struct {
+	Name string
+	URL  string
+}
+
+func (c *CutIssue) render(checked map[string]bool) (string, error) {
+	version, err := util.TagStringToSemver(c.options.Version)
+	if err != nil {
+		return "", fmt.Errorf("parse version: %w", err)
+	}
+
+	branch := c.options.Branch
+	if branch == "" {
+		branch = branchForVersion(version)
+	}
+
+	tpl := defaultTemplate
+	if c.options.Template != "" {
+		content, err := c.impl.ReadFile(c.options.Template)
+		if err != nil {
+			return "", fmt.Errorf("read template %s: %w", c.options.Template, err)
+		}
+		tpl = string(content)
+	}
+
+	t, err := template.New("issue").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		Parse(tpl)
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+
+	data := &templateData{
+		Version:         util.AddTagPrefix(c.options.Version),
+		Branch:          branch,
+		Date:            c.options.Date,
+		Milestone:       fmt.Sprintf("v%d.%d", version.Major, version.Minor),
+		ReleaseManagers: c.options.ReleaseManagers,
+		Links: []link{
+			{
+				Name: "Release blocking dashboard",
+				URL:  fmt.Sprintf("https://testgrid.k8s.io/%s", testgrid.BlockingDashboard(branch)),
+			},
+			{
+				Name: "Release informing dashboard",
+				URL: fmt.Sprintf(
+					"https://testgrid.k8s.io/sig-release-%s-informing",
+					strings.TrimPrefix(branch, "release-"),
+				),
+			},
+			{
+				Name: "Google Cloud Build jobs",
+				URL: fmt.Sprintf(
+					"https://console.cloud.google.com/cloud-build/builds?project=%s",
+					release.DefaultKubernetesStagingProject,
+				),
+			},
+			{
+				Name: "Patch release schedule",
+				URL:  "https://kubernetes.io/releases/patch-releases",
+			},
+		},
+		Phases: phases(version.Patch > 0),
+	}
+	for i := range data.Phases {
+		for j := range data.Phases[i].Items {
+			data.Phases[i].Items[j].Checked = checked[data.Phases[i].Items[j].Key]
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("execute template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// branchForVersion returns the branch from which the provided version gets
+// cut. Alpha and beta releases are cut from the default branch, while all
+// others are cut from their release branch.
+func branchForVersion(version semver.Version) string {
+	if version.Patch == 0 && len(version.Pre) > 0 {
+		if pre := version.Pre[0].String(); pre == release.ReleaseTypeAlpha || pre == release.ReleaseTypeBeta {
+			return git.DefaultBranch
+		}
+	}
+	return fmt.Sprintf("release-%d.%d", version.Major, version.Minor)
+}
@@ -0,0 +1,382 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cutissue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/cutissue/cutissuefakes"
+)
+
+var errTest = errors.New("test")
+
+const botLogin = "release-bot"
+
+func testOptions() *Options {
+	opts := DefaultOptions()
+	opts.Version = "v1.29.1"
+	opts.NoMock = true
+	return opts
+}
+
+func testIssue(body string) *gogithub.Issue {
+	return &gogithub.Issue{
+		Number: gogithub.Int(1),
+		Title:  gogithub.String("Cut v1.29.1 release"),
+		User:   &gogithub.User{Login: gogithub.String(botLogin)},
+		Body:   gogithub.String(body),
+	}
+}
+
+func foreignIssue(body string) *gogithub.Issue {
+	issue := testIssue(body)
+	issue.Number = gogithub.Int(2)
+	issue.User = &gogithub.User{Login: gogithub.String("someone")}
+	return issue
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		prepare func(*cutissuefakes.FakeImpl) *Options
+		assert  func(*cutissuefakes.FakeImpl, error)
+	}{
+		{ // success create
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.CreateIssueReturns(testIssue(""), nil)
+				opts := testOptions()
+				opts.Date = "2024-01-17"
+				opts.ReleaseManagers = []string{"a", "b"}
+				return opts
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, mock.CreateIssueCallCount())
+				require.Zero(t, mock.UpdateIssueBodyCallCount())
+
+				_, _, title, body, opts := mock.CreateIssueArgsForCall(0)
+				require.Equal(t, "Cut v1.29.1 release", title)
+				require.Equal(t, []string{"a", "b"}, opts.Assignees)
+				require.Contains(t, body, "- **Release branch:** release-1.29")
+				require.Contains(t, body, "- **Target date:** 2024-01-17")
+				require.Contains(t, body, "- **Release managers:** a, b")
+				require.Contains(t, body, "https://testgrid.k8s.io/sig-release-1.29-blocking")
+				require.Contains(t, body, "- [ ] <!-- item:cherry-picks -->")
+				require.Contains(t, body, "- [ ] <!-- item:stage -->")
+				require.Contains(t, body, "/milestone v1.29")
+			},
+		},
+		{ // success update keeps checked items
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{
+					testIssue("- [x] <!-- item:stage-mock --> old"),
+				}, nil)
+				return testOptions()
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Zero(t, mock.CreateIssueCallCount())
+				require.Equal(t, 1, mock.UpdateIssueBodyCallCount())
+
+				_, _, number, body := mock.UpdateIssueBodyArgsForCall(0)
+				require.Equal(t, 1, number)
+				require.Contains(t, body, "- [x] <!-- item:stage-mock --> Mock stage the release")
+				require.Contains(t, body, "- [ ] <!-- item:stage --> Stage the release")
+			},
+		},
+		{ // success foreign issue with the same title is ignored
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.CreateIssueReturns(testIssue(""), nil)
+				mock.ListIssuesReturns([]*gogithub.Issue{
+					foreignIssue("- [x] <!-- item:stage --> old"),
+				}, nil)
+				return testOptions()
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Zero(t, mock.UpdateIssueBodyCallCount())
+				require.Equal(t, 1, mock.CreateIssueCallCount())
+
+				_, _, _, body, _ := mock.CreateIssueArgsForCall(0)
+				require.Contains(t, body, "- [ ] <!-- item:stage --> Stage the release")
+			},
+		},
+		{ // success custom template
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ReadFileReturns([]byte("{{ .Version }} from {{ .Branch }}"), nil)
+				opts := testOptions()
+				opts.Template = "template.md"
+				return opts
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				_, _, _, body, _ := mock.CreateIssueArgsForCall(0)
+				require.Equal(t, "v1.29.1 from release-1.29", body)
+			},
+		},
+		{ // success mock
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				opts := testOptions()
+				opts.NoMock = false
+				return opts
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Zero(t, mock.CreateIssueCallCount())
+				require.Zero(t, mock.UpdateIssueBodyCallCount())
+			},
+		},
+		{ // failure invalid version
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				opts := testOptions()
+				opts.Version = "invalid"
+				return opts
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure ListIssues
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns(nil, errTest)
+				return testOptions()
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure Login
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.LoginReturns("", errTest)
+				return testOptions()
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.ErrorIs(t, err, errTest)
+				require.Zero(t, mock.CreateIssueCallCount())
+			},
+		},
+		{ // failure ReadFile
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ReadFileReturns(nil, errTest)
+				opts := testOptions()
+				opts.Template = "template.md"
+				return opts
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure CreateIssue
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.CreateIssueReturns(nil, errTest)
+				return testOptions()
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure UpdateIssueBody
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{testIssue("")}, nil)
+				mock.UpdateIssueBodyReturns(nil, errTest)
+				return testOptions()
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+	} {
+		mock := &cutissuefakes.FakeImpl{}
+		mock.LoginReturns(botLogin, nil)
+		sut := New(tc.prepare(mock))
+		sut.SetImpl(mock)
+
+		_, err := sut.Create()
+		tc.assert(mock, err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	const body = "- [ ] <!-- item:stage-mock --> a\n- [x] <!-- item:stage --> b\n"
+
+	for _, tc := range []struct {
+		prepare func(*cutissuefakes.FakeImpl) *Options
+		items   []string
+		assert  func(*cutissuefakes.FakeImpl, error)
+	}{
+		{ // success
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{testIssue(body)}, nil)
+				return testOptions()
+			},
+			items: []string{ItemStageMock},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, mock.UpdateIssueBodyCallCount())
+				_, _, _, res := mock.UpdateIssueBodyArgsForCall(0)
+				require.Equal(t, "- [x] <!-- item:stage-mock --> a\n- [x] <!-- item:stage --> b\n", res)
+			},
+		},
+		{ // success already checked
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{testIssue(body)}, nil)
+				return testOptions()
+			},
+			items: []string{ItemStage, ItemAnnounce},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Zero(t, mock.UpdateIssueBodyCallCount())
+			},
+		},
+		{ // success mock
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{testIssue(body)}, nil)
+				opts := testOptions()
+				opts.NoMock = false
+				return opts
+			},
+			items: []string{ItemStageMock},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Zero(t, mock.UpdateIssueBodyCallCount())
+			},
+		},
+		{ // failure issue not found
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{
+					{Title: gogithub.String("Cut v1.29.1 release"), PullRequestLinks: &gogithub.PullRequestLinks{}},
+				}, nil)
+				return testOptions()
+			},
+			items: []string{ItemStageMock},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.ErrorIs(t, err, ErrIssueNotFound)
+			},
+		},
+		{ // failure foreign issue with the same title
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{foreignIssue(body)}, nil)
+				return testOptions()
+			},
+			items: []string{ItemStageMock},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.ErrorIs(t, err, ErrIssueNotFound)
+				require.Zero(t, mock.UpdateIssueBodyCallCount())
+			},
+		},
+		{ // failure UpdateIssueBody
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{testIssue(body)}, nil)
+				mock.UpdateIssueBodyReturns(nil, errTest)
+				return testOptions()
+			},
+			items: []string{ItemStageMock},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+	} {
+		mock := &cutissuefakes.FakeImpl{}
+		mock.LoginReturns(botLogin, nil)
+		sut := New(tc.prepare(mock))
+		sut.SetImpl(mock)
+
+		tc.assert(mock, sut.Check(tc.items...))
+	}
+}
+
+func TestComment(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		prepare func(*cutissuefakes.FakeImpl) *Options
+		assert  func(*cutissuefakes.FakeImpl, error)
+	}{
+		{ // success
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{testIssue("")}, nil)
+				return testOptions()
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, mock.CreateCommentCallCount())
+				_, _, _, res := mock.CreateCommentArgsForCall(0)
+				require.Equal(t, "comment", res)
+			},
+		},
+		{ // success mock
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{testIssue("")}, nil)
+				opts := testOptions()
+				opts.NoMock = false
+				return opts
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Zero(t, mock.CreateCommentCallCount())
+			},
+		},
+		{ // failure issue not found
+			prepare: func(*cutissuefakes.FakeImpl) *Options {
+				return testOptions()
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.ErrorIs(t, err, ErrIssueNotFound)
+				require.Zero(t, mock.CreateCommentCallCount())
+			},
+		},
+		{ // failure CreateComment
+			prepare: func(mock *cutissuefakes.FakeImpl) *Options {
+				mock.ListIssuesReturns([]*gogithub.Issue{testIssue("")}, nil)
+				mock.CreateCommentReturns(errTest)
+				return testOptions()
+			},
+			assert: func(mock *cutissuefakes.FakeImpl, err error) {
+				require.ErrorIs(t, err, errTest)
+			},
+		},
+	} {
+		mock := &cutissuefakes.FakeImpl{}
+		mock.LoginReturns(botLogin, nil)
+		sut := New(tc.prepare(mock))
+		sut.SetImpl(mock)
+
+		tc.assert(mock, sut.Comment("comment"))
+	}
+}
+
+func TestBranchForVersion(t *testing.T) {
+	t.Parallel()
+
+	for version, expected := range map[string]string{
+		"1.30.0-alpha.1": "master",
+		"1.30.0-beta.0":  "master",
+		"1.30.0-rc.1":    "release-1.30",
+		"1.30.0":         "release-1.30",
+		"1.29.1":         "release-1.29",
+	} {
+		require.Equal(t, expected, branchForVersion(semver.MustParse(version)))
+	}
+}
@@ -0,0 +1,534 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package cutissuefakes
+
+import (
+	"sync"
+
+	"github.com/google/go-github/v58/github"
+	githuba "sigs.k8s.io/release-sdk/github"
+)
+
+type FakeImpl struct {
+	CreateCommentStub        func(string, string, int, string) error
+	createCommentMutex       sync.RWMutex
+	createCommentArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}
+	createCommentReturns struct {
+		result1 error
+	}
+	createCommentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateIssueStub        func(string, string, string, string, *githuba.NewIssueOptions) (*github.Issue, error)
+	createIssueMutex       sync.RWMutex
+	createIssueArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 *githuba.NewIssueOptions
+	}
+	createIssueReturns struct {
+		result1 *github.Issue
+		result2 error
+	}
+	createIssueReturnsOnCall map[int]struct {
+		result1 *github.Issue
+		result2 error
+	}
+	ListIssuesStub        func(string, string) ([]*github.Issue, error)
+	listIssuesMutex       sync.RWMutex
+	listIssuesArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	listIssuesReturns struct {
+		result1 []*github.Issue
+		result2 error
+	}
+	listIssuesReturnsOnCall map[int]struct {
+		result1 []*github.Issue
+		result2 error
+	}
+	LoginStub        func() (string, error)
+	loginMutex       sync.RWMutex
+	loginArgsForCall []struct {
+	}
+	loginReturns struct {
+		result1 string
+		result2 error
+	}
+	loginReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ReadFileStub        func(string) ([]byte, error)
+	readFileMutex       sync.RWMutex
+	readFileArgsForCall []struct {
+		arg1 string
+	}
+	readFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	UpdateIssueBodyStub        func(string, string, int, string) (*github.Issue, error)
+	updateIssueBodyMutex       sync.RWMutex
+	updateIssueBodyArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}
+	updateIssueBodyReturns struct {
+		result1 *github.Issue
+		result2 error
+	}
+	updateIssueBodyReturnsOnCall map[int]struct {
+		result1 *github.Issue
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) CreateComment(arg1 string, arg2 string, arg3 int, arg4 string) error {
+	fake.createCommentMutex.Lock()
+	ret, specificReturn := fake.createCommentReturnsOnCall[len(fake.createCommentArgsForCall)]
+	fake.createCommentArgsForCall = append(fake.createCommentArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CreateCommentStub
+	fakeReturns := fake.createCommentReturns
+	fake.recordInvocation("CreateComment", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createCommentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CreateCommentCallCount() int {
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	return len(fake.createCommentArgsForCall)
+}
+
+func (fake *FakeImpl) CreateCommentCalls(stub func(string, string, int, string) error) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = stub
+}
+
+func (fake *FakeImpl) CreateCommentArgsForCall(i int) (string, string, int, string) {
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	argsForCall := fake.createCommentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) CreateCommentReturns(result1 error) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = nil
+	fake.createCommentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CreateCommentReturnsOnCall(i int, result1 error) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = nil
+	if fake.createCommentReturnsOnCall == nil {
+		fake.createCommentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createCommentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CreateIssue(arg1 string, arg2 string, arg3 string, arg4 string, arg5 *githuba.NewIssueOptions) (*github.Issue, error) {
+	fake.createIssueMutex.Lock()
+	ret, specificReturn := fake.createIssueReturnsOnCall[len(fake.createIssueArgsForCall)]
+	fake.createIssueArgsForCall = append(fake.createIssueArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 *githuba.NewIssueOptions
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.CreateIssueStub
+	fakeReturns := fake.createIssueReturns
+	fake.recordInvocation("CreateIssue", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.createIssueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) CreateIssueCallCount() int {
+	fake.createIssueMutex.RLock()
+	defer fake.createIssueMutex.RUnlock()
+	return len(fake.createIssueArgsForCall)
+}
+
+func (fake *FakeImpl) CreateIssueCalls(stub func(string, string, string, string, *githuba.NewIssueOptions) (*github.Issue, error)) {
+	fake.createIssueMutex.Lock()
+	defer fake.createIssueMutex.Unlock()
+	fake.CreateIssueStub = stub
+}
+
+func (fake *FakeImpl) CreateIssueArgsForCall(i int) (string, string, string, string, *githuba.NewIssueOptions) {
+	fake.createIssueMutex.RLock()
+	defer fake.createIssueMutex.RUnlock()
+	argsForCall := fake.createIssueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeImpl) CreateIssueReturns(result1 *github.Issue, result2 error) {
+	fake.createIssueMutex.Lock()
+	defer fake.createIssueMutex.Unlock()
+	fake.CreateIssueStub = nil
+	fake.createIssueReturns = struct {
+		result1 *github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) CreateIssueReturnsOnCall(i int, result1 *github.Issue, result2 error) {
+	fake.createIssueMutex.Lock()
+	defer fake.createIssueMutex.Unlock()
+	fake.CreateIssueStub = nil
+	if fake.createIssueReturnsOnCall == nil {
+		fake.createIssueReturnsOnCall = make(map[int]struct {
+			result1 *github.Issue
+			result2 error
+		})
+	}
+	fake.createIssueReturnsOnCall[i] = struct {
+		result1 *github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListIssues(arg1 string, arg2 string) ([]*github.Issue, error) {
+	fake.listIssuesMutex.Lock()
+	ret, specificReturn := fake.listIssuesReturnsOnCall[len(fake.listIssuesArgsForCall)]
+	fake.listIssuesArgsForCall = append(fake.listIssuesArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ListIssuesStub
+	fakeReturns := fake.listIssuesReturns
+	fake.recordInvocation("ListIssues", []interface{}{arg1, arg2})
+	fake.listIssuesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ListIssuesCallCount() int {
+	fake.listIssuesMutex.RLock()
+	defer fake.listIssuesMutex.RUnlock()
+	return len(fake.listIssuesArgsForCall)
+}
+
+func (fake *FakeImpl) ListIssuesCalls(stub func(string, string) ([]*github.Issue, error)) {
+	fake.listIssuesMutex.Lock()
+	defer fake.listIssuesMutex.Unlock()
+	fake.ListIssuesStub = stub
+}
+
+func (fake *FakeImpl) ListIssuesArgsForCall(i int) (string, string) {
+	fake.listIssuesMutex.RLock()
+	defer fake.listIssuesMutex.RUnlock()
+	argsForCall := fake.listIssuesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) ListIssuesReturns(result1 []*github.Issue, result2 error) {
+	fake.listIssuesMutex.Lock()
+	defer fake.listIssuesMutex.Unlock()
+	fake.ListIssuesStub = nil
+	fake.listIssuesReturns = struct {
+		result1 []*github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListIssuesReturnsOnCall(i int, result1 []*github.Issue, result2 error) {
+	fake.listIssuesMutex.Lock()
+	defer fake.listIssuesMutex.Unlock()
+	fake.ListIssuesStub = nil
+	if fake.listIssuesReturnsOnCall == nil {
+		fake.listIssuesReturnsOnCall = make(map[int]struct {
+			result1 []*github.Issue
+			result2 error
+		})
+	}
+	fake.listIssuesReturnsOnCall[i] = struct {
+		result1 []*github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Login() (string, error) {
+	fake.loginMutex.Lock()
+	ret, specificReturn := fake.loginReturnsOnCall[len(fake.loginArgsForCall)]
+	fake.loginArgsForCall = append(fake.loginArgsForCall, struct {
+	}{})
+	stub := fake.LoginStub
+	fakeReturns := fake.loginReturns
+	fake.recordInvocation("Login", []interface{}{})
+	fake.loginMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) LoginCallCount() int {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	return len(fake.loginArgsForCall)
+}
+
+func (fake *FakeImpl) LoginCalls(stub func() (string, error)) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = stub
+}
+
+func (fake *FakeImpl) LoginReturns(result1 string, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	fake.loginReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) LoginReturnsOnCall(i int, result1 string, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	if fake.loginReturnsOnCall == nil {
+		fake.loginReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.loginReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadFile(arg1 string) ([]byte, error) {
+	fake.readFileMutex.Lock()
+	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
+	fake.readFileArgsForCall = append(fake.readFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReadFileStub
+	fakeReturns := fake.readFileReturns
+	fake.recordInvocation("ReadFile", []interface{}{arg1})
+	fake.readFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ReadFileCallCount() int {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return len(fake.readFileArgsForCall)
+}
+
+func (fake *FakeImpl) ReadFileCalls(stub func(string) ([]byte, error)) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = stub
+}
+
+func (fake *FakeImpl) ReadFileArgsForCall(i int) string {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	argsForCall := fake.readFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ReadFileReturns(result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	fake.readFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	if fake.readFileReturnsOnCall == nil {
+		fake.readFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) UpdateIssueBody(arg1 string, arg2 string, arg3 int, arg4 string) (*github.Issue, error) {
+	fake.updateIssueBodyMutex.Lock()
+	ret, specificReturn := fake.updateIssueBodyReturnsOnCall[len(fake.updateIssueBodyArgsForCall)]
+	fake.updateIssueBodyArgsForCall = append(fake.updateIssueBodyArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateIssueBodyStub
+	fakeReturns := fake.updateIssueBodyReturns
+	fake.recordInvocation("UpdateIssueBody", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateIssueBodyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) UpdateIssueBodyCallCount() int {
+	fake.updateIssueBodyMutex.RLock()
+	defer fake.updateIssueBodyMutex.RUnlock()
+	return len(fake.updateIssueBodyArgsForCall)
+}
+
+func (fake *FakeImpl) UpdateIssueBodyCalls(stub func(string, string, int, string) (*github.Issue, error)) {
+	fake.updateIssueBodyMutex.Lock()
+	defer fake.updateIssueBodyMutex.Unlock()
+	fake.UpdateIssueBodyStub = stub
+}
+
+func (fake *FakeImpl) UpdateIssueBodyArgsForCall(i int) (string, string, int, string) {
+	fake.updateIssueBodyMutex.RLock()
+	defer fake.updateIssueBodyMutex.RUnlock()
+	argsForCall := fake.updateIssueBodyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) UpdateIssueBodyReturns(result1 *github.Issue, result2 error) {
+	fake.updateIssueBodyMutex.Lock()
+	defer fake.updateIssueBodyMutex.Unlock()
+	fake.UpdateIssueBodyStub = nil
+	fake.updateIssueBodyReturns = struct {
+		result1 *github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) UpdateIssueBodyReturnsOnCall(i int, result1 *github.Issue, result2 error) {
+	fake.updateIssueBodyMutex.Lock()
+	defer fake.updateIssueBodyMutex.Unlock()
+	fake.UpdateIssueBodyStub = nil
+	if fake.updateIssueBodyReturnsOnCall == nil {
+		fake.updateIssueBodyReturnsOnCall = make(map[int]struct {
+			result1 *github.Issue
+			result2 error
+		})
+	}
+	fake.updateIssueBodyReturnsOnCall[i] = struct {
+		result1 *github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	fake.createIssueMutex.RLock()
+	defer fake.createIssueMutex.RUnlock()
+	fake.listIssuesMutex.RLock()
+	defer fake.listIssuesMutex.RUnlock()
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	fake.updateIssueBodyMutex.RLock()
+	defer fake.updateIssueBodyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cutissue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	gogithub "github.com/google/go-github/v58/github"
+
+	"sigs.k8s.io/release-sdk/github"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt cutissuefakes/fake_impl.go > cutissuefakes/_fake_impl.go && mv cutissuefakes/_fake_impl.go cutissuefakes/fake_impl.go"
+type impl interface {
+	// Login returns the login of the user owning the token.
+	Login() (string, error)
+	ListIssues(owner, repo string) ([]*gogithub.Issue, error)
+	CreateIssue(owner, repo, title, body string, opts *github.NewIssueOptions) (*gogithub.Issue, error)
+	UpdateIssueBody(owner, repo string, number int, body string) (*gogithub.Issue, error)
+	CreateComment(owner, repo string, number int, body string) error
+	ReadFile(name string) ([]byte, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) Login() (string, error) {
+	token := os.Getenv(github.TokenEnvKey)
+	if token == "" {
+		return "", fmt.Errorf("$%s is not set", github.TokenEnvKey)
+	}
+	user, _, err := gogithub.NewClient(nil).WithAuthToken(token).Users.Get(
+		context.Background(), "",
+	)
+	if err != nil {
+		return "", fmt.Errorf("get authenticated user: %w", err)
+	}
+	if user.GetLogin() == "" {
+		return "", errors.New("authenticated user has no login")
+	}
+	return user.GetLogin(), nil
+}
+
+func (*defaultImpl) ListIssues(owner, repo string) ([]*gogithub.Issue, error) {
+	return github.New().ListIssues(owner, repo, github.IssueStateOpen)
+}
+
+func (*defaultImpl) CreateIssue(
+	owner, repo, title, body string, opts *github.NewIssueOptions,
+) (*gogithub.Issue, error) {
+	return github.New().CreateIssue(owner, repo, title, body, opts)
+}
+
+func (*defaultImpl) UpdateIssueBody(
+	owner, repo string, number int, body string,
+) (*gogithub.Issue, error) {
+	issue, _, err := github.New().Client().UpdateIssue(
+		context.Background(), owner, repo, number,
+		&gogithub.IssueRequest{Body: &body},
+	)
+	return issue, err
+}
+
+func (*defaultImpl) CreateComment(owner, repo string, number int, body string) error {
+	_, _, err := github.New().Client().CreateComment(
+		context.Background(), owner, repo, number, body,
+	)
+	return err
+}
+
+func (*defaultImpl) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
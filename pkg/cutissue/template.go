@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cutissue
+
+// defaultTemplate is the default template of the release cut issue body.
+const defaultTemplate = `<!-- This issue is managed by krel, checklist items are updated automatically -->
+## Cut {{ .Version }} release
+
+- **Release branch:** {{ .Branch }}
+{{- if .Date }}
+- **Target date:** {{ .Date }}
+{{- end }}
+{{- if .ReleaseManagers }}
+- **Release managers:** {{ join .ReleaseManagers ", " }}
+{{- end }}
+
+### Links
+{{ range .Links }}
+- [{{ .Name }}]({{ .URL }})
+{{- end }}
+{{ range .Phases }}
+### {{ .Name }}
+{{ range .Items }}
+- [{{ if .Checked }}x{{ else }} {{ end }}] <!-- item:{{ .Key }} --> {{ .Description }}
+{{- end }}
+{{ end }}
+/sig release
+/area release-eng
+/milestone {{ .Milestone }}
+`
+
+// Phase is a release cut phase containing multiple checklist items.
+type Phase struct {
+	// Name is the title of the phase.
+	Name string
+
+	// Items are the checklist items of the phase.
+	Items []Item
+}
+
+// Item is a single checklist item of a release cut phase.
+type Item struct {
+	// Key is the unique identifier of the item, which is used to check it
+	// off.
+	Key string
+
+	// Description is the human readable text of the item.
+	Description string
+
+	// Checked indicates if the item has been completed.
+	Checked bool
+}
+
+// Keys for the checklist items which are checked off automatically by krel.
+const (
+	ItemCISignal    = "ci-signal"
+	ItemCherryPicks = "cherry-picks"
+	ItemStageMock   = "stage-mock"
+	ItemStage       = "stage"
+	ItemReleaseMock = "release-mock"
+	ItemRelease     = "release"
+	ItemAnnounce    = "announce"
+	ItemPackages    = "packages"
+)
+
+// phases returns the default release cut phases, which includes cherry picks
+// only for patch releases.
+func phases(patch bool) []Phase {
+	preparation := Phase{Name: "Preparation", Items: []Item{
+		{Key: ItemCISignal, Description: "Verify the release blocking CI signal (krel testgridshot)"},
+	}}
+	if patch {
+		preparation.Items = append(preparation.Items, Item{
+			Key: ItemCherryPicks, Description: "Merge the approved cherry picks (krel cherry-picks)",
+		})
+	}
+
+	return []Phase{
+		preparation,
+		{Name: "Stage", Items: []Item{
+			{Key: ItemStageMock, Description: "Mock stage the release (krel stage)"},
+			{Key: ItemStage, Description: "Stage the release (krel stage --nomock)"},
+		}},
+		{Name: "Release", Items: []Item{
+			{Key: ItemReleaseMock, Description: "Mock release the staged build (krel release)"},
+			{Key: ItemRelease, Description: "Release the staged build (krel release --nomock)"},
+		}},
+		{Name: "Post release", Items: []Item{
+			{Key: ItemAnnounce, Description: "Send the release announcement (krel announce)"},
+			{Key: ItemPackages, Description: "Build and publish the packages (krel obs)"},
+		}},
+	}
+}
@@ -17,10 +17,12 @@ limitations under the License.
 package cve
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"k8s.io/release/pkg/notes"
 	"k8s.io/release/pkg/release"
 	"sigs.k8s.io/release-sdk/object"
 )
@@ -117,3 +119,34 @@ func (c *Client) CreateEmptyMap(cve string) (file *os.File, err error) {
 func (c *Client) EntryExists(cveID string) (bool, error) {
 	return c.impl.EntryExists(cveID, &c.options)
 }
+
+// Get reads the data of a published CVE entry
+func (c *Client) Get(cveID string) (*CVE, error) {
+	file, err := c.impl.CopyToTemp(cveID, &c.options)
+	if err != nil {
+		return nil, fmt.Errorf("copying CVE entry: %w", err)
+	}
+	defer file.Close()
+	return ReadMap(cveID, file.Name())
+}
+
+// ReadMap returns the data of a CVE from a local map file
+func ReadMap(cveID, path string) (*CVE, error) {
+	maps, err := notes.ParseReleaseNotesMap(path)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CVE data map: %w", err)
+	}
+	for _, dataMap := range *maps {
+		if _, ok := dataMap.DataFields["cve"]; !ok {
+			continue
+		}
+		cvedata := &CVE{}
+		if err := cvedata.ReadRawInterface(dataMap.DataFields["cve"]); err != nil {
+			return nil, fmt.Errorf("reading CVE data from YAML file: %w", err)
+		}
+		if cvedata.ID == cveID {
+			return cvedata, nil
+		}
+	}
+	return nil, errors.New("no data of " + cveID + " found in map file")
+}
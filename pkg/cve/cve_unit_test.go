@@ -17,6 +17,8 @@ limitations under the License.
 package cve
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -92,3 +94,24 @@ func TestCVEValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestReadMap(t *testing.T) {
+	mapFile := filepath.Join(t.TempDir(), "CVE-2020-8559.yaml")
+	require.NoError(t, os.WriteFile(mapFile, []byte(`---
+pr: 0
+datafields:
+  cve:
+    id: CVE-2020-8559
+    title: Privilege escalation from compromised node to cluster
+    issue: https://github.com/kubernetes/kubernetes/issues/92914
+    rating: Medium
+`), 0o600))
+
+	cve, err := ReadMap("CVE-2020-8559", mapFile)
+	require.NoError(t, err)
+	require.Equal(t, "Privilege escalation from compromised node to cluster", cve.Title)
+	require.Equal(t, "https://github.com/kubernetes/kubernetes/issues/92914", cve.TrackingIssue)
+
+	_, err = ReadMap("CVE-2020-8558", mapFile)
+	require.ErrorContains(t, err, "no data of CVE-2020-8558 found")
+}
@@ -30,6 +30,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/workdir"
 	"sigs.k8s.io/release-sdk/object"
 )
 
@@ -120,7 +121,7 @@ func (impl *defaultClientImplementation) DeleteFile(
 func (impl *defaultClientImplementation) CopyToTemp(
 	cve string, opts *ClientOptions,
 ) (*os.File, error) {
-	dir, err := os.MkdirTemp(os.TempDir(), "cve-maps-")
+	dir, err := workdir.MkdirTemp("cve-maps-")
 	if err != nil {
 		return nil, fmt.Errorf("creating temp dir: %w", err)
 	}
@@ -251,7 +252,7 @@ func (impl *defaultClientImplementation) CreateEmptyFile(cve string, _ *ClientOp
 		return nil, fmt.Errorf("marshalling CVE data map: %w", err)
 	}
 
-	file, err = os.CreateTemp(os.TempDir(), "cve-data-*.yaml")
+	file, err = workdir.CreateTemp("cve-data-*.yaml")
 	if err != nil {
 		return nil, fmt.Errorf("creating new map file: %w", err)
 	}
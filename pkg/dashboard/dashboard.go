@@ -0,0 +1,373 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dashboard renders a static HTML dashboard of the release history,
+// the release schedule, the build environments of the releases and the CI
+// signal of the release branches, which can be published to a bucket.
+package dashboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/object"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/buildenv"
+	"k8s.io/release/pkg/freeze"
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/testgrid"
+)
+
+const (
+	// Dir is the bucket directory of the published dashboard.
+	Dir = "dashboard"
+
+	// IndexFile is the rendered dashboard page.
+	IndexFile = "index.html"
+
+	// DataFile contains the data the dashboard got rendered from.
+	DataFile = "dashboard.json"
+)
+
+const pageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Release dashboard</title>
+</head>
+<body>
+<h1>Release dashboard</h1>
+<p>Generated on {{ .Generated.Format "2006-01-02 15:04 MST" }}</p>
+
+<h2>CI signal</h2>
+<table>
+<tr><th>Branch</th><th>Dashboard</th><th>Passing</th><th>Flaky</th><th>Failing</th><th>Blocking jobs</th></tr>
+{{- range .Signals }}
+<tr><td>{{ .Branch }}</td>
+{{- if .Error }}<td colspan="5">{{ .Error }}</td>
+{{- else }}<td><a href="https://testgrid.k8s.io/{{ .Dashboard }}">{{ .Dashboard }}</a></td><td>{{ .Passing }}</td><td>{{ .Flaky }}</td><td>{{ .Failing }}</td><td>{{ range $i, $job := .Blocking }}{{ if $i }}, {{ end }}{{ $job }}{{ end }}</td>
+{{- end }}</tr>
+{{- end }}
+</table>
+
+<h2>Schedule</h2>
+{{- if .Schedule }}
+<ul>
+{{- range .Schedule }}
+<li>{{ .String }}{{ if .Active $.Generated }} <b>(active)</b>{{ end }}</li>
+{{- end }}
+</ul>
+{{- else }}
+<p>No upcoming freezes.</p>
+{{- end }}
+
+<h2>Releases</h2>
+<table>
+<tr><th>Release</th><th>Date</th><th>Go</th><th>Builder image</th><th>krel</th></tr>
+{{- range .Releases }}
+<tr><td>{{ if .Announcement }}<a href="{{ .Announcement }}">{{ .Tag }}</a>{{ else }}{{ .Tag }}{{ end }}</td><td>{{ .Date.Format "2006-01-02" }}</td>
+{{- with .BuildEnvironment }}<td>{{ .GoVersion }}</td><td>{{ range .Images }}{{ .Reference }} {{ end }}</td><td>{{ .Krel }}</td>
+{{- else }}<td colspan="3">No build environment manifest</td>
+{{- end }}</tr>
+{{- end }}
+</table>
+</body>
+</html>
+`
+
+// Options are the options for generating the dashboard.
+type Options struct {
+	// Bucket is the release bucket containing the announcement archive and
+	// the released artifacts.
+	Bucket string
+
+	// Branches are the branches to show the CI signal for.
+	Branches []string
+
+	// Releases is the maximum number of shown releases, newest first.
+	Releases int
+
+	// ScheduleFile is the optional release schedule YAML of
+	// kubernetes/sig-release.
+	ScheduleFile string
+
+	// AllowedFlakyJobs is the number of flaky jobs per branch which are not
+	// counted as blocking.
+	AllowedFlakyJobs int
+
+	// OutputDir is the local directory the dashboard gets written to.
+	OutputDir string
+
+	// PublishBucket is the optional bucket the dashboard gets published to
+	// below Dir.
+	PublishBucket string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Bucket:    release.ProductionBucket,
+		Branches:  []string{git.DefaultBranch},
+		Releases:  10,
+		OutputDir: Dir,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Bucket == "" {
+		return errors.New("need to specify a release bucket")
+	}
+	if o.Releases < 1 {
+		return fmt.Errorf("number of releases must be at least 1, got %d", o.Releases)
+	}
+	if o.OutputDir == "" {
+		return errors.New("need to specify an output directory")
+	}
+	return nil
+}
+
+// Data is the content of the dashboard.
+type Data struct {
+	// Generated is the time the dashboard got generated.
+	Generated time.Time `json:"generated"`
+
+	// Signals is the CI signal per branch.
+	Signals []Signal `json:"signals"`
+
+	// Schedule contains the active and upcoming freezes.
+	Schedule []freeze.Window `json:"schedule"`
+
+	// Releases are the latest releases, newest first.
+	Releases []Release `json:"releases"`
+}
+
+// Signal is the summarized CI signal of a branch.
+type Signal struct {
+	Branch    string                 `json:"branch"`
+	Dashboard testgrid.DashboardName `json:"dashboard,omitempty"`
+	Passing   int                    `json:"passing"`
+	Flaky     int                    `json:"flaky"`
+	Failing   int                    `json:"failing"`
+	Blocking  []testgrid.JobName     `json:"blocking,omitempty"`
+
+	// Error is set if the signal could not be retrieved.
+	Error string `json:"error,omitempty"`
+}
+
+// Release is a single release of the history.
+type Release struct {
+	Tag  string    `json:"tag"`
+	Date time.Time `json:"date"`
+
+	// Announcement is the URL of the archived announcement.
+	Announcement string `json:"announcement"`
+
+	// BuildEnvironment is nil if the release has no manifest.
+	BuildEnvironment *buildenv.Manifest `json:"buildEnvironment,omitempty"`
+}
+
+// Generator generates the dashboard.
+type Generator struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Generator instance.
+func New(options *Options) *Generator {
+	return &Generator{&defaultImpl{}, options}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (g *Generator) SetImpl(impl impl) {
+	g.impl = impl
+}
+
+// Run generates the dashboard, writes it to the output directory and
+// publishes it if a publish bucket is set. It returns the generated data.
+func (g *Generator) Run() (*Data, error) {
+	if err := g.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	data, err := g.Generate(time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := Render(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range []string{IndexFile, DataFile} {
+		path := filepath.Join(g.options.OutputDir, file)
+		if err := g.impl.WriteFile(path, files[file]); err != nil {
+			return nil, fmt.Errorf("write %s: %w", path, err)
+		}
+		logrus.Infof("Wrote %s", path)
+
+		if g.options.PublishBucket == "" {
+			continue
+		}
+		gcsPath := object.GcsPrefix + filepath.Join(
+			strings.TrimPrefix(g.options.PublishBucket, object.GcsPrefix), Dir, file,
+		)
+		if err := g.impl.WriteObject(gcsPath, files[file]); err != nil {
+			return nil, fmt.Errorf("publish %s: %w", gcsPath, err)
+		}
+		logrus.Infof("Published %s", gcsPath)
+	}
+	return data, nil
+}
+
+// Generate collects the data of the dashboard.
+func (g *Generator) Generate(now time.Time) (*Data, error) {
+	data := &Data{Generated: now}
+
+	for _, branch := range g.options.Branches {
+		data.Signals = append(data.Signals, g.signal(branch))
+	}
+
+	if g.options.ScheduleFile != "" {
+		content, err := g.impl.ReadFile(g.options.ScheduleFile)
+		if err != nil {
+			return nil, fmt.Errorf("read release schedule: %w", err)
+		}
+		windows, err := freeze.Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("parse release schedule: %w", err)
+		}
+		for _, w := range windows {
+			if w.End.IsZero() || now.Before(w.End) {
+				data.Schedule = append(data.Schedule, w)
+			}
+		}
+		sort.SliceStable(data.Schedule, func(i, j int) bool {
+			return data.Schedule[i].Start.Before(data.Schedule[j].Start)
+		})
+	}
+
+	releases, err := g.releases()
+	if err != nil {
+		return nil, err
+	}
+	data.Releases = releases
+	return data, nil
+}
+
+// signal summarizes the CI signal of the branch. Errors are recorded instead
+// of returned to keep the dashboard available during testgrid outages.
+func (g *Generator) signal(branch string) Signal {
+	res := Signal{Branch: branch}
+	signal, err := g.impl.Signal(branch)
+	if err != nil {
+		logrus.Warnf("Unable to get CI signal of %s: %v", branch, err)
+		res.Error = err.Error()
+		return res
+	}
+
+	res.Dashboard = signal.Dashboard
+	for _, job := range signal.Jobs {
+		switch job.OverallStatus {
+		case testgrid.Passing:
+			res.Passing++
+		case testgrid.Flaky:
+			res.Flaky++
+		default:
+			res.Failing++
+		}
+	}
+	res.Blocking = signal.Blocking(g.options.AllowedFlakyJobs)
+	return res
+}
+
+// releases returns the latest releases of the announcement archive along
+// with their build environment manifests.
+func (g *Generator) releases() ([]Release, error) {
+	bucket := strings.TrimPrefix(g.options.Bucket, object.GcsPrefix)
+	content, err := g.impl.ReadObject(
+		object.GcsPrefix + filepath.Join(bucket, announce.ArchiveDir, "index.json"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("read announcement archive: %w", err)
+	}
+	entries := []announce.ArchiveEntry{}
+	if content != nil {
+		if err := json.Unmarshal(content, &entries); err != nil {
+			return nil, fmt.Errorf("unmarshal announcement archive: %w", err)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Date.After(entries[j].Date)
+	})
+	if len(entries) > g.options.Releases {
+		entries = entries[:g.options.Releases]
+	}
+
+	urlPrefix := release.URLPrefixForBucket(bucket)
+	releases := []Release{}
+	for _, entry := range entries {
+		r := Release{
+			Tag:          entry.Tag,
+			Date:         entry.Date,
+			Announcement: fmt.Sprintf("%s/%s/%s", urlPrefix, announce.ArchiveDir, entry.File),
+		}
+
+		releasePath, err := layout.Default().ReleasePath(bucket, "release", entry.Tag, false)
+		if err != nil {
+			return nil, fmt.Errorf("get release path of %s: %w", entry.Tag, err)
+		}
+		manifest, err := g.impl.ReadObject(
+			object.GcsPrefix + filepath.Join(releasePath, buildenv.ManifestFilename),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("read build environment of %s: %w", entry.Tag, err)
+		}
+		if manifest != nil {
+			r.BuildEnvironment = &buildenv.Manifest{}
+			if err := json.Unmarshal(manifest, r.BuildEnvironment); err != nil {
+				return nil, fmt.Errorf("unmarshal build environment of %s: %w", entry.Tag, err)
+			}
+		}
+		releases = append(releases, r)
+	}
+	return releases, nil
+}
+
+// Render returns the dashboard page and its data file by file name.
+func Render(data *Data) (map[string][]byte, error) {
+	page := &bytes.Buffer{}
+	if err := template.Must(template.New("dashboard").Parse(pageTemplate)).Execute(page, data); err != nil {
+		return nil, fmt.Errorf("render dashboard: %w", err)
+	}
+	dataJSON, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal dashboard data: %w", err)
+	}
+	return map[string][]byte{IndexFile: page.Bytes(), DataFile: dataJSON}, nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dashboard_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/dashboard"
+	"k8s.io/release/pkg/dashboard/dashboardfakes"
+	"k8s.io/release/pkg/testgrid"
+)
+
+const index = `[
+  {"tag": "v1.30.1", "subject": "Kubernetes v1.30.1 is live!", "date": "2024-05-15T10:00:00Z", "file": "v1.30.1.html"},
+  {"tag": "v1.30.2", "subject": "Kubernetes v1.30.2 is live!", "date": "2024-06-12T10:00:00Z", "file": "v1.30.2.html"},
+  {"tag": "v1.30.0", "subject": "Kubernetes v1.30.0 is live!", "date": "2024-04-17T10:00:00Z", "file": "v1.30.0.html"}
+]`
+
+const schedule = `releases:
+- version: 1.31
+  timeline:
+  - what: Begin Code Freeze
+    when: "2024-07-09"
+  - what: Thaw
+    when: "2024-08-13"
+- version: 1.30
+  timeline:
+  - what: Begin Code Freeze
+    when: "2024-03-05"
+  - what: Thaw
+    when: "2024-04-17"
+`
+
+func newFake() *dashboardfakes.FakeImpl {
+	mock := &dashboardfakes.FakeImpl{}
+	mock.ReadObjectCalls(func(path string) ([]byte, error) {
+		switch path {
+		case "gs://bucket/announcements/index.json":
+			return []byte(index), nil
+		case "gs://bucket/release/v1.30.2/build-environment.json":
+			return []byte(`{"buildVersion": "v1.30.2-rc.0.5+abc", "goVersion": "1.22.4", "krel": "v0.17.0"}`), nil
+		}
+		return nil, nil
+	})
+	mock.ReadFileReturns([]byte(schedule), nil)
+	mock.SignalCalls(func(branch string) (*testgrid.Signal, error) {
+		if branch == "release-1.29" {
+			return nil, errors.New("testgrid unavailable")
+		}
+		return &testgrid.Signal{
+			Dashboard: testgrid.BlockingDashboard(branch),
+			Jobs: testgrid.JobData{
+				"a": testgrid.JobSummary{OverallStatus: testgrid.Passing},
+				"b": testgrid.JobSummary{OverallStatus: testgrid.Flaky},
+				"c": testgrid.JobSummary{OverallStatus: testgrid.Failing},
+			},
+		}, nil
+	})
+	return mock
+}
+
+func newOptions() *dashboard.Options {
+	opts := dashboard.DefaultOptions()
+	opts.Bucket = "bucket"
+	opts.Branches = []string{"master", "release-1.29"}
+	opts.Releases = 2
+	opts.ScheduleFile = "schedule.yaml"
+	opts.AllowedFlakyJobs = 1
+	return opts
+}
+
+func TestGenerate(t *testing.T) {
+	mock := newFake()
+	sut := dashboard.New(newOptions())
+	sut.SetImpl(mock)
+
+	now := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	data, err := sut.Generate(now)
+	require.NoError(t, err)
+	require.Equal(t, now, data.Generated)
+
+	require.Len(t, data.Signals, 2)
+	require.Equal(t, dashboard.Signal{
+		Branch:    "master",
+		Dashboard: "sig-release-master-blocking",
+		Passing:   1,
+		Flaky:     1,
+		Failing:   1,
+		Blocking:  []testgrid.JobName{"c"},
+	}, data.Signals[0])
+	require.Equal(t, "testgrid unavailable", data.Signals[1].Error)
+
+	require.Len(t, data.Schedule, 1)
+	require.Equal(t, "1.31", data.Schedule[0].Release)
+
+	require.Len(t, data.Releases, 2)
+	require.Equal(t, "v1.30.2", data.Releases[0].Tag)
+	require.Equal(t, "https://storage.googleapis.com/bucket/announcements/v1.30.2.html", data.Releases[0].Announcement)
+	require.NotNil(t, data.Releases[0].BuildEnvironment)
+	require.Equal(t, "1.22.4", data.Releases[0].BuildEnvironment.GoVersion)
+	require.Equal(t, "v1.30.1", data.Releases[1].Tag)
+	require.Nil(t, data.Releases[1].BuildEnvironment)
+}
+
+func TestRender(t *testing.T) {
+	mock := newFake()
+	sut := dashboard.New(newOptions())
+	sut.SetImpl(mock)
+
+	data, err := sut.Generate(time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	files, err := dashboard.Render(data)
+	require.NoError(t, err)
+	require.Contains(t, string(files[dashboard.IndexFile]), "code freeze of 1.31 from 2024-07-09 until 2024-08-13 <b>(active)</b>")
+	require.Contains(t, string(files[dashboard.DataFile]), `"tag": "v1.30.2"`)
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		publish string
+		prepare func(*dashboardfakes.FakeImpl, *dashboard.Options)
+		assert  func(*testing.T, *dashboardfakes.FakeImpl, error)
+	}{
+		{
+			name: "write only",
+			assert: func(t *testing.T, mock *dashboardfakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 2, mock.WriteFileCallCount())
+				path, content := mock.WriteFileArgsForCall(0)
+				require.Equal(t, filepath.Join(dashboard.Dir, dashboard.IndexFile), path)
+				require.Contains(t, string(content), "sig-release-master-blocking")
+				require.Contains(t, string(content), `<a href="https://storage.googleapis.com/bucket/announcements/v1.30.2.html">v1.30.2</a>`)
+				require.Zero(t, mock.WriteObjectCallCount())
+			},
+		},
+		{
+			name:    "publish",
+			publish: "gs://public",
+			assert: func(t *testing.T, mock *dashboardfakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 2, mock.WriteObjectCallCount())
+				path, _ := mock.WriteObjectArgsForCall(0)
+				require.Equal(t, "gs://public/dashboard/index.html", path)
+				path, _ = mock.WriteObjectArgsForCall(1)
+				require.Equal(t, "gs://public/dashboard/dashboard.json", path)
+			},
+		},
+		{
+			name: "invalid options",
+			prepare: func(_ *dashboardfakes.FakeImpl, opts *dashboard.Options) {
+				opts.Releases = 0
+			},
+			assert: func(t *testing.T, mock *dashboardfakes.FakeImpl, err error) {
+				require.Error(t, err)
+				require.Zero(t, mock.WriteFileCallCount())
+			},
+		},
+		{
+			name: "archive read fails",
+			prepare: func(mock *dashboardfakes.FakeImpl, _ *dashboard.Options) {
+				mock.ReadObjectReturns(nil, errors.New("error"))
+			},
+			assert: func(t *testing.T, mock *dashboardfakes.FakeImpl, err error) {
+				require.Error(t, err)
+				require.Zero(t, mock.WriteFileCallCount())
+			},
+		},
+		{
+			name: "write fails",
+			prepare: func(mock *dashboardfakes.FakeImpl, _ *dashboard.Options) {
+				mock.WriteFileReturns(errors.New("error"))
+			},
+			assert: func(t *testing.T, mock *dashboardfakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newFake()
+			opts := newOptions()
+			opts.PublishBucket = tc.publish
+			if tc.prepare != nil {
+				tc.prepare(mock, opts)
+			}
+			sut := dashboard.New(opts)
+			sut.SetImpl(mock)
+
+			_, err := sut.Run()
+			tc.assert(t, mock, err)
+		})
+	}
+}
@@ -0,0 +1,450 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package dashboardfakes
+
+import (
+	"sync"
+
+	"k8s.io/release/pkg/testgrid"
+)
+
+type FakeImpl struct {
+	ReadFileStub        func(string) ([]byte, error)
+	readFileMutex       sync.RWMutex
+	readFileArgsForCall []struct {
+		arg1 string
+	}
+	readFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	ReadObjectStub        func(string) ([]byte, error)
+	readObjectMutex       sync.RWMutex
+	readObjectArgsForCall []struct {
+		arg1 string
+	}
+	readObjectReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readObjectReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	SignalStub        func(string) (*testgrid.Signal, error)
+	signalMutex       sync.RWMutex
+	signalArgsForCall []struct {
+		arg1 string
+	}
+	signalReturns struct {
+		result1 *testgrid.Signal
+		result2 error
+	}
+	signalReturnsOnCall map[int]struct {
+		result1 *testgrid.Signal
+		result2 error
+	}
+	WriteFileStub        func(string, []byte) error
+	writeFileMutex       sync.RWMutex
+	writeFileArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	writeFileReturns struct {
+		result1 error
+	}
+	writeFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteObjectStub        func(string, []byte) error
+	writeObjectMutex       sync.RWMutex
+	writeObjectArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	writeObjectReturns struct {
+		result1 error
+	}
+	writeObjectReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) ReadFile(arg1 string) ([]byte, error) {
+	fake.readFileMutex.Lock()
+	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
+	fake.readFileArgsForCall = append(fake.readFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReadFileStub
+	fakeReturns := fake.readFileReturns
+	fake.recordInvocation("ReadFile", []interface{}{arg1})
+	fake.readFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ReadFileCallCount() int {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return len(fake.readFileArgsForCall)
+}
+
+func (fake *FakeImpl) ReadFileCalls(stub func(string) ([]byte, error)) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = stub
+}
+
+func (fake *FakeImpl) ReadFileArgsForCall(i int) string {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	argsForCall := fake.readFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ReadFileReturns(result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	fake.readFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	if fake.readFileReturnsOnCall == nil {
+		fake.readFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadObject(arg1 string) ([]byte, error) {
+	fake.readObjectMutex.Lock()
+	ret, specificReturn := fake.readObjectReturnsOnCall[len(fake.readObjectArgsForCall)]
+	fake.readObjectArgsForCall = append(fake.readObjectArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReadObjectStub
+	fakeReturns := fake.readObjectReturns
+	fake.recordInvocation("ReadObject", []interface{}{arg1})
+	fake.readObjectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ReadObjectCallCount() int {
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
+	return len(fake.readObjectArgsForCall)
+}
+
+func (fake *FakeImpl) ReadObjectCalls(stub func(string) ([]byte, error)) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = stub
+}
+
+func (fake *FakeImpl) ReadObjectArgsForCall(i int) string {
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
+	argsForCall := fake.readObjectArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ReadObjectReturns(result1 []byte, result2 error) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = nil
+	fake.readObjectReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadObjectReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = nil
+	if fake.readObjectReturnsOnCall == nil {
+		fake.readObjectReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readObjectReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Signal(arg1 string) (*testgrid.Signal, error) {
+	fake.signalMutex.Lock()
+	ret, specificReturn := fake.signalReturnsOnCall[len(fake.signalArgsForCall)]
+	fake.signalArgsForCall = append(fake.signalArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SignalStub
+	fakeReturns := fake.signalReturns
+	fake.recordInvocation("Signal", []interface{}{arg1})
+	fake.signalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) SignalCallCount() int {
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
+	return len(fake.signalArgsForCall)
+}
+
+func (fake *FakeImpl) SignalCalls(stub func(string) (*testgrid.Signal, error)) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = stub
+}
+
+func (fake *FakeImpl) SignalArgsForCall(i int) string {
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
+	argsForCall := fake.signalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) SignalReturns(result1 *testgrid.Signal, result2 error) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = nil
+	fake.signalReturns = struct {
+		result1 *testgrid.Signal
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SignalReturnsOnCall(i int, result1 *testgrid.Signal, result2 error) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = nil
+	if fake.signalReturnsOnCall == nil {
+		fake.signalReturnsOnCall = make(map[int]struct {
+			result1 *testgrid.Signal
+			result2 error
+		})
+	}
+	fake.signalReturnsOnCall[i] = struct {
+		result1 *testgrid.Signal
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeFileMutex.Lock()
+	ret, specificReturn := fake.writeFileReturnsOnCall[len(fake.writeFileArgsForCall)]
+	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.WriteFileStub
+	fakeReturns := fake.writeFileReturns
+	fake.recordInvocation("WriteFile", []interface{}{arg1, arg2Copy})
+	fake.writeFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) WriteFileCallCount() int {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return len(fake.writeFileArgsForCall)
+}
+
+func (fake *FakeImpl) WriteFileCalls(stub func(string, []byte) error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = stub
+}
+
+func (fake *FakeImpl) WriteFileArgsForCall(i int) (string, []byte) {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	argsForCall := fake.writeFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) WriteFileReturns(result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	fake.writeFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteFileReturnsOnCall(i int, result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	if fake.writeFileReturnsOnCall == nil {
+		fake.writeFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteObject(arg1 string, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeObjectMutex.Lock()
+	ret, specificReturn := fake.writeObjectReturnsOnCall[len(fake.writeObjectArgsForCall)]
+	fake.writeObjectArgsForCall = append(fake.writeObjectArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.WriteObjectStub
+	fakeReturns := fake.writeObjectReturns
+	fake.recordInvocation("WriteObject", []interface{}{arg1, arg2Copy})
+	fake.writeObjectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) WriteObjectCallCount() int {
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
+	return len(fake.writeObjectArgsForCall)
+}
+
+func (fake *FakeImpl) WriteObjectCalls(stub func(string, []byte) error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = stub
+}
+
+func (fake *FakeImpl) WriteObjectArgsForCall(i int) (string, []byte) {
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
+	argsForCall := fake.writeObjectArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) WriteObjectReturns(result1 error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = nil
+	fake.writeObjectReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteObjectReturnsOnCall(i int, result1 error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = nil
+	if fake.writeObjectReturnsOnCall == nil {
+		fake.writeObjectReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeObjectReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dashboard
+
+import (
+	"os"
+	"path/filepath"
+
+	"k8s.io/release/pkg/gcp/gcs"
+	"k8s.io/release/pkg/testgrid"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt dashboardfakes/fake_impl.go > dashboardfakes/_fake_impl.go && mv dashboardfakes/_fake_impl.go dashboardfakes/fake_impl.go"
+type impl interface {
+	// ReadObject returns nil if the object does not exist.
+	ReadObject(gcsPath string) ([]byte, error)
+	WriteObject(gcsPath string, content []byte) error
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path string, content []byte) error
+	Signal(branch string) (*testgrid.Signal, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) ReadObject(gcsPath string) ([]byte, error) {
+	return gcs.ReadObject(gcsPath)
+}
+
+func (*defaultImpl) WriteObject(gcsPath string, content []byte) error {
+	return gcs.WriteObject(gcsPath, content)
+}
+
+func (*defaultImpl) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (*defaultImpl) WriteFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o644) //nolint:gosec // the dashboard is public
+}
+
+func (*defaultImpl) Signal(branch string) (*testgrid.Signal, error) {
+	return testgrid.New().Signal(branch)
+}
@@ -0,0 +1,453 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encryption provides the client-side envelope encryption of
+// artifacts staged ahead of an embargoed security release, which allows
+// keeping them on shared infrastructure like the staging bucket. Every
+// artifact gets encrypted with AES-256-GCM using a random data key, which
+// itself is encrypted by a Cloud KMS key and stored in the header of the
+// artifact. Decrypting therefore only requires access to the KMS key, but no
+// further configuration.
+package encryption
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MarkerFile is the name of the file indicating that the artifacts of a
+// stage run are encrypted.
+const MarkerFile = "ENCRYPTED"
+
+const (
+	dataKeySize     = 32
+	noncePrefixSize = 8
+	chunkSize       = 64 * 1024
+)
+
+// magic is the start of every encrypted file.
+var magic = []byte("K8SRELENC1\n")
+
+// keyNameRegex matches Cloud KMS key resource names.
+var keyNameRegex = regexp.MustCompile(
+	`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`,
+)
+
+var (
+	// ErrInvalidKeyName is returned if the key is not a Cloud KMS key
+	// resource name.
+	ErrInvalidKeyName = errors.New("invalid Cloud KMS key name")
+
+	// ErrNotEncrypted is returned when decrypting a file which has not been
+	// encrypted by this package.
+	ErrNotEncrypted = errors.New("file is not encrypted")
+
+	// ErrCorrupted is returned if an encrypted file got truncated or
+	// modified.
+	ErrCorrupted = errors.New("encrypted file is corrupted")
+)
+
+// Options are the options for encrypting artifacts.
+type Options struct {
+	// KeyName is the resource name of the Cloud KMS key used for encrypting
+	// the data keys, for example
+	// projects/k8s-releng-prod/locations/global/keyRings/release/cryptoKeys/embargo.
+	// Decrypting does not require it, because the key name is part of every
+	// encrypted file.
+	KeyName string
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{}
+}
+
+// Validate checks if the options are usable for encrypting.
+func (o *Options) Validate() error {
+	return ValidateKeyName(o.KeyName)
+}
+
+// ValidateKeyName checks if the key is a Cloud KMS key resource name.
+func ValidateKeyName(keyName string) error {
+	if !keyNameRegex.MatchString(keyName) {
+		return fmt.Errorf(
+			"%w %q, expected projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>",
+			ErrInvalidKeyName, keyName,
+		)
+	}
+	return nil
+}
+
+// Envelope encrypts and decrypts artifacts.
+type Envelope struct {
+	impl    impl
+	options *Options
+
+	// dataKey is created once and used for all encrypted files, which only
+	// requires a single KMS request.
+	dataKey    []byte
+	wrappedKey []byte
+
+	// unwrapped are the already decrypted data keys indexed by their
+	// encrypted form.
+	unwrapped map[string][]byte
+}
+
+// New creates a new Envelope instance.
+func New(opts *Options) *Envelope {
+	return &Envelope{
+		impl:      &defaultImpl{},
+		options:   opts,
+		unwrapped: map[string][]byte{},
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (e *Envelope) SetImpl(impl impl) {
+	e.impl = impl
+}
+
+// IsEncrypted returns true if the file has been encrypted by this package.
+func IsEncrypted(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	start := make([]byte, len(magic))
+	if _, err := io.ReadFull(f, start); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read %s: %w", path, err)
+	}
+	return bytes.Equal(start, magic), nil
+}
+
+// EncryptFile encrypts the file src into dst.
+func (e *Envelope) EncryptFile(src, dst string) error {
+	if err := e.setupDataKey(); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", src, err)
+	}
+
+	return writeFile(dst, info.Mode().Perm(), func(out io.Writer) error {
+		return e.encrypt(bufio.NewReaderSize(in, chunkSize), out)
+	})
+}
+
+// DecryptFile decrypts the file src into dst. It returns ErrNotEncrypted if
+// src has not been encrypted.
+func (e *Envelope) DecryptFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	return writeFile(dst, 0o644, func(out io.Writer) error {
+		if err := e.decrypt(bufio.NewReaderSize(in, chunkSize), out); err != nil {
+			return fmt.Errorf("decrypt %s: %w", src, err)
+		}
+		return nil
+	})
+}
+
+// EncryptDir encrypts all files below src into dst, keeping their relative
+// paths and names.
+func (e *Envelope) EncryptDir(src, dst string) error {
+	count := 0
+	if err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("create directory: %w", err)
+		}
+		if err := e.EncryptFile(path, target); err != nil {
+			return fmt.Errorf("encrypt %s: %w", rel, err)
+		}
+		count++
+		return nil
+	}); err != nil {
+		return fmt.Errorf("encrypt %s: %w", src, err)
+	}
+	logrus.Infof("Encrypted %d files of %s using %s", count, src, e.options.KeyName)
+	return nil
+}
+
+// DecryptDir decrypts all encrypted files below dir in place and returns
+// their number. Files which are not encrypted are left untouched.
+func (e *Envelope) DecryptDir(dir string) (int, error) {
+	count := 0
+	if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		encrypted, err := IsEncrypted(path)
+		if err != nil || !encrypted {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		tmp := path + ".decrypted"
+		if err := e.DecryptFile(path, tmp); err != nil {
+			return err
+		}
+		if err := os.Chmod(tmp, info.Mode()); err != nil {
+			return fmt.Errorf("set mode of %s: %w", path, err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return fmt.Errorf("replace %s: %w", path, err)
+		}
+		count++
+		return nil
+	}); err != nil {
+		return count, fmt.Errorf("decrypt %s: %w", dir, err)
+	}
+	if count > 0 {
+		logrus.Infof("Decrypted %d files of %s", count, dir)
+	}
+	return count, nil
+}
+
+// setupDataKey creates the data key and encrypts it with the KMS key if not
+// already done.
+func (e *Envelope) setupDataKey() error {
+	if e.dataKey != nil {
+		return nil
+	}
+	if err := e.options.Validate(); err != nil {
+		return fmt.Errorf("validating options: %w", err)
+	}
+
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("create data key: %w", err)
+	}
+	wrapped, err := e.impl.WrapKey(e.options.KeyName, key)
+	if err != nil {
+		return fmt.Errorf("encrypt data key with %s: %w", e.options.KeyName, err)
+	}
+	e.dataKey, e.wrappedKey = key, wrapped
+	return nil
+}
+
+// encrypt writes the header followed by the encrypted chunks of the input.
+// Every chunk is prefixed by a flag marking the final one and its length.
+// The flag is authenticated as additional data, which detects truncated
+// files.
+func (e *Envelope) encrypt(in *bufio.Reader, out io.Writer) error {
+	noncePrefix := make([]byte, noncePrefixSize)
+	if _, err := rand.Read(noncePrefix); err != nil {
+		return fmt.Errorf("create nonce: %w", err)
+	}
+
+	header := &bytes.Buffer{}
+	header.Write(magic)
+	binary.Write(header, binary.BigEndian, uint16(len(e.options.KeyName))) //nolint:errcheck // bytes.Buffer does not fail
+	header.WriteString(e.options.KeyName)
+	binary.Write(header, binary.BigEndian, uint32(len(e.wrappedKey))) //nolint:errcheck // bytes.Buffer does not fail
+	header.Write(e.wrappedKey)
+	header.Write(noncePrefix)
+	if _, err := out.Write(header.Bytes()); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	gcm, err := newGCM(e.dataKey)
+	if err != nil {
+		return err
+	}
+
+	chunk := make([]byte, chunkSize)
+	for counter := uint32(0); ; counter++ {
+		n, err := io.ReadFull(in, chunk)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("read input: %w", err)
+		}
+		final := n < chunkSize
+		if !final {
+			if _, err := in.Peek(1); errors.Is(err, io.EOF) {
+				final = true
+			}
+		}
+
+		flag := []byte{0}
+		if final {
+			flag[0] = 1
+		}
+		sealed := gcm.Seal(nil, nonce(noncePrefix, counter), chunk[:n], flag)
+
+		record := make([]byte, 5, 5+len(sealed))
+		record[0] = flag[0]
+		binary.BigEndian.PutUint32(record[1:], uint32(len(sealed)))
+		if _, err := out.Write(append(record, sealed...)); err != nil {
+			return fmt.Errorf("write chunk: %w", err)
+		}
+		if final {
+			return nil
+		}
+	}
+}
+
+// decrypt reads the header and decrypts the chunks of the input.
+func (e *Envelope) decrypt(in *bufio.Reader, out io.Writer) error {
+	start := make([]byte, len(magic))
+	if _, err := io.ReadFull(in, start); err != nil || !bytes.Equal(start, magic) {
+		return ErrNotEncrypted
+	}
+
+	var keyNameLen uint16
+	if err := binary.Read(in, binary.BigEndian, &keyNameLen); err != nil {
+		return fmt.Errorf("%w: read key name: %w", ErrCorrupted, err)
+	}
+	keyName := make([]byte, keyNameLen)
+	if _, err := io.ReadFull(in, keyName); err != nil {
+		return fmt.Errorf("%w: read key name: %w", ErrCorrupted, err)
+	}
+	var wrappedLen uint32
+	if err := binary.Read(in, binary.BigEndian, &wrappedLen); err != nil {
+		return fmt.Errorf("%w: read data key: %w", ErrCorrupted, err)
+	}
+	wrapped := make([]byte, wrappedLen)
+	if _, err := io.ReadFull(in, wrapped); err != nil {
+		return fmt.Errorf("%w: read data key: %w", ErrCorrupted, err)
+	}
+	noncePrefix := make([]byte, noncePrefixSize)
+	if _, err := io.ReadFull(in, noncePrefix); err != nil {
+		return fmt.Errorf("%w: read nonce: %w", ErrCorrupted, err)
+	}
+
+	key, err := e.unwrap(string(keyName), wrapped)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+
+	record := make([]byte, 5)
+	for counter := uint32(0); ; counter++ {
+		if _, err := io.ReadFull(in, record); err != nil {
+			return fmt.Errorf("%w: read chunk %d: %w", ErrCorrupted, counter, err)
+		}
+		sealedLen := binary.BigEndian.Uint32(record[1:])
+		if sealedLen > chunkSize+uint32(gcm.Overhead()) {
+			return fmt.Errorf("%w: chunk %d exceeds the maximum size", ErrCorrupted, counter)
+		}
+		sealed := make([]byte, sealedLen)
+		if _, err := io.ReadFull(in, sealed); err != nil {
+			return fmt.Errorf("%w: read chunk %d: %w", ErrCorrupted, counter, err)
+		}
+		plain, err := gcm.Open(nil, nonce(noncePrefix, counter), sealed, record[:1])
+		if err != nil {
+			return fmt.Errorf("%w: chunk %d: %w", ErrCorrupted, counter, err)
+		}
+		if _, err := out.Write(plain); err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+		if record[0] == 1 {
+			if _, err := in.Peek(1); !errors.Is(err, io.EOF) {
+				return fmt.Errorf("%w: data after the final chunk", ErrCorrupted)
+			}
+			return nil
+		}
+	}
+}
+
+// unwrap decrypts the data key with the KMS key, which only happens once per
+// data key.
+func (e *Envelope) unwrap(keyName string, wrapped []byte) ([]byte, error) {
+	if key, ok := e.unwrapped[string(wrapped)]; ok {
+		return key, nil
+	}
+	key, err := e.impl.UnwrapKey(keyName, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt data key with %s: %w", keyName, err)
+	}
+	e.unwrapped[string(wrapped)] = key
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+// nonce returns the nonce of a chunk, which consists of the random prefix of
+// the file and the chunk counter.
+func nonce(prefix []byte, counter uint32) []byte {
+	n := make([]byte, noncePrefixSize+4)
+	copy(n, prefix)
+	binary.BigEndian.PutUint32(n[noncePrefixSize:], counter)
+	return n
+}
+
+// writeFile writes dst using the provided function and removes it on
+// failure.
+func writeFile(dst string, perm fs.FileMode, write func(io.Writer) error) error {
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", dst, err)
+	}
+	if err := write(out); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", dst, err)
+	}
+	return nil
+}
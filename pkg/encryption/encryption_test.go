@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/encryption"
+	"k8s.io/release/pkg/encryption/encryptionfakes"
+)
+
+const keyName = "projects/k8s-releng-prod/locations/global/keyRings/release/cryptoKeys/embargo"
+
+// newEnvelope returns an Envelope whose KMS key reverses the data key.
+func newEnvelope() (*encryption.Envelope, *encryptionfakes.FakeImpl) {
+	reverse := func(_ string, in []byte) ([]byte, error) {
+		out := make([]byte, len(in))
+		for i := range in {
+			out[len(in)-1-i] = in[i]
+		}
+		return out, nil
+	}
+	mock := &encryptionfakes.FakeImpl{}
+	mock.WrapKeyStub = reverse
+	mock.UnwrapKeyStub = reverse
+
+	e := encryption.New(&encryption.Options{KeyName: keyName})
+	e.SetImpl(mock)
+	return e, mock
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		keyName     string
+		shouldError bool
+	}{
+		{name: "success", keyName: keyName},
+		{name: "empty", keyName: "", shouldError: true},
+		{name: "key ring only", keyName: "projects/p/locations/global/keyRings/release", shouldError: true},
+		{name: "key version", keyName: keyName + "/cryptoKeyVersions/1", shouldError: true},
+	} {
+		err := (&encryption.Options{KeyName: tc.keyName}).Validate()
+		if tc.shouldError {
+			require.ErrorIs(t, err, encryption.ErrInvalidKeyName, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
+
+func TestEncryptDecryptFile(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		size int
+	}{
+		{name: "empty", size: 0},
+		{name: "small", size: 100},
+		{name: "exact chunk", size: 64 * 1024},
+		{name: "multiple chunks", size: 3*64*1024 + 17},
+	} {
+		dir := t.TempDir()
+		content := bytes.Repeat([]byte("kubernetes"), tc.size/10+1)[:tc.size]
+		src := filepath.Join(dir, "plain")
+		require.NoError(t, os.WriteFile(src, content, 0o644), tc.name)
+
+		e, mock := newEnvelope()
+		enc := filepath.Join(dir, "encrypted")
+		require.NoError(t, e.EncryptFile(src, enc), tc.name)
+
+		encrypted, err := encryption.IsEncrypted(enc)
+		require.NoError(t, err, tc.name)
+		require.True(t, encrypted, tc.name)
+		if tc.size > 0 {
+			encContent, err := os.ReadFile(enc)
+			require.NoError(t, err, tc.name)
+			require.NotContains(t, string(encContent), "kubernetes", tc.name)
+		}
+
+		dec := filepath.Join(dir, "decrypted")
+		require.NoError(t, e.DecryptFile(enc, dec), tc.name)
+		decContent, err := os.ReadFile(dec)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, content, decContent, tc.name)
+
+		require.Equal(t, 1, mock.WrapKeyCallCount(), tc.name)
+		name, _ := mock.UnwrapKeyArgsForCall(0)
+		require.Equal(t, keyName, name, tc.name)
+	}
+}
+
+func TestDecryptFileFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		modify  func([]byte) []byte
+		unwrap  error
+		wantErr error
+	}{
+		{
+			name:    "not encrypted",
+			modify:  func([]byte) []byte { return []byte("plain") },
+			wantErr: encryption.ErrNotEncrypted,
+		},
+		{
+			name: "modified",
+			modify: func(b []byte) []byte {
+				b[len(b)-1] ^= 1
+				return b
+			},
+			wantErr: encryption.ErrCorrupted,
+		},
+		{
+			name:    "truncated",
+			modify:  func(b []byte) []byte { return b[:len(b)-100] },
+			wantErr: encryption.ErrCorrupted,
+		},
+		{
+			name:    "final chunk removed",
+			modify:  func(b []byte) []byte { return b[:len(b)-(5+100+16)] },
+			wantErr: encryption.ErrCorrupted,
+		},
+		{
+			name:    "data appended",
+			modify:  func(b []byte) []byte { return append(b, 0) },
+			wantErr: encryption.ErrCorrupted,
+		},
+		{
+			name:    "key not accessible",
+			modify:  func(b []byte) []byte { return b },
+			unwrap:  errors.New("permission denied"),
+			wantErr: nil,
+		},
+	} {
+		dir := t.TempDir()
+		src := filepath.Join(dir, "plain")
+		require.NoError(t, os.WriteFile(src, bytes.Repeat([]byte{1}, 64*1024+100), 0o644), tc.name)
+
+		e, mock := newEnvelope()
+		enc := filepath.Join(dir, "encrypted")
+		require.NoError(t, e.EncryptFile(src, enc), tc.name)
+		content, err := os.ReadFile(enc)
+		require.NoError(t, err, tc.name)
+		require.NoError(t, os.WriteFile(enc, tc.modify(content), 0o644), tc.name)
+		if tc.unwrap != nil {
+			mock.UnwrapKeyStub = nil
+			mock.UnwrapKeyReturns(nil, tc.unwrap)
+		}
+
+		dec := filepath.Join(dir, "decrypted")
+		err = e.DecryptFile(enc, dec)
+		require.Error(t, err, tc.name)
+		if tc.wantErr != nil {
+			require.ErrorIs(t, err, tc.wantErr, tc.name)
+		}
+		require.NoFileExists(t, dec, tc.name)
+	}
+}
+
+func TestEncryptFileInvalidKey(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "plain")
+	require.NoError(t, os.WriteFile(src, []byte("plain"), 0o644))
+
+	e := encryption.New(encryption.DefaultOptions())
+	require.ErrorIs(t, e.EncryptFile(src, filepath.Join(dir, "encrypted")), encryption.ErrInvalidKeyName)
+}
+
+func TestEncryptDecryptDir(t *testing.T) {
+	src := t.TempDir()
+	files := map[string]string{
+		"kubernetes.tar.gz":       "tarball",
+		"bin/linux/amd64/kubectl": "binary",
+		"SHA256SUMS":              "sums",
+	}
+	for name, content := range files {
+		path := filepath.Join(src, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o755))
+	}
+
+	e, mock := newEnvelope()
+	dst := t.TempDir()
+	require.NoError(t, e.EncryptDir(src, dst))
+	require.Equal(t, 1, mock.WrapKeyCallCount())
+
+	// A file which got added unencrypted is left untouched
+	require.NoError(t, os.WriteFile(filepath.Join(dst, "plain.txt"), []byte("plain"), 0o644))
+
+	d, mock := newEnvelope()
+	count, err := d.DecryptDir(dst)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	require.Equal(t, 1, mock.UnwrapKeyCallCount())
+
+	for name, content := range files {
+		got, err := os.ReadFile(filepath.Join(dst, name))
+		require.NoError(t, err, name)
+		require.Equal(t, content, string(got), name)
+
+		info, err := os.Stat(filepath.Join(dst, name))
+		require.NoError(t, err, name)
+		require.Equal(t, os.FileMode(0o755), info.Mode().Perm(), name)
+	}
+	got, err := os.ReadFile(filepath.Join(dst, "plain.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "plain", string(got))
+}
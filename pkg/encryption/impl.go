@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/release-utils/command"
+
+	"k8s.io/release/pkg/workdir"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt encryptionfakes/fake_impl.go > encryptionfakes/_fake_impl.go && mv encryptionfakes/_fake_impl.go encryptionfakes/fake_impl.go"
+type impl interface {
+	// WrapKey encrypts the data key with the Cloud KMS key.
+	WrapKey(keyName string, key []byte) ([]byte, error)
+	// UnwrapKey decrypts the data key with the Cloud KMS key.
+	UnwrapKey(keyName string, wrapped []byte) ([]byte, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) WrapKey(keyName string, key []byte) ([]byte, error) {
+	return kms("encrypt", keyName, key)
+}
+
+func (*defaultImpl) UnwrapKey(keyName string, wrapped []byte) ([]byte, error) {
+	return kms("decrypt", keyName, wrapped)
+}
+
+// kms runs `gcloud kms encrypt` or `gcloud kms decrypt` on the input. The
+// files are used instead of stdin and stdout to keep the binary data out of
+// the command logs.
+func kms(operation, keyName string, input []byte) ([]byte, error) {
+	dir, err := workdir.MkdirTemp("encryption-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "input")
+	out := filepath.Join(dir, "output")
+	if err := os.WriteFile(in, input, 0o600); err != nil {
+		return nil, fmt.Errorf("write input: %w", err)
+	}
+
+	inFlag, outFlag := "--plaintext-file", "--ciphertext-file"
+	if operation == "decrypt" {
+		inFlag, outFlag = outFlag, inFlag
+	}
+	if err := command.New(
+		"gcloud", "kms", operation, "--key", keyName, inFlag, in, outFlag, out,
+	).RunSilentSuccess(); err != nil {
+		return nil, fmt.Errorf("run gcloud kms %s: %w", operation, err)
+	}
+	return os.ReadFile(out)
+}
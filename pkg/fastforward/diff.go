@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fastforward
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-sdk/git"
+)
+
+const (
+	logFieldSeparator  = "\x1f"
+	logRecordSeparator = "\x1e"
+
+	// logFormat is the git log format used for parsing the commits, which
+	// contains the hash, author, subject and body of each commit.
+	logFormat = "--format=%H" + logFieldSeparator + "%an" + logFieldSeparator +
+		"%s" + logFieldSeparator + "%b" + logRecordSeparator
+)
+
+var (
+	// mergeCommitRE matches the default GitHub merge commit subject.
+	mergeCommitRE = regexp.MustCompile(`^Merge pull request #(\d+) from ([^/\s]+)/`)
+
+	// squashCommitRE matches the PR number suffix of squashed commits.
+	squashCommitRE = regexp.MustCompile(`\(#(\d+)\)$`)
+)
+
+// Commit is a single commit which would be fast forwarded.
+type Commit struct {
+	// SHA is the full commit hash.
+	SHA string
+
+	// Author is the author of the change, which is the PR author for GitHub
+	// merge commits.
+	Author string
+
+	// Subject is the commit subject or the PR title for merge commits.
+	Subject string
+
+	// PR is the pull request number or zero if not available.
+	PR int
+}
+
+// PRLink returns the link to the pull request of the commit or an empty
+// string if the commit does not reference any pull request.
+func (c *Commit) PRLink(org, repo string) string {
+	if c.PR == 0 {
+		return ""
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/pull/%d", org, repo, c.PR)
+}
+
+// parseCommits converts the git log output using logFormat into commits.
+func parseCommits(log string) []Commit {
+	commits := []Commit{}
+	for _, record := range strings.Split(log, logRecordSeparator) {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+
+		fields := strings.SplitN(record, logFieldSeparator, 4)
+		if len(fields) < 3 {
+			continue
+		}
+
+		commit := Commit{SHA: fields[0], Author: fields[1], Subject: fields[2]}
+
+		if m := mergeCommitRE.FindStringSubmatch(commit.Subject); m != nil {
+			commit.PR, _ = strconv.Atoi(m[1]) //nolint:errcheck // matched by regex
+			commit.Author = m[2]
+
+			// The PR title is the first line of the merge commit body
+			if len(fields) == 4 {
+				if title, _, _ := strings.Cut(strings.TrimSpace(fields[3]), "\n"); title != "" {
+					commit.Subject = title
+				}
+			}
+		} else if m := squashCommitRE.FindStringSubmatch(commit.Subject); m != nil {
+			commit.PR, _ = strconv.Atoi(m[1]) //nolint:errcheck // matched by regex
+		}
+
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// printCommits renders the provided commits as markdown table.
+func printCommits(w io.Writer, org, repo string, commits []Commit) {
+	table := tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Commit", "Author", "Subject", "PR"})
+	for i := range commits {
+		sha := commits[i].SHA
+		if len(sha) > 10 {
+			sha = sha[:10]
+		}
+		table.Append([]string{
+			sha, commits[i].Author, commits[i].Subject, commits[i].PRLink(org, repo),
+		})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+}
+
+// diffBranches verifies and lists the commits to be fast forwarded for
+// multiple release branches concurrently, each of them checked out into its
+// own worktree of the provided repository.
+func (f *FastForward) diffBranches(repo *git.Repo) error {
+	branches := f.options.DiffBranches
+	for _, branch := range branches {
+		if !f.IsReleaseBranch(branch) {
+			return fmt.Errorf("%s is not a release branch", branch)
+		}
+	}
+
+	mainTag, err := f.RepoDescribe(
+		repo,
+		git.NewDescribeOptions().
+			WithRevision(git.Remotify(git.DefaultBranch)).
+			WithAbbrev(0).
+			WithTags(),
+	)
+	if err != nil {
+		return fmt.Errorf("describe latest main tag: %w", err)
+	}
+
+	var (
+		mu      sync.Mutex
+		outputs = map[string]string{}
+	)
+	runErr := f.RunInWorktrees(f.RepoDir(repo), branches, func(branch, dir string) error {
+		mergeBase, err := f.Git(dir, "merge-base", git.Remotify(git.DefaultBranch), "HEAD")
+		if err != nil {
+			return fmt.Errorf("find merge base: %w", err)
+		}
+		mergeBaseTag, err := f.Git(dir, "describe", "--abbrev=0", "--tags", mergeBase)
+		if err != nil {
+			return fmt.Errorf("describe latest merge base tag: %w", err)
+		}
+		if mainTag != mergeBaseTag {
+			return fmt.Errorf(
+				"unable to fast forward: tag %q does not match %q",
+				mainTag, mergeBaseTag,
+			)
+		}
+
+		log, err := f.Git(dir, "log", "--first-parent", logFormat, "HEAD.."+f.options.MainRef)
+		if err != nil {
+			return fmt.Errorf("list commits: %w", err)
+		}
+
+		output := &bytes.Buffer{}
+		if commits := parseCommits(log); len(commits) > 0 {
+			fmt.Fprintf(output, "%s: %d commits to be fast forwarded\n", branch, len(commits))
+			printCommits(output, f.options.GitHubOrg, f.options.GitHubRepo, commits)
+		} else {
+			fmt.Fprintf(output, "%s: no commits to be fast forwarded, the branch is up to date\n", branch)
+		}
+
+		mu.Lock()
+		outputs[branch] = output.String()
+		mu.Unlock()
+		return nil
+	})
+
+	// Print in the provided order, including the successful branches of a
+	// failed run
+	for _, branch := range branches {
+		if output, ok := outputs[branch]; ok {
+			fmt.Fprintln(os.Stdout, output)
+		}
+	}
+	if runErr != nil {
+		return fmt.Errorf("verify release branches: %w", runErr)
+	}
+	logrus.Infof("Verified %d release branches", len(branches))
+	return nil
+}
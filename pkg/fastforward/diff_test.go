@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fastforward
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func logRecord(sha, author, subject, body string) string {
+	return strings.Join([]string{sha, author, subject, body}, logFieldSeparator) + logRecordSeparator + "\n"
+}
+
+func TestParseCommits(t *testing.T) {
+	t.Parallel()
+
+	log := logRecord(
+		"1111111111111111", "Kubernetes Prow Robot",
+		"Merge pull request #123 from user/branch", "Fix the thing\n\nSome details",
+	) + logRecord(
+		"2222222222222222", "Jane Doe", "Squashed change (#456)", "",
+	) + logRecord(
+		"3333333333333333", "John Doe", "Direct commit", "",
+	)
+
+	commits := parseCommits(log)
+	require.Len(t, commits, 3)
+
+	require.Equal(t, "user", commits[0].Author)
+	require.Equal(t, "Fix the thing", commits[0].Subject)
+	require.Equal(t, 123, commits[0].PR)
+	require.Equal(t, "https://github.com/org/repo/pull/123", commits[0].PRLink("org", "repo"))
+
+	require.Equal(t, "Jane Doe", commits[1].Author)
+	require.Equal(t, 456, commits[1].PR)
+
+	require.Equal(t, "John Doe", commits[2].Author)
+	require.Zero(t, commits[2].PR)
+	require.Empty(t, commits[2].PRLink("org", "repo"))
+
+	require.Empty(t, parseCommits(""))
+}
+
+func TestPrintCommits(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	printCommits(buf, "org", "repo", []Commit{
+		{SHA: "1234567890abcdef", Author: "user", Subject: "Fix the thing", PR: 123},
+	})
+
+	res := buf.String()
+	require.Contains(t, res, "1234567890 ")
+	require.Contains(t, res, "Fix the thing")
+	require.Contains(t, res, "https://github.com/org/repo/pull/123")
+}
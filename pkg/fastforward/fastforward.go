@@ -17,14 +17,20 @@ limitations under the License.
 package fastforward
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/release/pkg/approver"
+	"k8s.io/release/pkg/freeze"
 	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/ghperms"
+	"k8s.io/release/pkg/notify"
 	"k8s.io/release/pkg/release"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/github"
@@ -61,6 +67,27 @@ type Options struct {
 
 	// GCPProjectID is the GCP project to use to submit the job.
 	GCPProjectID string
+
+	// NotifyWebhookURL is an optional webhook URL which gets notified about
+	// the success or failure of the fast forward.
+	NotifyWebhookURL string
+
+	// Diff only prints the commits which would be fast forwarded without
+	// merging or pushing anything.
+	Diff bool
+
+	// CIRunURL is the link to the CI run to be included in notifications. It
+	// will be inferred from the Prow environment if not set.
+	CIRunURL string
+
+	// SSHKey is the path to a private SSH key, like a deploy key, which is
+	// used to push over SSH instead of HTTPS and a GitHub token.
+	SSHKey string
+
+	// DiffBranches are release branches to be verified concurrently, which
+	// prints the commits to be fast forwarded for each of them. Nothing will
+	// be merged or pushed in that mode.
+	DiffBranches []string
 }
 
 // FastForward is the main structure of this package.
@@ -80,8 +107,43 @@ func New(opts *Options) *FastForward {
 const pushUpstreamQuestion = `Are you ready to push the local branch fast-forward changes upstream?
 Please only answer after you have validated the changes.`
 
+// result contains the outcome of a fast forward run, used for notifications.
+type result struct {
+	branch     string
+	releaseRev string
+	headRev    string
+	pushed     bool
+}
+
 // Run starts the FastForward.
-func (f *FastForward) Run() (err error) {
+func (f *FastForward) Run() error {
+	return f.RunContext(context.Background())
+}
+
+// RunContext is like Run but stops watching a submitted job if the provided
+// context gets cancelled.
+func (f *FastForward) RunContext(ctx context.Context) error {
+	res := &result{}
+	err := f.run(ctx, res)
+
+	if f.options.NotifyWebhookURL != "" && !f.options.Submit {
+		if notifyErr := f.notify(res, err); notifyErr != nil {
+			logrus.Errorf("Unable to send fast forward notification: %v", notifyErr)
+		}
+	}
+
+	return err
+}
+
+func (f *FastForward) run(ctx context.Context, res *result) (err error) {
+	if f.options.Submit && (f.options.Diff || len(f.options.DiffBranches) > 0) {
+		return errors.New("diff mode cannot be used together with submit")
+	}
+
+	if f.options.Submit && f.options.SSHKey != "" {
+		return errors.New("SSH key cannot be used together with submit")
+	}
+
 	if f.options.Submit {
 		if err := f.prepareToolRepo(); err != nil {
 			return fmt.Errorf("prepare tool repo: %w", err)
@@ -96,7 +158,8 @@ func (f *FastForward) Run() (err error) {
 		options.ScratchBucket = "gs://" + f.options.GCPProjectID + "-gcb"
 		options.CustomK8SRepo = f.options.GitHubRepo
 		options.CustomK8sOrg = f.options.GitHubOrg
-		return f.Submit(options)
+		options.NotifyWebhookURL = f.options.NotifyWebhookURL
+		return f.Submit(ctx, options)
 	}
 
 	repo, err := f.prepareFastForwardRepo()
@@ -109,6 +172,10 @@ func (f *FastForward) Run() (err error) {
 		f.RepoSetDry(repo)
 	}
 
+	if len(f.options.DiffBranches) > 0 {
+		return f.diffBranches(repo)
+	}
+
 	branch := f.options.Branch
 	if branch == "" {
 		logrus.Info("No release branch specified, finding the latest")
@@ -117,6 +184,7 @@ func (f *FastForward) Run() (err error) {
 			return fmt.Errorf("finding latest release branch: %w", err)
 		}
 		logrus.Infof("Found latest release branch: %s", branch)
+		res.branch = branch
 
 		notRequired, err := f.noFastForwardRequired(repo, branch)
 		if err != nil {
@@ -130,6 +198,7 @@ func (f *FastForward) Run() (err error) {
 			return nil
 		}
 	} else {
+		res.branch = branch
 		logrus.Infof("Checking if %q is a release branch", branch)
 		if isReleaseBranch := f.IsReleaseBranch(branch); !isReleaseBranch {
 			return fmt.Errorf("%s is not a release branch", branch)
@@ -228,6 +297,31 @@ func (f *FastForward) Run() (err error) {
 		return fmt.Errorf("get release rev: %w", err)
 	}
 	logrus.Infof("Latest release branch revision is %s", releaseRev)
+	res.releaseRev = releaseRev
+
+	if f.options.Diff {
+		return f.diff(repo, branch, releaseRev)
+	}
+
+	if f.options.NoMock {
+		if err := freeze.Check("fast forward", branch); err != nil {
+			return err
+		}
+		if err := approver.Check("fast forward", branch); err != nil {
+			return err
+		}
+		// Pushing over SSH uses a deploy key instead of the token. The
+		// merged changes may contain GitHub Actions workflows, which can only
+		// be pushed with the workflow permission.
+		if f.options.SSHKey == "" {
+			if err := f.CheckPermissions(
+				"fast forward", f.options.GitHubOrg, f.options.GitHubRepo,
+				ghperms.Contents, ghperms.Workflows,
+			); err != nil {
+				return err
+			}
+		}
+	}
 
 	logrus.Info("Configuring git user and email")
 	if err := f.ConfigureGlobalDefaultUserAndEmail(); err != nil {
@@ -243,6 +337,7 @@ func (f *FastForward) Run() (err error) {
 	if err != nil {
 		return fmt.Errorf("get HEAD rev: %w", err)
 	}
+	res.headRev = headRev
 
 	prepushMessage(f.RepoDir(repo), f.options.GitHubOrg, f.options.GitHubRepo, branch, f.options.MainRef, releaseRev, headRev)
 
@@ -259,11 +354,101 @@ func (f *FastForward) Run() (err error) {
 		if err := f.RepoPush(repo, branch); err != nil {
 			return fmt.Errorf("push to repo: %w", err)
 		}
+		res.pushed = true
+	}
+
+	return nil
+}
+
+// diff prints the commits of the main ref which are not part of the release
+// branch yet.
+func (f *FastForward) diff(repo *git.Repo, branch, releaseRev string) error {
+	logrus.Infof("Listing commits to be fast forwarded from %s into %s", f.options.MainRef, branch)
+	log, err := f.RepoLog(
+		repo, "--first-parent", logFormat, fmt.Sprintf("%s..%s", releaseRev, f.options.MainRef),
+	)
+	if err != nil {
+		return fmt.Errorf("list commits: %w", err)
 	}
 
+	commits := parseCommits(log)
+	if len(commits) == 0 {
+		logrus.Infof("No commits to be fast forwarded, %s is up to date", branch)
+		return nil
+	}
+
+	logrus.Infof("Found %d commits to be fast forwarded", len(commits))
+	printCommits(os.Stdout, f.options.GitHubOrg, f.options.GitHubRepo, commits)
 	return nil
 }
 
+// notify sends the result of the fast forward to the configured webhook. It
+// does nothing if the run finished successfully without pushing anything.
+func (f *FastForward) notify(res *result, runErr error) error {
+	if runErr == nil && !res.pushed {
+		logrus.Info("Nothing pushed, skipping fast forward notification")
+		return nil
+	}
+
+	mode := "mock"
+	if f.options.NoMock {
+		mode = "nomock"
+	}
+
+	branch := res.branch
+	if branch == "" {
+		branch = "<unknown branch>"
+	}
+
+	var text strings.Builder
+	if runErr != nil {
+		fmt.Fprintf(&text, ":x: Fast forward of %s/%s %s (%s) failed: %v\n",
+			f.options.GitHubOrg, f.options.GitHubRepo, branch, mode, runErr,
+		)
+	} else {
+		fmt.Fprintf(&text, ":white_check_mark: Fast forward of %s/%s %s (%s) succeeded\n",
+			f.options.GitHubOrg, f.options.GitHubRepo, branch, mode,
+		)
+	}
+
+	if res.releaseRev != "" {
+		fmt.Fprintf(&text, "Old HEAD: %s\n", res.releaseRev)
+	}
+	if res.headRev != "" {
+		fmt.Fprintf(&text, "New HEAD: %s\n", res.headRev)
+	}
+	if res.pushed && res.releaseRev != "" && res.headRev != "" {
+		fmt.Fprintf(&text, "Diff: https://github.com/%s/%s/compare/%s...%s\n",
+			f.options.GitHubOrg, f.options.GitHubRepo, res.releaseRev, res.headRev,
+		)
+	}
+	if runURL := f.ciRunURL(); runURL != "" {
+		fmt.Fprintf(&text, "CI run: %s\n", runURL)
+	}
+
+	logrus.Infof("Sending fast forward notification")
+	return f.Notify(f.options.NotifyWebhookURL, &notify.Message{Text: text.String()})
+}
+
+// ciRunURL returns the configured CI run URL or tries to build it from the
+// environment variables of the Prow job.
+func (f *FastForward) ciRunURL() string {
+	if f.options.CIRunURL != "" {
+		return f.options.CIRunURL
+	}
+
+	jobName := f.EnvDefault("JOB_NAME", "")
+	buildID := f.EnvDefault("BUILD_ID", "")
+	if jobName == "" || buildID == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"https://prow.k8s.io/view/gs/kubernetes-jenkins/logs/%s/%s",
+		jobName, buildID,
+	)
+}
+
 func prepushMessage(gitRoot, org, repo, branch, ref, releaseRev, headRev string) {
 	fmt.Printf(`Go look around in %s to make sure things look okay before pushing…
 	
@@ -314,9 +499,11 @@ func (f *FastForward) prepareFastForwardRepo() (*git.Repo, error) {
 
 	token := f.EnvDefault(github.TokenEnvKey, "")
 
+	// Cloning always happens over HTTPs if a key is provided, because only
+	// the git executable is able to use it.
 	useSSH := true
 	stringMsg := "using SSH"
-	if token != "" {
+	if token != "" || f.options.SSHKey != "" {
 		useSSH = false
 		stringMsg = "using HTTPs"
 	}
@@ -329,6 +516,19 @@ func (f *FastForward) prepareFastForwardRepo() (*git.Repo, error) {
 		)
 	}
 
+	if f.options.SSHKey != "" {
+		logrus.Info("Found SSH key, using it for repository interactions")
+		if err := f.ConfigureGitSSH(f.options.SSHKey); err != nil {
+			return nil, fmt.Errorf("configure git SSH: %w", err)
+		}
+		if err := f.RepoSetURL(repo, git.DefaultRemote, git.GetRepoURL(
+			f.options.GitHubOrg, f.options.GitHubRepo, true,
+		)); err != nil {
+			return nil, fmt.Errorf("changing git remote of repository: %w", err)
+		}
+		return repo, nil
+	}
+
 	if token != "" {
 		logrus.Info("Found GitHub token, using it for repository interactions")
 		if f.IsDefaultK8sUpstream() {
@@ -355,7 +555,7 @@ func (f *FastForward) prepareToolRepo() error {
 
 	logrus.Info("Not in a git repo, preparing k/release clone")
 
-	tmpPath, err := f.MkdirTemp("", "k-release-")
+	tmpPath, err := f.MkdirTemp("k-release-")
 	if err != nil {
 		return fmt.Errorf("create temp directory: %w", err)
 	}
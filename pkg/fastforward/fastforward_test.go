@@ -405,6 +405,96 @@ func TestRun(t *testing.T) {
 				require.NotNil(t, err)
 			},
 		},
+		{ // success diff
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.IsReleaseBranchReturns(true)
+				mock.RepoHasRemoteBranchReturns(true, nil)
+				mock.RepoLogReturns(
+					"abc\x1fuser\x1fMerge pull request #1 from user/branch\x1fTitle\x1e", nil,
+				)
+				// never called
+				mock.RepoMergeReturns(errTest)
+				mock.RepoPushReturns(errTest)
+				return &Options{Branch: branch, Diff: true}
+			},
+			assert: func(err error) {
+				require.Nil(t, err)
+			},
+		},
+		{ // success diff without commits
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.IsReleaseBranchReturns(true)
+				mock.RepoHasRemoteBranchReturns(true, nil)
+				return &Options{Branch: branch, Diff: true}
+			},
+			assert: func(err error) {
+				require.Nil(t, err)
+			},
+		},
+		{ // failure on RepoLog
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.IsReleaseBranchReturns(true)
+				mock.RepoHasRemoteBranchReturns(true, nil)
+				mock.RepoLogReturns("", errTest)
+				return &Options{Branch: branch, Diff: true}
+			},
+			assert: func(err error) {
+				require.NotNil(t, err)
+			},
+		},
+		{ // failure diff with submit
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				return &Options{Submit: true, Diff: true}
+			},
+			assert: func(err error) {
+				require.NotNil(t, err)
+			},
+		},
+		{ // failure SSH key with submit
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				return &Options{Submit: true, SSHKey: "id_ed25519"}
+			},
+			assert: func(err error) {
+				require.NotNil(t, err)
+			},
+		},
+		{ // failure on ConfigureGitSSH
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.IsReleaseBranchReturns(true)
+				mock.RepoHasRemoteBranchReturns(true, nil)
+				mock.ConfigureGitSSHReturns(errTest)
+				return &Options{Branch: branch, SSHKey: "id_ed25519"}
+			},
+			assert: func(err error) {
+				require.NotNil(t, err)
+			},
+		},
+		{ // success NoMock with verified GitHub permissions
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.IsReleaseBranchReturns(true)
+				mock.RepoHasRemoteBranchReturns(true, nil)
+				return &Options{
+					Branch: branch, GitHubOrg: "kubernetes", GitHubRepo: "kubernetes",
+					NonInteractive: true, NoMock: true,
+				}
+			},
+			assert: func(err error) {
+				require.Nil(t, err)
+			},
+		},
+		{ // failure on CheckPermissions
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.IsReleaseBranchReturns(true)
+				mock.RepoHasRemoteBranchReturns(true, nil)
+				mock.CheckPermissionsReturns(errTest)
+				// never called
+				mock.RepoMergeReturns(errTest)
+				return &Options{Branch: branch, NonInteractive: true, NoMock: true}
+			},
+			assert: func(err error) {
+				require.ErrorIs(t, err, errTest)
+			},
+		},
 		{ // failure on RepoPush
 			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
 				mock.IsReleaseBranchReturns(true)
@@ -428,3 +518,265 @@ func TestRun(t *testing.T) {
 		tc.assert(err)
 	}
 }
+
+func TestRunSSHKey(t *testing.T) {
+	t.Parallel()
+
+	mock := &fastforwardfakes.FakeImpl{}
+	mock.IsReleaseBranchReturns(true)
+	mock.RepoHasRemoteBranchReturns(true, nil)
+	mock.EnvDefaultReturns("token")
+
+	sut := New(&Options{
+		Branch:         "release-x.y",
+		GitHubOrg:      "kubernetes",
+		GitHubRepo:     "kubernetes",
+		NonInteractive: true,
+		NoMock:         true,
+		SSHKey:         "id_ed25519",
+	})
+	sut.impl = mock
+
+	require.NoError(t, sut.Run())
+	require.Zero(t, mock.CheckPermissionsCallCount())
+
+	_, _, _, useSSH := mock.CloneOrOpenGitHubRepoArgsForCall(0)
+	require.False(t, useSSH)
+
+	require.Equal(t, 1, mock.ConfigureGitSSHCallCount())
+	require.Equal(t, "id_ed25519", mock.ConfigureGitSSHArgsForCall(0))
+
+	require.Equal(t, 1, mock.RepoSetURLCallCount())
+	_, remote, url := mock.RepoSetURLArgsForCall(0)
+	require.Equal(t, "origin", remote)
+	require.Equal(t, "git@github.com:kubernetes/kubernetes", url)
+}
+
+func TestRunDiffBranches(t *testing.T) {
+	t.Parallel()
+
+	branches := []string{"release-1.29", "release-1.30"}
+
+	for _, tc := range []struct {
+		name        string
+		prepare     func(*fastforwardfakes.FakeImpl)
+		shouldError bool
+	}{
+		{
+			name: "success",
+		},
+		{
+			name: "failure no release branch",
+			prepare: func(mock *fastforwardfakes.FakeImpl) {
+				mock.IsReleaseBranchReturnsOnCall(1, false)
+			},
+			shouldError: true,
+		},
+		{
+			name: "failure tag mismatch",
+			prepare: func(mock *fastforwardfakes.FakeImpl) {
+				mock.RepoDescribeReturns("v1.31.0-alpha.1", nil)
+			},
+			shouldError: true,
+		},
+		{
+			name: "failure on Git",
+			prepare: func(mock *fastforwardfakes.FakeImpl) {
+				mock.GitReturns("", errTest)
+			},
+			shouldError: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &fastforwardfakes.FakeImpl{}
+			mock.IsReleaseBranchReturns(true)
+			mock.RepoDescribeReturns("v1.31.0-alpha.0", nil)
+			mock.GitStub = func(_ string, args ...string) (string, error) {
+				switch args[0] {
+				case "describe":
+					return "v1.31.0-alpha.0", nil
+				case "log":
+					return "abc" + logFieldSeparator + "author" + logFieldSeparator +
+						"subject (#1)" + logFieldSeparator + logRecordSeparator, nil
+				}
+				return "", nil
+			}
+			mock.RunInWorktreesStub = func(_ string, branches []string, fn func(string, string) error) error {
+				for _, branch := range branches {
+					if err := fn(branch, "/worktrees/"+branch); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			if tc.prepare != nil {
+				tc.prepare(mock)
+			}
+
+			sut := New(&Options{DiffBranches: branches, MainRef: "origin/master"})
+			sut.impl = mock
+
+			err := sut.Run()
+			if tc.shouldError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, 1, mock.RunInWorktreesCallCount())
+			_, worktreeBranches, _ := mock.RunInWorktreesArgsForCall(0)
+			require.Equal(t, branches, worktreeBranches)
+
+			dir, args := mock.GitArgsForCall(2)
+			require.Equal(t, "/worktrees/release-1.29", dir)
+			require.Equal(t, []string{"log", "--first-parent", logFormat, "HEAD..origin/master"}, args)
+
+			// Nothing gets merged or pushed
+			require.Zero(t, mock.RepoMergeCallCount())
+			require.Zero(t, mock.RepoPushCallCount())
+		})
+	}
+}
+
+func TestRunNotify(t *testing.T) {
+	t.Parallel()
+
+	const (
+		branch  = "release-x.y"
+		webhook = "https://hooks.example.com/test"
+	)
+
+	for _, tc := range []struct {
+		prepare func(*fastforwardfakes.FakeImpl) *Options
+		assert  func(*fastforwardfakes.FakeImpl, error)
+	}{
+		{ // success notification after push
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.IsReleaseBranchReturns(true)
+				mock.RepoHasRemoteBranchReturns(true, nil)
+				mock.RepoHeadReturnsOnCall(0, "old", nil)
+				mock.RepoHeadReturnsOnCall(1, "new", nil)
+				return &Options{
+					Branch:           branch,
+					NonInteractive:   true,
+					NotifyWebhookURL: webhook,
+					CIRunURL:         "https://prow.example.com/run",
+				}
+			},
+			assert: func(mock *fastforwardfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+				require.Equal(t, 1, mock.NotifyCallCount())
+				url, msg := mock.NotifyArgsForCall(0)
+				require.Equal(t, webhook, url)
+				require.Contains(t, msg.Text, "succeeded")
+				require.Contains(t, msg.Text, "Old HEAD: old")
+				require.Contains(t, msg.Text, "New HEAD: new")
+				require.Contains(t, msg.Text, "https://prow.example.com/run")
+			},
+		},
+		{ // failure notification
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.IsReleaseBranchReturns(true)
+				mock.RepoHasRemoteBranchReturns(true, nil)
+				mock.RepoMergeReturns(errTest)
+				return &Options{
+					Branch:           branch,
+					NonInteractive:   true,
+					NotifyWebhookURL: webhook,
+				}
+			},
+			assert: func(mock *fastforwardfakes.FakeImpl, err error) {
+				require.NotNil(t, err)
+				require.Equal(t, 1, mock.NotifyCallCount())
+				_, msg := mock.NotifyArgsForCall(0)
+				require.Contains(t, msg.Text, "failed")
+				require.Contains(t, msg.Text, branch)
+			},
+		},
+		{ // no notification if nothing got pushed
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.RepoHasRemoteTagReturns(true, nil)
+				return &Options{NotifyWebhookURL: webhook}
+			},
+			assert: func(mock *fastforwardfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+				require.Zero(t, mock.NotifyCallCount())
+			},
+		},
+		{ // no notification without webhook
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.IsReleaseBranchReturns(true)
+				mock.RepoHasRemoteBranchReturns(true, nil)
+				return &Options{Branch: branch, NonInteractive: true}
+			},
+			assert: func(mock *fastforwardfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+				require.Zero(t, mock.NotifyCallCount())
+			},
+		},
+		{ // webhook forwarded to the GCB job on submit
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				return &Options{Submit: true, NotifyWebhookURL: webhook}
+			},
+			assert: func(mock *fastforwardfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+				require.Zero(t, mock.NotifyCallCount())
+				_, options := mock.SubmitArgsForCall(0)
+				require.Equal(t, webhook, options.NotifyWebhookURL)
+			},
+		},
+		{ // notification failure does not fail the run
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.IsReleaseBranchReturns(true)
+				mock.RepoHasRemoteBranchReturns(true, nil)
+				mock.NotifyReturns(errTest)
+				return &Options{
+					Branch:           branch,
+					NonInteractive:   true,
+					NotifyWebhookURL: webhook,
+				}
+			},
+			assert: func(mock *fastforwardfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+				require.Equal(t, 1, mock.NotifyCallCount())
+			},
+		},
+		{ // Prow job URL inferred from environment
+			prepare: func(mock *fastforwardfakes.FakeImpl) *Options {
+				mock.IsReleaseBranchReturns(true)
+				mock.RepoHasRemoteBranchReturns(true, nil)
+				mock.EnvDefaultCalls(func(key, def string) string {
+					switch key {
+					case "JOB_NAME":
+						return "ci-fast-forward"
+					case "BUILD_ID":
+						return "123"
+					}
+					return def
+				})
+				return &Options{
+					Branch:           branch,
+					NonInteractive:   true,
+					NotifyWebhookURL: webhook,
+				}
+			},
+			assert: func(mock *fastforwardfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+				_, msg := mock.NotifyArgsForCall(0)
+				require.Contains(t, msg.Text, "/logs/ci-fast-forward/123")
+			},
+		},
+	} {
+		mock := &fastforwardfakes.FakeImpl{}
+		options := tc.prepare(mock)
+
+		sut := New(options)
+		sut.impl = mock
+
+		err := sut.Run()
+		tc.assert(mock, err)
+	}
+}
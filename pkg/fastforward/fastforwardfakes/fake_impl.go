@@ -18,10 +18,13 @@ limitations under the License.
 package fastforwardfakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/google/go-github/v58/github"
 	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/ghperms"
+	"k8s.io/release/pkg/notify"
 	"sigs.k8s.io/release-sdk/git"
 )
 
@@ -54,6 +57,20 @@ type FakeImpl struct {
 	chdirReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CheckPermissionsStub        func(string, string, string, ...ghperms.Permission) error
+	checkPermissionsMutex       sync.RWMutex
+	checkPermissionsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 []ghperms.Permission
+	}
+	checkPermissionsReturns struct {
+		result1 error
+	}
+	checkPermissionsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CloneOrOpenDefaultGitHubRepoSSHStub        func(string) (*git.Repo, error)
 	cloneOrOpenDefaultGitHubRepoSSHMutex       sync.RWMutex
 	cloneOrOpenDefaultGitHubRepoSSHArgsForCall []struct {
@@ -83,6 +100,17 @@ type FakeImpl struct {
 		result1 *git.Repo
 		result2 error
 	}
+	ConfigureGitSSHStub        func(string) error
+	configureGitSSHMutex       sync.RWMutex
+	configureGitSSHArgsForCall []struct {
+		arg1 string
+	}
+	configureGitSSHReturns struct {
+		result1 error
+	}
+	configureGitSSHReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ConfigureGlobalDefaultUserAndEmailStub        func() error
 	configureGlobalDefaultUserAndEmailMutex       sync.RWMutex
 	configureGlobalDefaultUserAndEmailArgsForCall []struct {
@@ -116,6 +144,20 @@ type FakeImpl struct {
 	existsReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	GitStub        func(string, ...string) (string, error)
+	gitMutex       sync.RWMutex
+	gitArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	gitReturns struct {
+		result1 string
+		result2 error
+	}
+	gitReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	IsDefaultK8sUpstreamStub        func() bool
 	isDefaultK8sUpstreamMutex       sync.RWMutex
 	isDefaultK8sUpstreamArgsForCall []struct {
@@ -149,11 +191,10 @@ type FakeImpl struct {
 		result1 []*github.Issue
 		result2 error
 	}
-	MkdirTempStub        func(string, string) (string, error)
+	MkdirTempStub        func(string) (string, error)
 	mkdirTempMutex       sync.RWMutex
 	mkdirTempArgsForCall []struct {
 		arg1 string
-		arg2 string
 	}
 	mkdirTempReturns struct {
 		result1 string
@@ -163,6 +204,18 @@ type FakeImpl struct {
 		result1 string
 		result2 error
 	}
+	NotifyStub        func(string, *notify.Message) error
+	notifyMutex       sync.RWMutex
+	notifyArgsForCall []struct {
+		arg1 string
+		arg2 *notify.Message
+	}
+	notifyReturns struct {
+		result1 error
+	}
+	notifyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RemoveAllStub        func(string) error
 	removeAllMutex       sync.RWMutex
 	removeAllArgsForCall []struct {
@@ -290,6 +343,20 @@ type FakeImpl struct {
 		result1 string
 		result2 error
 	}
+	RepoLogStub        func(*git.Repo, ...string) (string, error)
+	repoLogMutex       sync.RWMutex
+	repoLogArgsForCall []struct {
+		arg1 *git.Repo
+		arg2 []string
+	}
+	repoLogReturns struct {
+		result1 string
+		result2 error
+	}
+	repoLogReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	RepoMergeStub        func(*git.Repo, string) error
 	repoMergeMutex       sync.RWMutex
 	repoMergeArgsForCall []struct {
@@ -347,10 +414,24 @@ type FakeImpl struct {
 	repoSetURLReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SubmitStub        func(*gcb.Options) error
+	RunInWorktreesStub        func(string, []string, func(branch string, dir string) error) error
+	runInWorktreesMutex       sync.RWMutex
+	runInWorktreesArgsForCall []struct {
+		arg1 string
+		arg2 []string
+		arg3 func(branch string, dir string) error
+	}
+	runInWorktreesReturns struct {
+		result1 error
+	}
+	runInWorktreesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SubmitStub        func(context.Context, *gcb.Options) error
 	submitMutex       sync.RWMutex
 	submitArgsForCall []struct {
-		arg1 *gcb.Options
+		arg1 context.Context
+		arg2 *gcb.Options
 	}
 	submitReturns struct {
 		result1 error
@@ -492,6 +573,70 @@ func (fake *FakeImpl) ChdirReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeImpl) CheckPermissions(arg1 string, arg2 string, arg3 string, arg4 ...ghperms.Permission) error {
+	fake.checkPermissionsMutex.Lock()
+	ret, specificReturn := fake.checkPermissionsReturnsOnCall[len(fake.checkPermissionsArgsForCall)]
+	fake.checkPermissionsArgsForCall = append(fake.checkPermissionsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 []ghperms.Permission
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CheckPermissionsStub
+	fakeReturns := fake.checkPermissionsReturns
+	fake.recordInvocation("CheckPermissions", []interface{}{arg1, arg2, arg3, arg4})
+	fake.checkPermissionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CheckPermissionsCallCount() int {
+	fake.checkPermissionsMutex.RLock()
+	defer fake.checkPermissionsMutex.RUnlock()
+	return len(fake.checkPermissionsArgsForCall)
+}
+
+func (fake *FakeImpl) CheckPermissionsCalls(stub func(string, string, string, ...ghperms.Permission) error) {
+	fake.checkPermissionsMutex.Lock()
+	defer fake.checkPermissionsMutex.Unlock()
+	fake.CheckPermissionsStub = stub
+}
+
+func (fake *FakeImpl) CheckPermissionsArgsForCall(i int) (string, string, string, []ghperms.Permission) {
+	fake.checkPermissionsMutex.RLock()
+	defer fake.checkPermissionsMutex.RUnlock()
+	argsForCall := fake.checkPermissionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) CheckPermissionsReturns(result1 error) {
+	fake.checkPermissionsMutex.Lock()
+	defer fake.checkPermissionsMutex.Unlock()
+	fake.CheckPermissionsStub = nil
+	fake.checkPermissionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CheckPermissionsReturnsOnCall(i int, result1 error) {
+	fake.checkPermissionsMutex.Lock()
+	defer fake.checkPermissionsMutex.Unlock()
+	fake.CheckPermissionsStub = nil
+	if fake.checkPermissionsReturnsOnCall == nil {
+		fake.checkPermissionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkPermissionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeImpl) CloneOrOpenDefaultGitHubRepoSSH(arg1 string) (*git.Repo, error) {
 	fake.cloneOrOpenDefaultGitHubRepoSSHMutex.Lock()
 	ret, specificReturn := fake.cloneOrOpenDefaultGitHubRepoSSHReturnsOnCall[len(fake.cloneOrOpenDefaultGitHubRepoSSHArgsForCall)]
@@ -623,6 +768,67 @@ func (fake *FakeImpl) CloneOrOpenGitHubRepoReturnsOnCall(i int, result1 *git.Rep
 	}{result1, result2}
 }
 
+func (fake *FakeImpl) ConfigureGitSSH(arg1 string) error {
+	fake.configureGitSSHMutex.Lock()
+	ret, specificReturn := fake.configureGitSSHReturnsOnCall[len(fake.configureGitSSHArgsForCall)]
+	fake.configureGitSSHArgsForCall = append(fake.configureGitSSHArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ConfigureGitSSHStub
+	fakeReturns := fake.configureGitSSHReturns
+	fake.recordInvocation("ConfigureGitSSH", []interface{}{arg1})
+	fake.configureGitSSHMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) ConfigureGitSSHCallCount() int {
+	fake.configureGitSSHMutex.RLock()
+	defer fake.configureGitSSHMutex.RUnlock()
+	return len(fake.configureGitSSHArgsForCall)
+}
+
+func (fake *FakeImpl) ConfigureGitSSHCalls(stub func(string) error) {
+	fake.configureGitSSHMutex.Lock()
+	defer fake.configureGitSSHMutex.Unlock()
+	fake.ConfigureGitSSHStub = stub
+}
+
+func (fake *FakeImpl) ConfigureGitSSHArgsForCall(i int) string {
+	fake.configureGitSSHMutex.RLock()
+	defer fake.configureGitSSHMutex.RUnlock()
+	argsForCall := fake.configureGitSSHArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ConfigureGitSSHReturns(result1 error) {
+	fake.configureGitSSHMutex.Lock()
+	defer fake.configureGitSSHMutex.Unlock()
+	fake.ConfigureGitSSHStub = nil
+	fake.configureGitSSHReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) ConfigureGitSSHReturnsOnCall(i int, result1 error) {
+	fake.configureGitSSHMutex.Lock()
+	defer fake.configureGitSSHMutex.Unlock()
+	fake.ConfigureGitSSHStub = nil
+	if fake.configureGitSSHReturnsOnCall == nil {
+		fake.configureGitSSHReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.configureGitSSHReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeImpl) ConfigureGlobalDefaultUserAndEmail() error {
 	fake.configureGlobalDefaultUserAndEmailMutex.Lock()
 	ret, specificReturn := fake.configureGlobalDefaultUserAndEmailReturnsOnCall[len(fake.configureGlobalDefaultUserAndEmailArgsForCall)]
@@ -799,6 +1005,71 @@ func (fake *FakeImpl) ExistsReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeImpl) Git(arg1 string, arg2 ...string) (string, error) {
+	fake.gitMutex.Lock()
+	ret, specificReturn := fake.gitReturnsOnCall[len(fake.gitArgsForCall)]
+	fake.gitArgsForCall = append(fake.gitArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2})
+	stub := fake.GitStub
+	fakeReturns := fake.gitReturns
+	fake.recordInvocation("Git", []interface{}{arg1, arg2})
+	fake.gitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GitCallCount() int {
+	fake.gitMutex.RLock()
+	defer fake.gitMutex.RUnlock()
+	return len(fake.gitArgsForCall)
+}
+
+func (fake *FakeImpl) GitCalls(stub func(string, ...string) (string, error)) {
+	fake.gitMutex.Lock()
+	defer fake.gitMutex.Unlock()
+	fake.GitStub = stub
+}
+
+func (fake *FakeImpl) GitArgsForCall(i int) (string, []string) {
+	fake.gitMutex.RLock()
+	defer fake.gitMutex.RUnlock()
+	argsForCall := fake.gitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) GitReturns(result1 string, result2 error) {
+	fake.gitMutex.Lock()
+	defer fake.gitMutex.Unlock()
+	fake.GitStub = nil
+	fake.gitReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GitReturnsOnCall(i int, result1 string, result2 error) {
+	fake.gitMutex.Lock()
+	defer fake.gitMutex.Unlock()
+	fake.GitStub = nil
+	if fake.gitReturnsOnCall == nil {
+		fake.gitReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.gitReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeImpl) IsDefaultK8sUpstream() bool {
 	fake.isDefaultK8sUpstreamMutex.Lock()
 	ret, specificReturn := fake.isDefaultK8sUpstreamReturnsOnCall[len(fake.isDefaultK8sUpstreamArgsForCall)]
@@ -969,19 +1240,18 @@ func (fake *FakeImpl) ListIssuesReturnsOnCall(i int, result1 []*github.Issue, re
 	}{result1, result2}
 }
 
-func (fake *FakeImpl) MkdirTemp(arg1 string, arg2 string) (string, error) {
+func (fake *FakeImpl) MkdirTemp(arg1 string) (string, error) {
 	fake.mkdirTempMutex.Lock()
 	ret, specificReturn := fake.mkdirTempReturnsOnCall[len(fake.mkdirTempArgsForCall)]
 	fake.mkdirTempArgsForCall = append(fake.mkdirTempArgsForCall, struct {
 		arg1 string
-		arg2 string
-	}{arg1, arg2})
+	}{arg1})
 	stub := fake.MkdirTempStub
 	fakeReturns := fake.mkdirTempReturns
-	fake.recordInvocation("MkdirTemp", []interface{}{arg1, arg2})
+	fake.recordInvocation("MkdirTemp", []interface{}{arg1})
 	fake.mkdirTempMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -995,17 +1265,17 @@ func (fake *FakeImpl) MkdirTempCallCount() int {
 	return len(fake.mkdirTempArgsForCall)
 }
 
-func (fake *FakeImpl) MkdirTempCalls(stub func(string, string) (string, error)) {
+func (fake *FakeImpl) MkdirTempCalls(stub func(string) (string, error)) {
 	fake.mkdirTempMutex.Lock()
 	defer fake.mkdirTempMutex.Unlock()
 	fake.MkdirTempStub = stub
 }
 
-func (fake *FakeImpl) MkdirTempArgsForCall(i int) (string, string) {
+func (fake *FakeImpl) MkdirTempArgsForCall(i int) string {
 	fake.mkdirTempMutex.RLock()
 	defer fake.mkdirTempMutex.RUnlock()
 	argsForCall := fake.mkdirTempArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1
 }
 
 func (fake *FakeImpl) MkdirTempReturns(result1 string, result2 error) {
@@ -1034,6 +1304,68 @@ func (fake *FakeImpl) MkdirTempReturnsOnCall(i int, result1 string, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeImpl) Notify(arg1 string, arg2 *notify.Message) error {
+	fake.notifyMutex.Lock()
+	ret, specificReturn := fake.notifyReturnsOnCall[len(fake.notifyArgsForCall)]
+	fake.notifyArgsForCall = append(fake.notifyArgsForCall, struct {
+		arg1 string
+		arg2 *notify.Message
+	}{arg1, arg2})
+	stub := fake.NotifyStub
+	fakeReturns := fake.notifyReturns
+	fake.recordInvocation("Notify", []interface{}{arg1, arg2})
+	fake.notifyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) NotifyCallCount() int {
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	return len(fake.notifyArgsForCall)
+}
+
+func (fake *FakeImpl) NotifyCalls(stub func(string, *notify.Message) error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = stub
+}
+
+func (fake *FakeImpl) NotifyArgsForCall(i int) (string, *notify.Message) {
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	argsForCall := fake.notifyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) NotifyReturns(result1 error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = nil
+	fake.notifyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) NotifyReturnsOnCall(i int, result1 error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = nil
+	if fake.notifyReturnsOnCall == nil {
+		fake.notifyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.notifyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeImpl) RemoveAll(arg1 string) error {
 	fake.removeAllMutex.Lock()
 	ret, specificReturn := fake.removeAllReturnsOnCall[len(fake.removeAllArgsForCall)]
@@ -1667,6 +1999,71 @@ func (fake *FakeImpl) RepoLatestReleaseBranchReturnsOnCall(i int, result1 string
 	}{result1, result2}
 }
 
+func (fake *FakeImpl) RepoLog(arg1 *git.Repo, arg2 ...string) (string, error) {
+	fake.repoLogMutex.Lock()
+	ret, specificReturn := fake.repoLogReturnsOnCall[len(fake.repoLogArgsForCall)]
+	fake.repoLogArgsForCall = append(fake.repoLogArgsForCall, struct {
+		arg1 *git.Repo
+		arg2 []string
+	}{arg1, arg2})
+	stub := fake.RepoLogStub
+	fakeReturns := fake.repoLogReturns
+	fake.recordInvocation("RepoLog", []interface{}{arg1, arg2})
+	fake.repoLogMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) RepoLogCallCount() int {
+	fake.repoLogMutex.RLock()
+	defer fake.repoLogMutex.RUnlock()
+	return len(fake.repoLogArgsForCall)
+}
+
+func (fake *FakeImpl) RepoLogCalls(stub func(*git.Repo, ...string) (string, error)) {
+	fake.repoLogMutex.Lock()
+	defer fake.repoLogMutex.Unlock()
+	fake.RepoLogStub = stub
+}
+
+func (fake *FakeImpl) RepoLogArgsForCall(i int) (*git.Repo, []string) {
+	fake.repoLogMutex.RLock()
+	defer fake.repoLogMutex.RUnlock()
+	argsForCall := fake.repoLogArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) RepoLogReturns(result1 string, result2 error) {
+	fake.repoLogMutex.Lock()
+	defer fake.repoLogMutex.Unlock()
+	fake.RepoLogStub = nil
+	fake.repoLogReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) RepoLogReturnsOnCall(i int, result1 string, result2 error) {
+	fake.repoLogMutex.Lock()
+	defer fake.repoLogMutex.Unlock()
+	fake.RepoLogStub = nil
+	if fake.repoLogReturnsOnCall == nil {
+		fake.repoLogReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.repoLogReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeImpl) RepoMerge(arg1 *git.Repo, arg2 string) error {
 	fake.repoMergeMutex.Lock()
 	ret, specificReturn := fake.repoMergeReturnsOnCall[len(fake.repoMergeArgsForCall)]
@@ -1952,18 +2349,87 @@ func (fake *FakeImpl) RepoSetURLReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeImpl) Submit(arg1 *gcb.Options) error {
+func (fake *FakeImpl) RunInWorktrees(arg1 string, arg2 []string, arg3 func(branch string, dir string) error) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.runInWorktreesMutex.Lock()
+	ret, specificReturn := fake.runInWorktreesReturnsOnCall[len(fake.runInWorktreesArgsForCall)]
+	fake.runInWorktreesArgsForCall = append(fake.runInWorktreesArgsForCall, struct {
+		arg1 string
+		arg2 []string
+		arg3 func(branch string, dir string) error
+	}{arg1, arg2Copy, arg3})
+	stub := fake.RunInWorktreesStub
+	fakeReturns := fake.runInWorktreesReturns
+	fake.recordInvocation("RunInWorktrees", []interface{}{arg1, arg2Copy, arg3})
+	fake.runInWorktreesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) RunInWorktreesCallCount() int {
+	fake.runInWorktreesMutex.RLock()
+	defer fake.runInWorktreesMutex.RUnlock()
+	return len(fake.runInWorktreesArgsForCall)
+}
+
+func (fake *FakeImpl) RunInWorktreesCalls(stub func(string, []string, func(branch string, dir string) error) error) {
+	fake.runInWorktreesMutex.Lock()
+	defer fake.runInWorktreesMutex.Unlock()
+	fake.RunInWorktreesStub = stub
+}
+
+func (fake *FakeImpl) RunInWorktreesArgsForCall(i int) (string, []string, func(branch string, dir string) error) {
+	fake.runInWorktreesMutex.RLock()
+	defer fake.runInWorktreesMutex.RUnlock()
+	argsForCall := fake.runInWorktreesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) RunInWorktreesReturns(result1 error) {
+	fake.runInWorktreesMutex.Lock()
+	defer fake.runInWorktreesMutex.Unlock()
+	fake.RunInWorktreesStub = nil
+	fake.runInWorktreesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) RunInWorktreesReturnsOnCall(i int, result1 error) {
+	fake.runInWorktreesMutex.Lock()
+	defer fake.runInWorktreesMutex.Unlock()
+	fake.RunInWorktreesStub = nil
+	if fake.runInWorktreesReturnsOnCall == nil {
+		fake.runInWorktreesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.runInWorktreesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Submit(arg1 context.Context, arg2 *gcb.Options) error {
 	fake.submitMutex.Lock()
 	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
 	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
-		arg1 *gcb.Options
-	}{arg1})
+		arg1 context.Context
+		arg2 *gcb.Options
+	}{arg1, arg2})
 	stub := fake.SubmitStub
 	fakeReturns := fake.submitReturns
-	fake.recordInvocation("Submit", []interface{}{arg1})
+	fake.recordInvocation("Submit", []interface{}{arg1, arg2})
 	fake.submitMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1977,17 +2443,17 @@ func (fake *FakeImpl) SubmitCallCount() int {
 	return len(fake.submitArgsForCall)
 }
 
-func (fake *FakeImpl) SubmitCalls(stub func(*gcb.Options) error) {
+func (fake *FakeImpl) SubmitCalls(stub func(context.Context, *gcb.Options) error) {
 	fake.submitMutex.Lock()
 	defer fake.submitMutex.Unlock()
 	fake.SubmitStub = stub
 }
 
-func (fake *FakeImpl) SubmitArgsForCall(i int) *gcb.Options {
+func (fake *FakeImpl) SubmitArgsForCall(i int) (context.Context, *gcb.Options) {
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	argsForCall := fake.submitArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeImpl) SubmitReturns(result1 error) {
@@ -2020,16 +2486,22 @@ func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	defer fake.askMutex.RUnlock()
 	fake.chdirMutex.RLock()
 	defer fake.chdirMutex.RUnlock()
+	fake.checkPermissionsMutex.RLock()
+	defer fake.checkPermissionsMutex.RUnlock()
 	fake.cloneOrOpenDefaultGitHubRepoSSHMutex.RLock()
 	defer fake.cloneOrOpenDefaultGitHubRepoSSHMutex.RUnlock()
 	fake.cloneOrOpenGitHubRepoMutex.RLock()
 	defer fake.cloneOrOpenGitHubRepoMutex.RUnlock()
+	fake.configureGitSSHMutex.RLock()
+	defer fake.configureGitSSHMutex.RUnlock()
 	fake.configureGlobalDefaultUserAndEmailMutex.RLock()
 	defer fake.configureGlobalDefaultUserAndEmailMutex.RUnlock()
 	fake.envDefaultMutex.RLock()
 	defer fake.envDefaultMutex.RUnlock()
 	fake.existsMutex.RLock()
 	defer fake.existsMutex.RUnlock()
+	fake.gitMutex.RLock()
+	defer fake.gitMutex.RUnlock()
 	fake.isDefaultK8sUpstreamMutex.RLock()
 	defer fake.isDefaultK8sUpstreamMutex.RUnlock()
 	fake.isReleaseBranchMutex.RLock()
@@ -2038,6 +2510,8 @@ func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	defer fake.listIssuesMutex.RUnlock()
 	fake.mkdirTempMutex.RLock()
 	defer fake.mkdirTempMutex.RUnlock()
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
 	fake.removeAllMutex.RLock()
 	defer fake.removeAllMutex.RUnlock()
 	fake.repoCheckoutMutex.RLock()
@@ -2058,6 +2532,8 @@ func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	defer fake.repoHeadMutex.RUnlock()
 	fake.repoLatestReleaseBranchMutex.RLock()
 	defer fake.repoLatestReleaseBranchMutex.RUnlock()
+	fake.repoLogMutex.RLock()
+	defer fake.repoLogMutex.RUnlock()
 	fake.repoMergeMutex.RLock()
 	defer fake.repoMergeMutex.RUnlock()
 	fake.repoMergeBaseMutex.RLock()
@@ -2068,6 +2544,8 @@ func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	defer fake.repoSetDryMutex.RUnlock()
 	fake.repoSetURLMutex.RLock()
 	defer fake.repoSetURLMutex.RUnlock()
+	fake.runInWorktreesMutex.RLock()
+	defer fake.runInWorktreesMutex.RUnlock()
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -17,14 +17,21 @@ limitations under the License.
 package fastforward
 
 import (
+	"context"
 	"os"
 
 	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/ghperms"
+	"k8s.io/release/pkg/notify"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/workdir"
+	"k8s.io/release/pkg/worktree"
 
 	gogithub "github.com/google/go-github/v58/github"
+	"github.com/sirupsen/logrus"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/command"
 	"sigs.k8s.io/release-utils/env"
 	"sigs.k8s.io/release-utils/util"
 )
@@ -52,17 +59,23 @@ type impl interface {
 	RepoPush(*git.Repo, string) error
 	RepoLatestReleaseBranch(*git.Repo) (string, error)
 	RepoHasRemoteTag(*git.Repo, string) (bool, error)
-	Submit(*gcb.Options) error
+	Submit(context.Context, *gcb.Options) error
 	EnvDefault(string, string) string
 	CloneOrOpenGitHubRepo(string, string, string, bool) (*git.Repo, error)
 	IsDefaultK8sUpstream() bool
 	RepoSetURL(*git.Repo, string, string) error
+	ConfigureGitSSH(string) error
+	RunInWorktrees(string, []string, func(branch, dir string) error) error
+	Git(string, ...string) (string, error)
 	Chdir(string) error
 	RemoveAll(string) error
-	MkdirTemp(string, string) (string, error)
+	MkdirTemp(string) (string, error)
 	Exists(string) bool
 	ConfigureGlobalDefaultUserAndEmail() error
 	ListIssues() ([]*gogithub.Issue, error)
+	Notify(string, *notify.Message) error
+	RepoLog(*git.Repo, ...string) (string, error)
+	CheckPermissions(string, string, string, ...ghperms.Permission) error
 }
 
 func (*defaultImpl) CloneOrOpenDefaultGitHubRepoSSH(repo string) (*git.Repo, error) {
@@ -129,8 +142,10 @@ func (*defaultImpl) RepoHasRemoteTag(r *git.Repo, tag string) (bool, error) {
 	return r.HasRemoteTag(tag)
 }
 
-func (*defaultImpl) Submit(options *gcb.Options) error {
-	return gcb.New(options).Submit()
+func (*defaultImpl) Submit(ctx context.Context, options *gcb.Options) error {
+	g := gcb.New(options)
+	g.SetContext(ctx)
+	return g.Submit()
 }
 
 func (*defaultImpl) EnvDefault(key, def string) string {
@@ -149,6 +164,34 @@ func (*defaultImpl) RepoSetURL(r *git.Repo, remote, newURL string) error {
 	return r.SetURL(remote, newURL)
 }
 
+func (*defaultImpl) ConfigureGitSSH(key string) error {
+	return release.ConfigureGitSSH(key)
+}
+
+func (*defaultImpl) RunInWorktrees(
+	repoDir string, branches []string, fn func(branch, dir string) error,
+) error {
+	manager := worktree.New(repoDir)
+	defer func() {
+		if err := manager.Cleanup(); err != nil {
+			logrus.Warnf("Unable to clean up worktrees: %v", err)
+		}
+	}()
+	return manager.Run(branches, git.Remotify, worktree.DefaultWorkers,
+		func(wt *worktree.Worktree) error {
+			return fn(wt.Branch, wt.Dir)
+		},
+	)
+}
+
+func (*defaultImpl) Git(dir string, args ...string) (string, error) {
+	res, err := command.NewWithWorkDir(dir, "git", args...).RunSilentSuccessOutput()
+	if err != nil {
+		return "", err
+	}
+	return res.OutputTrimNL(), nil
+}
+
 func (*defaultImpl) Chdir(dir string) error {
 	return os.Chdir(dir)
 }
@@ -157,8 +200,8 @@ func (*defaultImpl) RemoveAll(path string) error {
 	return os.RemoveAll(path)
 }
 
-func (*defaultImpl) MkdirTemp(dir, pattern string) (string, error) {
-	return os.MkdirTemp(dir, pattern)
+func (*defaultImpl) MkdirTemp(pattern string) (string, error) {
+	return workdir.MkdirTemp(pattern)
 }
 
 func (*defaultImpl) Exists(path string) bool {
@@ -174,3 +217,21 @@ func (*defaultImpl) ListIssues() ([]*gogithub.Issue, error) {
 		git.DefaultGithubOrg, git.DefaultGithubReleaseRepo, github.IssueStateOpen,
 	)
 }
+
+func (*defaultImpl) Notify(url string, msg *notify.Message) error {
+	return notify.New().Send(url, msg)
+}
+
+func (*defaultImpl) RepoLog(r *git.Repo, args ...string) (string, error) {
+	res, err := command.NewWithWorkDir(
+		r.Dir(), "git", append([]string{"log"}, args...)...,
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return "", err
+	}
+	return res.OutputTrimNL(), nil
+}
+
+func (*defaultImpl) CheckPermissions(operation, owner, repo string, perms ...ghperms.Permission) error {
+	return ghperms.Check(operation, owner, repo, perms...)
+}
@@ -0,0 +1,369 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package freeze enforces the code and test freezes of the release schedule
+// for mutating operations, like merging cherry picks or fast forwarding a
+// release branch. Operations during a freeze are refused unless they get
+// confirmed by an override token, which prevents accidental out-of-window
+// pushes.
+package freeze
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+
+	"sigs.k8s.io/release-utils/env"
+	khttp "sigs.k8s.io/release-utils/http"
+
+	"k8s.io/release/pkg/audit"
+)
+
+const (
+	// ScheduleEnvKey is the environment variable containing the default path
+	// to the release schedule.
+	ScheduleEnvKey = "KREL_FREEZE_SCHEDULE"
+
+	// OverrideEnvKey is the environment variable containing the default
+	// override token.
+	OverrideEnvKey = "KREL_FREEZE_OVERRIDE"
+)
+
+// Kind is the type of a freeze.
+type Kind string
+
+const (
+	// KindCodeFreeze lasts from the begin of the code freeze until the thaw.
+	KindCodeFreeze Kind = "code freeze"
+
+	// KindTestFreeze lasts from the begin of the test freeze until the thaw.
+	KindTestFreeze Kind = "test freeze"
+)
+
+var (
+	// codeFreezeRE matches the timeline entry beginning the code freeze.
+	codeFreezeRE = regexp.MustCompile(`(?i)^(begin\s+)?code\s+freeze\b`)
+
+	// testFreezeRE matches the timeline entry beginning the test freeze.
+	testFreezeRE = regexp.MustCompile(`(?i)^(begin\s+)?test\s+freeze\b`)
+
+	// thawRE matches the timeline entry ending all freezes.
+	thawRE = regexp.MustCompile(`(?i)^(code\s+)?thaw\b|^lift\s+code\s+freeze\b`)
+
+	// dateLayouts are the supported formats of the timeline dates.
+	dateLayouts = []string{
+		"Mon January 2, 2006",
+		"Monday January 2, 2006",
+		"Mon, January 2, 2006",
+		"January 2, 2006",
+		time.DateOnly,
+	}
+)
+
+// Window is a freeze of a release.
+type Window struct {
+	// Kind is the type of the freeze.
+	Kind Kind
+
+	// Release is the minor version of the release, for example 1.30.
+	Release string
+
+	// Start is the first day of the freeze.
+	Start time.Time
+
+	// End is the day of the thaw, which is not frozen any more. The freeze
+	// lasts until further notice if it is zero.
+	End time.Time
+}
+
+// Active returns true if the window contains the provided time.
+func (w *Window) Active(t time.Time) bool {
+	return !t.Before(w.Start) && (w.End.IsZero() || t.Before(w.End))
+}
+
+func (w *Window) String() string {
+	end := "further notice"
+	if !w.End.IsZero() {
+		end = w.End.Format(time.DateOnly)
+	}
+	return fmt.Sprintf("%s of %s from %s until %s", w.Kind, w.Release, w.Start.Format(time.DateOnly), end)
+}
+
+// schedule is the release schedule as maintained in kubernetes/sig-release
+// and consumed by the schedule-builder. It is decoded by yaml.v2 to keep
+// versions like 1.30 as they are.
+type schedule struct {
+	Releases []struct {
+		Version  string `yaml:"version"`
+		Timeline []struct {
+			What string `yaml:"what"`
+			When string `yaml:"when"`
+		} `yaml:"timeline"`
+	} `yaml:"releases"`
+}
+
+// Parse returns the freeze windows of the provided release schedule.
+// Timeline entries without a valid date, like TBD ones, are ignored.
+func Parse(content []byte) ([]Window, error) {
+	s := &schedule{}
+	if err := yaml.Unmarshal(content, s); err != nil {
+		return nil, fmt.Errorf("parse schedule: %w", err)
+	}
+
+	windows := []Window{}
+	for _, release := range s.Releases {
+		var thaw time.Time
+		starts := map[Kind]time.Time{}
+		for _, entry := range release.Timeline {
+			what := strings.TrimSpace(entry.What)
+			date, ok := parseDate(entry.When)
+			if !ok {
+				continue
+			}
+			switch {
+			case codeFreezeRE.MatchString(what):
+				starts[KindCodeFreeze] = date
+			case testFreezeRE.MatchString(what):
+				starts[KindTestFreeze] = date
+			case thawRE.MatchString(what):
+				thaw = date
+			}
+		}
+
+		for _, kind := range []Kind{KindCodeFreeze, KindTestFreeze} {
+			start, ok := starts[kind]
+			if !ok {
+				continue
+			}
+			if !thaw.IsZero() && !thaw.After(start) {
+				return nil, fmt.Errorf(
+					"%s of %s starts on %s, which is not before the thaw",
+					kind, release.Version, start.Format(time.DateOnly),
+				)
+			}
+			windows = append(windows, Window{
+				Kind:    kind,
+				Release: strings.TrimPrefix(release.Version, "v"),
+				Start:   start,
+				End:     thaw,
+			})
+		}
+	}
+	return windows, nil
+}
+
+// parseDate parses a timeline date as UTC midnight.
+func parseDate(when string) (time.Time, bool) {
+	when = strings.TrimSpace(when)
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, when); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Load reads and parses the release schedule from the provided path or
+// HTTP(S) URL.
+func Load(location string) ([]Window, error) {
+	var (
+		content []byte
+		err     error
+	)
+	if strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://") {
+		content, err = khttp.NewAgent().Get(location)
+	} else {
+		content, err = os.ReadFile(location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read schedule: %w", err)
+	}
+	return Parse(content)
+}
+
+// Options are the freeze settings.
+type Options struct {
+	// Schedule is the path or URL of the release schedule. The freezes are
+	// not enforced if it is empty. Only URLs can be enforced by submitted
+	// Google Cloud Build jobs.
+	Schedule string
+
+	// Override is the token confirming an operation during a freeze.
+	Override string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Schedule: env.Default(ScheduleEnvKey, ""),
+		Override: env.Default(OverrideEnvKey, ""),
+	}
+}
+
+// AddFlags adds the freeze flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Schedule,
+		"freeze-schedule",
+		o.Schedule,
+		fmt.Sprintf("path or URL of the release schedule YAML whose code and test freezes are enforced for mutating operations (default $%s)", ScheduleEnvKey),
+	)
+
+	flags.StringVar(
+		&o.Override,
+		"freeze-override",
+		o.Override,
+		fmt.Sprintf("token printed by a refused operation to run it during a freeze anyways (default $%s)", OverrideEnvKey),
+	)
+}
+
+// Guard refuses operations during the freeze windows.
+type Guard struct {
+	windows  []Window
+	override string
+}
+
+// NewGuard creates a new Guard for the provided windows and override token.
+func NewGuard(windows []Window, override string) *Guard {
+	return &Guard{windows: windows, override: override}
+}
+
+// Check returns an error if the operation on the provided branch violates
+// an active freeze and is not confirmed by the override token. Release
+// branches are only affected by the test freeze of their release, because
+// they follow the frozen main branch during the code freeze. All other
+// branches are affected by every freeze.
+func (g *Guard) Check(operation, branch string) error {
+	return g.CheckAt(operation, branch, time.Now())
+}
+
+// CheckAt works like Check for the provided time.
+func (g *Guard) CheckAt(operation, branch string, now time.Time) error {
+	freezes := g.freezesAt(branch, now)
+	if len(freezes) == 0 {
+		return nil
+	}
+
+	token := token(operation, branch, freezes)
+	if g.override != token {
+		return fmt.Errorf(
+			"refusing %s of %s during the %s, use the override token %s to proceed anyways",
+			operation, branch, strings.Join(freezes, " and the "), token,
+		)
+	}
+
+	logrus.Warnf("Overriding the %s for %s of %s", strings.Join(freezes, " and the "), operation, branch)
+	audit.Record(audit.ActionFreezeOverride, branch, map[string]string{
+		"operation": operation,
+		"freezes":   strings.Join(freezes, ", "),
+	})
+	return nil
+}
+
+// TokenAt returns the override token for the operation on the provided
+// branch at the provided time, or an empty string if no freeze is active.
+func (g *Guard) TokenAt(operation, branch string, now time.Time) string {
+	freezes := g.freezesAt(branch, now)
+	if len(freezes) == 0 {
+		return ""
+	}
+	return token(operation, branch, freezes)
+}
+
+// freezesAt describes the windows affecting the branch at the provided
+// time.
+func (g *Guard) freezesAt(branch string, now time.Time) []string {
+	release, isReleaseBranch := strings.CutPrefix(branch, "release-")
+	freezes := []string{}
+	for i := range g.windows {
+		w := &g.windows[i]
+		if !w.Active(now) {
+			continue
+		}
+		if isReleaseBranch && (w.Release != release || w.Kind == KindCodeFreeze) {
+			continue
+		}
+		freezes = append(freezes, w.String())
+	}
+	return freezes
+}
+
+// token derives the override token from the operation and the freezes, so
+// that it confirms exactly one kind of violation.
+func token(operation, branch string, freezes []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(
+		append([]string{operation, branch}, freezes...), "\n",
+	)))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+var (
+	mu            sync.RWMutex
+	active        *Guard
+	activeOptions *Options
+)
+
+// Setup enables the package level guard if a schedule is configured.
+func Setup(opts *Options) error {
+	var guard *Guard
+	if opts.Schedule != "" {
+		windows, err := Load(opts.Schedule)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("Enforcing %d freezes of release schedule %s", len(windows), opts.Schedule)
+		guard = NewGuard(windows, opts.Override)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	active = guard
+	activeOptions = nil
+	if guard != nil {
+		activeOptions = opts
+	}
+	return nil
+}
+
+// ActiveOptions returns the options of the package level guard, or nil if
+// no schedule is configured.
+func ActiveOptions() *Options {
+	mu.RLock()
+	defer mu.RUnlock()
+	return activeOptions
+}
+
+// Check verifies the operation using the package level guard. It does
+// nothing if no schedule is configured.
+func Check(operation, branch string) error {
+	mu.RLock()
+	guard := active
+	mu.RUnlock()
+
+	if guard == nil {
+		return nil
+	}
+	return guard.Check(operation, branch)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freeze_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/freeze"
+)
+
+const testSchedule = `releases:
+- version: 1.30
+  timeline:
+    - what: Start of Release Cycle
+      who: Lead
+      when: Mon January 8, 2024
+    - what: 1.30.0-alpha.2 released
+      when: TBD
+    - what: Brace Yourself, Code Freeze is Coming
+      when: Mon February 26, 2024
+    - what: Begin Code Freeze (02:00 UTC Wednesday / 18:00 PST Tuesday)
+      when: Tue March 5, 2024
+    - what: Test Freeze (02:00 UTC Wednesday / 18:00 PST Tuesday)
+      when: Tue March 26, 2024
+    - what: Thaw
+      when: Wed April 17, 2024
+- version: 1.31
+  timeline:
+    - what: Begin Code Freeze
+      when: Tue July 23, 2024
+`
+
+func date(t *testing.T, s string) time.Time {
+	d, err := time.Parse(time.DateOnly, s)
+	require.NoError(t, err)
+	return d
+}
+
+func TestParse(t *testing.T) {
+	windows, err := freeze.Parse([]byte(testSchedule))
+	require.NoError(t, err)
+	require.Equal(t, []freeze.Window{
+		{Kind: freeze.KindCodeFreeze, Release: "1.30", Start: date(t, "2024-03-05"), End: date(t, "2024-04-17")},
+		{Kind: freeze.KindTestFreeze, Release: "1.30", Start: date(t, "2024-03-26"), End: date(t, "2024-04-17")},
+		{Kind: freeze.KindCodeFreeze, Release: "1.31", Start: date(t, "2024-07-23")},
+	}, windows)
+
+	_, err = freeze.Parse([]byte(`releases:
+- version: 1.30
+  timeline:
+    - what: Thaw
+      when: Tue March 5, 2024
+    - what: Begin Code Freeze
+      when: Tue March 5, 2024
+`))
+	require.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	windows, err := freeze.Parse([]byte(testSchedule))
+	require.NoError(t, err)
+	guard := freeze.NewGuard(windows, "")
+	token := guard.TokenAt("merge cherry picks", "release-1.30", date(t, "2024-03-27"))
+	require.Len(t, token, 12)
+	require.Empty(t, guard.TokenAt("merge cherry picks", "release-1.30", date(t, "2024-03-10")))
+	require.Empty(t, guard.TokenAt("merge cherry picks", "release-1.30", date(t, "2024-05-01")))
+
+	for _, tc := range []struct {
+		name        string
+		operation   string
+		branch      string
+		override    string
+		now         string
+		shouldError bool
+	}{
+		{name: "before freeze", branch: "release-1.30", now: "2024-03-04"},
+		{name: "code freeze of the release branch", branch: "release-1.30", now: "2024-03-05"},
+		{name: "test freeze of the release branch", branch: "release-1.30", now: "2024-03-26", shouldError: true},
+		{name: "thaw", branch: "release-1.30", now: "2024-04-17"},
+		{name: "other release branch", branch: "release-1.29", now: "2024-03-27"},
+		{name: "main branch", branch: "master", now: "2024-03-10", shouldError: true},
+		{name: "open ended freeze", branch: "master", now: "2025-01-01", shouldError: true},
+		{name: "open ended code freeze of the release branch", branch: "release-1.31", now: "2025-01-01"},
+		{name: "override", branch: "release-1.30", override: token, now: "2024-03-27"},
+		{
+			name:        "override of a different operation",
+			operation:   "fast forward",
+			branch:      "release-1.30",
+			override:    token,
+			now:         "2024-03-27",
+			shouldError: true,
+		},
+		{
+			// The token does not cover the freezes of the main branch
+			name:        "override of a different branch",
+			branch:      "master",
+			override:    token,
+			now:         "2024-03-27",
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := tc.operation
+			if operation == "" {
+				operation = "merge cherry picks"
+			}
+			err := freeze.NewGuard(windows, tc.override).CheckAt(operation, tc.branch, date(t, tc.now))
+			if tc.shouldError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"google.golang.org/api/option"
 
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/workdir"
 	"sigs.k8s.io/release-sdk/gcli"
 	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-utils/command"
@@ -64,14 +65,27 @@ type Options struct {
 	DiskSize       string
 	Variant        string
 	EnvPassthrough string
+
+	// Local runs the job steps by using a local container runtime instead
+	// of submitting them to Google Cloud Build.
+	Local bool
+
+	// ContainerRuntime is the container runtime executable for local jobs,
+	// for example docker or podman.
+	ContainerRuntime string
+
+	// LocalWorkspace is the directory mounted as workspace into the steps
+	// of local jobs. A temporary directory is used if empty.
+	LocalWorkspace string
 }
 
 // NewDefaultOptions returns a new default `*Options` instance.
 func NewDefaultOptions() *Options {
 	return &Options{
-		objStore:       object.NewGCS(),
-		Project:        release.DefaultKubernetesStagingProject,
-		CloudbuildFile: DefaultCloudbuildFile,
+		objStore:         object.NewGCS(),
+		Project:          release.DefaultKubernetesStagingProject,
+		CloudbuildFile:   DefaultCloudbuildFile,
+		ContainerRuntime: DefaultContainerRuntime,
 	}
 }
 
@@ -141,7 +155,7 @@ func (o *Options) ValidateConfigDir() error {
 }
 
 func (o *Options) uploadBuildDir(targetBucket string) (string, error) {
-	f, err := os.CreateTemp("", "")
+	f, err := workdir.CreateTemp("build-")
 	if err != nil {
 		return "", fmt.Errorf("failed to create temp file: %w", err)
 	}
@@ -180,6 +194,20 @@ func getExtraSubs(o *Options) map[string]string {
 }
 
 func RunSingleJob(o *Options, jobName, uploaded, version string, subs map[string]string) error {
+	return RunSingleJobContext(context.Background(), o, jobName, uploaded, version, subs)
+}
+
+// RunSingleJobContext is like RunSingleJob but stops watching the job if the
+// provided context gets cancelled. The job itself keeps running in Google
+// Cloud Build and can be watched again by using gcloud.
+// Local jobs are run by RunLocalJobContext instead.
+func RunSingleJobContext(
+	ctx context.Context, o *Options, jobName, uploaded, version string, subs map[string]string,
+) error {
+	if o.Local {
+		return RunLocalJobContext(ctx, o, version, subs)
+	}
+
 	s := make([]string, 0, len(subs)+1)
 	for k, v := range subs {
 		s = append(s, fmt.Sprintf("_%s=%s", k, v))
@@ -251,8 +279,20 @@ func RunSingleJob(o *Options, jobName, uploaded, version string, subs map[string
 	}
 
 	logrus.Infof("cloudbuild command to send to gcp: %s", cmd.String())
-	if err := cmd.RunSuccess(); err != nil {
-		return fmt.Errorf("error running %s: %w", cmd.String(), err)
+	errCh := make(chan error, 1)
+	go func() { errCh <- cmd.RunSuccess() }()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return fmt.Errorf("error running %s: %w", cmd.String(), err)
+		}
+	case <-ctx.Done():
+		logrus.Warn(
+			"Stopped watching the job, it may still run in Google Cloud Build " +
+				`and can be found via "gcloud builds list --ongoing"`,
+		)
+		return fmt.Errorf("watching job: %w", ctx.Err())
 	}
 
 	return nil
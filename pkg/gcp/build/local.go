@@ -0,0 +1,287 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-utils/command"
+	"sigs.k8s.io/release-utils/util"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/release/pkg/workdir"
+)
+
+const (
+	// DefaultContainerRuntime is the container runtime used for running
+	// jobs locally.
+	DefaultContainerRuntime = "docker"
+
+	// localWorkspaceDir is the directory the workspace gets mounted to, like
+	// in Google Cloud Build.
+	localWorkspaceDir = "/workspace"
+
+	// dockerSocket is the socket of the local container runtime, which gets
+	// mounted into the steps for building and pushing images like in Google
+	// Cloud Build.
+	dockerSocket = "/var/run/docker.sock"
+
+	// localGCloudConfigDir is the directory the local gcloud configuration
+	// gets mounted to, which provides the credentials for gcloud and gsutil.
+	localGCloudConfigDir = "/builder/home/.config/gcloud"
+)
+
+// cloudbuildConfig is the subset of the cloudbuild.yaml fields which are
+// required for running a job locally.
+type cloudbuildConfig struct {
+	Steps         []cloudbuildStep  `json:"steps"`
+	Substitutions map[string]string `json:"substitutions"`
+	Options       struct {
+		Env []string `json:"env"`
+	} `json:"options"`
+}
+
+type cloudbuildStep struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Entrypoint string   `json:"entrypoint"`
+	Dir        string   `json:"dir"`
+	Args       []string `json:"args"`
+	Env        []string `json:"env"`
+	SecretEnv  []string `json:"secretEnv"`
+}
+
+// LocalJob is a cloudbuild job which runs its steps as containers of a local
+// container runtime like Docker or Podman.
+type LocalJob struct {
+	// Runtime is the container runtime executable.
+	Runtime string
+
+	// Workspace is the local directory mounted as /workspace into every
+	// step.
+	Workspace string
+
+	// Steps are the container runtime arguments of every step.
+	Steps [][]string
+
+	// Containers are the names of the containers of every step.
+	Containers []string
+}
+
+// NewLocalJob parses the cloudbuild file of the options and returns the job
+// using the same images and substitutions as Google Cloud Build. Secrets
+// cannot be decrypted locally, which means that the secret environment
+// variables of the steps are passed through from the local environment. The
+// steps get the socket of the local container runtime and the gcloud
+// configuration of the local user mounted, if they exist.
+func NewLocalJob(
+	o *Options, workspace, version string, subs map[string]string,
+) (*LocalJob, error) {
+	content, err := os.ReadFile(o.CloudbuildFile)
+	if err != nil {
+		return nil, fmt.Errorf("read cloudbuild file: %w", err)
+	}
+	config := &cloudbuildConfig{}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("parse cloudbuild file: %w", err)
+	}
+	if len(config.Steps) == 0 {
+		return nil, fmt.Errorf("no steps found in %s", o.CloudbuildFile)
+	}
+
+	runtime := o.ContainerRuntime
+	if runtime == "" {
+		runtime = DefaultContainerRuntime
+	}
+
+	substitutions := map[string]string{
+		"BUILD_ID":   "local-" + uuid.New().String(),
+		"PROJECT_ID": o.Project,
+	}
+	for k, v := range config.Substitutions {
+		substitutions[k] = v
+	}
+	for k, v := range subs {
+		substitutions["_"+k] = v
+	}
+	substitutions["_GIT_TAG"] = version
+
+	expand := func(s string) string {
+		return os.Expand(s, func(key string) string {
+			if key == "$" {
+				return "$"
+			}
+			if v, ok := substitutions[key]; ok {
+				return v
+			}
+			// Variables which are no substitution belong to the shell
+			return "${" + key + "}"
+		})
+	}
+
+	mounts := []string{"--volume", workspace + ":" + localWorkspaceDir}
+	if util.Exists(dockerSocket) {
+		mounts = append(mounts, "--volume", dockerSocket+":"+dockerSocket)
+	} else {
+		logrus.Warnf("No container runtime socket found at %s, steps building images will fail", dockerSocket)
+	}
+	if gcloudConfig := hostGCloudConfig(); util.Exists(gcloudConfig) {
+		mounts = append(mounts,
+			"--volume", gcloudConfig+":"+localGCloudConfigDir,
+			"--env", "CLOUDSDK_CONFIG="+localGCloudConfigDir,
+		)
+	} else {
+		logrus.Warnf("No gcloud configuration found at %s, steps accessing GCP will fail", gcloudConfig)
+	}
+
+	job := &LocalJob{Runtime: runtime, Workspace: workspace}
+	for i, step := range config.Steps {
+		if step.Name == "" {
+			return nil, fmt.Errorf("step %d has no image", i)
+		}
+
+		dir := localWorkspaceDir
+		if step.Dir != "" {
+			dir = expand(step.Dir)
+			if !path.IsAbs(dir) {
+				dir = path.Join(localWorkspaceDir, dir)
+			}
+		}
+
+		container := fmt.Sprintf("%s-step-%d", substitutions["BUILD_ID"], i+1)
+		args := []string{"run", "--rm", "--name", container}
+		args = append(args, mounts...)
+		args = append(args, "--workdir", dir)
+		for _, env := range append(append([]string{}, config.Options.Env...), step.Env...) {
+			args = append(args, "--env", expand(env))
+		}
+		for _, env := range step.SecretEnv {
+			if _, ok := os.LookupEnv(env); !ok {
+				logrus.Warnf("Secret %s of step %d is not set in the local environment", env, i)
+			}
+			// Passing only the name takes the value from the local
+			// environment without printing it.
+			args = append(args, "--env", env)
+		}
+		if step.Entrypoint != "" {
+			args = append(args, "--entrypoint", expand(step.Entrypoint))
+		}
+		args = append(args, expand(step.Name))
+		for _, arg := range step.Args {
+			args = append(args, expand(arg))
+		}
+
+		job.Steps = append(job.Steps, args)
+		job.Containers = append(job.Containers, container)
+	}
+
+	return job, nil
+}
+
+// hostGCloudConfig returns the gcloud configuration directory of the local
+// user.
+func hostGCloudConfig() string {
+	if dir := os.Getenv("CLOUDSDK_CONFIG"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gcloud")
+}
+
+// Run executes all steps of the job in order and stops at the first failing
+// one, or if the provided context gets cancelled, which kills the container
+// of the running step.
+func (j *LocalJob) Run(ctx context.Context) error {
+	for i, args := range j.Steps {
+		logrus.Infof("Running local step %d/%d: %s", i+1, len(j.Steps), strings.Join(args, " "))
+		cmd := command.New(j.Runtime, args...)
+
+		errCh := make(chan error, 1)
+		go func() { errCh <- cmd.RunSuccess() }()
+
+		select {
+		case err := <-errCh:
+			if err != nil {
+				return fmt.Errorf("run local step %d: %w", i+1, err)
+			}
+		case <-ctx.Done():
+			if i < len(j.Containers) {
+				logrus.Warnf("Killing container %s of local step %d", j.Containers[i], i+1)
+				if err := command.New(j.Runtime, "kill", j.Containers[i]).RunSilentSuccess(); err != nil {
+					logrus.Warnf("Unable to kill container %s: %v", j.Containers[i], err)
+				} else {
+					<-errCh
+				}
+			}
+			return fmt.Errorf("running local step %d: %w", i+1, ctx.Err())
+		}
+	}
+	return nil
+}
+
+// RunLocalJobContext runs the job of the cloudbuild file by using the local
+// container runtime instead of submitting it to Google Cloud Build. A
+// temporary workspace gets removed with the other temporary files, unless
+// they are kept for debugging.
+func RunLocalJobContext(
+	ctx context.Context, o *Options, version string, subs map[string]string,
+) error {
+	workspace := o.LocalWorkspace
+	if workspace == "" {
+		var err error
+		workspace, err = workdir.MkdirTemp("gcb-local-")
+		if err != nil {
+			return fmt.Errorf("create local workspace: %w", err)
+		}
+	} else if err := os.MkdirAll(workspace, 0o755); err != nil {
+		return fmt.Errorf("create local workspace: %w", err)
+	}
+
+	job, err := NewLocalJob(o, workspace, version, subs)
+	if err != nil {
+		return fmt.Errorf("create local job: %w", err)
+	}
+
+	keys := make([]string, 0, len(subs))
+	for k := range subs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	logrus.Infof(
+		"Running %d steps of %s locally using %s with substitutions %s",
+		len(job.Steps), o.CloudbuildFile, job.Runtime, strings.Join(keys, ", "),
+	)
+
+	if err := job.Run(ctx); err != nil {
+		logrus.Warnf("Local job failed, its workspace is %s", workspace)
+		return err
+	}
+
+	logrus.Infof("Local job succeeded, its workspace is %s", workspace)
+	return nil
+}
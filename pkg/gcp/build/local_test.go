@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/gcp/build"
+)
+
+const testCloudbuild = `
+steps:
+- name: gcr.io/cloud-builders/git
+  dir: "go/src/k8s.io"
+  args:
+  - "clone"
+  - "https://github.com/${_TOOL_ORG}/${_TOOL_REPO}"
+- name: gcr.io/k8s-staging-releng/k8s-cloud-builder:${_KUBE_CROSS_VERSION}
+  entrypoint: bash
+  dir: "/workspace"
+  env:
+  - "BUILD_ID=${BUILD_ID}"
+  - "TAG=$_GIT_TAG"
+  secretEnv:
+  - GITHUB_TOKEN
+  args:
+  - "-c"
+  - "echo $${HOME} ${SHELL_VAR} ${_COMMIT}"
+options:
+  env:
+  - "PROJECT=${PROJECT_ID}"
+substitutions:
+  _COMMIT: 'default'
+  _KUBE_CROSS_VERSION: 'v1.0.0'
+`
+
+func TestNewLocalJob(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "secret")
+	gcloudConfig := t.TempDir()
+	t.Setenv("CLOUDSDK_CONFIG", gcloudConfig)
+
+	dir := t.TempDir()
+	cloudbuildFile := filepath.Join(dir, "cloudbuild.yaml")
+	require.NoError(t, os.WriteFile(cloudbuildFile, []byte(testCloudbuild), 0o600))
+
+	opts := build.NewDefaultOptions()
+	opts.CloudbuildFile = cloudbuildFile
+	opts.Project = "project"
+	opts.ContainerRuntime = "podman"
+
+	job, err := build.NewLocalJob(opts, "/tmp/ws", "v1.0.0-1-g123", map[string]string{
+		"TOOL_ORG":           "kubernetes",
+		"TOOL_REPO":          "release",
+		"KUBE_CROSS_VERSION": "v1.30.0-go1.22.0-bullseye.0",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "podman", job.Runtime)
+	require.Len(t, job.Steps, 2)
+
+	require.Len(t, job.Containers, 2)
+	require.Contains(t, job.Containers[1], "-step-2")
+
+	step := job.Steps[0]
+	require.Equal(t, []string{
+		"run", "--rm",
+		"--name", job.Containers[0],
+		"--volume", "/tmp/ws:/workspace",
+	}, step[:6])
+	require.Subset(t, step, []string{
+		gcloudConfig + ":/builder/home/.config/gcloud",
+		"CLOUDSDK_CONFIG=/builder/home/.config/gcloud",
+	})
+	require.Equal(t, []string{
+		"--workdir", "/workspace/go/src/k8s.io",
+		"--env", "PROJECT=project",
+		"gcr.io/cloud-builders/git",
+		"clone", "https://github.com/kubernetes/release",
+	}, step[len(step)-7:])
+
+	step = job.Steps[1]
+	require.Contains(t, step, "/workspace")
+	require.Contains(t, step, "TAG=v1.0.0-1-g123")
+	require.Contains(t, step, "GITHUB_TOKEN")
+	require.NotContains(t, step, "secret")
+	require.Contains(t, step, "gcr.io/k8s-staging-releng/k8s-cloud-builder:v1.30.0-go1.22.0-bullseye.0")
+	require.Equal(t, "echo ${HOME} ${SHELL_VAR} default", step[len(step)-1])
+
+	for _, arg := range step {
+		if len(arg) > 9 && arg[:9] == "BUILD_ID=" {
+			require.Contains(t, arg, "BUILD_ID=local-")
+		}
+	}
+}
+
+func TestNewLocalJobFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	opts := build.NewDefaultOptions()
+	opts.CloudbuildFile = filepath.Join(dir, "missing.yaml")
+	_, err := build.NewLocalJob(opts, dir, "", nil)
+	require.Error(t, err)
+
+	opts.CloudbuildFile = filepath.Join(dir, "cloudbuild.yaml")
+	require.NoError(t, os.WriteFile(opts.CloudbuildFile, []byte("steps: []\n"), 0o600))
+	_, err = build.NewLocalJob(opts, dir, "", nil)
+	require.ErrorContains(t, err, "no steps found")
+}
@@ -23,24 +23,42 @@ package gcb
 //go:generate /usr/bin/env bash -c "cat ../../../hack/boilerplate/boilerplate.generatego.txt gcbfakes/fake_repository.go > gcbfakes/_fake_repository.go  && mv gcbfakes/_fake_repository.go gcbfakes/fake_repository.go"
 //go:generate /usr/bin/env bash -c "cat ../../../hack/boilerplate/boilerplate.generatego.txt gcbfakes/fake_version.go > gcbfakes/_fake_version.go  && mv gcbfakes/_fake_version.go gcbfakes/fake_version.go"
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/blang/semver/v4"
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/release/gcb"
+	"k8s.io/release/pkg/approver"
+	"k8s.io/release/pkg/baseimage"
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/budget"
+	"k8s.io/release/pkg/freeze"
 	"k8s.io/release/pkg/gcp/auth"
 	"k8s.io/release/pkg/gcp/build"
+	"k8s.io/release/pkg/ghusage"
+	"k8s.io/release/pkg/imagerewrite"
+	"k8s.io/release/pkg/knownissues"
 	"k8s.io/release/pkg/kubecross"
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/logging"
+	"k8s.io/release/pkg/metrics"
+	"k8s.io/release/pkg/platforms"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/signkey"
+	"k8s.io/release/pkg/tagscheme"
+	"k8s.io/release/pkg/tracing"
 	"sigs.k8s.io/release-sdk/gcli"
 	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-utils/util"
 	utilsversion "sigs.k8s.io/release-utils/version"
 )
@@ -51,6 +69,7 @@ const StringSliceSeparator = "..."
 
 // GCB is the main structure of this package.
 type GCB struct {
+	ctx            context.Context
 	options        *Options
 	repoClient     Repository
 	versionClient  Version
@@ -61,6 +80,7 @@ type GCB struct {
 // New creates a new `*GCB` instance.
 func New(options *Options) *GCB {
 	return &GCB{
+		ctx:            context.Background(),
 		repoClient:     release.NewRepo(),
 		versionClient:  release.NewVersion(),
 		listJobsClient: &defaultListJobsClient{},
@@ -69,6 +89,11 @@ func New(options *Options) *GCB {
 	}
 }
 
+// SetContext sets the context used for watching the submitted jobs.
+func (g *GCB) SetContext(ctx context.Context) {
+	g.ctx = ctx
+}
+
 // SetRepoClient can be used to set the internal `Repository` client.
 func (g *GCB) SetRepoClient(client Repository) {
 	g.repoClient = client
@@ -104,12 +129,88 @@ type Options struct {
 	Branch        string
 	ReleaseType   string
 	BuildVersion  string
+	Commit        string
+	PublishAt     string
 	GcpUser       string
 	LogLevel      string
+	LogFormat     string
+	OTLPEndpoint  string
 	CustomK8SRepo string
 	CustomK8sOrg  string
 	LastJobs      int64
 
+	// Metrics endpoints of the jobs
+	MetricsPushgatewayURL string
+	MetricsRemoteWriteURL string
+
+	// Vulnerability scan parameters of stage jobs
+	VulnerabilityScan      string
+	IgnoredVulnerabilities []string
+
+	// Artifact size growth threshold in percent of stage jobs
+	SizeThreshold float64
+
+	// Cloud KMS key for encrypting the artifacts of embargoed stage jobs
+	EncryptionKey string
+
+	// KMS signing key reference of stage and release jobs
+	SigningKey string
+
+	// Release manager approval settings of stage and release jobs
+	ApproverTeams             string
+	ApproverRules             []string
+	ApproverTrustedIdentities []string
+
+	// Format of the generated release tags of stage and release jobs
+	TagSchemeFormat string
+
+	// Path to the in-toto functionary key inside of stage and release jobs
+	CustodyKey string
+
+	// Product name, registry and download host of stage and release jobs
+	BrandingProductName  string
+	BrandingRegistry     string
+	BrandingDownloadHost string
+
+	// Image rewrites of the announcements rendered by release jobs
+	ImageRewritesData string
+
+	// Known issues of the changelog rendered by release jobs
+	KnownIssuesLabels    []string
+	KnownIssuesMilestone string
+
+	// Base image policy of stage and release jobs
+	BaseImagePolicyData string
+
+	// Platform matrix of stage and release jobs
+	BuildPlatforms      []string
+	ExtraBuildPlatforms []string
+
+	// Allowlisted artifact set changes of release jobs
+	AllowAddedArtifacts   []string
+	AllowRemovedArtifacts []string
+
+	// Artifact layout templates of stage and release jobs
+	LayoutRelease string
+	LayoutMarker  string
+	LayoutStage   string
+
+	// GitHub API budget and rate limit reserve of stage and release jobs
+	GitHubAPIBudget  int64
+	GitHubAPIReserve int64
+
+	// Phase timeout budget of stage and release jobs
+	PhaseTimeouts       []string
+	PhaseTimeoutDefault time.Duration
+	PhaseTimeoutWebhook string
+
+	// Release freeze schedule and override token of fast forward jobs
+	FreezeSchedule string
+	FreezeOverride string
+
+	// Webhook to be notified about the result of fast forward jobs
+	NotifyWebhookURL string
+
 	// OpenBuildService parameters
 	OBSStage         bool
 	OBSRelease       bool
@@ -124,10 +225,62 @@ type Options struct {
 
 // NewDefaultOptions returns a new default `*Options` instance.
 func NewDefaultOptions() *Options {
-	return &Options{
-		LogLevel: logrus.StandardLogger().GetLevel().String(),
-		Options:  *build.NewDefaultOptions(),
+	opts := &Options{
+		LogLevel:              logging.Level(),
+		LogFormat:             logging.Format(),
+		OTLPEndpoint:          tracing.Endpoint(),
+		MetricsPushgatewayURL: metrics.PushgatewayURL(),
+		MetricsRemoteWriteURL: metrics.RemoteWriteURL(),
+		SigningKey:            remoteSigningKey(),
+		TagSchemeFormat:       tagscheme.Default().Format,
+		BrandingProductName:   branding.Default().ProductName,
+		BrandingRegistry:      branding.Default().Registry,
+		BrandingDownloadHost:  branding.Default().DownloadHost,
+		BaseImagePolicyData:   baseimage.Default().PolicyData(),
+		ImageRewritesData:     imagerewrite.Default().Data(),
+		LayoutRelease:         layout.Default().Release,
+		LayoutMarker:          layout.Default().Marker,
+		LayoutStage:           layout.Default().Stage,
+		Options:               *build.NewDefaultOptions(),
+	}
+	if approverOpts := approver.ActiveOptions(); approverOpts != nil {
+		opts.ApproverTeams = approverOpts.Teams
+		opts.ApproverRules = approverOpts.Rules
+		opts.ApproverTrustedIdentities = approverOpts.TrustedIdentities
+	}
+	if ghusageOpts := ghusage.ActiveOptions(); ghusageOpts != nil {
+		opts.GitHubAPIBudget = ghusageOpts.Budget
+		opts.GitHubAPIReserve = ghusageOpts.Reserve
+	} else {
+		opts.GitHubAPIReserve = ghusage.DefaultReserve
+	}
+	if knownIssuesOpts := knownissues.ActiveOptions(); knownIssuesOpts != nil {
+		opts.KnownIssuesLabels = knownIssuesOpts.Labels
+		opts.KnownIssuesMilestone = knownIssuesOpts.Milestone
+	}
+	if platformsOpts := platforms.ActiveOptions(); platformsOpts != nil {
+		opts.BuildPlatforms = platformsOpts.Platforms
+		opts.ExtraBuildPlatforms = platformsOpts.ExtraPlatforms
+	}
+	if budgetOpts := budget.ActiveOptions(); budgetOpts != nil {
+		opts.PhaseTimeouts = budgetOpts.Timeouts
+		opts.PhaseTimeoutDefault = budgetOpts.DefaultTimeout
+		opts.PhaseTimeoutWebhook = budgetOpts.WebhookURL
+	}
+	if freezeOpts := freeze.ActiveOptions(); freezeOpts != nil {
+		opts.FreezeSchedule = freezeOpts.Schedule
+		opts.FreezeOverride = freezeOpts.Override
+	}
+	return opts
+}
+
+// remoteSigningKey returns the reference of the current signing key if it
+// can be used by the GCB jobs, which is only the case for KMS keys.
+func remoteSigningKey() string {
+	if key := signkey.Default(); key.KMS() {
+		return key.Ref
 	}
+	return ""
 }
 
 //counterfeiter:generate . Repository
@@ -201,6 +354,14 @@ func (o *Options) Validate() error {
 		return errors.New("cannot specify both the 'build-version' and 'build-at-head' flag; resubmit with only one build option selected")
 	}
 
+	if o.Commit != "" && o.BuildVersion == "" {
+		return errors.New("cannot specify the 'commit' flag without a 'build-version' referencing it; resubmit with a 'build-version' flag set")
+	}
+
+	if o.Local && o.NoMock {
+		return errors.New("cannot run a --nomock job locally; resubmit it to Google Cloud Build")
+	}
+
 	if o.BuildAtHead && o.Release {
 		return errors.New("cannot specify both the 'build-at-head' flag together with the 'release' flag; resubmit with a 'build-version' flag set")
 	}
@@ -218,8 +379,10 @@ func (g *GCB) Submit() error {
 	toolRepo := release.GetToolRepo()
 	toolRef := release.GetToolRef()
 
-	if err := gcli.PreCheck(); err != nil {
-		return fmt.Errorf("pre-checking for GCP package usage: %w", err)
+	if !g.options.Local {
+		if err := gcli.PreCheck(); err != nil {
+			return fmt.Errorf("pre-checking for GCP package usage: %w", err)
+		}
 	}
 
 	var jobType string
@@ -278,7 +441,7 @@ func (g *GCB) Submit() error {
 
 	g.options.Async = true
 
-	if g.options.Stream {
+	if g.options.Stream || g.options.Local {
 		g.options.Async = false
 	}
 
@@ -339,13 +502,83 @@ func (g *GCB) Submit() error {
 	}
 
 	gcbSubs["LOG_LEVEL"] = g.options.LogLevel
+	gcbSubs["LOG_FORMAT"] = g.options.LogFormat
+	gcbSubs["OTLP_ENDPOINT"] = g.options.OTLPEndpoint
+	gcbSubs["METRICS_PUSHGATEWAY_URL"] = g.options.MetricsPushgatewayURL
+	gcbSubs["METRICS_REMOTE_WRITE_URL"] = g.options.MetricsRemoteWriteURL
+
+	if g.options.Stage {
+		gcbSubs["VULNERABILITY_SCAN"] = g.options.VulnerabilityScan
+		gcbSubs["IGNORED_VULNERABILITIES"] = strings.Join(
+			g.options.IgnoredVulnerabilities, StringSliceSeparator,
+		)
+		gcbSubs["SIZE_THRESHOLD"] = strconv.FormatFloat(g.options.SizeThreshold, 'f', -1, 64)
+		gcbSubs["ENCRYPTION_KEY"] = g.options.EncryptionKey
+	}
+
+	if g.options.Release {
+		gcbSubs["ALLOW_ADDED_ARTIFACTS"] = strings.Join(
+			g.options.AllowAddedArtifacts, StringSliceSeparator,
+		)
+		gcbSubs["ALLOW_REMOVED_ARTIFACTS"] = strings.Join(
+			g.options.AllowRemovedArtifacts, StringSliceSeparator,
+		)
+	}
+
+	if g.options.Stage || g.options.Release {
+		gcbSubs["SIGNING_KEY"] = g.options.SigningKey
+		gcbSubs["TAG_SCHEME_FORMAT"] = g.options.TagSchemeFormat
+		gcbSubs["CUSTODY_KEY"] = g.options.CustodyKey
+		gcbSubs["BRANDING_PRODUCT_NAME"] = g.options.BrandingProductName
+		gcbSubs["BRANDING_REGISTRY"] = g.options.BrandingRegistry
+		gcbSubs["BRANDING_DOWNLOAD_HOST"] = g.options.BrandingDownloadHost
+		gcbSubs["BASE_IMAGE_POLICY_DATA"] = g.options.BaseImagePolicyData
+		gcbSubs["IMAGE_REWRITES_DATA"] = g.options.ImageRewritesData
+		gcbSubs["KNOWN_ISSUES_LABELS"] = strings.Join(
+			g.options.KnownIssuesLabels, StringSliceSeparator,
+		)
+		gcbSubs["KNOWN_ISSUES_MILESTONE"] = g.options.KnownIssuesMilestone
+		gcbSubs["BUILD_PLATFORMS"] = strings.Join(
+			g.options.BuildPlatforms, StringSliceSeparator,
+		)
+		gcbSubs["EXTRA_BUILD_PLATFORMS"] = strings.Join(
+			g.options.ExtraBuildPlatforms, StringSliceSeparator,
+		)
+		gcbSubs["LAYOUT_RELEASE_TEMPLATE"] = g.options.LayoutRelease
+		gcbSubs["LAYOUT_MARKER_TEMPLATE"] = g.options.LayoutMarker
+		gcbSubs["LAYOUT_STAGE_TEMPLATE"] = g.options.LayoutStage
+		gcbSubs["GITHUB_API_BUDGET"] = strconv.FormatInt(g.options.GitHubAPIBudget, 10)
+		gcbSubs["GITHUB_API_RESERVE"] = strconv.FormatInt(g.options.GitHubAPIReserve, 10)
+		gcbSubs["PHASE_TIMEOUTS"] = strings.Join(
+			g.options.PhaseTimeouts, StringSliceSeparator,
+		)
+		gcbSubs["PHASE_TIMEOUT_DEFAULT"] = g.options.PhaseTimeoutDefault.String()
+		gcbSubs["PHASE_TIMEOUT_WEBHOOK"] = g.options.PhaseTimeoutWebhook
+		gcbSubs["APPROVER_TEAMS"] = g.options.ApproverTeams
+		gcbSubs["APPROVER_RULES"] = strings.Join(
+			g.options.ApproverRules, StringSliceSeparator,
+		)
+		gcbSubs["APPROVER_TRUSTED_IDENTITIES"] = strings.Join(
+			g.options.ApproverTrustedIdentities, StringSliceSeparator,
+		)
+	}
+
+	if g.options.OBSStage {
+		gcbSubs["LAYOUT_STAGE_TEMPLATE"] = g.options.LayoutStage
+	}
+
+	if g.options.FastForward {
+		gcbSubs["FREEZE_SCHEDULE"] = g.options.FreezeSchedule
+		gcbSubs["FREEZE_OVERRIDE"] = g.options.FreezeOverride
+		gcbSubs["FF_NOTIFY_WEBHOOK_URL"] = g.options.NotifyWebhookURL
+	}
 
 	prepareBuildErr := build.PrepareBuilds(&g.options.Options)
 	if prepareBuildErr != nil {
 		return prepareBuildErr
 	}
 
-	if err := build.RunSingleJob(&g.options.Options, "", "", version, gcbSubs); err != nil {
+	if err := build.RunSingleJobContext(g.ctx, &g.options.Options, "", "", version, gcbSubs); err != nil {
 		return fmt.Errorf("run GCB job: %w", err)
 	}
 
@@ -472,6 +705,12 @@ func (g *GCB) SetGCBSubstitutions(toolOrg, toolRepo, toolRef, gcsBucket string)
 	}
 
 	gcbSubs["BUILDVERSION"] = buildVersion
+	if g.options.Commit != "" {
+		gcbSubs["COMMIT"] = g.options.Commit
+	}
+	if g.options.Release && g.options.PublishAt != "" {
+		gcbSubs["PUBLISH_AT"] = g.options.PublishAt
+	}
 
 	buildVersionSemver, err := util.TagStringToSemver(buildVersion)
 	if err != nil {
@@ -502,7 +741,9 @@ func (g *GCB) SetGCBSubstitutions(toolOrg, toolRepo, toolRef, gcsBucket string)
 	gcbSubs["KUBERNETES_VERSION_TAG"] = primeSemver.String()
 
 	if g.options.Release {
-		gcbSubs["KUBERNETES_GCS_BUCKET"] = fmt.Sprintf("%s/stage/%s/%s/gcs-stage/%s", gcsBucket, buildVersion, versions.Prime(), versions.Prime())
+		gcbSubs["KUBERNETES_GCS_BUCKET"] = object.GcsPrefix + layout.Default().StagePath(
+			gcsBucket, buildVersion, versions.Prime(), release.GCSStagePath, versions.Prime(),
+		)
 	}
 
 	return gcbSubs, nil
@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"k8s.io/release/pkg/gcp/build"
 	"k8s.io/release/pkg/gcp/gcb"
 	"k8s.io/release/pkg/gcp/gcb/gcbfakes"
 	"k8s.io/release/pkg/release"
@@ -363,6 +364,36 @@ func TestSetGCBSubstitutionsSuccess(t *testing.T) {
 				"K8S_REF":                git.DefaultRef,
 			},
 		},
+		{
+			name: "release-1.18 RC 1 from pinned commit",
+			gcbOpts: &gcb.Options{
+				Stage:        true,
+				Branch:       "release-1.18",
+				ReleaseType:  release.ReleaseTypeRC,
+				GcpUser:      "test-user",
+				BuildVersion: "v1.18.6-rc.0.15+e38139724f8f00",
+				Commit:       "e38139724f8f00a9ec0e9ee6bd32fd3fa1dc7bd4",
+			},
+			repoMock:    mockRepo(),
+			versionMock: mockVersion("v1.18.6-rc.0.20+abcdef12345678"),
+			releaseMock: mockRelease("1.18.6-rc.1"),
+			expected: map[string]string{
+				"RELEASE_BRANCH":         "release-1.18",
+				"TOOL_ORG":               "",
+				"TOOL_REPO":              "",
+				"TOOL_REF":               "",
+				"TYPE":                   release.ReleaseTypeRC,
+				"TYPE_TAG":               release.ReleaseTypeRC,
+				"COMMIT":                 "e38139724f8f00a9ec0e9ee6bd32fd3fa1dc7bd4",
+				"MAJOR_VERSION_TAG":      "1",
+				"MINOR_VERSION_TAG":      "18",
+				"KUBERNETES_VERSION_TAG": "1.18.6-rc.1",
+				"PATCH_VERSION_TAG":      "6",
+				"K8S_ORG":                git.DefaultGithubOrg,
+				"K8S_REPO":               git.DefaultGithubRepo,
+				"K8S_REF":                git.DefaultRef,
+			},
+		},
 		{
 			name: "release-1.18 RC 1 from Beta",
 			gcbOpts: &gcb.Options{
@@ -499,6 +530,16 @@ func TestValidateSuccess(t *testing.T) {
 				ReleaseType: release.ReleaseTypeOfficial,
 			},
 		},
+		{
+			name: "release-1.16 official from pinned commit",
+			gcbOpts: &gcb.Options{
+				Stage:        true,
+				Branch:       "release-1.16",
+				ReleaseType:  release.ReleaseTypeOfficial,
+				BuildVersion: "v1.16.1-rc.0.3+2da917d3701904",
+				Commit:       "2da917d3701904a278fb8f838edb1ab8a78a9c4e",
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -528,6 +569,15 @@ func TestValidateFailure(t *testing.T) {
 				ReleaseType: release.ReleaseTypeOfficial,
 			},
 		},
+		{
+			name: "pinned commit without build version",
+			gcbOpts: &gcb.Options{
+				Stage:       true,
+				Branch:      "release-1.16",
+				ReleaseType: release.ReleaseTypeOfficial,
+				Commit:      "2da917d3701904a278fb8f838edb1ab8a78a9c4e",
+			},
+		},
 		{
 			name: "alpha on release branch",
 			gcbOpts: &gcb.Options{
@@ -542,6 +592,16 @@ func TestValidateFailure(t *testing.T) {
 				ReleaseType: release.ReleaseTypeBeta,
 			},
 		},
+		{
+			name: "local nomock job",
+			gcbOpts: &gcb.Options{
+				Options:     build.Options{Local: true},
+				NoMock:      true,
+				Stage:       true,
+				Branch:      "release-1.16",
+				ReleaseType: release.ReleaseTypeOfficial,
+			},
+		},
 	}
 
 	for _, tc := range testcases {
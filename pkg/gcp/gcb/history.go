@@ -17,8 +17,12 @@ limitations under the License.
 package gcb
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,8 +31,12 @@ import (
 	"google.golang.org/api/cloudbuild/v1"
 
 	"k8s.io/release/pkg/gcp/build"
+	"k8s.io/release/pkg/layout"
 	"k8s.io/release/pkg/release"
 	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/object"
+	"sigs.k8s.io/release-utils/util"
+	"sigs.k8s.io/yaml"
 )
 
 // History is the main structure for retrieving the GCB history output.
@@ -52,7 +60,8 @@ func (h *History) SetImpl(impl historyImpl) {
 
 // HistoryOptions are the main settings for the `History`.
 type HistoryOptions struct {
-	// Branch is the release branch for filtering the jobs.
+	// Branch is the release branch for filtering the jobs. All branches will
+	// be considered if empty.
 	Branch string
 
 	// Project is the GCB project to be used.
@@ -63,6 +72,52 @@ type HistoryOptions struct {
 
 	// DateTo is the string date for selecting the end of the range.
 	DateTo string
+
+	// ReleaseType is the release type (alpha, beta, rc or official) for
+	// filtering the jobs. All release types will be considered if empty.
+	ReleaseType string
+
+	// Output is the output format, can be one of HistoryOutputTable,
+	// HistoryOutputJSON, HistoryOutputYAML or HistoryOutputCSV.
+	Output string
+}
+
+const (
+	// HistoryOutputTable renders the history as markdown table.
+	HistoryOutputTable = "table"
+
+	// HistoryOutputJSON renders the history as JSON.
+	HistoryOutputJSON = "json"
+
+	// HistoryOutputYAML renders the history as YAML.
+	HistoryOutputYAML = "yaml"
+
+	// HistoryOutputCSV renders the history as CSV.
+	HistoryOutputCSV = "csv"
+)
+
+// HistoryOutputs are all supported output formats of the history.
+var HistoryOutputs = []string{
+	HistoryOutputTable, HistoryOutputJSON, HistoryOutputYAML, HistoryOutputCSV,
+}
+
+// HistoryEntry is a single job of the history.
+type HistoryEntry struct {
+	ID              string   `json:"id"`
+	Subcommand      string   `json:"subcommand"`
+	Mock            bool     `json:"mock"`
+	Command         string   `json:"command"`
+	Branch          string   `json:"branch"`
+	ReleaseType     string   `json:"releaseType"`
+	BuildVersion    string   `json:"buildVersion"`
+	Version         string   `json:"version,omitempty"`
+	Status          string   `json:"status"`
+	Succeeded       bool     `json:"succeeded"`
+	Start           string   `json:"start"`
+	End             string   `json:"end"`
+	DurationSeconds float64  `json:"durationSeconds"`
+	LogURL          string   `json:"logURL"`
+	Artifacts       []string `json:"artifacts"`
 }
 
 //counterfeiter:generate . historyImpl
@@ -90,6 +145,7 @@ func NewHistoryOptions() *HistoryOptions {
 		Project:  release.DefaultKubernetesStagingProject,
 		DateFrom: time.Now().Format("2006-01-02"),
 		DateTo:   time.Now().Format("2006-01-02"),
+		Output:   HistoryOutputTable,
 	}
 }
 
@@ -103,26 +159,48 @@ var status = map[string]string{
 // RunHistory is the function invoked by 'krel history', responsible for
 // getting the jobs and builind the list of commands to be added in the GitHub issue
 func (h *History) Run() error {
+	entries, err := h.Entries()
+	if err != nil {
+		return err
+	}
+
+	output, err := h.Render(entries)
+	if err != nil {
+		return fmt.Errorf("render history: %w", err)
+	}
+
+	fmt.Print(output)
+	return nil
+}
+
+// Entries retrieves all finished jobs matching the options.
+func (h *History) Entries() ([]*HistoryEntry, error) {
+	switch h.opts.Output {
+	case "", HistoryOutputTable, HistoryOutputJSON, HistoryOutputYAML, HistoryOutputCSV:
+	default:
+		return nil, fmt.Errorf(
+			"invalid output format %q, must be one of: %s",
+			h.opts.Output, strings.Join(HistoryOutputs, ", "),
+		)
+	}
+
 	from, to, err := h.parseDateRange()
 	if err != nil {
-		return fmt.Errorf("parse from and to dates: %w", err)
+		return nil, fmt.Errorf("parse from and to dates: %w", err)
 	}
 
 	logrus.Infof("Running history with the following options: %+v", h.opts)
 
-	tagFilter := fmt.Sprintf(
-		"tags=%q create_time>%q create_time<%q", h.opts.Branch, from, to,
-	)
+	tagFilter := fmt.Sprintf("create_time>%q create_time<%q", from, to)
+	if h.opts.Branch != "" {
+		tagFilter = fmt.Sprintf("tags=%q %s", h.opts.Branch, tagFilter)
+	}
 	jobs, err := h.impl.GetJobsByTag(h.opts.Project, tagFilter)
 	if err != nil {
-		return fmt.Errorf("get GCP build jobs by tag: %w", err)
+		return nil, fmt.Errorf("get GCP build jobs by tag: %w", err)
 	}
 
-	tableString := &strings.Builder{}
-	table := tablewriter.NewWriter(tableString)
-	table.SetAutoWrapText(false)
-
-	table.SetHeader([]string{"Step", "Command", "Link", "Start", "Duration", "Succeeded?"})
+	entries := []*HistoryEntry{}
 	for i := len(jobs) - 1; i >= 0; i-- {
 		job := jobs[i]
 		subcommand := ""
@@ -133,26 +211,27 @@ func (h *History) Run() error {
 			}
 		}
 
+		if h.opts.ReleaseType != "" && job.Substitutions["_TYPE"] != h.opts.ReleaseType {
+			logrus.Debugf("Skipping job %s of release type %q", job.Id, job.Substitutions["_TYPE"])
+			continue
+		}
+
 		// Build the command that was executed
-		command := fmt.Sprintf("`krel %s --type %s --branch %s --build-version %s",
+		command := fmt.Sprintf("krel %s --type %s --branch %s --build-version %s",
 			subcommand,
 			job.Substitutions["_TYPE"],
 			job.Substitutions["_RELEASE_BRANCH"],
 			job.Substitutions["_BUILDVERSION"],
 		)
 
-		var mock string
+		mock := true
 		if job.Substitutions["_NOMOCK"] != "" {
-			command = fmt.Sprintf("%s %s`", command, job.Substitutions["_NOMOCK"])
-			mock = ""
-		} else {
-			command = fmt.Sprintf("%s`", command)
-			mock = "mock "
+			command = fmt.Sprintf("%s %s", command, job.Substitutions["_NOMOCK"])
+			mock = false
 		}
 
 		start := job.Timing["BUILD"].StartTime
 		end := job.Timing["BUILD"].EndTime
-		logs := job.LogUrl
 
 		if start == "" || end == "" {
 			logrus.Infof("Skipping unfinished job from %s with ID: %s", job.CreateTime, job.Id)
@@ -163,19 +242,128 @@ func (h *History) Run() error {
 		const layout = "2006-01-02T15:04:05.99Z"
 		tStart, err := h.impl.ParseTime(layout, start)
 		if err != nil {
-			return fmt.Errorf("parsing the start job time: %w", err)
+			return nil, fmt.Errorf("parsing the start job time: %w", err)
 		}
 		tEnd, err := h.impl.ParseTime(layout, end)
 		if err != nil {
-			return fmt.Errorf("parsing the end job time: %w", err)
+			return nil, fmt.Errorf("parsing the end job time: %w", err)
 		}
-		diff := tEnd.Sub(tStart)
-		out := time.Time{}.Add(diff)
 
-		step := fmt.Sprintf("`%s%s`", mock, subcommand)
+		entry := &HistoryEntry{
+			ID:              job.Id,
+			Subcommand:      subcommand,
+			Mock:            mock,
+			Command:         command,
+			Branch:          job.Substitutions["_RELEASE_BRANCH"],
+			ReleaseType:     job.Substitutions["_TYPE"],
+			BuildVersion:    job.Substitutions["_BUILDVERSION"],
+			Status:          job.Status,
+			Succeeded:       job.Status == "SUCCESS",
+			Start:           start,
+			End:             end,
+			DurationSeconds: tEnd.Sub(tStart).Seconds(),
+			LogURL:          job.LogUrl,
+		}
+		if v := job.Substitutions["_KUBERNETES_VERSION_TAG"]; v != "" {
+			entry.Version = util.AddTagPrefix(v)
+		}
+		entry.Artifacts = artifactLocations(job, entry)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// artifactLocations returns the locations of the artifacts produced by the
+// job.
+func artifactLocations(job *cloudbuild.Build, entry *HistoryEntry) []string {
+	locations := []string{}
+
+	bucket := release.ProductionBucket
+	if entry.Mock {
+		bucket = release.TestBucket
+	}
+	switch {
+	case entry.Subcommand == "stage" && entry.BuildVersion != "":
+		locations = append(locations, object.GcsPrefix+layout.Default().StagePath(
+			bucket, entry.BuildVersion, entry.Version,
+		))
+	case entry.Subcommand == "release" && entry.Version != "":
+		locations = append(locations, object.GcsPrefix+path.Join(
+			bucket, "release", entry.Version,
+		))
+	}
+
+	if job.Artifacts != nil {
+		if job.Artifacts.Objects != nil && job.Artifacts.Objects.Location != "" {
+			locations = append(locations, job.Artifacts.Objects.Location)
+		}
+		locations = append(locations, job.Artifacts.Images...)
+	}
+	if job.Results != nil {
+		for _, image := range job.Results.Images {
+			locations = append(locations, image.Name+"@"+image.Digest)
+		}
+	}
+	return locations
+}
+
+// Render returns the entries in the output format of the options.
+func (h *History) Render(entries []*HistoryEntry) (string, error) {
+	switch h.opts.Output {
+	case HistoryOutputJSON:
+		res, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("marshal history: %w", err)
+		}
+		return string(res) + "\n", nil
+
+	case HistoryOutputYAML:
+		res, err := yaml.Marshal(entries)
+		if err != nil {
+			return "", fmt.Errorf("marshal history: %w", err)
+		}
+		return string(res), nil
+
+	case HistoryOutputCSV:
+		res := &strings.Builder{}
+		w := csv.NewWriter(res)
+		records := [][]string{{
+			"id", "subcommand", "mock", "command", "branch", "release_type",
+			"build_version", "version", "status", "succeeded", "start", "end",
+			"duration_seconds", "log_url", "artifacts",
+		}}
+		for _, e := range entries {
+			records = append(records, []string{
+				e.ID, e.Subcommand, strconv.FormatBool(e.Mock), e.Command,
+				e.Branch, e.ReleaseType, e.BuildVersion, e.Version, e.Status,
+				strconv.FormatBool(e.Succeeded), e.Start, e.End,
+				strconv.FormatFloat(e.DurationSeconds, 'f', 0, 64), e.LogURL,
+				strings.Join(e.Artifacts, " "),
+			})
+		}
+		if err := w.WriteAll(records); err != nil {
+			return "", fmt.Errorf("write csv: %w", err)
+		}
+		return res.String(), nil
+	}
+
+	tableString := &strings.Builder{}
+	table := tablewriter.NewWriter(tableString)
+	table.SetAutoWrapText(false)
+
+	table.SetHeader([]string{"Step", "Command", "Link", "Start", "Duration", "Succeeded?"})
+	for _, e := range entries {
+		mock := ""
+		if e.Mock {
+			mock = "mock "
+		}
+		out := time.Time{}.Add(time.Duration(e.DurationSeconds * float64(time.Second)))
 		table.Append([]string{
-			step, command, logs, start,
-			out.Format("15:04:05"), status[job.Status],
+			fmt.Sprintf("`%s%s`", mock, e.Subcommand),
+			fmt.Sprintf("`%s`", e.Command),
+			e.LogURL, e.Start,
+			out.Format("15:04:05"), status[e.Status],
 		})
 	}
 
@@ -185,8 +373,7 @@ func (h *History) Run() error {
 	table.SetCenterSeparator("|")
 	table.Render()
 
-	fmt.Print(tableString.String())
-	return nil
+	return tableString.String(), nil
 }
 
 func (h *History) parseDateRange() (from, to string, err error) {
@@ -17,7 +17,10 @@ limitations under the License.
 package gcb_test
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +29,7 @@ import (
 
 	"k8s.io/release/pkg/gcp/gcb"
 	"k8s.io/release/pkg/gcp/gcb/gcbfakes"
+	"sigs.k8s.io/yaml"
 )
 
 func TestHistoryRun(t *testing.T) {
@@ -62,6 +66,22 @@ func TestHistoryRun(t *testing.T) {
 			},
 			shouldErr: false,
 		},
+		{ // success json output
+			options: &gcb.HistoryOptions{
+				DateFrom: "2020-11-11",
+				Output:   gcb.HistoryOutputJSON,
+			},
+			prepare:   func(*gcbfakes.FakeHistoryImpl) {},
+			shouldErr: false,
+		},
+		{ // failure invalid output
+			options: &gcb.HistoryOptions{
+				DateFrom: "2020-11-11",
+				Output:   "invalid",
+			},
+			prepare:   func(*gcbfakes.FakeHistoryImpl) {},
+			shouldErr: true,
+		},
 		{ // failure no from date
 			options:   &gcb.HistoryOptions{},
 			prepare:   func(*gcbfakes.FakeHistoryImpl) {},
@@ -150,3 +170,166 @@ func TestHistoryRun(t *testing.T) {
 		}
 	}
 }
+
+func testHistoryJobs() []*cloudbuild.Build {
+	return []*cloudbuild.Build{
+		{
+			Id:     "2",
+			Tags:   []string{"RELEASE"},
+			Status: "FAILURE",
+			LogUrl: "https://logs/2",
+			Timing: map[string]cloudbuild.TimeSpan{
+				"BUILD": {StartTime: "2020-11-11T11:00:00.00Z", EndTime: "2020-11-11T11:30:00.00Z"},
+			},
+			Substitutions: map[string]string{
+				"_TYPE":                   "rc",
+				"_RELEASE_BRANCH":         "release-1.20",
+				"_BUILDVERSION":           "v1.20.0-rc.0.1+abc",
+				"_KUBERNETES_VERSION_TAG": "1.20.0-rc.1",
+			},
+		},
+		{
+			Id:     "1",
+			Tags:   []string{"STAGE"},
+			Status: "SUCCESS",
+			LogUrl: "https://logs/1",
+			Timing: map[string]cloudbuild.TimeSpan{
+				"BUILD": {StartTime: "2020-11-11T10:00:00.00Z", EndTime: "2020-11-11T11:00:00.00Z"},
+			},
+			Substitutions: map[string]string{
+				"_TYPE":                   "official",
+				"_RELEASE_BRANCH":         "release-1.20",
+				"_BUILDVERSION":           "v1.20.1-1+def",
+				"_KUBERNETES_VERSION_TAG": "1.20.1",
+				"_NOMOCK":                 "--nomock",
+			},
+			Results: &cloudbuild.Results{
+				Images: []*cloudbuild.BuiltImage{{Name: "gcr.io/image", Digest: "sha256:123"}},
+			},
+		},
+	}
+}
+
+func TestHistoryEntries(t *testing.T) {
+	for _, tc := range []struct {
+		releaseType string
+		branch      string
+		assert      func([]*gcb.HistoryEntry, *gcbfakes.FakeHistoryImpl)
+	}{
+		{ // all types
+			branch: "release-1.20",
+			assert: func(entries []*gcb.HistoryEntry, mock *gcbfakes.FakeHistoryImpl) {
+				require.Len(t, entries, 2)
+				_, filter := mock.GetJobsByTagArgsForCall(0)
+				require.Contains(t, filter, `tags="release-1.20"`)
+
+				require.Equal(t, "1", entries[0].ID)
+				require.False(t, entries[0].Mock)
+				require.True(t, entries[0].Succeeded)
+				require.Equal(t, "v1.20.1", entries[0].Version)
+				require.InDelta(t, 3600, entries[0].DurationSeconds, 0)
+				require.Equal(t,
+					"krel stage --type official --branch release-1.20 --build-version v1.20.1-1+def --nomock",
+					entries[0].Command,
+				)
+				require.Equal(t, []string{
+					"gs://kubernetes-release/stage/v1.20.1-1+def/v1.20.1",
+					"gcr.io/image@sha256:123",
+				}, entries[0].Artifacts)
+
+				require.Equal(t, "2", entries[1].ID)
+				require.True(t, entries[1].Mock)
+				require.False(t, entries[1].Succeeded)
+				require.Equal(t, []string{
+					"gs://kubernetes-release-gcb/release/v1.20.0-rc.1",
+				}, entries[1].Artifacts)
+			},
+		},
+		{ // filter release type, all branches
+			releaseType: "rc",
+			assert: func(entries []*gcb.HistoryEntry, mock *gcbfakes.FakeHistoryImpl) {
+				require.Len(t, entries, 1)
+				require.Equal(t, "rc", entries[0].ReleaseType)
+				_, filter := mock.GetJobsByTagArgsForCall(0)
+				require.NotContains(t, filter, "tags=")
+			},
+		},
+	} {
+		sut := gcb.NewHistory(&gcb.HistoryOptions{
+			Branch:      tc.branch,
+			DateFrom:    "2020-11-11",
+			ReleaseType: tc.releaseType,
+		})
+		mock := &gcbfakes.FakeHistoryImpl{}
+		mock.ParseTimeCalls(time.Parse)
+		mock.GetJobsByTagReturns(testHistoryJobs(), nil)
+		sut.SetImpl(mock)
+
+		entries, err := sut.Entries()
+		require.Nil(t, err)
+		tc.assert(entries, mock)
+	}
+}
+
+func TestHistoryRender(t *testing.T) {
+	entries := []*gcb.HistoryEntry{{
+		ID:              "1",
+		Subcommand:      "stage",
+		Mock:            true,
+		Command:         "krel stage --type rc",
+		Status:          "SUCCESS",
+		Succeeded:       true,
+		Start:           "2020-11-11T10:00:00.00Z",
+		DurationSeconds: 90,
+		LogURL:          "https://logs/1",
+		Artifacts:       []string{"gs://a", "gs://b"},
+	}}
+
+	for _, tc := range []struct {
+		output string
+		assert func(string)
+	}{
+		{
+			output: gcb.HistoryOutputTable,
+			assert: func(res string) {
+				require.Contains(t, res, "`mock stage`")
+				require.Contains(t, res, "`krel stage --type rc`")
+				require.Contains(t, res, "00:01:30")
+				require.Contains(t, res, "Yes")
+			},
+		},
+		{
+			output: gcb.HistoryOutputJSON,
+			assert: func(res string) {
+				parsed := []*gcb.HistoryEntry{}
+				require.Nil(t, json.Unmarshal([]byte(res), &parsed))
+				require.Equal(t, entries, parsed)
+			},
+		},
+		{
+			output: gcb.HistoryOutputYAML,
+			assert: func(res string) {
+				parsed := []*gcb.HistoryEntry{}
+				require.Nil(t, yaml.Unmarshal([]byte(res), &parsed))
+				require.Equal(t, entries, parsed)
+			},
+		},
+		{
+			output: gcb.HistoryOutputCSV,
+			assert: func(res string) {
+				records, err := csv.NewReader(strings.NewReader(res)).ReadAll()
+				require.Nil(t, err)
+				require.Len(t, records, 2)
+				require.Equal(t, "id", records[0][0])
+				require.Equal(t, "1", records[1][0])
+				require.Equal(t, "90", records[1][12])
+				require.Equal(t, "gs://a gs://b", records[1][14])
+			},
+		},
+	} {
+		sut := gcb.NewHistory(&gcb.HistoryOptions{Output: tc.output})
+		res, err := sut.Render(entries)
+		require.Nil(t, err)
+		tc.assert(res)
+	}
+}
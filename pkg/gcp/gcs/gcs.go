@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gcs reads and writes single small objects, like indexes and
+// generated pages, on Google Cloud Storage.
+package gcs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/release-sdk/gcli"
+	"sigs.k8s.io/release-sdk/object"
+
+	"k8s.io/release/pkg/workdir"
+)
+
+// ErrGenerationMismatch is returned if an object got changed concurrently.
+var ErrGenerationMismatch = errors.New("object generation does not match")
+
+// ReadObject returns the content of the object, or nil if it does not exist.
+func ReadObject(gcsPath string) ([]byte, error) {
+	gcs := object.NewGCS()
+	exists, err := gcs.PathExists(gcsPath)
+	if err != nil {
+		return nil, fmt.Errorf("check if %s exists: %w", gcsPath, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	dir, err := workdir.MkdirTemp("gcs-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dst := filepath.Join(dir, filepath.Base(gcsPath))
+	if err := gcs.CopyToLocal(gcsPath, dst); err != nil {
+		return nil, fmt.Errorf("copy %s: %w", gcsPath, err)
+	}
+	return os.ReadFile(dst)
+}
+
+// WriteObject writes the content to the object, which gets overwritten if
+// it already exists.
+func WriteObject(gcsPath string, content []byte) error {
+	dir, src, err := writeTemp(gcsPath, content)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	gcs := object.NewGCS()
+	gcs.SetOptions(gcs.WithNoClobber(false))
+	return gcs.CopyToRemote(src, gcsPath)
+}
+
+// ReadObjectGeneration returns the content of the object together with its
+// generation, or nil and generation 0 if it does not exist.
+func ReadObjectGeneration(gcsPath string) (content []byte, generation int64, err error) {
+	gcs := object.NewGCS()
+	exists, err := gcs.PathExists(gcsPath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("check if %s exists: %w", gcsPath, err)
+	}
+	if !exists {
+		return nil, 0, nil
+	}
+
+	stat, err := gcli.GSUtilOutput("stat", gcsPath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("stat %s: %w", gcsPath, err)
+	}
+	for _, line := range strings.Split(stat, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if ok && key == "Generation" {
+			generation, err = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return nil, 0, fmt.Errorf("parse generation of %s: %w", gcsPath, err)
+			}
+		}
+	}
+	if generation == 0 {
+		return nil, 0, fmt.Errorf("no generation found for %s", gcsPath)
+	}
+
+	dir, err := workdir.MkdirTemp("gcs-")
+	if err != nil {
+		return nil, 0, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Download the generation of the stat, which may not be the live one
+	// anymore and lets the write fail instead of losing a concurrent update.
+	dst := filepath.Join(dir, filepath.Base(gcsPath))
+	if err := gcli.GSUtil(
+		"cp", fmt.Sprintf("%s#%d", gcsPath, generation), dst,
+	); err != nil {
+		return nil, 0, fmt.Errorf("copy %s: %w", gcsPath, err)
+	}
+	content, err = os.ReadFile(dst)
+	if err != nil {
+		return nil, 0, err
+	}
+	return content, generation, nil
+}
+
+// WriteObjectGeneration writes the content to the object if its generation
+// still matches, where 0 requires that it does not exist yet. It returns
+// ErrGenerationMismatch if the object changed since reading the generation.
+func WriteObjectGeneration(gcsPath string, content []byte, generation int64) error {
+	dir, src, err := writeTemp(gcsPath, content)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	status, err := gcli.GSUtilStatus(
+		"-h", fmt.Sprintf("x-goog-if-generation-match:%d", generation),
+		"cp", src, gcsPath,
+	)
+	if err != nil {
+		return fmt.Errorf("copy %s: %w", gcsPath, err)
+	}
+	if !status.Success() {
+		if strings.Contains(status.Error(), "PreconditionException") ||
+			strings.Contains(status.Error(), "412") {
+			return fmt.Errorf("write %s: %w", gcsPath, ErrGenerationMismatch)
+		}
+		return fmt.Errorf("copy %s: %s", gcsPath, strings.TrimSpace(status.Error()))
+	}
+	return nil
+}
+
+// writeTemp writes the content to a temporary file named like the object,
+// and returns the temporary directory to be removed by the caller.
+func writeTemp(gcsPath string, content []byte) (dir, src string, err error) {
+	dir, err = workdir.MkdirTemp("gcs-")
+	if err != nil {
+		return "", "", fmt.Errorf("create temp dir: %w", err)
+	}
+	src = filepath.Join(dir, filepath.Base(gcsPath))
+	if err := os.WriteFile(src, content, 0o600); err != nil {
+		os.RemoveAll(dir)
+		return "", "", fmt.Errorf("write %s: %w", src, err)
+	}
+	return dir, src, nil
+}
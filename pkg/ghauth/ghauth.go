@@ -0,0 +1,448 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ghauth authenticates the release tooling as a GitHub App
+// installation. Installation tokens expire after one hour, which is shorter
+// than a full stage or release run, so they get refreshed transparently for
+// all GitHub clients of the process.
+package ghauth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	"golang.org/x/oauth2"
+
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/env"
+)
+
+const (
+	// AppIDEnvKey is the environment variable containing the default GitHub
+	// App ID.
+	AppIDEnvKey = "GITHUB_APP_ID"
+
+	// InstallationIDEnvKey is the environment variable containing the
+	// default installation ID of the GitHub App.
+	InstallationIDEnvKey = "GITHUB_APP_INSTALLATION_ID"
+
+	// PrivateKeyEnvKey is the environment variable containing the default
+	// path to the PEM encoded private key of the GitHub App.
+	PrivateKeyEnvKey = "GITHUB_APP_PRIVATE_KEY_PATH"
+
+	// RefreshMargin is the time before the expiry of an installation token
+	// when it gets replaced by a new one.
+	RefreshMargin = 10 * time.Minute
+
+	// jwtLifetime is the validity of the JSON Web Token used to request
+	// installation tokens. GitHub accepts at most ten minutes.
+	jwtLifetime = 9 * time.Minute
+
+	// clockSkew is subtracted from the issue time of the JSON Web Token to
+	// tolerate clocks which are slightly ahead of the GitHub ones.
+	clockSkew = time.Minute
+
+	// permissionsKey is the extra field of the oauth2 token containing the
+	// permissions of the installation token.
+	permissionsKey = "permissions"
+
+	// retryInterval is the wait time of the background refresh after a
+	// failed attempt.
+	retryInterval = time.Minute
+)
+
+// Options are the settings of the GitHub App authentication.
+type Options struct {
+	// AppID is the ID of the GitHub App.
+	AppID int64
+
+	// InstallationID is the ID of the app installation in the organization
+	// or repository to be accessed.
+	InstallationID int64
+
+	// PrivateKey is the path to the PEM encoded private key of the app.
+	PrivateKey string
+
+	// BaseURL is the API endpoint for GitHub Enterprise installations.
+	// Defaults to the public GitHub API if empty.
+	BaseURL string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		AppID:          envInt64(AppIDEnvKey),
+		InstallationID: envInt64(InstallationIDEnvKey),
+		PrivateKey:     env.Default(PrivateKeyEnvKey, ""),
+	}
+}
+
+// AddFlags adds the GitHub App flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.Int64Var(
+		&o.AppID,
+		"github-app-id",
+		o.AppID,
+		fmt.Sprintf("ID of the GitHub App to authenticate as instead of using $%s (default $%s)", github.TokenEnvKey, AppIDEnvKey),
+	)
+
+	flags.Int64Var(
+		&o.InstallationID,
+		"github-app-installation-id",
+		o.InstallationID,
+		fmt.Sprintf("installation ID of the GitHub App (default $%s)", InstallationIDEnvKey),
+	)
+
+	flags.StringVar(
+		&o.PrivateKey,
+		"github-app-private-key",
+		o.PrivateKey,
+		fmt.Sprintf("path to the PEM encoded private key of the GitHub App (default $%s)", PrivateKeyEnvKey),
+	)
+}
+
+// Enabled returns true if any GitHub App setting is provided.
+func (o *Options) Enabled() bool {
+	return o.AppID != 0 || o.InstallationID != 0 || o.PrivateKey != ""
+}
+
+// Validate checks if the options are complete.
+func (o *Options) Validate() error {
+	if !o.Enabled() {
+		return nil
+	}
+	if o.AppID <= 0 {
+		return errors.New("GitHub App ID is required")
+	}
+	if o.InstallationID <= 0 {
+		return errors.New("GitHub App installation ID is required")
+	}
+	if o.PrivateKey == "" {
+		return errors.New("GitHub App private key is required")
+	}
+	return nil
+}
+
+// AppTokenSource is an oauth2.TokenSource which requests a new installation
+// token of a GitHub App on every call.
+type AppTokenSource struct {
+	impl           impl
+	appID          int64
+	installationID int64
+	baseURL        string
+	key            *rsa.PrivateKey
+}
+
+// NewAppTokenSource creates a new AppTokenSource from the provided options.
+func NewAppTokenSource(opts *Options) (*AppTokenSource, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if !opts.Enabled() {
+		return nil, errors.New("no GitHub App configured")
+	}
+
+	content, err := os.ReadFile(opts.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("read private key: %w", err)
+	}
+	key, err := ParsePrivateKey(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key %s: %w", opts.PrivateKey, err)
+	}
+
+	return &AppTokenSource{
+		impl:           &defaultImpl{client: &http.Client{Transport: http.DefaultTransport}},
+		appID:          opts.AppID,
+		installationID: opts.InstallationID,
+		baseURL:        opts.BaseURL,
+		key:            key,
+	}, nil
+}
+
+// SetImpl can be used to set the internal implementation.
+func (s *AppTokenSource) SetImpl(impl impl) {
+	s.impl = impl
+}
+
+// Token requests a new installation token.
+func (s *AppTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := s.JWT(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("sign JSON web token: %w", err)
+	}
+
+	token, expiry, permissions, err := s.impl.CreateInstallationToken(
+		s.baseURL, s.installationID, jwt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"create token for installation %d of app %d: %w",
+			s.installationID, s.appID, err,
+		)
+	}
+	logrus.Debugf(
+		"Got token for installation %d of GitHub App %d valid until %s",
+		s.installationID, s.appID, expiry.Format(time.RFC3339),
+	)
+	return (&oauth2.Token{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}).WithExtra(map[string]any{permissionsKey: permissions}), nil
+}
+
+// JWT returns the RS256 signed JSON Web Token authenticating the app at the
+// provided time.
+func (s *AppTokenSource) JWT(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", fmt.Errorf("marshal header: %w", err)
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-clockSkew).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": strconv.FormatInt(s.appID, 10),
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal claims: %w", err)
+	}
+
+	encoding := base64.RawURLEncoding
+	unsigned := encoding.EncodeToString(header) + "." + encoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign: %w", err)
+	}
+	return unsigned + "." + encoding.EncodeToString(signature), nil
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS #1 or PKCS #8 RSA private key.
+func ParsePrivateKey(content []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", parsed)
+	}
+	return key, nil
+}
+
+// state is the process wide authentication set up by Setup.
+var state struct {
+	sync.Mutex
+	source oauth2.TokenSource
+	issued map[string]bool
+}
+
+// Setup authenticates the process as the configured GitHub App
+// installation. It is a no-op if no app is configured.
+//
+// The installation token gets exported to $GITHUB_TOKEN, which is read by
+// the GitHub clients, the git remotes and the subprocesses of the tooling.
+// It is kept up to date by a background refresh, and requests of long lived
+// clients still carrying an expired token of the app get the current token
+// injected through http.DefaultTransport.
+func Setup(opts *Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if !opts.Enabled() {
+		return nil
+	}
+
+	src, err := NewAppTokenSource(opts)
+	if err != nil {
+		return err
+	}
+	if err := setup(src); err != nil {
+		return err
+	}
+	logrus.Infof(
+		"Authenticating as installation %d of GitHub App %d",
+		opts.InstallationID, opts.AppID,
+	)
+	return nil
+}
+
+// setup installs the provided token source and starts the background
+// refresh.
+func setup(src oauth2.TokenSource) error {
+	state.Lock()
+	state.source = oauth2.ReuseTokenSourceWithExpiry(nil, src, RefreshMargin)
+	state.issued = map[string]bool{}
+	state.Unlock()
+
+	token, err := current()
+	if err != nil {
+		return err
+	}
+
+	http.DefaultTransport = &Transport{Base: http.DefaultTransport}
+	go refresh(token)
+	return nil
+}
+
+// Enabled returns true if the process is authenticated as GitHub App.
+func Enabled() bool {
+	state.Lock()
+	defer state.Unlock()
+	return state.source != nil
+}
+
+// Token returns a valid GitHub token. This is the current installation
+// token if a GitHub App is set up, and the content of $GITHUB_TOKEN
+// otherwise.
+func Token() (string, error) {
+	if !Enabled() {
+		return env.Default(github.TokenEnvKey, ""), nil
+	}
+	token, err := current()
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// Permissions returns the permissions of the current installation token,
+// like "contents": "write", or nil if no GitHub App is set up.
+func Permissions() (map[string]string, error) {
+	if !Enabled() {
+		return nil, nil
+	}
+	token, err := current()
+	if err != nil {
+		return nil, err
+	}
+	permissions, ok := token.Extra(permissionsKey).(map[string]string)
+	if !ok {
+		return map[string]string{}, nil
+	}
+	return permissions, nil
+}
+
+// current returns the current installation token, refreshing and exporting
+// it if required.
+func current() (*oauth2.Token, error) {
+	state.Lock()
+	src := state.source
+	state.Unlock()
+
+	token, err := src.Token()
+	if err != nil {
+		return nil, fmt.Errorf("get GitHub App installation token: %w", err)
+	}
+
+	state.Lock()
+	defer state.Unlock()
+	if !state.issued[token.AccessToken] {
+		state.issued[token.AccessToken] = true
+		if err := os.Setenv(github.TokenEnvKey, token.AccessToken); err != nil {
+			return nil, fmt.Errorf("set %s: %w", github.TokenEnvKey, err)
+		}
+	}
+	return token, nil
+}
+
+// issued returns true if the provided token got issued by the app.
+func issued(token string) bool {
+	state.Lock()
+	defer state.Unlock()
+	return state.issued[token]
+}
+
+// refresh renews the installation token before it expires.
+func refresh(token *oauth2.Token) {
+	for {
+		time.Sleep(time.Until(token.Expiry.Add(-RefreshMargin)) + time.Second)
+
+		next, err := current()
+		if err != nil {
+			logrus.Warnf("Unable to refresh GitHub App token: %v", err)
+			time.Sleep(retryInterval)
+			continue
+		}
+		token = next
+	}
+}
+
+// Transport is a http.RoundTripper which replaces outdated installation
+// tokens of the GitHub App with the current one.
+type Transport struct {
+	// Base is the underlying round tripper, http.DefaultTransport if nil.
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	scheme, token, found := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !found || !issued(token) {
+		return base.RoundTrip(req)
+	}
+
+	fresh, err := current()
+	if err != nil {
+		return nil, err
+	}
+	if fresh.AccessToken != token {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", scheme+" "+fresh.AccessToken)
+	}
+	return base.RoundTrip(req)
+}
+
+// envInt64 returns the integer value of the provided environment variable
+// or zero if it is unset or invalid.
+func envInt64(key string) int64 {
+	value, err := strconv.ParseInt(env.Default(key, "0"), 10, 64)
+	if err != nil {
+		logrus.Warnf("Ignoring invalid value of $%s: %v", key, err)
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghauth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"k8s.io/release/pkg/ghauth/ghauthfakes"
+)
+
+func writeKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}), 0o600))
+	return key, path
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		opts        Options
+		shouldError bool
+	}{
+		{name: "disabled", opts: Options{}},
+		{name: "complete", opts: Options{AppID: 1, InstallationID: 2, PrivateKey: "key.pem"}},
+		{name: "no app ID", opts: Options{InstallationID: 2, PrivateKey: "key.pem"}, shouldError: true},
+		{name: "no installation ID", opts: Options{AppID: 1, PrivateKey: "key.pem"}, shouldError: true},
+		{name: "no private key", opts: Options{AppID: 1, InstallationID: 2}, shouldError: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			if tc.shouldError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, path := writeKey(t)
+	pkcs1, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pkcs8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	for _, content := range [][]byte{pkcs1, pkcs8} {
+		parsed, err := ParsePrivateKey(content)
+		require.NoError(t, err)
+		require.True(t, key.Equal(parsed))
+	}
+
+	_, err = ParsePrivateKey([]byte("no key"))
+	require.Error(t, err)
+}
+
+func TestJWT(t *testing.T) {
+	key, path := writeKey(t)
+	src, err := NewAppTokenSource(&Options{AppID: 42, InstallationID: 1, PrivateKey: path})
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	jwt, err := src.JWT(now)
+	require.NoError(t, err)
+
+	parts := strings.Split(jwt, ".")
+	require.Len(t, parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	claims := map[string]any{}
+	require.NoError(t, json.Unmarshal(rawClaims, &claims))
+	require.Equal(t, "42", claims["iss"])
+	require.EqualValues(t, now.Add(-clockSkew).Unix(), claims["iat"])
+	require.EqualValues(t, now.Add(jwtLifetime).Unix(), claims["exp"])
+}
+
+func TestToken(t *testing.T) {
+	_, path := writeKey(t)
+	src, err := NewAppTokenSource(&Options{
+		AppID: 42, InstallationID: 7, PrivateKey: path, BaseURL: "https://ghe.example.com/api/v3",
+	})
+	require.NoError(t, err)
+
+	expiry := time.Now().Add(time.Hour)
+	mock := &ghauthfakes.FakeImpl{}
+	mock.CreateInstallationTokenReturns("ghs_token", expiry, map[string]string{"contents": "write"}, nil)
+	src.SetImpl(mock)
+
+	token, err := src.Token()
+	require.NoError(t, err)
+	require.Equal(t, "ghs_token", token.AccessToken)
+	require.Equal(t, expiry, token.Expiry)
+	require.Equal(t, map[string]string{"contents": "write"}, token.Extra("permissions"))
+
+	baseURL, installationID, jwt := mock.CreateInstallationTokenArgsForCall(0)
+	require.Equal(t, "https://ghe.example.com/api/v3", baseURL)
+	require.EqualValues(t, 7, installationID)
+	require.NotEmpty(t, jwt)
+
+	mock.CreateInstallationTokenReturns("", time.Time{}, nil, errors.New("bad credentials"))
+	_, err = src.Token()
+	require.ErrorContains(t, err, "bad credentials")
+}
+
+// sequence returns a new token on every call.
+type sequence struct {
+	tokens []string
+	calls  int
+}
+
+func (s *sequence) Token() (*oauth2.Token, error) {
+	token := &oauth2.Token{
+		AccessToken: s.tokens[s.calls],
+		Expiry:      time.Now().Add(time.Hour),
+	}
+	// All but the last token are already expiring, which forces a refresh.
+	if s.calls < len(s.tokens)-1 {
+		token.Expiry = time.Now().Add(RefreshMargin / 2)
+		s.calls++
+	}
+	return token, nil
+}
+
+func TestSetupAndTransport(t *testing.T) {
+	defaultTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = defaultTransport
+		state.Lock()
+		state.source = nil
+		state.issued = nil
+		state.Unlock()
+	})
+	t.Setenv("GITHUB_TOKEN", "")
+
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	permissions, err := Permissions()
+	require.NoError(t, err)
+	require.Nil(t, permissions)
+
+	require.NoError(t, setup(&sequence{tokens: []string{"first", "second"}}))
+	require.True(t, Enabled())
+	require.Equal(t, "first", os.Getenv("GITHUB_TOKEN"))
+
+	send := func(header string) {
+		req, err := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", header)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+	}
+
+	// The expiring token of the app gets replaced
+	send("Bearer first")
+	// Foreign credentials are left untouched
+	send("Bearer personal")
+
+	require.Equal(t, []string{"Bearer second", "Bearer personal"}, seen)
+	require.Equal(t, "second", os.Getenv("GITHUB_TOKEN"))
+
+	token, err := Token()
+	require.NoError(t, err)
+	require.Equal(t, "second", token)
+
+	permissions, err = Permissions()
+	require.NoError(t, err)
+	require.NotNil(t, permissions)
+}
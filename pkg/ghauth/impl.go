@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt ghauthfakes/fake_impl.go > ghauthfakes/_fake_impl.go && mv ghauthfakes/_fake_impl.go ghauthfakes/fake_impl.go"
+type impl interface {
+	// CreateInstallationToken returns the token, its expiry and the
+	// permissions granted to it, like "contents": "write".
+	CreateInstallationToken(baseURL string, installationID int64, jwt string) (string, time.Time, map[string]string, error)
+}
+
+type defaultImpl struct {
+	// client uses the transport from before the setup to not route the
+	// token requests through the refreshing Transport.
+	client *http.Client
+}
+
+func (d *defaultImpl) CreateInstallationToken(
+	baseURL string, installationID int64, jwt string,
+) (string, time.Time, map[string]string, error) {
+	client := gogithub.NewClient(d.client).WithAuthToken(jwt)
+	if baseURL != "" {
+		var err error
+		baseURL = strings.TrimSuffix(baseURL, "/") + "/"
+		client, err = client.WithEnterpriseURLs(baseURL, baseURL)
+		if err != nil {
+			return "", time.Time{}, nil, fmt.Errorf("set enterprise URL: %w", err)
+		}
+	}
+
+	token, _, err := client.Apps.CreateInstallationToken(
+		context.Background(), installationID, nil,
+	)
+	if err != nil {
+		return "", time.Time{}, nil, err
+	}
+	if token.GetToken() == "" {
+		return "", time.Time{}, nil, errors.New("empty token returned")
+	}
+
+	// The permissions are a struct of optional strings, which results in
+	// a map of the granted ones.
+	permissions := map[string]string{}
+	content, err := json.Marshal(token.GetPermissions())
+	if err != nil {
+		return "", time.Time{}, nil, fmt.Errorf("marshal permissions: %w", err)
+	}
+	if err := json.Unmarshal(content, &permissions); err != nil {
+		return "", time.Time{}, nil, fmt.Errorf("unmarshal permissions: %w", err)
+	}
+	return token.GetToken(), token.GetExpiresAt().Time, permissions, nil
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ghperms verifies that the GitHub token has the permissions
+// required by a mutating operation before running it, instead of failing
+// with an opaque 403 in the middle of the operation.
+package ghperms
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-sdk/github"
+)
+
+// Permission is a permission required on a repository.
+type Permission string
+
+const (
+	// Contents is required for pushing branches and tags.
+	Contents Permission = "contents:write"
+
+	// Releases is required for creating and editing GitHub releases.
+	Releases Permission = "releases"
+
+	// Workflows is required for pushing changes of GitHub Actions
+	// workflows, for example when merging them into a release branch.
+	Workflows Permission = "workflow"
+)
+
+// scopesHeader contains the OAuth scopes of classic personal access tokens.
+// It is not set for fine-grained tokens and GitHub App installations.
+const scopesHeader = "X-OAuth-Scopes"
+
+// ErrMissingPermissions is returned if the token lacks at least one of the
+// required permissions.
+var ErrMissingPermissions = errors.New("missing GitHub permissions")
+
+// Checker verifies the permissions of the GitHub token.
+type Checker struct {
+	impl impl
+}
+
+// New returns a new Checker instance.
+func New() *Checker {
+	return &Checker{impl: &defaultImpl{}}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (c *Checker) SetImpl(impl impl) {
+	c.impl = impl
+}
+
+// Check verifies that the GitHub token has the permissions on owner/repo
+// required by the operation. The returned error lists all missing
+// permissions.
+//
+// The permissions of GitHub App installation tokens and the scopes of classic
+// personal access tokens are verified, together with the write access of the
+// token owner. Only the write access can be verified for fine-grained
+// personal access tokens, because GitHub does not expose their permissions.
+func (c *Checker) Check(operation, owner, repo string, perms ...Permission) error {
+	slug := owner + "/" + repo
+	token, err := c.impl.Token()
+	if err != nil {
+		return fmt.Errorf("get GitHub token: %w", err)
+	}
+	if token == "" {
+		return fmt.Errorf("refusing %s of %s: no GitHub token set via $%s", operation, slug, github.TokenEnvKey)
+	}
+
+	repository, header, err := c.impl.GetRepository(token, owner, repo)
+	if err != nil {
+		var errResp *gogithub.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil {
+			switch errResp.Response.StatusCode {
+			case http.StatusUnauthorized:
+				return fmt.Errorf("refusing %s of %s: the GitHub token is invalid or expired", operation, slug)
+			case http.StatusNotFound:
+				return fmt.Errorf(
+					"refusing %s of %s: the repository does not exist or is not accessible with the GitHub token",
+					operation, slug,
+				)
+			}
+		}
+		return fmt.Errorf("get repository %s: %w", slug, err)
+	}
+
+	appPermissions, err := c.impl.AppPermissions()
+	if err != nil {
+		return fmt.Errorf("get GitHub App permissions: %w", err)
+	}
+
+	var missing []string
+	switch {
+	case appPermissions != nil:
+		missing = missingAppPermissions(appPermissions, perms)
+
+	case len(header.Values(scopesHeader)) > 0:
+		missing = missingScopes(header.Get(scopesHeader), repository.GetPrivate(), perms)
+		missing = append(missing, missingAccess(repository)...)
+
+	default:
+		logrus.Warnf(
+			"The permissions of fine-grained GitHub tokens cannot be verified, "+
+				"make sure that the token grants %s on %s", join(perms), slug,
+		)
+		missing = missingAccess(repository)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf(
+			"refusing %s of %s: %w: %s",
+			operation, slug, ErrMissingPermissions, strings.Join(missing, "; "),
+		)
+	}
+	logrus.Infof("Verified GitHub permissions %s on %s for %s", join(perms), slug, operation)
+	return nil
+}
+
+// missingAppPermissions returns the permissions of the installation token
+// which are not granted with write access.
+func missingAppPermissions(granted map[string]string, perms []Permission) []string {
+	missing := []string{}
+	for _, perm := range perms {
+		name := "contents"
+		if perm == Workflows {
+			name = "workflows"
+		}
+		if granted[name] == "write" {
+			continue
+		}
+		has := "not granted"
+		if level := granted[name]; level != "" {
+			has = "has " + name + ":" + level
+		}
+		msg := fmt.Sprintf("GitHub App permission %s:write (%s)", name, has)
+		if !slices.Contains(missing, msg) {
+			missing = append(missing, msg)
+		}
+	}
+	return missing
+}
+
+// missingScopes returns the OAuth scopes required for the permissions,
+// which are not included in the comma separated scopes of a classic token.
+func missingScopes(scopes string, private bool, perms []Permission) []string {
+	granted := map[string]bool{}
+	for _, scope := range strings.Split(scopes, ",") {
+		granted[strings.TrimSpace(scope)] = true
+	}
+
+	missing := []string{}
+	for _, perm := range perms {
+		msg := ""
+		switch perm {
+		case Workflows:
+			if !granted["workflow"] {
+				msg = "token scope workflow"
+			}
+		default:
+			if !granted["repo"] && (private || !granted["public_repo"]) {
+				msg = "token scope repo"
+				if !private {
+					msg += " or public_repo"
+				}
+			}
+		}
+		if msg != "" && !slices.Contains(missing, msg) {
+			missing = append(missing, msg)
+		}
+	}
+	return missing
+}
+
+// missingAccess returns the write access to the repository if the token
+// owner does not have it.
+func missingAccess(repository *gogithub.Repository) []string {
+	access := repository.GetPermissions()
+	if access["push"] || access["admin"] || access["maintain"] {
+		return nil
+	}
+	return []string{"write access to the repository for the token owner"}
+}
+
+func join(perms []Permission) string {
+	res := make([]string, 0, len(perms))
+	for _, perm := range perms {
+		res = append(res, string(perm))
+	}
+	return strings.Join(res, ", ")
+}
+
+// Check verifies the permissions of the GitHub token for the operation by
+// using a new Checker.
+func Check(operation, owner, repo string, perms ...Permission) error {
+	return New().Check(operation, owner, repo, perms...)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghperms_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/ghperms"
+	"k8s.io/release/pkg/ghperms/ghpermsfakes"
+)
+
+var errTest = errors.New("test")
+
+func testRepository(private bool, push bool) *gogithub.Repository {
+	return &gogithub.Repository{
+		Private:     gogithub.Bool(private),
+		Permissions: map[string]bool{"pull": true, "push": push},
+	}
+}
+
+func scopes(s string) http.Header {
+	header := http.Header{}
+	header.Set("X-OAuth-Scopes", s)
+	return header
+}
+
+func errorResponse(status int) error {
+	return &gogithub.ErrorResponse{Response: &http.Response{StatusCode: status, Request: &http.Request{}}}
+}
+
+func TestCheck(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		perms       []ghperms.Permission
+		prepare     func(*ghpermsfakes.FakeImpl)
+		expectedErr string
+	}{
+		{
+			name:  "classic token with all scopes",
+			perms: []ghperms.Permission{ghperms.Contents, ghperms.Workflows},
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(testRepository(false, true), scopes("repo, workflow"), nil)
+			},
+		},
+		{
+			name:  "classic token with public_repo scope for a public repository",
+			perms: []ghperms.Permission{ghperms.Releases},
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(testRepository(false, true), scopes("public_repo"), nil)
+			},
+		},
+		{
+			name:  "classic token with public_repo scope for a private repository",
+			perms: []ghperms.Permission{ghperms.Releases},
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(testRepository(true, true), scopes("public_repo"), nil)
+			},
+			expectedErr: "missing GitHub permissions: token scope repo",
+		},
+		{
+			name:  "classic token without workflow scope and write access",
+			perms: []ghperms.Permission{ghperms.Contents, ghperms.Workflows},
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(testRepository(false, false), scopes("repo"), nil)
+			},
+			expectedErr: "token scope workflow; write access to the repository for the token owner",
+		},
+		{
+			name:  "classic token without scopes",
+			perms: []ghperms.Permission{ghperms.Contents, ghperms.Releases},
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(testRepository(false, true), scopes(""), nil)
+			},
+			expectedErr: "missing GitHub permissions: token scope repo or public_repo",
+		},
+		{
+			name:  "fine-grained token with write access",
+			perms: []ghperms.Permission{ghperms.Releases},
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(testRepository(false, true), http.Header{}, nil)
+			},
+		},
+		{
+			name:  "fine-grained token without write access",
+			perms: []ghperms.Permission{ghperms.Releases},
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(testRepository(false, false), http.Header{}, nil)
+			},
+			expectedErr: "write access to the repository for the token owner",
+		},
+		{
+			name:  "GitHub App with all permissions",
+			perms: []ghperms.Permission{ghperms.Contents, ghperms.Releases, ghperms.Workflows},
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(&gogithub.Repository{}, http.Header{}, nil)
+				mock.AppPermissionsReturns(map[string]string{"contents": "write", "workflows": "write"}, nil)
+			},
+		},
+		{
+			name:  "GitHub App without permissions",
+			perms: []ghperms.Permission{ghperms.Contents, ghperms.Releases, ghperms.Workflows},
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(&gogithub.Repository{}, http.Header{}, nil)
+				mock.AppPermissionsReturns(map[string]string{"contents": "read"}, nil)
+			},
+			expectedErr: "GitHub App permission contents:write (has contents:read); GitHub App permission workflows:write (not granted)",
+		},
+		{
+			name: "no token",
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.TokenReturns("", nil)
+			},
+			expectedErr: "refusing fast forward of kubernetes/kubernetes: no GitHub token set via $GITHUB_TOKEN",
+		},
+		{
+			name: "invalid token",
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(nil, nil, errorResponse(http.StatusUnauthorized))
+			},
+			expectedErr: "the GitHub token is invalid or expired",
+		},
+		{
+			name: "repository not accessible",
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(nil, nil, errorResponse(http.StatusNotFound))
+			},
+			expectedErr: "the repository does not exist or is not accessible",
+		},
+		{
+			name: "get repository fails",
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(nil, nil, errTest)
+			},
+			expectedErr: "get repository kubernetes/kubernetes",
+		},
+		{
+			name: "get app permissions fails",
+			prepare: func(mock *ghpermsfakes.FakeImpl) {
+				mock.GetRepositoryReturns(testRepository(false, true), http.Header{}, nil)
+				mock.AppPermissionsReturns(nil, errTest)
+			},
+			expectedErr: "get GitHub App permissions",
+		},
+	} {
+		mock := &ghpermsfakes.FakeImpl{}
+		mock.TokenReturns("token", nil)
+		tc.prepare(mock)
+		sut := ghperms.New()
+		sut.SetImpl(mock)
+
+		err := sut.Check("fast forward", "kubernetes", "kubernetes", tc.perms...)
+		if tc.expectedErr == "" {
+			require.NoError(t, err, tc.name)
+			continue
+		}
+		require.ErrorContains(t, err, tc.expectedErr, tc.name)
+	}
+}
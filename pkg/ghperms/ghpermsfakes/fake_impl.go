@@ -0,0 +1,280 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package ghpermsfakes
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/google/go-github/v58/github"
+)
+
+type FakeImpl struct {
+	AppPermissionsStub        func() (map[string]string, error)
+	appPermissionsMutex       sync.RWMutex
+	appPermissionsArgsForCall []struct {
+	}
+	appPermissionsReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	appPermissionsReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	GetRepositoryStub        func(string, string, string) (*github.Repository, http.Header, error)
+	getRepositoryMutex       sync.RWMutex
+	getRepositoryArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getRepositoryReturns struct {
+		result1 *github.Repository
+		result2 http.Header
+		result3 error
+	}
+	getRepositoryReturnsOnCall map[int]struct {
+		result1 *github.Repository
+		result2 http.Header
+		result3 error
+	}
+	TokenStub        func() (string, error)
+	tokenMutex       sync.RWMutex
+	tokenArgsForCall []struct {
+	}
+	tokenReturns struct {
+		result1 string
+		result2 error
+	}
+	tokenReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) AppPermissions() (map[string]string, error) {
+	fake.appPermissionsMutex.Lock()
+	ret, specificReturn := fake.appPermissionsReturnsOnCall[len(fake.appPermissionsArgsForCall)]
+	fake.appPermissionsArgsForCall = append(fake.appPermissionsArgsForCall, struct {
+	}{})
+	stub := fake.AppPermissionsStub
+	fakeReturns := fake.appPermissionsReturns
+	fake.recordInvocation("AppPermissions", []interface{}{})
+	fake.appPermissionsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) AppPermissionsCallCount() int {
+	fake.appPermissionsMutex.RLock()
+	defer fake.appPermissionsMutex.RUnlock()
+	return len(fake.appPermissionsArgsForCall)
+}
+
+func (fake *FakeImpl) AppPermissionsCalls(stub func() (map[string]string, error)) {
+	fake.appPermissionsMutex.Lock()
+	defer fake.appPermissionsMutex.Unlock()
+	fake.AppPermissionsStub = stub
+}
+
+func (fake *FakeImpl) AppPermissionsReturns(result1 map[string]string, result2 error) {
+	fake.appPermissionsMutex.Lock()
+	defer fake.appPermissionsMutex.Unlock()
+	fake.AppPermissionsStub = nil
+	fake.appPermissionsReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) AppPermissionsReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.appPermissionsMutex.Lock()
+	defer fake.appPermissionsMutex.Unlock()
+	fake.AppPermissionsStub = nil
+	if fake.appPermissionsReturnsOnCall == nil {
+		fake.appPermissionsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.appPermissionsReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GetRepository(arg1 string, arg2 string, arg3 string) (*github.Repository, http.Header, error) {
+	fake.getRepositoryMutex.Lock()
+	ret, specificReturn := fake.getRepositoryReturnsOnCall[len(fake.getRepositoryArgsForCall)]
+	fake.getRepositoryArgsForCall = append(fake.getRepositoryArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetRepositoryStub
+	fakeReturns := fake.getRepositoryReturns
+	fake.recordInvocation("GetRepository", []interface{}{arg1, arg2, arg3})
+	fake.getRepositoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeImpl) GetRepositoryCallCount() int {
+	fake.getRepositoryMutex.RLock()
+	defer fake.getRepositoryMutex.RUnlock()
+	return len(fake.getRepositoryArgsForCall)
+}
+
+func (fake *FakeImpl) GetRepositoryCalls(stub func(string, string, string) (*github.Repository, http.Header, error)) {
+	fake.getRepositoryMutex.Lock()
+	defer fake.getRepositoryMutex.Unlock()
+	fake.GetRepositoryStub = stub
+}
+
+func (fake *FakeImpl) GetRepositoryArgsForCall(i int) (string, string, string) {
+	fake.getRepositoryMutex.RLock()
+	defer fake.getRepositoryMutex.RUnlock()
+	argsForCall := fake.getRepositoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) GetRepositoryReturns(result1 *github.Repository, result2 http.Header, result3 error) {
+	fake.getRepositoryMutex.Lock()
+	defer fake.getRepositoryMutex.Unlock()
+	fake.GetRepositoryStub = nil
+	fake.getRepositoryReturns = struct {
+		result1 *github.Repository
+		result2 http.Header
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImpl) GetRepositoryReturnsOnCall(i int, result1 *github.Repository, result2 http.Header, result3 error) {
+	fake.getRepositoryMutex.Lock()
+	defer fake.getRepositoryMutex.Unlock()
+	fake.GetRepositoryStub = nil
+	if fake.getRepositoryReturnsOnCall == nil {
+		fake.getRepositoryReturnsOnCall = make(map[int]struct {
+			result1 *github.Repository
+			result2 http.Header
+			result3 error
+		})
+	}
+	fake.getRepositoryReturnsOnCall[i] = struct {
+		result1 *github.Repository
+		result2 http.Header
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImpl) Token() (string, error) {
+	fake.tokenMutex.Lock()
+	ret, specificReturn := fake.tokenReturnsOnCall[len(fake.tokenArgsForCall)]
+	fake.tokenArgsForCall = append(fake.tokenArgsForCall, struct {
+	}{})
+	stub := fake.TokenStub
+	fakeReturns := fake.tokenReturns
+	fake.recordInvocation("Token", []interface{}{})
+	fake.tokenMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) TokenCallCount() int {
+	fake.tokenMutex.RLock()
+	defer fake.tokenMutex.RUnlock()
+	return len(fake.tokenArgsForCall)
+}
+
+func (fake *FakeImpl) TokenCalls(stub func() (string, error)) {
+	fake.tokenMutex.Lock()
+	defer fake.tokenMutex.Unlock()
+	fake.TokenStub = stub
+}
+
+func (fake *FakeImpl) TokenReturns(result1 string, result2 error) {
+	fake.tokenMutex.Lock()
+	defer fake.tokenMutex.Unlock()
+	fake.TokenStub = nil
+	fake.tokenReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) TokenReturnsOnCall(i int, result1 string, result2 error) {
+	fake.tokenMutex.Lock()
+	defer fake.tokenMutex.Unlock()
+	fake.TokenStub = nil
+	if fake.tokenReturnsOnCall == nil {
+		fake.tokenReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.tokenReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.appPermissionsMutex.RLock()
+	defer fake.appPermissionsMutex.RUnlock()
+	fake.getRepositoryMutex.RLock()
+	defer fake.getRepositoryMutex.RUnlock()
+	fake.tokenMutex.RLock()
+	defer fake.tokenMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
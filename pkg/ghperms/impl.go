@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghperms
+
+import (
+	"context"
+	"net/http"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"golang.org/x/oauth2"
+
+	"k8s.io/release/pkg/ghauth"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt ghpermsfakes/fake_impl.go > ghpermsfakes/_fake_impl.go && mv ghpermsfakes/_fake_impl.go ghpermsfakes/fake_impl.go"
+type impl interface {
+	Token() (string, error)
+	AppPermissions() (map[string]string, error)
+	// GetRepository returns the repository together with the response
+	// headers, which contain the scopes of classic tokens.
+	GetRepository(token, owner, repo string) (*gogithub.Repository, http.Header, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) Token() (string, error) {
+	return ghauth.Token()
+}
+
+func (*defaultImpl) AppPermissions() (map[string]string, error) {
+	return ghauth.Permissions()
+}
+
+func (*defaultImpl) GetRepository(token, owner, repo string) (*gogithub.Repository, http.Header, error) {
+	client := gogithub.NewClient(oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	)))
+	repository, resp, err := client.Repositories.Get(context.Background(), owner, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return repository, resp.Header, nil
+}
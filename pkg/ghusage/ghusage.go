@@ -0,0 +1,337 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ghusage tracks the GitHub API calls of a run by endpoint category.
+// A configurable budget stops the run between two release steps before the
+// rate limit of the token gets exhausted, while a reserve of the rate limit
+// is never touched.
+package ghusage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+)
+
+// DefaultReserve is the default number of remaining rate limit calls which
+// are never used.
+const DefaultReserve = 100
+
+// ErrRateLimitReserve is returned for requests which would use the reserve
+// of the rate limit.
+var ErrRateLimitReserve = errors.New("GitHub API rate limit reserve reached")
+
+// hosts are the GitHub API hosts whose requests get tracked.
+var hosts = map[string]bool{
+	"api.github.com":     true,
+	"uploads.github.com": true,
+}
+
+var numericRE = regexp.MustCompile(`^\d+$`)
+
+// Options are the options for tracking the GitHub API usage.
+type Options struct {
+	// Budget is the maximum number of GitHub API calls of the run. Reaching
+	// it stops the run before the next release step. Zero means unlimited.
+	Budget int64
+
+	// Reserve is the number of remaining rate limit calls of the token,
+	// below which all GitHub API requests fail.
+	Reserve int64
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{Reserve: DefaultReserve}
+}
+
+// AddFlags adds the GitHub API usage flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.Int64Var(
+		&o.Budget,
+		"github-api-budget",
+		o.Budget,
+		"maximum number of GitHub API calls, reaching it stops the run before the next release step (default unlimited)",
+	)
+	flags.Int64Var(
+		&o.Reserve,
+		"github-api-reserve",
+		o.Reserve,
+		"remaining GitHub API rate limit below which all GitHub API requests fail",
+	)
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Budget < 0 {
+		return fmt.Errorf("invalid GitHub API budget %d", o.Budget)
+	}
+	if o.Reserve < 0 {
+		return fmt.Errorf("invalid GitHub API rate limit reserve %d", o.Reserve)
+	}
+	return nil
+}
+
+// Tracker counts the GitHub API calls and observes the rate limit.
+type Tracker struct {
+	options *Options
+
+	mu          sync.Mutex
+	calls       map[string]int64
+	total       int64
+	limit       int64
+	remaining   int64
+	reset       time.Time
+	exhausted   bool
+	onExhausted func()
+}
+
+// New creates a new Tracker for the provided options.
+func New(opts *Options) *Tracker {
+	return &Tracker{
+		options:   opts,
+		calls:     map[string]int64{},
+		remaining: -1,
+	}
+}
+
+// OnExhausted sets the function called once the budget got exhausted, for
+// example to cancel the run.
+func (t *Tracker) OnExhausted(fn func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onExhausted = fn
+}
+
+// Total returns the number of tracked calls.
+func (t *Tracker) Total() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total
+}
+
+// Calls returns the number of tracked calls per endpoint category.
+func (t *Tracker) Calls() map[string]int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	res := make(map[string]int64, len(t.calls))
+	for category, calls := range t.calls {
+		res[category] = calls
+	}
+	return res
+}
+
+// Exhausted returns true if the budget got exhausted.
+func (t *Tracker) Exhausted() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.exhausted
+}
+
+// begin accounts a new call of the category, failing if the call would use
+// the reserve of the rate limit.
+func (t *Tracker) begin(category string) error {
+	t.mu.Lock()
+	if t.remaining >= 0 && t.remaining <= t.options.Reserve {
+		remaining, reset := t.remaining, t.reset
+		t.mu.Unlock()
+		return fmt.Errorf(
+			"%w: %d calls remaining, resets at %s",
+			ErrRateLimitReserve, remaining, reset.Format(time.RFC3339),
+		)
+	}
+
+	t.calls[category]++
+	t.total++
+
+	var onExhausted func()
+	if t.options.Budget > 0 && t.total >= t.options.Budget && !t.exhausted {
+		t.exhausted = true
+		onExhausted = t.onExhausted
+	}
+	t.mu.Unlock()
+
+	if onExhausted != nil {
+		logrus.Warnf(
+			"GitHub API budget of %d calls exhausted, cancelling the run",
+			t.options.Budget,
+		)
+		onExhausted()
+	}
+	return nil
+}
+
+// observe updates the rate limit from the response headers.
+func (t *Tracker) observe(header http.Header) {
+	remaining, err := strconv.ParseInt(header.Get("X-RateLimit-Remaining"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.remaining = remaining
+	if limit, err := strconv.ParseInt(header.Get("X-RateLimit-Limit"), 10, 64); err == nil {
+		t.limit = limit
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		t.reset = time.Unix(reset, 0).UTC()
+	}
+}
+
+// Report writes the calls by endpoint category, ordered by their number, and
+// the last observed rate limit to w.
+func (t *Tracker) Report(w io.Writer) error {
+	calls := t.Calls()
+	categories := make([]string, 0, len(calls))
+	for category := range calls {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if calls[categories[i]] != calls[categories[j]] {
+			return calls[categories[i]] > calls[categories[j]]
+		}
+		return categories[i] < categories[j]
+	})
+
+	t.mu.Lock()
+	total, limit, remaining, reset := t.total, t.limit, t.remaining, t.reset
+	t.mu.Unlock()
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "GITHUB API CATEGORY\tCALLS")
+	for _, category := range categories {
+		fmt.Fprintf(tw, "%s\t%d\n", category, calls[category])
+	}
+	fmt.Fprintf(tw, "total\t%d\n", total)
+	if remaining >= 0 {
+		fmt.Fprintf(
+			tw, "rate limit\t%d/%d remaining, resets at %s\n",
+			remaining, limit, reset.Format(time.RFC3339),
+		)
+	}
+	return tw.Flush()
+}
+
+// Category returns the endpoint category of the GitHub API request path,
+// for example "repos/pulls" for "/repos/kubernetes/kubernetes/pulls/1".
+func Category(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case segments[0] == "":
+		return "root"
+	case segments[0] == "repos":
+		if len(segments) > 3 {
+			return "repos/" + segments[3]
+		}
+		return "repos"
+	case segments[0] == "orgs" || segments[0] == "users":
+		if len(segments) > 2 {
+			return segments[0] + "/" + segments[2]
+		}
+		return segments[0]
+	case len(segments) > 1 && !numericRE.MatchString(segments[1]):
+		return segments[0] + "/" + segments[1]
+	default:
+		return segments[0]
+	}
+}
+
+// Transport is a http.RoundTripper which tracks the GitHub API calls.
+type Transport struct {
+	// Base is the underlying round tripper, http.DefaultTransport if nil.
+	Base http.RoundTripper
+
+	// Tracker is the tracker of the calls.
+	Tracker *Tracker
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if !hosts[req.URL.Hostname()] {
+		return base.RoundTrip(req)
+	}
+
+	if err := t.Tracker.begin(Category(req.URL.Path)); err != nil {
+		return nil, err
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.Tracker.observe(resp.Header)
+	return resp, nil
+}
+
+var (
+	mu      sync.RWMutex
+	current *Tracker
+)
+
+// Setup starts tracking the GitHub API calls of the process by wrapping
+// http.DefaultTransport.
+func Setup(opts *Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	tracker := New(opts)
+	http.DefaultTransport = &Transport{Base: http.DefaultTransport, Tracker: tracker}
+	if opts.Budget > 0 {
+		logrus.Infof("Using a GitHub API budget of %d calls", opts.Budget)
+	}
+	SetDefault(tracker)
+	return nil
+}
+
+// SetDefault sets the tracker of the process.
+func SetDefault(t *Tracker) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = t
+}
+
+// Default returns the tracker of the process, which is nil if not set up.
+func Default() *Tracker {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// ActiveOptions returns the options of the tracker of the process, or nil if
+// not set up.
+func ActiveOptions() *Options {
+	t := Default()
+	if t == nil {
+		return nil
+	}
+	return t.options
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghusage_test
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/ghusage"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// rateLimitedBase returns responses with a decreasing remaining rate limit,
+// starting at the provided value.
+func rateLimitedBase(remaining int) (http.RoundTripper, *int) {
+	calls := 0
+	return roundTripFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		header := http.Header{}
+		header.Set("X-RateLimit-Limit", "5000")
+		header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining-calls))
+		header.Set("X-RateLimit-Reset", "1714557600")
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: http.NoBody}, nil
+	}), &calls
+}
+
+func get(t *testing.T, transport http.RoundTripper, url string) error {
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	return err
+}
+
+func TestCategory(t *testing.T) {
+	t.Parallel()
+
+	for path, expected := range map[string]string{
+		"/":                                    "root",
+		"/graphql":                             "graphql",
+		"/rate_limit":                          "rate_limit",
+		"/repos/kubernetes/kubernetes":         "repos",
+		"/repos/kubernetes/kubernetes/pulls/1": "repos/pulls",
+		"/repos/kubernetes/kubernetes/issues":  "repos/issues",
+		"/orgs/kubernetes/members":             "orgs/members",
+		"/users/foo":                           "users",
+		"/search/issues":                       "search/issues",
+		"/app/installations/123/access_tokens": "app/installations",
+		"/gists/123":                           "gists",
+	} {
+		require.Equal(t, expected, ghusage.Category(path), path)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	base, calls := rateLimitedBase(1000)
+	tracker := ghusage.New(&ghusage.Options{})
+	transport := &ghusage.Transport{Base: base, Tracker: tracker}
+
+	require.NoError(t, get(t, transport, "https://api.github.com/repos/kubernetes/kubernetes/pulls/1"))
+	require.NoError(t, get(t, transport, "https://api.github.com/repos/kubernetes/kubernetes/pulls/2"))
+	require.NoError(t, get(t, transport, "https://api.github.com/graphql"))
+	require.NoError(t, get(t, transport, "https://dl.k8s.io/release/stable.txt"))
+
+	require.Equal(t, 4, *calls)
+	require.EqualValues(t, 3, tracker.Total())
+	require.Equal(t, map[string]int64{"repos/pulls": 2, "graphql": 1}, tracker.Calls())
+	require.False(t, tracker.Exhausted())
+
+	out := &bytes.Buffer{}
+	require.NoError(t, tracker.Report(out))
+	require.Equal(t, `GITHUB API CATEGORY  CALLS
+repos/pulls          2
+graphql              1
+total                3
+rate limit           997/5000 remaining, resets at 2024-05-01T10:00:00Z
+`, out.String())
+}
+
+func TestBudget(t *testing.T) {
+	t.Parallel()
+
+	base, _ := rateLimitedBase(1000)
+	tracker := ghusage.New(&ghusage.Options{Budget: 2})
+	exhausted := 0
+	tracker.OnExhausted(func() { exhausted++ })
+	transport := &ghusage.Transport{Base: base, Tracker: tracker}
+
+	require.NoError(t, get(t, transport, "https://api.github.com/rate_limit"))
+	require.False(t, tracker.Exhausted())
+
+	// Requests in flight are not failed by the budget
+	for i := 0; i < 3; i++ {
+		require.NoError(t, get(t, transport, "https://api.github.com/rate_limit"))
+	}
+	require.True(t, tracker.Exhausted())
+	require.Equal(t, 1, exhausted)
+}
+
+func TestReserve(t *testing.T) {
+	t.Parallel()
+
+	base, calls := rateLimitedBase(12)
+	tracker := ghusage.New(&ghusage.Options{Reserve: 10})
+	transport := &ghusage.Transport{Base: base, Tracker: tracker}
+
+	// The rate limit is unknown before the first response
+	require.NoError(t, get(t, transport, "https://api.github.com/rate_limit"))
+	require.NoError(t, get(t, transport, "https://api.github.com/rate_limit"))
+
+	err := get(t, transport, "https://api.github.com/rate_limit")
+	require.ErrorIs(t, err, ghusage.ErrRateLimitReserve)
+	require.Equal(t, 2, *calls)
+	require.EqualValues(t, 2, tracker.Total())
+
+	// The error reports the actually remaining calls
+	base, _ = rateLimitedBase(8)
+	transport = &ghusage.Transport{Base: base, Tracker: ghusage.New(&ghusage.Options{Reserve: 10})}
+	require.NoError(t, get(t, transport, "https://api.github.com/rate_limit"))
+	require.ErrorContains(t, get(t, transport, "https://api.github.com/rate_limit"), ": 7 calls remaining")
+
+	// Other hosts are not affected
+	require.NoError(t, get(t, transport, "https://dl.k8s.io/release/stable.txt"))
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ghusage.DefaultOptions().Validate())
+	require.Error(t, (&ghusage.Options{Budget: -1}).Validate())
+	require.Error(t, (&ghusage.Options{Reserve: -1}).Validate())
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitclone contains the shallow and partial clone settings for the
+// git operations which do not need the full history of a repository, like
+// preparing a fork to update a single file or checking out a tag to rebuild
+// it. Cutting the history avoids multi-gigabyte clones of repositories like
+// kubernetes/kubernetes in CI runs.
+package gitclone
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/command"
+	"sigs.k8s.io/release-utils/env"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/workdir"
+)
+
+const (
+	// ShallowEnvKey is the environment variable enabling shallow clones by
+	// default.
+	ShallowEnvKey = "KREL_GIT_SHALLOW"
+
+	// FilterEnvKey is the environment variable containing the default
+	// partial clone filter.
+	FilterEnvKey = "KREL_GIT_FILTER"
+)
+
+// filterRE matches the partial clone filters supported by GitHub.
+var filterRE = regexp.MustCompile(`^(blob:none|blob:limit=\d+[kmg]?|tree:\d+)$`)
+
+// Options are the clone settings.
+type Options struct {
+	// Shallow fetches only the latest commit of the branches, or of the
+	// requested ref.
+	Shallow bool
+
+	// Filter is the partial clone filter, for example blob:none, which
+	// fetches the file contents on demand.
+	Filter string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	shallow, err := strconv.ParseBool(env.Default(ShallowEnvKey, "false"))
+	if err != nil {
+		logrus.Warnf("Ignoring invalid value of $%s: %v", ShallowEnvKey, err)
+	}
+	return &Options{
+		Shallow: shallow,
+		Filter:  env.Default(FilterEnvKey, ""),
+	}
+}
+
+// AddFlags adds the clone flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Shallow,
+		"shallow",
+		o.Shallow,
+		fmt.Sprintf("clone repositories without history for operations which do not need it (default $%s)", ShallowEnvKey),
+	)
+
+	flags.StringVar(
+		&o.Filter,
+		"filter",
+		o.Filter,
+		fmt.Sprintf("partial clone filter like blob:none for operations which do not need the full history (default $%s)", FilterEnvKey),
+	)
+}
+
+// Validate checks if the options are valid.
+func (o *Options) Validate() error {
+	if o.Filter != "" && !filterRE.MatchString(o.Filter) {
+		return fmt.Errorf(
+			"unsupported clone filter %q, expected blob:none, blob:limit=<n> or tree:<depth>",
+			o.Filter,
+		)
+	}
+	return nil
+}
+
+// Enabled returns true if the options reduce the cloned data.
+func (o *Options) Enabled() bool {
+	return o.Shallow || o.Filter != ""
+}
+
+// Args returns the arguments for `git clone`. The ref is checked out if
+// provided, which also makes it available in a shallow clone.
+func (o *Options) Args(ref string) []string {
+	args := []string{}
+	if o.Shallow {
+		args = append(args, "--depth=1")
+		if ref == "" {
+			args = append(args, "--no-single-branch")
+		}
+	}
+	if o.Filter != "" {
+		args = append(args, "--filter="+o.Filter)
+	}
+	if ref != "" {
+		args = append(args, "--branch="+ref)
+	}
+	return args
+}
+
+// GoGit returns the clone options for go-git based clones. Partial clones
+// are not supported by go-git, which means that only the depth is set.
+func (o *Options) GoGit() *gogit.CloneOptions {
+	opts := &gogit.CloneOptions{}
+	if o.Shallow {
+		opts.Depth = 1
+	}
+	if o.Filter != "" {
+		logrus.Debugf("Ignoring clone filter %s for go-git clone", o.Filter)
+	}
+	return opts
+}
+
+var (
+	mu      sync.RWMutex
+	current = &Options{}
+)
+
+// Setup sets the process wide clone options.
+func Setup(opts *Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if opts.Enabled() {
+		logrus.Infof("Using shallow (%v) and partial (%q) clones where possible", opts.Shallow, opts.Filter)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current = opts
+	return nil
+}
+
+// Default returns the process wide clone options.
+func Default() *Options {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// CloneOrOpenGitHubRepo works like git.CloneOrOpenGitHubRepo, but clones
+// new repositories with the default options. The ref is checked out after
+// cloning if provided. Existing repositories are opened and updated as
+// usual.
+func CloneOrOpenGitHubRepo(repoPath, owner, repo, ref string, useSSH bool) (*git.Repo, error) {
+	opts := Default()
+	if !opts.Enabled() || (repoPath != "" && util.Exists(repoPath) && !isEmptyDir(repoPath)) {
+		return git.CloneOrOpenGitHubRepo(repoPath, owner, repo, useSSH)
+	}
+
+	if repoPath == "" {
+		dir, err := workdir.MkdirTemp("k8s-")
+		if err != nil {
+			return nil, fmt.Errorf("create clone directory: %w", err)
+		}
+		repoPath = dir
+	}
+
+	url := git.GetRepoURL(owner, repo, useSSH)
+	args := append([]string{"clone"}, opts.Args(ref)...)
+	args = append(args, url, repoPath)
+	logrus.Infof("Cloning %s/%s to %s (%v)", owner, repo, repoPath, opts.Args(ref))
+	if err := command.New("git", args...).RunSilentSuccess(); err != nil {
+		return nil, fmt.Errorf("clone %s: %w", url, err)
+	}
+	r, err := git.OpenRepo(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("open clone: %w", err)
+	}
+	return r, nil
+}
+
+// isEmptyDir returns true if the provided path is an empty directory.
+func isEmptyDir(path string) bool {
+	entries, err := os.ReadDir(path)
+	return err == nil && len(entries) == 0
+}
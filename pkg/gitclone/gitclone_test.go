@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitclone_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/gitclone"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		filter      string
+		shouldError bool
+	}{
+		{filter: ""},
+		{filter: "blob:none"},
+		{filter: "blob:limit=1m"},
+		{filter: "tree:0"},
+		{filter: "blob", shouldError: true},
+		{filter: "sparse:oid=abc", shouldError: true},
+	} {
+		err := (&gitclone.Options{Filter: tc.filter}).Validate()
+		if tc.shouldError {
+			require.Error(t, err, tc.filter)
+		} else {
+			require.NoError(t, err, tc.filter)
+		}
+	}
+}
+
+func TestArgs(t *testing.T) {
+	for _, tc := range []struct {
+		opts     gitclone.Options
+		ref      string
+		expected []string
+	}{
+		{opts: gitclone.Options{}, expected: []string{}},
+		{opts: gitclone.Options{Shallow: true}, expected: []string{"--depth=1", "--no-single-branch"}},
+		{opts: gitclone.Options{Shallow: true}, ref: "v1.30.0", expected: []string{"--depth=1", "--branch=v1.30.0"}},
+		{opts: gitclone.Options{Filter: "blob:none"}, expected: []string{"--filter=blob:none"}},
+		{
+			opts:     gitclone.Options{Shallow: true, Filter: "blob:none"},
+			ref:      "master",
+			expected: []string{"--depth=1", "--filter=blob:none", "--branch=master"},
+		},
+	} {
+		require.Equal(t, tc.expected, tc.opts.Args(tc.ref))
+		require.Equal(t, tc.opts.Shallow || tc.opts.Filter != "", tc.opts.Enabled())
+	}
+}
+
+func TestGoGit(t *testing.T) {
+	require.Zero(t, (&gitclone.Options{Filter: "blob:none"}).GoGit().Depth)
+	require.Equal(t, 1, (&gitclone.Options{Shallow: true}).GoGit().Depth)
+}
+
+func TestSetup(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, gitclone.Setup(&gitclone.Options{})) })
+
+	require.Error(t, gitclone.Setup(&gitclone.Options{Filter: "invalid"}))
+	require.False(t, gitclone.Default().Enabled())
+
+	opts := &gitclone.Options{Shallow: true}
+	require.NoError(t, gitclone.Setup(opts))
+	require.Same(t, opts, gitclone.Default())
+}
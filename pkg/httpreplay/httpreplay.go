@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httpreplay records and replays HTTP interactions. It allows tests
+// of the network paths, like the GitHub, Google Cloud Storage and container
+// registry ones, to run hermetically from recorded cassettes.
+package httpreplay
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/release/pkg/redact"
+)
+
+// ErrNoInteraction is returned on replay if the cassette does not contain an
+// unused interaction for a request.
+var ErrNoInteraction = errors.New("no recorded interaction")
+
+// skippedHeaders are the response headers which are never recorded.
+var skippedHeaders = map[string]bool{
+	"Authorization": true,
+	"Date":          true,
+	"Set-Cookie":    true,
+}
+
+// Cassette is a list of recorded HTTP interactions.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request. Requests are matched by their method
+// and URL, the body is only recorded for reference.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int               `json:"status"`
+	Header     map[string]string `json:"header,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// Load reads a cassette from the provided path.
+func Load(path string) (*Cassette, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+	cassette := &Cassette{}
+	if err := yaml.UnmarshalStrict(content, cassette); err != nil {
+		return nil, fmt.Errorf("unmarshal cassette %s: %w", path, err)
+	}
+	return cassette, nil
+}
+
+// Save writes the cassette to the provided path.
+func (c *Cassette) Save(path string) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshal cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil { //nolint:gosec // cassettes are checked in
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+// Recorder is an http.RoundTripper which either records the interactions
+// of an underlying transport or replays them from a cassette.
+type Recorder struct {
+	mu       sync.Mutex
+	base     http.RoundTripper
+	cassette *Cassette
+	used     []bool
+}
+
+// NewRecorder returns a Recorder which records the interactions of the
+// provided transport. Credentials get redacted from the recorded bodies.
+func NewRecorder(base http.RoundTripper) *Recorder {
+	return &Recorder{base: base, cassette: &Cassette{}}
+}
+
+// NewReplayer returns a Recorder which replays the interactions of the
+// provided cassette. Every interaction gets replayed only once, in the
+// recorded order.
+func NewReplayer(cassette *Cassette) *Recorder {
+	return &Recorder{
+		cassette: cassette,
+		used:     make([]bool, len(cassette.Interactions)),
+	}
+}
+
+// Cassette returns the recorded or replayed cassette.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette
+}
+
+// Unused returns the interactions which have not been replayed yet.
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	unused := []*Interaction{}
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.base == nil {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] ||
+			interaction.Request.Method != req.Method ||
+			interaction.Request.URL != req.URL.String() {
+			continue
+		}
+		r.used[i] = true
+
+		header := http.Header{}
+		for key, value := range interaction.Response.Header {
+			header.Set(key, value)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		reqBody = body
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := map[string]string{}
+	for key := range resp.Header {
+		if !skippedHeaders[key] {
+			header[key] = redact.String(resp.Header.Get(key))
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    redact.String(req.URL.String()),
+			Body:   redact.String(string(reqBody)),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       redact.String(string(respBody)),
+		},
+	})
+	return resp, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpreplay
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// RecordEnvKey is the environment variable which enables recording of the
+// cassettes used by Use instead of replaying them.
+const RecordEnvKey = "KREL_HTTP_RECORD"
+
+// Use replaces http.DefaultTransport and the default transport of the
+// container registry client with a Recorder for the duration of the test.
+//
+// The interactions get replayed from the cassette at path by default and the
+// test fails if not all of them got used. If $KREL_HTTP_RECORD is true, then
+// the real network gets used and the cassette is written on cleanup.
+func Use(t testing.TB, path string) {
+	t.Helper()
+
+	defaultTransport := http.DefaultTransport
+	registryTransport := remote.DefaultTransport
+
+	var recorder *Recorder
+	record, _ := strconv.ParseBool(os.Getenv(RecordEnvKey)) //nolint:errcheck // only true enables recording
+	if record {
+		recorder = NewRecorder(defaultTransport)
+	} else {
+		cassette, err := Load(path)
+		if err != nil {
+			t.Fatalf("Unable to load cassette: %v", err)
+		}
+		recorder = NewReplayer(cassette)
+	}
+
+	http.DefaultTransport = recorder
+	remote.DefaultTransport = recorder
+
+	t.Cleanup(func() {
+		http.DefaultTransport = defaultTransport
+		remote.DefaultTransport = registryTransport
+
+		if record {
+			if err := recorder.Cassette().Save(path); err != nil {
+				t.Errorf("Unable to save cassette: %v", err)
+			}
+			return
+		}
+		for _, interaction := range recorder.Unused() {
+			t.Errorf(
+				"Interaction %s %s of cassette %s not used",
+				interaction.Request.Method, interaction.Request.URL, path,
+			)
+		}
+	})
+}
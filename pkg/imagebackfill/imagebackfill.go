@@ -0,0 +1,411 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagebackfill
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/platforms"
+	"k8s.io/release/pkg/release"
+)
+
+// Options are the main options for backfilling release images.
+type Options struct {
+	// Version is the release version to be repaired, for example v1.30.1.
+	Version string
+
+	// StagingRegistry is the registry the images get promoted from.
+	StagingRegistry string
+
+	// ProductionRegistry is the registry the missing images get promoted to.
+	ProductionRegistry string
+
+	// Images are the names of the release images to be checked.
+	Images []string
+
+	// Architectures are the architectures every image has to exist for,
+	// which default to the image architectures of the platform matrix.
+	Architectures []string
+
+	// Fork is the GitHub organization of the kubernetes/k8s.io fork used for
+	// opening the image promotion pull request.
+	Fork string
+
+	// UseSSH specifies if the fork should be pushed via SSH.
+	UseSSH bool
+
+	// Confirm opens the image promotion pull request for the missing images
+	// if set, otherwise they're only reported.
+	Confirm bool
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		StagingRegistry:    release.GCRIOPathStaging,
+		ProductionRegistry: release.GCRIOPathProd,
+		Images:             release.ManifestImages,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Version == "" {
+		return errors.New("no version specified")
+	}
+	if _, err := util.TagStringToSemver(o.Version); err != nil {
+		return fmt.Errorf("invalid version %s: %w", o.Version, err)
+	}
+	if o.StagingRegistry == "" {
+		return errors.New("no staging registry specified")
+	}
+	if o.ProductionRegistry == "" {
+		return errors.New("no production registry specified")
+	}
+	if len(o.Images) == 0 {
+		return errors.New("no images specified")
+	}
+	if o.Confirm && o.Fork == "" {
+		return errors.New("a fork is required to open the image promotion pull request")
+	}
+	return nil
+}
+
+// Missing is a single image which does not exist on the production registry.
+type Missing struct {
+	// Image is the name of the release image.
+	Image string `json:"image"`
+
+	// Architecture is the missing architecture, or empty if the manifest
+	// list is missing or incomplete.
+	Architecture string `json:"architecture,omitempty"`
+
+	// Source is the staging reference to be promoted.
+	Source string `json:"source"`
+
+	// Digest is the digest of the staging reference.
+	Digest string `json:"digest"`
+
+	// Destination is the production reference to be created.
+	Destination string `json:"destination"`
+}
+
+// Name returns the name of the image in the image promoter manifest.
+func (m *Missing) Name() string {
+	if m.Architecture == "" {
+		return m.Image
+	}
+	return m.Image + "-" + m.Architecture
+}
+
+// Report contains all missing images of a release.
+type Report struct {
+	Version            string     `json:"version"`
+	StagingRegistry    string     `json:"stagingRegistry"`
+	ProductionRegistry string     `json:"productionRegistry"`
+	Missing            []*Missing `json:"missing"`
+
+	// PullRequest is the number of the image promotion pull request, if
+	// opened.
+	PullRequest int `json:"pullRequest,omitempty"`
+}
+
+// Markdown returns a markdown table of all missing images in the report.
+func (r *Report) Markdown() string {
+	buf := &bytes.Buffer{}
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeader([]string{"Image", "Architecture", "Digest", "Destination"})
+	for _, m := range r.Missing {
+		arch := m.Architecture
+		if arch == "" {
+			arch = "manifest list"
+		}
+		table.Append([]string{m.Image, arch, m.Digest, m.Destination})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+	return buf.String()
+}
+
+// Backfiller is the main structure for repairing partially promoted release
+// images.
+type Backfiller struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Backfiller instance.
+func New(opts *Options) *Backfiller {
+	return &Backfiller{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (b *Backfiller) SetImpl(impl impl) {
+	b.impl = impl
+}
+
+// Detect returns the report of all images which are missing on the
+// production registry. It fails if a missing image does not exist on the
+// staging registry either, because it cannot be backfilled then.
+func (b *Backfiller) Detect() (*Report, error) {
+	if err := b.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+	if len(b.options.Architectures) == 0 {
+		b.options.Architectures = platforms.Default().ImageArchitectures()
+	}
+
+	report := &Report{
+		Version:            b.options.Version,
+		StagingRegistry:    b.options.StagingRegistry,
+		ProductionRegistry: b.options.ProductionRegistry,
+		Missing:            []*Missing{},
+	}
+
+	for _, image := range b.options.Images {
+		missing, err := b.detectImage(image)
+		if err != nil {
+			return nil, fmt.Errorf("detect missing %s images: %w", image, err)
+		}
+		report.Missing = append(report.Missing, missing...)
+	}
+	return report, nil
+}
+
+// Run detects the missing images and opens a pull request adding their
+// digests to the image promoter manifest if the options are confirmed. The
+// production registry is served by the image promoter, which is why the
+// images cannot be copied there directly.
+func (b *Backfiller) Run() error {
+	report, err := b.Detect()
+	if err != nil {
+		return err
+	}
+
+	if len(report.Missing) == 0 {
+		logrus.Infof(
+			"All images of %s exist on %s, nothing to backfill",
+			b.options.Version, b.options.ProductionRegistry,
+		)
+		return nil
+	}
+
+	fmt.Print(report.Markdown())
+	if !b.options.Confirm {
+		logrus.Infof(
+			"Found %d missing images, not opening the image promotion pull request in mock mode",
+			len(report.Missing),
+		)
+		return nil
+	}
+
+	if err := b.createPullRequest(report); err != nil {
+		return fmt.Errorf("create image promotion pull request: %w", err)
+	}
+	logrus.Infof(
+		"Successfully created PR to promote %d images of %s: https://github.com/%s/%s/pull/%d",
+		len(report.Missing), b.options.Version, PromoterOrg, PromoterRepo, report.PullRequest,
+	)
+	return nil
+}
+
+// createPullRequest adds the missing images to the image promoter manifest on
+// a branch of the fork and opens the pull request against kubernetes/k8s.io.
+func (b *Backfiller) createPullRequest(report *Report) (err error) {
+	branch := "backfill-images-" + strings.ReplaceAll(b.options.Version, "+", "_")
+	repo, err := b.impl.PrepareFork(branch, b.options.Fork, b.options.UseSSH)
+	if err != nil {
+		return fmt.Errorf("prepare fork: %w", err)
+	}
+	defer func() {
+		if cleanupErr := b.impl.Cleanup(repo); cleanupErr != nil && err == nil {
+			err = fmt.Errorf("cleanup repository: %w", cleanupErr)
+		}
+	}()
+
+	path := filepath.Join(b.impl.RepoDir(repo), PromoterManifestPath)
+	manifest, err := b.impl.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read image promoter manifest: %w", err)
+	}
+	manifest, err = report.PromoterManifest(manifest)
+	if err != nil {
+		return err
+	}
+	if err := b.impl.WriteFile(path, manifest); err != nil {
+		return fmt.Errorf("write image promoter manifest: %w", err)
+	}
+	if err := b.impl.Add(repo, PromoterManifestPath); err != nil {
+		return fmt.Errorf("add image promoter manifest: %w", err)
+	}
+
+	title := "Backfill missing images of Kubernetes " + b.options.Version
+	if err := b.impl.Commit(repo, title); err != nil {
+		return fmt.Errorf("commit changes: %w", err)
+	}
+
+	logrus.Infof("Pushing branch %s to %s", branch, b.options.Fork)
+	if err := b.impl.PushToRemote(repo, branch); err != nil {
+		return fmt.Errorf("push branch: %w", err)
+	}
+
+	body := "#### What this PR does / why we need it:\n\n"
+	body += fmt.Sprintf(
+		"Promotes the images of Kubernetes %s which are missing on %s after a partial promotion:\n\n",
+		b.options.Version, b.options.ProductionRegistry,
+	)
+	body += report.Markdown() + "\n"
+	body += "This is an automated PR generated from `krel The Kubernetes Release Toolbox`\n"
+
+	report.PullRequest, err = b.impl.CreatePullRequest(
+		fmt.Sprintf("%s:%s", b.options.Fork, branch), title, body,
+	)
+	if err != nil {
+		return fmt.Errorf("creating the pull request: %w", err)
+	}
+	return nil
+}
+
+// detectImage returns the missing architecture images and manifest list of
+// a single release image.
+func (b *Backfiller) detectImage(image string) ([]*Missing, error) {
+	version := strings.ReplaceAll(b.options.Version, "+", "_")
+	res := []*Missing{}
+
+	for _, arch := range b.options.Architectures {
+		name := fmt.Sprintf("%s-%s:%s", image, arch, version)
+		m, err := b.detect(image, name)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			m.Architecture = arch
+			res = append(res, m)
+		}
+	}
+
+	name := fmt.Sprintf("%s:%s", image, version)
+	dst := b.options.ProductionRegistry + "/" + name
+	manifest, found, err := b.impl.Manifest(dst)
+	if err != nil {
+		return nil, fmt.Errorf("get manifest list %s: %w", dst, err)
+	}
+	if found {
+		arches, err := manifestArchitectures(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("parse manifest list %s: %w", dst, err)
+		}
+		if b.hasArchitectures(arches) {
+			return res, nil
+		}
+		logrus.Infof("Manifest list %s is missing architectures", dst)
+	}
+
+	src := b.options.StagingRegistry + "/" + name
+	manifest, found, err = b.impl.Manifest(src)
+	if err != nil {
+		return nil, fmt.Errorf("get manifest list %s: %w", src, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("manifest list %s does not exist", src)
+	}
+	arches, err := manifestArchitectures(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("parse manifest list %s: %w", src, err)
+	}
+	if !b.hasArchitectures(arches) {
+		return nil, fmt.Errorf("manifest list %s is missing architectures as well", src)
+	}
+
+	digest, err := b.impl.Digest(src)
+	if err != nil {
+		return nil, fmt.Errorf("get digest of %s: %w", src, err)
+	}
+	return append(res, &Missing{Image: image, Source: src, Digest: digest, Destination: dst}), nil
+}
+
+// detect returns the missing image for the name, or nil if it exists on the
+// production registry.
+func (b *Backfiller) detect(image, name string) (*Missing, error) {
+	dst := b.options.ProductionRegistry + "/" + name
+	_, found, err := b.impl.Manifest(dst)
+	if err != nil {
+		return nil, fmt.Errorf("get manifest %s: %w", dst, err)
+	}
+	if found {
+		return nil, nil
+	}
+	logrus.Infof("Image %s does not exist", dst)
+
+	src := b.options.StagingRegistry + "/" + name
+	_, found, err = b.impl.Manifest(src)
+	if err != nil {
+		return nil, fmt.Errorf("get manifest %s: %w", src, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("image %s does not exist", src)
+	}
+	digest, err := b.impl.Digest(src)
+	if err != nil {
+		return nil, fmt.Errorf("get digest of %s: %w", src, err)
+	}
+	return &Missing{Image: image, Source: src, Digest: digest, Destination: dst}, nil
+}
+
+// hasArchitectures returns true if all configured architectures are part of
+// the provided ones.
+func (b *Backfiller) hasArchitectures(arches map[string]bool) bool {
+	for _, arch := range b.options.Architectures {
+		if !arches[arch] {
+			return false
+		}
+	}
+	return true
+}
+
+// manifestArchitectures returns the architectures of a manifest list.
+func manifestArchitectures(manifest []byte) (map[string]bool, error) {
+	index, err := v1.ParseIndexManifest(bytes.NewReader(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal manifest list: %w", err)
+	}
+
+	res := map[string]bool{}
+	for _, m := range index.Manifests {
+		if m.Platform != nil {
+			res[m.Platform.Architecture] = true
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagebackfill_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/imagebackfill"
+	"k8s.io/release/pkg/imagebackfill/imagebackfillfakes"
+)
+
+var errTest = errors.New("test")
+
+const (
+	staging    = "gcr.io/k8s-staging-kubernetes"
+	production = "registry.k8s.io"
+	version    = "v1.30.1"
+
+	fullList    = `{"manifests":[{"platform":{"architecture":"amd64"}},{"platform":{"architecture":"arm64"}}]}`
+	partialList = `{"manifests":[{"platform":{"architecture":"amd64"}}]}`
+)
+
+func newOptions(confirm bool) *imagebackfill.Options {
+	opts := imagebackfill.DefaultOptions()
+	opts.Version = version
+	opts.StagingRegistry = staging
+	opts.ProductionRegistry = production
+	opts.Images = []string{"kube-proxy"}
+	opts.Architectures = []string{"amd64", "arm64"}
+	opts.Confirm = confirm
+	return opts
+}
+
+// registry returns a Manifest implementation serving the provided manifests.
+func registry(manifests map[string]string) func(string) ([]byte, bool, error) {
+	return func(ref string) ([]byte, bool, error) {
+		manifest, ok := manifests[ref]
+		if !ok {
+			return nil, false, nil
+		}
+		return []byte(manifest), true, nil
+	}
+}
+
+// complete returns the manifests of a fully promoted release.
+func complete() map[string]string {
+	res := map[string]string{}
+	for _, reg := range []string{staging, production} {
+		res[reg+"/kube-proxy:"+version] = fullList
+		for _, arch := range []string{"amd64", "arm64"} {
+			res[reg+"/kube-proxy-"+arch+":"+version] = "{}"
+		}
+	}
+	return res
+}
+
+func TestDetect(t *testing.T) {
+	for _, tc := range []struct {
+		opts    *imagebackfill.Options
+		prepare func(map[string]string, *imagebackfillfakes.FakeImpl)
+		assert  func(*imagebackfill.Report, error)
+	}{
+		{ // nothing missing
+			opts: newOptions(false),
+			assert: func(report *imagebackfill.Report, err error) {
+				require.NoError(t, err)
+				require.Empty(t, report.Missing)
+			},
+		},
+		{ // missing architecture image and manifest list entry
+			opts: newOptions(false),
+			prepare: func(m map[string]string, _ *imagebackfillfakes.FakeImpl) {
+				delete(m, production+"/kube-proxy-arm64:"+version)
+				m[production+"/kube-proxy:"+version] = partialList
+			},
+			assert: func(report *imagebackfill.Report, err error) {
+				require.NoError(t, err)
+				require.Len(t, report.Missing, 2)
+				require.Equal(t, "arm64", report.Missing[0].Architecture)
+				require.Equal(t, staging+"/kube-proxy-arm64:"+version, report.Missing[0].Source)
+				require.Equal(t, production+"/kube-proxy-arm64:"+version, report.Missing[0].Destination)
+				require.Empty(t, report.Missing[1].Architecture)
+				require.Equal(t, production+"/kube-proxy:"+version, report.Missing[1].Destination)
+			},
+		},
+		{ // missing manifest list
+			opts: newOptions(false),
+			prepare: func(m map[string]string, _ *imagebackfillfakes.FakeImpl) {
+				delete(m, production+"/kube-proxy:"+version)
+			},
+			assert: func(report *imagebackfill.Report, err error) {
+				require.NoError(t, err)
+				require.Len(t, report.Missing, 1)
+				require.Equal(t, staging+"/kube-proxy:"+version, report.Missing[0].Source)
+			},
+		},
+		{ // missing on staging as well
+			opts: newOptions(false),
+			prepare: func(m map[string]string, _ *imagebackfillfakes.FakeImpl) {
+				delete(m, production+"/kube-proxy-arm64:"+version)
+				delete(m, staging+"/kube-proxy-arm64:"+version)
+			},
+			assert: func(_ *imagebackfill.Report, err error) {
+				require.ErrorContains(t, err, "does not exist")
+			},
+		},
+		{ // incomplete staging manifest list
+			opts: newOptions(false),
+			prepare: func(m map[string]string, _ *imagebackfillfakes.FakeImpl) {
+				m[production+"/kube-proxy:"+version] = partialList
+				m[staging+"/kube-proxy:"+version] = partialList
+			},
+			assert: func(_ *imagebackfill.Report, err error) {
+				require.ErrorContains(t, err, "missing architectures as well")
+			},
+		},
+		{ // registry failure
+			opts: newOptions(false),
+			prepare: func(_ map[string]string, mock *imagebackfillfakes.FakeImpl) {
+				mock.ManifestStub = nil
+				mock.ManifestReturns(nil, false, errTest)
+			},
+			assert: func(_ *imagebackfill.Report, err error) {
+				require.ErrorIs(t, err, errTest)
+			},
+		},
+		{ // invalid options
+			opts: imagebackfill.DefaultOptions(),
+			assert: func(_ *imagebackfill.Report, err error) {
+				require.ErrorContains(t, err, "no version specified")
+			},
+		},
+	} {
+		manifests := complete()
+		mock := &imagebackfillfakes.FakeImpl{}
+		mock.ManifestStub = registry(manifests)
+		if tc.prepare != nil {
+			tc.prepare(manifests, mock)
+		}
+
+		sut := imagebackfill.New(tc.opts)
+		sut.SetImpl(mock)
+		report, err := sut.Detect()
+		tc.assert(report, err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	const existing = `- name: kube-proxy
+  dmap:
+    "sha256:0000": ["v1.30.0"]
+`
+
+	for _, tc := range []struct {
+		confirm bool
+		fork    string
+		prErr   error
+		assert  func(*imagebackfillfakes.FakeImpl, error)
+	}{
+		{ // mock mode
+			assert: func(mock *imagebackfillfakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Zero(t, mock.PrepareForkCallCount())
+				require.Zero(t, mock.CreatePullRequestCallCount())
+			},
+		},
+		{ // promote missing images
+			confirm: true,
+			fork:    "user",
+			assert: func(mock *imagebackfillfakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				branch, fork, _ := mock.PrepareForkArgsForCall(0)
+				require.Equal(t, "backfill-images-"+version, branch)
+				require.Equal(t, "user", fork)
+
+				require.Equal(t, 1, mock.WriteFileCallCount())
+				path, content := mock.WriteFileArgsForCall(0)
+				require.True(t, strings.HasSuffix(path, imagebackfill.PromoterManifestPath))
+				require.Equal(t, `- name: kube-proxy
+  dmap:
+    "sha256:0000": ["v1.30.0"]
+    "sha256:1111": ["v1.30.1"]
+- name: kube-proxy-arm64
+  dmap:
+    "sha256:1111": ["v1.30.1"]
+`, string(content))
+
+				require.Equal(t, 1, mock.PushToRemoteCallCount())
+				require.Equal(t, 1, mock.CreatePullRequestCallCount())
+				head, _, body := mock.CreatePullRequestArgsForCall(0)
+				require.Equal(t, "user:backfill-images-"+version, head)
+				require.Contains(t, body, "kube-proxy")
+				require.Equal(t, 1, mock.CleanupCallCount())
+			},
+		},
+		{ // pull request failure
+			confirm: true,
+			fork:    "user",
+			prErr:   errTest,
+			assert: func(mock *imagebackfillfakes.FakeImpl, err error) {
+				require.ErrorIs(t, err, errTest)
+				require.Equal(t, 1, mock.CleanupCallCount())
+			},
+		},
+		{ // no fork
+			confirm: true,
+			assert: func(mock *imagebackfillfakes.FakeImpl, err error) {
+				require.ErrorContains(t, err, "fork is required")
+				require.Zero(t, mock.PrepareForkCallCount())
+			},
+		},
+	} {
+		manifests := complete()
+		delete(manifests, production+"/kube-proxy-arm64:"+version)
+		manifests[production+"/kube-proxy:"+version] = partialList
+
+		mock := &imagebackfillfakes.FakeImpl{}
+		mock.ManifestStub = registry(manifests)
+		mock.DigestReturns("sha256:1111", nil)
+		mock.ReadFileReturns([]byte(existing), nil)
+		mock.CreatePullRequestReturns(1, tc.prErr)
+
+		opts := newOptions(tc.confirm)
+		opts.Fork = tc.fork
+		sut := imagebackfill.New(opts)
+		sut.SetImpl(mock)
+		tc.assert(mock, sut.Run())
+	}
+}
+
+func TestPromoterManifest(t *testing.T) {
+	report := &imagebackfill.Report{
+		Version: "v1.30.1+abc",
+		Missing: []*imagebackfill.Missing{
+			{Image: "kube-apiserver", Architecture: "amd64", Digest: "sha256:2222"},
+			{Image: "conformance", Digest: "sha256:1111"},
+		},
+	}
+
+	manifest, err := report.PromoterManifest([]byte(`- name: kube-apiserver-amd64
+  dmap:
+    "sha256:2222": ["v1.30.1_abc"]
+`))
+	require.NoError(t, err)
+	require.Equal(t, `- name: conformance
+  dmap:
+    "sha256:1111": ["v1.30.1_abc"]
+- name: kube-apiserver-amd64
+  dmap:
+    "sha256:2222": ["v1.30.1_abc"]
+`, string(manifest))
+
+	report.Missing[0].Digest = ""
+	_, err = report.PromoterManifest(nil)
+	require.ErrorContains(t, err, "no digest")
+
+	_, err = report.PromoterManifest([]byte("invalid"))
+	require.Error(t, err)
+}
@@ -0,0 +1,916 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package imagebackfillfakes
+
+import (
+	"sync"
+
+	"sigs.k8s.io/release-sdk/git"
+)
+
+type FakeImpl struct {
+	AddStub        func(*git.Repo, string) error
+	addMutex       sync.RWMutex
+	addArgsForCall []struct {
+		arg1 *git.Repo
+		arg2 string
+	}
+	addReturns struct {
+		result1 error
+	}
+	addReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CleanupStub        func(*git.Repo) error
+	cleanupMutex       sync.RWMutex
+	cleanupArgsForCall []struct {
+		arg1 *git.Repo
+	}
+	cleanupReturns struct {
+		result1 error
+	}
+	cleanupReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CommitStub        func(*git.Repo, string) error
+	commitMutex       sync.RWMutex
+	commitArgsForCall []struct {
+		arg1 *git.Repo
+		arg2 string
+	}
+	commitReturns struct {
+		result1 error
+	}
+	commitReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreatePullRequestStub        func(string, string, string) (int, error)
+	createPullRequestMutex       sync.RWMutex
+	createPullRequestArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	createPullRequestReturns struct {
+		result1 int
+		result2 error
+	}
+	createPullRequestReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	DigestStub        func(string) (string, error)
+	digestMutex       sync.RWMutex
+	digestArgsForCall []struct {
+		arg1 string
+	}
+	digestReturns struct {
+		result1 string
+		result2 error
+	}
+	digestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ManifestStub        func(string) ([]byte, bool, error)
+	manifestMutex       sync.RWMutex
+	manifestArgsForCall []struct {
+		arg1 string
+	}
+	manifestReturns struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}
+	manifestReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}
+	PrepareForkStub        func(string, string, bool) (*git.Repo, error)
+	prepareForkMutex       sync.RWMutex
+	prepareForkArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	prepareForkReturns struct {
+		result1 *git.Repo
+		result2 error
+	}
+	prepareForkReturnsOnCall map[int]struct {
+		result1 *git.Repo
+		result2 error
+	}
+	PushToRemoteStub        func(*git.Repo, string) error
+	pushToRemoteMutex       sync.RWMutex
+	pushToRemoteArgsForCall []struct {
+		arg1 *git.Repo
+		arg2 string
+	}
+	pushToRemoteReturns struct {
+		result1 error
+	}
+	pushToRemoteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReadFileStub        func(string) ([]byte, error)
+	readFileMutex       sync.RWMutex
+	readFileArgsForCall []struct {
+		arg1 string
+	}
+	readFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RepoDirStub        func(*git.Repo) string
+	repoDirMutex       sync.RWMutex
+	repoDirArgsForCall []struct {
+		arg1 *git.Repo
+	}
+	repoDirReturns struct {
+		result1 string
+	}
+	repoDirReturnsOnCall map[int]struct {
+		result1 string
+	}
+	WriteFileStub        func(string, []byte) error
+	writeFileMutex       sync.RWMutex
+	writeFileArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	writeFileReturns struct {
+		result1 error
+	}
+	writeFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) Add(arg1 *git.Repo, arg2 string) error {
+	fake.addMutex.Lock()
+	ret, specificReturn := fake.addReturnsOnCall[len(fake.addArgsForCall)]
+	fake.addArgsForCall = append(fake.addArgsForCall, struct {
+		arg1 *git.Repo
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AddStub
+	fakeReturns := fake.addReturns
+	fake.recordInvocation("Add", []interface{}{arg1, arg2})
+	fake.addMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) AddCallCount() int {
+	fake.addMutex.RLock()
+	defer fake.addMutex.RUnlock()
+	return len(fake.addArgsForCall)
+}
+
+func (fake *FakeImpl) AddCalls(stub func(*git.Repo, string) error) {
+	fake.addMutex.Lock()
+	defer fake.addMutex.Unlock()
+	fake.AddStub = stub
+}
+
+func (fake *FakeImpl) AddArgsForCall(i int) (*git.Repo, string) {
+	fake.addMutex.RLock()
+	defer fake.addMutex.RUnlock()
+	argsForCall := fake.addArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) AddReturns(result1 error) {
+	fake.addMutex.Lock()
+	defer fake.addMutex.Unlock()
+	fake.AddStub = nil
+	fake.addReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) AddReturnsOnCall(i int, result1 error) {
+	fake.addMutex.Lock()
+	defer fake.addMutex.Unlock()
+	fake.AddStub = nil
+	if fake.addReturnsOnCall == nil {
+		fake.addReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Cleanup(arg1 *git.Repo) error {
+	fake.cleanupMutex.Lock()
+	ret, specificReturn := fake.cleanupReturnsOnCall[len(fake.cleanupArgsForCall)]
+	fake.cleanupArgsForCall = append(fake.cleanupArgsForCall, struct {
+		arg1 *git.Repo
+	}{arg1})
+	stub := fake.CleanupStub
+	fakeReturns := fake.cleanupReturns
+	fake.recordInvocation("Cleanup", []interface{}{arg1})
+	fake.cleanupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CleanupCallCount() int {
+	fake.cleanupMutex.RLock()
+	defer fake.cleanupMutex.RUnlock()
+	return len(fake.cleanupArgsForCall)
+}
+
+func (fake *FakeImpl) CleanupCalls(stub func(*git.Repo) error) {
+	fake.cleanupMutex.Lock()
+	defer fake.cleanupMutex.Unlock()
+	fake.CleanupStub = stub
+}
+
+func (fake *FakeImpl) CleanupArgsForCall(i int) *git.Repo {
+	fake.cleanupMutex.RLock()
+	defer fake.cleanupMutex.RUnlock()
+	argsForCall := fake.cleanupArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) CleanupReturns(result1 error) {
+	fake.cleanupMutex.Lock()
+	defer fake.cleanupMutex.Unlock()
+	fake.CleanupStub = nil
+	fake.cleanupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CleanupReturnsOnCall(i int, result1 error) {
+	fake.cleanupMutex.Lock()
+	defer fake.cleanupMutex.Unlock()
+	fake.CleanupStub = nil
+	if fake.cleanupReturnsOnCall == nil {
+		fake.cleanupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cleanupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Commit(arg1 *git.Repo, arg2 string) error {
+	fake.commitMutex.Lock()
+	ret, specificReturn := fake.commitReturnsOnCall[len(fake.commitArgsForCall)]
+	fake.commitArgsForCall = append(fake.commitArgsForCall, struct {
+		arg1 *git.Repo
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CommitStub
+	fakeReturns := fake.commitReturns
+	fake.recordInvocation("Commit", []interface{}{arg1, arg2})
+	fake.commitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CommitCallCount() int {
+	fake.commitMutex.RLock()
+	defer fake.commitMutex.RUnlock()
+	return len(fake.commitArgsForCall)
+}
+
+func (fake *FakeImpl) CommitCalls(stub func(*git.Repo, string) error) {
+	fake.commitMutex.Lock()
+	defer fake.commitMutex.Unlock()
+	fake.CommitStub = stub
+}
+
+func (fake *FakeImpl) CommitArgsForCall(i int) (*git.Repo, string) {
+	fake.commitMutex.RLock()
+	defer fake.commitMutex.RUnlock()
+	argsForCall := fake.commitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) CommitReturns(result1 error) {
+	fake.commitMutex.Lock()
+	defer fake.commitMutex.Unlock()
+	fake.CommitStub = nil
+	fake.commitReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CommitReturnsOnCall(i int, result1 error) {
+	fake.commitMutex.Lock()
+	defer fake.commitMutex.Unlock()
+	fake.CommitStub = nil
+	if fake.commitReturnsOnCall == nil {
+		fake.commitReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.commitReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CreatePullRequest(arg1 string, arg2 string, arg3 string) (int, error) {
+	fake.createPullRequestMutex.Lock()
+	ret, specificReturn := fake.createPullRequestReturnsOnCall[len(fake.createPullRequestArgsForCall)]
+	fake.createPullRequestArgsForCall = append(fake.createPullRequestArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CreatePullRequestStub
+	fakeReturns := fake.createPullRequestReturns
+	fake.recordInvocation("CreatePullRequest", []interface{}{arg1, arg2, arg3})
+	fake.createPullRequestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) CreatePullRequestCallCount() int {
+	fake.createPullRequestMutex.RLock()
+	defer fake.createPullRequestMutex.RUnlock()
+	return len(fake.createPullRequestArgsForCall)
+}
+
+func (fake *FakeImpl) CreatePullRequestCalls(stub func(string, string, string) (int, error)) {
+	fake.createPullRequestMutex.Lock()
+	defer fake.createPullRequestMutex.Unlock()
+	fake.CreatePullRequestStub = stub
+}
+
+func (fake *FakeImpl) CreatePullRequestArgsForCall(i int) (string, string, string) {
+	fake.createPullRequestMutex.RLock()
+	defer fake.createPullRequestMutex.RUnlock()
+	argsForCall := fake.createPullRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) CreatePullRequestReturns(result1 int, result2 error) {
+	fake.createPullRequestMutex.Lock()
+	defer fake.createPullRequestMutex.Unlock()
+	fake.CreatePullRequestStub = nil
+	fake.createPullRequestReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) CreatePullRequestReturnsOnCall(i int, result1 int, result2 error) {
+	fake.createPullRequestMutex.Lock()
+	defer fake.createPullRequestMutex.Unlock()
+	fake.CreatePullRequestStub = nil
+	if fake.createPullRequestReturnsOnCall == nil {
+		fake.createPullRequestReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.createPullRequestReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Digest(arg1 string) (string, error) {
+	fake.digestMutex.Lock()
+	ret, specificReturn := fake.digestReturnsOnCall[len(fake.digestArgsForCall)]
+	fake.digestArgsForCall = append(fake.digestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DigestStub
+	fakeReturns := fake.digestReturns
+	fake.recordInvocation("Digest", []interface{}{arg1})
+	fake.digestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) DigestCallCount() int {
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	return len(fake.digestArgsForCall)
+}
+
+func (fake *FakeImpl) DigestCalls(stub func(string) (string, error)) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = stub
+}
+
+func (fake *FakeImpl) DigestArgsForCall(i int) string {
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	argsForCall := fake.digestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) DigestReturns(result1 string, result2 error) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = nil
+	fake.digestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) DigestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = nil
+	if fake.digestReturnsOnCall == nil {
+		fake.digestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.digestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Manifest(arg1 string) ([]byte, bool, error) {
+	fake.manifestMutex.Lock()
+	ret, specificReturn := fake.manifestReturnsOnCall[len(fake.manifestArgsForCall)]
+	fake.manifestArgsForCall = append(fake.manifestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ManifestStub
+	fakeReturns := fake.manifestReturns
+	fake.recordInvocation("Manifest", []interface{}{arg1})
+	fake.manifestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeImpl) ManifestCallCount() int {
+	fake.manifestMutex.RLock()
+	defer fake.manifestMutex.RUnlock()
+	return len(fake.manifestArgsForCall)
+}
+
+func (fake *FakeImpl) ManifestCalls(stub func(string) ([]byte, bool, error)) {
+	fake.manifestMutex.Lock()
+	defer fake.manifestMutex.Unlock()
+	fake.ManifestStub = stub
+}
+
+func (fake *FakeImpl) ManifestArgsForCall(i int) string {
+	fake.manifestMutex.RLock()
+	defer fake.manifestMutex.RUnlock()
+	argsForCall := fake.manifestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ManifestReturns(result1 []byte, result2 bool, result3 error) {
+	fake.manifestMutex.Lock()
+	defer fake.manifestMutex.Unlock()
+	fake.ManifestStub = nil
+	fake.manifestReturns = struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImpl) ManifestReturnsOnCall(i int, result1 []byte, result2 bool, result3 error) {
+	fake.manifestMutex.Lock()
+	defer fake.manifestMutex.Unlock()
+	fake.ManifestStub = nil
+	if fake.manifestReturnsOnCall == nil {
+		fake.manifestReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 bool
+			result3 error
+		})
+	}
+	fake.manifestReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImpl) PrepareFork(arg1 string, arg2 string, arg3 bool) (*git.Repo, error) {
+	fake.prepareForkMutex.Lock()
+	ret, specificReturn := fake.prepareForkReturnsOnCall[len(fake.prepareForkArgsForCall)]
+	fake.prepareForkArgsForCall = append(fake.prepareForkArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.PrepareForkStub
+	fakeReturns := fake.prepareForkReturns
+	fake.recordInvocation("PrepareFork", []interface{}{arg1, arg2, arg3})
+	fake.prepareForkMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) PrepareForkCallCount() int {
+	fake.prepareForkMutex.RLock()
+	defer fake.prepareForkMutex.RUnlock()
+	return len(fake.prepareForkArgsForCall)
+}
+
+func (fake *FakeImpl) PrepareForkCalls(stub func(string, string, bool) (*git.Repo, error)) {
+	fake.prepareForkMutex.Lock()
+	defer fake.prepareForkMutex.Unlock()
+	fake.PrepareForkStub = stub
+}
+
+func (fake *FakeImpl) PrepareForkArgsForCall(i int) (string, string, bool) {
+	fake.prepareForkMutex.RLock()
+	defer fake.prepareForkMutex.RUnlock()
+	argsForCall := fake.prepareForkArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) PrepareForkReturns(result1 *git.Repo, result2 error) {
+	fake.prepareForkMutex.Lock()
+	defer fake.prepareForkMutex.Unlock()
+	fake.PrepareForkStub = nil
+	fake.prepareForkReturns = struct {
+		result1 *git.Repo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) PrepareForkReturnsOnCall(i int, result1 *git.Repo, result2 error) {
+	fake.prepareForkMutex.Lock()
+	defer fake.prepareForkMutex.Unlock()
+	fake.PrepareForkStub = nil
+	if fake.prepareForkReturnsOnCall == nil {
+		fake.prepareForkReturnsOnCall = make(map[int]struct {
+			result1 *git.Repo
+			result2 error
+		})
+	}
+	fake.prepareForkReturnsOnCall[i] = struct {
+		result1 *git.Repo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) PushToRemote(arg1 *git.Repo, arg2 string) error {
+	fake.pushToRemoteMutex.Lock()
+	ret, specificReturn := fake.pushToRemoteReturnsOnCall[len(fake.pushToRemoteArgsForCall)]
+	fake.pushToRemoteArgsForCall = append(fake.pushToRemoteArgsForCall, struct {
+		arg1 *git.Repo
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.PushToRemoteStub
+	fakeReturns := fake.pushToRemoteReturns
+	fake.recordInvocation("PushToRemote", []interface{}{arg1, arg2})
+	fake.pushToRemoteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) PushToRemoteCallCount() int {
+	fake.pushToRemoteMutex.RLock()
+	defer fake.pushToRemoteMutex.RUnlock()
+	return len(fake.pushToRemoteArgsForCall)
+}
+
+func (fake *FakeImpl) PushToRemoteCalls(stub func(*git.Repo, string) error) {
+	fake.pushToRemoteMutex.Lock()
+	defer fake.pushToRemoteMutex.Unlock()
+	fake.PushToRemoteStub = stub
+}
+
+func (fake *FakeImpl) PushToRemoteArgsForCall(i int) (*git.Repo, string) {
+	fake.pushToRemoteMutex.RLock()
+	defer fake.pushToRemoteMutex.RUnlock()
+	argsForCall := fake.pushToRemoteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) PushToRemoteReturns(result1 error) {
+	fake.pushToRemoteMutex.Lock()
+	defer fake.pushToRemoteMutex.Unlock()
+	fake.PushToRemoteStub = nil
+	fake.pushToRemoteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) PushToRemoteReturnsOnCall(i int, result1 error) {
+	fake.pushToRemoteMutex.Lock()
+	defer fake.pushToRemoteMutex.Unlock()
+	fake.PushToRemoteStub = nil
+	if fake.pushToRemoteReturnsOnCall == nil {
+		fake.pushToRemoteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushToRemoteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) ReadFile(arg1 string) ([]byte, error) {
+	fake.readFileMutex.Lock()
+	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
+	fake.readFileArgsForCall = append(fake.readFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReadFileStub
+	fakeReturns := fake.readFileReturns
+	fake.recordInvocation("ReadFile", []interface{}{arg1})
+	fake.readFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ReadFileCallCount() int {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return len(fake.readFileArgsForCall)
+}
+
+func (fake *FakeImpl) ReadFileCalls(stub func(string) ([]byte, error)) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = stub
+}
+
+func (fake *FakeImpl) ReadFileArgsForCall(i int) string {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	argsForCall := fake.readFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ReadFileReturns(result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	fake.readFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	if fake.readFileReturnsOnCall == nil {
+		fake.readFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) RepoDir(arg1 *git.Repo) string {
+	fake.repoDirMutex.Lock()
+	ret, specificReturn := fake.repoDirReturnsOnCall[len(fake.repoDirArgsForCall)]
+	fake.repoDirArgsForCall = append(fake.repoDirArgsForCall, struct {
+		arg1 *git.Repo
+	}{arg1})
+	stub := fake.RepoDirStub
+	fakeReturns := fake.repoDirReturns
+	fake.recordInvocation("RepoDir", []interface{}{arg1})
+	fake.repoDirMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) RepoDirCallCount() int {
+	fake.repoDirMutex.RLock()
+	defer fake.repoDirMutex.RUnlock()
+	return len(fake.repoDirArgsForCall)
+}
+
+func (fake *FakeImpl) RepoDirCalls(stub func(*git.Repo) string) {
+	fake.repoDirMutex.Lock()
+	defer fake.repoDirMutex.Unlock()
+	fake.RepoDirStub = stub
+}
+
+func (fake *FakeImpl) RepoDirArgsForCall(i int) *git.Repo {
+	fake.repoDirMutex.RLock()
+	defer fake.repoDirMutex.RUnlock()
+	argsForCall := fake.repoDirArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) RepoDirReturns(result1 string) {
+	fake.repoDirMutex.Lock()
+	defer fake.repoDirMutex.Unlock()
+	fake.RepoDirStub = nil
+	fake.repoDirReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeImpl) RepoDirReturnsOnCall(i int, result1 string) {
+	fake.repoDirMutex.Lock()
+	defer fake.repoDirMutex.Unlock()
+	fake.RepoDirStub = nil
+	if fake.repoDirReturnsOnCall == nil {
+		fake.repoDirReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.repoDirReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeFileMutex.Lock()
+	ret, specificReturn := fake.writeFileReturnsOnCall[len(fake.writeFileArgsForCall)]
+	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.WriteFileStub
+	fakeReturns := fake.writeFileReturns
+	fake.recordInvocation("WriteFile", []interface{}{arg1, arg2Copy})
+	fake.writeFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) WriteFileCallCount() int {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return len(fake.writeFileArgsForCall)
+}
+
+func (fake *FakeImpl) WriteFileCalls(stub func(string, []byte) error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = stub
+}
+
+func (fake *FakeImpl) WriteFileArgsForCall(i int) (string, []byte) {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	argsForCall := fake.writeFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) WriteFileReturns(result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	fake.writeFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteFileReturnsOnCall(i int, result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	if fake.writeFileReturnsOnCall == nil {
+		fake.writeFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.addMutex.RLock()
+	defer fake.addMutex.RUnlock()
+	fake.cleanupMutex.RLock()
+	defer fake.cleanupMutex.RUnlock()
+	fake.commitMutex.RLock()
+	defer fake.commitMutex.RUnlock()
+	fake.createPullRequestMutex.RLock()
+	defer fake.createPullRequestMutex.RUnlock()
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	fake.manifestMutex.RLock()
+	defer fake.manifestMutex.RUnlock()
+	fake.prepareForkMutex.RLock()
+	defer fake.prepareForkMutex.RUnlock()
+	fake.pushToRemoteMutex.RLock()
+	defer fake.pushToRemoteMutex.RUnlock()
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	fake.repoDirMutex.RLock()
+	defer fake.repoDirMutex.RUnlock()
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
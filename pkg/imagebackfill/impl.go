@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagebackfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/github"
+
+	"k8s.io/release/pkg/gitclone"
+	"k8s.io/release/pkg/retry"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt imagebackfillfakes/fake_impl.go > imagebackfillfakes/_fake_impl.go && mv imagebackfillfakes/_fake_impl.go imagebackfillfakes/fake_impl.go"
+type impl interface {
+	Manifest(ref string) (manifest []byte, found bool, err error)
+	Digest(ref string) (string, error)
+	PrepareFork(branch, forkOrg string, useSSH bool) (*git.Repo, error)
+	RepoDir(repo *git.Repo) string
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path string, content []byte) error
+	Add(repo *git.Repo, path string) error
+	Commit(repo *git.Repo, msg string) error
+	PushToRemote(repo *git.Repo, branch string) error
+	Cleanup(repo *git.Repo) error
+	CreatePullRequest(head, title, body string) (int, error)
+}
+
+type defaultImpl struct{}
+
+// Manifest returns the raw manifest of the ref, or false if it does not exist
+// on the registry.
+func (*defaultImpl) Manifest(ref string) (manifest []byte, found bool, err error) {
+	err = retry.Do(context.Background(), retry.ServiceRegistry, func() (err error) {
+		manifest, err = crane.Manifest(ref)
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return manifest, manifest != nil, nil
+}
+
+func (*defaultImpl) Digest(ref string) (digest string, err error) {
+	err = retry.Do(context.Background(), retry.ServiceRegistry, func() (err error) {
+		digest, err = crane.Digest(ref)
+		return err
+	})
+	return digest, err
+}
+
+func (*defaultImpl) PrepareFork(branch, forkOrg string, useSSH bool) (*git.Repo, error) {
+	repo, err := github.PrepareFork(
+		branch,
+		PromoterOrg, PromoterRepo,
+		forkOrg, PromoterRepo,
+		useSSH, false, gitclone.Default().GoGit(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.Checkout("-B", branch, git.Remotify(promoterBranch)); err != nil {
+		return nil, fmt.Errorf("checkout %s based on %s: %w", branch, promoterBranch, err)
+	}
+	return repo, nil
+}
+
+func (*defaultImpl) RepoDir(repo *git.Repo) string {
+	return repo.Dir()
+}
+
+func (*defaultImpl) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (*defaultImpl) WriteFile(path string, content []byte) error {
+	return os.WriteFile(path, content, 0o644)
+}
+
+func (*defaultImpl) Add(repo *git.Repo, path string) error {
+	return repo.Add(path)
+}
+
+func (*defaultImpl) Commit(repo *git.Repo, msg string) error {
+	return repo.UserCommit(msg)
+}
+
+func (*defaultImpl) PushToRemote(repo *git.Repo, branch string) error {
+	return repo.PushToRemote(github.UserForkName, branch)
+}
+
+func (*defaultImpl) Cleanup(repo *git.Repo) error {
+	return repo.Cleanup()
+}
+
+func (*defaultImpl) CreatePullRequest(head, title, body string) (int, error) {
+	pr, err := github.New().CreatePullRequest(
+		PromoterOrg, PromoterRepo, promoterBranch, head, title, body,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return pr.GetNumber(), nil
+}
+
+func isNotFound(err error) bool {
+	var terr *transport.Error
+	return errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagebackfill
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// PromoterOrg is the GitHub organization of the image promoter manifests.
+	PromoterOrg = "kubernetes"
+
+	// PromoterRepo is the GitHub repository of the image promoter manifests.
+	PromoterRepo = "k8s.io"
+
+	// PromoterManifestPath is the path of the image promoter manifest of the
+	// Kubernetes release images inside of the PromoterRepo.
+	PromoterManifestPath = "registry.k8s.io/images/k8s-staging-kubernetes/images.yaml"
+
+	promoterBranch = "main"
+)
+
+// promoterImage is a single image of the image promoter manifest, which maps
+// the digests to be promoted to their tags.
+type promoterImage struct {
+	Name string              `json:"name"`
+	DMap map[string][]string `json:"dmap"`
+}
+
+// PromoterManifest adds the missing images of the report to the provided
+// image promoter manifest, and returns the updated manifest in the format
+// used by the promoter.
+func (r *Report) PromoterManifest(manifest []byte) ([]byte, error) {
+	images := []*promoterImage{}
+	if err := yaml.Unmarshal(manifest, &images); err != nil {
+		return nil, fmt.Errorf("parse image promoter manifest: %w", err)
+	}
+
+	byName := map[string]*promoterImage{}
+	for _, image := range images {
+		byName[image.Name] = image
+	}
+
+	tag := strings.ReplaceAll(r.Version, "+", "_")
+	for _, m := range r.Missing {
+		if m.Digest == "" {
+			return nil, fmt.Errorf("no digest known for %s", m.Source)
+		}
+		name := m.Name()
+		image, ok := byName[name]
+		if !ok {
+			image = &promoterImage{Name: name}
+			byName[name] = image
+			images = append(images, image)
+		}
+		if image.DMap == nil {
+			image.DMap = map[string][]string{}
+		}
+		if !slices.Contains(image.DMap[m.Digest], tag) {
+			image.DMap[m.Digest] = append(image.DMap[m.Digest], tag)
+		}
+	}
+
+	sort.SliceStable(images, func(i, j int) bool {
+		return images[i].Name < images[j].Name
+	})
+
+	buf := &bytes.Buffer{}
+	for _, image := range images {
+		fmt.Fprintf(buf, "- name: %s\n  dmap:\n", image.Name)
+		digests := make([]string, 0, len(image.DMap))
+		for digest := range image.DMap {
+			digests = append(digests, digest)
+		}
+		sort.Strings(digests)
+		for _, digest := range digests {
+			tags := make([]string, 0, len(image.DMap[digest]))
+			for _, t := range image.DMap[digest] {
+				tags = append(tags, fmt.Sprintf("%q", t))
+			}
+			fmt.Fprintf(buf, "    %q: [%s]\n", digest, strings.Join(tags, ", "))
+		}
+	}
+	return buf.Bytes(), nil
+}
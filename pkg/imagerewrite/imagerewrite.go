@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imagerewrite rewrites container image references in rendered
+// release notes and announcements. Downstream distributions can point their
+// announcements to the registry their users have to pull from, for example
+// by replacing registry.k8s.io with mirror.example.com.
+package imagerewrite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-utils/env"
+	"sigs.k8s.io/yaml"
+)
+
+// FileEnvKey is the environment variable containing the default path to the
+// mapping file.
+const FileEnvKey = "KREL_IMAGE_REWRITES"
+
+// Rule rewrites the image references starting with a registry or repository
+// prefix.
+type Rule struct {
+	// From is the registry or repository prefix to be replaced, for example
+	// registry.k8s.io or registry.k8s.io/sig-storage.
+	From string `json:"from"`
+
+	// To is the replacement of the prefix, for example mirror.example.com.
+	To string `json:"to"`
+}
+
+// Mapping contains the rewrite rules.
+type Mapping struct {
+	// Rewrites are the rules of the mapping. The longest matching prefix of
+	// an image reference wins.
+	Rewrites []Rule `json:"rewrites"`
+
+	pattern *regexp.Regexp
+	targets map[string]string
+}
+
+// Load reads a mapping from the provided YAML file.
+func Load(file string) (*Mapping, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read image rewrites: %w", err)
+	}
+	m, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("image rewrites %s: %w", file, err)
+	}
+	return m, nil
+}
+
+// Parse parses and validates a YAML or JSON mapping.
+func Parse(content []byte) (*Mapping, error) {
+	m := &Mapping{}
+	if err := yaml.UnmarshalStrict(content, m); err != nil {
+		return nil, fmt.Errorf("unmarshal image rewrites: %w", err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("validate image rewrites: %w", err)
+	}
+	return m, nil
+}
+
+// Data returns the mapping as single line JSON, which can be passed via
+// --image-rewrites-data. It is empty for a nil mapping.
+func (m *Mapping) Data() string {
+	if m == nil {
+		return ""
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// Validate checks the rules and compiles the mapping.
+func (m *Mapping) Validate() error {
+	m.targets = map[string]string{}
+	prefixes := []string{}
+	for i, rule := range m.Rewrites {
+		for _, value := range []string{rule.From, rule.To} {
+			if value == "" {
+				return fmt.Errorf("rewrite %d: from and to must not be empty", i)
+			}
+			if strings.Contains(value, "://") || strings.HasSuffix(value, "/") {
+				return fmt.Errorf("rewrite %d: %q must not contain a scheme or trailing slash", i, value)
+			}
+		}
+		if _, ok := m.targets[rule.From]; ok {
+			return fmt.Errorf("rewrite %d: duplicate prefix %q", i, rule.From)
+		}
+		m.targets[rule.From] = rule.To
+		prefixes = append(prefixes, regexp.QuoteMeta(rule.From))
+	}
+	if len(prefixes) == 0 {
+		return errors.New("no rewrites specified")
+	}
+
+	// Alternatives are matched in order, which makes the longest prefix win.
+	sort.SliceStable(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+	m.pattern = regexp.MustCompile(`(^|[^\w.\-/])(` + strings.Join(prefixes, "|") + `)/`)
+	return nil
+}
+
+// Apply rewrites all image references in the text. Every reference gets
+// rewritten at most once, even if the replacement matches another rule.
+func (m *Mapping) Apply(text string) string {
+	if m == nil || m.pattern == nil {
+		return text
+	}
+	return m.pattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := m.pattern.FindStringSubmatch(match)
+		return parts[1] + m.targets[parts[2]] + "/"
+	})
+}
+
+// Options are the options for selecting the mapping.
+type Options struct {
+	// File is the YAML file containing the mapping. Empty means that image
+	// references are not rewritten.
+	File string
+
+	// Data is the YAML or JSON mapping, which takes precedence over the
+	// file. It is used for forwarding the mapping to the GCB jobs, which
+	// cannot access the local file.
+	Data string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		File: env.Default(FileEnvKey, ""),
+	}
+}
+
+// AddFlags adds the image rewrite flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.File,
+		"image-rewrites",
+		o.File,
+		fmt.Sprintf("YAML file mapping registries to the ones referenced in release notes and announcements (default $%s)", FileEnvKey),
+	)
+	flags.StringVar(
+		&o.Data,
+		"image-rewrites-data",
+		o.Data,
+		"YAML or JSON image rewrites mapping, which takes precedence over --image-rewrites",
+	)
+}
+
+var (
+	mu      sync.RWMutex
+	current *Mapping
+)
+
+// Setup loads the mapping of the provided options and uses it for rewriting
+// the rendered release notes and announcements.
+func Setup(opts *Options) error {
+	if opts.Data != "" {
+		m, err := Parse([]byte(opts.Data))
+		if err != nil {
+			return err
+		}
+		logrus.Info("Using the provided image rewrites data")
+		SetDefault(m)
+		return nil
+	}
+	if opts.File == "" {
+		SetDefault(nil)
+		return nil
+	}
+	m, err := Load(opts.File)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Using image rewrites %s", opts.File)
+	SetDefault(m)
+	return nil
+}
+
+// SetDefault sets the mapping used for rewriting. A nil mapping disables the
+// rewriting.
+func SetDefault(m *Mapping) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = m
+}
+
+// Default returns the mapping used for rewriting, which is nil if disabled.
+func Default() *Mapping {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Apply rewrites the image references of the text by using the default
+// mapping.
+func Apply(text string) string {
+	return Default().Apply(text)
+}
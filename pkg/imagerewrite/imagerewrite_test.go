@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagerewrite_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/imagerewrite"
+)
+
+func TestApply(t *testing.T) {
+	m := &imagerewrite.Mapping{Rewrites: []imagerewrite.Rule{
+		{From: "registry.k8s.io", To: "mirror.example.com/k8s"},
+		{From: "registry.k8s.io/sig-storage", To: "storage.example.com"},
+		{From: "mirror.example.com", To: "other.example.com"},
+	}}
+	require.NoError(t, m.Validate())
+
+	for _, tc := range []struct {
+		name, text, expected string
+	}{
+		{
+			name:     "image reference",
+			text:     "docker pull registry.k8s.io/kube-apiserver:v1.30.0",
+			expected: "docker pull mirror.example.com/k8s/kube-apiserver:v1.30.0",
+		},
+		{
+			name:     "longest prefix wins",
+			text:     "registry.k8s.io/sig-storage/csi-provisioner:v4.0.0",
+			expected: "storage.example.com/csi-provisioner:v4.0.0",
+		},
+		{
+			name:     "rewritten only once",
+			text:     "<code>registry.k8s.io/pause:3.9</code> and mirror.example.com/etcd",
+			expected: "<code>mirror.example.com/k8s/pause:3.9</code> and other.example.com/etcd",
+		},
+		{
+			name:     "multiple references",
+			text:     "registry.k8s.io/kube-proxy\nregistry.k8s.io/kube-scheduler",
+			expected: "mirror.example.com/k8s/kube-proxy\nmirror.example.com/k8s/kube-scheduler",
+		},
+		{
+			name:     "registry without image",
+			text:     "images are served by registry.k8s.io.",
+			expected: "images are served by registry.k8s.io.",
+		},
+		{
+			name:     "other registry with same suffix",
+			text:     "us.registry.k8s.io/pause and https://example.com/registry.k8s.io/pause",
+			expected: "us.registry.k8s.io/pause and https://example.com/registry.k8s.io/pause",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, m.Apply(tc.text))
+		})
+	}
+
+	var disabled *imagerewrite.Mapping
+	require.Equal(t, "registry.k8s.io/pause", disabled.Apply("registry.k8s.io/pause"))
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		rules   []imagerewrite.Rule
+		wantErr string
+	}{
+		{name: "no rules", wantErr: "no rewrites"},
+		{name: "empty target", rules: []imagerewrite.Rule{{From: "registry.k8s.io"}}, wantErr: "must not be empty"},
+		{name: "scheme", rules: []imagerewrite.Rule{{From: "https://registry.k8s.io", To: "mirror.example.com"}}, wantErr: "scheme"},
+		{name: "trailing slash", rules: []imagerewrite.Rule{{From: "registry.k8s.io", To: "mirror.example.com/"}}, wantErr: "trailing slash"},
+		{
+			name: "duplicate",
+			rules: []imagerewrite.Rule{
+				{From: "registry.k8s.io", To: "a.example.com"},
+				{From: "registry.k8s.io", To: "b.example.com"},
+			},
+			wantErr: "duplicate",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &imagerewrite.Mapping{Rewrites: tc.rules}
+			require.ErrorContains(t, m.Validate(), tc.wantErr)
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	t.Cleanup(func() { imagerewrite.SetDefault(nil) })
+
+	file := filepath.Join(t.TempDir(), "rewrites.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`rewrites:
+- from: registry.k8s.io
+  to: mirror.example.com
+`), 0o600))
+
+	require.NoError(t, imagerewrite.Setup(&imagerewrite.Options{File: file}))
+	require.Equal(t, "mirror.example.com/pause:3.9", imagerewrite.Apply("registry.k8s.io/pause:3.9"))
+
+	// The forwarded data takes precedence over the file
+	data := imagerewrite.Default().Data()
+	require.NotContains(t, data, "\n")
+	require.NoError(t, imagerewrite.Setup(&imagerewrite.Options{File: "missing.yaml", Data: data}))
+	require.Equal(t, "mirror.example.com/pause:3.9", imagerewrite.Apply("registry.k8s.io/pause:3.9"))
+	require.Error(t, imagerewrite.Setup(&imagerewrite.Options{Data: `{"rewrites":[]}`}))
+
+	require.NoError(t, os.WriteFile(file, []byte("rewrites:\n- form: registry.k8s.io\n"), 0o600))
+	require.Error(t, imagerewrite.Setup(&imagerewrite.Options{File: file}))
+
+	require.NoError(t, imagerewrite.Setup(&imagerewrite.Options{}))
+	require.Nil(t, imagerewrite.Default())
+	require.Equal(t, "registry.k8s.io/pause:3.9", imagerewrite.Apply("registry.k8s.io/pause:3.9"))
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knownissues
+
+import (
+	"context"
+
+	gogithub "github.com/google/go-github/v58/github"
+
+	"sigs.k8s.io/release-sdk/github"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt knownissuesfakes/fake_impl.go > knownissuesfakes/_fake_impl.go && mv knownissuesfakes/_fake_impl.go knownissuesfakes/fake_impl.go"
+type impl interface {
+	GetMilestone(owner, repo, title string) (*gogithub.Milestone, bool, error)
+	ListIssues(owner, repo string, opts *gogithub.IssueListByRepoOptions) ([]*gogithub.Issue, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) GetMilestone(owner, repo, title string) (*gogithub.Milestone, bool, error) {
+	return github.New().GetMilestone(owner, repo, title)
+}
+
+func (*defaultImpl) ListIssues(
+	owner, repo string, opts *gogithub.IssueListByRepoOptions,
+) ([]*gogithub.Issue, error) {
+	client := github.New().Client()
+	issues := []*gogithub.Issue{}
+	for {
+		more, resp, err := client.ListIssues(context.Background(), owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, more...)
+		if resp.NextPage == 0 {
+			return issues, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
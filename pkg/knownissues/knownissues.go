@@ -0,0 +1,244 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package knownissues renders the open issues of a release, which are marked
+// by labels and a milestone, into a "Known Issues" section of the changelog.
+package knownissues
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/env"
+
+	"k8s.io/release/pkg/notes"
+)
+
+const (
+	// LabelsEnvKey is the environment variable containing the comma separated
+	// default labels of known issues.
+	LabelsEnvKey = "KREL_KNOWN_ISSUES_LABELS"
+
+	// MilestoneEnvKey is the environment variable containing the default
+	// milestone of known issues.
+	MilestoneEnvKey = "KREL_KNOWN_ISSUES_MILESTONE"
+
+	// MilestoneAny matches issues with any or no milestone.
+	MilestoneAny = "any"
+
+	// Heading is the heading of the rendered section.
+	Heading = "## Known Issues"
+)
+
+// Options are the options for querying known issues.
+type Options struct {
+	// GitHubOrg is the GitHub organization of the queried repository.
+	GitHubOrg string
+
+	// GitHubRepo is the queried GitHub repository.
+	GitHubRepo string
+
+	// Labels are the labels all known issues must have, for example
+	// "known-issue". Known issues are disabled if empty.
+	Labels []string
+
+	// Milestone is the milestone of the known issues. It defaults to the
+	// minor version of the release, for example "v1.31", while MilestoneAny
+	// disables the milestone filter.
+	Milestone string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	labels := []string{}
+	for _, label := range strings.Split(env.Default(LabelsEnvKey, ""), ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return &Options{
+		GitHubOrg:  git.DefaultGithubOrg,
+		GitHubRepo: git.DefaultGithubRepo,
+		Labels:     labels,
+		Milestone:  env.Default(MilestoneEnvKey, ""),
+	}
+}
+
+// AddFlags adds the known issues flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.Labels,
+		"known-issues-label",
+		o.Labels,
+		fmt.Sprintf("labels of open issues to be listed as known issues in the changelog, disabled if empty (default $%s)", LabelsEnvKey),
+	)
+	flags.StringVar(
+		&o.Milestone,
+		"known-issues-milestone",
+		o.Milestone,
+		fmt.Sprintf("milestone of the known issues, defaults to the minor version of the release, %q disables the filter (default $%s)", MilestoneAny, MilestoneEnvKey),
+	)
+}
+
+// Enabled returns true if known issues should be queried.
+func (o *Options) Enabled() bool {
+	return len(o.Labels) > 0
+}
+
+// KnownIssues queries and renders the known issues of a release.
+type KnownIssues struct {
+	impl    impl
+	options *Options
+}
+
+// New creates a new KnownIssues instance.
+func New(opts *Options) *KnownIssues {
+	return &KnownIssues{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (k *KnownIssues) SetImpl(impl impl) {
+	k.impl = impl
+}
+
+// List returns the open known issues of the release version, excluding pull
+// requests.
+func (k *KnownIssues) List(version semver.Version) ([]*gogithub.Issue, error) {
+	if !k.options.Enabled() {
+		return nil, nil
+	}
+
+	opts := &gogithub.IssueListByRepoOptions{
+		State:       "open",
+		Labels:      k.options.Labels,
+		Sort:        "created",
+		Direction:   "asc",
+		ListOptions: gogithub.ListOptions{PerPage: 100},
+	}
+
+	milestone := k.options.Milestone
+	if milestone == "" {
+		milestone = fmt.Sprintf("v%d.%d", version.Major, version.Minor)
+	}
+	if milestone != MilestoneAny {
+		ms, exists, err := k.impl.GetMilestone(
+			k.options.GitHubOrg, k.options.GitHubRepo, milestone,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("get milestone %s: %w", milestone, err)
+		}
+		if !exists {
+			logrus.Warnf("Milestone %s does not exist, skipping known issues", milestone)
+			return nil, nil
+		}
+		opts.Milestone = fmt.Sprint(ms.GetNumber())
+	}
+
+	logrus.Infof(
+		"Querying known issues of %s/%s with labels %s and milestone %s",
+		k.options.GitHubOrg, k.options.GitHubRepo,
+		strings.Join(k.options.Labels, ","), milestone,
+	)
+	issues, err := k.impl.ListIssues(k.options.GitHubOrg, k.options.GitHubRepo, opts)
+	if err != nil {
+		return nil, fmt.Errorf("list known issues: %w", err)
+	}
+
+	res := []*gogithub.Issue{}
+	for _, issue := range issues {
+		if issue.IsPullRequest() {
+			continue
+		}
+		res = append(res, issue)
+	}
+	logrus.Infof("Found %d known issues", len(res))
+	return res, nil
+}
+
+// Markdown returns the "Known Issues" section of the release version, which
+// is empty if no known issues exist. The issue titles are sanitized like
+// the release notes, because anyone can open an issue.
+func (k *KnownIssues) Markdown(version semver.Version) (string, error) {
+	issues, err := k.List(version)
+	if err != nil {
+		return "", err
+	}
+	if len(issues) == 0 {
+		return "", nil
+	}
+
+	var b strings.Builder
+	b.WriteString(Heading + "\n\n")
+	for _, issue := range issues {
+		fmt.Fprintf(
+			&b, "- %s ([#%d](%s))\n",
+			notes.SanitizeText(strings.TrimSpace(issue.GetTitle())),
+			issue.GetNumber(), issue.GetHTMLURL(),
+		)
+	}
+	return b.String(), nil
+}
+
+var (
+	mu            sync.RWMutex
+	current       = New(&Options{})
+	activeOptions *Options
+)
+
+// Setup uses the provided options for querying the known issues of the
+// changelog.
+func Setup(opts *Options) {
+	if opts.Enabled() {
+		logrus.Infof("Listing issues with labels %s as known issues", strings.Join(opts.Labels, ","))
+	}
+	SetDefault(New(opts))
+	mu.Lock()
+	activeOptions = opts
+	mu.Unlock()
+}
+
+// ActiveOptions returns the options of the last Setup, or nil if Setup was
+// not called.
+func ActiveOptions() *Options {
+	mu.RLock()
+	defer mu.RUnlock()
+	return activeOptions
+}
+
+// SetDefault sets the known issues used by the changelog.
+func SetDefault(k *KnownIssues) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = k
+}
+
+// Default returns the known issues used by the changelog, which are disabled
+// if not set up.
+func Default() *KnownIssues {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
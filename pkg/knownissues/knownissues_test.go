@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knownissues_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/knownissues"
+	"k8s.io/release/pkg/knownissues/knownissuesfakes"
+)
+
+func testIssue(number int, title string, pr bool) *gogithub.Issue {
+	issue := &gogithub.Issue{
+		Number:  gogithub.Int(number),
+		Title:   gogithub.String(title),
+		HTMLURL: gogithub.String(fmt.Sprintf("https://github.com/kubernetes/kubernetes/issues/%d", number)),
+	}
+	if pr {
+		issue.PullRequestLinks = &gogithub.PullRequestLinks{}
+	}
+	return issue
+}
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test")
+	version := semver.MustParse("1.31.0-rc.0")
+
+	for _, tc := range []struct {
+		name              string
+		opts              *knownissues.Options
+		prepare           func(*knownissuesfakes.FakeImpl)
+		expected          string
+		expectedMilestone string
+		shouldErr         bool
+	}{
+		{
+			name:    "disabled",
+			opts:    &knownissues.Options{},
+			prepare: func(*knownissuesfakes.FakeImpl) {},
+		},
+		{
+			name: "issues of the release milestone",
+			opts: &knownissues.Options{Labels: []string{"known-issue"}},
+			prepare: func(mock *knownissuesfakes.FakeImpl) {
+				mock.GetMilestoneReturns(&gogithub.Milestone{Number: gogithub.Int(42)}, true, nil)
+				mock.ListIssuesReturns([]*gogithub.Issue{
+					testIssue(1, "first", false),
+					testIssue(2, "pull request", true),
+					testIssue(3, " second ", false),
+				}, nil)
+			},
+			expected: "## Known Issues\n\n" +
+				"- first ([#1](https://github.com/kubernetes/kubernetes/issues/1))\n" +
+				"- second ([#3](https://github.com/kubernetes/kubernetes/issues/3))\n",
+			expectedMilestone: "v1.31",
+		},
+		{
+			name: "any milestone",
+			opts: &knownissues.Options{
+				Labels:    []string{"known-issue"},
+				Milestone: knownissues.MilestoneAny,
+			},
+			prepare: func(mock *knownissuesfakes.FakeImpl) {
+				mock.ListIssuesReturns([]*gogithub.Issue{testIssue(1, "first", false)}, nil)
+			},
+			expected: "## Known Issues\n\n" +
+				"- first ([#1](https://github.com/kubernetes/kubernetes/issues/1))\n",
+		},
+		{
+			name: "sanitized titles",
+			opts: &knownissues.Options{Labels: []string{"known-issue"}, Milestone: knownissues.MilestoneAny},
+			prepare: func(mock *knownissuesfakes.FakeImpl) {
+				mock.ListIssuesReturns([]*gogithub.Issue{
+					testIssue(1, "crash <script>alert(1)</script>on start", false),
+				}, nil)
+			},
+			expected: "## Known Issues\n\n" +
+				"- crash on start ([#1](https://github.com/kubernetes/kubernetes/issues/1))\n",
+		},
+		{
+			name: "no issues",
+			opts: &knownissues.Options{Labels: []string{"known-issue"}, Milestone: "v1.30"},
+			prepare: func(mock *knownissuesfakes.FakeImpl) {
+				mock.GetMilestoneReturns(&gogithub.Milestone{Number: gogithub.Int(42)}, true, nil)
+			},
+			expectedMilestone: "v1.30",
+		},
+		{
+			name: "missing milestone",
+			opts: &knownissues.Options{Labels: []string{"known-issue"}},
+			prepare: func(mock *knownissuesfakes.FakeImpl) {
+				mock.GetMilestoneReturns(nil, false, nil)
+			},
+			expectedMilestone: "v1.31",
+		},
+		{
+			name: "failing milestone lookup",
+			opts: &knownissues.Options{Labels: []string{"known-issue"}},
+			prepare: func(mock *knownissuesfakes.FakeImpl) {
+				mock.GetMilestoneReturns(nil, false, errTest)
+			},
+			expectedMilestone: "v1.31",
+			shouldErr:         true,
+		},
+		{
+			name: "failing issue listing",
+			opts: &knownissues.Options{Labels: []string{"known-issue"}, Milestone: knownissues.MilestoneAny},
+			prepare: func(mock *knownissuesfakes.FakeImpl) {
+				mock.ListIssuesReturns(nil, errTest)
+			},
+			shouldErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &knownissuesfakes.FakeImpl{}
+			tc.prepare(mock)
+			sut := knownissues.New(tc.opts)
+			sut.SetImpl(mock)
+
+			res, err := sut.Markdown(version)
+			if tc.shouldErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, res)
+			}
+
+			if tc.expectedMilestone != "" {
+				require.Equal(t, 1, mock.GetMilestoneCallCount())
+				_, _, title := mock.GetMilestoneArgsForCall(0)
+				require.Equal(t, tc.expectedMilestone, title)
+			} else {
+				require.Zero(t, mock.GetMilestoneCallCount())
+			}
+
+			if mock.ListIssuesCallCount() > 0 {
+				_, _, opts := mock.ListIssuesArgsForCall(0)
+				require.Equal(t, "open", opts.State)
+				require.Equal(t, tc.opts.Labels, opts.Labels)
+				if tc.expectedMilestone != "" {
+					require.Equal(t, "42", opts.Milestone)
+				}
+			}
+		})
+	}
+}
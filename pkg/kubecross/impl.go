@@ -18,8 +18,17 @@ package kubecross
 
 import (
 	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
 
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/command"
 	"sigs.k8s.io/release-utils/http"
+
+	"k8s.io/release/pkg/gitclone"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -27,6 +36,16 @@ import (
 //go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt kubecrossfakes/fake_impl.go > kubecrossfakes/_fake_impl.go && mv kubecrossfakes/_fake_impl.go kubecrossfakes/fake_impl.go"
 type impl interface {
 	GetURLResponse(url string) (string, error)
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path string, content []byte) error
+	SubmitBuild(dir, config, project string, substitutions map[string]string) error
+	PrepareFork(branch, baseBranch, forkOrg string, useSSH bool) (*git.Repo, error)
+	RepoDir(repo *git.Repo) string
+	Add(repo *git.Repo, path string) error
+	Commit(repo *git.Repo, msg string) error
+	PushToRemote(repo *git.Repo, branch string) error
+	Cleanup(repo *git.Repo) error
+	CreatePullRequest(baseBranch, head, title, body string) (int, error)
 }
 
 type defaultImpl struct{}
@@ -38,3 +57,86 @@ func (*defaultImpl) GetURLResponse(url string) (string, error) {
 	}
 	return string(bytes.TrimSpace(content)), nil
 }
+
+func (*defaultImpl) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (*defaultImpl) WriteFile(path string, content []byte) error {
+	//nolint:gosec // the files are part of a repository checkout
+	return os.WriteFile(path, content, 0o644)
+}
+
+func (*defaultImpl) SubmitBuild(
+	dir, config, project string, substitutions map[string]string,
+) error {
+	keys := []string{}
+	for key := range substitutions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := []string{}
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, substitutions[key]))
+	}
+
+	return command.New(
+		"gcloud", "builds", "submit",
+		"--project", project,
+		"--config", config,
+		"--substitutions", strings.Join(pairs, ","),
+		dir,
+	).RunSuccess()
+}
+
+func (*defaultImpl) PrepareFork(
+	branch, baseBranch, forkOrg string, useSSH bool,
+) (*git.Repo, error) {
+	repo, err := github.PrepareFork(
+		branch,
+		git.DefaultGithubOrg, git.DefaultGithubRepo,
+		forkOrg, git.DefaultGithubRepo,
+		useSSH, false, gitclone.Default().GoGit(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.Checkout("-B", branch, git.Remotify(baseBranch)); err != nil {
+		return nil, fmt.Errorf("checkout %s based on %s: %w", branch, baseBranch, err)
+	}
+	return repo, nil
+}
+
+func (*defaultImpl) RepoDir(repo *git.Repo) string {
+	return repo.Dir()
+}
+
+func (*defaultImpl) Add(repo *git.Repo, path string) error {
+	return repo.Add(path)
+}
+
+func (*defaultImpl) Commit(repo *git.Repo, msg string) error {
+	return repo.UserCommit(msg)
+}
+
+func (*defaultImpl) PushToRemote(repo *git.Repo, branch string) error {
+	return repo.PushToRemote(github.UserForkName, branch)
+}
+
+func (*defaultImpl) Cleanup(repo *git.Repo) error {
+	return repo.Cleanup()
+}
+
+func (*defaultImpl) CreatePullRequest(
+	baseBranch, head, title, body string,
+) (int, error) {
+	pr, err := github.New().CreatePullRequest(
+		git.DefaultGithubOrg, git.DefaultGithubRepo, baseBranch, head, title, body,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return pr.GetNumber(), nil
+}
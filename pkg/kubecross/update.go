@@ -0,0 +1,628 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubecross
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// GoReleasesURL is the location of the list of the supported Go releases.
+	GoReleasesURL = "https://go.dev/dl/?mode=json"
+
+	variantsFile     = "variants.yaml"
+	makefile         = "Makefile"
+	cloudBuildFile   = "cloudbuild.yaml"
+	dependenciesFile = "dependencies.yaml"
+
+	// Files inside of the kubernetes/kubernetes repository
+	k8sDependenciesFile = "build/dependencies.yaml"
+	k8sKubeCrossFile    = "build/build-image/cross/VERSION"
+	k8sGoVersionFile    = ".go-version"
+
+	// Keys of the image variants
+	variantGoVersion    = "GO_VERSION"
+	variantGoMajor      = "GO_MAJOR_VERSION"
+	variantImageVersion = "IMAGE_VERSION"
+	variantRevision     = "REVISION"
+	variantK8sVersion   = "KUBERNETES_VERSION"
+
+	kubeCrossImageName = "kube-cross"
+	updateBranchPrefix = "kube-cross-"
+)
+
+var (
+	variantRe       = regexp.MustCompile(`^  (\S+):\s*$`)
+	variantValueRe  = regexp.MustCompile(`^(\s+)([A-Z_]+): '.*'$`)
+	makeGoVersionRe = regexp.MustCompile(`(?m)^GO_VERSION \?= (\S+)$`)
+	makeRevisionRe  = regexp.MustCompile(`(?m)^REVISION \?= \d+$`)
+	imageRevisionRe = regexp.MustCompile(`\.\d+$`)
+)
+
+// Image is a builder image whose variants are maintained in the
+// kubernetes/release repository.
+type Image struct {
+	// Name of the image, for example "kube-cross".
+	Name string
+
+	// Path to the image definition relative to the repository root.
+	Path string
+
+	// Project is the Google Cloud project used for building the image.
+	Project string
+}
+
+// DefaultImages are the builder images depending on the Go version.
+var DefaultImages = []Image{
+	{Name: kubeCrossImageName, Path: "images/build/cross", Project: "k8s-staging-build-image"},
+	{Name: "go-runner", Path: "images/build/go-runner", Project: "k8s-staging-build-image"},
+	{Name: "releng-ci", Path: "images/releng/ci", Project: "k8s-staging-releng"},
+}
+
+// UpdateOptions are the options for updating the builder images.
+type UpdateOptions struct {
+	// RepoPath is the path to the local kubernetes/release repository.
+	RepoPath string
+
+	// Images to be updated.
+	Images []Image
+
+	// Fork is the GitHub organization of the kubernetes/kubernetes fork used
+	// for opening the pull requests.
+	Fork string
+
+	// UseSSH specifies if the fork should be pushed via SSH.
+	UseSSH bool
+
+	// NoMock submits the image builds and opens the pull requests if set.
+	NoMock bool
+}
+
+// DefaultUpdateOptions returns a new default UpdateOptions instance.
+func DefaultUpdateOptions() *UpdateOptions {
+	return &UpdateOptions{
+		RepoPath: ".",
+		Images:   DefaultImages,
+	}
+}
+
+// Validate checks if the options are set correctly.
+func (o *UpdateOptions) Validate() error {
+	if o.RepoPath == "" {
+		return errors.New("no repository path specified")
+	}
+	if len(o.Images) == 0 {
+		return errors.New("no images specified")
+	}
+	if o.NoMock && o.Fork == "" {
+		return errors.New("a fork is required to open pull requests in no mock mode")
+	}
+	return nil
+}
+
+// Bump is the Go version update of a single image variant.
+type Bump struct {
+	Image             Image
+	Variant           string
+	OldGoVersion      string
+	NewGoVersion      string
+	OldImageVersion   string
+	NewImageVersion   string
+	KubernetesVersion string
+
+	// Values contains the updated variant definition.
+	Values map[string]string
+}
+
+// Updater updates the builder images to the latest Go patch releases.
+type Updater struct {
+	impl    impl
+	options *UpdateOptions
+}
+
+// NewUpdater creates a new Updater instance.
+func NewUpdater(options *UpdateOptions) *Updater {
+	return &Updater{&defaultImpl{}, options}
+}
+
+// Run detects new Go patch releases, bumps the image definitions, submits
+// the image builds and opens the pull requests against kubernetes/kubernetes.
+func (u *Updater) Run() ([]*Bump, error) {
+	if err := u.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	latest, err := u.LatestGoVersions()
+	if err != nil {
+		return nil, fmt.Errorf("get latest go versions: %w", err)
+	}
+
+	bumps, err := u.UpdateImages(latest)
+	if err != nil {
+		return nil, fmt.Errorf("update images: %w", err)
+	}
+	if len(bumps) == 0 {
+		logrus.Info("All images are already using the latest Go versions")
+		return nil, nil
+	}
+
+	if err := u.BuildImages(bumps); err != nil {
+		return nil, fmt.Errorf("build images: %w", err)
+	}
+
+	if err := u.CreatePullRequests(bumps); err != nil {
+		return nil, fmt.Errorf("create pull requests: %w", err)
+	}
+
+	return bumps, nil
+}
+
+// LatestGoVersions returns the latest stable patch release for each supported
+// Go minor version, for example "1.22" -> "1.22.3".
+func (u *Updater) LatestGoVersions() (map[string]string, error) {
+	logrus.Infof("Retrieving Go releases from %s", GoReleasesURL)
+	content, err := u.impl.GetURLResponse(GoReleasesURL)
+	if err != nil {
+		return nil, fmt.Errorf("get URL response: %w", err)
+	}
+
+	releases := []struct {
+		Version string `json:"version"`
+		Stable  bool   `json:"stable"`
+	}{}
+	if err := json.Unmarshal([]byte(content), &releases); err != nil {
+		return nil, fmt.Errorf("unmarshal go releases: %w", err)
+	}
+
+	latest := map[string]string{}
+	for _, r := range releases {
+		if !r.Stable {
+			continue
+		}
+		version := strings.TrimPrefix(r.Version, "go")
+		major, err := goMajorVersion(version)
+		if err != nil {
+			logrus.Debugf("Skipping go release %s: %v", r.Version, err)
+			continue
+		}
+		if current, ok := latest[major]; !ok || goVersionNewer(version, current) {
+			latest[major] = version
+		}
+	}
+
+	if len(latest) == 0 {
+		return nil, errors.New("no stable go releases found")
+	}
+	logrus.Infof("Latest Go releases: %v", latest)
+	return latest, nil
+}
+
+// UpdateImages bumps the variants of all images to the provided latest Go
+// versions and updates the related Makefiles and the dependencies file.
+func (u *Updater) UpdateImages(latest map[string]string) ([]*Bump, error) {
+	bumps := []*Bump{}
+	for _, image := range u.options.Images {
+		imageDir := filepath.Join(u.options.RepoPath, image.Path)
+
+		variantsPath := filepath.Join(imageDir, variantsFile)
+		content, err := u.impl.ReadFile(variantsPath)
+		if err != nil {
+			return nil, fmt.Errorf("read variants of %s: %w", image.Name, err)
+		}
+
+		imageBumps, err := variantBumps(image, content, latest)
+		if err != nil {
+			return nil, fmt.Errorf("check variants of %s: %w", image.Name, err)
+		}
+		if len(imageBumps) == 0 {
+			logrus.Infof("Image %s is up to date", image.Name)
+			continue
+		}
+
+		for _, bump := range imageBumps {
+			logrus.Infof(
+				"Bumping %s variant %s from go %s to %s",
+				image.Name, bump.Variant, bump.OldGoVersion, bump.NewGoVersion,
+			)
+		}
+
+		if err := u.impl.WriteFile(
+			variantsPath, []byte(applyVariantBumps(string(content), imageBumps)),
+		); err != nil {
+			return nil, fmt.Errorf("write variants of %s: %w", image.Name, err)
+		}
+
+		if err := u.updateMakefile(filepath.Join(imageDir, makefile), imageBumps); err != nil {
+			return nil, fmt.Errorf("update makefile of %s: %w", image.Name, err)
+		}
+
+		bumps = append(bumps, imageBumps...)
+	}
+
+	if len(bumps) == 0 {
+		return nil, nil
+	}
+
+	if err := u.updateDependencies(
+		filepath.Join(u.options.RepoPath, dependenciesFile), bumps,
+	); err != nil {
+		return nil, fmt.Errorf("update dependencies: %w", err)
+	}
+
+	return bumps, nil
+}
+
+// BuildImages submits the Google Cloud Build jobs for all bumped variants.
+func (u *Updater) BuildImages(bumps []*Bump) error {
+	for _, bump := range bumps {
+		substitutions := map[string]string{
+			"_GIT_TAG":       "v" + time.Now().UTC().Format("20060102"),
+			"_PULL_BASE_REF": git.DefaultBranch,
+			"_REGISTRY":      "gcr.io/" + bump.Image.Project,
+		}
+		for key, value := range bump.Values {
+			substitutions["_"+key] = value
+		}
+
+		if !u.options.NoMock {
+			logrus.Infof(
+				"Mock mode: skipping build of %s variant %s with substitutions %v",
+				bump.Image.Name, bump.Variant, substitutions,
+			)
+			continue
+		}
+
+		logrus.Infof("Building %s variant %s", bump.Image.Name, bump.Variant)
+		if err := u.impl.SubmitBuild(
+			u.options.RepoPath,
+			filepath.Join(u.options.RepoPath, bump.Image.Path, cloudBuildFile),
+			bump.Image.Project,
+			substitutions,
+		); err != nil {
+			return fmt.Errorf("build %s variant %s: %w", bump.Image.Name, bump.Variant, err)
+		}
+	}
+	return nil
+}
+
+// CreatePullRequests opens the kube-cross bump pull requests against all
+// kubernetes/kubernetes branches which use an image version being updated.
+func (u *Updater) CreatePullRequests(bumps []*Bump) error {
+	kc := &KubeCross{u.impl}
+	done := map[string]bool{}
+
+	for _, bump := range bumps {
+		if bump.Image.Name != kubeCrossImageName || bump.OldImageVersion == "" {
+			continue
+		}
+
+		branches := []string{git.DefaultBranch}
+		if v, err := semver.ParseTolerant(bump.KubernetesVersion); err == nil {
+			branches = append(branches, fmt.Sprintf("release-%d.%d", v.Major, v.Minor))
+		}
+
+		for _, branch := range branches {
+			if done[branch] {
+				continue
+			}
+
+			current, err := kc.ForBranch(branch)
+			if err != nil {
+				logrus.Warnf("Unable to get kube-cross version for branch %s: %v", branch, err)
+				continue
+			}
+			if current != bump.OldImageVersion {
+				logrus.Infof(
+					"Branch %s uses kube-cross %s, not updating it to %s",
+					branch, current, bump.NewImageVersion,
+				)
+				continue
+			}
+
+			if err := u.createPullRequest(branch, bump); err != nil {
+				return fmt.Errorf("create pull request for %s: %w", branch, err)
+			}
+			done[branch] = true
+		}
+	}
+	return nil
+}
+
+func (u *Updater) createPullRequest(baseBranch string, bump *Bump) (err error) {
+	title := "Bump images, dependencies and versions to go " + bump.NewGoVersion
+	if baseBranch == git.DefaultBranch {
+		title = "[go] " + title
+	} else {
+		title = fmt.Sprintf("[%s] %s", baseBranch, title)
+	}
+
+	if !u.options.NoMock {
+		logrus.Infof("Mock mode: skipping pull request %q against %s", title, baseBranch)
+		return nil
+	}
+
+	branch := fmt.Sprintf("%s%s-%s", updateBranchPrefix, bump.NewImageVersion, baseBranch)
+	repo, err := u.impl.PrepareFork(branch, baseBranch, u.options.Fork, u.options.UseSSH)
+	if err != nil {
+		return fmt.Errorf("prepare fork: %w", err)
+	}
+	defer func() {
+		if cleanupErr := u.impl.Cleanup(repo); cleanupErr != nil && err == nil {
+			err = fmt.Errorf("cleanup repository: %w", cleanupErr)
+		}
+	}()
+	dir := u.impl.RepoDir(repo)
+
+	files := map[string]string{
+		k8sKubeCrossFile: bump.NewImageVersion + "\n",
+	}
+	if _, err := u.impl.ReadFile(filepath.Join(dir, k8sGoVersionFile)); err == nil {
+		files[k8sGoVersionFile] = bump.NewGoVersion + "\n"
+	}
+
+	dependencies, err := u.impl.ReadFile(filepath.Join(dir, k8sDependenciesFile))
+	if err != nil {
+		return fmt.Errorf("read dependencies: %w", err)
+	}
+	files[k8sDependenciesFile] = replaceVersion(
+		replaceVersion(string(dependencies), bump.OldImageVersion, bump.NewImageVersion),
+		bump.OldGoVersion, bump.NewGoVersion,
+	)
+
+	paths := []string{}
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if err := u.impl.WriteFile(filepath.Join(dir, path), []byte(files[path])); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+		if err := u.impl.Add(repo, path); err != nil {
+			return fmt.Errorf("add %s: %w", path, err)
+		}
+	}
+
+	if err := u.impl.Commit(repo, title); err != nil {
+		return fmt.Errorf("commit changes: %w", err)
+	}
+
+	logrus.Infof("Pushing branch %s to %s", branch, u.options.Fork)
+	if err := u.impl.PushToRemote(repo, branch); err != nil {
+		return fmt.Errorf("push branch: %w", err)
+	}
+
+	body := "#### What type of PR is this?\n\n/kind feature\n/area release-eng\n\n"
+	body += "#### What this PR does / why we need it:\n\n"
+	body += fmt.Sprintf(
+		"Bumps kube-cross to %s, which uses go %s.\n\n",
+		bump.NewImageVersion, bump.NewGoVersion,
+	)
+	body += "#### Special notes for your reviewer:\n\n"
+	body += "/hold\n"
+	body += "Hold until the kube-cross image has been promoted.\n\n"
+	body += "This is an automated PR generated from `krel The Kubernetes Release Toolbox`\n\n"
+	body += "#### Does this PR introduce a user-facing change?\n\n"
+	body += "```release-note\n"
+	body += fmt.Sprintf("Kubernetes is now built with go %s\n", bump.NewGoVersion)
+	body += "```\n"
+
+	number, err := u.impl.CreatePullRequest(
+		baseBranch, fmt.Sprintf("%s:%s", u.options.Fork, branch), title, body,
+	)
+	if err != nil {
+		return fmt.Errorf("creating the pull request: %w", err)
+	}
+	logrus.Infof(
+		"Successfully created PR: https://github.com/%s/%s/pull/%d",
+		git.DefaultGithubOrg, git.DefaultGithubRepo, number,
+	)
+	return nil
+}
+
+func (u *Updater) updateMakefile(path string, bumps []*Bump) error {
+	content, err := u.impl.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read makefile: %w", err)
+	}
+
+	match := makeGoVersionRe.FindSubmatch(content)
+	if match == nil {
+		return nil
+	}
+
+	for _, bump := range bumps {
+		if string(match[1]) != bump.OldGoVersion {
+			continue
+		}
+		updated := makeGoVersionRe.ReplaceAllString(
+			string(content), "GO_VERSION ?= "+bump.NewGoVersion,
+		)
+		updated = makeRevisionRe.ReplaceAllString(updated, "REVISION ?= 0")
+		return u.impl.WriteFile(path, []byte(updated))
+	}
+	return nil
+}
+
+func (u *Updater) updateDependencies(path string, bumps []*Bump) error {
+	content, err := u.impl.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read dependencies: %w", err)
+	}
+
+	updated := string(content)
+	for _, bump := range bumps {
+		updated = replaceVersion(updated, bump.OldGoVersion, bump.NewGoVersion)
+		if bump.OldImageVersion != "" {
+			updated = replaceVersion(updated, bump.OldImageVersion, bump.NewImageVersion)
+		}
+	}
+	return u.impl.WriteFile(path, []byte(updated))
+}
+
+// variantBumps returns the bumps for all variants of the image which are not
+// using the latest Go patch release.
+func variantBumps(image Image, content []byte, latest map[string]string) ([]*Bump, error) {
+	variants := struct {
+		Variants map[string]map[string]string `json:"variants"`
+	}{}
+	if err := yaml.Unmarshal(content, &variants); err != nil {
+		return nil, fmt.Errorf("unmarshal variants: %w", err)
+	}
+
+	names := []string{}
+	for name := range variants.Variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bumps := []*Bump{}
+	for _, name := range names {
+		values := variants.Variants[name]
+
+		goVersion := values[variantGoVersion]
+		major := values[variantGoMajor]
+		if major == "" {
+			m, err := goMajorVersion(goVersion)
+			if err != nil {
+				logrus.Warnf("Skipping %s variant %s: %v", image.Name, name, err)
+				continue
+			}
+			major = m
+		}
+
+		newGoVersion, ok := latest[major]
+		if !ok || !goVersionNewer(newGoVersion, goVersion) {
+			continue
+		}
+
+		bump := &Bump{
+			Image:             image,
+			Variant:           name,
+			OldGoVersion:      goVersion,
+			NewGoVersion:      newGoVersion,
+			OldImageVersion:   values[variantImageVersion],
+			KubernetesVersion: values[variantK8sVersion],
+			Values:            map[string]string{},
+		}
+		for key, value := range values {
+			bump.Values[key] = value
+		}
+		bump.Values[variantGoVersion] = newGoVersion
+		if _, ok := values[variantRevision]; ok {
+			bump.Values[variantRevision] = "0"
+		}
+
+		if bump.OldImageVersion != "" {
+			newImageVersion, err := bumpImageVersion(bump.OldImageVersion, goVersion, newGoVersion)
+			if err != nil {
+				return nil, fmt.Errorf("bump image version of variant %s: %w", name, err)
+			}
+			bump.NewImageVersion = newImageVersion
+			bump.Values[variantImageVersion] = newImageVersion
+		}
+
+		bumps = append(bumps, bump)
+	}
+	return bumps, nil
+}
+
+// applyVariantBumps updates the values of the bumped variants in place to
+// keep the format of the file.
+func applyVariantBumps(content string, bumps []*Bump) string {
+	byVariant := map[string]*Bump{}
+	for _, bump := range bumps {
+		byVariant[bump.Variant] = bump
+	}
+
+	lines := strings.Split(content, "\n")
+	var current *Bump
+	for i, line := range lines {
+		if m := variantRe.FindStringSubmatch(line); m != nil {
+			current = byVariant[m[1]]
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		m := variantValueRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if value, ok := current.Values[m[2]]; ok {
+			lines[i] = fmt.Sprintf("%s%s: '%s'", m[1], m[2], value)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bumpImageVersion replaces the Go version of an image version like
+// "v1.30.0-go1.22.2-bullseye.1" and resets its revision.
+func bumpImageVersion(imageVersion, oldGoVersion, newGoVersion string) (string, error) {
+	oldPart := "-go" + oldGoVersion + "-"
+	if !strings.Contains(imageVersion, oldPart) {
+		return "", fmt.Errorf("%s does not contain go %s", imageVersion, oldGoVersion)
+	}
+	res := strings.Replace(imageVersion, oldPart, "-go"+newGoVersion+"-", 1)
+	return imageRevisionRe.ReplaceAllString(res, ".0"), nil
+}
+
+// replaceVersion replaces all dependency versions matching old.
+func replaceVersion(content, old, replacement string) string {
+	re := regexp.MustCompile(`(?m)^(\s*version:\s*)` + regexp.QuoteMeta(old) + `(\s*)$`)
+	return re.ReplaceAllString(content, "${1}"+replacement+"${2}")
+}
+
+// goMajorVersion returns the major version like "1.22" of a Go version.
+func goMajorVersion(version string) (string, error) {
+	if _, err := semver.Parse(version); err != nil {
+		return "", fmt.Errorf("parse go version %q: %w", version, err)
+	}
+	parts := strings.SplitN(version, ".", 3)
+	return parts[0] + "." + parts[1], nil
+}
+
+// goVersionNewer returns true if version a is newer than version b.
+func goVersionNewer(a, b string) bool {
+	va, err := semver.Parse(a)
+	if err != nil {
+		return false
+	}
+	vb, err := semver.Parse(b)
+	if err != nil {
+		return false
+	}
+	return va.GT(vb)
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubecross
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/kubecross/kubecrossfakes"
+)
+
+const (
+	testGoReleases = `[
+  {"version": "go1.23rc1", "stable": false},
+  {"version": "go1.22.3", "stable": true},
+  {"version": "go1.21.10", "stable": true}
+]`
+
+	testCrossVariants = `variants:
+  v1.30-go1.22-bullseye:
+    CONFIG: 'go1.22-bullseye'
+    IMAGE_VERSION: 'v1.30.0-go1.22.2-bullseye.1'
+    KUBERNETES_VERSION: 'v1.30.0'
+    GO_VERSION: '1.22.2'
+    GO_MAJOR_VERSION: '1.22'
+    OS_CODENAME: 'bullseye'
+    REVISION: '1'
+  v1.29-go1.21-bullseye:
+    CONFIG: 'go1.21-bullseye'
+    IMAGE_VERSION: 'v1.29.0-go1.21.10-bullseye.0'
+    KUBERNETES_VERSION: 'v1.29.0'
+    GO_VERSION: '1.21.10'
+    GO_MAJOR_VERSION: '1.21'
+    OS_CODENAME: 'bullseye'
+    REVISION: '0'
+`
+
+	testCrossMakefile = `KUBERNETES_VERSION ?= v1.30.0
+GO_VERSION ?= 1.22.2
+GO_MAJOR_VERSION ?= 1.22
+REVISION ?= 1
+`
+
+	testDependencies = `dependencies:
+  - name: "golang"
+    version: 1.22.2
+  - name: "registry.k8s.io/build-image/kube-cross (v1.30-go1.22)"
+    version: v1.30.0-go1.22.2-bullseye.1
+`
+)
+
+var testImages = []Image{{Name: kubeCrossImageName, Path: "images/build/cross", Project: "project"}}
+
+func TestLatestGoVersions(t *testing.T) {
+	for _, tc := range []struct {
+		prepare func(*kubecrossfakes.FakeImpl)
+		assert  func(map[string]string, error)
+	}{
+		{ // success
+			prepare: func(mock *kubecrossfakes.FakeImpl) {
+				mock.GetURLResponseReturns(testGoReleases, nil)
+			},
+			assert: func(res map[string]string, err error) {
+				require.Nil(t, err)
+				require.Equal(t, map[string]string{"1.22": "1.22.3", "1.21": "1.21.10"}, res)
+			},
+		},
+		{ // failure GetURLResponse
+			prepare: func(mock *kubecrossfakes.FakeImpl) {
+				mock.GetURLResponseReturns("", errors.New(""))
+			},
+			assert: func(res map[string]string, err error) {
+				require.NotNil(t, err)
+			},
+		},
+		{ // failure no stable releases
+			prepare: func(mock *kubecrossfakes.FakeImpl) {
+				mock.GetURLResponseReturns(`[{"version": "go1.23rc1", "stable": false}]`, nil)
+			},
+			assert: func(res map[string]string, err error) {
+				require.NotNil(t, err)
+			},
+		},
+	} {
+		mock := &kubecrossfakes.FakeImpl{}
+		tc.prepare(mock)
+
+		sut := NewUpdater(DefaultUpdateOptions())
+		sut.impl = mock
+
+		tc.assert(sut.LatestGoVersions())
+	}
+}
+
+func TestApplyVariantBumps(t *testing.T) {
+	bumps, err := variantBumps(
+		testImages[0], []byte(testCrossVariants), map[string]string{"1.22": "1.22.3", "1.21": "1.21.10"},
+	)
+	require.Nil(t, err)
+	require.Len(t, bumps, 1)
+	require.Equal(t, "v1.30-go1.22-bullseye", bumps[0].Variant)
+	require.Equal(t, "1.22.2", bumps[0].OldGoVersion)
+	require.Equal(t, "1.22.3", bumps[0].NewGoVersion)
+	require.Equal(t, "v1.30.0-go1.22.3-bullseye.0", bumps[0].NewImageVersion)
+
+	res := applyVariantBumps(testCrossVariants, bumps)
+	require.Contains(t, res, "    IMAGE_VERSION: 'v1.30.0-go1.22.3-bullseye.0'\n")
+	require.Contains(t, res, "    GO_VERSION: '1.22.3'\n")
+	require.Contains(t, res, "    REVISION: '0'\n")
+	require.Contains(t, res, "    IMAGE_VERSION: 'v1.29.0-go1.21.10-bullseye.0'\n")
+	require.Contains(t, res, "    GO_VERSION: '1.21.10'\n")
+}
+
+func TestUpdaterRun(t *testing.T) {
+	files := map[string]string{
+		filepath.Join("images/build/cross", variantsFile): testCrossVariants,
+		filepath.Join("images/build/cross", makefile):     testCrossMakefile,
+		dependenciesFile:    testDependencies,
+		k8sDependenciesFile: testDependencies,
+		k8sGoVersionFile:    "1.22.2\n",
+	}
+	readFile := func(path string) ([]byte, error) {
+		if content, ok := files[path]; ok {
+			return []byte(content), nil
+		}
+		return nil, fs.ErrNotExist
+	}
+	getURLResponse := func(url string) (string, error) {
+		if url == GoReleasesURL {
+			return testGoReleases, nil
+		}
+		if strings.Contains(url, "/master/") {
+			return "v1.30.0-go1.22.2-bullseye.1", nil
+		}
+		return "v1.30.0-go1.22.2-bullseye.0", nil
+	}
+
+	for _, tc := range []struct {
+		noMock  bool
+		fork    string
+		prepare func(*kubecrossfakes.FakeImpl)
+		assert  func(*kubecrossfakes.FakeImpl, []*Bump, error)
+	}{
+		{ // success mock
+			prepare: func(*kubecrossfakes.FakeImpl) {},
+			assert: func(mock *kubecrossfakes.FakeImpl, bumps []*Bump, err error) {
+				require.Nil(t, err)
+				require.Len(t, bumps, 1)
+				require.Equal(t, 3, mock.WriteFileCallCount())
+				require.Zero(t, mock.SubmitBuildCallCount())
+				require.Zero(t, mock.PrepareForkCallCount())
+				require.Zero(t, mock.CreatePullRequestCallCount())
+
+				_, content := mock.WriteFileArgsForCall(1)
+				require.Contains(t, string(content), "GO_VERSION ?= 1.22.3\n")
+				require.Contains(t, string(content), "REVISION ?= 0\n")
+
+				_, content = mock.WriteFileArgsForCall(2)
+				require.Contains(t, string(content), "    version: 1.22.3\n")
+				require.Contains(t, string(content), "    version: v1.30.0-go1.22.3-bullseye.0\n")
+			},
+		},
+		{ // success no mock
+			noMock:  true,
+			fork:    "fork",
+			prepare: func(*kubecrossfakes.FakeImpl) {},
+			assert: func(mock *kubecrossfakes.FakeImpl, bumps []*Bump, err error) {
+				require.Nil(t, err)
+				require.Equal(t, 1, mock.SubmitBuildCallCount())
+				_, config, project, substitutions := mock.SubmitBuildArgsForCall(0)
+				require.Equal(t, filepath.Join("images/build/cross", cloudBuildFile), config)
+				require.Equal(t, "project", project)
+				require.Equal(t, "1.22.3", substitutions["_GO_VERSION"])
+				require.Equal(t, "v1.30.0-go1.22.3-bullseye.0", substitutions["_IMAGE_VERSION"])
+
+				// Only the master branch uses the bumped image
+				require.Equal(t, 1, mock.PrepareForkCallCount())
+				_, baseBranch, fork, _ := mock.PrepareForkArgsForCall(0)
+				require.Equal(t, "master", baseBranch)
+				require.Equal(t, "fork", fork)
+				require.Equal(t, 3, mock.AddCallCount())
+				require.Equal(t, 1, mock.CommitCallCount())
+				require.Equal(t, 1, mock.PushToRemoteCallCount())
+				require.Equal(t, 1, mock.CleanupCallCount())
+				require.Equal(t, 1, mock.CreatePullRequestCallCount())
+				base, head, title, body := mock.CreatePullRequestArgsForCall(0)
+				require.Equal(t, "master", base)
+				require.True(t, strings.HasPrefix(head, "fork:"))
+				require.Equal(t, "[go] Bump images, dependencies and versions to go 1.22.3", title)
+				require.Contains(t, body, "\n/hold\n")
+			},
+		},
+		{ // failure no fork in no mock mode
+			noMock:  true,
+			prepare: func(*kubecrossfakes.FakeImpl) {},
+			assert: func(mock *kubecrossfakes.FakeImpl, bumps []*Bump, err error) {
+				require.NotNil(t, err)
+				require.Zero(t, mock.GetURLResponseCallCount())
+			},
+		},
+		{ // failure SubmitBuild
+			noMock: true,
+			fork:   "fork",
+			prepare: func(mock *kubecrossfakes.FakeImpl) {
+				mock.SubmitBuildReturns(errors.New(""))
+			},
+			assert: func(mock *kubecrossfakes.FakeImpl, bumps []*Bump, err error) {
+				require.NotNil(t, err)
+				require.Zero(t, mock.CreatePullRequestCallCount())
+			},
+		},
+		{ // failure CreatePullRequest
+			noMock: true,
+			fork:   "fork",
+			prepare: func(mock *kubecrossfakes.FakeImpl) {
+				mock.CreatePullRequestReturns(0, errors.New(""))
+			},
+			assert: func(mock *kubecrossfakes.FakeImpl, bumps []*Bump, err error) {
+				require.NotNil(t, err)
+				require.Equal(t, 1, mock.CleanupCallCount())
+			},
+		},
+	} {
+		mock := &kubecrossfakes.FakeImpl{}
+		mock.ReadFileCalls(readFile)
+		mock.GetURLResponseCalls(getURLResponse)
+		tc.prepare(mock)
+
+		opts := DefaultUpdateOptions()
+		opts.Images = testImages
+		opts.NoMock = tc.noMock
+		opts.Fork = tc.fork
+
+		sut := NewUpdater(opts)
+		sut.impl = mock
+
+		bumps, err := sut.Run()
+		tc.assert(mock, bumps, err)
+	}
+}
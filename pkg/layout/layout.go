@@ -0,0 +1,302 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package layout contains the naming policy of the artifact paths in the
+// buckets. Downstream rebuilds, like vendor builds, can provide their own
+// policy to use different prefixes or suffixes without changing the push
+// code.
+package layout
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-sdk/object"
+	"sigs.k8s.io/release-utils/env"
+	"sigs.k8s.io/yaml"
+)
+
+// PolicyEnvKey is the environment variable containing the default path to
+// the policy file.
+const PolicyEnvKey = "KREL_LAYOUT_POLICY"
+
+// The templates of the default policy, which match the historic layout.
+const (
+	// DefaultRelease is the default template of release paths, for example
+	// gs://<bucket>/<root>[/fast]/<version>.
+	DefaultRelease = "{{ .Bucket }}/{{ .Root }}{{ if .Fast }}/fast{{ end }}/{{ .Version }}"
+
+	// DefaultMarker is the default template of the directory containing the
+	// version markers, for example gs://<bucket>/<root>[/fast].
+	DefaultMarker = "{{ .Bucket }}/{{ .Root }}{{ if .Fast }}/fast{{ end }}"
+
+	// DefaultStage is the default template of staged builds, for example
+	// gs://<bucket>/stage/<build-version>.
+	DefaultStage = "{{ .Bucket }}/stage/{{ .BuildVersion }}"
+)
+
+// Values are the variables available in the templates of a policy.
+type Values struct {
+	// Bucket is the bucket without gs:// prefix.
+	Bucket string
+
+	// Root is the top-level directory of the build type, for example "ci"
+	// or "release".
+	Root string
+
+	// Version is the version of the artifacts.
+	Version string
+
+	// BuildVersion is the version of a staged build.
+	BuildVersion string
+
+	// Fast indicates a fast build.
+	Fast bool
+}
+
+// Policy contains the templates of the artifact paths. Empty templates use
+// their default. The templates render to bucket paths without gs:// prefix.
+type Policy struct {
+	// Release is the template of the path of released artifacts.
+	Release string `json:"release,omitempty"`
+
+	// Marker is the template of the directory containing version markers.
+	Marker string `json:"marker,omitempty"`
+
+	// Stage is the template of the path of staged builds.
+	Stage string `json:"stage,omitempty"`
+
+	release, marker, stage *template.Template
+}
+
+// DefaultPolicy returns the policy of the historic layout.
+func DefaultPolicy() *Policy {
+	p := &Policy{}
+	if err := p.Validate(); err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Load reads a policy from the provided YAML file.
+func Load(file string) (*Policy, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read layout policy: %w", err)
+	}
+	p := &Policy{}
+	if err := yaml.UnmarshalStrict(content, p); err != nil {
+		return nil, fmt.Errorf("unmarshal layout policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("validate layout policy %s: %w", file, err)
+	}
+	return p, nil
+}
+
+// Validate parses the templates and ensures that they render to a path for
+// sample values.
+func (p *Policy) Validate() error {
+	sample := &Values{
+		Bucket: "bucket", Root: "root", Version: "v1.0.0", BuildVersion: "v1.0.0-rc.0", Fast: true,
+	}
+	for _, t := range []struct {
+		name     string
+		text     *string
+		defaults string
+		parsed   **template.Template
+	}{
+		{"release", &p.Release, DefaultRelease, &p.release},
+		{"marker", &p.Marker, DefaultMarker, &p.marker},
+		{"stage", &p.Stage, DefaultStage, &p.stage},
+	} {
+		text := *t.text
+		if text == "" {
+			text = t.defaults
+		}
+		parsed, err := template.New(t.name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("parse %s template: %w", t.name, err)
+		}
+		if _, err := render(parsed, sample); err != nil {
+			return fmt.Errorf("render %s template: %w", t.name, err)
+		}
+		*t.parsed = parsed
+	}
+	return nil
+}
+
+// ReleasePath returns the bucket path of the released artifacts.
+func (p *Policy) ReleasePath(bucket, root, version string, fast bool) (string, error) {
+	if root == "" {
+		return "", errors.New("GCS root must be specified")
+	}
+	return render(p.release, &Values{Bucket: trimPrefix(bucket), Root: root, Version: version, Fast: fast})
+}
+
+// MarkerPath returns the bucket path of the directory containing the version
+// markers.
+func (p *Policy) MarkerPath(bucket, root string, fast bool) (string, error) {
+	if root == "" {
+		return "", errors.New("GCS root must be specified")
+	}
+	return render(p.marker, &Values{Bucket: trimPrefix(bucket), Root: root, Fast: fast})
+}
+
+// StagePath returns the bucket path of a staged build. The provided elements
+// get appended to the path.
+func (p *Policy) StagePath(bucket, buildVersion string, elem ...string) string {
+	bucket = trimPrefix(bucket)
+	stagePath, err := render(p.stage, &Values{Bucket: bucket, BuildVersion: buildVersion})
+	if err != nil {
+		// Not possible for validated policies
+		logrus.Errorf("Unable to render stage path, using the default: %v", err)
+		stagePath = path.Join(bucket, "stage", buildVersion)
+	}
+	return path.Join(append([]string{stagePath}, elem...)...)
+}
+
+func trimPrefix(bucket string) string {
+	return strings.TrimPrefix(bucket, object.GcsPrefix)
+}
+
+func render(t *template.Template, values *Values) (string, error) {
+	rendered := &strings.Builder{}
+	if err := t.Execute(rendered, values); err != nil {
+		return "", err
+	}
+	result := path.Clean(strings.TrimSpace(rendered.String()))
+	if result == "." || result == "/" {
+		return "", errors.New("rendered an empty path")
+	}
+	return result, nil
+}
+
+// Options are the options for selecting the policy.
+type Options struct {
+	// PolicyFile is the YAML file containing the policy. Empty means the
+	// default policy.
+	PolicyFile string
+
+	// Release, Marker and Stage are templates which take precedence over the
+	// ones of the PolicyFile. They are used for forwarding the policy to the
+	// GCB jobs.
+	Release string
+	Marker  string
+	Stage   string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		PolicyFile: env.Default(PolicyEnvKey, ""),
+	}
+}
+
+// AddFlags adds the layout flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.PolicyFile,
+		"layout-policy",
+		o.PolicyFile,
+		fmt.Sprintf("YAML file of the artifact path templates in the buckets (default $%s)", PolicyEnvKey),
+	)
+	flags.StringVar(
+		&o.Release,
+		"layout-release-template",
+		o.Release,
+		"Go template of the release path in the buckets, takes precedence over --layout-policy",
+	)
+	flags.StringVar(
+		&o.Marker,
+		"layout-marker-template",
+		o.Marker,
+		"Go template of the version marker directory in the buckets, takes precedence over --layout-policy",
+	)
+	flags.StringVar(
+		&o.Stage,
+		"layout-stage-template",
+		o.Stage,
+		"Go template of the stage path in the buckets, takes precedence over --layout-policy",
+	)
+}
+
+var (
+	mu      sync.RWMutex
+	current = DefaultPolicy()
+)
+
+// Setup loads the policy of the provided options and uses it for the push
+// code.
+func Setup(opts *Options) error {
+	overrides := opts.Release != "" || opts.Marker != "" || opts.Stage != ""
+	if opts.PolicyFile == "" && !overrides {
+		SetDefault(nil)
+		return nil
+	}
+
+	p := &Policy{}
+	if opts.PolicyFile != "" {
+		var err error
+		if p, err = Load(opts.PolicyFile); err != nil {
+			return err
+		}
+		logrus.Infof("Using artifact layout policy %s", opts.PolicyFile)
+	}
+	if overrides {
+		if opts.Release != "" {
+			p.Release = opts.Release
+		}
+		if opts.Marker != "" {
+			p.Marker = opts.Marker
+		}
+		if opts.Stage != "" {
+			p.Stage = opts.Stage
+		}
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("validate layout templates: %w", err)
+		}
+		logrus.Info("Using artifact layout templates from the command line")
+	}
+	SetDefault(p)
+	return nil
+}
+
+// SetDefault sets the policy used by the push code. A nil policy restores
+// the default one.
+func SetDefault(p *Policy) {
+	mu.Lock()
+	defer mu.Unlock()
+	if p == nil {
+		p = DefaultPolicy()
+	}
+	current = p
+}
+
+// Default returns the policy used by the push code.
+func Default() *Policy {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package layout_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/layout"
+)
+
+func TestDefaultPolicy(t *testing.T) {
+	sut := layout.DefaultPolicy()
+
+	for _, tc := range []struct {
+		root, version string
+		fast          bool
+		expected      string
+		shouldErr     bool
+	}{
+		{root: "release", version: "v1.30.0", expected: "bucket/release/v1.30.0"},
+		{root: "ci", version: "v1.30.0-alpha.1", fast: true, expected: "bucket/ci/fast/v1.30.0-alpha.1"},
+		{root: "ci", fast: true, expected: "bucket/ci/fast"},
+		{version: "v1.30.0", shouldErr: true},
+	} {
+		res, err := sut.ReleasePath("gs://bucket", tc.root, tc.version, tc.fast)
+		if tc.shouldErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, res)
+	}
+
+	marker, err := sut.MarkerPath("bucket", "ci", true)
+	require.NoError(t, err)
+	require.Equal(t, "bucket/ci/fast", marker)
+
+	_, err = sut.MarkerPath("bucket", "", false)
+	require.Error(t, err)
+
+	require.Equal(t,
+		"bucket/stage/v1.30.0-rc.0/v1.30.0/gcs-stage",
+		sut.StagePath("bucket", "v1.30.0-rc.0", "v1.30.0", "gcs-stage"),
+	)
+}
+
+func TestLoad(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		content   string
+		release   string
+		stage     string
+		shouldErr bool
+	}{
+		{
+			name:    "custom release with default stage",
+			content: `release: "{{ .Bucket }}/vendor/{{ .Root }}/{{ .Version }}-vendor"`,
+			release: "bucket/vendor/release/v1.30.0-vendor",
+			stage:   "bucket/stage/v1.30.0-rc.0",
+		},
+		{
+			name:    "custom stage",
+			content: `stage: "{{ .Bucket }}/vendor-stage/{{ .BuildVersion }}"`,
+			release: "bucket/release/v1.30.0",
+			stage:   "bucket/vendor-stage/v1.30.0-rc.0",
+		},
+		{
+			name:      "unknown field",
+			content:   `releases: "{{ .Bucket }}"`,
+			shouldErr: true,
+		},
+		{
+			name:      "invalid template",
+			content:   `release: "{{ .Bucket"`,
+			shouldErr: true,
+		},
+		{
+			name:      "unknown variable",
+			content:   `release: "{{ .Unknown }}"`,
+			shouldErr: true,
+		},
+		{
+			name:      "empty path",
+			content:   `marker: "/"`,
+			shouldErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "layout.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(tc.content), 0o600))
+
+			sut, err := layout.Load(file)
+			if tc.shouldErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			release, err := sut.ReleasePath("bucket", "release", "v1.30.0", false)
+			require.NoError(t, err)
+			require.Equal(t, tc.release, release)
+			require.Equal(t, tc.stage, sut.StagePath("bucket", "v1.30.0-rc.0"))
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "layout.yaml")
+	require.NoError(t, os.WriteFile(
+		file, []byte(`stage: "{{ .Bucket }}/vendor/{{ .BuildVersion }}"`), 0o600,
+	))
+
+	require.NoError(t, layout.Setup(&layout.Options{PolicyFile: file}))
+	defer layout.SetDefault(nil)
+	require.Equal(t, "bucket/vendor/v1", layout.Default().StagePath("bucket", "v1"))
+
+	require.Error(t, layout.Setup(&layout.Options{PolicyFile: filepath.Join(t.TempDir(), "missing")}))
+
+	require.NoError(t, layout.Setup(&layout.Options{
+		PolicyFile: file, Stage: "{{ .Bucket }}/gcb/{{ .BuildVersion }}",
+	}))
+	require.Equal(t, "bucket/gcb/v1", layout.Default().StagePath("bucket", "v1"))
+
+	require.Error(t, layout.Setup(&layout.Options{Marker: "{{ .Missing }}"}))
+
+	require.NoError(t, layout.Setup(&layout.Options{}))
+	require.Equal(t, "bucket/stage/v1", layout.Default().StagePath("bucket", "v1"))
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"k8s.io/release/pkg/redact"
+	"sigs.k8s.io/release-utils/log"
+)
+
+const (
+	// FormatText is the human readable log format.
+	FormatText = "text"
+
+	// FormatJSON is the machine parseable log format.
+	FormatJSON = "json"
+
+	// ModuleField is the log field containing the module of an entry.
+	ModuleField = "module"
+
+	// DefaultLevel is the default global log level.
+	DefaultLevel = "info"
+
+	modulePrefix = "k8s.io/release/"
+)
+
+// Options are the settings of the global logger.
+type Options struct {
+	// Level is the global log level.
+	Level string
+
+	// Format is the log format, can be one of FormatText or FormatJSON.
+	Format string
+
+	// ModuleLevels override the log level for single modules, for example
+	// "anago=debug". Modules are the packages of this repository like
+	// "anago", "gcp/gcb" or "cmd/krel".
+	ModuleLevels []string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Level:  DefaultLevel,
+		Format: FormatText,
+	}
+}
+
+// AddFlags registers the format and module level flags of the options to
+// the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Format,
+		"log-format",
+		o.Format,
+		fmt.Sprintf("the logging format, either '%s' or '%s'", FormatText, FormatJSON),
+	)
+
+	flags.StringSliceVar(
+		&o.ModuleLevels,
+		"log-module-levels",
+		o.ModuleLevels,
+		"per module overrides of the log level, for example 'anago=debug,gcp/gcb=warn'",
+	)
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if _, err := o.moduleLevels(); err != nil {
+		return err
+	}
+	switch o.Format {
+	case FormatText, FormatJSON:
+	default:
+		return fmt.Errorf(
+			"invalid log format %q, must be one of: %s, %s",
+			o.Format, FormatText, FormatJSON,
+		)
+	}
+	return nil
+}
+
+func (o *Options) moduleLevels() (map[string]logrus.Level, error) {
+	res := map[string]logrus.Level{}
+	for _, override := range o.ModuleLevels {
+		module, level, ok := strings.Cut(override, "=")
+		if !ok || module == "" {
+			return nil, fmt.Errorf("invalid module log level %q, expected <module>=<level>", override)
+		}
+		lvl, err := logrus.ParseLevel(level)
+		if err != nil {
+			return nil, fmt.Errorf("parse log level of module %s: %w", module, err)
+		}
+		res[strings.Trim(module, "/")] = lvl
+	}
+	return res, nil
+}
+
+var (
+	current   = DefaultOptions()
+	currentMu sync.RWMutex
+
+	redactOnce sync.Once
+)
+
+// Setup configures the global logger by using the provided options.
+func Setup(opts *Options) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("validating logging options: %w", err)
+	}
+	if err := log.SetupGlobalLogger(opts.Level); err != nil {
+		return fmt.Errorf("setup global logger: %w", err)
+	}
+
+	global := logrus.GetLevel()
+	modules, err := opts.moduleLevels()
+	if err != nil {
+		return err
+	}
+
+	// The logger has to be as verbose as the most verbose module, while the
+	// formatter drops all entries above the level of their module.
+	maxLevel := global
+	for _, lvl := range modules {
+		if lvl > maxLevel {
+			maxLevel = lvl
+		}
+	}
+	logrus.SetLevel(maxLevel)
+
+	// Release logs are routinely shared, which is why all entries get
+	// scrubbed from credentials. The hook survives later formatter changes.
+	redact.RegisterEnv(redact.DefaultEnvKeys...)
+	redactOnce.Do(func() { logrus.AddHook(redact.Hook{}) })
+
+	var formatter logrus.Formatter = logrus.StandardLogger().Formatter
+	if opts.Format == FormatJSON {
+		formatter = &logrus.JSONFormatter{}
+	}
+	logrus.SetFormatter(&moduleFormatter{
+		formatter:  formatter,
+		global:     global,
+		modules:    modules,
+		withModule: opts.Format == FormatJSON,
+	})
+
+	currentMu.Lock()
+	current = &Options{
+		Level:        global.String(),
+		Format:       opts.Format,
+		ModuleLevels: append([]string{}, opts.ModuleLevels...),
+	}
+	currentMu.Unlock()
+
+	logrus.Debugf("Using log format %q and module levels %v", opts.Format, opts.ModuleLevels)
+	return nil
+}
+
+// Level returns the configured global log level.
+func Level() string {
+	currentMu.RLock()
+	defer currentMu.RUnlock()
+	return current.Level
+}
+
+// Format returns the configured log format.
+func Format() string {
+	currentMu.RLock()
+	defer currentMu.RUnlock()
+	return current.Format
+}
+
+// For returns a log entry for the provided module, which takes precedence
+// over the module detected from the caller.
+func For(module string) *logrus.Entry {
+	return logrus.WithField(ModuleField, module)
+}
+
+// moduleFormatter filters the entries by their module level and adds the
+// module as field before formatting them.
+type moduleFormatter struct {
+	formatter  logrus.Formatter
+	global     logrus.Level
+	modules    map[string]logrus.Level
+	withModule bool
+}
+
+// Format formats the entry or returns no output if the entry is above the
+// level of its module.
+func (m *moduleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if len(m.modules) == 0 && !m.withModule {
+		return m.formatter.Format(entry)
+	}
+
+	module, ok := entry.Data[ModuleField].(string)
+	if !ok {
+		module = callerModule()
+	}
+
+	if moduleLevel(module, m.global, m.modules) < entry.Level {
+		return nil, nil
+	}
+
+	if m.withModule && module != "" && !ok {
+		withModule := entry.WithField(ModuleField, module)
+		withModule.Level = entry.Level
+		withModule.Message = entry.Message
+		withModule.Caller = entry.Caller
+		entry = withModule
+	}
+	return m.formatter.Format(entry)
+}
+
+// moduleLevel returns the level of the module, which is the level of the
+// most specific configured parent module or the global level.
+func moduleLevel(module string, global logrus.Level, modules map[string]logrus.Level) logrus.Level {
+	for module != "" {
+		if lvl, ok := modules[module]; ok {
+			return lvl
+		}
+		i := strings.LastIndex(module, "/")
+		if i < 0 {
+			break
+		}
+		module = module[:i]
+	}
+	return global
+}
+
+// callerModule returns the module of the first caller outside of the
+// logging packages.
+func callerModule() string {
+	const maxFrames = 32
+	pcs := make([]uintptr, maxFrames)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if pkg := packagePath(frame.Function); !isLoggingPackage(pkg) {
+			if !strings.HasPrefix(pkg, modulePrefix) {
+				return ""
+			}
+			return strings.TrimPrefix(strings.TrimPrefix(pkg, modulePrefix), "pkg/")
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// packagePath returns the import path of a fully qualified function name
+// like "k8s.io/release/pkg/anago.(*DefaultStage).Build".
+func packagePath(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if slash < 0 {
+		slash = 0
+	}
+	if dot := strings.Index(function[slash:], "."); dot >= 0 {
+		return function[:slash+dot]
+	}
+	return function
+}
+
+func isLoggingPackage(pkg string) bool {
+	for _, p := range []string{
+		"github.com/sirupsen/logrus",
+		"sigs.k8s.io/release-utils/log",
+		modulePrefix + "pkg/logging",
+		"runtime",
+	} {
+		if pkg == p {
+			return true
+		}
+	}
+	return false
+}
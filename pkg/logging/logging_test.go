@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/logging"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		opts        *logging.Options
+		shouldError bool
+	}{
+		{ // default
+			opts: logging.DefaultOptions(),
+		},
+		{ // json with module levels
+			opts: &logging.Options{
+				Level:        "info",
+				Format:       logging.FormatJSON,
+				ModuleLevels: []string{"anago=debug", "gcp/gcb=warn"},
+			},
+		},
+		{ // invalid format
+			opts:        &logging.Options{Level: "info", Format: "xml"},
+			shouldError: true,
+		},
+		{ // invalid module level
+			opts: &logging.Options{
+				Level: "info", Format: logging.FormatText, ModuleLevels: []string{"anago=loud"},
+			},
+			shouldError: true,
+		},
+		{ // missing module
+			opts: &logging.Options{
+				Level: "info", Format: logging.FormatText, ModuleLevels: []string{"debug"},
+			},
+			shouldError: true,
+		},
+	} {
+		err := tc.opts.Validate()
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
+func TestSetup(t *testing.T) {
+	logger := logrus.StandardLogger()
+	oldOut, oldFormatter, oldLevel := logger.Out, logger.Formatter, logger.Level
+	defer func() {
+		logger.SetOutput(oldOut)
+		logger.SetFormatter(oldFormatter)
+		logger.SetLevel(oldLevel)
+	}()
+
+	require.Nil(t, logging.Setup(&logging.Options{
+		Level:        "warn",
+		Format:       logging.FormatJSON,
+		ModuleLevels: []string{"logging_test=info", "anago/sub=debug"},
+	}))
+	require.Equal(t, "warning", logging.Level())
+	require.Equal(t, logging.FormatJSON, logging.Format())
+	require.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+
+	logrus.Info("caller module info")
+	logrus.Debug("caller module debug")
+	logging.For("anago").Info("anago info")
+	logging.For("anago/sub").Debug("anago sub debug")
+	logging.For("other").Warn("other warn")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	entries := []map[string]string{}
+	for _, line := range lines {
+		entry := map[string]string{}
+		require.Nil(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+
+	require.Equal(t, "caller module info", entries[0]["msg"])
+	require.Equal(t, "logging_test", entries[0][logging.ModuleField])
+	require.Equal(t, "info", entries[0]["level"])
+	require.Equal(t, "anago sub debug", entries[1]["msg"])
+	require.Equal(t, "other warn", entries[2]["msg"])
+	require.Equal(t, "other", entries[2][logging.ModuleField])
+}
+
+func TestSetupFailure(t *testing.T) {
+	require.NotNil(t, logging.Setup(&logging.Options{Level: "invalid", Format: logging.FormatText}))
+	require.NotNil(t, logging.Setup(&logging.Options{Level: "info", Format: "invalid"}))
+}
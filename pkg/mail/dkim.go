@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mail
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// dkimHeaders are the headers covered by the DKIM signature, if present.
+var dkimHeaders = []string{
+	"From", "To", "Subject", "Date", "Message-ID", "MIME-Version",
+	"Content-Type", "Content-Transfer-Encoding", "List-Id", "List-Unsubscribe",
+}
+
+var wspRegex = regexp.MustCompile(`[ \t]+`)
+
+// DKIMOptions are the settings for signing mails with DKIM (RFC 6376).
+type DKIMOptions struct {
+	// Domain is the signing domain (d=), which has to match the domain of
+	// the sender address.
+	Domain string
+
+	// Selector is the DNS selector (s=) of the public key, which is
+	// published as TXT record at <selector>._domainkey.<domain>.
+	Selector string
+
+	// PrivateKey is the path to the PEM encoded RSA private key.
+	PrivateKey string
+}
+
+// Validate checks if the options are correctly set.
+func (o *DKIMOptions) Validate() error {
+	if o.Domain == "" {
+		return errors.New("need to specify a DKIM domain")
+	}
+	if o.Selector == "" {
+		return errors.New("need to specify a DKIM selector")
+	}
+	if o.PrivateKey == "" {
+		return errors.New("need to specify a DKIM private key")
+	}
+	return nil
+}
+
+type dkimSigner struct {
+	domain   string
+	selector string
+	key      crypto.Signer
+}
+
+// newDKIMSigner loads the private key of the provided options.
+func newDKIMSigner(opts *DKIMOptions) (*dkimSigner, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating DKIM options: %w", err)
+	}
+	content, err := os.ReadFile(opts.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("read DKIM private key: %w", err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", opts.PrivateKey)
+	}
+
+	var key any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse DKIM private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("DKIM private key is of type %T, but has to be RSA", key)
+	}
+	return &dkimSigner{domain: opts.Domain, selector: opts.Selector, key: rsaKey}, nil
+}
+
+// Sign prepends a DKIM-Signature header to the CRLF separated message,
+// using relaxed header and body canonicalization.
+func (d *dkimSigner) Sign(msg []byte, now time.Time) ([]byte, error) {
+	header, body, found := bytes.Cut(msg, []byte("\r\n\r\n"))
+	if !found {
+		return nil, errors.New("message contains no header separator")
+	}
+
+	fields := map[string]string{}
+	for _, field := range splitHeader(string(header)) {
+		name, _, _ := strings.Cut(field, ":")
+		fields[strings.ToLower(strings.TrimSpace(name))] = field
+	}
+
+	bodyHash := sha256.Sum256(relaxedBody(body))
+
+	signed := []string{}
+	hash := sha256.New()
+	for _, name := range dkimHeaders {
+		field, ok := fields[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+		signed = append(signed, strings.ToLower(name))
+		hash.Write([]byte(relaxedHeader(field) + "\r\n"))
+	}
+
+	signature := fmt.Sprintf(
+		"DKIM-Signature: v=1; a=rsa-sha256; c=relaxed/relaxed; d=%s; s=%s; t=%d; h=%s; bh=%s; b=",
+		d.domain, d.selector, now.Unix(), strings.Join(signed, ":"),
+		base64.StdEncoding.EncodeToString(bodyHash[:]),
+	)
+	hash.Write([]byte(relaxedHeader(signature)))
+
+	b, err := d.key.Sign(rand.Reader, hash.Sum(nil), crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("sign message: %w", err)
+	}
+
+	res := &bytes.Buffer{}
+	res.WriteString(signature + base64.StdEncoding.EncodeToString(b) + "\r\n")
+	res.Write(msg)
+	return res.Bytes(), nil
+}
+
+// splitHeader returns the unfolded header fields of the message header.
+func splitHeader(header string) []string {
+	fields := []string{}
+	for _, line := range strings.Split(header, "\r\n") {
+		if len(fields) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			fields[len(fields)-1] += "\r\n" + line
+			continue
+		}
+		fields = append(fields, line)
+	}
+	return fields
+}
+
+// relaxedHeader canonicalizes a header field as described in RFC 6376
+// section 3.4.2, without the trailing CRLF.
+func relaxedHeader(field string) string {
+	name, value, _ := strings.Cut(field, ":")
+	value = strings.ReplaceAll(value, "\r\n", "")
+	value = strings.TrimSpace(wspRegex.ReplaceAllString(value, " "))
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + value
+}
+
+// relaxedBody canonicalizes the message body as described in RFC 6376
+// section 3.4.4.
+func relaxedBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(wspRegex.ReplaceAllString(line, " "), " ")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return []byte{}
+	}
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
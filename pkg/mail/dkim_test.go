@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mail
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelaxedHeader(t *testing.T) {
+	for _, tc := range []struct {
+		field    string
+		expected string
+	}{
+		{"Subject: Hello", "subject:Hello"},
+		{"SUBJECT :  Hello \t World  ", "subject:Hello World"},
+		{"To: a@example.org,\r\n\tb@example.org", "to:a@example.org, b@example.org"},
+	} {
+		require.Equal(t, tc.expected, relaxedHeader(tc.field))
+	}
+}
+
+func TestRelaxedBody(t *testing.T) {
+	for _, tc := range []struct {
+		body     string
+		expected string
+	}{
+		{"", ""},
+		{"\r\n\r\n", ""},
+		{"line", "line\r\n"},
+		{" a  \t b \r\nc\t\r\n\r\n\r\n", " a b\r\nc\r\n"},
+	} {
+		require.Equal(t, tc.expected, string(relaxedBody([]byte(tc.body))))
+	}
+}
+
+func TestDKIMSign(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(t.TempDir(), "dkim.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{
+		Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}), 0o600))
+
+	_, err = newDKIMSigner(&DKIMOptions{Domain: "example.org", Selector: "krel"})
+	require.Error(t, err)
+	_, err = newDKIMSigner(&DKIMOptions{
+		Domain: "example.org", Selector: "krel", PrivateKey: filepath.Join(t.TempDir(), "missing"),
+	})
+	require.Error(t, err)
+
+	signer, err := newDKIMSigner(&DKIMOptions{
+		Domain: "example.org", Selector: "krel", PrivateKey: keyFile,
+	})
+	require.NoError(t, err)
+
+	_, err = signer.Sign([]byte("no header separator"), time.Now())
+	require.Error(t, err)
+
+	msg := "From: Jane <jane@example.org>\r\nTo: list@example.org\r\n" +
+		"Subject: Hello\r\nX-Unsigned: value\r\n\r\n<p>Body</p>  \r\n\r\n"
+	res, err := signer.Sign([]byte(msg), time.Unix(1700000000, 0))
+	require.NoError(t, err)
+
+	signature, rest, found := strings.Cut(string(res), "\r\n")
+	require.True(t, found)
+	require.Equal(t, msg, rest)
+	require.Contains(t, signature, "d=example.org; s=krel; t=1700000000; h=from:to:subject;")
+
+	bodyHash := sha256.Sum256([]byte("<p>Body</p>\r\n"))
+	require.Contains(t, signature, "bh="+base64.StdEncoding.EncodeToString(bodyHash[:])+";")
+
+	unsigned, b, found := strings.Cut(signature, "; b=")
+	require.True(t, found)
+	sig, err := base64.StdEncoding.DecodeString(b)
+	require.NoError(t, err)
+
+	hash := sha256.New()
+	hash.Write([]byte("from:Jane <jane@example.org>\r\nto:list@example.org\r\nsubject:Hello\r\n"))
+	hash.Write([]byte(relaxedHeader(unsigned + "; b=")))
+	require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash.Sum(nil), sig))
+}
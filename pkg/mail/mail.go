@@ -17,9 +17,16 @@ limitations under the License.
 package mail
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"mime"
 	"net/http"
+	netmail "net/mail"
+	"net/smtp"
+	"strings"
+	"time"
 
 	"github.com/sendgrid/rest"
 	"github.com/sendgrid/sendgrid-go"
@@ -36,12 +43,28 @@ const (
 	KubernetesAnnounceTestGoogleGroup GoogleGroup = "kubernetes-announce-test"
 )
 
+const (
+	// sendgridSMTPHost is the SMTP relay of Sendgrid, which is used for
+	// delivering DKIM signed messages unchanged.
+	sendgridSMTPHost = "smtp.sendgrid.net"
+
+	// sendgridSMTPUser is the user to authenticate with the API key.
+	sendgridSMTPUser = "apikey"
+
+	// sendgridSMTPAPIHeader disables the click and open tracking of
+	// Sendgrid, which would rewrite the body and break the DKIM signature.
+	sendgridSMTPAPIHeader = `{"filters":{"clicktrack":{"settings":{"enable":0}},"opentrack":{"settings":{"enable":0}}}}`
+)
+
 type Sender struct {
 	apiKey     string
 	sendClient SendClient
 	apiClient  APIClient
+	smtpClient SMTPClient
 	sender     *mail.Email
 	recipients []*mail.Email
+	headers    map[string]string
+	dkim       *dkimSigner
 }
 
 func NewSender(apiKey string) *Sender {
@@ -49,9 +72,48 @@ func NewSender(apiKey string) *Sender {
 		apiKey:     apiKey,
 		sendClient: sendgrid.NewSendClient(apiKey),
 		apiClient:  &sendgridAPIClient{},
+		smtpClient: &defaultSMTPClient{},
+		headers:    map[string]string{},
 	}
 }
 
+// SetSMTPClient can be used to set the client for sending DKIM signed mails
+func (s *Sender) SetSMTPClient(client SMTPClient) {
+	s.smtpClient = client
+}
+
+// SetListHeaders sets the List-Id and List-Unsubscribe headers (RFC 2919
+// and RFC 2369) of the mail, which are required by strict receivers for
+// bulk mails.
+func (s *Sender) SetListHeaders(id, unsubscribe string) {
+	s.headers["List-Id"] = fmt.Sprintf("<%s>", id)
+	s.headers["List-Unsubscribe"] = fmt.Sprintf("<%s>", unsubscribe)
+	logrus.WithField("headers", s.headers).Debugf("List headers set")
+}
+
+// SetGoogleGroupListHeaders sets the list headers of the provided Google
+// Group.
+func (s *Sender) SetGoogleGroupListHeaders(group GoogleGroup) {
+	domain := group.domain()
+	s.SetListHeaders(
+		fmt.Sprintf("%s.%s", group, domain),
+		fmt.Sprintf("mailto:%s+unsubscribe@%s", group, domain),
+	)
+}
+
+// SetDKIM enables signing the mails with the key of the provided options.
+// Signed mails get delivered via the SMTP relay of Sendgrid, because the
+// API does not allow submitting signed messages.
+func (s *Sender) SetDKIM(opts *DKIMOptions) error {
+	signer, err := newDKIMSigner(opts)
+	if err != nil {
+		return fmt.Errorf("create DKIM signer: %w", err)
+	}
+	s.dkim = signer
+	logrus.Debugf("DKIM signing enabled for domain %s", opts.Domain)
+	return nil
+}
+
 // SetSendClient can be used to set the sendgrid sender client
 func (s *Sender) SetSendClient(client SendClient) {
 	s.sendClient = client
@@ -75,6 +137,18 @@ type APIClient interface {
 	API(rest.Request) (*rest.Response, error)
 }
 
+//counterfeiter:generate . SMTPClient
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt mailfakes/fake_smtpclient.go > mailfakes/_fake_smtpclient.go && mv mailfakes/_fake_smtpclient.go mailfakes/fake_smtpclient.go"
+type SMTPClient interface {
+	SendMail(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+type defaultSMTPClient struct{}
+
+func (*defaultSMTPClient) SendMail(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	return smtp.SendMail(addr, a, from, to, msg)
+}
+
 type sendgridAPIClient struct{}
 
 func (s *sendgridAPIClient) API(request rest.Request) (*rest.Response, error) {
@@ -82,6 +156,10 @@ func (s *sendgridAPIClient) API(request rest.Request) (*rest.Response, error) {
 }
 
 func (s *Sender) Send(body, subject string) error {
+	if s.dkim != nil {
+		return s.sendSigned(body, subject)
+	}
+
 	html := mail.NewContent("text/html", body)
 
 	p := mail.NewPersonalization()
@@ -92,6 +170,9 @@ func (s *Sender) Send(body, subject string) error {
 		AddContent(html).
 		AddPersonalizations(p)
 	msg.Subject = subject
+	for key, value := range s.headers {
+		msg.SetHeader(key, value)
+	}
 
 	logrus.WithField("message", msg).Trace("Message prepared")
 
@@ -110,6 +191,74 @@ func (s *Sender) Send(body, subject string) error {
 	return nil
 }
 
+// sendSigned delivers a DKIM signed message via the Sendgrid SMTP relay.
+func (s *Sender) sendSigned(body, subject string) error {
+	if s.sender == nil {
+		return fmt.Errorf("mail sender must be set")
+	}
+	now := time.Now()
+	msg, err := s.dkim.Sign(s.rawMessage(body, subject, now), now)
+	if err != nil {
+		return fmt.Errorf("DKIM sign message: %w", err)
+	}
+	logrus.WithField("message", string(msg)).Trace("Signed message prepared")
+
+	to := []string{}
+	for _, r := range s.recipients {
+		to = append(to, r.Address)
+	}
+	if err := s.smtpClient.SendMail(
+		sendgridSMTPHost+":587",
+		smtp.PlainAuth("", sendgridSMTPUser, s.apiKey, sendgridSMTPHost),
+		s.sender.Address, to, msg,
+	); err != nil {
+		return fmt.Errorf("send mail via %s: %w", sendgridSMTPHost, err)
+	}
+
+	logrus.Debug("Signed mail successfully sent")
+	return nil
+}
+
+// rawMessage builds the MIME message of a HTML mail.
+func (s *Sender) rawMessage(body, subject string, now time.Time) []byte {
+	to := []string{}
+	for _, r := range s.recipients {
+		to = append(to, (&netmail.Address{Name: r.Name, Address: r.Address}).String())
+	}
+
+	_, domain, _ := strings.Cut(s.sender.Address, "@")
+	headers := [][2]string{
+		{"From", (&netmail.Address{Name: s.sender.Name, Address: s.sender.Address}).String()},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%d@%s>", now.UnixNano(), domain)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", `text/html; charset="utf-8"`},
+		{"Content-Transfer-Encoding", "base64"},
+		{"X-SMTPAPI", sendgridSMTPAPIHeader},
+	}
+	for _, key := range []string{"List-Id", "List-Unsubscribe"} {
+		if value, ok := s.headers[key]; ok {
+			headers = append(headers, [2]string{key, value})
+		}
+	}
+
+	msg := &bytes.Buffer{}
+	for _, h := range headers {
+		msg.WriteString(h[0] + ": " + h[1] + "\r\n")
+	}
+	msg.WriteString("\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(body))
+	for len(encoded) > 76 {
+		msg.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	msg.WriteString(encoded + "\r\n")
+	return msg.Bytes()
+}
+
 type SendError struct {
 	code       int
 	resBody    string
@@ -201,15 +350,19 @@ func (s *Sender) SetRecipients(recipientArgs ...string) error {
 func (s *Sender) SetGoogleGroupRecipients(groups ...GoogleGroup) error {
 	args := []string{}
 	for _, group := range groups {
-		if group == "dev" {
-			args = append(args, string(group), fmt.Sprintf("%s@kubernetes.io", group))
-		} else {
-			args = append(args, string(group), fmt.Sprintf("%s@googlegroups.com", group))
-		}
+		args = append(args, string(group), fmt.Sprintf("%s@%s", group, group.domain()))
 	}
 	return s.SetRecipients(args...)
 }
 
+// domain returns the mail domain of the Google Group.
+func (g GoogleGroup) domain() string {
+	if g == KubernetesDevGoogleGroup {
+		return "kubernetes.io"
+	}
+	return "googlegroups.com"
+}
+
 // GetRecipients can be used to get the recipients
 func (s *Sender) GetRecipients() []*mail.Email {
 	return s.recipients
@@ -17,8 +17,15 @@ limitations under the License.
 package mail_test
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/sendgrid/rest"
@@ -196,6 +203,59 @@ func sendTest(t *testing.T) {
 	}
 }
 
+func TestListHeaders(t *testing.T) {
+	m := mail.NewSender("")
+	c := &mailfakes.FakeSendClient{}
+	c.SendReturns(simpleResponse("", 202), nil)
+	m.SetSendClient(c)
+	m.SetGoogleGroupListHeaders(mail.KubernetesAnnounceGoogleGroup)
+
+	require.NoError(t, m.Send("some content", "some subject"))
+	require.Equal(t, map[string]string{
+		"List-Id":          "<kubernetes-announce.googlegroups.com>",
+		"List-Unsubscribe": "<mailto:kubernetes-announce+unsubscribe@googlegroups.com>",
+	}, c.SendArgsForCall(0).Headers)
+}
+
+func TestSendSigned(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "dkim.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{
+		Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}), 0o600))
+
+	m := mail.NewSender("some key")
+	sendClient := &mailfakes.FakeSendClient{}
+	m.SetSendClient(sendClient)
+	smtpClient := &mailfakes.FakeSMTPClient{}
+	m.SetSMTPClient(smtpClient)
+
+	require.Error(t, m.SetDKIM(&mail.DKIMOptions{Domain: "example.org"}))
+	require.NoError(t, m.SetDKIM(&mail.DKIMOptions{
+		Domain: "example.org", Selector: "krel", PrivateKey: keyFile,
+	}))
+	require.Error(t, m.Send("some content", "some subject"), "sender not set")
+
+	require.NoError(t, m.SetSender("Jane Doe", "djane@example.org"))
+	require.NoError(t, m.SetGoogleGroupRecipients(mail.KubernetesDevGoogleGroup))
+	m.SetGoogleGroupListHeaders(mail.KubernetesDevGoogleGroup)
+	require.NoError(t, m.Send("some content", "some subject"))
+
+	require.Zero(t, sendClient.SendCallCount())
+	require.Equal(t, 1, smtpClient.SendMailCallCount())
+	addr, _, from, to, msg := smtpClient.SendMailArgsForCall(0)
+	require.Equal(t, "smtp.sendgrid.net:587", addr)
+	require.Equal(t, "djane@example.org", from)
+	require.Equal(t, []string{"dev@kubernetes.io"}, to)
+	require.True(t, strings.HasPrefix(string(msg), "DKIM-Signature: v=1; a=rsa-sha256;"))
+	require.Contains(t, string(msg), "h=from:to:subject:date:message-id:mime-version:content-type:content-transfer-encoding:list-id:list-unsubscribe;")
+	require.Contains(t, string(msg), "\r\nList-Unsubscribe: <mailto:dev+unsubscribe@kubernetes.io>\r\n")
+
+	smtpClient.SendMailReturns(errors.New("test"))
+	require.Error(t, m.Send("some content", "some subject"))
+}
+
 func simpleResponse(body string, code int) *rest.Response {
 	return &rest.Response{Body: body, StatusCode: code}
 }
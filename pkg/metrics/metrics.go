@@ -0,0 +1,295 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// DefaultJob is the default job name of the pushed metrics.
+	DefaultJob = "krel"
+
+	namespace = "krel"
+)
+
+// Failure classes used for the failure metrics.
+const (
+	FailureClassTimeout    = "timeout"
+	FailureClassCanceled   = "canceled"
+	FailureClassNetwork    = "network"
+	FailureClassNotFound   = "not_found"
+	FailureClassPermission = "permission"
+	FailureClassUnknown    = "unknown"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	phaseDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "phase_duration_seconds",
+		Help:      "Duration of the last run of a phase in seconds.",
+	}, []string{"phase"})
+
+	phaseFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "phase_failures_total",
+		Help:      "Number of failed phases by failure class.",
+	}, []string{"phase", "class"})
+
+	artifacts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "artifacts_total",
+		Help:      "Number of handled artifacts.",
+	}, []string{"kind"})
+
+	artifactBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "artifacts_bytes_total",
+		Help:      "Size of the handled artifacts in bytes.",
+	}, []string{"kind"})
+
+	runDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "run_duration_seconds",
+		Help:      "Duration of the last run of a command in seconds.",
+	}, []string{"command"})
+
+	runSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "run_success",
+		Help:      "Whether the last run of a command succeeded (1) or failed (0).",
+	}, []string{"command"})
+)
+
+func init() {
+	registry.MustRegister(
+		phaseDuration,
+		phaseFailures,
+		artifacts,
+		artifactBytes,
+		runDuration,
+		runSuccess,
+	)
+}
+
+// Options are the settings for pushing metrics.
+type Options struct {
+	// PushgatewayURL is the URL of the Prometheus Pushgateway.
+	PushgatewayURL string
+
+	// RemoteWriteURL is the URL of a Prometheus remote-write endpoint.
+	RemoteWriteURL string
+
+	// Job is the job label of the pushed metrics.
+	Job string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Job: DefaultJob,
+	}
+}
+
+// AddFlags adds the metrics flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.PushgatewayURL,
+		"metrics-pushgateway-url",
+		o.PushgatewayURL,
+		"the Prometheus Pushgateway URL to push the run metrics to",
+	)
+
+	flags.StringVar(
+		&o.RemoteWriteURL,
+		"metrics-remote-write-url",
+		o.RemoteWriteURL,
+		"the Prometheus remote-write URL to push the run metrics to",
+	)
+}
+
+// Enabled returns true if any metrics endpoint is configured.
+func (o *Options) Enabled() bool {
+	return o.PushgatewayURL != "" || o.RemoteWriteURL != ""
+}
+
+var (
+	globalOptions   = DefaultOptions()
+	globalOptionsMu sync.RWMutex
+)
+
+// Setup stores the provided options globally, which makes them available
+// for nested runs, for example in Google Cloud Build.
+func Setup(opts *Options) {
+	globalOptionsMu.Lock()
+	defer globalOptionsMu.Unlock()
+	globalOptions = opts
+}
+
+// PushgatewayURL returns the globally configured Pushgateway URL.
+func PushgatewayURL() string {
+	globalOptionsMu.RLock()
+	defer globalOptionsMu.RUnlock()
+	return globalOptions.PushgatewayURL
+}
+
+// RemoteWriteURL returns the globally configured remote-write URL.
+func RemoteWriteURL() string {
+	globalOptionsMu.RLock()
+	defer globalOptionsMu.RUnlock()
+	return globalOptions.RemoteWriteURL
+}
+
+// Pusher pushes the collected metrics to the configured endpoints.
+type Pusher struct {
+	impl    impl
+	options *Options
+}
+
+// New creates a new Pusher instance.
+func New(opts *Options) *Pusher {
+	return &Pusher{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (p *Pusher) SetImpl(impl impl) {
+	p.impl = impl
+}
+
+// Push sends the collected metrics to all configured endpoints. It does
+// nothing if no endpoint is configured.
+func (p *Pusher) Push(ctx context.Context) error {
+	if !p.options.Enabled() {
+		logrus.Debug("No metrics endpoint specified, skipping push")
+		return nil
+	}
+
+	job := p.options.Job
+	if job == "" {
+		job = DefaultJob
+	}
+
+	if p.options.PushgatewayURL != "" {
+		logrus.Infof("Pushing metrics to Pushgateway %s", p.options.PushgatewayURL)
+		if err := p.impl.PushGateway(ctx, p.options.PushgatewayURL, job, registry); err != nil {
+			return fmt.Errorf("push metrics to pushgateway: %w", err)
+		}
+	}
+
+	if p.options.RemoteWriteURL != "" {
+		logrus.Infof("Pushing metrics to remote-write endpoint %s", p.options.RemoteWriteURL)
+		families, err := registry.Gather()
+		if err != nil {
+			return fmt.Errorf("gather metrics: %w", err)
+		}
+		body := encodeWriteRequest(families, job, time.Now())
+		if err := p.impl.Post(ctx, p.options.RemoteWriteURL, body, remoteWriteHeaders); err != nil {
+			return fmt.Errorf("push metrics to remote-write endpoint: %w", err)
+		}
+	}
+	return nil
+}
+
+// ObservePhase records the duration and the failure class of a phase.
+func ObservePhase(phase string, duration time.Duration, err error) {
+	phaseDuration.WithLabelValues(phase).Set(duration.Seconds())
+	if err != nil {
+		phaseFailures.WithLabelValues(phase, FailureClass(err)).Inc()
+	}
+}
+
+// ObserveRun records the duration and the result of a command run.
+func ObserveRun(command string, duration time.Duration, err error) {
+	runDuration.WithLabelValues(command).Set(duration.Seconds())
+	success := 1.0
+	if err != nil {
+		success = 0
+	}
+	runSuccess.WithLabelValues(command).Set(success)
+}
+
+// ObserveArtifact records a single artifact of the provided kind and size.
+func ObserveArtifact(kind string, size int64) {
+	artifacts.WithLabelValues(kind).Inc()
+	artifactBytes.WithLabelValues(kind).Add(float64(size))
+}
+
+// ObserveArtifacts records all regular files below dir as artifacts of the
+// provided kind.
+func ObserveArtifacts(kind, dir string) error {
+	if err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("get file info: %w", err)
+		}
+		ObserveArtifact(kind, info.Size())
+		return nil
+	}); err != nil {
+		return fmt.Errorf("walk artifacts directory %s: %w", dir, err)
+	}
+	return nil
+}
+
+// FailureClass returns the failure class of the provided error.
+func FailureClass(err error) string {
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded):
+		return FailureClassTimeout
+	case errors.Is(err, context.Canceled):
+		return FailureClassCanceled
+	case errors.Is(err, fs.ErrNotExist):
+		return FailureClassNotFound
+	case errors.Is(err, fs.ErrPermission):
+		return FailureClassPermission
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return FailureClassTimeout
+		}
+		return FailureClassNetwork
+	case strings.Contains(strings.ToLower(err.Error()), "timeout"):
+		return FailureClassTimeout
+	default:
+		return FailureClassUnknown
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/metrics"
+	"k8s.io/release/pkg/metrics/metricsfakes"
+)
+
+func TestPush(t *testing.T) {
+	metrics.ObservePhase("build", time.Minute, nil)
+	metrics.ObservePhase("push", time.Second, context.DeadlineExceeded)
+	metrics.ObserveRun("krel stage", time.Hour, nil)
+
+	for _, tc := range []struct {
+		name        string
+		opts        *metrics.Options
+		prepare     func(*metricsfakes.FakeImpl)
+		assert      func(*metricsfakes.FakeImpl)
+		shouldError bool
+	}{
+		{
+			name:    "disabled",
+			opts:    metrics.DefaultOptions(),
+			prepare: func(*metricsfakes.FakeImpl) {},
+			assert: func(mock *metricsfakes.FakeImpl) {
+				require.Zero(t, mock.PushGatewayCallCount())
+				require.Zero(t, mock.PostCallCount())
+			},
+		},
+		{
+			name: "pushgateway",
+			opts: &metrics.Options{PushgatewayURL: "http://pushgateway:9091"},
+			prepare: func(mock *metricsfakes.FakeImpl) {
+				mock.PushGatewayReturns(nil)
+			},
+			assert: func(mock *metricsfakes.FakeImpl) {
+				require.Equal(t, 1, mock.PushGatewayCallCount())
+				require.Zero(t, mock.PostCallCount())
+
+				_, url, job, gatherer := mock.PushGatewayArgsForCall(0)
+				require.Equal(t, "http://pushgateway:9091", url)
+				require.Equal(t, metrics.DefaultJob, job)
+
+				families, err := gatherer.Gather()
+				require.NoError(t, err)
+				names := []string{}
+				for _, family := range families {
+					names = append(names, family.GetName())
+				}
+				require.Contains(t, names, "krel_phase_duration_seconds")
+				require.Contains(t, names, "krel_phase_failures_total")
+				require.Contains(t, names, "krel_run_success")
+			},
+		},
+		{
+			name: "remote write",
+			opts: &metrics.Options{RemoteWriteURL: "http://prometheus/api/v1/write", Job: "job"},
+			prepare: func(mock *metricsfakes.FakeImpl) {
+				mock.PostReturns(nil)
+			},
+			assert: func(mock *metricsfakes.FakeImpl) {
+				require.Zero(t, mock.PushGatewayCallCount())
+				require.Equal(t, 1, mock.PostCallCount())
+
+				_, url, body, headers := mock.PostArgsForCall(0)
+				require.Equal(t, "http://prometheus/api/v1/write", url)
+				require.Equal(t, "snappy", headers["Content-Encoding"])
+
+				decoded, err := snappy.Decode(nil, body)
+				require.NoError(t, err)
+				require.Contains(t, string(decoded), "krel_phase_duration_seconds")
+				require.Contains(t, string(decoded), metrics.FailureClassTimeout)
+			},
+		},
+		{
+			name: "failure on pushgateway",
+			opts: &metrics.Options{PushgatewayURL: "http://pushgateway:9091"},
+			prepare: func(mock *metricsfakes.FakeImpl) {
+				mock.PushGatewayReturns(errors.New("error"))
+			},
+			assert:      func(*metricsfakes.FakeImpl) {},
+			shouldError: true,
+		},
+		{
+			name: "failure on remote write",
+			opts: &metrics.Options{RemoteWriteURL: "http://prometheus/api/v1/write"},
+			prepare: func(mock *metricsfakes.FakeImpl) {
+				mock.PostReturns(errors.New("error"))
+			},
+			assert:      func(*metricsfakes.FakeImpl) {},
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &metricsfakes.FakeImpl{}
+			tc.prepare(mock)
+
+			sut := metrics.New(tc.opts)
+			sut.SetImpl(mock)
+
+			err := sut.Push(context.Background())
+			if tc.shouldError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			tc.assert(mock)
+		})
+	}
+}
+
+func TestObserveArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b"), []byte("bb"), 0o644))
+
+	require.NoError(t, metrics.ObserveArtifacts("test", dir))
+	require.Error(t, metrics.ObserveArtifacts("test", filepath.Join(dir, "missing")))
+}
+
+func TestFailureClass(t *testing.T) {
+	for _, tc := range []struct {
+		err      error
+		expected string
+	}{
+		{err: nil, expected: ""},
+		{err: fmt.Errorf("wrapped: %w", context.DeadlineExceeded), expected: metrics.FailureClassTimeout},
+		{err: context.Canceled, expected: metrics.FailureClassCanceled},
+		{err: fmt.Errorf("open: %w", os.ErrNotExist), expected: metrics.FailureClassNotFound},
+		{err: os.ErrPermission, expected: metrics.FailureClassPermission},
+		{err: errors.New("i/o timeout"), expected: metrics.FailureClassTimeout},
+		{err: errors.New("error"), expected: metrics.FailureClassUnknown},
+	} {
+		require.Equal(t, tc.expected, metrics.FailureClass(tc.err), fmt.Sprint(tc.err))
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/golang/snappy"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// remoteWriteHeaders are the HTTP headers required by the Prometheus
+// remote-write protocol.
+var remoteWriteHeaders = map[string]string{
+	"Content-Encoding":                  "snappy",
+	"Content-Type":                      "application/x-protobuf",
+	"X-Prometheus-Remote-Write-Version": "0.1.0",
+}
+
+// Field numbers of the remote-write protobuf messages, see
+// https://github.com/prometheus/prometheus/blob/main/prompb/remote.proto
+const (
+	writeRequestTimeseries = 1
+	timeSeriesLabels       = 1
+	timeSeriesSamples      = 2
+	labelName              = 1
+	labelValue             = 2
+	sampleValue            = 1
+	sampleTimestamp        = 2
+)
+
+type label struct {
+	name, value string
+}
+
+// encodeWriteRequest converts the metric families into a snappy compressed
+// remote-write request. Only counters, gauges and untyped metrics are
+// supported, which are the only types used by this package.
+func encodeWriteRequest(families []*dto.MetricFamily, job string, now time.Time) []byte {
+	var req []byte
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			var value float64
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				value = metric.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				value = metric.GetGauge().GetValue()
+			case dto.MetricType_UNTYPED:
+				value = metric.GetUntyped().GetValue()
+			case dto.MetricType_SUMMARY, dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+				continue
+			}
+
+			labels := []label{
+				{name: "__name__", value: family.GetName()},
+				{name: "job", value: job},
+			}
+			for _, l := range metric.GetLabel() {
+				labels = append(labels, label{name: l.GetName(), value: l.GetValue()})
+			}
+			sort.Slice(labels, func(i, j int) bool {
+				return labels[i].name < labels[j].name
+			})
+
+			req = protowire.AppendTag(req, writeRequestTimeseries, protowire.BytesType)
+			req = protowire.AppendBytes(req, encodeTimeSeries(labels, value, now))
+		}
+	}
+	return snappy.Encode(nil, req)
+}
+
+func encodeTimeSeries(labels []label, value float64, now time.Time) []byte {
+	var ts []byte
+	for _, l := range labels {
+		var b []byte
+		b = protowire.AppendTag(b, labelName, protowire.BytesType)
+		b = protowire.AppendString(b, l.name)
+		b = protowire.AppendTag(b, labelValue, protowire.BytesType)
+		b = protowire.AppendString(b, l.value)
+
+		ts = protowire.AppendTag(ts, timeSeriesLabels, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, b)
+	}
+
+	var sample []byte
+	sample = protowire.AppendTag(sample, sampleValue, protowire.Fixed64Type)
+	sample = protowire.AppendFixed64(sample, math.Float64bits(value))
+	sample = protowire.AppendTag(sample, sampleTimestamp, protowire.VarintType)
+	sample = protowire.AppendVarint(sample, uint64(now.UnixMilli()))
+
+	ts = protowire.AppendTag(ts, timeSeriesSamples, protowire.BytesType)
+	return protowire.AppendBytes(ts, sample)
+}
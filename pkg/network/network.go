@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package network configures the HTTP transport shared by all clients of
+// the release tooling, like the GitHub, Google Cloud Storage, SendGrid and
+// container registry ones.
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-utils/env"
+
+	"k8s.io/release/pkg/workdir"
+)
+
+// CABundleEnvKey is the environment variable containing the default path to
+// the additional CA bundle.
+const CABundleEnvKey = "KREL_CA_BUNDLE"
+
+// subprocessEnvKeys are the environment variables pointing tools like git,
+// gcloud, gsutil and osc to the combined CA bundle.
+var subprocessEnvKeys = []string{
+	"SSL_CERT_FILE",
+	"GIT_SSL_CAINFO",
+	"CLOUDSDK_CORE_CUSTOM_CA_CERTS_FILE",
+	"REQUESTS_CA_BUNDLE",
+}
+
+// systemBundles are the well known locations of the system CA bundle.
+var systemBundles = []string{
+	"/etc/ssl/certs/ca-certificates.crt",
+	"/etc/pki/tls/certs/ca-bundle.crt",
+	"/etc/ssl/ca-bundle.pem",
+	"/etc/pki/tls/cacert.pem",
+	"/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem",
+	"/etc/ssl/cert.pem",
+}
+
+// Options are the network settings of the HTTP clients.
+//
+// Proxies are always taken from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+// environment variables.
+type Options struct {
+	// CABundle is the path to a PEM file containing CA certificates which
+	// are trusted in addition to the system ones.
+	CABundle string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		CABundle: env.Default(CABundleEnvKey, ""),
+	}
+}
+
+// AddFlags adds the network flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.CABundle,
+		"ca-bundle",
+		o.CABundle,
+		fmt.Sprintf("PEM file of CA certificates to trust in addition to the system ones (default $%s)", CABundleEnvKey),
+	)
+}
+
+// CertPool returns the system certificate pool extended by the certificates
+// of the provided PEM content.
+func CertPool(bundle []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		logrus.Warnf("Unable to load system certificates, using an empty pool: %v", err)
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New("no PEM encoded certificates found")
+	}
+	return pool, nil
+}
+
+// New returns a new HTTP transport based on http.DefaultTransport which
+// honors the proxy environment variables and trusts the configured CA bundle.
+func New(opts *Options) (*http.Transport, error) {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unsupported default transport %T", http.DefaultTransport)
+	}
+	transport := base.Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if opts.CABundle == "" {
+		return transport, nil
+	}
+
+	bundle, err := os.ReadFile(opts.CABundle)
+	if err != nil {
+		return nil, fmt.Errorf("read CA bundle: %w", err)
+	}
+	pool, err := CertPool(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("load CA bundle %s: %w", opts.CABundle, err)
+	}
+	transport.TLSClientConfig = withRootCAs(transport.TLSClientConfig, pool)
+	return transport, nil
+}
+
+// Setup configures the process wide defaults from the provided options. It
+// replaces http.DefaultTransport and the default transport of the container
+// registry client, which are used by all other clients of the tooling. If a
+// CA bundle is configured, then subprocesses get pointed to a combination of
+// the system and the configured certificates as well.
+func Setup(opts *Options) error {
+	transport, err := New(opts)
+	if err != nil {
+		return err
+	}
+	http.DefaultTransport = transport
+
+	registryTransport, ok := remote.DefaultTransport.(*http.Transport)
+	if ok {
+		registryTransport = registryTransport.Clone()
+		registryTransport.Proxy = http.ProxyFromEnvironment
+		if transport.TLSClientConfig != nil {
+			registryTransport.TLSClientConfig = withRootCAs(
+				registryTransport.TLSClientConfig, transport.TLSClientConfig.RootCAs,
+			)
+		}
+		remote.DefaultTransport = registryTransport
+	}
+
+	if opts.CABundle == "" {
+		return nil
+	}
+	logrus.Infof("Trusting additional CA certificates from %s", opts.CABundle)
+
+	path, err := writeCombinedBundle(opts.CABundle)
+	if err != nil {
+		return fmt.Errorf("write combined CA bundle: %w", err)
+	}
+	for _, key := range subprocessEnvKeys {
+		if os.Getenv(key) != "" {
+			continue
+		}
+		if err := os.Setenv(key, path); err != nil {
+			return fmt.Errorf("set %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// withRootCAs returns a copy of the TLS config using the provided pool.
+func withRootCAs(config *tls.Config, pool *x509.CertPool) *tls.Config {
+	if config == nil {
+		config = &tls.Config{MinVersion: tls.VersionTLS12}
+	} else {
+		config = config.Clone()
+	}
+	config.RootCAs = pool
+	return config
+}
+
+// writeCombinedBundle writes the system CA bundle together with the provided
+// one to a temporary file and returns its path.
+func writeCombinedBundle(caBundle string) (string, error) {
+	bundle, err := os.ReadFile(caBundle)
+	if err != nil {
+		return "", fmt.Errorf("read CA bundle: %w", err)
+	}
+
+	combined := &strings.Builder{}
+	for _, system := range systemBundles {
+		content, err := os.ReadFile(system)
+		if err != nil {
+			continue
+		}
+		combined.Write(content)
+		combined.WriteString("\n")
+		break
+	}
+	combined.Write(bundle)
+
+	file, err := workdir.CreateTemp("krel-ca-bundle-*.pem")
+	if err != nil {
+		return "", fmt.Errorf("create file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(combined.String()); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+	return file.Name(), nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network_test
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/network"
+)
+
+func TestNew(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	serverBundle := filepath.Join(dir, "server.pem")
+	require.NoError(t, os.WriteFile(serverBundle, pem.EncodeToMemory(&pem.Block{
+		Type: "CERTIFICATE", Bytes: server.Certificate().Raw,
+	}), 0o600))
+	invalidBundle := filepath.Join(dir, "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidBundle, []byte("invalid"), 0o600))
+
+	for _, tc := range []struct {
+		name          string
+		caBundle      string
+		shouldErr     bool
+		shouldConnect bool
+	}{
+		{
+			name:          "no bundle does not trust the server",
+			shouldConnect: false,
+		},
+		{
+			name:          "bundle trusts the server",
+			caBundle:      serverBundle,
+			shouldConnect: true,
+		},
+		{
+			name:      "bundle without certificates",
+			caBundle:  invalidBundle,
+			shouldErr: true,
+		},
+		{
+			name:      "bundle does not exist",
+			caBundle:  filepath.Join(dir, "missing.pem"),
+			shouldErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			transport, err := network.New(&network.Options{CABundle: tc.caBundle})
+			if tc.shouldErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, transport.Proxy)
+
+			client := &http.Client{Transport: transport}
+			resp, err := client.Get(server.URL)
+			if !tc.shouldConnect {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
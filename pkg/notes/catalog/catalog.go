@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package catalog provides per-note message catalogs, which can be used by
+// translation teams to localize the release notes.
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"k8s.io/release/pkg/notes"
+)
+
+// SourceLanguage is the language of the release notes as written in the
+// pull requests.
+const SourceLanguage = "en"
+
+// ErrInvalidCatalog is returned if a catalog cannot be used for rendering.
+var ErrInvalidCatalog = errors.New("invalid catalog")
+
+// Message is a single translatable release note.
+type Message struct {
+	// PR is the pull request number of the release note.
+	PR int `yaml:"pr"`
+
+	// Source is the original release note markdown.
+	Source string `yaml:"source"`
+
+	// Translation is the localized release note markdown. Empty if the
+	// note has not been translated yet.
+	Translation string `yaml:"translation,omitempty"`
+
+	// Fuzzy marks translations which have been done for a different
+	// source. Fuzzy translations are not used for rendering.
+	Fuzzy bool `yaml:"fuzzy,omitempty"`
+}
+
+// Catalog contains all messages of the release notes for one language.
+type Catalog struct {
+	// Language is the language code of the translations, for example "ja"
+	// or "zh-cn".
+	Language string `yaml:"language"`
+
+	// Messages are the release notes ordered by their history.
+	Messages []*Message `yaml:"messages"`
+}
+
+// Export creates a new catalog from the release notes. Notes which should not
+// be published are skipped.
+func Export(releaseNotes *notes.ReleaseNotes, language string) *Catalog {
+	catalog := &Catalog{Language: language, Messages: []*Message{}}
+	seen := map[int]bool{}
+	for _, pr := range releaseNotes.History() {
+		note := releaseNotes.Get(pr)
+		if seen[pr] || note == nil || note.DoNotPublish || note.Markdown == "" {
+			continue
+		}
+		seen[pr] = true
+		catalog.Messages = append(catalog.Messages, &Message{
+			PR: pr, Source: note.Markdown,
+		})
+	}
+	return catalog
+}
+
+// Merge takes over the translations of the existing catalog. Translations
+// of changed sources are marked as fuzzy.
+func (c *Catalog) Merge(existing *Catalog) {
+	translations := map[int]*Message{}
+	for _, msg := range existing.Messages {
+		translations[msg.PR] = msg
+	}
+
+	for _, msg := range c.Messages {
+		old, ok := translations[msg.PR]
+		if !ok || old.Translation == "" {
+			continue
+		}
+		msg.Translation = old.Translation
+		msg.Fuzzy = old.Fuzzy || old.Source != msg.Source
+	}
+}
+
+// Validate checks if the catalog can be used for rendering.
+func (c *Catalog) Validate() error {
+	if c.Language == "" {
+		return fmt.Errorf("%w: language must not be empty", ErrInvalidCatalog)
+	}
+	if strings.ContainsAny(c.Language, `/\`) {
+		return fmt.Errorf("%w: language %q must not contain path separators", ErrInvalidCatalog, c.Language)
+	}
+	seen := map[int]bool{}
+	for _, msg := range c.Messages {
+		if seen[msg.PR] {
+			return fmt.Errorf("%w: duplicate message for PR #%d", ErrInvalidCatalog, msg.PR)
+		}
+		seen[msg.PR] = true
+	}
+	return nil
+}
+
+// Localize returns a copy of the release notes which uses the translations
+// of the catalog. Notes without translation fall back to the source
+// language.
+func (c *Catalog) Localize(releaseNotes *notes.ReleaseNotes) *notes.ReleaseNotes {
+	translations := map[int]string{}
+	for _, msg := range c.Messages {
+		if msg.Translation != "" && !msg.Fuzzy {
+			translations[msg.PR] = msg.Translation
+		}
+	}
+
+	res := notes.NewReleaseNotes()
+	missing := 0
+	for _, pr := range releaseNotes.History() {
+		note := releaseNotes.Get(pr)
+		if note == nil || res.Get(pr) != nil {
+			continue
+		}
+		localized := *note
+		if translation, ok := translations[pr]; ok {
+			localized.Markdown = translation
+		} else if !note.DoNotPublish {
+			missing++
+		}
+		res.Set(pr, &localized)
+	}
+
+	if missing > 0 {
+		logrus.Warnf(
+			"%d release notes are not translated to %s and will use %s",
+			missing, c.Language, SourceLanguage,
+		)
+	}
+	return res
+}
+
+// Load reads a catalog from the provided YAML file.
+func Load(path string) (*Catalog, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read catalog: %w", err)
+	}
+	catalog := &Catalog{}
+	if err := yaml.UnmarshalStrict(content, catalog); err != nil {
+		return nil, fmt.Errorf("unmarshal catalog %s: %w", path, err)
+	}
+	if err := catalog.Validate(); err != nil {
+		return nil, fmt.Errorf("validate catalog %s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+// LoadDir reads all YAML catalogs of the directory, except the one of the
+// source language, sorted by their language.
+func LoadDir(dir string) ([]*Catalog, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("list catalogs: %w", err)
+	}
+
+	res := []*Catalog{}
+	for _, file := range files {
+		catalog, err := Load(file)
+		if err != nil {
+			return nil, err
+		}
+		if catalog.Language == SourceLanguage {
+			continue
+		}
+		res = append(res, catalog)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Language < res[j].Language
+	})
+	return res, nil
+}
+
+// Save writes the catalog as YAML to the provided path.
+func (c *Catalog) Save(path string) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshal catalog: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("write catalog: %w", err)
+	}
+	return nil
+}
+
+// LocalizedPath returns the path of a localized output file, by adding the
+// language before the file extension of the original path.
+func LocalizedPath(path, language string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + language + ext
+}
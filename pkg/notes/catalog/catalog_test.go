@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package catalog_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/catalog"
+)
+
+func testReleaseNotes() *notes.ReleaseNotes {
+	releaseNotes := notes.NewReleaseNotes()
+	releaseNotes.Set(1, &notes.ReleaseNote{PrNumber: 1, Markdown: "Added foo"})
+	releaseNotes.Set(2, &notes.ReleaseNote{PrNumber: 2, Markdown: "Fixed bar"})
+	releaseNotes.Set(3, &notes.ReleaseNote{PrNumber: 3, Markdown: "Hidden", DoNotPublish: true})
+	releaseNotes.Set(4, &notes.ReleaseNote{PrNumber: 4, Markdown: "Removed baz"})
+	return releaseNotes
+}
+
+func TestExport(t *testing.T) {
+	c := catalog.Export(testReleaseNotes(), "ja")
+	require.Equal(t, "ja", c.Language)
+	require.Len(t, c.Messages, 3)
+	require.Equal(t, 1, c.Messages[0].PR)
+	require.Equal(t, "Added foo", c.Messages[0].Source)
+	require.Equal(t, 4, c.Messages[2].PR)
+}
+
+func TestMerge(t *testing.T) {
+	existing := &catalog.Catalog{Language: "ja", Messages: []*catalog.Message{
+		{PR: 1, Source: "Added foo", Translation: "foo を追加"},
+		{PR: 2, Source: "Fixed the bar", Translation: "bar を修正"},
+		{PR: 5, Source: "Gone", Translation: "消えた"},
+	}}
+
+	c := catalog.Export(testReleaseNotes(), "ja")
+	c.Merge(existing)
+
+	require.Len(t, c.Messages, 3)
+	require.Equal(t, "foo を追加", c.Messages[0].Translation)
+	require.False(t, c.Messages[0].Fuzzy)
+	require.Equal(t, "bar を修正", c.Messages[1].Translation)
+	require.True(t, c.Messages[1].Fuzzy)
+	require.Empty(t, c.Messages[2].Translation)
+}
+
+func TestLocalize(t *testing.T) {
+	c := &catalog.Catalog{Language: "ja", Messages: []*catalog.Message{
+		{PR: 1, Source: "Added foo", Translation: "foo を追加"},
+		{PR: 2, Source: "Fixed the bar", Translation: "bar を修正", Fuzzy: true},
+	}}
+
+	source := testReleaseNotes()
+	res := c.Localize(source)
+	require.Equal(t, "foo を追加", res.Get(1).Markdown)
+	require.Equal(t, "Fixed bar", res.Get(2).Markdown)
+	require.True(t, res.Get(3).DoNotPublish)
+	require.Equal(t, "Removed baz", res.Get(4).Markdown)
+	require.Equal(t, source.History(), res.History())
+
+	// The source notes are unchanged
+	require.Equal(t, "Added foo", source.Get(1).Markdown)
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, language := range []string{"ja", "de", catalog.SourceLanguage} {
+		c := catalog.Export(testReleaseNotes(), language)
+		c.Messages[0].Translation = "translated"
+		require.Nil(t, c.Save(filepath.Join(dir, language+".yaml")))
+	}
+
+	loaded, err := catalog.Load(filepath.Join(dir, "ja.yaml"))
+	require.Nil(t, err)
+	require.Equal(t, "ja", loaded.Language)
+	require.Equal(t, "translated", loaded.Messages[0].Translation)
+
+	catalogs, err := catalog.LoadDir(dir)
+	require.Nil(t, err)
+	require.Len(t, catalogs, 2)
+	require.Equal(t, "de", catalogs[0].Language)
+	require.Equal(t, "ja", catalogs[1].Language)
+}
+
+func TestLoadFailure(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"unknown-field.yaml": "language: ja\nwrong: true\n",
+		"no-language.yaml":   "messages: []\n",
+		"duplicate.yaml":     "language: ja\nmessages:\n- pr: 1\n  source: a\n- pr: 1\n  source: b\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+		_, err := catalog.Load(path)
+		require.NotNil(t, err, name)
+	}
+
+	_, err := catalog.Load(filepath.Join(dir, "missing.yaml"))
+	require.NotNil(t, err)
+}
+
+func TestLocalizedPath(t *testing.T) {
+	require.Equal(t, "/tmp/CHANGELOG-1.30.ja.md", catalog.LocalizedPath("/tmp/CHANGELOG-1.30.md", "ja"))
+	require.Equal(t, "/tmp/notes.de", catalog.LocalizedPath("/tmp/notes", "de"))
+}
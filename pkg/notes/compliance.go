@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"regexp"
+	"strings"
+
+	gogithub "github.com/google/go-github/v58/github"
+)
+
+// The labels set on kubernetes pull requests depending on their release
+// note block.
+const (
+	LabelReleaseNote               = "release-note"
+	LabelReleaseNoteNone           = "release-note-none"
+	LabelReleaseNoteActionRequired = "release-note-action-required"
+)
+
+var (
+	releaseNoteBlockRegex = regexp.MustCompile("(?i)```(dev-)?release-notes?\\s")
+	actionRequiredRegex   = regexp.MustCompile(`(?i)action required`)
+)
+
+// CheckCompliance validates the release note block of a pull request against
+// the requirements of the pull request template: The block has to be present,
+// parseable and consistent with the release note and kind labels. It returns
+// an actionable description of every problem, which means that compliant
+// pull requests result in an empty list.
+func CheckCompliance(pr *gogithub.PullRequest) []string {
+	problems := []string{}
+	body := pr.GetBody()
+	none := MatchesExcludeFilter(body)
+
+	note, err := noteTextFromString(body)
+	switch {
+	case none:
+	case !releaseNoteBlockRegex.MatchString(body):
+		problems = append(problems,
+			"The pull request description contains no ```release-note block. "+
+				"Add one describing the user facing change, or containing NONE if there is none.",
+		)
+	case err != nil || note == "":
+		problems = append(problems,
+			"The ```release-note block is empty or cannot be parsed. Make sure it is "+
+				"closed by ``` on its own line and contains the note, or NONE if there is no user facing change.",
+		)
+	}
+
+	hasNoteLabel := labelExactMatch(pr, LabelReleaseNote) || labelExactMatch(pr, LabelReleaseNoteActionRequired)
+	if none && hasNoteLabel {
+		problems = append(problems,
+			"The release note is NONE, but the pull request is labeled as having a release note. "+
+				"Add a note or remove the `"+LabelReleaseNote+"` label.",
+		)
+	}
+	if !none && note != "" && labelExactMatch(pr, LabelReleaseNoteNone) {
+		problems = append(problems,
+			"The pull request contains a release note, but is labeled `"+LabelReleaseNoteNone+"`. "+
+				"Replace the note by NONE or remove the label.",
+		)
+	}
+
+	if !none && note != "" {
+		raw, _ := rawNoteTextFromString(body)
+		mentionsActionRequired := actionRequiredRegex.MatchString(raw)
+		hasActionRequiredLabel := labelExactMatch(pr, LabelReleaseNoteActionRequired)
+		if mentionsActionRequired && !hasActionRequiredLabel {
+			problems = append(problems,
+				"The release note requires action, but the pull request is not labeled `"+
+					LabelReleaseNoteActionRequired+"`.",
+			)
+		}
+		if !mentionsActionRequired && hasActionRequiredLabel {
+			problems = append(problems,
+				"The pull request is labeled `"+LabelReleaseNoteActionRequired+
+					"`, but the release note does not start with `ACTION REQUIRED:`.",
+			)
+		}
+	}
+
+	if len(labelsWithPrefix(pr, "kind")) == 0 {
+		problems = append(problems,
+			"The pull request has no `kind/` label, which is required to categorize the release note. "+
+				"Add one by commenting for example `/kind bug`.",
+		)
+	}
+
+	return problems
+}
+
+// ComplianceCommentMarker identifies the comments posted for the problems
+// of CheckCompliance, which allows updating them.
+const ComplianceCommentMarker = "<!-- release-notes-compliance -->"
+
+// ComplianceComment returns the markdown comment listing the problems.
+func ComplianceComment(problems []string) string {
+	sb := &strings.Builder{}
+	sb.WriteString(ComplianceCommentMarker + "\n")
+	sb.WriteString("The release note of this pull request needs attention:\n\n")
+	for _, problem := range problems {
+		sb.WriteString("- " + problem + "\n")
+	}
+	sb.WriteString("\nSee https://git.k8s.io/community/contributors/guide/release-notes.md for details. ")
+	sb.WriteString("This comment gets updated automatically.\n")
+	return sb.String()
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compliance checks the release note blocks of pull requests, either
+// on demand in CI or as GitHub webhook, and posts actionable comments about
+// the found problems.
+package compliance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/notes"
+)
+
+// ErrMissingWebhookSecret is returned if webhooks should be served without
+// a secret.
+var ErrMissingWebhookSecret = errors.New("webhook secret is required for serving webhooks")
+
+// webhookActions are the pull request event actions which can change the
+// compliance of a pull request.
+var webhookActions = map[string]bool{
+	"opened":      true,
+	"edited":      true,
+	"reopened":    true,
+	"synchronize": true,
+	"labeled":     true,
+	"unlabeled":   true,
+}
+
+// Options are the settings of the compliance checker.
+type Options struct {
+	// Comment posts the problems as comment on the pull request. The comment
+	// gets updated on subsequent checks and deleted once the pull request
+	// is compliant.
+	Comment bool
+
+	// WebhookSecret is the secret of the GitHub webhook, which is required
+	// for serving webhooks.
+	WebhookSecret string
+}
+
+// Validate checks if the options are correctly set for serving webhooks.
+func (o *Options) Validate() error {
+	if o.WebhookSecret == "" {
+		return ErrMissingWebhookSecret
+	}
+	return nil
+}
+
+// Result is the compliance of a single pull request.
+type Result struct {
+	Org      string   `json:"org"`
+	Repo     string   `json:"repo"`
+	Number   int      `json:"number"`
+	Problems []string `json:"problems"`
+}
+
+// Compliant returns true if the pull request has no problems.
+func (r *Result) Compliant() bool {
+	return len(r.Problems) == 0
+}
+
+// Checker checks the release notes of pull requests.
+type Checker struct {
+	impl    impl
+	options *Options
+
+	// login is the cached login of the token, which authors the comment.
+	login   string
+	loginMu sync.Mutex
+}
+
+// New creates a new Checker instance.
+func New(opts *Options) *Checker {
+	return &Checker{
+		impl:    newDefaultImpl(),
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (c *Checker) SetImpl(impl impl) {
+	c.impl = impl
+}
+
+// Check retrieves and checks the pull request.
+func (c *Checker) Check(org, repo string, number int) (*Result, error) {
+	pr, err := c.impl.GetPullRequest(org, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("get pull request #%d: %w", number, err)
+	}
+	return c.check(org, repo, pr)
+}
+
+func (c *Checker) check(org, repo string, pr *gogithub.PullRequest) (*Result, error) {
+	res := &Result{
+		Org:      org,
+		Repo:     repo,
+		Number:   pr.GetNumber(),
+		Problems: notes.CheckCompliance(pr),
+	}
+	logrus.Infof("Pull request %s/%s#%d has %d release note problems", org, repo, res.Number, len(res.Problems))
+
+	if c.options.Comment {
+		if err := c.comment(res); err != nil {
+			return nil, fmt.Errorf("comment on pull request #%d: %w", res.Number, err)
+		}
+	}
+	return res, nil
+}
+
+// currentLogin returns the login of the token. Only comments authored by it
+// are considered to be the compliance comment, because anyone can post the
+// marker, while the token would not be allowed to edit or delete their text.
+func (c *Checker) currentLogin() (string, error) {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+	if c.login != "" {
+		return c.login, nil
+	}
+	login, err := c.impl.Login()
+	if err != nil {
+		return "", fmt.Errorf("get login of the GitHub token: %w", err)
+	}
+	c.login = login
+	return login, nil
+}
+
+// comment creates, updates or deletes the compliance comment.
+func (c *Checker) comment(res *Result) error {
+	login, err := c.currentLogin()
+	if err != nil {
+		return err
+	}
+	comments, err := c.impl.ListComments(res.Org, res.Repo, res.Number)
+	if err != nil {
+		return fmt.Errorf("list comments: %w", err)
+	}
+
+	var existing *gogithub.IssueComment
+	for _, comment := range comments {
+		if comment.GetUser().GetLogin() == login &&
+			strings.Contains(comment.GetBody(), notes.ComplianceCommentMarker) {
+			existing = comment
+			break
+		}
+	}
+
+	switch {
+	case res.Compliant() && existing != nil:
+		logrus.Info("Deleting outdated compliance comment")
+		return c.impl.DeleteComment(res.Org, res.Repo, existing.GetID())
+
+	case res.Compliant():
+		return nil
+
+	case existing != nil:
+		body := notes.ComplianceComment(res.Problems)
+		if existing.GetBody() == body {
+			return nil
+		}
+		logrus.Info("Updating compliance comment")
+		return c.impl.EditComment(res.Org, res.Repo, existing.GetID(), body)
+
+	default:
+		logrus.Info("Creating compliance comment")
+		return c.impl.CreateComment(res.Org, res.Repo, res.Number, notes.ComplianceComment(res.Problems))
+	}
+}
+
+// ServeHTTP handles GitHub pull request webhooks and checks the pull request
+// of the event.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if c.options.WebhookSecret == "" {
+		http.Error(w, "no webhook secret configured", http.StatusInternalServerError)
+		return
+	}
+
+	payload, err := gogithub.ValidatePayload(r, []byte(c.options.WebhookSecret))
+	if err != nil {
+		logrus.Warnf("Rejecting webhook: %v", err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	event, err := gogithub.ParseWebHook(gogithub.WebHookType(r), payload)
+	if err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	prEvent, ok := event.(*gogithub.PullRequestEvent)
+	if !ok || !webhookActions[prEvent.GetAction()] || prEvent.GetPullRequest() == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	res, err := c.check(
+		prEvent.GetRepo().GetOwner().GetLogin(), prEvent.GetRepo().GetName(),
+		prEvent.GetPullRequest(),
+	)
+	if err != nil {
+		logrus.Errorf("Unable to check pull request: %v", err)
+		http.Error(w, "check failed", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		logrus.Warnf("Unable to write webhook response: %v", err)
+	}
+}
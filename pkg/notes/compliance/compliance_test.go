@@ -0,0 +1,256 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compliance_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/compliance"
+	"k8s.io/release/pkg/notes/compliance/compliancefakes"
+)
+
+const botLogin = "release-bot"
+
+func testPR(body string, labels ...string) *gogithub.PullRequest {
+	pr := &gogithub.PullRequest{Number: gogithub.Int(42), Body: &body}
+	for _, label := range labels {
+		pr.Labels = append(pr.Labels, &gogithub.Label{Name: gogithub.String(label)})
+	}
+	return pr
+}
+
+func TestCheck(t *testing.T) {
+	compliant := testPR("```release-note\nFixed a bug\n```\n", "kind/bug")
+	broken := testPR("no block", "kind/bug")
+	existing := &gogithub.IssueComment{
+		ID:   gogithub.Int64(1),
+		User: &gogithub.User{Login: gogithub.String(botLogin)},
+		Body: gogithub.String(notes.ComplianceCommentMarker + "\noutdated"),
+	}
+	foreign := &gogithub.IssueComment{
+		ID:   gogithub.Int64(2),
+		User: &gogithub.User{Login: gogithub.String("someone")},
+		Body: gogithub.String(notes.ComplianceCommentMarker + "\nfake"),
+	}
+
+	for _, tc := range []struct {
+		name        string
+		comment     bool
+		prepare     func(*compliancefakes.FakeImpl)
+		assert      func(*compliancefakes.FakeImpl, *compliance.Result)
+		shouldError bool
+	}{
+		{
+			name: "compliant without comment",
+			prepare: func(mock *compliancefakes.FakeImpl) {
+				mock.GetPullRequestReturns(compliant, nil)
+			},
+			assert: func(mock *compliancefakes.FakeImpl, res *compliance.Result) {
+				require.True(t, res.Compliant())
+				require.Zero(t, mock.ListCommentsCallCount())
+			},
+		},
+		{
+			name:    "problems create comment",
+			comment: true,
+			prepare: func(mock *compliancefakes.FakeImpl) {
+				mock.GetPullRequestReturns(broken, nil)
+			},
+			assert: func(mock *compliancefakes.FakeImpl, res *compliance.Result) {
+				require.Len(t, res.Problems, 1)
+				require.Equal(t, 1, mock.CreateCommentCallCount())
+				org, repo, number, body := mock.CreateCommentArgsForCall(0)
+				require.Equal(t, "kubernetes", org)
+				require.Equal(t, "kubernetes", repo)
+				require.Equal(t, 42, number)
+				require.Contains(t, body, notes.ComplianceCommentMarker)
+			},
+		},
+		{
+			name:    "problems update comment",
+			comment: true,
+			prepare: func(mock *compliancefakes.FakeImpl) {
+				mock.GetPullRequestReturns(broken, nil)
+				mock.ListCommentsReturns([]*gogithub.IssueComment{{Body: gogithub.String("lgtm")}, existing}, nil)
+			},
+			assert: func(mock *compliancefakes.FakeImpl, res *compliance.Result) {
+				require.Zero(t, mock.CreateCommentCallCount())
+				require.Equal(t, 1, mock.EditCommentCallCount())
+				_, _, id, _ := mock.EditCommentArgsForCall(0)
+				require.EqualValues(t, 1, id)
+			},
+		},
+		{
+			name:    "compliant deletes comment",
+			comment: true,
+			prepare: func(mock *compliancefakes.FakeImpl) {
+				mock.GetPullRequestReturns(compliant, nil)
+				mock.ListCommentsReturns([]*gogithub.IssueComment{existing}, nil)
+			},
+			assert: func(mock *compliancefakes.FakeImpl, res *compliance.Result) {
+				require.Equal(t, 1, mock.DeleteCommentCallCount())
+				require.Zero(t, mock.CreateCommentCallCount())
+			},
+		},
+		{
+			name:    "foreign comment with marker is ignored",
+			comment: true,
+			prepare: func(mock *compliancefakes.FakeImpl) {
+				mock.GetPullRequestReturns(broken, nil)
+				mock.ListCommentsReturns([]*gogithub.IssueComment{foreign}, nil)
+			},
+			assert: func(mock *compliancefakes.FakeImpl, res *compliance.Result) {
+				require.Zero(t, mock.EditCommentCallCount())
+				require.Zero(t, mock.DeleteCommentCallCount())
+				require.Equal(t, 1, mock.CreateCommentCallCount())
+			},
+		},
+		{
+			name:    "compliant keeps foreign comment",
+			comment: true,
+			prepare: func(mock *compliancefakes.FakeImpl) {
+				mock.GetPullRequestReturns(compliant, nil)
+				mock.ListCommentsReturns([]*gogithub.IssueComment{foreign}, nil)
+			},
+			assert: func(mock *compliancefakes.FakeImpl, res *compliance.Result) {
+				require.Zero(t, mock.DeleteCommentCallCount())
+			},
+		},
+		{
+			name: "failure on get",
+			prepare: func(mock *compliancefakes.FakeImpl) {
+				mock.GetPullRequestReturns(nil, errors.New(""))
+			},
+			shouldError: true,
+		},
+		{
+			name:    "failure on comment",
+			comment: true,
+			prepare: func(mock *compliancefakes.FakeImpl) {
+				mock.GetPullRequestReturns(broken, nil)
+				mock.CreateCommentReturns(errors.New(""))
+			},
+			shouldError: true,
+		},
+		{
+			name:    "failure on login",
+			comment: true,
+			prepare: func(mock *compliancefakes.FakeImpl) {
+				mock.GetPullRequestReturns(broken, nil)
+				mock.LoginReturns("", errors.New(""))
+			},
+			shouldError: true,
+		},
+	} {
+		mock := &compliancefakes.FakeImpl{}
+		mock.LoginReturns(botLogin, nil)
+		tc.prepare(mock)
+		sut := compliance.New(&compliance.Options{Comment: tc.comment})
+		sut.SetImpl(mock)
+
+		res, err := sut.Check("kubernetes", "kubernetes", 42)
+		if tc.shouldError {
+			require.Error(t, err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		tc.assert(mock, res)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	const secret = "secret"
+
+	payload, err := json.Marshal(&gogithub.PullRequestEvent{
+		Action:      gogithub.String("edited"),
+		PullRequest: testPR("no block"),
+		Repo: &gogithub.Repository{
+			Name:  gogithub.String("release"),
+			Owner: &gogithub.User{Login: gogithub.String("kubernetes")},
+		},
+	})
+	require.NoError(t, err)
+
+	request := func(event string, body []byte, key string) *http.Request {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(body)
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Event", event)
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		return req
+	}
+
+	for _, tc := range []struct {
+		name     string
+		req      *http.Request
+		status   int
+		comments int
+	}{
+		{
+			name:     "pull request event",
+			req:      request("pull_request", payload, secret),
+			status:   http.StatusOK,
+			comments: 1,
+		},
+		{
+			name:   "invalid signature",
+			req:    request("pull_request", payload, "wrong"),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "other event",
+			req:    request("ping", []byte(`{"zen": "Keep it simple."}`), secret),
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "wrong method",
+			req:    httptest.NewRequest(http.MethodGet, "/", http.NoBody),
+			status: http.StatusMethodNotAllowed,
+		},
+	} {
+		mock := &compliancefakes.FakeImpl{}
+		sut := compliance.New(&compliance.Options{Comment: true, WebhookSecret: secret})
+		sut.SetImpl(mock)
+
+		rec := httptest.NewRecorder()
+		sut.ServeHTTP(rec, tc.req)
+		require.Equal(t, tc.status, rec.Code, tc.name)
+		require.Equal(t, tc.comments, mock.CreateCommentCallCount(), tc.name)
+
+		if tc.status == http.StatusOK {
+			res := &compliance.Result{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), res))
+			require.Equal(t, "kubernetes", res.Org)
+			require.Equal(t, "release", res.Repo)
+			require.Equal(t, 42, res.Number)
+			require.Len(t, res.Problems, 2)
+		}
+	}
+}
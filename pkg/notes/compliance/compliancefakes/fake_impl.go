@@ -0,0 +1,525 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package compliancefakes
+
+import (
+	"sync"
+
+	"github.com/google/go-github/v58/github"
+)
+
+type FakeImpl struct {
+	CreateCommentStub        func(string, string, int, string) error
+	createCommentMutex       sync.RWMutex
+	createCommentArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}
+	createCommentReturns struct {
+		result1 error
+	}
+	createCommentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteCommentStub        func(string, string, int64) error
+	deleteCommentMutex       sync.RWMutex
+	deleteCommentArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}
+	deleteCommentReturns struct {
+		result1 error
+	}
+	deleteCommentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EditCommentStub        func(string, string, int64, string) error
+	editCommentMutex       sync.RWMutex
+	editCommentArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+		arg4 string
+	}
+	editCommentReturns struct {
+		result1 error
+	}
+	editCommentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetPullRequestStub        func(string, string, int) (*github.PullRequest, error)
+	getPullRequestMutex       sync.RWMutex
+	getPullRequestArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}
+	getPullRequestReturns struct {
+		result1 *github.PullRequest
+		result2 error
+	}
+	getPullRequestReturnsOnCall map[int]struct {
+		result1 *github.PullRequest
+		result2 error
+	}
+	ListCommentsStub        func(string, string, int) ([]*github.IssueComment, error)
+	listCommentsMutex       sync.RWMutex
+	listCommentsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}
+	listCommentsReturns struct {
+		result1 []*github.IssueComment
+		result2 error
+	}
+	listCommentsReturnsOnCall map[int]struct {
+		result1 []*github.IssueComment
+		result2 error
+	}
+	LoginStub        func() (string, error)
+	loginMutex       sync.RWMutex
+	loginArgsForCall []struct {
+	}
+	loginReturns struct {
+		result1 string
+		result2 error
+	}
+	loginReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) CreateComment(arg1 string, arg2 string, arg3 int, arg4 string) error {
+	fake.createCommentMutex.Lock()
+	ret, specificReturn := fake.createCommentReturnsOnCall[len(fake.createCommentArgsForCall)]
+	fake.createCommentArgsForCall = append(fake.createCommentArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CreateCommentStub
+	fakeReturns := fake.createCommentReturns
+	fake.recordInvocation("CreateComment", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createCommentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CreateCommentCallCount() int {
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	return len(fake.createCommentArgsForCall)
+}
+
+func (fake *FakeImpl) CreateCommentCalls(stub func(string, string, int, string) error) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = stub
+}
+
+func (fake *FakeImpl) CreateCommentArgsForCall(i int) (string, string, int, string) {
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	argsForCall := fake.createCommentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) CreateCommentReturns(result1 error) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = nil
+	fake.createCommentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CreateCommentReturnsOnCall(i int, result1 error) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = nil
+	if fake.createCommentReturnsOnCall == nil {
+		fake.createCommentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createCommentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) DeleteComment(arg1 string, arg2 string, arg3 int64) error {
+	fake.deleteCommentMutex.Lock()
+	ret, specificReturn := fake.deleteCommentReturnsOnCall[len(fake.deleteCommentArgsForCall)]
+	fake.deleteCommentArgsForCall = append(fake.deleteCommentArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteCommentStub
+	fakeReturns := fake.deleteCommentReturns
+	fake.recordInvocation("DeleteComment", []interface{}{arg1, arg2, arg3})
+	fake.deleteCommentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) DeleteCommentCallCount() int {
+	fake.deleteCommentMutex.RLock()
+	defer fake.deleteCommentMutex.RUnlock()
+	return len(fake.deleteCommentArgsForCall)
+}
+
+func (fake *FakeImpl) DeleteCommentCalls(stub func(string, string, int64) error) {
+	fake.deleteCommentMutex.Lock()
+	defer fake.deleteCommentMutex.Unlock()
+	fake.DeleteCommentStub = stub
+}
+
+func (fake *FakeImpl) DeleteCommentArgsForCall(i int) (string, string, int64) {
+	fake.deleteCommentMutex.RLock()
+	defer fake.deleteCommentMutex.RUnlock()
+	argsForCall := fake.deleteCommentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) DeleteCommentReturns(result1 error) {
+	fake.deleteCommentMutex.Lock()
+	defer fake.deleteCommentMutex.Unlock()
+	fake.DeleteCommentStub = nil
+	fake.deleteCommentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) DeleteCommentReturnsOnCall(i int, result1 error) {
+	fake.deleteCommentMutex.Lock()
+	defer fake.deleteCommentMutex.Unlock()
+	fake.DeleteCommentStub = nil
+	if fake.deleteCommentReturnsOnCall == nil {
+		fake.deleteCommentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteCommentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) EditComment(arg1 string, arg2 string, arg3 int64, arg4 string) error {
+	fake.editCommentMutex.Lock()
+	ret, specificReturn := fake.editCommentReturnsOnCall[len(fake.editCommentArgsForCall)]
+	fake.editCommentArgsForCall = append(fake.editCommentArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.EditCommentStub
+	fakeReturns := fake.editCommentReturns
+	fake.recordInvocation("EditComment", []interface{}{arg1, arg2, arg3, arg4})
+	fake.editCommentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) EditCommentCallCount() int {
+	fake.editCommentMutex.RLock()
+	defer fake.editCommentMutex.RUnlock()
+	return len(fake.editCommentArgsForCall)
+}
+
+func (fake *FakeImpl) EditCommentCalls(stub func(string, string, int64, string) error) {
+	fake.editCommentMutex.Lock()
+	defer fake.editCommentMutex.Unlock()
+	fake.EditCommentStub = stub
+}
+
+func (fake *FakeImpl) EditCommentArgsForCall(i int) (string, string, int64, string) {
+	fake.editCommentMutex.RLock()
+	defer fake.editCommentMutex.RUnlock()
+	argsForCall := fake.editCommentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) EditCommentReturns(result1 error) {
+	fake.editCommentMutex.Lock()
+	defer fake.editCommentMutex.Unlock()
+	fake.EditCommentStub = nil
+	fake.editCommentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) EditCommentReturnsOnCall(i int, result1 error) {
+	fake.editCommentMutex.Lock()
+	defer fake.editCommentMutex.Unlock()
+	fake.EditCommentStub = nil
+	if fake.editCommentReturnsOnCall == nil {
+		fake.editCommentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.editCommentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) GetPullRequest(arg1 string, arg2 string, arg3 int) (*github.PullRequest, error) {
+	fake.getPullRequestMutex.Lock()
+	ret, specificReturn := fake.getPullRequestReturnsOnCall[len(fake.getPullRequestArgsForCall)]
+	fake.getPullRequestArgsForCall = append(fake.getPullRequestArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetPullRequestStub
+	fakeReturns := fake.getPullRequestReturns
+	fake.recordInvocation("GetPullRequest", []interface{}{arg1, arg2, arg3})
+	fake.getPullRequestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GetPullRequestCallCount() int {
+	fake.getPullRequestMutex.RLock()
+	defer fake.getPullRequestMutex.RUnlock()
+	return len(fake.getPullRequestArgsForCall)
+}
+
+func (fake *FakeImpl) GetPullRequestCalls(stub func(string, string, int) (*github.PullRequest, error)) {
+	fake.getPullRequestMutex.Lock()
+	defer fake.getPullRequestMutex.Unlock()
+	fake.GetPullRequestStub = stub
+}
+
+func (fake *FakeImpl) GetPullRequestArgsForCall(i int) (string, string, int) {
+	fake.getPullRequestMutex.RLock()
+	defer fake.getPullRequestMutex.RUnlock()
+	argsForCall := fake.getPullRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) GetPullRequestReturns(result1 *github.PullRequest, result2 error) {
+	fake.getPullRequestMutex.Lock()
+	defer fake.getPullRequestMutex.Unlock()
+	fake.GetPullRequestStub = nil
+	fake.getPullRequestReturns = struct {
+		result1 *github.PullRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GetPullRequestReturnsOnCall(i int, result1 *github.PullRequest, result2 error) {
+	fake.getPullRequestMutex.Lock()
+	defer fake.getPullRequestMutex.Unlock()
+	fake.GetPullRequestStub = nil
+	if fake.getPullRequestReturnsOnCall == nil {
+		fake.getPullRequestReturnsOnCall = make(map[int]struct {
+			result1 *github.PullRequest
+			result2 error
+		})
+	}
+	fake.getPullRequestReturnsOnCall[i] = struct {
+		result1 *github.PullRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListComments(arg1 string, arg2 string, arg3 int) ([]*github.IssueComment, error) {
+	fake.listCommentsMutex.Lock()
+	ret, specificReturn := fake.listCommentsReturnsOnCall[len(fake.listCommentsArgsForCall)]
+	fake.listCommentsArgsForCall = append(fake.listCommentsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.ListCommentsStub
+	fakeReturns := fake.listCommentsReturns
+	fake.recordInvocation("ListComments", []interface{}{arg1, arg2, arg3})
+	fake.listCommentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ListCommentsCallCount() int {
+	fake.listCommentsMutex.RLock()
+	defer fake.listCommentsMutex.RUnlock()
+	return len(fake.listCommentsArgsForCall)
+}
+
+func (fake *FakeImpl) ListCommentsCalls(stub func(string, string, int) ([]*github.IssueComment, error)) {
+	fake.listCommentsMutex.Lock()
+	defer fake.listCommentsMutex.Unlock()
+	fake.ListCommentsStub = stub
+}
+
+func (fake *FakeImpl) ListCommentsArgsForCall(i int) (string, string, int) {
+	fake.listCommentsMutex.RLock()
+	defer fake.listCommentsMutex.RUnlock()
+	argsForCall := fake.listCommentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) ListCommentsReturns(result1 []*github.IssueComment, result2 error) {
+	fake.listCommentsMutex.Lock()
+	defer fake.listCommentsMutex.Unlock()
+	fake.ListCommentsStub = nil
+	fake.listCommentsReturns = struct {
+		result1 []*github.IssueComment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListCommentsReturnsOnCall(i int, result1 []*github.IssueComment, result2 error) {
+	fake.listCommentsMutex.Lock()
+	defer fake.listCommentsMutex.Unlock()
+	fake.ListCommentsStub = nil
+	if fake.listCommentsReturnsOnCall == nil {
+		fake.listCommentsReturnsOnCall = make(map[int]struct {
+			result1 []*github.IssueComment
+			result2 error
+		})
+	}
+	fake.listCommentsReturnsOnCall[i] = struct {
+		result1 []*github.IssueComment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Login() (string, error) {
+	fake.loginMutex.Lock()
+	ret, specificReturn := fake.loginReturnsOnCall[len(fake.loginArgsForCall)]
+	fake.loginArgsForCall = append(fake.loginArgsForCall, struct {
+	}{})
+	stub := fake.LoginStub
+	fakeReturns := fake.loginReturns
+	fake.recordInvocation("Login", []interface{}{})
+	fake.loginMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) LoginCallCount() int {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	return len(fake.loginArgsForCall)
+}
+
+func (fake *FakeImpl) LoginCalls(stub func() (string, error)) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = stub
+}
+
+func (fake *FakeImpl) LoginReturns(result1 string, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	fake.loginReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) LoginReturnsOnCall(i int, result1 string, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	if fake.loginReturnsOnCall == nil {
+		fake.loginReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.loginReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	fake.deleteCommentMutex.RLock()
+	defer fake.deleteCommentMutex.RUnlock()
+	fake.editCommentMutex.RLock()
+	defer fake.editCommentMutex.RUnlock()
+	fake.getPullRequestMutex.RLock()
+	defer fake.getPullRequestMutex.RUnlock()
+	fake.listCommentsMutex.RLock()
+	defer fake.listCommentsMutex.RUnlock()
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
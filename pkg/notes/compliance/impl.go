@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compliance
+
+import (
+	"context"
+	"net/http"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"golang.org/x/oauth2"
+
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/env"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../../hack/boilerplate/boilerplate.generatego.txt compliancefakes/fake_impl.go > compliancefakes/_fake_impl.go && mv compliancefakes/_fake_impl.go compliancefakes/fake_impl.go"
+type impl interface {
+	GetPullRequest(owner, repo string, number int) (*gogithub.PullRequest, error)
+	// Login returns the login of the user owning the token.
+	Login() (string, error)
+	ListComments(owner, repo string, number int) ([]*gogithub.IssueComment, error)
+	CreateComment(owner, repo string, number int, body string) error
+	EditComment(owner, repo string, id int64, body string) error
+	DeleteComment(owner, repo string, id int64) error
+}
+
+type defaultImpl struct {
+	client *gogithub.Client
+}
+
+func newDefaultImpl() *defaultImpl {
+	httpClient := http.DefaultClient
+	if token := env.Default(github.TokenEnvKey, ""); token != "" {
+		httpClient = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		))
+	}
+	return &defaultImpl{client: gogithub.NewClient(httpClient)}
+}
+
+func (d *defaultImpl) GetPullRequest(owner, repo string, number int) (*gogithub.PullRequest, error) {
+	pr, _, err := d.client.PullRequests.Get(context.Background(), owner, repo, number)
+	return pr, err
+}
+
+func (d *defaultImpl) Login() (string, error) {
+	user, _, err := d.client.Users.Get(context.Background(), "")
+	if err != nil {
+		return "", err
+	}
+	return user.GetLogin(), nil
+}
+
+func (d *defaultImpl) ListComments(owner, repo string, number int) ([]*gogithub.IssueComment, error) {
+	res := []*gogithub.IssueComment{}
+	opts := &gogithub.IssueListCommentsOptions{ListOptions: gogithub.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := d.client.Issues.ListComments(
+			context.Background(), owner, repo, number, opts,
+		)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, comments...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+	return res, nil
+}
+
+func (d *defaultImpl) CreateComment(owner, repo string, number int, body string) error {
+	_, _, err := d.client.Issues.CreateComment(
+		context.Background(), owner, repo, number, &gogithub.IssueComment{Body: &body},
+	)
+	return err
+}
+
+func (d *defaultImpl) EditComment(owner, repo string, id int64, body string) error {
+	_, _, err := d.client.Issues.EditComment(
+		context.Background(), owner, repo, id, &gogithub.IssueComment{Body: &body},
+	)
+	return err
+}
+
+func (d *defaultImpl) DeleteComment(owner, repo string, id int64) error {
+	_, err := d.client.Issues.DeleteComment(context.Background(), owner, repo, id)
+	return err
+}
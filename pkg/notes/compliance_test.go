@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"testing"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCompliance(t *testing.T) {
+	pr := func(body string, labels ...string) *gogithub.PullRequest {
+		res := &gogithub.PullRequest{Body: &body}
+		for _, label := range labels {
+			res.Labels = append(res.Labels, &gogithub.Label{Name: gogithub.String(label)})
+		}
+		return res
+	}
+	const template = "<!-- include the string \"action required\" if needed -->\n"
+
+	for _, tc := range []struct {
+		name     string
+		pr       *gogithub.PullRequest
+		problems []string
+	}{
+		{
+			name: "compliant note",
+			pr:   pr(template+"```release-note\nFixed a bug\n```\n", "kind/bug", LabelReleaseNote),
+		},
+		{
+			name: "compliant NONE",
+			pr:   pr("```release-note\nNONE\n```\n", "kind/cleanup", LabelReleaseNoteNone),
+		},
+		{
+			name: "compliant action required",
+			pr: pr("```release-note\nACTION REQUIRED: Removed the flag\n```\n",
+				"kind/api-change", LabelReleaseNoteActionRequired),
+		},
+		{
+			name:     "missing block",
+			pr:       pr("Some description", "kind/bug"),
+			problems: []string{"contains no ```release-note block"},
+		},
+		{
+			name:     "empty block",
+			pr:       pr("```release-note\n\n```\n", "kind/bug"),
+			problems: []string{"is empty or cannot be parsed"},
+		},
+		{
+			name:     "NONE with note label",
+			pr:       pr("```release-note\nNONE\n```\n", "kind/bug", LabelReleaseNote),
+			problems: []string{"The release note is NONE"},
+		},
+		{
+			name:     "note with none label",
+			pr:       pr("```release-note\nFixed a bug\n```\n", "kind/bug", LabelReleaseNoteNone),
+			problems: []string{"labeled `release-note-none`"},
+		},
+		{
+			name:     "action required without label",
+			pr:       pr("```release-note\nAction required: Migrate\n```\n", "kind/bug"),
+			problems: []string{"requires action"},
+		},
+		{
+			name:     "action required label without mention",
+			pr:       pr(template+"```release-note\nFixed a bug\n```\n", "kind/bug", LabelReleaseNoteActionRequired),
+			problems: []string{"does not start with `ACTION REQUIRED:`"},
+		},
+		{
+			name:     "missing kind",
+			pr:       pr("```release-note\nFixed a bug\n```\n"),
+			problems: []string{"no `kind/` label"},
+		},
+	} {
+		problems := CheckCompliance(tc.pr)
+		require.Len(t, problems, len(tc.problems), tc.name)
+		for i, problem := range tc.problems {
+			require.Contains(t, problems[i], problem, tc.name)
+		}
+	}
+}
+
+func TestComplianceComment(t *testing.T) {
+	comment := ComplianceComment([]string{"first", "second"})
+	require.Contains(t, comment, ComplianceCommentMarker)
+	require.Contains(t, comment, "- first\n- second\n")
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diff compares two release notes documents, for example of a
+// release candidate and the final release.
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/release/pkg/notes"
+)
+
+// Change is an edited release note.
+type Change struct {
+	// PR is the pull request number of the note.
+	PR int `json:"pr"`
+
+	// Fields are the JSON names of the changed note fields.
+	Fields []string `json:"fields"`
+
+	// Old and New are the notes of both documents.
+	Old *notes.ReleaseNote `json:"old"`
+	New *notes.ReleaseNote `json:"new"`
+}
+
+// Result is the difference between two release notes documents.
+type Result struct {
+	// Added are the notes which only exist in the new document.
+	Added []*notes.ReleaseNote `json:"added"`
+
+	// Removed are the notes which only exist in the old document.
+	Removed []*notes.ReleaseNote `json:"removed"`
+
+	// Edited are the notes which exist in both documents, but differ.
+	Edited []*Change `json:"edited"`
+}
+
+// Empty returns true if both documents contain the same notes.
+func (r *Result) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Edited) == 0
+}
+
+// comparedFields are the user facing fields of a release note, mapped by
+// their JSON name.
+var comparedFields = []struct {
+	name  string
+	value func(*notes.ReleaseNote) interface{}
+}{
+	{"markdown", func(n *notes.ReleaseNote) interface{} { return n.Markdown }},
+	{"kinds", func(n *notes.ReleaseNote) interface{} { return sorted(n.Kinds) }},
+	{"sigs", func(n *notes.ReleaseNote) interface{} { return sorted(n.SIGs) }},
+	{"areas", func(n *notes.ReleaseNote) interface{} { return sorted(n.Areas) }},
+	{"feature", func(n *notes.ReleaseNote) interface{} { return n.Feature }},
+	{"action_required", func(n *notes.ReleaseNote) interface{} { return n.ActionRequired }},
+	{"do_not_publish", func(n *notes.ReleaseNote) interface{} { return n.DoNotPublish }},
+}
+
+// Compare returns the difference between the old and new release notes.
+func Compare(oldNotes, newNotes notes.ReleaseNotesByPR) *Result {
+	res := &Result{
+		Added:   []*notes.ReleaseNote{},
+		Removed: []*notes.ReleaseNote{},
+		Edited:  []*Change{},
+	}
+
+	for _, pr := range sortedPRs(newNotes) {
+		newNote := newNotes[pr]
+		oldNote, ok := oldNotes[pr]
+		if !ok || oldNote == nil {
+			res.Added = append(res.Added, newNote)
+			continue
+		}
+
+		fields := []string{}
+		for _, field := range comparedFields {
+			if !reflect.DeepEqual(field.value(oldNote), field.value(newNote)) {
+				fields = append(fields, field.name)
+			}
+		}
+		if len(fields) > 0 {
+			res.Edited = append(res.Edited, &Change{
+				PR: pr, Fields: fields, Old: oldNote, New: newNote,
+			})
+		}
+	}
+
+	for _, pr := range sortedPRs(oldNotes) {
+		if newNote, ok := newNotes[pr]; !ok || newNote == nil {
+			res.Removed = append(res.Removed, oldNotes[pr])
+		}
+	}
+	return res
+}
+
+// CompareFiles compares two release notes JSON files, as written by
+// `release-notes --format json`.
+func CompareFiles(oldFile, newFile string) (*Result, error) {
+	oldNotes, err := readNotes(oldFile)
+	if err != nil {
+		return nil, err
+	}
+	newNotes, err := readNotes(newFile)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(oldNotes, newNotes), nil
+}
+
+func readNotes(file string) (notes.ReleaseNotesByPR, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read release notes: %w", err)
+	}
+	res := notes.ReleaseNotesByPR{}
+	if err := json.Unmarshal(content, &res); err != nil {
+		return nil, fmt.Errorf("unmarshal release notes %s: %w", file, err)
+	}
+	// Filter out null entries, which do not contain any note.
+	for pr, note := range res {
+		if note == nil {
+			delete(res, pr)
+		}
+	}
+	return res, nil
+}
+
+// Markdown renders the difference as markdown report.
+func (r *Result) Markdown() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf,
+		"# Release notes diff\n\n%d added, %d removed, %d edited\n",
+		len(r.Added), len(r.Removed), len(r.Edited),
+	)
+
+	if len(r.Added) > 0 {
+		buf.WriteString("\n## Added\n\n")
+		for _, note := range r.Added {
+			fmt.Fprintf(buf, "- %s\n", noteLine(note))
+		}
+	}
+
+	if len(r.Removed) > 0 {
+		buf.WriteString("\n## Removed\n\n")
+		for _, note := range r.Removed {
+			fmt.Fprintf(buf, "- %s\n", noteLine(note))
+		}
+	}
+
+	if len(r.Edited) > 0 {
+		buf.WriteString("\n## Edited\n")
+		for _, change := range r.Edited {
+			fmt.Fprintf(buf,
+				"\n### #%d (%s)\n\n", change.PR, strings.Join(change.Fields, ", "),
+			)
+			for _, field := range comparedFields {
+				if !contains(change.Fields, field.name) {
+					continue
+				}
+				fmt.Fprintf(buf,
+					"- %s: `%v` → `%v`\n", field.name,
+					oneLine(field.value(change.Old)), oneLine(field.value(change.New)),
+				)
+			}
+		}
+	}
+	return buf.String()
+}
+
+func noteLine(note *notes.ReleaseNote) string {
+	line := fmt.Sprintf("#%d: %s", note.PrNumber, oneLine(note.Markdown))
+	if note.ActionRequired {
+		line += " **(action required)**"
+	}
+	return line
+}
+
+func oneLine(v interface{}) string {
+	return strings.Join(strings.Fields(fmt.Sprint(v)), " ")
+}
+
+func contains(list []string, elem string) bool {
+	for _, e := range list {
+		if e == elem {
+			return true
+		}
+	}
+	return false
+}
+
+func sorted(list []string) []string {
+	res := append([]string{}, list...)
+	sort.Strings(res)
+	return res
+}
+
+func sortedPRs(releaseNotes notes.ReleaseNotesByPR) []int {
+	res := make([]int, 0, len(releaseNotes))
+	for pr := range releaseNotes {
+		res = append(res, pr)
+	}
+	sort.Ints(res)
+	return res
+}
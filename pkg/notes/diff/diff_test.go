@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/diff"
+)
+
+func testNotes() (oldNotes, newNotes notes.ReleaseNotesByPR) {
+	oldNotes = notes.ReleaseNotesByPR{
+		1: {PrNumber: 1, Markdown: "Unchanged", Kinds: []string{"bug", "cleanup"}},
+		2: {PrNumber: 2, Markdown: "Removed note"},
+		3: {PrNumber: 3, Markdown: "Old text", SIGs: []string{"node"}},
+		4: {PrNumber: 4, Markdown: "Flag changed"},
+	}
+	newNotes = notes.ReleaseNotesByPR{
+		1: {PrNumber: 1, Markdown: "Unchanged", Kinds: []string{"cleanup", "bug"}, Author: "other"},
+		3: {PrNumber: 3, Markdown: "New text", SIGs: []string{"node", "api-machinery"}},
+		4: {PrNumber: 4, Markdown: "Flag changed", ActionRequired: true},
+		5: {PrNumber: 5, Markdown: "Added note", ActionRequired: true},
+	}
+	return oldNotes, newNotes
+}
+
+func TestCompare(t *testing.T) {
+	oldNotes, newNotes := testNotes()
+	res := diff.Compare(oldNotes, newNotes)
+
+	require.False(t, res.Empty())
+	require.Len(t, res.Added, 1)
+	require.Equal(t, 5, res.Added[0].PrNumber)
+	require.Len(t, res.Removed, 1)
+	require.Equal(t, 2, res.Removed[0].PrNumber)
+	require.Len(t, res.Edited, 2)
+	require.Equal(t, 3, res.Edited[0].PR)
+	require.Equal(t, []string{"markdown", "sigs"}, res.Edited[0].Fields)
+	require.Equal(t, 4, res.Edited[1].PR)
+	require.Equal(t, []string{"action_required"}, res.Edited[1].Fields)
+}
+
+func TestCompareEqual(t *testing.T) {
+	oldNotes, _ := testNotes()
+	require.True(t, diff.Compare(oldNotes, oldNotes).Empty())
+}
+
+func TestMarkdown(t *testing.T) {
+	oldNotes, newNotes := testNotes()
+	markdown := diff.Compare(oldNotes, newNotes).Markdown()
+
+	require.Contains(t, markdown, "1 added, 1 removed, 2 edited")
+	require.Contains(t, markdown, "## Added\n\n- #5: Added note **(action required)**\n")
+	require.Contains(t, markdown, "## Removed\n\n- #2: Removed note\n")
+	require.Contains(t, markdown, "### #3 (markdown, sigs)")
+	require.Contains(t, markdown, "- markdown: `Old text` → `New text`")
+	require.Contains(t, markdown, "- action_required: `false` → `true`")
+
+	require.NotContains(t, diff.Compare(oldNotes, oldNotes).Markdown(), "## ")
+}
+
+func TestCompareFiles(t *testing.T) {
+	dir := t.TempDir()
+	oldNotes, newNotes := testNotes()
+
+	write := func(name string, releaseNotes notes.ReleaseNotesByPR) string {
+		content, err := json.Marshal(releaseNotes)
+		require.Nil(t, err)
+		path := filepath.Join(dir, name)
+		require.Nil(t, os.WriteFile(path, content, 0o644))
+		return path
+	}
+	oldFile := write("old.json", oldNotes)
+	newFile := write("new.json", newNotes)
+
+	res, err := diff.CompareFiles(oldFile, newFile)
+	require.Nil(t, err)
+	require.Len(t, res.Added, 1)
+	require.Len(t, res.Removed, 1)
+	require.Len(t, res.Edited, 2)
+
+	_, err = diff.CompareFiles(oldFile, filepath.Join(dir, "missing.json"))
+	require.NotNil(t, err)
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.Nil(t, os.WriteFile(invalid, []byte("wrong"), 0o644))
+	_, err = diff.CompareFiles(invalid, newFile)
+	require.NotNil(t, err)
+}
@@ -32,13 +32,22 @@ import (
 	"golang.org/x/text/language"
 	"sigs.k8s.io/release-utils/hash"
 
+	"k8s.io/release/pkg/branding"
 	"k8s.io/release/pkg/cve"
 	"k8s.io/release/pkg/notes"
 	"k8s.io/release/pkg/notes/options"
 	"k8s.io/release/pkg/release"
 )
 
-const prodRegistry = "registry.k8s.io"
+var (
+	// ErrMissingReleaseTag is returned if the release tags of a document
+	// are not specified.
+	ErrMissingReleaseTag = errors.New("release tags not specified")
+
+	// ErrMissingURLPrefix is returned if the download URL prefix of the
+	// release artifacts is not specified.
+	ErrMissingURLPrefix = errors.New("url prefix not specified")
+)
 
 // Document represents the underlying structure of a release notes document.
 type Document struct {
@@ -74,10 +83,10 @@ func fetchFileMetadata(dir, urlPrefix, tag string) (*FileMetadata, error) {
 		return nil, nil
 	}
 	if tag == "" {
-		return nil, errors.New("release tags not specified")
+		return nil, ErrMissingReleaseTag
 	}
 	if urlPrefix == "" {
-		return nil, errors.New("url prefix not specified")
+		return nil, ErrMissingURLPrefix
 	}
 
 	fm := new(FileMetadata)
@@ -109,11 +118,11 @@ func fetchImageMetadata(dir, tag string) (*ImageMetadata, error) {
 		return nil, nil
 	}
 	if tag == "" {
-		return nil, errors.New("release tag not specified")
+		return nil, ErrMissingReleaseTag
 	}
 
 	manifests, err := release.NewImages().GetManifestImages(
-		prodRegistry, tag, dir, nil,
+		branding.Default().Registry, tag, dir, nil,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("get manifest images: %w", err)
@@ -130,7 +139,7 @@ func fetchImageMetadata(dir, tag string) (*ImageMetadata, error) {
 	const linkBase = "https://console.cloud.google.com/artifacts/docker/k8s-artifacts-prod/southamerica-east1/images/"
 
 	for manifest, tempArchitectures := range manifests {
-		imageName := strings.TrimPrefix(manifest, prodRegistry+"/")
+		imageName := strings.TrimPrefix(manifest, branding.Default().Registry+"/")
 
 		architectures := []string{}
 		for _, architecture := range tempArchitectures {
@@ -239,6 +248,7 @@ var kindPriority = []notes.Kind{
 	notes.KindFlake,
 	notes.KindOther,
 	notes.KindUncategorized,
+	notes.KindAutomated,
 }
 
 var kindMap = map[notes.Kind]notes.Kind{
@@ -321,6 +331,14 @@ func New(
 			}
 		} else if note.ActionRequired {
 			doc.NotesWithActionRequired = append(doc.NotesWithActionRequired, processNote(note.Markdown))
+		} else if note.Automated {
+			// the note has been grouped by the author filters
+			kind := notes.KindAutomated
+			if existing, ok := kindCategory[kind]; ok {
+				*existing.NoteEntries = append(*existing.NoteEntries, processNote(note.Markdown))
+			} else {
+				kindCategory[kind] = NoteCategory{Kind: kind, NoteEntries: &notes.Notes{processNote(note.Markdown)}}
+			}
 		} else {
 			for _, kind := range note.Kinds {
 				mappedKind := mapKind(notes.Kind(kind))
@@ -377,7 +395,7 @@ func (d *Document) RenderMarkdownTemplate(bucket, tars, images, templateSpec str
 		return "", fmt.Errorf("fetching template: %w", err)
 	}
 	tmpl, err := template.New("markdown").
-		Funcs(template.FuncMap{"prettyKind": prettyKind}).
+		Funcs(template.FuncMap{"prettyKind": PrettyKind}).
 		Parse(goTemplate)
 	if err != nil {
 		return "", fmt.Errorf("parsing template: %w", err)
@@ -431,7 +449,7 @@ func (d *Document) template(templateSpec string) (string, error) {
 // The function does nothing if the `tars` variable is empty.
 func CreateDownloadsTable(w io.Writer, bucket, tars, images, prevTag, newTag string) error {
 	if prevTag == "" || newTag == "" {
-		return errors.New("release tags not specified")
+		return ErrMissingReleaseTag
 	}
 
 	printChangelogSinceLine := func() {
@@ -531,7 +549,9 @@ func mapKind(kind notes.Kind) notes.Kind {
 	return kind
 }
 
-func prettyKind(kind notes.Kind) string {
+// PrettyKind returns the heading of the kind used in release notes
+// documents, for example "Bug or Regression".
+func PrettyKind(kind notes.Kind) string {
 	switch kind {
 	case notes.KindAPIChange:
 		return "API Change"
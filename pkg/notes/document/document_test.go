@@ -390,6 +390,30 @@ func TestNew(t *testing.T) {
 				},
 			},
 		},
+		{
+			"notes of automated authors are grouped last",
+			func() *notes.ReleaseNotes {
+				n := notes.NewReleaseNotes()
+				n.Set(0, makeReleaseNote(notes.KindFeature, "A"))
+				automated := makeReleaseNote(notes.KindFeature, "Bump dependency")
+				automated.Automated = true
+				n.Set(1, automated)
+				return n
+			},
+			&Document{
+				NotesWithActionRequired: notes.Notes{},
+				Notes: NoteCollection{
+					NoteCategory{
+						Kind:        notes.KindFeature,
+						NoteEntries: &notes.Notes{"A"},
+					},
+					NoteCategory{
+						Kind:        notes.KindAutomated,
+						NoteEntries: &notes.Notes{"Bump dependency"},
+					},
+				},
+			},
+		},
 		{
 			"highest kind for duplicate note",
 			func() *notes.ReleaseNotes {
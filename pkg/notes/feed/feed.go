@@ -0,0 +1,287 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package feed renders release notes as Atom or RSS feed, which can be
+// watched to monitor the incoming changes of a release branch.
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/release/pkg/notes"
+)
+
+// Format is the format of a feed.
+type Format string
+
+const (
+	// FormatAtom renders an Atom 1.0 feed.
+	FormatAtom Format = "atom"
+
+	// FormatRSS renders an RSS 2.0 feed.
+	FormatRSS Format = "rss"
+)
+
+// maxTitleLength is the maximum number of characters of an entry title.
+const maxTitleLength = 100
+
+// Feed is a feed of release notes.
+type Feed struct {
+	// Title is the title of the feed, for example "Kubernetes release-1.30".
+	Title string
+
+	// Link points to the HTML representation of the feed, for example the
+	// release branch on GitHub.
+	Link string
+
+	// Description is a short summary of the feed.
+	Description string
+
+	// Updated is the time the feed got generated.
+	Updated time.Time
+
+	// Entries are the release notes, one entry per pull request.
+	Entries []*Entry
+}
+
+// Entry is a single release note of a feed.
+type Entry struct {
+	// Title is the first line of the note including the PR number.
+	Title string
+
+	// Link points to the pull request of the note.
+	Link string
+
+	// Author is the GitHub user of the note.
+	Author string
+
+	// AuthorURL is the GitHub profile of the author.
+	AuthorURL string
+
+	// Content is the markdown text of the note.
+	Content string
+
+	// Categories are the kinds, SIGs and areas of the note.
+	Categories []string
+
+	// Updated is the time the PR of the note got merged.
+	Updated time.Time
+}
+
+// New creates a feed with one entry per pull request of the release notes,
+// in the order of their history. The entries are dated by the merge time of
+// their pull request, which falls back to the updated time if unknown.
+func New(title, link, description string, releaseNotes *notes.ReleaseNotes, updated time.Time) *Feed {
+	f := &Feed{
+		Title:       title,
+		Link:        link,
+		Description: description,
+		Updated:     updated.UTC(),
+	}
+	for _, pr := range releaseNotes.History() {
+		note := releaseNotes.Get(pr)
+		if note == nil || note.DoNotPublish {
+			continue
+		}
+		entry := newEntry(note)
+		if entry.Updated.IsZero() {
+			entry.Updated = f.Updated
+		}
+		f.Entries = append(f.Entries, entry)
+	}
+	return f
+}
+
+func newEntry(note *notes.ReleaseNote) *Entry {
+	content := note.Markdown
+	if content == "" {
+		content = note.Text
+	}
+	content = notes.SanitizeText(content)
+
+	categories := []string{}
+	for _, kind := range note.Kinds {
+		categories = append(categories, "kind/"+kind)
+	}
+	for _, sig := range note.SIGs {
+		categories = append(categories, "sig/"+sig)
+	}
+	for _, area := range note.Areas {
+		categories = append(categories, "area/"+area)
+	}
+
+	entry := &Entry{
+		Title:      fmt.Sprintf("#%d: %s", note.PrNumber, entryTitle(notes.SanitizeText(note.Text))),
+		Link:       note.PrURL,
+		Author:     note.Author,
+		AuthorURL:  note.AuthorURL,
+		Content:    content,
+		Categories: categories,
+	}
+	if note.MergedAt != nil {
+		entry.Updated = note.MergedAt.UTC()
+	}
+	return entry
+}
+
+// entryTitle returns the first line of the note text, shortened to
+// maxTitleLength characters.
+func entryTitle(text string) string {
+	title, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	title = strings.TrimSpace(title)
+	if runes := []rune(title); len(runes) > maxTitleLength {
+		return strings.TrimSpace(string(runes[:maxTitleLength-1])) + "…"
+	}
+	return title
+}
+
+// Render returns the feed in the provided format.
+func (f *Feed) Render(format Format) ([]byte, error) {
+	var v interface{}
+	switch format {
+	case FormatAtom:
+		v = f.atom()
+	case FormatRSS:
+		v = f.rss()
+	default:
+		return nil, fmt.Errorf("unsupported feed format %q", format)
+	}
+
+	out, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s feed: %w", format, err)
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+type atomFeed struct {
+	XMLName  xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Subtitle string       `xml:"subtitle,omitempty"`
+	Updated  string       `xml:"updated"`
+	Link     atomLink     `xml:"link"`
+	Entries  []*atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Link       atomLink       `xml:"link"`
+	Author     *atomAuthor    `xml:"author,omitempty"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+func (f *Feed) atom() *atomFeed {
+	res := &atomFeed{
+		ID:       f.Link,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  f.Updated.Format(time.RFC3339),
+		Link:     atomLink{Href: f.Link},
+	}
+	for _, e := range f.Entries {
+		entry := &atomEntry{
+			ID:      e.Link,
+			Title:   e.Title,
+			Updated: e.Updated.Format(time.RFC3339),
+			Link:    atomLink{Href: e.Link},
+			Content: atomContent{Type: "text", Value: e.Content},
+		}
+		if e.Author != "" {
+			entry.Author = &atomAuthor{Name: e.Author, URI: e.AuthorURL}
+		}
+		for _, c := range e.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: c})
+		}
+		res.Entries = append(res.Entries, entry)
+	}
+	return res
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	LastBuildDate string     `xml:"lastBuildDate"`
+	Items         []*rssItem `xml:"item"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+}
+
+func (f *Feed) rss() *rssFeed {
+	res := &rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Description,
+			LastBuildDate: f.Updated.Format(time.RFC1123Z),
+		},
+	}
+	for _, e := range f.Entries {
+		res.Channel.Items = append(res.Channel.Items, &rssItem{
+			Title:       e.Title,
+			Link:        e.Link,
+			GUID:        rssGUID{IsPermaLink: true, Value: e.Link},
+			PubDate:     e.Updated.Format(time.RFC1123Z),
+			Categories:  e.Categories,
+			Description: e.Content,
+		})
+	}
+	return res
+}
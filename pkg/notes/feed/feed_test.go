@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package feed_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/feed"
+)
+
+func testNotes() *notes.ReleaseNotes {
+	mergedAt := time.Date(2024, 4, 20, 8, 30, 0, 0, time.UTC)
+	releaseNotes := notes.NewReleaseNotes()
+	releaseNotes.Set(2, &notes.ReleaseNote{
+		Text:      "Fixed a bug\n\nwith more details",
+		Markdown:  "Fixed a bug ([#2](https://github.com/kubernetes/kubernetes/pull/2), [@bar](https://github.com/bar))",
+		Author:    "bar",
+		AuthorURL: "https://github.com/bar",
+		PrURL:     "https://github.com/kubernetes/kubernetes/pull/2",
+		PrNumber:  2,
+		Kinds:     []string{"bug"},
+		SIGs:      []string{"node"},
+		MergedAt:  &mergedAt,
+	})
+	releaseNotes.Set(1, &notes.ReleaseNote{
+		Text:     strings.Repeat("a", 150),
+		PrURL:    "https://github.com/kubernetes/kubernetes/pull/1",
+		PrNumber: 1,
+		Areas:    []string{"kubelet"},
+	})
+	releaseNotes.Set(3, &notes.ReleaseNote{
+		Text:         "Hidden",
+		PrNumber:     3,
+		DoNotPublish: true,
+	})
+	return releaseNotes
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	f := feed.New("title", "https://example.com", "description", testNotes(), time.Now())
+	require.Len(t, f.Entries, 2)
+
+	require.Equal(t, "#2: Fixed a bug", f.Entries[0].Title)
+	require.Equal(t, "https://github.com/kubernetes/kubernetes/pull/2", f.Entries[0].Link)
+	require.Contains(t, f.Entries[0].Content, "[@bar]")
+	require.Equal(t, []string{"kind/bug", "sig/node"}, f.Entries[0].Categories)
+
+	require.Equal(t, "#1: "+strings.Repeat("a", 99)+"…", f.Entries[1].Title)
+	require.Equal(t, strings.Repeat("a", 150), f.Entries[1].Content)
+	require.Equal(t, []string{"area/kubelet"}, f.Entries[1].Categories)
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	updated := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	f := feed.New("Kubernetes release-1.30", "https://example.com", "description", testNotes(), updated)
+
+	t.Run("atom", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := f.Render(feed.FormatAtom)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(out), xml.Header))
+
+		var res struct {
+			XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+			Title   string   `xml:"title"`
+			Updated string   `xml:"updated"`
+			Entries []struct {
+				ID      string `xml:"id"`
+				Title   string `xml:"title"`
+				Updated string `xml:"updated"`
+				Author  struct {
+					Name string `xml:"name"`
+				} `xml:"author"`
+				Categories []struct {
+					Term string `xml:"term,attr"`
+				} `xml:"category"`
+				Content string `xml:"content"`
+			} `xml:"entry"`
+		}
+		require.NoError(t, xml.Unmarshal(out, &res))
+		require.Equal(t, "Kubernetes release-1.30", res.Title)
+		require.Equal(t, "2024-05-01T10:00:00Z", res.Updated)
+		require.Len(t, res.Entries, 2)
+		require.Equal(t, "https://github.com/kubernetes/kubernetes/pull/2", res.Entries[0].ID)
+		require.Equal(t, "#2: Fixed a bug", res.Entries[0].Title)
+		require.Equal(t, "2024-04-20T08:30:00Z", res.Entries[0].Updated)
+		require.Equal(t, "2024-05-01T10:00:00Z", res.Entries[1].Updated)
+		require.Equal(t, "bar", res.Entries[0].Author.Name)
+		require.Len(t, res.Entries[0].Categories, 2)
+		require.Contains(t, res.Entries[0].Content, "Fixed a bug")
+	})
+
+	t.Run("rss", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := f.Render(feed.FormatRSS)
+		require.NoError(t, err)
+
+		var res struct {
+			XMLName xml.Name `xml:"rss"`
+			Version string   `xml:"version,attr"`
+			Channel struct {
+				Title         string `xml:"title"`
+				LastBuildDate string `xml:"lastBuildDate"`
+				Items         []struct {
+					Title       string   `xml:"title"`
+					Link        string   `xml:"link"`
+					GUID        string   `xml:"guid"`
+					PubDate     string   `xml:"pubDate"`
+					Categories  []string `xml:"category"`
+					Description string   `xml:"description"`
+				} `xml:"item"`
+			} `xml:"channel"`
+		}
+		require.NoError(t, xml.Unmarshal(out, &res))
+		require.Equal(t, "2.0", res.Version)
+		require.Equal(t, "Wed, 01 May 2024 10:00:00 +0000", res.Channel.LastBuildDate)
+		require.Len(t, res.Channel.Items, 2)
+		require.Equal(t, "https://github.com/kubernetes/kubernetes/pull/1", res.Channel.Items[1].GUID)
+		require.Equal(t, "Sat, 20 Apr 2024 08:30:00 +0000", res.Channel.Items[0].PubDate)
+		require.Equal(t, []string{"kind/bug", "sig/node"}, res.Channel.Items[0].Categories)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := f.Render(feed.Format("html"))
+		require.Error(t, err)
+	})
+}
@@ -19,13 +19,10 @@ package notes
 import (
 	"bufio"
 	"context"
-	"crypto/rand"
 	"crypto/sha1" //nolint:gosec // used for file integrity checks, NOT security
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"math/big"
-	"net/http"
 	"net/url"
 	"regexp"
 	"sort"
@@ -44,13 +41,13 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"k8s.io/release/pkg/notes/options"
+	"k8s.io/release/pkg/retry"
 	"sigs.k8s.io/release-sdk/github"
 )
 
 var (
-	errNoPRIDFoundInCommitMessage       = errors.New("no PR IDs found in the commit message")
-	errNoPRFoundForCommitSHA            = errors.New("no PR found for this commit")
-	apiSleepTime                  int64 = 60
+	errNoPRIDFoundInCommitMessage = errors.New("no PR IDs found in the commit message")
+	errNoPRFoundForCommitSHA      = errors.New("no PR found for this commit")
 )
 
 const (
@@ -80,6 +77,7 @@ const (
 	KindRegression    Kind = "regression"
 	KindOther         Kind = "Other (Cleanup or Flake)"
 	KindUncategorized Kind = "Uncategorized"
+	KindAutomated     Kind = "Automated Changes"
 )
 
 // ReleaseNote is the type that represents the total sum of all the information
@@ -143,6 +141,13 @@ type ReleaseNote struct {
 
 	// PRBody is the full PR body of the release note
 	PRBody string `json:"pr_body,omitempty"`
+
+	// MergedAt is the time the PR got merged
+	MergedAt *time.Time `json:"merged_at,omitempty"`
+
+	// Automated is true if the PR was opened by a bot or automation account,
+	// which has to be grouped separately because of the author filters.
+	Automated bool `json:"automated,omitempty"`
 }
 
 type Documentation struct {
@@ -244,8 +249,14 @@ func NewGathererWithClient(ctx context.Context, c github.Client) *Gatherer {
 // GatherReleaseNotes creates a new gatherer and collects the release notes
 // afterwards
 func GatherReleaseNotes(opts *options.Options) (*ReleaseNotes, error) {
+	return GatherReleaseNotesContext(context.Background(), opts)
+}
+
+// GatherReleaseNotesContext is like GatherReleaseNotes but cancels all
+// in-flight GitHub requests if the provided context gets cancelled.
+func GatherReleaseNotesContext(ctx context.Context, opts *options.Options) (*ReleaseNotes, error) {
 	logrus.Info("Gathering release notes")
-	gatherer, err := NewGatherer(context.Background(), opts)
+	gatherer, err := NewGatherer(ctx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("retrieving notes gatherer: %w", err)
 	}
@@ -279,6 +290,11 @@ func (g *Gatherer) ListReleaseNotes() (*ReleaseNotes, error) {
 		mapProviders = append(mapProviders, provider)
 	}
 
+	authorFilters, err := options.ParseAuthorFilters(g.options.AuthorFilters)
+	if err != nil {
+		return nil, fmt.Errorf("parsing author filters: %w", err)
+	}
+
 	commits, err := g.listCommits(g.options.Branch, g.options.StartSHA, g.options.EndSHA)
 	if err != nil {
 		return nil, fmt.Errorf("listing commits: %w", err)
@@ -336,6 +352,20 @@ func (g *Gatherer) ListReleaseNotes() (*ReleaseNotes, error) {
 			}
 		}
 
+		if len(g.options.Paths) > 0 {
+			touches, err := g.touchesPaths(result.commit.GetSHA())
+			if err != nil {
+				return nil, fmt.Errorf("checking paths of commit %s: %w", result.commit.GetSHA(), err)
+			}
+			if !touches {
+				logrus.Infof(
+					"Skipping release note for PR #%d because it does not touch any of the paths %v",
+					result.pullRequest.GetNumber(), g.options.Paths,
+				)
+				continue
+			}
+		}
+
 		note, err := g.ReleaseNoteFromCommit(result)
 		if err != nil {
 			logrus.Errorf(
@@ -359,6 +389,10 @@ func (g *Gatherer) ListReleaseNotes() (*ReleaseNotes, error) {
 				}
 			}
 		}
+
+		if !applyAuthorFilters(authorFilters, note) {
+			continue
+		}
 		if _, ok := dedupeCache[note.Markdown]; !ok {
 			notes.Set(note.PrNumber, note)
 			dedupeCache[note.Markdown] = struct{}{}
@@ -367,10 +401,74 @@ func (g *Gatherer) ListReleaseNotes() (*ReleaseNotes, error) {
 	return notes, nil
 }
 
+// applyAuthorFilters marks the note as automated if its author has to be
+// grouped separately. It returns false if the note has to be excluded.
+func applyAuthorFilters(filters options.AuthorFilters, note *ReleaseNote) bool {
+	switch filters.Action(note.Author, note.Kinds) {
+	case options.AuthorFilterExclude:
+		logrus.Infof(
+			"Skipping release note for PR #%d because author %q is excluded",
+			note.PrNumber, note.Author,
+		)
+		return false
+	case options.AuthorFilterGroup:
+		logrus.Debugf("Grouping release note for PR #%d of author %q", note.PrNumber, note.Author)
+		note.Automated = true
+	case options.AuthorFilterInclude:
+	}
+	return true
+}
+
+// touchesPaths returns true if the commit changes files below at least one
+// of the configured paths. Merge commits are compared to their first parent,
+// which means that all changes of the merged pull request are considered.
+func (g *Gatherer) touchesPaths(sha string) (bool, error) {
+	var commit *gogithub.RepositoryCommit
+	if err := retry.Do(g.context, retry.ServiceGitHub, func() (err error) {
+		commit, _, err = g.client.GetRepoCommit(g.context, g.options.GithubOrg, g.options.GithubRepo, sha)
+		return err
+	}); err != nil {
+		return false, fmt.Errorf("retrieve commit: %w", err)
+	}
+
+	files := []string{}
+	for _, file := range commit.Files {
+		files = append(files, file.GetFilename(), file.GetPreviousFilename())
+	}
+	return matchesPaths(files, g.options.Paths), nil
+}
+
+// matchesPaths returns true if one of the files is located at or below one
+// of the paths.
+func matchesPaths(files, paths []string) bool {
+	for _, file := range files {
+		for _, p := range paths {
+			if file != "" && (file == p || strings.HasPrefix(file, p+"/")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // noteTextFromString returns the text of the release note given a string which
 // may contain the commit message, the PR description, etc.
 // This is generally the content inside the ```release-note ``` stanza.
 func noteTextFromString(s string) (string, error) {
+	note, err := rawNoteTextFromString(s)
+	if err != nil {
+		return "", err
+	}
+	note = stripActionRequired(note)
+	note = dashify(note)
+	note = unlist(note)
+	note = strings.TrimSpace(note)
+	return note, nil
+}
+
+// rawNoteTextFromString returns the unmodified content of the release note
+// block.
+func rawNoteTextFromString(s string) (string, error) {
 	exps := []*regexp.Regexp{
 		// (?s) is needed for '.' to be matching on newlines, by default that's disabled
 		// we need to match ungreedy 'U', because after the notes a `docs` block can occur
@@ -392,12 +490,7 @@ func noteTextFromString(s string) (string, error) {
 			}
 		}
 
-		note := strings.ReplaceAll(result["note"], "\r", "")
-		note = stripActionRequired(note)
-		note = dashify(note)
-		note = unlist(note)
-		note = strings.TrimSpace(note)
-		return note, nil
+		return strings.ReplaceAll(result["note"], "\r", ""), nil
 	}
 
 	return "", errors.New("no matches found when parsing note text from commit string")
@@ -470,6 +563,7 @@ func (g *Gatherer) ReleaseNoteFromCommit(result *Result) (*ReleaseNote, error) {
 	if err != nil {
 		return nil, err
 	}
+	text = SanitizeText(text)
 
 	documentation := DocumentationFromString(prBody)
 
@@ -523,9 +617,19 @@ func (g *Gatherer) ReleaseNoteFromCommit(result *Result) (*ReleaseNote, error) {
 		ActionRequired: labelExactMatch(pr, "release-note-action-required"),
 		DoNotPublish:   labelExactMatch(pr, "release-note-none"),
 		PRBody:         prBody,
+		MergedAt:       mergedAt(pr),
 	}, nil
 }
 
+// mergedAt returns the merge time of the PR, or nil if it is unknown.
+func mergedAt(pr *gogithub.PullRequest) *time.Time {
+	if pr.MergedAt == nil {
+		return nil
+	}
+	t := pr.GetMergedAt().Time
+	return &t
+}
+
 // listCommits lists all commits starting from a given commit SHA and ending at
 // a given commit SHA.
 func (g *Gatherer) listCommits(branch, start, end string) ([]*gogithub.RepositoryCommit, error) {
@@ -544,17 +648,14 @@ func (g *Gatherer) listCommits(branch, start, end string) ([]*gogithub.Repositor
 	worker := func(clo *gogithub.CommitsListOptions) (
 		commits []*gogithub.RepositoryCommit, resp *gogithub.Response, err error,
 	) {
-		for {
+		err = retry.Do(g.context, retry.ServiceGitHub, func() (err error) {
 			commits, resp, err = g.client.ListCommits(g.context, g.options.GithubOrg, g.options.GithubRepo, clo)
-			if err != nil {
-				if !canWaitAndRetry(resp, err) {
-					return nil, nil, err
-				}
-			} else {
-				break
-			}
+			return err
+		})
+		if err != nil {
+			return nil, nil, err
 		}
-		return commits, resp, err
+		return commits, resp, nil
 	}
 
 	clo := gogithub.CommitsListOptions{
@@ -743,6 +844,7 @@ func (g *Gatherer) ReleaseNoteForPullRequest(prNr int) (*ReleaseNote, error) {
 	if doNotPublish {
 		s = ""
 	}
+	s = SanitizeText(s)
 
 	// Create the release notes object
 	note := &ReleaseNote{
@@ -957,29 +1059,6 @@ func hasString(a []string, x string) bool {
 	return false
 }
 
-// canWaitAndRetry retruen true if the gatherer hit the GitHub API secondary rate limit
-func canWaitAndRetry(r *gogithub.Response, err error) bool {
-	// If we hit the secondary rate limit...
-	if r == nil {
-		return false
-	}
-	if r.StatusCode == http.StatusForbidden &&
-		strings.Contains(err.Error(), "secondary rate limit. Please wait") {
-		// ... sleep for a minute plus a random bit so that workers don't
-		// respawn all at the same time
-		rtime, err := rand.Int(rand.Reader, big.NewInt(30))
-		if err != nil {
-			logrus.Error(err)
-			return false
-		}
-		waitTime := rtime.Int64() + apiSleepTime
-		logrus.Warnf("Hit the GitHub secondary rate limit, sleeping for %d secs.", waitTime)
-		time.Sleep(time.Duration(waitTime) * time.Second)
-		return true
-	}
-	return false
-}
-
 // prsForCommitFromSHA retrieves the PR numbers for a commit given its sha
 func (g *Gatherer) prsForCommitFromSHA(sha string) (prs []*gogithub.PullRequest, err error) {
 	plo := &gogithub.ListOptions{
@@ -992,17 +1071,13 @@ func (g *Gatherer) prsForCommitFromSHA(sha string) (prs []*gogithub.PullRequest,
 	var resp *gogithub.Response
 
 	for {
-		for {
+		if err := retry.Do(g.context, retry.ServiceGitHub, func() (err error) {
 			pResult, resp, err = g.client.ListPullRequestsWithCommit(
 				g.context, g.options.GithubOrg, g.options.GithubRepo, sha, plo,
 			)
-			if err != nil {
-				if !canWaitAndRetry(resp, err) {
-					return nil, err
-				}
-			} else {
-				break
-			}
+			return err
+		}); err != nil {
+			return nil, err
 		}
 
 		for _, result := range pResult {
@@ -1033,20 +1108,15 @@ func (g *Gatherer) prsForCommitFromMessage(commitMessage string) (prs []*gogithu
 		return nil, err
 	}
 	var res *gogithub.PullRequest
-	var resp *gogithub.Response
 
 	for _, pr := range prsNum {
 		// Given the PR number that we've now converted to an integer, get the PR from
 		// the API
-		for {
-			res, resp, err = g.client.GetPullRequest(g.context, g.options.GithubOrg, g.options.GithubRepo, pr)
-			if err != nil {
-				if !canWaitAndRetry(resp, err) {
-					return nil, err
-				}
-			} else {
-				break
-			}
+		if err := retry.Do(g.context, retry.ServiceGitHub, func() (err error) {
+			res, _, err = g.client.GetPullRequest(g.context, g.options.GithubOrg, g.options.GithubRepo, pr)
+			return err
+		}); err != nil {
+			return nil, err
 		}
 		prs = append(prs, res)
 	}
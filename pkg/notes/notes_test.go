@@ -24,10 +24,13 @@ import (
 	"reflect"
 	"testing"
 
+	gogithub "github.com/google/go-github/v58/github"
 	"github.com/stretchr/testify/require"
+	"k8s.io/release/pkg/httpreplay"
 	"k8s.io/release/pkg/notes/options"
 
 	kgithub "sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-sdk/github/githubfakes"
 )
 
 const (
@@ -245,7 +248,7 @@ func TestPrettySIG(t *testing.T) {
 	}
 
 	for input, expected := range cases {
-		require.Equal(t, expected, (prettySIG(input)))
+		require.Equal(t, expected, prettySIG(input))
 	}
 }
 
@@ -301,6 +304,50 @@ func TestNoteTextFromString(t *testing.T) {
 	}
 }
 
+func TestMatchesPaths(t *testing.T) {
+	paths := []string{"staging/src/k8s.io/client-go", "pkg/kubelet"}
+	for _, tc := range []struct {
+		files   []string
+		matches bool
+	}{
+		{files: []string{"staging/src/k8s.io/client-go/rest/client.go"}, matches: true},
+		{files: []string{"README.md", "pkg/kubelet/kubelet.go"}, matches: true},
+		{files: []string{"pkg/kubelet"}, matches: true},
+		{files: []string{"pkg/kubeletfoo/main.go"}, matches: false},
+		{files: []string{"staging/src/k8s.io/api/types.go", ""}, matches: false},
+		{files: []string{}, matches: false},
+	} {
+		require.Equal(t, tc.matches, matchesPaths(tc.files, paths), tc.files)
+	}
+}
+
+func TestTouchesPaths(t *testing.T) {
+	client := &githubfakes.FakeClient{}
+	client.GetRepoCommitReturns(&gogithub.RepositoryCommit{
+		Files: []*gogithub.CommitFile{
+			{Filename: gogithub.String("README.md")},
+			{
+				Filename:         gogithub.String("staging/src/k8s.io/api/types.go"),
+				PreviousFilename: gogithub.String("staging/src/k8s.io/client-go/types.go"),
+			},
+		},
+	}, nil, nil)
+
+	gatherer := NewGathererWithClient(context.Background(), client)
+	gatherer.options.Paths = []string{"staging/src/k8s.io/client-go"}
+	touches, err := gatherer.touchesPaths("abc")
+	require.NoError(t, err)
+	require.True(t, touches)
+
+	gatherer.options.Paths = []string{"pkg/kubelet"}
+	touches, err = gatherer.touchesPaths("abc")
+	require.NoError(t, err)
+	require.False(t, touches)
+
+	_, _, _, sha := client.GetRepoCommitArgsForCall(0)
+	require.Equal(t, "abc", sha)
+}
+
 func TestMatchesExcludeFilter(t *testing.T) {
 	for _, tc := range []struct {
 		input         string
@@ -505,6 +552,8 @@ func TestCapitalizeString(t *testing.T) {
 }
 
 func TestReleaseNoteForPullRequest(t *testing.T) {
+	httpreplay.Use(t, "testdata/cassettes/release-note-for-pull-request.yaml")
+
 	g, err := NewGatherer(context.Background(), &options.Options{
 		GithubBaseURL: kgithub.GitHubURL,
 		GithubOrg:     DefaultOrg,
@@ -46,6 +46,11 @@ type releaseNotesAggregator struct {
 
 func (g *Gatherer) ListReleaseNotesV2() (*ReleaseNotes, error) {
 	// left parent of Git commits is always the main branch parent
+	authorFilters, err := options.ParseAuthorFilters(g.options.AuthorFilters)
+	if err != nil {
+		return nil, fmt.Errorf("parsing author filters: %w", err)
+	}
+
 	pairs, err := g.listLeftParentCommits(g.options)
 	if err != nil {
 		return nil, fmt.Errorf("listing offline commits: %w", err)
@@ -93,6 +98,19 @@ func (g *Gatherer) ListReleaseNotesV2() (*ReleaseNotes, error) {
 				}
 			}
 
+			if len(g.options.Paths) > 0 {
+				touches, err := g.touchesPaths(pair.Commit.Hash.String())
+				if err != nil || !touches {
+					logrus.WithFields(logrus.Fields{
+						"sha": pair.Commit.Hash.String(),
+						"pr":  pair.PrNum,
+					}).Debugf("skip: does not touch paths %v (err: %v)", g.options.Paths, err)
+					bar.Increment()
+					t.Done(err)
+					return
+				}
+			}
+
 			releaseNote, err := g.buildReleaseNote(pair)
 			if err == nil {
 				if releaseNote != nil {
@@ -103,6 +121,11 @@ func (g *Gatherer) ListReleaseNotesV2() (*ReleaseNotes, error) {
 							}).Errorf("ignore err: %v", err)
 						}
 					}
+					if !applyAuthorFilters(authorFilters, releaseNote) {
+						bar.Increment()
+						t.Done(nil)
+						return
+					}
 					logrus.WithFields(logrus.Fields{
 						"pr":   pair.PrNum,
 						"note": releaseNote.Text,
@@ -159,6 +182,7 @@ func (g *Gatherer) buildReleaseNote(pair *commitPrPair) (*ReleaseNote, error) {
 		}).Debugf("ignore err: %v", err)
 		return nil, nil
 	}
+	text = SanitizeText(text)
 
 	documentation := DocumentationFromString(prBody)
 
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AuthorFilterAction defines how the notes of a matching author are handled.
+type AuthorFilterAction string
+
+const (
+	// AuthorFilterExclude removes the notes of the author.
+	AuthorFilterExclude AuthorFilterAction = "exclude"
+
+	// AuthorFilterGroup moves the notes of the author into a separate
+	// section of the automated changes.
+	AuthorFilterGroup AuthorFilterAction = "group"
+
+	// AuthorFilterInclude keeps the notes of the author as they are, which
+	// can be used for overriding the rule of the author for single kinds.
+	AuthorFilterInclude AuthorFilterAction = "include"
+)
+
+// AuthorFilter is a single parsed rule of the AuthorFilters option.
+type AuthorFilter struct {
+	// Author is the GitHub login of the pull request author, matched case
+	// insensitive.
+	Author string
+
+	// Kind is the optional kind label of the notes the rule applies to.
+	Kind string
+
+	// Action is the handling of the matching notes.
+	Action AuthorFilterAction
+}
+
+// AuthorFilters is a list of parsed author filter rules.
+type AuthorFilters []AuthorFilter
+
+// ParseAuthorFilters parses rules of the format `<author>[:<kind>]=<action>`,
+// for example `dependabot[bot]=exclude` or `dependabot[bot]:bug=group`.
+func ParseAuthorFilters(rules []string) (AuthorFilters, error) {
+	res := AuthorFilters{}
+	for _, rule := range rules {
+		match, action, ok := strings.Cut(strings.TrimSpace(rule), "=")
+		if !ok {
+			return nil, fmt.Errorf("%w %q: missing action", ErrInvalidAuthorFilter, rule)
+		}
+
+		author, kind, _ := strings.Cut(match, ":")
+		filter := AuthorFilter{
+			Author: strings.ToLower(strings.TrimSpace(author)),
+			Kind:   strings.TrimPrefix(strings.TrimSpace(kind), "kind/"),
+			Action: AuthorFilterAction(strings.TrimSpace(action)),
+		}
+		if filter.Author == "" {
+			return nil, fmt.Errorf("%w %q: missing author", ErrInvalidAuthorFilter, rule)
+		}
+
+		switch filter.Action {
+		case AuthorFilterExclude, AuthorFilterGroup, AuthorFilterInclude:
+		default:
+			return nil, fmt.Errorf(
+				"%w %q: action has to be one of %s, %s or %s",
+				ErrInvalidAuthorFilter, rule,
+				AuthorFilterExclude, AuthorFilterGroup, AuthorFilterInclude,
+			)
+		}
+
+		res = append(res, filter)
+	}
+	return res, nil
+}
+
+// Action returns the action for the notes of the author with the provided
+// kinds. Rules matching one of the kinds take precedence over the rules of
+// the author without a kind, while the last matching rule wins otherwise.
+// AuthorFilterInclude is returned if no rule matches.
+func (f AuthorFilters) Action(author string, kinds []string) AuthorFilterAction {
+	author = strings.ToLower(author)
+	authorAction, kindAction := AuthorFilterInclude, AuthorFilterAction("")
+
+	for _, filter := range f {
+		if filter.Author != author {
+			continue
+		}
+		if filter.Kind == "" {
+			authorAction = filter.Action
+			continue
+		}
+		for _, kind := range kinds {
+			if kind == filter.Kind {
+				kindAction = filter.Action
+			}
+		}
+	}
+
+	if kindAction != "" {
+		return kindAction
+	}
+	return authorAction
+}
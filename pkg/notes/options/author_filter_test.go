@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAuthorFilters(t *testing.T) {
+	filters, err := ParseAuthorFilters([]string{
+		"dependabot[bot]=exclude",
+		" Renovate[bot]:kind/bug = group",
+	})
+	require.NoError(t, err)
+	require.Equal(t, AuthorFilters{
+		{Author: "dependabot[bot]", Action: AuthorFilterExclude},
+		{Author: "renovate[bot]", Kind: "bug", Action: AuthorFilterGroup},
+	}, filters)
+
+	for _, rule := range []string{
+		"dependabot[bot]",
+		"=exclude",
+		"dependabot[bot]=drop",
+	} {
+		_, err := ParseAuthorFilters([]string{rule})
+		require.ErrorIs(t, err, ErrInvalidAuthorFilter, rule)
+	}
+}
+
+func TestAuthorFiltersAction(t *testing.T) {
+	filters, err := ParseAuthorFilters([]string{
+		"dependabot[bot]=exclude",
+		"dependabot[bot]:feature=group",
+		"k8s-ci-robot=group",
+		"k8s-ci-robot:bug=include",
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		author   string
+		kinds    []string
+		expected AuthorFilterAction
+	}{
+		{author: "someone", kinds: []string{"bug"}, expected: AuthorFilterInclude},
+		{author: "dependabot[bot]", kinds: []string{"cleanup"}, expected: AuthorFilterExclude},
+		{author: "Dependabot[bot]", expected: AuthorFilterExclude},
+		{author: "dependabot[bot]", kinds: []string{"cleanup", "feature"}, expected: AuthorFilterGroup},
+		{author: "k8s-ci-robot", expected: AuthorFilterGroup},
+		{author: "k8s-ci-robot", kinds: []string{"bug"}, expected: AuthorFilterInclude},
+	} {
+		require.Equal(t, tc.expected, filters.Action(tc.author, tc.kinds), tc.author)
+	}
+}
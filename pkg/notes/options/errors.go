@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import "errors"
+
+// The errors returned when validating the options, which can be matched by
+// using errors.Is.
+var (
+	// ErrConflictingOptions is returned if options are set which cannot be
+	// used together.
+	ErrConflictingOptions = errors.New("conflicting options")
+
+	// ErrInvalidPath is returned if a path filter is not relative to the
+	// repository root.
+	ErrInvalidPath = errors.New("invalid path")
+
+	// ErrMissingGitHubToken is returned if neither a GitHub token nor a
+	// replay directory is set.
+	ErrMissingGitHubToken = errors.New("missing GitHub token")
+
+	// ErrMissingStartRev is returned if no start of the commit range is
+	// set.
+	ErrMissingStartRev = errors.New("missing start revision")
+
+	// ErrMissingEndRev is returned if no end of the commit range is set.
+	ErrMissingEndRev = errors.New("missing end revision")
+
+	// ErrInvalidDate is returned if a date cannot be parsed or the date
+	// range is empty.
+	ErrInvalidDate = errors.New("invalid date")
+
+	// ErrInvalidFormat is returned if the output format is not supported.
+	ErrInvalidFormat = errors.New("invalid format")
+
+	// ErrInvalidTemplate is returned if the go template is malformed or
+	// does not exist.
+	ErrInvalidTemplate = errors.New("invalid go template")
+
+	// ErrInvalidAuthorFilter is returned if an author filter rule cannot be
+	// parsed.
+	ErrInvalidAuthorFilter = errors.New("invalid author filter")
+)
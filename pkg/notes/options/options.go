@@ -17,16 +17,18 @@ limitations under the License.
 package options
 
 import (
-	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/command"
 )
 
 // Options is the global options structure which can be used to build release
@@ -70,6 +72,17 @@ type Options struct {
 	// valid git revision. Should not be used together with EndSHA.
 	EndRev string
 
+	// StartDate can be used to set the release notes start revision to the
+	// last commit on Branch before the date. Should not be used together
+	// with StartSHA or StartRev.
+	StartDate time.Time
+
+	// EndDate can be used to set the release notes end revision to the last
+	// commit on Branch before the date. Should not be used together with
+	// EndSHA or EndRev. The end revision defaults to the head of Branch if
+	// only StartDate is set.
+	EndDate time.Time
+
 	// Format specifies the format of the release notes. Can be either
 	// `json` or `markdown`.
 	Format string
@@ -83,6 +96,18 @@ type Options struct {
 	// author
 	RequiredAuthor string
 
+	// Paths can be used to restrict the release notes to changes touching
+	// at least one of the repository paths, for example
+	// `staging/src/k8s.io/client-go`, to generate per component changelogs.
+	Paths []string
+
+	// AuthorFilters are rules for excluding or grouping the notes of pull
+	// requests opened by bot and automation accounts. Every rule has the
+	// format `<author>[:<kind>]=<action>`, where the action is one of
+	// AuthorFilterExclude, AuthorFilterGroup or AuthorFilterInclude. Rules
+	// with a kind override the rules without one for notes of that kind.
+	AuthorFilters []string
+
 	// DiscoverMode can be used to automatically discover StartSHA and EndSHA.
 	// Can be either RevisionDiscoveryModeNONE (default),
 	// RevisionDiscoveryModeMergeBaseToLatest,
@@ -175,7 +200,15 @@ func (o *Options) ValidateAndFinish() (err error) {
 	}
 
 	if o.ReplayDir != "" && o.RecordDir != "" {
-		return errors.New("please do not use record and replay together")
+		return fmt.Errorf("%w: record and replay cannot be used together", ErrConflictingOptions)
+	}
+
+	if err := o.checkPaths(); err != nil {
+		return fmt.Errorf("while checking paths: %w", err)
+	}
+
+	if _, err := ParseAuthorFilters(o.AuthorFilters); err != nil {
+		return fmt.Errorf("while checking author filters: %w", err)
 	}
 
 	// Recover for replay if needed
@@ -190,8 +223,8 @@ func (o *Options) ValidateAndFinish() (err error) {
 		o.githubToken = token
 	} else if o.ReplayDir == "" {
 		return fmt.Errorf(
-			"neither environment variable `%s` nor `replay` option is set",
-			github.TokenEnvKey,
+			"%w: neither environment variable `%s` nor `replay` option is set",
+			ErrMissingGitHubToken, github.TokenEnvKey,
 		)
 	}
 
@@ -207,14 +240,21 @@ func (o *Options) ValidateAndFinish() (err error) {
 		}
 	}
 
+	// Check if we have to resolve the revisions from a date range
+	if !o.StartDate.IsZero() || !o.EndDate.IsZero() {
+		if err := o.resolveDates(); err != nil {
+			return fmt.Errorf("resolving date range: %w", err)
+		}
+	}
+
 	// The start SHA or rev is required.
 	if o.StartSHA == "" && o.StartRev == "" {
-		return errors.New("the starting commit hash must be set via --start-sha, $START_SHA, --start-rev or $START_REV")
+		return fmt.Errorf("%w: the starting commit hash must be set via --start-sha, $START_SHA, --start-rev, $START_REV, --start-date or $START_DATE", ErrMissingStartRev)
 	}
 
 	// The end SHA or rev is required.
 	if o.EndSHA == "" && o.EndRev == "" {
-		return errors.New("the ending commit hash must be set via --end-sha, $END_SHA, --end-rev or $END_REV")
+		return fmt.Errorf("%w: the ending commit hash must be set via --end-sha, $END_SHA, --end-rev or $END_REV", ErrMissingEndRev)
 	}
 
 	// Check if we have to parse a revision
@@ -260,13 +300,26 @@ func (o *Options) ValidateAndFinish() (err error) {
 	return nil
 }
 
+// checkPaths verifies that the paths are relative to the repository root
+// and cleans them.
+func (o *Options) checkPaths() error {
+	for i, p := range o.Paths {
+		cleaned := path.Clean(strings.TrimSpace(p))
+		if cleaned == "." || path.IsAbs(cleaned) || strings.HasPrefix(cleaned, "..") {
+			return fmt.Errorf("%w %q: has to be relative to the repository root", ErrInvalidPath, p)
+		}
+		o.Paths[i] = cleaned
+	}
+	return nil
+}
+
 // checkFormatOptions verifies that template related options are sane
 func (o *Options) checkFormatOptions() error {
 	// Validate the output format and template
 	logrus.Infof("Using output format: %s", o.Format)
 	if o.Format == FormatMarkdown && o.GoTemplate != GoTemplateDefault {
 		if !strings.HasPrefix(o.GoTemplate, GoTemplatePrefix) {
-			return fmt.Errorf("go template has to be prefixed with %q", GoTemplatePrefix)
+			return fmt.Errorf("%w: has to be prefixed with %q", ErrInvalidTemplate, GoTemplatePrefix)
 		}
 
 		templatePathOrOnline := strings.TrimPrefix(o.GoTemplate, GoTemplatePrefix)
@@ -274,18 +327,18 @@ func (o *Options) checkFormatOptions() error {
 		if !strings.HasPrefix(templatePathOrOnline, GoTemplatePrefixInline) {
 			fileStats, err := os.Stat(templatePathOrOnline)
 			if os.IsNotExist(err) {
-				return fmt.Errorf("could not find template file (%s)", templatePathOrOnline)
+				return fmt.Errorf("%w: could not find template file (%s)", ErrInvalidTemplate, templatePathOrOnline)
 			}
 			if fileStats.Size() == 0 {
-				return fmt.Errorf("template file %s is empty", templatePathOrOnline)
+				return fmt.Errorf("%w: template file %s is empty", ErrInvalidTemplate, templatePathOrOnline)
 			}
 		}
 	}
 	if o.Format == FormatJSON && o.GoTemplate != GoTemplateDefault {
-		return errors.New("go-template cannot be defined when in JSON mode")
+		return fmt.Errorf("%w: go-template cannot be defined when in JSON mode", ErrConflictingOptions)
 	}
 	if o.Format != FormatJSON && o.Format != FormatMarkdown {
-		return fmt.Errorf("invalid format: %s", o.Format)
+		return fmt.Errorf("%w: %s", ErrInvalidFormat, o.Format)
 	}
 	return nil
 }
@@ -329,6 +382,99 @@ func (o *Options) resolveDiscoverMode() error {
 	return nil
 }
 
+// ParseDate parses a date provided as YYYY-MM-DD or in RFC3339 format. Dates
+// without time are interpreted as the beginning of the day in UTC.
+func ParseDate(date string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, date); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"%w %q: neither in the format YYYY-MM-DD nor RFC3339", ErrInvalidDate, date,
+		)
+	}
+	return t, nil
+}
+
+// resolveDates sets the start and end SHA to the last commits on the branch
+// before StartDate and EndDate.
+func (o *Options) resolveDates() error {
+	if o.DiscoverMode != RevisionDiscoveryModeNONE {
+		return fmt.Errorf("%w: dates cannot be used together with a discovery mode", ErrConflictingOptions)
+	}
+	if !o.StartDate.IsZero() && (o.StartSHA != "" || o.StartRev != "") {
+		return fmt.Errorf("%w: the start date cannot be used together with a start SHA or revision", ErrConflictingOptions)
+	}
+	if !o.EndDate.IsZero() && (o.EndSHA != "" || o.EndRev != "") {
+		return fmt.Errorf("%w: the end date cannot be used together with an end SHA or revision", ErrConflictingOptions)
+	}
+	if !o.StartDate.IsZero() && !o.EndDate.IsZero() && !o.StartDate.Before(o.EndDate) {
+		return fmt.Errorf(
+			"%w: the start date %s has to be before the end date %s",
+			ErrInvalidDate, o.StartDate.Format(time.RFC3339), o.EndDate.Format(time.RFC3339),
+		)
+	}
+
+	branch := o.Branch
+	if branch == "" {
+		branch = git.DefaultBranch
+	}
+
+	repo, err := o.repo()
+	if err != nil {
+		return err
+	}
+	ref := git.Remotify(branch)
+
+	if !o.StartDate.IsZero() {
+		sha, err := lastCommitBefore(repo, ref, o.StartDate)
+		if err != nil {
+			return fmt.Errorf("resolving start date: %w", err)
+		}
+		logrus.Infof("Using found start SHA for %s: %s", o.StartDate.Format(time.RFC3339), sha)
+		o.StartSHA = sha
+	}
+
+	switch {
+	case !o.EndDate.IsZero():
+		sha, err := lastCommitBefore(repo, ref, o.EndDate)
+		if err != nil {
+			return fmt.Errorf("resolving end date: %w", err)
+		}
+		logrus.Infof("Using found end SHA for %s: %s", o.EndDate.Format(time.RFC3339), sha)
+		o.EndSHA = sha
+
+	case o.EndSHA == "" && o.EndRev == "":
+		sha, err := repo.RevParse(ref)
+		if err != nil {
+			return fmt.Errorf("resolving %s: %w", ref, err)
+		}
+		logrus.Infof("Using head of %s as end SHA: %s", ref, sha)
+		o.EndSHA = sha
+	}
+	return nil
+}
+
+// lastCommitBefore returns the last first-parent commit of ref which has
+// been committed before the provided date.
+func lastCommitBefore(repo *git.Repo, ref string, date time.Time) (string, error) {
+	res, err := command.NewWithWorkDir(
+		repo.Dir(), "git", "rev-list", "-1", "--first-parent",
+		"--before="+date.Format(time.RFC3339), ref,
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return "", fmt.Errorf("list commits of %s: %w", ref, err)
+	}
+	sha := res.OutputTrimNL()
+	if sha == "" {
+		return "", fmt.Errorf(
+			"no commit found on %s before %s", ref, date.Format(time.RFC3339),
+		)
+	}
+	return sha, nil
+}
+
 func (o *Options) repo() (repo *git.Repo, err error) {
 	if o.Pull {
 		logrus.Infof("Cloning/updating repository %s/%s", o.GithubOrg, o.GithubRepo)
@@ -265,7 +265,7 @@ func TestValidateAndFinishFailureStartShaAndRevWrong(t *testing.T) {
 	defer options.testRepo.cleanup(t)
 
 	options.StartSHA = ""
-	require.NotNil(t, options.ValidateAndFinish())
+	require.ErrorIs(t, options.ValidateAndFinish(), ErrMissingStartRev)
 }
 
 func TestValidateAndFinishFailureEndShaAndRevWrong(t *testing.T) {
@@ -273,7 +273,7 @@ func TestValidateAndFinishFailureEndShaAndRevWrong(t *testing.T) {
 	defer options.testRepo.cleanup(t)
 
 	options.EndSHA = ""
-	require.NotNil(t, options.ValidateAndFinish())
+	require.ErrorIs(t, options.ValidateAndFinish(), ErrMissingEndRev)
 }
 
 func TestValidateAndFinishFailureClone(t *testing.T) {
@@ -326,6 +326,94 @@ func TestValidateAndFinishFailureEndRevNotExisting(t *testing.T) {
 	require.NotNil(t, options.ValidateAndFinish())
 }
 
+func TestValidateAndFinishSuccessStartDate(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.Branch = options.testRepo.branchName
+	options.StartSHA = ""
+	options.StartDate = time.Now().Add(time.Hour)
+	require.Nil(t, options.ValidateAndFinish())
+	require.Equal(t, options.testRepo.secondBranchCommit, options.StartSHA)
+	require.Equal(t, "0", options.EndSHA)
+}
+
+func TestValidateAndFinishSuccessStartDateEndsAtHead(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.Branch = options.testRepo.branchName
+	options.StartSHA = ""
+	options.EndSHA = ""
+	options.StartDate = time.Now().Add(time.Hour)
+	require.Nil(t, options.ValidateAndFinish())
+	require.Equal(t, options.testRepo.secondBranchCommit, options.EndSHA)
+}
+
+func TestValidateAndFinishSuccessStartAndEndDate(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.Branch = options.testRepo.branchName
+	options.StartSHA = ""
+	options.EndSHA = ""
+	options.StartDate = time.Now().Add(time.Hour)
+	options.EndDate = time.Now().Add(2 * time.Hour)
+	require.Nil(t, options.ValidateAndFinish())
+	require.Equal(t, options.testRepo.secondBranchCommit, options.StartSHA)
+	require.Equal(t, options.testRepo.secondBranchCommit, options.EndSHA)
+}
+
+func TestValidateAndFinishFailureStartDateWithoutCommits(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.Branch = options.testRepo.branchName
+	options.StartSHA = ""
+	options.StartDate = time.Now().Add(-24 * time.Hour)
+	require.NotNil(t, options.ValidateAndFinish())
+}
+
+func TestValidateAndFinishFailureStartDateAndSHA(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.StartDate = time.Now()
+	require.ErrorIs(t, options.ValidateAndFinish(), ErrConflictingOptions)
+}
+
+func TestValidateAndFinishFailureEndDateBeforeStartDate(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.StartSHA = ""
+	options.EndSHA = ""
+	options.StartDate = time.Now()
+	options.EndDate = time.Now().Add(-time.Hour)
+	require.ErrorIs(t, options.ValidateAndFinish(), ErrInvalidDate)
+}
+
+func TestParseDate(t *testing.T) {
+	for _, tc := range []struct {
+		date        string
+		expected    time.Time
+		shouldError bool
+	}{
+		{"2024-05-14", time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC), false},
+		{"2024-05-14T10:30:00Z", time.Date(2024, 5, 14, 10, 30, 0, 0, time.UTC), false},
+		{"14.05.2024", time.Time{}, true},
+		{"", time.Time{}, true},
+	} {
+		res, err := ParseDate(tc.date)
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+			require.True(t, tc.expected.Equal(res))
+		}
+	}
+}
+
 func TestValidateAndFinishSuccessDiscoveryModeMergeBaseToLatest(t *testing.T) {
 	options := newTestOptions(t)
 	defer options.testRepo.cleanup(t)
@@ -389,7 +477,7 @@ func TestValidateAndFinishFailureFormat(t *testing.T) {
 	options.Format = "wrong"
 
 	// When
-	require.NotNil(t, options.ValidateAndFinish())
+	require.ErrorIs(t, options.ValidateAndFinish(), ErrInvalidFormat)
 }
 
 func TestValidateAndFinishFailureGoTemplate(t *testing.T) {
@@ -400,5 +488,30 @@ func TestValidateAndFinishFailureGoTemplate(t *testing.T) {
 	options.GoTemplate = "wrong"
 
 	// When
-	require.NotNil(t, options.ValidateAndFinish())
+	require.ErrorIs(t, options.ValidateAndFinish(), ErrInvalidTemplate)
+}
+
+func TestValidateAndFinishSuccessPaths(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	// Given
+	options.Paths = []string{"staging/src/k8s.io/client-go/", " pkg/kubelet"}
+
+	// When
+	require.Nil(t, options.ValidateAndFinish())
+	require.Equal(t, []string{"staging/src/k8s.io/client-go", "pkg/kubelet"}, options.Paths)
+}
+
+func TestValidateAndFinishFailurePaths(t *testing.T) {
+	for _, p := range []string{"", ".", "/pkg", "../pkg"} {
+		options := newTestOptions(t)
+
+		// Given
+		options.Paths = []string{p}
+
+		// When
+		require.ErrorIs(t, options.ValidateAndFinish(), ErrInvalidPath, p)
+		options.testRepo.cleanup(t)
+	}
 }
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// sanitizeContentElements are removed together with their content.
+	sanitizeContentElements = map[atom.Atom]bool{
+		atom.Frameset: true,
+		atom.Iframe:   true,
+		atom.Noscript: true,
+		atom.Object:   true,
+		atom.Script:   true,
+		atom.Style:    true,
+		atom.Template: true,
+	}
+
+	// sanitizeTagElements are removed while their content is kept.
+	sanitizeTagElements = map[atom.Atom]bool{
+		atom.Base:  true,
+		atom.Embed: true,
+		atom.Frame: true,
+		atom.Link:  true,
+		atom.Meta:  true,
+	}
+
+	// sanitizeURLAttributes are the attributes which contain links.
+	sanitizeURLAttributes = map[string]bool{
+		"action":     true,
+		"background": true,
+		"cite":       true,
+		"data":       true,
+		"formaction": true,
+		"href":       true,
+		"poster":     true,
+		"src":        true,
+		"xlink:href": true,
+	}
+
+	// dangerousSchemes are the URL schemes which are able to execute code
+	// in the browser.
+	dangerousSchemes = []string{"javascript:", "vbscript:", "data:"}
+
+	// markdownLinkRegex matches the destination of inline markdown links
+	// and link reference definitions.
+	markdownLinkRegex = regexp.MustCompile(
+		`(?m)(\]\(\s*<?|^\s{0,3}\[[^\]]+\]:\s*<?)\s*([^\s)>]+)`,
+	)
+)
+
+// SanitizeText removes HTML and markdown constructs from user authored
+// release note text, which would allow injecting code when rendering the
+// notes to web formats like relnotes.k8s.io or the feeds. Script and style
+// elements are removed together with their content, while event handler
+// attributes and links using the javascript, vbscript or data schemes are
+// removed. Script and style tags without closing tag, for example written
+// inside backticks, get escaped instead. Everything else is kept as written.
+func SanitizeText(text string) string {
+	if !strings.ContainsAny(text, "<]") {
+		return text
+	}
+
+	res := &strings.Builder{}
+	tokenizer := html.NewTokenizer(strings.NewReader(text))
+	skip := atom.Atom(0)
+
+	// pos is the offset after the current token, while skipRaw is the raw
+	// start tag of the skipped element and skipEnd the offset after it.
+	pos, skipEnd := 0, 0
+	skipRaw := ""
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			// The only possible error is io.EOF, because we read from a
+			// string.
+			break
+		}
+
+		raw := string(tokenizer.Raw())
+		token := tokenizer.Token()
+		pos += len(raw)
+
+		if skip != 0 {
+			if tokenType == html.EndTagToken && token.DataAtom == skip {
+				skip = 0
+			}
+			continue
+		}
+
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if sanitizeContentElements[token.DataAtom] {
+				if tokenType == html.StartTagToken {
+					skip = token.DataAtom
+					skipRaw, skipEnd = raw, pos
+				}
+				continue
+			}
+			if sanitizeTagElements[token.DataAtom] || isDangerousURL(token.Data) {
+				continue
+			}
+			if sanitizeAttributes(&token) {
+				raw = token.String()
+			}
+
+		case html.EndTagToken:
+			if sanitizeContentElements[token.DataAtom] ||
+				sanitizeTagElements[token.DataAtom] {
+				continue
+			}
+
+		case html.TextToken:
+			raw = markdownLinkRegex.ReplaceAllStringFunc(raw, sanitizeMarkdownLink)
+
+		default:
+		}
+
+		res.WriteString(raw)
+	}
+
+	if skip != 0 {
+		// The element is never closed, which would drop all following text.
+		res.WriteString(html.EscapeString(skipRaw))
+		res.WriteString(SanitizeText(text[skipEnd:]))
+	}
+
+	return res.String()
+}
+
+// sanitizeAttributes removes all event handler, inline style and dangerous
+// link attributes from the token. It returns true if the token got modified.
+func sanitizeAttributes(token *html.Token) (modified bool) {
+	attrs := []html.Attribute{}
+	for _, attr := range token.Attr {
+		key := strings.ToLower(attr.Key)
+		if strings.HasPrefix(key, "on") ||
+			key == "style" ||
+			key == "srcdoc" ||
+			(sanitizeURLAttributes[key] && isDangerousURL(attr.Val)) {
+			modified = true
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	token.Attr = attrs
+	return modified
+}
+
+// sanitizeMarkdownLink replaces the scheme of a markdown link destination
+// using one of the dangerousSchemes with "#".
+func sanitizeMarkdownLink(match string) string {
+	parts := markdownLinkRegex.FindStringSubmatch(match)
+	if !isDangerousURL(parts[2]) {
+		return match
+	}
+	dest := unescapeURL(parts[2])
+	return parts[1] + "#" + html.EscapeString(dest[strings.Index(dest, ":")+1:])
+}
+
+// unescapeURL decodes the HTML entities and markdown backslash escapes of
+// the URL, which CommonMark decodes as well before rendering the link.
+func unescapeURL(u string) string {
+	return html.UnescapeString(strings.ReplaceAll(u, `\`, ""))
+}
+
+// isDangerousURL returns true if the provided URL uses one of the
+// dangerousSchemes. HTML entities, markdown backslash escapes, whitespace
+// and control characters are ignored like browsers and CommonMark do.
+func isDangerousURL(u string) bool {
+	u = strings.ToLower(strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, unescapeURL(u)))
+
+	for _, scheme := range dangerousSchemes {
+		if strings.HasPrefix(u, scheme) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeText(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name, input, expected string
+	}{
+		{
+			name:     "plain text",
+			input:    "Fixed a bug in `kubectl` where `a < b` and Vec<String> broke",
+			expected: "Fixed a bug in `kubectl` where `a < b` and Vec<String> broke",
+		},
+		{
+			name:     "harmless html",
+			input:    `Added <b>bold</b> and <a href="https://k8s.io">link</a> &amp; more`,
+			expected: `Added <b>bold</b> and <a href="https://k8s.io">link</a> &amp; more`,
+		},
+		{
+			name:     "script element",
+			input:    "Before <script>alert('x')</script>after",
+			expected: "Before after",
+		},
+		{
+			name:     "style element",
+			input:    "Before <STYLE>body { display: none }</STYLE>after",
+			expected: "Before after",
+		},
+		{
+			name:     "unclosed script element",
+			input:    "Before <script>alert('x')",
+			expected: "Before &lt;script&gt;alert('x')",
+		},
+		{
+			name:     "unclosed script element in backticks",
+			input:    "Removed `<script>` from the <b>docs</b> and <style>a</style>fixed <iframe src=\"x\">",
+			expected: "Removed `&lt;script&gt;` from the <b>docs</b> and fixed &lt;iframe src=&#34;x&#34;&gt;",
+		},
+		{
+			name:     "iframe and embed",
+			input:    `<iframe src="https://evil.com"></iframe>a<embed src="x.swf">b`,
+			expected: "ab",
+		},
+		{
+			name:     "event handler attributes",
+			input:    `<img src="x.png" onerror="alert(1)" alt="x">`,
+			expected: `<img src="x.png" alt="x">`,
+		},
+		{
+			name:     "javascript link",
+			input:    `<a href="javascript:alert(1)">click</a>`,
+			expected: `<a>click</a>`,
+		},
+		{
+			name:     "obfuscated javascript link",
+			input:    `<a href=" java&#x09;script:alert(1)">click</a>`,
+			expected: `<a>click</a>`,
+		},
+		{
+			name:     "data link",
+			input:    `<a HREF="data:text/html;base64,PHNjcmlwdD4=">click</a>`,
+			expected: `<a>click</a>`,
+		},
+		{
+			name:     "markdown javascript link",
+			input:    "See [docs](javascript:alert(1)) and [k8s](https://k8s.io)",
+			expected: "See [docs](#alert(1)) and [k8s](https://k8s.io)",
+		},
+		{
+			name:     "markdown reference link",
+			input:    "See [docs][1]\n\n[1]: JavaScript:alert(1)",
+			expected: "See [docs][1]\n\n[1]: #alert(1)",
+		},
+		{
+			name:     "markdown entity encoded javascript link",
+			input:    "See [docs](javascript&#58;alert(1)) and [x](jav&#x61;script&colon;alert(2))",
+			expected: "See [docs](#alert(1)) and [x](#alert(2))",
+		},
+		{
+			name:     "markdown escaped javascript link",
+			input:    `See [docs](javascript\:alert(1))`,
+			expected: "See [docs](#alert(1))",
+		},
+		{
+			name:     "markdown entity encoded markup in a javascript link",
+			input:    "See [docs](javascript&#58;&lt;b&gt;)",
+			expected: "See [docs](#&lt;b&gt;)",
+		},
+		{
+			name:     "markdown entity encoded autolink",
+			input:    "See <javascript&#58;alert(1)> now",
+			expected: "See  now",
+		},
+		{
+			name:     "markdown autolink",
+			input:    "See <javascript:alert(1)> now",
+			expected: "See  now",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expected, SanitizeText(tc.input))
+		})
+	}
+}
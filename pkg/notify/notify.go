@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Message is the payload sent to a webhook. Its format is compatible with
+// Slack incoming webhooks, which most chat services accept as well.
+type Message struct {
+	// Text is the main content of the notification.
+	Text string `json:"text"`
+}
+
+// Client is the main structure for sending webhook notifications.
+type Client struct {
+	impl impl
+}
+
+// New creates a new notification Client.
+func New() *Client {
+	return &Client{impl: &defaultImpl{}}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (c *Client) SetImpl(impl impl) {
+	c.impl = impl
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt notifyfakes/fake_impl.go > notifyfakes/_fake_impl.go && mv notifyfakes/_fake_impl.go notifyfakes/fake_impl.go"
+type impl interface {
+	PostJSON(url string, body []byte) (*http.Response, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) PostJSON(url string, body []byte) (*http.Response, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	return client.Post(url, "application/json", bytes.NewReader(body))
+}
+
+// Send posts the message to the provided webhook URL.
+func (c *Client) Send(url string, msg *Message) error {
+	if url == "" {
+		return fmt.Errorf("no webhook URL provided")
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal message: %w", err)
+	}
+
+	res, err := c.impl.PostJSON(url, body)
+	if err != nil {
+		return fmt.Errorf("post message: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		resBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			return fmt.Errorf("read response body: %w", err)
+		}
+		return fmt.Errorf(
+			"webhook returned HTTP status %d: %s", res.StatusCode, resBody,
+		)
+	}
+
+	logrus.Debug("Webhook notification sent")
+	return nil
+}
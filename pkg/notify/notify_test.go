@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notify/notifyfakes"
+)
+
+func response(code int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: code,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		url     string
+		prepare func(*notifyfakes.FakeImpl)
+		assert  func(*notifyfakes.FakeImpl, error)
+	}{
+		{ // success
+			url: "https://hooks.example.com/test",
+			prepare: func(mock *notifyfakes.FakeImpl) {
+				mock.PostJSONReturns(response(http.StatusOK, "ok"), nil)
+			},
+			assert: func(mock *notifyfakes.FakeImpl, err error) {
+				require.Nil(t, err)
+				require.Equal(t, 1, mock.PostJSONCallCount())
+				url, body := mock.PostJSONArgsForCall(0)
+				require.Equal(t, "https://hooks.example.com/test", url)
+				require.JSONEq(t, `{"text":"hello"}`, string(body))
+			},
+		},
+		{ // failure no URL
+			prepare: func(*notifyfakes.FakeImpl) {},
+			assert: func(mock *notifyfakes.FakeImpl, err error) {
+				require.NotNil(t, err)
+				require.Zero(t, mock.PostJSONCallCount())
+			},
+		},
+		{ // failure on PostJSON
+			url: "https://hooks.example.com/test",
+			prepare: func(mock *notifyfakes.FakeImpl) {
+				mock.PostJSONReturns(nil, errors.New("test"))
+			},
+			assert: func(_ *notifyfakes.FakeImpl, err error) {
+				require.NotNil(t, err)
+			},
+		},
+		{ // failure on HTTP status
+			url: "https://hooks.example.com/test",
+			prepare: func(mock *notifyfakes.FakeImpl) {
+				mock.PostJSONReturns(response(http.StatusNotFound, "no_service"), nil)
+			},
+			assert: func(_ *notifyfakes.FakeImpl, err error) {
+				require.NotNil(t, err)
+				require.Contains(t, err.Error(), "no_service")
+			},
+		},
+	} {
+		mock := &notifyfakes.FakeImpl{}
+		tc.prepare(mock)
+
+		sut := New()
+		sut.SetImpl(mock)
+
+		err := sut.Send(tc.url, &Message{Text: "hello"})
+		tc.assert(mock, err)
+	}
+}
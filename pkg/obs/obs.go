@@ -17,6 +17,7 @@ limitations under the License.
 package obs
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -40,6 +41,7 @@ import (
 //go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt obsfakes/fake_stage_impl.go > obsfakes/_fake_stage_impl.go && mv obsfakes/_fake_stage_impl.go obsfakes/fake_stage_impl.go"
 //go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt obsfakes/fake_release_client.go > obsfakes/_fake_release_client.go && mv obsfakes/_fake_release_client.go obsfakes/fake_release_client.go"
 //go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt obsfakes/fake_release_impl.go > obsfakes/_fake_release_impl.go && mv obsfakes/_fake_release_impl.go obsfakes/fake_release_impl.go"
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt obsfakes/fake_watch_impl.go > obsfakes/_fake_watch_impl.go && mv obsfakes/_fake_watch_impl.go obsfakes/fake_watch_impl.go"
 const (
 	// OBSKubernetesProject is name of the organization/project on openSUSE's
 	// OBS instance where packages are built and published.
@@ -381,8 +383,14 @@ func (s *Stage) SetClient(client stageClient) {
 
 // Submit can be used to submit a staging Google Cloud Build (GCB) job.
 func (s *Stage) Submit(stream bool) error {
+	return s.SubmitContext(context.Background(), stream)
+}
+
+// SubmitContext is like Submit but stops watching the job if the provided
+// context gets cancelled.
+func (s *Stage) SubmitContext(ctx context.Context, stream bool) error {
 	logrus.Info("Submitting OBS stage GCB job")
-	if err := s.client.Submit(stream); err != nil {
+	if err := s.client.Submit(ctx, stream); err != nil {
 		return fmt.Errorf("submit obs stage job: %w", err)
 	}
 	return nil
@@ -522,8 +530,14 @@ func (r *Release) SetClient(client releaseClient) {
 
 // Submit can be used to submit a releasing Google Cloud Build (GCB) job.
 func (r *Release) Submit(stream bool) error {
+	return r.SubmitContext(context.Background(), stream)
+}
+
+// SubmitContext is like Submit but stops watching the job if the provided
+// context gets cancelled.
+func (r *Release) SubmitContext(ctx context.Context, stream bool) error {
 	logrus.Info("Submitting release GCB job")
-	if err := r.client.Submit(stream); err != nil {
+	if err := r.client.Submit(ctx, stream); err != nil {
 		return fmt.Errorf("submit release job: %w", err)
 	}
 	return nil
@@ -18,6 +18,7 @@ limitations under the License.
 package obsfakes
 
 import (
+	"context"
 	"sync"
 )
 
@@ -96,10 +97,11 @@ type FakeReleaseClient struct {
 	releasePackagesReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SubmitStub        func(bool) error
+	SubmitStub        func(context.Context, bool) error
 	submitMutex       sync.RWMutex
 	submitArgsForCall []struct {
-		arg1 bool
+		arg1 context.Context
+		arg2 bool
 	}
 	submitReturns struct {
 		result1 error
@@ -516,18 +518,19 @@ func (fake *FakeReleaseClient) ReleasePackagesReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
-func (fake *FakeReleaseClient) Submit(arg1 bool) error {
+func (fake *FakeReleaseClient) Submit(arg1 context.Context, arg2 bool) error {
 	fake.submitMutex.Lock()
 	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
 	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
-		arg1 bool
-	}{arg1})
+		arg1 context.Context
+		arg2 bool
+	}{arg1, arg2})
 	stub := fake.SubmitStub
 	fakeReturns := fake.submitReturns
-	fake.recordInvocation("Submit", []interface{}{arg1})
+	fake.recordInvocation("Submit", []interface{}{arg1, arg2})
 	fake.submitMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -541,17 +544,17 @@ func (fake *FakeReleaseClient) SubmitCallCount() int {
 	return len(fake.submitArgsForCall)
 }
 
-func (fake *FakeReleaseClient) SubmitCalls(stub func(bool) error) {
+func (fake *FakeReleaseClient) SubmitCalls(stub func(context.Context, bool) error) {
 	fake.submitMutex.Lock()
 	defer fake.submitMutex.Unlock()
 	fake.SubmitStub = stub
 }
 
-func (fake *FakeReleaseClient) SubmitArgsForCall(i int) bool {
+func (fake *FakeReleaseClient) SubmitArgsForCall(i int) (context.Context, bool) {
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	argsForCall := fake.submitArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeReleaseClient) SubmitReturns(result1 error) {
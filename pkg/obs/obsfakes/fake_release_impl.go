@@ -18,6 +18,7 @@ limitations under the License.
 package obsfakes
 
 import (
+	"context"
 	"sync"
 
 	semver "github.com/blang/semver/v4"
@@ -116,10 +117,11 @@ type FakeReleaseImpl struct {
 	releasePackageReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SubmitStub        func(*gcb.Options) error
+	SubmitStub        func(context.Context, *gcb.Options) error
 	submitMutex       sync.RWMutex
 	submitArgsForCall []struct {
-		arg1 *gcb.Options
+		arg1 context.Context
+		arg2 *gcb.Options
 	}
 	submitReturns struct {
 		result1 error
@@ -573,18 +575,19 @@ func (fake *FakeReleaseImpl) ReleasePackageReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeReleaseImpl) Submit(arg1 *gcb.Options) error {
+func (fake *FakeReleaseImpl) Submit(arg1 context.Context, arg2 *gcb.Options) error {
 	fake.submitMutex.Lock()
 	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
 	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
-		arg1 *gcb.Options
-	}{arg1})
+		arg1 context.Context
+		arg2 *gcb.Options
+	}{arg1, arg2})
 	stub := fake.SubmitStub
 	fakeReturns := fake.submitReturns
-	fake.recordInvocation("Submit", []interface{}{arg1})
+	fake.recordInvocation("Submit", []interface{}{arg1, arg2})
 	fake.submitMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -598,17 +601,17 @@ func (fake *FakeReleaseImpl) SubmitCallCount() int {
 	return len(fake.submitArgsForCall)
 }
 
-func (fake *FakeReleaseImpl) SubmitCalls(stub func(*gcb.Options) error) {
+func (fake *FakeReleaseImpl) SubmitCalls(stub func(context.Context, *gcb.Options) error) {
 	fake.submitMutex.Lock()
 	defer fake.submitMutex.Unlock()
 	fake.SubmitStub = stub
 }
 
-func (fake *FakeReleaseImpl) SubmitArgsForCall(i int) *gcb.Options {
+func (fake *FakeReleaseImpl) SubmitArgsForCall(i int) (context.Context, *gcb.Options) {
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	argsForCall := fake.submitArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeReleaseImpl) SubmitReturns(result1 error) {
@@ -18,6 +18,7 @@ limitations under the License.
 package obsfakes
 
 import (
+	"context"
 	"sync"
 
 	semver "github.com/blang/semver/v4"
@@ -153,10 +154,11 @@ type FakeStageImpl struct {
 	removePackageFilesReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SubmitStub        func(*gcb.Options) error
+	SubmitStub        func(context.Context, *gcb.Options) error
 	submitMutex       sync.RWMutex
 	submitArgsForCall []struct {
-		arg1 *gcb.Options
+		arg1 context.Context
+		arg2 *gcb.Options
 	}
 	submitReturns struct {
 		result1 error
@@ -808,18 +810,19 @@ func (fake *FakeStageImpl) RemovePackageFilesReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeStageImpl) Submit(arg1 *gcb.Options) error {
+func (fake *FakeStageImpl) Submit(arg1 context.Context, arg2 *gcb.Options) error {
 	fake.submitMutex.Lock()
 	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
 	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
-		arg1 *gcb.Options
-	}{arg1})
+		arg1 context.Context
+		arg2 *gcb.Options
+	}{arg1, arg2})
 	stub := fake.SubmitStub
 	fakeReturns := fake.submitReturns
-	fake.recordInvocation("Submit", []interface{}{arg1})
+	fake.recordInvocation("Submit", []interface{}{arg1, arg2})
 	fake.submitMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -833,17 +836,17 @@ func (fake *FakeStageImpl) SubmitCallCount() int {
 	return len(fake.submitArgsForCall)
 }
 
-func (fake *FakeStageImpl) SubmitCalls(stub func(*gcb.Options) error) {
+func (fake *FakeStageImpl) SubmitCalls(stub func(context.Context, *gcb.Options) error) {
 	fake.submitMutex.Lock()
 	defer fake.submitMutex.Unlock()
 	fake.SubmitStub = stub
 }
 
-func (fake *FakeStageImpl) SubmitArgsForCall(i int) *gcb.Options {
+func (fake *FakeStageImpl) SubmitArgsForCall(i int) (context.Context, *gcb.Options) {
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	argsForCall := fake.submitArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeStageImpl) SubmitReturns(result1 error) {
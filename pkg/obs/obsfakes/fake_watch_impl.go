@@ -0,0 +1,287 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package obsfakes
+
+import (
+	"sync"
+
+	"k8s.io/release/pkg/obs"
+)
+
+type FakeWatchImpl struct {
+	GetURLResponseStub        func(string) (string, error)
+	getURLResponseMutex       sync.RWMutex
+	getURLResponseArgsForCall []struct {
+		arg1 string
+	}
+	getURLResponseReturns struct {
+		result1 string
+		result2 error
+	}
+	getURLResponseReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ReleaseTagsStub        func(string, string) ([]string, error)
+	releaseTagsMutex       sync.RWMutex
+	releaseTagsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	releaseTagsReturns struct {
+		result1 []string
+		result2 error
+	}
+	releaseTagsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	SubmitStageStub        func(*obs.StageOptions, bool) error
+	submitStageMutex       sync.RWMutex
+	submitStageArgsForCall []struct {
+		arg1 *obs.StageOptions
+		arg2 bool
+	}
+	submitStageReturns struct {
+		result1 error
+	}
+	submitStageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWatchImpl) GetURLResponse(arg1 string) (string, error) {
+	fake.getURLResponseMutex.Lock()
+	ret, specificReturn := fake.getURLResponseReturnsOnCall[len(fake.getURLResponseArgsForCall)]
+	fake.getURLResponseArgsForCall = append(fake.getURLResponseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetURLResponseStub
+	fakeReturns := fake.getURLResponseReturns
+	fake.recordInvocation("GetURLResponse", []interface{}{arg1})
+	fake.getURLResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWatchImpl) GetURLResponseCallCount() int {
+	fake.getURLResponseMutex.RLock()
+	defer fake.getURLResponseMutex.RUnlock()
+	return len(fake.getURLResponseArgsForCall)
+}
+
+func (fake *FakeWatchImpl) GetURLResponseCalls(stub func(string) (string, error)) {
+	fake.getURLResponseMutex.Lock()
+	defer fake.getURLResponseMutex.Unlock()
+	fake.GetURLResponseStub = stub
+}
+
+func (fake *FakeWatchImpl) GetURLResponseArgsForCall(i int) string {
+	fake.getURLResponseMutex.RLock()
+	defer fake.getURLResponseMutex.RUnlock()
+	argsForCall := fake.getURLResponseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWatchImpl) GetURLResponseReturns(result1 string, result2 error) {
+	fake.getURLResponseMutex.Lock()
+	defer fake.getURLResponseMutex.Unlock()
+	fake.GetURLResponseStub = nil
+	fake.getURLResponseReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWatchImpl) GetURLResponseReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getURLResponseMutex.Lock()
+	defer fake.getURLResponseMutex.Unlock()
+	fake.GetURLResponseStub = nil
+	if fake.getURLResponseReturnsOnCall == nil {
+		fake.getURLResponseReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getURLResponseReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWatchImpl) ReleaseTags(arg1 string, arg2 string) ([]string, error) {
+	fake.releaseTagsMutex.Lock()
+	ret, specificReturn := fake.releaseTagsReturnsOnCall[len(fake.releaseTagsArgsForCall)]
+	fake.releaseTagsArgsForCall = append(fake.releaseTagsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ReleaseTagsStub
+	fakeReturns := fake.releaseTagsReturns
+	fake.recordInvocation("ReleaseTags", []interface{}{arg1, arg2})
+	fake.releaseTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWatchImpl) ReleaseTagsCallCount() int {
+	fake.releaseTagsMutex.RLock()
+	defer fake.releaseTagsMutex.RUnlock()
+	return len(fake.releaseTagsArgsForCall)
+}
+
+func (fake *FakeWatchImpl) ReleaseTagsCalls(stub func(string, string) ([]string, error)) {
+	fake.releaseTagsMutex.Lock()
+	defer fake.releaseTagsMutex.Unlock()
+	fake.ReleaseTagsStub = stub
+}
+
+func (fake *FakeWatchImpl) ReleaseTagsArgsForCall(i int) (string, string) {
+	fake.releaseTagsMutex.RLock()
+	defer fake.releaseTagsMutex.RUnlock()
+	argsForCall := fake.releaseTagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWatchImpl) ReleaseTagsReturns(result1 []string, result2 error) {
+	fake.releaseTagsMutex.Lock()
+	defer fake.releaseTagsMutex.Unlock()
+	fake.ReleaseTagsStub = nil
+	fake.releaseTagsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWatchImpl) ReleaseTagsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.releaseTagsMutex.Lock()
+	defer fake.releaseTagsMutex.Unlock()
+	fake.ReleaseTagsStub = nil
+	if fake.releaseTagsReturnsOnCall == nil {
+		fake.releaseTagsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.releaseTagsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWatchImpl) SubmitStage(arg1 *obs.StageOptions, arg2 bool) error {
+	fake.submitStageMutex.Lock()
+	ret, specificReturn := fake.submitStageReturnsOnCall[len(fake.submitStageArgsForCall)]
+	fake.submitStageArgsForCall = append(fake.submitStageArgsForCall, struct {
+		arg1 *obs.StageOptions
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.SubmitStageStub
+	fakeReturns := fake.submitStageReturns
+	fake.recordInvocation("SubmitStage", []interface{}{arg1, arg2})
+	fake.submitStageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWatchImpl) SubmitStageCallCount() int {
+	fake.submitStageMutex.RLock()
+	defer fake.submitStageMutex.RUnlock()
+	return len(fake.submitStageArgsForCall)
+}
+
+func (fake *FakeWatchImpl) SubmitStageCalls(stub func(*obs.StageOptions, bool) error) {
+	fake.submitStageMutex.Lock()
+	defer fake.submitStageMutex.Unlock()
+	fake.SubmitStageStub = stub
+}
+
+func (fake *FakeWatchImpl) SubmitStageArgsForCall(i int) (*obs.StageOptions, bool) {
+	fake.submitStageMutex.RLock()
+	defer fake.submitStageMutex.RUnlock()
+	argsForCall := fake.submitStageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWatchImpl) SubmitStageReturns(result1 error) {
+	fake.submitStageMutex.Lock()
+	defer fake.submitStageMutex.Unlock()
+	fake.SubmitStageStub = nil
+	fake.submitStageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWatchImpl) SubmitStageReturnsOnCall(i int, result1 error) {
+	fake.submitStageMutex.Lock()
+	defer fake.submitStageMutex.Unlock()
+	fake.SubmitStageStub = nil
+	if fake.submitStageReturnsOnCall == nil {
+		fake.submitStageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.submitStageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWatchImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getURLResponseMutex.RLock()
+	defer fake.getURLResponseMutex.RUnlock()
+	fake.releaseTagsMutex.RLock()
+	defer fake.releaseTagsMutex.RUnlock()
+	fake.submitStageMutex.RLock()
+	defer fake.submitStageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWatchImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
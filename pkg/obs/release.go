@@ -17,6 +17,7 @@ limitations under the License.
 package obs
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -34,8 +35,9 @@ import (
 //
 //counterfeiter:generate . releaseClient
 type releaseClient interface {
-	// Submit can be used to submit a Google Cloud Build (GCB) job.
-	Submit(stream bool) error
+	// Submit can be used to submit a Google Cloud Build (GCB) job, which
+	// stops being watched once ctx gets cancelled.
+	Submit(ctx context.Context, stream bool) error
 
 	// InitState initializes the default internal state.
 	InitState()
@@ -103,7 +105,7 @@ type defaultReleaseImpl struct{}
 //
 //counterfeiter:generate . releaseImpl
 type releaseImpl interface {
-	Submit(options *gcb.Options) error
+	Submit(ctx context.Context, options *gcb.Options) error
 	CheckPrerequisites(workspaceDir string) error
 	MkdirAll(path string) error
 	GenerateReleaseVersion(
@@ -117,8 +119,10 @@ type releaseImpl interface {
 	ReleasePackage(workspaceDir, project, packageName string) error
 }
 
-func (d *defaultReleaseImpl) Submit(options *gcb.Options) error {
-	return gcb.New(options).Submit()
+func (d *defaultReleaseImpl) Submit(ctx context.Context, options *gcb.Options) error {
+	g := gcb.New(options)
+	g.SetContext(ctx)
+	return g.Submit()
 }
 
 func (d *defaultReleaseImpl) CheckPrerequisites(workspaceDir string) error {
@@ -162,7 +166,7 @@ func (d *defaultReleaseImpl) ReleasePackage(workspaceDir, project, packageName s
 	return osc.OSC(filepath.Join(workspaceDir, obsRoot, project, packageName), "release")
 }
 
-func (d *DefaultRelease) Submit(stream bool) error {
+func (d *DefaultRelease) Submit(ctx context.Context, stream bool) error {
 	options := gcb.NewDefaultOptions()
 
 	options.Stream = stream
@@ -173,7 +177,7 @@ func (d *DefaultRelease) Submit(stream bool) error {
 	options.Packages = d.options.Packages
 	options.OBSProject = d.options.Project
 
-	return d.impl.Submit(options)
+	return d.impl.Submit(ctx, options)
 }
 
 func (d *DefaultRelease) InitState() {
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/notes"
+)
+
+const (
+	// changelogAuthor is the author of the generated changelog entries,
+	// which matches the packager of the spec templates.
+	changelogAuthor = "Kubernetes Authors <dev@kubernetes.io>"
+
+	// maxChangelogChanges is the maximum number of release notes in a
+	// single changelog entry.
+	maxChangelogChanges = 50
+)
+
+// RPMChangelog returns the %changelog entry of the package version. The
+// Debian changelog gets derived from it by debbuild.
+func (p *PackageDefinition) RPMChangelog() string {
+	date := p.ChangelogDate
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+
+	changes := p.Changes
+	if len(changes) == 0 {
+		changes = []string{"Update to version " + p.Version}
+	}
+
+	entry := &strings.Builder{}
+	fmt.Fprintf(entry, "* %s %s - %s-%s\n",
+		date.Format("Mon Jan 02 2006"), changelogAuthor, p.RPMVersion(), p.Revision,
+	)
+	for _, change := range changes {
+		// Macros would get expanded by rpmbuild
+		fmt.Fprintf(entry, "- %s\n", strings.ReplaceAll(change, "%", "%%"))
+	}
+	return strings.TrimSuffix(entry.String(), "\n")
+}
+
+// GetChanges returns the changelog entries for the package version from the
+// JSON release notes at source, which can be a local path or https:// URL.
+// Remote release notes which are not available (yet) result in no changes.
+func (s *Specs) GetChanges(source, version string) ([]string, error) {
+	if source == "" {
+		return nil, nil
+	}
+
+	content, err := s.readReleaseNotes(source)
+	if err != nil {
+		if strings.HasPrefix(source, "https://") {
+			logrus.Warnf("Unable to get release notes, using a generic changelog entry: %v", err)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading release notes: %w", err)
+	}
+
+	releaseNotes := notes.ReleaseNotesByPR{}
+	if err := json.Unmarshal(content, &releaseNotes); err != nil {
+		return nil, fmt.Errorf("unmarshal release notes %s: %w", source, err)
+	}
+
+	prs := []int{}
+	for pr, note := range releaseNotes {
+		if note != nil && !note.DoNotPublish && strings.TrimSpace(note.Text) != "" {
+			prs = append(prs, pr)
+		}
+	}
+	sort.Ints(prs)
+
+	changes := []string{"Update to version " + version}
+	for i, pr := range prs {
+		if i == maxChangelogChanges {
+			changes = append(changes, fmt.Sprintf("And %d more changes", len(prs)-maxChangelogChanges))
+			break
+		}
+		text, _, _ := strings.Cut(strings.TrimSpace(releaseNotes[pr].Text), "\n")
+		changes = append(changes, fmt.Sprintf("%s (#%d)", strings.TrimSpace(text), pr))
+	}
+	return changes, nil
+}
+
+func (s *Specs) readReleaseNotes(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "https://") {
+		return s.impl.ReadFile(source)
+	}
+
+	resp, err := s.impl.GetRequest(source)
+	if err != nil {
+		return nil, fmt.Errorf("getting %s: %w", source, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("getting %s: unexpected status %s", source, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
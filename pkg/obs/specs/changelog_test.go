@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specs_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/obs/specs"
+	"k8s.io/release/pkg/obs/specs/specsfakes"
+)
+
+const releaseNotes = `{
+  "2": {"text": "Fixed the kubelet %s handling.\nMore details.", "pr_number": 2},
+  "1": {"text": "Added a new kubectl command.", "pr_number": 1},
+  "3": {"text": "NONE", "pr_number": 3, "do_not_publish": true}
+}`
+
+func TestGetChanges(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		source          string
+		prepare         func(*specsfakes.FakeImpl)
+		expectedChanges []string
+		shouldErr       bool
+	}{
+		{
+			name: "no release notes",
+		},
+		{
+			name:   "local release notes",
+			source: "release-notes.json",
+			prepare: func(mock *specsfakes.FakeImpl) {
+				mock.ReadFileReturns([]byte(releaseNotes), nil)
+			},
+			expectedChanges: []string{
+				"Update to version 1.30.0",
+				"Added a new kubectl command. (#1)",
+				"Fixed the kubelet %s handling. (#2)",
+			},
+		},
+		{
+			name:   "local release notes do not exist",
+			source: "release-notes.json",
+			prepare: func(mock *specsfakes.FakeImpl) {
+				mock.ReadFileReturns(nil, errors.New(""))
+			},
+			shouldErr: true,
+		},
+		{
+			name:   "invalid release notes",
+			source: "release-notes.json",
+			prepare: func(mock *specsfakes.FakeImpl) {
+				mock.ReadFileReturns([]byte("invalid"), nil)
+			},
+			shouldErr: true,
+		},
+		{
+			name:   "remote release notes",
+			source: "https://dl.k8s.io/release/v1.30.0/release-notes.json",
+			prepare: func(mock *specsfakes.FakeImpl) {
+				mock.GetRequestReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(releaseNotes)),
+				}, nil)
+			},
+			expectedChanges: []string{
+				"Update to version 1.30.0",
+				"Added a new kubectl command. (#1)",
+				"Fixed the kubelet %s handling. (#2)",
+			},
+		},
+		{
+			name:   "remote release notes not yet published",
+			source: "https://dl.k8s.io/release/v1.30.0/release-notes.json",
+			prepare: func(mock *specsfakes.FakeImpl) {
+				mock.GetRequestReturns(&http.Response{
+					StatusCode: http.StatusNotFound,
+					Status:     "404 Not Found",
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sut := specs.New(specs.DefaultOptions())
+			mock := &specsfakes.FakeImpl{}
+			if tc.prepare != nil {
+				tc.prepare(mock)
+			}
+			sut.SetImpl(mock)
+
+			changes, err := sut.GetChanges(tc.source, "1.30.0")
+			if tc.shouldErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedChanges, changes)
+		})
+	}
+}
+
+func TestRPMChangelog(t *testing.T) {
+	date := time.Date(2024, time.April, 17, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name     string
+		changes  []string
+		expected string
+	}{
+		{
+			name: "generic entry",
+			expected: "* Wed Apr 17 2024 Kubernetes Authors <dev@kubernetes.io> - 1.30.0~rc.1-1.1\n" +
+				"- Update to version 1.30.0-rc.1",
+		},
+		{
+			name:    "release notes",
+			changes: []string{"Update to version 1.30.0-rc.1", "Fixed the kubelet %s handling. (#2)"},
+			expected: "* Wed Apr 17 2024 Kubernetes Authors <dev@kubernetes.io> - 1.30.0~rc.1-1.1\n" +
+				"- Update to version 1.30.0-rc.1\n" +
+				"- Fixed the kubelet %%s handling. (#2)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pkgDef := &specs.PackageDefinition{
+				Name:          "kubelet",
+				Version:       "1.30.0-rc.1",
+				Revision:      "1.1",
+				Changes:       tc.changes,
+				ChangelogDate: date,
+			}
+			require.Equal(t, tc.expected, pkgDef.RPMChangelog())
+		})
+	}
+}
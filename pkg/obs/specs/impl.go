@@ -17,6 +17,7 @@ limitations under the License.
 package specs
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -26,6 +27,7 @@ import (
 	"github.com/blang/semver/v4"
 	"k8s.io/release/pkg/obs/metadata"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/retry"
 	"sigs.k8s.io/release-sdk/object"
 	khttp "sigs.k8s.io/release-utils/http"
 	"sigs.k8s.io/release-utils/tar"
@@ -55,6 +57,7 @@ type impl interface {
 	TagStringToSemver(tag string) (semver.Version, error)
 	TrimTagPrefix(tag string) string
 	LoadPackageMetadata(path string) (metadata.PackageMetadataList, error)
+	ReadFile(name string) ([]byte, error)
 }
 
 func (d *defaultImpl) GetKubeVersion(versionType release.VersionType) (string, error) {
@@ -110,7 +113,9 @@ func (d *defaultImpl) Extract(tarFilePath, destinationPath string) error {
 }
 
 func (d *defaultImpl) GCSCopyToLocal(gcsPath, dst string) error {
-	return object.NewGCS().CopyToLocal(gcsPath, dst)
+	return retry.Do(context.Background(), retry.ServiceGCS, func() error {
+		return object.NewGCS().CopyToLocal(gcsPath, dst)
+	})
 }
 
 func (d *defaultImpl) TagStringToSemver(tag string) (semver.Version, error) {
@@ -124,3 +129,7 @@ func (d *defaultImpl) TrimTagPrefix(tag string) string {
 func (d *defaultImpl) LoadPackageMetadata(path string) (metadata.PackageMetadataList, error) {
 	return metadata.LoadPackageMetadata(path)
 }
+
+func (d *defaultImpl) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
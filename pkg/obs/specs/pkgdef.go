@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	template "github.com/google/safetext/yamltemplate"
 
@@ -43,6 +44,12 @@ type PackageDefinition struct {
 
 	SpecTemplatePath string
 	SpecOutputPath   string
+
+	// Changes are the entries of the package changelog for this version.
+	Changes []string
+
+	// ChangelogDate is the date of the changelog entry.
+	ChangelogDate time.Time
 }
 
 // PackageVariation is a variation of the same package. Variation currently
@@ -76,6 +83,7 @@ func (s *Specs) ConstructPackageDefinition() (*PackageDefinition, error) {
 
 		SpecTemplatePath: s.options.SpecTemplatePath,
 		SpecOutputPath:   s.options.SpecOutputPath,
+		ChangelogDate:    time.Now().UTC(),
 	}
 
 	logrus.Infof("Writing output to %s", pkgDef.SpecOutputPath)
@@ -120,6 +128,11 @@ func (s *Specs) ConstructPackageDefinition() (*PackageDefinition, error) {
 		pkgDef.Variations = append(pkgDef.Variations, pkgVar)
 	}
 
+	pkgDef.Changes, err = s.GetChanges(s.options.ReleaseNotes, pkgDef.Version)
+	if err != nil {
+		return nil, fmt.Errorf("getting changelog for %s: %w", pkgDef.Name, err)
+	}
+
 	logrus.Infof("Successfully constructed package definition for %s %s!", pkgDef.Name, pkgDef.Version)
 
 	return pkgDef, nil
@@ -65,6 +65,11 @@ type Options struct {
 
 	// SpecOnly generates only spec files without the artifacts archive.
 	SpecOnly bool
+
+	// ReleaseNotes is a path or https:// URL to the JSON release notes of
+	// the version, which are used for the changelog entry of the package.
+	// Omit for a generic changelog entry.
+	ReleaseNotes string
 }
 
 // DefaultOptions returns a new Options instance.
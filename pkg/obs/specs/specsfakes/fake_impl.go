@@ -18,7 +18,6 @@ limitations under the License.
 package specsfakes
 
 import (
-	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -131,11 +130,11 @@ type FakeImpl struct {
 		result1 metadata.PackageMetadataList
 		result2 error
 	}
-	MkdirStub        func(string, fs.FileMode) error
+	MkdirStub        func(string, os.FileMode) error
 	mkdirMutex       sync.RWMutex
 	mkdirArgsForCall []struct {
 		arg1 string
-		arg2 fs.FileMode
+		arg2 os.FileMode
 	}
 	mkdirReturns struct {
 		result1 error
@@ -143,11 +142,11 @@ type FakeImpl struct {
 	mkdirReturnsOnCall map[int]struct {
 		result1 error
 	}
-	MkdirAllStub        func(string, fs.FileMode) error
+	MkdirAllStub        func(string, os.FileMode) error
 	mkdirAllMutex       sync.RWMutex
 	mkdirAllArgsForCall []struct {
 		arg1 string
-		arg2 fs.FileMode
+		arg2 os.FileMode
 	}
 	mkdirAllReturns struct {
 		result1 error
@@ -155,6 +154,19 @@ type FakeImpl struct {
 	mkdirAllReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ReadFileStub        func(string) ([]byte, error)
+	readFileMutex       sync.RWMutex
+	readFileArgsForCall []struct {
+		arg1 string
+	}
+	readFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	RemoveAllStub        func(string) error
 	removeAllMutex       sync.RWMutex
 	removeAllArgsForCall []struct {
@@ -177,17 +189,17 @@ type FakeImpl struct {
 	removeFileReturnsOnCall map[int]struct {
 		result1 error
 	}
-	StatStub        func(string) (fs.FileInfo, error)
+	StatStub        func(string) (os.FileInfo, error)
 	statMutex       sync.RWMutex
 	statArgsForCall []struct {
 		arg1 string
 	}
 	statReturns struct {
-		result1 fs.FileInfo
+		result1 os.FileInfo
 		result2 error
 	}
 	statReturnsOnCall map[int]struct {
-		result1 fs.FileInfo
+		result1 os.FileInfo
 		result2 error
 	}
 	TagStringToSemverStub        func(string) (semver.Version, error)
@@ -226,12 +238,12 @@ type FakeImpl struct {
 	walkReturnsOnCall map[int]struct {
 		result1 error
 	}
-	WriteFileStub        func(string, []byte, fs.FileMode) error
+	WriteFileStub        func(string, []byte, os.FileMode) error
 	writeFileMutex       sync.RWMutex
 	writeFileArgsForCall []struct {
 		arg1 string
 		arg2 []byte
-		arg3 fs.FileMode
+		arg3 os.FileMode
 	}
 	writeFileReturns struct {
 		result1 error
@@ -747,12 +759,12 @@ func (fake *FakeImpl) LoadPackageMetadataReturnsOnCall(i int, result1 metadata.P
 	}{result1, result2}
 }
 
-func (fake *FakeImpl) Mkdir(arg1 string, arg2 fs.FileMode) error {
+func (fake *FakeImpl) Mkdir(arg1 string, arg2 os.FileMode) error {
 	fake.mkdirMutex.Lock()
 	ret, specificReturn := fake.mkdirReturnsOnCall[len(fake.mkdirArgsForCall)]
 	fake.mkdirArgsForCall = append(fake.mkdirArgsForCall, struct {
 		arg1 string
-		arg2 fs.FileMode
+		arg2 os.FileMode
 	}{arg1, arg2})
 	stub := fake.MkdirStub
 	fakeReturns := fake.mkdirReturns
@@ -773,13 +785,13 @@ func (fake *FakeImpl) MkdirCallCount() int {
 	return len(fake.mkdirArgsForCall)
 }
 
-func (fake *FakeImpl) MkdirCalls(stub func(string, fs.FileMode) error) {
+func (fake *FakeImpl) MkdirCalls(stub func(string, os.FileMode) error) {
 	fake.mkdirMutex.Lock()
 	defer fake.mkdirMutex.Unlock()
 	fake.MkdirStub = stub
 }
 
-func (fake *FakeImpl) MkdirArgsForCall(i int) (string, fs.FileMode) {
+func (fake *FakeImpl) MkdirArgsForCall(i int) (string, os.FileMode) {
 	fake.mkdirMutex.RLock()
 	defer fake.mkdirMutex.RUnlock()
 	argsForCall := fake.mkdirArgsForCall[i]
@@ -809,12 +821,12 @@ func (fake *FakeImpl) MkdirReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeImpl) MkdirAll(arg1 string, arg2 fs.FileMode) error {
+func (fake *FakeImpl) MkdirAll(arg1 string, arg2 os.FileMode) error {
 	fake.mkdirAllMutex.Lock()
 	ret, specificReturn := fake.mkdirAllReturnsOnCall[len(fake.mkdirAllArgsForCall)]
 	fake.mkdirAllArgsForCall = append(fake.mkdirAllArgsForCall, struct {
 		arg1 string
-		arg2 fs.FileMode
+		arg2 os.FileMode
 	}{arg1, arg2})
 	stub := fake.MkdirAllStub
 	fakeReturns := fake.mkdirAllReturns
@@ -835,13 +847,13 @@ func (fake *FakeImpl) MkdirAllCallCount() int {
 	return len(fake.mkdirAllArgsForCall)
 }
 
-func (fake *FakeImpl) MkdirAllCalls(stub func(string, fs.FileMode) error) {
+func (fake *FakeImpl) MkdirAllCalls(stub func(string, os.FileMode) error) {
 	fake.mkdirAllMutex.Lock()
 	defer fake.mkdirAllMutex.Unlock()
 	fake.MkdirAllStub = stub
 }
 
-func (fake *FakeImpl) MkdirAllArgsForCall(i int) (string, fs.FileMode) {
+func (fake *FakeImpl) MkdirAllArgsForCall(i int) (string, os.FileMode) {
 	fake.mkdirAllMutex.RLock()
 	defer fake.mkdirAllMutex.RUnlock()
 	argsForCall := fake.mkdirAllArgsForCall[i]
@@ -871,6 +883,70 @@ func (fake *FakeImpl) MkdirAllReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeImpl) ReadFile(arg1 string) ([]byte, error) {
+	fake.readFileMutex.Lock()
+	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
+	fake.readFileArgsForCall = append(fake.readFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReadFileStub
+	fakeReturns := fake.readFileReturns
+	fake.recordInvocation("ReadFile", []interface{}{arg1})
+	fake.readFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ReadFileCallCount() int {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return len(fake.readFileArgsForCall)
+}
+
+func (fake *FakeImpl) ReadFileCalls(stub func(string) ([]byte, error)) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = stub
+}
+
+func (fake *FakeImpl) ReadFileArgsForCall(i int) string {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	argsForCall := fake.readFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ReadFileReturns(result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	fake.readFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	if fake.readFileReturnsOnCall == nil {
+		fake.readFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeImpl) RemoveAll(arg1 string) error {
 	fake.removeAllMutex.Lock()
 	ret, specificReturn := fake.removeAllReturnsOnCall[len(fake.removeAllArgsForCall)]
@@ -993,7 +1069,7 @@ func (fake *FakeImpl) RemoveFileReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeImpl) Stat(arg1 string) (fs.FileInfo, error) {
+func (fake *FakeImpl) Stat(arg1 string) (os.FileInfo, error) {
 	fake.statMutex.Lock()
 	ret, specificReturn := fake.statReturnsOnCall[len(fake.statArgsForCall)]
 	fake.statArgsForCall = append(fake.statArgsForCall, struct {
@@ -1018,7 +1094,7 @@ func (fake *FakeImpl) StatCallCount() int {
 	return len(fake.statArgsForCall)
 }
 
-func (fake *FakeImpl) StatCalls(stub func(string) (fs.FileInfo, error)) {
+func (fake *FakeImpl) StatCalls(stub func(string) (os.FileInfo, error)) {
 	fake.statMutex.Lock()
 	defer fake.statMutex.Unlock()
 	fake.StatStub = stub
@@ -1031,28 +1107,28 @@ func (fake *FakeImpl) StatArgsForCall(i int) string {
 	return argsForCall.arg1
 }
 
-func (fake *FakeImpl) StatReturns(result1 fs.FileInfo, result2 error) {
+func (fake *FakeImpl) StatReturns(result1 os.FileInfo, result2 error) {
 	fake.statMutex.Lock()
 	defer fake.statMutex.Unlock()
 	fake.StatStub = nil
 	fake.statReturns = struct {
-		result1 fs.FileInfo
+		result1 os.FileInfo
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeImpl) StatReturnsOnCall(i int, result1 fs.FileInfo, result2 error) {
+func (fake *FakeImpl) StatReturnsOnCall(i int, result1 os.FileInfo, result2 error) {
 	fake.statMutex.Lock()
 	defer fake.statMutex.Unlock()
 	fake.StatStub = nil
 	if fake.statReturnsOnCall == nil {
 		fake.statReturnsOnCall = make(map[int]struct {
-			result1 fs.FileInfo
+			result1 os.FileInfo
 			result2 error
 		})
 	}
 	fake.statReturnsOnCall[i] = struct {
-		result1 fs.FileInfo
+		result1 os.FileInfo
 		result2 error
 	}{result1, result2}
 }
@@ -1244,7 +1320,7 @@ func (fake *FakeImpl) WalkReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte, arg3 fs.FileMode) error {
+func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte, arg3 os.FileMode) error {
 	var arg2Copy []byte
 	if arg2 != nil {
 		arg2Copy = make([]byte, len(arg2))
@@ -1255,7 +1331,7 @@ func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte, arg3 fs.FileMode) erro
 	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
 		arg1 string
 		arg2 []byte
-		arg3 fs.FileMode
+		arg3 os.FileMode
 	}{arg1, arg2Copy, arg3})
 	stub := fake.WriteFileStub
 	fakeReturns := fake.writeFileReturns
@@ -1276,13 +1352,13 @@ func (fake *FakeImpl) WriteFileCallCount() int {
 	return len(fake.writeFileArgsForCall)
 }
 
-func (fake *FakeImpl) WriteFileCalls(stub func(string, []byte, fs.FileMode) error) {
+func (fake *FakeImpl) WriteFileCalls(stub func(string, []byte, os.FileMode) error) {
 	fake.writeFileMutex.Lock()
 	defer fake.writeFileMutex.Unlock()
 	fake.WriteFileStub = stub
 }
 
-func (fake *FakeImpl) WriteFileArgsForCall(i int) (string, []byte, fs.FileMode) {
+func (fake *FakeImpl) WriteFileArgsForCall(i int) (string, []byte, os.FileMode) {
 	fake.writeFileMutex.RLock()
 	defer fake.writeFileMutex.RUnlock()
 	argsForCall := fake.writeFileArgsForCall[i]
@@ -1335,6 +1411,8 @@ func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	defer fake.mkdirMutex.RUnlock()
 	fake.mkdirAllMutex.RLock()
 	defer fake.mkdirAllMutex.RUnlock()
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
 	fake.removeAllMutex.RLock()
 	defer fake.removeAllMutex.RUnlock()
 	fake.removeFileMutex.RLock()
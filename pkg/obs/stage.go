@@ -17,6 +17,7 @@ limitations under the License.
 package obs
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"os"
@@ -26,8 +27,11 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/layout"
 	"k8s.io/release/pkg/obs/specs"
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/retry"
+	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-sdk/osc"
 	"sigs.k8s.io/release-utils/command"
 	"sigs.k8s.io/release-utils/util"
@@ -37,8 +41,9 @@ import (
 //
 //counterfeiter:generate . stageClient
 type stageClient interface {
-	// Submit can be used to submit a Google Cloud Build (GCB) job.
-	Submit(stream bool) error
+	// Submit can be used to submit a Google Cloud Build (GCB) job, which
+	// stops being watched once ctx gets cancelled.
+	Submit(ctx context.Context, stream bool) error
 
 	// InitState initializes the default internal state.
 	InitState()
@@ -121,7 +126,7 @@ type defaultStageImpl struct{}
 //
 //counterfeiter:generate . stageImpl
 type stageImpl interface {
-	Submit(options *gcb.Options) error
+	Submit(ctx context.Context, options *gcb.Options) error
 	CheckPrerequisites(workspaceDir string) error
 	MkdirAll(path string) error
 	RemovePackageFiles(path string) error
@@ -139,8 +144,10 @@ type stageImpl interface {
 	Wait(project, packageName string) error
 }
 
-func (d *defaultStageImpl) Submit(options *gcb.Options) error {
-	return gcb.New(options).Submit()
+func (d *defaultStageImpl) Submit(ctx context.Context, options *gcb.Options) error {
+	g := gcb.New(options)
+	g.SetContext(ctx)
+	return g.Submit()
 }
 
 func (d *defaultStageImpl) CheckPrerequisites(workspaceDir string) error {
@@ -224,7 +231,7 @@ func (d *defaultStageImpl) Wait(project, packageName string) error {
 	return command.New(osc.OSCExecutable, "results", fmt.Sprintf("%s/%s", project, packageName), "-w").RunSuccess()
 }
 
-func (d *DefaultStage) Submit(stream bool) error {
+func (d *DefaultStage) Submit(ctx context.Context, stream bool) error {
 	options := gcb.NewDefaultOptions()
 
 	options.Stream = stream
@@ -241,7 +248,7 @@ func (d *DefaultStage) Submit(stream bool) error {
 	options.PackageSource = d.options.PackageSource
 	options.OBSWait = d.options.Wait
 
-	return d.impl.Submit(options)
+	return d.impl.Submit(ctx, options)
 }
 
 func (d *DefaultStage) InitState() {
@@ -381,7 +388,13 @@ func (d *DefaultStage) GeneratePackageArtifacts() error {
 		opts.Architectures = d.options.Architectures
 		opts.PackageSourceBase = d.options.PackageSource
 		if d.state.corePackages {
-			opts.PackageSourceBase = fmt.Sprintf("gs://%s/stage/%s/%s/gcs-stage", d.options.Bucket(), d.options.BuildVersion, d.state.versions.Prime())
+			opts.PackageSourceBase = object.GcsPrefix + layout.Default().StagePath(
+				d.options.Bucket(), d.options.BuildVersion, d.state.versions.Prime(), release.GCSStagePath,
+			)
+			opts.ReleaseNotes = fmt.Sprintf(
+				"%s/release/%s/release-notes.json",
+				release.URLPrefixForBucket(d.options.Bucket()), d.state.versions.Prime(),
+			)
 		}
 		opts.SpecTemplatePath = d.options.SpecTemplatePath
 		opts.SpecOutputPath = filepath.Join(d.options.Workspace, obsRoot, d.state.obsProject, pkg)
@@ -431,23 +444,18 @@ func (d *DefaultStage) Wait() error {
 		return nil
 	}
 
-	const retries = 3
-	for _, pkg := range d.options.Packages {
-		var tryError error
-
-		for try := 0; try < retries; try++ {
-			logrus.Infof("Waiting for package: %s (try %d)", pkg, try)
-
-			tryError = d.impl.Wait(d.state.obsProject, pkg)
-			if tryError == nil {
-				break
-			}
+	// Waiting fails for various reasons, like an interrupted connection
+	// or a build which got restarted, so retry every failure.
+	policy := retry.DefaultPolicy()
+	policy.Attempts = 3
+	policy.Classify = retry.Always
 
-			logrus.Errorf("Unable to wait for package %s: %v", pkg, tryError)
-		}
-
-		if tryError != nil {
-			return fmt.Errorf("wait for package %s: %w", pkg, tryError)
+	for _, pkg := range d.options.Packages {
+		logrus.Infof("Waiting for package: %s", pkg)
+		if err := policy.Do(context.Background(), retry.ServiceOBS, func() error {
+			return d.impl.Wait(d.state.obsProject, pkg)
+		}); err != nil {
+			return fmt.Errorf("wait for package %s: %w", pkg, err)
 		}
 	}
 
@@ -0,0 +1,391 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package obs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/consts"
+	"sigs.k8s.io/release-sdk/github"
+	khttp "sigs.k8s.io/release-utils/http"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// DefaultPackageRepositoryURL is the URL of the community-owned package
+// repositories.
+const DefaultPackageRepositoryURL = "https://pkgs.k8s.io"
+
+// companionPackage is a package which is built from the releases of an
+// upstream project and published next to the core Kubernetes packages.
+type companionPackage struct {
+	// owner and repo identify the upstream GitHub project.
+	owner, repo string
+
+	// followsKubernetes indicates that the minor versions of the upstream
+	// project match the Kubernetes minor versions.
+	followsKubernetes bool
+}
+
+// companionPackages are the packages which can be watched for new upstream
+// versions.
+var companionPackages = map[string]companionPackage{
+	consts.PackageCRITools:      {owner: "kubernetes-sigs", repo: "cri-tools", followsKubernetes: true},
+	consts.PackageKubernetesCNI: {owner: "containernetworking", repo: "plugins"},
+}
+
+// WatchOptions are the options for checking the companion packages for new
+// upstream versions.
+type WatchOptions struct {
+	// KubernetesVersions are the Kubernetes minor versions, like `v1.30`,
+	// whose package repositories should be checked.
+	KubernetesVersions []string
+
+	// Packages are the companion packages to check.
+	Packages []string
+
+	// Architectures for which outdated packages should be built.
+	Architectures []string
+
+	// SpecTemplatePath is path to a directory with spec template files.
+	SpecTemplatePath string
+
+	// RepositoryURL is the base URL of the package repositories.
+	RepositoryURL string
+
+	// Submit the packaging updates for outdated packages.
+	Submit bool
+
+	// Stream the output of the submitted Google Cloud Build jobs.
+	Stream bool
+
+	// NoMock submits the packaging updates in non-mocked mode.
+	NoMock bool
+}
+
+// DefaultWatchOptions returns a new default `WatchOptions`.
+func DefaultWatchOptions() *WatchOptions {
+	return &WatchOptions{
+		Packages: []string{
+			consts.PackageCRITools,
+			consts.PackageKubernetesCNI,
+		},
+		Architectures:    DefaultOptions().Architectures,
+		SpecTemplatePath: defaultSpecTemplatePath,
+		RepositoryURL:    DefaultPackageRepositoryURL,
+	}
+}
+
+// Validate checks if the watch options are correctly set.
+func (o *WatchOptions) Validate() error {
+	if len(o.KubernetesVersions) == 0 {
+		return errors.New("at least one kubernetes version is required")
+	}
+	for _, v := range o.KubernetesVersions {
+		if _, err := kubernetesMinor(v); err != nil {
+			return err
+		}
+	}
+	if len(o.Packages) == 0 {
+		return errors.New("at least one package is required")
+	}
+	for _, pkg := range o.Packages {
+		if _, ok := companionPackages[pkg]; !ok {
+			return fmt.Errorf("package %s cannot be watched", pkg)
+		}
+	}
+	if !consts.IsSupported("architectures", o.Architectures, consts.SupportedArchitectures) {
+		return errors.New("provided architectures are not supported")
+	}
+	if o.RepositoryURL == "" {
+		return errors.New("repository URL is required")
+	}
+	return nil
+}
+
+// PackageUpdate is a companion package for which a newer upstream version
+// is available than the published one.
+type PackageUpdate struct {
+	// Package is the name of the package.
+	Package string `json:"package"`
+
+	// KubernetesVersion is the Kubernetes minor version of the package
+	// repository.
+	KubernetesVersion string `json:"kubernetesVersion"`
+
+	// Published is the latest version in the package repository, which is
+	// empty if the package has not been published yet.
+	Published string `json:"published,omitempty"`
+
+	// Upstream is the latest upstream version.
+	Upstream string `json:"upstream"`
+
+	// Project is the OBS project where the package gets staged.
+	Project string `json:"project"`
+}
+
+// Watcher checks the companion packages for new upstream versions.
+type Watcher struct {
+	impl    watchImpl
+	options *WatchOptions
+}
+
+// NewWatcher creates a new `Watcher` instance.
+func NewWatcher(options *WatchOptions) *Watcher {
+	return &Watcher{&defaultWatchImpl{}, options}
+}
+
+// SetImpl can be used to set the internal watch implementation.
+func (w *Watcher) SetImpl(impl watchImpl) {
+	w.impl = impl
+}
+
+// defaultWatchImpl is the default internal watch client implementation.
+type defaultWatchImpl struct{}
+
+// watchImpl is the implementation of the watcher.
+//
+//counterfeiter:generate . watchImpl
+type watchImpl interface {
+	ReleaseTags(owner, repo string) ([]string, error)
+	GetURLResponse(url string) (string, error)
+	SubmitStage(options *StageOptions, stream bool) error
+}
+
+// ReleaseTags returns the tags of all non pre-releases on all pages.
+func (*defaultWatchImpl) ReleaseTags(owner, repo string) ([]string, error) {
+	client := github.New().Client()
+	opts := &gogithub.ListOptions{PerPage: 100}
+	tags := []string{}
+	for {
+		releases, resp, err := client.ListReleases(context.Background(), owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range releases {
+			if !r.GetPrerelease() {
+				tags = append(tags, r.GetTagName())
+			}
+		}
+		if resp.NextPage == 0 {
+			return tags, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (*defaultWatchImpl) GetURLResponse(url string) (string, error) {
+	return khttp.GetURLResponse(url, false)
+}
+
+func (*defaultWatchImpl) SubmitStage(options *StageOptions, stream bool) error {
+	if err := options.Validate(&State{}, true); err != nil {
+		return fmt.Errorf("prechecking stage options: %w", err)
+	}
+	return NewDefaultStage(options).Submit(context.Background(), stream)
+}
+
+// Run checks the companion packages and submits the packaging updates of
+// outdated ones if requested.
+func (w *Watcher) Run() ([]PackageUpdate, error) {
+	if err := w.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating watch options: %w", err)
+	}
+
+	updates, err := w.Check()
+	if err != nil {
+		return nil, err
+	}
+
+	if !w.options.Submit {
+		return updates, nil
+	}
+
+	for _, update := range updates {
+		if err := w.SubmitUpdate(update); err != nil {
+			return updates, err
+		}
+	}
+	return updates, nil
+}
+
+// Check compares the latest upstream versions of the companion packages
+// against the versions published for every Kubernetes minor version.
+func (w *Watcher) Check() ([]PackageUpdate, error) {
+	upstreamTags := map[string][]string{}
+	for _, pkg := range w.options.Packages {
+		cp := companionPackages[pkg]
+		tags, err := w.impl.ReleaseTags(cp.owner, cp.repo)
+		if err != nil {
+			return nil, fmt.Errorf("listing releases of %s/%s: %w", cp.owner, cp.repo, err)
+		}
+		upstreamTags[pkg] = tags
+	}
+
+	updates := []PackageUpdate{}
+	for _, kubeVersion := range w.options.KubernetesVersions {
+		minor, err := kubernetesMinor(kubeVersion)
+		if err != nil {
+			return nil, err
+		}
+		kubeVersion = fmt.Sprintf("v%d.%d", minor.Major, minor.Minor)
+
+		url := fmt.Sprintf(
+			"%s/core:/%s:/%s/deb/Packages",
+			strings.TrimSuffix(w.options.RepositoryURL, "/"), OBSNamespaceStable, kubeVersion,
+		)
+		logrus.Infof("Checking published packages in %s", url)
+		index, err := w.impl.GetURLResponse(url)
+		if err != nil {
+			return nil, fmt.Errorf("getting package index %s: %w", url, err)
+		}
+		published := publishedVersions(index)
+
+		for _, pkg := range w.options.Packages {
+			cp := companionPackages[pkg]
+			upstream, ok := latestUpstreamVersion(upstreamTags[pkg], minor, cp.followsKubernetes)
+			if !ok {
+				logrus.Infof("No upstream release of %s found for Kubernetes %s", pkg, kubeVersion)
+				continue
+			}
+
+			current, isPublished := published[pkg]
+			if isPublished && !current.LT(upstream) {
+				logrus.Infof("Package %s %s is up to date for Kubernetes %s", pkg, current, kubeVersion)
+				continue
+			}
+
+			update := PackageUpdate{
+				Package:           pkg,
+				KubernetesVersion: kubeVersion,
+				Upstream:          upstream.String(),
+				Project: fmt.Sprintf(
+					"%s:core:%s:%s:build", OBSKubernetesProject, OBSNamespaceStable, kubeVersion,
+				),
+			}
+			if isPublished {
+				update.Published = current.String()
+			}
+			logrus.Infof(
+				"Package %s for Kubernetes %s is outdated: published %q, upstream %s",
+				pkg, kubeVersion, update.Published, update.Upstream,
+			)
+			updates = append(updates, update)
+		}
+	}
+
+	return updates, nil
+}
+
+// SubmitUpdate submits the stage of the given package update.
+func (w *Watcher) SubmitUpdate(update PackageUpdate) error {
+	options := DefaultStageOptions()
+	options.NoMock = w.options.NoMock
+	options.Packages = []string{update.Package}
+	options.Architectures = w.options.Architectures
+	options.SpecTemplatePath = w.options.SpecTemplatePath
+	options.Version = update.Upstream
+	options.Project = update.Project
+
+	logrus.Infof(
+		"Submitting %s %s to OBS project %s",
+		update.Package, update.Upstream, update.Project,
+	)
+	if err := w.impl.SubmitStage(options, w.options.Stream); err != nil {
+		return fmt.Errorf(
+			"submitting %s %s for kubernetes %s: %w",
+			update.Package, update.Upstream, update.KubernetesVersion, err,
+		)
+	}
+	return nil
+}
+
+// kubernetesMinor parses a Kubernetes minor version like `v1.30`.
+func kubernetesMinor(version string) (semver.Version, error) {
+	v := version
+	if strings.Count(v, ".") == 1 {
+		v += ".0"
+	}
+	parsed, err := util.TagStringToSemver(v)
+	if err != nil || len(parsed.Pre) > 0 {
+		return semver.Version{}, fmt.Errorf("invalid kubernetes version: %s", version)
+	}
+	return parsed, nil
+}
+
+// latestUpstreamVersion returns the highest stable version of the given
+// release tags. Versions have to match the Kubernetes minor version if the
+// project follows the Kubernetes versioning.
+func latestUpstreamVersion(tags []string, kubeMinor semver.Version, followsKubernetes bool) (semver.Version, bool) {
+	versions := semver.Versions{}
+	for _, tag := range tags {
+		v, err := util.TagStringToSemver(tag)
+		if err != nil || len(v.Pre) > 0 {
+			continue
+		}
+		if followsKubernetes && (v.Major != kubeMinor.Major || v.Minor != kubeMinor.Minor) {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	if len(versions) == 0 {
+		return semver.Version{}, false
+	}
+	sort.Sort(versions)
+	return versions[len(versions)-1], true
+}
+
+// publishedVersions parses the Debian package index and returns the highest
+// upstream version of every package.
+func publishedVersions(index string) map[string]semver.Version {
+	res := map[string]semver.Version{}
+	pkg := ""
+	scanner := bufio.NewScanner(strings.NewReader(index))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			pkg = ""
+		case strings.HasPrefix(line, "Package:"):
+			pkg = strings.TrimSpace(strings.TrimPrefix(line, "Package:"))
+		case strings.HasPrefix(line, "Version:") && pkg != "":
+			version := strings.TrimSpace(strings.TrimPrefix(line, "Version:"))
+			// Strip the Debian revision, like `-1.1`.
+			if i := strings.LastIndex(version, "-"); i > 0 {
+				version = version[:i]
+			}
+			v, err := semver.ParseTolerant(version)
+			if err != nil {
+				logrus.Debugf("Skipping unparsable version %q of %s", version, pkg)
+				continue
+			}
+			if current, ok := res[pkg]; !ok || current.LT(v) {
+				res[pkg] = v
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package obs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/obs"
+	"k8s.io/release/pkg/obs/obsfakes"
+)
+
+const testPackageIndex = `Package: cri-tools
+Architecture: amd64
+Version: 1.30.0-1.1
+
+Package: cri-tools
+Architecture: amd64
+Version: 1.30.1-1.1
+
+Package: kubernetes-cni
+Architecture: amd64
+Version: 1.4.0-1.1
+
+Package: kubectl
+Architecture: amd64
+Version: 1.30.2-1.1
+`
+
+func TestWatcherRun(t *testing.T) {
+	t.Parallel()
+
+	criToolsTags := []string{"v1.29.0", "v1.30.0", "v1.30.1", "v1.31.0-rc.0"}
+	cniTags := []string{"v1.3.0", "v1.4.0", "v1.5.0"}
+
+	for _, tc := range []struct {
+		name     string
+		prepare  func(*obs.WatchOptions, *obsfakes.FakeWatchImpl)
+		expected []obs.PackageUpdate
+		submits  int
+		err      bool
+	}{
+		{
+			name: "outdated kubernetes-cni",
+			prepare: func(_ *obs.WatchOptions, mock *obsfakes.FakeWatchImpl) {
+				mock.ReleaseTagsReturnsOnCall(0, criToolsTags, nil)
+				mock.ReleaseTagsReturnsOnCall(1, cniTags, nil)
+				mock.GetURLResponseReturns(testPackageIndex, nil)
+			},
+			expected: []obs.PackageUpdate{{
+				Package:           "kubernetes-cni",
+				KubernetesVersion: "v1.30",
+				Published:         "1.4.0",
+				Upstream:          "1.5.0",
+				Project:           "isv:kubernetes:core:stable:v1.30:build",
+			}},
+		},
+		{
+			name: "unpublished packages get submitted",
+			prepare: func(opts *obs.WatchOptions, mock *obsfakes.FakeWatchImpl) {
+				opts.Submit = true
+				mock.ReleaseTagsReturnsOnCall(0, criToolsTags, nil)
+				mock.ReleaseTagsReturnsOnCall(1, cniTags, nil)
+				mock.GetURLResponseReturns("", nil)
+			},
+			expected: []obs.PackageUpdate{
+				{
+					Package:           "cri-tools",
+					KubernetesVersion: "v1.30",
+					Upstream:          "1.30.1",
+					Project:           "isv:kubernetes:core:stable:v1.30:build",
+				},
+				{
+					Package:           "kubernetes-cni",
+					KubernetesVersion: "v1.30",
+					Upstream:          "1.5.0",
+					Project:           "isv:kubernetes:core:stable:v1.30:build",
+				},
+			},
+			submits: 2,
+		},
+		{
+			name: "no upstream release for minor version",
+			prepare: func(opts *obs.WatchOptions, mock *obsfakes.FakeWatchImpl) {
+				opts.KubernetesVersions = []string{"v1.31"}
+				opts.Packages = []string{"cri-tools"}
+				mock.ReleaseTagsReturns(criToolsTags, nil)
+				mock.GetURLResponseReturns("", nil)
+			},
+			expected: []obs.PackageUpdate{},
+		},
+		{
+			name: "failure on listing releases",
+			prepare: func(_ *obs.WatchOptions, mock *obsfakes.FakeWatchImpl) {
+				mock.ReleaseTagsReturns(nil, errors.New(""))
+			},
+			err: true,
+		},
+		{
+			name: "failure on getting package index",
+			prepare: func(_ *obs.WatchOptions, mock *obsfakes.FakeWatchImpl) {
+				mock.GetURLResponseReturns("", errors.New(""))
+			},
+			err: true,
+		},
+		{
+			name: "failure on submitting",
+			prepare: func(opts *obs.WatchOptions, mock *obsfakes.FakeWatchImpl) {
+				opts.Submit = true
+				mock.ReleaseTagsReturns(cniTags, nil)
+				mock.SubmitStageReturns(errors.New(""))
+			},
+			submits: 1,
+			err:     true,
+		},
+		{
+			name: "invalid kubernetes version",
+			prepare: func(opts *obs.WatchOptions, _ *obsfakes.FakeWatchImpl) {
+				opts.KubernetesVersions = []string{"latest"}
+			},
+			err: true,
+		},
+		{
+			name: "unsupported package",
+			prepare: func(opts *obs.WatchOptions, _ *obsfakes.FakeWatchImpl) {
+				opts.Packages = []string{"kubelet"}
+			},
+			err: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := obs.DefaultWatchOptions()
+			opts.KubernetesVersions = []string{"v1.30"}
+			mock := &obsfakes.FakeWatchImpl{}
+			tc.prepare(opts, mock)
+
+			sut := obs.NewWatcher(opts)
+			sut.SetImpl(mock)
+
+			updates, err := sut.Run()
+			require.Equal(t, tc.submits, mock.SubmitStageCallCount())
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, updates)
+
+			for i := 0; i < mock.SubmitStageCallCount(); i++ {
+				stageOpts, _ := mock.SubmitStageArgsForCall(i)
+				require.Equal(t, []string{tc.expected[i].Package}, stageOpts.Packages)
+				require.Equal(t, tc.expected[i].Upstream, stageOpts.Version)
+				require.Equal(t, tc.expected[i].Project, stageOpts.Project)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pendingnotes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/env"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt pendingnotesfakes/fake_impl.go > pendingnotesfakes/_fake_impl.go && mv pendingnotesfakes/_fake_impl.go pendingnotesfakes/fake_impl.go"
+type impl interface {
+	// GatherReleaseNotes validates the options, which discovers the
+	// revisions, and gathers the release notes between them.
+	GatherReleaseNotes(opts *options.Options) (*notes.ReleaseNotes, error)
+	// Login returns the login of the user owning the token.
+	Login() (string, error)
+	// SearchIssues returns the open issues of the author with the title.
+	SearchIssues(owner, repo, author, title string) ([]*gogithub.Issue, error)
+	CreateIssue(owner, repo, title, body string) (*gogithub.Issue, error)
+	EditIssue(owner, repo string, number int, body string) error
+	ListComments(owner, repo string, number int) ([]*gogithub.IssueComment, error)
+	CreateComment(owner, repo string, number int, body string) error
+	EditComment(owner, repo string, id int64, body string) error
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type defaultImpl struct {
+	client *gogithub.Client
+}
+
+func newDefaultImpl() *defaultImpl {
+	httpClient := http.DefaultClient
+	if token := env.Default(github.TokenEnvKey, ""); token != "" {
+		httpClient = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		))
+	}
+	return &defaultImpl{client: gogithub.NewClient(httpClient)}
+}
+
+func (*defaultImpl) GatherReleaseNotes(opts *options.Options) (*notes.ReleaseNotes, error) {
+	if err := opts.ValidateAndFinish(); err != nil {
+		return nil, fmt.Errorf("validating notes options: %w", err)
+	}
+	return notes.GatherReleaseNotes(opts)
+}
+
+func (d *defaultImpl) Login() (string, error) {
+	user, _, err := d.client.Users.Get(context.Background(), "")
+	if err != nil {
+		return "", err
+	}
+	return user.GetLogin(), nil
+}
+
+func (d *defaultImpl) SearchIssues(owner, repo, author, title string) ([]*gogithub.Issue, error) {
+	query := fmt.Sprintf(
+		"repo:%s/%s is:issue is:open author:%s in:title %q",
+		owner, repo, author, title,
+	)
+	res := []*gogithub.Issue{}
+	opts := &gogithub.SearchOptions{ListOptions: gogithub.ListOptions{PerPage: 100}}
+	for {
+		result, resp, err := d.client.Search.Issues(context.Background(), query, opts)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, result.Issues...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+	return res, nil
+}
+
+func (d *defaultImpl) CreateIssue(owner, repo, title, body string) (*gogithub.Issue, error) {
+	issue, _, err := d.client.Issues.Create(
+		context.Background(), owner, repo, &gogithub.IssueRequest{Title: &title, Body: &body},
+	)
+	return issue, err
+}
+
+func (d *defaultImpl) EditIssue(owner, repo string, number int, body string) error {
+	_, _, err := d.client.Issues.Edit(
+		context.Background(), owner, repo, number, &gogithub.IssueRequest{Body: &body},
+	)
+	return err
+}
+
+func (d *defaultImpl) ListComments(owner, repo string, number int) ([]*gogithub.IssueComment, error) {
+	res := []*gogithub.IssueComment{}
+	opts := &gogithub.IssueListCommentsOptions{ListOptions: gogithub.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := d.client.Issues.ListComments(
+			context.Background(), owner, repo, number, opts,
+		)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, comments...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+	return res, nil
+}
+
+func (d *defaultImpl) CreateComment(owner, repo string, number int, body string) error {
+	_, _, err := d.client.Issues.CreateComment(
+		context.Background(), owner, repo, number, &gogithub.IssueComment{Body: &body},
+	)
+	return err
+}
+
+func (d *defaultImpl) EditComment(owner, repo string, id int64, body string) error {
+	_, _, err := d.client.Issues.EditComment(
+		context.Background(), owner, repo, id, &gogithub.IssueComment{Body: &body},
+	)
+	return err
+}
+
+func (*defaultImpl) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
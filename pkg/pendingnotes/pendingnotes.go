@@ -0,0 +1,418 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pendingnotes keeps a summary of the release notes which have been
+// merged into a release branch since its latest patch release up to date on
+// GitHub. This gives the maintainers visibility into what the next patch
+// release will contain, without running the release notes tooling.
+package pendingnotes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/document"
+	"k8s.io/release/pkg/notes/options"
+)
+
+// maxBodyLength is the number of characters from which on notes get omitted,
+// which keeps the summary below the GitHub limit of issue and comment
+// bodies.
+const maxBodyLength = 60000
+
+// Options are the main options for summarizing the pending release notes.
+type Options struct {
+	// GitHubOrg is the GitHub organization of the tracking repository.
+	GitHubOrg string
+
+	// GitHubRepo is the GitHub repository containing the summary issue.
+	GitHubRepo string
+
+	// Branch is the release branch to summarize, for example release-1.31.
+	Branch string
+
+	// Issue is the optional number of an existing issue in the tracking
+	// repository. The summary gets maintained as comment on it instead of
+	// in a dedicated issue if set.
+	Issue int
+
+	// RepoPath is the optional path to a local clone of kubernetes/kubernetes
+	// for gathering the release notes.
+	RepoPath string
+
+	// Interval keeps updating the summary in this interval if set, otherwise
+	// it gets updated once.
+	Interval time.Duration
+
+	// NoMock actually creates or updates the issue or comment if set to
+	// true.
+	NoMock bool
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		GitHubOrg:  git.DefaultGithubOrg,
+		GitHubRepo: git.DefaultGithubReleaseRepo,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.GitHubOrg == "" || o.GitHubRepo == "" {
+		return errors.New("GitHub organization and repository must not be empty")
+	}
+	if !git.IsReleaseBranch(o.Branch) || o.Branch == git.DefaultBranch {
+		return fmt.Errorf("invalid release branch %q", o.Branch)
+	}
+	if o.Issue < 0 {
+		return fmt.Errorf("invalid issue number %d", o.Issue)
+	}
+	if o.Interval < 0 {
+		return fmt.Errorf("interval must not be negative, got %s", o.Interval)
+	}
+	return nil
+}
+
+// Summary contains the release notes merged since the latest patch release
+// of a branch.
+type Summary struct {
+	// Branch is the summarized release branch.
+	Branch string `json:"branch"`
+
+	// PreviousRelease is the latest release of the branch.
+	PreviousRelease string `json:"previousRelease"`
+
+	// NextRelease is the next patch release of the branch, which is unknown
+	// for pre-releases.
+	NextRelease string `json:"nextRelease,omitempty"`
+
+	// Head is the commit of the branch up to which the notes got gathered.
+	Head string `json:"head"`
+
+	// Notes is the number of published release notes.
+	Notes int `json:"notes"`
+
+	// Document contains the release notes grouped by their kinds.
+	Document *document.Document `json:"document"`
+}
+
+// Title returns the title of the summary issue.
+func (s *Summary) Title() string {
+	return "Pending release notes of " + s.Branch
+}
+
+// Marker returns the hidden marker identifying the summary of the branch,
+// which allows updating it.
+func (s *Summary) Marker() string {
+	return fmt.Sprintf("<!-- pending-release-notes:%s -->", s.Branch)
+}
+
+// Markdown returns the summary as markdown. Notes get omitted if the summary
+// would exceed the GitHub limit.
+func (s *Summary) Markdown() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%s\n## %s\n\n", s.Marker(), s.Title())
+
+	head := s.Head
+	if len(head) > 10 {
+		head = head[:10]
+	}
+	headLink := fmt.Sprintf(
+		"[%s](https://github.com/%s/%s/commit/%s)",
+		head, git.DefaultGithubOrg, git.DefaultGithubRepo, s.Head,
+	)
+	if s.Notes == 0 {
+		fmt.Fprintf(
+			buf, "No release notes have been merged since %s (up to %s).\n",
+			s.PreviousRelease, headLink,
+		)
+		return buf.String()
+	}
+
+	plural := "s have"
+	if s.Notes == 1 {
+		plural = " has"
+	}
+	fmt.Fprintf(
+		buf, "%d release note%s been merged since %s (up to %s)",
+		s.Notes, plural, s.PreviousRelease, headLink,
+	)
+	if s.NextRelease != "" {
+		fmt.Fprintf(buf, ", which will be part of %s", s.NextRelease)
+	}
+	buf.WriteString(".\n")
+
+	omitted := 0
+	addNotes := func(entries notes.Notes) {
+		for _, entry := range entries {
+			line := "- " + strings.TrimSpace(entry) + "\n"
+			if buf.Len()+len(line) > maxBodyLength {
+				omitted++
+				continue
+			}
+			buf.WriteString(line)
+		}
+	}
+
+	if len(s.Document.NotesWithActionRequired) > 0 {
+		buf.WriteString("\n### Urgent Upgrade Notes\n\n")
+		addNotes(s.Document.NotesWithActionRequired)
+	}
+	if len(s.Document.Notes) > 0 {
+		buf.WriteString("\n### Changes by Kind\n")
+		for _, category := range s.Document.Notes {
+			fmt.Fprintf(buf, "\n#### %s\n\n", document.PrettyKind(category.Kind))
+			addNotes(*category.NoteEntries)
+		}
+	}
+	if omitted > 0 {
+		fmt.Fprintf(
+			buf, "\n%d more notes got omitted, run `release-notes` for the full list.\n",
+			omitted,
+		)
+	}
+	return buf.String()
+}
+
+// PendingNotes is the main structure for summarizing the pending release
+// notes.
+type PendingNotes struct {
+	impl    impl
+	options *Options
+
+	// login is the cached login of the token, which authors the summary.
+	login string
+}
+
+// New returns a new PendingNotes instance.
+func New(opts *Options) *PendingNotes {
+	return &PendingNotes{
+		impl:    newDefaultImpl(),
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (p *PendingNotes) SetImpl(impl impl) {
+	p.impl = impl
+}
+
+// Run updates the summary once, or periodically if an interval is set.
+func (p *PendingNotes) Run() error {
+	return p.RunContext(context.Background())
+}
+
+// RunContext is like Run but stops updating periodically once the context
+// gets cancelled. Failing periodic updates are only logged, to be retried in
+// the next interval.
+func (p *PendingNotes) RunContext(ctx context.Context) error {
+	for {
+		if _, err := p.Update(); err != nil {
+			if p.options.Interval == 0 {
+				return err
+			}
+			logrus.Errorf("Unable to update the pending release notes of %s: %v", p.options.Branch, err)
+		}
+		if p.options.Interval == 0 {
+			return nil
+		}
+		logrus.Infof("Updating the pending release notes again in %s", p.options.Interval)
+		if err := p.impl.Sleep(ctx, p.options.Interval); err != nil {
+			logrus.Infof("Stopping to update the pending release notes: %v", err)
+			return nil
+		}
+	}
+}
+
+// Update summarizes the pending release notes and creates or updates the
+// issue or comment containing them.
+func (p *PendingNotes) Update() (*Summary, error) {
+	summary, err := p.Summarize()
+	if err != nil {
+		return nil, err
+	}
+	body := summary.Markdown()
+
+	if !p.options.NoMock {
+		logrus.Infof("Not updating the pending release notes in mock mode, summary:\n%s", body)
+		return summary, nil
+	}
+
+	if p.options.Issue > 0 {
+		if err := p.comment(summary, body); err != nil {
+			return nil, fmt.Errorf("comment on issue #%d: %w", p.options.Issue, err)
+		}
+		return summary, nil
+	}
+	if err := p.issue(summary, body); err != nil {
+		return nil, fmt.Errorf("update summary issue: %w", err)
+	}
+	return summary, nil
+}
+
+// Summarize gathers the release notes merged since the latest patch release
+// of the branch.
+func (p *PendingNotes) Summarize() (*Summary, error) {
+	if err := p.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	notesOptions := options.New()
+	notesOptions.Branch = p.options.Branch
+	notesOptions.RepoPath = p.options.RepoPath
+	notesOptions.DiscoverMode = options.RevisionDiscoveryModePatchToLatest
+	notesOptions.AddMarkdownLinks = true
+
+	logrus.Infof("Gathering the pending release notes of %s", p.options.Branch)
+	releaseNotes, err := p.impl.GatherReleaseNotes(notesOptions)
+	if err != nil {
+		return nil, fmt.Errorf("gathering release notes: %w", err)
+	}
+
+	doc, err := document.New(releaseNotes, notesOptions.StartRev, notesOptions.EndSHA)
+	if err != nil {
+		return nil, fmt.Errorf("creating release note document: %w", err)
+	}
+
+	summary := &Summary{
+		Branch:          p.options.Branch,
+		PreviousRelease: notesOptions.StartRev,
+		Head:            notesOptions.EndSHA,
+		Document:        doc,
+	}
+	if previous, err := util.TagStringToSemver(notesOptions.StartRev); err == nil && len(previous.Pre) == 0 {
+		previous.Patch++
+		summary.NextRelease = util.SemverToTagString(previous)
+	}
+	for _, pr := range releaseNotes.History() {
+		if note := releaseNotes.Get(pr); note.IsMapped || !note.DoNotPublish {
+			summary.Notes++
+		}
+	}
+	logrus.Infof(
+		"Found %d pending release notes of %s since %s",
+		summary.Notes, summary.Branch, summary.PreviousRelease,
+	)
+	return summary, nil
+}
+
+// currentLogin returns the login of the token. Only issues and comments
+// authored by it are considered to be the summary, because anyone can post
+// the marker, while the token would not be allowed to edit their text.
+func (p *PendingNotes) currentLogin() (string, error) {
+	if p.login != "" {
+		return p.login, nil
+	}
+	login, err := p.impl.Login()
+	if err != nil {
+		return "", fmt.Errorf("get login of the GitHub token: %w", err)
+	}
+	p.login = login
+	return login, nil
+}
+
+// issue creates or updates the dedicated summary issue of the branch.
+func (p *PendingNotes) issue(summary *Summary, body string) error {
+	login, err := p.currentLogin()
+	if err != nil {
+		return err
+	}
+	issues, err := p.impl.SearchIssues(
+		p.options.GitHubOrg, p.options.GitHubRepo, login, summary.Title(),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"search issues for %s/%s: %w",
+			p.options.GitHubOrg, p.options.GitHubRepo, err,
+		)
+	}
+
+	var existing *gogithub.Issue
+	for _, issue := range issues {
+		if !issue.IsPullRequest() &&
+			issue.GetUser().GetLogin() == login &&
+			strings.Contains(issue.GetBody(), summary.Marker()) {
+			existing = issue
+			break
+		}
+	}
+
+	switch {
+	case existing != nil && existing.GetBody() == body:
+		logrus.Infof("Pending release notes issue #%d is up to date", existing.GetNumber())
+		return nil
+
+	case existing != nil:
+		logrus.Infof("Updating pending release notes issue #%d", existing.GetNumber())
+		return p.impl.EditIssue(p.options.GitHubOrg, p.options.GitHubRepo, existing.GetNumber(), body)
+
+	default:
+		logrus.Infof("Creating pending release notes issue %q", summary.Title())
+		issue, err := p.impl.CreateIssue(p.options.GitHubOrg, p.options.GitHubRepo, summary.Title(), body)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("Pending release notes issue created: %s", issue.GetHTMLURL())
+		return nil
+	}
+}
+
+// comment creates or updates the summary comment of the branch on the
+// configured issue.
+func (p *PendingNotes) comment(summary *Summary, body string) error {
+	login, err := p.currentLogin()
+	if err != nil {
+		return err
+	}
+	comments, err := p.impl.ListComments(p.options.GitHubOrg, p.options.GitHubRepo, p.options.Issue)
+	if err != nil {
+		return fmt.Errorf("list comments: %w", err)
+	}
+
+	var existing *gogithub.IssueComment
+	for _, comment := range comments {
+		if comment.GetUser().GetLogin() == login &&
+			strings.Contains(comment.GetBody(), summary.Marker()) {
+			existing = comment
+			break
+		}
+	}
+
+	switch {
+	case existing != nil && existing.GetBody() == body:
+		logrus.Info("Pending release notes comment is up to date")
+		return nil
+
+	case existing != nil:
+		logrus.Info("Updating pending release notes comment")
+		return p.impl.EditComment(p.options.GitHubOrg, p.options.GitHubRepo, existing.GetID(), body)
+
+	default:
+		logrus.Info("Creating pending release notes comment")
+		return p.impl.CreateComment(p.options.GitHubOrg, p.options.GitHubRepo, p.options.Issue, body)
+	}
+}
@@ -0,0 +1,383 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pendingnotes_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+	"k8s.io/release/pkg/pendingnotes"
+	"k8s.io/release/pkg/pendingnotes/pendingnotesfakes"
+)
+
+var errTest = errors.New("test")
+
+const (
+	marker   = "<!-- pending-release-notes:release-1.31 -->"
+	botLogin = "release-bot"
+)
+
+var bot = &gogithub.User{Login: gogithub.String(botLogin)}
+
+func newOptions() *pendingnotes.Options {
+	opts := pendingnotes.DefaultOptions()
+	opts.Branch = "release-1.31"
+	opts.NoMock = true
+	return opts
+}
+
+func newMock(startRev string, releaseNotes ...*notes.ReleaseNote) *pendingnotesfakes.FakeImpl {
+	mock := &pendingnotesfakes.FakeImpl{}
+	mock.GatherReleaseNotesCalls(func(opts *options.Options) (*notes.ReleaseNotes, error) {
+		opts.StartRev = startRev
+		opts.EndSHA = "0123456789abcdef"
+		res := notes.NewReleaseNotes()
+		for _, note := range releaseNotes {
+			res.Set(note.PrNumber, note)
+		}
+		return res, nil
+	})
+	mock.LoginReturns(botLogin, nil)
+	return mock
+}
+
+func bugNote() *notes.ReleaseNote {
+	return &notes.ReleaseNote{
+		PrNumber: 100,
+		Kinds:    []string{"bug"},
+		Markdown: "Fixed a kubelet crash ([#100](https://github.com/kubernetes/kubernetes/pull/100), [@alice](https://github.com/alice))",
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		modify      func(*pendingnotes.Options)
+		shouldError bool
+	}{
+		{name: "success", modify: func(*pendingnotes.Options) {}},
+		{name: "missing repository", modify: func(o *pendingnotes.Options) { o.GitHubRepo = "" }, shouldError: true},
+		{name: "missing branch", modify: func(o *pendingnotes.Options) { o.Branch = "" }, shouldError: true},
+		{name: "default branch", modify: func(o *pendingnotes.Options) { o.Branch = "master" }, shouldError: true},
+		{name: "negative issue", modify: func(o *pendingnotes.Options) { o.Issue = -1 }, shouldError: true},
+		{name: "negative interval", modify: func(o *pendingnotes.Options) { o.Interval = -time.Hour }, shouldError: true},
+	} {
+		opts := newOptions()
+		tc.modify(opts)
+		err := opts.Validate()
+		if tc.shouldError {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		startRev string
+		notes    []*notes.ReleaseNote
+		next     string
+		count    int
+		contains []string
+	}{
+		{
+			name:     "no notes",
+			startRev: "v1.31.2",
+			next:     "v1.31.3",
+			contains: []string{
+				"No release notes have been merged since v1.31.2 (up to [0123456789](https://github.com/kubernetes/kubernetes/commit/0123456789abcdef)).",
+			},
+		},
+		{
+			name:     "notes by kind",
+			startRev: "v1.31.2",
+			notes: []*notes.ReleaseNote{
+				bugNote(),
+				{
+					PrNumber:       101,
+					Kinds:          []string{"api-change"},
+					ActionRequired: true,
+					Markdown:       "Removed the deprecated flag ([#101](https://github.com/kubernetes/kubernetes/pull/101), [@bob](https://github.com/bob))",
+				},
+				{PrNumber: 102, Kinds: []string{"cleanup"}, DoNotPublish: true, Markdown: "Not published"},
+			},
+			next:  "v1.31.3",
+			count: 2,
+			contains: []string{
+				"2 release notes have been merged since v1.31.2 (up to [0123456789](https://github.com/kubernetes/kubernetes/commit/0123456789abcdef)), which will be part of v1.31.3.",
+				"### Urgent Upgrade Notes\n\n- Removed the deprecated flag",
+				"#### Bug or Regression\n\n- Fixed a kubelet crash",
+			},
+		},
+		{
+			name:     "pre-release",
+			startRev: "v1.32.0-rc.1",
+			notes:    []*notes.ReleaseNote{bugNote()},
+			count:    1,
+			contains: []string{
+				"1 release note has been merged since v1.32.0-rc.1 (up to [0123456789](https://github.com/kubernetes/kubernetes/commit/0123456789abcdef)).",
+			},
+		},
+	} {
+		sut := pendingnotes.New(newOptions())
+		mock := newMock(tc.startRev, tc.notes...)
+		sut.SetImpl(mock)
+
+		summary, err := sut.Summarize()
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.startRev, summary.PreviousRelease, tc.name)
+		require.Equal(t, tc.next, summary.NextRelease, tc.name)
+		require.Equal(t, tc.count, summary.Notes, tc.name)
+
+		opts := mock.GatherReleaseNotesArgsForCall(0)
+		require.Equal(t, "release-1.31", opts.Branch, tc.name)
+		require.Equal(t, options.RevisionDiscoveryModePatchToLatest, opts.DiscoverMode, tc.name)
+
+		markdown := summary.Markdown()
+		require.True(t, strings.HasPrefix(markdown, marker+"\n## Pending release notes of release-1.31\n"), tc.name)
+		for _, s := range tc.contains {
+			require.Contains(t, markdown, s, tc.name)
+		}
+		require.NotContains(t, markdown, "Not published", tc.name)
+	}
+}
+
+func TestMarkdownOmitsNotes(t *testing.T) {
+	releaseNotes := []*notes.ReleaseNote{}
+	for i := 0; i < 1000; i++ {
+		releaseNotes = append(releaseNotes, &notes.ReleaseNote{
+			PrNumber: i,
+			Kinds:    []string{"bug"},
+			Markdown: fmt.Sprintf("%d %s", i, strings.Repeat("x", 100)),
+		})
+	}
+	sut := pendingnotes.New(newOptions())
+	sut.SetImpl(newMock("v1.31.2", releaseNotes...))
+
+	summary, err := sut.Summarize()
+	require.NoError(t, err)
+	markdown := summary.Markdown()
+	require.Less(t, len(markdown), 65536)
+	require.Contains(t, markdown, "more notes got omitted")
+}
+
+func TestUpdate(t *testing.T) {
+	summaryBody := func() string {
+		sut := pendingnotes.New(newOptions())
+		sut.SetImpl(newMock("v1.31.2", bugNote()))
+		summary, err := sut.Summarize()
+		require.NoError(t, err)
+		return summary.Markdown()
+	}()
+
+	for _, tc := range []struct {
+		name        string
+		modify      func(*pendingnotes.Options)
+		prepare     func(*pendingnotesfakes.FakeImpl)
+		shouldError bool
+		assert      func(*pendingnotesfakes.FakeImpl)
+	}{
+		{
+			name:   "mock mode",
+			modify: func(o *pendingnotes.Options) { o.NoMock = false },
+			assert: func(mock *pendingnotesfakes.FakeImpl) {
+				require.Zero(t, mock.SearchIssuesCallCount())
+				require.Zero(t, mock.ListCommentsCallCount())
+			},
+		},
+		{
+			name: "create issue",
+			prepare: func(mock *pendingnotesfakes.FakeImpl) {
+				mock.SearchIssuesReturns([]*gogithub.Issue{
+					{Number: gogithub.Int(1), User: bot, Body: gogithub.String("<!-- pending-release-notes:release-1.30 -->")},
+					{Number: gogithub.Int(4), User: &gogithub.User{Login: gogithub.String("someone")}, Body: gogithub.String(marker)},
+				}, nil)
+				mock.CreateIssueReturns(&gogithub.Issue{Number: gogithub.Int(2)}, nil)
+			},
+			assert: func(mock *pendingnotesfakes.FakeImpl) {
+				require.Equal(t, 1, mock.SearchIssuesCallCount())
+				_, _, author, searchTitle := mock.SearchIssuesArgsForCall(0)
+				require.Equal(t, botLogin, author)
+				require.Equal(t, "Pending release notes of release-1.31", searchTitle)
+
+				require.Equal(t, 1, mock.CreateIssueCallCount())
+				org, repo, title, body := mock.CreateIssueArgsForCall(0)
+				require.Equal(t, "kubernetes", org)
+				require.Equal(t, "sig-release", repo)
+				require.Equal(t, "Pending release notes of release-1.31", title)
+				require.Equal(t, summaryBody, body)
+				require.Zero(t, mock.EditIssueCallCount())
+			},
+		},
+		{
+			name: "update issue",
+			prepare: func(mock *pendingnotesfakes.FakeImpl) {
+				mock.SearchIssuesReturns([]*gogithub.Issue{
+					{Number: gogithub.Int(3), User: bot, Body: gogithub.String(marker + "\nold")},
+				}, nil)
+			},
+			assert: func(mock *pendingnotesfakes.FakeImpl) {
+				require.Zero(t, mock.CreateIssueCallCount())
+				require.Equal(t, 1, mock.EditIssueCallCount())
+				_, _, number, body := mock.EditIssueArgsForCall(0)
+				require.Equal(t, 3, number)
+				require.Equal(t, summaryBody, body)
+			},
+		},
+		{
+			name: "issue up to date",
+			prepare: func(mock *pendingnotesfakes.FakeImpl) {
+				mock.SearchIssuesReturns([]*gogithub.Issue{
+					{Number: gogithub.Int(3), User: bot, Body: gogithub.String(summaryBody)},
+				}, nil)
+			},
+			assert: func(mock *pendingnotesfakes.FakeImpl) {
+				require.Zero(t, mock.CreateIssueCallCount())
+				require.Zero(t, mock.EditIssueCallCount())
+			},
+		},
+		{
+			name:   "create comment",
+			modify: func(o *pendingnotes.Options) { o.Issue = 42 },
+			prepare: func(mock *pendingnotesfakes.FakeImpl) {
+				mock.ListCommentsReturns([]*gogithub.IssueComment{
+					{ID: gogithub.Int64(1), User: bot, Body: gogithub.String("unrelated")},
+					{ID: gogithub.Int64(2), User: &gogithub.User{Login: gogithub.String("someone")}, Body: gogithub.String(marker)},
+				}, nil)
+			},
+			assert: func(mock *pendingnotesfakes.FakeImpl) {
+				require.Zero(t, mock.SearchIssuesCallCount())
+				require.Equal(t, 1, mock.CreateCommentCallCount())
+				_, _, number, body := mock.CreateCommentArgsForCall(0)
+				require.Equal(t, 42, number)
+				require.Equal(t, summaryBody, body)
+			},
+		},
+		{
+			name:   "update comment",
+			modify: func(o *pendingnotes.Options) { o.Issue = 42 },
+			prepare: func(mock *pendingnotesfakes.FakeImpl) {
+				mock.ListCommentsReturns([]*gogithub.IssueComment{
+					{ID: gogithub.Int64(7), User: bot, Body: gogithub.String(marker + "\nold")},
+				}, nil)
+			},
+			assert: func(mock *pendingnotesfakes.FakeImpl) {
+				require.Zero(t, mock.CreateCommentCallCount())
+				require.Equal(t, 1, mock.EditCommentCallCount())
+				_, _, id, body := mock.EditCommentArgsForCall(0)
+				require.EqualValues(t, 7, id)
+				require.Equal(t, summaryBody, body)
+			},
+		},
+		{
+			name: "gathering fails",
+			prepare: func(mock *pendingnotesfakes.FakeImpl) {
+				mock.GatherReleaseNotesCalls(nil)
+				mock.GatherReleaseNotesReturns(nil, errTest)
+			},
+			shouldError: true,
+		},
+		{
+			name: "listing issues fails",
+			prepare: func(mock *pendingnotesfakes.FakeImpl) {
+				mock.SearchIssuesReturns(nil, errTest)
+			},
+			shouldError: true,
+		},
+		{
+			name: "login fails",
+			prepare: func(mock *pendingnotesfakes.FakeImpl) {
+				mock.LoginReturns("", errTest)
+			},
+			shouldError: true,
+		},
+		{
+			name:   "editing comment fails",
+			modify: func(o *pendingnotes.Options) { o.Issue = 42 },
+			prepare: func(mock *pendingnotesfakes.FakeImpl) {
+				mock.ListCommentsReturns([]*gogithub.IssueComment{
+					{ID: gogithub.Int64(7), User: bot, Body: gogithub.String(marker)},
+				}, nil)
+				mock.EditCommentReturns(errTest)
+			},
+			shouldError: true,
+		},
+	} {
+		opts := newOptions()
+		if tc.modify != nil {
+			tc.modify(opts)
+		}
+		mock := newMock("v1.31.2", bugNote())
+		if tc.prepare != nil {
+			tc.prepare(mock)
+		}
+		sut := pendingnotes.New(opts)
+		sut.SetImpl(mock)
+
+		_, err := sut.Update()
+		if tc.shouldError {
+			require.Error(t, err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		if tc.assert != nil {
+			tc.assert(mock)
+		}
+	}
+}
+
+func TestRunContext(t *testing.T) {
+	opts := newOptions()
+	opts.Interval = time.Hour
+	mock := newMock("v1.31.2")
+	mock.SearchIssuesReturnsOnCall(0, nil, errTest)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mock.SleepCalls(func(ctx context.Context, _ time.Duration) error {
+		if mock.SleepCallCount() == 3 {
+			cancel()
+			return ctx.Err()
+		}
+		return nil
+	})
+
+	sut := pendingnotes.New(opts)
+	sut.SetImpl(mock)
+
+	// A failing update does not stop updating periodically
+	require.NoError(t, sut.RunContext(ctx))
+	require.Equal(t, 3, mock.GatherReleaseNotesCallCount())
+	require.Equal(t, 2, mock.CreateIssueCallCount())
+
+	// A single update returns the error
+	opts.Interval = 0
+	mock.GatherReleaseNotesCalls(nil)
+	mock.GatherReleaseNotesReturns(nil, errTest)
+	require.Error(t, sut.Run())
+}
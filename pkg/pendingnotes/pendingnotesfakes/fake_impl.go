@@ -0,0 +1,773 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package pendingnotesfakes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v58/github"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+)
+
+type FakeImpl struct {
+	CreateCommentStub        func(string, string, int, string) error
+	createCommentMutex       sync.RWMutex
+	createCommentArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}
+	createCommentReturns struct {
+		result1 error
+	}
+	createCommentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateIssueStub        func(string, string, string, string) (*github.Issue, error)
+	createIssueMutex       sync.RWMutex
+	createIssueArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	createIssueReturns struct {
+		result1 *github.Issue
+		result2 error
+	}
+	createIssueReturnsOnCall map[int]struct {
+		result1 *github.Issue
+		result2 error
+	}
+	EditCommentStub        func(string, string, int64, string) error
+	editCommentMutex       sync.RWMutex
+	editCommentArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+		arg4 string
+	}
+	editCommentReturns struct {
+		result1 error
+	}
+	editCommentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EditIssueStub        func(string, string, int, string) error
+	editIssueMutex       sync.RWMutex
+	editIssueArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}
+	editIssueReturns struct {
+		result1 error
+	}
+	editIssueReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GatherReleaseNotesStub        func(*options.Options) (*notes.ReleaseNotes, error)
+	gatherReleaseNotesMutex       sync.RWMutex
+	gatherReleaseNotesArgsForCall []struct {
+		arg1 *options.Options
+	}
+	gatherReleaseNotesReturns struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}
+	gatherReleaseNotesReturnsOnCall map[int]struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}
+	ListCommentsStub        func(string, string, int) ([]*github.IssueComment, error)
+	listCommentsMutex       sync.RWMutex
+	listCommentsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}
+	listCommentsReturns struct {
+		result1 []*github.IssueComment
+		result2 error
+	}
+	listCommentsReturnsOnCall map[int]struct {
+		result1 []*github.IssueComment
+		result2 error
+	}
+	LoginStub        func() (string, error)
+	loginMutex       sync.RWMutex
+	loginArgsForCall []struct {
+	}
+	loginReturns struct {
+		result1 string
+		result2 error
+	}
+	loginReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	SearchIssuesStub        func(string, string, string, string) ([]*github.Issue, error)
+	searchIssuesMutex       sync.RWMutex
+	searchIssuesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	searchIssuesReturns struct {
+		result1 []*github.Issue
+		result2 error
+	}
+	searchIssuesReturnsOnCall map[int]struct {
+		result1 []*github.Issue
+		result2 error
+	}
+	SleepStub        func(context.Context, time.Duration) error
+	sleepMutex       sync.RWMutex
+	sleepArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}
+	sleepReturns struct {
+		result1 error
+	}
+	sleepReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) CreateComment(arg1 string, arg2 string, arg3 int, arg4 string) error {
+	fake.createCommentMutex.Lock()
+	ret, specificReturn := fake.createCommentReturnsOnCall[len(fake.createCommentArgsForCall)]
+	fake.createCommentArgsForCall = append(fake.createCommentArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CreateCommentStub
+	fakeReturns := fake.createCommentReturns
+	fake.recordInvocation("CreateComment", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createCommentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CreateCommentCallCount() int {
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	return len(fake.createCommentArgsForCall)
+}
+
+func (fake *FakeImpl) CreateCommentCalls(stub func(string, string, int, string) error) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = stub
+}
+
+func (fake *FakeImpl) CreateCommentArgsForCall(i int) (string, string, int, string) {
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	argsForCall := fake.createCommentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) CreateCommentReturns(result1 error) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = nil
+	fake.createCommentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CreateCommentReturnsOnCall(i int, result1 error) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = nil
+	if fake.createCommentReturnsOnCall == nil {
+		fake.createCommentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createCommentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CreateIssue(arg1 string, arg2 string, arg3 string, arg4 string) (*github.Issue, error) {
+	fake.createIssueMutex.Lock()
+	ret, specificReturn := fake.createIssueReturnsOnCall[len(fake.createIssueArgsForCall)]
+	fake.createIssueArgsForCall = append(fake.createIssueArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CreateIssueStub
+	fakeReturns := fake.createIssueReturns
+	fake.recordInvocation("CreateIssue", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createIssueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) CreateIssueCallCount() int {
+	fake.createIssueMutex.RLock()
+	defer fake.createIssueMutex.RUnlock()
+	return len(fake.createIssueArgsForCall)
+}
+
+func (fake *FakeImpl) CreateIssueCalls(stub func(string, string, string, string) (*github.Issue, error)) {
+	fake.createIssueMutex.Lock()
+	defer fake.createIssueMutex.Unlock()
+	fake.CreateIssueStub = stub
+}
+
+func (fake *FakeImpl) CreateIssueArgsForCall(i int) (string, string, string, string) {
+	fake.createIssueMutex.RLock()
+	defer fake.createIssueMutex.RUnlock()
+	argsForCall := fake.createIssueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) CreateIssueReturns(result1 *github.Issue, result2 error) {
+	fake.createIssueMutex.Lock()
+	defer fake.createIssueMutex.Unlock()
+	fake.CreateIssueStub = nil
+	fake.createIssueReturns = struct {
+		result1 *github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) CreateIssueReturnsOnCall(i int, result1 *github.Issue, result2 error) {
+	fake.createIssueMutex.Lock()
+	defer fake.createIssueMutex.Unlock()
+	fake.CreateIssueStub = nil
+	if fake.createIssueReturnsOnCall == nil {
+		fake.createIssueReturnsOnCall = make(map[int]struct {
+			result1 *github.Issue
+			result2 error
+		})
+	}
+	fake.createIssueReturnsOnCall[i] = struct {
+		result1 *github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) EditComment(arg1 string, arg2 string, arg3 int64, arg4 string) error {
+	fake.editCommentMutex.Lock()
+	ret, specificReturn := fake.editCommentReturnsOnCall[len(fake.editCommentArgsForCall)]
+	fake.editCommentArgsForCall = append(fake.editCommentArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.EditCommentStub
+	fakeReturns := fake.editCommentReturns
+	fake.recordInvocation("EditComment", []interface{}{arg1, arg2, arg3, arg4})
+	fake.editCommentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) EditCommentCallCount() int {
+	fake.editCommentMutex.RLock()
+	defer fake.editCommentMutex.RUnlock()
+	return len(fake.editCommentArgsForCall)
+}
+
+func (fake *FakeImpl) EditCommentCalls(stub func(string, string, int64, string) error) {
+	fake.editCommentMutex.Lock()
+	defer fake.editCommentMutex.Unlock()
+	fake.EditCommentStub = stub
+}
+
+func (fake *FakeImpl) EditCommentArgsForCall(i int) (string, string, int64, string) {
+	fake.editCommentMutex.RLock()
+	defer fake.editCommentMutex.RUnlock()
+	argsForCall := fake.editCommentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) EditCommentReturns(result1 error) {
+	fake.editCommentMutex.Lock()
+	defer fake.editCommentMutex.Unlock()
+	fake.EditCommentStub = nil
+	fake.editCommentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) EditCommentReturnsOnCall(i int, result1 error) {
+	fake.editCommentMutex.Lock()
+	defer fake.editCommentMutex.Unlock()
+	fake.EditCommentStub = nil
+	if fake.editCommentReturnsOnCall == nil {
+		fake.editCommentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.editCommentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) EditIssue(arg1 string, arg2 string, arg3 int, arg4 string) error {
+	fake.editIssueMutex.Lock()
+	ret, specificReturn := fake.editIssueReturnsOnCall[len(fake.editIssueArgsForCall)]
+	fake.editIssueArgsForCall = append(fake.editIssueArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.EditIssueStub
+	fakeReturns := fake.editIssueReturns
+	fake.recordInvocation("EditIssue", []interface{}{arg1, arg2, arg3, arg4})
+	fake.editIssueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) EditIssueCallCount() int {
+	fake.editIssueMutex.RLock()
+	defer fake.editIssueMutex.RUnlock()
+	return len(fake.editIssueArgsForCall)
+}
+
+func (fake *FakeImpl) EditIssueCalls(stub func(string, string, int, string) error) {
+	fake.editIssueMutex.Lock()
+	defer fake.editIssueMutex.Unlock()
+	fake.EditIssueStub = stub
+}
+
+func (fake *FakeImpl) EditIssueArgsForCall(i int) (string, string, int, string) {
+	fake.editIssueMutex.RLock()
+	defer fake.editIssueMutex.RUnlock()
+	argsForCall := fake.editIssueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) EditIssueReturns(result1 error) {
+	fake.editIssueMutex.Lock()
+	defer fake.editIssueMutex.Unlock()
+	fake.EditIssueStub = nil
+	fake.editIssueReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) EditIssueReturnsOnCall(i int, result1 error) {
+	fake.editIssueMutex.Lock()
+	defer fake.editIssueMutex.Unlock()
+	fake.EditIssueStub = nil
+	if fake.editIssueReturnsOnCall == nil {
+		fake.editIssueReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.editIssueReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) GatherReleaseNotes(arg1 *options.Options) (*notes.ReleaseNotes, error) {
+	fake.gatherReleaseNotesMutex.Lock()
+	ret, specificReturn := fake.gatherReleaseNotesReturnsOnCall[len(fake.gatherReleaseNotesArgsForCall)]
+	fake.gatherReleaseNotesArgsForCall = append(fake.gatherReleaseNotesArgsForCall, struct {
+		arg1 *options.Options
+	}{arg1})
+	stub := fake.GatherReleaseNotesStub
+	fakeReturns := fake.gatherReleaseNotesReturns
+	fake.recordInvocation("GatherReleaseNotes", []interface{}{arg1})
+	fake.gatherReleaseNotesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GatherReleaseNotesCallCount() int {
+	fake.gatherReleaseNotesMutex.RLock()
+	defer fake.gatherReleaseNotesMutex.RUnlock()
+	return len(fake.gatherReleaseNotesArgsForCall)
+}
+
+func (fake *FakeImpl) GatherReleaseNotesCalls(stub func(*options.Options) (*notes.ReleaseNotes, error)) {
+	fake.gatherReleaseNotesMutex.Lock()
+	defer fake.gatherReleaseNotesMutex.Unlock()
+	fake.GatherReleaseNotesStub = stub
+}
+
+func (fake *FakeImpl) GatherReleaseNotesArgsForCall(i int) *options.Options {
+	fake.gatherReleaseNotesMutex.RLock()
+	defer fake.gatherReleaseNotesMutex.RUnlock()
+	argsForCall := fake.gatherReleaseNotesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) GatherReleaseNotesReturns(result1 *notes.ReleaseNotes, result2 error) {
+	fake.gatherReleaseNotesMutex.Lock()
+	defer fake.gatherReleaseNotesMutex.Unlock()
+	fake.GatherReleaseNotesStub = nil
+	fake.gatherReleaseNotesReturns = struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GatherReleaseNotesReturnsOnCall(i int, result1 *notes.ReleaseNotes, result2 error) {
+	fake.gatherReleaseNotesMutex.Lock()
+	defer fake.gatherReleaseNotesMutex.Unlock()
+	fake.GatherReleaseNotesStub = nil
+	if fake.gatherReleaseNotesReturnsOnCall == nil {
+		fake.gatherReleaseNotesReturnsOnCall = make(map[int]struct {
+			result1 *notes.ReleaseNotes
+			result2 error
+		})
+	}
+	fake.gatherReleaseNotesReturnsOnCall[i] = struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListComments(arg1 string, arg2 string, arg3 int) ([]*github.IssueComment, error) {
+	fake.listCommentsMutex.Lock()
+	ret, specificReturn := fake.listCommentsReturnsOnCall[len(fake.listCommentsArgsForCall)]
+	fake.listCommentsArgsForCall = append(fake.listCommentsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.ListCommentsStub
+	fakeReturns := fake.listCommentsReturns
+	fake.recordInvocation("ListComments", []interface{}{arg1, arg2, arg3})
+	fake.listCommentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ListCommentsCallCount() int {
+	fake.listCommentsMutex.RLock()
+	defer fake.listCommentsMutex.RUnlock()
+	return len(fake.listCommentsArgsForCall)
+}
+
+func (fake *FakeImpl) ListCommentsCalls(stub func(string, string, int) ([]*github.IssueComment, error)) {
+	fake.listCommentsMutex.Lock()
+	defer fake.listCommentsMutex.Unlock()
+	fake.ListCommentsStub = stub
+}
+
+func (fake *FakeImpl) ListCommentsArgsForCall(i int) (string, string, int) {
+	fake.listCommentsMutex.RLock()
+	defer fake.listCommentsMutex.RUnlock()
+	argsForCall := fake.listCommentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) ListCommentsReturns(result1 []*github.IssueComment, result2 error) {
+	fake.listCommentsMutex.Lock()
+	defer fake.listCommentsMutex.Unlock()
+	fake.ListCommentsStub = nil
+	fake.listCommentsReturns = struct {
+		result1 []*github.IssueComment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListCommentsReturnsOnCall(i int, result1 []*github.IssueComment, result2 error) {
+	fake.listCommentsMutex.Lock()
+	defer fake.listCommentsMutex.Unlock()
+	fake.ListCommentsStub = nil
+	if fake.listCommentsReturnsOnCall == nil {
+		fake.listCommentsReturnsOnCall = make(map[int]struct {
+			result1 []*github.IssueComment
+			result2 error
+		})
+	}
+	fake.listCommentsReturnsOnCall[i] = struct {
+		result1 []*github.IssueComment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Login() (string, error) {
+	fake.loginMutex.Lock()
+	ret, specificReturn := fake.loginReturnsOnCall[len(fake.loginArgsForCall)]
+	fake.loginArgsForCall = append(fake.loginArgsForCall, struct {
+	}{})
+	stub := fake.LoginStub
+	fakeReturns := fake.loginReturns
+	fake.recordInvocation("Login", []interface{}{})
+	fake.loginMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) LoginCallCount() int {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	return len(fake.loginArgsForCall)
+}
+
+func (fake *FakeImpl) LoginCalls(stub func() (string, error)) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = stub
+}
+
+func (fake *FakeImpl) LoginReturns(result1 string, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	fake.loginReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) LoginReturnsOnCall(i int, result1 string, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	if fake.loginReturnsOnCall == nil {
+		fake.loginReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.loginReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SearchIssues(arg1 string, arg2 string, arg3 string, arg4 string) ([]*github.Issue, error) {
+	fake.searchIssuesMutex.Lock()
+	ret, specificReturn := fake.searchIssuesReturnsOnCall[len(fake.searchIssuesArgsForCall)]
+	fake.searchIssuesArgsForCall = append(fake.searchIssuesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SearchIssuesStub
+	fakeReturns := fake.searchIssuesReturns
+	fake.recordInvocation("SearchIssues", []interface{}{arg1, arg2, arg3, arg4})
+	fake.searchIssuesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) SearchIssuesCallCount() int {
+	fake.searchIssuesMutex.RLock()
+	defer fake.searchIssuesMutex.RUnlock()
+	return len(fake.searchIssuesArgsForCall)
+}
+
+func (fake *FakeImpl) SearchIssuesCalls(stub func(string, string, string, string) ([]*github.Issue, error)) {
+	fake.searchIssuesMutex.Lock()
+	defer fake.searchIssuesMutex.Unlock()
+	fake.SearchIssuesStub = stub
+}
+
+func (fake *FakeImpl) SearchIssuesArgsForCall(i int) (string, string, string, string) {
+	fake.searchIssuesMutex.RLock()
+	defer fake.searchIssuesMutex.RUnlock()
+	argsForCall := fake.searchIssuesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) SearchIssuesReturns(result1 []*github.Issue, result2 error) {
+	fake.searchIssuesMutex.Lock()
+	defer fake.searchIssuesMutex.Unlock()
+	fake.SearchIssuesStub = nil
+	fake.searchIssuesReturns = struct {
+		result1 []*github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SearchIssuesReturnsOnCall(i int, result1 []*github.Issue, result2 error) {
+	fake.searchIssuesMutex.Lock()
+	defer fake.searchIssuesMutex.Unlock()
+	fake.SearchIssuesStub = nil
+	if fake.searchIssuesReturnsOnCall == nil {
+		fake.searchIssuesReturnsOnCall = make(map[int]struct {
+			result1 []*github.Issue
+			result2 error
+		})
+	}
+	fake.searchIssuesReturnsOnCall[i] = struct {
+		result1 []*github.Issue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Sleep(arg1 context.Context, arg2 time.Duration) error {
+	fake.sleepMutex.Lock()
+	ret, specificReturn := fake.sleepReturnsOnCall[len(fake.sleepArgsForCall)]
+	fake.sleepArgsForCall = append(fake.sleepArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.SleepStub
+	fakeReturns := fake.sleepReturns
+	fake.recordInvocation("Sleep", []interface{}{arg1, arg2})
+	fake.sleepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) SleepCallCount() int {
+	fake.sleepMutex.RLock()
+	defer fake.sleepMutex.RUnlock()
+	return len(fake.sleepArgsForCall)
+}
+
+func (fake *FakeImpl) SleepCalls(stub func(context.Context, time.Duration) error) {
+	fake.sleepMutex.Lock()
+	defer fake.sleepMutex.Unlock()
+	fake.SleepStub = stub
+}
+
+func (fake *FakeImpl) SleepArgsForCall(i int) (context.Context, time.Duration) {
+	fake.sleepMutex.RLock()
+	defer fake.sleepMutex.RUnlock()
+	argsForCall := fake.sleepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) SleepReturns(result1 error) {
+	fake.sleepMutex.Lock()
+	defer fake.sleepMutex.Unlock()
+	fake.SleepStub = nil
+	fake.sleepReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) SleepReturnsOnCall(i int, result1 error) {
+	fake.sleepMutex.Lock()
+	defer fake.sleepMutex.Unlock()
+	fake.SleepStub = nil
+	if fake.sleepReturnsOnCall == nil {
+		fake.sleepReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.sleepReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	fake.createIssueMutex.RLock()
+	defer fake.createIssueMutex.RUnlock()
+	fake.editCommentMutex.RLock()
+	defer fake.editCommentMutex.RUnlock()
+	fake.editIssueMutex.RLock()
+	defer fake.editIssueMutex.RUnlock()
+	fake.gatherReleaseNotesMutex.RLock()
+	defer fake.gatherReleaseNotesMutex.RUnlock()
+	fake.listCommentsMutex.RLock()
+	defer fake.listCommentsMutex.RUnlock()
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	fake.searchIssuesMutex.RLock()
+	defer fake.searchIssuesMutex.RUnlock()
+	fake.sleepMutex.RLock()
+	defer fake.sleepMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"github.com/blang/semver/v4"
+
+	"k8s.io/release/pkg/release"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt planfakes/fake_impl.go > planfakes/_fake_impl.go && mv planfakes/_fake_impl.go planfakes/fake_impl.go"
+type impl interface {
+	BranchNeedsCreation(
+		branch, releaseType string, buildVersion semver.Version,
+	) (bool, error)
+	GenerateReleaseVersion(
+		releaseType, version, branch string, branchFromMaster bool,
+	) (*release.Versions, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) BranchNeedsCreation(
+	branch, releaseType string, buildVersion semver.Version,
+) (bool, error) {
+	return release.NewBranchChecker().NeedsCreation(
+		branch, releaseType, buildVersion,
+	)
+}
+
+func (*defaultImpl) GenerateReleaseVersion(
+	releaseType, version, branch string, branchFromMaster bool,
+) (*release.Versions, error) {
+	return release.GenerateReleaseVersion(
+		releaseType, version, branch, branchFromMaster,
+	)
+}
@@ -0,0 +1,397 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plan resolves the options of a release run into the ordered
+// actions of the stage and release jobs, similar to `terraform plan`. It
+// validates the options and resolves the versions, buckets, registries and
+// announcement targets without executing anything.
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/attribution"
+	"k8s.io/release/pkg/baseimage"
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/platforms"
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/vulnscan"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/util"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// OutputText prints the plan in a human readable format.
+	OutputText = "text"
+
+	// OutputJSON prints the plan as JSON.
+	OutputJSON = "json"
+
+	// OutputYAML prints the plan as YAML.
+	OutputYAML = "yaml"
+)
+
+const (
+	// PhaseStage are the actions of the stage job.
+	PhaseStage = "stage"
+
+	// PhaseRelease are the actions of the release job.
+	PhaseRelease = "release"
+
+	// PhaseAnnounce are the actions after the release job.
+	PhaseAnnounce = "announce"
+)
+
+// unresolvedVersion is used in the actions if the versions are only known
+// after the build version got discovered.
+const unresolvedVersion = "<version>"
+
+// Plan is the resolved configuration and the ordered actions of a release.
+type Plan struct {
+	NoMock         bool     `json:"nomock"`
+	ReleaseType    string   `json:"releaseType"`
+	Branch         string   `json:"branch"`
+	CreateBranch   bool     `json:"createBranch"`
+	BuildVersion   string   `json:"buildVersion,omitempty"`
+	Commit         string   `json:"commit,omitempty"`
+	Versions       []string `json:"versions,omitempty"`
+	Bucket         string   `json:"bucket"`
+	Registry       string   `json:"registry"`
+	TargetRegistry string   `json:"targetRegistry"`
+	Platforms      []string `json:"platforms"`
+	Announce       []string `json:"announce"`
+
+	// Actions are the ordered actions of all phases.
+	Actions []Action `json:"actions"`
+
+	// Problems are the validation failures, which would fail the run.
+	Problems []string `json:"problems,omitempty"`
+}
+
+// Action is a single step of a release phase.
+type Action struct {
+	Phase       string `json:"phase"`
+	Step        string `json:"step"`
+	Description string `json:"description"`
+
+	// NonFatal is true if a failure of the step only produces a warning.
+	NonFatal bool `json:"nonFatal,omitempty"`
+}
+
+// Planner resolves the plan of a release.
+type Planner struct {
+	impl    impl
+	options *anago.StageOptions
+}
+
+// New creates a new Planner for the provided options. The stage options
+// are a superset of the release ones.
+func New(options *anago.StageOptions) *Planner {
+	return &Planner{
+		impl:    &defaultImpl{},
+		options: options,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (p *Planner) SetImpl(impl impl) {
+	p.impl = impl
+}
+
+// Plan validates the options and resolves the plan. Validation failures
+// are part of the plan, while an error is returned if the plan cannot be
+// resolved at all.
+func (p *Planner) Plan() (*Plan, error) {
+	o := p.options
+	res := &Plan{
+		NoMock:         o.NoMock,
+		ReleaseType:    o.ReleaseType,
+		Branch:         o.ReleaseBranch,
+		BuildVersion:   o.BuildVersion,
+		Commit:         o.Commit,
+		Bucket:         o.Bucket(),
+		Registry:       o.ContainerRegistry(),
+		TargetRegistry: o.ContainerRegistry(),
+		Platforms:      platforms.Default().Strings(),
+	}
+	if res.Registry == release.GCRIOPathStaging {
+		res.TargetRegistry = branding.Default().Registry
+	}
+	for _, group := range (&announce.SendOptions{NoMock: o.NoMock}).Recipients() {
+		res.Announce = append(res.Announce, string(group))
+	}
+
+	if err := o.Validate(&anago.State{}); err != nil {
+		res.Problems = append(res.Problems, err.Error())
+	} else if o.BuildVersion != "" {
+		buildVersion, err := util.TagStringToSemver(o.BuildVersion)
+		if err != nil {
+			return nil, fmt.Errorf("parse build version: %w", err)
+		}
+		res.CreateBranch, err = p.impl.BranchNeedsCreation(
+			o.ReleaseBranch, o.ReleaseType, buildVersion,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("check if release branch needs creation: %w", err)
+		}
+		versions, err := p.impl.GenerateReleaseVersion(
+			o.ReleaseType, o.BuildVersion, o.ReleaseBranch, res.CreateBranch,
+		)
+		if err != nil {
+			res.Problems = append(res.Problems, fmt.Sprintf("generate release versions: %v", err))
+		} else {
+			res.Versions = versions.Ordered()
+		}
+	}
+
+	res.Actions = append(p.stageActions(res), p.releaseActions(res)...)
+	res.Actions = append(res.Actions, Action{
+		Phase: PhaseAnnounce,
+		Step:  "send announcement",
+		Description: fmt.Sprintf(
+			"Mail the announcement of %s to %s by running krel announce send",
+			res.prime(), strings.Join(res.Announce, ", "),
+		),
+	})
+	return res, nil
+}
+
+// Valid returns true if the plan has no problems.
+func (p *Plan) Valid() bool {
+	return len(p.Problems) == 0
+}
+
+// prime returns the main version of the release.
+func (p *Plan) prime() string {
+	if len(p.Versions) == 0 {
+		return unresolvedVersion
+	}
+	return p.Versions[0]
+}
+
+// versions returns all versions of the release for descriptions.
+func (p *Plan) versions() string {
+	if len(p.Versions) == 0 {
+		return unresolvedVersion
+	}
+	return strings.Join(p.Versions, ", ")
+}
+
+// actions returns the actions of a phase in the order of its steps, which
+// get described by the provided descriptions.
+func actions(phase string, steps []anago.Step, descriptions map[string]string) []Action {
+	res := make([]Action, 0, len(steps))
+	for _, step := range steps {
+		res = append(res, Action{
+			Phase:       phase,
+			Step:        step.Name,
+			Description: descriptions[step.Name],
+			NonFatal:    step.NonFatal,
+		})
+	}
+	return res
+}
+
+// commonDescriptions describes the steps shared by the stage and release
+// phases.
+func (p *Planner) commonDescriptions(res *Plan) map[string]string {
+	buildVersion := res.BuildVersion
+	if buildVersion == "" {
+		buildVersion = "the latest green CI build"
+	}
+	branchState := "Use the existing release branch " + res.Branch
+	if res.CreateBranch {
+		branchState = fmt.Sprintf("Create the release branch %s from %s", res.Branch, git.DefaultBranch)
+	}
+	return map[string]string{
+		"validate options": fmt.Sprintf(
+			"Validate the %s release of %s from %s", res.ReleaseType, res.Branch, buildVersion,
+		),
+		"check prerequisites":        "Verify the GitHub token, required packages, Google Cloud project and free disk space",
+		"check release branch state": branchState,
+		"generate release version":   "Generate the versions " + res.versions(),
+		"prepare workspace":          "Prepare a clean checkout of " + git.DefaultGithubOrg + "/" + git.DefaultGithubRepo,
+	}
+}
+
+func (p *Planner) stageActions(res *Plan) []Action {
+	o := p.options
+
+	ciSignal := fmt.Sprintf(
+		"Verify the release blocking TestGrid jobs of %s, tolerating %d flaky jobs",
+		res.Branch, o.AllowedFlakyJobs,
+	)
+	if !res.NoMock {
+		ciSignal += ", only logged in mock mode"
+	}
+	if o.SkipCISignalCheck {
+		ciSignal = "Skipped by --skip-ci-signal-check"
+	}
+
+	tag := fmt.Sprintf("Tag %s at the head of %s", res.versions(), res.Branch)
+	if res.Commit != "" {
+		tag = fmt.Sprintf("Tag %s at commit %s", res.versions(), res.Commit)
+	}
+
+	scan := fmt.Sprintf("Scan the images for vulnerabilities in %q mode", o.VulnerabilityScanOptions().Mode)
+	if o.VulnerabilityScanOptions().Mode == vulnscan.ModeOff {
+		scan = "Skipped, the vulnerability scan is off"
+	}
+
+	descriptions := p.commonDescriptions(res)
+	descriptions["tag repository"] = tag
+	descriptions["build"] = "Cross build the platforms " + strings.Join(res.Platforms, ", ")
+	descriptions["generate changelog"] = "Generate the changelog of " + res.prime()
+	descriptions["generate attribution"] = "Assemble the license attribution archive " + attribution.ArchiveName
+	descriptions["verify artifacts"] = "Verify the built artifacts"
+	descriptions["scan images"] = scan
+	descriptions["generate bill of materials"] = "Generate the SPDX bill of materials"
+	descriptions["stage artifacts"] = fmt.Sprintf(
+		"Stage the artifacts to gs://%s and the images to %s", res.Bucket, res.Registry,
+	)
+	descriptions["report artifact sizes"] = fmt.Sprintf(
+		"Report artifacts which grew more than %.1f%% since the previous release", o.SizeReportOptions().Threshold,
+	)
+	descriptions["update release cut issue"] = "Check off the stage in the release cut issue"
+
+	// The CI signal gets checked before submitting the stage job.
+	return append(
+		[]Action{{Phase: PhaseStage, Step: "check ci signal", Description: ciSignal}},
+		actions(PhaseStage, anago.NewStage(o).Steps(), descriptions)...,
+	)
+}
+
+func (p *Planner) releaseActions(res *Plan) []Action {
+	push := fmt.Sprintf(
+		"Push the binaries to gs://%s/release/%s and reference the images from %s",
+		res.Bucket, res.prime(), res.TargetRegistry,
+	)
+	if baseimage.Default().Enabled() {
+		push += ", verifying their base images against the policy"
+	}
+
+	pushGit := fmt.Sprintf("Push the tags %s", res.versions())
+	if res.Branch != git.DefaultBranch {
+		pushGit += " and the branch " + res.Branch
+	}
+	pushGit += " to " + git.DefaultGithubOrg + "/" + git.DefaultGithubRepo
+	page := "Print the GitHub release page of " + res.prime()
+	if res.NoMock {
+		page = fmt.Sprintf(
+			"Publish https://github.com/%s/%s/releases/tag/%s",
+			git.DefaultGithubOrg, git.DefaultGithubRepo, res.prime(),
+		)
+	} else {
+		pushGit = "Dry run: " + pushGit
+	}
+
+	descriptions := p.commonDescriptions(res)
+	descriptions["check provenance"] = "Verify the provenance attestation of the staged artifacts"
+	descriptions["check artifact anomalies"] = "Compare the artifacts with the previous release"
+	descriptions["push artifacts"] = push
+	descriptions["push git objects"] = pushGit
+	descriptions["create announcement"] = "Create the announcement of " + res.prime()
+	descriptions["update GitHub page"] = page
+	descriptions["archive"] = fmt.Sprintf(
+		"Archive the release to gs://%s/%s/anago-%s", res.Bucket, release.ArchivePath, res.prime(),
+	)
+	descriptions["update release cut issue"] = "Check off the release in the release cut issue"
+
+	steps := anago.NewRelease(&anago.ReleaseOptions{Options: p.options.Options}).Steps()
+	return actions(PhaseRelease, steps, descriptions)
+}
+
+// Write prints the plan in the provided output format.
+func (p *Plan) Write(w io.Writer, output string) error {
+	switch output {
+	case OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(p)
+	case OutputYAML:
+		content, err := yaml.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("marshal plan: %w", err)
+		}
+		_, err = w.Write(content)
+		return err
+	case OutputText, "":
+		return p.writeText(w)
+	default:
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+}
+
+func (p *Plan) writeText(w io.Writer) error {
+	mode := "mock"
+	if p.NoMock {
+		mode = "nomock"
+	}
+	createBranch := ""
+	if p.CreateBranch {
+		createBranch = " (new)"
+	}
+	buildVersion := p.BuildVersion
+	if buildVersion == "" {
+		buildVersion = "discovered on submit"
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Release plan (%s)\n\n", mode)
+	fmt.Fprintf(tw, "Type:\t%s\n", p.ReleaseType)
+	fmt.Fprintf(tw, "Branch:\t%s%s\n", p.Branch, createBranch)
+	fmt.Fprintf(tw, "Build version:\t%s\n", buildVersion)
+	if p.Commit != "" {
+		fmt.Fprintf(tw, "Commit:\t%s\n", p.Commit)
+	}
+	fmt.Fprintf(tw, "Versions:\t%s\n", p.versions())
+	fmt.Fprintf(tw, "Bucket:\tgs://%s\n", p.Bucket)
+	fmt.Fprintf(tw, "Registry:\t%s\n", p.Registry)
+	fmt.Fprintf(tw, "Target registry:\t%s\n", p.TargetRegistry)
+	fmt.Fprintf(tw, "Platforms:\t%s\n", strings.Join(p.Platforms, ", "))
+	fmt.Fprintf(tw, "Announce:\t%s\n", strings.Join(p.Announce, ", "))
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("write plan: %w", err)
+	}
+
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprint(tw, "\nActions:\n")
+	for i, action := range p.Actions {
+		description := action.Description
+		if action.NonFatal {
+			description += " (non-fatal)"
+		}
+		fmt.Fprintf(tw, "%d.\t%s\t%s\t%s\n", i+1, action.Phase, action.Step, description)
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("write plan: %w", err)
+	}
+
+	if !p.Valid() {
+		fmt.Fprint(w, "\nProblems:\n")
+		for _, problem := range p.Problems {
+			fmt.Fprintf(w, "- %s\n", problem)
+		}
+	}
+	return nil
+}
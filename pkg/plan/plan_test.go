@@ -0,0 +1,260 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/plan"
+	"k8s.io/release/pkg/plan/planfakes"
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/yaml"
+)
+
+const testBuildVersion = "v1.30.0-rc.0.34+a1b2c3d4e5f6a7"
+
+func testOptions(noMock bool) *anago.StageOptions {
+	opts := anago.DefaultStageOptions()
+	opts.NoMock = noMock
+	opts.ReleaseType = release.ReleaseTypeRC
+	opts.ReleaseBranch = "release-1.30"
+	opts.BuildVersion = testBuildVersion
+	return opts
+}
+
+func testImpl() *planfakes.FakeImpl {
+	mock := &planfakes.FakeImpl{}
+	mock.GenerateReleaseVersionReturns(
+		release.NewReleaseVersions("v1.30.0-rc.1", "", "v1.30.0-rc.1", "", ""), nil,
+	)
+	return mock
+}
+
+func action(t *testing.T, p *plan.Plan, phase, step string) plan.Action {
+	t.Helper()
+	for _, a := range p.Actions {
+		if a.Phase == phase && a.Step == step {
+			return a
+		}
+	}
+	require.Failf(t, "action not found", "%s %s", phase, step)
+	return plan.Action{}
+}
+
+func TestPlan(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		options  *anago.StageOptions
+		prepare  func(*planfakes.FakeImpl)
+		assert   func(*testing.T, *plan.Plan, *planfakes.FakeImpl)
+		errorMsg string
+	}{
+		{
+			name:    "mock release candidate",
+			options: testOptions(false),
+			assert: func(t *testing.T, p *plan.Plan, mock *planfakes.FakeImpl) {
+				require.True(t, p.Valid())
+				require.Equal(t, []string{"v1.30.0-rc.1"}, p.Versions)
+				require.Equal(t, release.TestBucket, p.Bucket)
+				require.Equal(t, release.GCRIOPathMock, p.Registry)
+				require.Equal(t, release.GCRIOPathMock, p.TargetRegistry)
+				require.Equal(t, []string{"kubernetes-announce-test"}, p.Announce)
+
+				branch, releaseType, _ := mock.BranchNeedsCreationArgsForCall(0)
+				require.Equal(t, "release-1.30", branch)
+				require.Equal(t, release.ReleaseTypeRC, releaseType)
+
+				require.Equal(t, "check ci signal", p.Actions[0].Step)
+				require.Equal(t, plan.PhaseAnnounce, p.Actions[len(p.Actions)-1].Phase)
+				require.Equal(t,
+					"Dry run: Push the tags v1.30.0-rc.1 and the branch release-1.30 to kubernetes/kubernetes",
+					action(t, p, plan.PhaseRelease, "push git objects").Description,
+				)
+				require.True(t, action(t, p, plan.PhaseStage, "report artifact sizes").NonFatal)
+			},
+		},
+		{
+			name:    "official release",
+			options: testOptions(true),
+			prepare: func(mock *planfakes.FakeImpl) {
+				mock.GenerateReleaseVersionReturns(
+					release.NewReleaseVersions("v1.30.1", "v1.30.1", "", "", ""), nil,
+				)
+			},
+			assert: func(t *testing.T, p *plan.Plan, _ *planfakes.FakeImpl) {
+				require.True(t, p.Valid())
+				require.Equal(t, release.ProductionBucket, p.Bucket)
+				require.Equal(t, release.GCRIOPathStaging, p.Registry)
+				require.Equal(t, branding.Default().Registry, p.TargetRegistry)
+				require.Equal(t, []string{"kubernetes-announce", "dev"}, p.Announce)
+				require.Equal(t,
+					"Publish https://github.com/kubernetes/kubernetes/releases/tag/v1.30.1",
+					action(t, p, plan.PhaseRelease, "update GitHub page").Description,
+				)
+			},
+		},
+		{
+			name: "new release branch",
+			options: func() *anago.StageOptions {
+				opts := testOptions(false)
+				opts.ReleaseType = release.ReleaseTypeBeta
+				return opts
+			}(),
+			prepare: func(mock *planfakes.FakeImpl) {
+				mock.BranchNeedsCreationReturns(true, nil)
+			},
+			assert: func(t *testing.T, p *plan.Plan, mock *planfakes.FakeImpl) {
+				require.True(t, p.CreateBranch)
+				_, _, _, fromMaster := mock.GenerateReleaseVersionArgsForCall(0)
+				require.True(t, fromMaster)
+				require.Equal(t,
+					"Create the release branch release-1.30 from master",
+					action(t, p, plan.PhaseStage, "check release branch state").Description,
+				)
+			},
+		},
+		{
+			name: "build version discovered on submit",
+			options: func() *anago.StageOptions {
+				opts := testOptions(false)
+				opts.BuildVersion = ""
+				opts.SkipCISignalCheck = true
+				return opts
+			}(),
+			assert: func(t *testing.T, p *plan.Plan, mock *planfakes.FakeImpl) {
+				require.True(t, p.Valid())
+				require.Empty(t, p.Versions)
+				require.Zero(t, mock.GenerateReleaseVersionCallCount())
+				require.Equal(t, "Skipped by --skip-ci-signal-check", p.Actions[0].Description)
+				require.Equal(t,
+					"Generate the versions <version>",
+					action(t, p, plan.PhaseStage, "generate release version").Description,
+				)
+			},
+		},
+		{
+			name: "invalid options",
+			options: func() *anago.StageOptions {
+				opts := testOptions(false)
+				opts.ReleaseType = "invalid"
+				return opts
+			}(),
+			assert: func(t *testing.T, p *plan.Plan, mock *planfakes.FakeImpl) {
+				require.False(t, p.Valid())
+				require.Len(t, p.Problems, 1)
+				require.Contains(t, p.Problems[0], "invalid release type")
+				require.Zero(t, mock.BranchNeedsCreationCallCount())
+			},
+		},
+		{
+			name:    "version generation fails",
+			options: testOptions(false),
+			prepare: func(mock *planfakes.FakeImpl) {
+				mock.GenerateReleaseVersionReturns(nil, errors.New("invalid branch"))
+			},
+			assert: func(t *testing.T, p *plan.Plan, _ *planfakes.FakeImpl) {
+				require.Equal(t, []string{"generate release versions: invalid branch"}, p.Problems)
+			},
+		},
+		{
+			name:    "branch check fails",
+			options: testOptions(false),
+			prepare: func(mock *planfakes.FakeImpl) {
+				mock.BranchNeedsCreationReturns(false, errors.New("network"))
+			},
+			errorMsg: "check if release branch needs creation",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := testImpl()
+			if tc.prepare != nil {
+				tc.prepare(mock)
+			}
+			sut := plan.New(tc.options)
+			sut.SetImpl(mock)
+
+			p, err := sut.Plan()
+			if tc.errorMsg != "" {
+				require.ErrorContains(t, err, tc.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			tc.assert(t, p, mock)
+		})
+	}
+}
+
+func TestPlanSteps(t *testing.T) {
+	opts := testOptions(true)
+	sut := plan.New(opts)
+	sut.SetImpl(testImpl())
+	p, err := sut.Plan()
+	require.NoError(t, err)
+
+	for phase, steps := range map[string][]anago.Step{
+		plan.PhaseStage:   anago.NewStage(opts).Steps(),
+		plan.PhaseRelease: anago.NewRelease(&anago.ReleaseOptions{Options: opts.Options}).Steps(),
+	} {
+		actions := []plan.Action{}
+		for _, a := range p.Actions {
+			if a.Phase == phase && a.Step != "check ci signal" {
+				actions = append(actions, a)
+			}
+		}
+		require.Len(t, actions, len(steps), phase)
+		for i, step := range steps {
+			require.Equal(t, step.Name, actions[i].Step, phase)
+			require.Equal(t, step.NonFatal, actions[i].NonFatal, step.Name)
+			require.NotEmpty(t, actions[i].Description, "no description of %s step %q", phase, step.Name)
+		}
+	}
+}
+
+func TestPlanWrite(t *testing.T) {
+	sut := plan.New(testOptions(false))
+	sut.SetImpl(testImpl())
+	p, err := sut.Plan()
+	require.NoError(t, err)
+
+	text := &bytes.Buffer{}
+	require.NoError(t, p.Write(text, plan.OutputText))
+	require.Contains(t, text.String(), "Release plan (mock)")
+	require.Contains(t, text.String(), "Versions:         v1.30.0-rc.1")
+	require.Contains(t, text.String(), "(non-fatal)")
+	require.NotContains(t, text.String(), "Problems:")
+
+	out := &bytes.Buffer{}
+	require.NoError(t, p.Write(out, plan.OutputJSON))
+	res := &plan.Plan{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), res))
+	require.Equal(t, p, res)
+
+	out.Reset()
+	require.NoError(t, p.Write(out, plan.OutputYAML))
+	res = &plan.Plan{}
+	require.NoError(t, yaml.Unmarshal(out.Bytes(), res))
+	require.Equal(t, p, res)
+
+	require.Error(t, p.Write(out, "xml"))
+}
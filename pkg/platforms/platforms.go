@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package platforms defines the os/arch matrix of the Kubernetes builds. The
+// matrix defaults to the client platforms of the Kubernetes build system and
+// can be replaced or extended, for example with linux/riscv64, linux/loong64
+// or windows/arm64, without touching the build, push and validation code.
+package platforms
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+
+	"k8s.io/release/pkg/consts"
+)
+
+// DefaultPlatforms are the platforms built per default, which match the
+// client platforms of the Kubernetes build system.
+var DefaultPlatforms = []string{
+	"linux/amd64",
+	"linux/386",
+	"linux/arm",
+	"linux/arm64",
+	"linux/ppc64le",
+	"linux/s390x",
+	"darwin/amd64",
+	"darwin/arm64",
+	"windows/amd64",
+	"windows/386",
+}
+
+// Platform is a single os/arch pair.
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+// Parse parses a platform in the format <os>/<arch> or, as used for the
+// artifact directories, <os>-<arch>.
+func Parse(platform string) (Platform, error) {
+	sep := "/"
+	if !strings.Contains(platform, sep) {
+		sep = "-"
+	}
+	goos, arch, ok := strings.Cut(strings.TrimSpace(platform), sep)
+	if !ok || goos == "" || arch == "" || strings.ContainsAny(arch, "/-") {
+		return Platform{}, fmt.Errorf("invalid platform %q, expected <os>/<arch>", platform)
+	}
+	return Platform{OS: goos, Arch: arch}, nil
+}
+
+// String returns the platform in the format <os>/<arch>.
+func (p Platform) String() string {
+	return p.OS + "/" + p.Arch
+}
+
+// Dir returns the name of the artifact directories and tarball suffixes of
+// the platform, for example linux-riscv64.
+func (p Platform) Dir() string {
+	return p.OS + "-" + p.Arch
+}
+
+// Binary returns the file name of the binary on the platform.
+func (p Platform) Binary(name string) string {
+	if p.OS == "windows" {
+		return name + ".exe"
+	}
+	return name
+}
+
+// Options are the options for configuring the platform matrix.
+type Options struct {
+	// Platforms replace the default platform matrix if set.
+	Platforms []string
+
+	// ExtraPlatforms are added to the platform matrix.
+	ExtraPlatforms []string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{}
+}
+
+// AddFlags adds the platform matrix flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.Platforms,
+		"build-platforms",
+		o.Platforms,
+		"os/arch platforms to be built instead of the default matrix of the Kubernetes build system",
+	)
+	flags.StringSliceVar(
+		&o.ExtraPlatforms,
+		"extra-build-platforms",
+		o.ExtraPlatforms,
+		"os/arch platforms to be built in addition, for example linux/riscv64,linux/loong64,windows/arm64",
+	)
+}
+
+// Validate validates the options.
+func (o *Options) Validate() error {
+	_, err := New(o)
+	return err
+}
+
+// Matrix is an ordered set of platforms.
+type Matrix struct {
+	platforms []Platform
+	images    []string
+	custom    bool
+}
+
+// New creates a new Matrix for the provided options.
+func New(opts *Options) (*Matrix, error) {
+	platforms := DefaultPlatforms
+	if len(opts.Platforms) > 0 {
+		platforms = opts.Platforms
+	}
+
+	m := &Matrix{custom: len(opts.Platforms) > 0 || len(opts.ExtraPlatforms) > 0}
+	if len(opts.Platforms) == 0 {
+		m.images = append(m.images, consts.SupportedArchitectures...)
+	}
+	seen := map[Platform]bool{}
+	for _, s := range append(append([]string{}, platforms...), opts.ExtraPlatforms...) {
+		p, err := Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		m.platforms = append(m.platforms, p)
+	}
+
+	// The default matrix contains more linux platforms than the images get
+	// built for, so only the explicitly requested ones extend the images.
+	images := opts.ExtraPlatforms
+	if len(opts.Platforms) > 0 {
+		images = m.Strings()
+	}
+	for _, s := range images {
+		p, err := Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		if p.OS == "linux" && !slices.Contains(m.images, p.Arch) {
+			m.images = append(m.images, p.Arch)
+		}
+	}
+	return m, nil
+}
+
+// Platforms returns all platforms of the matrix.
+func (m *Matrix) Platforms() []Platform {
+	return append([]Platform{}, m.platforms...)
+}
+
+// Custom returns true if the matrix differs from the default one of the
+// Kubernetes build system.
+func (m *Matrix) Custom() bool {
+	return m.custom
+}
+
+// Contains returns true if the platform is part of the matrix.
+func (m *Matrix) Contains(p Platform) bool {
+	for _, platform := range m.platforms {
+		if platform == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Architectures returns the architectures of the matrix for the provided
+// operating system.
+func (m *Matrix) Architectures(goos string) []string {
+	arches := []string{}
+	for _, p := range m.platforms {
+		if p.OS == goos {
+			arches = append(arches, p.Arch)
+		}
+	}
+	return arches
+}
+
+// ImageArchitectures returns the architectures of the container images built
+// for the matrix, which are the consts.SupportedArchitectures for the
+// default matrix.
+func (m *Matrix) ImageArchitectures() []string {
+	return append([]string{}, m.images...)
+}
+
+// Strings returns the platforms in the format <os>/<arch>.
+func (m *Matrix) Strings() []string {
+	res := make([]string, 0, len(m.platforms))
+	for _, p := range m.platforms {
+		res = append(res, p.String())
+	}
+	return res
+}
+
+// KubeBuildPlatforms returns the value of KUBE_BUILD_PLATFORMS selecting the
+// matrix in the Kubernetes build system.
+func (m *Matrix) KubeBuildPlatforms() string {
+	return strings.Join(m.Strings(), " ")
+}
+
+var (
+	current       = defaultMatrix()
+	activeOptions *Options
+	mu            sync.RWMutex
+)
+
+// defaultMatrix returns the matrix of the DefaultPlatforms.
+func defaultMatrix() *Matrix {
+	m := &Matrix{images: append([]string{}, consts.SupportedArchitectures...)}
+	for _, s := range DefaultPlatforms {
+		if p, err := Parse(s); err == nil {
+			m.platforms = append(m.platforms, p)
+		}
+	}
+	return m
+}
+
+// Setup configures the global platform matrix.
+func Setup(opts *Options) error {
+	m, err := New(opts)
+	if err != nil {
+		return err
+	}
+	SetDefault(m)
+	mu.Lock()
+	activeOptions = opts
+	mu.Unlock()
+	return nil
+}
+
+// ActiveOptions returns the options of the last Setup, or nil if Setup was
+// not called.
+func ActiveOptions() *Options {
+	mu.RLock()
+	defer mu.RUnlock()
+	return activeOptions
+}
+
+// SetDefault sets the global platform matrix.
+func SetDefault(m *Matrix) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = m
+}
+
+// Default returns the global platform matrix.
+func Default() *Matrix {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platforms_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/platforms"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		input       string
+		expected    platforms.Platform
+		shouldError bool
+	}{
+		{input: "linux/riscv64", expected: platforms.Platform{OS: "linux", Arch: "riscv64"}},
+		{input: "windows-arm64", expected: platforms.Platform{OS: "windows", Arch: "arm64"}},
+		{input: " linux/loong64 ", expected: platforms.Platform{OS: "linux", Arch: "loong64"}},
+		{input: "linux", shouldError: true},
+		{input: "/amd64", shouldError: true},
+		{input: "linux/arm/v7", shouldError: true},
+		{input: "linux-arm-v7", shouldError: true},
+	} {
+		res, err := platforms.Parse(tc.input)
+		if tc.shouldError {
+			require.Error(t, err, tc.input)
+			continue
+		}
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.expected, res)
+	}
+}
+
+func TestPlatformNaming(t *testing.T) {
+	t.Parallel()
+
+	p := platforms.Platform{OS: "windows", Arch: "arm64"}
+	require.Equal(t, "windows/arm64", p.String())
+	require.Equal(t, "windows-arm64", p.Dir())
+	require.Equal(t, "kubectl.exe", p.Binary("kubectl"))
+
+	p = platforms.Platform{OS: "linux", Arch: "riscv64"}
+	require.Equal(t, "linux-riscv64", p.Dir())
+	require.Equal(t, "kubectl", p.Binary("kubectl"))
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	m, err := platforms.New(platforms.DefaultOptions())
+	require.NoError(t, err)
+	require.False(t, m.Custom())
+	require.Equal(t, platforms.DefaultPlatforms, m.Strings())
+	require.Equal(t, m.Strings(), platforms.Default().Strings())
+	require.Equal(t, []string{"amd64", "arm64", "ppc64le", "s390x"}, m.ImageArchitectures())
+	require.Equal(t, m.ImageArchitectures(), platforms.Default().ImageArchitectures())
+
+	m, err = platforms.New(&platforms.Options{
+		ExtraPlatforms: []string{"linux/riscv64", "linux/loong64", "windows/arm64", "linux/amd64"},
+	})
+	require.NoError(t, err)
+	require.True(t, m.Custom())
+	require.Len(t, m.Platforms(), len(platforms.DefaultPlatforms)+3)
+	require.True(t, m.Contains(platforms.Platform{OS: "linux", Arch: "loong64"}))
+	require.Equal(t, []string{"amd64", "386", "arm64"}, m.Architectures("windows"))
+	require.Equal(t, []string{"amd64", "arm64", "ppc64le", "s390x", "riscv64", "loong64"}, m.ImageArchitectures())
+
+	m, err = platforms.New(&platforms.Options{
+		Platforms:      []string{"linux/amd64", "linux/arm64"},
+		ExtraPlatforms: []string{"windows/arm64"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "linux/amd64 linux/arm64 windows/arm64", m.KubeBuildPlatforms())
+	require.Equal(t, []string{"amd64", "arm64"}, m.Architectures("linux"))
+	require.Equal(t, []string{"amd64", "arm64"}, m.ImageArchitectures())
+	require.Equal(t, []string{"arm64"}, m.Architectures("windows"))
+	require.Empty(t, m.Architectures("darwin"))
+	require.False(t, m.Contains(platforms.Platform{OS: "darwin", Arch: "arm64"}))
+
+	_, err = platforms.New(&platforms.Options{ExtraPlatforms: []string{"riscv64"}})
+	require.Error(t, err)
+	require.Error(t, (&platforms.Options{Platforms: []string{"linux"}}).Validate())
+}
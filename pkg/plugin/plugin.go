@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DirEnvKey is the environment variable to override the default plugin
+	// directory.
+	DirEnvKey = "KREL_PLUGIN_DIR"
+
+	// ManifestFile is the name of the manifest file of every plugin.
+	ManifestFile = "plugin.yaml"
+)
+
+// When defines if a hook runs before or after a phase.
+type When string
+
+const (
+	// Before runs the hook before the phase.
+	Before When = "before"
+
+	// After runs the hook after the phase succeeded.
+	After When = "after"
+)
+
+var nameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// Plugin is a single plugin as defined by its manifest.
+type Plugin struct {
+	// Name is the unique name of the plugin.
+	Name string `json:"name"`
+
+	// Description is a short description of the plugin.
+	Description string `json:"description,omitempty"`
+
+	// Command is the executable of the plugin, relative to the plugin
+	// directory.
+	Command string `json:"command"`
+
+	// Subcommand adds the plugin as krel subcommand if set to true.
+	Subcommand bool `json:"subcommand,omitempty"`
+
+	// Hooks are the release phases the plugin hooks into.
+	Hooks []Hook `json:"hooks,omitempty"`
+
+	// Dir is the directory of the plugin.
+	Dir string `json:"-"`
+}
+
+// Hook defines a release phase where the plugin has to be executed.
+type Hook struct {
+	// Phase is the name of the release phase, for example "build" or
+	// "push-artifacts".
+	Phase string `json:"phase"`
+
+	// When defines if the hook runs before or after the phase.
+	When When `json:"when"`
+}
+
+// Validate checks if the plugin is correctly defined.
+func (p *Plugin) Validate() error {
+	if !nameRegex.MatchString(p.Name) {
+		return fmt.Errorf("invalid plugin name %q, must consist of lower case alphanumeric characters or '-'", p.Name)
+	}
+	if p.Command == "" {
+		return errors.New("no command specified")
+	}
+	if p.Dir != "" {
+		info, err := os.Stat(p.CommandPath())
+		if err != nil {
+			return fmt.Errorf("check plugin command: %w", err)
+		}
+		if info.IsDir() || info.Mode()&0o111 == 0 {
+			return fmt.Errorf("plugin command %s is not executable", p.CommandPath())
+		}
+	}
+	for _, hook := range p.Hooks {
+		if hook.Phase == "" {
+			return errors.New("hook without phase")
+		}
+		if hook.When != Before && hook.When != After {
+			return fmt.Errorf(
+				"invalid hook time %q for phase %s, must be %q or %q",
+				hook.When, hook.Phase, Before, After,
+			)
+		}
+	}
+	return nil
+}
+
+// CommandPath returns the absolute path to the plugin command.
+func (p *Plugin) CommandPath() string {
+	if filepath.IsAbs(p.Command) {
+		return p.Command
+	}
+	return filepath.Join(p.Dir, p.Command)
+}
+
+// Manager discovers and executes plugins.
+type Manager struct {
+	impl    impl
+	plugins []*Plugin
+}
+
+// New creates a new Manager without any plugins.
+func New() *Manager {
+	return &Manager{impl: &defaultImpl{}}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (m *Manager) SetImpl(impl impl) {
+	m.impl = impl
+}
+
+// DefaultDir returns the plugin directory, which is either set via
+// $KREL_PLUGIN_DIR or defaults to ~/.krel/plugins.
+func DefaultDir() string {
+	if dir := os.Getenv(DirEnvKey); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".krel", "plugins")
+}
+
+// Load discovers all plugins from the sub directories of dir, which contain
+// a plugin manifest. A non existing directory results in no plugins.
+func (m *Manager) Load(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		logrus.Debugf("Plugin directory %s does not exist", dir)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read plugin directory: %w", err)
+	}
+
+	names := map[string]string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pluginDir := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(filepath.Join(pluginDir, ManifestFile))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("read plugin manifest: %w", err)
+		}
+
+		p := &Plugin{}
+		if err := yaml.UnmarshalStrict(content, p); err != nil {
+			return fmt.Errorf("unmarshal plugin manifest of %s: %w", pluginDir, err)
+		}
+		p.Dir = pluginDir
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("validate plugin %s: %w", pluginDir, err)
+		}
+		if other, ok := names[p.Name]; ok {
+			return fmt.Errorf("plugin %q is defined in %s and %s", p.Name, other, pluginDir)
+		}
+		names[p.Name] = pluginDir
+
+		logrus.Debugf("Found plugin %s in %s", p.Name, pluginDir)
+		m.plugins = append(m.plugins, p)
+	}
+
+	sort.Slice(m.plugins, func(i, j int) bool {
+		return m.plugins[i].Name < m.plugins[j].Name
+	})
+	return nil
+}
+
+// Plugins returns all loaded plugins sorted by their name.
+func (m *Manager) Plugins() []*Plugin {
+	return m.plugins
+}
+
+// RunHooks executes all plugins hooking into the phase at the provided
+// time. The plugins retrieve the hook details via the environment variables
+// KREL_PLUGIN_PHASE and KREL_PLUGIN_WHEN as well as the arguments
+// "hook <when> <phase>".
+func (m *Manager) RunHooks(ctx context.Context, phase string, when When) error {
+	for _, p := range m.plugins {
+		for _, hook := range p.Hooks {
+			if hook.Phase != phase || hook.When != when {
+				continue
+			}
+
+			logrus.Infof("Running plugin %s %s phase %s", p.Name, when, phase)
+			if err := m.impl.Execute(
+				ctx, p.Dir, p.CommandPath(),
+				[]string{"hook", string(when), phase},
+				[]string{
+					"KREL_PLUGIN_PHASE=" + phase,
+					"KREL_PLUGIN_WHEN=" + string(when),
+				},
+			); err != nil {
+				return fmt.Errorf("run plugin %s hook %s %s: %w", p.Name, when, phase, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RunCommand executes the plugin with the provided arguments.
+func (m *Manager) RunCommand(ctx context.Context, p *Plugin, args []string) error {
+	if err := m.impl.Execute(ctx, p.Dir, p.CommandPath(), args, nil); err != nil {
+		return fmt.Errorf("run plugin %s: %w", p.Name, err)
+	}
+	return nil
+}
+
+var (
+	defaultManager   = New()
+	defaultManagerMu sync.RWMutex
+)
+
+// SetDefault sets the manager used by the package level functions.
+func SetDefault(m *Manager) {
+	defaultManagerMu.Lock()
+	defer defaultManagerMu.Unlock()
+	defaultManager = m
+}
+
+// Phase converts a step name like "push artifacts" or "push_artifacts" into
+// a phase name like "push-artifacts", which allows referring to the phases on
+// the command line.
+func Phase(name string) string {
+	return strings.NewReplacer(" ", "-", "_", "-").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// RunHooks executes the hooks of the default manager.
+func RunHooks(ctx context.Context, phase string, when When) error {
+	defaultManagerMu.RLock()
+	defer defaultManagerMu.RUnlock()
+	return defaultManager.RunHooks(ctx, phase, when)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/plugin"
+	"k8s.io/release/pkg/plugin/pluginfakes"
+)
+
+func writePlugin(t *testing.T, dir, name, manifest string) {
+	pluginDir := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(pluginDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, plugin.ManifestFile), []byte(manifest), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "run.sh"), []byte("#!/bin/sh\n"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "noexec.sh"), []byte("#!/bin/sh\n"), 0o644))
+}
+
+func TestLoad(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		manifests   map[string]string
+		expected    []string
+		shouldError bool
+	}{
+		{
+			name: "success",
+			manifests: map[string]string{
+				"b": "name: second\ncommand: run.sh\n",
+				"a": "name: first\ncommand: run.sh\nsubcommand: true\nhooks:\n- phase: build\n  when: after\n",
+			},
+			expected: []string{"first", "second"},
+		},
+		{
+			name:      "no plugins",
+			manifests: map[string]string{},
+			expected:  []string{},
+		},
+		{
+			name:        "invalid name",
+			manifests:   map[string]string{"a": "name: Invalid_Name\ncommand: run.sh\n"},
+			shouldError: true,
+		},
+		{
+			name:        "missing command",
+			manifests:   map[string]string{"a": "name: plugin\ncommand: missing.sh\n"},
+			shouldError: true,
+		},
+		{
+			name:        "command not executable",
+			manifests:   map[string]string{"a": "name: plugin\ncommand: noexec.sh\n"},
+			shouldError: true,
+		},
+		{
+			name:        "invalid hook",
+			manifests:   map[string]string{"a": "name: plugin\ncommand: run.sh\nhooks:\n- phase: build\n  when: during\n"},
+			shouldError: true,
+		},
+		{
+			name: "duplicate name",
+			manifests: map[string]string{
+				"a": "name: plugin\ncommand: run.sh\n",
+				"b": "name: plugin\ncommand: run.sh\n",
+			},
+			shouldError: true,
+		},
+		{
+			name:        "unknown field",
+			manifests:   map[string]string{"a": "name: plugin\ncommand: run.sh\nfoo: bar\n"},
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, manifest := range tc.manifests {
+				writePlugin(t, dir, name, manifest)
+			}
+
+			sut := plugin.New()
+			err := sut.Load(dir)
+			if tc.shouldError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			names := []string{}
+			for _, p := range sut.Plugins() {
+				names = append(names, p.Name)
+			}
+			require.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestLoadMissingDir(t *testing.T) {
+	sut := plugin.New()
+	require.NoError(t, sut.Load(filepath.Join(t.TempDir(), "missing")))
+	require.Empty(t, sut.Plugins())
+}
+
+func TestRunHooks(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, dir, "a", "name: first\ncommand: run.sh\nhooks:\n- phase: build\n  when: before\n- phase: build\n  when: after\n")
+	writePlugin(t, dir, "b", "name: second\ncommand: run.sh\nhooks:\n- phase: push-artifacts\n  when: after\n")
+
+	for _, tc := range []struct {
+		name          string
+		phase         string
+		when          plugin.When
+		prepare       func(*pluginfakes.FakeImpl)
+		expectedCalls int
+		shouldError   bool
+	}{
+		{
+			name:          "before build",
+			phase:         "build",
+			when:          plugin.Before,
+			prepare:       func(*pluginfakes.FakeImpl) {},
+			expectedCalls: 1,
+		},
+		{
+			name:          "after push",
+			phase:         "push-artifacts",
+			when:          plugin.After,
+			prepare:       func(*pluginfakes.FakeImpl) {},
+			expectedCalls: 1,
+		},
+		{
+			name:          "no hooks",
+			phase:         "tag-repository",
+			when:          plugin.Before,
+			prepare:       func(*pluginfakes.FakeImpl) {},
+			expectedCalls: 0,
+		},
+		{
+			name:  "failure",
+			phase: "build",
+			when:  plugin.After,
+			prepare: func(mock *pluginfakes.FakeImpl) {
+				mock.ExecuteReturns(errors.New("error"))
+			},
+			expectedCalls: 1,
+			shouldError:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &pluginfakes.FakeImpl{}
+			tc.prepare(mock)
+
+			sut := plugin.New()
+			sut.SetImpl(mock)
+			require.NoError(t, sut.Load(dir))
+
+			err := sut.RunHooks(context.Background(), tc.phase, tc.when)
+			if tc.shouldError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedCalls, mock.ExecuteCallCount())
+
+			if tc.expectedCalls > 0 {
+				_, _, command, args, env := mock.ExecuteArgsForCall(0)
+				require.Equal(t, "run.sh", filepath.Base(command))
+				require.Equal(t, []string{"hook", string(tc.when), tc.phase}, args)
+				require.Contains(t, env, "KREL_PLUGIN_PHASE="+tc.phase)
+			}
+		})
+	}
+}
+
+func TestPhase(t *testing.T) {
+	require.Equal(t, "push-artifacts", plugin.Phase("push artifacts"))
+	require.Equal(t, "build", plugin.Phase(" Build "))
+	require.Equal(t, "stage-artifacts", plugin.Phase("stage_artifacts"))
+}
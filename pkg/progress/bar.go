@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"sync"
+
+	"github.com/cheggaaa/pb/v3"
+	"github.com/sirupsen/logrus"
+)
+
+// barTemplate renders the title, counters, bar, elapsed and remaining time.
+const barTemplate = `{{ string . "title" }} {{ counters . }} {{ bar . }} {{ percent . }} {{ etime . }} {{ rtime . "ETA %s" }}`
+
+// logSteps is the number of plain log messages of a bar.
+const logSteps = 10
+
+// Bar shows the progress of countable work, like uploaded files.
+type Bar struct {
+	mu      sync.Mutex
+	title   string
+	total   int
+	current int
+	logged  int
+	bar     *pb.ProgressBar
+}
+
+// NewBar starts a new bar for the total amount of work. Outside of
+// interactive terminals, the progress gets logged in steps of ten percent.
+func NewBar(title string, total int) *Bar {
+	b := &Bar{title: title, total: total}
+	if !Interactive() || total <= 0 {
+		logrus.Infof("%s: 0/%d", title, total)
+		return b
+	}
+
+	mu.Lock()
+	activeBars++
+	w := output
+	mu.Unlock()
+
+	b.bar = pb.New(total).
+		SetTemplateString(barTemplate).
+		Set("title", title).
+		SetWriter(w).
+		Start()
+	return b
+}
+
+// Increment marks one unit of work as done.
+func (b *Bar) Increment() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.current++
+	if b.bar != nil {
+		b.bar.Increment()
+		return
+	}
+
+	if b.total <= 0 {
+		return
+	}
+	if step := b.current * logSteps / b.total; step > b.logged && b.current < b.total {
+		b.logged = step
+		logrus.Infof("%s: %d/%d (%d%%)", b.title, b.current, b.total, b.current*100/b.total)
+	}
+}
+
+// Finish stops the bar.
+func (b *Bar) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.bar == nil {
+		logrus.Infof("%s: %d/%d finished", b.title, b.current, b.total)
+		return
+	}
+	if b.bar.IsFinished() {
+		return
+	}
+	b.bar.Finish()
+
+	mu.Lock()
+	activeBars--
+	mu.Unlock()
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package progress renders the progress of long running operations. In
+// interactive terminals it shows bars with an estimated time of arrival for
+// countable work like uploads, spinners for API heavy phases and a summary
+// footer of all phases. Outside of terminals, for example in CI, it degrades
+// to plain log messages.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-isatty"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-utils/env"
+)
+
+// ModeEnvKey is the environment variable containing the default mode.
+const ModeEnvKey = "KREL_PROGRESS"
+
+// Mode selects if the progress gets rendered interactively.
+type Mode string
+
+const (
+	// ModeAuto renders the progress interactively if stderr is a terminal
+	// and no CI environment got detected.
+	ModeAuto Mode = "auto"
+
+	// ModeAlways always renders the progress interactively.
+	ModeAlways Mode = "always"
+
+	// ModeNever always logs the progress as plain messages.
+	ModeNever Mode = "never"
+)
+
+// ciEnvKeys are environment variables indicating a CI run.
+var ciEnvKeys = []string{"CI", "PROW_JOB_ID", "BUILD_ID", "GITHUB_ACTIONS"}
+
+var (
+	mu          sync.RWMutex
+	interactive bool
+	output      io.Writer = os.Stderr
+
+	// activeBars counts the running bars, which pause the spinners.
+	activeBars int
+)
+
+// Options are the options for rendering the progress.
+type Options struct {
+	// Mode selects if the progress gets rendered interactively.
+	Mode Mode
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Mode: Mode(env.Default(ModeEnvKey, string(ModeAuto))),
+	}
+}
+
+// AddFlags adds the progress flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.Var(
+		(*modeValue)(&o.Mode),
+		"progress",
+		fmt.Sprintf("render progress bars and spinners: %s, %s or %s (default $%s or %s)", ModeAuto, ModeAlways, ModeNever, ModeEnvKey, ModeAuto),
+	)
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if !slices.Contains([]Mode{ModeAuto, ModeAlways, ModeNever}, o.Mode) {
+		return fmt.Errorf("invalid progress mode %q, expected %s, %s or %s", o.Mode, ModeAuto, ModeAlways, ModeNever)
+	}
+	return nil
+}
+
+// modeValue implements pflag.Value for the mode.
+type modeValue Mode
+
+func (m *modeValue) String() string { return string(*m) }
+
+func (m *modeValue) Set(value string) error {
+	mode := Mode(value)
+	if err := (&Options{Mode: mode}).Validate(); err != nil {
+		return err
+	}
+	*m = modeValue(mode)
+	return nil
+}
+
+func (*modeValue) Type() string { return "mode" }
+
+// Setup selects the rendering of the progress based on the options and the
+// environment. Interactive rendering clears the current progress line before
+// every log message, which keeps the log readable.
+func Setup(opts *Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	enabled := opts.Mode == ModeAlways
+	if opts.Mode == ModeAuto {
+		enabled = terminal() && !ci()
+	}
+	SetInteractive(enabled)
+
+	if enabled && logrus.StandardLogger().Out == os.Stderr {
+		logrus.SetOutput(&lineClearingWriter{os.Stderr})
+	}
+	return nil
+}
+
+// SetInteractive enables or disables the interactive rendering.
+func SetInteractive(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	interactive = enabled
+}
+
+// Interactive returns true if the progress gets rendered interactively.
+func Interactive() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return interactive
+}
+
+// terminal returns true if stderr is a terminal.
+func terminal() bool {
+	fd := os.Stderr.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// ci returns true if a CI environment got detected.
+func ci() bool {
+	for _, key := range ciEnvKeys {
+		if env.IsSet(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// lineClearingWriter clears the current terminal line before writing.
+type lineClearingWriter struct {
+	w io.Writer
+}
+
+func (l *lineClearingWriter) Write(p []byte) (int, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, err := io.WriteString(l.w, clearLine); err != nil {
+		return 0, err
+	}
+	return l.w.Write(p)
+}
+
+// clearLine moves the cursor to the start of the line and erases it.
+const clearLine = "\r\033[K"
+
+// write writes a line of the interactive progress.
+func write(format string, args ...any) {
+	mu.Lock()
+	defer mu.Unlock()
+	fmt.Fprintf(output, clearLine+strings.TrimSuffix(format, "\n"), args...)
+	if strings.HasSuffix(format, "\n") {
+		fmt.Fprintln(output)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a buffer which can be written concurrently.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+// setInteractive enables the interactive rendering into the returned buffer
+// for the duration of the test.
+func setInteractive(t *testing.T) *syncBuffer {
+	buf := &syncBuffer{}
+	mu.Lock()
+	previous := output
+	output = buf
+	mu.Unlock()
+	SetInteractive(true)
+
+	t.Cleanup(func() {
+		mu.Lock()
+		output = previous
+		mu.Unlock()
+		SetInteractive(false)
+	})
+	return buf
+}
+
+func TestSetup(t *testing.T) {
+	t.Cleanup(func() {
+		SetInteractive(false)
+		logrus.SetOutput(os.Stderr)
+	})
+
+	require.NoError(t, Setup(&Options{Mode: ModeAlways}))
+	require.True(t, Interactive())
+	require.IsType(t, &lineClearingWriter{}, logrus.StandardLogger().Out)
+
+	require.NoError(t, Setup(&Options{Mode: ModeNever}))
+	require.False(t, Interactive())
+
+	t.Setenv("CI", "true")
+	require.NoError(t, Setup(&Options{Mode: ModeAuto}))
+	require.False(t, Interactive())
+
+	require.ErrorContains(t, Setup(&Options{Mode: "sometimes"}), "invalid progress mode")
+
+	value := modeValue(ModeAuto)
+	require.NoError(t, value.Set("never"))
+	require.Equal(t, "never", value.String())
+	require.Error(t, value.Set("invalid"))
+}
+
+func TestBarPlain(t *testing.T) {
+	hook := test.NewGlobal()
+	t.Cleanup(hook.Reset)
+
+	bar := NewBar("Uploading", 20)
+	for i := 0; i < 20; i++ {
+		bar.Increment()
+	}
+	bar.Finish()
+
+	messages := []string{}
+	for _, entry := range hook.AllEntries() {
+		messages = append(messages, entry.Message)
+	}
+	require.Len(t, messages, 11)
+	require.Equal(t, "Uploading: 0/20", messages[0])
+	require.Equal(t, "Uploading: 2/20 (10%)", messages[1])
+	require.Equal(t, "Uploading: 18/20 (90%)", messages[9])
+	require.Equal(t, "Uploading: 20/20 finished", messages[10])
+}
+
+func TestBarInteractive(t *testing.T) {
+	buf := setInteractive(t)
+
+	bar := NewBar("Uploading", 3)
+	for i := 0; i < 3; i++ {
+		bar.Increment()
+	}
+	bar.Finish()
+	bar.Finish()
+
+	require.Contains(t, buf.String(), "Uploading 3 / 3")
+	require.Contains(t, buf.String(), "100.00%")
+	require.Zero(t, activeBars)
+}
+
+func TestSpinner(t *testing.T) {
+	buf := setInteractive(t)
+
+	spinner := NewSpinner("build")
+	time.Sleep(3 * spinnerInterval)
+	spinner.Stop(nil)
+	spinner.Stop(nil)
+	require.Contains(t, buf.String(), spinnerFrames[0]+" build")
+	require.True(t, strings.HasSuffix(buf.String(), "✓ build (0s)\n"))
+
+	NewSpinner("push").Stop(errors.New("failed"))
+	require.True(t, strings.HasSuffix(buf.String(), "✗ push (0s)\n"))
+}
+
+func TestSummary(t *testing.T) {
+	require.Nil(t, SummaryFromContext(context.Background()))
+	var disabled *Summary
+	disabled.Record("build", time.Second, nil)
+	disabled.Print(nil)
+
+	ctx, summary := NewSummary(context.Background(), "Stage")
+	require.Same(t, summary, SummaryFromContext(ctx))
+
+	SummaryFromContext(ctx).Record("build", 90*time.Second, nil)
+	SummaryFromContext(ctx).Record("stage artifacts", 2*time.Second, errors.New("upload failed\ndetails"))
+	require.Len(t, summary.Phases(), 2)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, summary.Write(buf, errors.New("stage artifacts")))
+	require.Contains(t, buf.String(), "Stage failed after 0s")
+	require.Contains(t, buf.String(), "  build            1m30s  ok\n")
+	require.Contains(t, buf.String(), "  stage artifacts  2s     failed: upload failed\n")
+
+	hook := test.NewGlobal()
+	t.Cleanup(hook.Reset)
+	summary.Print(nil)
+	require.Len(t, hook.AllEntries(), 3)
+	require.Equal(t, "Stage succeeded after 0s", hook.AllEntries()[0].Message)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// spinnerFrames are the animation frames of a spinner.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval is the duration between two frames.
+const spinnerInterval = 100 * time.Millisecond
+
+// Spinner shows that a phase of unknown length is running.
+type Spinner struct {
+	title string
+	start time.Time
+	stop  chan struct{}
+	once  sync.Once
+	wg    sync.WaitGroup
+}
+
+// NewSpinner starts a new spinner, which only gets rendered in interactive
+// terminals. Running bars pause the spinner.
+func NewSpinner(title string) *Spinner {
+	s := &Spinner{title: title, start: time.Now()}
+	if !Interactive() {
+		return s
+	}
+
+	s.stop = make(chan struct{})
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+		for frame := 0; ; frame++ {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				mu.RLock()
+				paused := activeBars > 0
+				mu.RUnlock()
+				if !paused {
+					write("%s %s (%s)", spinnerFrames[frame%len(spinnerFrames)], s.title, elapsed(s.start))
+				}
+			}
+		}
+	}()
+	return s
+}
+
+// Stop stops the spinner and shows the result of the phase.
+func (s *Spinner) Stop(err error) {
+	s.once.Do(func() {
+		if s.stop == nil {
+			logrus.Debugf("%s finished after %s", s.title, elapsed(s.start))
+			return
+		}
+		close(s.stop)
+		s.wg.Wait()
+
+		symbol := "✓"
+		if err != nil {
+			symbol = "✗"
+		}
+		write("%s %s (%s)\n", symbol, s.title, elapsed(s.start))
+	})
+}
+
+// elapsed returns the rounded duration since the start.
+func elapsed(start time.Time) time.Duration {
+	return time.Since(start).Round(time.Second)
+}
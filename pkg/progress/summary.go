@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Phase is a finished phase of a run.
+type Phase struct {
+	// Name is the name of the phase.
+	Name string
+
+	// Duration is the time the phase took.
+	Duration time.Duration
+
+	// Err is the error of the phase, which is nil if it succeeded.
+	Err error
+}
+
+// Summary collects the phases of a run for printing a footer.
+type Summary struct {
+	mu     sync.Mutex
+	title  string
+	start  time.Time
+	phases []Phase
+}
+
+type summaryKey struct{}
+
+// NewSummary returns a new summary of the run, which is additionally stored
+// in the returned context.
+func NewSummary(ctx context.Context, title string) (context.Context, *Summary) {
+	s := &Summary{title: title, start: time.Now()}
+	return context.WithValue(ctx, summaryKey{}, s), s
+}
+
+// SummaryFromContext returns the summary of the context or nil.
+func SummaryFromContext(ctx context.Context) *Summary {
+	s, ok := ctx.Value(summaryKey{}).(*Summary)
+	if !ok {
+		return nil
+	}
+	return s
+}
+
+// Record adds a finished phase to the summary. It is a no-op for a nil
+// summary.
+func (s *Summary) Record(name string, duration time.Duration, err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.phases = append(s.phases, Phase{Name: name, Duration: duration, Err: err})
+}
+
+// Phases returns the recorded phases.
+func (s *Summary) Phases() []Phase {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Phase{}, s.phases...)
+}
+
+// Write writes the summary footer as table to the writer.
+func (s *Summary) Write(w io.Writer, err error) error {
+	result := "succeeded"
+	if err != nil {
+		result = "failed"
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\n%s %s after %s\n", s.title, result, time.Since(s.start).Round(time.Second))
+	for _, phase := range s.Phases() {
+		status := "ok"
+		if phase.Err != nil {
+			status = "failed: " + strings.SplitN(phase.Err.Error(), "\n", 2)[0]
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", phase.Name, phase.Duration.Round(time.Second), status)
+	}
+	return tw.Flush()
+}
+
+// Print prints the summary footer of the run, which ended with the error.
+// In interactive terminals it gets written as table, while it gets logged
+// as plain messages otherwise. It is a no-op for a nil summary.
+func (s *Summary) Print(err error) {
+	if s == nil {
+		return
+	}
+
+	if Interactive() {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := s.Write(output, err); err != nil {
+			logrus.Warnf("Unable to print summary: %v", err)
+		}
+		return
+	}
+
+	builder := &strings.Builder{}
+	if err := s.Write(builder, err); err != nil {
+		logrus.Warnf("Unable to print summary: %v", err)
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSpace(builder.String()), "\n") {
+		logrus.Info(line)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenancecheck
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/bom/pkg/provenance"
+	"sigs.k8s.io/release-sdk/sign"
+	"sigs.k8s.io/release-utils/command"
+	"sigs.k8s.io/release-utils/hash"
+	"sigs.k8s.io/release-utils/http"
+
+	"k8s.io/release/pkg/workdir"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt provenancecheckfakes/fake_impl.go > provenancecheckfakes/_fake_impl.go && mv provenancecheckfakes/_fake_impl.go provenancecheckfakes/fake_impl.go"
+type impl interface {
+	MkdirTemp(pattern string) (string, error)
+	RemoveAll(path string) error
+	Download(url, dest string) error
+	SHA256ForFile(path string) (string, error)
+	VerifySignature(opts *sign.Options, path string) error
+	LoadStatement(path string) (*provenance.Statement, error)
+	TagCommit(repoURL, tag string) (string, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) MkdirTemp(pattern string) (string, error) {
+	return workdir.MkdirTemp(pattern)
+}
+
+func (*defaultImpl) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+func (*defaultImpl) Download(url, dest string) error {
+	content, err := http.NewAgent().Get(url)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dest, content, 0o600)
+}
+
+func (*defaultImpl) SHA256ForFile(path string) (string, error) {
+	return hash.SHA256ForFile(path)
+}
+
+func (*defaultImpl) VerifySignature(opts *sign.Options, path string) error {
+	signed, err := sign.New(opts).VerifyFile(path, false)
+	if err != nil {
+		return err
+	}
+	if signed == nil {
+		return errors.New("no signature found in the transparency log")
+	}
+	return nil
+}
+
+func (*defaultImpl) LoadStatement(path string) (*provenance.Statement, error) {
+	return provenance.LoadStatement(path)
+}
+
+// TagCommit returns the commit the annotated or lightweight tag points to.
+func (*defaultImpl) TagCommit(repoURL, tag string) (string, error) {
+	res, err := command.New(
+		"git", "ls-remote", repoURL, "refs/tags/"+tag, "refs/tags/"+tag+"^{}",
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return "", fmt.Errorf("listing remote tag: %w", err)
+	}
+
+	commit := ""
+	for _, line := range strings.Split(res.OutputTrimNL(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		// The peeled reference of annotated tags points to the commit
+		if commit == "" || strings.HasSuffix(fields[1], "^{}") {
+			commit = fields[0]
+		}
+	}
+	if commit == "" {
+		return "", fmt.Errorf("tag %s not found", tag)
+	}
+	return commit, nil
+}
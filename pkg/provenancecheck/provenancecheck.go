@@ -0,0 +1,343 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provenancecheck verifies published release artifacts against their
+// signature and SLSA provenance attestation.
+package provenancecheck
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	slsa "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-sdk/sign"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/release"
+)
+
+const (
+	// DefaultBuilderID is the builder identity of the Kubernetes release
+	// process, as set by krel stage.
+	DefaultBuilderID = "https://git.k8s.io/release/docs/krel"
+
+	// DefaultSourceRepo is the material URI of the Kubernetes sources.
+	DefaultSourceRepo = "git+https://github.com/kubernetes/kubernetes"
+)
+
+// Options are the main options for verifying the provenance of an artifact.
+type Options struct {
+	// Version is the release tag of the artifact, for example v1.29.1.
+	Version string
+
+	// Artifact is the path of the artifact below the release directory, for
+	// example bin/linux/amd64/kubectl.
+	Artifact string
+
+	// BaseURL is the location of the published release artifacts.
+	BaseURL string
+
+	// BuilderID is the expected builder identity of the attestation.
+	BuilderID string
+
+	// SourceRepo is the expected material URI of the built sources.
+	SourceRepo string
+
+	// CertIdentityRegexp is the expected identity of the signing
+	// certificate.
+	CertIdentityRegexp string
+
+	// CertOidcIssuer is the expected OIDC issuer of the signing certificate.
+	CertOidcIssuer string
+
+	// WorkDir is the directory the files get downloaded into. A temporary
+	// directory will be created and removed afterwards if not set.
+	WorkDir string
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	signOpts := sign.Default()
+	return &Options{
+		BaseURL:            release.ProductionBucketURL + "/release",
+		BuilderID:          DefaultBuilderID,
+		SourceRepo:         DefaultSourceRepo,
+		CertIdentityRegexp: signOpts.CertIdentityRegexp,
+		CertOidcIssuer:     signOpts.CertOidcIssuer,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if _, err := util.TagStringToSemver(o.Version); err != nil {
+		return fmt.Errorf("invalid version %q: %w", o.Version, err)
+	}
+	if o.Artifact == "" {
+		return errors.New("no artifact specified")
+	}
+	if o.BuilderID == "" {
+		return errors.New("no builder ID specified")
+	}
+	if o.SourceRepo == "" {
+		return errors.New("no source repository specified")
+	}
+	return nil
+}
+
+// Check is the result of a single verification step.
+type Check struct {
+	// Name of the verification step.
+	Name string `json:"name"`
+
+	// Passed is true if the verification succeeded.
+	Passed bool `json:"passed"`
+
+	// Detail explains the result.
+	Detail string `json:"detail"`
+}
+
+// Verdict is the verification result of an artifact.
+type Verdict struct {
+	// Artifact is the path of the artifact below the release directory.
+	Artifact string `json:"artifact"`
+
+	// Version is the release tag of the artifact.
+	Version string `json:"version"`
+
+	// SHA256 is the digest of the downloaded artifact.
+	SHA256 string `json:"sha256"`
+
+	// Checks are the results of all verification steps.
+	Checks []*Check `json:"checks"`
+}
+
+// Verified returns true if all checks passed.
+func (v *Verdict) Verified() bool {
+	for _, c := range v.Checks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return len(v.Checks) > 0
+}
+
+func (v *Verdict) add(name string, err error, detail string) {
+	check := &Check{Name: name, Passed: err == nil, Detail: detail}
+	if err != nil {
+		check.Detail = err.Error()
+	}
+	v.Checks = append(v.Checks, check)
+}
+
+// Verifier is the main structure for verifying provenance.
+type Verifier struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Verifier instance.
+func New(opts *Options) *Verifier {
+	return &Verifier{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (v *Verifier) SetImpl(impl impl) {
+	v.impl = impl
+}
+
+// Run verifies the artifact, prints the verdict and returns an error if any
+// of the checks failed.
+func (v *Verifier) Run() error {
+	verdict, err := v.Verify()
+	if err != nil {
+		return err
+	}
+
+	verdict.Print(os.Stdout)
+	return v.Check(verdict)
+}
+
+// Check fails if any of the checks of the verdict did not pass.
+func (v *Verifier) Check(verdict *Verdict) error {
+	if !verdict.Verified() {
+		failed := 0
+		for _, c := range verdict.Checks {
+			if !c.Passed {
+				failed++
+			}
+		}
+		return fmt.Errorf("%d of %d provenance checks failed", failed, len(verdict.Checks))
+	}
+	return nil
+}
+
+// Verify downloads the artifact, its signature and the provenance
+// attestation of the release and runs all checks.
+func (v *Verifier) Verify() (verdict *Verdict, err error) {
+	if err := v.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+	version := util.AddTagPrefix(v.options.Version)
+	artifact := strings.TrimPrefix(v.options.Artifact, "/")
+
+	workDir := v.options.WorkDir
+	if workDir == "" {
+		workDir, err = v.impl.MkdirTemp("k8s-provenance-")
+		if err != nil {
+			return nil, fmt.Errorf("create work directory: %w", err)
+		}
+		defer func() {
+			if err := v.impl.RemoveAll(workDir); err != nil {
+				logrus.Warnf("Unable to remove work directory %s: %v", workDir, err)
+			}
+		}()
+	}
+
+	baseURL := fmt.Sprintf("%s/%s", strings.TrimSuffix(v.options.BaseURL, "/"), version)
+	artifactPath := filepath.Join(workDir, path.Base(artifact))
+	statementPath := filepath.Join(workDir, release.ProvenanceFilename)
+	for _, download := range []struct{ url, dest string }{
+		{baseURL + "/" + artifact, artifactPath},
+		{baseURL + "/" + artifact + ".sig", artifactPath + ".sig"},
+		{baseURL + "/" + artifact + ".cert", artifactPath + ".cert"},
+		{baseURL + "/" + release.ProvenanceFilename, statementPath},
+	} {
+		logrus.Infof("Downloading %s", download.url)
+		if err := v.impl.Download(download.url, download.dest); err != nil {
+			return nil, fmt.Errorf("download %s: %w", download.url, err)
+		}
+	}
+
+	digest, err := v.impl.SHA256ForFile(artifactPath)
+	if err != nil {
+		return nil, fmt.Errorf("get artifact digest: %w", err)
+	}
+	verdict = &Verdict{Artifact: artifact, Version: version, SHA256: digest}
+
+	signOpts := sign.Default()
+	signOpts.CertIdentityRegexp = v.options.CertIdentityRegexp
+	signOpts.CertOidcIssuer = v.options.CertOidcIssuer
+	signOpts.OutputSignaturePath = artifactPath + ".sig"
+	signOpts.OutputCertificatePath = artifactPath + ".cert"
+	verdict.add(
+		"signature",
+		v.impl.VerifySignature(signOpts, artifactPath),
+		"signed by "+v.options.CertIdentityRegexp,
+	)
+
+	statement, err := v.impl.LoadStatement(statementPath)
+	if err != nil {
+		return nil, fmt.Errorf("load provenance attestation: %w", err)
+	}
+
+	verdict.add("predicate type", expect(
+		"predicate type", statement.PredicateType, slsa.PredicateSLSAProvenance,
+	), statement.PredicateType)
+
+	verdict.add("subject", checkSubject(statement.Subject, artifact, digest), "sha256:"+digest)
+
+	verdict.add("builder", expect(
+		"builder", statement.Predicate.Builder.ID, v.options.BuilderID,
+	), statement.Predicate.Builder.ID)
+
+	sourceErr := v.checkSource(statement.Predicate.Materials, version)
+	verdict.add("source", sourceErr, v.options.SourceRepo+"@"+version)
+
+	return verdict, nil
+}
+
+// checkSubject verifies that the attestation lists the artifact with its
+// digest.
+func checkSubject(subjects []intoto.Subject, artifact, digest string) error {
+	for _, subject := range subjects {
+		if subject.Name != artifact && !strings.HasSuffix(subject.Name, "/"+artifact) {
+			continue
+		}
+		if subject.Digest["sha256"] == digest {
+			return nil
+		}
+		return fmt.Errorf("attested sha256 %s does not match %s", subject.Digest["sha256"], digest)
+	}
+	return fmt.Errorf("artifact %s not found in attestation subjects", artifact)
+}
+
+// checkSource verifies that the attested materials contain the source
+// repository at the commit of the release tag.
+func (v *Verifier) checkSource(materials []slsa.ProvenanceMaterial, version string) error {
+	for _, material := range materials {
+		if material.URI != v.options.SourceRepo {
+			continue
+		}
+		commit, err := v.impl.TagCommit(strings.TrimPrefix(material.URI, "git+"), version)
+		if err != nil {
+			return fmt.Errorf("resolving tag %s: %w", version, err)
+		}
+		if material.Digest["sha1"] != commit {
+			return fmt.Errorf(
+				"attested commit %s does not match %s of tag %s",
+				material.Digest["sha1"], commit, version,
+			)
+		}
+		return nil
+	}
+	return fmt.Errorf("source repository %s not found in attestation materials", v.options.SourceRepo)
+}
+
+func expect(name, got, want string) error {
+	if got != want {
+		return fmt.Errorf("%s %q does not match %q", name, got, want)
+	}
+	return nil
+}
+
+// Print writes the checks and the verdict as human readable table.
+func (v *Verdict) Print(w io.Writer) {
+	fmt.Fprintf(w, "Artifact: %s (%s)\nSHA256:   %s\n\n", v.Artifact, v.Version, v.SHA256)
+
+	table := tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Check", "Result", "Detail"})
+	for _, c := range v.Checks {
+		result := "PASS"
+		if !c.Passed {
+			result = "FAIL"
+		}
+		table.Append([]string{c.Name, result, c.Detail})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+
+	if v.Verified() {
+		fmt.Fprintf(w, "\nVerdict: %s %s is VERIFIED\n", v.Artifact, v.Version)
+		return
+	}
+	fmt.Fprintf(w, "\nVerdict: %s %s FAILED verification\n", v.Artifact, v.Version)
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenancecheck
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	slsa "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/provenance"
+
+	"k8s.io/release/pkg/provenancecheck/provenancecheckfakes"
+)
+
+var errTest = errors.New("test")
+
+const (
+	testDigest = "f3b2b8a7d1e6c4a9b0d5e8c7a6f1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9"
+	testCommit = "4b8e819355d791d96b7e9d9efe4cbafae2311c88"
+)
+
+func testOptions() *Options {
+	opts := DefaultOptions()
+	opts.Version = "v1.29.1"
+	opts.Artifact = "bin/linux/amd64/kubectl"
+	opts.WorkDir = "/work"
+	return opts
+}
+
+func testStatement() *provenance.Statement {
+	statement := provenance.NewSLSAStatement()
+	statement.PredicateType = slsa.PredicateSLSAProvenance
+	statement.Subject = []intoto.Subject{
+		{Name: "gs://kubernetes-release/release/v1.29.1/bin/linux/amd64/kubeadm", Digest: map[string]string{"sha256": "other"}},
+		{Name: "gs://kubernetes-release/release/v1.29.1/bin/linux/amd64/kubectl", Digest: map[string]string{"sha256": testDigest}},
+	}
+	statement.Predicate.Builder.ID = DefaultBuilderID
+	statement.Predicate.Materials = []slsa.ProvenanceMaterial{
+		{URI: DefaultSourceRepo, Digest: map[string]string{"sha1": testCommit}},
+	}
+	return statement
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		modify      func(*Options)
+		shouldError bool
+	}{
+		{ // success
+			modify:      func(*Options) {},
+			shouldError: false,
+		},
+		{ // invalid version
+			modify:      func(o *Options) { o.Version = "wrong" },
+			shouldError: true,
+		},
+		{ // no artifact
+			modify:      func(o *Options) { o.Artifact = "" },
+			shouldError: true,
+		},
+		{ // no builder
+			modify:      func(o *Options) { o.BuilderID = "" },
+			shouldError: true,
+		},
+	} {
+		opts := testOptions()
+		tc.modify(opts)
+		err := opts.Validate()
+		if tc.shouldError {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		prepare     func(*provenancecheckfakes.FakeImpl)
+		failed      []string
+		shouldError bool
+	}{
+		{
+			name:    "verified",
+			prepare: func(*provenancecheckfakes.FakeImpl) {},
+		},
+		{
+			name: "invalid signature",
+			prepare: func(mock *provenancecheckfakes.FakeImpl) {
+				mock.VerifySignatureReturns(errTest)
+			},
+			failed: []string{"signature"},
+		},
+		{
+			name: "wrong builder and predicate type",
+			prepare: func(mock *provenancecheckfakes.FakeImpl) {
+				statement := testStatement()
+				statement.PredicateType = "https://slsa.dev/provenance/v1"
+				statement.Predicate.Builder.ID = "https://example.com/builder"
+				mock.LoadStatementReturns(statement, nil)
+			},
+			failed: []string{"predicate type", "builder"},
+		},
+		{
+			name: "digest mismatch",
+			prepare: func(mock *provenancecheckfakes.FakeImpl) {
+				mock.SHA256ForFileReturns("tampered", nil)
+			},
+			failed: []string{"subject"},
+		},
+		{
+			name: "tag points to another commit",
+			prepare: func(mock *provenancecheckfakes.FakeImpl) {
+				mock.TagCommitReturns("other", nil)
+			},
+			failed: []string{"source"},
+		},
+		{
+			name: "source repository missing",
+			prepare: func(mock *provenancecheckfakes.FakeImpl) {
+				statement := testStatement()
+				statement.Predicate.Materials = nil
+				mock.LoadStatementReturns(statement, nil)
+			},
+			failed: []string{"source"},
+		},
+		{
+			name: "download fails",
+			prepare: func(mock *provenancecheckfakes.FakeImpl) {
+				mock.DownloadReturns(errTest)
+			},
+			shouldError: true,
+		},
+		{
+			name: "attestation invalid",
+			prepare: func(mock *provenancecheckfakes.FakeImpl) {
+				mock.LoadStatementReturns(nil, errTest)
+			},
+			shouldError: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &provenancecheckfakes.FakeImpl{}
+			mock.SHA256ForFileReturns(testDigest, nil)
+			mock.LoadStatementReturns(testStatement(), nil)
+			mock.TagCommitReturns(testCommit, nil)
+			tc.prepare(mock)
+
+			sut := New(testOptions())
+			sut.SetImpl(mock)
+
+			verdict, err := sut.Verify()
+			if tc.shouldError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			failed := []string{}
+			for _, c := range verdict.Checks {
+				if !c.Passed {
+					failed = append(failed, c.Name)
+				}
+			}
+			require.ElementsMatch(t, tc.failed, failed)
+			require.Equal(t, len(tc.failed) == 0, verdict.Verified())
+
+			require.Equal(t, 4, mock.DownloadCallCount())
+			url, dest := mock.DownloadArgsForCall(1)
+			require.Equal(t, "https://dl.k8s.io/release/v1.29.1/bin/linux/amd64/kubectl.sig", url)
+			require.Equal(t, "/work/kubectl.sig", dest)
+			if mock.TagCommitCallCount() > 0 {
+				repoURL, tag := mock.TagCommitArgsForCall(0)
+				require.Equal(t, "https://github.com/kubernetes/kubernetes", repoURL)
+				require.Equal(t, "v1.29.1", tag)
+			}
+		})
+	}
+}
+
+func TestPrintVerdict(t *testing.T) {
+	t.Parallel()
+
+	verdict := &Verdict{Artifact: "bin/linux/amd64/kubectl", Version: "v1.29.1", SHA256: testDigest}
+	verdict.add("signature", nil, "signed")
+	buf := &bytes.Buffer{}
+	verdict.Print(buf)
+	require.Contains(t, buf.String(), "Verdict: bin/linux/amd64/kubectl v1.29.1 is VERIFIED")
+
+	verdict.add("builder", errTest, "")
+	buf.Reset()
+	verdict.Print(buf)
+	require.Contains(t, buf.String(), "| builder   | FAIL   | test   |")
+	require.Contains(t, buf.String(), "FAILED verification")
+}
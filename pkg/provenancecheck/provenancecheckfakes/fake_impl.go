@@ -0,0 +1,596 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package provenancecheckfakes
+
+import (
+	"sync"
+
+	"sigs.k8s.io/bom/pkg/provenance"
+	"sigs.k8s.io/release-sdk/sign"
+)
+
+type FakeImpl struct {
+	DownloadStub        func(string, string) error
+	downloadMutex       sync.RWMutex
+	downloadArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	downloadReturns struct {
+		result1 error
+	}
+	downloadReturnsOnCall map[int]struct {
+		result1 error
+	}
+	LoadStatementStub        func(string) (*provenance.Statement, error)
+	loadStatementMutex       sync.RWMutex
+	loadStatementArgsForCall []struct {
+		arg1 string
+	}
+	loadStatementReturns struct {
+		result1 *provenance.Statement
+		result2 error
+	}
+	loadStatementReturnsOnCall map[int]struct {
+		result1 *provenance.Statement
+		result2 error
+	}
+	MkdirTempStub        func(string) (string, error)
+	mkdirTempMutex       sync.RWMutex
+	mkdirTempArgsForCall []struct {
+		arg1 string
+	}
+	mkdirTempReturns struct {
+		result1 string
+		result2 error
+	}
+	mkdirTempReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	RemoveAllStub        func(string) error
+	removeAllMutex       sync.RWMutex
+	removeAllArgsForCall []struct {
+		arg1 string
+	}
+	removeAllReturns struct {
+		result1 error
+	}
+	removeAllReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SHA256ForFileStub        func(string) (string, error)
+	sHA256ForFileMutex       sync.RWMutex
+	sHA256ForFileArgsForCall []struct {
+		arg1 string
+	}
+	sHA256ForFileReturns struct {
+		result1 string
+		result2 error
+	}
+	sHA256ForFileReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	TagCommitStub        func(string, string) (string, error)
+	tagCommitMutex       sync.RWMutex
+	tagCommitArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	tagCommitReturns struct {
+		result1 string
+		result2 error
+	}
+	tagCommitReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	VerifySignatureStub        func(*sign.Options, string) error
+	verifySignatureMutex       sync.RWMutex
+	verifySignatureArgsForCall []struct {
+		arg1 *sign.Options
+		arg2 string
+	}
+	verifySignatureReturns struct {
+		result1 error
+	}
+	verifySignatureReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) Download(arg1 string, arg2 string) error {
+	fake.downloadMutex.Lock()
+	ret, specificReturn := fake.downloadReturnsOnCall[len(fake.downloadArgsForCall)]
+	fake.downloadArgsForCall = append(fake.downloadArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DownloadStub
+	fakeReturns := fake.downloadReturns
+	fake.recordInvocation("Download", []interface{}{arg1, arg2})
+	fake.downloadMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) DownloadCallCount() int {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	return len(fake.downloadArgsForCall)
+}
+
+func (fake *FakeImpl) DownloadCalls(stub func(string, string) error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = stub
+}
+
+func (fake *FakeImpl) DownloadArgsForCall(i int) (string, string) {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	argsForCall := fake.downloadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) DownloadReturns(result1 error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = nil
+	fake.downloadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) DownloadReturnsOnCall(i int, result1 error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = nil
+	if fake.downloadReturnsOnCall == nil {
+		fake.downloadReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.downloadReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) LoadStatement(arg1 string) (*provenance.Statement, error) {
+	fake.loadStatementMutex.Lock()
+	ret, specificReturn := fake.loadStatementReturnsOnCall[len(fake.loadStatementArgsForCall)]
+	fake.loadStatementArgsForCall = append(fake.loadStatementArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.LoadStatementStub
+	fakeReturns := fake.loadStatementReturns
+	fake.recordInvocation("LoadStatement", []interface{}{arg1})
+	fake.loadStatementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) LoadStatementCallCount() int {
+	fake.loadStatementMutex.RLock()
+	defer fake.loadStatementMutex.RUnlock()
+	return len(fake.loadStatementArgsForCall)
+}
+
+func (fake *FakeImpl) LoadStatementCalls(stub func(string) (*provenance.Statement, error)) {
+	fake.loadStatementMutex.Lock()
+	defer fake.loadStatementMutex.Unlock()
+	fake.LoadStatementStub = stub
+}
+
+func (fake *FakeImpl) LoadStatementArgsForCall(i int) string {
+	fake.loadStatementMutex.RLock()
+	defer fake.loadStatementMutex.RUnlock()
+	argsForCall := fake.loadStatementArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) LoadStatementReturns(result1 *provenance.Statement, result2 error) {
+	fake.loadStatementMutex.Lock()
+	defer fake.loadStatementMutex.Unlock()
+	fake.LoadStatementStub = nil
+	fake.loadStatementReturns = struct {
+		result1 *provenance.Statement
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) LoadStatementReturnsOnCall(i int, result1 *provenance.Statement, result2 error) {
+	fake.loadStatementMutex.Lock()
+	defer fake.loadStatementMutex.Unlock()
+	fake.LoadStatementStub = nil
+	if fake.loadStatementReturnsOnCall == nil {
+		fake.loadStatementReturnsOnCall = make(map[int]struct {
+			result1 *provenance.Statement
+			result2 error
+		})
+	}
+	fake.loadStatementReturnsOnCall[i] = struct {
+		result1 *provenance.Statement
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) MkdirTemp(arg1 string) (string, error) {
+	fake.mkdirTempMutex.Lock()
+	ret, specificReturn := fake.mkdirTempReturnsOnCall[len(fake.mkdirTempArgsForCall)]
+	fake.mkdirTempArgsForCall = append(fake.mkdirTempArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.MkdirTempStub
+	fakeReturns := fake.mkdirTempReturns
+	fake.recordInvocation("MkdirTemp", []interface{}{arg1})
+	fake.mkdirTempMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) MkdirTempCallCount() int {
+	fake.mkdirTempMutex.RLock()
+	defer fake.mkdirTempMutex.RUnlock()
+	return len(fake.mkdirTempArgsForCall)
+}
+
+func (fake *FakeImpl) MkdirTempCalls(stub func(string) (string, error)) {
+	fake.mkdirTempMutex.Lock()
+	defer fake.mkdirTempMutex.Unlock()
+	fake.MkdirTempStub = stub
+}
+
+func (fake *FakeImpl) MkdirTempArgsForCall(i int) string {
+	fake.mkdirTempMutex.RLock()
+	defer fake.mkdirTempMutex.RUnlock()
+	argsForCall := fake.mkdirTempArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) MkdirTempReturns(result1 string, result2 error) {
+	fake.mkdirTempMutex.Lock()
+	defer fake.mkdirTempMutex.Unlock()
+	fake.MkdirTempStub = nil
+	fake.mkdirTempReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) MkdirTempReturnsOnCall(i int, result1 string, result2 error) {
+	fake.mkdirTempMutex.Lock()
+	defer fake.mkdirTempMutex.Unlock()
+	fake.MkdirTempStub = nil
+	if fake.mkdirTempReturnsOnCall == nil {
+		fake.mkdirTempReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.mkdirTempReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) RemoveAll(arg1 string) error {
+	fake.removeAllMutex.Lock()
+	ret, specificReturn := fake.removeAllReturnsOnCall[len(fake.removeAllArgsForCall)]
+	fake.removeAllArgsForCall = append(fake.removeAllArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RemoveAllStub
+	fakeReturns := fake.removeAllReturns
+	fake.recordInvocation("RemoveAll", []interface{}{arg1})
+	fake.removeAllMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) RemoveAllCallCount() int {
+	fake.removeAllMutex.RLock()
+	defer fake.removeAllMutex.RUnlock()
+	return len(fake.removeAllArgsForCall)
+}
+
+func (fake *FakeImpl) RemoveAllCalls(stub func(string) error) {
+	fake.removeAllMutex.Lock()
+	defer fake.removeAllMutex.Unlock()
+	fake.RemoveAllStub = stub
+}
+
+func (fake *FakeImpl) RemoveAllArgsForCall(i int) string {
+	fake.removeAllMutex.RLock()
+	defer fake.removeAllMutex.RUnlock()
+	argsForCall := fake.removeAllArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) RemoveAllReturns(result1 error) {
+	fake.removeAllMutex.Lock()
+	defer fake.removeAllMutex.Unlock()
+	fake.RemoveAllStub = nil
+	fake.removeAllReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) RemoveAllReturnsOnCall(i int, result1 error) {
+	fake.removeAllMutex.Lock()
+	defer fake.removeAllMutex.Unlock()
+	fake.RemoveAllStub = nil
+	if fake.removeAllReturnsOnCall == nil {
+		fake.removeAllReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeAllReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) SHA256ForFile(arg1 string) (string, error) {
+	fake.sHA256ForFileMutex.Lock()
+	ret, specificReturn := fake.sHA256ForFileReturnsOnCall[len(fake.sHA256ForFileArgsForCall)]
+	fake.sHA256ForFileArgsForCall = append(fake.sHA256ForFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SHA256ForFileStub
+	fakeReturns := fake.sHA256ForFileReturns
+	fake.recordInvocation("SHA256ForFile", []interface{}{arg1})
+	fake.sHA256ForFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) SHA256ForFileCallCount() int {
+	fake.sHA256ForFileMutex.RLock()
+	defer fake.sHA256ForFileMutex.RUnlock()
+	return len(fake.sHA256ForFileArgsForCall)
+}
+
+func (fake *FakeImpl) SHA256ForFileCalls(stub func(string) (string, error)) {
+	fake.sHA256ForFileMutex.Lock()
+	defer fake.sHA256ForFileMutex.Unlock()
+	fake.SHA256ForFileStub = stub
+}
+
+func (fake *FakeImpl) SHA256ForFileArgsForCall(i int) string {
+	fake.sHA256ForFileMutex.RLock()
+	defer fake.sHA256ForFileMutex.RUnlock()
+	argsForCall := fake.sHA256ForFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) SHA256ForFileReturns(result1 string, result2 error) {
+	fake.sHA256ForFileMutex.Lock()
+	defer fake.sHA256ForFileMutex.Unlock()
+	fake.SHA256ForFileStub = nil
+	fake.sHA256ForFileReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SHA256ForFileReturnsOnCall(i int, result1 string, result2 error) {
+	fake.sHA256ForFileMutex.Lock()
+	defer fake.sHA256ForFileMutex.Unlock()
+	fake.SHA256ForFileStub = nil
+	if fake.sHA256ForFileReturnsOnCall == nil {
+		fake.sHA256ForFileReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.sHA256ForFileReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) TagCommit(arg1 string, arg2 string) (string, error) {
+	fake.tagCommitMutex.Lock()
+	ret, specificReturn := fake.tagCommitReturnsOnCall[len(fake.tagCommitArgsForCall)]
+	fake.tagCommitArgsForCall = append(fake.tagCommitArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.TagCommitStub
+	fakeReturns := fake.tagCommitReturns
+	fake.recordInvocation("TagCommit", []interface{}{arg1, arg2})
+	fake.tagCommitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) TagCommitCallCount() int {
+	fake.tagCommitMutex.RLock()
+	defer fake.tagCommitMutex.RUnlock()
+	return len(fake.tagCommitArgsForCall)
+}
+
+func (fake *FakeImpl) TagCommitCalls(stub func(string, string) (string, error)) {
+	fake.tagCommitMutex.Lock()
+	defer fake.tagCommitMutex.Unlock()
+	fake.TagCommitStub = stub
+}
+
+func (fake *FakeImpl) TagCommitArgsForCall(i int) (string, string) {
+	fake.tagCommitMutex.RLock()
+	defer fake.tagCommitMutex.RUnlock()
+	argsForCall := fake.tagCommitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) TagCommitReturns(result1 string, result2 error) {
+	fake.tagCommitMutex.Lock()
+	defer fake.tagCommitMutex.Unlock()
+	fake.TagCommitStub = nil
+	fake.tagCommitReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) TagCommitReturnsOnCall(i int, result1 string, result2 error) {
+	fake.tagCommitMutex.Lock()
+	defer fake.tagCommitMutex.Unlock()
+	fake.TagCommitStub = nil
+	if fake.tagCommitReturnsOnCall == nil {
+		fake.tagCommitReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.tagCommitReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) VerifySignature(arg1 *sign.Options, arg2 string) error {
+	fake.verifySignatureMutex.Lock()
+	ret, specificReturn := fake.verifySignatureReturnsOnCall[len(fake.verifySignatureArgsForCall)]
+	fake.verifySignatureArgsForCall = append(fake.verifySignatureArgsForCall, struct {
+		arg1 *sign.Options
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.VerifySignatureStub
+	fakeReturns := fake.verifySignatureReturns
+	fake.recordInvocation("VerifySignature", []interface{}{arg1, arg2})
+	fake.verifySignatureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) VerifySignatureCallCount() int {
+	fake.verifySignatureMutex.RLock()
+	defer fake.verifySignatureMutex.RUnlock()
+	return len(fake.verifySignatureArgsForCall)
+}
+
+func (fake *FakeImpl) VerifySignatureCalls(stub func(*sign.Options, string) error) {
+	fake.verifySignatureMutex.Lock()
+	defer fake.verifySignatureMutex.Unlock()
+	fake.VerifySignatureStub = stub
+}
+
+func (fake *FakeImpl) VerifySignatureArgsForCall(i int) (*sign.Options, string) {
+	fake.verifySignatureMutex.RLock()
+	defer fake.verifySignatureMutex.RUnlock()
+	argsForCall := fake.verifySignatureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) VerifySignatureReturns(result1 error) {
+	fake.verifySignatureMutex.Lock()
+	defer fake.verifySignatureMutex.Unlock()
+	fake.VerifySignatureStub = nil
+	fake.verifySignatureReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) VerifySignatureReturnsOnCall(i int, result1 error) {
+	fake.verifySignatureMutex.Lock()
+	defer fake.verifySignatureMutex.Unlock()
+	fake.VerifySignatureStub = nil
+	if fake.verifySignatureReturnsOnCall == nil {
+		fake.verifySignatureReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.verifySignatureReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	fake.loadStatementMutex.RLock()
+	defer fake.loadStatementMutex.RUnlock()
+	fake.mkdirTempMutex.RLock()
+	defer fake.mkdirTempMutex.RUnlock()
+	fake.removeAllMutex.RLock()
+	defer fake.removeAllMutex.RUnlock()
+	fake.sHA256ForFileMutex.RLock()
+	defer fake.sHA256ForFileMutex.RUnlock()
+	fake.tagCommitMutex.RLock()
+	defer fake.tagCommitMutex.RUnlock()
+	fake.verifySignatureMutex.RLock()
+	defer fake.verifySignatureMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/env"
+
+	"k8s.io/release/pkg/testgrid"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt readinessfakes/fake_impl.go > readinessfakes/_fake_impl.go && mv readinessfakes/_fake_impl.go readinessfakes/fake_impl.go"
+type impl interface {
+	Signal(branch string) (*testgrid.Signal, error)
+	SearchIssues(query string) ([]*gogithub.Issue, error)
+}
+
+type defaultImpl struct {
+	client *gogithub.Client
+}
+
+func newDefaultImpl() *defaultImpl {
+	httpClient := http.DefaultClient
+	if token := env.Default(github.TokenEnvKey, ""); token != "" {
+		httpClient = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		))
+	}
+	return &defaultImpl{client: gogithub.NewClient(httpClient)}
+}
+
+func (*defaultImpl) Signal(branch string) (*testgrid.Signal, error) {
+	return testgrid.New().Signal(branch)
+}
+
+func (d *defaultImpl) SearchIssues(query string) ([]*gogithub.Issue, error) {
+	res := []*gogithub.Issue{}
+	opts := &gogithub.SearchOptions{ListOptions: gogithub.ListOptions{PerPage: 100}}
+	for {
+		result, resp, err := d.client.Search.Issues(context.Background(), query, opts)
+		if err != nil {
+			return nil, fmt.Errorf("search issues: %w", err)
+		}
+		res = append(res, result.Issues...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+	return res, nil
+}
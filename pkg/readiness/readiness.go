@@ -0,0 +1,337 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package readiness aggregates the CI signal, the open release blockers, the
+// test flakes, the documentation pull requests and the enhancement freeze
+// exceptions of a release into a scorecard for the go/no-go meeting.
+package readiness
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/testgrid"
+)
+
+// Status is the readiness status of a single check or the whole scorecard.
+type Status string
+
+const (
+	// StatusGo indicates that the check does not prevent the release.
+	StatusGo Status = "go"
+
+	// StatusAtRisk indicates that the check should be discussed in the
+	// go/no-go meeting.
+	StatusAtRisk Status = "at-risk"
+
+	// StatusNoGo indicates that the check prevents the release.
+	StatusNoGo Status = "no-go"
+
+	// StatusUnknown indicates that the signal could not be retrieved.
+	StatusUnknown Status = "unknown"
+)
+
+// Names of the checks of the scorecard.
+const (
+	CheckCISignal              = "CI signal"
+	CheckReleaseBlockers       = "Release blockers"
+	CheckTestFlakes            = "Test flakes"
+	CheckDocumentation         = "Documentation"
+	CheckEnhancementExceptions = "Enhancement exceptions"
+)
+
+// Options are the options for generating the scorecard.
+type Options struct {
+	// Branch is the release branch, for example release-1.30.
+	Branch string
+
+	// Milestone is the GitHub milestone of the release, which defaults to
+	// v1.30 for the branch release-1.30.
+	Milestone string
+
+	// Org is the GitHub organization of the repositories.
+	Org string
+
+	// Repo is the GitHub repository containing the release blockers and the
+	// test flakes.
+	Repo string
+
+	// DocsRepo is the GitHub repository of the documentation pull requests.
+	DocsRepo string
+
+	// EnhancementsRepo is the GitHub repository of the enhancements.
+	EnhancementsRepo string
+
+	// BlockerLabel is the label of the issues and pull requests blocking the
+	// release.
+	BlockerLabel string
+
+	// FlakeLabel is the label of the issues tracking test flakes.
+	FlakeLabel string
+
+	// ExceptionLabel is the label of the enhancements which got a freeze
+	// exception.
+	ExceptionLabel string
+
+	// AllowedFlakyJobs is the number of flaky release blocking jobs which do
+	// not put the release at risk.
+	AllowedFlakyJobs int
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Org:              "kubernetes",
+		Repo:             "kubernetes",
+		DocsRepo:         "website",
+		EnhancementsRepo: "enhancements",
+		BlockerLabel:     "priority/critical-urgent",
+		FlakeLabel:       "kind/flake",
+		ExceptionLabel:   "freeze-exception",
+	}
+}
+
+// Validate checks if the options are correctly set and defaults the
+// milestone.
+func (o *Options) Validate() error {
+	if o.Branch == "" {
+		return errors.New("branch is required")
+	}
+	if o.Milestone == "" {
+		if !strings.HasPrefix(o.Branch, "release-") {
+			return fmt.Errorf("milestone is required for branch %s", o.Branch)
+		}
+		o.Milestone = "v" + strings.TrimPrefix(o.Branch, "release-")
+	}
+	if o.Org == "" || o.Repo == "" {
+		return errors.New("org and repo are required")
+	}
+	if o.AllowedFlakyJobs < 0 {
+		return errors.New("allowed flaky jobs must not be negative")
+	}
+	return nil
+}
+
+// Check is the result of a single readiness signal.
+type Check struct {
+	// Name is the name of the check, for example CheckCISignal.
+	Name string `json:"name"`
+
+	// Status is the readiness status of the check.
+	Status Status `json:"status"`
+
+	// Summary is the human readable value of the signal.
+	Summary string `json:"summary"`
+
+	// Details are the job names or issue URLs causing the status.
+	Details []string `json:"details,omitempty"`
+}
+
+// Scorecard is the aggregated release readiness.
+type Scorecard struct {
+	Branch    string    `json:"branch"`
+	Milestone string    `json:"milestone"`
+	Generated time.Time `json:"generated"`
+	Status    Status    `json:"status"`
+	Checks    []Check   `json:"checks"`
+}
+
+// Scorer generates release readiness scorecards.
+type Scorer struct {
+	options *Options
+	impl    impl
+}
+
+// New creates a new Scorer.
+func New(opts *Options) *Scorer {
+	return &Scorer{options: opts, impl: newDefaultImpl()}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (s *Scorer) SetImpl(impl impl) {
+	s.impl = impl
+}
+
+// Run collects all signals into a new scorecard. Signals which cannot be
+// retrieved are marked as StatusUnknown instead of failing the whole run.
+func (s *Scorer) Run() (*Scorecard, error) {
+	if err := s.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+	o := s.options
+	logrus.Infof("Generating readiness scorecard for %s (%s)", o.Branch, o.Milestone)
+
+	card := &Scorecard{
+		Branch:    o.Branch,
+		Milestone: o.Milestone,
+		Generated: time.Now().UTC(),
+	}
+
+	signal, err := s.impl.Signal(o.Branch)
+	if err != nil {
+		logrus.Warnf("Unable to retrieve CI signal: %v", err)
+	}
+	card.Checks = append(card.Checks, s.ciSignal(signal, err))
+
+	repo := fmt.Sprintf("repo:%s/%s", o.Org, o.Repo)
+	card.Checks = append(card.Checks,
+		s.search(
+			CheckReleaseBlockers, StatusNoGo, "open release blocker(s)",
+			repo, "is:open", "milestone:"+o.Milestone, "label:"+o.BlockerLabel,
+		),
+		s.search(
+			CheckTestFlakes, StatusAtRisk, "open flake issue(s)",
+			repo, "is:issue", "is:open", "milestone:"+o.Milestone, "label:"+o.FlakeLabel,
+		),
+		s.search(
+			CheckDocumentation, StatusAtRisk, "open documentation PR(s)",
+			fmt.Sprintf("repo:%s/%s", o.Org, o.DocsRepo), "is:pr", "is:open",
+			"base:dev-"+strings.TrimPrefix(o.Milestone, "v"),
+		),
+		s.search(
+			CheckEnhancementExceptions, StatusAtRisk, "enhancement(s) with freeze exception",
+			fmt.Sprintf("repo:%s/%s", o.Org, o.EnhancementsRepo), "is:issue",
+			"milestone:"+o.Milestone, "label:"+o.ExceptionLabel,
+		),
+	)
+
+	card.Status = StatusGo
+	for _, check := range card.Checks {
+		switch check.Status {
+		case StatusNoGo:
+			card.Status = StatusNoGo
+		case StatusAtRisk, StatusUnknown:
+			if card.Status == StatusGo {
+				card.Status = StatusAtRisk
+			}
+		case StatusGo:
+		}
+	}
+
+	return card, nil
+}
+
+// ciSignal evaluates the release blocking dashboard, where blocking jobs
+// prevent the release and tolerated flaky jobs put it at risk.
+func (s *Scorer) ciSignal(signal *testgrid.Signal, err error) Check {
+	check := Check{Name: CheckCISignal}
+	if err != nil {
+		check.Status = StatusUnknown
+		check.Summary = err.Error()
+		return check
+	}
+
+	if len(signal.Jobs) == 0 {
+		check.Status = StatusUnknown
+		check.Summary = fmt.Sprintf("no jobs found on dashboard %s", signal.Dashboard)
+		return check
+	}
+
+	passing, flaky := 0, 0
+	for _, job := range signal.Jobs {
+		switch job.OverallStatus {
+		case testgrid.Passing:
+			passing++
+		case testgrid.Flaky:
+			flaky++
+		}
+	}
+	check.Summary = fmt.Sprintf(
+		"%d%% green (%d/%d jobs passing, %d flaky) on %s",
+		passing*100/len(signal.Jobs), passing, len(signal.Jobs), flaky, signal.Dashboard,
+	)
+
+	blocking := signal.Blocking(s.options.AllowedFlakyJobs)
+	for _, job := range blocking {
+		check.Details = append(check.Details, string(job))
+	}
+
+	switch {
+	case len(blocking) > 0:
+		check.Status = StatusNoGo
+	case flaky > 0:
+		check.Status = StatusAtRisk
+	default:
+		check.Status = StatusGo
+	}
+	return check
+}
+
+// search counts the GitHub issues and pull requests matching the query
+// terms, which result in the failed status if there are any.
+func (s *Scorer) search(name string, failed Status, what string, terms ...string) Check {
+	check := Check{Name: name}
+	query := strings.Join(terms, " ")
+	logrus.Debugf("Searching GitHub for: %s", query)
+
+	issues, err := s.impl.SearchIssues(query)
+	if err != nil {
+		logrus.Warnf("Unable to search for %s: %v", strings.ToLower(name), err)
+		check.Status = StatusUnknown
+		check.Summary = err.Error()
+		return check
+	}
+
+	check.Summary = fmt.Sprintf("%d %s", len(issues), what)
+	for _, issue := range issues {
+		check.Details = append(check.Details, issue.GetHTMLURL())
+	}
+
+	check.Status = StatusGo
+	if len(issues) > 0 {
+		check.Status = failed
+	}
+	return check
+}
+
+// Markdown renders the scorecard as markdown document.
+func (c *Scorecard) Markdown() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "# Release readiness of %s (%s)\n\n", c.Branch, c.Milestone)
+	fmt.Fprintf(buf, "Generated on %s, overall status: **%s**\n\n",
+		c.Generated.Format("2006-01-02 15:04 MST"), strings.ToUpper(string(c.Status)),
+	)
+
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Check", "Status", "Summary"})
+	for _, check := range c.Checks {
+		table.Append([]string{check.Name, string(check.Status), check.Summary})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+
+	for _, check := range c.Checks {
+		if check.Status == StatusGo || len(check.Details) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "\n## %s\n\n", check.Name)
+		for _, detail := range check.Details {
+			fmt.Fprintf(buf, "- %s\n", detail)
+		}
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/readiness"
+	"k8s.io/release/pkg/readiness/readinessfakes"
+	"k8s.io/release/pkg/testgrid"
+)
+
+func newFake(jobs testgrid.JobData, issues map[string][]*gogithub.Issue) *readinessfakes.FakeImpl {
+	mock := &readinessfakes.FakeImpl{}
+	mock.SignalCalls(func(branch string) (*testgrid.Signal, error) {
+		return &testgrid.Signal{Dashboard: testgrid.BlockingDashboard(branch), Jobs: jobs}, nil
+	})
+	mock.SearchIssuesCalls(func(query string) ([]*gogithub.Issue, error) {
+		for term, res := range issues {
+			if strings.Contains(query, term) {
+				return res, nil
+			}
+		}
+		return nil, nil
+	})
+	return mock
+}
+
+func issue(url string) *gogithub.Issue {
+	return &gogithub.Issue{HTMLURL: gogithub.String(url)}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		modify      func(*readiness.Options)
+		milestone   string
+		shouldError bool
+	}{
+		{ // milestone defaults from branch
+			modify:    func(o *readiness.Options) { o.Branch = "release-1.30" },
+			milestone: "v1.30",
+		},
+		{ // explicit milestone
+			modify: func(o *readiness.Options) {
+				o.Branch = "master"
+				o.Milestone = "v1.31"
+			},
+			milestone: "v1.31",
+		},
+		{ // milestone required for master
+			modify:      func(o *readiness.Options) { o.Branch = "master" },
+			shouldError: true,
+		},
+		{ // branch required
+			modify:      func(o *readiness.Options) {},
+			shouldError: true,
+		},
+		{ // negative flakes
+			modify: func(o *readiness.Options) {
+				o.Branch = "release-1.30"
+				o.AllowedFlakyJobs = -1
+			},
+			shouldError: true,
+		},
+	} {
+		opts := readiness.DefaultOptions()
+		tc.modify(opts)
+		err := opts.Validate()
+		if tc.shouldError {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.milestone, opts.Milestone)
+	}
+}
+
+func TestRun(t *testing.T) {
+	green := testgrid.JobData{
+		"a": testgrid.JobSummary{OverallStatus: testgrid.Passing},
+		"b": testgrid.JobSummary{OverallStatus: testgrid.Passing},
+	}
+
+	for _, tc := range []struct {
+		jobs     testgrid.JobData
+		issues   map[string][]*gogithub.Issue
+		status   readiness.Status
+		statuses []readiness.Status
+	}{
+		{ // everything green
+			jobs:   green,
+			status: readiness.StatusGo,
+			statuses: []readiness.Status{
+				readiness.StatusGo, readiness.StatusGo, readiness.StatusGo,
+				readiness.StatusGo, readiness.StatusGo,
+			},
+		},
+		{ // open release blocker
+			jobs: green,
+			issues: map[string][]*gogithub.Issue{
+				"label:priority/critical-urgent": {issue("https://github.com/kubernetes/kubernetes/issues/1")},
+			},
+			status: readiness.StatusNoGo,
+			statuses: []readiness.Status{
+				readiness.StatusGo, readiness.StatusNoGo, readiness.StatusGo,
+				readiness.StatusGo, readiness.StatusGo,
+			},
+		},
+		{ // tolerated flaky job and open docs PR
+			jobs: testgrid.JobData{
+				"a": testgrid.JobSummary{OverallStatus: testgrid.Passing},
+				"b": testgrid.JobSummary{OverallStatus: testgrid.Flaky},
+			},
+			issues: map[string][]*gogithub.Issue{
+				"base:dev-1.30": {issue("https://github.com/kubernetes/website/pull/2")},
+			},
+			status: readiness.StatusAtRisk,
+			statuses: []readiness.Status{
+				readiness.StatusAtRisk, readiness.StatusGo, readiness.StatusGo,
+				readiness.StatusAtRisk, readiness.StatusGo,
+			},
+		},
+		{ // failing job
+			jobs: testgrid.JobData{
+				"a": testgrid.JobSummary{OverallStatus: testgrid.Failing},
+			},
+			status: readiness.StatusNoGo,
+			statuses: []readiness.Status{
+				readiness.StatusNoGo, readiness.StatusGo, readiness.StatusGo,
+				readiness.StatusGo, readiness.StatusGo,
+			},
+		},
+	} {
+		opts := readiness.DefaultOptions()
+		opts.Branch = "release-1.30"
+		opts.AllowedFlakyJobs = 1
+
+		sut := readiness.New(opts)
+		sut.SetImpl(newFake(tc.jobs, tc.issues))
+
+		card, err := sut.Run()
+		require.NoError(t, err)
+		require.Equal(t, tc.status, card.Status)
+		require.Len(t, card.Checks, len(tc.statuses))
+		for i, status := range tc.statuses {
+			require.Equal(t, status, card.Checks[i].Status, card.Checks[i].Name)
+		}
+	}
+}
+
+func TestRunUnknownSignal(t *testing.T) {
+	mock := &readinessfakes.FakeImpl{}
+	mock.SignalReturns(nil, errors.New("testgrid unavailable"))
+	mock.SearchIssuesReturns(nil, errors.New("rate limited"))
+
+	opts := readiness.DefaultOptions()
+	opts.Branch = "release-1.30"
+	sut := readiness.New(opts)
+	sut.SetImpl(mock)
+
+	card, err := sut.Run()
+	require.NoError(t, err)
+	require.Equal(t, readiness.StatusAtRisk, card.Status)
+	for _, check := range card.Checks {
+		require.Equal(t, readiness.StatusUnknown, check.Status)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	opts := readiness.DefaultOptions()
+	opts.Branch = "release-1.30"
+	sut := readiness.New(opts)
+	sut.SetImpl(newFake(
+		testgrid.JobData{"a": testgrid.JobSummary{OverallStatus: testgrid.Failing}},
+		map[string][]*gogithub.Issue{
+			"label:kind/flake": {issue("https://github.com/kubernetes/kubernetes/issues/3")},
+		},
+	))
+
+	card, err := sut.Run()
+	require.NoError(t, err)
+
+	md := card.Markdown()
+	require.Contains(t, md, "# Release readiness of release-1.30 (v1.30)")
+	require.Contains(t, md, "overall status: **NO-GO**")
+	require.Contains(t, md, "0% green (0/1 jobs passing, 0 flaky) on sig-release-1.30-blocking")
+	require.Contains(t, md, "## CI signal\n\n- a\n")
+	require.Contains(t, md, "## Test flakes\n\n- https://github.com/kubernetes/kubernetes/issues/3\n")
+	require.NotContains(t, md, "## Documentation")
+}
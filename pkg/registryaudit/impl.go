@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryaudit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"k8s.io/release/pkg/retry"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt registryauditfakes/fake_impl.go > registryauditfakes/_fake_impl.go && mv registryauditfakes/_fake_impl.go registryauditfakes/fake_impl.go"
+type impl interface {
+	Digest(ref string) (string, error)
+	RedirectLocation(ref string) (string, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte) error
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) Digest(ref string) (string, error) {
+	var digest string
+	err := retry.Do(context.Background(), retry.ServiceRegistry, func() (err error) {
+		digest, err = crane.Digest(ref)
+		return err
+	})
+	return digest, err
+}
+
+// RedirectLocation returns the location the manifest request of the ref gets
+// redirected to, or an empty string if the registry serves it directly.
+func (*defaultImpl) RedirectLocation(ref string) (string, error) {
+	parsed, err := name.ParseReference(ref)
+	if err != nil {
+		return "", fmt.Errorf("parse reference: %w", err)
+	}
+
+	url := fmt.Sprintf(
+		"%s://%s/v2/%s/manifests/%s",
+		parsed.Context().Scheme(),
+		parsed.Context().RegistryStr(),
+		parsed.Context().RepositoryStr(),
+		parsed.Identifier(),
+	)
+	req, err := http.NewRequest(http.MethodHead, url, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices && resp.StatusCode < http.StatusBadRequest {
+		return strings.TrimSpace(resp.Header.Get("Location")), nil
+	}
+	return "", nil
+}
+
+func (*defaultImpl) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (*defaultImpl) WriteFile(name string, data []byte) error {
+	return os.WriteFile(name, data, 0o644)
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryaudit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultLegacyRegistry is the deprecated registry which should redirect
+	// to the target registry.
+	DefaultLegacyRegistry = "k8s.gcr.io"
+
+	// DefaultTargetRegistry is the registry the legacy paths should resolve to.
+	DefaultTargetRegistry = release.GCRIOPathProd
+)
+
+// Options are the main options for auditing the legacy registry.
+type Options struct {
+	// ConfigFile is an optional YAML file containing the list of legacy
+	// image paths to be probed.
+	ConfigFile string
+
+	// Paths are the legacy image paths to be probed, for example
+	// k8s.gcr.io/pause:3.9.
+	Paths []string
+
+	// LegacyRegistry is the prefix of the legacy image paths.
+	LegacyRegistry string
+
+	// TargetRegistry is the prefix the legacy paths should resolve to.
+	TargetRegistry string
+
+	// ReportFile is an optional path for writing the report as JSON.
+	ReportFile string
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		LegacyRegistry: DefaultLegacyRegistry,
+		TargetRegistry: DefaultTargetRegistry,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.ConfigFile == "" && len(o.Paths) == 0 {
+		return errors.New("neither a config file nor paths specified")
+	}
+	if o.LegacyRegistry == "" {
+		return errors.New("no legacy registry specified")
+	}
+	if o.TargetRegistry == "" {
+		return errors.New("no target registry specified")
+	}
+	return nil
+}
+
+// Config is the format of the configuration file.
+type Config struct {
+	// Paths are the legacy image paths to be probed.
+	Paths []string `json:"paths"`
+}
+
+// Result is the audit result of a single legacy path.
+type Result struct {
+	LegacyPath   string `json:"legacyPath"`
+	TargetPath   string `json:"targetPath"`
+	Redirect     string `json:"redirect,omitempty"`
+	LegacyDigest string `json:"legacyDigest,omitempty"`
+	TargetDigest string `json:"targetDigest,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// OK returns true if the legacy path resolves to the same digest as the
+// target path.
+func (r *Result) OK() bool {
+	return r.Error == "" && r.LegacyDigest != "" && r.LegacyDigest == r.TargetDigest
+}
+
+// Status returns a short human readable status of the result.
+func (r *Result) Status() string {
+	switch {
+	case r.Error != "":
+		return "error: " + r.Error
+	case r.LegacyDigest != r.TargetDigest:
+		return "digest mismatch"
+	default:
+		return "ok"
+	}
+}
+
+// Report is the result of auditing all legacy paths.
+type Report struct {
+	LegacyRegistry string    `json:"legacyRegistry"`
+	TargetRegistry string    `json:"targetRegistry"`
+	Results        []*Result `json:"results"`
+}
+
+// Failed returns all results which are not OK.
+func (r *Report) Failed() []*Result {
+	failed := []*Result{}
+	for _, res := range r.Results {
+		if !res.OK() {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Markdown returns a markdown table of all results in the report.
+func (r *Report) Markdown() string {
+	buf := &bytes.Buffer{}
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeader([]string{"Legacy Path", "Redirect", "Digest", "Status"})
+	for _, res := range r.Results {
+		redirect := res.Redirect
+		if redirect == "" {
+			redirect = "-"
+		}
+		digest := res.LegacyDigest
+		if digest == "" {
+			digest = "-"
+		}
+		table.Append([]string{res.LegacyPath, redirect, digest, res.Status()})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+	return buf.String()
+}
+
+// Auditor is the main structure for auditing the legacy registry.
+type Auditor struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Auditor instance.
+func New(opts *Options) *Auditor {
+	return &Auditor{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (a *Auditor) SetImpl(impl impl) {
+	a.impl = impl
+}
+
+// Paths returns the configured legacy paths from the options and the config
+// file.
+func (a *Auditor) Paths() ([]string, error) {
+	paths := append([]string{}, a.options.Paths...)
+	if a.options.ConfigFile == "" {
+		return paths, nil
+	}
+
+	content, err := a.impl.ReadFile(a.options.ConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("parse config file: %w", err)
+	}
+	return append(paths, config.Paths...), nil
+}
+
+// Audit probes all legacy paths and returns the report.
+func (a *Auditor) Audit() (*Report, error) {
+	if err := a.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	paths, err := a.Paths()
+	if err != nil {
+		return nil, fmt.Errorf("get legacy paths: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, errors.New("no legacy paths to audit")
+	}
+
+	report := &Report{
+		LegacyRegistry: a.options.LegacyRegistry,
+		TargetRegistry: a.options.TargetRegistry,
+		Results:        []*Result{},
+	}
+	for _, path := range paths {
+		report.Results = append(report.Results, a.probe(path))
+	}
+	return report, nil
+}
+
+// Run audits all legacy paths, writes the report and fails if any of the
+// paths does not resolve to the same digest as in the target registry.
+func (a *Auditor) Run() error {
+	report, err := a.Audit()
+	if err != nil {
+		return err
+	}
+	if err := a.WriteReport(report); err != nil {
+		return err
+	}
+	fmt.Print(report.Markdown())
+	return a.Check(report)
+}
+
+// Check fails if any of the legacy paths of the report does not resolve to
+// the same digest as in the target registry.
+func (a *Auditor) Check(report *Report) error {
+	if failed := report.Failed(); len(failed) > 0 {
+		return fmt.Errorf(
+			"%d of %d legacy paths do not resolve to %s",
+			len(failed), len(report.Results), a.options.TargetRegistry,
+		)
+	}
+	logrus.Infof(
+		"All %d legacy paths resolve to %s", len(report.Results), a.options.TargetRegistry,
+	)
+	return nil
+}
+
+// WriteReport writes the report as JSON to the report file, if configured.
+func (a *Auditor) WriteReport(report *Report) error {
+	if a.options.ReportFile == "" {
+		return nil
+	}
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+	if err := a.impl.WriteFile(a.options.ReportFile, content); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	logrus.Infof("Wrote registry audit report to %s", a.options.ReportFile)
+	return nil
+}
+
+// probe verifies a single legacy path.
+func (a *Auditor) probe(path string) *Result {
+	res := &Result{LegacyPath: path}
+	logrus.Infof("Probing legacy path %s", path)
+
+	legacyPrefix := strings.TrimSuffix(a.options.LegacyRegistry, "/") + "/"
+	if !strings.HasPrefix(path, legacyPrefix) {
+		res.Error = "not a path of " + a.options.LegacyRegistry
+		return res
+	}
+	res.TargetPath = strings.TrimSuffix(a.options.TargetRegistry, "/") + "/" +
+		strings.TrimPrefix(path, legacyPrefix)
+
+	redirect, err := a.impl.RedirectLocation(path)
+	if err != nil {
+		res.Error = fmt.Sprintf("check redirect: %v", err)
+		return res
+	}
+	res.Redirect = redirect
+	if redirect != "" {
+		u, err := url.Parse(redirect)
+		if err != nil {
+			res.Error = fmt.Sprintf("parse redirect: %v", err)
+			return res
+		}
+		targetHost := strings.SplitN(a.options.TargetRegistry, "/", 2)[0]
+		if u.Host != targetHost {
+			res.Error = "redirects to unexpected host " + u.Host
+			return res
+		}
+	}
+
+	res.LegacyDigest, err = a.impl.Digest(path)
+	if err != nil {
+		res.Error = fmt.Sprintf("resolve legacy digest: %v", err)
+		return res
+	}
+	res.TargetDigest, err = a.impl.Digest(res.TargetPath)
+	if err != nil {
+		res.Error = fmt.Sprintf("resolve target digest: %v", err)
+		return res
+	}
+	return res
+}
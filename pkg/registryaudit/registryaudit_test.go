@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registryaudit_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/registryaudit"
+	"k8s.io/release/pkg/registryaudit/registryauditfakes"
+)
+
+var errTest = errors.New("test")
+
+func TestAudit(t *testing.T) {
+	const (
+		legacyPath = "k8s.gcr.io/pause:3.9"
+		targetPath = "registry.k8s.io/pause:3.9"
+		digest     = "sha256:7031c1b283388d2c2e09b57badb803c05ebed362dc88d84b480cc47f72a21097"
+	)
+
+	for _, tc := range []struct {
+		paths   []string
+		config  string
+		prepare func(*registryauditfakes.FakeImpl)
+		assert  func(*registryaudit.Report, error)
+	}{
+		{ // success redirect
+			paths: []string{legacyPath},
+			prepare: func(mock *registryauditfakes.FakeImpl) {
+				mock.RedirectLocationReturns("https://registry.k8s.io/v2/pause/manifests/3.9", nil)
+				mock.DigestReturns(digest, nil)
+			},
+			assert: func(report *registryaudit.Report, err error) {
+				require.Nil(t, err)
+				require.Len(t, report.Results, 1)
+				require.Equal(t, targetPath, report.Results[0].TargetPath)
+				require.True(t, report.Results[0].OK())
+				require.Empty(t, report.Failed())
+			},
+		},
+		{ // success alias from config file
+			config: "paths:\n- " + legacyPath + "\n",
+			prepare: func(mock *registryauditfakes.FakeImpl) {
+				mock.DigestReturns(digest, nil)
+			},
+			assert: func(report *registryaudit.Report, err error) {
+				require.Nil(t, err)
+				require.Len(t, report.Results, 1)
+				require.Empty(t, report.Results[0].Redirect)
+				require.Empty(t, report.Failed())
+			},
+		},
+		{ // digest mismatch
+			paths: []string{legacyPath},
+			prepare: func(mock *registryauditfakes.FakeImpl) {
+				mock.DigestReturnsOnCall(0, digest, nil)
+				mock.DigestReturnsOnCall(1, "sha256:other", nil)
+			},
+			assert: func(report *registryaudit.Report, err error) {
+				require.Nil(t, err)
+				require.Len(t, report.Failed(), 1)
+				require.Equal(t, "digest mismatch", report.Results[0].Status())
+			},
+		},
+		{ // unexpected redirect
+			paths: []string{legacyPath},
+			prepare: func(mock *registryauditfakes.FakeImpl) {
+				mock.RedirectLocationReturns("https://example.com/v2/pause/manifests/3.9", nil)
+			},
+			assert: func(report *registryaudit.Report, err error) {
+				require.Nil(t, err)
+				require.Len(t, report.Failed(), 1)
+				require.Contains(t, report.Results[0].Error, "example.com")
+			},
+		},
+		{ // not a legacy path
+			paths:   []string{"gcr.io/pause:3.9"},
+			prepare: func(*registryauditfakes.FakeImpl) {},
+			assert: func(report *registryaudit.Report, err error) {
+				require.Nil(t, err)
+				require.Len(t, report.Failed(), 1)
+			},
+		},
+		{ // failure Digest
+			paths: []string{legacyPath},
+			prepare: func(mock *registryauditfakes.FakeImpl) {
+				mock.DigestReturns("", errTest)
+			},
+			assert: func(report *registryaudit.Report, err error) {
+				require.Nil(t, err)
+				require.Len(t, report.Failed(), 1)
+				require.Contains(t, report.Results[0].Status(), "error")
+			},
+		},
+		{ // failure invalid config
+			config:  "invalid: true\n",
+			prepare: func(*registryauditfakes.FakeImpl) {},
+			assert: func(report *registryaudit.Report, err error) {
+				require.NotNil(t, err)
+			},
+		},
+		{ // failure no paths
+			prepare: func(*registryauditfakes.FakeImpl) {},
+			assert: func(report *registryaudit.Report, err error) {
+				require.NotNil(t, err)
+			},
+		},
+	} {
+		opts := registryaudit.DefaultOptions()
+		opts.Paths = tc.paths
+		mock := &registryauditfakes.FakeImpl{}
+		if tc.config != "" {
+			opts.ConfigFile = "config.yaml"
+			mock.ReadFileReturns([]byte(tc.config), nil)
+		}
+		tc.prepare(mock)
+
+		sut := registryaudit.New(opts)
+		sut.SetImpl(mock)
+		tc.assert(sut.Audit())
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		prepare     func(*registryauditfakes.FakeImpl)
+		shouldError bool
+	}{
+		{ // success
+			prepare: func(mock *registryauditfakes.FakeImpl) {
+				mock.DigestReturns("sha256:digest", nil)
+			},
+		},
+		{ // failed paths
+			prepare: func(mock *registryauditfakes.FakeImpl) {
+				mock.DigestReturns("", errTest)
+			},
+			shouldError: true,
+		},
+		{ // failure WriteFile
+			prepare: func(mock *registryauditfakes.FakeImpl) {
+				mock.DigestReturns("sha256:digest", nil)
+				mock.WriteFileReturns(errTest)
+			},
+			shouldError: true,
+		},
+	} {
+		opts := registryaudit.DefaultOptions()
+		opts.Paths = []string{"k8s.gcr.io/pause:3.9"}
+		opts.ReportFile = "report.json"
+		mock := &registryauditfakes.FakeImpl{}
+		tc.prepare(mock)
+
+		sut := registryaudit.New(opts)
+		sut.SetImpl(mock)
+		err := sut.Run()
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+			require.Equal(t, 1, mock.WriteFileCallCount())
+		}
+	}
+}
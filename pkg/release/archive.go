@@ -31,6 +31,8 @@ import (
 	"sigs.k8s.io/release-utils/tar"
 
 	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/layout"
 )
 
 const (
@@ -156,9 +158,9 @@ func (archiver *Archiver) ArchiveRelease() error {
 		return fmt.Errorf("looking for stale password files: %w", err)
 	}
 
-	// Clean previous staged builds
+	// Clean previous staged builds, which are the siblings of the stage path
 	if err := archiver.impl.CleanStagedBuilds(
-		object.GcsPrefix+filepath.Join(archiver.opts.Bucket, StagePath),
+		object.GcsPrefix+filepath.Dir(layout.Default().StagePath(archiver.opts.Bucket, archiver.opts.BuildVersion)),
 		archiver.opts.BuildVersion,
 	); err != nil {
 		return fmt.Errorf("deleting previous staged builds: %w", err)
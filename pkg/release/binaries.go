@@ -19,6 +19,7 @@ package release
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/release/pkg/binary"
@@ -30,8 +31,13 @@ type ArtifactChecker struct {
 }
 
 type ArtifactCheckerOptions struct {
-	GitRoot  string   // Directory where the repo was cloned
-	Versions []string // Version tags we are checking
+	GitRoot   string   // Directory where the repo was cloned
+	Versions  []string // Version tags we are checking
+	GoVersion string   // Optional Go version the binaries have to be built with
+	Platforms []string // Optional os/arch platforms which have to be built
+
+	// LinkagePolicies per binary name, DefaultLinkagePolicies if not set
+	LinkagePolicies map[string]*LinkagePolicy
 }
 
 func NewArtifactChecker() *ArtifactChecker {
@@ -71,10 +77,66 @@ func (ac *ArtifactChecker) CheckBinaryArchitectures() error {
 	return nil
 }
 
+// CheckBinaryBuildInfo ensures all the binaries produced in each release
+// embed the right version and build metadata
+func (ac *ArtifactChecker) CheckBinaryBuildInfo() error {
+	for _, tag := range ac.opts.Versions {
+		if err := ac.impl.CheckVersionBuildInfo(ac.opts, tag); err != nil {
+			return fmt.Errorf("checking build info in %s binaries: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+// CheckBinaryLinkage ensures all the binaries produced in each release
+// comply with their linkage policy
+func (ac *ArtifactChecker) CheckBinaryLinkage() error {
+	for _, tag := range ac.opts.Versions {
+		if err := ac.impl.CheckVersionLinkage(ac.opts, tag); err != nil {
+			return fmt.Errorf("checking linkage of %s binaries: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+// CheckBuildPlatforms ensures that binaries got built for all the expected
+// platforms of each release
+func (ac *ArtifactChecker) CheckBuildPlatforms() error {
+	if len(ac.opts.Platforms) == 0 {
+		return nil
+	}
+	for _, tag := range ac.opts.Versions {
+		binaries, err := ac.impl.ListReleaseBinaries(ac.opts, tag)
+		if err != nil {
+			return fmt.Errorf("listing binaries for release %s: %w", tag, err)
+		}
+		built := map[string]bool{}
+		for _, bin := range binaries {
+			built[bin.Platform+"/"+bin.Arch] = true
+		}
+		missing := []string{}
+		for _, platform := range ac.opts.Platforms {
+			if !built[platform] {
+				missing = append(missing, platform)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf(
+				"no %s binaries found for platforms: %s", tag, strings.Join(missing, ", "),
+			)
+		}
+	}
+	return nil
+}
+
 type artifactCheckerImplementation interface {
 	ListReleaseBinaries(opts *ArtifactCheckerOptions, version string) ([]struct{ Path, Platform, Arch string }, error)
 	CheckVersionTags(*ArtifactCheckerOptions, string) error
 	CheckVersionArch(*ArtifactCheckerOptions, string) error
+	CheckVersionBuildInfo(*ArtifactCheckerOptions, string) error
+	CheckVersionLinkage(*ArtifactCheckerOptions, string) error
+	ListReleaseImages(opts *ArtifactCheckerOptions, version string) ([]string, error)
+	CheckVersionGoVersions(*ArtifactCheckerOptions, string) error
 }
 
 type defaultArtifactCheckerImpl struct{}
@@ -146,13 +208,126 @@ func (impl *defaultArtifactCheckerImpl) CheckVersionArch(
 			)
 		}
 
-		linkMode, err := bin.LinkMode()
+	}
+	return nil
+}
+
+// CheckVersionBuildInfo checks that the binaries of a certain version embed
+// the expected version via the linker flags, or contain the version tag if
+// no linker flags are recorded. It also verifies the Go version
+// (if specified in the options) and reports the CGO setting and build mode.
+func (impl *defaultArtifactCheckerImpl) CheckVersionBuildInfo(
+	opts *ArtifactCheckerOptions, version string,
+) error {
+	binaries, err := impl.ListReleaseBinaries(opts, version)
+	if err != nil {
+		return fmt.Errorf("listing binaries for release %s: %w", version, err)
+	}
+	logrus.Infof("Checking build info of %d binaries for version %s", len(binaries), version)
+	for _, binData := range binaries {
+		// The mounter binary is not a Go binary
+		if filepath.Base(binData.Path) == "mounter" {
+			continue
+		}
+
+		bin, err := binary.New(binData.Path)
 		if err != nil {
-			logrus.Warnf("Unable to get linkmode from binary %s: %v", binData.Path, err)
-		} else if linkMode == binary.LinkModeDynamic {
-			// TODO: fail hard if not all binaries are static (or unknown)
-			// Ref: https://github.com/kubernetes/release/issues/2786
-			logrus.Warnf("Binary is dynamically linked, which should be nothing we release: %s", binData.Path)
+			return fmt.Errorf("creating binary object from %s: %w", binData.Path, err)
+		}
+
+		info, err := bin.BuildInfo()
+		if err != nil {
+			return fmt.Errorf("getting build info from %s: %w", binData.Path, err)
+		}
+
+		logrus.Debugf(
+			"Binary %s: version %s, %s, CGO enabled: %v, build mode: %s",
+			binData.Path, info.GitVersion(), info.GoVersion,
+			info.CGOEnabled, info.BuildMode,
+		)
+
+		switch gitVersion := info.GitVersion(); gitVersion {
+		case version:
+		case "":
+			// The linker flags are not recorded for binaries built with
+			// -trimpath, so fall back to scanning the binary for the tag
+			logrus.Debugf("No linker flags recorded in %s, scanning binary for version", binData.Path)
+			contains, err := bin.ContainsStrings(version)
+			if err != nil {
+				return fmt.Errorf("scanning binary %s: %w", binData.Path, err)
+			}
+			if !contains {
+				return fmt.Errorf(
+					"tag %s not found in produced binary: %s", version, binData.Path,
+				)
+			}
+		default:
+			return fmt.Errorf(
+				"binary %s reports wrong version: expected %s got %q",
+				binData.Path, version, gitVersion,
+			)
+		}
+
+		if opts.GoVersion != "" && info.GoVersion != opts.GoVersion {
+			return fmt.Errorf(
+				"binary %s has been built with wrong go version: expected %s got %s",
+				binData.Path, opts.GoVersion, info.GoVersion,
+			)
+		}
+
+		if info.CGOEnabled {
+			logrus.Warnf("Binary has been built with CGO enabled: %s", binData.Path)
+		}
+
+		if info.BuildMode != "exe" {
+			logrus.Warnf("Binary has unexpected build mode %q: %s", info.BuildMode, binData.Path)
+		}
+	}
+	return nil
+}
+
+// CheckVersionLinkage checks that the binaries of a certain version are
+// linked according to their policy. Binaries without a policy are only
+// reported if they are dynamically linked.
+func (impl *defaultArtifactCheckerImpl) CheckVersionLinkage(
+	opts *ArtifactCheckerOptions, version string,
+) error {
+	binaries, err := impl.ListReleaseBinaries(opts, version)
+	if err != nil {
+		return fmt.Errorf("listing binaries for release %s: %w", version, err)
+	}
+
+	policies := opts.LinkagePolicies
+	if policies == nil {
+		policies = DefaultLinkagePolicies
+	}
+
+	logrus.Infof("Checking linkage of %d binaries for version %s", len(binaries), version)
+	for _, binData := range binaries {
+		bin, err := binary.New(binData.Path)
+		if err != nil {
+			return fmt.Errorf("creating binary object from %s: %w", binData.Path, err)
+		}
+
+		linkage, err := bin.Linkage()
+		if err != nil {
+			return fmt.Errorf("getting linkage of %s: %w", binData.Path, err)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(binData.Path), ".exe")
+		policy, ok := policies[name]
+		if !ok {
+			if linkage.Mode == binary.LinkModeDynamic {
+				logrus.Warnf("Binary is dynamically linked, which should be nothing we release: %s", binData.Path)
+			}
+			continue
+		}
+
+		if err := policy.Check(linkage); err != nil {
+			return fmt.Errorf(
+				"binary %s (%s/%s) violates linkage policy: %w",
+				binData.Path, binData.Platform, binData.Arch, err,
+			)
 		}
 	}
 	return nil
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+// Component is a binary, which can be released independently including its
+// container image if available.
+type Component string
+
+// The components which can be selected for a partial release.
+const (
+	ComponentKubectl               Component = "kubectl"
+	ComponentKubeadm               Component = "kubeadm"
+	ComponentKubelet               Component = "kubelet"
+	ComponentKubeProxy             Component = "kube-proxy"
+	ComponentKubeAPIServer         Component = "kube-apiserver"
+	ComponentKubeControllerManager Component = "kube-controller-manager"
+	ComponentKubeScheduler         Component = "kube-scheduler"
+)
+
+// controlPlaneComponents get deployed together by kubeadm and therefore
+// always have to be released at the same version.
+var controlPlaneComponents = []Component{
+	ComponentKubeAPIServer,
+	ComponentKubeControllerManager,
+	ComponentKubeScheduler,
+}
+
+// AllComponents returns all components which can be selected.
+func AllComponents() []Component {
+	return append([]Component{
+		ComponentKubectl,
+		ComponentKubeadm,
+		ComponentKubelet,
+		ComponentKubeProxy,
+	}, controlPlaneComponents...)
+}
+
+// Components is a selection of components to be released. An empty
+// selection means a full release.
+type Components []Component
+
+// ParseComponents converts the provided names into a validated selection.
+func ParseComponents(names []string) (Components, error) {
+	components := Components{}
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			components = append(components, Component(name))
+		}
+	}
+	if err := components.Validate(); err != nil {
+		return nil, err
+	}
+	return components, nil
+}
+
+// Validate verifies that the selection is internally consistent.
+func (c Components) Validate() error {
+	known := map[Component]bool{}
+	for _, component := range AllComponents() {
+		known[component] = true
+	}
+
+	seen := map[Component]bool{}
+	for _, component := range c {
+		if !known[component] {
+			return fmt.Errorf(
+				"unknown component %q, has to be one of: %s",
+				component, Components(AllComponents()),
+			)
+		}
+		if seen[component] {
+			return fmt.Errorf("component %q selected multiple times", component)
+		}
+		seen[component] = true
+	}
+
+	missing := []string{}
+	for _, component := range controlPlaneComponents {
+		if !seen[component] {
+			missing = append(missing, string(component))
+		}
+	}
+	if len(missing) > 0 && len(missing) < len(controlPlaneComponents) {
+		return fmt.Errorf(
+			"the control plane components have to be released together, missing: %s",
+			strings.Join(missing, ", "),
+		)
+	}
+
+	return nil
+}
+
+// Partial returns true if only a subset of the components gets released.
+func (c Components) Partial() bool {
+	return len(c) > 0 && len(c) < len(AllComponents())
+}
+
+// Contains returns true if the provided binary or image name is part of the
+// selection. Every name is part of a full release.
+func (c Components) Contains(name string) bool {
+	if !c.Partial() {
+		return true
+	}
+	name = strings.TrimSuffix(name, ".exe")
+	for _, component := range c {
+		if string(component) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the comma separated list of component names.
+func (c Components) String() string {
+	names := make([]string, 0, len(c))
+	for _, component := range c {
+		names = append(names, string(component))
+	}
+	return strings.Join(names, ", ")
+}
+
+// copyComponentBinaries copies the binaries of the selected components from
+// srcDir to dstDir.
+func copyComponentBinaries(srcDir, dstDir string, components Components) error {
+	if !components.Partial() {
+		return util.CopyDirContentsLocal(srcDir, dstDir)
+	}
+
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		return fmt.Errorf("read binaries dir %s: %w", srcDir, err)
+	}
+	if err := os.MkdirAll(dstDir, os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("create binaries dir %s: %w", dstDir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !components.Contains(entry.Name()) {
+			continue
+		}
+		if err := util.CopyFileLocal(
+			filepath.Join(srcDir, entry.Name()),
+			filepath.Join(dstDir, entry.Name()),
+			true,
+		); err != nil {
+			return fmt.Errorf("copy binary %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+)
+
+func TestParseComponents(t *testing.T) {
+	for _, tc := range []struct {
+		names     []string
+		partial   bool
+		shouldErr bool
+	}{
+		{names: nil},
+		{names: []string{"kubectl"}, partial: true},
+		{names: []string{"kubeadm", " kubelet "}, partial: true},
+		{names: []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler"}, partial: true},
+		{names: []string{"kube-apiserver"}, shouldErr: true},
+		{names: []string{"kubectl", "kubectl"}, shouldErr: true},
+		{names: []string{"kubefoo"}, shouldErr: true},
+	} {
+		res, err := release.ParseComponents(tc.names)
+		if tc.shouldErr {
+			require.Error(t, err, tc.names)
+			continue
+		}
+		require.NoError(t, err, tc.names)
+		require.Equal(t, tc.partial, res.Partial(), tc.names)
+	}
+
+	all, err := release.ParseComponents([]string{
+		"kubectl", "kubeadm", "kubelet", "kube-proxy",
+		"kube-apiserver", "kube-controller-manager", "kube-scheduler",
+	})
+	require.NoError(t, err)
+	require.False(t, all.Partial())
+}
+
+func TestComponentsContains(t *testing.T) {
+	full := release.Components{}
+	require.True(t, full.Contains("kubectl"))
+	require.True(t, full.Contains("conformance"))
+
+	partial := release.Components{release.ComponentKubectl}
+	require.True(t, partial.Contains("kubectl"))
+	require.True(t, partial.Contains("kubectl.exe"))
+	require.False(t, partial.Contains("kubeadm"))
+	require.False(t, partial.Contains("conformance"))
+	require.Equal(t, "kubectl", partial.String())
+}
+
+func TestCopyComponentBinaries(t *testing.T) {
+	rootPath := t.TempDir()
+	for platform, binaries := range map[string][]string{
+		"linux-amd64":   {"kubectl", "kubeadm", "kubelet"},
+		"windows-amd64": {"kubectl.exe", "kubeadm.exe"},
+	} {
+		binDir := filepath.Join(rootPath, "client", platform, "kubernetes", "client", "bin")
+		require.NoError(t, os.MkdirAll(binDir, os.FileMode(0o755)))
+		for _, binary := range binaries {
+			require.NoError(t, os.WriteFile(
+				filepath.Join(binDir, binary), []byte(binary), os.FileMode(0o755),
+			))
+		}
+	}
+	stageDir := filepath.Join(rootPath, release.StagePath)
+
+	require.NoError(t, release.CopyComponentBinaries(
+		rootPath, stageDir, release.Components{release.ComponentKubectl},
+	))
+
+	for _, tc := range []struct {
+		path   string
+		exists bool
+	}{
+		{"bin/linux/amd64/kubectl", true},
+		{"bin/linux/amd64/kubeadm", false},
+		{"bin/linux/amd64/kubelet", false},
+		{"bin/windows/amd64/kubectl.exe", true},
+		{"bin/windows/amd64/kubeadm.exe", false},
+	} {
+		_, err := os.Stat(filepath.Join(stageDir, tc.path))
+		if tc.exists {
+			require.NoError(t, err, tc.path)
+		} else {
+			require.True(t, os.IsNotExist(err), tc.path)
+		}
+	}
+}
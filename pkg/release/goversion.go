@@ -0,0 +1,238 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"archive/tar"
+	"bytes"
+	"debug/buildinfo"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/sirupsen/logrus"
+)
+
+// GoVersionFile is the file in the root of the Kubernetes repository which
+// pins the Go version of a branch.
+const GoVersionFile = ".go-version"
+
+// imageBinaryDir is the location of the Kubernetes binary in release images.
+const imageBinaryDir = "usr/local/bin"
+
+// imageBinaryPaths are the Go binaries of the release images which do not
+// contain their binary in the imageBinaryDir.
+var imageBinaryPaths = map[string]string{
+	"kubectl":     "bin/kubectl",
+	"conformance": imageBinaryDir + "/e2e.test",
+}
+
+// errImageBinaryNotFound is returned if an image does not contain the
+// expected Go binary.
+var errImageBinaryNotFound = errors.New("binary not found in image")
+
+// PinnedGoVersion returns the Go version pinned in the repository, for
+// example `go1.21.6`. It returns an empty string if no version is pinned.
+func PinnedGoVersion(gitRoot string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(gitRoot, GoVersionFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read %s: %w", GoVersionFile, err)
+	}
+
+	version := strings.TrimSpace(string(content))
+	if version == "" {
+		return "", nil
+	}
+	if !strings.HasPrefix(version, "go") {
+		version = "go" + version
+	}
+	return version, nil
+}
+
+// CheckGoVersions ensures that all binaries and images produced in each
+// release have been built with the same Go version. If the options contain
+// a Go version, then all components have to match it.
+func (ac *ArtifactChecker) CheckGoVersions() error {
+	for _, tag := range ac.opts.Versions {
+		if err := ac.impl.CheckVersionGoVersions(ac.opts, tag); err != nil {
+			return fmt.Errorf("checking go versions of %s artifacts: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+// ValidateGoVersions verifies that all components (mapped to their Go
+// version) use the same Go version, which has to be the expected one if set.
+func ValidateGoVersions(components map[string]string, expected string) error {
+	byVersion := map[string][]string{}
+	for component, version := range components {
+		byVersion[version] = append(byVersion[version], component)
+	}
+
+	if expected == "" {
+		if len(byVersion) <= 1 {
+			return nil
+		}
+	} else if _, ok := byVersion[expected]; ok && len(byVersion) == 1 {
+		return nil
+	}
+
+	versions := []string{}
+	for version := range byVersion {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	details := []string{}
+	for _, version := range versions {
+		if version == expected {
+			continue
+		}
+		sort.Strings(byVersion[version])
+		details = append(details, fmt.Sprintf(
+			"%s: %s", version, strings.Join(byVersion[version], ", "),
+		))
+	}
+
+	if expected == "" {
+		return fmt.Errorf(
+			"artifacts have been built with different go versions (%s)",
+			strings.Join(details, "; "),
+		)
+	}
+	return fmt.Errorf(
+		"artifacts have not been built with the pinned go version %s (%s)",
+		expected, strings.Join(details, "; "),
+	)
+}
+
+// ListReleaseImages lists a release's image tarballs
+func (impl *defaultArtifactCheckerImpl) ListReleaseImages(
+	opts *ArtifactCheckerOptions, version string,
+) ([]string, error) {
+	return ListBuildImages(opts.GitRoot, version)
+}
+
+// CheckVersionGoVersions extracts the Go version from every binary and
+// image of a certain version and validates them.
+func (impl *defaultArtifactCheckerImpl) CheckVersionGoVersions(
+	opts *ArtifactCheckerOptions, version string,
+) error {
+	binaries, err := impl.ListReleaseBinaries(opts, version)
+	if err != nil {
+		return fmt.Errorf("listing binaries for release %s: %w", version, err)
+	}
+
+	components := map[string]string{}
+	for _, binData := range binaries {
+		// The mounter binary is not a Go binary
+		if filepath.Base(binData.Path) == "mounter" {
+			continue
+		}
+
+		info, err := buildinfo.ReadFile(binData.Path)
+		if err != nil {
+			return fmt.Errorf("reading build info from %s: %w", binData.Path, err)
+		}
+		components[binData.Path] = info.GoVersion
+	}
+
+	images, err := impl.ListReleaseImages(opts, version)
+	if err != nil {
+		return fmt.Errorf("listing images for release %s: %w", version, err)
+	}
+
+	for _, image := range images {
+		if !strings.HasSuffix(image, ".tar") {
+			continue
+		}
+
+		goVersion, err := imageGoVersion(image)
+		if errors.Is(err, errImageBinaryNotFound) {
+			logrus.Warnf("Skipping go version check of image %s: %v", image, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("getting go version of image %s: %w", image, err)
+		}
+		components[image] = goVersion
+	}
+
+	logrus.Infof(
+		"Checking go version of %d binaries and images for version %s",
+		len(components), version,
+	)
+	return ValidateGoVersions(components, opts.GoVersion)
+}
+
+// imageGoVersion returns the Go version of the Kubernetes binary inside an
+// image tarball. The binary is expected to be named like the tarball, for
+// example /usr/local/bin/kube-apiserver for kube-apiserver.tar, unless the
+// image is listed in the imageBinaryPaths.
+func imageGoVersion(path string) (string, error) {
+	img, err := tarball.ImageFromPath(path, nil)
+	if err != nil {
+		return "", fmt.Errorf("open image tarball: %w", err)
+	}
+
+	imageName := strings.TrimSuffix(filepath.Base(path), ".tar")
+	binaryPath, ok := imageBinaryPaths[imageName]
+	if !ok {
+		binaryPath = imageBinaryDir + "/" + imageName
+	}
+
+	fs := mutate.Extract(img)
+	defer fs.Close()
+
+	reader := tar.NewReader(fs)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("read image filesystem: %w", err)
+		}
+
+		name := strings.TrimPrefix(filepath.Clean(header.Name), "/")
+		if header.Typeflag != tar.TypeReg || name != binaryPath {
+			continue
+		}
+
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return "", fmt.Errorf("read %s: %w", binaryPath, err)
+		}
+
+		info, err := buildinfo.Read(bytes.NewReader(content))
+		if err != nil {
+			return "", fmt.Errorf("reading build info from %s: %w", binaryPath, err)
+		}
+		return info.GoVersion, nil
+	}
+
+	return "", fmt.Errorf("%w: %s", errImageBinaryNotFound, binaryPath)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+)
+
+func TestPinnedGoVersion(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		content  *string
+		expected string
+	}{
+		{content: nil, expected: ""},
+		{content: ptr(""), expected: ""},
+		{content: ptr("1.21.6\n"), expected: "go1.21.6"},
+		{content: ptr("go1.22.0"), expected: "go1.22.0"},
+	} {
+		dir := t.TempDir()
+		if tc.content != nil {
+			require.NoError(t, os.WriteFile(
+				filepath.Join(dir, release.GoVersionFile), []byte(*tc.content), 0o644,
+			))
+		}
+
+		res, err := release.PinnedGoVersion(dir)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, res)
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestValidateGoVersions(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		components  map[string]string
+		expected    string
+		shouldError bool
+	}{
+		{ // no components
+			components:  map[string]string{},
+			shouldError: false,
+		},
+		{ // consistent without pin
+			components:  map[string]string{"kubectl": "go1.21.6", "kube-apiserver.tar": "go1.21.6"},
+			shouldError: false,
+		},
+		{ // consistent with pin
+			components:  map[string]string{"kubectl": "go1.21.6", "kube-apiserver.tar": "go1.21.6"},
+			expected:    "go1.21.6",
+			shouldError: false,
+		},
+		{ // inconsistent without pin
+			components:  map[string]string{"kubectl": "go1.21.6", "kube-apiserver.tar": "go1.21.5"},
+			shouldError: true,
+		},
+		{ // consistent but not pinned version
+			components:  map[string]string{"kubectl": "go1.21.5", "kube-apiserver.tar": "go1.21.5"},
+			expected:    "go1.21.6",
+			shouldError: true,
+		},
+		{ // single component differs from pin
+			components:  map[string]string{"kubectl": "go1.21.6", "kube-apiserver.tar": "go1.21.5"},
+			expected:    "go1.21.6",
+			shouldError: true,
+		},
+	} {
+		err := release.ValidateGoVersions(tc.components, tc.expected)
+		if tc.shouldError {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
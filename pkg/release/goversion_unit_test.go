@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestImage(t *testing.T, path, binaryPath string) {
+	// The test binary itself is a Go binary containing build info
+	content, err := os.ReadFile(os.Args[0])
+	require.NoError(t, err)
+
+	layerContent := &bytes.Buffer{}
+	writer := tar.NewWriter(layerContent)
+	require.NoError(t, writer.WriteHeader(&tar.Header{
+		Name:     binaryPath,
+		Typeflag: tar.TypeReg,
+		Mode:     0o755,
+		Size:     int64(len(content)),
+	}))
+	_, err = writer.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(layerContent.Bytes())), nil
+	})
+	require.NoError(t, err)
+
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	require.NoError(t, err)
+
+	ref, err := name.ParseReference("registry.k8s.io/kube-apiserver-amd64:v1.29.1")
+	require.NoError(t, err)
+	require.NoError(t, tarball.WriteToFile(path, ref, img))
+}
+
+func TestImageGoVersion(t *testing.T) {
+	dir := t.TempDir()
+
+	image := filepath.Join(dir, "kube-apiserver.tar")
+	writeTestImage(t, image, "/usr/local/bin/kube-apiserver")
+	res, err := imageGoVersion(image)
+	require.NoError(t, err)
+	require.Equal(t, runtime.Version(), res)
+
+	wrongImage := filepath.Join(dir, "kube-scheduler.tar")
+	writeTestImage(t, wrongImage, "/usr/local/bin/kube-apiserver")
+	_, err = imageGoVersion(wrongImage)
+	require.ErrorIs(t, err, errImageBinaryNotFound)
+
+	kubectlImage := filepath.Join(dir, "kubectl.tar")
+	writeTestImage(t, kubectlImage, "/bin/kubectl")
+	res, err = imageGoVersion(kubectlImage)
+	require.NoError(t, err)
+	require.Equal(t, runtime.Version(), res)
+
+	_, err = imageGoVersion(filepath.Join(dir, "not-existing.tar"))
+	require.Error(t, err)
+}
@@ -17,17 +17,21 @@ limitations under the License.
 package release
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/google/go-containerregistry/pkg/crane"
 	"github.com/sirupsen/logrus"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/release/pkg/consts"
+	"k8s.io/release/pkg/platforms"
+	"k8s.io/release/pkg/progress"
+	"k8s.io/release/pkg/retry"
+	"k8s.io/release/pkg/signkey"
+	"k8s.io/release/pkg/workdir"
 
 	"sigs.k8s.io/release-sdk/sign"
 	"sigs.k8s.io/release-utils/command"
@@ -36,14 +40,15 @@ import (
 // Images is a wrapper around container image related functionality.
 type Images struct {
 	imageImpl
-	signer *sign.Signer
+	signer     *sign.Signer
+	components Components
 }
 
 // NewImages creates a new Images instance
 func NewImages() *Images {
 	return &Images{
 		imageImpl: &defaultImageImpl{},
-		signer:    sign.New(sign.Default()),
+		signer:    sign.New(signkey.Default().SignOptions()),
 	}
 }
 
@@ -52,6 +57,12 @@ func (i *Images) SetImpl(impl imageImpl) {
 	i.imageImpl = impl
 }
 
+// SetComponents restricts the images to the ones of the selected components.
+// An empty selection keeps all images.
+func (i *Images) SetComponents(components Components) {
+	i.components = components
+}
+
 // imageImpl is a client for working with container images.
 //
 //counterfeiter:generate . imageImpl
@@ -159,6 +170,8 @@ func (i *Images) Publish(registry, version, buildPath string) error {
 		return fmt.Errorf("enable docker experimental CLI: %w", err)
 	}
 
+	bar := progress.NewBar("Pushing manifest images", len(manifestImages))
+	defer bar.Finish()
 	for image, arches := range manifestImages {
 		imageVersion := fmt.Sprintf("%s:%s", image, version)
 		logrus.Infof("Creating manifest image %s", imageVersion)
@@ -190,23 +203,13 @@ func (i *Images) Publish(registry, version, buildPath string) error {
 		}
 
 		logrus.Infof("Pushing manifest image %s", imageVersion)
-		if err := wait.ExponentialBackoff(wait.Backoff{
-			Duration: time.Second,
-			Factor:   1.5,
-			Steps:    5,
-		}, func() (bool, error) {
-			if err := i.Execute("docker", "manifest", "push", imageVersion, "--purge"); err == nil {
-				return true, nil
-			} else if strings.Contains(err.Error(), "request canceled while waiting for connection") {
-				// The error is unfortunately not exported:
-				// https://github.com/golang/go/blob/dc04f3b/src/net/http/client.go#L720
-				// https://github.com/golang/go/blob/dc04f3b/src/net/http/transport.go#L2518
-				// ref: https://github.com/kubernetes/release/issues/2810
-				logrus.Info("Retrying manifest push")
-				return false, nil
-			}
-
-			return false, err
+		// Transient errors like "request canceled while waiting for
+		// connection" are only available within the docker output.
+		// ref: https://github.com/kubernetes/release/issues/2810
+		policy := retry.DefaultPolicy()
+		policy.Factor = 1.5
+		if err := policy.Do(context.Background(), retry.ServiceRegistry, func() error {
+			return i.Execute("docker", "manifest", "push", imageVersion, "--purge")
 		}); err != nil {
 			return fmt.Errorf("push manifest: %w", err)
 		}
@@ -214,6 +217,7 @@ func (i *Images) Publish(registry, version, buildPath string) error {
 		if err := i.SignImage(i.signer, imageVersion); err != nil {
 			return fmt.Errorf("sign manifest list: %w", err)
 		}
+		bar.Increment()
 	}
 
 	return nil
@@ -254,7 +258,7 @@ func (i *Images) Validate(registry, version, buildPath string) error {
 		}
 
 		manifest := string(manifestBytes)
-		manifestFile, err := os.CreateTemp("", "manifest-")
+		manifestFile, err := workdir.CreateTemp("manifest-")
 		if err != nil {
 			return fmt.Errorf("create temp file for manifest: %w", err)
 		}
@@ -308,7 +312,7 @@ func (i *Images) Exists(registry, version string, fast bool) (bool, error) {
 
 	manifestImages := ManifestImages
 
-	arches := consts.SupportedArchitectures
+	arches := platforms.Default().ImageArchitectures()
 	if fast {
 		arches = consts.FastArchitectures
 	}
@@ -322,7 +326,7 @@ func (i *Images) Exists(registry, version string, fast bool) (bool, error) {
 		}
 
 		manifest := string(manifestBytes)
-		manifestFile, err := os.CreateTemp("", "manifest-")
+		manifestFile, err := workdir.CreateTemp("manifest-")
 		if err != nil {
 			return false, fmt.Errorf("create temp file for manifest: %w", err)
 		}
@@ -414,10 +418,12 @@ func (i *Images) GetManifestImages(
 				}
 
 				binary := tagMatches[1]
-				newTag := filepath.Join(
-					registry,
-					strings.TrimSuffix(binary, "-"+arch),
-				)
+				image := strings.TrimSuffix(binary, "-"+arch)
+				if !i.components.Contains(image) {
+					logrus.Infof("Skipping %s because it's not a selected component", image)
+					return nil
+				}
+				newTag := filepath.Join(registry, image)
 				newTagWithArch := fmt.Sprintf("%s-%s:%s", newTag, arch, version)
 				manifestImages[newTag] = append(manifestImages[newTag], arch)
 
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/release/pkg/binary"
+)
+
+// LinkagePolicy defines how a released binary is allowed to be linked.
+type LinkagePolicy struct {
+	// Static requires the binary to be statically linked.
+	Static bool
+
+	// AllowedLibraries are the shared libraries a dynamically linked binary
+	// is allowed to depend on.
+	AllowedLibraries []string
+
+	// MaxGLIBCVersion is the highest glibc symbol version a dynamically
+	// linked binary is allowed to require, for example `2.17`.
+	MaxGLIBCVersion string
+}
+
+// staticPolicy is the policy for all binaries built with CGO disabled.
+var staticPolicy = &LinkagePolicy{Static: true}
+
+// DefaultLinkagePolicies are the linkage policies per released binary. The
+// static binaries match KUBE_STATIC_LIBRARIES in k/k hack/lib/golang.sh.
+// Binaries without a policy only produce a warning if they are dynamically
+// linked.
+var DefaultLinkagePolicies = map[string]*LinkagePolicy{
+	"kube-apiserver":          staticPolicy,
+	"kube-controller-manager": staticPolicy,
+	"kube-scheduler":          staticPolicy,
+	"kube-proxy":              staticPolicy,
+	"kube-log-runner":         staticPolicy,
+	"kubeadm":                 staticPolicy,
+	"kubectl":                 staticPolicy,
+	"kubectl-convert":         staticPolicy,
+	"kubemark":                staticPolicy,
+	"kubelet": {
+		AllowedLibraries: []string{
+			"libc.so.6",
+			"libdl.so.2",
+			"libpthread.so.0",
+			"libresolv.so.2",
+		},
+		MaxGLIBCVersion: "2.17",
+	},
+}
+
+// Check verifies that the linkage of a binary complies with the policy.
+// Binaries with an unknown link mode (non ELF) are always compliant.
+func (p *LinkagePolicy) Check(linkage *binary.Linkage) error {
+	switch linkage.Mode {
+	case binary.LinkModeStatic, binary.LinkModeUnknown:
+		return nil
+	case binary.LinkModeDynamic:
+	default:
+		return fmt.Errorf("unsupported link mode: %s", linkage.Mode)
+	}
+
+	if p.Static {
+		return fmt.Errorf(
+			"binary is dynamically linked against %s but has to be static",
+			strings.Join(linkage.Libraries, ", "),
+		)
+	}
+
+	allowed := map[string]bool{}
+	for _, lib := range p.AllowedLibraries {
+		allowed[lib] = true
+	}
+	unexpected := []string{}
+	for _, lib := range linkage.Libraries {
+		if !allowed[lib] {
+			unexpected = append(unexpected, lib)
+		}
+	}
+	if len(unexpected) > 0 {
+		return fmt.Errorf(
+			"binary depends on unexpected shared libraries: %s",
+			strings.Join(unexpected, ", "),
+		)
+	}
+
+	if p.MaxGLIBCVersion != "" {
+		if symbols := linkage.SymbolsNewerThanGLIBC(p.MaxGLIBCVersion); len(symbols) > 0 {
+			return fmt.Errorf(
+				"binary requires glibc %s but only up to %s is allowed: %s",
+				linkage.MaxGLIBCVersion(), p.MaxGLIBCVersion, strings.Join(symbols, ", "),
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/binary"
+	"k8s.io/release/pkg/release"
+)
+
+func TestLinkagePolicyCheck(t *testing.T) {
+	t.Parallel()
+
+	kubelet := release.DefaultLinkagePolicies["kubelet"]
+	kubectl := release.DefaultLinkagePolicies["kubectl"]
+	dynamic := func(libs []string, symbols map[string]string) *binary.Linkage {
+		return &binary.Linkage{
+			Mode:      binary.LinkModeDynamic,
+			Libraries: libs,
+			Symbols:   symbols,
+		}
+	}
+
+	for _, tc := range []struct {
+		policy      *release.LinkagePolicy
+		linkage     *binary.Linkage
+		shouldError bool
+	}{
+		{ // static kubectl
+			policy:      kubectl,
+			linkage:     &binary.Linkage{Mode: binary.LinkModeStatic},
+			shouldError: false,
+		},
+		{ // unknown link mode (non ELF) kubectl
+			policy:      kubectl,
+			linkage:     &binary.Linkage{Mode: binary.LinkModeUnknown},
+			shouldError: false,
+		},
+		{ // dynamic kubectl
+			policy:      kubectl,
+			linkage:     dynamic([]string{"libc.so.6"}, nil),
+			shouldError: true,
+		},
+		{ // static kubelet
+			policy:      kubelet,
+			linkage:     &binary.Linkage{Mode: binary.LinkModeStatic},
+			shouldError: false,
+		},
+		{ // dynamic kubelet with allowed libraries and symbols
+			policy: kubelet,
+			linkage: dynamic(
+				[]string{"libc.so.6", "libpthread.so.0"},
+				map[string]string{"malloc": "GLIBC_2.2.5", "pthread_create": "GLIBC_2.17"},
+			),
+			shouldError: false,
+		},
+		{ // dynamic kubelet with unexpected library
+			policy:      kubelet,
+			linkage:     dynamic([]string{"libc.so.6", "libseccomp.so.2"}, nil),
+			shouldError: true,
+		},
+		{ // dynamic kubelet with too new glibc symbol
+			policy: kubelet,
+			linkage: dynamic(
+				[]string{"libc.so.6"},
+				map[string]string{"malloc": "GLIBC_2.2.5", "__libc_start_main": "GLIBC_2.34"},
+			),
+			shouldError: true,
+		},
+		{ // unsupported link mode
+			policy:      kubelet,
+			linkage:     &binary.Linkage{Mode: "wrong"},
+			shouldError: true,
+		},
+	} {
+		err := tc.policy.Check(tc.linkage)
+		if tc.shouldError {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
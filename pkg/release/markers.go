@@ -0,0 +1,287 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+const (
+	// VersionMarkerStable is the version marker prefix for official releases.
+	VersionMarkerStable = "stable"
+
+	// VersionMarkerLatest is the version marker prefix for any release,
+	// including pre-releases and CI builds.
+	VersionMarkerLatest = "latest"
+
+	// VersionMarkerLatestGreen is the version marker of the latest nightly
+	// build which got built and pushed successfully.
+	VersionMarkerLatestGreen = "latest-green"
+
+	// VersionMarkerSuffix is the file extension of all version markers.
+	VersionMarkerSuffix = ".txt"
+
+	// VerifiedFile is the file which has to exist in a release path to
+	// consider the release as verified. It contains the checksums of all
+	// release artifacts.
+	VerifiedFile = "SHA256SUMS"
+
+	fastSuffix = "-fast"
+)
+
+var versionMarkerRe = regexp.MustCompile(`^[a-zA-Z0-9]+([._-][a-zA-Z0-9]+)*$`)
+
+// ValidateVersionMarker checks if the provided version marker name (without
+// the .txt suffix) is valid.
+func ValidateVersionMarker(marker string) error {
+	if strings.HasSuffix(marker, VersionMarkerSuffix) {
+		return fmt.Errorf(
+			"version marker %q must not contain the %s suffix", marker, VersionMarkerSuffix,
+		)
+	}
+	if !versionMarkerRe.MatchString(marker) {
+		return fmt.Errorf("invalid version marker name %q", marker)
+	}
+	return nil
+}
+
+// ValidateVersionMarkerVersion checks if the version is allowed to be set for
+// the version marker. For example, stable markers must not point to
+// pre-releases, and stable-1.31 has to point to a v1.31 release.
+// Version markers which are not prefixed with stable or latest are only
+// validated for their name.
+func ValidateVersionMarkerVersion(marker, version string) error {
+	if err := ValidateVersionMarker(marker); err != nil {
+		return err
+	}
+
+	sv, err := util.TagStringToSemver(version)
+	if err != nil {
+		return fmt.Errorf("invalid version %q for marker %s: %w", version, marker, err)
+	}
+
+	name := strings.TrimSuffix(marker, fastSuffix)
+	var scope string
+	switch {
+	case name == VersionMarkerLatestGreen:
+		return nil
+	case name == VersionMarkerStable || strings.HasPrefix(name, VersionMarkerStable+"-"):
+		if len(sv.Pre) > 0 || len(sv.Build) > 0 {
+			return fmt.Errorf(
+				"stable version marker %s must not point to %s", marker, version,
+			)
+		}
+		scope = strings.TrimPrefix(name, VersionMarkerStable)
+	case name == VersionMarkerLatest || strings.HasPrefix(name, VersionMarkerLatest+"-"):
+		scope = strings.TrimPrefix(name, VersionMarkerLatest)
+	default:
+		return nil
+	}
+
+	if scope == "" {
+		return nil
+	}
+	if err := matchesVersionScope(sv, strings.TrimPrefix(scope, "-")); err != nil {
+		return fmt.Errorf("version marker %s must not point to %s: %w", marker, version, err)
+	}
+	return nil
+}
+
+// matchesVersionScope verifies that the version is within scope, which is
+// either a major ("1") or a major and minor ("1.31") version.
+func matchesVersionScope(sv semver.Version, scope string) error {
+	parts := strings.Split(scope, ".")
+	if len(parts) > 2 {
+		return fmt.Errorf("invalid version scope %q", scope)
+	}
+
+	major, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid major version in scope %q: %w", scope, err)
+	}
+	if sv.Major != major {
+		return fmt.Errorf("major version %d does not match %d", sv.Major, major)
+	}
+
+	if len(parts) == 2 {
+		minor, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid minor version in scope %q: %w", scope, err)
+		}
+		if sv.Minor != minor {
+			return fmt.Errorf("minor version %d does not match %d", sv.Minor, minor)
+		}
+	}
+	return nil
+}
+
+// VerifyPublishedRelease checks if the release of the provided version has
+// been published and verified. A release is verified if the release path
+// contains the checksums of all its artifacts.
+func (p *Publisher) VerifyPublishedRelease(
+	bucket, gcsRoot, version string, fast bool,
+) error {
+	releasePath, err := p.client.GetReleasePath(bucket, gcsRoot, version, fast)
+	if err != nil {
+		return fmt.Errorf("get release path: %w", err)
+	}
+
+	// TODO: This should probably be a more thorough check of explicit files
+	// TODO: This should explicitly do a `gsutil ls` via gcs.PathExists
+	if err := p.client.GSUtil("ls", releasePath); err != nil {
+		return fmt.Errorf("release files don't exist at %s: %w", releasePath, err)
+	}
+
+	verifiedPath, err := p.client.NormalizePath(releasePath, VerifiedFile)
+	if err != nil {
+		return fmt.Errorf("get verified file path: %w", err)
+	}
+	if err := p.client.GSUtil("ls", verifiedPath); err != nil {
+		return fmt.Errorf("release at %s is not verified: %w", releasePath, err)
+	}
+	return nil
+}
+
+// versionMarkerUpdate is a single update of a version marker, used for
+// rolling back the marker if a subsequent update fails.
+type versionMarkerUpdate struct {
+	// versionMarker is the file name of the marker.
+	versionMarker string
+
+	// previous is the previous version of the marker, empty if the marker
+	// did not exist before.
+	previous string
+}
+
+// rollbackVersionMarkers restores the previous state of all updated version
+// markers in reverse order.
+func (p *Publisher) rollbackVersionMarkers(
+	updates []versionMarkerUpdate, buildDir, markerPath string,
+	privateBucket bool,
+) error {
+	var errs []error
+	for i := len(updates) - 1; i >= 0; i-- {
+		update := updates[i]
+
+		if update.previous == "" {
+			dst, err := p.client.NormalizePath(markerPath, update.versionMarker)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("get marker file destination: %w", err))
+				continue
+			}
+			logrus.Infof("Rolling back %s by removing it", dst)
+			if err := p.client.GSUtil("rm", dst); err != nil {
+				errs = append(errs, fmt.Errorf("remove %s: %w", dst, err))
+			}
+			continue
+		}
+
+		logrus.Infof("Rolling back %s to %s", update.versionMarker, update.previous)
+		if err := p.PublishToGcs(
+			update.versionMarker, buildDir, markerPath, update.previous, privateBucket,
+		); err != nil {
+			errs = append(errs, fmt.Errorf("restore %s: %w", update.versionMarker, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RollbackVersionMarker sets the version marker to the provided version,
+// even if that version is older than the currently published one. The target
+// version has to be published and verified.
+func (p *Publisher) RollbackVersionMarker(
+	marker, version, buildDir, bucket, gcsRoot string,
+	privateBucket, fast bool,
+) error {
+	if err := ValidateVersionMarkerVersion(marker, version); err != nil {
+		return fmt.Errorf("validate version marker: %w", err)
+	}
+
+	if err := p.VerifyPublishedRelease(bucket, gcsRoot, version, fast); err != nil {
+		return fmt.Errorf("verify release %s: %w", version, err)
+	}
+
+	markerPath, err := p.client.GetMarkerPath(bucket, gcsRoot, fast)
+	if err != nil {
+		return fmt.Errorf("get version marker path: %w", err)
+	}
+
+	logrus.Infof("Rolling back version marker %s to %s", marker, version)
+	if err := p.PublishToGcs(
+		marker+VersionMarkerSuffix, buildDir, markerPath, version, privateBucket,
+	); err != nil {
+		return fmt.Errorf("publish version marker %s: %w", marker, err)
+	}
+	return nil
+}
+
+// CheckVersionMarkers verifies that all provided version markers exist, are
+// valid for the version they contain and point to published and verified
+// releases. All inconsistencies are returned as a single error.
+func (p *Publisher) CheckVersionMarkers(
+	bucket, gcsRoot string, markers []string, fast bool,
+) error {
+	markerPath, err := p.client.GetMarkerPath(bucket, gcsRoot, fast)
+	if err != nil {
+		return fmt.Errorf("get version marker path: %w", err)
+	}
+
+	var errs []error
+	for _, marker := range markers {
+		if err := p.checkVersionMarker(
+			bucket, gcsRoot, markerPath, marker, fast,
+		); err != nil {
+			errs = append(errs, fmt.Errorf("version marker %s: %w", marker, err))
+			continue
+		}
+		logrus.Infof("Version marker %s is consistent", marker)
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Publisher) checkVersionMarker(
+	bucket, gcsRoot, markerPath, marker string, fast bool,
+) error {
+	if err := ValidateVersionMarker(marker); err != nil {
+		return err
+	}
+
+	dst, err := p.client.NormalizePath(markerPath, marker+VersionMarkerSuffix)
+	if err != nil {
+		return fmt.Errorf("get marker file destination: %w", err)
+	}
+
+	version, err := p.client.GSUtilOutput("cat", dst)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", dst, err)
+	}
+	version = strings.TrimSpace(version)
+
+	if err := ValidateVersionMarkerVersion(marker, version); err != nil {
+		return err
+	}
+	return p.VerifyPublishedRelease(bucket, gcsRoot, version, fast)
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/release/releasefakes"
+)
+
+func TestValidateVersionMarkerVersion(t *testing.T) {
+	for _, tc := range []struct {
+		marker, version string
+		shouldError     bool
+	}{
+		{"stable", "v1.31.0", false},
+		{"stable-1", "v1.31.2", false},
+		{"stable-1.31", "v1.31.2", false},
+		{"stable-fast", "v1.31.2", false},
+		{"latest", "v1.32.0-alpha.1.66+d19aec8bf1c8ca", false},
+		{"latest-1.32", "v1.32.0-rc.0", false},
+		{"latest-green", "v1.32.0-alpha.1.66+d19aec8bf1c8ca", false},
+		{"k8s-master", "v1.32.0-alpha.1", false},
+		{"stable", "v1.32.0-rc.0", true},
+		{"stable-1.31", "v1.30.2", true},
+		{"stable-2", "v1.31.2", true},
+		{"latest-1.x", "v1.31.2", true},
+		{"stable.txt", "v1.31.2", true},
+		{"release/stable", "v1.31.2", true},
+		{"", "v1.31.2", true},
+		{"stable", "wrong", true},
+	} {
+		err := release.ValidateVersionMarkerVersion(tc.marker, tc.version)
+		if tc.shouldError {
+			require.NotNil(t, err, "%s: %s", tc.marker, tc.version)
+		} else {
+			require.Nil(t, err, "%s: %s", tc.marker, tc.version)
+		}
+	}
+}
+
+func TestVerifyPublishedRelease(t *testing.T) {
+	for _, tc := range []struct {
+		prepare     func(*releasefakes.FakePublisherClient)
+		shouldError bool
+	}{
+		{ // success
+			prepare:     func(*releasefakes.FakePublisherClient) {},
+			shouldError: false,
+		},
+		{ // release not published
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GSUtilReturnsOnCall(0, errors.New(""))
+			},
+			shouldError: true,
+		},
+		{ // release not verified
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GSUtilReturnsOnCall(1, errors.New(""))
+			},
+			shouldError: true,
+		},
+	} {
+		sut := release.NewPublisher()
+		clientMock := &releasefakes.FakePublisherClient{}
+		tc.prepare(clientMock)
+		sut.SetClient(clientMock)
+
+		err := sut.VerifyPublishedRelease(release.ProductionBucket, "release", "v1.31.0", false)
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
+func TestPublishVersionRollback(t *testing.T) {
+	const (
+		testVersion      = "v1.31.0"
+		olderTestVersion = "v1.30.4"
+	)
+
+	tempDir, err := os.MkdirTemp("", "publish-version-rollback-test-")
+	require.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	sut := release.NewPublisher()
+	clientMock := &releasefakes.FakePublisherClient{}
+	clientMock.GetMarkerPathReturns("gs://k8s-another-bucket/release", nil)
+	clientMock.NormalizePathStub = func(parts ...string) (string, error) {
+		return parts[len(parts)-1], nil
+	}
+	// stable.txt exists, stable-1.txt does not and stable-1.31.txt fails to
+	// publish
+	clientMock.GSUtilOutputReturnsOnCall(0, olderTestVersion, nil)
+	clientMock.GSUtilOutputReturnsOnCall(1, "", errors.New(""))
+	clientMock.GSUtilOutputReturnsOnCall(2, "", errors.New(""))
+	clientMock.GetURLResponseReturnsOnCall(0, testVersion, nil)
+	clientMock.GetURLResponseReturnsOnCall(1, testVersion, nil)
+	clientMock.GetURLResponseReturnsOnCall(2, "", errors.New(""))
+	clientMock.GetURLResponseReturnsOnCall(3, olderTestVersion, nil)
+	sut.SetClient(clientMock)
+
+	err = sut.PublishVersion(
+		"release", testVersion, tempDir, "k8s-another-bucket", "release",
+		nil, false, false,
+	)
+	require.NotNil(t, err)
+
+	// The new markers got removed and the existing one restored, in
+	// reverse order
+	removed := []string{}
+	for i := 0; i < clientMock.GSUtilCallCount(); i++ {
+		if args := clientMock.GSUtilArgsForCall(i); args[0] == "rm" {
+			removed = append(removed, args[1])
+		}
+	}
+	require.Equal(t, []string{"stable-1.31.txt", "stable-1.txt"}, removed)
+	require.Equal(t, 4, clientMock.GetURLResponseCallCount())
+}
+
+func TestRollbackVersionMarker(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "rollback-version-marker-test-")
+	require.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	for _, tc := range []struct {
+		marker      string
+		version     string
+		prepare     func(*releasefakes.FakePublisherClient)
+		shouldError bool
+	}{
+		{ // success
+			marker:  "stable",
+			version: "v1.30.4",
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GetURLResponseReturns("v1.30.4", nil)
+			},
+			shouldError: false,
+		},
+		{ // invalid version for marker
+			marker:      "stable-1.31",
+			version:     "v1.30.4",
+			prepare:     func(*releasefakes.FakePublisherClient) {},
+			shouldError: true,
+		},
+		{ // release not verified
+			marker:  "stable",
+			version: "v1.30.4",
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GSUtilReturnsOnCall(1, errors.New(""))
+			},
+			shouldError: true,
+		},
+		{ // publish fails
+			marker:  "stable",
+			version: "v1.30.4",
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GetURLResponseReturns("", errors.New(""))
+			},
+			shouldError: true,
+		},
+	} {
+		sut := release.NewPublisher()
+		clientMock := &releasefakes.FakePublisherClient{}
+		tc.prepare(clientMock)
+		sut.SetClient(clientMock)
+
+		err := sut.RollbackVersionMarker(
+			tc.marker, tc.version, tempDir, "k8s-another-bucket", "release",
+			false, false,
+		)
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
+func TestCheckVersionMarkers(t *testing.T) {
+	for _, tc := range []struct {
+		prepare     func(*releasefakes.FakePublisherClient)
+		shouldError bool
+	}{
+		{ // success
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GSUtilOutputReturnsOnCall(0, "v1.31.2\n", nil)
+				mock.GSUtilOutputReturnsOnCall(1, "v1.31.2", nil)
+			},
+			shouldError: false,
+		},
+		{ // marker does not exist
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GSUtilOutputReturnsOnCall(0, "", errors.New(""))
+				mock.GSUtilOutputReturnsOnCall(1, "v1.31.2", nil)
+			},
+			shouldError: true,
+		},
+		{ // marker points to wrong version
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GSUtilOutputReturnsOnCall(0, "v1.31.2", nil)
+				mock.GSUtilOutputReturnsOnCall(1, "v1.30.5", nil)
+			},
+			shouldError: true,
+		},
+		{ // release is not verified
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GSUtilOutputReturnsOnCall(0, "v1.31.2", nil)
+				mock.GSUtilOutputReturnsOnCall(1, "v1.31.2", nil)
+				mock.GSUtilReturnsOnCall(3, errors.New(""))
+			},
+			shouldError: true,
+		},
+		{ // get marker path fails
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GetMarkerPathReturns("", errors.New(""))
+			},
+			shouldError: true,
+		},
+	} {
+		sut := release.NewPublisher()
+		clientMock := &releasefakes.FakePublisherClient{}
+		tc.prepare(clientMock)
+		sut.SetClient(clientMock)
+
+		err := sut.CheckVersionMarkers(
+			release.ProductionBucket, "release", []string{"stable", "stable-1.31"}, false,
+		)
+		if tc.shouldError {
+			require.NotNil(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
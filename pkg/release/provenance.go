@@ -31,6 +31,10 @@ import (
 	"sigs.k8s.io/bom/pkg/spdx"
 	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/encryption"
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/workdir"
 )
 
 func NewProvenanceChecker(opts *ProvenanceCheckerOptions) *ProvenanceChecker {
@@ -62,8 +66,8 @@ func (pc *ProvenanceChecker) CheckStageProvenance(buildVersion string) error {
 	pc.options.StageDirectory = filepath.Join(pc.options.ScratchDirectory, hex.EncodeToString(h.Sum(nil)))
 
 	gcsPath, err := pc.objStore.NormalizePath(
-		object.GcsPrefix + filepath.Join(
-			pc.options.StageBucket, StagePath, buildVersion,
+		object.GcsPrefix + layout.Default().StagePath(
+			pc.options.StageBucket, buildVersion,
 		) + string(filepath.Separator),
 	)
 	if err != nil {
@@ -138,6 +142,12 @@ func (di *defaultProvenanceCheckerImpl) downloadStagedArtifacts(
 	if err := objStore.CopyToLocal(path, opts.StageDirectory); err != nil {
 		return fmt.Errorf("synching staged sources: %w", err)
 	}
+
+	// The provenance covers the plain artifacts, which requires decrypting
+	// the ones of an embargoed stage run.
+	if _, err := encryption.New(encryption.DefaultOptions()).DecryptDir(opts.StageDirectory); err != nil {
+		return fmt.Errorf("decrypting staged artifacts: %w", err)
+	}
 	return nil
 }
 
@@ -152,8 +162,9 @@ func (di *defaultProvenanceCheckerImpl) processAttestation(
 	}
 
 	// We've downloaded all artifacts, so to check we need to strip
-	// the gcs bucket prefix from the subjects to read from the local copy
-	gcsPath := object.GcsPrefix + filepath.Join(opts.StageBucket, StagePath)
+	// the gcs bucket prefix from the subjects to read from the local copy,
+	// which is rooted at the parent of the stage path
+	gcsPath := object.GcsPrefix + filepath.Dir(layout.Default().StagePath(opts.StageBucket, buildVersion))
 
 	newSubjects := []intoto.Subject{}
 
@@ -197,7 +208,7 @@ func (di *defaultProvenanceCheckerImpl) generateFinalAttestation(
 		return fmt.Errorf("cloning SLSA predicate from staging provenance: %s: %w", stageProvenance, err)
 	}
 	if err := slsaStatement.Write(
-		filepath.Join(os.TempDir(), fmt.Sprintf("provenance-%s.json", version)),
+		filepath.Join(workdir.TempDir(), fmt.Sprintf("provenance-%s.json", version)),
 	); err != nil {
 		return fmt.Errorf("writing final provenance attestation for %s: %w", version, err)
 	}
@@ -250,7 +261,7 @@ func (di *defaultProvenanceReaderImpl) GetStagingSubjects(
 	dummy := provenance.NewSLSAStatement()
 
 	// The path in the bucket were built artifacts will be staged
-	gcsPath := filepath.Join(opts.Bucket, StagePath, opts.BuildVersion)
+	gcsPath := layout.Default().StagePath(opts.Bucket, opts.BuildVersion)
 
 	info, err := os.Stat(path)
 	if err != nil {
@@ -283,7 +294,7 @@ func (di *defaultProvenanceReaderImpl) GetBuildSubjects(
 	opts *ProvenanceReaderOptions, path, version string,
 ) ([]intoto.Subject, error) {
 	// The path in the bucket were built artifacts will be staged
-	gcsPath := filepath.Join(opts.Bucket, StagePath, opts.BuildVersion)
+	gcsPath := layout.Default().StagePath(opts.Bucket, opts.BuildVersion)
 
 	// When adding the output directory for a specific version, we need
 	// to modiy the paths in the attestation to match the bucket names.
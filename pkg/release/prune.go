@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+// PruneBuilds deletes the builds next to the provided version, which belong
+// to the same minor release, except for the newest retain ones. The provided
+// version is always kept and counts as retained. Entries which are no
+// semantic versions, like version markers or the fast directory, are never
+// touched. It returns the deleted paths.
+func (p *Publisher) PruneBuilds(
+	bucket, gcsRoot, version string, fast bool, retain int,
+) ([]string, error) {
+	if retain < 1 {
+		return nil, fmt.Errorf("number of retained builds must be at least 1, got %d", retain)
+	}
+
+	sv, err := util.TagStringToSemver(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %s: %w", version, err)
+	}
+
+	releasePath, err := p.client.GetReleasePath(bucket, gcsRoot, version, fast)
+	if err != nil {
+		return nil, fmt.Errorf("get release path: %w", err)
+	}
+	releasePath = strings.TrimSuffix(releasePath, "/")
+	parent := releasePath[:strings.LastIndex(releasePath, "/")]
+
+	output, err := p.client.GSUtilOutput("ls", "-d", parent+"/*")
+	if err != nil {
+		return nil, fmt.Errorf("list builds in %s: %w", parent, err)
+	}
+
+	type build struct {
+		path    string
+		version semver.Version
+	}
+	builds := []build{}
+	for _, line := range strings.Fields(output) {
+		buildPath := strings.TrimSuffix(line, "/")
+		if buildPath == releasePath {
+			continue
+		}
+		bv, err := util.TagStringToSemver(buildPath[strings.LastIndex(buildPath, "/")+1:])
+		if err != nil || bv.Major != sv.Major || bv.Minor != sv.Minor {
+			continue
+		}
+		builds = append(builds, build{buildPath, bv})
+	}
+	sort.SliceStable(builds, func(i, j int) bool {
+		return builds[i].version.GT(builds[j].version)
+	})
+
+	deleted := []string{}
+	for i, b := range builds {
+		if i < retain-1 {
+			continue
+		}
+		logrus.Infof("Deleting old build %s", b.path)
+		if err := p.client.GSUtil("-m", "rm", "-r", b.path); err != nil {
+			return deleted, fmt.Errorf("delete build %s: %w", b.path, err)
+		}
+		deleted = append(deleted, b.path)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/release/releasefakes"
+)
+
+func TestPruneBuilds(t *testing.T) {
+	const (
+		root    = "gs://bucket/ci"
+		version = "v1.31.0-alpha.2.10+abc"
+	)
+	listing := root + "/fast/\n" +
+		root + "/latest.txt\n" +
+		root + "/v1.30.3-rc.0.5+def/\n" +
+		root + "/v1.31.0-alpha.2.8+abc/\n" +
+		root + "/" + version + "/\n" +
+		root + "/v1.31.0-alpha.2.9+abc/\n" +
+		root + "/v1.31.0-alpha.1.20+abc/\n"
+
+	for _, tc := range []struct {
+		name      string
+		retain    int
+		prepare   func(*releasefakes.FakePublisherClient)
+		expected  []string
+		shouldErr bool
+	}{
+		{
+			name:   "keep newest",
+			retain: 2,
+			expected: []string{
+				root + "/v1.31.0-alpha.2.8+abc",
+				root + "/v1.31.0-alpha.1.20+abc",
+			},
+		},
+		{
+			name:     "keep all",
+			retain:   5,
+			expected: []string{},
+		},
+		{
+			name:   "keep only current",
+			retain: 1,
+			expected: []string{
+				root + "/v1.31.0-alpha.2.9+abc",
+				root + "/v1.31.0-alpha.2.8+abc",
+				root + "/v1.31.0-alpha.1.20+abc",
+			},
+		},
+		{
+			name:      "invalid retain",
+			retain:    0,
+			shouldErr: true,
+		},
+		{
+			name:   "list fails",
+			retain: 2,
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GSUtilOutputReturns("", errors.New("error"))
+			},
+			shouldErr: true,
+		},
+		{
+			name:   "delete fails",
+			retain: 2,
+			prepare: func(mock *releasefakes.FakePublisherClient) {
+				mock.GSUtilReturns(errors.New("error"))
+			},
+			expected:  []string{},
+			shouldErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &releasefakes.FakePublisherClient{}
+			mock.GetReleasePathReturns(root+"/"+version, nil)
+			mock.GSUtilOutputReturns(listing, nil)
+			if tc.prepare != nil {
+				tc.prepare(mock)
+			}
+			sut := release.NewPublisher()
+			sut.SetClient(mock)
+
+			deleted, err := sut.PruneBuilds("bucket", "ci", version, false, tc.retain)
+			if tc.shouldErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expected != nil {
+				require.Equal(t, tc.expected, deleted)
+			}
+		})
+	}
+}
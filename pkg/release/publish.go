@@ -17,7 +17,9 @@ limitations under the License.
 package release
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -29,6 +31,12 @@ import (
 	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-utils/http"
 	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/retry"
+	"k8s.io/release/pkg/workdir"
 )
 
 // Publisher is the structure for publishing anything release related
@@ -63,7 +71,7 @@ type publisherClient interface {
 	GetReleasePath(bucket, gcsRoot, version string, fast bool) (string, error)
 	GetMarkerPath(bucket, gcsRoot string, fast bool) (string, error)
 	NormalizePath(pathParts ...string) (string, error)
-	TempDir(dir, pattern string) (name string, err error)
+	TempDir(pattern string) (name string, err error)
 	CopyToLocal(remote, local string) error
 	ReadFile(filename string) ([]byte, error)
 	Unmarshal(data []byte, v interface{}) error
@@ -99,25 +107,35 @@ func (*defaultPublisher) GetURLResponse(url string) (string, error) {
 func (d *defaultPublisher) GetReleasePath(
 	bucket, gcsRoot, version string, fast bool,
 ) (string, error) {
-	return d.objStore.GetReleasePath(bucket, gcsRoot, version, fast)
+	releasePath, err := layout.Default().ReleasePath(bucket, gcsRoot, version, fast)
+	if err != nil {
+		return "", fmt.Errorf("get release path: %w", err)
+	}
+	return d.objStore.NormalizePath(releasePath)
 }
 
 func (d *defaultPublisher) GetMarkerPath(
 	bucket, gcsRoot string, fast bool,
 ) (string, error) {
-	return d.objStore.GetMarkerPath(bucket, gcsRoot, fast)
+	markerPath, err := layout.Default().MarkerPath(bucket, gcsRoot, fast)
+	if err != nil {
+		return "", fmt.Errorf("get marker path: %w", err)
+	}
+	return d.objStore.NormalizePath(markerPath)
 }
 
 func (d *defaultPublisher) NormalizePath(pathParts ...string) (string, error) {
 	return d.objStore.NormalizePath(pathParts...)
 }
 
-func (*defaultPublisher) TempDir(dir, pattern string) (name string, err error) {
-	return os.MkdirTemp(dir, pattern)
+func (*defaultPublisher) TempDir(pattern string) (name string, err error) {
+	return workdir.MkdirTemp(pattern)
 }
 
 func (d *defaultPublisher) CopyToLocal(remote, local string) error {
-	return d.objStore.CopyToLocal(remote, local)
+	return retry.Do(context.Background(), retry.ServiceGCS, func() error {
+		return d.objStore.CopyToLocal(remote, local)
+	})
 }
 
 func (*defaultPublisher) ReadFile(filename string) ([]byte, error) {
@@ -137,7 +155,9 @@ func (*defaultPublisher) TempFile(dir, pattern string) (f *os.File, err error) {
 }
 
 func (d *defaultPublisher) CopyToRemote(local, remote string) error {
-	return d.objStore.CopyToRemote(local, remote)
+	return retry.Do(context.Background(), retry.ServiceGCS, func() error {
+		return d.objStore.CopyToRemote(local, remote)
+	})
 }
 
 // Publish a new version, (latest or stable) but only if the files actually
@@ -183,20 +203,8 @@ func (p *Publisher) PublishVersion(
 		return fmt.Errorf("get version marker path: %w", markerPathErr)
 	}
 
-	releasePath, releasePathErr := p.client.GetReleasePath(
-		bucket,
-		gcsRoot,
-		version,
-		fast,
-	)
-	if releasePathErr != nil {
-		return fmt.Errorf("get release path: %w", releasePathErr)
-	}
-
-	// TODO: This should probably be a more thorough check of explicit files
-	// TODO: This should explicitly do a `gsutil ls` via gcs.PathExists
-	if err := p.client.GSUtil("ls", releasePath); err != nil {
-		return fmt.Errorf("release files don't exist at %s: %w", releasePath, err)
+	if err := p.VerifyPublishedRelease(bucket, gcsRoot, version, fast); err != nil {
+		return fmt.Errorf("verify release %s: %w", version, err)
 	}
 
 	var versionMarkers []string
@@ -218,16 +226,34 @@ func (p *Publisher) PublishVersion(
 		versionMarkers = append(versionMarkers, extraVersionMarkers...)
 	}
 
+	for _, marker := range versionMarkers {
+		if err := ValidateVersionMarkerVersion(marker, version); err != nil {
+			return fmt.Errorf("validate version marker: %w", err)
+		}
+	}
+
 	logrus.Infof("Publish version markers: %v", versionMarkers)
 	logrus.Infof("Publish official pointer text files to %s", markerPath)
 
+	// All updated markers get rolled back if a later update fails, to never
+	// leave the markers in a partially updated state.
+	updates := []versionMarkerUpdate{}
+	rollback := func(publishErr error) error {
+		if err := p.rollbackVersionMarkers(
+			updates, buildDir, markerPath, privateBucket,
+		); err != nil {
+			return errors.Join(publishErr, fmt.Errorf("rollback version markers: %w", err))
+		}
+		return publishErr
+	}
+
 	for _, file := range versionMarkers {
-		versionMarker := file + ".txt"
-		needsUpdate, err := p.VerifyLatestUpdate(
+		versionMarker := file + VersionMarkerSuffix
+		needsUpdate, previous, err := p.verifyLatestUpdate(
 			versionMarker, markerPath, version,
 		)
 		if err != nil {
-			return fmt.Errorf("verify latest update for %s: %w", versionMarker, err)
+			return rollback(fmt.Errorf("verify latest update for %s: %w", versionMarker, err))
 		}
 
 		// If there's a version that's above the one we're trying to release,
@@ -240,10 +266,11 @@ func (p *Publisher) PublishVersion(
 			continue
 		}
 
+		updates = append(updates, versionMarkerUpdate{versionMarker, previous})
 		if err := p.PublishToGcs(
 			versionMarker, buildDir, markerPath, version, privateBucket,
 		); err != nil {
-			return fmt.Errorf("publish release to GCS: %w", err)
+			return rollback(fmt.Errorf("publish release to GCS: %w", err))
 		}
 	}
 
@@ -259,39 +286,49 @@ func (p *Publisher) PublishVersion(
 func (p *Publisher) VerifyLatestUpdate(
 	publishFile, markerPath, version string,
 ) (needsUpdate bool, err error) {
+	needsUpdate, _, err = p.verifyLatestUpdate(publishFile, markerPath, version)
+	return needsUpdate, err
+}
+
+// verifyLatestUpdate works like VerifyLatestUpdate but additionally returns
+// the currently published version, which is empty if the marker does not
+// exist.
+func (p *Publisher) verifyLatestUpdate(
+	publishFile, markerPath, version string,
+) (needsUpdate bool, previous string, err error) {
 	logrus.Infof("Testing %s > %s (published)", version, publishFile)
 
 	publishFileDst, publishFileDstErr := p.client.NormalizePath(markerPath, publishFile)
 	if publishFileDstErr != nil {
-		return false, fmt.Errorf("get marker file destination: %w", publishFileDstErr)
+		return false, "", fmt.Errorf("get marker file destination: %w", publishFileDstErr)
 	}
 
 	// TODO: Should we add a object.`GCS` method for `gsutil cat`?
 	gcsVersion, err := p.client.GSUtilOutput("cat", publishFileDst)
 	if err != nil {
 		logrus.Infof("%s does not exist but will be created", publishFileDst)
-		return true, nil
+		return true, "", nil
 	}
 
 	sv, err := util.TagStringToSemver(version)
 	if err != nil {
-		return false, fmt.Errorf("invalid version format %s", version)
+		return false, "", fmt.Errorf("invalid version format %s", version)
 	}
 
 	gcsSemverVersion, err := util.TagStringToSemver(gcsVersion)
 	if err != nil {
-		return false, fmt.Errorf("invalid GCS version format %s", gcsVersion)
+		return false, "", fmt.Errorf("invalid GCS version format %s", gcsVersion)
 	}
 
 	if sv.LTE(gcsSemverVersion) {
 		logrus.Infof(
 			"Not updating version, because %s <= %s", version, gcsVersion,
 		)
-		return false, nil
+		return false, gcsVersion, nil
 	}
 
 	logrus.Infof("Updating version, because %s > %s", version, gcsVersion)
-	return true, nil
+	return true, gcsVersion, nil
 }
 
 // PublishToGcs publishes a release to GCS
@@ -311,7 +348,7 @@ func (p *Publisher) PublishToGcs(
 
 	publicLink := fmt.Sprintf("%s/%s", URLPrefixForBucket(markerPath), publishFile)
 	if strings.HasPrefix(markerPath, ProductionBucket) {
-		publicLink = fmt.Sprintf("%s/%s", ProductionBucketURL, publishFile)
+		publicLink = fmt.Sprintf("%s/%s", branding.Default().DownloadURL(), publishFile)
 	}
 
 	uploadDir := filepath.Join(releaseStage, "upload")
@@ -336,6 +373,7 @@ func (p *Publisher) PublishToGcs(
 	); err != nil {
 		return fmt.Errorf("copy %s to %s: %w", latestFile, publishFileDst, err)
 	}
+	audit.Record(audit.ActionBucketWrite, publishFileDst, map[string]string{"version": version})
 
 	var content string
 	if !privateBucket {
@@ -414,7 +452,7 @@ func (p *Publisher) PublishReleaseNotesIndex(
 	if success {
 		logrus.Info("Modifying existing release notes index file")
 
-		tempDir, err := p.client.TempDir("", "release-notes-index-")
+		tempDir, err := p.client.TempDir("release-notes-index-")
 		if err != nil {
 			return fmt.Errorf("create temp dir: %w", err)
 		}
@@ -461,6 +499,7 @@ func (p *Publisher) PublishReleaseNotesIndex(
 	); err != nil {
 		return fmt.Errorf("upload index file: %w", err)
 	}
+	audit.Record(audit.ActionBucketWrite, indexFilePath, map[string]string{"version": version})
 
 	return nil
 }
@@ -25,6 +25,9 @@ import (
 	"github.com/sirupsen/logrus"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/audit"
+	"k8s.io/release/pkg/ghauth"
 )
 
 // GitObjectPusher is an object that pushes things to a gitrepo
@@ -54,6 +57,23 @@ func NewGitPusher(opts *GitObjectPusherOptions) (*GitObjectPusher, error) {
 		return nil, fmt.Errorf("while opening repository: %w", err)
 	}
 
+	// The SSH key has to be configured for every process, while the
+	// installation tokens of a GitHub App expire after one hour, which means
+	// that the one set up when preparing the workspace may be outdated.
+	if key := GitSSHKey(); key != "" {
+		if err := ConfigureGitSSH(key); err != nil {
+			return nil, fmt.Errorf("configuring git SSH: %w", err)
+		}
+	} else if ghauth.Enabled() && IsDefaultK8sUpstream() {
+		token, err := ghauth.Token()
+		if err != nil {
+			return nil, fmt.Errorf("getting GitHub token: %w", err)
+		}
+		if err := setGitHubRemote(repo, token); err != nil {
+			return nil, err
+		}
+	}
+
 	logrus.Infof("Checkout %s branch to push objects", git.DefaultBranch)
 	if err := repo.Checkout(git.DefaultBranch); err != nil {
 		return nil, fmt.Errorf("checking out %s branch: %w", git.DefaultBranch, err)
@@ -117,6 +137,7 @@ func (gp *GitObjectPusher) PushBranch(branchName string) error {
 	if err := gp.repo.Push(branchName); err != nil {
 		return fmt.Errorf("pushing branch %s: %w", branchName, err)
 	}
+	gp.record(branchName)
 	logrus.Infof("Branch %s pushed successfully", branchName)
 	return nil
 }
@@ -175,6 +196,7 @@ func (gp *GitObjectPusher) PushTag(newTag string) (err error) {
 	if err := gp.repo.Push(newTag); err != nil {
 		return fmt.Errorf("pushing tag %s: %w", newTag, err)
 	}
+	gp.record(newTag)
 
 	logrus.Infof("Successfully pushed tag %s", newTag)
 	return nil
@@ -245,9 +267,19 @@ func (gp *GitObjectPusher) PushMain() error {
 	if err := gp.repo.Push(git.DefaultBranch); err != nil {
 		return fmt.Errorf("pushing %s branch: %w", git.DefaultBranch, err)
 	}
+	gp.record(git.DefaultBranch)
 	return nil
 }
 
+// record adds the pushed git object to the audit log, unless running in
+// dry mode.
+func (gp *GitObjectPusher) record(ref string) {
+	if gp.opts.DryRun {
+		return
+	}
+	audit.Record(audit.ActionGitPush, ref, map[string]string{"remote": git.DefaultRemote})
+}
+
 func (gp *GitObjectPusher) mergeRemoteIfRequired(branch string) error {
 	branch = git.Remotify(branch)
 	branchParts := strings.Split(branch, "/")
@@ -39,6 +39,9 @@ import (
 	rhash "sigs.k8s.io/release-utils/hash"
 	"sigs.k8s.io/release-utils/tar"
 	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/platforms"
 )
 
 const (
@@ -81,6 +84,11 @@ const (
 	// ReleaseStagePath is the directory where releases are staged.
 	ReleaseStagePath = "release-stage"
 
+	// ChecksumsFile is the consolidated SHA256 checksums file of a release in
+	// the format expected by common installer tooling like asdf, arkade and
+	// eget.
+	ChecksumsFile = "checksums.txt"
+
 	// GCEPath is the directory where GCE scripts are created.
 	GCEPath = ReleaseStagePath + "/full/kubernetes/cluster/gce"
 
@@ -137,6 +145,7 @@ const (
 	DockerHubUserName = "k8sreleng"       // Docker Hub username
 
 	ProvenanceFilename = "provenance.json" // Name of the SLSA provenance file (used in stage and release)
+	VersionsFilename   = "versions.json"   // Name of the file containing the staged release versions
 )
 
 var ManifestImages = []string{
@@ -266,7 +275,7 @@ func URLPrefixForBucket(bucket string) string {
 	bucket = strings.TrimPrefix(bucket, object.GcsPrefix)
 	urlPrefix := fmt.Sprintf("https://storage.googleapis.com/%s", bucket)
 	if bucket == ProductionBucket {
-		urlPrefix = ProductionBucketURL
+		urlPrefix = branding.Default().DownloadURL()
 	}
 	return urlPrefix
 }
@@ -274,6 +283,12 @@ func URLPrefixForBucket(bucket string) string {
 // CopyBinaries takes the provided `rootPath` and copies the binaries sorted by
 // their platform into the `targetPath`.
 func CopyBinaries(rootPath, targetPath string) error {
+	return CopyComponentBinaries(rootPath, targetPath, nil)
+}
+
+// CopyComponentBinaries is like CopyBinaries, but only copies the binaries of
+// the selected components. An empty selection copies all binaries.
+func CopyComponentBinaries(rootPath, targetPath string, components Components) error {
 	platformsPath := filepath.Join(rootPath, "client")
 	platformsAndArches, err := os.ReadDir(platformsPath)
 	if err != nil {
@@ -289,15 +304,15 @@ func CopyBinaries(rootPath, targetPath string) error {
 			continue
 		}
 
-		split := strings.Split(platformArch.Name(), "-")
-		if len(split) != 2 {
+		p, err := platforms.Parse(platformArch.Name())
+		if err != nil {
 			return fmt.Errorf(
-				"expected `platform-arch` format for %s", platformArch.Name(),
+				"expected `platform-arch` format for %s: %w", platformArch.Name(), err,
 			)
 		}
 
-		platform := split[0]
-		arch := split[1]
+		platform := p.OS
+		arch := p.Arch
 		logrus.Infof(
 			"Copying binaries for %s platform on %s arch", platform, arch,
 		)
@@ -316,7 +331,7 @@ func CopyBinaries(rootPath, targetPath string) error {
 
 		dst := filepath.Join(targetPath, "bin", platform, arch)
 		logrus.Infof("Copying server binaries from %s to %s", src, dst)
-		if err := util.CopyDirContentsLocal(src, dst); err != nil {
+		if err := copyComponentBinaries(src, dst, components); err != nil {
 			return fmt.Errorf("copy server binaries from %s to %s: %w", src, dst, err)
 		}
 
@@ -326,7 +341,7 @@ func CopyBinaries(rootPath, targetPath string) error {
 			src = filepath.Join(nodeSrc, "kubernetes", "node", "bin")
 
 			logrus.Infof("Copying node binaries from %s to %s", src, dst)
-			if err := util.CopyDirContentsLocal(src, dst); err != nil {
+			if err := copyComponentBinaries(src, dst, components); err != nil {
 				return fmt.Errorf("copy node binaries from %s to %s: %w", src, dst, err)
 			}
 		}
@@ -335,11 +350,12 @@ func CopyBinaries(rootPath, targetPath string) error {
 }
 
 // WriteChecksums writes the SHA256SUMS/SHA512SUMS files (contains all
-// checksums) as well as a sepearete *.sha[256|512] file containing only the
-// SHA for the corresponding file name.
+// checksums) and the consolidated checksums.txt as well as a sepearete
+// *.sha[256|512] file containing only the SHA for the corresponding file name.
 func WriteChecksums(rootPath string) error {
 	logrus.Info("Writing artifact hashes to SHA256SUMS/SHA512SUMS files")
 
+	var sha256Sums []string
 	createSHASums := func(hasher hash.Hash) (string, error) {
 		fileName := fmt.Sprintf("SHA%dSUMS", hasher.Size()*8)
 		files := []string{}
@@ -373,6 +389,9 @@ func WriteChecksums(rootPath string) error {
 			return "", fmt.Errorf("write to file %s: %w", fileName, err)
 		}
 
+		if hasher.Size() == sha256.Size {
+			sha256Sums = files
+		}
 		return file.Name(), nil
 	}
 
@@ -387,6 +406,17 @@ func WriteChecksums(rootPath string) error {
 		return fmt.Errorf("create SHA512 sums: %w", err)
 	}
 
+	// Installers expect every line to be terminated, including the last one
+	checksums := ""
+	if len(sha256Sums) > 0 {
+		checksums = strings.Join(sha256Sums, "\n") + "\n"
+	}
+	if err := os.WriteFile(
+		ChecksumsFile, []byte(checksums), os.FileMode(0o644),
+	); err != nil {
+		return fmt.Errorf("write %s: %w", ChecksumsFile, err)
+	}
+
 	// After all the checksum files are generated, move them into the bucket
 	// staging area
 	moveFile := func(file string) error {
@@ -406,6 +436,9 @@ func WriteChecksums(rootPath string) error {
 	if err := moveFile(sha512SumsFile); err != nil {
 		return fmt.Errorf("move SHA512 sums: %w", err)
 	}
+	if err := moveFile(ChecksumsFile); err != nil {
+		return fmt.Errorf("move %s: %w", ChecksumsFile, err)
+	}
 
 	logrus.Infof("Hashing files in %s", rootPath)
 
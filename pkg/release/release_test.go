@@ -26,6 +26,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -583,6 +584,14 @@ func TestWriteChecksums(t *testing.T) {
 			validate: func(err error, rootPath string) {
 				require.Nil(t, err)
 
+				checksums, err := os.ReadFile(filepath.Join(rootPath, ChecksumsFile))
+				require.Nil(t, err)
+				require.True(t, strings.HasSuffix(string(checksums), "test/9\n"))
+				require.Contains(t, string(checksums),
+					"4bf5122f344554c53bde2ebb8cd2b7e3d1600ad631c385a5d7cce23c7785459a  0\n",
+				)
+				require.NotContains(t, string(checksums), "SHA256SUMS")
+
 				type shaValue struct{ sha, path string }
 				for digest, shas := range map[int][]shaValue{
 					256: {
@@ -635,8 +644,12 @@ func TestWriteChecksums(t *testing.T) {
 					require.Nil(t, os.RemoveAll(tempDir))
 				}
 			},
-			validate: func(err error, _ string) {
+			validate: func(err error, rootPath string) {
+				require.Nil(t, err)
+
+				checksums, err := os.ReadFile(filepath.Join(rootPath, ChecksumsFile))
 				require.Nil(t, err)
+				require.Empty(t, checksums)
 			},
 		},
 		{ // failure dir not existing
@@ -17,14 +17,23 @@ limitations under the License.
 package release
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-sdk/regex"
 	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/tagscheme"
+	"k8s.io/release/pkg/workdir"
 )
 
 const (
@@ -98,6 +107,96 @@ func (r *Versions) String() string {
 	return sb.String()
 }
 
+// versionsJSON is the serialized form of the Versions.
+type versionsJSON struct {
+	Prime    string `json:"prime"`
+	Official string `json:"official,omitempty"`
+	RC       string `json:"rc,omitempty"`
+	Beta     string `json:"beta,omitempty"`
+	Alpha    string `json:"alpha,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *Versions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&versionsJSON{
+		Prime: r.prime, Official: r.official, RC: r.rc, Beta: r.beta, Alpha: r.alpha,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Versions) UnmarshalJSON(data []byte) error {
+	v := &versionsJSON{}
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	*r = Versions{v.Prime, v.Official, v.RC, v.Beta, v.Alpha}
+	return nil
+}
+
+// WriteStagedVersions stores the versions of a stage next to its artifacts,
+// which lets the release reuse the tags rendered during the stage.
+func WriteStagedVersions(bucket, buildVersion string, versions *Versions) error {
+	content, err := json.Marshal(versions)
+	if err != nil {
+		return fmt.Errorf("marshal release versions: %w", err)
+	}
+	tempDir, err := workdir.MkdirTemp("versions-")
+	if err != nil {
+		return fmt.Errorf("create versions temp dir: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	file := filepath.Join(tempDir, VersionsFilename)
+	if err := os.WriteFile(file, content, 0o644); err != nil {
+		return fmt.Errorf("write release versions: %w", err)
+	}
+	gcs := object.NewGCS()
+	gcs.SetOptions(gcs.WithNoClobber(false))
+	if err := gcs.CopyToRemote(
+		file, layout.Default().StagePath(bucket, buildVersion, VersionsFilename),
+	); err != nil {
+		return fmt.Errorf("upload release versions: %w", err)
+	}
+	return nil
+}
+
+// ReadStagedVersions returns the versions stored by WriteStagedVersions, or
+// nil if the stage did not store them.
+func ReadStagedVersions(bucket, buildVersion string) (*Versions, error) {
+	src := layout.Default().StagePath(bucket, buildVersion, VersionsFilename)
+	gcs := object.NewGCS()
+	exists, err := gcs.PathExists(src)
+	if err != nil {
+		return nil, fmt.Errorf("check for staged release versions: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	tempDir, err := workdir.MkdirTemp("versions-")
+	if err != nil {
+		return nil, fmt.Errorf("create versions temp dir: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	file := filepath.Join(tempDir, VersionsFilename)
+	if err := gcs.CopyToLocal(src, file); err != nil {
+		return nil, fmt.Errorf("download staged release versions: %w", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read staged release versions: %w", err)
+	}
+	versions := &Versions{}
+	if err := json.Unmarshal(content, versions); err != nil {
+		return nil, fmt.Errorf("unmarshal staged release versions: %w", err)
+	}
+	if versions.Prime() == "" {
+		return nil, fmt.Errorf("staged release versions %s contain no prime version", src)
+	}
+	return versions, nil
+}
+
 // Ordered returns a list of ordered release versions.
 func (r *Versions) Ordered() (versions []string) {
 	if r.Official() != "" {
@@ -238,6 +337,32 @@ func GenerateReleaseVersion(
 		releaseVersions.prime = releaseVersions.alpha
 	}
 
+	if err := releaseVersions.applyTagScheme(); err != nil {
+		return nil, fmt.Errorf("applying tag scheme: %w", err)
+	}
+
 	logrus.Infof("Found release versions: %+v", releaseVersions.String())
 	return releaseVersions, nil
 }
+
+// applyTagScheme renders all versions using the configured tag scheme, where
+// the counter continues the existing tags of the same upstream version.
+func (r *Versions) applyTagScheme() error {
+	scheme := tagscheme.Default()
+	now := time.Now()
+	for _, v := range []*string{&r.prime, &r.official, &r.rc, &r.beta, &r.alpha} {
+		if *v == "" {
+			continue
+		}
+		previous, err := scheme.Previous(*v)
+		if err != nil {
+			return fmt.Errorf("get previous tag of %s: %w", *v, err)
+		}
+		tag, err := scheme.Apply(*v, previous, now)
+		if err != nil {
+			return err
+		}
+		*v = tag
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/tagscheme"
 	"sigs.k8s.io/release-sdk/git"
 )
 
@@ -179,3 +180,22 @@ func TestGenerateReleaseVersion(t *testing.T) {
 		))
 	}
 }
+
+func TestGenerateReleaseVersionTagScheme(t *testing.T) {
+	scheme := &tagscheme.Scheme{Format: "{{ .Version }}+acme.{{ .Counter }}"}
+	require.NoError(t, scheme.Validate())
+	tagscheme.SetDefault(scheme)
+	t.Cleanup(func() { tagscheme.SetDefault(nil) })
+	tagscheme.SetTagLister(func(string) ([]string, error) {
+		return []string{"v1.18.4+acme.1", "v1.18.4+acme.2"}, nil
+	})
+	t.Cleanup(func() { tagscheme.SetTagLister(nil) })
+
+	res, err := release.GenerateReleaseVersion(
+		release.ReleaseTypeOfficial, "v1.18.4-rc.0.3+3ff09514d162b0", "release-1.18", false,
+	)
+	require.NoError(t, err)
+	require.Equal(t, "v1.18.4+acme.3", res.Prime())
+	require.Equal(t, "v1.18.4+acme.3", res.Official())
+	require.Empty(t, res.RC())
+}
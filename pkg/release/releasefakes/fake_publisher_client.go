@@ -89,6 +89,7 @@ type FakePublisherClient struct {
 	getMarkerPathArgsForCall []struct {
 		arg1 string
 		arg2 string
+		arg3 bool
 	}
 	getMarkerPathReturns struct {
 		result1 string
@@ -166,11 +167,10 @@ type FakePublisherClient struct {
 		result1 []byte
 		result2 error
 	}
-	TempDirStub        func(string, string) (string, error)
+	TempDirStub        func(string) (string, error)
 	tempDirMutex       sync.RWMutex
 	tempDirArgsForCall []struct {
 		arg1 string
-		arg2 string
 	}
 	tempDirReturns struct {
 		result1 string
@@ -529,10 +529,11 @@ func (fake *FakePublisherClient) GetMarkerPath(arg1 string, arg2 string, arg3 bo
 	fake.getMarkerPathArgsForCall = append(fake.getMarkerPathArgsForCall, struct {
 		arg1 string
 		arg2 string
-	}{arg1, arg2})
+		arg3 bool
+	}{arg1, arg2, arg3})
 	stub := fake.GetMarkerPathStub
 	fakeReturns := fake.getMarkerPathReturns
-	fake.recordInvocation("GetMarkerPath", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetMarkerPath", []interface{}{arg1, arg2, arg3})
 	fake.getMarkerPathMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2, arg3)
@@ -555,11 +556,11 @@ func (fake *FakePublisherClient) GetMarkerPathCalls(stub func(string, string, bo
 	fake.GetMarkerPathStub = stub
 }
 
-func (fake *FakePublisherClient) GetMarkerPathArgsForCall(i int) (string, string) {
+func (fake *FakePublisherClient) GetMarkerPathArgsForCall(i int) (string, string, bool) {
 	fake.getMarkerPathMutex.RLock()
 	defer fake.getMarkerPathMutex.RUnlock()
 	argsForCall := fake.getMarkerPathArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakePublisherClient) GetMarkerPathReturns(result1 string, result2 error) {
@@ -911,19 +912,18 @@ func (fake *FakePublisherClient) ReadFileReturnsOnCall(i int, result1 []byte, re
 	}{result1, result2}
 }
 
-func (fake *FakePublisherClient) TempDir(arg1 string, arg2 string) (string, error) {
+func (fake *FakePublisherClient) TempDir(arg1 string) (string, error) {
 	fake.tempDirMutex.Lock()
 	ret, specificReturn := fake.tempDirReturnsOnCall[len(fake.tempDirArgsForCall)]
 	fake.tempDirArgsForCall = append(fake.tempDirArgsForCall, struct {
 		arg1 string
-		arg2 string
-	}{arg1, arg2})
+	}{arg1})
 	stub := fake.TempDirStub
 	fakeReturns := fake.tempDirReturns
-	fake.recordInvocation("TempDir", []interface{}{arg1, arg2})
+	fake.recordInvocation("TempDir", []interface{}{arg1})
 	fake.tempDirMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -937,17 +937,17 @@ func (fake *FakePublisherClient) TempDirCallCount() int {
 	return len(fake.tempDirArgsForCall)
 }
 
-func (fake *FakePublisherClient) TempDirCalls(stub func(string, string) (string, error)) {
+func (fake *FakePublisherClient) TempDirCalls(stub func(string) (string, error)) {
 	fake.tempDirMutex.Lock()
 	defer fake.tempDirMutex.Unlock()
 	fake.TempDirStub = stub
 }
 
-func (fake *FakePublisherClient) TempDirArgsForCall(i int) (string, string) {
+func (fake *FakePublisherClient) TempDirArgsForCall(i int) string {
 	fake.tempDirMutex.RLock()
 	defer fake.tempDirMutex.RUnlock()
 	argsForCall := fake.tempDirArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1
 }
 
 func (fake *FakePublisherClient) TempDirReturns(result1 string, result2 error) {
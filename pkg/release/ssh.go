@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/env"
+)
+
+const (
+	// GitSSHKeyEnvKey is the environment variable containing the path to the
+	// private SSH key, for example a deploy key, to be used for git pushes.
+	// Remotes are switched from HTTPS and token authentication to SSH if it
+	// is set.
+	GitSSHKeyEnvKey = "KREL_GIT_SSH_KEY"
+
+	// gitSSHCommandEnvKey is the environment variable used by git to run
+	// SSH.
+	gitSSHCommandEnvKey = "GIT_SSH_COMMAND"
+)
+
+// GitSSHKey returns the path to the private SSH key for git pushes or an
+// empty string if HTTPS should be used.
+func GitSSHKey() string {
+	return env.Default(GitSSHKeyEnvKey, "")
+}
+
+// ConfigureGitSSH makes all git invocations of the process use the provided
+// private key for SSH remotes. Agent identities are ignored, which allows to
+// select a deploy key of a specific repository.
+func ConfigureGitSSH(key string) error {
+	if key == "" {
+		return errors.New("no SSH key provided")
+	}
+	path, err := filepath.Abs(key)
+	if err != nil {
+		return fmt.Errorf("get absolute path of %s: %w", key, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("check SSH key: %w", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("SSH key %s is a directory", path)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		logrus.Warnf("SSH key %s is accessible by others, ssh may refuse to use it", path)
+	}
+
+	command := fmt.Sprintf(
+		"ssh -i %s -o IdentitiesOnly=yes -o StrictHostKeyChecking=accept-new",
+		shellQuote(path),
+	)
+	if existing := os.Getenv(gitSSHCommandEnvKey); existing != "" && existing != command {
+		logrus.Warnf("Overriding $%s to use SSH key %s", gitSSHCommandEnvKey, path)
+	}
+	if err := os.Setenv(gitSSHCommandEnvKey, command); err != nil {
+		return fmt.Errorf("set %s: %w", gitSSHCommandEnvKey, err)
+	}
+	logrus.Infof("Using SSH key %s for git remotes", path)
+	return nil
+}
+
+// UseGitSSH points the default remote of the repository to the provided
+// GitHub repository over SSH, authenticated by the provided private key.
+func UseGitSSH(repo *git.Repo, org, name, key string) error {
+	if err := ConfigureGitSSH(key); err != nil {
+		return fmt.Errorf("configuring git SSH: %w", err)
+	}
+	if err := repo.SetURL(git.DefaultRemote, git.GetRepoURL(org, name, true)); err != nil {
+		return fmt.Errorf("changing git remote of repository: %w", err)
+	}
+	return nil
+}
+
+// shellQuote quotes the provided string for the usage in a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+)
+
+func TestConfigureGitSSH(t *testing.T) {
+	dir := t.TempDir()
+	key := filepath.Join(dir, "deploy key's")
+	require.NoError(t, os.WriteFile(key, []byte("key"), 0o600))
+
+	for _, tc := range []struct {
+		name        string
+		key         string
+		expected    string
+		shouldError bool
+	}{
+		{
+			name:     "success",
+			key:      key,
+			expected: `ssh -i '` + dir + `/deploy key'\''s' -o IdentitiesOnly=yes -o StrictHostKeyChecking=accept-new`,
+		},
+		{name: "no key", key: "", shouldError: true},
+		{name: "missing key", key: filepath.Join(dir, "missing"), shouldError: true},
+		{name: "directory", key: dir, shouldError: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("GIT_SSH_COMMAND", "")
+
+			err := release.ConfigureGitSSH(tc.key)
+			if tc.shouldError {
+				require.Error(t, err)
+				require.Empty(t, os.Getenv("GIT_SSH_COMMAND"))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, os.Getenv("GIT_SSH_COMMAND"))
+		})
+	}
+}
@@ -33,6 +33,10 @@ import (
 	"sigs.k8s.io/release-sdk/object"
 	"sigs.k8s.io/release-utils/tar"
 	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/platforms"
+	"k8s.io/release/pkg/workdir"
 )
 
 // PrepareWorkspaceStage sets up the workspace by cloning a new copy of k/k.
@@ -79,19 +83,20 @@ func PrepareWorkspaceStage(directory string, noMock bool) error {
 		return fmt.Errorf("retrieving SPDX licenses: %w", err)
 	}
 
-	if isDefaultK8sUpstream {
+	if isDefaultK8sUpstream && GitSSHKey() != "" {
+		if err := UseGitSSH(
+			repo, git.DefaultGithubOrg, git.DefaultGithubRepo, GitSSHKey(),
+		); err != nil {
+			return err
+		}
+	} else if isDefaultK8sUpstream {
 		token, ok := os.LookupEnv(github.TokenEnvKey)
 		if !ok {
 			return fmt.Errorf("%s env variable is not set", github.TokenEnvKey)
 		}
 
-		if err := repo.SetURL(git.DefaultRemote, (&url.URL{
-			Scheme: "https",
-			User:   url.UserPassword("git", token),
-			Host:   "github.com",
-			Path:   filepath.Join(git.DefaultGithubOrg, git.DefaultGithubRepo),
-		}).String()); err != nil {
-			return fmt.Errorf("changing git remote of repository: %w", err)
+		if err := setGitHubRemote(repo, token); err != nil {
+			return err
 		}
 	} else {
 		logrus.Info("Using non-default k8s upstream, doing no git modifications")
@@ -105,14 +110,14 @@ func PrepareWorkspaceStage(directory string, noMock bool) error {
 func PrepareWorkspaceRelease(directory, buildVersion, bucket string) error {
 	logrus.Infof("Preparing workspace for release in %s", directory)
 	logrus.Infof("Searching for staged %s on %s", SourcesTar, bucket)
-	tempDir, err := os.MkdirTemp("", "staged-")
+	tempDir, err := workdir.MkdirTemp("staged-")
 	if err != nil {
 		return fmt.Errorf("create staged sources temp dir: %w", err)
 	}
 	defer os.RemoveAll(tempDir)
 
 	// On `release`, we lookup the staged sources and use them directly
-	src := filepath.Join(bucket, StagePath, buildVersion, SourcesTar)
+	src := layout.Default().StagePath(bucket, buildVersion, SourcesTar)
 	dst := filepath.Join(tempDir, SourcesTar)
 
 	gcs := object.NewGCS()
@@ -128,17 +133,28 @@ func PrepareWorkspaceRelease(directory, buildVersion, bucket string) error {
 		return fmt.Errorf("extracting %s: %w", dst, err)
 	}
 
+	repo, err := git.OpenRepo(directory)
+	if err != nil {
+		return fmt.Errorf("opening staged clone of k/k: %w", err)
+	}
+
+	// Push over SSH if a key is configured
+	if key := GitSSHKey(); key != "" {
+		return UseGitSSH(repo, git.DefaultGithubOrg, git.DefaultGithubRepo, key)
+	}
+
 	// Reset the github token in the staged k/k clone
 	token, ok := os.LookupEnv(github.TokenEnvKey)
 	if !ok {
 		return fmt.Errorf("%s env variable is not set", github.TokenEnvKey)
 	}
 
-	repo, err := git.OpenRepo(directory)
-	if err != nil {
-		return fmt.Errorf("opening staged clone of k/k: %w", err)
-	}
+	return setGitHubRemote(repo, token)
+}
 
+// setGitHubRemote points the default remote of the repository to k/k on
+// GitHub, authenticated by the provided token.
+func setGitHubRemote(repo *git.Repo, token string) error {
 	if err := repo.SetURL(git.DefaultRemote, (&url.URL{
 		Scheme: "https",
 		User:   url.UserPassword("git", token),
@@ -147,7 +163,6 @@ func PrepareWorkspaceRelease(directory, buildVersion, bucket string) error {
 	}).String()); err != nil {
 		return fmt.Errorf("changing git remote of repository: %w", err)
 	}
-
 	return nil
 }
 
@@ -182,15 +197,15 @@ func ListBuildBinaries(gitroot, version string) (list []struct{ Path, Platform,
 			continue
 		}
 
-		split := strings.Split(platformArch.Name(), "-")
-		if len(split) != 2 {
+		p, err := platforms.Parse(platformArch.Name())
+		if err != nil {
 			return nil, fmt.Errorf(
-				"expected `platform-arch` format for %s", platformArch.Name(),
+				"expected `platform-arch` format for %s: %w", platformArch.Name(), err,
 			)
 		}
 
-		platform := split[0]
-		arch := split[1]
+		platform := p.OS
+		arch := p.Arch
 
 		src := filepath.Join(
 			rootPath, "client", platformArch.Name(), "kubernetes", "client", "bin",
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseassets
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	gogithub "github.com/google/go-github/v58/github"
+
+	"sigs.k8s.io/release-sdk/github"
+	"sigs.k8s.io/release-utils/hash"
+	"sigs.k8s.io/release-utils/http"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt releaseassetsfakes/fake_impl.go > releaseassetsfakes/_fake_impl.go && mv releaseassetsfakes/_fake_impl.go releaseassetsfakes/fake_impl.go"
+type impl interface {
+	ListAssets(owner, repo, tag string) ([]*gogithub.ReleaseAsset, error)
+	Download(url, dest string) error
+	SHA256ForFile(path string) (string, error)
+	SHA512ForFile(path string) (string, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) ListAssets(owner, repo, tag string) ([]*gogithub.ReleaseAsset, error) {
+	gh := github.New()
+	release, _, err := gh.Client().GetReleaseByTag(context.Background(), owner, repo, tag)
+	if err != nil {
+		return nil, fmt.Errorf("get release %s: %w", tag, err)
+	}
+	return gh.ListReleaseAssets(owner, repo, release.GetID())
+}
+
+func (*defaultImpl) Download(url, dest string) error {
+	content, err := http.NewAgent().Get(url)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dest, content, 0o644)
+}
+
+func (*defaultImpl) SHA256ForFile(path string) (string, error) {
+	return hash.SHA256ForFile(path)
+}
+
+func (*defaultImpl) SHA512ForFile(path string) (string, error) {
+	return hash.SHA512ForFile(path)
+}
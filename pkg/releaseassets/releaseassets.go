@@ -0,0 +1,346 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package releaseassets downloads the assets of a GitHub release, verifies
+// them against the published checksum manifests and lays them out locally, for example to mirror a release or to build an air-gapped
+// bundle.
+package releaseassets
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/progress"
+	"k8s.io/release/pkg/release"
+)
+
+const (
+	// SHA256Manifest is the published manifest of the SHA256 checksums.
+	SHA256Manifest = "SHA256SUMS"
+
+	// SHA512Manifest is the published manifest of the SHA512 checksums.
+	SHA512Manifest = "SHA512SUMS"
+
+	// ChecksumsManifest is the consolidated SHA256 manifest attached to the
+	// GitHub release page by krel release.
+	ChecksumsManifest = release.ChecksumsFile
+)
+
+// Options are the main options for downloading release assets.
+type Options struct {
+	// GitHubOrg is the GitHub organization of the release.
+	GitHubOrg string
+
+	// GitHubRepo is the GitHub repository of the release.
+	GitHubRepo string
+
+	// Tag is the tag of the release, for example v1.30.0.
+	Tag string
+
+	// OutputDir is the directory the assets get laid out in, below
+	// <org>/<repo>/<tag>.
+	OutputDir string
+
+	// Patterns restrict the downloaded assets to the ones matching any of
+	// the shell patterns. All assets get downloaded if empty. The checksum
+	// manifests are always downloaded.
+	Patterns []string
+
+	// VerifyChecksums verifies every asset against the published checksum
+	// manifests.
+	VerifyChecksums bool
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		GitHubOrg:       git.DefaultGithubOrg,
+		GitHubRepo:      git.DefaultGithubRepo,
+		OutputDir:       ".",
+		VerifyChecksums: true,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.GitHubOrg == "" || o.GitHubRepo == "" {
+		return errors.New("GitHub organization and repository must not be empty")
+	}
+	if o.Tag == "" {
+		return errors.New("no release tag specified")
+	}
+	if o.OutputDir == "" {
+		return errors.New("no output directory specified")
+	}
+	for _, pattern := range o.Patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid asset pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Asset is a downloaded release asset.
+type Asset struct {
+	// Name is the file name of the asset.
+	Name string `json:"name"`
+
+	// Path is the local path of the asset.
+	Path string `json:"path"`
+
+	// SHA256 is the digest of the asset.
+	SHA256 string `json:"sha256"`
+
+	// ChecksumVerified is true if the asset matched the checksum manifests.
+	ChecksumVerified bool `json:"checksumVerified"`
+}
+
+// Downloader downloads and verifies the assets of a GitHub release.
+type Downloader struct {
+	impl    impl
+	options *Options
+}
+
+// New creates a new Downloader instance.
+func New(opts *Options) *Downloader {
+	return &Downloader{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (d *Downloader) SetImpl(impl impl) {
+	d.impl = impl
+}
+
+// Dir returns the local directory of the release assets.
+func (d *Downloader) Dir() string {
+	return filepath.Join(
+		d.options.OutputDir, d.options.GitHubOrg, d.options.GitHubRepo, d.options.Tag,
+	)
+}
+
+// Download downloads the selected release assets and verifies them. The
+// downloaded files are kept if the verification fails, to allow further
+// inspection.
+func (d *Downloader) Download() ([]*Asset, error) {
+	if err := d.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	releaseAssets, err := d.impl.ListAssets(
+		d.options.GitHubOrg, d.options.GitHubRepo, d.options.Tag,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list release assets: %w", err)
+	}
+
+	selected := d.selectAssets(releaseAssets)
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no assets of release %s match the patterns", d.options.Tag)
+	}
+
+	dir := d.Dir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create output directory: %w", err)
+	}
+
+	bar := progress.NewBar("Downloading assets", len(selected))
+	for _, asset := range selected {
+		dest := filepath.Join(dir, asset.GetName())
+		logrus.Debugf("Downloading %s to %s", asset.GetBrowserDownloadURL(), dest)
+		if err := d.impl.Download(asset.GetBrowserDownloadURL(), dest); err != nil {
+			return nil, fmt.Errorf("download %s: %w", asset.GetName(), err)
+		}
+		bar.Increment()
+	}
+	bar.Finish()
+
+	res := []*Asset{}
+	for _, asset := range selected {
+		name := asset.GetName()
+		if isManifest(name) {
+			continue
+		}
+		p := filepath.Join(dir, name)
+		digest, err := d.impl.SHA256ForFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("get digest of %s: %w", name, err)
+		}
+		res = append(res, &Asset{Name: name, Path: p, SHA256: digest})
+	}
+
+	if d.options.VerifyChecksums {
+		if err := d.verifyChecksums(dir, res); err != nil {
+			return res, err
+		}
+	}
+	logrus.Infof("Downloaded %d assets of %s to %s", len(res), d.options.Tag, dir)
+	return res, nil
+}
+
+// selectAssets returns the assets matching the patterns, including the
+// checksum manifests.
+func (d *Downloader) selectAssets(assets []*gogithub.ReleaseAsset) []*gogithub.ReleaseAsset {
+	res := []*gogithub.ReleaseAsset{}
+	for _, asset := range assets {
+		if isManifest(asset.GetName()) || d.matches(asset.GetName()) {
+			res = append(res, asset)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].GetName() < res[j].GetName() })
+	return res
+}
+
+func (d *Downloader) matches(name string) bool {
+	if len(d.options.Patterns) == 0 {
+		return true
+	}
+	for _, pattern := range d.options.Patterns {
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyChecksums verifies the assets against all downloaded checksum
+// manifests. Every asset has to be listed in at least one of them.
+func (d *Downloader) verifyChecksums(dir string, assets []*Asset) error {
+	manifests := []struct {
+		name   string
+		digest func(string) (string, error)
+	}{
+		{SHA256Manifest, d.impl.SHA256ForFile},
+		{SHA512Manifest, d.impl.SHA512ForFile},
+		{ChecksumsManifest, d.impl.SHA256ForFile},
+	}
+
+	found := false
+	listed := map[string]bool{}
+	for _, manifest := range manifests {
+		manifestPath := filepath.Join(dir, manifest.name)
+		if !util.Exists(manifestPath) {
+			continue
+		}
+		found = true
+
+		content, err := os.ReadFile(manifestPath)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", manifest.name, err)
+		}
+		expected := ParseManifest(content)
+
+		for _, asset := range assets {
+			want, ok := expected[asset.Name]
+			if !ok {
+				continue
+			}
+			got, err := manifest.digest(asset.Path)
+			if err != nil {
+				return fmt.Errorf("get digest of %s: %w", asset.Name, err)
+			}
+			if !strings.EqualFold(got, want) {
+				return fmt.Errorf(
+					"checksum mismatch of %s in %s: expected %s, got %s",
+					asset.Name, manifest.name, want, got,
+				)
+			}
+			listed[asset.Name] = true
+		}
+	}
+	if !found {
+		return fmt.Errorf(
+			"release %s publishes no %s, %s or %s manifest",
+			d.options.Tag, SHA256Manifest, SHA512Manifest, ChecksumsManifest,
+		)
+	}
+
+	for _, asset := range assets {
+		if !listed[asset.Name] {
+			return fmt.Errorf("asset %s is not listed in the checksum manifests", asset.Name)
+		}
+		asset.ChecksumVerified = true
+	}
+	logrus.Infof("Verified the checksums of %d assets", len(assets))
+	return nil
+}
+
+// ParseManifest parses the content of a SHA256SUMS, SHA512SUMS or
+// checksums.txt manifest into the digests per file name. Entries with a directory get additionally
+// mapped to their base name if it is unique, because release assets are flat.
+func ParseManifest(content []byte) map[string]string {
+	res := map[string]string{}
+	bases := map[string][]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		name := strings.TrimPrefix(fields[1], "*")
+		res[name] = fields[0]
+		if base := path.Base(name); base != name {
+			bases[base] = append(bases[base], fields[0])
+		}
+	}
+	for base, digests := range bases {
+		if _, ok := res[base]; !ok && len(digests) == 1 {
+			res[base] = digests[0]
+		}
+	}
+	return res
+}
+
+// PrintAssets writes a table of the downloaded assets and their verification
+// results to w.
+func PrintAssets(w io.Writer, assets []*Asset) {
+	table := tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Asset", "SHA256", "Checksum"})
+	for _, asset := range assets {
+		result := "-"
+		if asset.ChecksumVerified {
+			result = "VERIFIED"
+		}
+		table.Append([]string{asset.Name, asset.SHA256, result})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+}
+
+func isManifest(name string) bool {
+	return name == SHA256Manifest || name == SHA512Manifest || name == ChecksumsManifest
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseassets_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/releaseassets"
+	"k8s.io/release/pkg/releaseassets/releaseassetsfakes"
+)
+
+func testAssets(names ...string) []*gogithub.ReleaseAsset {
+	res := []*gogithub.ReleaseAsset{}
+	for _, name := range names {
+		res = append(res, &gogithub.ReleaseAsset{
+			Name:               gogithub.String(name),
+			BrowserDownloadURL: gogithub.String("https://example.com/" + name),
+		})
+	}
+	return res
+}
+
+// prepareDownloads writes the asset name as content of every download and
+// the provided content for the manifest.
+func prepareDownloads(mock *releaseassetsfakes.FakeImpl, manifest, content string) {
+	mock.DownloadCalls(func(url, dest string) error {
+		data := filepath.Base(dest)
+		if data == manifest {
+			data = content
+		}
+		return os.WriteFile(dest, []byte(data), 0o600)
+	})
+	mock.SHA256ForFileCalls(func(path string) (string, error) {
+		return "sha-" + filepath.Base(path), nil
+	})
+}
+
+func TestDownload(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test")
+
+	for _, tc := range []struct {
+		name              string
+		prepare           func(*releaseassetsfakes.FakeImpl, *releaseassets.Options)
+		expectedAssets    []string
+		expectedDownloads int
+		shouldErr         bool
+	}{
+		{
+			name: "success",
+			prepare: func(mock *releaseassetsfakes.FakeImpl, _ *releaseassets.Options) {
+				mock.ListAssetsReturns(testAssets("a.tar.gz", "b.tar.gz", "SHA256SUMS"), nil)
+				prepareDownloads(mock, releaseassets.SHA256Manifest, "sha-a.tar.gz  a.tar.gz\nsha-b.tar.gz  dir/b.tar.gz\n")
+			},
+			expectedAssets:    []string{"a.tar.gz", "b.tar.gz"},
+			expectedDownloads: 3,
+		},
+		{
+			name: "success with patterns",
+			prepare: func(mock *releaseassetsfakes.FakeImpl, opts *releaseassets.Options) {
+				opts.Patterns = []string{"a.*"}
+				mock.ListAssetsReturns(testAssets("a.tar.gz", "b.tar.gz", "SHA256SUMS"), nil)
+				prepareDownloads(mock, releaseassets.SHA256Manifest, "sha-a.tar.gz  a.tar.gz\n")
+			},
+			expectedAssets:    []string{"a.tar.gz"},
+			expectedDownloads: 2,
+		},
+		{
+			name: "success with checksums.txt",
+			prepare: func(mock *releaseassetsfakes.FakeImpl, _ *releaseassets.Options) {
+				mock.ListAssetsReturns(testAssets("a.tar.gz", "checksums.txt"), nil)
+				prepareDownloads(mock, releaseassets.ChecksumsManifest, "sha-a.tar.gz  bin/a.tar.gz\n")
+			},
+			expectedAssets:    []string{"a.tar.gz"},
+			expectedDownloads: 2,
+		},
+		{
+			name: "success without checksum verification",
+			prepare: func(mock *releaseassetsfakes.FakeImpl, opts *releaseassets.Options) {
+				opts.VerifyChecksums = false
+				mock.ListAssetsReturns(testAssets("a.tar.gz"), nil)
+				prepareDownloads(mock, releaseassets.SHA256Manifest, "")
+			},
+			expectedAssets:    []string{"a.tar.gz"},
+			expectedDownloads: 1,
+		},
+		{
+			name: "failure checksum mismatch",
+			prepare: func(mock *releaseassetsfakes.FakeImpl, _ *releaseassets.Options) {
+				mock.ListAssetsReturns(testAssets("a.tar.gz", "SHA256SUMS"), nil)
+				prepareDownloads(mock, releaseassets.SHA256Manifest, "wrong  a.tar.gz\n")
+			},
+			expectedDownloads: 2,
+			shouldErr:         true,
+		},
+		{
+			name: "failure unlisted asset",
+			prepare: func(mock *releaseassetsfakes.FakeImpl, _ *releaseassets.Options) {
+				mock.ListAssetsReturns(testAssets("a.tar.gz", "b.tar.gz", "SHA256SUMS"), nil)
+				prepareDownloads(mock, releaseassets.SHA256Manifest, "sha-a.tar.gz  a.tar.gz\n")
+			},
+			expectedDownloads: 3,
+			shouldErr:         true,
+		},
+		{
+			name: "failure no manifest",
+			prepare: func(mock *releaseassetsfakes.FakeImpl, _ *releaseassets.Options) {
+				mock.ListAssetsReturns(testAssets("a.tar.gz"), nil)
+				prepareDownloads(mock, releaseassets.SHA256Manifest, "")
+			},
+			expectedDownloads: 1,
+			shouldErr:         true,
+		},
+		{
+			name: "failure no matching assets",
+			prepare: func(mock *releaseassetsfakes.FakeImpl, opts *releaseassets.Options) {
+				opts.Patterns = []string{"c.*"}
+				mock.ListAssetsReturns(testAssets("a.tar.gz"), nil)
+			},
+			shouldErr: true,
+		},
+		{
+			name: "failure list assets",
+			prepare: func(mock *releaseassetsfakes.FakeImpl, _ *releaseassets.Options) {
+				mock.ListAssetsReturns(nil, errTest)
+			},
+			shouldErr: true,
+		},
+		{
+			name: "failure download",
+			prepare: func(mock *releaseassetsfakes.FakeImpl, _ *releaseassets.Options) {
+				mock.ListAssetsReturns(testAssets("a.tar.gz"), nil)
+				mock.DownloadReturns(errTest)
+			},
+			expectedDownloads: 1,
+			shouldErr:         true,
+		},
+		{
+			name: "failure invalid pattern",
+			prepare: func(_ *releaseassetsfakes.FakeImpl, opts *releaseassets.Options) {
+				opts.Patterns = []string{"["}
+			},
+			shouldErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := releaseassets.DefaultOptions()
+			opts.Tag = "v1.30.0"
+			opts.OutputDir = t.TempDir()
+
+			mock := &releaseassetsfakes.FakeImpl{}
+			tc.prepare(mock, opts)
+			sut := releaseassets.New(opts)
+			sut.SetImpl(mock)
+
+			assets, err := sut.Download()
+			require.Equal(t, tc.expectedDownloads, mock.DownloadCallCount())
+			if tc.shouldErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			names := []string{}
+			for _, asset := range assets {
+				names = append(names, asset.Name)
+				require.Equal(t, filepath.Join(opts.OutputDir, "kubernetes", "kubernetes", "v1.30.0", asset.Name), asset.Path)
+				require.FileExists(t, asset.Path)
+				require.Equal(t, opts.VerifyChecksums, asset.ChecksumVerified)
+			}
+			require.Equal(t, tc.expectedAssets, names)
+		})
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	t.Parallel()
+
+	res := releaseassets.ParseManifest([]byte(strings.Join([]string{
+		"aaa  kubernetes.tar.gz",
+		"bbb  bin/linux/amd64/kubectl",
+		"ccc  bin/darwin/amd64/kubectl",
+		"ddd *bin/linux/amd64/kubeadm",
+		"invalid",
+		"",
+	}, "\n")))
+
+	require.Equal(t, map[string]string{
+		"kubernetes.tar.gz":        "aaa",
+		"bin/linux/amd64/kubectl":  "bbb",
+		"bin/darwin/amd64/kubectl": "ccc",
+		"bin/linux/amd64/kubeadm":  "ddd",
+		"kubeadm":                  "ddd",
+	}, res)
+}
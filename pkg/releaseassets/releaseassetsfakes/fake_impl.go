@@ -0,0 +1,368 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package releaseassetsfakes
+
+import (
+	"sync"
+
+	"github.com/google/go-github/v58/github"
+)
+
+type FakeImpl struct {
+	DownloadStub        func(string, string) error
+	downloadMutex       sync.RWMutex
+	downloadArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	downloadReturns struct {
+		result1 error
+	}
+	downloadReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListAssetsStub        func(string, string, string) ([]*github.ReleaseAsset, error)
+	listAssetsMutex       sync.RWMutex
+	listAssetsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	listAssetsReturns struct {
+		result1 []*github.ReleaseAsset
+		result2 error
+	}
+	listAssetsReturnsOnCall map[int]struct {
+		result1 []*github.ReleaseAsset
+		result2 error
+	}
+	SHA256ForFileStub        func(string) (string, error)
+	sHA256ForFileMutex       sync.RWMutex
+	sHA256ForFileArgsForCall []struct {
+		arg1 string
+	}
+	sHA256ForFileReturns struct {
+		result1 string
+		result2 error
+	}
+	sHA256ForFileReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	SHA512ForFileStub        func(string) (string, error)
+	sHA512ForFileMutex       sync.RWMutex
+	sHA512ForFileArgsForCall []struct {
+		arg1 string
+	}
+	sHA512ForFileReturns struct {
+		result1 string
+		result2 error
+	}
+	sHA512ForFileReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) Download(arg1 string, arg2 string) error {
+	fake.downloadMutex.Lock()
+	ret, specificReturn := fake.downloadReturnsOnCall[len(fake.downloadArgsForCall)]
+	fake.downloadArgsForCall = append(fake.downloadArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DownloadStub
+	fakeReturns := fake.downloadReturns
+	fake.recordInvocation("Download", []interface{}{arg1, arg2})
+	fake.downloadMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) DownloadCallCount() int {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	return len(fake.downloadArgsForCall)
+}
+
+func (fake *FakeImpl) DownloadCalls(stub func(string, string) error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = stub
+}
+
+func (fake *FakeImpl) DownloadArgsForCall(i int) (string, string) {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	argsForCall := fake.downloadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) DownloadReturns(result1 error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = nil
+	fake.downloadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) DownloadReturnsOnCall(i int, result1 error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = nil
+	if fake.downloadReturnsOnCall == nil {
+		fake.downloadReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.downloadReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) ListAssets(arg1 string, arg2 string, arg3 string) ([]*github.ReleaseAsset, error) {
+	fake.listAssetsMutex.Lock()
+	ret, specificReturn := fake.listAssetsReturnsOnCall[len(fake.listAssetsArgsForCall)]
+	fake.listAssetsArgsForCall = append(fake.listAssetsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ListAssetsStub
+	fakeReturns := fake.listAssetsReturns
+	fake.recordInvocation("ListAssets", []interface{}{arg1, arg2, arg3})
+	fake.listAssetsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ListAssetsCallCount() int {
+	fake.listAssetsMutex.RLock()
+	defer fake.listAssetsMutex.RUnlock()
+	return len(fake.listAssetsArgsForCall)
+}
+
+func (fake *FakeImpl) ListAssetsCalls(stub func(string, string, string) ([]*github.ReleaseAsset, error)) {
+	fake.listAssetsMutex.Lock()
+	defer fake.listAssetsMutex.Unlock()
+	fake.ListAssetsStub = stub
+}
+
+func (fake *FakeImpl) ListAssetsArgsForCall(i int) (string, string, string) {
+	fake.listAssetsMutex.RLock()
+	defer fake.listAssetsMutex.RUnlock()
+	argsForCall := fake.listAssetsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) ListAssetsReturns(result1 []*github.ReleaseAsset, result2 error) {
+	fake.listAssetsMutex.Lock()
+	defer fake.listAssetsMutex.Unlock()
+	fake.ListAssetsStub = nil
+	fake.listAssetsReturns = struct {
+		result1 []*github.ReleaseAsset
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ListAssetsReturnsOnCall(i int, result1 []*github.ReleaseAsset, result2 error) {
+	fake.listAssetsMutex.Lock()
+	defer fake.listAssetsMutex.Unlock()
+	fake.ListAssetsStub = nil
+	if fake.listAssetsReturnsOnCall == nil {
+		fake.listAssetsReturnsOnCall = make(map[int]struct {
+			result1 []*github.ReleaseAsset
+			result2 error
+		})
+	}
+	fake.listAssetsReturnsOnCall[i] = struct {
+		result1 []*github.ReleaseAsset
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SHA256ForFile(arg1 string) (string, error) {
+	fake.sHA256ForFileMutex.Lock()
+	ret, specificReturn := fake.sHA256ForFileReturnsOnCall[len(fake.sHA256ForFileArgsForCall)]
+	fake.sHA256ForFileArgsForCall = append(fake.sHA256ForFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SHA256ForFileStub
+	fakeReturns := fake.sHA256ForFileReturns
+	fake.recordInvocation("SHA256ForFile", []interface{}{arg1})
+	fake.sHA256ForFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) SHA256ForFileCallCount() int {
+	fake.sHA256ForFileMutex.RLock()
+	defer fake.sHA256ForFileMutex.RUnlock()
+	return len(fake.sHA256ForFileArgsForCall)
+}
+
+func (fake *FakeImpl) SHA256ForFileCalls(stub func(string) (string, error)) {
+	fake.sHA256ForFileMutex.Lock()
+	defer fake.sHA256ForFileMutex.Unlock()
+	fake.SHA256ForFileStub = stub
+}
+
+func (fake *FakeImpl) SHA256ForFileArgsForCall(i int) string {
+	fake.sHA256ForFileMutex.RLock()
+	defer fake.sHA256ForFileMutex.RUnlock()
+	argsForCall := fake.sHA256ForFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) SHA256ForFileReturns(result1 string, result2 error) {
+	fake.sHA256ForFileMutex.Lock()
+	defer fake.sHA256ForFileMutex.Unlock()
+	fake.SHA256ForFileStub = nil
+	fake.sHA256ForFileReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SHA256ForFileReturnsOnCall(i int, result1 string, result2 error) {
+	fake.sHA256ForFileMutex.Lock()
+	defer fake.sHA256ForFileMutex.Unlock()
+	fake.SHA256ForFileStub = nil
+	if fake.sHA256ForFileReturnsOnCall == nil {
+		fake.sHA256ForFileReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.sHA256ForFileReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SHA512ForFile(arg1 string) (string, error) {
+	fake.sHA512ForFileMutex.Lock()
+	ret, specificReturn := fake.sHA512ForFileReturnsOnCall[len(fake.sHA512ForFileArgsForCall)]
+	fake.sHA512ForFileArgsForCall = append(fake.sHA512ForFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SHA512ForFileStub
+	fakeReturns := fake.sHA512ForFileReturns
+	fake.recordInvocation("SHA512ForFile", []interface{}{arg1})
+	fake.sHA512ForFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) SHA512ForFileCallCount() int {
+	fake.sHA512ForFileMutex.RLock()
+	defer fake.sHA512ForFileMutex.RUnlock()
+	return len(fake.sHA512ForFileArgsForCall)
+}
+
+func (fake *FakeImpl) SHA512ForFileCalls(stub func(string) (string, error)) {
+	fake.sHA512ForFileMutex.Lock()
+	defer fake.sHA512ForFileMutex.Unlock()
+	fake.SHA512ForFileStub = stub
+}
+
+func (fake *FakeImpl) SHA512ForFileArgsForCall(i int) string {
+	fake.sHA512ForFileMutex.RLock()
+	defer fake.sHA512ForFileMutex.RUnlock()
+	argsForCall := fake.sHA512ForFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) SHA512ForFileReturns(result1 string, result2 error) {
+	fake.sHA512ForFileMutex.Lock()
+	defer fake.sHA512ForFileMutex.Unlock()
+	fake.SHA512ForFileStub = nil
+	fake.sHA512ForFileReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SHA512ForFileReturnsOnCall(i int, result1 string, result2 error) {
+	fake.sHA512ForFileMutex.Lock()
+	defer fake.sHA512ForFileMutex.Unlock()
+	fake.SHA512ForFileStub = nil
+	if fake.sHA512ForFileReturnsOnCall == nil {
+		fake.sHA512ForFileReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.sHA512ForFileReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	fake.listAssetsMutex.RLock()
+	defer fake.listAssetsMutex.RUnlock()
+	fake.sHA256ForFileMutex.RLock()
+	defer fake.sHA256ForFileMutex.RUnlock()
+	fake.sHA512ForFileMutex.RLock()
+	defer fake.sHA512ForFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
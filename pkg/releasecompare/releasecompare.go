@@ -0,0 +1,470 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package releasecompare compares the published artifacts and images of two
+// releases, for example for upgrade planning or a security review.
+package releasecompare
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-sdk/object"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/sizereport"
+)
+
+// DefaultPlatform is the default platform for comparing the base images.
+const DefaultPlatform = "linux/amd64"
+
+// The statuses of a compared image.
+const (
+	// StatusAdded means that the image exists only in the newer release.
+	StatusAdded = "added"
+
+	// StatusRemoved means that the image exists only in the older release.
+	StatusRemoved = "removed"
+
+	// StatusChanged means that the image got rebuilt on the same base image.
+	StatusChanged = "changed"
+
+	// StatusBaseChanged means that the image is built on a different base
+	// image.
+	StatusBaseChanged = "base image changed"
+
+	// StatusUnchanged means that both releases have the same image digest.
+	StatusUnchanged = "unchanged"
+)
+
+// Options are the main options for comparing two releases.
+type Options struct {
+	// Bucket is the bucket containing the released artifacts.
+	Bucket string
+
+	// Registry is the registry the images got released to. Empty means the
+	// registry of the branding.
+	Registry string
+
+	// Images are the names of the compared images.
+	Images []string
+
+	// Platform is the platform in the format <os>/<arch> for which the base
+	// images get compared.
+	Platform string
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Bucket:   release.ProductionBucket,
+		Images:   slices.Clone(release.ManifestImages),
+		Platform: DefaultPlatform,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Bucket == "" {
+		return errors.New("no bucket specified")
+	}
+	if len(o.Images) == 0 {
+		return errors.New("no images specified")
+	}
+	if goos, arch, ok := strings.Cut(o.Platform, "/"); !ok || goos == "" || arch == "" {
+		return fmt.Errorf("invalid platform %q, expected <os>/<arch>", o.Platform)
+	}
+	return nil
+}
+
+// Artifacts are the artifacts which got added or removed, based on the
+// SHA256SUMS files of both releases.
+type Artifacts struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// Image is the comparison result of a single image.
+type Image struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	FromRef    string `json:"fromRef"`
+	ToRef      string `json:"toRef"`
+	FromDigest string `json:"fromDigest,omitempty"`
+	ToDigest   string `json:"toDigest,omitempty"`
+
+	// FromBase and ToBase identify the base images by the digest of their
+	// top layer, which is the layer below the one containing the binary.
+	FromBase string `json:"fromBase,omitempty"`
+	ToBase   string `json:"toBase,omitempty"`
+}
+
+// Size is the size change of a single artifact.
+type Size struct {
+	Name     string  `json:"name"`
+	Kind     string  `json:"kind"`
+	FromSize int64   `json:"fromSize"`
+	ToSize   int64   `json:"toSize"`
+	Delta    int64   `json:"delta"`
+	Change   float64 `json:"change"`
+}
+
+// Report is the result of comparing two releases.
+type Report struct {
+	From      string     `json:"from"`
+	To        string     `json:"to"`
+	Platform  string     `json:"platform"`
+	Artifacts *Artifacts `json:"artifacts"`
+	Images    []*Image   `json:"images"`
+
+	// Sizes are the size changes of all artifacts recorded for both
+	// releases, sorted by the absolute delta. Empty if the sizes of one of
+	// the releases have not been recorded.
+	Sizes []*Size `json:"sizes"`
+}
+
+// Markdown returns the report as markdown.
+func (r *Report) Markdown() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "### Changes from %s to %s\n\n", r.From, r.To)
+
+	buf.WriteString("#### Artifacts\n\n")
+	if len(r.Artifacts.Added)+len(r.Artifacts.Removed) == 0 {
+		buf.WriteString("No artifacts got added or removed.\n")
+	}
+	for _, p := range r.Artifacts.Added {
+		fmt.Fprintf(buf, "- added: `%s`\n", p)
+	}
+	for _, p := range r.Artifacts.Removed {
+		fmt.Fprintf(buf, "- removed: `%s`\n", p)
+	}
+
+	fmt.Fprintf(buf, "\n#### Images (%s)\n\n", r.Platform)
+	renderTable(
+		buf, []string{"Image", "Status", r.From, r.To, "Base " + r.From, "Base " + r.To},
+		func(table *tablewriter.Table) {
+			for _, image := range r.Images {
+				table.Append([]string{
+					image.Name, image.Status,
+					shortDigest(image.FromDigest), shortDigest(image.ToDigest),
+					shortDigest(image.FromBase), shortDigest(image.ToBase),
+				})
+			}
+		},
+	)
+
+	buf.WriteString("\n#### Sizes\n\n")
+	if len(r.Sizes) == 0 {
+		fmt.Fprintf(buf, "No artifact sizes recorded for both %s and %s.\n", r.From, r.To)
+		return buf.String()
+	}
+	renderTable(
+		buf, []string{"Kind", "Artifact", r.From, r.To, "Delta"},
+		func(table *tablewriter.Table) {
+			for _, size := range r.Sizes {
+				table.Append([]string{
+					size.Kind, size.Name,
+					sizereport.FormatSize(size.FromSize), sizereport.FormatSize(size.ToSize),
+					fmt.Sprintf("%+.1f%%", size.Change),
+				})
+			}
+		},
+	)
+	return buf.String()
+}
+
+func renderTable(buf *bytes.Buffer, header []string, rows func(*tablewriter.Table)) {
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeader(header)
+	rows(table)
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+}
+
+// shortDigest abbreviates a sha256 digest to 12 characters.
+func shortDigest(digest string) string {
+	if digest == "" {
+		return "-"
+	}
+	hex := strings.TrimPrefix(digest, "sha256:")
+	if len(hex) > 12 {
+		hex = hex[:12]
+	}
+	return hex
+}
+
+// Comparer is the main structure for comparing two releases.
+type Comparer struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Comparer instance.
+func New(opts *Options) *Comparer {
+	return &Comparer{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (c *Comparer) SetImpl(impl impl) {
+	c.impl = impl
+}
+
+// Compare compares the published artifacts, images and artifact sizes of the
+// releases from and to.
+func (c *Comparer) Compare(from, to string) (*Report, error) {
+	if err := c.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+	for _, version := range []string{from, to} {
+		if _, err := util.TagStringToSemver(version); err != nil {
+			return nil, fmt.Errorf("invalid version %s: %w", version, err)
+		}
+	}
+
+	report := &Report{
+		From:     util.AddTagPrefix(from),
+		To:       util.AddTagPrefix(to),
+		Platform: c.options.Platform,
+		Images:   []*Image{},
+		Sizes:    []*Size{},
+	}
+
+	fromSums, err := c.checksums(report.From)
+	if err != nil {
+		return nil, err
+	}
+	toSums, err := c.checksums(report.To)
+	if err != nil {
+		return nil, err
+	}
+	report.Artifacts = compareArtifacts(fromSums, toSums)
+
+	registry := c.options.Registry
+	if registry == "" {
+		registry = branding.Default().Registry
+	}
+	for _, name := range c.options.Images {
+		image, err := c.compareImage(strings.TrimSuffix(registry, "/"), name, report.From, report.To)
+		if err != nil {
+			return nil, err
+		}
+		report.Images = append(report.Images, image)
+	}
+
+	history, err := c.sizeHistory()
+	if err != nil {
+		return nil, err
+	}
+	report.Sizes = compareSizes(history.Get(report.From), history.Get(report.To))
+	return report, nil
+}
+
+// checksums returns the SHA256 checksums of all artifacts of the version,
+// indexed by their path relative to the release directory.
+func (c *Comparer) checksums(version string) (map[string]string, error) {
+	releasePath, err := layout.Default().ReleasePath(c.options.Bucket, "release", version, false)
+	if err != nil {
+		return nil, fmt.Errorf("get release path: %w", err)
+	}
+	sumsPath := object.GcsPrefix + path.Join(releasePath, release.VerifiedFile)
+
+	logrus.Infof("Reading artifact checksums of %s from %s", version, sumsPath)
+	content, err := c.impl.ReadObject(sumsPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", sumsPath, err)
+	}
+	if content == nil {
+		return nil, fmt.Errorf("no %s found for %s in %s", release.VerifiedFile, version, sumsPath)
+	}
+
+	sums := map[string]string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		sums[fields[1]] = fields[0]
+	}
+	return sums, nil
+}
+
+// compareArtifacts returns the sorted paths which exist only in one of the
+// releases.
+func compareArtifacts(from, to map[string]string) *Artifacts {
+	res := &Artifacts{Added: []string{}, Removed: []string{}}
+	for p := range to {
+		if _, ok := from[p]; !ok {
+			res.Added = append(res.Added, p)
+		}
+	}
+	for p := range from {
+		if _, ok := to[p]; !ok {
+			res.Removed = append(res.Removed, p)
+		}
+	}
+	sort.Strings(res.Added)
+	sort.Strings(res.Removed)
+	return res
+}
+
+// compareImage compares the image name of both releases. Images which cannot
+// be resolved are considered as not existing in the release.
+func (c *Comparer) compareImage(registry, name, from, to string) (*Image, error) {
+	image := &Image{
+		Name:    name,
+		FromRef: fmt.Sprintf("%s/%s:%s", registry, name, strings.ReplaceAll(from, "+", "_")),
+		ToRef:   fmt.Sprintf("%s/%s:%s", registry, name, strings.ReplaceAll(to, "+", "_")),
+	}
+
+	var err error
+	if image.FromDigest, err = c.impl.Digest(image.FromRef); err != nil {
+		logrus.Warnf("Unable to resolve image %s: %v", image.FromRef, err)
+	}
+	if image.ToDigest, err = c.impl.Digest(image.ToRef); err != nil {
+		logrus.Warnf("Unable to resolve image %s: %v", image.ToRef, err)
+	}
+
+	switch {
+	case image.FromDigest == "" && image.ToDigest == "":
+		return nil, fmt.Errorf("image %s exists in neither %s nor %s", name, from, to)
+	case image.FromDigest == "":
+		image.Status = StatusAdded
+		return image, nil
+	case image.ToDigest == "":
+		image.Status = StatusRemoved
+		return image, nil
+	case image.FromDigest == image.ToDigest:
+		image.Status = StatusUnchanged
+		return image, nil
+	}
+
+	fromBase, err := c.baseLayers(image.FromRef)
+	if err != nil {
+		return nil, err
+	}
+	toBase, err := c.baseLayers(image.ToRef)
+	if err != nil {
+		return nil, err
+	}
+	if len(fromBase) > 0 {
+		image.FromBase = fromBase[len(fromBase)-1]
+	}
+	if len(toBase) > 0 {
+		image.ToBase = toBase[len(toBase)-1]
+	}
+
+	image.Status = StatusChanged
+	if !slices.Equal(fromBase, toBase) {
+		image.Status = StatusBaseChanged
+	}
+	return image, nil
+}
+
+// baseLayers returns all layers of the image except the top one, which
+// contains the Kubernetes binary.
+func (c *Comparer) baseLayers(ref string) ([]string, error) {
+	layers, err := c.impl.Layers(ref, c.options.Platform)
+	if err != nil {
+		return nil, fmt.Errorf("get layers of %s: %w", ref, err)
+	}
+	if len(layers) == 0 {
+		return nil, nil
+	}
+	return layers[:len(layers)-1], nil
+}
+
+func (c *Comparer) sizeHistory() (*sizereport.History, error) {
+	markerPath, err := layout.Default().MarkerPath(c.options.Bucket, "release", false)
+	if err != nil {
+		return nil, fmt.Errorf("get history path: %w", err)
+	}
+	historyPath := object.GcsPrefix + path.Join(markerPath, sizereport.HistoryFile)
+
+	history := &sizereport.History{}
+	content, err := c.impl.ReadObject(historyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", historyPath, err)
+	}
+	if content == nil {
+		logrus.Infof("No artifact size history found in %s", historyPath)
+		return history, nil
+	}
+	if err := json.Unmarshal(content, history); err != nil {
+		return nil, fmt.Errorf("unmarshal artifact size history: %w", err)
+	}
+	return history, nil
+}
+
+// compareSizes returns the size changes of all artifacts recorded for both
+// releases, sorted by the absolute delta.
+func compareSizes(from, to *sizereport.Sizes) []*Size {
+	res := []*Size{}
+	if from == nil || to == nil {
+		return res
+	}
+
+	fromSizes := map[string]int64{}
+	for _, entry := range from.Artifacts {
+		fromSizes[entry.Kind+"/"+entry.Name] = entry.Size
+	}
+	for _, entry := range to.Artifacts {
+		fromSize, ok := fromSizes[entry.Kind+"/"+entry.Name]
+		if !ok {
+			continue
+		}
+		size := &Size{
+			Name:     entry.Name,
+			Kind:     entry.Kind,
+			FromSize: fromSize,
+			ToSize:   entry.Size,
+			Delta:    entry.Size - fromSize,
+		}
+		if fromSize > 0 {
+			size.Change = float64(size.Delta) / float64(fromSize) * 100
+		}
+		res = append(res, size)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return abs(res[i].Delta) > abs(res[j].Delta)
+	})
+	return res
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasecompare_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/releasecompare"
+	"k8s.io/release/pkg/releasecompare/releasecomparefakes"
+)
+
+var errTest = errors.New("test")
+
+const testHistory = `{"releases": [
+	{"version": "v1.30.2", "artifacts": [
+		{"name": "bin/linux/amd64/kubectl", "kind": "artifact", "size": 1000},
+		{"name": "amd64/kube-apiserver.tar", "kind": "image", "size": 2000}
+	]},
+	{"version": "v1.31.0", "artifacts": [
+		{"name": "bin/linux/amd64/kubectl", "kind": "artifact", "size": 1100},
+		{"name": "amd64/kube-apiserver.tar", "kind": "image", "size": 1000}
+	]}
+]}`
+
+func testImpl() *releasecomparefakes.FakeImpl {
+	mock := &releasecomparefakes.FakeImpl{}
+	mock.ReadObjectCalls(func(gcsPath string) ([]byte, error) {
+		switch {
+		case strings.HasSuffix(gcsPath, "/v1.30.2/SHA256SUMS"):
+			return []byte("aaa  bin/linux/amd64/kubectl\nbbb  bin/linux/s390x/kubectl\n"), nil
+		case strings.HasSuffix(gcsPath, "/v1.31.0/SHA256SUMS"):
+			return []byte("ccc  bin/linux/amd64/kubectl\nddd  bin/linux/riscv64/kubectl"), nil
+		case strings.HasSuffix(gcsPath, "/artifact-sizes.json"):
+			return []byte(testHistory), nil
+		}
+		return nil, nil
+	})
+	mock.DigestCalls(func(ref string) (string, error) {
+		switch ref {
+		case "registry.k8s.io/kube-apiserver:v1.30.2":
+			return "sha256:1111111111111111", nil
+		case "registry.k8s.io/kube-apiserver:v1.31.0":
+			return "sha256:2222222222222222", nil
+		case "registry.k8s.io/kube-proxy:v1.30.2", "registry.k8s.io/kube-proxy:v1.31.0":
+			return "sha256:3333333333333333", nil
+		case "registry.k8s.io/kubectl:v1.31.0":
+			return "sha256:4444444444444444", nil
+		}
+		return "", errTest
+	})
+	mock.LayersCalls(func(ref, _ string) ([]string, error) {
+		if strings.HasSuffix(ref, ":v1.30.2") {
+			return []string{"sha256:base1", "sha256:bin1"}, nil
+		}
+		return []string{"sha256:base2", "sha256:bin2"}, nil
+	})
+	return mock
+}
+
+func TestCompare(t *testing.T) {
+	opts := releasecompare.DefaultOptions()
+	opts.Images = []string{"kube-apiserver", "kube-proxy", "kubectl"}
+	sut := releasecompare.New(opts)
+	sut.SetImpl(testImpl())
+
+	report, err := sut.Compare("1.30.2", "v1.31.0")
+	require.NoError(t, err)
+	require.Equal(t, "v1.30.2", report.From)
+	require.Equal(t, "v1.31.0", report.To)
+
+	require.Equal(t, []string{"bin/linux/riscv64/kubectl"}, report.Artifacts.Added)
+	require.Equal(t, []string{"bin/linux/s390x/kubectl"}, report.Artifacts.Removed)
+
+	require.Len(t, report.Images, 3)
+	require.Equal(t, releasecompare.StatusBaseChanged, report.Images[0].Status)
+	require.Equal(t, "sha256:base1", report.Images[0].FromBase)
+	require.Equal(t, "sha256:base2", report.Images[0].ToBase)
+	require.Equal(t, releasecompare.StatusUnchanged, report.Images[1].Status)
+	require.Equal(t, releasecompare.StatusAdded, report.Images[2].Status)
+	require.Equal(t, "registry.k8s.io/kubectl:v1.31.0", report.Images[2].ToRef)
+
+	require.Len(t, report.Sizes, 2)
+	require.Equal(t, "amd64/kube-apiserver.tar", report.Sizes[0].Name)
+	require.EqualValues(t, -1000, report.Sizes[0].Delta)
+	require.InDelta(t, -50.0, report.Sizes[0].Change, 0.01)
+	require.EqualValues(t, 100, report.Sizes[1].Delta)
+
+	markdown := report.Markdown()
+	require.Contains(t, markdown, "### Changes from v1.30.2 to v1.31.0")
+	require.Contains(t, markdown, "- added: `bin/linux/riscv64/kubectl`")
+	require.Contains(t, markdown, "base image changed")
+	require.Contains(t, markdown, "-50.0%")
+}
+
+func TestCompareFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		from    string
+		prepare func(*releasecomparefakes.FakeImpl)
+	}{
+		{
+			name: "invalid version",
+			from: "invalid",
+		},
+		{
+			name: "missing checksums",
+			from: "v1.29.0",
+		},
+		{
+			name: "read checksums fails",
+			from: "v1.30.2",
+			prepare: func(mock *releasecomparefakes.FakeImpl) {
+				mock.ReadObjectReturns(nil, errTest)
+			},
+		},
+		{
+			name: "layers fail",
+			from: "v1.30.2",
+			prepare: func(mock *releasecomparefakes.FakeImpl) {
+				mock.LayersReturns(nil, errTest)
+			},
+		},
+	} {
+		mock := testImpl()
+		if tc.prepare != nil {
+			tc.prepare(mock)
+		}
+		sut := releasecompare.New(releasecompare.DefaultOptions())
+		sut.SetImpl(mock)
+
+		_, err := sut.Compare(tc.from, "v1.31.0")
+		require.Error(t, err, tc.name)
+	}
+}
+
+func TestCompareWithoutSizes(t *testing.T) {
+	mock := testImpl()
+	readObject := mock.ReadObjectStub
+	mock.ReadObjectCalls(func(gcsPath string) ([]byte, error) {
+		if strings.HasSuffix(gcsPath, "/artifact-sizes.json") {
+			return nil, nil
+		}
+		return readObject(gcsPath)
+	})
+	opts := releasecompare.DefaultOptions()
+	opts.Images = []string{"kube-apiserver"}
+	sut := releasecompare.New(opts)
+	sut.SetImpl(mock)
+
+	report, err := sut.Compare("v1.30.2", "v1.31.0")
+	require.NoError(t, err)
+	require.Empty(t, report.Sizes)
+	require.Contains(t, report.Markdown(), "No artifact sizes recorded")
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		modify  func(*releasecompare.Options)
+		success bool
+	}{
+		{
+			name:    "default",
+			modify:  func(*releasecompare.Options) {},
+			success: true,
+		},
+		{
+			name:   "no bucket",
+			modify: func(o *releasecompare.Options) { o.Bucket = "" },
+		},
+		{
+			name:   "no images",
+			modify: func(o *releasecompare.Options) { o.Images = nil },
+		},
+		{
+			name:   "invalid platform",
+			modify: func(o *releasecompare.Options) { o.Platform = "amd64" },
+		},
+	} {
+		opts := releasecompare.DefaultOptions()
+		tc.modify(opts)
+		err := opts.Validate()
+		if tc.success {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.Error(t, err, tc.name)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reproducible
+
+import (
+	"os"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/command"
+	"sigs.k8s.io/release-utils/hash"
+	"sigs.k8s.io/release-utils/http"
+
+	"k8s.io/release/pkg/gitclone"
+	"k8s.io/release/pkg/workdir"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt reproduciblefakes/fake_impl.go > reproduciblefakes/_fake_impl.go && mv reproduciblefakes/_fake_impl.go reproduciblefakes/fake_impl.go"
+type impl interface {
+	MkdirTemp(pattern string) (string, error)
+	RemoveAll(path string) error
+	CloneRepo(repoPath, owner, repo, ref string) (*git.Repo, error)
+	Checkout(repo *git.Repo, rev string) error
+	Command(workDir string, env []string, cmd string, args ...string) error
+	GetURLResponse(url string) (string, error)
+	SHA256ForFile(path string) (string, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) MkdirTemp(pattern string) (string, error) {
+	return workdir.MkdirTemp(pattern)
+}
+
+func (*defaultImpl) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+func (*defaultImpl) CloneRepo(repoPath, owner, repo, ref string) (*git.Repo, error) {
+	return gitclone.CloneOrOpenGitHubRepo(repoPath, owner, repo, ref, false)
+}
+
+func (*defaultImpl) Checkout(repo *git.Repo, rev string) error {
+	return repo.Checkout(rev)
+}
+
+func (*defaultImpl) Command(workDir string, env []string, cmd string, args ...string) error {
+	return command.NewWithWorkDir(workDir, cmd, args...).Env(env...).RunSuccess()
+}
+
+func (*defaultImpl) GetURLResponse(url string) (string, error) {
+	return http.GetURLResponse(url, true)
+}
+
+func (*defaultImpl) SHA256ForFile(path string) (string, error) {
+	return hash.SHA256ForFile(path)
+}
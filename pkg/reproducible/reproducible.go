@@ -0,0 +1,283 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reproducible
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// DefaultBinaries are the binaries which get verified per default.
+var DefaultBinaries = []string{"kubectl", "kubeadm", "kubelet"}
+
+// DefaultPlatforms are the platforms which get verified per default.
+var DefaultPlatforms = []string{"linux/amd64"}
+
+// dockerizedBinDir is the output directory of containerized Kubernetes builds.
+var dockerizedBinDir = filepath.Join(release.BuildDir, "dockerized", "bin")
+
+// Options are the main options for verifying reproducible builds.
+type Options struct {
+	// Version is the release tag to be rebuilt, for example v1.29.1.
+	Version string
+
+	// Binaries are the names of the binaries to be verified.
+	Binaries []string
+
+	// Platforms are the os/arch pairs to be verified.
+	Platforms []string
+
+	// GitHubOrg is the GitHub organization of the Kubernetes repository.
+	GitHubOrg string
+
+	// GitHubRepo is the GitHub repository of Kubernetes.
+	GitHubRepo string
+
+	// BaseURL is the location of the published release artifacts.
+	BaseURL string
+
+	// WorkDir is the directory used for building. A temporary directory will
+	// be created and removed afterwards if not set.
+	WorkDir string
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Binaries:   DefaultBinaries,
+		Platforms:  DefaultPlatforms,
+		GitHubOrg:  git.DefaultGithubOrg,
+		GitHubRepo: git.DefaultGithubRepo,
+		BaseURL:    release.ProductionBucketURL + "/release",
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if _, err := util.TagStringToSemver(o.Version); err != nil {
+		return fmt.Errorf("invalid version %q: %w", o.Version, err)
+	}
+	if len(o.Binaries) == 0 {
+		return errors.New("no binaries specified")
+	}
+	if len(o.Platforms) == 0 {
+		return errors.New("no platforms specified")
+	}
+	for _, platform := range o.Platforms {
+		if parts := strings.Split(platform, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid platform %q, expected os/arch", platform)
+		}
+	}
+	return nil
+}
+
+// Result is the verification result of a single artifact.
+type Result struct {
+	// Artifact is the file name of the artifact.
+	Artifact string
+
+	// Platform is the os/arch pair of the artifact.
+	Platform string
+
+	// Published is the SHA256 digest of the published artifact.
+	Published string
+
+	// Rebuilt is the SHA256 digest of the locally rebuilt artifact.
+	Rebuilt string
+}
+
+// Reproducible returns true if the rebuilt artifact matches the published
+// one bit-for-bit.
+func (r *Result) Reproducible() bool {
+	return r.Published != "" && r.Published == r.Rebuilt
+}
+
+// Verifier is the main structure for verifying reproducible builds.
+type Verifier struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Verifier instance.
+func New(opts *Options) *Verifier {
+	return &Verifier{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (v *Verifier) SetImpl(impl impl) {
+	v.impl = impl
+}
+
+// Run rebuilds the artifacts, prints the report and returns an error if any
+// of them diverge from the published artifacts.
+func (v *Verifier) Run() error {
+	results, err := v.Verify()
+	if err != nil {
+		return err
+	}
+
+	printReport(os.Stdout, results)
+
+	divergent := 0
+	for _, r := range results {
+		if !r.Reproducible() {
+			divergent++
+		}
+	}
+	if divergent > 0 {
+		return fmt.Errorf("%d of %d artifacts are not reproducible", divergent, len(results))
+	}
+
+	logrus.Infof("All %d artifacts are reproducible", len(results))
+	return nil
+}
+
+// Verify rebuilds the selected artifacts from the release tag in a clean
+// checkout and compares their digests against the published artifacts.
+func (v *Verifier) Verify() (results []*Result, err error) {
+	if err := v.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+	version := util.AddTagPrefix(v.options.Version)
+
+	workDir := v.options.WorkDir
+	if workDir == "" {
+		workDir, err = v.impl.MkdirTemp("k8s-reproducible-")
+		if err != nil {
+			return nil, fmt.Errorf("create work directory: %w", err)
+		}
+		defer func() {
+			if err := v.impl.RemoveAll(workDir); err != nil {
+				logrus.Warnf("Unable to remove work directory %s: %v", workDir, err)
+			}
+		}()
+	}
+
+	logrus.Infof("Cloning %s/%s into %s", v.options.GitHubOrg, v.options.GitHubRepo, workDir)
+	repo, err := v.impl.CloneRepo(workDir, v.options.GitHubOrg, v.options.GitHubRepo, version)
+	if err != nil {
+		return nil, fmt.Errorf("clone repository: %w", err)
+	}
+
+	logrus.Infof("Checking out %s", version)
+	if err := v.impl.Checkout(repo, version); err != nil {
+		return nil, fmt.Errorf("checkout %s: %w", version, err)
+	}
+
+	what := []string{}
+	for _, bin := range v.options.Binaries {
+		what = append(what, "cmd/"+bin)
+	}
+
+	logrus.Infof("Rebuilding %v for %v", v.options.Binaries, v.options.Platforms)
+	if err := v.impl.Command(
+		workDir,
+		[]string{"KUBE_DOCKER_IMAGE_TAG=" + version},
+		filepath.Join("build", "run.sh"),
+		"make", "all",
+		"WHAT="+strings.Join(what, " "),
+		"KUBE_BUILD_PLATFORMS="+strings.Join(v.options.Platforms, " "),
+	); err != nil {
+		return nil, fmt.Errorf("build %s: %w", version, err)
+	}
+
+	for _, platform := range v.options.Platforms {
+		for _, bin := range v.options.Binaries {
+			result, err := v.verifyArtifact(workDir, version, platform, bin)
+			if err != nil {
+				return nil, fmt.Errorf("verify %s for %s: %w", bin, platform, err)
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+func (v *Verifier) verifyArtifact(workDir, version, platform, bin string) (*Result, error) {
+	if strings.HasPrefix(platform, "windows/") {
+		bin += ".exe"
+	}
+
+	published, err := v.impl.GetURLResponse(fmt.Sprintf(
+		"%s/%s/bin/%s/%s.sha256",
+		strings.TrimSuffix(v.options.BaseURL, "/"), version, platform, bin,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("get published digest: %w", err)
+	}
+
+	rebuilt, err := v.impl.SHA256ForFile(
+		filepath.Join(workDir, dockerizedBinDir, platform, bin),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get rebuilt digest: %w", err)
+	}
+
+	// The published file may contain the file name after the digest
+	if fields := strings.Fields(published); len(fields) > 0 {
+		published = fields[0]
+	}
+
+	result := &Result{
+		Artifact:  bin,
+		Platform:  platform,
+		Published: published,
+		Rebuilt:   rebuilt,
+	}
+	if result.Reproducible() {
+		logrus.Infof("Artifact %s for %s is reproducible", bin, platform)
+	} else {
+		logrus.Warnf(
+			"Artifact %s for %s diverges: published %s, rebuilt %s",
+			bin, platform, published, rebuilt,
+		)
+	}
+	return result, nil
+}
+
+func printReport(w io.Writer, results []*Result) {
+	table := tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Artifact", "Platform", "Published SHA256", "Rebuilt SHA256", "Reproducible"})
+	for _, r := range results {
+		reproducible := "Yes"
+		if !r.Reproducible() {
+			reproducible = "No"
+		}
+		table.Append([]string{r.Artifact, r.Platform, r.Published, r.Rebuilt, reproducible})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reproducible
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/reproducible/reproduciblefakes"
+)
+
+var errTest = errors.New("test")
+
+func testOptions() *Options {
+	opts := DefaultOptions()
+	opts.Version = "v1.29.1"
+	opts.Binaries = []string{"kubectl"}
+	opts.Platforms = []string{"linux/amd64", "windows/amd64"}
+	return opts
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		modify      func(*Options)
+		shouldError bool
+	}{
+		{ // success
+			modify:      func(*Options) {},
+			shouldError: false,
+		},
+		{ // invalid version
+			modify:      func(o *Options) { o.Version = "wrong" },
+			shouldError: true,
+		},
+		{ // no binaries
+			modify:      func(o *Options) { o.Binaries = nil },
+			shouldError: true,
+		},
+		{ // no platforms
+			modify:      func(o *Options) { o.Platforms = nil },
+			shouldError: true,
+		},
+		{ // invalid platform
+			modify:      func(o *Options) { o.Platforms = []string{"linux"} },
+			shouldError: true,
+		},
+	} {
+		opts := testOptions()
+		tc.modify(opts)
+		err := opts.Validate()
+		if tc.shouldError {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		prepare func(*reproduciblefakes.FakeImpl, *Options)
+		assert  func(*reproduciblefakes.FakeImpl, []*Result, error)
+	}{
+		{ // success reproducible
+			prepare: func(mock *reproduciblefakes.FakeImpl, _ *Options) {
+				mock.MkdirTempReturns("/tmp/work", nil)
+				mock.GetURLResponseReturns("abc  kubectl", nil)
+				mock.SHA256ForFileReturns("abc", nil)
+			},
+			assert: func(mock *reproduciblefakes.FakeImpl, res []*Result, err error) {
+				require.NoError(t, err)
+				require.Len(t, res, 2)
+				for _, r := range res {
+					require.True(t, r.Reproducible())
+				}
+				require.Equal(t, "kubectl.exe", res[1].Artifact)
+
+				_, _, _, ref := mock.CloneRepoArgsForCall(0)
+				require.Equal(t, "v1.29.1", ref)
+
+				_, tag := mock.CheckoutArgsForCall(0)
+				require.Equal(t, "v1.29.1", tag)
+
+				workDir, env, cmd, args := mock.CommandArgsForCall(0)
+				require.Equal(t, "/tmp/work", workDir)
+				require.Equal(t, []string{"KUBE_DOCKER_IMAGE_TAG=v1.29.1"}, env)
+				require.Equal(t, filepath.Join("build", "run.sh"), cmd)
+				require.Contains(t, args, "WHAT=cmd/kubectl")
+				require.Contains(t, args, "KUBE_BUILD_PLATFORMS=linux/amd64 windows/amd64")
+
+				require.Equal(t,
+					"https://dl.k8s.io/release/v1.29.1/bin/windows/amd64/kubectl.exe.sha256",
+					mock.GetURLResponseArgsForCall(1),
+				)
+				require.Equal(t,
+					filepath.Join("/tmp/work", "_output", "dockerized", "bin", "linux", "amd64", "kubectl"),
+					mock.SHA256ForFileArgsForCall(0),
+				)
+				require.Equal(t, 1, mock.RemoveAllCallCount())
+			},
+		},
+		{ // success divergent
+			prepare: func(mock *reproduciblefakes.FakeImpl, _ *Options) {
+				mock.GetURLResponseReturns("abc", nil)
+				mock.SHA256ForFileReturnsOnCall(0, "abc", nil)
+				mock.SHA256ForFileReturnsOnCall(1, "def", nil)
+			},
+			assert: func(mock *reproduciblefakes.FakeImpl, res []*Result, err error) {
+				require.NoError(t, err)
+				require.Len(t, res, 2)
+				require.True(t, res[0].Reproducible())
+				require.False(t, res[1].Reproducible())
+			},
+		},
+		{ // success custom work dir
+			prepare: func(mock *reproduciblefakes.FakeImpl, opts *Options) {
+				opts.WorkDir = "/custom"
+			},
+			assert: func(mock *reproduciblefakes.FakeImpl, res []*Result, err error) {
+				require.NoError(t, err)
+				require.Zero(t, mock.MkdirTempCallCount())
+				require.Zero(t, mock.RemoveAllCallCount())
+				workDir, _, _, _ := mock.CommandArgsForCall(0)
+				require.Equal(t, "/custom", workDir)
+			},
+		},
+		{ // failure invalid options
+			prepare: func(mock *reproduciblefakes.FakeImpl, opts *Options) {
+				opts.Version = ""
+			},
+			assert: func(mock *reproduciblefakes.FakeImpl, res []*Result, err error) {
+				require.Error(t, err)
+				require.Zero(t, mock.CloneRepoCallCount())
+			},
+		},
+		{ // failure on MkdirTemp
+			prepare: func(mock *reproduciblefakes.FakeImpl, _ *Options) {
+				mock.MkdirTempReturns("", errTest)
+			},
+			assert: func(mock *reproduciblefakes.FakeImpl, res []*Result, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure on CloneRepo
+			prepare: func(mock *reproduciblefakes.FakeImpl, _ *Options) {
+				mock.CloneRepoReturns(nil, errTest)
+			},
+			assert: func(mock *reproduciblefakes.FakeImpl, res []*Result, err error) {
+				require.Error(t, err)
+				require.Equal(t, 1, mock.RemoveAllCallCount())
+			},
+		},
+		{ // failure on Checkout
+			prepare: func(mock *reproduciblefakes.FakeImpl, _ *Options) {
+				mock.CheckoutReturns(errTest)
+			},
+			assert: func(mock *reproduciblefakes.FakeImpl, res []*Result, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure on Command
+			prepare: func(mock *reproduciblefakes.FakeImpl, _ *Options) {
+				mock.CommandReturns(errTest)
+			},
+			assert: func(mock *reproduciblefakes.FakeImpl, res []*Result, err error) {
+				require.Error(t, err)
+				require.Zero(t, mock.GetURLResponseCallCount())
+			},
+		},
+		{ // failure on GetURLResponse
+			prepare: func(mock *reproduciblefakes.FakeImpl, _ *Options) {
+				mock.GetURLResponseReturns("", errTest)
+			},
+			assert: func(mock *reproduciblefakes.FakeImpl, res []*Result, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure on SHA256ForFile
+			prepare: func(mock *reproduciblefakes.FakeImpl, _ *Options) {
+				mock.SHA256ForFileReturns("", errTest)
+			},
+			assert: func(mock *reproduciblefakes.FakeImpl, res []*Result, err error) {
+				require.Error(t, err)
+			},
+		},
+	} {
+		mock := &reproduciblefakes.FakeImpl{}
+		opts := testOptions()
+		tc.prepare(mock, opts)
+
+		sut := New(opts)
+		sut.SetImpl(mock)
+		res, err := sut.Verify()
+		tc.assert(mock, res, err)
+	}
+}
+
+func TestPrintReport(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	printReport(buf, []*Result{
+		{Artifact: "kubectl", Platform: "linux/amd64", Published: "abc", Rebuilt: "abc"},
+		{Artifact: "kubelet", Platform: "linux/amd64", Published: "abc", Rebuilt: "def"},
+	})
+	out := buf.String()
+	require.Contains(t, out, "REPRODUCIBLE")
+	require.Contains(t, out, "| kubectl  | linux/amd64 | abc              | abc            | Yes          |")
+	require.Contains(t, out, "| kubelet  | linux/amd64 | abc              | def            | No           |")
+}
@@ -0,0 +1,608 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package reproduciblefakes
+
+import (
+	"sync"
+
+	"sigs.k8s.io/release-sdk/git"
+)
+
+type FakeImpl struct {
+	CheckoutStub        func(*git.Repo, string) error
+	checkoutMutex       sync.RWMutex
+	checkoutArgsForCall []struct {
+		arg1 *git.Repo
+		arg2 string
+	}
+	checkoutReturns struct {
+		result1 error
+	}
+	checkoutReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CloneRepoStub        func(string, string, string, string) (*git.Repo, error)
+	cloneRepoMutex       sync.RWMutex
+	cloneRepoArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	cloneRepoReturns struct {
+		result1 *git.Repo
+		result2 error
+	}
+	cloneRepoReturnsOnCall map[int]struct {
+		result1 *git.Repo
+		result2 error
+	}
+	CommandStub        func(string, []string, string, ...string) error
+	commandMutex       sync.RWMutex
+	commandArgsForCall []struct {
+		arg1 string
+		arg2 []string
+		arg3 string
+		arg4 []string
+	}
+	commandReturns struct {
+		result1 error
+	}
+	commandReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetURLResponseStub        func(string) (string, error)
+	getURLResponseMutex       sync.RWMutex
+	getURLResponseArgsForCall []struct {
+		arg1 string
+	}
+	getURLResponseReturns struct {
+		result1 string
+		result2 error
+	}
+	getURLResponseReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	MkdirTempStub        func(string) (string, error)
+	mkdirTempMutex       sync.RWMutex
+	mkdirTempArgsForCall []struct {
+		arg1 string
+	}
+	mkdirTempReturns struct {
+		result1 string
+		result2 error
+	}
+	mkdirTempReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	RemoveAllStub        func(string) error
+	removeAllMutex       sync.RWMutex
+	removeAllArgsForCall []struct {
+		arg1 string
+	}
+	removeAllReturns struct {
+		result1 error
+	}
+	removeAllReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SHA256ForFileStub        func(string) (string, error)
+	sHA256ForFileMutex       sync.RWMutex
+	sHA256ForFileArgsForCall []struct {
+		arg1 string
+	}
+	sHA256ForFileReturns struct {
+		result1 string
+		result2 error
+	}
+	sHA256ForFileReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) Checkout(arg1 *git.Repo, arg2 string) error {
+	fake.checkoutMutex.Lock()
+	ret, specificReturn := fake.checkoutReturnsOnCall[len(fake.checkoutArgsForCall)]
+	fake.checkoutArgsForCall = append(fake.checkoutArgsForCall, struct {
+		arg1 *git.Repo
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CheckoutStub
+	fakeReturns := fake.checkoutReturns
+	fake.recordInvocation("Checkout", []interface{}{arg1, arg2})
+	fake.checkoutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CheckoutCallCount() int {
+	fake.checkoutMutex.RLock()
+	defer fake.checkoutMutex.RUnlock()
+	return len(fake.checkoutArgsForCall)
+}
+
+func (fake *FakeImpl) CheckoutCalls(stub func(*git.Repo, string) error) {
+	fake.checkoutMutex.Lock()
+	defer fake.checkoutMutex.Unlock()
+	fake.CheckoutStub = stub
+}
+
+func (fake *FakeImpl) CheckoutArgsForCall(i int) (*git.Repo, string) {
+	fake.checkoutMutex.RLock()
+	defer fake.checkoutMutex.RUnlock()
+	argsForCall := fake.checkoutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) CheckoutReturns(result1 error) {
+	fake.checkoutMutex.Lock()
+	defer fake.checkoutMutex.Unlock()
+	fake.CheckoutStub = nil
+	fake.checkoutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CheckoutReturnsOnCall(i int, result1 error) {
+	fake.checkoutMutex.Lock()
+	defer fake.checkoutMutex.Unlock()
+	fake.CheckoutStub = nil
+	if fake.checkoutReturnsOnCall == nil {
+		fake.checkoutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkoutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CloneRepo(arg1 string, arg2 string, arg3 string, arg4 string) (*git.Repo, error) {
+	fake.cloneRepoMutex.Lock()
+	ret, specificReturn := fake.cloneRepoReturnsOnCall[len(fake.cloneRepoArgsForCall)]
+	fake.cloneRepoArgsForCall = append(fake.cloneRepoArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CloneRepoStub
+	fakeReturns := fake.cloneRepoReturns
+	fake.recordInvocation("CloneRepo", []interface{}{arg1, arg2, arg3, arg4})
+	fake.cloneRepoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) CloneRepoCallCount() int {
+	fake.cloneRepoMutex.RLock()
+	defer fake.cloneRepoMutex.RUnlock()
+	return len(fake.cloneRepoArgsForCall)
+}
+
+func (fake *FakeImpl) CloneRepoCalls(stub func(string, string, string, string) (*git.Repo, error)) {
+	fake.cloneRepoMutex.Lock()
+	defer fake.cloneRepoMutex.Unlock()
+	fake.CloneRepoStub = stub
+}
+
+func (fake *FakeImpl) CloneRepoArgsForCall(i int) (string, string, string, string) {
+	fake.cloneRepoMutex.RLock()
+	defer fake.cloneRepoMutex.RUnlock()
+	argsForCall := fake.cloneRepoArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) CloneRepoReturns(result1 *git.Repo, result2 error) {
+	fake.cloneRepoMutex.Lock()
+	defer fake.cloneRepoMutex.Unlock()
+	fake.CloneRepoStub = nil
+	fake.cloneRepoReturns = struct {
+		result1 *git.Repo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) CloneRepoReturnsOnCall(i int, result1 *git.Repo, result2 error) {
+	fake.cloneRepoMutex.Lock()
+	defer fake.cloneRepoMutex.Unlock()
+	fake.CloneRepoStub = nil
+	if fake.cloneRepoReturnsOnCall == nil {
+		fake.cloneRepoReturnsOnCall = make(map[int]struct {
+			result1 *git.Repo
+			result2 error
+		})
+	}
+	fake.cloneRepoReturnsOnCall[i] = struct {
+		result1 *git.Repo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Command(arg1 string, arg2 []string, arg3 string, arg4 ...string) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.commandMutex.Lock()
+	ret, specificReturn := fake.commandReturnsOnCall[len(fake.commandArgsForCall)]
+	fake.commandArgsForCall = append(fake.commandArgsForCall, struct {
+		arg1 string
+		arg2 []string
+		arg3 string
+		arg4 []string
+	}{arg1, arg2Copy, arg3, arg4})
+	stub := fake.CommandStub
+	fakeReturns := fake.commandReturns
+	fake.recordInvocation("Command", []interface{}{arg1, arg2Copy, arg3, arg4})
+	fake.commandMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CommandCallCount() int {
+	fake.commandMutex.RLock()
+	defer fake.commandMutex.RUnlock()
+	return len(fake.commandArgsForCall)
+}
+
+func (fake *FakeImpl) CommandCalls(stub func(string, []string, string, ...string) error) {
+	fake.commandMutex.Lock()
+	defer fake.commandMutex.Unlock()
+	fake.CommandStub = stub
+}
+
+func (fake *FakeImpl) CommandArgsForCall(i int) (string, []string, string, []string) {
+	fake.commandMutex.RLock()
+	defer fake.commandMutex.RUnlock()
+	argsForCall := fake.commandArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) CommandReturns(result1 error) {
+	fake.commandMutex.Lock()
+	defer fake.commandMutex.Unlock()
+	fake.CommandStub = nil
+	fake.commandReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CommandReturnsOnCall(i int, result1 error) {
+	fake.commandMutex.Lock()
+	defer fake.commandMutex.Unlock()
+	fake.CommandStub = nil
+	if fake.commandReturnsOnCall == nil {
+		fake.commandReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.commandReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) GetURLResponse(arg1 string) (string, error) {
+	fake.getURLResponseMutex.Lock()
+	ret, specificReturn := fake.getURLResponseReturnsOnCall[len(fake.getURLResponseArgsForCall)]
+	fake.getURLResponseArgsForCall = append(fake.getURLResponseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetURLResponseStub
+	fakeReturns := fake.getURLResponseReturns
+	fake.recordInvocation("GetURLResponse", []interface{}{arg1})
+	fake.getURLResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GetURLResponseCallCount() int {
+	fake.getURLResponseMutex.RLock()
+	defer fake.getURLResponseMutex.RUnlock()
+	return len(fake.getURLResponseArgsForCall)
+}
+
+func (fake *FakeImpl) GetURLResponseCalls(stub func(string) (string, error)) {
+	fake.getURLResponseMutex.Lock()
+	defer fake.getURLResponseMutex.Unlock()
+	fake.GetURLResponseStub = stub
+}
+
+func (fake *FakeImpl) GetURLResponseArgsForCall(i int) string {
+	fake.getURLResponseMutex.RLock()
+	defer fake.getURLResponseMutex.RUnlock()
+	argsForCall := fake.getURLResponseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) GetURLResponseReturns(result1 string, result2 error) {
+	fake.getURLResponseMutex.Lock()
+	defer fake.getURLResponseMutex.Unlock()
+	fake.GetURLResponseStub = nil
+	fake.getURLResponseReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GetURLResponseReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getURLResponseMutex.Lock()
+	defer fake.getURLResponseMutex.Unlock()
+	fake.GetURLResponseStub = nil
+	if fake.getURLResponseReturnsOnCall == nil {
+		fake.getURLResponseReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getURLResponseReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) MkdirTemp(arg1 string) (string, error) {
+	fake.mkdirTempMutex.Lock()
+	ret, specificReturn := fake.mkdirTempReturnsOnCall[len(fake.mkdirTempArgsForCall)]
+	fake.mkdirTempArgsForCall = append(fake.mkdirTempArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.MkdirTempStub
+	fakeReturns := fake.mkdirTempReturns
+	fake.recordInvocation("MkdirTemp", []interface{}{arg1})
+	fake.mkdirTempMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) MkdirTempCallCount() int {
+	fake.mkdirTempMutex.RLock()
+	defer fake.mkdirTempMutex.RUnlock()
+	return len(fake.mkdirTempArgsForCall)
+}
+
+func (fake *FakeImpl) MkdirTempCalls(stub func(string) (string, error)) {
+	fake.mkdirTempMutex.Lock()
+	defer fake.mkdirTempMutex.Unlock()
+	fake.MkdirTempStub = stub
+}
+
+func (fake *FakeImpl) MkdirTempArgsForCall(i int) string {
+	fake.mkdirTempMutex.RLock()
+	defer fake.mkdirTempMutex.RUnlock()
+	argsForCall := fake.mkdirTempArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) MkdirTempReturns(result1 string, result2 error) {
+	fake.mkdirTempMutex.Lock()
+	defer fake.mkdirTempMutex.Unlock()
+	fake.MkdirTempStub = nil
+	fake.mkdirTempReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) MkdirTempReturnsOnCall(i int, result1 string, result2 error) {
+	fake.mkdirTempMutex.Lock()
+	defer fake.mkdirTempMutex.Unlock()
+	fake.MkdirTempStub = nil
+	if fake.mkdirTempReturnsOnCall == nil {
+		fake.mkdirTempReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.mkdirTempReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) RemoveAll(arg1 string) error {
+	fake.removeAllMutex.Lock()
+	ret, specificReturn := fake.removeAllReturnsOnCall[len(fake.removeAllArgsForCall)]
+	fake.removeAllArgsForCall = append(fake.removeAllArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RemoveAllStub
+	fakeReturns := fake.removeAllReturns
+	fake.recordInvocation("RemoveAll", []interface{}{arg1})
+	fake.removeAllMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) RemoveAllCallCount() int {
+	fake.removeAllMutex.RLock()
+	defer fake.removeAllMutex.RUnlock()
+	return len(fake.removeAllArgsForCall)
+}
+
+func (fake *FakeImpl) RemoveAllCalls(stub func(string) error) {
+	fake.removeAllMutex.Lock()
+	defer fake.removeAllMutex.Unlock()
+	fake.RemoveAllStub = stub
+}
+
+func (fake *FakeImpl) RemoveAllArgsForCall(i int) string {
+	fake.removeAllMutex.RLock()
+	defer fake.removeAllMutex.RUnlock()
+	argsForCall := fake.removeAllArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) RemoveAllReturns(result1 error) {
+	fake.removeAllMutex.Lock()
+	defer fake.removeAllMutex.Unlock()
+	fake.RemoveAllStub = nil
+	fake.removeAllReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) RemoveAllReturnsOnCall(i int, result1 error) {
+	fake.removeAllMutex.Lock()
+	defer fake.removeAllMutex.Unlock()
+	fake.RemoveAllStub = nil
+	if fake.removeAllReturnsOnCall == nil {
+		fake.removeAllReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeAllReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) SHA256ForFile(arg1 string) (string, error) {
+	fake.sHA256ForFileMutex.Lock()
+	ret, specificReturn := fake.sHA256ForFileReturnsOnCall[len(fake.sHA256ForFileArgsForCall)]
+	fake.sHA256ForFileArgsForCall = append(fake.sHA256ForFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SHA256ForFileStub
+	fakeReturns := fake.sHA256ForFileReturns
+	fake.recordInvocation("SHA256ForFile", []interface{}{arg1})
+	fake.sHA256ForFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) SHA256ForFileCallCount() int {
+	fake.sHA256ForFileMutex.RLock()
+	defer fake.sHA256ForFileMutex.RUnlock()
+	return len(fake.sHA256ForFileArgsForCall)
+}
+
+func (fake *FakeImpl) SHA256ForFileCalls(stub func(string) (string, error)) {
+	fake.sHA256ForFileMutex.Lock()
+	defer fake.sHA256ForFileMutex.Unlock()
+	fake.SHA256ForFileStub = stub
+}
+
+func (fake *FakeImpl) SHA256ForFileArgsForCall(i int) string {
+	fake.sHA256ForFileMutex.RLock()
+	defer fake.sHA256ForFileMutex.RUnlock()
+	argsForCall := fake.sHA256ForFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) SHA256ForFileReturns(result1 string, result2 error) {
+	fake.sHA256ForFileMutex.Lock()
+	defer fake.sHA256ForFileMutex.Unlock()
+	fake.SHA256ForFileStub = nil
+	fake.sHA256ForFileReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) SHA256ForFileReturnsOnCall(i int, result1 string, result2 error) {
+	fake.sHA256ForFileMutex.Lock()
+	defer fake.sHA256ForFileMutex.Unlock()
+	fake.SHA256ForFileStub = nil
+	if fake.sHA256ForFileReturnsOnCall == nil {
+		fake.sHA256ForFileReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.sHA256ForFileReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.checkoutMutex.RLock()
+	defer fake.checkoutMutex.RUnlock()
+	fake.cloneRepoMutex.RLock()
+	defer fake.cloneRepoMutex.RUnlock()
+	fake.commandMutex.RLock()
+	defer fake.commandMutex.RUnlock()
+	fake.getURLResponseMutex.RLock()
+	defer fake.getURLResponseMutex.RUnlock()
+	fake.mkdirTempMutex.RLock()
+	defer fake.mkdirTempMutex.RUnlock()
+	fake.removeAllMutex.RLock()
+	defer fake.removeAllMutex.RUnlock()
+	fake.sHA256ForFileMutex.RLock()
+	defer fake.sHA256ForFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBreakerThreshold is the number of consecutive retryable failures
+	// after which the circuit breaker of a service opens.
+	DefaultBreakerThreshold = 10
+
+	// DefaultBreakerCooldown is the time an open circuit breaker rejects
+	// calls before letting a trial call pass.
+	DefaultBreakerCooldown = time.Minute
+)
+
+// ErrCircuitOpen is returned if the circuit breaker of a service is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+var (
+	breakersMu sync.Mutex
+	breakers   = map[string]*Breaker{}
+)
+
+// BreakerFor returns the circuit breaker of the provided service, which gets
+// created with the default threshold and cooldown on first use.
+func BreakerFor(service string) *Breaker {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	breaker, ok := breakers[service]
+	if !ok {
+		breaker = NewBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown)
+		breakers[service] = breaker
+	}
+	return breaker
+}
+
+// ResetBreakers removes all circuit breakers of the services.
+func ResetBreakers() {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+	breakers = map[string]*Breaker{}
+}
+
+// Breaker is a circuit breaker which opens after a number of consecutive
+// failures and lets a single trial call pass after the cooldown.
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	trial     bool
+}
+
+// NewBreaker creates a new closed circuit breaker.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow returns ErrCircuitOpen if calls are currently rejected.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return nil
+	}
+	if b.trial || time.Since(b.openedAt) < b.cooldown {
+		return ErrCircuitOpen
+	}
+	b.trial = true
+	return nil
+}
+
+// Success closes the circuit breaker.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.trial = false
+}
+
+// Failure records a failed call and opens the circuit breaker once the
+// threshold is reached or when the trial call failed.
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.trial || b.failures == b.threshold {
+		b.openedAt = time.Now()
+	}
+	if b.failures > b.threshold {
+		b.failures = b.threshold
+	}
+	b.trial = false
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package retry provides the shared retry policy of the release tooling,
+// including the classification of retryable errors and circuit breaking per
+// external service.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/googleapi"
+)
+
+// The external services which have their own circuit breaker.
+const (
+	ServiceGitHub   = "github"
+	ServiceGCS      = "gcs"
+	ServiceRegistry = "registry"
+	ServiceOBS      = "obs"
+	ServiceTSA      = "tsa"
+)
+
+// rateLimitWait is the time to wait if a rate limit does not indicate when
+// to retry.
+const rateLimitWait = time.Minute
+
+// transientMessages are parts of error messages indicating a temporary
+// failure. They are required for errors of invoked tools like gsutil, osc
+// and docker, which only provide their output.
+var transientMessages = []string{
+	"request canceled while waiting for connection",
+	"connection reset by peer",
+	"connection refused",
+	"broken pipe",
+	"i/o timeout",
+	"tls handshake timeout",
+	"unexpected eof",
+	"too many requests",
+	"secondary rate limit",
+	"internal server error",
+	"bad gateway",
+	"service unavailable",
+	"gateway timeout",
+}
+
+// Classifier decides if an error is retryable. A positive wait overrides the
+// backoff delay of the policy, for example to honor rate limits.
+type Classifier func(err error) (retryable bool, wait time.Duration)
+
+// Policy describes how often and how fast an operation gets retried.
+type Policy struct {
+	// Attempts is the maximum number of attempts, including the first one.
+	Attempts int
+
+	// InitialDelay is the delay after the first failed attempt.
+	InitialDelay time.Duration
+
+	// MaxDelay caps the backoff delay.
+	MaxDelay time.Duration
+
+	// Factor is the multiplier of the delay for every further attempt.
+	Factor float64
+
+	// Jitter is the fraction by which the delay gets randomized.
+	Jitter float64
+
+	// Classify decides if an error is retryable, defaults to Classify.
+	Classify Classifier
+}
+
+// DefaultPolicy returns the default policy used by Do.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Attempts:     5,
+		InitialDelay: time.Second,
+		MaxDelay:     time.Minute,
+		Factor:       2,
+		Jitter:       0.2,
+		Classify:     Classify,
+	}
+}
+
+// Do runs fn for the provided service with the default policy.
+func Do(ctx context.Context, service string, fn func() error) error {
+	return DefaultPolicy().Do(ctx, service, fn)
+}
+
+// Do runs fn until it succeeds, returns a non retryable error or the
+// attempts are exhausted. Retryable failures count towards the circuit
+// breaker of the service, which rejects calls with ErrCircuitOpen while
+// being open.
+func (p *Policy) Do(ctx context.Context, service string, fn func() error) error {
+	classify := p.Classify
+	if classify == nil {
+		classify = Classify
+	}
+	breaker := BreakerFor(service)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err := breaker.Allow(); err != nil {
+			return fmt.Errorf("%s: %w", service, err)
+		}
+
+		err = fn()
+		if err == nil {
+			breaker.Success()
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+
+		retryable, wait := classify(err)
+		if !retryable {
+			return err
+		}
+
+		// GitHub rate limits are expected for large API consumers like the
+		// release notes gathering, which is why they neither count as failed
+		// attempts nor towards the circuit breaker.
+		if service == ServiceGitHub && RateLimited(err) {
+			if wait <= 0 {
+				wait = rateLimitWait
+			}
+			wait = p.jitter(wait)
+			logrus.Warnf(
+				"Hit the rate limit of %s, retrying in %v: %v",
+				service, wait.Round(time.Second), err,
+			)
+			if sleepErr := sleep(ctx, wait); sleepErr != nil {
+				return fmt.Errorf("%w: %w", sleepErr, err)
+			}
+			attempt--
+			continue
+		}
+		breaker.Failure()
+
+		if attempt >= p.Attempts {
+			if attempt == 1 {
+				return err
+			}
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		if wait <= 0 {
+			wait = p.backoff(attempt)
+		}
+		wait = p.jitter(wait)
+		logrus.Warnf(
+			"Attempt %d/%d for %s failed, retrying in %v: %v",
+			attempt, p.Attempts, service, wait.Round(time.Millisecond), err,
+		)
+
+		if sleepErr := sleep(ctx, wait); sleepErr != nil {
+			return fmt.Errorf("%w: %w", sleepErr, err)
+		}
+	}
+}
+
+// sleep waits for the provided duration or until the context is done.
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// backoff returns the backoff delay after the provided attempt.
+func (p *Policy) backoff(attempt int) time.Duration {
+	factor := p.Factor
+	if factor < 1 {
+		factor = 1
+	}
+	delay := float64(p.InitialDelay) * math.Pow(factor, float64(attempt-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	return time.Duration(delay)
+}
+
+// jitter randomizes the delay to avoid that concurrent callers retry at the
+// same time.
+func (p *Policy) jitter(delay time.Duration) time.Duration {
+	if p.Jitter <= 0 {
+		return delay
+	}
+	return delay + time.Duration(float64(delay)*p.Jitter*(2*rand.Float64()-1)) //nolint:gosec // no crypto required
+}
+
+// Classify is the default Classifier. It treats network errors, server side
+// HTTP errors and rate limits of GitHub, Google Cloud and container
+// registries as retryable.
+func Classify(err error) (retryable bool, wait time.Duration) {
+	if err == nil ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ErrCircuitOpen) {
+		return false, 0
+	}
+
+	var (
+		rateLimitErr *gogithub.RateLimitError
+		abuseErr     *gogithub.AbuseRateLimitError
+		githubErr    *gogithub.ErrorResponse
+		googleErr    *googleapi.Error
+		registryErr  *transport.Error
+		netErr       net.Error
+	)
+	switch {
+	case errors.As(err, &rateLimitErr):
+		return true, time.Until(rateLimitErr.Rate.Reset.Time)
+	case errors.As(err, &abuseErr):
+		if abuseErr.RetryAfter != nil {
+			return true, *abuseErr.RetryAfter
+		}
+		return true, rateLimitWait
+	case errors.As(err, &githubErr) && githubErr.Response != nil:
+		if RateLimited(err) {
+			if wait := rateLimitRetryAfter(githubErr.Response.Header); wait > 0 {
+				return true, wait
+			}
+			return true, rateLimitWait
+		}
+		return retryableStatus(githubErr.Response.StatusCode), 0
+	case errors.As(err, &googleErr):
+		return retryableStatus(googleErr.Code), 0
+	case errors.As(err, &registryErr):
+		return registryErr.Temporary() || retryableStatus(registryErr.StatusCode), 0
+	case errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, io.ErrUnexpectedEOF):
+		return true, 0
+	case errors.As(err, &netErr):
+		return true, 0
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, transient := range transientMessages {
+		if strings.Contains(msg, transient) {
+			return true, 0
+		}
+	}
+	return false, 0
+}
+
+// RateLimited returns true if the error is caused by a primary or secondary
+// rate limit of GitHub.
+func RateLimited(err error) bool {
+	var (
+		rateLimitErr *gogithub.RateLimitError
+		abuseErr     *gogithub.AbuseRateLimitError
+		githubErr    *gogithub.ErrorResponse
+	)
+	switch {
+	case errors.As(err, &rateLimitErr), errors.As(err, &abuseErr):
+		return true
+	case errors.As(err, &githubErr) && githubErr.Response != nil:
+		switch githubErr.Response.StatusCode {
+		case http.StatusTooManyRequests:
+			return true
+		case http.StatusForbidden:
+			return strings.Contains(strings.ToLower(githubErr.Message), "rate limit") ||
+				githubErr.Response.Header.Get("X-RateLimit-Remaining") == "0"
+		}
+	}
+	return false
+}
+
+// rateLimitRetryAfter returns the wait time indicated by the Retry-After or
+// X-RateLimit-Reset headers, or zero if none is set or the reset has passed.
+func rateLimitRetryAfter(header http.Header) time.Duration {
+	if seconds, err := strconv.ParseInt(header.Get("Retry-After"), 10, 64); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return time.Until(time.Unix(reset, 0))
+	}
+	return 0
+}
+
+// Always is a Classifier which retries every error.
+func Always(error) (retryable bool, wait time.Duration) {
+	return true, 0
+}
+
+// retryableStatus returns true for HTTP status codes indicating a temporary
+// failure.
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// Permanent wraps an error to stop retrying it, independently of the
+// classification. Do returns the unwrapped error.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
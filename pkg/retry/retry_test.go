@@ -0,0 +1,287 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+
+	"k8s.io/release/pkg/retry"
+)
+
+var errTest = errors.New("test")
+
+func testPolicy() *retry.Policy {
+	policy := retry.DefaultPolicy()
+	policy.Attempts = 3
+	policy.InitialDelay = time.Millisecond
+	policy.Factor = 1
+	policy.Jitter = 0
+	return policy
+}
+
+func TestDo(t *testing.T) {
+	transient := fmt.Errorf("upload: %w", syscall.ECONNRESET)
+
+	for _, tc := range []struct {
+		name          string
+		classify      retry.Classifier
+		errs          []error
+		expectedCalls int
+		shouldErr     bool
+	}{
+		{
+			name:          "success",
+			errs:          []error{nil},
+			expectedCalls: 1,
+		},
+		{
+			name:          "success after transient failures",
+			errs:          []error{transient, transient, nil},
+			expectedCalls: 3,
+		},
+		{
+			name:          "attempts exhausted",
+			errs:          []error{transient, transient, transient},
+			expectedCalls: 3,
+			shouldErr:     true,
+		},
+		{
+			name:          "non retryable error",
+			errs:          []error{errTest},
+			expectedCalls: 1,
+			shouldErr:     true,
+		},
+		{
+			name:          "permanent error",
+			errs:          []error{retry.Permanent(transient)},
+			expectedCalls: 1,
+			shouldErr:     true,
+		},
+		{
+			name:          "custom classifier",
+			classify:      retry.Always,
+			errs:          []error{errTest, nil},
+			expectedCalls: 2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			retry.ResetBreakers()
+			policy := testPolicy()
+			if tc.classify != nil {
+				policy.Classify = tc.classify
+			}
+
+			calls := 0
+			err := policy.Do(context.Background(), retry.ServiceGCS, func() error {
+				err := tc.errs[calls]
+				calls++
+				return err
+			})
+			require.Equal(t, tc.expectedCalls, calls)
+			if tc.shouldErr {
+				require.Error(t, err)
+				require.NotErrorIs(t, err, retry.ErrCircuitOpen)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDoGitHubRateLimit(t *testing.T) {
+	retry.ResetBreakers()
+	retryAfter := time.Millisecond
+	rateLimited := &gogithub.AbuseRateLimitError{RetryAfter: &retryAfter}
+
+	// More rate limits than attempts and the breaker threshold
+	calls := 0
+	err := testPolicy().Do(context.Background(), retry.ServiceGitHub, func() error {
+		calls++
+		if calls <= retry.DefaultBreakerThreshold+2 {
+			return rateLimited
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, retry.DefaultBreakerThreshold+3, calls)
+	require.NoError(t, retry.BreakerFor(retry.ServiceGitHub).Allow())
+}
+
+func TestDoCanceled(t *testing.T) {
+	retry.ResetBreakers()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	policy := testPolicy()
+	policy.InitialDelay = time.Hour
+	err := policy.Do(ctx, retry.ServiceGitHub, func() error {
+		return syscall.ECONNREFUSED
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, err, syscall.ECONNREFUSED)
+}
+
+func TestDoCircuitOpen(t *testing.T) {
+	retry.ResetBreakers()
+	policy := testPolicy()
+	policy.Attempts = retry.DefaultBreakerThreshold + 1
+
+	calls := 0
+	err := policy.Do(context.Background(), retry.ServiceOBS, func() error {
+		calls++
+		return syscall.ECONNRESET
+	})
+	require.ErrorIs(t, err, retry.ErrCircuitOpen)
+	require.Equal(t, retry.DefaultBreakerThreshold, calls)
+
+	// Other services are not affected
+	require.NoError(t, policy.Do(context.Background(), retry.ServiceRegistry, func() error {
+		return nil
+	}))
+}
+
+func TestBreaker(t *testing.T) {
+	breaker := retry.NewBreaker(2, 10*time.Millisecond)
+	require.NoError(t, breaker.Allow())
+
+	breaker.Failure()
+	require.NoError(t, breaker.Allow())
+	breaker.Failure()
+	require.ErrorIs(t, breaker.Allow(), retry.ErrCircuitOpen)
+
+	// A single trial call passes after the cooldown
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, breaker.Allow())
+	require.ErrorIs(t, breaker.Allow(), retry.ErrCircuitOpen)
+
+	// A failed trial opens the breaker again
+	breaker.Failure()
+	require.ErrorIs(t, breaker.Allow(), retry.ErrCircuitOpen)
+
+	// A successful trial closes the breaker
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, breaker.Allow())
+	breaker.Success()
+	require.NoError(t, breaker.Allow())
+	require.NoError(t, breaker.Allow())
+}
+
+func TestClassify(t *testing.T) {
+	retryAfter := 5 * time.Second
+
+	for _, tc := range []struct {
+		name      string
+		err       error
+		retryable bool
+		wait      time.Duration
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "unknown error",
+			err:  errTest,
+		},
+		{
+			name: "canceled",
+			err:  fmt.Errorf("wrapped: %w", context.Canceled),
+		},
+		{
+			name:      "connection reset",
+			err:       fmt.Errorf("wrapped: %w", syscall.ECONNRESET),
+			retryable: true,
+		},
+		{
+			name:      "transient tool output",
+			err:       errors.New("command gsutil failed: 503 Service Unavailable"),
+			retryable: true,
+		},
+		{
+			name: "GitHub not found",
+			err: &gogithub.ErrorResponse{
+				Response: &http.Response{StatusCode: http.StatusNotFound},
+			},
+		},
+		{
+			name: "GitHub server error",
+			err: &gogithub.ErrorResponse{
+				Response: &http.Response{StatusCode: http.StatusBadGateway},
+			},
+			retryable: true,
+		},
+		{
+			name: "GitHub secondary rate limit",
+			err: &gogithub.ErrorResponse{
+				Response: &http.Response{StatusCode: http.StatusForbidden},
+				Message:  "You have exceeded a secondary rate limit",
+			},
+			retryable: true,
+			wait:      time.Minute,
+		},
+		{
+			name: "GitHub secondary rate limit with retry after",
+			err: &gogithub.ErrorResponse{
+				Response: &http.Response{
+					StatusCode: http.StatusForbidden,
+					Header:     http.Header{"Retry-After": []string{"30"}},
+				},
+				Message: "You have exceeded a secondary rate limit",
+			},
+			retryable: true,
+			wait:      30 * time.Second,
+		},
+		{
+			name: "GitHub too many requests",
+			err: &gogithub.ErrorResponse{
+				Response: &http.Response{StatusCode: http.StatusTooManyRequests},
+			},
+			retryable: true,
+			wait:      time.Minute,
+		},
+		{
+			name:      "GitHub abuse rate limit",
+			err:       &gogithub.AbuseRateLimitError{RetryAfter: &retryAfter},
+			retryable: true,
+			wait:      retryAfter,
+		},
+		{
+			name: "Google permission denied",
+			err:  &googleapi.Error{Code: http.StatusForbidden},
+		},
+		{
+			name:      "Google too many requests",
+			err:       &googleapi.Error{Code: http.StatusTooManyRequests},
+			retryable: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			retryable, wait := retry.Classify(tc.err)
+			require.Equal(t, tc.retryable, retryable)
+			require.Equal(t, tc.wait, wait)
+		})
+	}
+}
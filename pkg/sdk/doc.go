@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package sdk provides a stable Go API for embedding release operations into
+other tools, instead of shelling out to krel.
+
+The API is free of global state, never exits the process and returns all
+failures as errors. Every operation takes a context, which cancels the
+operation between its phases. Interactive prompts are only used if the caller
+provides them via the options, for example announce.SendOptions.Confirm.
+
+The exported API of this package follows semantic versioning: within a major
+version, types and functions may be added but existing signatures will not
+change. The option types are shared with the underlying packages, new fields
+always default to the previous behavior if left empty.
+
+Example:
+
+	client := sdk.New()
+	releaseNotes, err := client.GatherReleaseNotes(ctx, opts)
+	if err != nil {
+		return err
+	}
+*/
+package sdk
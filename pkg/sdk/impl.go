@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/build"
+	"k8s.io/release/pkg/changelog"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt sdkfakes/fake_impl.go > sdkfakes/_fake_impl.go && mv sdkfakes/_fake_impl.go sdkfakes/fake_impl.go"
+type impl interface {
+	ValidateNotesOptions(opts *options.Options) error
+	GatherReleaseNotes(ctx context.Context, opts *options.Options) (*notes.ReleaseNotes, error)
+	RunChangelog(opts *changelog.Options) error
+	Push(ctx context.Context, opts *build.Options) error
+	CreateReleaseAnnouncement(opts *announce.Options) error
+	CreateBranchAnnouncement(opts *announce.Options) error
+	FetchAnnouncement(tag string) (string, error)
+	SendAnnouncement(opts *announce.SendOptions, content string) error
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) ValidateNotesOptions(opts *options.Options) error {
+	return opts.ValidateAndFinish()
+}
+
+func (*defaultImpl) GatherReleaseNotes(
+	ctx context.Context, opts *options.Options,
+) (*notes.ReleaseNotes, error) {
+	return notes.GatherReleaseNotesContext(ctx, opts)
+}
+
+func (*defaultImpl) RunChangelog(opts *changelog.Options) error {
+	return changelog.New(opts).Run()
+}
+
+func (*defaultImpl) Push(ctx context.Context, opts *build.Options) error {
+	instance := build.NewInstance(opts)
+	instance.SetContext(ctx)
+	return instance.Push()
+}
+
+func (*defaultImpl) CreateReleaseAnnouncement(opts *announce.Options) error {
+	return announce.CreateForRelease(opts)
+}
+
+func (*defaultImpl) CreateBranchAnnouncement(opts *announce.Options) error {
+	return announce.CreateForBranch(opts)
+}
+
+func (*defaultImpl) FetchAnnouncement(tag string) (string, error) {
+	return announce.Fetch(tag)
+}
+
+func (*defaultImpl) SendAnnouncement(opts *announce.SendOptions, content string) error {
+	return announce.Send(opts, content)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/build"
+	"k8s.io/release/pkg/changelog"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+)
+
+// Client runs release operations. The zero value is not usable, use New
+// to create a Client.
+type Client struct {
+	impl impl
+}
+
+// New creates a new Client.
+func New() *Client {
+	return &Client{impl: &defaultImpl{}}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (c *Client) SetImpl(impl impl) {
+	c.impl = impl
+}
+
+// GatherReleaseNotes validates the options and collects the release notes
+// of all pull requests in the configured revision range.
+func (c *Client) GatherReleaseNotes(
+	ctx context.Context, opts *options.Options,
+) (*notes.ReleaseNotes, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("gather release notes: %w", err)
+	}
+	if err := c.impl.ValidateNotesOptions(opts); err != nil {
+		return nil, fmt.Errorf("validate release notes options: %w", err)
+	}
+	releaseNotes, err := c.impl.GatherReleaseNotes(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("gather release notes: %w", err)
+	}
+	return releaseNotes, nil
+}
+
+// GenerateChangelog generates the changelog for a release and commits it
+// into the repository at opts.RepoPath.
+func (c *Client) GenerateChangelog(ctx context.Context, opts *changelog.Options) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("generate changelog: %w", err)
+	}
+	if err := c.impl.RunChangelog(opts); err != nil {
+		return fmt.Errorf("generate changelog: %w", err)
+	}
+	return nil
+}
+
+// Push uploads the release artifacts and container images of a build to
+// Google Cloud Storage and the container registry.
+func (c *Client) Push(ctx context.Context, opts *build.Options) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("push build: %w", err)
+	}
+	if err := c.impl.Push(ctx, opts); err != nil {
+		return fmt.Errorf("push build: %w", err)
+	}
+	return nil
+}
+
+// CreateAnnouncement writes the announcement for a release into the work
+// directory of the options.
+func (c *Client) CreateAnnouncement(ctx context.Context, opts *announce.Options) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("create announcement: %w", err)
+	}
+	if err := c.impl.CreateReleaseAnnouncement(opts); err != nil {
+		return fmt.Errorf("create announcement: %w", err)
+	}
+	return nil
+}
+
+// CreateBranchAnnouncement writes the announcement for a new release branch
+// into the work directory of the options.
+func (c *Client) CreateBranchAnnouncement(ctx context.Context, opts *announce.Options) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("create branch announcement: %w", err)
+	}
+	if err := c.impl.CreateBranchAnnouncement(opts); err != nil {
+		return fmt.Errorf("create branch announcement: %w", err)
+	}
+	return nil
+}
+
+// SendAnnouncement retrieves the announcement of an already published
+// release and mails it to the Kubernetes Google Groups.
+func (c *Client) SendAnnouncement(ctx context.Context, opts *announce.SendOptions) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("validate announcement options: %w", err)
+	}
+	content, err := c.impl.FetchAnnouncement(opts.Tag)
+	if err != nil {
+		return fmt.Errorf("fetch announcement: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("send announcement: %w", err)
+	}
+	if err := c.impl.SendAnnouncement(opts, content); err != nil {
+		return fmt.Errorf("send announcement: %w", err)
+	}
+	return nil
+}
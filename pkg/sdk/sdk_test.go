@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/build"
+	"k8s.io/release/pkg/changelog"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+	"k8s.io/release/pkg/sdk"
+	"k8s.io/release/pkg/sdk/sdkfakes"
+)
+
+var errTest = errors.New("test")
+
+func cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestGatherReleaseNotes(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		ctx         context.Context
+		prepare     func(*sdkfakes.FakeImpl)
+		shouldError bool
+	}{
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			prepare: func(mock *sdkfakes.FakeImpl) { mock.GatherReleaseNotesReturns(notes.NewReleaseNotes(), nil) },
+		},
+		{
+			name:        "cancelled",
+			ctx:         cancelledContext(),
+			prepare:     func(*sdkfakes.FakeImpl) {},
+			shouldError: true,
+		},
+		{
+			name:        "invalid options",
+			ctx:         context.Background(),
+			prepare:     func(mock *sdkfakes.FakeImpl) { mock.ValidateNotesOptionsReturns(errTest) },
+			shouldError: true,
+		},
+		{
+			name:        "gather fails",
+			ctx:         context.Background(),
+			prepare:     func(mock *sdkfakes.FakeImpl) { mock.GatherReleaseNotesReturns(nil, errTest) },
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &sdkfakes.FakeImpl{}
+			tc.prepare(mock)
+			sut := sdk.New()
+			sut.SetImpl(mock)
+
+			res, err := sut.GatherReleaseNotes(tc.ctx, options.New())
+			if tc.shouldError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, res)
+		})
+	}
+}
+
+func TestOperations(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		run     func(context.Context, *sdk.Client) error
+		fail    func(*sdkfakes.FakeImpl)
+		callCnt func(*sdkfakes.FakeImpl) int
+	}{
+		{
+			name: "changelog",
+			run: func(ctx context.Context, c *sdk.Client) error {
+				return c.GenerateChangelog(ctx, &changelog.Options{})
+			},
+			fail:    func(mock *sdkfakes.FakeImpl) { mock.RunChangelogReturns(errTest) },
+			callCnt: (*sdkfakes.FakeImpl).RunChangelogCallCount,
+		},
+		{
+			name: "push",
+			run: func(ctx context.Context, c *sdk.Client) error {
+				return c.Push(ctx, &build.Options{})
+			},
+			fail:    func(mock *sdkfakes.FakeImpl) { mock.PushReturns(errTest) },
+			callCnt: (*sdkfakes.FakeImpl).PushCallCount,
+		},
+		{
+			name: "create announcement",
+			run: func(ctx context.Context, c *sdk.Client) error {
+				return c.CreateAnnouncement(ctx, announce.NewOptions())
+			},
+			fail:    func(mock *sdkfakes.FakeImpl) { mock.CreateReleaseAnnouncementReturns(errTest) },
+			callCnt: (*sdkfakes.FakeImpl).CreateReleaseAnnouncementCallCount,
+		},
+		{
+			name: "create branch announcement",
+			run: func(ctx context.Context, c *sdk.Client) error {
+				return c.CreateBranchAnnouncement(ctx, announce.NewOptions())
+			},
+			fail:    func(mock *sdkfakes.FakeImpl) { mock.CreateBranchAnnouncementReturns(errTest) },
+			callCnt: (*sdkfakes.FakeImpl).CreateBranchAnnouncementCallCount,
+		},
+		{
+			name: "send announcement",
+			run: func(ctx context.Context, c *sdk.Client) error {
+				return c.SendAnnouncement(ctx, &announce.SendOptions{Tag: "v1.31.0", SendgridAPIKey: "key"})
+			},
+			fail:    func(mock *sdkfakes.FakeImpl) { mock.SendAnnouncementReturns(errTest) },
+			callCnt: (*sdkfakes.FakeImpl).SendAnnouncementCallCount,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &sdkfakes.FakeImpl{}
+			sut := sdk.New()
+			sut.SetImpl(mock)
+
+			require.NoError(t, tc.run(context.Background(), sut))
+			require.Equal(t, 1, tc.callCnt(mock))
+
+			require.Error(t, tc.run(cancelledContext(), sut))
+			require.Equal(t, 1, tc.callCnt(mock))
+
+			tc.fail(mock)
+			require.ErrorIs(t, tc.run(context.Background(), sut), errTest)
+		})
+	}
+}
+
+func TestSendAnnouncementValidation(t *testing.T) {
+	mock := &sdkfakes.FakeImpl{}
+	sut := sdk.New()
+	sut.SetImpl(mock)
+
+	require.Error(t, sut.SendAnnouncement(context.Background(), &announce.SendOptions{Tag: "v1.31.0"}))
+	require.Zero(t, mock.FetchAnnouncementCallCount())
+}
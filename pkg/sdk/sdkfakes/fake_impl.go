@@ -0,0 +1,664 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package sdkfakes
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/build"
+	"k8s.io/release/pkg/changelog"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+)
+
+type FakeImpl struct {
+	CreateBranchAnnouncementStub        func(*announce.Options) error
+	createBranchAnnouncementMutex       sync.RWMutex
+	createBranchAnnouncementArgsForCall []struct {
+		arg1 *announce.Options
+	}
+	createBranchAnnouncementReturns struct {
+		result1 error
+	}
+	createBranchAnnouncementReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateReleaseAnnouncementStub        func(*announce.Options) error
+	createReleaseAnnouncementMutex       sync.RWMutex
+	createReleaseAnnouncementArgsForCall []struct {
+		arg1 *announce.Options
+	}
+	createReleaseAnnouncementReturns struct {
+		result1 error
+	}
+	createReleaseAnnouncementReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FetchAnnouncementStub        func(string) (string, error)
+	fetchAnnouncementMutex       sync.RWMutex
+	fetchAnnouncementArgsForCall []struct {
+		arg1 string
+	}
+	fetchAnnouncementReturns struct {
+		result1 string
+		result2 error
+	}
+	fetchAnnouncementReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GatherReleaseNotesStub        func(context.Context, *options.Options) (*notes.ReleaseNotes, error)
+	gatherReleaseNotesMutex       sync.RWMutex
+	gatherReleaseNotesArgsForCall []struct {
+		arg1 context.Context
+		arg2 *options.Options
+	}
+	gatherReleaseNotesReturns struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}
+	gatherReleaseNotesReturnsOnCall map[int]struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}
+	PushStub        func(context.Context, *build.Options) error
+	pushMutex       sync.RWMutex
+	pushArgsForCall []struct {
+		arg1 context.Context
+		arg2 *build.Options
+	}
+	pushReturns struct {
+		result1 error
+	}
+	pushReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RunChangelogStub        func(*changelog.Options) error
+	runChangelogMutex       sync.RWMutex
+	runChangelogArgsForCall []struct {
+		arg1 *changelog.Options
+	}
+	runChangelogReturns struct {
+		result1 error
+	}
+	runChangelogReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SendAnnouncementStub        func(*announce.SendOptions, string) error
+	sendAnnouncementMutex       sync.RWMutex
+	sendAnnouncementArgsForCall []struct {
+		arg1 *announce.SendOptions
+		arg2 string
+	}
+	sendAnnouncementReturns struct {
+		result1 error
+	}
+	sendAnnouncementReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ValidateNotesOptionsStub        func(*options.Options) error
+	validateNotesOptionsMutex       sync.RWMutex
+	validateNotesOptionsArgsForCall []struct {
+		arg1 *options.Options
+	}
+	validateNotesOptionsReturns struct {
+		result1 error
+	}
+	validateNotesOptionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) CreateBranchAnnouncement(arg1 *announce.Options) error {
+	fake.createBranchAnnouncementMutex.Lock()
+	ret, specificReturn := fake.createBranchAnnouncementReturnsOnCall[len(fake.createBranchAnnouncementArgsForCall)]
+	fake.createBranchAnnouncementArgsForCall = append(fake.createBranchAnnouncementArgsForCall, struct {
+		arg1 *announce.Options
+	}{arg1})
+	stub := fake.CreateBranchAnnouncementStub
+	fakeReturns := fake.createBranchAnnouncementReturns
+	fake.recordInvocation("CreateBranchAnnouncement", []interface{}{arg1})
+	fake.createBranchAnnouncementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CreateBranchAnnouncementCallCount() int {
+	fake.createBranchAnnouncementMutex.RLock()
+	defer fake.createBranchAnnouncementMutex.RUnlock()
+	return len(fake.createBranchAnnouncementArgsForCall)
+}
+
+func (fake *FakeImpl) CreateBranchAnnouncementCalls(stub func(*announce.Options) error) {
+	fake.createBranchAnnouncementMutex.Lock()
+	defer fake.createBranchAnnouncementMutex.Unlock()
+	fake.CreateBranchAnnouncementStub = stub
+}
+
+func (fake *FakeImpl) CreateBranchAnnouncementArgsForCall(i int) *announce.Options {
+	fake.createBranchAnnouncementMutex.RLock()
+	defer fake.createBranchAnnouncementMutex.RUnlock()
+	argsForCall := fake.createBranchAnnouncementArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) CreateBranchAnnouncementReturns(result1 error) {
+	fake.createBranchAnnouncementMutex.Lock()
+	defer fake.createBranchAnnouncementMutex.Unlock()
+	fake.CreateBranchAnnouncementStub = nil
+	fake.createBranchAnnouncementReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CreateBranchAnnouncementReturnsOnCall(i int, result1 error) {
+	fake.createBranchAnnouncementMutex.Lock()
+	defer fake.createBranchAnnouncementMutex.Unlock()
+	fake.CreateBranchAnnouncementStub = nil
+	if fake.createBranchAnnouncementReturnsOnCall == nil {
+		fake.createBranchAnnouncementReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createBranchAnnouncementReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CreateReleaseAnnouncement(arg1 *announce.Options) error {
+	fake.createReleaseAnnouncementMutex.Lock()
+	ret, specificReturn := fake.createReleaseAnnouncementReturnsOnCall[len(fake.createReleaseAnnouncementArgsForCall)]
+	fake.createReleaseAnnouncementArgsForCall = append(fake.createReleaseAnnouncementArgsForCall, struct {
+		arg1 *announce.Options
+	}{arg1})
+	stub := fake.CreateReleaseAnnouncementStub
+	fakeReturns := fake.createReleaseAnnouncementReturns
+	fake.recordInvocation("CreateReleaseAnnouncement", []interface{}{arg1})
+	fake.createReleaseAnnouncementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CreateReleaseAnnouncementCallCount() int {
+	fake.createReleaseAnnouncementMutex.RLock()
+	defer fake.createReleaseAnnouncementMutex.RUnlock()
+	return len(fake.createReleaseAnnouncementArgsForCall)
+}
+
+func (fake *FakeImpl) CreateReleaseAnnouncementCalls(stub func(*announce.Options) error) {
+	fake.createReleaseAnnouncementMutex.Lock()
+	defer fake.createReleaseAnnouncementMutex.Unlock()
+	fake.CreateReleaseAnnouncementStub = stub
+}
+
+func (fake *FakeImpl) CreateReleaseAnnouncementArgsForCall(i int) *announce.Options {
+	fake.createReleaseAnnouncementMutex.RLock()
+	defer fake.createReleaseAnnouncementMutex.RUnlock()
+	argsForCall := fake.createReleaseAnnouncementArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) CreateReleaseAnnouncementReturns(result1 error) {
+	fake.createReleaseAnnouncementMutex.Lock()
+	defer fake.createReleaseAnnouncementMutex.Unlock()
+	fake.CreateReleaseAnnouncementStub = nil
+	fake.createReleaseAnnouncementReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CreateReleaseAnnouncementReturnsOnCall(i int, result1 error) {
+	fake.createReleaseAnnouncementMutex.Lock()
+	defer fake.createReleaseAnnouncementMutex.Unlock()
+	fake.CreateReleaseAnnouncementStub = nil
+	if fake.createReleaseAnnouncementReturnsOnCall == nil {
+		fake.createReleaseAnnouncementReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createReleaseAnnouncementReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) FetchAnnouncement(arg1 string) (string, error) {
+	fake.fetchAnnouncementMutex.Lock()
+	ret, specificReturn := fake.fetchAnnouncementReturnsOnCall[len(fake.fetchAnnouncementArgsForCall)]
+	fake.fetchAnnouncementArgsForCall = append(fake.fetchAnnouncementArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FetchAnnouncementStub
+	fakeReturns := fake.fetchAnnouncementReturns
+	fake.recordInvocation("FetchAnnouncement", []interface{}{arg1})
+	fake.fetchAnnouncementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) FetchAnnouncementCallCount() int {
+	fake.fetchAnnouncementMutex.RLock()
+	defer fake.fetchAnnouncementMutex.RUnlock()
+	return len(fake.fetchAnnouncementArgsForCall)
+}
+
+func (fake *FakeImpl) FetchAnnouncementCalls(stub func(string) (string, error)) {
+	fake.fetchAnnouncementMutex.Lock()
+	defer fake.fetchAnnouncementMutex.Unlock()
+	fake.FetchAnnouncementStub = stub
+}
+
+func (fake *FakeImpl) FetchAnnouncementArgsForCall(i int) string {
+	fake.fetchAnnouncementMutex.RLock()
+	defer fake.fetchAnnouncementMutex.RUnlock()
+	argsForCall := fake.fetchAnnouncementArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) FetchAnnouncementReturns(result1 string, result2 error) {
+	fake.fetchAnnouncementMutex.Lock()
+	defer fake.fetchAnnouncementMutex.Unlock()
+	fake.FetchAnnouncementStub = nil
+	fake.fetchAnnouncementReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) FetchAnnouncementReturnsOnCall(i int, result1 string, result2 error) {
+	fake.fetchAnnouncementMutex.Lock()
+	defer fake.fetchAnnouncementMutex.Unlock()
+	fake.FetchAnnouncementStub = nil
+	if fake.fetchAnnouncementReturnsOnCall == nil {
+		fake.fetchAnnouncementReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.fetchAnnouncementReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GatherReleaseNotes(arg1 context.Context, arg2 *options.Options) (*notes.ReleaseNotes, error) {
+	fake.gatherReleaseNotesMutex.Lock()
+	ret, specificReturn := fake.gatherReleaseNotesReturnsOnCall[len(fake.gatherReleaseNotesArgsForCall)]
+	fake.gatherReleaseNotesArgsForCall = append(fake.gatherReleaseNotesArgsForCall, struct {
+		arg1 context.Context
+		arg2 *options.Options
+	}{arg1, arg2})
+	stub := fake.GatherReleaseNotesStub
+	fakeReturns := fake.gatherReleaseNotesReturns
+	fake.recordInvocation("GatherReleaseNotes", []interface{}{arg1, arg2})
+	fake.gatherReleaseNotesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GatherReleaseNotesCallCount() int {
+	fake.gatherReleaseNotesMutex.RLock()
+	defer fake.gatherReleaseNotesMutex.RUnlock()
+	return len(fake.gatherReleaseNotesArgsForCall)
+}
+
+func (fake *FakeImpl) GatherReleaseNotesCalls(stub func(context.Context, *options.Options) (*notes.ReleaseNotes, error)) {
+	fake.gatherReleaseNotesMutex.Lock()
+	defer fake.gatherReleaseNotesMutex.Unlock()
+	fake.GatherReleaseNotesStub = stub
+}
+
+func (fake *FakeImpl) GatherReleaseNotesArgsForCall(i int) (context.Context, *options.Options) {
+	fake.gatherReleaseNotesMutex.RLock()
+	defer fake.gatherReleaseNotesMutex.RUnlock()
+	argsForCall := fake.gatherReleaseNotesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) GatherReleaseNotesReturns(result1 *notes.ReleaseNotes, result2 error) {
+	fake.gatherReleaseNotesMutex.Lock()
+	defer fake.gatherReleaseNotesMutex.Unlock()
+	fake.GatherReleaseNotesStub = nil
+	fake.gatherReleaseNotesReturns = struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GatherReleaseNotesReturnsOnCall(i int, result1 *notes.ReleaseNotes, result2 error) {
+	fake.gatherReleaseNotesMutex.Lock()
+	defer fake.gatherReleaseNotesMutex.Unlock()
+	fake.GatherReleaseNotesStub = nil
+	if fake.gatherReleaseNotesReturnsOnCall == nil {
+		fake.gatherReleaseNotesReturnsOnCall = make(map[int]struct {
+			result1 *notes.ReleaseNotes
+			result2 error
+		})
+	}
+	fake.gatherReleaseNotesReturnsOnCall[i] = struct {
+		result1 *notes.ReleaseNotes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) Push(arg1 context.Context, arg2 *build.Options) error {
+	fake.pushMutex.Lock()
+	ret, specificReturn := fake.pushReturnsOnCall[len(fake.pushArgsForCall)]
+	fake.pushArgsForCall = append(fake.pushArgsForCall, struct {
+		arg1 context.Context
+		arg2 *build.Options
+	}{arg1, arg2})
+	stub := fake.PushStub
+	fakeReturns := fake.pushReturns
+	fake.recordInvocation("Push", []interface{}{arg1, arg2})
+	fake.pushMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) PushCallCount() int {
+	fake.pushMutex.RLock()
+	defer fake.pushMutex.RUnlock()
+	return len(fake.pushArgsForCall)
+}
+
+func (fake *FakeImpl) PushCalls(stub func(context.Context, *build.Options) error) {
+	fake.pushMutex.Lock()
+	defer fake.pushMutex.Unlock()
+	fake.PushStub = stub
+}
+
+func (fake *FakeImpl) PushArgsForCall(i int) (context.Context, *build.Options) {
+	fake.pushMutex.RLock()
+	defer fake.pushMutex.RUnlock()
+	argsForCall := fake.pushArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) PushReturns(result1 error) {
+	fake.pushMutex.Lock()
+	defer fake.pushMutex.Unlock()
+	fake.PushStub = nil
+	fake.pushReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) PushReturnsOnCall(i int, result1 error) {
+	fake.pushMutex.Lock()
+	defer fake.pushMutex.Unlock()
+	fake.PushStub = nil
+	if fake.pushReturnsOnCall == nil {
+		fake.pushReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) RunChangelog(arg1 *changelog.Options) error {
+	fake.runChangelogMutex.Lock()
+	ret, specificReturn := fake.runChangelogReturnsOnCall[len(fake.runChangelogArgsForCall)]
+	fake.runChangelogArgsForCall = append(fake.runChangelogArgsForCall, struct {
+		arg1 *changelog.Options
+	}{arg1})
+	stub := fake.RunChangelogStub
+	fakeReturns := fake.runChangelogReturns
+	fake.recordInvocation("RunChangelog", []interface{}{arg1})
+	fake.runChangelogMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) RunChangelogCallCount() int {
+	fake.runChangelogMutex.RLock()
+	defer fake.runChangelogMutex.RUnlock()
+	return len(fake.runChangelogArgsForCall)
+}
+
+func (fake *FakeImpl) RunChangelogCalls(stub func(*changelog.Options) error) {
+	fake.runChangelogMutex.Lock()
+	defer fake.runChangelogMutex.Unlock()
+	fake.RunChangelogStub = stub
+}
+
+func (fake *FakeImpl) RunChangelogArgsForCall(i int) *changelog.Options {
+	fake.runChangelogMutex.RLock()
+	defer fake.runChangelogMutex.RUnlock()
+	argsForCall := fake.runChangelogArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) RunChangelogReturns(result1 error) {
+	fake.runChangelogMutex.Lock()
+	defer fake.runChangelogMutex.Unlock()
+	fake.RunChangelogStub = nil
+	fake.runChangelogReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) RunChangelogReturnsOnCall(i int, result1 error) {
+	fake.runChangelogMutex.Lock()
+	defer fake.runChangelogMutex.Unlock()
+	fake.RunChangelogStub = nil
+	if fake.runChangelogReturnsOnCall == nil {
+		fake.runChangelogReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.runChangelogReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) SendAnnouncement(arg1 *announce.SendOptions, arg2 string) error {
+	fake.sendAnnouncementMutex.Lock()
+	ret, specificReturn := fake.sendAnnouncementReturnsOnCall[len(fake.sendAnnouncementArgsForCall)]
+	fake.sendAnnouncementArgsForCall = append(fake.sendAnnouncementArgsForCall, struct {
+		arg1 *announce.SendOptions
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SendAnnouncementStub
+	fakeReturns := fake.sendAnnouncementReturns
+	fake.recordInvocation("SendAnnouncement", []interface{}{arg1, arg2})
+	fake.sendAnnouncementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) SendAnnouncementCallCount() int {
+	fake.sendAnnouncementMutex.RLock()
+	defer fake.sendAnnouncementMutex.RUnlock()
+	return len(fake.sendAnnouncementArgsForCall)
+}
+
+func (fake *FakeImpl) SendAnnouncementCalls(stub func(*announce.SendOptions, string) error) {
+	fake.sendAnnouncementMutex.Lock()
+	defer fake.sendAnnouncementMutex.Unlock()
+	fake.SendAnnouncementStub = stub
+}
+
+func (fake *FakeImpl) SendAnnouncementArgsForCall(i int) (*announce.SendOptions, string) {
+	fake.sendAnnouncementMutex.RLock()
+	defer fake.sendAnnouncementMutex.RUnlock()
+	argsForCall := fake.sendAnnouncementArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) SendAnnouncementReturns(result1 error) {
+	fake.sendAnnouncementMutex.Lock()
+	defer fake.sendAnnouncementMutex.Unlock()
+	fake.SendAnnouncementStub = nil
+	fake.sendAnnouncementReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) SendAnnouncementReturnsOnCall(i int, result1 error) {
+	fake.sendAnnouncementMutex.Lock()
+	defer fake.sendAnnouncementMutex.Unlock()
+	fake.SendAnnouncementStub = nil
+	if fake.sendAnnouncementReturnsOnCall == nil {
+		fake.sendAnnouncementReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.sendAnnouncementReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) ValidateNotesOptions(arg1 *options.Options) error {
+	fake.validateNotesOptionsMutex.Lock()
+	ret, specificReturn := fake.validateNotesOptionsReturnsOnCall[len(fake.validateNotesOptionsArgsForCall)]
+	fake.validateNotesOptionsArgsForCall = append(fake.validateNotesOptionsArgsForCall, struct {
+		arg1 *options.Options
+	}{arg1})
+	stub := fake.ValidateNotesOptionsStub
+	fakeReturns := fake.validateNotesOptionsReturns
+	fake.recordInvocation("ValidateNotesOptions", []interface{}{arg1})
+	fake.validateNotesOptionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) ValidateNotesOptionsCallCount() int {
+	fake.validateNotesOptionsMutex.RLock()
+	defer fake.validateNotesOptionsMutex.RUnlock()
+	return len(fake.validateNotesOptionsArgsForCall)
+}
+
+func (fake *FakeImpl) ValidateNotesOptionsCalls(stub func(*options.Options) error) {
+	fake.validateNotesOptionsMutex.Lock()
+	defer fake.validateNotesOptionsMutex.Unlock()
+	fake.ValidateNotesOptionsStub = stub
+}
+
+func (fake *FakeImpl) ValidateNotesOptionsArgsForCall(i int) *options.Options {
+	fake.validateNotesOptionsMutex.RLock()
+	defer fake.validateNotesOptionsMutex.RUnlock()
+	argsForCall := fake.validateNotesOptionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ValidateNotesOptionsReturns(result1 error) {
+	fake.validateNotesOptionsMutex.Lock()
+	defer fake.validateNotesOptionsMutex.Unlock()
+	fake.ValidateNotesOptionsStub = nil
+	fake.validateNotesOptionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) ValidateNotesOptionsReturnsOnCall(i int, result1 error) {
+	fake.validateNotesOptionsMutex.Lock()
+	defer fake.validateNotesOptionsMutex.Unlock()
+	fake.ValidateNotesOptionsStub = nil
+	if fake.validateNotesOptionsReturnsOnCall == nil {
+		fake.validateNotesOptionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateNotesOptionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createBranchAnnouncementMutex.RLock()
+	defer fake.createBranchAnnouncementMutex.RUnlock()
+	fake.createReleaseAnnouncementMutex.RLock()
+	defer fake.createReleaseAnnouncementMutex.RUnlock()
+	fake.fetchAnnouncementMutex.RLock()
+	defer fake.fetchAnnouncementMutex.RUnlock()
+	fake.gatherReleaseNotesMutex.RLock()
+	defer fake.gatherReleaseNotesMutex.RUnlock()
+	fake.pushMutex.RLock()
+	defer fake.pushMutex.RUnlock()
+	fake.runChangelogMutex.RLock()
+	defer fake.runChangelogMutex.RUnlock()
+	fake.sendAnnouncementMutex.RLock()
+	defer fake.sendAnnouncementMutex.RUnlock()
+	fake.validateNotesOptionsMutex.RLock()
+	defer fake.validateNotesOptionsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// waitDelay is the time a job gets for a graceful shutdown after being
+// interrupted, before it gets killed.
+const waitDelay = time.Minute
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt serverfakes/fake_impl.go > serverfakes/_fake_impl.go && mv serverfakes/_fake_impl.go serverfakes/fake_impl.go"
+type impl interface {
+	Execute(ctx context.Context, args []string, output io.Writer) error
+}
+
+type defaultImpl struct{}
+
+// Execute runs the current executable with the provided arguments. The
+// process gets interrupted if the context is cancelled, which allows it to
+// shutdown gracefully.
+func (*defaultImpl) Execute(ctx context.Context, args []string, output io.Writer) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("get executable: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, executable, args...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = waitDelay
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run %s: %w", executable, err)
+	}
+	return nil
+}
@@ -0,0 +1,467 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/redact"
+)
+
+const (
+	// TokenEnvKey is the environment variable containing the API token.
+	TokenEnvKey = "KREL_SERVE_TOKEN"
+
+	// DefaultAddress is the default listen address of the server, which only
+	// accepts local connections unless TLS is configured.
+	DefaultAddress = "127.0.0.1:8080"
+
+	apiPrefix         = "/api/v1"
+	shutdownTimeout   = 30 * time.Second
+	readHeaderTimeout = 10 * time.Second
+)
+
+// Status is the status of a job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Options are the settings of the server.
+type Options struct {
+	// Address is the listen address of the server.
+	Address string
+
+	// Token is the bearer token required for all API requests.
+	Token string
+
+	// TLSCertFile and TLSKeyFile are the certificate and private key for
+	// serving the API via HTTPS. The API is served via plain HTTP if both
+	// are empty.
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Address: DefaultAddress,
+		Token:   os.Getenv(TokenEnvKey),
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Address == "" {
+		return errors.New("no listen address specified")
+	}
+	if o.Token == "" {
+		return fmt.Errorf("no API token specified, set it via $%s", TokenEnvKey)
+	}
+	if (o.TLSCertFile == "") != (o.TLSKeyFile == "") {
+		return errors.New("TLS certificate and key have to be specified together")
+	}
+	return nil
+}
+
+// Operation is a release operation which can be triggered via the API.
+type Operation struct {
+	// Name is the unique name of the operation, for example "stage".
+	Name string `json:"name"`
+
+	// Description is a short description of the operation.
+	Description string `json:"description"`
+
+	// Args are the fixed krel arguments of the operation.
+	Args []string `json:"-"`
+
+	// Parameters are the flags which can be set by the API caller.
+	Parameters []string `json:"parameters"`
+}
+
+// args returns the command line arguments for the provided parameters.
+func (o *Operation) args(params map[string]string) ([]string, error) {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := append([]string{}, o.Args...)
+	for _, name := range names {
+		allowed := false
+		for _, p := range o.Parameters {
+			if p == name {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, fmt.Errorf(
+				"unsupported parameter %q for operation %s, allowed are: %s",
+				name, o.Name, strings.Join(o.Parameters, ", "),
+			)
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", name, params[name]))
+	}
+	return args, nil
+}
+
+// Job is a single asynchronous run of an operation.
+type Job struct {
+	ID         string            `json:"id"`
+	Operation  string            `json:"operation"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Status     Status            `json:"status"`
+	Error      string            `json:"error,omitempty"`
+	Created    time.Time         `json:"created"`
+	Started    *time.Time        `json:"started,omitempty"`
+	Finished   *time.Time        `json:"finished,omitempty"`
+
+	logs *syncBuffer
+}
+
+// JobRequest is the request body for creating a new job.
+type JobRequest struct {
+	Operation  string            `json:"operation"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// Server exposes release operations via an authenticated REST API and
+// tracks their runs as asynchronous jobs.
+type Server struct {
+	impl       impl
+	options    *Options
+	operations map[string]*Operation
+	handlers   map[string]http.Handler
+
+	ctx  context.Context
+	mu   sync.RWMutex
+	jobs map[string]*Job
+	wg   sync.WaitGroup
+}
+
+// New creates a new Server instance for the provided operations.
+func New(opts *Options, operations ...*Operation) *Server {
+	ops := map[string]*Operation{}
+	for _, op := range operations {
+		ops[op.Name] = op
+	}
+	return &Server{
+		impl:       &defaultImpl{},
+		options:    opts,
+		operations: ops,
+		handlers:   map[string]http.Handler{},
+		ctx:        context.Background(),
+		jobs:       map[string]*Job{},
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (s *Server) SetImpl(impl impl) {
+	s.impl = impl
+}
+
+// Run starts the server and blocks until the context is cancelled. Running
+// jobs get interrupted on shutdown.
+func (s *Server) Run(ctx context.Context) error {
+	if err := s.options.Validate(); err != nil {
+		return fmt.Errorf("validate options: %w", err)
+	}
+
+	s.ctx = ctx
+	srv := &http.Server{
+		Addr:              s.options.Address,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if s.options.TLSCertFile != "" {
+			logrus.Infof("Serving release API via HTTPS on %s", s.options.Address)
+			errCh <- srv.ListenAndServeTLS(s.options.TLSCertFile, s.options.TLSKeyFile)
+			return
+		}
+		logrus.Infof("Serving release API on %s", s.options.Address)
+		if host, _, err := net.SplitHostPort(s.options.Address); err == nil {
+			if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+				logrus.Warnf("Serving the bearer token protected API on %s without TLS", s.options.Address)
+			}
+		}
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	logrus.Info("Shutting down server and waiting for running jobs")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown server: %w", err)
+	}
+	s.Wait()
+	return nil
+}
+
+// Wait blocks until all jobs are finished.
+func (s *Server) Wait() {
+	s.wg.Wait()
+}
+
+// Handler returns the HTTP handler of the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle(apiPrefix+"/", s.authenticate(http.HandlerFunc(s.route)))
+	for pattern, handler := range s.handlers {
+		mux.Handle(pattern, handler)
+	}
+	return mux
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if s.options.Token == "" ||
+			subtle.ConstantTimeCompare([]byte(token), []byte(s.options.Token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("invalid or missing bearer token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/"), "/")
+
+	switch {
+	case len(parts) == 1 && parts[0] == "operations" && r.Method == http.MethodGet:
+		s.listOperations(w)
+	case len(parts) == 1 && parts[0] == "jobs" && r.Method == http.MethodGet:
+		s.listJobs(w)
+	case len(parts) == 1 && parts[0] == "jobs" && r.Method == http.MethodPost:
+		s.createJob(w, r)
+	case len(parts) == 2 && parts[0] == "jobs" && r.Method == http.MethodGet:
+		s.getJob(w, parts[1])
+	case len(parts) == 3 && parts[0] == "jobs" && parts[2] == "logs" && r.Method == http.MethodGet:
+		s.getJobLogs(w, parts[1])
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s %s not found", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) listOperations(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, s.Operations())
+}
+
+func (s *Server) listJobs(w http.ResponseWriter) {
+	s.mu.RLock()
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, *job)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Created.Before(jobs[j].Created) })
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+func (s *Server) createJob(w http.ResponseWriter, r *http.Request) {
+	req := &JobRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decode request: %w", err))
+		return
+	}
+
+	job, err := s.Submit(req.Operation, req.Parameters)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// Submit starts a new asynchronous job for the operation and returns a
+// snapshot of it.
+func (s *Server) Submit(operation string, params map[string]string) (*Job, error) {
+	op, ok := s.operations[operation]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation %q", operation)
+	}
+	args, err := op.args(params)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &Job{
+		ID:         uuid.NewString(),
+		Operation:  op.Name,
+		Parameters: params,
+		Status:     StatusPending,
+		Created:    time.Now().UTC(),
+		logs:       &syncBuffer{},
+	}
+	s.mu.Lock()
+	s.jobs[job.ID] = job
+	snapshot := *job
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.runJob(job, args)
+
+	logrus.Infof("Created job %s for operation %s", job.ID, op.Name)
+	return &snapshot, nil
+}
+
+// Job returns a snapshot of the job with the provided ID.
+func (s *Server) Job(id string) (*Job, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	snapshot := *job
+	return &snapshot, true
+}
+
+// Logs returns the output of the job.
+func (j *Job) Logs() []byte {
+	if j.logs == nil {
+		return nil
+	}
+	return j.logs.Bytes()
+}
+
+// Operations returns all operations sorted by their name.
+func (s *Server) Operations() []*Operation {
+	ops := make([]*Operation, 0, len(s.operations))
+	for _, op := range s.operations {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Name < ops[j].Name })
+	return ops
+}
+
+// Handle registers an additional handler, which has to take care of its
+// own authentication.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.handlers[pattern] = handler
+}
+
+func (s *Server) runJob(job *Job, args []string) {
+	defer s.wg.Done()
+
+	started := time.Now().UTC()
+	s.mu.Lock()
+	job.Status = StatusRunning
+	job.Started = &started
+	s.mu.Unlock()
+
+	err := s.impl.Execute(s.ctx, args, job.logs)
+
+	finished := time.Now().UTC()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job.Finished = &finished
+	if err != nil {
+		logrus.Warnf("Job %s failed: %v", job.ID, err)
+		job.Status = StatusFailed
+		job.Error = redact.String(err.Error())
+		return
+	}
+	logrus.Infof("Job %s succeeded", job.ID)
+	job.Status = StatusSucceeded
+}
+
+func (s *Server) getJob(w http.ResponseWriter, id string) {
+	job, ok := s.Job(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("job %s not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (s *Server) getJobLogs(w http.ResponseWriter, id string) {
+	job, ok := s.Job(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("job %s not found", id))
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(job.Logs()); err != nil {
+		logrus.Warnf("Unable to write logs of job %s: %v", id, err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logrus.Warnf("Unable to write response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": redact.String(err.Error())})
+}
+
+// syncBuffer is a bytes.Buffer which can be written and read concurrently.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte{}, b.buf.Bytes()...)
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/server"
+	"k8s.io/release/pkg/server/serverfakes"
+)
+
+const testToken = "secret"
+
+func newTestServer(mock *serverfakes.FakeImpl) (*server.Server, *httptest.Server) {
+	sut := server.New(
+		&server.Options{Address: server.DefaultAddress, Token: testToken},
+		&server.Operation{
+			Name:       "stage",
+			Args:       []string{"stage", "--submit"},
+			Parameters: []string{"branch", "type"},
+		},
+	)
+	sut.SetImpl(mock)
+	return sut, httptest.NewServer(sut.Handler())
+}
+
+func request(t *testing.T, method, url, token, body string) (int, string) {
+	req, err := http.NewRequestWithContext(context.Background(), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(content)
+}
+
+func TestOptionsValidate(t *testing.T) {
+	require.NoError(t, (&server.Options{Address: ":8080", Token: "token"}).Validate())
+	require.Error(t, (&server.Options{Address: ":8080"}).Validate())
+	require.Error(t, (&server.Options{Token: "token"}).Validate())
+	require.NoError(t, (&server.Options{
+		Address: ":8443", Token: "token", TLSCertFile: "tls.crt", TLSKeyFile: "tls.key",
+	}).Validate())
+	require.Error(t, (&server.Options{Address: ":8443", Token: "token", TLSCertFile: "tls.crt"}).Validate())
+}
+
+func TestAuthentication(t *testing.T) {
+	_, srv := newTestServer(&serverfakes.FakeImpl{})
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		token    string
+		expected int
+	}{
+		{token: "", expected: http.StatusUnauthorized},
+		{token: "wrong", expected: http.StatusUnauthorized},
+		{token: testToken, expected: http.StatusOK},
+	} {
+		status, _ := request(t, http.MethodGet, srv.URL+"/api/v1/operations", tc.token, "")
+		require.Equal(t, tc.expected, status)
+	}
+
+	status, _ := request(t, http.MethodGet, srv.URL+"/healthz", "", "")
+	require.Equal(t, http.StatusOK, status)
+}
+
+func TestCreateJob(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		body           string
+		prepare        func(*serverfakes.FakeImpl)
+		expectedStatus int
+		expectedJob    server.Status
+		expectedArgs   []string
+	}{
+		{
+			name: "success",
+			body: `{"operation": "stage", "parameters": {"type": "rc", "branch": "release-1.30"}}`,
+			prepare: func(mock *serverfakes.FakeImpl) {
+				mock.ExecuteStub = func(_ context.Context, _ []string, w io.Writer) error {
+					_, err := w.Write([]byte("staging"))
+					return err
+				}
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedJob:    server.StatusSucceeded,
+			expectedArgs:   []string{"stage", "--submit", "--branch=release-1.30", "--type=rc"},
+		},
+		{
+			name: "failure on execute",
+			body: `{"operation": "stage"}`,
+			prepare: func(mock *serverfakes.FakeImpl) {
+				mock.ExecuteReturns(errors.New("error"))
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedJob:    server.StatusFailed,
+			expectedArgs:   []string{"stage", "--submit"},
+		},
+		{
+			name:           "unknown operation",
+			body:           `{"operation": "unknown"}`,
+			prepare:        func(*serverfakes.FakeImpl) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unsupported parameter",
+			body:           `{"operation": "stage", "parameters": {"nomock": "true"}}`,
+			prepare:        func(*serverfakes.FakeImpl) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body",
+			body:           `{`,
+			prepare:        func(*serverfakes.FakeImpl) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &serverfakes.FakeImpl{}
+			tc.prepare(mock)
+			sut, srv := newTestServer(mock)
+			defer srv.Close()
+
+			status, body := request(t, http.MethodPost, srv.URL+"/api/v1/jobs", testToken, tc.body)
+			require.Equal(t, tc.expectedStatus, status, body)
+			if tc.expectedStatus != http.StatusAccepted {
+				require.Zero(t, mock.ExecuteCallCount())
+				return
+			}
+
+			created := &server.Job{}
+			require.NoError(t, json.Unmarshal([]byte(body), created))
+			require.NotEmpty(t, created.ID)
+			sut.Wait()
+
+			require.Equal(t, 1, mock.ExecuteCallCount())
+			_, args, _ := mock.ExecuteArgsForCall(0)
+			require.Equal(t, tc.expectedArgs, args)
+
+			status, body = request(t, http.MethodGet, srv.URL+"/api/v1/jobs/"+created.ID, testToken, "")
+			require.Equal(t, http.StatusOK, status)
+			job := &server.Job{}
+			require.NoError(t, json.Unmarshal([]byte(body), job))
+			require.Equal(t, tc.expectedJob, job.Status)
+			require.NotNil(t, job.Finished)
+
+			status, body = request(t, http.MethodGet, srv.URL+"/api/v1/jobs", testToken, "")
+			require.Equal(t, http.StatusOK, status)
+			jobs := []server.Job{}
+			require.NoError(t, json.Unmarshal([]byte(body), &jobs))
+			require.Len(t, jobs, 1)
+
+			status, body = request(t, http.MethodGet, srv.URL+"/api/v1/jobs/"+created.ID+"/logs", testToken, "")
+			require.Equal(t, http.StatusOK, status)
+			if tc.expectedJob == server.StatusSucceeded {
+				require.Equal(t, "staging", body)
+			}
+		})
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	_, srv := newTestServer(&serverfakes.FakeImpl{})
+	defer srv.Close()
+
+	for _, path := range []string{"/api/v1/jobs/missing", "/api/v1/jobs/missing/logs", "/api/v1/unknown"} {
+		status, _ := request(t, http.MethodGet, srv.URL+path, testToken, "")
+		require.Equal(t, http.StatusNotFound, status, path)
+	}
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signkey selects the key custody used for signing release artifacts
+// and images. Next to keyless Sigstore signing and local key files, keys can
+// be held by GCP KMS, AWS KMS, Azure Key Vault or HashiCorp Vault transit,
+// which gets passed to cosign as key reference.
+package signkey
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sigstore/sigstore/pkg/signature/kms"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-sdk/sign"
+	"sigs.k8s.io/release-utils/env"
+
+	// Register the supported KMS providers
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/aws"
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/azure"
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/gcp"
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/hashivault"
+)
+
+const (
+	// KeyEnvKey is the environment variable containing the default signing
+	// key reference.
+	KeyEnvKey = "KREL_SIGNING_KEY"
+
+	// PublicKeyEnvKey is the environment variable containing the default
+	// public key of a local signing key.
+	PublicKeyEnvKey = "KREL_SIGNING_PUBLIC_KEY"
+)
+
+// Provider is the custody of a signing key.
+type Provider string
+
+const (
+	// ProviderKeyless signs with short lived Sigstore certificates based on
+	// the OIDC identity of the caller.
+	ProviderKeyless Provider = "keyless"
+
+	// ProviderFile signs with a local cosign private key.
+	ProviderFile Provider = "file"
+
+	// ProviderGCPKMS signs with a key of Google Cloud KMS.
+	ProviderGCPKMS Provider = "gcpkms"
+
+	// ProviderAWSKMS signs with a key of AWS KMS.
+	ProviderAWSKMS Provider = "awskms"
+
+	// ProviderAzureKMS signs with a key of Azure Key Vault.
+	ProviderAzureKMS Provider = "azurekms"
+
+	// ProviderHashiVault signs with a transit key of HashiCorp Vault.
+	ProviderHashiVault Provider = "hashivault"
+)
+
+// kmsFormats are the expected key references of the KMS providers.
+var kmsFormats = map[Provider]*regexp.Regexp{
+	ProviderGCPKMS: regexp.MustCompile(
+		`^gcpkms://projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+(/(cryptoKeyVersions|versions)/[^/]+)?$`,
+	),
+	ProviderAWSKMS: regexp.MustCompile(
+		`^awskms://[^/]*/([0-9a-fA-F-]{36}|alias/[^/]+|arn:aws[\w-]*:kms:[^:]+:\d+:(key/[0-9a-fA-F-]{36}|alias/[^/]+))$`,
+	),
+	ProviderAzureKMS:   regexp.MustCompile(`^azurekms://[^/]+/[^/]+(/[^/]+)?$`),
+	ProviderHashiVault: regexp.MustCompile(`^hashivault://[^/]+$`),
+}
+
+// kmsExamples are shown for invalid key references of the KMS providers.
+var kmsExamples = map[Provider]string{
+	ProviderGCPKMS:     "gcpkms://projects/PROJECT/locations/LOCATION/keyRings/RING/cryptoKeys/KEY",
+	ProviderAWSKMS:     "awskms:///alias/NAME",
+	ProviderAzureKMS:   "azurekms://VAULT.vault.azure.net/KEY",
+	ProviderHashiVault: "hashivault://KEY",
+}
+
+// kmsEnvironment are the environment variables required by the KMS providers.
+var kmsEnvironment = map[Provider][]string{
+	ProviderHashiVault: {"VAULT_ADDR", "VAULT_TOKEN"},
+}
+
+// Key is a reference to a signing key.
+type Key struct {
+	// Provider is the custody of the key.
+	Provider Provider
+
+	// Ref is the key reference passed to cosign, which is empty for keyless
+	// signing.
+	Ref string
+
+	// PublicKey is the path to the public key of a local private key, used
+	// for verifying the signatures.
+	PublicKey string
+}
+
+// Parse returns the key of the provided reference. An empty reference or
+// "keyless" selects keyless signing, references with a KMS scheme like
+// gcpkms:// select the KMS provider and all other references are paths to
+// local private keys.
+func Parse(ref string) (*Key, error) {
+	if ref == "" || ref == string(ProviderKeyless) {
+		return &Key{Provider: ProviderKeyless}, nil
+	}
+
+	scheme, _, found := strings.Cut(ref, "://")
+	if !found {
+		return &Key{Provider: ProviderFile, Ref: ref}, nil
+	}
+
+	provider := Provider(scheme)
+	format, ok := kmsFormats[provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported key provider %q", scheme)
+	}
+	if !format.MatchString(ref) {
+		return nil, fmt.Errorf("invalid %s key reference %q, expected %s", provider, ref, kmsExamples[provider])
+	}
+	return &Key{Provider: provider, Ref: ref}, nil
+}
+
+// KMS returns true if the key is held by a KMS provider.
+func (k *Key) KMS() bool {
+	_, ok := kmsFormats[k.Provider]
+	return ok
+}
+
+// Validate checks that the key can be used for signing. KMS providers have
+// to be registered with the sigstore KMS package of the binary, which this
+// package does for all supported ones, and their required environment needs
+// to be set.
+func (k *Key) Validate() error {
+	switch {
+	case k.Provider == ProviderKeyless:
+		return nil
+
+	case k.Provider == ProviderFile:
+		if _, err := os.Stat(k.Ref); err != nil {
+			return fmt.Errorf("signing key: %w", err)
+		}
+		if k.PublicKey == "" {
+			return errors.New("no public key specified for verifying the signatures of the signing key")
+		}
+		return nil
+
+	case k.KMS():
+		if !slices.Contains(kms.SupportedProviders(), string(k.Provider)+"://") {
+			return fmt.Errorf(
+				"the %s provider is not available in this binary, it has to be registered by importing %s/%s",
+				k.Provider, "github.com/sigstore/sigstore/pkg/signature/kms", kmsPackage(k.Provider),
+			)
+		}
+		for _, key := range kmsEnvironment[k.Provider] {
+			if !env.IsSet(key) {
+				return fmt.Errorf("the %s provider requires $%s to be set", k.Provider, key)
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported key provider %q", k.Provider)
+	}
+}
+
+// kmsPackage returns the name of the sigstore package of the KMS provider.
+func kmsPackage(provider Provider) string {
+	switch provider {
+	case ProviderGCPKMS:
+		return "gcp"
+	case ProviderAWSKMS:
+		return "aws"
+	case ProviderAzureKMS:
+		return "azure"
+	default:
+		return "hashivault"
+	}
+}
+
+// Apply sets the key references of the signing options. KMS keys are used
+// for signing as well as verifying.
+func (k *Key) Apply(opts *sign.Options) {
+	switch {
+	case k.Provider == ProviderFile:
+		opts.PrivateKeyPath = k.Ref
+		opts.PublicKeyPath = k.PublicKey
+	case k.KMS():
+		opts.PrivateKeyPath = k.Ref
+		opts.PublicKeyPath = k.Ref
+	default:
+		opts.PrivateKeyPath = ""
+		opts.PublicKeyPath = ""
+	}
+}
+
+// SignOptions returns the default signing options using the key.
+func (k *Key) SignOptions() *sign.Options {
+	opts := sign.Default()
+	k.Apply(opts)
+	return opts
+}
+
+// String returns a printable representation of the key.
+func (k *Key) String() string {
+	if k.Provider == ProviderKeyless {
+		return string(ProviderKeyless)
+	}
+	return k.Ref
+}
+
+// Options are the options for selecting the signing key.
+type Options struct {
+	// Key is the signing key reference, see Parse.
+	Key string
+
+	// PublicKey is the path to the public key of a local signing key.
+	PublicKey string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Key:       env.Default(KeyEnvKey, ""),
+		PublicKey: env.Default(PublicKeyEnvKey, ""),
+	}
+}
+
+// AddFlags adds the signing key flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Key,
+		"signing-key",
+		o.Key,
+		fmt.Sprintf("signing key reference: keyless, a local key path or a gcpkms://, awskms://, azurekms:// or hashivault:// KMS key (default $%s or keyless)", KeyEnvKey),
+	)
+	flags.StringVar(
+		&o.PublicKey,
+		"signing-public-key",
+		o.PublicKey,
+		fmt.Sprintf("public key of a local signing key (default $%s)", PublicKeyEnvKey),
+	)
+}
+
+var (
+	mu      sync.RWMutex
+	current = &Key{Provider: ProviderKeyless}
+)
+
+// Setup parses and validates the key of the provided options and uses it
+// for signing.
+func Setup(opts *Options) error {
+	key, err := Parse(opts.Key)
+	if err != nil {
+		return err
+	}
+	key.PublicKey = opts.PublicKey
+	if err := key.Validate(); err != nil {
+		return err
+	}
+	if key.Provider != ProviderKeyless {
+		logrus.Infof("Using %s signing key %s", key.Provider, key)
+	}
+	SetDefault(key)
+	return nil
+}
+
+// SetDefault sets the key used for signing. A nil key restores keyless
+// signing.
+func SetDefault(key *Key) {
+	mu.Lock()
+	defer mu.Unlock()
+	if key == nil {
+		key = &Key{Provider: ProviderKeyless}
+	}
+	current = key
+}
+
+// Default returns the key used for signing.
+func Default() *Key {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signkey_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/signkey"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		ref      string
+		provider signkey.Provider
+		wantErr  string
+	}{
+		{ref: "", provider: signkey.ProviderKeyless},
+		{ref: "keyless", provider: signkey.ProviderKeyless},
+		{ref: "cosign.key", provider: signkey.ProviderFile},
+		{ref: "/etc/keys/cosign.key", provider: signkey.ProviderFile},
+		{
+			ref:      "gcpkms://projects/k8s-releng-prod/locations/global/keyRings/release/cryptoKeys/signing",
+			provider: signkey.ProviderGCPKMS,
+		},
+		{
+			ref:      "gcpkms://projects/k8s-releng-prod/locations/global/keyRings/release/cryptoKeys/signing/versions/2",
+			provider: signkey.ProviderGCPKMS,
+		},
+		{ref: "gcpkms://projects/k8s-releng-prod/keyRings/release", wantErr: "invalid gcpkms key reference"},
+		{ref: "awskms:///alias/release-signing", provider: signkey.ProviderAWSKMS},
+		{ref: "awskms://localhost:4566/1234abcd-12ab-34cd-56ef-1234567890ab", provider: signkey.ProviderAWSKMS},
+		{
+			ref:      "awskms:///arn:aws:kms:us-east-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			provider: signkey.ProviderAWSKMS,
+		},
+		{ref: "awskms:///release-signing", wantErr: "invalid awskms key reference"},
+		{ref: "azurekms://release.vault.azure.net/signing", provider: signkey.ProviderAzureKMS},
+		{ref: "azurekms://release.vault.azure.net", wantErr: "invalid azurekms key reference"},
+		{ref: "hashivault://release-signing", provider: signkey.ProviderHashiVault},
+		{ref: "hashivault://transit/release-signing", wantErr: "invalid hashivault key reference"},
+		{ref: "pkcs11://token", wantErr: `unsupported key provider "pkcs11"`},
+	} {
+		t.Run(tc.ref, func(t *testing.T) {
+			key, err := signkey.Parse(tc.ref)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.provider, key.Provider)
+			require.Equal(t, tc.provider != signkey.ProviderKeyless && tc.provider != signkey.ProviderFile, key.KMS())
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "cosign.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("key"), 0o600))
+
+	for _, tc := range []struct {
+		name    string
+		key     *signkey.Key
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name: "keyless",
+			key:  &signkey.Key{Provider: signkey.ProviderKeyless},
+		},
+		{
+			name: "key file",
+			key:  &signkey.Key{Provider: signkey.ProviderFile, Ref: keyFile, PublicKey: "cosign.pub"},
+		},
+		{
+			name:    "missing key file",
+			key:     &signkey.Key{Provider: signkey.ProviderFile, Ref: filepath.Join(dir, "missing"), PublicKey: "cosign.pub"},
+			wantErr: "no such file",
+		},
+		{
+			name:    "key file without public key",
+			key:     &signkey.Key{Provider: signkey.ProviderFile, Ref: keyFile},
+			wantErr: "no public key",
+		},
+		{
+			name: "KMS provider",
+			key:  &signkey.Key{Provider: signkey.ProviderHashiVault, Ref: "hashivault://release"},
+			env:  map[string]string{"VAULT_ADDR": "https://vault", "VAULT_TOKEN": "token"},
+		},
+		{
+			name:    "KMS provider without environment",
+			key:     &signkey.Key{Provider: signkey.ProviderHashiVault, Ref: "hashivault://release"},
+			env:     map[string]string{"VAULT_ADDR": "https://vault", "VAULT_TOKEN": ""},
+			wantErr: "$VAULT_TOKEN",
+		},
+		{
+			name: "GCP KMS provider",
+			key:  &signkey.Key{Provider: signkey.ProviderGCPKMS, Ref: "gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/k"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+				if v == "" {
+					require.NoError(t, os.Unsetenv(k))
+				}
+			}
+			err := tc.key.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignOptions(t *testing.T) {
+	kms := &signkey.Key{Provider: signkey.ProviderAWSKMS, Ref: "awskms:///alias/release"}
+	opts := kms.SignOptions()
+	require.Equal(t, kms.Ref, opts.PrivateKeyPath)
+	require.Equal(t, kms.Ref, opts.PublicKeyPath)
+
+	file := &signkey.Key{Provider: signkey.ProviderFile, Ref: "cosign.key", PublicKey: "cosign.pub"}
+	opts = file.SignOptions()
+	require.Equal(t, "cosign.key", opts.PrivateKeyPath)
+	require.Equal(t, "cosign.pub", opts.PublicKeyPath)
+
+	keyless := &signkey.Key{Provider: signkey.ProviderKeyless}
+	keyless.Apply(opts)
+	require.Empty(t, opts.PrivateKeyPath)
+	require.Empty(t, opts.PublicKeyPath)
+}
+
+func TestSetup(t *testing.T) {
+	t.Cleanup(func() { signkey.SetDefault(nil) })
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "cosign.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("key"), 0o600))
+
+	require.NoError(t, signkey.Setup(&signkey.Options{Key: keyFile, PublicKey: "cosign.pub"}))
+	require.Equal(t, signkey.ProviderFile, signkey.Default().Provider)
+	require.Equal(t, "cosign.pub", signkey.Default().PublicKey)
+
+	require.Error(t, signkey.Setup(&signkey.Options{Key: "gcpkms://invalid"}))
+	require.Equal(t, signkey.ProviderFile, signkey.Default().Provider)
+
+	require.NoError(t, signkey.Setup(signkey.DefaultOptions()))
+	require.Equal(t, signkey.ProviderKeyless, signkey.Default().Provider)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sizereport
+
+import (
+	"os"
+
+	"sigs.k8s.io/release-sdk/object"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt sizereportfakes/fake_impl.go > sizereportfakes/_fake_impl.go && mv sizereportfakes/_fake_impl.go sizereportfakes/fake_impl.go"
+type impl interface {
+	PathExists(gcsPath string) (bool, error)
+	CopyToLocal(gcsPath, dst string) error
+	CopyToRemote(src, gcsPath string) error
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm os.FileMode) error
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) PathExists(gcsPath string) (bool, error) {
+	return object.NewGCS().PathExists(gcsPath)
+}
+
+func (*defaultImpl) CopyToLocal(gcsPath, dst string) error {
+	return object.NewGCS().CopyToLocal(gcsPath, dst)
+}
+
+func (*defaultImpl) CopyToRemote(src, gcsPath string) error {
+	gcs := object.NewGCS()
+	gcs.SetOptions(gcs.WithNoClobber(false))
+	return gcs.CopyToRemote(src, gcsPath)
+}
+
+func (*defaultImpl) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (*defaultImpl) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
@@ -0,0 +1,411 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sizereport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-sdk/object"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/workdir"
+)
+
+const (
+	// HistoryFile is the file name of the persisted artifact sizes in the
+	// bucket.
+	HistoryFile = "artifact-sizes.json"
+
+	// DefaultThreshold is the default growth in percent since the previous
+	// release, from which on an artifact gets reported.
+	DefaultThreshold = 10.0
+
+	// KindArtifact is the kind of files pushed to the bucket.
+	KindArtifact = "artifact"
+
+	// KindImage is the kind of container image tarballs.
+	KindImage = "image"
+)
+
+// Options are the main options for reporting artifact sizes.
+type Options struct {
+	// Bucket is the bucket where the sizes are persisted.
+	Bucket string
+
+	// Threshold is the growth in percent since the previous release, from
+	// which on an artifact gets reported.
+	Threshold float64
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{Threshold: DefaultThreshold}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Bucket == "" {
+		return errors.New("bucket must not be empty")
+	}
+	if o.Threshold <= 0 {
+		return fmt.Errorf("threshold has to be positive, got %v", o.Threshold)
+	}
+	return nil
+}
+
+// Entry is the size of a single artifact.
+type Entry struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	Size int64  `json:"size"`
+}
+
+// Sizes are the artifact sizes of a single release.
+type Sizes struct {
+	Version   string   `json:"version"`
+	Artifacts []*Entry `json:"artifacts"`
+}
+
+// History contains the artifact sizes of all recorded releases.
+type History struct {
+	Releases []*Sizes `json:"releases"`
+}
+
+// Previous returns the sizes of the highest recorded release lower than the
+// provided version, or nil if none exists.
+func (h *History) Previous(version string) *Sizes {
+	current, err := util.TagStringToSemver(version)
+	if err != nil {
+		logrus.Warnf("Unable to parse version %s: %v", version, err)
+		return nil
+	}
+
+	var (
+		previous        *Sizes
+		previousVersion semver.Version
+	)
+	for _, sizes := range h.Releases {
+		v, err := util.TagStringToSemver(sizes.Version)
+		if err != nil || !v.LT(current) {
+			continue
+		}
+		if previous == nil || v.GT(previousVersion) {
+			previous = sizes
+			previousVersion = v
+		}
+	}
+	return previous
+}
+
+// Get returns the recorded sizes of the version, or nil if none exist.
+func (h *History) Get(version string) *Sizes {
+	for _, sizes := range h.Releases {
+		if sizes.Version == version {
+			return sizes
+		}
+	}
+	return nil
+}
+
+// Set adds the provided sizes to the history or replaces the ones of the
+// same version.
+func (h *History) Set(sizes *Sizes) {
+	for i := range h.Releases {
+		if h.Releases[i].Version == sizes.Version {
+			h.Releases[i] = sizes
+			return
+		}
+	}
+	h.Releases = append(h.Releases, sizes)
+}
+
+// Regression is an artifact which grew beyond the threshold.
+type Regression struct {
+	Name         string  `json:"name"`
+	Kind         string  `json:"kind"`
+	PreviousSize int64   `json:"previousSize"`
+	Size         int64   `json:"size"`
+	Growth       float64 `json:"growth"`
+}
+
+// Report is the result of comparing the artifact sizes of two releases.
+type Report struct {
+	Version         string        `json:"version"`
+	PreviousVersion string        `json:"previousVersion,omitempty"`
+	Threshold       float64       `json:"threshold"`
+	Regressions     []*Regression `json:"regressions"`
+}
+
+// Compare returns a report of all artifacts of current, which grew more than
+// threshold percent since previous. Artifacts which did not exist in previous
+// are not reported.
+func Compare(previous, current *Sizes, threshold float64) *Report {
+	report := &Report{
+		Version:     current.Version,
+		Threshold:   threshold,
+		Regressions: []*Regression{},
+	}
+	if previous == nil {
+		return report
+	}
+	report.PreviousVersion = previous.Version
+
+	previousSizes := map[string]int64{}
+	for _, entry := range previous.Artifacts {
+		previousSizes[entry.Kind+"/"+entry.Name] = entry.Size
+	}
+
+	for _, entry := range current.Artifacts {
+		previousSize, ok := previousSizes[entry.Kind+"/"+entry.Name]
+		if !ok || previousSize <= 0 {
+			continue
+		}
+		growth := float64(entry.Size-previousSize) / float64(previousSize) * 100
+		if growth <= threshold {
+			continue
+		}
+		report.Regressions = append(report.Regressions, &Regression{
+			Name:         entry.Name,
+			Kind:         entry.Kind,
+			PreviousSize: previousSize,
+			Size:         entry.Size,
+			Growth:       growth,
+		})
+	}
+	sort.Slice(report.Regressions, func(i, j int) bool {
+		return report.Regressions[i].Growth > report.Regressions[j].Growth
+	})
+	return report
+}
+
+// Markdown returns the report as markdown, suitable for a GitHub comment.
+func (r *Report) Markdown() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "### Artifact size report for %s\n\n", r.Version)
+	if len(r.Regressions) == 0 {
+		fmt.Fprintf(buf, "No artifact grew by more than %.0f%% since %s.\n", r.Threshold, r.PreviousVersion)
+		return buf.String()
+	}
+	fmt.Fprintf(
+		buf, "The following artifacts grew by more than %.0f%% since %s:\n\n",
+		r.Threshold, r.PreviousVersion,
+	)
+
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeader([]string{"Kind", "Artifact", "Previous", "Current", "Growth"})
+	for _, r := range r.Regressions {
+		table.Append([]string{
+			r.Kind, r.Name, FormatSize(r.PreviousSize), FormatSize(r.Size),
+			fmt.Sprintf("+%.1f%%", r.Growth),
+		})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+	return buf.String()
+}
+
+// FormatSize returns a human readable representation of size in bytes.
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// Measure collects the sizes of all artifacts below stageDir and all
+// container image tarballs below imagesDir. Checksum files are skipped.
+func Measure(version, stageDir, imagesDir string) (*Sizes, error) {
+	sizes := &Sizes{Version: version, Artifacts: []*Entry{}}
+	for _, dir := range []struct{ path, kind string }{
+		{stageDir, KindArtifact},
+		{imagesDir, KindImage},
+	} {
+		if dir.path == "" || !util.Exists(dir.path) {
+			logrus.Infof("Skipping not existing %s dir %s", dir.kind, dir.path)
+			continue
+		}
+		if err := filepath.Walk(dir.path, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || skipFile(dir.kind, path) {
+				return nil
+			}
+			name, err := filepath.Rel(dir.path, path)
+			if err != nil {
+				return err
+			}
+			sizes.Artifacts = append(sizes.Artifacts, &Entry{
+				Name: filepath.ToSlash(name), Kind: dir.kind, Size: info.Size(),
+			})
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("walk %s dir %s: %w", dir.kind, dir.path, err)
+		}
+	}
+	return sizes, nil
+}
+
+func skipFile(kind, path string) bool {
+	if kind == KindImage {
+		return !strings.HasSuffix(path, ".tar")
+	}
+	for _, suffix := range []string{".sha1", ".sha256", ".sha512", ".md5"} {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SizeReport is the main structure for tracking artifact sizes.
+type SizeReport struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new SizeReport instance.
+func New(opts *Options) *SizeReport {
+	return &SizeReport{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (s *SizeReport) SetImpl(impl impl) {
+	s.impl = impl
+}
+
+// Run measures the artifacts of the provided version, compares them with the
+// previous release and persists the sizes in the bucket.
+func (s *SizeReport) Run(version, stageDir, imagesDir string) (*Report, error) {
+	if err := s.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	sizes, err := Measure(version, stageDir, imagesDir)
+	if err != nil {
+		return nil, fmt.Errorf("measure artifacts: %w", err)
+	}
+
+	historyPath, err := s.historyPath()
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.loadHistory(historyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := Compare(history.Previous(version), sizes, s.options.Threshold)
+	if report.PreviousVersion == "" {
+		logrus.Infof("No previous release found for %s, not comparing artifact sizes", version)
+	}
+
+	history.Set(sizes)
+	if err := s.saveHistory(historyPath, history); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *SizeReport) historyPath() (string, error) {
+	markerPath, err := layout.Default().MarkerPath(s.options.Bucket, "release", false)
+	if err != nil {
+		return "", fmt.Errorf("get history path: %w", err)
+	}
+	return object.GcsPrefix + markerPath + "/" + HistoryFile, nil
+}
+
+func (s *SizeReport) loadHistory(historyPath string) (*History, error) {
+	history := &History{}
+	exists, err := s.impl.PathExists(historyPath)
+	if err != nil {
+		return nil, fmt.Errorf("check if %s exists: %w", historyPath, err)
+	}
+	if !exists {
+		logrus.Infof("No artifact size history found in %s", historyPath)
+		return history, nil
+	}
+
+	tempDir, err := workdir.MkdirTemp("artifact-sizes-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	localPath := filepath.Join(tempDir, HistoryFile)
+	if err := s.impl.CopyToLocal(historyPath, localPath); err != nil {
+		return nil, fmt.Errorf("download %s: %w", historyPath, err)
+	}
+	content, err := s.impl.ReadFile(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("read artifact size history: %w", err)
+	}
+	if err := json.Unmarshal(content, history); err != nil {
+		return nil, fmt.Errorf("unmarshal artifact size history: %w", err)
+	}
+	return history, nil
+}
+
+func (s *SizeReport) saveHistory(historyPath string, history *History) error {
+	content, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal artifact size history: %w", err)
+	}
+
+	tempDir, err := workdir.MkdirTemp("artifact-sizes-")
+	if err != nil {
+		return fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	localPath := filepath.Join(tempDir, HistoryFile)
+	if err := s.impl.WriteFile(localPath, content, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("write artifact size history: %w", err)
+	}
+	if err := s.impl.CopyToRemote(localPath, historyPath); err != nil {
+		return fmt.Errorf("upload %s: %w", historyPath, err)
+	}
+	logrus.Infof("Persisted artifact sizes of %d releases in %s", len(history.Releases), historyPath)
+	return nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sizereport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/sizereport"
+	"k8s.io/release/pkg/sizereport/sizereportfakes"
+)
+
+var errTest = errors.New("test")
+
+func testSizes(version string, kubectl, apiserver int64) *sizereport.Sizes {
+	return &sizereport.Sizes{
+		Version: version,
+		Artifacts: []*sizereport.Entry{
+			{Name: "bin/linux/amd64/kubectl", Kind: sizereport.KindArtifact, Size: kubectl},
+			{Name: "amd64/kube-apiserver.tar", Kind: sizereport.KindImage, Size: apiserver},
+		},
+	}
+}
+
+func TestHistoryPrevious(t *testing.T) {
+	history := &sizereport.History{Releases: []*sizereport.Sizes{
+		testSizes("v1.29.0", 1, 1),
+		testSizes("v1.29.2", 1, 1),
+		testSizes("v1.29.1", 1, 1),
+		testSizes("v1.30.0", 1, 1),
+	}}
+
+	require.Equal(t, "v1.29.2", history.Previous("v1.29.3").Version)
+	require.Equal(t, "v1.29.2", history.Previous("v1.30.0-rc.0").Version)
+	require.Equal(t, "v1.29.0", history.Previous("v1.29.1").Version)
+	require.Nil(t, history.Previous("v1.28.0"))
+	require.Nil(t, history.Previous("invalid"))
+	require.Equal(t, "v1.29.1", history.Get("v1.29.1").Version)
+	require.Nil(t, history.Get("v1.29.3"))
+
+	history.Set(testSizes("v1.29.2", 2, 2))
+	require.Len(t, history.Releases, 4)
+	require.EqualValues(t, 2, history.Previous("v1.29.3").Artifacts[0].Size)
+}
+
+func TestCompare(t *testing.T) {
+	previous := testSizes("v1.29.0", 100, 100)
+	current := testSizes("v1.29.1", 105, 150)
+	current.Artifacts = append(current.Artifacts, &sizereport.Entry{
+		Name: "new", Kind: sizereport.KindArtifact, Size: 1000,
+	})
+
+	report := sizereport.Compare(previous, current, 10)
+	require.Equal(t, "v1.29.0", report.PreviousVersion)
+	require.Len(t, report.Regressions, 1)
+	require.Equal(t, "amd64/kube-apiserver.tar", report.Regressions[0].Name)
+	require.InDelta(t, 50.0, report.Regressions[0].Growth, 0.001)
+	require.Contains(t, report.Markdown(), "| image | amd64/kube-apiserver.tar | 100 B    | 150 B   | +50.0% |")
+
+	require.Empty(t, sizereport.Compare(nil, current, 10).Regressions)
+	require.Empty(t, sizereport.Compare(previous, current, 60).Regressions)
+}
+
+func TestMeasure(t *testing.T) {
+	dir := t.TempDir()
+	stageDir := filepath.Join(dir, "gcs-stage")
+	imagesDir := filepath.Join(dir, "release-images")
+	for path, size := range map[string]int{
+		filepath.Join(stageDir, "bin", "linux", "amd64", "kubectl"):        10,
+		filepath.Join(stageDir, "bin", "linux", "amd64", "kubectl.sha256"): 64,
+		filepath.Join(stageDir, "kubernetes.tar.gz"):                       20,
+		filepath.Join(imagesDir, "amd64", "kube-apiserver.tar"):            30,
+		filepath.Join(imagesDir, "amd64", "kube-apiserver.docker_tag"):     5,
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)))
+		require.NoError(t, os.WriteFile(path, make([]byte, size), os.FileMode(0o644)))
+	}
+
+	sizes, err := sizereport.Measure("v1.29.0", stageDir, imagesDir)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*sizereport.Entry{
+		{Name: "bin/linux/amd64/kubectl", Kind: sizereport.KindArtifact, Size: 10},
+		{Name: "kubernetes.tar.gz", Kind: sizereport.KindArtifact, Size: 20},
+		{Name: "amd64/kube-apiserver.tar", Kind: sizereport.KindImage, Size: 30},
+	}, sizes.Artifacts)
+
+	sizes, err = sizereport.Measure("v1.29.0", stageDir, filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	require.Len(t, sizes.Artifacts, 2)
+}
+
+func TestRun(t *testing.T) {
+	stageDir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(stageDir, "kubectl"), make([]byte, 200), os.FileMode(0o644),
+	))
+
+	history, err := json.Marshal(&sizereport.History{Releases: []*sizereport.Sizes{{
+		Version:   "v1.29.0",
+		Artifacts: []*sizereport.Entry{{Name: "kubectl", Kind: sizereport.KindArtifact, Size: 100}},
+	}}})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		prepare func(*sizereportfakes.FakeImpl)
+		assert  func(*sizereportfakes.FakeImpl, *sizereport.Report, error)
+	}{
+		{ // success with history
+			prepare: func(mock *sizereportfakes.FakeImpl) {
+				mock.PathExistsReturns(true, nil)
+				mock.ReadFileReturns(history, nil)
+			},
+			assert: func(mock *sizereportfakes.FakeImpl, report *sizereport.Report, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "v1.29.0", report.PreviousVersion)
+				require.Len(t, report.Regressions, 1)
+
+				src, dst := mock.CopyToLocalArgsForCall(0)
+				require.Equal(t, "gs://bucket/release/"+sizereport.HistoryFile, src)
+				require.Equal(t, sizereport.HistoryFile, filepath.Base(dst))
+
+				require.Equal(t, 1, mock.CopyToRemoteCallCount())
+				_, content, _ := mock.WriteFileArgsForCall(0)
+				res := &sizereport.History{}
+				require.NoError(t, json.Unmarshal(content, res))
+				require.Len(t, res.Releases, 2)
+			},
+		},
+		{ // success without history
+			prepare: func(*sizereportfakes.FakeImpl) {},
+			assert: func(mock *sizereportfakes.FakeImpl, report *sizereport.Report, err error) {
+				require.NoError(t, err)
+				require.Empty(t, report.PreviousVersion)
+				require.Empty(t, report.Regressions)
+				require.Zero(t, mock.CopyToLocalCallCount())
+				require.Equal(t, 1, mock.CopyToRemoteCallCount())
+			},
+		},
+		{ // failure PathExists
+			prepare: func(mock *sizereportfakes.FakeImpl) {
+				mock.PathExistsReturns(false, errTest)
+			},
+			assert: func(mock *sizereportfakes.FakeImpl, _ *sizereport.Report, err error) {
+				require.ErrorIs(t, err, errTest)
+				require.Zero(t, mock.CopyToRemoteCallCount())
+			},
+		},
+		{ // failure invalid history
+			prepare: func(mock *sizereportfakes.FakeImpl) {
+				mock.PathExistsReturns(true, nil)
+				mock.ReadFileReturns([]byte("invalid"), nil)
+			},
+			assert: func(mock *sizereportfakes.FakeImpl, _ *sizereport.Report, err error) {
+				require.Error(t, err)
+				require.Zero(t, mock.CopyToRemoteCallCount())
+			},
+		},
+		{ // failure CopyToRemote
+			prepare: func(mock *sizereportfakes.FakeImpl) {
+				mock.CopyToRemoteReturns(errTest)
+			},
+			assert: func(_ *sizereportfakes.FakeImpl, _ *sizereport.Report, err error) {
+				require.ErrorIs(t, err, errTest)
+			},
+		},
+	} {
+		opts := sizereport.DefaultOptions()
+		opts.Bucket = "bucket"
+		sut := sizereport.New(opts)
+		mock := &sizereportfakes.FakeImpl{}
+		tc.prepare(mock)
+		sut.SetImpl(mock)
+
+		report, err := sut.Run("v1.29.1", stageDir, "")
+		tc.assert(mock, report, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	opts := sizereport.DefaultOptions()
+	require.Error(t, opts.Validate())
+
+	opts.Bucket = "bucket"
+	require.NoError(t, opts.Validate())
+
+	opts.Threshold = 0
+	require.Error(t, opts.Validate())
+}
@@ -0,0 +1,443 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package sizereportfakes
+
+import (
+	"io/fs"
+	"sync"
+)
+
+type FakeImpl struct {
+	CopyToLocalStub        func(string, string) error
+	copyToLocalMutex       sync.RWMutex
+	copyToLocalArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	copyToLocalReturns struct {
+		result1 error
+	}
+	copyToLocalReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CopyToRemoteStub        func(string, string) error
+	copyToRemoteMutex       sync.RWMutex
+	copyToRemoteArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	copyToRemoteReturns struct {
+		result1 error
+	}
+	copyToRemoteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PathExistsStub        func(string) (bool, error)
+	pathExistsMutex       sync.RWMutex
+	pathExistsArgsForCall []struct {
+		arg1 string
+	}
+	pathExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	pathExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ReadFileStub        func(string) ([]byte, error)
+	readFileMutex       sync.RWMutex
+	readFileArgsForCall []struct {
+		arg1 string
+	}
+	readFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	WriteFileStub        func(string, []byte, fs.FileMode) error
+	writeFileMutex       sync.RWMutex
+	writeFileArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+		arg3 fs.FileMode
+	}
+	writeFileReturns struct {
+		result1 error
+	}
+	writeFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) CopyToLocal(arg1 string, arg2 string) error {
+	fake.copyToLocalMutex.Lock()
+	ret, specificReturn := fake.copyToLocalReturnsOnCall[len(fake.copyToLocalArgsForCall)]
+	fake.copyToLocalArgsForCall = append(fake.copyToLocalArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CopyToLocalStub
+	fakeReturns := fake.copyToLocalReturns
+	fake.recordInvocation("CopyToLocal", []interface{}{arg1, arg2})
+	fake.copyToLocalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CopyToLocalCallCount() int {
+	fake.copyToLocalMutex.RLock()
+	defer fake.copyToLocalMutex.RUnlock()
+	return len(fake.copyToLocalArgsForCall)
+}
+
+func (fake *FakeImpl) CopyToLocalCalls(stub func(string, string) error) {
+	fake.copyToLocalMutex.Lock()
+	defer fake.copyToLocalMutex.Unlock()
+	fake.CopyToLocalStub = stub
+}
+
+func (fake *FakeImpl) CopyToLocalArgsForCall(i int) (string, string) {
+	fake.copyToLocalMutex.RLock()
+	defer fake.copyToLocalMutex.RUnlock()
+	argsForCall := fake.copyToLocalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) CopyToLocalReturns(result1 error) {
+	fake.copyToLocalMutex.Lock()
+	defer fake.copyToLocalMutex.Unlock()
+	fake.CopyToLocalStub = nil
+	fake.copyToLocalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CopyToLocalReturnsOnCall(i int, result1 error) {
+	fake.copyToLocalMutex.Lock()
+	defer fake.copyToLocalMutex.Unlock()
+	fake.CopyToLocalStub = nil
+	if fake.copyToLocalReturnsOnCall == nil {
+		fake.copyToLocalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.copyToLocalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CopyToRemote(arg1 string, arg2 string) error {
+	fake.copyToRemoteMutex.Lock()
+	ret, specificReturn := fake.copyToRemoteReturnsOnCall[len(fake.copyToRemoteArgsForCall)]
+	fake.copyToRemoteArgsForCall = append(fake.copyToRemoteArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CopyToRemoteStub
+	fakeReturns := fake.copyToRemoteReturns
+	fake.recordInvocation("CopyToRemote", []interface{}{arg1, arg2})
+	fake.copyToRemoteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) CopyToRemoteCallCount() int {
+	fake.copyToRemoteMutex.RLock()
+	defer fake.copyToRemoteMutex.RUnlock()
+	return len(fake.copyToRemoteArgsForCall)
+}
+
+func (fake *FakeImpl) CopyToRemoteCalls(stub func(string, string) error) {
+	fake.copyToRemoteMutex.Lock()
+	defer fake.copyToRemoteMutex.Unlock()
+	fake.CopyToRemoteStub = stub
+}
+
+func (fake *FakeImpl) CopyToRemoteArgsForCall(i int) (string, string) {
+	fake.copyToRemoteMutex.RLock()
+	defer fake.copyToRemoteMutex.RUnlock()
+	argsForCall := fake.copyToRemoteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) CopyToRemoteReturns(result1 error) {
+	fake.copyToRemoteMutex.Lock()
+	defer fake.copyToRemoteMutex.Unlock()
+	fake.CopyToRemoteStub = nil
+	fake.copyToRemoteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) CopyToRemoteReturnsOnCall(i int, result1 error) {
+	fake.copyToRemoteMutex.Lock()
+	defer fake.copyToRemoteMutex.Unlock()
+	fake.CopyToRemoteStub = nil
+	if fake.copyToRemoteReturnsOnCall == nil {
+		fake.copyToRemoteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.copyToRemoteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) PathExists(arg1 string) (bool, error) {
+	fake.pathExistsMutex.Lock()
+	ret, specificReturn := fake.pathExistsReturnsOnCall[len(fake.pathExistsArgsForCall)]
+	fake.pathExistsArgsForCall = append(fake.pathExistsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.PathExistsStub
+	fakeReturns := fake.pathExistsReturns
+	fake.recordInvocation("PathExists", []interface{}{arg1})
+	fake.pathExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) PathExistsCallCount() int {
+	fake.pathExistsMutex.RLock()
+	defer fake.pathExistsMutex.RUnlock()
+	return len(fake.pathExistsArgsForCall)
+}
+
+func (fake *FakeImpl) PathExistsCalls(stub func(string) (bool, error)) {
+	fake.pathExistsMutex.Lock()
+	defer fake.pathExistsMutex.Unlock()
+	fake.PathExistsStub = stub
+}
+
+func (fake *FakeImpl) PathExistsArgsForCall(i int) string {
+	fake.pathExistsMutex.RLock()
+	defer fake.pathExistsMutex.RUnlock()
+	argsForCall := fake.pathExistsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) PathExistsReturns(result1 bool, result2 error) {
+	fake.pathExistsMutex.Lock()
+	defer fake.pathExistsMutex.Unlock()
+	fake.PathExistsStub = nil
+	fake.pathExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) PathExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.pathExistsMutex.Lock()
+	defer fake.pathExistsMutex.Unlock()
+	fake.PathExistsStub = nil
+	if fake.pathExistsReturnsOnCall == nil {
+		fake.pathExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.pathExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadFile(arg1 string) ([]byte, error) {
+	fake.readFileMutex.Lock()
+	ret, specificReturn := fake.readFileReturnsOnCall[len(fake.readFileArgsForCall)]
+	fake.readFileArgsForCall = append(fake.readFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReadFileStub
+	fakeReturns := fake.readFileReturns
+	fake.recordInvocation("ReadFile", []interface{}{arg1})
+	fake.readFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ReadFileCallCount() int {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	return len(fake.readFileArgsForCall)
+}
+
+func (fake *FakeImpl) ReadFileCalls(stub func(string) ([]byte, error)) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = stub
+}
+
+func (fake *FakeImpl) ReadFileArgsForCall(i int) string {
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	argsForCall := fake.readFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ReadFileReturns(result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	fake.readFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReadFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readFileMutex.Lock()
+	defer fake.readFileMutex.Unlock()
+	fake.ReadFileStub = nil
+	if fake.readFileReturnsOnCall == nil {
+		fake.readFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte, arg3 fs.FileMode) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeFileMutex.Lock()
+	ret, specificReturn := fake.writeFileReturnsOnCall[len(fake.writeFileArgsForCall)]
+	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+		arg3 fs.FileMode
+	}{arg1, arg2Copy, arg3})
+	stub := fake.WriteFileStub
+	fakeReturns := fake.writeFileReturns
+	fake.recordInvocation("WriteFile", []interface{}{arg1, arg2Copy, arg3})
+	fake.writeFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) WriteFileCallCount() int {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return len(fake.writeFileArgsForCall)
+}
+
+func (fake *FakeImpl) WriteFileCalls(stub func(string, []byte, fs.FileMode) error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = stub
+}
+
+func (fake *FakeImpl) WriteFileArgsForCall(i int) (string, []byte, fs.FileMode) {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	argsForCall := fake.writeFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) WriteFileReturns(result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	fake.writeFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteFileReturnsOnCall(i int, result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	if fake.writeFileReturnsOnCall == nil {
+		fake.writeFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.copyToLocalMutex.RLock()
+	defer fake.copyToLocalMutex.RUnlock()
+	fake.copyToRemoteMutex.RLock()
+	defer fake.copyToRemoteMutex.RUnlock()
+	fake.pathExistsMutex.RLock()
+	defer fake.pathExistsMutex.RUnlock()
+	fake.readFileMutex.RLock()
+	defer fake.readFileMutex.RUnlock()
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
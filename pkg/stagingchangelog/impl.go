@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stagingchangelog
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	gogithub "github.com/google/go-github/v58/github"
+
+	"sigs.k8s.io/release-sdk/github"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/document"
+	"k8s.io/release/pkg/notes/options"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt stagingchangelogfakes/fake_impl.go > stagingchangelogfakes/_fake_impl.go && mv stagingchangelogfakes/_fake_impl.go stagingchangelogfakes/fake_impl.go"
+type impl interface {
+	Changelog(repoPath, branch, path, startRev, endRev string) (string, error)
+	TagExists(org, repo, tag string) (bool, error)
+	ReleaseID(org, repo, tag string) (int64, error)
+	UpdateReleasePage(org, repo string, id int64, tag, name, body string, prerelease bool) error
+	WriteFile(name string, data []byte) error
+}
+
+type defaultImpl struct{}
+
+// Changelog renders the markdown release notes of the kubernetes/kubernetes
+// changes touching the path between both revisions of the branch.
+func (*defaultImpl) Changelog(repoPath, branch, path, startRev, endRev string) (string, error) {
+	notesOptions := options.New()
+	notesOptions.Branch = branch
+	notesOptions.RepoPath = repoPath
+	notesOptions.StartRev = startRev
+	notesOptions.EndRev = endRev
+	notesOptions.Paths = []string{path}
+	notesOptions.AddMarkdownLinks = true
+
+	if err := notesOptions.ValidateAndFinish(); err != nil {
+		return "", fmt.Errorf("validating notes options: %w", err)
+	}
+
+	releaseNotes, err := notes.GatherReleaseNotesContext(context.Background(), notesOptions)
+	if err != nil {
+		return "", fmt.Errorf("gathering release notes: %w", err)
+	}
+
+	doc, err := document.New(releaseNotes, startRev, endRev)
+	if err != nil {
+		return "", fmt.Errorf("creating release note document: %w", err)
+	}
+	doc.PreviousRevision = startRev
+	doc.CurrentRevision = endRev
+
+	return doc.RenderMarkdownTemplate("", "", "", options.GoTemplateDefault)
+}
+
+func (*defaultImpl) TagExists(org, repo, tag string) (bool, error) {
+	return github.New().TagExists(org, repo, tag)
+}
+
+// ReleaseID returns the ID of the GitHub release of the tag, or 0 if it does
+// not exist yet.
+func (*defaultImpl) ReleaseID(org, repo, tag string) (int64, error) {
+	client := github.New().Client()
+	opts := &gogithub.ListOptions{PerPage: 100}
+	for {
+		releases, resp, err := client.ListReleases(context.Background(), org, repo, opts)
+		if err != nil {
+			return 0, err
+		}
+		for _, release := range releases {
+			if release.GetTagName() == tag {
+				return release.GetID(), nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return 0, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (*defaultImpl) UpdateReleasePage(org, repo string, id int64, tag, name, body string, prerelease bool) error {
+	_, err := github.New().UpdateReleasePage(org, repo, id, tag, "", name, body, false, prerelease)
+	return err
+}
+
+func (*defaultImpl) WriteFile(name string, data []byte) error {
+	return os.WriteFile(name, data, 0o644)
+}
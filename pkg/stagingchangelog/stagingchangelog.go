@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stagingchangelog generates the changelogs of the staging
+// repositories, like client-go, from the path scoped release notes of
+// kubernetes/kubernetes and publishes them as GitHub releases on the mirrors
+// maintained by the publishing-bot.
+package stagingchangelog
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// StagingDir is the directory of the staging repositories in
+// kubernetes/kubernetes.
+const StagingDir = "staging/src/k8s.io"
+
+// Options are the main options for generating the staging changelogs.
+type Options struct {
+	// Tag is the kubernetes/kubernetes tag, for example v1.30.1.
+	Tag string
+
+	// PreviousTag is the tag to start the changelogs at. It defaults to the
+	// previous patch release for patch releases and has to be set otherwise.
+	PreviousTag string
+
+	// Repos are the names of the staging repositories.
+	Repos []string
+
+	// Org is the GitHub organization of the published staging repositories.
+	Org string
+
+	// RepoPath is the path to a local kubernetes/kubernetes clone, which
+	// gets cloned if it does not exist yet.
+	RepoPath string
+
+	// OutputDir is an optional directory for writing the changelogs.
+	OutputDir string
+
+	// NoMock creates the GitHub releases on the staging repositories.
+	// Otherwise the changelogs are only generated.
+	NoMock bool
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Repos: []string{"client-go", "api", "apimachinery"},
+		Org:   git.DefaultGithubOrg,
+	}
+}
+
+// Validate checks if the options are correctly set and derives the previous
+// tag if required.
+func (o *Options) Validate() error {
+	tag, err := util.TagStringToSemver(o.Tag)
+	if err != nil {
+		return fmt.Errorf("invalid tag %s: %w", o.Tag, err)
+	}
+	if len(o.Repos) == 0 {
+		return errors.New("no staging repositories specified")
+	}
+	if o.Org == "" {
+		return errors.New("no GitHub organization specified")
+	}
+
+	if o.PreviousTag == "" {
+		if tag.Patch == 0 || len(tag.Pre) > 0 {
+			return fmt.Errorf("previous tag has to be specified for %s", o.Tag)
+		}
+		previous := tag
+		previous.Patch--
+		o.PreviousTag = util.SemverToTagString(previous)
+		logrus.Infof("Using previous patch release %s as start tag", o.PreviousTag)
+	} else if _, err := util.TagStringToSemver(o.PreviousTag); err != nil {
+		return fmt.Errorf("invalid previous tag %s: %w", o.PreviousTag, err)
+	}
+	return nil
+}
+
+// StagingTag returns the tag of the staging repositories for a
+// kubernetes/kubernetes tag, for example v0.30.1 for v1.30.1.
+func StagingTag(tag string) (string, error) {
+	v, err := util.TagStringToSemver(tag)
+	if err != nil {
+		return "", fmt.Errorf("invalid tag %s: %w", tag, err)
+	}
+	v.Major = 0
+	return util.SemverToTagString(v), nil
+}
+
+// Result is the changelog of a single staging repository.
+type Result struct {
+	// Repo is the name of the staging repository.
+	Repo string
+
+	// Tag is the tag of the staging repository.
+	Tag string
+
+	// Changelog is the rendered markdown changelog.
+	Changelog string
+
+	// File is the written changelog, if any.
+	File string
+
+	// Released is true if the GitHub release got created or updated.
+	Released bool
+}
+
+// Generator generates and publishes the staging changelogs.
+type Generator struct {
+	impl    impl
+	options *Options
+}
+
+// New creates a new Generator instance.
+func New(opts *Options) *Generator {
+	return &Generator{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (g *Generator) SetImpl(impl impl) {
+	g.impl = impl
+}
+
+// Run generates the changelogs of all staging repositories and creates their
+// GitHub releases if NoMock is set.
+func (g *Generator) Run() ([]*Result, error) {
+	if err := g.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+	stagingTag, err := StagingTag(g.options.Tag)
+	if err != nil {
+		return nil, err
+	}
+	v, err := util.TagStringToSemver(g.options.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag %s: %w", g.options.Tag, err)
+	}
+	prerelease := len(v.Pre) > 0
+	branch := fmt.Sprintf("release-%d.%d", v.Major, v.Minor)
+
+	results := []*Result{}
+	for _, repo := range g.options.Repos {
+		logrus.Infof("Generating changelog of %s %s", repo, stagingTag)
+		changelog, err := g.impl.Changelog(
+			g.options.RepoPath, branch, StagingDir+"/"+repo,
+			g.options.PreviousTag, g.options.Tag,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("generate changelog of %s: %w", repo, err)
+		}
+		changelog = fmt.Sprintf(
+			"Published from kubernetes/kubernetes [%s](https://github.com/kubernetes/kubernetes/releases/tag/%s).\n\n%s",
+			g.options.Tag, g.options.Tag, changelog,
+		)
+		result := &Result{Repo: repo, Tag: stagingTag, Changelog: changelog}
+
+		if g.options.OutputDir != "" {
+			result.File = filepath.Join(
+				g.options.OutputDir, fmt.Sprintf("CHANGELOG-%s-%s.md", repo, stagingTag),
+			)
+			if err := g.impl.WriteFile(result.File, []byte(changelog)); err != nil {
+				return nil, fmt.Errorf("write changelog of %s: %w", repo, err)
+			}
+			logrus.Infof("Wrote changelog to %s", result.File)
+		}
+
+		if err := g.release(repo, stagingTag, changelog, prerelease); err != nil {
+			return nil, fmt.Errorf("release %s: %w", repo, err)
+		}
+		result.Released = g.options.NoMock
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// release creates or updates the GitHub release of the staging repository.
+func (g *Generator) release(repo, tag, changelog string, prerelease bool) error {
+	if !g.options.NoMock {
+		logrus.Infof("Mock run: not creating release %s of %s/%s", tag, g.options.Org, repo)
+		return nil
+	}
+
+	exists, err := g.impl.TagExists(g.options.Org, repo, tag)
+	if err != nil {
+		return fmt.Errorf("check if tag %s exists: %w", tag, err)
+	}
+	if !exists {
+		return fmt.Errorf(
+			"tag %s does not exist in %s/%s yet, it may not be published by the publishing-bot",
+			tag, g.options.Org, repo,
+		)
+	}
+
+	id, err := g.impl.ReleaseID(g.options.Org, repo, tag)
+	if err != nil {
+		return fmt.Errorf("get release of tag %s: %w", tag, err)
+	}
+	if id != 0 {
+		logrus.Infof("Updating existing release %s of %s/%s", tag, g.options.Org, repo)
+	}
+	if err := g.impl.UpdateReleasePage(
+		g.options.Org, repo, id, tag, tag, changelog, prerelease,
+	); err != nil {
+		return fmt.Errorf("update release page: %w", err)
+	}
+	logrus.Infof("Published release %s of %s/%s", tag, g.options.Org, repo)
+	return nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stagingchangelog_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/stagingchangelog"
+	"k8s.io/release/pkg/stagingchangelog/stagingchangelogfakes"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		tag, previousTag string
+		repos            []string
+		expectedPrevious string
+		shouldError      bool
+	}{
+		{tag: "v1.30.1", expectedPrevious: "v1.30.0"},
+		{tag: "v1.30.0", previousTag: "v1.29.0", expectedPrevious: "v1.29.0"},
+		{tag: "v1.30.0", shouldError: true},
+		{tag: "v1.30.1-rc.0", shouldError: true},
+		{tag: "v1.30.1", previousTag: "wrong", shouldError: true},
+		{tag: "wrong", shouldError: true},
+		{tag: "v1.30.1", repos: []string{}, shouldError: true},
+	} {
+		opts := stagingchangelog.DefaultOptions()
+		opts.Tag = tc.tag
+		opts.PreviousTag = tc.previousTag
+		if tc.repos != nil {
+			opts.Repos = tc.repos
+		}
+		err := opts.Validate()
+		if tc.shouldError {
+			require.Error(t, err, tc.tag)
+			continue
+		}
+		require.NoError(t, err, tc.tag)
+		require.Equal(t, tc.expectedPrevious, opts.PreviousTag)
+	}
+}
+
+func TestStagingTag(t *testing.T) {
+	for tag, expected := range map[string]string{
+		"v1.30.1":      "v0.30.1",
+		"1.31.0-rc.1":  "v0.31.0-rc.1",
+		"v1.29.10":     "v0.29.10",
+		"not-a-semver": "",
+	} {
+		res, err := stagingchangelog.StagingTag(tag)
+		if expected == "" {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, expected, res)
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		nomock      bool
+		outputDir   string
+		prepare     func(*stagingchangelogfakes.FakeImpl)
+		assert      func(*stagingchangelogfakes.FakeImpl, []*stagingchangelog.Result)
+		shouldError bool
+	}{
+		{ // mock run
+			outputDir: "/tmp/out",
+			prepare: func(mock *stagingchangelogfakes.FakeImpl) {
+				mock.ChangelogReturns("## Changes\n", nil)
+			},
+			assert: func(mock *stagingchangelogfakes.FakeImpl, res []*stagingchangelog.Result) {
+				require.Len(t, res, 3)
+				require.Equal(t, 3, mock.WriteFileCallCount())
+				name, data := mock.WriteFileArgsForCall(0)
+				require.Equal(t, "/tmp/out/CHANGELOG-client-go-v0.30.1.md", name)
+				require.Contains(t, string(data), "## Changes")
+				require.Contains(t, string(data), "kubernetes/kubernetes [v1.30.1]")
+
+				_, branch, path, start, end := mock.ChangelogArgsForCall(1)
+				require.Equal(t, "release-1.30", branch)
+				require.Equal(t, "staging/src/k8s.io/api", path)
+				require.Equal(t, "v1.30.0", start)
+				require.Equal(t, "v1.30.1", end)
+
+				require.Zero(t, mock.TagExistsCallCount())
+				require.Zero(t, mock.UpdateReleasePageCallCount())
+				require.False(t, res[0].Released)
+			},
+		},
+		{ // nomock run
+			nomock: true,
+			prepare: func(mock *stagingchangelogfakes.FakeImpl) {
+				mock.TagExistsReturns(true, nil)
+				mock.ReleaseIDReturnsOnCall(1, 42, nil)
+			},
+			assert: func(mock *stagingchangelogfakes.FakeImpl, res []*stagingchangelog.Result) {
+				require.Len(t, res, 3)
+				require.True(t, res[2].Released)
+				require.Zero(t, mock.WriteFileCallCount())
+				require.Equal(t, 3, mock.UpdateReleasePageCallCount())
+
+				org, repo, id, tag, name, _, prerelease := mock.UpdateReleasePageArgsForCall(1)
+				require.Equal(t, "kubernetes", org)
+				require.Equal(t, "api", repo)
+				require.EqualValues(t, 42, id)
+				require.Equal(t, "v0.30.1", tag)
+				require.Equal(t, "v0.30.1", name)
+				require.False(t, prerelease)
+			},
+		},
+		{ // tag not published yet
+			nomock: true,
+			prepare: func(mock *stagingchangelogfakes.FakeImpl) {
+				mock.TagExistsReturns(false, nil)
+			},
+			shouldError: true,
+		},
+		{ // changelog failure
+			prepare: func(mock *stagingchangelogfakes.FakeImpl) {
+				mock.ChangelogReturns("", errors.New(""))
+			},
+			shouldError: true,
+		},
+		{ // release failure
+			nomock: true,
+			prepare: func(mock *stagingchangelogfakes.FakeImpl) {
+				mock.TagExistsReturns(true, nil)
+				mock.UpdateReleasePageReturns(errors.New(""))
+			},
+			shouldError: true,
+		},
+	} {
+		opts := stagingchangelog.DefaultOptions()
+		opts.Tag = "v1.30.1"
+		opts.NoMock = tc.nomock
+		opts.OutputDir = tc.outputDir
+
+		mock := &stagingchangelogfakes.FakeImpl{}
+		tc.prepare(mock)
+		sut := stagingchangelog.New(opts)
+		sut.SetImpl(mock)
+
+		res, err := sut.Run()
+		if tc.shouldError {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		tc.assert(mock, res)
+	}
+}
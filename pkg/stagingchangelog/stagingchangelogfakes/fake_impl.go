@@ -0,0 +1,469 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package stagingchangelogfakes
+
+import (
+	"sync"
+)
+
+type FakeImpl struct {
+	ChangelogStub        func(string, string, string, string, string) (string, error)
+	changelogMutex       sync.RWMutex
+	changelogArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	changelogReturns struct {
+		result1 string
+		result2 error
+	}
+	changelogReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ReleaseIDStub        func(string, string, string) (int64, error)
+	releaseIDMutex       sync.RWMutex
+	releaseIDArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	releaseIDReturns struct {
+		result1 int64
+		result2 error
+	}
+	releaseIDReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	TagExistsStub        func(string, string, string) (bool, error)
+	tagExistsMutex       sync.RWMutex
+	tagExistsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	tagExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	tagExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UpdateReleasePageStub        func(string, string, int64, string, string, string, bool) error
+	updateReleasePageMutex       sync.RWMutex
+	updateReleasePageArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+		arg4 string
+		arg5 string
+		arg6 string
+		arg7 bool
+	}
+	updateReleasePageReturns struct {
+		result1 error
+	}
+	updateReleasePageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteFileStub        func(string, []byte) error
+	writeFileMutex       sync.RWMutex
+	writeFileArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+	}
+	writeFileReturns struct {
+		result1 error
+	}
+	writeFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) Changelog(arg1 string, arg2 string, arg3 string, arg4 string, arg5 string) (string, error) {
+	fake.changelogMutex.Lock()
+	ret, specificReturn := fake.changelogReturnsOnCall[len(fake.changelogArgsForCall)]
+	fake.changelogArgsForCall = append(fake.changelogArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.ChangelogStub
+	fakeReturns := fake.changelogReturns
+	fake.recordInvocation("Changelog", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.changelogMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ChangelogCallCount() int {
+	fake.changelogMutex.RLock()
+	defer fake.changelogMutex.RUnlock()
+	return len(fake.changelogArgsForCall)
+}
+
+func (fake *FakeImpl) ChangelogCalls(stub func(string, string, string, string, string) (string, error)) {
+	fake.changelogMutex.Lock()
+	defer fake.changelogMutex.Unlock()
+	fake.ChangelogStub = stub
+}
+
+func (fake *FakeImpl) ChangelogArgsForCall(i int) (string, string, string, string, string) {
+	fake.changelogMutex.RLock()
+	defer fake.changelogMutex.RUnlock()
+	argsForCall := fake.changelogArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeImpl) ChangelogReturns(result1 string, result2 error) {
+	fake.changelogMutex.Lock()
+	defer fake.changelogMutex.Unlock()
+	fake.ChangelogStub = nil
+	fake.changelogReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ChangelogReturnsOnCall(i int, result1 string, result2 error) {
+	fake.changelogMutex.Lock()
+	defer fake.changelogMutex.Unlock()
+	fake.ChangelogStub = nil
+	if fake.changelogReturnsOnCall == nil {
+		fake.changelogReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.changelogReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReleaseID(arg1 string, arg2 string, arg3 string) (int64, error) {
+	fake.releaseIDMutex.Lock()
+	ret, specificReturn := fake.releaseIDReturnsOnCall[len(fake.releaseIDArgsForCall)]
+	fake.releaseIDArgsForCall = append(fake.releaseIDArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ReleaseIDStub
+	fakeReturns := fake.releaseIDReturns
+	fake.recordInvocation("ReleaseID", []interface{}{arg1, arg2, arg3})
+	fake.releaseIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ReleaseIDCallCount() int {
+	fake.releaseIDMutex.RLock()
+	defer fake.releaseIDMutex.RUnlock()
+	return len(fake.releaseIDArgsForCall)
+}
+
+func (fake *FakeImpl) ReleaseIDCalls(stub func(string, string, string) (int64, error)) {
+	fake.releaseIDMutex.Lock()
+	defer fake.releaseIDMutex.Unlock()
+	fake.ReleaseIDStub = stub
+}
+
+func (fake *FakeImpl) ReleaseIDArgsForCall(i int) (string, string, string) {
+	fake.releaseIDMutex.RLock()
+	defer fake.releaseIDMutex.RUnlock()
+	argsForCall := fake.releaseIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) ReleaseIDReturns(result1 int64, result2 error) {
+	fake.releaseIDMutex.Lock()
+	defer fake.releaseIDMutex.Unlock()
+	fake.ReleaseIDStub = nil
+	fake.releaseIDReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ReleaseIDReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.releaseIDMutex.Lock()
+	defer fake.releaseIDMutex.Unlock()
+	fake.ReleaseIDStub = nil
+	if fake.releaseIDReturnsOnCall == nil {
+		fake.releaseIDReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.releaseIDReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) TagExists(arg1 string, arg2 string, arg3 string) (bool, error) {
+	fake.tagExistsMutex.Lock()
+	ret, specificReturn := fake.tagExistsReturnsOnCall[len(fake.tagExistsArgsForCall)]
+	fake.tagExistsArgsForCall = append(fake.tagExistsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.TagExistsStub
+	fakeReturns := fake.tagExistsReturns
+	fake.recordInvocation("TagExists", []interface{}{arg1, arg2, arg3})
+	fake.tagExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) TagExistsCallCount() int {
+	fake.tagExistsMutex.RLock()
+	defer fake.tagExistsMutex.RUnlock()
+	return len(fake.tagExistsArgsForCall)
+}
+
+func (fake *FakeImpl) TagExistsCalls(stub func(string, string, string) (bool, error)) {
+	fake.tagExistsMutex.Lock()
+	defer fake.tagExistsMutex.Unlock()
+	fake.TagExistsStub = stub
+}
+
+func (fake *FakeImpl) TagExistsArgsForCall(i int) (string, string, string) {
+	fake.tagExistsMutex.RLock()
+	defer fake.tagExistsMutex.RUnlock()
+	argsForCall := fake.tagExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) TagExistsReturns(result1 bool, result2 error) {
+	fake.tagExistsMutex.Lock()
+	defer fake.tagExistsMutex.Unlock()
+	fake.TagExistsStub = nil
+	fake.tagExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) TagExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.tagExistsMutex.Lock()
+	defer fake.tagExistsMutex.Unlock()
+	fake.TagExistsStub = nil
+	if fake.tagExistsReturnsOnCall == nil {
+		fake.tagExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.tagExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) UpdateReleasePage(arg1 string, arg2 string, arg3 int64, arg4 string, arg5 string, arg6 string, arg7 bool) error {
+	fake.updateReleasePageMutex.Lock()
+	ret, specificReturn := fake.updateReleasePageReturnsOnCall[len(fake.updateReleasePageArgsForCall)]
+	fake.updateReleasePageArgsForCall = append(fake.updateReleasePageArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+		arg4 string
+		arg5 string
+		arg6 string
+		arg7 bool
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	stub := fake.UpdateReleasePageStub
+	fakeReturns := fake.updateReleasePageReturns
+	fake.recordInvocation("UpdateReleasePage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.updateReleasePageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) UpdateReleasePageCallCount() int {
+	fake.updateReleasePageMutex.RLock()
+	defer fake.updateReleasePageMutex.RUnlock()
+	return len(fake.updateReleasePageArgsForCall)
+}
+
+func (fake *FakeImpl) UpdateReleasePageCalls(stub func(string, string, int64, string, string, string, bool) error) {
+	fake.updateReleasePageMutex.Lock()
+	defer fake.updateReleasePageMutex.Unlock()
+	fake.UpdateReleasePageStub = stub
+}
+
+func (fake *FakeImpl) UpdateReleasePageArgsForCall(i int) (string, string, int64, string, string, string, bool) {
+	fake.updateReleasePageMutex.RLock()
+	defer fake.updateReleasePageMutex.RUnlock()
+	argsForCall := fake.updateReleasePageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+}
+
+func (fake *FakeImpl) UpdateReleasePageReturns(result1 error) {
+	fake.updateReleasePageMutex.Lock()
+	defer fake.updateReleasePageMutex.Unlock()
+	fake.UpdateReleasePageStub = nil
+	fake.updateReleasePageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) UpdateReleasePageReturnsOnCall(i int, result1 error) {
+	fake.updateReleasePageMutex.Lock()
+	defer fake.updateReleasePageMutex.Unlock()
+	fake.UpdateReleasePageStub = nil
+	if fake.updateReleasePageReturnsOnCall == nil {
+		fake.updateReleasePageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateReleasePageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeFileMutex.Lock()
+	ret, specificReturn := fake.writeFileReturnsOnCall[len(fake.writeFileArgsForCall)]
+	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.WriteFileStub
+	fakeReturns := fake.writeFileReturns
+	fake.recordInvocation("WriteFile", []interface{}{arg1, arg2Copy})
+	fake.writeFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) WriteFileCallCount() int {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return len(fake.writeFileArgsForCall)
+}
+
+func (fake *FakeImpl) WriteFileCalls(stub func(string, []byte) error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = stub
+}
+
+func (fake *FakeImpl) WriteFileArgsForCall(i int) (string, []byte) {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	argsForCall := fake.writeFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) WriteFileReturns(result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	fake.writeFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteFileReturnsOnCall(i int, result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	if fake.writeFileReturnsOnCall == nil {
+		fake.writeFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.changelogMutex.RLock()
+	defer fake.changelogMutex.RUnlock()
+	fake.releaseIDMutex.RLock()
+	defer fake.releaseIDMutex.RUnlock()
+	fake.tagExistsMutex.RLock()
+	defer fake.tagExistsMutex.RUnlock()
+	fake.updateReleasePageMutex.RLock()
+	defer fake.updateReleasePageMutex.RUnlock()
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
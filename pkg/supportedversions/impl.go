@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportedversions
+
+import (
+	"os"
+
+	"k8s.io/release/pkg/release"
+	"sigs.k8s.io/release-sdk/object"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt supportedversionsfakes/fake_impl.go > supportedversionsfakes/_fake_impl.go && mv supportedversionsfakes/_fake_impl.go supportedversionsfakes/fake_impl.go"
+type impl interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	LatestPatch(branch string) (string, error)
+	CopyToRemote(local, remote string) error
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (*defaultImpl) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (*defaultImpl) LatestPatch(branch string) (string, error) {
+	return release.NewVersion().GetKubeVersionForBranch(release.VersionTypeStable, branch)
+}
+
+func (*defaultImpl) CopyToRemote(local, remote string) error {
+	gcs := object.NewGCS()
+	gcs.SetOptions(gcs.WithNoClobber(false))
+	return gcs.CopyToRemote(local, remote)
+}
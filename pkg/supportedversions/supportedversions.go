@@ -0,0 +1,335 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package supportedversions generates a machine readable document of the
+// supported Kubernetes minor versions, their latest patches and end of life
+// dates, and publishes it to a stable URL.
+package supportedversions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/workdir"
+)
+
+// FileName is the name of the published document.
+const FileName = "supported-versions.json"
+
+// Document lists the supported and end of life minor versions.
+type Document struct {
+	// LastUpdated is the date the document got generated.
+	LastUpdated string `json:"lastUpdated"`
+
+	// Versions are the minor versions, newest first.
+	Versions []Version `json:"versions"`
+}
+
+// Version is the support status of a minor version.
+type Version struct {
+	// Version is the minor version, for example 1.30.
+	Version string `json:"version"`
+
+	// Supported is true if the minor version did not reach its end of life.
+	Supported bool `json:"supported"`
+
+	// LatestPatch is the latest released patch version, for example 1.30.2.
+	LatestPatch string `json:"latestPatch,omitempty"`
+
+	// ReleaseDate is the date of the minor release.
+	ReleaseDate string `json:"releaseDate,omitempty"`
+
+	// MaintenanceModeStartDate is the date from which only critical fixes
+	// get released.
+	MaintenanceModeStartDate string `json:"maintenanceModeStartDate,omitempty"`
+
+	// EndOfLifeDate is the end of life date, which is empty if not known yet.
+	EndOfLifeDate string `json:"endOfLifeDate,omitempty"`
+
+	// NextPatch is the next scheduled patch release.
+	NextPatch *Patch `json:"nextPatch,omitempty"`
+}
+
+// Patch is a scheduled patch release.
+type Patch struct {
+	Version            string `json:"version"`
+	CherryPickDeadline string `json:"cherryPickDeadline,omitempty"`
+	TargetDate         string `json:"targetDate,omitempty"`
+}
+
+// schedule is the patch release schedule as maintained in
+// kubernetes/website, `data/releases/schedule.yaml`.
+type schedule struct {
+	Schedules []struct {
+		Release                  string          `json:"release"`
+		ReleaseDate              string          `json:"releaseDate"`
+		Next                     *schedulePatch  `json:"next"`
+		EndOfLifeDate            string          `json:"endOfLifeDate"`
+		MaintenanceModeStartDate string          `json:"maintenanceModeStartDate"`
+		PreviousPatches          []schedulePatch `json:"previousPatches"`
+	} `json:"schedules"`
+}
+
+type schedulePatch struct {
+	Release            string `json:"release"`
+	CherryPickDeadline string `json:"cherryPickDeadline"`
+	TargetDate         string `json:"targetDate"`
+}
+
+// eolBranches are the end of life branches as maintained in
+// kubernetes/website, `data/releases/eol.yaml`.
+type eolBranches struct {
+	Branches []struct {
+		Release           string `json:"release"`
+		FinalPatchRelease string `json:"finalPatchRelease"`
+		EndOfLifeDate     string `json:"endOfLifeDate"`
+	} `json:"branches"`
+}
+
+// Options are the settings for generating the document.
+type Options struct {
+	// Schedule is the path to the patch release schedule.
+	Schedule string
+
+	// EOL is the path to the end of life branches, which is optional.
+	EOL string
+
+	// Output is the local path where the document gets written to.
+	Output string
+
+	// Publish uploads the document to the bucket.
+	Publish bool
+
+	// Bucket is the Google Cloud Storage bucket of the published document.
+	Bucket string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{Bucket: release.TestBucket}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Schedule == "" {
+		return errors.New("path to the patch release schedule is required")
+	}
+	if o.Publish && o.Bucket == "" {
+		return errors.New("bucket is required for publishing")
+	}
+	return nil
+}
+
+// URL returns the stable URL of the published document.
+func (o *Options) URL() string {
+	return fmt.Sprintf("%s/release/%s", release.URLPrefixForBucket(o.Bucket), FileName)
+}
+
+// Generator generates and publishes the supported versions document.
+type Generator struct {
+	impl    impl
+	options *Options
+}
+
+// New creates a new Generator for the provided options.
+func New(options *Options) *Generator {
+	return &Generator{&defaultImpl{}, options}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (g *Generator) SetImpl(impl impl) {
+	g.impl = impl
+}
+
+// Run generates the document, writes it to the output and publishes it if
+// requested.
+func (g *Generator) Run() (*Document, error) {
+	if err := g.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	doc, err := g.Generate(time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal document: %w", err)
+	}
+	content = append(content, '\n')
+
+	output := g.options.Output
+	if output == "" {
+		if !g.options.Publish {
+			return doc, nil
+		}
+		dir, err := workdir.MkdirTemp("supported-versions-")
+		if err != nil {
+			return nil, fmt.Errorf("create temp dir: %w", err)
+		}
+		output = filepath.Join(dir, FileName)
+	}
+
+	if err := g.impl.WriteFile(output, content, 0o644); err != nil {
+		return nil, fmt.Errorf("write document: %w", err)
+	}
+
+	if g.options.Publish {
+		remote := fmt.Sprintf("gs://%s/release/%s", strings.TrimPrefix(g.options.Bucket, "gs://"), FileName)
+		logrus.Infof("Publishing supported versions to %s", remote)
+		if err := g.impl.CopyToRemote(output, remote); err != nil {
+			return nil, fmt.Errorf("publish document: %w", err)
+		}
+		logrus.Infof("Supported versions are available at %s", g.options.URL())
+	}
+	return doc, nil
+}
+
+// Generate builds the document from the schedule, the end of life branches
+// and the latest patch releases at the provided time.
+func (g *Generator) Generate(now time.Time) (*Document, error) {
+	content, err := g.impl.ReadFile(g.options.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("read schedule: %w", err)
+	}
+	s := &schedule{}
+	if err := yaml.Unmarshal(content, s); err != nil {
+		return nil, fmt.Errorf("parse schedule: %w", err)
+	}
+
+	eol := &eolBranches{}
+	if g.options.EOL != "" {
+		content, err := g.impl.ReadFile(g.options.EOL)
+		if err != nil {
+			return nil, fmt.Errorf("read end of life branches: %w", err)
+		}
+		if err := yaml.Unmarshal(content, eol); err != nil {
+			return nil, fmt.Errorf("parse end of life branches: %w", err)
+		}
+	}
+
+	versions := map[string]Version{}
+	for i := range s.Schedules {
+		sched := &s.Schedules[i]
+		minor := strings.TrimPrefix(sched.Release, "v")
+		endOfLife := date(sched.EndOfLifeDate)
+		supported, err := supportedAt(endOfLife, now)
+		if err != nil {
+			return nil, fmt.Errorf("end of life date of %s: %w", minor, err)
+		}
+
+		v := Version{
+			Version:                  minor,
+			Supported:                supported,
+			LatestPatch:              g.latestPatch(minor, sched.PreviousPatches),
+			ReleaseDate:              date(sched.ReleaseDate),
+			MaintenanceModeStartDate: date(sched.MaintenanceModeStartDate),
+			EndOfLifeDate:            endOfLife,
+		}
+		if sched.Next != nil && sched.Next.Release != "" && supported {
+			v.NextPatch = &Patch{
+				Version:            sched.Next.Release,
+				CherryPickDeadline: date(sched.Next.CherryPickDeadline),
+				TargetDate:         date(sched.Next.TargetDate),
+			}
+		}
+		versions[minor] = v
+	}
+
+	for _, branch := range eol.Branches {
+		minor := strings.TrimPrefix(branch.Release, "v")
+		versions[minor] = Version{
+			Version:       minor,
+			LatestPatch:   strings.TrimPrefix(branch.FinalPatchRelease, "v"),
+			EndOfLifeDate: date(branch.EndOfLifeDate),
+		}
+	}
+
+	doc := &Document{
+		LastUpdated: now.Format(time.DateOnly),
+		Versions:    make([]Version, 0, len(versions)),
+	}
+	for _, v := range versions {
+		doc.Versions = append(doc.Versions, v)
+	}
+	sort.SliceStable(doc.Versions, func(i, j int) bool {
+		a, errA := semver.ParseTolerant(doc.Versions[i].Version)
+		b, errB := semver.ParseTolerant(doc.Versions[j].Version)
+		if errA != nil || errB != nil {
+			return doc.Versions[i].Version > doc.Versions[j].Version
+		}
+		return a.GT(b)
+	})
+	return doc, nil
+}
+
+// latestPatch returns the latest patch release of the minor version from its
+// stable version marker, or from the schedule if the marker is not available.
+func (g *Generator) latestPatch(minor string, previous []schedulePatch) string {
+	marker, err := g.impl.LatestPatch("release-" + minor)
+	if err == nil && marker != "" {
+		return strings.TrimPrefix(strings.TrimSpace(marker), "v")
+	}
+	if err != nil {
+		logrus.Warnf("Using the schedule for the latest patch of %s: %v", minor, err)
+	}
+
+	latest := semver.Version{}
+	found := ""
+	for _, p := range previous {
+		v, err := semver.ParseTolerant(p.Release)
+		if err != nil {
+			continue
+		}
+		if found == "" || v.GT(latest) {
+			latest, found = v, strings.TrimPrefix(p.Release, "v")
+		}
+	}
+	return found
+}
+
+// supportedAt returns true if the end of life date is not known yet or after
+// the provided time.
+func supportedAt(endOfLife string, now time.Time) (bool, error) {
+	if endOfLife == "" {
+		return true, nil
+	}
+	eol, err := time.Parse(time.DateOnly, endOfLife)
+	if err != nil {
+		return false, err
+	}
+	return now.Before(eol), nil
+}
+
+// date normalizes a schedule date, which is empty if not known yet.
+func date(d string) string {
+	d = strings.TrimSpace(d)
+	if strings.EqualFold(d, "TBD") {
+		return ""
+	}
+	return d
+}
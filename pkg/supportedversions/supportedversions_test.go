@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportedversions_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/supportedversions"
+	"k8s.io/release/pkg/supportedversions/supportedversionsfakes"
+)
+
+const (
+	testSchedule = `schedules:
+- release: "1.30"
+  releaseDate: 2024-04-17
+  next:
+    release: 1.30.3
+    cherryPickDeadline: 2024-07-12
+    targetDate: 2024-07-16
+  endOfLifeDate: 2025-06-28
+  maintenanceModeStartDate: 2025-04-28
+  previousPatches:
+    - release: 1.30.1
+      targetDate: 2024-05-15
+    - release: 1.30.2
+      targetDate: 2024-06-11
+- release: "1.27"
+  releaseDate: 2023-04-11
+  next:
+    release: 1.27.16
+    targetDate: 2024-07-16
+  endOfLifeDate: 2024-06-28
+  maintenanceModeStartDate: 2024-04-28
+  previousPatches:
+    - release: 1.27.15
+      targetDate: 2024-06-11
+- release: "1.31"
+  endOfLifeDate: TBD
+`
+	testEOL = `branches:
+- release: "1.26"
+  finalPatchRelease: 1.26.15
+  endOfLifeDate: 2024-02-28
+`
+)
+
+func TestGenerate(t *testing.T) {
+	mock := &supportedversionsfakes.FakeImpl{}
+	mock.ReadFileReturnsOnCall(0, []byte(testSchedule), nil)
+	mock.ReadFileReturnsOnCall(1, []byte(testEOL), nil)
+	mock.LatestPatchStub = func(branch string) (string, error) {
+		if branch == "release-1.30" {
+			return "v1.30.2\n", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	sut := supportedversions.New(&supportedversions.Options{Schedule: "schedule.yaml", EOL: "eol.yaml"})
+	sut.SetImpl(mock)
+
+	now, err := time.Parse(time.DateOnly, "2024-07-01")
+	require.NoError(t, err)
+	doc, err := sut.Generate(now)
+	require.NoError(t, err)
+	require.Equal(t, &supportedversions.Document{
+		LastUpdated: "2024-07-01",
+		Versions: []supportedversions.Version{
+			{Version: "1.31", Supported: true},
+			{
+				Version:                  "1.30",
+				Supported:                true,
+				LatestPatch:              "1.30.2",
+				ReleaseDate:              "2024-04-17",
+				MaintenanceModeStartDate: "2025-04-28",
+				EndOfLifeDate:            "2025-06-28",
+				NextPatch: &supportedversions.Patch{
+					Version:            "1.30.3",
+					CherryPickDeadline: "2024-07-12",
+					TargetDate:         "2024-07-16",
+				},
+			},
+			{
+				Version:                  "1.27",
+				LatestPatch:              "1.27.15",
+				ReleaseDate:              "2023-04-11",
+				MaintenanceModeStartDate: "2024-04-28",
+				EndOfLifeDate:            "2024-06-28",
+			},
+			{Version: "1.26", LatestPatch: "1.26.15", EndOfLifeDate: "2024-02-28"},
+		},
+	}, doc)
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		opts    *supportedversions.Options
+		prepare func(*supportedversionsfakes.FakeImpl)
+		writes  int
+		copies  int
+		err     bool
+	}{
+		{
+			name:   "write to output",
+			opts:   &supportedversions.Options{Schedule: "schedule.yaml", Output: "out.json"},
+			writes: 1,
+		},
+		{
+			name:   "publish",
+			opts:   &supportedversions.Options{Schedule: "schedule.yaml", Output: "out.json", Publish: true, Bucket: "k8s-release-dev"},
+			writes: 1,
+			copies: 1,
+		},
+		{
+			name: "invalid options",
+			opts: &supportedversions.Options{},
+			err:  true,
+		},
+		{
+			name: "failure on reading schedule",
+			opts: &supportedversions.Options{Schedule: "schedule.yaml"},
+			prepare: func(mock *supportedversionsfakes.FakeImpl) {
+				mock.ReadFileReturns(nil, errors.New(""))
+			},
+			err: true,
+		},
+		{
+			name: "invalid end of life date",
+			opts: &supportedversions.Options{Schedule: "schedule.yaml"},
+			prepare: func(mock *supportedversionsfakes.FakeImpl) {
+				mock.ReadFileReturns([]byte("schedules:\n- release: \"1.30\"\n  endOfLifeDate: soon\n"), nil)
+			},
+			err: true,
+		},
+		{
+			name: "failure on publishing",
+			opts: &supportedversions.Options{Schedule: "schedule.yaml", Output: "out.json", Publish: true, Bucket: "k8s-release-dev"},
+			prepare: func(mock *supportedversionsfakes.FakeImpl) {
+				mock.CopyToRemoteReturns(errors.New(""))
+			},
+			writes: 1,
+			copies: 1,
+			err:    true,
+		},
+	} {
+		mock := &supportedversionsfakes.FakeImpl{}
+		mock.ReadFileReturns([]byte(testSchedule), nil)
+		mock.LatestPatchReturns("v1.30.2", nil)
+		if tc.prepare != nil {
+			tc.prepare(mock)
+		}
+
+		sut := supportedversions.New(tc.opts)
+		sut.SetImpl(mock)
+
+		_, err := sut.Run()
+		require.Equal(t, tc.writes, mock.WriteFileCallCount(), tc.name)
+		require.Equal(t, tc.copies, mock.CopyToRemoteCallCount(), tc.name)
+		if tc.err {
+			require.Error(t, err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+
+		if tc.copies > 0 {
+			local, remote := mock.CopyToRemoteArgsForCall(0)
+			require.Equal(t, "out.json", local)
+			require.Equal(t, "gs://k8s-release-dev/release/supported-versions.json", remote)
+		}
+		_, content, _ := mock.WriteFileArgsForCall(0)
+		doc := &supportedversions.Document{}
+		require.NoError(t, json.Unmarshal(content, doc))
+		require.Len(t, doc.Versions, 3)
+	}
+}
+
+func TestURL(t *testing.T) {
+	require.Equal(t,
+		"https://dl.k8s.io/release/supported-versions.json",
+		(&supportedversions.Options{Bucket: "kubernetes-release"}).URL(),
+	)
+	require.Equal(t,
+		"https://storage.googleapis.com/k8s-release-dev/release/supported-versions.json",
+		(&supportedversions.Options{Bucket: "k8s-release-dev"}).URL(),
+	)
+}
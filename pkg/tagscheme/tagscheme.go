@@ -0,0 +1,335 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tagscheme contains the format of the generated release tags.
+// Downstream release trains can extend the upstream vX.Y.Z(-pre.N) tags, for
+// example with build metadata like vX.Y.Z+vendor.N or with date stamps for
+// nightlies, while reusing the version bumping of the release flow.
+package tagscheme
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/env"
+	"sigs.k8s.io/release-utils/util"
+	"sigs.k8s.io/yaml"
+)
+
+// SchemeEnvKey is the environment variable containing the default path to
+// the tag scheme file.
+const SchemeEnvKey = "KREL_TAG_SCHEME"
+
+// DefaultFormat is the format of the upstream tags.
+const DefaultFormat = "{{ .Version }}"
+
+// Values are the variables available in the format of a scheme.
+type Values struct {
+	// Version is the upstream version, for example v1.30.1-rc.0.
+	Version string
+
+	// Major, Minor and Patch are the numeric parts of Version.
+	Major, Minor, Patch uint64
+
+	// Pre is the pre-release part of Version without the leading dash, for
+	// example rc.0. It is empty for official releases.
+	Pre string
+
+	// Date is the current UTC date as YYYYMMDD.
+	Date string
+
+	// Counter is one more than the last numeric build metadata identifier of
+	// the previous tag if it has the same upstream version, and 1 otherwise.
+	Counter uint64
+}
+
+// TagLister returns the existing tags of the release repository which start
+// with the prefix.
+type TagLister func(prefix string) ([]string, error)
+
+// Scheme is the format of the release tags.
+type Scheme struct {
+	// Format is the Go template rendering a tag from the Values. Empty
+	// means the DefaultFormat.
+	Format string `json:"format,omitempty"`
+
+	format *template.Template
+}
+
+// DefaultScheme returns the scheme of the upstream tags.
+func DefaultScheme() *Scheme {
+	s := &Scheme{}
+	if err := s.Validate(); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Load reads a scheme from the provided YAML file.
+func Load(file string) (*Scheme, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read tag scheme: %w", err)
+	}
+	s := &Scheme{}
+	if err := yaml.UnmarshalStrict(content, s); err != nil {
+		return nil, fmt.Errorf("unmarshal tag scheme: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("validate tag scheme %s: %w", file, err)
+	}
+	return s, nil
+}
+
+// Validate parses the format and ensures that it renders semantic versions
+// with the same major, minor and patch version for sample values, which is
+// required for bumping the rendered tags.
+func (s *Scheme) Validate() error {
+	text := s.Format
+	if text == "" {
+		text = DefaultFormat
+	}
+	parsed, err := template.New("format").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("parse format: %w", err)
+	}
+	s.format = parsed
+
+	for _, sample := range []string{"v1.30.1", "v1.31.0-rc.2"} {
+		tag, err := s.Apply(sample, "", time.Now())
+		if err != nil {
+			return fmt.Errorf("render format for %s: %w", sample, err)
+		}
+		v, err := util.TagStringToSemver(tag)
+		if err != nil {
+			return fmt.Errorf("rendered tag %s for %s is not a semantic version: %w", tag, sample, err)
+		}
+		expected, err := util.TagStringToSemver(sample)
+		if err != nil {
+			return err
+		}
+		if v.Major != expected.Major || v.Minor != expected.Minor || v.Patch != expected.Patch {
+			return fmt.Errorf("rendered tag %s changes the version of %s", tag, sample)
+		}
+	}
+	return nil
+}
+
+// Apply renders the tag of an upstream version. The previous tag of the
+// release train is used for calculating the counter and can be empty.
+func (s *Scheme) Apply(version, previous string, now time.Time) (string, error) {
+	v, err := util.TagStringToSemver(version)
+	if err != nil {
+		return "", fmt.Errorf("invalid version %s: %w", version, err)
+	}
+	v.Build = nil
+
+	values := &Values{
+		Version: util.SemverToTagString(v),
+		Major:   v.Major,
+		Minor:   v.Minor,
+		Patch:   v.Patch,
+		Date:    now.UTC().Format("20060102"),
+		Counter: counter(v, previous),
+	}
+	if len(v.Pre) > 0 {
+		values.Pre = strings.TrimPrefix(values.Version, fmt.Sprintf("v%d.%d.%d-", v.Major, v.Minor, v.Patch))
+	}
+
+	rendered := &strings.Builder{}
+	if err := s.format.Execute(rendered, values); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(rendered.String()), nil
+}
+
+// Previous returns the existing tag of the upstream version with the highest
+// counter, which is the previous tag to be passed to Apply. It is empty if
+// the format does not use the counter or if no such tag exists.
+func (s *Scheme) Previous(version string) (string, error) {
+	if !strings.Contains(s.Format, ".Counter") {
+		return "", nil
+	}
+	v, err := util.TagStringToSemver(version)
+	if err != nil {
+		return "", fmt.Errorf("invalid version %s: %w", version, err)
+	}
+	v.Build = nil
+
+	tags, err := listTags()(util.SemverToTagString(v))
+	if err != nil {
+		return "", fmt.Errorf("list tags of %s: %w", version, err)
+	}
+	previous := ""
+	var highest uint64 = 1
+	for _, tag := range tags {
+		if c := counter(v, tag); c > highest {
+			previous, highest = tag, c
+		}
+	}
+	return previous, nil
+}
+
+// remoteTags lists the tags of the Kubernetes repository.
+func remoteTags(prefix string) ([]string, error) {
+	output, err := git.LSRemoteExec(
+		git.GetDefaultKubernetesRepoURL(), "--tags", "--refs", "refs/tags/"+prefix+"*",
+	)
+	if err != nil {
+		return nil, err
+	}
+	tags := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		tags = append(tags, strings.TrimPrefix(fields[1], "refs/tags/"))
+	}
+	return tags, nil
+}
+
+// counter returns the counter of the version based on the previous tag.
+func counter(version semver.Version, previous string) uint64 {
+	if previous == "" {
+		return 1
+	}
+	p, err := util.TagStringToSemver(previous)
+	if err != nil {
+		return 1
+	}
+	build := p.Build
+	p.Build = nil
+	if !p.Equals(version) {
+		return 1
+	}
+	for i := len(build) - 1; i >= 0; i-- {
+		if n, err := strconv.ParseUint(build[i], 10, 64); err == nil {
+			return n + 1
+		}
+	}
+	return 1
+}
+
+// Options are the options for selecting the scheme.
+type Options struct {
+	// SchemeFile is the YAML file containing the scheme. Empty means the
+	// default scheme.
+	SchemeFile string
+
+	// Format is the format of the scheme, which takes precedence over the
+	// SchemeFile. It is used for forwarding the scheme to the GCB jobs.
+	Format string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		SchemeFile: env.Default(SchemeEnvKey, ""),
+	}
+}
+
+// AddFlags adds the tag scheme flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.SchemeFile,
+		"tag-scheme",
+		o.SchemeFile,
+		fmt.Sprintf("YAML file of the format of the generated release tags (default $%s)", SchemeEnvKey),
+	)
+	flags.StringVar(
+		&o.Format,
+		"tag-scheme-format",
+		o.Format,
+		"Go template of the generated release tags, takes precedence over --tag-scheme",
+	)
+}
+
+var (
+	mu      sync.RWMutex
+	current           = DefaultScheme()
+	lister  TagLister = remoteTags
+)
+
+// Setup loads the scheme of the provided options and uses it for generating
+// the release versions.
+func Setup(opts *Options) error {
+	if opts.Format != "" {
+		s := &Scheme{Format: opts.Format}
+		if err := s.Validate(); err != nil {
+			return fmt.Errorf("validate tag scheme format: %w", err)
+		}
+		logrus.Infof("Using tag scheme format %s", opts.Format)
+		SetDefault(s)
+		return nil
+	}
+	if opts.SchemeFile == "" {
+		SetDefault(nil)
+		return nil
+	}
+	s, err := Load(opts.SchemeFile)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Using tag scheme %s", opts.SchemeFile)
+	SetDefault(s)
+	return nil
+}
+
+// SetDefault sets the scheme used for generating the release versions. A
+// nil scheme restores the default one.
+func SetDefault(s *Scheme) {
+	mu.Lock()
+	defer mu.Unlock()
+	if s == nil {
+		s = DefaultScheme()
+	}
+	current = s
+}
+
+// Default returns the scheme used for generating the release versions.
+func Default() *Scheme {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// SetTagLister sets the lister of the existing tags used by Previous. A nil
+// lister restores the one listing the tags of the Kubernetes repository.
+func SetTagLister(l TagLister) {
+	mu.Lock()
+	defer mu.Unlock()
+	if l == nil {
+		l = remoteTags
+	}
+	lister = l
+}
+
+func listTags() TagLister {
+	mu.RLock()
+	defer mu.RUnlock()
+	return lister
+}
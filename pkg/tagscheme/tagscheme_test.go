@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tagscheme_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/tagscheme"
+)
+
+func TestApply(t *testing.T) {
+	now := time.Date(2024, 10, 14, 23, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		format, version, previous string
+		expected                  string
+	}{
+		{
+			version:  "v1.30.1",
+			expected: "v1.30.1",
+		},
+		{
+			version:  "1.31.0-rc.0.10+abcdef",
+			expected: "v1.31.0-rc.0.10",
+		},
+		{
+			format:   "{{ .Version }}+acme.{{ .Counter }}",
+			version:  "v1.30.1",
+			expected: "v1.30.1+acme.1",
+		},
+		{
+			format:   "{{ .Version }}+acme.{{ .Counter }}",
+			version:  "v1.30.1",
+			previous: "v1.30.1+acme.3",
+			expected: "v1.30.1+acme.4",
+		},
+		{
+			format:   "{{ .Version }}+acme.{{ .Counter }}",
+			version:  "v1.30.2",
+			previous: "v1.30.1+acme.3",
+			expected: "v1.30.2+acme.1",
+		},
+		{
+			format:   "{{ .Version }}{{ if .Pre }}.{{ else }}-{{ end }}nightly.{{ .Date }}",
+			version:  "v1.31.0-alpha.1",
+			expected: "v1.31.0-alpha.1.nightly.20241014",
+		},
+		{
+			format:   "{{ .Version }}{{ if .Pre }}.{{ else }}-{{ end }}nightly.{{ .Date }}",
+			version:  "v1.31.0",
+			expected: "v1.31.0-nightly.20241014",
+		},
+		{
+			format:   "v{{ .Major }}.{{ .Minor }}.{{ .Patch }}{{ with .Pre }}-{{ . }}{{ end }}",
+			version:  "v1.31.0-beta.2",
+			expected: "v1.31.0-beta.2",
+		},
+	} {
+		sut := &tagscheme.Scheme{Format: tc.format}
+		require.NoError(t, sut.Validate(), tc.format)
+
+		res, err := sut.Apply(tc.version, tc.previous, now)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, res)
+	}
+
+	_, err := tagscheme.DefaultScheme().Apply("wrong", "", now)
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	for _, format := range []string{
+		"{{ .Version",
+		"{{ .Unknown }}",
+		"release-{{ .Version }}",
+		"v2.0.0",
+		"v{{ .Major }}.{{ .Minor }}",
+	} {
+		require.Error(t, (&tagscheme.Scheme{Format: format}).Validate(), format)
+	}
+}
+
+func TestSetup(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "scheme.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`format: "{{ .Version }}+acme.{{ .Counter }}"`), 0o600))
+
+	require.NoError(t, tagscheme.Setup(&tagscheme.Options{SchemeFile: file}))
+	t.Cleanup(func() { tagscheme.SetDefault(nil) })
+
+	res, err := tagscheme.Default().Apply("v1.30.1", "", time.Now())
+	require.NoError(t, err)
+	require.Equal(t, "v1.30.1+acme.1", res)
+
+	wrong := filepath.Join(dir, "wrong.yaml")
+	require.NoError(t, os.WriteFile(wrong, []byte(`unknown: true`), 0o600))
+	require.Error(t, tagscheme.Setup(&tagscheme.Options{SchemeFile: wrong}))
+	require.Error(t, tagscheme.Setup(&tagscheme.Options{SchemeFile: filepath.Join(dir, "missing")}))
+
+	require.NoError(t, tagscheme.Setup(&tagscheme.Options{SchemeFile: file, Format: "{{ .Version }}+corp"}))
+	res, err = tagscheme.Default().Apply("v1.30.1", "", time.Now())
+	require.NoError(t, err)
+	require.Equal(t, "v1.30.1+corp", res)
+	require.Error(t, tagscheme.Setup(&tagscheme.Options{Format: "corp"}))
+
+	require.NoError(t, tagscheme.Setup(&tagscheme.Options{}))
+	res, err = tagscheme.Default().Apply("v1.30.1", "", time.Now())
+	require.NoError(t, err)
+	require.Equal(t, "v1.30.1", res)
+}
+
+func TestPrevious(t *testing.T) {
+	prefixes := []string{}
+	tagscheme.SetTagLister(func(prefix string) ([]string, error) {
+		prefixes = append(prefixes, prefix)
+		return []string{
+			"v1.30.1-rc.0+acme.7", "v1.30.1+acme.2", "v1.30.1+acme.10", "v1.30.1+acme.3",
+		}, nil
+	})
+	t.Cleanup(func() { tagscheme.SetTagLister(nil) })
+
+	scheme := &tagscheme.Scheme{Format: "{{ .Version }}+acme.{{ .Counter }}"}
+	require.NoError(t, scheme.Validate())
+	res, err := scheme.Previous("v1.30.1")
+	require.NoError(t, err)
+	require.Equal(t, "v1.30.1+acme.10", res)
+	require.Equal(t, []string{"v1.30.1"}, prefixes)
+
+	res, err = scheme.Previous("v1.30.2-rc.1")
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	res, err = tagscheme.DefaultScheme().Previous("v1.30.1")
+	require.NoError(t, err)
+	require.Empty(t, res)
+	require.Len(t, prefixes, 2)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package templates contains the official Kubernetes announcement templates,
+// which are embedded into the binaries.
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// GitHubPage is the markdown template of the GitHub release page.
+	GitHubPage = "github-page"
+
+	// BranchAnnouncement is the HTML email template announcing the creation
+	// of a release branch.
+	BranchAnnouncement = "announcement-branch"
+
+	// ReleaseAnnouncement is the HTML email template announcing a release.
+	ReleaseAnnouncement = "announcement-release"
+
+	// BlogPost is the markdown template of the release blog post.
+	BlogPost = "blog-post"
+
+	// SocialAnnouncement is the plain text template of the short release
+	// announcement posted to social webhooks.
+	SocialAnnouncement = "announcement-social"
+)
+
+//go:embed official/*.tmpl
+var official embed.FS
+
+// Template is an official template shipped with the binary.
+type Template struct {
+	// Name is used to select the template instead of a file path.
+	Name string `json:"name"`
+
+	// Description explains what the template renders.
+	Description string `json:"description"`
+
+	// file is the embedded template file.
+	file string
+}
+
+var all = []Template{
+	{Name: BranchAnnouncement, Description: "Email announcing the creation of a release branch", file: "announcement-branch.html.tmpl"},
+	{Name: ReleaseAnnouncement, Description: "Email announcing a new release", file: "announcement-release.html.tmpl"},
+	{Name: SocialAnnouncement, Description: "Short post announcing a new release via a social webhook", file: "announcement-social.txt.tmpl"},
+	{Name: BlogPost, Description: "Release blog post for the Kubernetes website", file: "blog-post.md.tmpl"},
+	{Name: GitHubPage, Description: "GitHub release page", file: "github-page.md.tmpl"},
+}
+
+// List returns all official templates sorted by their name.
+func List() []Template {
+	return append([]Template{}, all...)
+}
+
+// Get returns the content of the official template with the provided name.
+func Get(name string) (string, error) {
+	for _, t := range all {
+		if t.Name != name {
+			continue
+		}
+		content, err := official.ReadFile(path.Join("official", t.file))
+		if err != nil {
+			return "", fmt.Errorf("read template %s: %w", name, err)
+		}
+		return string(content), nil
+	}
+	return "", fmt.Errorf("unknown template %q", name)
+}
+
+// MustGet returns the content of the official template with the provided
+// name and panics if it does not exist.
+func MustGet(name string) string {
+	content, err := Get(name)
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
+
+// Read returns the official template if nameOrPath is the name of one,
+// otherwise the content of the file at nameOrPath.
+func Read(nameOrPath string) (string, error) {
+	if content, err := Get(nameOrPath); err == nil {
+		logrus.Infof("Using official template %s", nameOrPath)
+		return content, nil
+	}
+
+	content, err := os.ReadFile(nameOrPath)
+	if err != nil {
+		return "", fmt.Errorf("reading template file: %w", err)
+	}
+	logrus.Infof("Using custom template from %s", nameOrPath)
+	return string(content), nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/templates"
+)
+
+func TestGet(t *testing.T) {
+	for _, tpl := range templates.List() {
+		content, err := templates.Get(tpl.Name)
+		require.NoError(t, err, tpl.Name)
+		require.NotEmpty(t, content, tpl.Name)
+		require.NotEmpty(t, tpl.Description, tpl.Name)
+	}
+
+	_, err := templates.Get("unknown")
+	require.ErrorContains(t, err, `unknown template "unknown"`)
+}
+
+func TestRead(t *testing.T) {
+	official, err := templates.Read(templates.BranchAnnouncement)
+	require.NoError(t, err)
+	require.Contains(t, official, "{{ .Branch }} branch has been created")
+
+	custom := filepath.Join(t.TempDir(), "custom.tmpl")
+	require.NoError(t, os.WriteFile(custom, []byte("custom {{ .Tag }}"), 0o600))
+	content, err := templates.Read(custom)
+	require.NoError(t, err)
+	require.Equal(t, "custom {{ .Tag }}", content)
+
+	_, err = templates.Read(filepath.Join(t.TempDir(), "missing.tmpl"))
+	require.ErrorContains(t, err, "reading template file")
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testgrid
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// maxReportTests is the maximum amount of tests listed per job in a report.
+const maxReportTests = 5
+
+// Summaries retrieves the job summaries for all provided dashboards.
+func (t *TestGrid) Summaries(dashboards []DashboardName) (DashboardData, error) {
+	res := DashboardData{}
+	for _, dashboard := range dashboards {
+		jobs, err := t.Summary(dashboard)
+		if err != nil {
+			return nil, fmt.Errorf("get summary for dashboard %s: %w", dashboard, err)
+		}
+		res[dashboard] = jobs
+	}
+	return res, nil
+}
+
+// Report renders a markdown health report for the provided dashboards. Every
+// dashboard contains its overall pass rate as well as a table of all jobs
+// matching the provided states, including their recent success rate, last
+// green run and failing or flaky tests.
+func (t *TestGrid) Report(
+	data DashboardData, dashboards []DashboardName, states []OverallStatus,
+) string {
+	output := []string{}
+
+	for _, dashboard := range dashboards {
+		jobs := data[dashboard]
+		output = append(output,
+			fmt.Sprintf("#### [%s](%s/%s)\n", dashboard, t.url, dashboard),
+			passRate(jobs)+"\n",
+		)
+
+		names := []JobName{}
+		for name, job := range jobs {
+			for _, state := range states {
+				if job.OverallStatus == state {
+					names = append(names, name)
+					break
+				}
+			}
+		}
+		sortJobNames(names)
+
+		if len(names) == 0 {
+			output = append(output, fmt.Sprintf("**No %s jobs**\n", joinStates(states)))
+			continue
+		}
+
+		buf := &bytes.Buffer{}
+		table := tablewriter.NewWriter(buf)
+		table.SetAutoWrapText(false)
+		table.SetHeader([]string{"Job", "Status", "Recent Runs", "Last Green", "Tests"})
+		for _, name := range names {
+			job := jobs[name]
+			lastGreen := job.LatestGreen
+			if lastGreen == "" {
+				lastGreen = "-"
+			}
+			table.Append([]string{
+				fmt.Sprintf("[%s](%s)", name, t.jobURL(dashboard, name)),
+				string(job.OverallStatus),
+				job.FilterSuccessRateForLastRuns(),
+				lastGreen,
+				reportTests(job.Tests),
+			})
+		}
+		table.SetBorders(tablewriter.Border{
+			Left: true, Top: false, Right: true, Bottom: false,
+		})
+		table.SetCenterSeparator("|")
+		table.Render()
+		output = append(output, buf.String())
+	}
+
+	return strings.Join(output, "\n")
+}
+
+func (t *TestGrid) jobURL(dashboard DashboardName, name JobName) string {
+	return fmt.Sprintf(
+		"%s/%s#%s", t.url, dashboard, strings.ReplaceAll(string(name), " ", "%20"),
+	)
+}
+
+func passRate(jobs JobData) string {
+	counts := map[OverallStatus]int{}
+	for _, job := range jobs {
+		counts[job.OverallStatus]++
+	}
+
+	rate := 0.0
+	if len(jobs) > 0 {
+		rate = float64(counts[Passing]) / float64(len(jobs)) * 100
+	}
+
+	return fmt.Sprintf(
+		"**Pass rate:** %d of %d jobs passing (%.1f%%), %d flaky, %d failing, %d stale",
+		counts[Passing], len(jobs), rate,
+		counts[Flaky], counts[Failing], counts[Stale],
+	)
+}
+
+func reportTests(tests []Test) string {
+	names := []string{}
+	for i := range tests {
+		if i == maxReportTests {
+			names = append(names, fmt.Sprintf("(+%d more)", len(tests)-maxReportTests))
+			break
+		}
+		name := tests[i].DisplayName
+		if name == "" {
+			name = tests[i].TestName
+		}
+		names = append(names, "`"+strings.ReplaceAll(name, "|", `\|`)+"`")
+	}
+	return strings.Join(names, "<br>")
+}
+
+func joinStates(states []OverallStatus) string {
+	res := []string{}
+	for _, state := range states {
+		res = append(res, string(state))
+	}
+	return strings.Join(res, " or ")
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testgrid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/testgrid"
+)
+
+const testReportSummary = `{
+  "job-a": {"overall_status": "PASSING", "status": "9 of 9 (100.0%) recent columns passed", "latest_green": "1001"},
+  "job-b": {"overall_status": "FLAKY", "status": "8 of 9 (88.9%) recent columns passed", "latest_green": "1002",
+            "tests": [{"display_name": "test-1"}, {"test_name": "test|2"}]},
+  "job-c": {"overall_status": "FAILING", "status": "0 of 9 (0.0%) recent columns passed",
+            "tests": [{"display_name": "1"}, {"display_name": "2"}, {"display_name": "3"},
+                      {"display_name": "4"}, {"display_name": "5"}, {"display_name": "6"}]}
+}`
+
+func TestSummariesSuccess(t *testing.T) {
+	// Given
+	sut, client := newSut()
+	client.GetURLResponseReturnsOnCall(0, testReportSummary, nil)
+	client.GetURLResponseReturnsOnCall(1, "{}", nil)
+	sut.SetURL("https://testgrid.example.com/")
+
+	// When
+	res, err := sut.Summaries([]testgrid.DashboardName{"first", "second"})
+
+	// Then
+	require.Nil(t, err)
+	require.Len(t, res, 2)
+	require.Len(t, res["first"], 3)
+	require.Empty(t, res["second"])
+	url, _ := client.GetURLResponseArgsForCall(1)
+	require.Equal(t, "https://testgrid.example.com/second/summary", url)
+}
+
+func TestSummariesFailure(t *testing.T) {
+	// Given
+	sut, client := newSut()
+	client.GetURLResponseReturns("", errors.New(""))
+
+	// When
+	res, err := sut.Summaries([]testgrid.DashboardName{"first"})
+
+	// Then
+	require.NotNil(t, err)
+	require.Nil(t, res)
+}
+
+func TestReport(t *testing.T) {
+	// Given
+	sut, client := newSut()
+	client.GetURLResponseReturnsOnCall(0, testReportSummary, nil)
+	client.GetURLResponseReturnsOnCall(1, testReportSummary, nil)
+	dashboards := []testgrid.DashboardName{"blocking", "informing"}
+	data, err := sut.Summaries(dashboards)
+	require.Nil(t, err)
+
+	// When
+	res := sut.Report(data, dashboards, []testgrid.OverallStatus{testgrid.Failing, testgrid.Flaky})
+
+	// Then
+	require.Contains(t, res, "#### [blocking](https://testgrid.k8s.io/blocking)")
+	require.Contains(t, res, "#### [informing](https://testgrid.k8s.io/informing)")
+	require.Contains(t, res, "**Pass rate:** 1 of 3 jobs passing (33.3%), 1 flaky, 1 failing, 0 stale")
+	require.Contains(t, res, "[job-b](https://testgrid.k8s.io/blocking#job-b)")
+	require.Contains(t, res, "`test-1`<br>`test\\|2`")
+	require.Contains(t, res, "`5`<br>(+1 more)")
+	require.NotContains(t, res, "job-a")
+}
+
+func TestReportNoJobs(t *testing.T) {
+	// Given
+	sut, _ := newSut()
+	data := testgrid.DashboardData{"blocking": testgrid.JobData{
+		"job-a": testgrid.JobSummary{OverallStatus: testgrid.Passing},
+	}}
+
+	// When
+	res := sut.Report(data, []testgrid.DashboardName{"blocking"}, []testgrid.OverallStatus{testgrid.Failing})
+
+	// Then
+	require.Contains(t, res, "**Pass rate:** 1 of 1 jobs passing (100.0%), 0 flaky, 0 failing, 0 stale")
+	require.Contains(t, res, "**No FAILING jobs**")
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testgrid
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Signal is the CI signal of a release blocking dashboard.
+type Signal struct {
+	// Dashboard is the name of the evaluated dashboard.
+	Dashboard DashboardName
+
+	// Jobs contains the summaries of all jobs on the dashboard.
+	Jobs JobData
+}
+
+// BlockingDashboard returns the release blocking dashboard name for the
+// provided branch, for example `sig-release-1.29-blocking` for the branch
+// `release-1.29`.
+func BlockingDashboard(branch string) DashboardName {
+	return DashboardName(fmt.Sprintf(
+		"sig-release-%s-blocking", strings.TrimPrefix(branch, "release-"),
+	))
+}
+
+// Signal retrieves the current CI signal of the release blocking dashboard
+// for the provided branch.
+func (t *TestGrid) Signal(branch string) (*Signal, error) {
+	dashboard := BlockingDashboard(branch)
+
+	jobs, err := t.Summary(dashboard)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("no jobs found on dashboard %s", dashboard)
+	}
+
+	return &Signal{Dashboard: dashboard, Jobs: jobs}, nil
+}
+
+// Summary retrieves the job summaries of the provided dashboard by using the
+// TestGrid JSON API.
+func (t *TestGrid) Summary(dashboard DashboardName) (JobData, error) {
+	response, err := t.client.GetURLResponse(
+		fmt.Sprintf("%s/%s/summary", t.url, dashboard), false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving summary for dashboard %s: %w", dashboard, err)
+	}
+
+	if strings.Contains(response, fmt.Sprintf("Dashboard %s not found", dashboard)) {
+		return nil, ErrDashboardNotFound
+	}
+
+	jobs, err := UnmarshalTestgridSummary([]byte(response))
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal summary for dashboard %s: %w", dashboard, err)
+	}
+
+	for name, job := range jobs {
+		if job.DashboardName == "" {
+			job.DashboardName = dashboard
+			jobs[name] = job
+		}
+	}
+
+	return jobs, nil
+}
+
+// Blocking returns all jobs which prevent a release cut, sorted by name.
+// Failing and stale jobs are always blocking, while flaky jobs are only
+// blocking if there are more than `allowedFlakes` of them.
+func (s *Signal) Blocking(allowedFlakes int) []JobName {
+	blocking, flaky := []JobName{}, []JobName{}
+	for name, job := range s.Jobs {
+		switch job.OverallStatus {
+		case Passing:
+		case Flaky:
+			flaky = append(flaky, name)
+		default:
+			blocking = append(blocking, name)
+		}
+	}
+
+	if len(flaky) > allowedFlakes {
+		blocking = append(blocking, flaky...)
+	}
+
+	sortJobNames(blocking)
+	return blocking
+}
+
+// Check returns an error including the dashboard summary if the CI signal
+// does not allow a release cut.
+func (s *Signal) Check(allowedFlakes int) error {
+	blocking := s.Blocking(allowedFlakes)
+	if len(blocking) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%d release blocking job(s) on dashboard %s are not green "+
+			"(allowed flaky jobs: %d):\n%s",
+		len(blocking), s.Dashboard, allowedFlakes, s.Summary(),
+	)
+}
+
+// Summary returns a markdown table containing the status of every job on the
+// dashboard, sorted by job name.
+func (s *Signal) Summary() string {
+	names := []JobName{}
+	for name := range s.Jobs {
+		names = append(names, name)
+	}
+	sortJobNames(names)
+
+	buf := &bytes.Buffer{}
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Job", "Status", "Recent Runs", "Failing Tests"})
+	for _, name := range names {
+		job := s.Jobs[name]
+		table.Append([]string{
+			fmt.Sprintf("[%s](%s)", name, job.GetJobURL(name)),
+			string(job.OverallStatus),
+			job.FilterSuccessRateForLastRuns(),
+			fmt.Sprint(len(job.Tests)),
+		})
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+
+	return buf.String()
+}
+
+func sortJobNames(names []JobName) {
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+}
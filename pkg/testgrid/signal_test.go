@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testgrid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/testgrid"
+)
+
+const testSummary = `{
+  "job-a": {"overall_status": "PASSING", "status": "9 of 9 (100.0%) recent columns passed"},
+  "job-b": {"overall_status": "FLAKY", "status": "8 of 9 (88.9%) recent columns passed"},
+  "job-c": {"overall_status": "FLAKY", "status": "7 of 9 (77.8%) recent columns passed"}
+}`
+
+func TestBlockingDashboard(t *testing.T) {
+	require.Equal(t, testgrid.DashboardName("sig-release-master-blocking"), testgrid.BlockingDashboard("master"))
+	require.Equal(t, testgrid.DashboardName("sig-release-1.29-blocking"), testgrid.BlockingDashboard("release-1.29"))
+}
+
+func TestSignalSuccess(t *testing.T) {
+	// Given
+	sut, client := newSut()
+	client.GetURLResponseReturns(testSummary, nil)
+
+	// When
+	res, err := sut.Signal("release-1.29")
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, testgrid.DashboardName("sig-release-1.29-blocking"), res.Dashboard)
+	require.Len(t, res.Jobs, 3)
+	url, _ := client.GetURLResponseArgsForCall(0)
+	require.Equal(t, "https://testgrid.k8s.io/sig-release-1.29-blocking/summary", url)
+	require.Equal(t, res.Dashboard, res.Jobs["job-a"].DashboardName)
+}
+
+func TestSignalFailureHTTP(t *testing.T) {
+	// Given
+	sut, client := newSut()
+	client.GetURLResponseReturns("", errors.New(""))
+
+	// When
+	res, err := sut.Signal("master")
+
+	// Then
+	require.NotNil(t, err)
+	require.Nil(t, res)
+}
+
+func TestSignalFailureDashboardNotFound(t *testing.T) {
+	// Given
+	sut, client := newSut()
+	client.GetURLResponseReturns("Dashboard sig-release-master-blocking not found", nil)
+
+	// When
+	res, err := sut.Signal("master")
+
+	// Then
+	require.ErrorIs(t, err, testgrid.ErrDashboardNotFound)
+	require.Nil(t, res)
+}
+
+func TestSignalFailureNoJobs(t *testing.T) {
+	// Given
+	sut, client := newSut()
+	client.GetURLResponseReturns("{}", nil)
+
+	// When
+	res, err := sut.Signal("master")
+
+	// Then
+	require.NotNil(t, err)
+	require.Nil(t, res)
+}
+
+func TestSignalCheck(t *testing.T) {
+	for _, tc := range []struct {
+		status        testgrid.OverallStatus
+		allowedFlakes int
+		blocking      []testgrid.JobName
+	}{
+		{ // flakes within tolerance
+			status:        testgrid.Passing,
+			allowedFlakes: 2,
+			blocking:      []testgrid.JobName{},
+		},
+		{ // too many flakes
+			status:        testgrid.Passing,
+			allowedFlakes: 1,
+			blocking:      []testgrid.JobName{"job-b", "job-c"},
+		},
+		{ // failing job
+			status:        testgrid.Failing,
+			allowedFlakes: 2,
+			blocking:      []testgrid.JobName{"job-a"},
+		},
+		{ // stale job
+			status:        testgrid.Stale,
+			allowedFlakes: 2,
+			blocking:      []testgrid.JobName{"job-a"},
+		},
+	} {
+		jobs, err := testgrid.UnmarshalTestgridSummary([]byte(testSummary))
+		require.Nil(t, err)
+		job := jobs["job-a"]
+		job.OverallStatus = tc.status
+		jobs["job-a"] = job
+
+		sut := &testgrid.Signal{Dashboard: "sig-release-master-blocking", Jobs: jobs}
+		require.Equal(t, tc.blocking, sut.Blocking(tc.allowedFlakes))
+
+		err = sut.Check(tc.allowedFlakes)
+		if len(tc.blocking) == 0 {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "job-a")
+		}
+	}
+}
+
+func TestSignalSummary(t *testing.T) {
+	sut, client := newSut()
+	client.GetURLResponseReturns(testSummary, nil)
+	signal, err := sut.Signal("master")
+	require.Nil(t, err)
+
+	summary := signal.Summary()
+	require.Contains(t, summary, "STATUS")
+	require.Contains(t, summary, "| [job-b](https://testgrid.k8s.io/sig-release-master-blocking#job-b) | FLAKY   | 8 of 9 (88.9%)")
+}
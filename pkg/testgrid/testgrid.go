@@ -19,25 +19,33 @@ package testgrid
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/testgrid/config"
 	pb "github.com/GoogleCloudPlatform/testgrid/pb/config"
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/release-utils/http"
+
+	"k8s.io/release/pkg/workdir"
 )
 
-const testgridConfigURL = "https://storage.googleapis.com/k8s-testgrid/config"
+const (
+	testgridURL       = "https://testgrid.k8s.io"
+	testgridConfigURL = "https://storage.googleapis.com/k8s-testgrid/config"
+)
 
 // TestGrid is the default test grid client
 type TestGrid struct {
 	client Client
+	url    string
 }
 
 // New creates a new TestGrid
 func New() *TestGrid {
 	return &TestGrid{
-		&testGridClient{},
+		client: &testGridClient{},
+		url:    testgridURL,
 	}
 }
 
@@ -59,6 +67,12 @@ func (t *TestGrid) SetClient(client Client) {
 	t.client = client
 }
 
+// SetURL can be used to set the TestGrid URL, which defaults to
+// https://testgrid.k8s.io
+func (t *TestGrid) SetURL(url string) {
+	t.url = strings.TrimSuffix(url, "/")
+}
+
 // BlockingTests returns the blocking tests for the provided branch name or an
 // error if those are not available
 func (t *TestGrid) BlockingTests(branch string) (tests []string, err error) {
@@ -83,7 +97,7 @@ func (t *TestGrid) BlockingTests(branch string) (tests []string, err error) {
 func (t *TestGrid) configFromURL(url string) (cfg *pb.Configuration, err error) {
 	logrus.Info("Retrieving testgrid configuration")
 
-	tmpFile, err := os.CreateTemp("", "testgrid-jobs-")
+	tmpFile, err := workdir.CreateTemp("testgrid-jobs-")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// tracesPath is the OTLP/HTTP path for exporting spans.
+const tracesPath = "/v1/traces"
+
+// Exporter exports spans to an OTLP/HTTP collector by using the JSON
+// encoding of the protocol.
+type Exporter struct {
+	impl     impl
+	url      string
+	headers  map[string]string
+	mu       sync.Mutex
+	shutdown bool
+}
+
+var _ sdktrace.SpanExporter = &Exporter{}
+
+// NewExporter creates a new Exporter for the provided collector endpoint.
+func NewExporter(endpoint string, headers map[string]string) *Exporter {
+	return &Exporter{
+		impl:    &defaultImpl{},
+		url:     strings.TrimSuffix(endpoint, "/") + tracesPath,
+		headers: headers,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (e *Exporter) SetImpl(impl impl) {
+	e.impl = impl
+}
+
+// ExportSpans sends the spans to the collector.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	shutdown := e.shutdown
+	e.mu.Unlock()
+	if shutdown || len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(newTracesRequest(spans))
+	if err != nil {
+		return fmt.Errorf("marshal spans: %w", err)
+	}
+	if err := e.impl.Post(ctx, e.url, body, e.headers); err != nil {
+		return fmt.Errorf("export %d spans to %s: %w", len(spans), e.url, err)
+	}
+	return nil
+}
+
+// Shutdown stops the exporter.
+func (e *Exporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return nil
+}
+
+// The types below are the JSON representation of the OTLP
+// ExportTraceServiceRequest.
+type tracesRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   otlpResource `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Events            []event    `json:"events,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type event struct {
+	Name         string     `json:"name"`
+	TimeUnixNano string     `json:"timeUnixNano"`
+	Attributes   []keyValue `json:"attributes,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// OTLP status codes
+const (
+	statusUnset = 0
+	statusOK    = 1
+	statusError = 2
+)
+
+func newTracesRequest(spans []sdktrace.ReadOnlySpan) *tracesRequest {
+	req := &tracesRequest{ResourceSpans: []resourceSpans{}}
+	resourceIndex := map[string]int{}
+	scopeIndex := map[string]map[string]int{}
+
+	for _, s := range spans {
+		resourceKey := s.Resource().Encoded(attribute.DefaultEncoder())
+		ri, ok := resourceIndex[resourceKey]
+		if !ok {
+			ri = len(req.ResourceSpans)
+			resourceIndex[resourceKey] = ri
+			scopeIndex[resourceKey] = map[string]int{}
+			req.ResourceSpans = append(req.ResourceSpans, resourceSpans{
+				Resource:   otlpResource{Attributes: keyValues(s.Resource().Attributes())},
+				ScopeSpans: []scopeSpans{},
+			})
+		}
+
+		scopeKey := s.InstrumentationScope().Name + "@" + s.InstrumentationScope().Version
+		si, ok := scopeIndex[resourceKey][scopeKey]
+		if !ok {
+			si = len(req.ResourceSpans[ri].ScopeSpans)
+			scopeIndex[resourceKey][scopeKey] = si
+			req.ResourceSpans[ri].ScopeSpans = append(req.ResourceSpans[ri].ScopeSpans, scopeSpans{
+				Scope: scope{
+					Name:    s.InstrumentationScope().Name,
+					Version: s.InstrumentationScope().Version,
+				},
+				Spans: []span{},
+			})
+		}
+
+		req.ResourceSpans[ri].ScopeSpans[si].Spans = append(
+			req.ResourceSpans[ri].ScopeSpans[si].Spans, newSpan(s),
+		)
+	}
+	return req
+}
+
+func newSpan(s sdktrace.ReadOnlySpan) span {
+	res := span{
+		TraceID:           s.SpanContext().TraceID().String(),
+		SpanID:            s.SpanContext().SpanID().String(),
+		Name:              s.Name(),
+		Kind:              int(s.SpanKind()),
+		StartTimeUnixNano: strconv.FormatInt(s.StartTime().UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.EndTime().UnixNano(), 10),
+		Attributes:        keyValues(s.Attributes()),
+		Status:            status{Code: statusUnset, Message: s.Status().Description},
+	}
+	if s.Parent().HasSpanID() {
+		res.ParentSpanID = s.Parent().SpanID().String()
+	}
+
+	switch s.Status().Code {
+	case codes.Ok:
+		res.Status.Code = statusOK
+	case codes.Error:
+		res.Status.Code = statusError
+	case codes.Unset:
+	}
+
+	for _, e := range s.Events() {
+		res.Events = append(res.Events, event{
+			Name:         e.Name,
+			TimeUnixNano: strconv.FormatInt(e.Time.UnixNano(), 10),
+			Attributes:   keyValues(e.Attributes),
+		})
+	}
+	return res
+}
+
+func keyValues(attrs []attribute.KeyValue) []keyValue {
+	res := []keyValue{}
+	for _, attr := range attrs {
+		kv := keyValue{Key: string(attr.Key)}
+		switch attr.Value.Type() {
+		case attribute.BOOL:
+			v := attr.Value.AsBool()
+			kv.Value.BoolValue = &v
+		case attribute.INT64:
+			v := strconv.FormatInt(attr.Value.AsInt64(), 10)
+			kv.Value.IntValue = &v
+		case attribute.FLOAT64:
+			v := attr.Value.AsFloat64()
+			kv.Value.DoubleValue = &v
+		default:
+			v := attr.Value.Emit()
+			kv.Value.StringValue = &v
+		}
+		res = append(res, kv)
+	}
+	return res
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"k8s.io/release/pkg/redact"
+)
+
+const (
+	// EndpointEnvKey is the standard OpenTelemetry environment variable for
+	// setting the OTLP endpoint.
+	EndpointEnvKey = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	// DefaultServiceName is the default service name of the exported spans.
+	DefaultServiceName = "krel"
+
+	tracerName = "k8s.io/release"
+)
+
+// Options are the settings for tracing.
+type Options struct {
+	// Endpoint is the OTLP/HTTP collector endpoint, for example
+	// http://localhost:4318. Tracing is disabled if empty.
+	Endpoint string
+
+	// ServiceName is the service name of the exported spans.
+	ServiceName string
+
+	// Headers are additional HTTP headers sent to the collector, for
+	// example for authentication.
+	Headers map[string]string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Endpoint:    os.Getenv(EndpointEnvKey),
+		ServiceName: DefaultServiceName,
+	}
+}
+
+// AddFlags adds the tracing flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Endpoint,
+		"otlp-endpoint",
+		o.Endpoint,
+		fmt.Sprintf("the OTLP/HTTP endpoint to export traces to, tracing is disabled if empty (default from $%s)", EndpointEnvKey),
+	)
+}
+
+var (
+	endpoint   string
+	endpointMu sync.RWMutex
+)
+
+// Setup configures the global tracer provider to export the spans to the
+// OTLP endpoint. The returned function has to be called for flushing the
+// remaining spans before exiting.
+func Setup(opts *Options) (shutdown func(context.Context) error, err error) {
+	if opts.Endpoint == "" {
+		logrus.Debug("No OTLP endpoint specified, tracing is disabled")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	if !strings.HasPrefix(opts.Endpoint, "http://") && !strings.HasPrefix(opts.Endpoint, "https://") {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q, must be a http(s) URL", opts.Endpoint)
+	}
+
+	serviceName := opts.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(NewExporter(opts.Endpoint, opts.Headers)),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	endpointMu.Lock()
+	endpoint = opts.Endpoint
+	endpointMu.Unlock()
+
+	logrus.Infof("Exporting traces to %s", opts.Endpoint)
+	return provider.Shutdown, nil
+}
+
+// Endpoint returns the configured OTLP endpoint, or an empty string if
+// tracing is disabled.
+func Endpoint() string {
+	endpointMu.RLock()
+	defer endpointMu.RUnlock()
+	return endpoint
+}
+
+// Start creates a new span and a context containing it.
+func Start(
+	ctx context.Context, name string, attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// Run executes fn within a new child span of ctx and records the returned
+// error on the span.
+func Run(ctx context.Context, name string, fn func() error) (err error) {
+	_, span := Start(ctx, name)
+	defer func() { End(span, err) }()
+	return fn()
+}
+
+// End records the error on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		err = redact.Error(err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"k8s.io/release/pkg/tracing"
+	"k8s.io/release/pkg/tracing/tracingfakes"
+)
+
+func TestSetup(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		endpoint    string
+		shouldError bool
+	}{
+		{
+			name:     "disabled",
+			endpoint: "",
+		},
+		{
+			name:        "invalid endpoint",
+			endpoint:    "localhost:4318",
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := tracing.DefaultOptions()
+			opts.Endpoint = tc.endpoint
+
+			shutdown, err := tracing.Setup(opts)
+			if tc.shouldError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, shutdown(context.Background()))
+			require.Empty(t, tracing.Endpoint())
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	err := errors.New("error")
+	require.NoError(t, tracing.Run(context.Background(), "success", func() error { return nil }))
+	require.ErrorIs(t, tracing.Run(context.Background(), "failure", func() error { return err }), err)
+}
+
+func TestExportSpans(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		prepare     func(*tracingfakes.FakeImpl)
+		assert      func(*tracingfakes.FakeImpl)
+		shouldError bool
+	}{
+		{
+			name: "success",
+			prepare: func(mock *tracingfakes.FakeImpl) {
+				mock.PostReturns(nil)
+			},
+			assert: func(mock *tracingfakes.FakeImpl) {
+				require.Equal(t, 1, mock.PostCallCount())
+				_, url, body, headers := mock.PostArgsForCall(0)
+				require.Equal(t, "http://localhost:4318/v1/traces", url)
+				require.Equal(t, map[string]string{"key": "value"}, headers)
+
+				res := map[string]any{}
+				require.NoError(t, json.Unmarshal(body, &res))
+				resourceSpans, ok := res["resourceSpans"].([]any)
+				require.True(t, ok)
+				require.Len(t, resourceSpans, 1)
+
+				scopeSpans, ok := resourceSpans[0].(map[string]any)["scopeSpans"].([]any)
+				require.True(t, ok)
+				require.Len(t, scopeSpans, 1)
+
+				spans, ok := scopeSpans[0].(map[string]any)["spans"].([]any)
+				require.True(t, ok)
+				require.Len(t, spans, 2)
+
+				child, ok := spans[0].(map[string]any)
+				require.True(t, ok)
+				require.Equal(t, "child", child["name"])
+				require.NotEmpty(t, child["parentSpanId"])
+				require.Equal(t, map[string]any{"code": float64(2), "message": "error"}, child["status"])
+				require.Equal(t, []any{map[string]any{
+					"key":   "step",
+					"value": map[string]any{"intValue": "1"},
+				}}, child["attributes"])
+
+				parent, ok := spans[1].(map[string]any)
+				require.True(t, ok)
+				require.Equal(t, "parent", parent["name"])
+				require.Nil(t, parent["parentSpanId"])
+				require.Equal(t, map[string]any{"code": float64(1)}, parent["status"])
+			},
+		},
+		{
+			name: "failure on post",
+			prepare: func(mock *tracingfakes.FakeImpl) {
+				mock.PostReturns(errors.New("error"))
+			},
+			assert: func(mock *tracingfakes.FakeImpl) {
+				require.Equal(t, 1, mock.PostCallCount())
+			},
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &tracingfakes.FakeImpl{}
+			tc.prepare(mock)
+
+			exporter := tracing.NewExporter("http://localhost:4318/", map[string]string{"key": "value"})
+			exporter.SetImpl(mock)
+
+			recorder := &spanRecorder{}
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(recorder))
+			tracer := provider.Tracer("test")
+
+			ctx, parent := tracer.Start(context.Background(), "parent")
+			_, child := tracer.Start(ctx, "child")
+			child.SetAttributes(attribute.Int("step", 1))
+			child.SetStatus(codes.Error, "error")
+			child.End()
+			parent.SetStatus(codes.Ok, "")
+			parent.End()
+
+			err := exporter.ExportSpans(context.Background(), recorder.spans)
+			if tc.shouldError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			tc.assert(mock)
+
+			require.NoError(t, exporter.Shutdown(context.Background()))
+			require.NoError(t, exporter.ExportSpans(context.Background(), recorder.spans))
+			tc.assert(mock)
+		})
+	}
+}
+
+type spanRecorder struct {
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (r *spanRecorder) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func (r *spanRecorder) Shutdown(context.Context) error { return nil }
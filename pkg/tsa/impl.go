@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsa
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"k8s.io/release/pkg/retry"
+)
+
+// requestTimeout is the timeout of a single timestamp request.
+const requestTimeout = 30 * time.Second
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt tsafakes/fake_impl.go > tsafakes/_fake_impl.go && mv tsafakes/_fake_impl.go tsafakes/fake_impl.go"
+type impl interface {
+	Post(url string, request []byte) ([]byte, error)
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path string, content []byte) error
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) Post(url string, request []byte) (response []byte, err error) {
+	client := &http.Client{Timeout: requestTimeout}
+	err = retry.Do(context.Background(), retry.ServiceTSA, func() error {
+		resp, err := client.Post(url, requestContentType, bytes.NewReader(request))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s returned %s: %s", url, resp.Status, bytes.TrimSpace(body))
+		}
+		response = body
+		return nil
+	})
+	return response, err
+}
+
+func (*defaultImpl) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (*defaultImpl) WriteFile(path string, content []byte) error {
+	return os.WriteFile(path, content, 0o644) //nolint:gosec // timestamps are public
+}
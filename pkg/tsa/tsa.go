@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tsa obtains and verifies RFC 3161 timestamps of release artifacts,
+// like the checksum manifests and signatures, from a time stamping authority
+// (TSA). The timestamps prove that the artifacts existed at the stamped time,
+// which keeps them verifiable after their signing certificates expired.
+package tsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/digitorus/timestamp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Extension is appended to the name of a timestamped file for storing
+	// the timestamp response next to it.
+	Extension = ".tsr"
+
+	// URLEnvKey is the environment variable for the TSA URL.
+	URLEnvKey = "KREL_TSA_URL"
+
+	requestContentType = "application/timestamp-query"
+)
+
+// Client requests timestamps from a TSA.
+type Client struct {
+	impl impl
+	url  string
+}
+
+// New returns a new Client for the TSA at the provided URL.
+func New(url string) *Client {
+	return &Client{&defaultImpl{}, url}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (c *Client) SetImpl(impl impl) {
+	c.impl = impl
+}
+
+// Timestamp requests a timestamp of the content and returns the DER encoded
+// timestamp response after verifying it.
+func (c *Client) Timestamp(content []byte) ([]byte, error) {
+	if c.url == "" {
+		return nil, errors.New("no TSA URL specified")
+	}
+
+	digest := crypto.SHA256.New()
+	digest.Write(content)
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	request, err := (&timestamp.Request{
+		HashAlgorithm: crypto.SHA256,
+		HashedMessage: digest.Sum(nil),
+		Certificates:  true,
+		Nonce:         nonce,
+	}).Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("create timestamp request: %w", err)
+	}
+
+	response, err := c.impl.Post(c.url, request)
+	if err != nil {
+		return nil, fmt.Errorf("request timestamp from %s: %w", c.url, err)
+	}
+
+	ts, err := Verify(content, response, nil)
+	if err != nil {
+		return nil, fmt.Errorf("verify timestamp response: %w", err)
+	}
+	if ts.Nonce == nil || ts.Nonce.Cmp(nonce) != 0 {
+		return nil, errors.New("timestamp response does not contain the nonce of the request")
+	}
+	return response, nil
+}
+
+// TimestampFile requests a timestamp of the file and writes the response next
+// to it, using the Extension. It returns the path of the response.
+func (c *Client) TimestampFile(path string) (string, error) {
+	content, err := c.impl.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+	response, err := c.Timestamp(content)
+	if err != nil {
+		return "", fmt.Errorf("timestamp %s: %w", path, err)
+	}
+	dst := path + Extension
+	if err := c.impl.WriteFile(dst, response); err != nil {
+		return "", fmt.Errorf("write %s: %w", dst, err)
+	}
+	logrus.Infof("Wrote timestamp of %s to %s", path, dst)
+	return dst, nil
+}
+
+// Verify checks that the DER encoded timestamp response is signed by the
+// included TSA certificate and stamps the content. The certificate chain is
+// verified against the roots if they are not nil.
+func Verify(content, response []byte, roots *x509.CertPool) (*timestamp.Timestamp, error) {
+	ts, err := timestamp.ParseResponse(response)
+	if err != nil {
+		return nil, fmt.Errorf("parse timestamp response: %w", err)
+	}
+	if !ts.AddTSACertificate || len(ts.Certificates) == 0 {
+		return nil, errors.New("timestamp response does not contain the TSA certificate")
+	}
+	if !ts.HashAlgorithm.Available() {
+		return nil, fmt.Errorf("unsupported hash algorithm %v", ts.HashAlgorithm)
+	}
+
+	digest := ts.HashAlgorithm.New()
+	digest.Write(content)
+	if !bytes.Equal(digest.Sum(nil), ts.HashedMessage) {
+		return nil, errors.New("timestamp does not match the content")
+	}
+
+	if roots != nil {
+		signer := ts.Certificates[0]
+		intermediates := x509.NewCertPool()
+		for _, cert := range ts.Certificates {
+			if slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageTimeStamping) {
+				signer = cert
+			}
+			intermediates.AddCert(cert)
+		}
+		if _, err := signer.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   ts.Time,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+		}); err != nil {
+			return nil, fmt.Errorf("verify TSA certificate: %w", err)
+		}
+	}
+	return ts, nil
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsa_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/digitorus/timestamp"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/tsa"
+	"k8s.io/release/pkg/tsa/tsafakes"
+)
+
+// The signing time of the response is always the current time, which has
+// to be within the validity of the TSA certificate.
+var stampTime = time.Now().UTC().Truncate(time.Second)
+
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newAuthority(t *testing.T) *authority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test TSA"},
+		NotBefore:             stampTime.Add(-time.Hour),
+		NotAfter:              stampTime.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &authority{cert, key}
+}
+
+// respond returns a timestamp response for the request, which gets modified
+// by the provided function before signing.
+func (a *authority) respond(t *testing.T, request []byte, modify func(*timestamp.Timestamp)) []byte {
+	req, err := timestamp.ParseRequest(request)
+	require.NoError(t, err)
+
+	ts := &timestamp.Timestamp{
+		HashAlgorithm:     req.HashAlgorithm,
+		HashedMessage:     req.HashedMessage,
+		Time:              stampTime,
+		SerialNumber:      big.NewInt(42),
+		Policy:            []int{1, 2, 3, 4, 1},
+		Nonce:             req.Nonce,
+		AddTSACertificate: req.Certificates,
+	}
+	if modify != nil {
+		modify(ts)
+	}
+	res, err := ts.CreateResponse(a.cert, a.key)
+	require.NoError(t, err)
+	return res
+}
+
+func TestTimestamp(t *testing.T) {
+	content := []byte("SHA256SUMS content")
+	a := newAuthority(t)
+
+	for _, tc := range []struct {
+		name    string
+		prepare func(*tsafakes.FakeImpl)
+		url     string
+		assert  func(*testing.T, []byte, error)
+	}{
+		{
+			name: "success",
+			prepare: func(mock *tsafakes.FakeImpl) {
+				mock.PostCalls(func(_ string, request []byte) ([]byte, error) {
+					return a.respond(t, request, nil), nil
+				})
+			},
+			url: "https://tsa.example.com",
+			assert: func(t *testing.T, res []byte, err error) {
+				require.NoError(t, err)
+				ts, err := tsa.Verify(content, res, nil)
+				require.NoError(t, err)
+				require.Equal(t, stampTime, ts.Time.UTC())
+			},
+		},
+		{
+			name: "no URL",
+			assert: func(t *testing.T, _ []byte, err error) {
+				require.ErrorContains(t, err, "no TSA URL")
+			},
+		},
+		{
+			name: "post failure",
+			prepare: func(mock *tsafakes.FakeImpl) {
+				mock.PostReturns(nil, errors.New("connection refused"))
+			},
+			url: "https://tsa.example.com",
+			assert: func(t *testing.T, _ []byte, err error) {
+				require.ErrorContains(t, err, "connection refused")
+			},
+		},
+		{
+			name: "invalid response",
+			prepare: func(mock *tsafakes.FakeImpl) {
+				mock.PostReturns([]byte("invalid"), nil)
+			},
+			url: "https://tsa.example.com",
+			assert: func(t *testing.T, _ []byte, err error) {
+				require.ErrorContains(t, err, "parse timestamp response")
+			},
+		},
+		{
+			name: "nonce mismatch",
+			prepare: func(mock *tsafakes.FakeImpl) {
+				mock.PostCalls(func(_ string, request []byte) ([]byte, error) {
+					return a.respond(t, request, func(ts *timestamp.Timestamp) {
+						ts.Nonce = big.NewInt(1)
+					}), nil
+				})
+			},
+			url: "https://tsa.example.com",
+			assert: func(t *testing.T, _ []byte, err error) {
+				require.ErrorContains(t, err, "nonce")
+			},
+		},
+		{
+			name: "content mismatch",
+			prepare: func(mock *tsafakes.FakeImpl) {
+				mock.PostCalls(func(_ string, request []byte) ([]byte, error) {
+					return a.respond(t, request, func(ts *timestamp.Timestamp) {
+						ts.HashedMessage = make([]byte, len(ts.HashedMessage))
+					}), nil
+				})
+			},
+			url: "https://tsa.example.com",
+			assert: func(t *testing.T, _ []byte, err error) {
+				require.ErrorContains(t, err, "does not match the content")
+			},
+		},
+		{
+			name: "missing TSA certificate",
+			prepare: func(mock *tsafakes.FakeImpl) {
+				mock.PostCalls(func(_ string, request []byte) ([]byte, error) {
+					return a.respond(t, request, func(ts *timestamp.Timestamp) {
+						ts.AddTSACertificate = false
+					}), nil
+				})
+			},
+			url: "https://tsa.example.com",
+			assert: func(t *testing.T, _ []byte, err error) {
+				require.ErrorContains(t, err, "TSA certificate")
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &tsafakes.FakeImpl{}
+			if tc.prepare != nil {
+				tc.prepare(mock)
+			}
+			sut := tsa.New(tc.url)
+			sut.SetImpl(mock)
+
+			res, err := sut.Timestamp(content)
+			tc.assert(t, res, err)
+		})
+	}
+}
+
+func TestTimestampFile(t *testing.T) {
+	a := newAuthority(t)
+	mock := &tsafakes.FakeImpl{}
+	mock.ReadFileReturns([]byte("signature"), nil)
+	mock.PostCalls(func(_ string, request []byte) ([]byte, error) {
+		return a.respond(t, request, nil), nil
+	})
+
+	sut := tsa.New("https://tsa.example.com")
+	sut.SetImpl(mock)
+
+	dst, err := sut.TimestampFile("kubectl.sig")
+	require.NoError(t, err)
+	require.Equal(t, "kubectl.sig"+tsa.Extension, dst)
+	require.Equal(t, 1, mock.WriteFileCallCount())
+	path, res := mock.WriteFileArgsForCall(0)
+	require.Equal(t, dst, path)
+	_, err = tsa.Verify([]byte("signature"), res, nil)
+	require.NoError(t, err)
+
+	mock.ReadFileReturns(nil, errors.New("not found"))
+	_, err = sut.TimestampFile("missing.sig")
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestVerifyRoots(t *testing.T) {
+	content := []byte("SHA512SUMS content")
+	a := newAuthority(t)
+	mock := &tsafakes.FakeImpl{}
+	mock.PostCalls(func(_ string, request []byte) ([]byte, error) {
+		return a.respond(t, request, nil), nil
+	})
+	sut := tsa.New("https://tsa.example.com")
+	sut.SetImpl(mock)
+
+	res, err := sut.Timestamp(content)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(a.cert)
+	_, err = tsa.Verify(content, res, roots)
+	require.NoError(t, err)
+
+	_, err = tsa.Verify(content, res, x509.NewCertPool())
+	require.ErrorContains(t, err, "verify TSA certificate")
+
+	_, err = tsa.Verify([]byte("other"), res, roots)
+	require.Error(t, err)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package urlalias
+
+import (
+	"net/http"
+	"time"
+
+	"k8s.io/release/pkg/gcp/gcs"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt urlaliasfakes/fake_impl.go > urlaliasfakes/_fake_impl.go && mv urlaliasfakes/_fake_impl.go urlaliasfakes/fake_impl.go"
+type impl interface {
+	// ReadObject returns nil and generation 0 if the object does not exist.
+	ReadObject(gcsPath string) (content []byte, generation int64, err error)
+	// WriteObject returns ErrGenerationMismatch if the object changed since
+	// reading the generation, where 0 requires that it does not exist yet.
+	WriteObject(gcsPath string, content []byte, generation int64) error
+	// Resolve requests the URL without following redirects and returns the
+	// status code together with the Location header.
+	Resolve(url string) (status int, location string, err error)
+}
+
+// ErrGenerationMismatch is returned if an object got changed concurrently.
+var ErrGenerationMismatch = gcs.ErrGenerationMismatch
+
+type defaultImpl struct{}
+
+func (*defaultImpl) ReadObject(gcsPath string) (content []byte, generation int64, err error) {
+	return gcs.ReadObjectGeneration(gcsPath)
+}
+
+func (*defaultImpl) WriteObject(gcsPath string, content []byte, generation int64) error {
+	return gcs.WriteObjectGeneration(gcsPath, content, generation)
+}
+
+func (*defaultImpl) Resolve(url string) (status int, location string, err error) {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	res, err := client.Head(url) //nolint:noctx // the URLs are built from the download host
+	if err != nil {
+		return 0, "", err
+	}
+	defer res.Body.Close()
+	return res.StatusCode, res.Header.Get("Location"), nil
+}
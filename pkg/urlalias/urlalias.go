@@ -0,0 +1,385 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package urlalias manages the short and stable alias URLs of the release
+// artifacts on the download host, for example
+// dl.k8s.io/v1.31.0/kubernetes.tar.gz, which redirect to their location below
+// the release directory. The aliases are kept in a manifest next to the
+// version markers. The manifest does not configure the redirector by itself:
+// the dl.k8s.io redirector is nginx configuration in kubernetes/k8s.io, to
+// which the rules rendered from the manifest have to be added.
+package urlalias
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-sdk/object"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/branding"
+	"k8s.io/release/pkg/layout"
+	"k8s.io/release/pkg/release"
+)
+
+// ManifestFile is the name of the alias manifest in the directory of the
+// version markers.
+const ManifestFile = "aliases.json"
+
+const (
+	// publishAttempts is the number of attempts for updating the manifest,
+	// which can be changed concurrently by releases of other branches.
+	publishAttempts = 5
+
+	// maxRedirects is the maximum number of redirects followed when
+	// verifying an alias.
+	maxRedirects = 10
+)
+
+// DefaultFiles are the release artifacts which get an alias by default.
+var DefaultFiles = []string{
+	release.KubernetesTar,
+	"kubernetes-src.tar.gz",
+	release.ChecksumsFile,
+	release.VerifiedFile,
+}
+
+// ErrUnresolved is returned if at least one alias does not redirect to its
+// target or the target does not exist.
+var ErrUnresolved = errors.New("aliases do not resolve")
+
+// Alias is a short path on the download host redirecting to a release
+// artifact.
+type Alias struct {
+	// Path is the alias below the download host, for example
+	// v1.31.0/kubernetes.tar.gz.
+	Path string `json:"path"`
+
+	// Target is the location of the artifact below the download host, for
+	// example release/v1.31.0/kubernetes.tar.gz.
+	Target string `json:"target"`
+}
+
+// URL returns the alias URL on the download host.
+func (a *Alias) URL() string {
+	return branding.Default().DownloadURL(a.Path)
+}
+
+// TargetURL returns the URL of the artifact on the download host.
+func (a *Alias) TargetURL() string {
+	return branding.Default().DownloadURL(a.Target)
+}
+
+// Manifest contains all aliases to be served by the redirector.
+type Manifest struct {
+	Aliases []Alias `json:"aliases"`
+}
+
+// Set adds the aliases to the manifest, replacing existing ones of the same
+// path, and keeps the manifest sorted by path.
+func (m *Manifest) Set(aliases ...Alias) {
+	byPath := make(map[string]int, len(m.Aliases))
+	for i, a := range m.Aliases {
+		byPath[a.Path] = i
+	}
+	for _, a := range aliases {
+		if i, ok := byPath[a.Path]; ok {
+			m.Aliases[i] = a
+			continue
+		}
+		byPath[a.Path] = len(m.Aliases)
+		m.Aliases = append(m.Aliases, a)
+	}
+	sort.Slice(m.Aliases, func(i, j int) bool {
+		return m.Aliases[i].Path < m.Aliases[j].Path
+	})
+}
+
+// Nginx renders the manifest as nginx redirect rules, for redirectors which
+// do not read the manifest directly.
+func (m *Manifest) Nginx() string {
+	b := &strings.Builder{}
+	for _, a := range m.Aliases {
+		fmt.Fprintf(b, "location = /%s { return 302 /%s; }\n", a.Path, a.Target)
+	}
+	return b.String()
+}
+
+// Options are the options for managing the aliases of a release.
+type Options struct {
+	// Version is the release version, for example v1.31.0.
+	Version string
+
+	// Bucket is the release bucket containing the artifacts and the
+	// manifest.
+	Bucket string
+
+	// GCSRoot is the top-level directory of the releases in the bucket.
+	GCSRoot string
+
+	// Files are the release artifacts relative to the release directory,
+	// which get an alias.
+	Files []string
+
+	// NoMock writes the manifest to the bucket instead of only logging the
+	// aliases.
+	NoMock bool
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Bucket:  release.ProductionBucket,
+		GCSRoot: "release",
+		Files:   DefaultFiles,
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	if o.Version == "" {
+		return errors.New("version is required")
+	}
+	if _, err := util.TagStringToSemver(o.Version); err != nil {
+		return fmt.Errorf("invalid version %s: %w", o.Version, err)
+	}
+	o.Version = util.AddTagPrefix(o.Version)
+	if o.Bucket == "" {
+		return errors.New("bucket is required")
+	}
+	if len(o.Files) == 0 {
+		return errors.New("at least one file is required")
+	}
+	for _, f := range o.Files {
+		if f == "" || path.IsAbs(f) || strings.Contains(f, "..") {
+			return fmt.Errorf("file %q has to be a path relative to the release directory", f)
+		}
+	}
+	return nil
+}
+
+// Manager publishes and verifies the aliases of a release.
+type Manager struct {
+	options *Options
+	impl    impl
+}
+
+// New creates a new Manager.
+func New(opts *Options) *Manager {
+	return &Manager{options: opts, impl: &defaultImpl{}}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (m *Manager) SetImpl(impl impl) {
+	m.impl = impl
+}
+
+// Aliases returns the aliases of the release artifacts.
+func (m *Manager) Aliases() ([]Alias, error) {
+	if err := m.options.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+
+	releasePath, err := layout.Default().ReleasePath(
+		m.options.Bucket, m.options.GCSRoot, m.options.Version, false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get release path: %w", err)
+	}
+	bucket := strings.TrimPrefix(m.options.Bucket, object.GcsPrefix)
+	target := strings.TrimPrefix(strings.TrimPrefix(releasePath, bucket), "/")
+
+	aliases := make([]Alias, 0, len(m.options.Files))
+	for _, f := range m.options.Files {
+		aliases = append(aliases, Alias{
+			Path:   path.Join(m.options.Version, f),
+			Target: path.Join(target, f),
+		})
+	}
+	return aliases, nil
+}
+
+// ManifestPath returns the GCS path of the alias manifest.
+func (m *Manager) ManifestPath() (string, error) {
+	markerPath, err := layout.Default().MarkerPath(m.options.Bucket, m.options.GCSRoot, false)
+	if err != nil {
+		return "", fmt.Errorf("get marker path: %w", err)
+	}
+	return object.GcsPrefix + path.Join(markerPath, ManifestFile), nil
+}
+
+// Manifest reads the alias manifest from the bucket, which is empty if it
+// does not exist yet.
+func (m *Manager) Manifest() (*Manifest, error) {
+	manifest, _, err := m.manifest()
+	return manifest, err
+}
+
+// manifest returns the manifest together with the generation of its object.
+func (m *Manager) manifest() (*Manifest, int64, error) {
+	manifestPath, err := m.ManifestPath()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	manifest := &Manifest{}
+	content, generation, err := m.impl.ReadObject(manifestPath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("read alias manifest: %w", err)
+	}
+	if content != nil {
+		if err := json.Unmarshal(content, manifest); err != nil {
+			return nil, 0, fmt.Errorf("unmarshal alias manifest %s: %w", manifestPath, err)
+		}
+	}
+	return manifest, generation, nil
+}
+
+// Publish adds the aliases of the release to the manifest, which only gets
+// written to the bucket if NoMock is set.
+func (m *Manager) Publish() (*Manifest, error) {
+	aliases, err := m.Aliases()
+	if err != nil {
+		return nil, err
+	}
+	manifestPath, err := m.ManifestPath()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range aliases {
+		logrus.Infof("Alias %s -> %s", a.URL(), a.TargetURL())
+	}
+
+	// Only write the manifest if it did not change since reading it, and
+	// merge the aliases into the new manifest otherwise.
+	for attempt := 1; ; attempt++ {
+		manifest, generation, err := m.manifest()
+		if err != nil {
+			return nil, err
+		}
+		manifest.Set(aliases...)
+
+		if !m.options.NoMock {
+			logrus.Infof("Not writing alias manifest %s in mock mode", manifestPath)
+			return manifest, nil
+		}
+
+		content, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal alias manifest: %w", err)
+		}
+		logrus.Infof("Writing %d aliases to %s", len(manifest.Aliases), manifestPath)
+		err = m.impl.WriteObject(manifestPath, content, generation)
+		if err == nil {
+			return manifest, nil
+		}
+		if !errors.Is(err, ErrGenerationMismatch) || attempt == publishAttempts {
+			return nil, fmt.Errorf("write alias manifest: %w", err)
+		}
+		logrus.Warnf("Alias manifest changed concurrently, retrying (%d/%d)", attempt, publishAttempts)
+	}
+}
+
+// Result is the verification result of a single alias.
+type Result struct {
+	Alias
+
+	// Error describes why the alias does not resolve, empty if it does.
+	Error string `json:"error,omitempty"`
+}
+
+// Verify checks that every alias of the release redirects to its target and
+// that the target exists. It returns the results of all aliases together
+// with ErrUnresolved if at least one of them failed.
+func (m *Manager) Verify() ([]Result, error) {
+	aliases, err := m.Aliases()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(aliases))
+	failed := 0
+	for _, a := range aliases {
+		res := Result{Alias: a}
+		if err := m.resolve(a); err != nil {
+			logrus.Warnf("Alias %s does not resolve: %v", a.URL(), err)
+			res.Error = err.Error()
+			failed++
+		}
+		results = append(results, res)
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("%w: %d of %d", ErrUnresolved, failed, len(aliases))
+	}
+	return results, nil
+}
+
+// resolve follows the redirects of the alias, which has to redirect to the
+// path of its target on any host, for example a CDN in front of the download
+// host, and the target has to exist.
+func (m *Manager) resolve(a Alias) error {
+	target, err := url.Parse(a.TargetURL())
+	if err != nil {
+		return fmt.Errorf("parse target URL: %w", err)
+	}
+
+	current := a.URL()
+	reachedTarget := false
+	for hop := 0; hop <= maxRedirects; hop++ {
+		status, location, err := m.impl.Resolve(current)
+		if err != nil {
+			if reachedTarget {
+				return fmt.Errorf("request target: %w", err)
+			}
+			return fmt.Errorf("request alias: %w", err)
+		}
+
+		if status < 300 || status >= 400 {
+			if hop == 0 {
+				return fmt.Errorf("expected a redirect, got HTTP status %d", status)
+			}
+			if !reachedTarget {
+				return fmt.Errorf("redirects to %s instead of %s", current, a.TargetURL())
+			}
+			if status >= 400 {
+				return fmt.Errorf("target returned HTTP status %d", status)
+			}
+			return nil
+		}
+
+		base, err := url.Parse(current)
+		if err != nil {
+			return fmt.Errorf("parse URL: %w", err)
+		}
+		redirect, err := base.Parse(location)
+		if err != nil {
+			return fmt.Errorf("parse redirect location %q: %w", location, err)
+		}
+		if redirect.Path == target.Path {
+			reachedTarget = true
+		}
+		current = redirect.String()
+	}
+	return fmt.Errorf("more than %d redirects", maxRedirects)
+}
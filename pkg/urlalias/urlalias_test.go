@@ -0,0 +1,294 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package urlalias_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/urlalias"
+	"k8s.io/release/pkg/urlalias/urlaliasfakes"
+)
+
+func newOptions() *urlalias.Options {
+	opts := urlalias.DefaultOptions()
+	opts.Version = "1.31.0"
+	opts.Bucket = "gs://bucket"
+	opts.Files = []string{"kubernetes.tar.gz", "bin/linux/amd64/kubectl"}
+	return opts
+}
+
+func TestAliases(t *testing.T) {
+	aliases, err := urlalias.New(newOptions()).Aliases()
+	require.NoError(t, err)
+	require.Equal(t, []urlalias.Alias{
+		{Path: "v1.31.0/kubernetes.tar.gz", Target: "release/v1.31.0/kubernetes.tar.gz"},
+		{Path: "v1.31.0/bin/linux/amd64/kubectl", Target: "release/v1.31.0/bin/linux/amd64/kubectl"},
+	}, aliases)
+	require.Equal(t, "https://dl.k8s.io/v1.31.0/kubernetes.tar.gz", aliases[0].URL())
+	require.Equal(t, "https://dl.k8s.io/release/v1.31.0/kubernetes.tar.gz", aliases[0].TargetURL())
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		modify      func(*urlalias.Options)
+		shouldError bool
+	}{
+		{ // success
+			modify: func(*urlalias.Options) {},
+		},
+		{ // missing version
+			modify:      func(o *urlalias.Options) { o.Version = "" },
+			shouldError: true,
+		},
+		{ // invalid version
+			modify:      func(o *urlalias.Options) { o.Version = "latest" },
+			shouldError: true,
+		},
+		{ // no files
+			modify:      func(o *urlalias.Options) { o.Files = nil },
+			shouldError: true,
+		},
+		{ // file outside of the release directory
+			modify:      func(o *urlalias.Options) { o.Files = []string{"../stable.txt"} },
+			shouldError: true,
+		},
+	} {
+		opts := newOptions()
+		tc.modify(opts)
+		err := opts.Validate()
+		if tc.shouldError {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestPublish(t *testing.T) {
+	existing := `{"aliases": [
+  {"path": "v1.30.0/kubernetes.tar.gz", "target": "release/v1.30.0/kubernetes.tar.gz"},
+  {"path": "v1.31.0/kubernetes.tar.gz", "target": "release/outdated"}
+]}`
+
+	for _, tc := range []struct {
+		noMock      bool
+		existing    []byte
+		readErr     error
+		writeErr    error
+		writeErrs   int
+		aliases     int
+		writes      int
+		shouldError bool
+	}{
+		{ // new manifest
+			noMock:  true,
+			aliases: 2,
+			writes:  1,
+		},
+		{ // existing manifest gets updated
+			noMock:   true,
+			existing: []byte(existing),
+			aliases:  3,
+			writes:   1,
+		},
+		{ // mock mode does not write
+			existing: []byte(existing),
+			aliases:  3,
+		},
+		{ // invalid manifest
+			noMock:      true,
+			existing:    []byte("{"),
+			shouldError: true,
+		},
+		{ // read fails
+			noMock:      true,
+			readErr:     errors.New("no access"),
+			shouldError: true,
+		},
+		{ // write fails
+			noMock:      true,
+			writeErr:    errors.New("no access"),
+			writeErrs:   1,
+			writes:      1,
+			shouldError: true,
+		},
+		{ // concurrent update gets merged
+			noMock:    true,
+			existing:  []byte(existing),
+			writeErr:  urlalias.ErrGenerationMismatch,
+			writeErrs: 2,
+			aliases:   3,
+			writes:    3,
+		},
+		{ // concurrent updates exceed the attempts
+			noMock:      true,
+			writeErr:    urlalias.ErrGenerationMismatch,
+			writeErrs:   5,
+			writes:      5,
+			shouldError: true,
+		},
+	} {
+		opts := newOptions()
+		opts.NoMock = tc.noMock
+		mock := &urlaliasfakes.FakeImpl{}
+		mock.ReadObjectReturns(tc.existing, 42, tc.readErr)
+		for i := 0; i < tc.writeErrs; i++ {
+			mock.WriteObjectReturnsOnCall(i, tc.writeErr)
+		}
+
+		sut := urlalias.New(opts)
+		sut.SetImpl(mock)
+
+		manifest, err := sut.Publish()
+		require.Equal(t, tc.writes, mock.WriteObjectCallCount())
+		if tc.shouldError {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Len(t, manifest.Aliases, tc.aliases)
+		require.Equal(t, "gs://bucket/release/aliases.json", mock.ReadObjectArgsForCall(0))
+
+		for _, a := range manifest.Aliases {
+			require.NotEqual(t, "release/outdated", a.Target)
+		}
+		for i := 1; i < len(manifest.Aliases); i++ {
+			require.Less(t, manifest.Aliases[i-1].Path, manifest.Aliases[i].Path)
+		}
+
+		if tc.writes > 0 {
+			path, content, generation := mock.WriteObjectArgsForCall(tc.writes - 1)
+			require.Equal(t, "gs://bucket/release/aliases.json", path)
+			require.EqualValues(t, 42, generation)
+			written := &urlalias.Manifest{}
+			require.NoError(t, json.Unmarshal(content, written))
+			require.Equal(t, manifest, written)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	for _, tc := range []struct {
+		resolve func(url string) (int, string, error)
+		failed  int
+	}{
+		{ // all aliases resolve
+			resolve: func(url string) (int, string, error) {
+				if url == "https://dl.k8s.io/v1.31.0/kubernetes.tar.gz" {
+					return http.StatusFound, "/release/v1.31.0/kubernetes.tar.gz", nil
+				}
+				if url == "https://dl.k8s.io/v1.31.0/bin/linux/amd64/kubectl" {
+					return http.StatusMovedPermanently, "https://dl.k8s.io/release/v1.31.0/bin/linux/amd64/kubectl", nil
+				}
+				if strings.HasPrefix(url, "https://dl.k8s.io/release/") {
+					return http.StatusFound, "https://cdn.dl.k8s.io/release/v1.31.0/file", nil
+				}
+				return http.StatusOK, "", nil
+			},
+		},
+		{ // aliases redirect to a CDN
+			resolve: func(url string) (int, string, error) {
+				if strings.HasPrefix(url, "https://dl.k8s.io/v1.31.0/") {
+					return http.StatusFound, strings.Replace(url, "://dl.k8s.io/", "://cdn.dl.k8s.io/release/", 1), nil
+				}
+				return http.StatusOK, "", nil
+			},
+		},
+		{ // redirect loop
+			resolve: func(url string) (int, string, error) {
+				return http.StatusFound, url, nil
+			},
+			failed: 2,
+		},
+		{ // no redirect
+			resolve: func(string) (int, string, error) {
+				return http.StatusNotFound, "", nil
+			},
+			failed: 2,
+		},
+		{ // wrong redirect
+			resolve: func(url string) (int, string, error) {
+				if url == "https://dl.k8s.io/v1.31.0/kubernetes.tar.gz" {
+					return http.StatusFound, "/release/v1.30.0/kubernetes.tar.gz", nil
+				}
+				if url == "https://dl.k8s.io/v1.31.0/bin/linux/amd64/kubectl" {
+					return http.StatusFound, "/release/v1.31.0/bin/linux/amd64/kubectl", nil
+				}
+				return http.StatusOK, "", nil
+			},
+			failed: 1,
+		},
+		{ // missing target
+			resolve: func(url string) (int, string, error) {
+				if url == "https://dl.k8s.io/v1.31.0/kubernetes.tar.gz" {
+					return http.StatusFound, "/release/v1.31.0/kubernetes.tar.gz", nil
+				}
+				if url == "https://dl.k8s.io/v1.31.0/bin/linux/amd64/kubectl" {
+					return http.StatusFound, "/release/v1.31.0/bin/linux/amd64/kubectl", nil
+				}
+				return http.StatusNotFound, "", nil
+			},
+			failed: 2,
+		},
+		{ // request fails
+			resolve: func(string) (int, string, error) {
+				return 0, "", errors.New("timeout")
+			},
+			failed: 2,
+		},
+	} {
+		mock := &urlaliasfakes.FakeImpl{}
+		mock.ResolveCalls(tc.resolve)
+
+		sut := urlalias.New(newOptions())
+		sut.SetImpl(mock)
+
+		results, err := sut.Verify()
+		require.Len(t, results, 2)
+		failed := 0
+		for _, res := range results {
+			if res.Error != "" {
+				failed++
+			}
+		}
+		require.Equal(t, tc.failed, failed)
+		if tc.failed > 0 {
+			require.ErrorIs(t, err, urlalias.ErrUnresolved)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestNginx(t *testing.T) {
+	m := &urlalias.Manifest{}
+	m.Set(
+		urlalias.Alias{Path: "v1.31.0/kubernetes.tar.gz", Target: "release/v1.31.0/kubernetes.tar.gz"},
+		urlalias.Alias{Path: "v1.30.0/kubernetes.tar.gz", Target: "release/v1.30.0/kubernetes.tar.gz"},
+	)
+	require.Equal(t,
+		"location = /v1.30.0/kubernetes.tar.gz { return 302 /release/v1.30.0/kubernetes.tar.gz; }\n"+
+			"location = /v1.31.0/kubernetes.tar.gz { return 302 /release/v1.31.0/kubernetes.tar.gz; }\n",
+		m.Nginx(),
+	)
+}
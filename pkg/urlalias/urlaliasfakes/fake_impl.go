@@ -0,0 +1,300 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package urlaliasfakes
+
+import (
+	"sync"
+)
+
+type FakeImpl struct {
+	ReadObjectStub        func(string) ([]byte, int64, error)
+	readObjectMutex       sync.RWMutex
+	readObjectArgsForCall []struct {
+		arg1 string
+	}
+	readObjectReturns struct {
+		result1 []byte
+		result2 int64
+		result3 error
+	}
+	readObjectReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 int64
+		result3 error
+	}
+	ResolveStub        func(string) (int, string, error)
+	resolveMutex       sync.RWMutex
+	resolveArgsForCall []struct {
+		arg1 string
+	}
+	resolveReturns struct {
+		result1 int
+		result2 string
+		result3 error
+	}
+	resolveReturnsOnCall map[int]struct {
+		result1 int
+		result2 string
+		result3 error
+	}
+	WriteObjectStub        func(string, []byte, int64) error
+	writeObjectMutex       sync.RWMutex
+	writeObjectArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+		arg3 int64
+	}
+	writeObjectReturns struct {
+		result1 error
+	}
+	writeObjectReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) ReadObject(arg1 string) ([]byte, int64, error) {
+	fake.readObjectMutex.Lock()
+	ret, specificReturn := fake.readObjectReturnsOnCall[len(fake.readObjectArgsForCall)]
+	fake.readObjectArgsForCall = append(fake.readObjectArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReadObjectStub
+	fakeReturns := fake.readObjectReturns
+	fake.recordInvocation("ReadObject", []interface{}{arg1})
+	fake.readObjectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeImpl) ReadObjectCallCount() int {
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
+	return len(fake.readObjectArgsForCall)
+}
+
+func (fake *FakeImpl) ReadObjectCalls(stub func(string) ([]byte, int64, error)) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = stub
+}
+
+func (fake *FakeImpl) ReadObjectArgsForCall(i int) string {
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
+	argsForCall := fake.readObjectArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ReadObjectReturns(result1 []byte, result2 int64, result3 error) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = nil
+	fake.readObjectReturns = struct {
+		result1 []byte
+		result2 int64
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImpl) ReadObjectReturnsOnCall(i int, result1 []byte, result2 int64, result3 error) {
+	fake.readObjectMutex.Lock()
+	defer fake.readObjectMutex.Unlock()
+	fake.ReadObjectStub = nil
+	if fake.readObjectReturnsOnCall == nil {
+		fake.readObjectReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 int64
+			result3 error
+		})
+	}
+	fake.readObjectReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 int64
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImpl) Resolve(arg1 string) (int, string, error) {
+	fake.resolveMutex.Lock()
+	ret, specificReturn := fake.resolveReturnsOnCall[len(fake.resolveArgsForCall)]
+	fake.resolveArgsForCall = append(fake.resolveArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ResolveStub
+	fakeReturns := fake.resolveReturns
+	fake.recordInvocation("Resolve", []interface{}{arg1})
+	fake.resolveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeImpl) ResolveCallCount() int {
+	fake.resolveMutex.RLock()
+	defer fake.resolveMutex.RUnlock()
+	return len(fake.resolveArgsForCall)
+}
+
+func (fake *FakeImpl) ResolveCalls(stub func(string) (int, string, error)) {
+	fake.resolveMutex.Lock()
+	defer fake.resolveMutex.Unlock()
+	fake.ResolveStub = stub
+}
+
+func (fake *FakeImpl) ResolveArgsForCall(i int) string {
+	fake.resolveMutex.RLock()
+	defer fake.resolveMutex.RUnlock()
+	argsForCall := fake.resolveArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) ResolveReturns(result1 int, result2 string, result3 error) {
+	fake.resolveMutex.Lock()
+	defer fake.resolveMutex.Unlock()
+	fake.ResolveStub = nil
+	fake.resolveReturns = struct {
+		result1 int
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImpl) ResolveReturnsOnCall(i int, result1 int, result2 string, result3 error) {
+	fake.resolveMutex.Lock()
+	defer fake.resolveMutex.Unlock()
+	fake.ResolveStub = nil
+	if fake.resolveReturnsOnCall == nil {
+		fake.resolveReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 string
+			result3 error
+		})
+	}
+	fake.resolveReturnsOnCall[i] = struct {
+		result1 int
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImpl) WriteObject(arg1 string, arg2 []byte, arg3 int64) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeObjectMutex.Lock()
+	ret, specificReturn := fake.writeObjectReturnsOnCall[len(fake.writeObjectArgsForCall)]
+	fake.writeObjectArgsForCall = append(fake.writeObjectArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+		arg3 int64
+	}{arg1, arg2Copy, arg3})
+	stub := fake.WriteObjectStub
+	fakeReturns := fake.writeObjectReturns
+	fake.recordInvocation("WriteObject", []interface{}{arg1, arg2Copy, arg3})
+	fake.writeObjectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) WriteObjectCallCount() int {
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
+	return len(fake.writeObjectArgsForCall)
+}
+
+func (fake *FakeImpl) WriteObjectCalls(stub func(string, []byte, int64) error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = stub
+}
+
+func (fake *FakeImpl) WriteObjectArgsForCall(i int) (string, []byte, int64) {
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
+	argsForCall := fake.writeObjectArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) WriteObjectReturns(result1 error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = nil
+	fake.writeObjectReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteObjectReturnsOnCall(i int, result1 error) {
+	fake.writeObjectMutex.Lock()
+	defer fake.writeObjectMutex.Unlock()
+	fake.WriteObjectStub = nil
+	if fake.writeObjectReturnsOnCall == nil {
+		fake.writeObjectReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeObjectReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.readObjectMutex.RLock()
+	defer fake.readObjectMutex.RUnlock()
+	fake.resolveMutex.RLock()
+	defer fake.resolveMutex.RUnlock()
+	fake.writeObjectMutex.RLock()
+	defer fake.writeObjectMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vulnscan
+
+import (
+	"os"
+	"strings"
+
+	"sigs.k8s.io/release-utils/command"
+)
+
+// trivyBinary is the scanner executable used for the image scans.
+const trivyBinary = "trivy"
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt vulnscanfakes/fake_impl.go > vulnscanfakes/_fake_impl.go && mv vulnscanfakes/_fake_impl.go vulnscanfakes/fake_impl.go"
+type impl interface {
+	ScannerAvailable() bool
+	Scan(tarball string, severities []string) ([]byte, error)
+	WriteFile(name string, data []byte, perm os.FileMode) error
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) ScannerAvailable() bool {
+	return command.Available(trivyBinary)
+}
+
+func (*defaultImpl) Scan(tarball string, severities []string) ([]byte, error) {
+	res, err := command.New(
+		trivyBinary, "image",
+		"--input", tarball,
+		"--format", "json",
+		"--severity", strings.Join(severities, ","),
+		"--quiet",
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(res.Output()), nil
+}
+
+func (*defaultImpl) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vulnscan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ModeFail fails the run if vulnerabilities have been found.
+	ModeFail = "fail"
+
+	// ModeWarn only reports found vulnerabilities.
+	ModeWarn = "warn"
+
+	// ModeOff disables the vulnerability scan.
+	ModeOff = "off"
+
+	// DefaultSeverity is the default severity of vulnerabilities which will
+	// be reported.
+	DefaultSeverity = "CRITICAL"
+
+	// ReportFile is the file name of the vulnerability report.
+	ReportFile = "vulnerability-report.json"
+)
+
+// Options are the main options for scanning images.
+type Options struct {
+	// Mode defines how found vulnerabilities are handled, can be one of
+	// ModeFail, ModeWarn or ModeOff.
+	Mode string
+
+	// Severities are the severities of the vulnerabilities to report.
+	Severities []string
+
+	// IgnoredVulnerabilities are already known vulnerability IDs, for
+	// example CVE-2023-12345, which will not be reported.
+	IgnoredVulnerabilities []string
+}
+
+// DefaultOptions returns a new Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		Mode:       ModeWarn,
+		Severities: []string{DefaultSeverity},
+	}
+}
+
+// Validate checks if the options are correctly set.
+func (o *Options) Validate() error {
+	switch o.Mode {
+	case ModeFail, ModeWarn, ModeOff:
+	default:
+		return fmt.Errorf(
+			"invalid vulnerability scan mode %q, must be one of: %s",
+			o.Mode, strings.Join([]string{ModeFail, ModeWarn, ModeOff}, ", "),
+		)
+	}
+	if o.Mode != ModeOff && len(o.Severities) == 0 {
+		return errors.New("no vulnerability severities specified")
+	}
+	return nil
+}
+
+// Vulnerability is a single vulnerability found in an image.
+type Vulnerability struct {
+	ID               string `json:"VulnerabilityID"`
+	Package          string `json:"PkgName"`
+	InstalledVersion string `json:"InstalledVersion"`
+	FixedVersion     string `json:"FixedVersion,omitempty"`
+	Severity         string `json:"Severity"`
+	Title            string `json:"Title,omitempty"`
+}
+
+// ImageReport contains all vulnerabilities of a single image.
+type ImageReport struct {
+	Image           string           `json:"Image"`
+	Vulnerabilities []*Vulnerability `json:"Vulnerabilities"`
+}
+
+// Report is the result of scanning a set of images.
+type Report struct {
+	Images []*ImageReport `json:"Images"`
+}
+
+// trivyReport is the subset of the trivy JSON output we need.
+type trivyReport struct {
+	Results []struct {
+		Vulnerabilities []*Vulnerability `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// Count returns the total number of vulnerabilities in the report.
+func (r *Report) Count() int {
+	count := 0
+	for _, image := range r.Images {
+		count += len(image.Vulnerabilities)
+	}
+	return count
+}
+
+// Markdown returns a markdown table of all vulnerabilities in the report.
+func (r *Report) Markdown() string {
+	buf := &bytes.Buffer{}
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeader([]string{"Image", "Vulnerability", "Severity", "Package", "Installed", "Fixed"})
+	for _, image := range r.Images {
+		for _, v := range image.Vulnerabilities {
+			table.Append([]string{
+				filepath.Base(image.Image), v.ID, v.Severity,
+				v.Package, v.InstalledVersion, v.FixedVersion,
+			})
+		}
+	}
+	table.SetBorders(tablewriter.Border{
+		Left: true, Top: false, Right: true, Bottom: false,
+	})
+	table.SetCenterSeparator("|")
+	table.Render()
+	return buf.String()
+}
+
+// Scanner is the main structure for scanning release images.
+type Scanner struct {
+	impl    impl
+	options *Options
+}
+
+// New returns a new Scanner instance.
+func New(opts *Options) *Scanner {
+	return &Scanner{
+		impl:    &defaultImpl{},
+		options: opts,
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (s *Scanner) SetImpl(impl impl) {
+	s.impl = impl
+}
+
+// Scan scans the provided image tarballs and returns a report of all not
+// ignored vulnerabilities.
+func (s *Scanner) Scan(images []string) (*Report, error) {
+	ignored := map[string]bool{}
+	for _, id := range s.options.IgnoredVulnerabilities {
+		ignored[id] = true
+	}
+
+	report := &Report{Images: []*ImageReport{}}
+	for _, image := range images {
+		logrus.Infof("Scanning image %s for vulnerabilities", image)
+		output, err := s.impl.Scan(image, s.options.Severities)
+		if err != nil {
+			return nil, fmt.Errorf("scan image %s: %w", image, err)
+		}
+
+		res := &trivyReport{}
+		if err := json.Unmarshal(output, res); err != nil {
+			return nil, fmt.Errorf("parse scan result of %s: %w", image, err)
+		}
+
+		imageReport := &ImageReport{Image: image, Vulnerabilities: []*Vulnerability{}}
+		seen := map[string]bool{}
+		for _, result := range res.Results {
+			for _, v := range result.Vulnerabilities {
+				key := v.ID + "/" + v.Package
+				if ignored[v.ID] || seen[key] {
+					continue
+				}
+				seen[key] = true
+				imageReport.Vulnerabilities = append(imageReport.Vulnerabilities, v)
+			}
+		}
+		sort.Slice(imageReport.Vulnerabilities, func(i, j int) bool {
+			return imageReport.Vulnerabilities[i].ID < imageReport.Vulnerabilities[j].ID
+		})
+		report.Images = append(report.Images, imageReport)
+	}
+
+	return report, nil
+}
+
+// Run scans the provided image tarballs, writes the report to reportPath and
+// handles found vulnerabilities depending on the configured mode.
+func (s *Scanner) Run(images []string, reportPath string) error {
+	if err := s.options.Validate(); err != nil {
+		return fmt.Errorf("validating options: %w", err)
+	}
+
+	if s.options.Mode == ModeOff {
+		logrus.Info("Vulnerability scan is disabled")
+		return nil
+	}
+
+	if !s.impl.ScannerAvailable() {
+		return s.handle(fmt.Errorf("vulnerability scanner %s not available", trivyBinary))
+	}
+
+	report, err := s.Scan(images)
+	if err != nil {
+		return s.handle(err)
+	}
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal vulnerability report: %w", err)
+	}
+	if err := s.impl.WriteFile(reportPath, content, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("write vulnerability report: %w", err)
+	}
+	logrus.Infof("Wrote vulnerability report to %s", reportPath)
+
+	count := report.Count()
+	if count == 0 {
+		logrus.Infof("No new vulnerabilities found in %d images", len(images))
+		return nil
+	}
+
+	logrus.Infof("Vulnerability report:\n%s", report.Markdown())
+	return s.handle(fmt.Errorf(
+		"found %d new %s vulnerabilities in %d images",
+		count, strings.Join(s.options.Severities, "/"), len(images),
+	))
+}
+
+// handle returns the error in ModeFail or logs it as warning otherwise.
+func (s *Scanner) handle(err error) error {
+	if s.options.Mode == ModeFail {
+		return err
+	}
+	logrus.Warnf("Ignoring vulnerability scan result: %v", err)
+	return nil
+}
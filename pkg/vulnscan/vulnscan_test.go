@@ -0,0 +1,246 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vulnscan
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/vulnscan/vulnscanfakes"
+)
+
+var errTest = errors.New("test")
+
+const testScanResult = `{
+  "Results": [
+    {
+      "Target": "kube-apiserver.tar (debian 12.4)",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2024-2", "PkgName": "libc6", "InstalledVersion": "2.36", "FixedVersion": "2.37", "Severity": "CRITICAL"},
+        {"VulnerabilityID": "CVE-2024-1", "PkgName": "openssl", "InstalledVersion": "3.0.11", "Severity": "CRITICAL"}
+      ]
+    },
+    {
+      "Target": "usr/local/bin/kube-apiserver",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2024-2", "PkgName": "libc6", "InstalledVersion": "2.36", "FixedVersion": "2.37", "Severity": "CRITICAL"}
+      ]
+    }
+  ]
+}`
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		modify      func(*Options)
+		shouldError bool
+	}{
+		{ // success default
+			modify:      func(*Options) {},
+			shouldError: false,
+		},
+		{ // success off without severities
+			modify:      func(o *Options) { o.Mode = ModeOff; o.Severities = nil },
+			shouldError: false,
+		},
+		{ // invalid mode
+			modify:      func(o *Options) { o.Mode = "wrong" },
+			shouldError: true,
+		},
+		{ // no severities
+			modify:      func(o *Options) { o.Severities = nil },
+			shouldError: true,
+		},
+	} {
+		opts := DefaultOptions()
+		tc.modify(opts)
+		err := opts.Validate()
+		if tc.shouldError {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	mock := &vulnscanfakes.FakeImpl{}
+	mock.ScanReturns([]byte(testScanResult), nil)
+	opts := DefaultOptions()
+	opts.IgnoredVulnerabilities = []string{"CVE-2024-3"}
+
+	sut := New(opts)
+	sut.SetImpl(mock)
+	report, err := sut.Scan([]string{"kube-apiserver.tar"})
+	require.NoError(t, err)
+	require.Len(t, report.Images, 1)
+	require.Equal(t, 2, report.Count())
+	require.Equal(t, "CVE-2024-1", report.Images[0].Vulnerabilities[0].ID)
+	require.Equal(t, "CVE-2024-2", report.Images[0].Vulnerabilities[1].ID)
+
+	image, severities := mock.ScanArgsForCall(0)
+	require.Equal(t, "kube-apiserver.tar", image)
+	require.Equal(t, []string{DefaultSeverity}, severities)
+
+	markdown := report.Markdown()
+	require.Contains(t, markdown, "| kube-apiserver.tar | CVE-2024-1    | CRITICAL | openssl | 3.0.11    |       |")
+	require.Contains(t, markdown, "| kube-apiserver.tar | CVE-2024-2    | CRITICAL | libc6   | 2.36      | 2.37  |")
+
+	// Ignored vulnerabilities are not part of the report
+	opts.IgnoredVulnerabilities = []string{"CVE-2024-1"}
+	report, err = sut.Scan([]string{"kube-apiserver.tar"})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Count())
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		prepare func(*vulnscanfakes.FakeImpl, *Options)
+		assert  func(*vulnscanfakes.FakeImpl, error)
+	}{
+		{ // success no vulnerabilities
+			prepare: func(mock *vulnscanfakes.FakeImpl, opts *Options) {
+				opts.Mode = ModeFail
+				mock.ScannerAvailableReturns(true)
+				mock.ScanReturns([]byte(`{"Results":[]}`), nil)
+			},
+			assert: func(mock *vulnscanfakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, mock.WriteFileCallCount())
+				name, data, _ := mock.WriteFileArgsForCall(0)
+				require.Equal(t, "report.json", name)
+				report := &Report{}
+				require.NoError(t, json.Unmarshal(data, report))
+				require.Len(t, report.Images, 2)
+			},
+		},
+		{ // success vulnerabilities in warn mode
+			prepare: func(mock *vulnscanfakes.FakeImpl, opts *Options) {
+				mock.ScannerAvailableReturns(true)
+				mock.ScanReturns([]byte(testScanResult), nil)
+			},
+			assert: func(mock *vulnscanfakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, mock.WriteFileCallCount())
+			},
+		},
+		{ // success vulnerabilities ignored in fail mode
+			prepare: func(mock *vulnscanfakes.FakeImpl, opts *Options) {
+				opts.Mode = ModeFail
+				opts.IgnoredVulnerabilities = []string{"CVE-2024-1", "CVE-2024-2"}
+				mock.ScannerAvailableReturns(true)
+				mock.ScanReturns([]byte(testScanResult), nil)
+			},
+			assert: func(mock *vulnscanfakes.FakeImpl, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{ // success scan disabled
+			prepare: func(mock *vulnscanfakes.FakeImpl, opts *Options) {
+				opts.Mode = ModeOff
+			},
+			assert: func(mock *vulnscanfakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Zero(t, mock.ScannerAvailableCallCount())
+				require.Zero(t, mock.ScanCallCount())
+			},
+		},
+		{ // success scanner not available in warn mode
+			prepare: func(mock *vulnscanfakes.FakeImpl, opts *Options) {
+				mock.ScannerAvailableReturns(false)
+			},
+			assert: func(mock *vulnscanfakes.FakeImpl, err error) {
+				require.NoError(t, err)
+				require.Zero(t, mock.ScanCallCount())
+			},
+		},
+		{ // failure vulnerabilities in fail mode
+			prepare: func(mock *vulnscanfakes.FakeImpl, opts *Options) {
+				opts.Mode = ModeFail
+				mock.ScannerAvailableReturns(true)
+				mock.ScanReturns([]byte(testScanResult), nil)
+			},
+			assert: func(mock *vulnscanfakes.FakeImpl, err error) {
+				require.Error(t, err)
+				require.Equal(t, 1, mock.WriteFileCallCount())
+			},
+		},
+		{ // failure scanner not available in fail mode
+			prepare: func(mock *vulnscanfakes.FakeImpl, opts *Options) {
+				opts.Mode = ModeFail
+				mock.ScannerAvailableReturns(false)
+			},
+			assert: func(mock *vulnscanfakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure on Scan in fail mode
+			prepare: func(mock *vulnscanfakes.FakeImpl, opts *Options) {
+				opts.Mode = ModeFail
+				mock.ScannerAvailableReturns(true)
+				mock.ScanReturns(nil, errTest)
+			},
+			assert: func(mock *vulnscanfakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure on invalid scan result in fail mode
+			prepare: func(mock *vulnscanfakes.FakeImpl, opts *Options) {
+				opts.Mode = ModeFail
+				mock.ScannerAvailableReturns(true)
+				mock.ScanReturns([]byte("wrong"), nil)
+			},
+			assert: func(mock *vulnscanfakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure on WriteFile
+			prepare: func(mock *vulnscanfakes.FakeImpl, opts *Options) {
+				mock.ScannerAvailableReturns(true)
+				mock.ScanReturns([]byte(testScanResult), nil)
+				mock.WriteFileReturns(errTest)
+			},
+			assert: func(mock *vulnscanfakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+		{ // failure invalid options
+			prepare: func(mock *vulnscanfakes.FakeImpl, opts *Options) {
+				opts.Mode = "wrong"
+			},
+			assert: func(mock *vulnscanfakes.FakeImpl, err error) {
+				require.Error(t, err)
+			},
+		},
+	} {
+		mock := &vulnscanfakes.FakeImpl{}
+		opts := DefaultOptions()
+		tc.prepare(mock, opts)
+
+		sut := New(opts)
+		sut.SetImpl(mock)
+		tc.assert(mock, sut.Run([]string{"kube-apiserver.tar", "kube-proxy.tar"}, "report.json"))
+	}
+}
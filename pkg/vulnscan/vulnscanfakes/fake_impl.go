@@ -0,0 +1,284 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package vulnscanfakes
+
+import (
+	"io/fs"
+	"sync"
+)
+
+type FakeImpl struct {
+	ScanStub        func(string, []string) ([]byte, error)
+	scanMutex       sync.RWMutex
+	scanArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	scanReturns struct {
+		result1 []byte
+		result2 error
+	}
+	scanReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	ScannerAvailableStub        func() bool
+	scannerAvailableMutex       sync.RWMutex
+	scannerAvailableArgsForCall []struct {
+	}
+	scannerAvailableReturns struct {
+		result1 bool
+	}
+	scannerAvailableReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	WriteFileStub        func(string, []byte, fs.FileMode) error
+	writeFileMutex       sync.RWMutex
+	writeFileArgsForCall []struct {
+		arg1 string
+		arg2 []byte
+		arg3 fs.FileMode
+	}
+	writeFileReturns struct {
+		result1 error
+	}
+	writeFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) Scan(arg1 string, arg2 []string) ([]byte, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.scanMutex.Lock()
+	ret, specificReturn := fake.scanReturnsOnCall[len(fake.scanArgsForCall)]
+	fake.scanArgsForCall = append(fake.scanArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.ScanStub
+	fakeReturns := fake.scanReturns
+	fake.recordInvocation("Scan", []interface{}{arg1, arg2Copy})
+	fake.scanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) ScanCallCount() int {
+	fake.scanMutex.RLock()
+	defer fake.scanMutex.RUnlock()
+	return len(fake.scanArgsForCall)
+}
+
+func (fake *FakeImpl) ScanCalls(stub func(string, []string) ([]byte, error)) {
+	fake.scanMutex.Lock()
+	defer fake.scanMutex.Unlock()
+	fake.ScanStub = stub
+}
+
+func (fake *FakeImpl) ScanArgsForCall(i int) (string, []string) {
+	fake.scanMutex.RLock()
+	defer fake.scanMutex.RUnlock()
+	argsForCall := fake.scanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) ScanReturns(result1 []byte, result2 error) {
+	fake.scanMutex.Lock()
+	defer fake.scanMutex.Unlock()
+	fake.ScanStub = nil
+	fake.scanReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ScanReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.scanMutex.Lock()
+	defer fake.scanMutex.Unlock()
+	fake.ScanStub = nil
+	if fake.scanReturnsOnCall == nil {
+		fake.scanReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.scanReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) ScannerAvailable() bool {
+	fake.scannerAvailableMutex.Lock()
+	ret, specificReturn := fake.scannerAvailableReturnsOnCall[len(fake.scannerAvailableArgsForCall)]
+	fake.scannerAvailableArgsForCall = append(fake.scannerAvailableArgsForCall, struct {
+	}{})
+	stub := fake.ScannerAvailableStub
+	fakeReturns := fake.scannerAvailableReturns
+	fake.recordInvocation("ScannerAvailable", []interface{}{})
+	fake.scannerAvailableMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) ScannerAvailableCallCount() int {
+	fake.scannerAvailableMutex.RLock()
+	defer fake.scannerAvailableMutex.RUnlock()
+	return len(fake.scannerAvailableArgsForCall)
+}
+
+func (fake *FakeImpl) ScannerAvailableCalls(stub func() bool) {
+	fake.scannerAvailableMutex.Lock()
+	defer fake.scannerAvailableMutex.Unlock()
+	fake.ScannerAvailableStub = stub
+}
+
+func (fake *FakeImpl) ScannerAvailableReturns(result1 bool) {
+	fake.scannerAvailableMutex.Lock()
+	defer fake.scannerAvailableMutex.Unlock()
+	fake.ScannerAvailableStub = nil
+	fake.scannerAvailableReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeImpl) ScannerAvailableReturnsOnCall(i int, result1 bool) {
+	fake.scannerAvailableMutex.Lock()
+	defer fake.scannerAvailableMutex.Unlock()
+	fake.ScannerAvailableStub = nil
+	if fake.scannerAvailableReturnsOnCall == nil {
+		fake.scannerAvailableReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.scannerAvailableReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteFile(arg1 string, arg2 []byte, arg3 fs.FileMode) error {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeFileMutex.Lock()
+	ret, specificReturn := fake.writeFileReturnsOnCall[len(fake.writeFileArgsForCall)]
+	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
+		arg1 string
+		arg2 []byte
+		arg3 fs.FileMode
+	}{arg1, arg2Copy, arg3})
+	stub := fake.WriteFileStub
+	fakeReturns := fake.writeFileReturns
+	fake.recordInvocation("WriteFile", []interface{}{arg1, arg2Copy, arg3})
+	fake.writeFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) WriteFileCallCount() int {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return len(fake.writeFileArgsForCall)
+}
+
+func (fake *FakeImpl) WriteFileCalls(stub func(string, []byte, fs.FileMode) error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = stub
+}
+
+func (fake *FakeImpl) WriteFileArgsForCall(i int) (string, []byte, fs.FileMode) {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	argsForCall := fake.writeFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImpl) WriteFileReturns(result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	fake.writeFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) WriteFileReturnsOnCall(i int, result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	if fake.writeFileReturnsOnCall == nil {
+		fake.writeFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.scanMutex.RLock()
+	defer fake.scanMutex.RUnlock()
+	fake.scannerAvailableMutex.RLock()
+	defer fake.scannerAvailableMutex.RUnlock()
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workdir
+
+import "github.com/shirou/gopsutil/v3/disk"
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt workdirfakes/fake_impl.go > workdirfakes/_fake_impl.go && mv workdirfakes/_fake_impl.go workdirfakes/fake_impl.go"
+type impl interface {
+	Usage(dir string) (*disk.UsageStat, error)
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) Usage(dir string) (*disk.UsageStat, error) {
+	return disk.Usage(dir)
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workdir manages the temporary directories and files of krel. All
+// of them get created below a configurable root directory instead of the
+// system default and get removed when the run finishes, unless they are kept
+// for debugging. It furthermore verifies the available disk space before the
+// release phases.
+package workdir
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/release-utils/env"
+
+	"k8s.io/release/pkg/plugin"
+)
+
+const (
+	// TempDirEnvKey is the environment variable containing the default root
+	// of the temporary directories.
+	TempDirEnvKey = "KREL_TMPDIR"
+
+	// DefaultMinDiskSpace is the default free disk space in GiB required
+	// before every release phase.
+	DefaultMinDiskSpace = 10
+
+	gib = 1024 * 1024 * 1024
+)
+
+// Options are the options for managing the temporary directories.
+type Options struct {
+	// TempDir is the root of all temporary directories and files. Defaults
+	// to the system temporary directory if empty.
+	TempDir string
+
+	// KeepWorkdir skips the removal of the temporary directories and files
+	// when the run finishes.
+	KeepWorkdir bool
+
+	// MinDiskSpace is the free disk space in GiB required before every
+	// release phase. Zero disables the check.
+	MinDiskSpace uint64
+
+	// PhaseDiskSpace are the free disk space requirements of single phases in
+	// the format <phase>=<GiB>, for example "build=100".
+	PhaseDiskSpace []string
+}
+
+// DefaultOptions returns a new default Options instance.
+func DefaultOptions() *Options {
+	return &Options{
+		TempDir:      env.Default(TempDirEnvKey, ""),
+		MinDiskSpace: DefaultMinDiskSpace,
+	}
+}
+
+// AddFlags adds the work directory flags to the provided flag set.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.TempDir,
+		"tmp-dir",
+		o.TempDir,
+		fmt.Sprintf("root directory of all temporary files (default $%s or the system temporary directory)", TempDirEnvKey),
+	)
+	flags.BoolVar(
+		&o.KeepWorkdir,
+		"keep-workdir",
+		o.KeepWorkdir,
+		"keep the temporary files after the run for debugging",
+	)
+	flags.Uint64Var(
+		&o.MinDiskSpace,
+		"min-disk-space",
+		o.MinDiskSpace,
+		"free disk space in GiB required before every release phase, 0 disables the check",
+	)
+	flags.StringArrayVar(
+		&o.PhaseDiskSpace,
+		"phase-disk-space",
+		o.PhaseDiskSpace,
+		`free disk space in GiB required before a release phase in the format <phase>=<GiB>, for example "build=100", can be set multiple times`,
+	)
+}
+
+// Manager creates and removes the temporary directories and files.
+type Manager struct {
+	impl           impl
+	root           string
+	keep           bool
+	minDiskSpace   uint64
+	phaseDiskSpace map[string]uint64
+
+	mu      sync.Mutex
+	created []string
+}
+
+// New creates a new Manager for the provided options.
+func New(opts *Options) (*Manager, error) {
+	root := opts.TempDir
+	if root == "" {
+		root = os.TempDir()
+	}
+
+	m := &Manager{
+		impl:           &defaultImpl{},
+		root:           root,
+		keep:           opts.KeepWorkdir,
+		minDiskSpace:   opts.MinDiskSpace,
+		phaseDiskSpace: map[string]uint64{},
+	}
+	for _, requirement := range opts.PhaseDiskSpace {
+		phase, value, ok := strings.Cut(requirement, "=")
+		phase = strings.TrimSpace(phase)
+		if !ok || phase == "" {
+			return nil, fmt.Errorf("invalid phase disk space %q, expected <phase>=<GiB>", requirement)
+		}
+		space, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid disk space of %q: %w", requirement, err)
+		}
+		m.phaseDiskSpace[plugin.Phase(phase)] = space
+	}
+	return m, nil
+}
+
+// SetImpl can be used to set the internal implementation.
+func (m *Manager) SetImpl(impl impl) {
+	m.impl = impl
+}
+
+// TempDir returns the root of all temporary directories and files.
+func (m *Manager) TempDir() string {
+	return m.root
+}
+
+// MkdirTemp creates a new temporary directory below the root, which gets
+// removed on Cleanup.
+func (m *Manager) MkdirTemp(pattern string) (string, error) {
+	dir, err := os.MkdirTemp(m.root, pattern)
+	if err != nil {
+		return "", err
+	}
+	m.track(dir)
+	return dir, nil
+}
+
+// CreateTemp creates a new temporary file below the root, which gets removed
+// on Cleanup.
+func (m *Manager) CreateTemp(pattern string) (*os.File, error) {
+	f, err := os.CreateTemp(m.root, pattern)
+	if err != nil {
+		return nil, err
+	}
+	m.track(f.Name())
+	return f, nil
+}
+
+func (m *Manager) track(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.created = append(m.created, path)
+}
+
+// Cleanup removes all temporary directories and files created by the
+// manager, unless they should be kept.
+func (m *Manager) Cleanup() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.created) == 0 {
+		return nil
+	}
+	if m.keep {
+		logrus.Infof("Keeping temporary files: %s", strings.Join(m.created, ", "))
+		return nil
+	}
+
+	var errs []error
+	for _, path := range m.created {
+		logrus.Debugf("Removing temporary path %s", path)
+		if err := os.RemoveAll(path); err != nil {
+			errs = append(errs, fmt.Errorf("remove %s: %w", path, err))
+		}
+	}
+	m.created = nil
+	return errors.Join(errs...)
+}
+
+// RequiredDiskSpace returns the free disk space in GiB required before the
+// phase.
+func (m *Manager) RequiredDiskSpace(phase string) uint64 {
+	if space, ok := m.phaseDiskSpace[plugin.Phase(phase)]; ok {
+		return space
+	}
+	return m.minDiskSpace
+}
+
+// CheckDiskSpace verifies that the root and the provided directories have
+// enough free disk space for the phase. Not yet existing directories are
+// skipped.
+func (m *Manager) CheckDiskSpace(phase string, dirs ...string) error {
+	required := m.RequiredDiskSpace(phase)
+	if required == 0 {
+		return nil
+	}
+
+	checked := map[string]bool{}
+	for _, dir := range append([]string{m.root}, dirs...) {
+		if checked[dir] {
+			continue
+		}
+		checked[dir] = true
+
+		if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+			logrus.Debugf("Skipping disk space check of not existing %s", dir)
+			continue
+		}
+		usage, err := m.impl.Usage(dir)
+		if err != nil {
+			return fmt.Errorf("check available disk space of %s: %w", dir, err)
+		}
+		if free := usage.Free / gib; free < required {
+			return fmt.Errorf(
+				"not enough disk space available for %s in %s: got %dGiB, need at least %dGiB",
+				phase, dir, free, required,
+			)
+		}
+	}
+	return nil
+}
+
+var (
+	mu      sync.RWMutex
+	current *Manager
+)
+
+// Setup creates the root directory of the provided options and uses it for
+// all temporary directories and files.
+func Setup(opts *Options) error {
+	m, err := New(opts)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.root, 0o755); err != nil {
+		return fmt.Errorf("create temporary root %s: %w", m.root, err)
+	}
+	if opts.TempDir != "" {
+		logrus.Infof("Using temporary directory %s", m.root)
+	}
+	if len(m.phaseDiskSpace) > 0 {
+		phases := []string{}
+		for phase, space := range m.phaseDiskSpace {
+			phases = append(phases, fmt.Sprintf("%s=%dGiB", phase, space))
+		}
+		sort.Strings(phases)
+		logrus.Infof("Requiring free disk space %s", strings.Join(phases, ", "))
+	}
+	SetDefault(m)
+	return nil
+}
+
+// SetDefault sets the manager used for the temporary directories and files.
+func SetDefault(m *Manager) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = m
+}
+
+// Default returns the manager used for the temporary directories and files.
+// It uses the system temporary directory without disk space checks if not
+// set up.
+func Default() *Manager {
+	mu.RLock()
+	m := current
+	mu.RUnlock()
+	if m != nil {
+		return m
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if current == nil {
+		current = &Manager{
+			impl:           &defaultImpl{},
+			root:           os.TempDir(),
+			phaseDiskSpace: map[string]uint64{},
+		}
+	}
+	return current
+}
+
+// TempDir returns the root of all temporary directories and files of the
+// default manager.
+func TempDir() string {
+	return Default().TempDir()
+}
+
+// MkdirTemp creates a new temporary directory by using the default manager.
+func MkdirTemp(pattern string) (string, error) {
+	return Default().MkdirTemp(pattern)
+}
+
+// CreateTemp creates a new temporary file by using the default manager.
+func CreateTemp(pattern string) (*os.File, error) {
+	return Default().CreateTemp(pattern)
+}
+
+// CheckDiskSpace verifies the free disk space for the phase by using the
+// default manager.
+func CheckDiskSpace(phase string, dirs ...string) error {
+	return Default().CheckDiskSpace(phase, dirs...)
+}
+
+// Cleanup removes the temporary directories and files of the default
+// manager.
+func Cleanup() error {
+	return Default().Cleanup()
+}
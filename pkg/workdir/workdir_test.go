@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workdir_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/workdir"
+	"k8s.io/release/pkg/workdir/workdirfakes"
+)
+
+const gib = 1024 * 1024 * 1024
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		opts       *workdir.Options
+		phase      string
+		expected   uint64
+		shouldFail bool
+	}{
+		{
+			name:     "default disk space",
+			opts:     &workdir.Options{MinDiskSpace: 10},
+			phase:    "build",
+			expected: 10,
+		},
+		{
+			name: "phase disk space",
+			opts: &workdir.Options{
+				MinDiskSpace:   10,
+				PhaseDiskSpace: []string{"stage-artifacts=100"},
+			},
+			phase:    "stage artifacts",
+			expected: 100,
+		},
+		{
+			name: "disabled phase check",
+			opts: &workdir.Options{
+				MinDiskSpace:   10,
+				PhaseDiskSpace: []string{"archive=0"},
+			},
+			phase:    "archive",
+			expected: 0,
+		},
+		{
+			name:       "missing disk space",
+			opts:       &workdir.Options{PhaseDiskSpace: []string{"build"}},
+			shouldFail: true,
+		},
+		{
+			name:       "invalid disk space",
+			opts:       &workdir.Options{PhaseDiskSpace: []string{"build=lots"}},
+			shouldFail: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := workdir.New(tc.opts)
+			if tc.shouldFail {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, m.RequiredDiskSpace(tc.phase))
+		})
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	t.Parallel()
+
+	for _, keep := range []bool{false, true} {
+		keep := keep
+		t.Run(map[bool]string{false: "remove", true: "keep"}[keep], func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			m, err := workdir.New(&workdir.Options{TempDir: root, KeepWorkdir: keep})
+			require.NoError(t, err)
+			require.Equal(t, root, m.TempDir())
+
+			dir, err := m.MkdirTemp("dir-")
+			require.NoError(t, err)
+			require.Equal(t, root, filepath.Dir(dir))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "file"), []byte("test"), 0o600))
+
+			f, err := m.CreateTemp("file-")
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+			require.Equal(t, root, filepath.Dir(f.Name()))
+
+			// Already removed paths are no failure
+			removed, err := m.MkdirTemp("removed-")
+			require.NoError(t, err)
+			require.NoError(t, os.RemoveAll(removed))
+
+			require.NoError(t, m.Cleanup())
+			for _, path := range []string{dir, f.Name()} {
+				_, err := os.Stat(path)
+				if keep {
+					require.NoError(t, err)
+				} else {
+					require.ErrorIs(t, err, os.ErrNotExist)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckDiskSpace(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test")
+	root := t.TempDir()
+	other := t.TempDir()
+
+	for _, tc := range []struct {
+		name          string
+		dirs          []string
+		usage         *disk.UsageStat
+		usageErr      error
+		expectedCalls int
+		shouldFail    bool
+	}{
+		{
+			name:          "enough disk space",
+			dirs:          []string{other},
+			usage:         &disk.UsageStat{Free: 20 * gib},
+			expectedCalls: 2,
+		},
+		{
+			name:          "not enough disk space",
+			usage:         &disk.UsageStat{Free: 5 * gib},
+			expectedCalls: 1,
+			shouldFail:    true,
+		},
+		{
+			name:          "not existing and duplicate directories get skipped",
+			dirs:          []string{root, filepath.Join(other, "missing")},
+			usage:         &disk.UsageStat{Free: 20 * gib},
+			expectedCalls: 1,
+		},
+		{
+			name:          "failing usage",
+			usageErr:      errTest,
+			expectedCalls: 1,
+			shouldFail:    true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := workdir.New(&workdir.Options{TempDir: root, MinDiskSpace: 10})
+			require.NoError(t, err)
+			mock := &workdirfakes.FakeImpl{}
+			mock.UsageReturns(tc.usage, tc.usageErr)
+			m.SetImpl(mock)
+
+			err = m.CheckDiskSpace("build", tc.dirs...)
+			if tc.shouldFail {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedCalls, mock.UsageCallCount())
+		})
+	}
+}
+
+func TestCheckDiskSpaceDisabled(t *testing.T) {
+	t.Parallel()
+
+	m, err := workdir.New(&workdir.Options{TempDir: t.TempDir()})
+	require.NoError(t, err)
+	mock := &workdirfakes.FakeImpl{}
+	m.SetImpl(mock)
+
+	require.NoError(t, m.CheckDiskSpace("build"))
+	require.Zero(t, mock.UsageCallCount())
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package worktree
+
+import (
+	"os"
+
+	"sigs.k8s.io/release-utils/command"
+
+	"k8s.io/release/pkg/workdir"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate . impl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt worktreefakes/fake_impl.go > worktreefakes/_fake_impl.go && mv worktreefakes/_fake_impl.go worktreefakes/fake_impl.go"
+type impl interface {
+	Git(dir string, args ...string) (string, error)
+	MkdirTemp(pattern string) (string, error)
+	RemoveAll(path string) error
+}
+
+type defaultImpl struct{}
+
+func (*defaultImpl) Git(dir string, args ...string) (string, error) {
+	res, err := command.NewWithWorkDir(dir, "git", args...).RunSilentSuccessOutput()
+	if err != nil {
+		return "", err
+	}
+	return res.OutputTrimNL(), nil
+}
+
+func (*defaultImpl) MkdirTemp(pattern string) (string, error) {
+	return workdir.MkdirTemp(pattern)
+}
+
+func (*defaultImpl) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package worktree runs operations on multiple branches of a repository
+// concurrently by checking each of them out into its own linked git
+// worktree. All worktrees share the object store of the repository, which
+// avoids additional clones and switching branches back and forth.
+package worktree
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/nozzle/throttler"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultWorkers is the default number of branches processed in parallel.
+const DefaultWorkers = 4
+
+// Manager creates and removes the worktrees of a repository.
+type Manager struct {
+	impl
+	repoDir string
+
+	mu        sync.Mutex
+	root      string
+	worktrees map[string]*Worktree
+}
+
+// Worktree is a linked working tree of the repository with a detached
+// checkout of a branch.
+type Worktree struct {
+	impl
+
+	// Branch is the name of the branch checked out in the worktree.
+	Branch string
+
+	// Dir is the path to the working tree.
+	Dir string
+}
+
+// New creates a new Manager for the repository in the provided directory.
+func New(repoDir string) *Manager {
+	return &Manager{
+		impl:      &defaultImpl{},
+		repoDir:   repoDir,
+		worktrees: map[string]*Worktree{},
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (m *Manager) SetImpl(impl impl) {
+	m.impl = impl
+}
+
+// Add checks out the provided revision into a new worktree for the branch.
+// The checkout is detached, which allows to add worktrees for branches
+// which are checked out somewhere else, like in the main working tree.
+func (m *Manager) Add(branch, rev string) (*Worktree, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if wt, ok := m.worktrees[branch]; ok {
+		return wt, nil
+	}
+
+	if m.root == "" {
+		root, err := m.impl.MkdirTemp("krel-worktrees-")
+		if err != nil {
+			return nil, fmt.Errorf("create worktrees directory: %w", err)
+		}
+		m.root = root
+	}
+
+	dir := filepath.Join(m.root, strings.ReplaceAll(branch, "/", "-"))
+	logrus.Infof("Adding worktree for %s in %s", branch, dir)
+	if _, err := m.impl.Git(
+		m.repoDir, "worktree", "add", "--detach", "--force", dir, rev,
+	); err != nil {
+		return nil, fmt.Errorf("add worktree for %s: %w", branch, err)
+	}
+
+	wt := &Worktree{impl: m.impl, Branch: branch, Dir: dir}
+	m.worktrees[branch] = wt
+	return wt, nil
+}
+
+// Remove deletes the worktree of the provided branch.
+func (m *Manager) Remove(branch string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.remove(branch)
+}
+
+func (m *Manager) remove(branch string) error {
+	wt, ok := m.worktrees[branch]
+	if !ok {
+		return nil
+	}
+	if _, err := m.impl.Git(
+		m.repoDir, "worktree", "remove", "--force", wt.Dir,
+	); err != nil {
+		return fmt.Errorf("remove worktree of %s: %w", branch, err)
+	}
+	delete(m.worktrees, branch)
+	return nil
+}
+
+// Cleanup removes all worktrees created by the manager.
+func (m *Manager) Cleanup() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var errs []error
+	for branch := range m.worktrees {
+		if err := m.remove(branch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if _, err := m.impl.Git(m.repoDir, "worktree", "prune"); err != nil {
+		errs = append(errs, fmt.Errorf("prune worktrees: %w", err))
+	}
+	if m.root != "" {
+		if err := m.impl.RemoveAll(m.root); err != nil {
+			errs = append(errs, fmt.Errorf("remove worktrees directory: %w", err))
+		}
+		m.root = ""
+	}
+	return errors.Join(errs...)
+}
+
+// Run checks out the revisions returned by rev into one worktree per branch
+// and calls fn for each of them, using up to workers goroutines. The
+// worktrees get removed afterwards. Errors of all branches are returned
+// together.
+func (m *Manager) Run(
+	branches []string, rev func(branch string) string, workers int,
+	fn func(*Worktree) error,
+) error {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	// Adding worktrees modifies the administrative files of the repository,
+	// which is why it is done upfront.
+	worktrees := make([]*Worktree, 0, len(branches))
+	defer func() {
+		for _, wt := range worktrees {
+			if err := m.Remove(wt.Branch); err != nil {
+				logrus.Warnf("Unable to remove worktree: %v", err)
+			}
+		}
+	}()
+	for _, branch := range branches {
+		wt, err := m.Add(branch, rev(branch))
+		if err != nil {
+			return err
+		}
+		worktrees = append(worktrees, wt)
+	}
+
+	errs := make([]error, len(worktrees))
+	t := throttler.New(workers, len(worktrees))
+	for i, wt := range worktrees {
+		go func(i int, wt *Worktree) {
+			if err := fn(wt); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", wt.Branch, err)
+			}
+			t.Done(nil)
+		}(i, wt)
+		t.Throttle()
+	}
+	return errors.Join(errs...)
+}
+
+// Git runs git with the provided arguments inside of the worktree and
+// returns its trimmed output.
+func (w *Worktree) Git(args ...string) (string, error) {
+	return w.impl.Git(w.Dir, args...)
+}
+
+// Head returns the commit checked out in the worktree.
+func (w *Worktree) Head() (string, error) {
+	return w.Git("rev-parse", "HEAD")
+}
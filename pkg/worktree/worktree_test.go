@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package worktree_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/worktree"
+	"k8s.io/release/pkg/worktree/worktreefakes"
+)
+
+func TestAddAndCleanup(t *testing.T) {
+	mock := &worktreefakes.FakeImpl{}
+	mock.MkdirTempReturns("/tmp/wt", nil)
+
+	sut := worktree.New("/repo")
+	sut.SetImpl(mock)
+
+	wt, err := sut.Add("release-1.30", "origin/release-1.30")
+	require.NoError(t, err)
+	require.Equal(t, "release-1.30", wt.Branch)
+	require.Equal(t, "/tmp/wt/release-1.30", wt.Dir)
+
+	// Adding the same branch again reuses the worktree
+	again, err := sut.Add("release-1.30", "origin/release-1.30")
+	require.NoError(t, err)
+	require.Same(t, wt, again)
+	require.Equal(t, 1, mock.MkdirTempCallCount())
+	require.Equal(t, 1, mock.GitCallCount())
+
+	dir, args := mock.GitArgsForCall(0)
+	require.Equal(t, "/repo", dir)
+	require.Equal(t, []string{
+		"worktree", "add", "--detach", "--force", "/tmp/wt/release-1.30", "origin/release-1.30",
+	}, args)
+
+	require.NoError(t, sut.Cleanup())
+	_, args = mock.GitArgsForCall(1)
+	require.Equal(t, []string{"worktree", "remove", "--force", "/tmp/wt/release-1.30"}, args)
+	_, args = mock.GitArgsForCall(2)
+	require.Equal(t, []string{"worktree", "prune"}, args)
+	require.Equal(t, "/tmp/wt", mock.RemoveAllArgsForCall(0))
+}
+
+func TestRunFailure(t *testing.T) {
+	mock := &worktreefakes.FakeImpl{}
+	mock.MkdirTempReturns("/tmp/wt", nil)
+
+	sut := worktree.New("/repo")
+	sut.SetImpl(mock)
+
+	err := sut.Run(
+		[]string{"release-1.29", "release-1.30"},
+		func(branch string) string { return "origin/" + branch },
+		0,
+		func(wt *worktree.Worktree) error {
+			if wt.Branch == "release-1.30" {
+				return errors.New("test")
+			}
+			return nil
+		},
+	)
+	require.EqualError(t, err, "release-1.30: test")
+
+	// Two adds and two removals
+	require.Equal(t, 4, mock.GitCallCount())
+}
+
+func git(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return string(out)
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	git(t, dir, "init", "--initial-branch=master")
+	git(t, dir, "commit", "--allow-empty", "-m", "initial")
+	branches := []string{"release-1.29", "release-1.30", "release-1.31"}
+	for _, branch := range branches {
+		git(t, dir, "branch", branch)
+	}
+
+	var (
+		mu    sync.Mutex
+		heads = map[string]string{}
+	)
+	require.NoError(t, worktree.New(dir).Run(
+		branches,
+		func(branch string) string { return branch },
+		2,
+		func(wt *worktree.Worktree) error {
+			require.NoError(t, os.WriteFile(
+				filepath.Join(wt.Dir, "file"), []byte(wt.Branch), 0o600,
+			))
+			if _, err := wt.Git("add", "file"); err != nil {
+				return err
+			}
+			if _, err := wt.Git(
+				"-c", "user.name=test", "-c", "user.email=test@example.com",
+				"commit", "-m", wt.Branch,
+			); err != nil {
+				return err
+			}
+			head, err := wt.Head()
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			heads[wt.Branch] = head
+			mu.Unlock()
+			return nil
+		},
+	))
+
+	require.Len(t, heads, 3)
+	for branch, head := range heads {
+		// The commits are available in the shared object store
+		require.Equal(t, branch+"\n", git(t, dir, "show", "-s", "--format=%s", head))
+	}
+	// Only the main working tree is left
+	require.Equal(t, 1, strings.Count(git(t, dir, "worktree", "list", "--porcelain"), "worktree "))
+}
@@ -0,0 +1,283 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package worktreefakes
+
+import (
+	"sync"
+)
+
+type FakeImpl struct {
+	GitStub        func(string, ...string) (string, error)
+	gitMutex       sync.RWMutex
+	gitArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	gitReturns struct {
+		result1 string
+		result2 error
+	}
+	gitReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	MkdirTempStub        func(string) (string, error)
+	mkdirTempMutex       sync.RWMutex
+	mkdirTempArgsForCall []struct {
+		arg1 string
+	}
+	mkdirTempReturns struct {
+		result1 string
+		result2 error
+	}
+	mkdirTempReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	RemoveAllStub        func(string) error
+	removeAllMutex       sync.RWMutex
+	removeAllArgsForCall []struct {
+		arg1 string
+	}
+	removeAllReturns struct {
+		result1 error
+	}
+	removeAllReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeImpl) Git(arg1 string, arg2 ...string) (string, error) {
+	fake.gitMutex.Lock()
+	ret, specificReturn := fake.gitReturnsOnCall[len(fake.gitArgsForCall)]
+	fake.gitArgsForCall = append(fake.gitArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2})
+	stub := fake.GitStub
+	fakeReturns := fake.gitReturns
+	fake.recordInvocation("Git", []interface{}{arg1, arg2})
+	fake.gitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) GitCallCount() int {
+	fake.gitMutex.RLock()
+	defer fake.gitMutex.RUnlock()
+	return len(fake.gitArgsForCall)
+}
+
+func (fake *FakeImpl) GitCalls(stub func(string, ...string) (string, error)) {
+	fake.gitMutex.Lock()
+	defer fake.gitMutex.Unlock()
+	fake.GitStub = stub
+}
+
+func (fake *FakeImpl) GitArgsForCall(i int) (string, []string) {
+	fake.gitMutex.RLock()
+	defer fake.gitMutex.RUnlock()
+	argsForCall := fake.gitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImpl) GitReturns(result1 string, result2 error) {
+	fake.gitMutex.Lock()
+	defer fake.gitMutex.Unlock()
+	fake.GitStub = nil
+	fake.gitReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) GitReturnsOnCall(i int, result1 string, result2 error) {
+	fake.gitMutex.Lock()
+	defer fake.gitMutex.Unlock()
+	fake.GitStub = nil
+	if fake.gitReturnsOnCall == nil {
+		fake.gitReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.gitReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) MkdirTemp(arg1 string) (string, error) {
+	fake.mkdirTempMutex.Lock()
+	ret, specificReturn := fake.mkdirTempReturnsOnCall[len(fake.mkdirTempArgsForCall)]
+	fake.mkdirTempArgsForCall = append(fake.mkdirTempArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.MkdirTempStub
+	fakeReturns := fake.mkdirTempReturns
+	fake.recordInvocation("MkdirTemp", []interface{}{arg1})
+	fake.mkdirTempMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImpl) MkdirTempCallCount() int {
+	fake.mkdirTempMutex.RLock()
+	defer fake.mkdirTempMutex.RUnlock()
+	return len(fake.mkdirTempArgsForCall)
+}
+
+func (fake *FakeImpl) MkdirTempCalls(stub func(string) (string, error)) {
+	fake.mkdirTempMutex.Lock()
+	defer fake.mkdirTempMutex.Unlock()
+	fake.MkdirTempStub = stub
+}
+
+func (fake *FakeImpl) MkdirTempArgsForCall(i int) string {
+	fake.mkdirTempMutex.RLock()
+	defer fake.mkdirTempMutex.RUnlock()
+	argsForCall := fake.mkdirTempArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) MkdirTempReturns(result1 string, result2 error) {
+	fake.mkdirTempMutex.Lock()
+	defer fake.mkdirTempMutex.Unlock()
+	fake.MkdirTempStub = nil
+	fake.mkdirTempReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) MkdirTempReturnsOnCall(i int, result1 string, result2 error) {
+	fake.mkdirTempMutex.Lock()
+	defer fake.mkdirTempMutex.Unlock()
+	fake.MkdirTempStub = nil
+	if fake.mkdirTempReturnsOnCall == nil {
+		fake.mkdirTempReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.mkdirTempReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImpl) RemoveAll(arg1 string) error {
+	fake.removeAllMutex.Lock()
+	ret, specificReturn := fake.removeAllReturnsOnCall[len(fake.removeAllArgsForCall)]
+	fake.removeAllArgsForCall = append(fake.removeAllArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RemoveAllStub
+	fakeReturns := fake.removeAllReturns
+	fake.recordInvocation("RemoveAll", []interface{}{arg1})
+	fake.removeAllMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImpl) RemoveAllCallCount() int {
+	fake.removeAllMutex.RLock()
+	defer fake.removeAllMutex.RUnlock()
+	return len(fake.removeAllArgsForCall)
+}
+
+func (fake *FakeImpl) RemoveAllCalls(stub func(string) error) {
+	fake.removeAllMutex.Lock()
+	defer fake.removeAllMutex.Unlock()
+	fake.RemoveAllStub = stub
+}
+
+func (fake *FakeImpl) RemoveAllArgsForCall(i int) string {
+	fake.removeAllMutex.RLock()
+	defer fake.removeAllMutex.RUnlock()
+	argsForCall := fake.removeAllArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImpl) RemoveAllReturns(result1 error) {
+	fake.removeAllMutex.Lock()
+	defer fake.removeAllMutex.Unlock()
+	fake.RemoveAllStub = nil
+	fake.removeAllReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) RemoveAllReturnsOnCall(i int, result1 error) {
+	fake.removeAllMutex.Lock()
+	defer fake.removeAllMutex.Unlock()
+	fake.RemoveAllStub = nil
+	if fake.removeAllReturnsOnCall == nil {
+		fake.removeAllReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeAllReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.gitMutex.RLock()
+	defer fake.gitMutex.RUnlock()
+	fake.mkdirTempMutex.RLock()
+	defer fake.mkdirTempMutex.RUnlock()
+	fake.removeAllMutex.RLock()
+	defer fake.removeAllMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}